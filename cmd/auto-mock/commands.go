@@ -4,15 +4,30 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal"
+	"github.com/hemantobora/auto-mock/internal/ask"
 	"github.com/hemantobora/auto-mock/internal/client"
 	"github.com/hemantobora/auto-mock/internal/cloud"
+	awsprovider "github.com/hemantobora/auto-mock/internal/cloud/aws"
+	azureprovider "github.com/hemantobora/auto-mock/internal/cloud/azure"
+	gitprovider "github.com/hemantobora/auto-mock/internal/cloud/git"
 	"github.com/hemantobora/auto-mock/internal/commands"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/hooks"
+	"github.com/hemantobora/auto-mock/internal/kubernetes"
+	"github.com/hemantobora/auto-mock/internal/loadtest"
+	"github.com/hemantobora/auto-mock/internal/mcp"
+	"github.com/hemantobora/auto-mock/internal/mocklogs"
 	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/network"
+	"github.com/hemantobora/auto-mock/internal/projectconfig"
 	"github.com/hemantobora/auto-mock/internal/prompts"
 	"github.com/hemantobora/auto-mock/internal/repl"
 	"github.com/hemantobora/auto-mock/internal/terraform"
@@ -67,6 +82,13 @@ func locustCommand(c *cli.Context) error {
 	download := c.Bool("download")
 	deletePtr := c.Bool("delete-pointer")
 	purgeAll := c.Bool("purge-all")
+	tool, ok := loadtest.ParseTool(c.String("tool"))
+	if !ok {
+		return exitcode.New(exitcode.Config, "--tool must be one of %s, got %q", strings.Join(loadtest.Tools, ", "), c.String("tool"))
+	}
+	if tool != loadtest.ToolLocust && headless {
+		return exitcode.New(exitcode.Config, "--headless applies to Locust only; %s bundles always run headless", tool)
+	}
 
 	// Validate mutually exclusive flags
 	exclusive := func(args ...string) string {
@@ -107,22 +129,551 @@ func locustCommand(c *cli.Context) error {
 		}
 	}
 
+	if err := applyArtifactProtectionFlags(c); err != nil {
+		return err
+	}
+
 	options := &client.Options{
 		CollectionType:             collectionType,
 		CollectionPath:             collectionFile,
 		OutDir:                     outDir,
 		Headless:                   &headless,
 		GenerateDistributedHelpers: &distributed,
+		Tool:                       tool,
 	}
 
 	profile := c.String("profile")
 	return commands.RunLocust(profile, project, *options, upload, download, deletePtr, purgeAll)
 }
 
+// applyArtifactProtectionFlags exports the load test artifact encryption and tags for the provider
+func applyArtifactProtectionFlags(c *cli.Context) error {
+	if key := c.String("kms-key"); key != "" {
+		os.Setenv(awsprovider.EnvLoadTestKMSKey, key)
+	}
+	if tags := c.StringSlice("object-tag"); len(tags) > 0 {
+		os.Setenv(awsprovider.EnvLoadTestTags, strings.Join(tags, ","))
+	}
+	_, err := awsprovider.ArtifactProtectionFromEnv()
+	return err
+}
+
+// consumersCommand lists, registers or removes contract consumers
+func consumersCommand(c *cli.Context) error {
+	return commands.RunConsumers(c.String("profile"), c.String("project"), commands.ConsumerOptions{
+		Add:     c.String("add"),
+		Remove:  c.String("remove"),
+		Webhook: c.String("webhook"),
+		Email:   c.String("email"),
+		Tags:    c.StringSlice("tags"),
+		Paths:   c.StringSlice("paths"),
+	})
+}
+
+// matchCommand traces request matching against a project's expectations offline
+func matchCommand(c *cli.Context) error {
+	return commands.RunMatch(c.String("profile"), c.String("project"), commands.MatchOptions{
+		File:    c.String("file"),
+		Request: c.String("request"),
+		Method:  c.String("method"),
+		Path:    c.String("path"),
+		Headers: c.StringSlice("header"),
+		Query:   c.StringSlice("query"),
+		Body:    c.String("body"),
+		Top:     c.Int("top"),
+	})
+}
+
+// diffCommand compares two saved versions of a project's expectations
+func diffCommand(c *cli.Context) error {
+	return commands.RunDiff(c.String("profile"), c.String("project"), commands.DiffOptions{
+		From: c.String("from"),
+		To:   c.String("to"),
+	})
+}
+
+// rollbackCommand restores a saved version of a project's expectations
+func rollbackCommand(c *cli.Context) error {
+	return commands.RunRollback(c.String("profile"), c.String("project"), commands.RollbackOptions{
+		Version:       c.String("version"),
+		Deploy:        c.Bool("deploy"),
+		AllowBreaking: c.Bool("allow-breaking"),
+	})
+}
+
+// validateCommand checks a project's expectations for problems before they are served
+func validateCommand(c *cli.Context) error {
+	return commands.RunValidate(c.String("profile"), c.String("project"), commands.ValidateOptions{
+		File:      c.String("file"),
+		Strict:    c.Bool("strict"),
+		Conflicts: c.Bool("conflicts"),
+		Fix:       c.Bool("fix"),
+	})
+}
+
+// serveCommand runs a project's expectations on a local mock server
+func serveCommand(c *cli.Context) error {
+	return commands.RunServe(c.String("profile"), c.String("project"), commands.ServeOptions{
+		File:   c.String("file"),
+		Dir:    c.String("dir"),
+		Host:   c.String("host"),
+		Port:   c.Int("port"),
+		Protos: c.StringSlice("proto"),
+		Trace:  c.Bool("verbose"),
+	})
+}
+
+// dockerCommand writes a Docker Compose bundle running a project's mocks
+func dockerCommand(c *cli.Context) error {
+	return commands.RunDocker(c.String("profile"), c.String("project"), commands.DockerOptions{
+		Out:       c.String("out"),
+		Image:     c.String("image"),
+		Port:      c.Int("port"),
+		Env:       c.String("env"),
+		Mask:      c.String("mask"),
+		Selection: models.TagSelection{Only: c.StringSlice("only-tags"), Exclude: c.StringSlice("exclude-tags")},
+		Force:     c.Bool("force"),
+	})
+}
+
+// recordCommand records live traffic through a proxy into a project's expectations
+func recordCommand(c *cli.Context) error {
+	return commands.RunRecord(c.String("profile"), c.String("project"), commands.RecordOptions{
+		Target: c.String("target"),
+		Host:   c.String("host"),
+		Port:   c.Int("port"),
+		TTL:    c.String("ttl"),
+	})
+}
+
+// refreshCommand re-records a project's stale expectations from their upstream
+func refreshCommand(c *cli.Context) error {
+	return commands.RunRefresh(c.String("profile"), c.String("project"), commands.RefreshOptions{
+		All:     c.Bool("all"),
+		Headers: c.StringSlice("header"),
+		DryRun:  c.Bool("dry-run"),
+	})
+}
+
+// downloadCommand writes a project's expectations to a file or split directory
+func downloadCommand(c *cli.Context) error {
+	return commands.RunDownload(c.String("profile"), c.String("project"), c.String("out"), c.String("format"), c.String("env"), c.String("mask"), c.Bool("split"),
+		models.TagSelection{Only: c.StringSlice("only-tags"), Exclude: c.StringSlice("exclude-tags")})
+}
+
+// generateFixturesCommand writes the expectations as language-native test fixtures
+func generateFixturesCommand(c *cli.Context) error {
+	return commands.RunGenerateFixtures(c.String("profile"), c.String("project"), commands.FixtureOptions{
+		Lang:      c.String("lang"),
+		Out:       c.String("out"),
+		Package:   c.String("package"),
+		Env:       c.String("env"),
+		Mask:      c.String("mask"),
+		Selection: models.TagSelection{Only: c.StringSlice("only-tags"), Exclude: c.StringSlice("exclude-tags")},
+	})
+}
+
+// watchCommand keeps a project in sync with a split expectations directory
+func watchCommand(c *cli.Context) error {
+	return commands.RunWatch(c.String("profile"), c.String("project"), c.String("dir"), c.Duration("interval"))
+}
+
+// applyAssumeRoleFlags exports the global role flags so every provider and Terraform run picks them up
+func applyAssumeRoleFlags(c *cli.Context) error {
+	role := c.String("assume-role")
+	if role == "" {
+		if c.IsSet("external-id") || c.IsSet("session-tag") {
+			return fmt.Errorf("--external-id and --session-tag require --assume-role")
+		}
+		return nil
+	}
+	os.Setenv(awsprovider.EnvAssumeRole, role)
+	os.Setenv(awsprovider.EnvExternalID, c.String("external-id"))
+	if tags := c.StringSlice("session-tag"); len(tags) > 0 {
+		if _, err := awsprovider.ParseSessionTags(strings.Join(tags, ",")); err != nil {
+			return err
+		}
+		os.Setenv(awsprovider.EnvSessionTags, strings.Join(tags, ","))
+	}
+	return nil
+}
+
+// applyS3EndpointFlags exports the S3-compatible endpoint settings for every storage client
+func applyS3EndpointFlags(c *cli.Context) error {
+	endpoint := c.String("s3-endpoint")
+	if endpoint == "" {
+		if c.Bool("s3-path-style") {
+			return fmt.Errorf("--s3-path-style requires --s3-endpoint")
+		}
+		return nil
+	}
+	os.Setenv(awsprovider.EnvS3Endpoint, endpoint)
+	os.Setenv(awsprovider.EnvS3PathStyle, strconv.FormatBool(c.Bool("s3-path-style")))
+	_, err := awsprovider.S3EndpointFromEnv()
+	return err
+}
+
+// applyCloudFlags exports the cloud selection and Azure storage settings for provider detection
+func applyCloudFlags(c *cli.Context) error {
+	switch name := c.String("cloud"); name {
+	case "":
+	case "aws", "azure", "local", "git":
+		os.Setenv(cloud.EnvCloud, name)
+	default:
+		return fmt.Errorf("--cloud must be aws, azure, local or git, got %q", name)
+	}
+	if path := c.String("git-path"); path != "" {
+		os.Setenv(gitprovider.EnvPath, path)
+	}
+	if account := c.String("azure-storage-account"); account != "" {
+		os.Setenv(azureprovider.EnvStorageAccount, account)
+	}
+	if location := c.String("azure-location"); location != "" {
+		os.Setenv(azureprovider.EnvLocation, location)
+	}
+	return nil
+}
+
+// applyNetworkFlags exports the call timeout and offline mode for every store, cloud and AI client
+func applyNetworkFlags(c *cli.Context) error {
+	timeout := c.Duration("timeout")
+	if timeout <= 0 {
+		return fmt.Errorf("--timeout must be positive, got %s", timeout)
+	}
+	os.Setenv(network.EnvTimeout, timeout.String())
+	os.Setenv(network.EnvOffline, strconv.FormatBool(c.Bool("offline")))
+	return nil
+}
+
+// applyModelFlag exports the AI model chosen with --model for whichever provider generates
+func applyModelFlag(c *cli.Context) {
+	if model := strings.TrimSpace(c.String("model")); model != "" {
+		os.Setenv(mcp.EnvModel, model)
+	}
+}
+
+// applyPromptFlags loads the answers file and turns prompting off for CI runs
+func applyPromptFlags(c *cli.Context) error {
+	if path := c.String("answers"); path != "" {
+		if err := ask.LoadAnswers(path); err != nil {
+			return err
+		}
+	}
+	ask.SetNonInteractive(c.Bool("non-interactive"))
+	return nil
+}
+
+// applyProjectFile makes automock.yaml the lowest-precedence source of settings: global flags
+// not given on the command line or through their environment variables are set from it, and
+// command flags take its values as defaults before the command parses its arguments
+func applyProjectFile(c *cli.Context) error {
+	file, err := projectconfig.Load()
+	if err != nil {
+		if failedCommand == "config" {
+			return nil // 'config init --force' replaces a broken file
+		}
+		return exitcode.WithHint(exitcode.Config, err, "Fix the file, or run 'automock config init --force' to start over")
+	}
+	if file == nil {
+		return nil
+	}
+	for name, values := range file.GlobalFlags() {
+		if c.IsSet(name) {
+			continue
+		}
+		if err := c.Set(name, values[0]); err != nil {
+			return exitcode.New(exitcode.Config, "%s: %s: %v", file.Path, name, err)
+		}
+	}
+	if region := file.AWSRegion(); region != "" && os.Getenv("AWS_REGION") == "" && os.Getenv("AWS_DEFAULT_REGION") == "" {
+		os.Setenv("AWS_REGION", region)
+	}
+	setFlagDefaults(c.App.Commands, file)
+	return nil
+}
+
+// setFlagDefaults makes the file's values the defaults of the command flags it covers
+func setFlagDefaults(cmds []*cli.Command, file *projectconfig.File) {
+	for _, cmd := range cmds {
+		values := file.CommandFlags(cmd.Name)
+		for _, flag := range cmd.Flags {
+			v, ok := values[flag.Names()[0]]
+			if !ok {
+				continue
+			}
+			switch f := flag.(type) {
+			case *cli.StringFlag:
+				f.Value, f.Required = v[0], false
+			case *cli.StringSliceFlag:
+				f.Value = cli.NewStringSlice(v...)
+			case *cli.IntFlag:
+				f.Value, _ = strconv.Atoi(v[0])
+			case *cli.Float64Flag:
+				f.Value, _ = strconv.ParseFloat(v[0], 64)
+			case *cli.BoolFlag:
+				f.Value = v[0] == "true"
+			}
+		}
+		setFlagDefaults(cmd.Subcommands, file)
+	}
+}
+
+// applyDeploySizingFlags exports the sizing flags so the deployment prompts skip what they set
+func applyDeploySizingFlags(c *cli.Context) error {
+	if size := c.String("instance-size"); size != "" {
+		os.Setenv(models.EnvInstanceSize, size)
+	}
+	if n := c.Int("min-tasks"); n != 0 {
+		os.Setenv(models.EnvMinTasks, strconv.Itoa(n))
+	}
+	if n := c.Int("max-tasks"); n != 0 {
+		os.Setenv(models.EnvMaxTasks, strconv.Itoa(n))
+	}
+	if err := (&models.DeploymentOptions{}).ApplySizingEnv(); err != nil {
+		return exitcode.Wrap(exitcode.Config, err)
+	}
+	return nil
+}
+
+// configInitCommand scaffolds an annotated automock.yaml
+func configInitCommand(c *cli.Context) error {
+	path := projectconfig.Path()
+	if _, err := os.Stat(path); err == nil && !c.Bool("force") {
+		return exitcode.WithHint(exitcode.Config, fmt.Errorf("%s already exists", path), "Pass --force to overwrite it")
+	}
+	project := c.String("project")
+	if project == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		project = strings.ToLower(filepath.Base(wd))
+	}
+	if err := os.WriteFile(path, projectconfig.Scaffold(project), 0644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	fmt.Printf("✅ Wrote %s for project %q\n", path, project)
+	fmt.Println("   Uncomment the settings your team shares and commit the file.")
+	return nil
+}
+
+// gcCommand prunes stored versions and bundles per the retention policy
+func gcCommand(c *cli.Context) error {
+	return commands.RunGC(c.String("profile"), c.String("project"), commands.GCOptions{
+		KeepLast: c.Int("keep-last"),
+		MaxAge:   c.Duration("older-than"),
+		DryRun:   c.Bool("dry-run"),
+	})
+}
+
+// debugBundleCommand writes a diagnostic bundle on demand
+func debugBundleCommand(c *cli.Context) error {
+	return commands.RunDebugBundle(c.String("profile"), c.String("project"), c.String("out"), c.App.Version)
+}
+
+// printEnvironmentStatus shows which environment overlay the mock serves and, when env is
+// given, that overlay's differences from the base
+func printEnvironmentStatus(projectName string, cfg *models.MockConfiguration, env string) {
+	deployed := cfg.Metadata.Environment
+	if deployed != "" {
+		fmt.Printf("🌐 Environment: %s\n", deployed)
+	} else if len(cfg.Environments) > 0 {
+		fmt.Printf("🌐 Environment: base (overlays: %s)\n", strings.Join(cfg.EnvironmentNames(), ", "))
+	}
+	if env == "" {
+		return
+	}
+	overlay, err := cfg.FindEnvironment(env)
+	if err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+		return
+	}
+	exps, _ := cfg.ForEnvironment(overlay.Name)
+	fmt.Printf("   %s: %d expectation(s); %s\n", overlay.Name, len(exps), overlay.Summary())
+	if !strings.EqualFold(deployed, overlay.Name) {
+		fmt.Printf("   Not deployed; run 'automock deploy --project %s --env %s' to serve it\n", projectName, overlay.Name)
+	}
+}
+
+// printTrafficSummary shows per-endpoint usage of the deployed mock, aggregated from its request log
+func printTrafficSummary(baseURL string, auth *models.EndpointAuth, cfg *models.MockConfiguration) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	client := mocklogs.NewClient(baseURL)
+	client.HTTP.Transport = auth.Transport()
+	received, err := client.Requests(ctx)
+	if err != nil {
+		fmt.Printf("⚠️  Traffic summary unavailable: %v\n\n", err)
+		return
+	}
+	fmt.Print(mocklogs.Summarize(cfg.Expectations, received, 5).Format())
+	fmt.Println()
+}
+
+// exportProjectCommand writes a project archive for moving or backing up a project, or a Pact contract
+func exportProjectCommand(c *cli.Context) error {
+	switch format := strings.ToLower(c.String("format")); format {
+	case "", "archive":
+	case "pact":
+		return commands.RunExportPact(c.String("profile"), c.String("project"), commands.PactOptions{
+			Out:       c.String("out"),
+			Consumer:  c.String("consumer"),
+			Provider:  c.String("provider"),
+			Selection: models.TagSelection{Only: c.StringSlice("only-tags"), Exclude: c.StringSlice("exclude-tags")},
+		})
+	default:
+		return exitcode.New(exitcode.Config, "unsupported export format %q (supported: archive, pact)", format)
+	}
+	return commands.RunExportProject(c.String("profile"), c.String("project"), c.String("out"), c.App.Version,
+		commands.ArchiveOptions{SkipVersions: c.Bool("skip-versions"), SkipLoadTest: c.Bool("skip-load-test")})
+}
+
+// importProjectCommand restores a project archive into the detected account and region
+func importProjectCommand(c *cli.Context) error {
+	return commands.RunImportProject(c.String("profile"), commands.ImportOptions{
+		ArchiveOptions: commands.ArchiveOptions{SkipVersions: c.Bool("skip-versions"), SkipLoadTest: c.Bool("skip-load-test")},
+		File:           c.String("file"),
+		Project:        c.String("project"),
+		Force:          c.Bool("force"),
+	})
+}
+
+// pushProjectCommand copies a local project to a cloud account
+func pushProjectCommand(c *cli.Context) error {
+	return commands.RunPushProject(c.String("profile"), c.String("project"), c.String("to"), c.App.Version,
+		commands.ArchiveOptions{SkipVersions: c.Bool("skip-versions"), SkipLoadTest: c.Bool("skip-load-test")}, c.Bool("force"))
+}
+
+// exportManifestCommand writes the consumer-facing manifest of a project's mock
+func exportManifestCommand(c *cli.Context) error {
+	return commands.RunExportManifest(c.String("profile"), c.String("project"), c.String("out"))
+}
+
+// demoCommand runs the guided tour on a local sample project
+func demoCommand(c *cli.Context) error {
+	return commands.RunDemo(commands.DemoOptions{Port: c.Int("port"), Keep: c.Bool("keep")})
+}
+
+// testCommand verifies a mock against the checks captured from collection tests
+func testCommand(c *cli.Context) error {
+	return commands.RunTest(c.String("profile"), c.String("project"), c.String("url"))
+}
+
+// verifyCommand replays the expectations against a real API as a contract test
+func verifyCommand(c *cli.Context) error {
+	return commands.RunVerify(c.String("profile"), c.String("project"), commands.VerifyOptions{
+		BaseURL:   c.String("base-url"),
+		Headers:   c.StringSlice("header"),
+		SafeOnly:  c.Bool("safe-only"),
+		JUnit:     c.String("junit"),
+		Selection: models.TagSelection{Only: c.StringSlice("only-tags"), Exclude: c.StringSlice("exclude-tags")},
+	})
+}
+
+// coverageCommand compares the expectations with a spec or collection
+func coverageCommand(c *cli.Context) error {
+	return commands.RunCoverage(c.String("profile"), c.String("project"), commands.CoverageOptions{
+		Spec:        c.String("spec"),
+		SpecType:    c.String("spec-type"),
+		JSON:        c.String("json"),
+		HTML:        c.String("html"),
+		MinCoverage: c.Float64("min-coverage"),
+	})
+}
+
+// logsCommand shows or follows the requests the mock received
+func logsCommand(c *cli.Context) error {
+	return commands.RunLogs(c.String("profile"), c.String("project"), commands.LogsOptions{
+		URL:      c.String("url"),
+		Filter:   mocklogs.Filter{Path: c.String("path"), Method: c.String("method"), Status: c.String("status")},
+		Limit:    c.Int("limit"),
+		Tail:     c.Bool("tail"),
+		Interval: c.Duration("interval"),
+	})
+}
+
+// logsAnalyzeCommand proposes expectations for the requests the mock did not answer
+func logsAnalyzeCommand(c *cli.Context) error {
+	return commands.RunLogsAnalyze(c.String("profile"), c.String("project"), commands.LogsAnalyzeOptions{
+		URL:      c.String("url"),
+		AI:       c.Bool("ai"),
+		Provider: c.String("provider"),
+		Yes:      c.Bool("yes"),
+	})
+}
+
+// logsExportCommand writes the traffic a mock received to a HAR file
+func logsExportCommand(c *cli.Context) error {
+	return commands.RunLogsExport(c.String("profile"), c.String("project"), commands.LogsExportOptions{
+		Format:  c.String("format"),
+		Out:     c.String("out"),
+		URL:     c.String("url"),
+		Version: c.App.Version,
+	})
+}
+
+// loadScheduleCommand shows, creates or removes scheduled load test runs
+func loadScheduleCommand(c *cli.Context) error {
+	return commands.RunLoadTestSchedule(c.String("profile"), c.String("project"), commands.LoadTestScheduleOptions{
+		Cron:      c.String("cron"),
+		Users:     c.Int("users"),
+		SpawnRate: c.Float64("spawn-rate"),
+		Duration:  c.Duration("duration"),
+		Host:      c.String("host"),
+		Remove:    c.Bool("remove"),
+	})
+}
+
+// loadResultsCommand lists the stored results of load test runs
+func loadResultsCommand(c *cli.Context) error {
+	return commands.RunLoadTestResults(c.String("profile"), c.String("project"), commands.LoadTestResultsOptions{
+		Version: c.String("version"),
+		Limit:   c.Int("limit"),
+		Format:  c.String("format"),
+	})
+}
+
+// loadRunCommand runs a Locust bundle and stores its results
+func loadRunCommand(c *cli.Context) error {
+	return commands.RunLoadTestRun(c.String("profile"), c.String("project"), commands.LoadTestRunOptions{
+		Dir:       c.String("dir"),
+		Users:     c.Int("users"),
+		SpawnRate: c.Float64("spawn-rate"),
+		Duration:  c.Duration("duration"),
+		Host:      c.String("host"),
+		Deployed:  c.Bool("deployed"),
+	})
+}
+
 // deployCommand handles infrastructure deployment
 func deployCommand(c *cli.Context) error {
+	if err := applyDeploySizingFlags(c); err != nil {
+		return err
+	}
+	cluster, err := kubernetesTarget(c)
+	if err != nil {
+		return err
+	}
+	if domain := c.String("domain"); domain != "" {
+		if err := models.ValidateDomain(domain); err != nil {
+			return exitcode.Wrap(exitcode.Config, err)
+		}
+	} else if c.String("hosted-zone-id") != "" {
+		return exitcode.New(exitcode.Config, "--hosted-zone-id is the zone of --domain; pass both")
+	}
+	switch auth := c.String("auth"); auth {
+	case "", models.AuthAPIKey, models.AuthBasic, models.AuthNone:
+	default:
+		return exitcode.New(exitcode.Config, "--auth must be %s, %s or %s, got %q", models.AuthAPIKey, models.AuthBasic, models.AuthNone, auth)
+	}
+	if c.Float64("max-monthly-cost") < 0 {
+		return exitcode.New(exitcode.Config, "--max-monthly-cost must not be negative")
+	}
 	profile := c.String("profile")
 	projectName := c.String("project")
+	if projects := commands.SplitProjects(projectName); len(projects) > 1 {
+		return deployProjects(c, projects, cluster)
+	}
 
 	fmt.Println("\nChecking Infrastructure Prerequisites")
 	fmt.Println(strings.Repeat("=", 80))
@@ -135,8 +686,8 @@ func deployCommand(c *cli.Context) error {
 	// 1. Check project existence
 	exists, _ := manager.Provider.ProjectExists(ctx, projectName)
 	if !exists {
-		fmt.Printf("❌ Project '%s' does not exist. Run 'automock init' (for mocks) or 'automock load' (for load tests) first.\n", projectName)
-		return nil
+		return exitcode.WithHint(exitcode.Config, fmt.Errorf("project '%s' does not exist", projectName),
+			"Run 'automock init' (for mocks) or 'automock load' (for load tests) first")
 	}
 
 	// 2. Detect pointers/config presence
@@ -160,11 +711,22 @@ func deployCommand(c *cli.Context) error {
 	// Helper lambdas
 	deployMocks := func() error {
 		deployer := repl.NewDeployment(projectName, profile, manager.Provider)
-		return deployer.DeployInfrastructureWithTerraform(c.Bool("skip-confirmation"))
+		deployer.Selection = models.TagSelection{Only: c.StringSlice("only-tags"), Exclude: c.StringSlice("exclude-tags")}
+		deployer.Manifest = c.String("manifest")
+		deployer.Masking = c.String("mask")
+		deployer.Environment = c.String("env")
+		deployer.Kubernetes = cluster
+		deployer.RenderTo = c.String("render-to")
+		deployer.ExportTo = c.String("export-terraform")
+		deployer.Domain = c.String("domain")
+		deployer.HostedZone = c.String("hosted-zone-id")
+		deployer.Auth = c.String("auth")
+		deployer.MaxCost = c.Float64("max-monthly-cost")
+		return deployer.Deploy(c.Bool("skip-confirmation"), c.Bool("allow-breaking"))
 	}
 	deployLoad := func() error {
 		fmt.Println("🚀 Deploying load-test infrastructure...")
-		opts := &models.LoadTestDeploymentOptions{WorkerDesiredCount: 0}
+		opts := &models.LoadTestDeploymentOptions{WorkerDesiredCount: 0, Provider: manager.Provider.GetProviderType()}
 
 		// Collect BYO options using shared prompts package
 		if err := prompts.PromptAllBYOOptions(opts); err != nil {
@@ -180,6 +742,10 @@ func deployCommand(c *cli.Context) error {
 			return err
 		}
 		fmt.Println()
+		if manager.Provider.GetProviderType() == "azure" {
+			fmt.Printf("✅ Load-test infra deployed: \nLocust UI=http://%s \nWorkers Count=%d ", out.ALBDNSName, out.WorkerDesiredCount)
+			return nil
+		}
 		fmt.Printf(`✅ Load-test infra deployed: 
 ALB TLS=https://%s 
 ALB Open=http://%s 
@@ -196,7 +762,7 @@ Workers Count=%d `, out.ALBDNSName, out.ALBDNSName, out.WorkerDesiredCount)
 			return err
 		}
 		var desiredStr string
-		_ = survey.AskOne(&survey.Input{
+		_ = ask.One(&survey.Input{
 			Message: "Enter desired worker count (-1 to stop the update):",
 			Help:    "Provide the worker count",
 			Default: "0",
@@ -220,6 +786,33 @@ Workers Count=%d `, out.ALBDNSName, out.ALBDNSName, out.WorkerDesiredCount)
 		return nil
 	}
 
+	// A cluster gets the current expectations on every deploy; load tests only run in a cloud
+	if cluster != nil {
+		if !hasMock {
+			return exitcode.WithHint(exitcode.Config, fmt.Errorf("project '%s' has no mocks to deploy to Kubernetes", projectName),
+				fmt.Sprintf("Load tests deploy to a cloud: run 'automock deploy --project %s' without --target kubernetes", projectName))
+		}
+		return deployMocks()
+	}
+	// An export only writes the mock stack, whatever is deployed
+	if c.String("export-terraform") != "" {
+		if !hasMock {
+			return exitcode.New(exitcode.Config, "project '%s' has no mocks to export", projectName)
+		}
+		return deployMocks()
+	}
+	// A new domain or credential is put in front of deployed mocks by applying the stack again
+	if mockDeployed {
+		if c.String("auth") != "" {
+			return deployMocks()
+		}
+		if domain := c.String("domain"); domain != "" {
+			if recorded, _ := terraform.DomainFromOutputs(mockMeta.Details); recorded != domain {
+				return deployMocks()
+			}
+		}
+	}
+
 	// 4. Decision matrix
 	// Case: both pointers present
 	if hasMock && hasLoad {
@@ -229,7 +822,7 @@ Workers Count=%d `, out.ALBDNSName, out.ALBDNSName, out.WorkerDesiredCount)
 			return scaleWorkers()
 		case loadDeployed && !mockDeployed:
 			choice := ""
-			_ = survey.AskOne(&survey.Select{Message: "Loadtest deployed; mocks not deployed. Action:", Options: []string{"deploy-mocks", "scale-workers", "exit"}, Default: "deploy-mocks"}, &choice)
+			_ = ask.One(&survey.Select{Message: "Loadtest deployed; mocks not deployed. Action:", Options: []string{"deploy-mocks", "scale-workers", "exit"}, Default: "deploy-mocks"}, &choice)
 			if choice == "deploy-mocks" {
 				return deployMocks()
 			}
@@ -242,7 +835,7 @@ Workers Count=%d `, out.ALBDNSName, out.ALBDNSName, out.WorkerDesiredCount)
 			return deployLoad()
 		default: // neither deployed but both bundles/config exist
 			choice := ""
-			_ = survey.AskOne(&survey.Select{Message: "Mocks & loadtest artifacts found. Deploy:", Options: []string{"both", "only-mocks", "only-loadtest", "exit"}, Default: "both"}, &choice)
+			_ = ask.One(&survey.Select{Message: "Mocks & loadtest artifacts found. Deploy:", Options: []string{"both", "only-mocks", "only-loadtest", "exit"}, Default: "both"}, &choice)
 			if choice == "both" {
 				fmt.Println("🚀 Deploying mock infrastructure first...")
 				if err := deployMocks(); err != nil {
@@ -283,11 +876,114 @@ Workers Count=%d `, out.ALBDNSName, out.ALBDNSName, out.WorkerDesiredCount)
 	return nil
 }
 
+// deployProjects deploys the mocks of several projects concurrently and prints a combined
+// summary. Prompts cannot be answered for many projects at once, so after one confirmation
+// they are answered as with --non-interactive (answers file, then defaults).
+func deployProjects(c *cli.Context, projects []string, cluster *kubernetes.Options) error {
+	if c.String("manifest") != "" {
+		return fmt.Errorf("--manifest regenerates one file; deploy projects one at a time to use it")
+	}
+	if c.String("render-to") != "" || c.String("export-terraform") != "" {
+		return fmt.Errorf("--render-to and --export-terraform write one project; run them one project at a time")
+	}
+	if c.String("domain") != "" {
+		return fmt.Errorf("--domain serves one project; deploy projects one at a time to give each its domain")
+	}
+	profile := c.String("profile")
+	fmt.Printf("\n🚀 Deploying mocks for %d projects: %s\n", len(projects), strings.Join(projects, ", "))
+	if !c.Bool("skip-confirmation") {
+		var confirmed bool
+		if err := ask.One(&survey.Confirm{
+			Message: fmt.Sprintf("Deploy mock infrastructure for %d projects?", len(projects)),
+			Default: true,
+			Help:    "Deployment prompts of each project are answered from --answers or their defaults.",
+		}, &confirmed); err != nil {
+			return err
+		}
+		if !confirmed {
+			return exitcode.New(exitcode.Cancelled, "deployment cancelled")
+		}
+	}
+	ask.SetNonInteractive(true)
+
+	results := commands.RunProjects(projects, c.Int("concurrency"), func(project string) (string, string, error) {
+		manager := cloud.NewCloudManager(profile)
+		if err := manager.AutoDetectProvider(profile); err != nil {
+			return "", "", err
+		}
+		ctx := context.Background()
+		if exists, _ := manager.Provider.ProjectExists(ctx, project); !exists {
+			return "", "", exitcode.New(exitcode.Config, "project does not exist")
+		}
+		if _, err := manager.Provider.GetConfig(ctx, project); err != nil {
+			return "skipped", "no mock configuration", nil
+		}
+		if meta, _ := manager.Provider.GetDeploymentMetadata(); cluster == nil && c.String("auth") == "" && meta != nil && meta.DeploymentStatus == "deployed" {
+			return "already deployed", mockEndpoint(meta), nil
+		}
+		deployer := repl.NewDeployment(project, profile, manager.Provider)
+		deployer.Selection = models.TagSelection{Only: c.StringSlice("only-tags"), Exclude: c.StringSlice("exclude-tags")}
+		deployer.Masking = c.String("mask")
+		deployer.Environment = c.String("env")
+		deployer.Kubernetes = cluster
+		deployer.Auth = c.String("auth")
+		deployer.MaxCost = c.Float64("max-monthly-cost")
+		if err := deployer.Deploy(true, c.Bool("allow-breaking")); err != nil {
+			return "", "", err
+		}
+		meta, _ := manager.Provider.GetDeploymentMetadata()
+		return "deployed", mockEndpoint(meta), nil
+	})
+	return exitcode.Wrap(exitcode.Deploy, commands.PrintProjectSummary(os.Stdout, "Deploy", results))
+}
+
+// kubernetesTarget returns the cluster 'deploy --target kubernetes' deploys to; nil for the cloud
+func kubernetesTarget(c *cli.Context) (*kubernetes.Options, error) {
+	switch target := c.String("target"); target {
+	case "", "cloud":
+		return nil, nil
+	case kubernetes.Target:
+		if c.String("export-terraform") != "" {
+			return nil, exitcode.New(exitcode.Config, "--export-terraform writes the cloud stack; use --render-to for Kubernetes manifests")
+		}
+		if c.String("domain") != "" {
+			return nil, exitcode.WithHint(exitcode.Config, fmt.Errorf("--domain provisions a certificate and CDN for the cloud stack"),
+				"Expose the automock Service through your cluster's ingress to give it a domain")
+		}
+		if c.String("auth") != "" {
+			return nil, exitcode.WithHint(exitcode.Config, fmt.Errorf("--auth is enforced by a WAF in front of the cloud stack"),
+				"Protect the automock Service with your cluster's ingress or network policies")
+		}
+		if c.Int("replicas") < 1 {
+			return nil, exitcode.New(exitcode.Config, "--replicas must be at least 1")
+		}
+		return &kubernetes.Options{
+			Context:   c.String("kube-context"),
+			Namespace: c.String("namespace"),
+			Image:     c.String("image"),
+			Replicas:  c.Int("replicas"),
+		}, nil
+	default:
+		return nil, exitcode.New(exitcode.Config, "--target must be cloud or kubernetes, got %q", target)
+	}
+}
+
+// mockEndpoint is the MockServer URL recorded in deployment metadata, if any
+func mockEndpoint(meta *models.DeploymentMetadata) string {
+	if meta == nil || meta.Details == nil {
+		return ""
+	}
+	return meta.Details.MockServerURL
+}
+
 // destroyCommand handles infrastructure teardown
 func destroyCommand(c *cli.Context) error {
 	profile := c.String("profile")
 	projectName := c.String("project")
 	force := c.Bool("force")
+	if projects := commands.SplitProjects(projectName); len(projects) > 1 {
+		return destroyProjects(c, projects)
+	}
 
 	// Show confirmation unless --force
 	if !force {
@@ -305,23 +1001,21 @@ func destroyCommand(c *cli.Context) error {
 			Message: "Enter project name:",
 		}
 
-		if err := survey.AskOne(namePrompt, &inputName); err != nil {
+		if err := ask.One(namePrompt, &inputName); err != nil {
 			return err
 		}
 
 		if inputName != projectName {
-			fmt.Println("\nProject name does not match. Deletion cancelled.")
-			return nil
+			return exitcode.New(exitcode.Cancelled, "project name does not match; deletion cancelled")
 		}
 
 		// Final confirmation
-		if err := survey.AskOne(prompt, &confirmed); err != nil {
+		if err := ask.One(prompt, &confirmed); err != nil {
 			return err
 		}
 
 		if !confirmed {
-			fmt.Println("\nDeletion cancelled")
-			return nil
+			return exitcode.New(exitcode.Cancelled, "deletion cancelled")
 		}
 	}
 	manager := cloud.NewCloudManager(profile)
@@ -332,7 +1026,7 @@ func destroyCommand(c *cli.Context) error {
 
 	exists, err := manager.Provider.ProjectExists(ctx, projectName)
 	if !exists || err != nil {
-		return fmt.Errorf("project %s does not exist", projectName)
+		return exitcode.New(exitcode.Config, "project %s does not exist", projectName)
 	}
 
 	// Discover presence
@@ -361,21 +1055,47 @@ func destroyCommand(c *cli.Context) error {
 		choice = "loadtest"
 	}
 	if choice == "" {
-		_ = survey.AskOne(&survey.Select{Message: "Select what to destroy:", Options: options, Default: options[0]}, &choice)
+		_ = ask.One(&survey.Select{Message: "Select what to destroy:", Options: options, Default: options[0]}, &choice)
+	}
+
+	return destroyProject(manager, projectName, profile, choice)
+}
+
+// destroyProject tears down a project's mock infrastructure, load test infrastructure, or both
+func destroyProject(manager *cloud.CloudManager, projectName, profile, choice string) error {
+	if err := hooks.Run(hooks.PreDestroy, projectName, profile, map[string]string{"target": choice}); err != nil {
+		return exitcode.Wrap(exitcode.Validation, fmt.Errorf("destroy aborted: %w", err))
 	}
 
 	// Destroy mocks
 	if choice == "mocks" || choice == "both" {
-		destroyer, err := terraform.NewManager(projectName, profile, manager.Provider)
-		if err != nil {
-			return fmt.Errorf("failed to create terraform manager: %w", err)
+		var outputs *models.InfrastructureOutputs
+		if meta, _ := manager.Provider.GetDeploymentMetadata(); meta != nil {
+			outputs = meta.Details
 		}
-		fmt.Println("\nDestroying mock infrastructure...")
-		if err := destroyer.Destroy(); err != nil {
-			return err
+		if cluster, ok := kubernetes.ManagerFromOutputs(projectName, outputs); ok {
+			if err := kubernetes.CheckKubectlInstalled(); err != nil {
+				return exitcode.Wrap(exitcode.Config, err)
+			}
+			fmt.Printf("\nDeleting mocks from Kubernetes namespace %s...\n", cluster.Options.Namespace)
+			if err := cluster.Destroy(context.Background()); err != nil {
+				return exitcode.Wrap(exitcode.Deploy, err)
+			}
+		} else {
+			destroyer, err := terraform.NewManager(projectName, profile, manager.Provider)
+			if err != nil {
+				return fmt.Errorf("failed to create terraform manager: %w", err)
+			}
+			fmt.Println("\nDestroying mock infrastructure...")
+			if err := destroyer.Destroy(); err != nil {
+				return exitcode.Wrap(exitcode.Deploy, err)
+			}
 		}
 		_ = manager.Provider.DeleteDeploymentMetadata()
 		fmt.Println("✅ Mock infra destroyed")
+		if sched, err := manager.Provider.GetLoadTestSchedule(context.Background(), projectName); err == nil && sched != nil {
+			fmt.Printf("💡 Scheduled load test runs of %s remain; remove them with 'automock loadtest schedule --project %s --remove'.\n", projectName, projectName)
+		}
 		if choice != "both" {
 			return nil
 		}
@@ -389,7 +1109,7 @@ func destroyCommand(c *cli.Context) error {
 		}
 		fmt.Println("\nDestroying load test infrastructure...")
 		if err := lt.Destroy(); err != nil {
-			return err
+			return exitcode.Wrap(exitcode.Deploy, err)
 		}
 		_ = manager.Provider.DeleteLoadTestDeploymentMetadata()
 		fmt.Println("✅ Load test infra destroyed")
@@ -397,11 +1117,90 @@ func destroyCommand(c *cli.Context) error {
 	return nil
 }
 
+// destroyProjects destroys all mock and load test infrastructure of several projects
+// concurrently, after a single confirmation, and prints a combined summary
+func destroyProjects(c *cli.Context, projects []string) error {
+	profile := c.String("profile")
+	if !c.Bool("force") {
+		terraform.DisplayDestroyConfirmation(strings.Join(projects, ", "))
+		var names string
+		if err := ask.One(&survey.Input{Message: "Enter the project names (comma-separated):"}, &names); err != nil {
+			return err
+		}
+		if strings.Join(commands.SplitProjects(names), ",") != strings.Join(projects, ",") {
+			return exitcode.New(exitcode.Cancelled, "project names do not match; deletion cancelled")
+		}
+		var confirmed bool
+		if err := ask.One(&survey.Confirm{
+			Message: fmt.Sprintf("Are you sure? %d projects will be destroyed. This action cannot be undone.", len(projects)),
+		}, &confirmed); err != nil {
+			return err
+		}
+		if !confirmed {
+			return exitcode.New(exitcode.Cancelled, "deletion cancelled")
+		}
+	}
+	ask.SetNonInteractive(true)
+
+	results := commands.RunProjects(projects, c.Int("concurrency"), func(project string) (string, string, error) {
+		manager := cloud.NewCloudManager(profile)
+		if err := manager.AutoDetectProvider(profile); err != nil {
+			return "", "", err
+		}
+		ctx := context.Background()
+		if exists, err := manager.Provider.ProjectExists(ctx, project); !exists || err != nil {
+			return "", "", exitcode.New(exitcode.Config, "project does not exist")
+		}
+		_, mockErr := manager.Provider.GetConfig(ctx, project)
+		ptr, loadErr := manager.Provider.GetLoadTestPointer(ctx, project)
+		hasMock, hasLoad := mockErr == nil, loadErr == nil && ptr != nil && ptr.ActiveVersion != ""
+		switch {
+		case hasMock && hasLoad:
+			return "destroyed", "mocks and load test", destroyProject(manager, project, profile, "both")
+		case hasMock:
+			return "destroyed", "mocks", destroyProject(manager, project, profile, "mocks")
+		case hasLoad:
+			return "destroyed", "load test", destroyProject(manager, project, profile, "loadtest")
+		}
+		return "skipped", "nothing to destroy", nil
+	})
+	return exitcode.Wrap(exitcode.Deploy, commands.PrintProjectSummary(os.Stdout, "Destroy", results))
+}
+
+// printProjectCosts shows what the project's cloud resources cost last month and this month so far
+func printProjectCosts(provider internal.Provider, projectName string) {
+	now := time.Now().UTC()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+
+	fmt.Println("\n💰 Actual Costs:")
+	for _, period := range []struct {
+		label    string
+		from, to time.Time
+	}{
+		{"Last month", thisMonth.AddDate(0, -1, 0), thisMonth},
+		{"Month to date", thisMonth, tomorrow},
+	} {
+		report, err := provider.ActualCosts(context.Background(), projectName, period.from, period.to)
+		if err != nil {
+			fmt.Printf("⚠️  Costs unavailable: %v\n", err)
+			return
+		}
+		fmt.Print(report.Format(period.label))
+		if period.to.Equal(tomorrow) {
+			fmt.Printf("  (%s)\n", report.Scope)
+		}
+	}
+}
+
 // statusCommand shows current infrastructure status
 func statusCommand(c *cli.Context) error {
 	profile := c.String("profile")
 	projectName := c.String("project")
 	detailed := c.Bool("detailed")
+	if c.Bool("watch") {
+		return commands.RunStatusWatch(profile, projectName, commands.StatusWatchOptions{Interval: c.Duration("interval"), URL: c.String("url")})
+	}
 
 	fmt.Printf("\n🛰️  Checking infrastructure status for: %s\n", projectName)
 	fmt.Println(strings.Repeat("━", 80))
@@ -426,6 +1225,11 @@ func statusCommand(c *cli.Context) error {
 	mockDeployed := mockMeta != nil && mockMeta.DeploymentStatus == "deployed"
 	loadDeployed := loadMeta != nil && loadMeta.DeploymentStatus == "deployed"
 
+	if c.Bool("costs") {
+		// Torn down stacks still cost money, so costs are shown whatever is deployed now
+		defer printProjectCosts(manager.Provider, projectName)
+	}
+
 	if !mockDeployed && !loadDeployed {
 		fmt.Println("❌ No infrastructure found for this project.")
 		fmt.Printf("💡 Run 'automock deploy --project %s' to create it if expectations exists or load test scripts uploaded.\n", projectName)
@@ -443,8 +1247,42 @@ func statusCommand(c *cli.Context) error {
 
 		fmt.Printf("🕓 Deployed At (Local): %s\n", deployedLocal.Format("2006-01-02 15:04:05 MST"))
 		fmt.Printf("⏱️  Uptime: %s\n", uptimeStr)
+		if cluster, ok := kubernetes.ManagerFromOutputs(projectName, mockMeta.Details); ok {
+			fmt.Printf("☸️  Kubernetes: %s in namespace %s\n", kubernetes.Name(projectName), cluster.Options.Namespace)
+			if out, err := cluster.Status(context.Background()); err != nil {
+				fmt.Printf("⚠️  Could not query the cluster: %v\n", err)
+			} else {
+				fmt.Print(out)
+			}
+		}
+		cfg, cfgErr := manager.Provider.GetConfig(context.Background(), projectName)
+		if cfgErr == nil && cfg.Metadata.ContractVersion != "" {
+			fmt.Printf("🏷️  Contract Version: %s (GET %s)\n", cfg.Metadata.ContractVersion, models.ContractInfoPath)
+			if len(cfg.Changelog) > 0 {
+				entries := cfg.Changelog[:1]
+				if detailed {
+					entries = cfg.Changelog
+				}
+				fmt.Println("\n📜 Changelog:")
+				fmt.Print(models.FormatChangelog(entries))
+			}
+		}
+		if cfgErr == nil {
+			printEnvironmentStatus(projectName, cfg, c.String("env"))
+		}
 		fmt.Println()
 
+		if mockMeta.Details != nil && mockMeta.Details.Auth != nil {
+			if auth := mockMeta.Details.Auth; detailed {
+				fmt.Printf("🔑 Access: %s\n", auth.Describe())
+			} else {
+				fmt.Printf("🔑 Access: requests need the %s header (--detailed shows the credential)\n", auth.Header)
+			}
+		}
+		if detailed && cfgErr == nil && mockMeta.Details != nil && mockMeta.Details.MockServerURL != "" {
+			printTrafficSummary(mockMeta.Details.MockServerURL, mockMeta.Details.Auth, cfg)
+		}
+
 		if !detailed {
 			fmt.Println("📊 Summary Status:")
 			mockMeta.Details = nil // hide the nested infra outputs
@@ -511,52 +1349,229 @@ func showDetailedHelp(c *cli.Context) error {
 	Example: automock --profile dev deploy --project orders
 
 %sCOMMANDS%s
+	demo      Guided tour on a sample project: import → review → serve → test (local, cleans up)
 	init      Generate expectations & bootstrap project
 	deploy    Deploy mock and/or load-test infrastructure
 	destroy   Tear down infrastructure and metadata
 	status    Show deployment status (add --detailed)
 	load      Generate / upload / download load-test bundle; manage pointers
+	consumers Register teams notified of contract changes on deploy
+	logs      Show or follow requests the deployed mock received; logs export writes them as HAR
+	export    Archive a project with its versions and load-test bundle (export --project users --out users.zip);
+	          export manifest --out MOCK_API.md writes a consumer manifest
+	          export --format pact [--consumer web] writes the expectations as a Pact v3 contract
+	import    Restore a project archive into this account and region (import --file users.automock.tar.gz)
+	push      Copy a project from the local or git store to a cloud (push --project users [--to aws])
+	match     Trace which expectation a request matches (offline)
+	diff      Compare two saved versions (diff --from deployed --to current)
+	rollback  Restore a saved version as current, optionally redeploying (rollback --version v... --deploy)
+	validate  Check expectations for invalid regexes, bad bodies and conflicting matchers
+	          (--conflicts also finds overlapping matchers; --fix saves the priorities resolving them)
+	serve     Run expectations on a local mock server (serve --port 1080 [--proto orders.proto])
+	docker    Write a Docker Compose bundle teammates run with 'docker compose up' (docker --project users)
+	record    Proxy traffic to a real API and save the recordings as expectations
+	refresh   Re-record stale recorded responses from the upstream (--all, --dry-run)
+	test      Verify the mock against pm.test assertions captured on import
+	verify    Replay expectations against a real API as a contract test (--base-url, --junit)
+	coverage  Compare expectations with an OpenAPI spec or collection (--spec, --json, --html, --min-coverage)
+	download  Save expectations to a file (--split: one file per expectation; --format wiremock; --env stage)
+	generate-fixtures  Write test fixtures that answer like the mock: Go httptest, MSW or Python responses (--lang)
+	watch     Sync a split expectations directory on every change
+	gc        Prune old versions and load-test bundles (retention policy)
+	config    Scaffold the project's automock.yaml (config init [--project <name>] [--force])
+	debug-bundle  Write a redacted diagnostic bundle for support tickets
 	help      Show this help
 
 %sGLOBAL FLAGS%s
 	--profile <name>   Cloud credential profile (or AWS_PROFILE env)
+	--assume-role <arn> [--external-id <id>] [--session-tag key=value]
+	                   Run all cloud operations (including Terraform) as this role
+	--s3-endpoint <url> [--s3-path-style]
+	                   Use an S3-compatible state store (MinIO, Cloudflare R2)
+	--cloud <aws|azure|local|git> [--azure-storage-account <name>] [--azure-location <region>]
+	                   Pick the cloud (default: auto-detect); with azure, --profile is the subscription ID;
+	                   local keeps projects under ~/.automock without credentials (serve, not deploy);
+	                   git commits each change to <repo>/mocks/<project>/expectations.json [--git-path <dir>]
+	--non-interactive  Never prompt (alias --yes): answers file, then prompt defaults; fail fast otherwise
+	--answers <file>   YAML/JSON map of prompt message -> answer (a list answers repeats in order)
+	--timeout <dur>    Fail a cloud store or AI provider call that takes longer (default: 60s)
+	--model <name>     AI model of the selected provider (e.g. gpt-4o-mini, claude-sonnet-4-5)
+	--offline          No store, deploy or AI generation; init writes expectations to a local file
+	--error-format <text|json>
+	                   json writes failures to stderr as one JSON object (exit codes: see README)
 
 %sINIT FLAGS%s
 	--project <name>
-	--provider <anthropic|openai>
-	--collection-file <path> --collection-type <postman|bruno|insomnia>
+	--provider <anthropic|openai|gemini|bedrock>
+	--collection-file <path> [--collection-type <postman|bruno|insomnia|openapi|proto>]
+	                   (type is auto-detected when omitted; repeat --collection-file, or pass a glob/directory, to merge files)
+	--schema <schema.graphql|introspection.json>
+	                   One expectation per GraphQL query and mutation, matched by operationName, with example data and error variants
+	--iteration-data <file.csv|file.json>   Run data-driven requests once per row (like newman -d)
+	--chunk-size <n>   Large collections run and review in chunks of n requests (default 50)
+	--max-requests <n> Refuse larger imports (default 2000); files over 20 MB are stream-parsed
+	--concurrency <n>  Run independent requests in parallel (default 1); dependent ones keep their order
 
 %sDEPLOY FLAGS%s
-	--project <name>  (required)
+	--project <name>  (required; a,b,c deploys several concurrently with a combined summary)
+	--concurrency <n>  Projects deployed at once with several projects (default 4)
 	--skip-confirmation
+	--allow-breaking   Deploy despite breaking changes since the last deploy
+	--only-tags <tags> / --exclude-tags <tags>   Serve a labeled subset; others stay stored but withheld
+	--manifest <file>  Regenerate the consumer manifest (see export manifest) after deploying
+	--mask <profile>   Mask served response bodies (demo, redact, or a project profile); originals stay stored
+	--env <name>       Serve an environment overlay (dev, stage, prod); without it the base is served
+	--instance-size <small|medium|large|xlarge> [--min-tasks <n>] [--max-tasks <n>]
+	                   Size the mock server up front instead of at the prompts
+	--target kubernetes [--namespace <ns>] [--kube-context <ctx>] [--replicas <n>] [--image <ref>]
+	                   Apply a MockServer Deployment, Service and expectations ConfigMap with kubectl;
+	                   status, destroy and rollback follow it there. Works from the local and git stores
+	--render-to <file> With --target kubernetes, write the manifests instead of applying them
+	--export-terraform <dir>   Write the mock stack as a Terraform/OpenTofu module for your own pipeline
+	                   instead of applying it (expectations are still saved for it to serve)
+	--domain <name> [--hosted-zone-id <id>]
+	                   Serve the mocks at https://<name> (AWS): ACM certificate, CloudFront and a
+	                   Route53 record, kept on redeploys and removed by destroy
+	--auth <api-key|basic|none>
+	                   Require a generated credential on every request (AWS WAF); status --detailed
+	                   shows it, redeploys keep it, none opens the mocks again
+	--max-monthly-cost <usd>   Refuse the deploy when the cost estimate shown before it exceeds this
 
 %sDESTROY FLAGS%s
-	--project <name>  (required)
+	--project <name>  (required; a,b,c destroys several concurrently)
+	--concurrency <n>  Projects destroyed at once with several projects (default 4)
 	--force            Skip confirmations
 
 %sSTATUS FLAGS%s
 	--project <name>  (required)
-	--detailed
+	--detailed         Adds per-endpoint hits, match rate, simulated latency and recent unmatched requests
+	--env <name>       Show an environment overlay and whether it is the one deployed
+	--watch [--interval 5s] [--url <mock>]   Live dashboard of health, status latency percentiles, per-endpoint hits
+	                   and unmatched requests of the deployed mock (or --url), refreshed until Ctrl+C
+	--costs            Adds last month's and this month's actual cost per service (AWS Cost Explorer,
+	                   Azure Cost Management) of resources tagged ProjectName=<name>
+
+%sLOGS FLAGS%s
+	--project <name> | --url <mock>   Deployed mock to read (its expectations explain unmatched requests)
+	--tail [--interval 2s]   Keep printing new requests until Ctrl+C
+	--path <pattern> --method <m> --status <404|4xx|unmatched>   Filter (* in paths matches anything)
+	--limit <n>        Most recent matching requests shown first (default 20)
+	analyze --project <name> [--url <mock>] [--ai [--provider <p>]] [--yes]
+	                   Cluster unmatched requests by method, path and shape, and append an expectation
+	                   per kept cluster (response borrowed from the same endpoint, a placeholder, or AI)
 
 %sLOAD FLAGS%s
-	--collection-file <path> --collection-type <type>
+	--collection-file <path> [--collection-type <type>]
 	--dir <path>              Output directory
-	--headless | --distributed (generation only)
+	--tool locust|k6|gatling|jmeter   Load testing tool (default: locust)
+	--headless | --distributed (generation only; --headless is Locust only)
 	--upload | --download | --delete-pointer | --purge-all
+	--kms-key <key> --object-tag key=value   Encrypt (SSE-KMS) and tag uploaded bundles
+	run --project <name> | --dir <path>   Run a Locust bundle headless and store the results
+	    --users <n> --spawn-rate <n> --duration 5m [--host <url>] [--deployed]
+	schedule --project <name> --cron "0 2 * * *" [--users --spawn-rate --duration --host] | --remove
+	results --project <name> [--version <v>] [--limit <n>] [--format table|json]
+
+%sCONSUMERS FLAGS%s
+	--project <name>  (required)
+	--add <team> --webhook <url> | --email <addr>
+	--tags <tag> --paths <pattern>   (repeatable; default: all)
+	--remove <team>
+
+%sGC FLAGS%s
+	--project <name>  (required)
+	--keep-last <n> and/or --older-than <duration>   (both: prune only what falls outside both)
+	--dry-run          List what would be pruned
+
+%sLOGS EXPORT FLAGS%s
+	--project <name> | --url <mock url>   (deployed endpoint, or any MockServer such as localhost:1080)
+	--format har       --out <file>
 
 %sENV VARS%s
 	AWS_PROFILE           Alternative to --profile
 	ANTHROPIC_API_KEY     Used with provider anthropic
 	OPENAI_API_KEY        Used with provider openai
+	GEMINI_API_KEY        Used with provider gemini (else Application Default Credentials)
+	AUTOMOCK_BEDROCK_REGION  Region of provider bedrock (AWS credential chain; default: the AWS region)
+	AUTOMOCK_MODEL        Alternative to --model (+ OPENAI_MODEL, ANTHROPIC_MODEL, GEMINI_MODEL, BEDROCK_MODEL_ID)
+	AUTOMOCK_SMTP_HOST    SMTP server for consumer emails (+ _PORT, _USER, _PASSWORD, _FROM)
+	AUTOMOCK_ASSUME_ROLE  Alternative to --assume-role (+ AUTOMOCK_EXTERNAL_ID, AUTOMOCK_SESSION_TAGS)
+	AUTOMOCK_S3_ENDPOINT  Alternative to --s3-endpoint (+ AUTOMOCK_S3_PATH_STYLE=true)
+	AUTOMOCK_CLOUD        Alternative to --cloud (+ AZURE_STORAGE_ACCOUNT, AZURE_LOCATION, AZURE_SUBSCRIPTION_ID)
+	AUTOMOCK_HOME         Directory of the local store (default ~/.automock)
+	AUTOMOCK_GIT_PATH     Alternative to --git-path
+	AUTOMOCK_NON_INTERACTIVE / AUTOMOCK_ANSWERS   Alternatives to --non-interactive / --answers
+	AUTOMOCK_ERROR_FORMAT Alternative to --error-format
+	AUTOMOCK_CONFIG       Project configuration and hooks file (default ./automock.yaml)
+	AUTOMOCK_INSTANCE_SIZE / AUTOMOCK_MIN_TASKS / AUTOMOCK_MAX_TASKS   Alternatives to the deploy sizing flags
+	AUTOMOCK_UPLOAD_CONCURRENCY / AUTOMOCK_UPLOAD_MAX_MBPS   Bundle upload parallelism / bandwidth cap
+
+%sPROJECT FILE%s
+	automock.yaml at the repository root holds shared defaults (project, provider, model, cloud,
+	profile, region, collections, matching, deploy sizing, hooks); 'automock config init' writes one.
+	Flags win over environment variables, which win over the file.
 
 %sQUICK EXAMPLES%s
+	automock demo
 	automock init --project users --provider anthropic
+	automock init --project users --collection-file openapi.yaml --collection-type openapi
 	automock load --project users --upload --dir ./load
 	automock load --project users --download --dir ./work
 	automock load --project users --delete-pointer
 	automock deploy --project users
+	automock --non-interactive --answers ci-answers.yaml deploy --project users
+	automock --error-format json --yes deploy --project users 2> error.json || echo "exit $?"
+	automock deploy --project users --only-tags v2-preview --exclude-tags flaky
+	automock deploy --project users-demo --mask demo
+	automock deploy --project users --env stage
+	automock deploy --project users,orders,billing --skip-confirmation --concurrency 8
+	automock record --project users --target https://api.example.com --port 8080 --ttl 7d
+	automock refresh --project users --header "Authorization: Bearer $TOKEN"
+	automock download --project users --split --out ./expectations
+	automock download --project users --format wiremock --out mappings/users.json
+	automock download --project users --only-tags auth,error-cases
+	automock generate-fixtures --project users --lang ts --out src/mocks/handlers.js
+	automock export --project users --format pact --consumer web-app
+	automock watch --project users --dir ./expectations
+	automock match --project users --method POST --path /users --body '{"name":"a"}'
+	automock match --project users --request req.json
+	automock validate --project users --strict
+	automock validate --project users --conflicts --fix
+	automock diff --project users --from v1712000000 --to current
+	automock rollback --project users --version v1712000000 --deploy
+	automock verify --project users --base-url https://staging.api.example.com --safe-only --junit verify.xml
+	automock coverage --project users --spec openapi.yaml --html coverage.html --min-coverage 90
+	automock serve --project users --port 1080
+	automock init --project orders --collection-file orders.proto
+	automock init --project shop --schema schema.graphql
+	automock serve --project orders --proto orders.proto
+	automock gc --project users --keep-last 10 --dry-run
+	automock logs --project users --tail --status unmatched
+	automock logs analyze --project users --ai
+	automock logs export --project users --format har --out users.har
+	automock export manifest --project users --out docs/MOCK_API.md
+	automock export --project users --out users-backup.tar.gz
+	automock --profile prod import --file users-backup.tar.gz --project users-copy
+	automock test --project users
 	automock status --project users --detailed
+	automock status --project users --costs
+	automock status --project users --watch --interval 2s
 	automock destroy --project users --force
+	automock --assume-role arn:aws:iam::123456789012:role/mock-deployer --external-id ci deploy --project users
+	automock --cloud azure --azure-storage-account mymocks init --project users
+	automock --cloud local init --project users
+	automock --cloud local serve --project users --port 1080
+	automock docker --project users --env dev --out mocks/users
+	automock push --project users --to aws
+	automock --cloud git init --project users
+	automock config init --project users
+	automock deploy --project users --instance-size medium --min-tasks 2 --max-tasks 10
+	automock --cloud local deploy --project users --target kubernetes --namespace mocks
+	automock deploy --project users --target kubernetes --render-to users-mocks.yaml
+	automock deploy --project users --export-terraform ./infra/mocks
+	automock deploy --project users --domain mock.mycompany.dev
+	automock deploy --project users --auth api-key
+	automock deploy --project users --instance-size medium --max-monthly-cost 60
 
 Run 'automock <command> --help' for command-specific flags.
 `,
@@ -571,6 +1586,11 @@ Run 'automock <command> --help' for command-specific flags.
 		yellow, reset,
 		yellow, reset,
 		yellow, reset,
+		yellow, reset,
+		yellow, reset,
+		yellow, reset,
+		yellow, reset,
+		yellow, reset,
 	)
 	fmt.Print(help)
 	return nil
@@ -3,8 +3,21 @@ package main
 import (
 	"fmt"
 	"os"
+	"runtime/debug"
+	"strings"
+	"time"
 
 	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/collections"
+	"github.com/hemantobora/auto-mock/internal/commands"
+	"github.com/hemantobora/auto-mock/internal/compose"
+	"github.com/hemantobora/auto-mock/internal/diagnostics"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/kubernetes"
+	"github.com/hemantobora/auto-mock/internal/loadtest"
+	"github.com/hemantobora/auto-mock/internal/mcp"
+	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/network"
 	"github.com/urfave/cli/v2"
 )
 
@@ -12,6 +25,22 @@ import (
 var version = "0.0.1-alpha"
 
 func main() {
+	defer func() {
+		if r := recover(); r != nil {
+			cause := fmt.Errorf("panic: %v", r)
+			if errorFormat == "json" {
+				report := exitcode.NewReport(cause, failedCommand)
+				report.Error.ExitCode = exitcode.CodeCrash
+				report.Error.Diagnostics = writeDiagnostics(cause, string(debug.Stack()))
+				_ = report.WriteJSON(os.Stderr)
+			} else {
+				fmt.Fprintf(os.Stderr, "\n💥 automock crashed: %v\n", r)
+				reportDiagnostics(cause, string(debug.Stack()))
+			}
+			os.Exit(exitcode.CodeCrash)
+		}
+	}()
+
 	app := &cli.App{
 		Name:    "automock",
 		Usage:   "Generate and deploy mock API infrastructure",
@@ -21,6 +50,112 @@ func main() {
 				Name:  "profile",
 				Usage: "Credential profile name (e.g., dev, prod)",
 			},
+			&cli.StringFlag{
+				Name:    "assume-role",
+				Usage:   "Role ARN to assume for all cloud operations (e.g., arn:aws:iam::123456789012:role/mock-deployer)",
+				EnvVars: []string{"AUTOMOCK_ASSUME_ROLE"},
+			},
+			&cli.StringFlag{
+				Name:    "external-id",
+				Usage:   "External ID required by the assumed role's trust policy",
+				EnvVars: []string{"AUTOMOCK_EXTERNAL_ID"},
+			},
+			&cli.StringSliceFlag{
+				Name:  "session-tag",
+				Usage: "Session tag key=value attached to the assumed role session (repeatable)",
+			},
+			&cli.StringFlag{
+				Name:    "s3-endpoint",
+				Usage:   "S3-compatible state store endpoint (e.g., http://minio.lab:9000, https://<account>.r2.cloudflarestorage.com)",
+				EnvVars: []string{"AUTOMOCK_S3_ENDPOINT"},
+			},
+			&cli.BoolFlag{
+				Name:    "s3-path-style",
+				Usage:   "Use path-style bucket addressing (required by most MinIO setups)",
+				EnvVars: []string{"AUTOMOCK_S3_PATH_STYLE"},
+			},
+			&cli.StringFlag{
+				Name:    "cloud",
+				Usage:   "Project store to use: aws, azure, local for ~/.automock, or git for commits to this repository (default: auto-detect from available credentials)",
+				EnvVars: []string{"AUTOMOCK_CLOUD"},
+			},
+			&cli.StringFlag{
+				Name:    "azure-storage-account",
+				Usage:   "Azure storage account holding project containers (with --cloud azure; --profile is the subscription ID)",
+				EnvVars: []string{"AZURE_STORAGE_ACCOUNT"},
+			},
+			&cli.StringFlag{
+				Name:    "azure-location",
+				Usage:   "Azure region for deployments (default: eastus)",
+				EnvVars: []string{"AZURE_LOCATION"},
+			},
+			&cli.StringFlag{
+				Name:    "git-path",
+				Usage:   "Directory of the git store's projects, relative to the top of the repository (with --cloud git; default: mocks)",
+				EnvVars: []string{"AUTOMOCK_GIT_PATH"},
+			},
+			&cli.BoolFlag{
+				Name:    "non-interactive",
+				Aliases: []string{"yes", "y"},
+				Usage:   "Never prompt: use the answers file, then prompt defaults, and fail when a prompt has neither (for CI)",
+				EnvVars: []string{"AUTOMOCK_NON_INTERACTIVE"},
+			},
+			&cli.StringFlag{
+				Name:    "answers",
+				Usage:   "YAML/JSON file mapping prompt messages to answers",
+				EnvVars: []string{"AUTOMOCK_ANSWERS"},
+			},
+			&cli.DurationFlag{
+				Name:    "timeout",
+				Usage:   "Give up on a cloud store or AI provider call that takes longer than this (e.g. 30s, 2m)",
+				Value:   network.DefaultTimeout,
+				EnvVars: []string{network.EnvTimeout},
+			},
+			&cli.StringFlag{
+				Name:    "model",
+				Usage:   "AI model used by the selected provider (e.g. gpt-4o-mini, claude-sonnet-4-5; default: the provider's)",
+				EnvVars: []string{mcp.EnvModel},
+			},
+			&cli.BoolFlag{
+				Name:    "offline",
+				Usage:   "Never touch the network: no store, deploy or AI generation; init writes expectations to a local file",
+				EnvVars: []string{network.EnvOffline},
+			},
+			&cli.StringFlag{
+				Name:    "error-format",
+				Usage:   "How failures are reported on stderr: text, or json (one line: {\"error\": {\"kind\", \"exitCode\", \"message\", ...}})",
+				Value:   "text",
+				EnvVars: []string{"AUTOMOCK_ERROR_FORMAT"},
+			},
+		},
+		OnUsageError: func(c *cli.Context, err error, isSubcommand bool) error {
+			return exitcode.Wrap(exitcode.Config, err)
+		},
+		Before: func(c *cli.Context) error {
+			failedCommand = c.Args().First()
+			switch errorFormat = c.String("error-format"); errorFormat {
+			case "text", "json":
+			default:
+				errorFormat = "text"
+				return exitcode.New(exitcode.Config, "--error-format must be text or json, got %q", c.String("error-format"))
+			}
+			if err := applyProjectFile(c); err != nil {
+				return err
+			}
+			if err := applyAssumeRoleFlags(c); err != nil {
+				return err
+			}
+			if err := applyS3EndpointFlags(c); err != nil {
+				return err
+			}
+			if err := applyCloudFlags(c); err != nil {
+				return err
+			}
+			if err := applyNetworkFlags(c); err != nil {
+				return err
+			}
+			applyModelFlag(c)
+			return applyPromptFlags(c)
 		},
 		Commands: []*cli.Command{
 			{
@@ -33,25 +168,61 @@ func main() {
 					},
 					&cli.StringFlag{
 						Name:  "provider",
-						Usage: "LLM provider (anthropic, openai, template) - bypasses provider selection",
+						Usage: "LLM provider (anthropic, openai, gemini, bedrock, template) - bypasses provider selection",
 					},
-					&cli.StringFlag{
+					&cli.StringSliceFlag{
 						Name:  "collection-file",
-						Usage: "Path to API collection file (Postman/Bruno/Insomnia)",
+						Usage: "Path to API collection file (Postman/Bruno/Insomnia), OpenAPI/Swagger spec or .proto file; repeatable, accepts globs and directories",
 					},
 					&cli.StringFlag{
 						Name:  "collection-type",
-						Usage: "Collection type (postman, bruno, insomnia) - required with --collection-file",
+						Usage: "Collection type (postman, bruno, insomnia, openapi, proto) - auto-detected from the file when omitted",
+					},
+					&cli.StringFlag{
+						Name:  "schema",
+						Usage: "GraphQL schema (SDL or introspection JSON); generates an expectation per query and mutation, matched by operationName",
+					},
+					&cli.StringFlag{
+						Name:  "iteration-data",
+						Usage: "CSV or JSON data file (like newman -d); requests using its fields run once per row",
+					},
+					&cli.IntFlag{
+						Name:  "chunk-size",
+						Usage: "Requests executed and reviewed per chunk for large collections",
+						Value: collections.DefaultChunkSize,
+					},
+					&cli.IntFlag{
+						Name:  "max-requests",
+						Usage: "Refuse collection imports with more requests than this",
+						Value: collections.DefaultMaxRequests,
+					},
+					&cli.IntFlag{
+						Name:  "concurrency",
+						Usage: "Run up to n independent collection requests at once (requests sharing variables or a written resource stay ordered)",
+						Value: collections.DefaultConcurrency,
+					},
+					&cli.BoolFlag{
+						Name:  "conditional-get",
+						Usage: "Add ETag headers to GET responses plus 304 Not Modified answers for matching If-None-Match",
 					},
 				},
 				Action: func(c *cli.Context) error {
 					profile := c.String("profile")
+					if c.String("schema") != "" && len(c.StringSlice("collection-file")) > 0 {
+						return exitcode.New(exitcode.Config, "--schema and --collection-file cannot be combined; import them in separate runs")
+					}
 
 					cliContext := &cloud.CLIContext{
-						ProjectName:    c.String("project"),
-						Provider:       c.String("provider"),
-						CollectionFile: c.String("collection-file"),
-						CollectionType: c.String("collection-type"),
+						ProjectName:     c.String("project"),
+						Provider:        c.String("provider"),
+						CollectionFiles: c.StringSlice("collection-file"),
+						CollectionType:  c.String("collection-type"),
+						Schema:          c.String("schema"),
+						IterationData:   c.String("iteration-data"),
+						ChunkSize:       c.Int("chunk-size"),
+						MaxRequests:     c.Int("max-requests"),
+						Concurrency:     c.Int("concurrency"),
+						ConditionalGet:  c.Bool("conditional-get"),
 					}
 
 					return cloud.AutoDetectAndInit(profile, cliContext)
@@ -63,13 +234,104 @@ func main() {
 				Flags: []cli.Flag{
 					&cli.StringFlag{
 						Name:     "project",
-						Usage:    "Project name to deploy",
+						Usage:    "Project name to deploy (comma-separated to deploy several concurrently)",
 						Required: true,
 					},
+					&cli.IntFlag{
+						Name:  "concurrency",
+						Usage: "Projects deployed at once when --project lists several",
+						Value: commands.DefaultProjectConcurrency,
+					},
 					&cli.BoolFlag{
 						Name:  "skip-confirmation",
 						Usage: "Skip deployment confirmation prompt",
 					},
+					&cli.BoolFlag{
+						Name:  "allow-breaking",
+						Usage: "Deploy even if breaking changes since the last deployed version are detected",
+					},
+					&cli.StringSliceFlag{
+						Name:  "only-tags",
+						Usage: "Serve only expectations with any of these tags (comma-separated or repeated)",
+					},
+					&cli.StringSliceFlag{
+						Name:  "exclude-tags",
+						Usage: "Withhold expectations with any of these tags from the deployment",
+					},
+					&cli.StringFlag{
+						Name:  "manifest",
+						Usage: "Regenerate this consumer manifest file after a successful deploy",
+					},
+					&cli.StringFlag{
+						Name:  "mask",
+						Usage: "Masking profile applied to served response bodies (demo, redact, or a project profile)",
+					},
+					&cli.StringFlag{
+						Name:  "env",
+						Usage: "Environment overlay served on the base expectations (e.g. dev, stage, prod)",
+					},
+					&cli.StringFlag{
+						Name:    "instance-size",
+						Usage:   "Mock server size (small, medium, large, xlarge) - skips the size prompt",
+						EnvVars: []string{models.EnvInstanceSize},
+					},
+					&cli.IntFlag{
+						Name:    "min-tasks",
+						Usage:   "Minimum running mock server tasks (AWS) - skips the prompt",
+						EnvVars: []string{models.EnvMinTasks},
+					},
+					&cli.IntFlag{
+						Name:    "max-tasks",
+						Usage:   "Maximum tasks auto-scaling may reach (AWS) - skips the prompt",
+						EnvVars: []string{models.EnvMaxTasks},
+					},
+					&cli.StringFlag{
+						Name:  "target",
+						Usage: "Where the mocks run: cloud (Terraform) or kubernetes (kubectl with your kubeconfig)",
+						Value: "cloud",
+					},
+					&cli.StringFlag{
+						Name:  "namespace",
+						Usage: "Kubernetes namespace (default: the context's namespace)",
+					},
+					&cli.StringFlag{
+						Name:  "kube-context",
+						Usage: "Kubeconfig context to deploy to (default: the current context)",
+					},
+					&cli.IntFlag{
+						Name:  "replicas",
+						Usage: "MockServer pods on Kubernetes",
+						Value: 1,
+					},
+					&cli.StringFlag{
+						Name:  "image",
+						Usage: "MockServer image on Kubernetes, e.g. mirrored in an internal registry",
+						Value: kubernetes.DefaultImage,
+					},
+					&cli.StringFlag{
+						Name:  "render-to",
+						Usage: "Write the Kubernetes manifests to this file instead of applying them",
+					},
+					&cli.StringFlag{
+						Name:  "export-terraform",
+						Usage: "Write the Terraform/OpenTofu module to this directory for your own pipeline instead of applying it",
+					},
+					&cli.StringFlag{
+						Name:  "domain",
+						Usage: "Serve the mocks at this domain over HTTPS (AWS: ACM certificate, CloudFront and Route53 record)",
+					},
+					&cli.StringFlag{
+						Name:  "hosted-zone-id",
+						Usage: "Route53 hosted zone of --domain (default: the public zone of its parent domain)",
+					},
+					&cli.StringFlag{
+						Name:  "auth",
+						Usage: "Require a credential on every request (AWS): api-key, basic, or none to open the mocks again; a new one is issued each time",
+					},
+					&cli.Float64Flag{
+						Name:  "max-monthly-cost",
+						Usage: "Refuse cloud deploys whose estimated monthly cost (USD) exceeds this",
+					},
 				},
 				Action: func(c *cli.Context) error {
 					return deployCommand(c)
@@ -81,9 +343,14 @@ func main() {
 				Flags: []cli.Flag{
 					&cli.StringFlag{
 						Name:     "project",
-						Usage:    "Project name to destroy",
+						Usage:    "Project name to destroy (comma-separated to destroy several concurrently)",
 						Required: true,
 					},
+					&cli.IntFlag{
+						Name:  "concurrency",
+						Usage: "Projects destroyed at once when --project lists several",
+						Value: commands.DefaultProjectConcurrency,
+					},
 					&cli.BoolFlag{
 						Name:  "force",
 						Usage: "Skip confirmation prompts",
@@ -104,7 +371,28 @@ func main() {
 					},
 					&cli.BoolFlag{
 						Name:  "detailed",
-						Usage: "Show detailed information including metrics",
+						Usage: "Show detailed information including per-endpoint traffic from the mock's request log",
+					},
+					&cli.StringFlag{
+						Name:  "env",
+						Usage: "Also summarize this environment overlay and whether it is the one deployed",
+					},
+					&cli.BoolFlag{
+						Name:  "watch",
+						Usage: "Refresh a live dashboard of the deployed mock's health, latency and traffic until Ctrl+C",
+					},
+					&cli.DurationFlag{
+						Name:  "interval",
+						Usage: "Time between --watch refreshes",
+						Value: 5 * time.Second,
+					},
+					&cli.StringFlag{
+						Name:  "url",
+						Usage: "With --watch, the mock to watch instead of the deployed one (e.g. one run by 'automock serve')",
+					},
+					&cli.BoolFlag{
+						Name:  "costs",
+						Usage: "Also show what the project's cloud resources cost last month and this month (AWS Cost Explorer, Azure Cost Management)",
 					},
 				},
 				Action: func(c *cli.Context) error {
@@ -112,8 +400,9 @@ func main() {
 				},
 			},
 			{
-				Name:  "load",
-				Usage: "Generate and manage load-test bundles (Locust)",
+				Name:    "load",
+				Aliases: []string{"loadtest"},
+				Usage:   "Generate and manage load-test bundles (Locust, k6, Gatling or JMeter)",
 				Flags: []cli.Flag{
 					&cli.StringFlag{Name: "project", Usage: "Project name."},
 					&cli.BoolFlag{Name: "upload", Usage: "Upload bundle to cloud storage."},
@@ -126,22 +415,461 @@ func main() {
 					},
 					&cli.StringFlag{
 						Name:  "collection-type",
-						Usage: "Collection type (postman, bruno, insomnia) - required with --collection-file",
+						Usage: "Collection type (postman, bruno, insomnia) - auto-detected from the file when omitted",
 					},
 					&cli.StringFlag{
 						Name:  "dir",
 						Usage: "Output directory for generated load-test files",
 					},
+					&cli.StringFlag{
+						Name:  "tool",
+						Usage: "Load testing tool the bundle is generated for: locust, k6, gatling or jmeter",
+						Value: loadtest.ToolLocust,
+					},
 					&cli.BoolFlag{
 						Name:  "headless",
 						Usage: "Run Locust in headless mode (without UI)",
 					},
 					&cli.BoolFlag{
 						Name:  "distributed",
-						Usage: "Generate distributed mode helpers (master/worker scripts)",
+						Usage: "Generate distributed mode helpers (Locust master/worker, k6 and Gatling segment, or JMeter remote server scripts)",
+					},
+					&cli.StringFlag{
+						Name:  "kms-key",
+						Usage: "Encrypt uploaded bundles with this KMS key (SSE-KMS); {project} is replaced, e.g. alias/perf-{project}",
+					},
+					&cli.StringSliceFlag{
+						Name:  "object-tag",
+						Usage: "Tag uploaded bundle objects key=value for IAM/bucket policy scoping (repeatable)",
 					},
 				},
 				Action: locustCommand,
+				Subcommands: []*cli.Command{
+					{
+						Name:  "run",
+						Usage: "Run the Locust bundle headless, locally or on the deployed workers, and store the results",
+						Flags: []cli.Flag{
+							&cli.StringFlag{Name: "project", Usage: "Run the project's active bundle; results are stored next to its version."},
+							&cli.StringFlag{Name: "dir", Usage: "Run a local bundle directory instead; results go to <dir>/results."},
+							&cli.IntFlag{Name: "users", Usage: "Peak number of concurrent users.", Value: 10},
+							&cli.Float64Flag{Name: "spawn-rate", Usage: "Users started per second.", Value: 2},
+							&cli.DurationFlag{Name: "duration", Usage: "How long the test runs (e.g. 5m).", Value: time.Minute},
+							&cli.StringFlag{Name: "host", Usage: "Target host (default: the bundle's host or AM_HOST)."},
+							&cli.BoolFlag{Name: "deployed", Usage: "Run on the project's deployed Locust master and workers."},
+						},
+						Action: func(c *cli.Context) error {
+							return loadRunCommand(c)
+						},
+					},
+					{
+						Name:  "schedule",
+						Usage: "Run the active Locust bundle on a cron schedule in the cloud and store each run's results",
+						Flags: []cli.Flag{
+							&cli.StringFlag{Name: "project", Usage: "Project name.", Required: true},
+							&cli.StringFlag{Name: "cron", Usage: "Cron expression in UTC (e.g. \"0 2 * * *\"); omit to show the current schedule."},
+							&cli.IntFlag{Name: "users", Usage: "Peak number of concurrent users.", Value: 10},
+							&cli.Float64Flag{Name: "spawn-rate", Usage: "Users started per second.", Value: 2},
+							&cli.DurationFlag{Name: "duration", Usage: "How long each run lasts.", Value: 5 * time.Minute},
+							&cli.StringFlag{Name: "host", Usage: "Target host (default: the deployed mock)."},
+							&cli.BoolFlag{Name: "remove", Usage: "Remove the schedule; stored results are kept."},
+						},
+						Action: func(c *cli.Context) error {
+							return loadScheduleCommand(c)
+						},
+					},
+					{
+						Name:  "results",
+						Usage: "List the stored results of load test runs, oldest first, with the p95 trend",
+						Flags: []cli.Flag{
+							&cli.StringFlag{Name: "project", Usage: "Project name.", Required: true},
+							&cli.StringFlag{Name: "version", Usage: "Only runs of this bundle version."},
+							&cli.IntFlag{Name: "limit", Usage: "Only the latest n runs."},
+							&cli.StringFlag{Name: "format", Usage: "Output format: table or json.", Value: "table"},
+						},
+						Action: func(c *cli.Context) error {
+							return loadResultsCommand(c)
+						},
+					},
+				},
+			},
+			{
+				Name:  "consumers",
+				Usage: "Register teams to be notified when expectations they depend on change",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "project", Usage: "Project name.", Required: true},
+					&cli.StringFlag{Name: "add", Usage: "Register (or update) a consumer by name."},
+					&cli.StringFlag{Name: "remove", Usage: "Remove a consumer by name."},
+					&cli.StringFlag{Name: "webhook", Usage: "Webhook URL receiving a JSON diff on deploy."},
+					&cli.StringFlag{Name: "email", Usage: "Email address (requires AUTOMOCK_SMTP_* env vars)."},
+					&cli.StringSliceFlag{Name: "tags", Usage: "Only notify for expectations with these tags."},
+					&cli.StringSliceFlag{Name: "paths", Usage: "Only notify for matching paths (e.g. /users/*, /orders/**)."},
+				},
+				Action: func(c *cli.Context) error {
+					return consumersCommand(c)
+				},
+			},
+			{
+				Name:  "match",
+				Usage: "Explain which expectation a request matches, matcher by matcher",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "project", Usage: "Project name (expectations loaded from cloud storage)."},
+					&cli.StringFlag{Name: "file", Usage: "Local MockServer expectations JSON instead of the project store."},
+					&cli.StringFlag{Name: "request", Usage: "JSON request example (method, path, query, headers, body); flags override its fields."},
+					&cli.StringFlag{Name: "method", Usage: "HTTP method (default GET)."},
+					&cli.StringFlag{Name: "path", Usage: "Request path, optionally with a query string (required without --request)."},
+					&cli.StringSliceFlag{Name: "header", Usage: "Request header 'Name: value' (repeatable)."},
+					&cli.StringSliceFlag{Name: "query", Usage: "Query parameter name=value (repeatable)."},
+					&cli.StringFlag{Name: "body", Usage: "Request body, or @file to read it from disk."},
+					&cli.IntFlag{Name: "top", Usage: "Closest non-matching candidates to trace.", Value: 3},
+				},
+				Action: func(c *cli.Context) error {
+					return matchCommand(c)
+				},
+			},
+			{
+				Name:  "diff",
+				Usage: "Show what changed between two saved versions of a project's expectations",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "project", Usage: "Project name.", Required: true},
+					&cli.StringFlag{Name: "from", Usage: "Older version, 'current' or 'deployed' (default: the version saved before --to)."},
+					&cli.StringFlag{Name: "to", Usage: "Newer version, 'current' or 'deployed'.", Value: "current"},
+				},
+				Action: func(c *cli.Context) error {
+					return diffCommand(c)
+				},
+			},
+			{
+				Name:  "rollback",
+				Usage: "Restore a previous version of a project's expectations as the current one",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "project", Usage: "Project name.", Required: true},
+					&cli.StringFlag{Name: "version", Usage: "Version to restore, e.g. v1712345678 (default: pick from the stored versions)."},
+					&cli.BoolFlag{Name: "deploy", Usage: "Redeploy the mocks with the restored version."},
+					&cli.BoolFlag{Name: "allow-breaking", Usage: "Redeploy even if the restored version breaks the deployed contract."},
+				},
+				Action: func(c *cli.Context) error {
+					return rollbackCommand(c)
+				},
+			},
+			{
+				Name:  "validate",
+				Usage: "Check expectations for invalid regexes, malformed bodies and conflicting matchers",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "project", Usage: "Project name (expectations loaded from cloud storage)."},
+					&cli.StringFlag{Name: "file", Usage: "Local MockServer expectations JSON instead of the project store."},
+					&cli.BoolFlag{Name: "strict", Usage: "Exit non-zero on warnings too."},
+					&cli.BoolFlag{Name: "conflicts", Usage: "Also report overlapping matchers where the more specific expectation loses, with the priorities that fix them."},
+					&cli.BoolFlag{Name: "fix", Usage: "Save the proposed priorities to the project (implies --conflicts)."},
+				},
+				Action: func(c *cli.Context) error {
+					return validateCommand(c)
+				},
+			},
+			{
+				Name:  "serve",
+				Usage: "Run a project's expectations on a local mock server (no cloud or Java needed)",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "project", Usage: "Project name (expectations loaded from cloud storage)."},
+					&cli.StringFlag{Name: "file", Usage: "Local MockServer expectations JSON instead of the project store."},
+					&cli.StringFlag{Name: "dir", Usage: "Directory of split expectation files ('download --split') instead of the project store."},
+					&cli.IntFlag{Name: "port", Usage: "Port to listen on.", Value: 1080},
+					&cli.StringFlag{Name: "host", Usage: "Interface to bind (default: all)."},
+					&cli.StringSliceFlag{Name: "proto", Usage: "Proto file whose methods are also answered as native gRPC (h2c) on the same port (repeatable)."},
+					&cli.BoolFlag{Name: "verbose", Usage: "Log the closest expectations and the checks they failed for each unmatched request."},
+				},
+				Action: func(c *cli.Context) error {
+					return serveCommand(c)
+				},
+			},
+			{
+				Name:  "docker",
+				Usage: "Write a Docker Compose bundle that runs a project's mocks with 'docker compose up'",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "project", Usage: "Project name."},
+					&cli.StringFlag{Name: "out", Usage: "Bundle directory (default: automock-<project>)."},
+					&cli.IntFlag{Name: "port", Usage: "Host port the mocks are published on.", Value: 1080},
+					&cli.StringFlag{Name: "image", Usage: "MockServer image, e.g. mirrored in an internal registry.", Value: compose.DefaultImage},
+					&cli.StringFlag{Name: "env", Usage: "Bundle the expectations of this environment overlay (e.g. dev)."},
+					&cli.StringFlag{Name: "mask", Usage: "Masking profile applied to response bodies (demo, redact, or a project profile)."},
+					&cli.StringSliceFlag{Name: "only-tags", Usage: "Bundle only expectations with any of these tags."},
+					&cli.StringSliceFlag{Name: "exclude-tags", Usage: "Leave out expectations with any of these tags."},
+					&cli.BoolFlag{Name: "force", Usage: "Overwrite an existing bundle in the directory."},
+				},
+				Action: func(c *cli.Context) error {
+					return dockerCommand(c)
+				},
+			},
+			{
+				Name:  "record",
+				Usage: "Proxy live traffic to a real backend and save the recorded exchanges as expectations",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "project", Usage: "Project the recordings are saved to (created if missing).", Required: true},
+					&cli.StringFlag{Name: "target", Usage: "Real backend base URL, e.g. https://api.example.com.", Required: true},
+					&cli.IntFlag{Name: "port", Usage: "Port the proxy listens on.", Value: 8080},
+					&cli.StringFlag{Name: "host", Usage: "Interface to bind (default: all)."},
+					&cli.StringFlag{Name: "ttl", Usage: "How long recorded responses stay fresh, e.g. 12h or 7d (default: never expire)."},
+				},
+				Action: func(c *cli.Context) error {
+					return recordCommand(c)
+				},
+			},
+			{
+				Name:  "refresh",
+				Usage: "Re-execute recorded requests against their upstream and update stale responses",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "project", Usage: "Project whose recordings are refreshed.", Required: true},
+					&cli.BoolFlag{Name: "all", Usage: "Refresh every recorded expectation, not only those past their TTL."},
+					&cli.StringSliceFlag{Name: "header", Usage: "Header added to each request, e.g. \"Authorization: Bearer ...\" (repeatable)."},
+					&cli.BoolFlag{Name: "dry-run", Usage: "Report upstream changes without saving; exits non-zero if any response changed."},
+				},
+				Action: func(c *cli.Context) error {
+					return refreshCommand(c)
+				},
+			},
+			{
+				Name:  "demo",
+				Usage: "Guided tour on a sample project: import a collection, review, serve locally and test",
+				Flags: []cli.Flag{
+					&cli.IntFlag{Name: "port", Usage: "Port for the local mock (default: any free port)."},
+					&cli.BoolFlag{Name: "keep", Usage: "Keep the demo collection and expectations instead of removing them."},
+				},
+				Action: func(c *cli.Context) error {
+					return demoCommand(c)
+				},
+			},
+			{
+				Name:  "test",
+				Usage: "Verify the mock satisfies the test assertions captured from the collection",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "project", Usage: "Project whose checks are run.", Required: true},
+					&cli.StringFlag{Name: "url", Usage: "Mock base URL instead of the project's deployment (e.g. http://localhost:1080)."},
+				},
+				Action: func(c *cli.Context) error {
+					return testCommand(c)
+				},
+			},
+			{
+				Name:  "verify",
+				Usage: "Replay each expectation against a real API and report contract mismatches",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "project", Usage: "Project whose expectations are verified.", Required: true},
+					&cli.StringFlag{Name: "base-url", Usage: "Real environment to verify, e.g. https://staging.api.example.com.", Required: true},
+					&cli.StringSliceFlag{Name: "header", Usage: "Header added to each request, e.g. \"Authorization: Bearer ...\" (repeatable)."},
+					&cli.BoolFlag{Name: "safe-only", Usage: "Replay only GET, HEAD and OPTIONS requests."},
+					&cli.StringFlag{Name: "junit", Usage: "Write a JUnit XML report to this file."},
+					&cli.StringSliceFlag{Name: "only-tags", Usage: "Verify only expectations with any of these tags."},
+					&cli.StringSliceFlag{Name: "exclude-tags", Usage: "Skip expectations with any of these tags."},
+				},
+				Action: func(c *cli.Context) error {
+					return verifyCommand(c)
+				},
+			},
+			{
+				Name:  "coverage",
+				Usage: "Report which operations of an OpenAPI spec or collection the expectations cover",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "project", Usage: "Project whose expectations are compared.", Required: true},
+					&cli.StringFlag{Name: "spec", Usage: "OpenAPI/Swagger spec or Postman/Bruno/Insomnia collection.", Required: true},
+					&cli.StringFlag{Name: "spec-type", Usage: "Type of --spec (openapi, postman, bruno, insomnia); detected when omitted."},
+					&cli.StringFlag{Name: "json", Usage: "Write a JSON report to this file."},
+					&cli.StringFlag{Name: "html", Usage: "Write an HTML report to this file."},
+					&cli.Float64Flag{Name: "min-coverage", Usage: "Exit with an error when fewer than this percentage of operations are covered."},
+				},
+				Action: func(c *cli.Context) error {
+					return coverageCommand(c)
+				},
+			},
+			{
+				Name:  "download",
+				Usage: "Download a project's expectations to disk",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "project", Usage: "Project name.", Required: true},
+					&cli.BoolFlag{Name: "split", Usage: "Write one file per expectation (e.g. expectations/GET_users.json)."},
+					&cli.StringFlag{Name: "format", Usage: "Output format: mockserver or wiremock (WireMock stub mappings).", Value: "mockserver"},
+					&cli.StringFlag{Name: "out", Usage: "Output file, or directory with --split (existing .json files there are replaced)."},
+					&cli.StringFlag{Name: "env", Usage: "Download the expectations of this environment overlay (e.g. stage)."},
+					&cli.StringFlag{Name: "mask", Usage: "Masking profile applied to response bodies (demo, redact, or a project profile)."},
+					&cli.StringSliceFlag{Name: "only-tags", Usage: "Download only expectations with any of these tags."},
+					&cli.StringSliceFlag{Name: "exclude-tags", Usage: "Leave out expectations with any of these tags."},
+				},
+				Action: func(c *cli.Context) error {
+					return downloadCommand(c)
+				},
+			},
+			{
+				Name:  "generate-fixtures",
+				Usage: "Write the expectations as test fixtures: a Go httptest handler, MSW handlers or a Python responses module",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "project", Usage: "Project name.", Required: true},
+					&cli.StringFlag{Name: "lang", Usage: "Fixture language: go, ts (Mock Service Worker) or python (responses + pytest).", Required: true},
+					&cli.StringFlag{Name: "out", Usage: "Output file (default: fixtures.go, handlers.js or <project>_fixtures.py)."},
+					&cli.StringFlag{Name: "package", Usage: "Go package name of the generated file.", Value: "fixtures"},
+					&cli.StringFlag{Name: "env", Usage: "Convert the expectations of this environment overlay (e.g. stage)."},
+					&cli.StringFlag{Name: "mask", Usage: "Masking profile applied to response bodies (demo, redact, or a project profile)."},
+					&cli.StringSliceFlag{Name: "only-tags", Usage: "Convert only expectations with any of these tags."},
+					&cli.StringSliceFlag{Name: "exclude-tags", Usage: "Leave out expectations with any of these tags."},
+				},
+				Action: func(c *cli.Context) error {
+					return generateFixturesCommand(c)
+				},
+			},
+			{
+				Name:  "watch",
+				Usage: "Sync a split expectations directory to the project on every change",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "project", Usage: "Project name.", Required: true},
+					&cli.StringFlag{Name: "dir", Usage: "Directory of per-expectation JSON files.", Value: "expectations"},
+					&cli.DurationFlag{Name: "interval", Usage: "Polling interval.", Value: 2 * time.Second},
+				},
+				Action: func(c *cli.Context) error {
+					return watchCommand(c)
+				},
+			},
+			{
+				Name:  "gc",
+				Usage: "Prune old expectation versions and load-test bundles",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "project", Usage: "Project name.", Required: true},
+					&cli.IntFlag{Name: "keep-last", Usage: "Keep the newest N versions of each kind."},
+					&cli.DurationFlag{Name: "older-than", Usage: "Only prune versions older than this (e.g. 720h)."},
+					&cli.BoolFlag{Name: "dry-run", Usage: "List what would be pruned without deleting anything."},
+				},
+				Action: func(c *cli.Context) error {
+					return gcCommand(c)
+				},
+			},
+			{
+				Name:  "export",
+				Usage: "Export a whole project to a tar.gz/zip archive, or artifacts for consumers (export manifest)",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "project", Usage: "Project to export."},
+					&cli.StringFlag{Name: "out", Usage: "Archive file, .tar.gz or .zip (default <project>.automock.tar.gz)."},
+					&cli.BoolFlag{Name: "skip-versions", Usage: "Export only the current configuration, not the saved versions."},
+					&cli.BoolFlag{Name: "skip-load-test", Usage: "Leave out the active load-test bundle."},
+					&cli.StringFlag{Name: "format", Usage: "archive (the whole project) or pact (a Pact v3 contract of the expectations).", Value: "archive"},
+					&cli.StringFlag{Name: "consumer", Usage: "Pact consumer name (default <project>-consumer)."},
+					&cli.StringFlag{Name: "provider", Usage: "Pact provider name (default the project)."},
+					&cli.StringSliceFlag{Name: "only-tags", Usage: "Pact: export only expectations with any of these tags."},
+					&cli.StringSliceFlag{Name: "exclude-tags", Usage: "Pact: leave out expectations with any of these tags."},
+				},
+				Action: func(c *cli.Context) error {
+					return exportProjectCommand(c)
+				},
+				Subcommands: []*cli.Command{
+					{
+						Name:  "manifest",
+						Usage: "Write a Markdown summary of endpoints, auth, base URL, error envelopes and versions",
+						Flags: []cli.Flag{
+							&cli.StringFlag{Name: "project", Usage: "Project name.", Required: true},
+							&cli.StringFlag{Name: "out", Usage: "Output file.", Value: "MOCK_API.md"},
+						},
+						Action: func(c *cli.Context) error {
+							return exportManifestCommand(c)
+						},
+					},
+				},
+			},
+			{
+				Name:  "import",
+				Usage: "Restore a project archive written by export into this account and region",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "file", Usage: "Archive written by 'automock export'.", Required: true},
+					&cli.StringFlag{Name: "project", Usage: "Import under this name (default: the exported project's name)."},
+					&cli.BoolFlag{Name: "force", Usage: "Replace the current configuration of an existing project."},
+					&cli.BoolFlag{Name: "skip-versions", Usage: "Import only the current configuration, not the saved versions."},
+					&cli.BoolFlag{Name: "skip-load-test", Usage: "Do not upload the archived load-test bundle."},
+				},
+				Action: func(c *cli.Context) error {
+					return importProjectCommand(c)
+				},
+			},
+			{
+				Name:  "push",
+				Usage: "Copy a project from the local store (or the git store, with --cloud git) to a cloud account",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "project", Usage: "Local project to push."},
+					&cli.StringFlag{Name: "to", Usage: "Target cloud: aws or azure (default: auto-detect from available credentials)."},
+					&cli.BoolFlag{Name: "force", Usage: "Replace the current configuration of an existing cloud project."},
+					&cli.BoolFlag{Name: "skip-versions", Usage: "Push only the current configuration, not the saved versions."},
+					&cli.BoolFlag{Name: "skip-load-test", Usage: "Do not upload the local load-test bundle."},
+				},
+				Action: func(c *cli.Context) error {
+					return pushProjectCommand(c)
+				},
+			},
+			{
+				Name:  "logs",
+				Usage: "Show or follow the requests the deployed mock received, or export them",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "project", Usage: "Project whose deployed mock is read; its expectations explain unmatched requests."},
+					&cli.StringFlag{Name: "url", Usage: "Mock base URL instead of the project's deployment (e.g. http://localhost:1080)."},
+					&cli.BoolFlag{Name: "tail", Usage: "Keep following the log and print new requests until Ctrl+C."},
+					&cli.StringFlag{Name: "path", Usage: "Only requests for this path (* matches any characters, e.g. /users/*)."},
+					&cli.StringFlag{Name: "method", Usage: "Only requests with this method."},
+					&cli.StringFlag{Name: "status", Usage: "Only responses with this status code (404), class (4xx), or unmatched requests (unmatched)."},
+					&cli.IntFlag{Name: "limit", Usage: "Most recent matching requests shown before following.", Value: 20},
+					&cli.DurationFlag{Name: "interval", Usage: "Time between polls with --tail.", Value: 2 * time.Second},
+				},
+				Action: func(c *cli.Context) error {
+					return logsCommand(c)
+				},
+				Subcommands: []*cli.Command{
+					{
+						Name:  "export",
+						Usage: "Export received requests and responses (HAR for devtools/Charles)",
+						Flags: []cli.Flag{
+							&cli.StringFlag{Name: "project", Usage: "Project whose deployed mock is read."},
+							&cli.StringFlag{Name: "url", Usage: "Mock base URL instead of the project's deployment (e.g. http://localhost:1080)."},
+							&cli.StringFlag{Name: "format", Usage: "Export format.", Value: "har"},
+							&cli.StringFlag{Name: "out", Usage: "Output file (default <project>-traffic.har)."},
+						},
+						Action: func(c *cli.Context) error {
+							return logsExportCommand(c)
+						},
+					},
+					{
+						Name:  "analyze",
+						Usage: "Cluster the requests the mock could not answer and add expectations for them",
+						Flags: []cli.Flag{
+							&cli.StringFlag{Name: "project", Usage: "Project whose deployed mock is read and that the expectations are added to.", Required: true},
+							&cli.StringFlag{Name: "url", Usage: "Mock base URL instead of the project's deployment (e.g. http://localhost:1080)."},
+							&cli.BoolFlag{Name: "ai", Usage: "Have an AI provider write the response bodies no existing expectation can lend."},
+							&cli.StringFlag{Name: "provider", Usage: "AI provider for --ai (default: the configured one)."},
+							&cli.BoolFlag{Name: "yes", Usage: "Add every proposed expectation without asking."},
+						},
+						Action: func(c *cli.Context) error {
+							return logsAnalyzeCommand(c)
+						},
+					},
+				},
+			},
+			{
+				Name:  "config",
+				Usage: "Manage the project configuration file (automock.yaml)",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "init",
+						Usage: "Scaffold an annotated automock.yaml in the current directory (or AUTOMOCK_CONFIG)",
+						Flags: []cli.Flag{
+							&cli.StringFlag{Name: "project", Usage: "Project name written to the file (default: the directory name)."},
+							&cli.BoolFlag{Name: "force", Usage: "Overwrite an existing file."},
+						},
+						Action: func(c *cli.Context) error {
+							return configInitCommand(c)
+						},
+					},
+				},
+			},
+			{
+				Name:  "debug-bundle",
+				Usage: "Write a redacted diagnostic bundle for support tickets",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "project", Usage: "Include a manifest of this project's state store."},
+					&cli.StringFlag{Name: "out", Usage: "Output directory.", Value: "."},
+				},
+				Action: func(c *cli.Context) error {
+					return debugBundleCommand(c)
+				},
 			},
 			{
 				Name:  "help",
@@ -154,7 +882,57 @@ func main() {
 	}
 
 	if err := app.Run(os.Args); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(reportError(err))
+	}
+}
+
+// errorFormat and failedCommand shape the failure report; set from the global flags
+var (
+	errorFormat   = os.Getenv("AUTOMOCK_ERROR_FORMAT")
+	failedCommand string
+)
+
+// reportError writes the failure to stderr and returns the exit code for its kind.
+// Only unexpected and deployment failures get a diagnostic bundle.
+func reportError(err error) int {
+	if strings.HasPrefix(err.Error(), "Required flag") || strings.HasPrefix(err.Error(), "flag provided but not defined") {
+		err = exitcode.Wrap(exitcode.Config, err)
+	}
+	report := exitcode.NewReport(err, failedCommand)
+	bundle := report.Error.Kind == exitcode.Internal || report.Error.Kind == exitcode.Deploy
+	if errorFormat == "json" {
+		if bundle {
+			report.Error.Diagnostics = writeDiagnostics(err, "")
+		}
+		_ = report.WriteJSON(os.Stderr)
+		return report.Error.ExitCode
+	}
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	if report.Error.Hint != "" {
+		fmt.Fprintf(os.Stderr, "💡 %s\n", report.Error.Hint)
+	}
+	if bundle {
+		reportDiagnostics(err, "")
+	}
+	return report.Error.ExitCode
+}
+
+// reportDiagnostics writes a crash bundle and tells the user how to share it
+func reportDiagnostics(cause error, stack string) {
+	path, err := diagnostics.New(version, cause, stack).Write("")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Could not write diagnostic bundle: %v\n", err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "🧰 Diagnostic bundle (secrets redacted) written to %s\n", path)
+	fmt.Fprintln(os.Stderr, "   Attach it when reporting this issue; run 'automock debug-bundle --project <name>' to include store details.")
+}
+
+// writeDiagnostics writes a crash bundle quietly and returns its path ("" if it failed)
+func writeDiagnostics(cause error, stack string) string {
+	path, err := diagnostics.New(version, cause, stack).Write("")
+	if err != nil {
+		return ""
 	}
+	return path
 }
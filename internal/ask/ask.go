@@ -0,0 +1,324 @@
+// Package ask wraps survey prompts so the CLI can run unattended: answers come from an
+// answers file first, then (in non-interactive mode) from prompt defaults, and a prompt
+// that cannot be answered fails fast instead of waiting for a terminal.
+package ask
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNoAnswer is returned in non-interactive mode for a prompt with no usable answer
+var ErrNoAnswer = errors.New("no answer available in non-interactive mode")
+
+// maxRepeats stops a prompt loop that keeps getting the same unattended answer
+const maxRepeats = 50
+
+var (
+	mu             sync.Mutex
+	nonInteractive bool
+	answers        = map[string]interface{}{}
+	asked          = map[string]int{}
+)
+
+// SetNonInteractive turns prompting off; unanswered prompts use their default or fail
+func SetNonInteractive(on bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	nonInteractive = on
+}
+
+// NonInteractive reports whether prompting is off
+func NonInteractive() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return nonInteractive
+}
+
+// LoadAnswers reads a YAML or JSON answers file: a map from prompt message (or question
+// name) to the answer. A list answers successive occurrences of the same prompt (a
+// multi-select takes a list of lists for that).
+func LoadAnswers(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read answers file: %w", err)
+	}
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("invalid answers file %s: %w", path, err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	answers = make(map[string]interface{}, len(raw))
+	for k, v := range raw {
+		answers[normalize(k)] = v
+	}
+	return nil
+}
+
+// One asks a single question, like survey.AskOne
+func One(p survey.Prompt, response interface{}, opts ...survey.AskOpt) error {
+	return Many([]*survey.Question{{Prompt: p}}, response, opts...)
+}
+
+// Many asks a list of questions, like survey.Ask. Questions with an answer in the answers
+// file are not shown; in non-interactive mode none are.
+func Many(qs []*survey.Question, response interface{}, opts ...survey.AskOpt) error {
+	options := survey.AskOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			if err := opt(&options); err != nil {
+				return err
+			}
+		}
+	}
+	for _, q := range qs {
+		ans, source, ok, err := resolve(q)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			if err := survey.Ask([]*survey.Question{q}, response, opts...); err != nil {
+				return err
+			}
+			continue
+		}
+		msg := message(q.Prompt)
+		for _, v := range append([]survey.Validator{q.Validate}, options.Validators...) {
+			if v == nil {
+				continue
+			}
+			if err := v(ans); err != nil {
+				return fmt.Errorf("answer for %q from %s is invalid: %w", msg, source, err)
+			}
+		}
+		if q.Transform != nil {
+			if t := q.Transform(ans); t != nil {
+				ans = t
+			}
+		}
+		fmt.Printf("? %s %s (%s)\n", msg, display(q.Prompt, ans), source)
+		if err := core.WriteAnswer(response, q.Name, ans); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolve finds an unattended answer for q; ok is false when q should be prompted
+func resolve(q *survey.Question) (ans interface{}, source string, ok bool, err error) {
+	msg := message(q.Prompt)
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, key := range []string{normalize(msg), normalize(q.Name)} {
+		if key == "" {
+			continue
+		}
+		raw, found := next(key, q.Prompt)
+		if !found {
+			continue
+		}
+		ans, err := convert(q.Prompt, raw)
+		if err != nil {
+			return nil, "", false, fmt.Errorf("answers file entry for %q: %w", msg, err)
+		}
+		return ans, "answers file", true, nil
+	}
+	if !nonInteractive {
+		return nil, "", false, nil
+	}
+
+	asked[msg]++
+	if asked[msg] > maxRepeats {
+		return nil, "", false, fmt.Errorf("%w: %q was asked %d times; add answers for it to the answers file", ErrNoAnswer, msg, maxRepeats)
+	}
+	ans, ok = defaultAnswer(q.Prompt)
+	if !ok {
+		return nil, "", false, fmt.Errorf("%w: %q has no default; add it to the answers file (--answers)", ErrNoAnswer, msg)
+	}
+	return ans, "default", true, nil
+}
+
+// next takes the answer for key, consuming one element when the entry is a sequence
+func next(key string, p survey.Prompt) (interface{}, bool) {
+	v, ok := answers[key]
+	if !ok {
+		return nil, false
+	}
+	list, isList := v.([]interface{})
+	if _, multi := p.(*survey.MultiSelect); multi && isList && len(list) > 0 {
+		if _, nested := list[0].([]interface{}); !nested {
+			isList = false // a plain list is the selection itself
+		}
+	}
+	if !isList {
+		return v, true
+	}
+	if len(list) == 0 {
+		return nil, false
+	}
+	answers[key] = list[1:]
+	return list[0], true
+}
+
+// convert turns an answers file value into the answer type the prompt produces
+func convert(p survey.Prompt, raw interface{}) (interface{}, error) {
+	switch pt := p.(type) {
+	case *survey.Confirm:
+		switch v := raw.(type) {
+		case bool:
+			return v, nil
+		case string:
+			switch strings.ToLower(strings.TrimSpace(v)) {
+			case "y", "yes", "true":
+				return true, nil
+			case "n", "no", "false":
+				return false, nil
+			}
+		}
+		return nil, fmt.Errorf("expected yes/no, got %v", raw)
+	case *survey.Select:
+		return option(pt.Options, raw)
+	case *survey.MultiSelect:
+		list, ok := raw.([]interface{})
+		if !ok {
+			list = []interface{}{raw}
+		}
+		out := make([]core.OptionAnswer, 0, len(list))
+		for _, item := range list {
+			o, err := option(pt.Options, item)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, o)
+		}
+		return out, nil
+	default:
+		if raw == nil {
+			return "", nil
+		}
+		return fmt.Sprint(raw), nil
+	}
+}
+
+// option matches a value against the options by text (case-insensitive) or by index
+func option(options []string, raw interface{}) (core.OptionAnswer, error) {
+	if i, ok := raw.(int); ok && i >= 0 && i < len(options) {
+		return core.OptionAnswer{Value: options[i], Index: i}, nil
+	}
+	want := strings.TrimSpace(fmt.Sprint(raw))
+	for i, o := range options {
+		if strings.EqualFold(o, want) {
+			return core.OptionAnswer{Value: o, Index: i}, nil
+		}
+	}
+	for i, o := range options { // allow the leading words of a long option
+		if want != "" && strings.HasPrefix(strings.ToLower(o), strings.ToLower(want)) {
+			return core.OptionAnswer{Value: o, Index: i}, nil
+		}
+	}
+	return core.OptionAnswer{}, fmt.Errorf("%q is not one of: %s", want, strings.Join(options, ", "))
+}
+
+// defaultAnswer returns the answer accepting the prompt's default would give
+func defaultAnswer(p survey.Prompt) (interface{}, bool) {
+	switch pt := p.(type) {
+	case *survey.Confirm:
+		return pt.Default, true
+	case *survey.Select:
+		if pt.Default == nil {
+			return nil, false
+		}
+		o, err := option(pt.Options, pt.Default)
+		return o, err == nil
+	case *survey.MultiSelect:
+		var out []core.OptionAnswer
+		switch d := pt.Default.(type) {
+		case []string:
+			for _, v := range d {
+				if o, err := option(pt.Options, v); err == nil {
+					out = append(out, o)
+				}
+			}
+		case []int:
+			for _, i := range d {
+				if o, err := option(pt.Options, i); err == nil {
+					out = append(out, o)
+				}
+			}
+		}
+		return out, true
+	case *survey.Input:
+		return pt.Default, true
+	case *survey.Multiline:
+		return pt.Default, true
+	case *survey.Editor:
+		return pt.Default, true
+	case *survey.Password:
+		return "", true
+	}
+	return nil, false
+}
+
+func message(p survey.Prompt) string {
+	switch pt := p.(type) {
+	case *survey.Confirm:
+		return pt.Message
+	case *survey.Select:
+		return pt.Message
+	case *survey.MultiSelect:
+		return pt.Message
+	case *survey.Input:
+		return pt.Message
+	case *survey.Multiline:
+		return pt.Message
+	case *survey.Editor:
+		return pt.Message
+	case *survey.Password:
+		return pt.Message
+	}
+	return fmt.Sprintf("%T", p)
+}
+
+// display renders an answer for the log line, hiding passwords
+func display(p survey.Prompt, ans interface{}) string {
+	switch v := ans.(type) {
+	case bool:
+		if v {
+			return "Yes"
+		}
+		return "No"
+	case core.OptionAnswer:
+		return v.Value
+	case []core.OptionAnswer:
+		values := make([]string, len(v))
+		for i, o := range v {
+			values[i] = o.Value
+		}
+		return "[" + strings.Join(values, ", ") + "]"
+	}
+	if _, ok := p.(*survey.Password); ok {
+		return "********"
+	}
+	s := fmt.Sprint(ans)
+	if strings.Contains(s, "\n") || len(s) > 80 {
+		return fmt.Sprintf("(%d characters)", len(s))
+	}
+	return strconv.Quote(s)
+}
+
+// normalize makes answer keys forgiving: case, surrounding space and a trailing ":" or "?"
+// do not matter
+func normalize(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.TrimSpace(strings.TrimRight(s, ":?"))
+}
@@ -0,0 +1,109 @@
+package ask
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+func loadTestAnswers(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "answers.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadAnswers(path); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		answers = map[string]interface{}{}
+		asked = map[string]int{}
+		SetNonInteractive(false)
+	})
+}
+
+func TestAnswersFile(t *testing.T) {
+	loadTestAnswers(t, `
+"Select LLM provider:": openai
+Deploy infrastructure now?: yes
+region: eu-west-1
+"Add another header?": [true, true, false]
+Features to enable: [CORS, delays]
+`)
+	SetNonInteractive(true)
+
+	var provider string
+	if err := One(&survey.Select{Message: "Select LLM provider:", Options: []string{"anthropic", "openai (GPT-4)", "template"}}, &provider); err != nil {
+		t.Fatal(err)
+	}
+	if provider != "openai (GPT-4)" {
+		t.Errorf("provider = %q", provider)
+	}
+
+	var deploy bool
+	if err := One(&survey.Confirm{Message: "deploy infrastructure now"}, &deploy); err != nil || !deploy {
+		t.Errorf("deploy = %v, err = %v", deploy, err)
+	}
+
+	resp := struct{ Region string }{}
+	if err := Many([]*survey.Question{{Name: "region", Prompt: &survey.Input{Message: "AWS region:"}}}, &resp); err != nil || resp.Region != "eu-west-1" {
+		t.Errorf("region = %q, err = %v", resp.Region, err)
+	}
+
+	var more []bool
+	for {
+		var again bool
+		if err := One(&survey.Confirm{Message: "Add another header?"}, &again); err != nil {
+			t.Fatal(err)
+		}
+		more = append(more, again)
+		if !again {
+			break
+		}
+	}
+	if len(more) != 3 {
+		t.Errorf("header loop ran %d times, want 3", len(more))
+	}
+
+	var features []string
+	if err := One(&survey.MultiSelect{Message: "Features to enable:", Options: []string{"CORS", "Delays", "Caching"}}, &features); err != nil {
+		t.Fatal(err)
+	}
+	if len(features) != 2 || features[1] != "Delays" {
+		t.Errorf("features = %v", features)
+	}
+}
+
+func TestNonInteractiveDefaults(t *testing.T) {
+	loadTestAnswers(t, "{}")
+	SetNonInteractive(true)
+
+	var mode string
+	if err := One(&survey.Select{Message: "Mode:", Options: []string{"fast", "safe"}, Default: "safe"}, &mode); err != nil || mode != "safe" {
+		t.Errorf("mode = %q, err = %v", mode, err)
+	}
+
+	var name string
+	err := One(&survey.Input{Message: "Project name:"}, &name, survey.WithValidator(survey.Required))
+	if err == nil {
+		t.Error("expected a required input without an answer to fail")
+	}
+
+	var kind string
+	err = One(&survey.Select{Message: "Kind:", Options: []string{"REST", "GraphQL"}}, &kind)
+	if !errors.Is(err, ErrNoAnswer) {
+		t.Errorf("err = %v, want ErrNoAnswer for a select without default", err)
+	}
+}
+
+func TestInvalidAnswer(t *testing.T) {
+	loadTestAnswers(t, `"Kind:": SOAP`)
+
+	var kind string
+	if err := One(&survey.Select{Message: "Kind:", Options: []string{"REST", "GraphQL"}}, &kind); err == nil {
+		t.Error("expected an answer outside the options to fail")
+	}
+}
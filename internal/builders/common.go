@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
 	"github.com/hemantobora/auto-mock/internal/models"
 )
 
@@ -266,9 +267,12 @@ func ReviewGraphQLExpectation(exp *MockExpectation) error {
 		fmt.Printf("   Transport: POST (application/json)\n")
 		fmt.Printf("   Body match mode: %s, Variables: %v\n", bodyMode, hasVars)
 	}
+	if g := exp.GraphQLErrors; g != nil && len(g.Variants) > 0 {
+		fmt.Printf("   Error variants: %s (select with %s)\n", strings.Join(g.Variants, ", "), g.Selector("<variant>"))
+	}
 
 	var confirm bool
-	if err := survey.AskOne(&survey.Confirm{
+	if err := ask.One(&survey.Confirm{
 		Message: "Create this GraphQL expectation?",
 		Default: true,
 	}, &confirm); err != nil {
@@ -355,6 +359,14 @@ func ExtendExpectationsForProgressive(expectations []MockExpectation) []MockExpe
 	return expectations
 }
 
+// errorTaxonomy is the project's error envelope; when set, error templates are rendered from it
+var errorTaxonomy *models.ErrorTaxonomy
+
+// SetErrorTaxonomy makes error-response templates use the project's error envelope and codes
+func SetErrorTaxonomy(t *models.ErrorTaxonomy) {
+	errorTaxonomy = t
+}
+
 // GenerateResponseTemplate generates enhanced response templates
 func GenerateResponseTemplate(expectation *MockExpectation) error {
 	fmt.Println("\n🏷️  Enhanced Response Template Generation")
@@ -362,7 +374,7 @@ func GenerateResponseTemplate(expectation *MockExpectation) error {
 
 	// Show template options
 	var templateType string
-	if err := survey.AskOne(&survey.Select{
+	if err := ask.One(&survey.Select{
 		Message: "Select template type:",
 		Options: []string{
 			"smart - Auto-generate based on method & status",
@@ -370,6 +382,7 @@ func GenerateResponseTemplate(expectation *MockExpectation) error {
 			"microservice - Microservice response",
 			"error-response - Comprehensive error response",
 			"minimal - Minimal response",
+			"schema - Example generated from a JSON Schema",
 			"custom - Custom template",
 		},
 		Default: "smart - Auto-generate based on method & status",
@@ -379,6 +392,19 @@ func GenerateResponseTemplate(expectation *MockExpectation) error {
 
 	templateType = strings.Split(templateType, " ")[0]
 
+	// Error responses follow the project's error taxonomy when one is defined
+	status := expectation.HttpResponse.StatusCode
+	if errorTaxonomy != nil && status >= 400 && (templateType == "smart" || templateType == "error-response") {
+		handled, err := useTaxonomyErrorBody(expectation, status)
+		if handled || err != nil {
+			return err
+		}
+	}
+
+	if templateType == "schema" {
+		return ResponseBodyFromSchema(expectation)
+	}
+
 	// Generate template based on selection
 	var template string
 	switch templateType {
@@ -407,10 +433,12 @@ func GenerateResponseTemplate(expectation *MockExpectation) error {
 	}
 
 	if template != "" {
-		fmt.Printf("💡 Generated %s template:\n%s\n\n", templateType, template)
+		fmt.Printf("💡 Generated %s template:\n%s\n", templateType, template)
+		fmt.Print("   $!uuid, $!now_epoch and $!rand_* are filled with generated values on save;\n")
+		fmt.Print("   $!request.* placeholders echo the request when the mock is served\n\n")
 
 		var useTemplate bool
-		if err := survey.AskOne(&survey.Confirm{
+		if err := ask.One(&survey.Confirm{
 			Message: "Use this generated template?",
 			Default: true,
 		}, &useTemplate); err != nil {
@@ -425,9 +453,9 @@ func GenerateResponseTemplate(expectation *MockExpectation) error {
 
 	// Manual entry for custom or if user declined generated template
 	var manualJSON string
-	if err := survey.AskOne(&survey.Multiline{
+	if err := ask.One(&survey.Multiline{
 		Message: "Enter response JSON manually:",
-		Help:    "Use $!template.variables for dynamic content",
+		Help:    "Use ${uuid}, ${name}, ${datetime} and other fake-data placeholders for generated values, and ${request.path.id}, ${request.query.page}, ${request.headers.X-Request-Id} or ${request.body.field} to echo the request",
 	}, &manualJSON); err != nil {
 		return err
 	}
@@ -435,6 +463,62 @@ func GenerateResponseTemplate(expectation *MockExpectation) error {
 	return nil
 }
 
+// useTaxonomyErrorBody offers the taxonomy error body for status; it reports whether the body was set
+func useTaxonomyErrorBody(expectation *MockExpectation, status int) (bool, error) {
+	body, err := errorTaxonomy.Body(status)
+	if err != nil {
+		return false, err
+	}
+	preview, _ := json.Marshal(body.(map[string]any)["json"])
+	fmt.Printf("💡 Project error envelope for %d:\n%s\n\n", status, preview)
+
+	var use bool
+	if err := ask.One(&survey.Confirm{
+		Message: "Use the project error envelope?",
+		Default: true,
+	}, &use); err != nil {
+		return false, err
+	}
+	if use {
+		expectation.HttpResponse.Body = body
+	}
+	return use, nil
+}
+
+// ResponseBodyFromSchema asks for a JSON Schema and uses an example generated from it as the
+// response body
+func ResponseBodyFromSchema(expectation *MockExpectation) error {
+	var raw string
+	if err := ask.One(&survey.Multiline{
+		Message: "Paste the JSON Schema of the response body:",
+		Help:    "Types, formats (email, date-time, uuid, ...), enums, bounds and minItems/maxItems shape the example; local $refs such as #/$defs/User and project schemas such as schemas/User are resolved",
+	}, &raw); err != nil {
+		return err
+	}
+	var schema any
+	if err := json.Unmarshal([]byte(strings.TrimSpace(raw)), &schema); err != nil {
+		return &models.JSONValidationError{Context: "JSON Schema", Content: raw, Cause: err}
+	}
+	example, err := ExampleFromSchema(schema)
+	if err != nil {
+		return err
+	}
+	preview, _ := json.MarshalIndent(example, "", "  ")
+	fmt.Printf("💡 Generated example:\n%s\n\n", preview)
+
+	var use bool
+	if err := ask.One(&survey.Confirm{Message: "Use this example as the response body?", Default: true}, &use); err != nil {
+		return err
+	}
+	if !use {
+		fmt.Println("ℹ️  Response body left unchanged")
+		return nil
+	}
+	expectation.HttpResponse.Body = map[string]any{"type": "JSON", "json": example}
+	fmt.Println("✅ Response body generated from the schema")
+	return nil
+}
+
 var gqlOpRegex = regexp.MustCompile(`(?m)^\s*(query|mutation|subscription)\s+([_A-Za-z][_0-9A-Za-z]*)`)
 
 // ExtractGraphQLOperationName returns operation type (query/mutation/subscription)
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
 	"github.com/hemantobora/auto-mock/internal/models"
 )
 
@@ -25,7 +26,7 @@ func applyCaching() FeatureFunc {
 
 		// Cache-Control
 		var cc string
-		if err := survey.AskOne(&survey.Select{
+		if err := ask.One(&survey.Select{
 			Message: "Cache policy:",
 			Options: []string{
 				"no-store",
@@ -50,7 +51,7 @@ func applyCaching() FeatureFunc {
 			SetNameValues(&exp.HttpResponse.Headers, "Cache-Control", []string{"public, max-age=300"})
 		case "custom":
 			var custom string
-			if err := survey.AskOne(&survey.Input{
+			if err := ask.One(&survey.Input{
 				Message: "Enter Cache-Control value:",
 				Default: "public, max-age=120",
 			}, &custom, survey.WithValidator(survey.Required)); err != nil {
@@ -61,7 +62,7 @@ func applyCaching() FeatureFunc {
 
 		// ETag
 		var addETag bool
-		if err := survey.AskOne(&survey.Confirm{
+		if err := ask.One(&survey.Confirm{
 			Message: "Generate ETag from response body?",
 			Default: true,
 		}, &addETag); err != nil {
@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
 	"github.com/hemantobora/auto-mock/internal/models"
 )
 
@@ -214,7 +215,7 @@ func applyCompression() FeatureFunc {
 
 		// 1) pick algorithm
 		var algoStr string
-		if err := survey.AskOne(&survey.Select{
+		if err := ask.One(&survey.Select{
 			Message: "Compression algorithm:",
 			Options: []string{"identity", "gzip", "deflate"},
 			Default: "gzip",
@@ -225,7 +226,7 @@ func applyCompression() FeatureFunc {
 
 		// 2) pick mode
 		var modeStr string
-		if err := survey.AskOne(&survey.Select{
+		if err := ask.One(&survey.Select{
 			Message: "Mode:",
 			Options: []string{
 				"headers-only  — set Content-Encoding/Vary, do NOT alter body",
@@ -0,0 +1,43 @@
+package builders
+
+import (
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// applyConditionalGet adds an ETag and a 304 answer for revalidating clients
+func applyConditionalGet() FeatureFunc {
+	return ConfigureConditionalGet
+}
+
+// ConfigureConditionalGet toggles ETag / If-None-Match support on a GET expectation
+func ConfigureConditionalGet(exp *MockExpectation) error {
+	fmt.Println("\n🏷️  Conditional GET (ETag / 304)")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("💡 The response gets an ETag computed from its body on every save")
+	fmt.Println("   Requests sending that ETag in If-None-Match get 304 Not Modified")
+
+	if !models.ConditionalGetEligible(*exp) {
+		fmt.Println("⚠️  Only GET expectations with a static 2xx body can be conditional.")
+		return nil
+	}
+	enabled := exp.ConditionalGet
+	if err := ask.One(&survey.Confirm{
+		Message: "Answer If-None-Match revalidations with 304?",
+		Default: true,
+	}, &enabled); err != nil {
+		return err
+	}
+	exp.ConditionalGet = enabled
+	if !enabled {
+		fmt.Println("✅ Conditional GET off")
+		return nil
+	}
+	etag := models.ETag(exp.HttpResponse.Body)
+	fmt.Printf("✅ Conditional GET on: ETag %s (changes with the body)\n", etag)
+	fmt.Printf("   curl -H 'If-None-Match: %s' ... → 304\n", etag)
+	return nil
+}
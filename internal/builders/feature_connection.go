@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
 )
 
 // suppressConnection returns a FeatureFunc for drop connection configuration
@@ -31,7 +32,7 @@ func applyChunked() FeatureFunc {
 		fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
 		var useChunked string
-		if err := survey.AskOne(&survey.Input{
+		if err := ask.One(&survey.Input{
 			Message: "Enable chunked transfer encoding? With chunk size in bytes (e.g., 50 for 50 bytes):",
 			Default: "50",
 			Help:    "Send response in chunks (Transfer-Encoding: chunked)",
@@ -69,7 +70,7 @@ func applyKeepAlive() FeatureFunc {
 		fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
 		var useKeepAlive bool
-		if err := survey.AskOne(&survey.Confirm{
+		if err := ask.One(&survey.Confirm{
 			Message: "Override connection keep-alive?",
 			Default: true,
 			Help:    "Reuse HTTP connection for multiple requests",
@@ -101,7 +102,7 @@ func closeSocket() FeatureFunc {
 		fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
 		var shouldClose bool
-		if err := survey.AskOne(&survey.Confirm{
+		if err := ask.One(&survey.Confirm{
 			Message: "Close socket after response?",
 			Default: false,
 			Help:    "Forcefully close the connection after sending response",
@@ -111,7 +112,7 @@ func closeSocket() FeatureFunc {
 
 		if shouldClose {
 			var shouldDelay bool
-			if err := survey.AskOne(&survey.Confirm{
+			if err := ask.One(&survey.Confirm{
 				Message: "Would you like to delay closing the socket?",
 				Default: false,
 				Help:    "Introduce a delay before forcefully closing the connection",
@@ -125,7 +126,7 @@ func closeSocket() FeatureFunc {
 			expectation.HttpResponse.ConnectionOptions.CloseSocket = true
 			if shouldDelay {
 				var fixedStr string
-				if err := survey.AskOne(&survey.Input{
+				if err := ask.One(&survey.Input{
 					Message: "Delay in milliseconds (e.g., 500):",
 					Default: "500",
 				}, &fixedStr, survey.WithValidator(survey.Required)); err != nil {
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
 	"github.com/hemantobora/auto-mock/internal/models"
 )
 
@@ -18,7 +19,7 @@ func applyDelays() func(exp *MockExpectation) error {
 		ensureNameValues(exp)
 
 		var mode string
-		if err := survey.AskOne(&survey.Select{
+		if err := ask.One(&survey.Select{
 			Message: "Select delay mode:",
 			Options: []string{
 				"fixed - single delay (ms)",
@@ -33,7 +34,7 @@ func applyDelays() func(exp *MockExpectation) error {
 		switch {
 		case strings.HasPrefix(mode, "fixed"):
 			var fixedStr string
-			if err := survey.AskOne(&survey.Input{
+			if err := ask.One(&survey.Input{
 				Message: "Delay in milliseconds (e.g., 500):",
 				Default: "500",
 			}, &fixedStr, survey.WithValidator(survey.Required)); err != nil {
@@ -47,7 +48,7 @@ func applyDelays() func(exp *MockExpectation) error {
 
 		case strings.HasPrefix(mode, "range"):
 			var rng string
-			if err := survey.AskOne(&survey.Input{
+			if err := ask.One(&survey.Input{
 				Message: "Range in ms as min-max (e.g., 400-900):",
 				Default: "400-900",
 			}, &rng, survey.WithValidator(survey.Required)); err != nil {
@@ -67,13 +68,13 @@ func applyDelays() func(exp *MockExpectation) error {
 		case strings.HasPrefix(mode, "progressive"):
 			// Simple progressive pattern: base, step, max
 			var baseStr, stepStr, maxStr string
-			if err := survey.AskOne(&survey.Input{Message: "Base delay (ms):", Default: "200"}, &baseStr); err != nil {
+			if err := ask.One(&survey.Input{Message: "Base delay (ms):", Default: "200"}, &baseStr); err != nil {
 				return err
 			}
-			if err := survey.AskOne(&survey.Input{Message: "Increment per hit (ms):", Default: "100"}, &stepStr); err != nil {
+			if err := ask.One(&survey.Input{Message: "Increment per hit (ms):", Default: "100"}, &stepStr); err != nil {
 				return err
 			}
-			if err := survey.AskOne(&survey.Input{Message: "Max delay cap (ms):", Default: "1500"}, &maxStr); err != nil {
+			if err := ask.One(&survey.Input{Message: "Max delay cap (ms):", Default: "1500"}, &maxStr); err != nil {
 				return err
 			}
 
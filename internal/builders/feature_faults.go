@@ -0,0 +1,129 @@
+package builders
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+const (
+	faultErrors   = "Random 5xx errors (percentage of requests)"
+	faultLatency  = "Latency (fixed, optionally with jitter)"
+	faultReset    = "Connection reset (every request)"
+	faultTruncate = "Truncated body (every request)"
+)
+
+// applyFaults injects errors, latency and broken connections for resilience testing
+func applyFaults() FeatureFunc {
+	return ConfigureFaults
+}
+
+// ConfigureFaults sets the faults injected into an expectation's response
+func ConfigureFaults(exp *MockExpectation) error {
+	fmt.Println("\n💥 Fault Injection")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("💡 Test how clients cope with an unreliable API: random 5xx errors, slow or jittery")
+	fmt.Println("   responses, reset connections and bodies cut off mid-transfer")
+
+	if !models.FaultsEligible(*exp) {
+		fmt.Println("⚠️  Faults can only be injected into a static response (not a template, forward or stream).")
+		return nil
+	}
+	current := exp.Faults
+	if current == nil {
+		current = &models.Faults{}
+	}
+	var defaults []string
+	if current.ErrorRate > 0 {
+		defaults = append(defaults, faultErrors)
+	}
+	if current.LatencyMs > 0 || current.JitterMs > 0 {
+		defaults = append(defaults, faultLatency)
+	}
+	if current.Reset {
+		defaults = append(defaults, faultReset)
+	}
+	if current.Truncate {
+		defaults = append(defaults, faultTruncate)
+	}
+	var picked []string
+	if err := ask.One(&survey.MultiSelect{
+		Message: "Faults to inject:",
+		Options: []string{faultErrors, faultLatency, faultReset, faultTruncate},
+		Default: defaults,
+		Help:    "Leave everything unselected to remove faults. Resets and truncation cannot be combined with errors or jitter.",
+	}, &picked); err != nil {
+		return err
+	}
+
+	faults := &models.Faults{}
+	for _, choice := range picked {
+		switch choice {
+		case faultErrors:
+			rate, err := askFaultNumber("Percentage of requests that fail (1-100):", current.ErrorRate, 10)
+			if err != nil {
+				return err
+			}
+			status, err := askFaultNumber("Status of the injected errors (5xx):", current.ErrorStatus, models.DefaultFaultStatus)
+			if err != nil {
+				return err
+			}
+			faults.ErrorRate, faults.ErrorStatus = rate, status
+		case faultLatency:
+			latency, err := askFaultNumber("Fixed latency in milliseconds:", current.LatencyMs, 500)
+			if err != nil {
+				return err
+			}
+			jitter, err := askFaultNumber("Random extra latency of up to (ms, 0 for none):", current.JitterMs, 0)
+			if err != nil {
+				return err
+			}
+			faults.LatencyMs, faults.JitterMs = latency, jitter
+		case faultReset:
+			faults.Reset = true
+		case faultTruncate:
+			faults.Truncate = true
+		}
+	}
+
+	if faults.IsZero() {
+		exp.Faults = nil
+		fmt.Println("✅ Fault injection off")
+		return nil
+	}
+	if msg := faults.Check(*exp); msg != "" {
+		fmt.Printf("⚠️  %s; faults not changed\n", msg)
+		return nil
+	}
+	exp.Faults = faults
+	fmt.Printf("✅ Faults: %s\n", faults.Describe())
+	if faults.Reset || faults.Truncate {
+		fmt.Println("   Every matching request is affected; give this expectation its own matcher (e.g. a header) to keep the normal response reachable")
+	}
+	return nil
+}
+
+// askFaultNumber asks for a non-negative number, offering current (or def when unset)
+func askFaultNumber(message string, current, def int) (int, error) {
+	if current == 0 {
+		current = def
+	}
+	var value string
+	if err := ask.One(&survey.Input{
+		Message: message,
+		Default: strconv.Itoa(current),
+	}, &value, survey.WithValidator(func(ans interface{}) error {
+		if n, err := strconv.Atoi(strings.TrimSpace(ans.(string))); err != nil || n < 0 {
+			return fmt.Errorf("enter a whole number of 0 or more")
+		}
+		return nil
+	})); err != nil {
+		return 0, err
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(value))
+	return n, nil
+}
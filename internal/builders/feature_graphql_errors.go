@@ -0,0 +1,122 @@
+package builders
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// applyGraphQLErrors adds GraphQL error and partial-data variants of the response
+func applyGraphQLErrors() FeatureFunc {
+	return ConfigureGraphQLErrors
+}
+
+// ConfigureGraphQLErrors picks the GraphQL error variants of an expectation, how requests
+// select them and which field fails, and previews the generated payloads. Selecting no
+// variant removes them.
+func ConfigureGraphQLErrors(exp *MockExpectation) error {
+	fmt.Println("\n🧯 GraphQL Error Variants")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("💡 GraphQL reports failures in an errors array, usually with HTTP 200:")
+	fmt.Println("   errors  → data is null, the error points at the root field")
+	fmt.Println("   partial → the recorded data with one field null, plus an error with its path")
+
+	if !models.GraphQLErrorsEligible(*exp) {
+		fmt.Println("⚠️  Only expectations with a static JSON response holding a data object can get GraphQL error variants.")
+		return nil
+	}
+
+	current := models.GraphQLVariants
+	spec := &models.GraphQLErrors{}
+	if exp.GraphQLErrors != nil {
+		current = exp.GraphQLErrors.Variants
+		*spec = *exp.GraphQLErrors
+	}
+	if err := ask.One(&survey.MultiSelect{
+		Message: "Generate error variants:",
+		Options: models.GraphQLVariants,
+		Default: current,
+	}, &spec.Variants); err != nil {
+		return err
+	}
+	if len(spec.Variants) == 0 {
+		exp.GraphQLErrors = nil
+		fmt.Println("✅ GraphQL error variants off")
+		return nil
+	}
+
+	byHeader := "Request header"
+	byVariable := "GraphQL variable"
+	selector := byHeader
+	if spec.Variable != "" {
+		selector = byVariable
+	}
+	if err := ask.One(&survey.Select{
+		Message: "Clients select a variant by:",
+		Options: []string{byHeader, byVariable},
+		Default: selector,
+		Help:    "The header or variable value names the variant, e.g. partial",
+	}, &selector); err != nil {
+		return err
+	}
+	if selector == byVariable {
+		name := spec.Variable
+		if name == "" {
+			name = "mockError"
+		}
+		if err := ask.One(&survey.Input{Message: "Variable name:", Default: name}, &name, survey.WithValidator(survey.Required)); err != nil {
+			return err
+		}
+		spec.Header, spec.Variable = "", strings.TrimSpace(name)
+	} else {
+		name := spec.Header
+		if name == "" {
+			name = models.DefaultGraphQLErrorHeader
+		}
+		if err := ask.One(&survey.Input{Message: "Header name:", Default: name}, &name, survey.WithValidator(survey.Required)); err != nil {
+			return err
+		}
+		spec.Header, spec.Variable = strings.TrimSpace(name), ""
+	}
+
+	for _, v := range spec.Variants {
+		if v != models.GraphQLVariantPartial {
+			continue
+		}
+		field := spec.Field
+		if field == "" {
+			field = models.DefaultGraphQLErrorField(*exp)
+		}
+		if err := ask.One(&survey.Input{
+			Message: "Failing field (dotted path under data):",
+			Default: field,
+			Help:    "e.g. user.email, or users.0.email for the first list element",
+		}, &spec.Field); err != nil {
+			return err
+		}
+	}
+
+	preview := *exp
+	preview.GraphQLErrors = spec
+	variants, err := models.GraphQLErrorVariants(preview)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return nil
+	}
+	exp.GraphQLErrors = spec
+	for _, v := range variants {
+		body, _ := v.HttpResponse.Body.(map[string]any)
+		pretty, _ := json.MarshalIndent(body["json"], "", "  ")
+		lines := strings.Split(string(pretty), "\n")
+		if len(lines) > 12 {
+			lines = append(lines[:12], "...")
+		}
+		fmt.Printf("\n📄 %s →\n%s\n", spec.Selector(v.GraphQLVariant), strings.Join(lines, "\n"))
+	}
+	fmt.Printf("\n✅ GraphQL error variants: %s (select with %s)\n", strings.Join(spec.Variants, ", "), spec.Selector("<variant>"))
+	return nil
+}
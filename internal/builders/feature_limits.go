@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
 )
 
 func applyLimits() FeatureFunc {
@@ -15,7 +16,7 @@ func applyLimits() FeatureFunc {
 
 		var mode string
 		if exp.Progressive == nil {
-			if err := survey.AskOne(&survey.Select{
+			if err := ask.One(&survey.Select{
 				Message: "Limit mode:",
 				Options: []string{"unlimited", "fixed-count"},
 				Default: "fixed-count",
@@ -37,7 +38,7 @@ func applyLimits() FeatureFunc {
 			exp.Times.RemainingTimes = 0
 		case "fixed-count":
 			var nStr string
-			if err := survey.AskOne(&survey.Input{
+			if err := ask.One(&survey.Input{
 				Message: "How many times should this expectation be served?",
 				Default: "1",
 			}, &nStr, survey.WithValidator(survey.Required)); err != nil {
@@ -0,0 +1,84 @@
+package builders
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// applyNegotiation offers the JSON response in other representations selected by Accept
+func applyNegotiation() FeatureFunc {
+	return ConfigureNegotiation
+}
+
+// ConfigureNegotiation picks the extra representations of an expectation's JSON body and
+// previews the converted bodies. Selecting none removes negotiation.
+func ConfigureNegotiation(exp *MockExpectation) error {
+	fmt.Println("\n🔀 Content Negotiation")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("💡 The JSON body stays canonical; XML/CSV variants are converted from it on every save")
+	fmt.Println("   Requests whose Accept header names no listed format still get JSON")
+
+	if exp.HttpResponseTemplate != nil {
+		fmt.Println("⚠️  Responses computed by a script cannot be negotiated; remove the script first.")
+		return nil
+	}
+
+	var current []string
+	if exp.Negotiation != nil {
+		current = exp.Negotiation.Formats
+	}
+	var formats []string
+	if err := ask.One(&survey.MultiSelect{
+		Message: "Also serve the response as:",
+		Options: models.NegotiationFormats,
+		Default: current,
+	}, &formats); err != nil {
+		return err
+	}
+	if len(formats) == 0 {
+		exp.Negotiation = nil
+		fmt.Println("✅ Content negotiation off; the response is JSON only")
+		return nil
+	}
+
+	negotiation := &models.Negotiation{Formats: formats}
+	for _, f := range formats {
+		if f != models.FormatXML {
+			continue
+		}
+		root := models.EntityFromPath(exp.HttpRequest.Path)
+		if exp.Negotiation != nil && exp.Negotiation.XMLRoot != "" {
+			root = exp.Negotiation.XMLRoot
+		}
+		if err := ask.One(&survey.Input{
+			Message: "XML root element:",
+			Default: root,
+			Help:    "Leave empty to derive it from the path (a JSON array adds \"List\")",
+		}, &negotiation.XMLRoot); err != nil {
+			return err
+		}
+	}
+
+	preview := *exp
+	preview.Negotiation = negotiation
+	variants, err := models.NegotiatedVariants(preview)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return nil
+	}
+	exp.Negotiation = negotiation
+	for _, v := range variants {
+		body, _ := v.HttpResponse.Body.(string)
+		lines := strings.Split(strings.TrimSpace(body), "\n")
+		if len(lines) > 8 {
+			lines = append(lines[:8], "...")
+		}
+		fmt.Printf("\n📄 Accept: %s →\n%s\n", v.NegotiatedFormat, strings.Join(lines, "\n"))
+	}
+	fmt.Printf("\n✅ Content negotiation: json (default), %s\n", strings.Join(formats, ", "))
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
 )
 
 func applyPriority() FeatureFunc {
@@ -14,15 +15,16 @@ func applyPriority() FeatureFunc {
 		fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
 		fmt.Println("\n💡 Priority Explanation:")
-		fmt.Println("   • Lower numbers = higher priority (0 is highest)")
-		fmt.Println("   • Lower-numbered (higher priority) expectations are matched first")
+		fmt.Println("   • Higher numbers win: expectations are matched highest priority first")
+		fmt.Println("   • Equal priorities are matched in the order they are listed")
 		fmt.Println("   • Use this to resolve conflicts between overlapping expectations")
-		fmt.Println("   • Example: Specific /users/123 before generic /users/{id}")
+		fmt.Println("   • Example: Specific /users/123 above generic /users/{id}")
+		fmt.Println("   • Saving raises the priority of an expectation a broader one would never let answer")
 		fmt.Println("   • No hard maximum; 0..100 is just a suggested range")
 
 		var pStr string
-		if err := survey.AskOne(&survey.Input{
-			Message: "Priority (lower wins). Suggest 0..100 (0 = highest; no hard max):",
+		if err := ask.One(&survey.Input{
+			Message: "Priority (higher wins). Suggest 0..100 (0 = default; no hard max):",
 			Default: "10",
 		}, &pStr, survey.WithValidator(survey.Required)); err != nil {
 			return err
@@ -0,0 +1,42 @@
+package builders
+
+import (
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// applyRanges lets clients fetch byte ranges of the response body
+func applyRanges() FeatureFunc {
+	return ConfigureRanges
+}
+
+// ConfigureRanges toggles Range request (206 Partial Content) support on a GET expectation
+func ConfigureRanges(exp *MockExpectation) error {
+	fmt.Println("\n✂️  Range Requests (206 Partial Content)")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("💡 Requests with 'Range: bytes=start-end' get that slice of the body with Content-Range")
+	fmt.Println("   Ranges past the end get 416; the response advertises Accept-Ranges: bytes")
+
+	if !models.RangesEligible(*exp) {
+		fmt.Println("⚠️  Only GET expectations with a static 200 body can serve ranges.")
+		return nil
+	}
+	enabled := exp.Ranges
+	if err := ask.One(&survey.Confirm{
+		Message: "Serve byte ranges of this response?",
+		Default: true,
+	}, &enabled); err != nil {
+		return err
+	}
+	exp.Ranges = enabled
+	if !enabled {
+		fmt.Println("✅ Range requests off")
+		return nil
+	}
+	data, _ := models.ResponseBytes(exp.HttpResponse.Body)
+	fmt.Printf("✅ Range requests on: %d byte(s), e.g. curl -H 'Range: bytes=0-99' ... → 206\n", len(data))
+	return nil
+}
@@ -0,0 +1,192 @@
+package builders
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+const (
+	redirectDefine = "Define the redirect chain"
+	redirectRemove = "Remove the redirect chain"
+	redirectKeep   = "Keep it"
+)
+
+// applyRedirects answers with a 3xx leading through a chain of hops
+func applyRedirects() FeatureFunc {
+	return ConfigureRedirects
+}
+
+// ConfigureRedirects edits the redirect chain of an expectation: the Locations the client is
+// sent through, the cookies set on the way and the query parameters carried along
+func ConfigureRedirects(exp *MockExpectation) error {
+	fmt.Println("\n↪️  Redirect Chain")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("💡 Answer with a 3xx and serve the hops it leads through, e.g. an OAuth flow:")
+	fmt.Println("   /oauth/authorize → /login → /consent → ${request.query.redirect_uri}?code=abc&state=${request.query.state}")
+	fmt.Println("   Every hop but the last is served by the mock; the last is where the chain ends")
+
+	if exp.Redirects != nil {
+		fmt.Printf("Current chain: %s\n", exp.Redirects.Describe())
+		var action string
+		if err := ask.One(&survey.Select{
+			Message: "Redirect chain:",
+			Options: []string{redirectDefine, redirectRemove, redirectKeep},
+			Default: redirectKeep,
+		}, &action); err != nil {
+			return err
+		}
+		switch action {
+		case redirectKeep:
+			return nil
+		case redirectRemove:
+			removeRedirects(exp)
+			fmt.Println("✅ Redirect chain removed; the response is a 200 again")
+			return nil
+		}
+	}
+
+	r, err := collectRedirects()
+	if err != nil {
+		return err
+	}
+	preview := *exp
+	preview.HttpResponse = nil
+	preview.Redirects = &r
+	if _, err := models.RedirectExpectations(&preview); err != nil {
+		return err
+	}
+	exp.Redirects = &r
+	fmt.Printf("✅ Redirect chain: %s\n", r.Describe())
+	return nil
+}
+
+func collectRedirects() (models.Redirects, error) {
+	var r models.Redirects
+	labels := make([]string, len(models.RedirectStatuses))
+	for i, s := range models.RedirectStatuses {
+		labels[i] = strconv.Itoa(s)
+	}
+	status := "302"
+	if err := ask.One(&survey.Select{
+		Message: "Redirect status:",
+		Options: labels,
+		Default: status,
+		Help:    "302/303 for browser flows (303 turns a POST into a GET); 307/308 keep the method; 301/308 are permanent",
+	}, &status); err != nil {
+		return r, err
+	}
+	if r.Status, _ = strconv.Atoi(status); r.Status == 302 {
+		r.Status = 0
+	}
+
+	cookies := false
+	for {
+		var hop models.RedirectHop
+		if err := ask.One(&survey.Input{
+			Message: fmt.Sprintf("Hop %d location:", len(r.Hops)+1),
+			Help:    "A path on the mock (/login) to continue the chain, or the final URL; ${request.query.state}-style references are filled in",
+		}, &hop.Location, survey.WithValidator(survey.Required)); err != nil {
+			return r, err
+		}
+		hop.Location = strings.TrimSpace(hop.Location)
+
+		var raw string
+		if err := ask.One(&survey.Input{
+			Message: "Cookies set on the way (optional):",
+			Help:    "name=value pairs separated by ;, e.g. session=abc123; csrf=xyz",
+		}, &raw); err != nil {
+			return r, err
+		}
+		for _, pair := range strings.Split(raw, ";") {
+			name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			if ok && strings.TrimSpace(name) != "" {
+				hop.Cookies = append(hop.Cookies, models.NameValues{Name: strings.TrimSpace(name), Values: []string{strings.TrimSpace(value)}})
+				cookies = true
+			}
+		}
+		r.Hops = append(r.Hops, hop)
+
+		more := false
+		if err := ask.One(&survey.Confirm{Message: "Add another hop after this one?", Default: false}, &more); err != nil {
+			return r, err
+		}
+		if !more {
+			break
+		}
+	}
+
+	if len(r.Hops) > 1 {
+		var carry string
+		if err := ask.One(&survey.Input{
+			Message: "Query parameters to carry along every hop (optional):",
+			Help:    "Comma-separated, e.g. state, redirect_uri; the last location can echo them with ${request.query.state}",
+		}, &carry); err != nil {
+			return r, err
+		}
+		for _, name := range strings.Split(carry, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				r.Carry = append(r.Carry, name)
+			}
+		}
+		if cookies {
+			if err := ask.One(&survey.Confirm{
+				Message: "Only answer hops sending back the cookies set before them?",
+				Default: true,
+			}, &r.RequireCookies); err != nil {
+				return r, err
+			}
+		}
+	}
+	if msg := r.Check(); msg != "" {
+		return r, fmt.Errorf("%s", msg)
+	}
+	return r, nil
+}
+
+// removeRedirects drops the chain and the redirect it wrote into the response
+func removeRedirects(exp *MockExpectation) {
+	if exp.HttpResponse != nil && exp.Redirects != nil {
+		exp.HttpResponse.StatusCode = 200
+		var first []models.NameValues
+		if len(exp.Redirects.Hops) > 0 {
+			first = exp.Redirects.Hops[0].Cookies
+		}
+		var headers []models.NameValues
+		for _, h := range exp.HttpResponse.Headers {
+			switch {
+			case strings.EqualFold(h.Name, "Location"):
+			case strings.EqualFold(h.Name, "Set-Cookie"):
+				if kept := keepSetCookies(h.Values, first); len(kept) > 0 {
+					headers = append(headers, models.NameValues{Name: h.Name, Values: kept})
+				}
+			default:
+				headers = append(headers, h)
+			}
+		}
+		exp.HttpResponse.Headers = headers
+	}
+	if models.IsRedirectTemplate(exp.HttpResponseTemplate) {
+		exp.HttpResponseTemplate = nil
+	}
+	exp.Redirects = nil
+}
+
+// keepSetCookies returns the Set-Cookie values other than those the chain set
+func keepSetCookies(values []string, cookies []models.NameValues) []string {
+	var kept []string
+	for _, v := range values {
+		set := false
+		for _, c := range cookies {
+			set = set || v == models.RedirectSetCookie(c)
+		}
+		if !set {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
 	"github.com/hemantobora/auto-mock/internal/models"
 )
 
@@ -25,7 +26,7 @@ func ControlContentLengthHeaders() FeatureFunc {
 		// add selection between contentLengthHeaderOverride and suppressContentLengthHeader.
 		// Only one could be chosen.
 		var choice string
-		if err := survey.AskOne(&survey.Select{
+		if err := ask.One(&survey.Select{
 			Message: "Choose Content-Length header action:",
 			Options: []string{
 				"Set Content-Length header value",
@@ -39,7 +40,7 @@ func ControlContentLengthHeaders() FeatureFunc {
 		if choice == "Set Content-Length header value" {
 			// Prompt for Content-Length header value
 			var contentLengthHeader string
-			if err := survey.AskOne(&survey.Input{
+			if err := ask.One(&survey.Input{
 				Message: "Set Content-Length header value (leave blank to skip):",
 				Help:    "Specify a value for the Content-Length header",
 			}, &contentLengthHeader); err != nil {
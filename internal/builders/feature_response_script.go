@@ -0,0 +1,73 @@
+package builders
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/matcher"
+	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/scripting"
+)
+
+const responseScriptExample = `// request: {method, path, headers, queryStringParameters, body}
+var order = JSON.parse(request.body || '{}');
+var total = (order.items || []).reduce(function (sum, i) { return sum + i.price * i.qty; }, 0);
+return {
+  statusCode: 200,
+  headers: { 'content-type': 'application/json' },
+  body: { total: total }
+};
+`
+
+// applyResponseScript attaches a JavaScript response template that computes the response
+// from the request at serve time. The static response is kept as the fallback.
+func applyResponseScript() FeatureFunc {
+	return func(exp *MockExpectation) error {
+		fmt.Println("\n🧮 Response Script (JavaScript)")
+		fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+		fmt.Println("💡 The script is a function body: read `request` and return {statusCode, headers, body}")
+		fmt.Println("   Request bodies are strings; use JSON.parse(request.body) for JSON")
+
+		script := responseScriptExample
+		if exp.HttpResponseTemplate != nil && !models.IsRequestTemplate(exp.HttpResponseTemplate) {
+			script = exp.HttpResponseTemplate.Template
+		}
+		for {
+			if err := ask.One(&survey.Editor{
+				Message:       "Response script:",
+				Default:       script,
+				AppendDefault: true,
+				HideDefault:   true,
+				FileName:      "*.js",
+			}, &script); err != nil {
+				return err
+			}
+			tmpl := &models.HttpTemplate{TemplateType: models.TemplateJavaScript, Template: strings.TrimSpace(script)}
+
+			resp, err := scripting.Render(tmpl, matcher.ExampleRequest(exp))
+			if err == nil {
+				exp.HttpResponseTemplate = tmpl
+				fmt.Printf("✅ Dry run against the request matcher returned status %d\n", resp.StatusCode)
+				break
+			}
+			fmt.Printf("⚠️  Dry run failed: %v\n", err)
+			var retry bool
+			if err := ask.One(&survey.Confirm{
+				Message: "Edit the script again? (No keeps it as is)",
+				Default: true,
+			}, &retry); err != nil {
+				return err
+			}
+			if !retry {
+				exp.HttpResponseTemplate = tmpl
+				break
+			}
+		}
+
+		fmt.Println("\n📚 MockServer Response Template Documentation:")
+		fmt.Println("   Templates: https://mock-server.com/mock_server/response_templates.html")
+		return nil
+	}
+}
@@ -0,0 +1,254 @@
+package builders
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+const (
+	ruleAdd    = "Add a rule"
+	ruleRemove = "Remove a rule"
+	ruleMoveUp = "Move a rule up"
+	ruleDone   = "Done"
+)
+
+var ruleOpLabels = map[string]string{
+	"eq":      "eq - equals",
+	"ne":      "ne - does not equal",
+	"gt":      "gt - greater than",
+	"gte":     "gte - greater than or equal",
+	"lt":      "lt - less than",
+	"lte":     "lte - less than or equal",
+	"matches": "matches - matches a regex (the whole value)",
+	"exists":  "exists - is present",
+}
+
+// applyRules selects alternative responses by request values
+func applyRules() FeatureFunc {
+	return ConfigureRules
+}
+
+// ConfigureRules edits the ordered response rules of an expectation: each rule answers the
+// requests meeting all its conditions with its own response
+func ConfigureRules(exp *MockExpectation) error {
+	fmt.Println("\n🔀 Response Rules")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("💡 Serve other responses from this endpoint by request values, e.g.")
+	fmt.Println("   header X-Test-Case == \"timeout\" → 504, or body amount > 1000 → 402")
+	fmt.Println("   Rules are tried in order; requests meeting no rule get the expectation's response")
+
+	for {
+		if len(exp.Rules) > 0 {
+			fmt.Println("\nRules:")
+			for i, r := range exp.Rules {
+				fmt.Printf("  %d. %s: %s\n", i+1, r.Label(i), r.Describe())
+			}
+		}
+		options := []string{ruleAdd}
+		if len(exp.Rules) > 0 {
+			options = append(options, ruleRemove)
+		}
+		if len(exp.Rules) > 1 {
+			options = append(options, ruleMoveUp)
+		}
+		options = append(options, ruleDone)
+
+		var action string
+		if err := ask.One(&survey.Select{Message: "Response rules:", Options: options, Default: options[0]}, &action); err != nil {
+			return err
+		}
+		switch action {
+		case ruleAdd:
+			rule, err := collectRule(exp)
+			if err != nil {
+				return err
+			}
+			exp.Rules = append(exp.Rules, rule)
+			fmt.Printf("✅ Rule added: %s\n", rule.Describe())
+		case ruleRemove:
+			i, err := pickRule(exp, "Remove which rule?")
+			if err != nil {
+				return err
+			}
+			exp.Rules = append(exp.Rules[:i], exp.Rules[i+1:]...)
+			if len(exp.Rules) == 0 {
+				exp.Rules = nil
+			}
+		case ruleMoveUp:
+			i, err := pickRule(exp, "Move which rule up?")
+			if err != nil {
+				return err
+			}
+			if i > 0 {
+				exp.Rules[i-1], exp.Rules[i] = exp.Rules[i], exp.Rules[i-1]
+			}
+		default:
+			return nil
+		}
+	}
+}
+
+func pickRule(exp *MockExpectation, message string) (int, error) {
+	labels := make([]string, len(exp.Rules))
+	for i, r := range exp.Rules {
+		labels[i] = fmt.Sprintf("%d. %s: %s", i+1, r.Label(i), r.Describe())
+	}
+	var i int
+	err := ask.One(&survey.Select{Message: message, Options: labels}, &i)
+	return i, err
+}
+
+// collectRule asks for a rule's conditions and response and checks it compiles
+func collectRule(exp *MockExpectation) (models.ResponseRule, error) {
+	var rule models.ResponseRule
+	if err := ask.One(&survey.Input{
+		Message: "Rule name (optional):",
+		Help:    "e.g. timeout or large-payment; shown in listings and appended to the description",
+	}, &rule.Name); err != nil {
+		return rule, err
+	}
+	rule.Name = strings.TrimSpace(rule.Name)
+
+	for {
+		cond, err := collectRuleCondition()
+		if err != nil {
+			return rule, err
+		}
+		rule.When = append(rule.When, cond)
+		more := false
+		if err := ask.One(&survey.Confirm{Message: "Add another condition (all must hold)?", Default: false}, &more); err != nil {
+			return rule, err
+		}
+		if !more {
+			break
+		}
+	}
+
+	resp, err := collectRuleResponse()
+	if err != nil {
+		return rule, err
+	}
+	rule.Response = resp
+
+	preview := *exp
+	preview.Rules = append(append([]models.ResponseRule(nil), exp.Rules...), rule)
+	if _, err := models.RuleExpectation(preview, len(preview.Rules)-1); err != nil {
+		return rule, err
+	}
+	return rule, nil
+}
+
+func collectRuleCondition() (models.RuleCondition, error) {
+	var c models.RuleCondition
+	if err := ask.One(&survey.Select{
+		Message: "Condition on:",
+		Options: models.RuleSources,
+		Default: models.RuleHeader,
+		Help:    "header and query compare the first value; body reads a JSON request body field",
+	}, &c.Source); err != nil {
+		return c, err
+	}
+	help := "e.g. X-Test-Case"
+	switch c.Source {
+	case models.RuleQuery:
+		help = "e.g. scenario"
+	case models.RuleBody:
+		help = "A dotted path, e.g. amount, customer.tier or items.0.sku"
+	}
+	if err := ask.One(&survey.Input{Message: "Field:", Help: help}, &c.Field, survey.WithValidator(survey.Required)); err != nil {
+		return c, err
+	}
+	c.Field = strings.TrimSpace(c.Field)
+
+	ops := models.RuleOps(c.Source)
+	labels := make([]string, len(ops))
+	for i, op := range ops {
+		labels[i] = ruleOpLabels[op]
+	}
+	var op string
+	if err := ask.One(&survey.Select{Message: "Operator:", Options: labels, Default: labels[0]}, &op); err != nil {
+		return c, err
+	}
+	c.Op = strings.Fields(op)[0]
+	if c.Op == "exists" {
+		return c, nil
+	}
+
+	var raw string
+	if err := ask.One(&survey.Input{
+		Message: "Value:",
+		Help:    "Body values are read as JSON when they parse (1000, true, null); anything else is text",
+	}, &raw, survey.WithValidator(func(ans any) error {
+		cond := c
+		cond.Value = ruleValue(c, fmt.Sprint(ans))
+		if msg := cond.Check(); msg != "" {
+			return fmt.Errorf("%s", msg)
+		}
+		return nil
+	})); err != nil {
+		return c, err
+	}
+	c.Value = ruleValue(c, raw)
+	return c, nil
+}
+
+// ruleValue types a typed-in value: JSON scalars for body comparisons, text otherwise
+func ruleValue(c models.RuleCondition, raw string) any {
+	raw = strings.TrimSpace(raw)
+	if c.Source != models.RuleBody || c.Op == "matches" {
+		return raw
+	}
+	var v any
+	if json.Unmarshal([]byte(raw), &v) == nil {
+		switch v.(type) {
+		case map[string]any, []any:
+		default:
+			return v
+		}
+	}
+	return raw
+}
+
+func collectRuleResponse() (*models.HttpResponse, error) {
+	var status string
+	if err := ask.One(&survey.Input{
+		Message: "Response status code:",
+		Default: "200",
+	}, &status, survey.WithValidator(func(ans any) error {
+		code, err := strconv.Atoi(strings.TrimSpace(fmt.Sprint(ans)))
+		if err != nil || code < 100 || code > 599 {
+			return fmt.Errorf("enter a status between 100 and 599")
+		}
+		return nil
+	})); err != nil {
+		return nil, err
+	}
+	code, _ := strconv.Atoi(strings.TrimSpace(status))
+	resp := &models.HttpResponse{StatusCode: code}
+
+	var body string
+	if err := ask.One(&survey.Multiline{
+		Message: "Response body (optional):",
+		Help:    "JSON or text. ${uuid}-style placeholders are filled on save; ${request.body.amount} echoes the request.",
+	}, &body); err != nil {
+		return nil, err
+	}
+	if body = strings.TrimSpace(body); body == "" {
+		return resp, nil
+	}
+	var v any
+	if json.Unmarshal([]byte(body), &v) == nil {
+		resp.Body = map[string]any{"type": "JSON", "json": v}
+		resp.Headers = []models.NameValues{{Name: "Content-Type", Values: []string{"application/json"}}}
+	} else {
+		resp.Body = body
+		resp.Headers = []models.NameValues{{Name: "Content-Type", Values: []string{"text/plain"}}}
+	}
+	return resp, nil
+}
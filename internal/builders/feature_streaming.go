@@ -0,0 +1,241 @@
+package builders
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// applyStreaming turns the endpoint into a WebSocket or Server-Sent Events stream
+func applyStreaming() FeatureFunc {
+	return ConfigureStream
+}
+
+// ConfigureStream defines (or removes) the message stream of an expectation: the messages,
+// their timing, and when the server closes the connection
+func ConfigureStream(exp *MockExpectation) error {
+	fmt.Println("\n📡 Streaming (WebSocket / Server-Sent Events)")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("💡 'automock serve' plays the stream; deployed MockServer sends SSE events all at once")
+	fmt.Println("   and answers WebSocket handshakes with 501")
+
+	current := "none"
+	if exp.Stream != nil {
+		current = exp.Stream.Type
+	}
+	var kind string
+	if err := ask.One(&survey.Select{
+		Message: "Stream type:",
+		Options: []string{"none", models.StreamSSE, models.StreamWebSocket},
+		Default: current,
+		Description: func(value string, _ int) string {
+			switch value {
+			case models.StreamSSE:
+				return "Server-Sent Events (text/event-stream)"
+			case models.StreamWebSocket:
+				return "WebSocket message exchange"
+			}
+			return "Regular HTTP response"
+		},
+	}, &kind); err != nil {
+		return err
+	}
+	if kind == "none" {
+		if exp.Stream != nil {
+			exp.Stream = nil
+			exp.HttpResponse = &HttpResponse{StatusCode: 200}
+			fmt.Println("✅ Streaming off; set the response status and body again")
+		}
+		return nil
+	}
+	if kind == models.StreamWebSocket && !strings.EqualFold(exp.HttpRequest.Method, "GET") {
+		exp.HttpRequest.Method = "GET"
+		fmt.Println("ℹ️  WebSocket handshakes are GET requests; method set to GET")
+	}
+
+	stream := &models.Stream{Type: kind}
+	if exp.Stream != nil && exp.Stream.Type == kind {
+		*stream = *exp.Stream
+		keep := true
+		if err := ask.One(&survey.Confirm{
+			Message: fmt.Sprintf("Keep the %d existing message(s)?", len(stream.Messages)),
+			Default: true,
+		}, &keep); err != nil {
+			return err
+		}
+		if !keep {
+			stream.Messages = nil
+		}
+	}
+
+	for {
+		more := len(stream.Messages) == 0
+		if !more {
+			if err := ask.One(&survey.Confirm{Message: "Add another message?", Default: false}, &more); err != nil {
+				return err
+			}
+		}
+		if !more {
+			break
+		}
+		m, err := askStreamMessage(kind)
+		if err != nil {
+			return err
+		}
+		stream.Messages = append(stream.Messages, m)
+	}
+
+	defaultInterval := "1000"
+	if kind == models.StreamWebSocket {
+		defaultInterval = "0"
+	}
+	if stream.Interval != nil && stream.Interval.TimeUnit == "MILLISECONDS" {
+		defaultInterval = strconv.Itoa(stream.Interval.Value)
+	}
+	interval, err := askMillis("Wait before each message, unless it sets its own (ms):", defaultInterval)
+	if err != nil {
+		return err
+	}
+	stream.Interval = interval
+	if err := ask.One(&survey.Confirm{
+		Message: "Repeat the sequence until the connection closes?",
+		Default: stream.Repeat,
+	}, &stream.Repeat); err != nil {
+		return err
+	}
+	if stream.Close, err = askStreamClose(stream); err != nil {
+		return err
+	}
+
+	if err := stream.Validate(); err != nil {
+		return err
+	}
+	exp.Stream = stream
+	exp.HttpResponse = models.StreamFallback(stream)
+	replies := len(stream.Messages) - len(stream.Sequence())
+	fmt.Printf("✅ %s stream: %d message(s) after connecting", strings.ToUpper(kind), len(stream.Sequence()))
+	if replies > 0 {
+		fmt.Printf(", %d reply(ies)", replies)
+	}
+	fmt.Println()
+	return nil
+}
+
+func askStreamMessage(kind string) (models.StreamMessage, error) {
+	var m models.StreamMessage
+	if kind == models.StreamWebSocket {
+		var when string
+		if err := ask.One(&survey.Select{
+			Message: "Send this message:",
+			Options: []string{"sequence - After connecting, in order", "reply - In reply to matching client messages"},
+			Default: "sequence - After connecting, in order",
+		}, &when); err != nil {
+			return m, err
+		}
+		if strings.HasPrefix(when, "reply") {
+			if err := ask.One(&survey.Input{
+				Message: "Reply to client messages matching (regex):",
+				Help:    `e.g. "type":"ping" or ^subscribe`,
+			}, &m.Reply, survey.WithValidator(survey.Required)); err != nil {
+				return m, err
+			}
+		}
+	}
+	var data string
+	if err := ask.One(&survey.Input{
+		Message: "Message data (text or JSON):",
+		Help:    "Sent as-is; SSE data spanning several lines becomes several data: fields",
+	}, &data, survey.WithValidator(survey.Required)); err != nil {
+		return m, err
+	}
+	m.Data = data
+	if kind == models.StreamSSE {
+		if err := ask.One(&survey.Input{Message: "Event type (optional):"}, &m.Event); err != nil {
+			return m, err
+		}
+		if err := ask.One(&survey.Input{Message: "Event ID (optional):"}, &m.ID); err != nil {
+			return m, err
+		}
+	}
+	delay, err := askMillis("Delay before this message in ms (blank = stream interval):", "")
+	if err != nil {
+		return m, err
+	}
+	m.Delay = delay
+	return m, nil
+}
+
+func askStreamClose(stream *models.Stream) (*models.StreamClose, error) {
+	options := []string{"open - Stay open until the client disconnects"}
+	if !stream.Repeat {
+		options = append(options, "after - Close after the sequence")
+	}
+	if stream.Type == models.StreamWebSocket {
+		options = append(options, "message - Close when the client sends a matching message")
+	}
+	var choice string
+	if err := ask.One(&survey.Select{Message: "Close the connection:", Options: options, Default: options[0]}, &choice); err != nil {
+		return nil, err
+	}
+	closing := &models.StreamClose{}
+	switch {
+	case strings.HasPrefix(choice, "open"):
+		return nil, nil
+	case strings.HasPrefix(choice, "after"):
+		after, err := askMillis("Wait after the last message before closing (ms):", "0")
+		if err != nil {
+			return nil, err
+		}
+		closing.After = after
+	default:
+		if err := ask.One(&survey.Input{
+			Message: "Close on client messages matching (regex):",
+			Default: "^close$",
+		}, &closing.OnMessage, survey.WithValidator(survey.Required)); err != nil {
+			return nil, err
+		}
+	}
+	if stream.Type == models.StreamWebSocket {
+		var code string
+		if err := ask.One(&survey.Input{
+			Message: "Close code:",
+			Default: "1000",
+			Help:    "1000 normal closure, 1001 going away, 1011 server error, 4000-4999 application codes",
+		}, &code); err != nil {
+			return nil, err
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(code))
+		if err != nil {
+			return nil, fmt.Errorf("invalid close code: %q", code)
+		}
+		closing.Code = n
+		if err := ask.One(&survey.Input{Message: "Close reason (optional):"}, &closing.Reason); err != nil {
+			return nil, err
+		}
+	}
+	return closing, nil
+}
+
+// askMillis reads a non-negative number of milliseconds; blank input returns nil
+func askMillis(message, def string) (*Delay, error) {
+	var raw string
+	if err := ask.One(&survey.Input{Message: message, Default: def}, &raw); err != nil {
+		return nil, err
+	}
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("invalid number: %q", raw)
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	return &Delay{TimeUnit: "MILLISECONDS", Value: n}, nil
+}
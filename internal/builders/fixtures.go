@@ -0,0 +1,504 @@
+package builders
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// Fixture languages understood by ExpectationsToFixtures
+const (
+	FixtureLangGo     = "go"     // net/http handler and httptest server
+	FixtureLangTS     = "ts"     // Mock Service Worker (v2) request handlers
+	FixtureLangPython = "python" // responses registrations and a pytest fixture
+)
+
+// FixtureLangs lists the fixture languages in help order
+var FixtureLangs = []string{FixtureLangGo, FixtureLangTS, FixtureLangPython}
+
+// FixtureOptions names what the generated fixtures are for
+type FixtureOptions struct {
+	Lang    string
+	Project string // named in the header, and the pytest fixture is <project>_mock
+	Package string // Go package (default "fixtures")
+}
+
+// fixture is the part of an expectation a test fixture reproduces
+type fixture struct {
+	label     string
+	method    string // "" = any method
+	pathRegex string // unanchored regex of the path
+	mswPath   string // MSW path such as '*/users/:id'; "" when only a regex fits
+	query     []models.NameValues
+	status    int
+	headers   []models.NameValues // response headers, Content-Type included
+	body      string
+	json      bool
+}
+
+// ExpectationsToFixtures converts expectations into a test fixture file that answers like the
+// mock, in MockServer's match order. Fixtures match on method, path and literal query values;
+// header and body matchers, delays and faults are left out so tests stay fast and simple.
+// Forwarded expectations and the contract info endpoint are skipped. What could not be
+// reproduced is reported as warnings.
+func ExpectationsToFixtures(expectations []MockExpectation, opts FixtureOptions) (string, []string, error) {
+	var fixtures []fixture
+	var warnings []string
+	partial := 0
+	for _, i := range matchOrder(expectations) {
+		exp := expectations[i]
+		if exp.HttpRequest == nil || exp.HttpRequest.Path == models.ContractInfoPath {
+			continue
+		}
+		f, notes := fixtureFor(exp)
+		for _, note := range notes {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", f.label, note))
+		}
+		if exp.Forward != nil {
+			continue
+		}
+		if len(exp.HttpRequest.Headers) > 0 || exp.HttpRequest.Body != nil {
+			partial++
+		}
+		fixtures = append(fixtures, f)
+	}
+	if partial > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d expectation(s) also match on headers or the body; their fixtures match method, path and query only", partial))
+	}
+
+	switch strings.ToLower(opts.Lang) {
+	case FixtureLangGo:
+		if opts.Package == "" {
+			opts.Package = "fixtures"
+		}
+		if !token.IsIdentifier(opts.Package) {
+			return "", nil, fmt.Errorf("invalid Go package name %q", opts.Package)
+		}
+		out, err := goFixtures(fixtures, opts)
+		return out, warnings, err
+	case FixtureLangTS, "js":
+		return mswFixtures(fixtures, opts), warnings, nil
+	case FixtureLangPython:
+		return pythonFixtures(fixtures, opts), warnings, nil
+	}
+	return "", nil, fmt.Errorf("unsupported fixture language %q (supported: %s)", opts.Lang, strings.Join(FixtureLangs, ", "))
+}
+
+func fixtureFor(exp MockExpectation) (fixture, []string) {
+	var notes []string
+	req := exp.HttpRequest
+	f := fixture{method: strings.ToUpper(req.Method), status: http.StatusOK}
+	f.label = strings.TrimSpace(f.method + " " + req.Path)
+	if f.method == "" {
+		f.label = "* " + req.Path
+	}
+	if exp.Forward != nil {
+		return f, []string{"forwarded to an upstream; no fixture written"}
+	}
+
+	switch {
+	case req.Path == "":
+		f.pathRegex, f.mswPath = ".*", "*"
+	case isRegexPath(req.Path):
+		f.pathRegex = req.Path
+	default:
+		// Literal or templated (/users/{id}) path
+		const param = "\x00"
+		quoted := regexp.QuoteMeta(wireMockPathParam.ReplaceAllString(req.Path, param))
+		f.pathRegex = strings.ReplaceAll(quoted, param, "[^/]+")
+		f.mswPath = "*" + wireMockPathParam.ReplaceAllStringFunc(req.Path, func(p string) string {
+			return ":" + strings.Trim(p, "{}")
+		})
+	}
+	for _, q := range req.QueryStringParameters {
+		var literal []string
+		for _, v := range q.Values {
+			if regexp.QuoteMeta(v) == v {
+				literal = append(literal, v)
+			} else {
+				notes = append(notes, fmt.Sprintf("query matcher %s=%s is not a literal value; left out", q.Name, v))
+			}
+		}
+		if len(literal) > 0 {
+			f.query = append(f.query, models.NameValues{Name: q.Name, Values: literal})
+		}
+	}
+
+	if exp.HttpResponseTemplate != nil {
+		notes = append(notes, "JavaScript response template not convertible; wrote its static fallback response")
+	}
+	resp := exp.HttpResponse
+	if resp == nil {
+		return f, notes
+	}
+	if resp.StatusCode != 0 {
+		f.status = resp.StatusCode
+	}
+	for _, h := range resp.Headers {
+		f.headers = append(f.headers, models.NameValues{Name: http.CanonicalHeaderKey(h.Name), Values: h.Values})
+	}
+	for _, c := range resp.Cookies {
+		for _, v := range c.Values {
+			f.headers = append(f.headers, models.NameValues{Name: "Set-Cookie", Values: []string{c.Name + "=" + v}})
+		}
+	}
+	var note string
+	f.body, f.json, note = fixtureBody(resp.Body)
+	if note != "" {
+		notes = append(notes, note)
+	}
+	if strings.Contains(f.body, "$!") {
+		notes = append(notes, "response placeholders ($!...) are written as-is")
+	}
+	if f.json && fixtureHeader(f.headers, "Content-Type") == "" {
+		f.headers = append(f.headers, models.NameValues{Name: "Content-Type", Values: []string{"application/json"}})
+	}
+	return f, notes
+}
+
+// fixtureBody renders a MockServer response body as text and reports whether it is JSON
+func fixtureBody(body any) (string, bool, string) {
+	switch b := body.(type) {
+	case nil:
+		return "", false, ""
+	case string:
+		return b, json.Valid([]byte(b)), ""
+	case map[string]any:
+		switch strings.ToUpper(fmt.Sprint(b["type"])) {
+		case "JSON":
+			if s, ok := b["json"].(string); ok {
+				return s, json.Valid([]byte(s)), ""
+			}
+			raw, err := json.Marshal(b["json"])
+			if err != nil {
+				return "", false, fmt.Sprintf("response body not serializable: %v", err)
+			}
+			return string(raw), true, ""
+		case "STRING":
+			s, _ := b["string"].(string)
+			return s, false, ""
+		case "XML":
+			s, _ := b["xml"].(string)
+			return s, false, ""
+		case "BINARY":
+			return "", false, "binary response body left out"
+		}
+	}
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return "", false, fmt.Sprintf("response body not serializable: %v", err)
+	}
+	return string(raw), true, ""
+}
+
+func fixtureHeader(headers []models.NameValues, name string) string {
+	for _, h := range headers {
+		if strings.EqualFold(h.Name, name) && len(h.Values) > 0 {
+			return h.Values[0]
+		}
+	}
+	return ""
+}
+
+// jsLiteral quotes a string for JavaScript and Python source
+func jsLiteral(s string) string {
+	raw, _ := json.Marshal(s)
+	return string(raw)
+}
+
+func goFixtures(fixtures []fixture, opts FixtureOptions) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Code generated by automock generate-fixtures from project %s; DO NOT EDIT.\n\n", opts.Project)
+	fmt.Fprintf(&b, "// Package %s answers like the %s mock from an httptest server.\n", opts.Package, opts.Project)
+	fmt.Fprintf(&b, "package %s\n\n", opts.Package)
+	b.WriteString(`import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"testing"
+)
+
+type fixture struct {
+	method string // "" = any method
+	path   *regexp.Regexp
+	query  url.Values // values that must be present
+	status int
+	header http.Header
+	body   string
+}
+
+// fixtures are in the mock's match order: the first one matching a request answers it
+var fixtures = []fixture{
+`)
+	for _, f := range fixtures {
+		fmt.Fprintf(&b, "\t// %s\n\t{method: %q, path: regexp.MustCompile(%s), status: %d", f.label, f.method, strconv.Quote("^(?:"+f.pathRegex+")$"), f.status)
+		if len(f.query) > 0 {
+			b.WriteString(", query: url.Values{")
+			for _, q := range f.query {
+				fmt.Fprintf(&b, "%q: %s, ", q.Name, goStrings(q.Values))
+			}
+			b.WriteString("}")
+		}
+		if len(f.headers) > 0 {
+			b.WriteString(", header: http.Header{")
+			for _, h := range mergeHeaders(f.headers) {
+				fmt.Fprintf(&b, "%q: %s, ", h.Name, goStrings(h.Values))
+			}
+			b.WriteString("}")
+		}
+		if f.body != "" {
+			fmt.Fprintf(&b, ", body: %s", strconv.Quote(f.body))
+		}
+		b.WriteString("},\n")
+	}
+	fmt.Fprintf(&b, `}
+
+func (f fixture) matches(r *http.Request) bool {
+	if f.method != "" && f.method != r.Method {
+		return false
+	}
+	if !f.path.MatchString(r.URL.Path) {
+		return false
+	}
+	got := r.URL.Query()
+	for name, values := range f.query {
+		for _, want := range values {
+			found := false
+			for _, v := range got[name] {
+				found = found || v == want
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Handler answers like the %s mock; requests no fixture matches get 404
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, f := range fixtures {
+			if !f.matches(r) {
+				continue
+			}
+			for name, values := range f.header {
+				w.Header()[name] = values
+			}
+			w.WriteHeader(f.status)
+			io.WriteString(w, f.body)
+			return
+		}
+		http.NotFound(w, r)
+	})
+}
+
+// NewServer starts an httptest server with Handler that is closed when the test ends
+func NewServer(t testing.TB) *httptest.Server {
+	srv := httptest.NewServer(Handler())
+	t.Cleanup(srv.Close)
+	return srv
+}
+`, opts.Project)
+
+	src, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return "", fmt.Errorf("generated Go fixtures do not parse: %w", err)
+	}
+	return string(src), nil
+}
+
+func goStrings(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
+	}
+	return "{" + strings.Join(quoted, ", ") + "}"
+}
+
+// mergeHeaders joins repeated header names into one entry, keeping first-seen order
+func mergeHeaders(headers []models.NameValues) []models.NameValues {
+	var out []models.NameValues
+	at := map[string]int{}
+	for _, h := range headers {
+		if i, ok := at[h.Name]; ok {
+			out[i].Values = append(out[i].Values, h.Values...)
+			continue
+		}
+		at[h.Name] = len(out)
+		out = append(out, models.NameValues{Name: h.Name, Values: append([]string(nil), h.Values...)})
+	}
+	return out
+}
+
+var mswMethods = map[string]string{"GET": "get", "POST": "post", "PUT": "put", "PATCH": "patch", "DELETE": "delete", "HEAD": "head", "OPTIONS": "options"}
+
+func mswFixtures(fixtures []fixture, opts FixtureOptions) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Generated by automock generate-fixtures from project %s. Do not edit.\n", opts.Project)
+	b.WriteString("// Mock Service Worker (v2) handlers: setupServer(...handlers) in tests, setupWorker(...handlers) in the browser.\n")
+	b.WriteString("import { http, HttpResponse } from 'msw'\n\n")
+	for _, f := range fixtures {
+		if len(f.query) > 0 {
+			b.WriteString(`const queryMatches = (request, query) => {
+  const params = new URL(request.url).searchParams
+  return Object.entries(query).every(([name, values]) => values.every((v) => params.getAll(name).includes(v)))
+}
+
+`)
+			break
+		}
+	}
+
+	b.WriteString("// In the mock's match order: the first handler that returns a response answers the request\n")
+	b.WriteString("export const handlers = [\n")
+	for _, f := range fixtures {
+		method, ok := mswMethods[f.method]
+		if !ok {
+			method = "all"
+		}
+		path := "'" + f.mswPath + "'"
+		if f.mswPath == "" || strings.ContainsAny(f.mswPath, "'\\") {
+			path = "new RegExp(" + jsLiteral("^[a-z]+://[^/]+(?:"+f.pathRegex+")(?:[?#].*)?$") + ")"
+		}
+		fmt.Fprintf(&b, "  // %s\n  http.%s(%s, ({ request }) => {\n", f.label, method, path)
+		if len(f.query) > 0 {
+			query := map[string][]string{}
+			for _, q := range f.query {
+				query[q.Name] = q.Values
+			}
+			raw, _ := json.Marshal(query)
+			fmt.Fprintf(&b, "    if (!queryMatches(request, %s)) return\n", raw)
+		}
+		init := fmt.Sprintf("{ status: %d", f.status)
+		if len(f.headers) > 0 {
+			pairs := make([]string, 0, len(f.headers))
+			for _, h := range f.headers {
+				for _, v := range h.Values {
+					pairs = append(pairs, fmt.Sprintf("[%s, %s]", jsLiteral(h.Name), jsLiteral(v)))
+				}
+			}
+			init += ", headers: [" + strings.Join(pairs, ", ") + "]"
+		}
+		init += " }"
+		switch {
+		case f.json && f.body != "":
+			fmt.Fprintf(&b, "    return HttpResponse.json(%s, %s)\n", f.body, init)
+		case f.body != "":
+			fmt.Fprintf(&b, "    return new HttpResponse(%s, %s)\n", jsLiteral(f.body), init)
+		default:
+			fmt.Fprintf(&b, "    return new HttpResponse(null, %s)\n", init)
+		}
+		b.WriteString("  }),\n")
+	}
+	b.WriteString("]\n")
+	return b.String()
+}
+
+var pythonMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+func pythonFixtures(fixtures []fixture, opts FixtureOptions) string {
+	name := PythonIdentifier(opts.Project) + "_mock"
+	var b strings.Builder
+	fmt.Fprintf(&b, `"""Fixtures generated by automock generate-fixtures from project %s. Do not edit.
+
+Call register(rsps) on a responses.RequestsMock, or request the %s pytest fixture.
+"""
+import re
+
+import pytest
+import responses
+from responses import matchers
+
+
+def register(rsps):
+    """Registers the responses of the %s mock in its match order."""
+`, opts.Project, name, opts.Project)
+	if len(fixtures) == 0 {
+		b.WriteString("    pass\n")
+	}
+	for _, f := range fixtures {
+		fmt.Fprintf(&b, "    # %s\n", f.label)
+		indent, method := "    ", jsLiteral(f.method)
+		if f.method == "" {
+			fmt.Fprintf(&b, "    for method in (%s):\n", strings.Join(pythonQuoted(pythonMethods), ", "))
+			indent, method = "        ", "method"
+		}
+		fmt.Fprintf(&b, "%srsps.add(\n", indent)
+		arg := func(format string, args ...any) {
+			fmt.Fprintf(&b, "%s    "+format+",\n", append([]any{indent}, args...)...)
+		}
+		arg("%s", method)
+		arg("re.compile(%s)", jsLiteral("^https?://[^/]+(?:"+f.pathRegex+")(?:[?#].*)?$"))
+		arg("status=%d", f.status)
+		contentType := fixtureHeader(f.headers, "Content-Type")
+		if contentType == "" {
+			contentType = "text/plain"
+		}
+		var headers []string
+		for _, h := range f.headers {
+			if strings.EqualFold(h.Name, "Content-Type") {
+				continue
+			}
+			for _, v := range h.Values {
+				headers = append(headers, fmt.Sprintf("(%s, %s)", jsLiteral(h.Name), jsLiteral(v)))
+			}
+		}
+		if len(headers) > 0 {
+			arg("headers=[%s]", strings.Join(headers, ", "))
+		}
+		arg("content_type=%s", jsLiteral(contentType))
+		if f.body != "" {
+			arg("body=%s", jsLiteral(f.body))
+		}
+		if len(f.query) > 0 {
+			params := make([]string, len(f.query))
+			for i, q := range f.query {
+				value := jsLiteral(q.Values[0])
+				if len(q.Values) > 1 {
+					value = "[" + strings.Join(pythonQuoted(q.Values), ", ") + "]"
+				}
+				params[i] = jsLiteral(q.Name) + ": " + value
+			}
+			arg("match=[matchers.query_param_matcher({%s}, strict_match=False)]", strings.Join(params, ", "))
+		}
+		fmt.Fprintf(&b, "%s)\n", indent)
+	}
+	fmt.Fprintf(&b, `
+
+@pytest.fixture
+def %s():
+    with responses.RequestsMock(assert_all_requests_are_fired=False) as rsps:
+        register(rsps)
+        yield rsps
+`, name)
+	return b.String()
+}
+
+func pythonQuoted(values []string) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = jsLiteral(v)
+	}
+	return out
+}
+
+var nonIdentifier = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// PythonIdentifier turns a project name into a Python identifier ("orders-api" → orders_api)
+func PythonIdentifier(s string) string {
+	id := strings.Trim(nonIdentifier.ReplaceAllString(strings.ToLower(s), "_"), "_")
+	if id == "" || (id[0] >= '0' && id[0] <= '9') {
+		id = "api_" + id
+	}
+	return id
+}
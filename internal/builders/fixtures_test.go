@@ -0,0 +1,87 @@
+package builders
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+func TestExpectationsToFixtures(t *testing.T) {
+	exps := []MockExpectation{
+		{
+			HttpRequest: &HttpRequest{Method: "GET", Path: "/users/{id}", Headers: []models.NameValues{{Name: "Authorization", Values: []string{"Bearer .*"}}}},
+			HttpResponse: &HttpResponse{StatusCode: 200, Body: map[string]any{"type": "JSON", "json": map[string]any{"id": 7}},
+				Cookies: []models.NameValues{{Name: "session", Values: []string{"s1"}}}},
+		},
+		{
+			Priority:     5,
+			HttpRequest:  &HttpRequest{Method: "GET", Path: "/users", QueryStringParameters: []models.NameValues{{Name: "page", Values: []string{"2"}}, {Name: "sort", Values: []string{"na.*"}}}},
+			HttpResponse: &HttpResponse{StatusCode: 200, Body: `[]`},
+		},
+		{HttpRequest: &HttpRequest{Path: "/files/.*"}, HttpResponse: &HttpResponse{StatusCode: 204}},
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/proxy"}, Forward: &models.HttpForward{Host: "api.example.com"}},
+	}
+
+	goSrc, warnings, err := ExpectationsToFixtures(exps, FixtureOptions{Lang: "go", Project: "users"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"package fixtures",
+		`regexp.MustCompile("^(?:/users/[^/]+)$")`,
+		`query: url.Values{"page": {"2"}}`,
+		`"Set-Cookie": {"session=s1"}, "Content-Type": {"application/json"}`,
+		`body: "{\"id\":7}"`,
+		`{method: "", path: regexp.MustCompile("^(?:/files/.*)$"), status: 204}`,
+	} {
+		if !strings.Contains(goSrc, want) {
+			t.Errorf("Go fixtures lack %s:\n%s", want, goSrc)
+		}
+	}
+	if strings.Index(goSrc, "/users/[^/]+") < strings.Index(goSrc, `"page"`) || strings.Contains(goSrc, "/proxy") {
+		t.Errorf("fixtures not in match order, or forwarded expectation written:\n%s", goSrc)
+	}
+	joined := strings.Join(warnings, "\n")
+	if !strings.Contains(joined, "sort=na.* is not a literal value") || !strings.Contains(joined, "GET /proxy: forwarded") || !strings.Contains(joined, "1 expectation(s) also match on headers") {
+		t.Errorf("warnings:\n%s", joined)
+	}
+
+	ts, _, err := ExpectationsToFixtures(exps, FixtureOptions{Lang: "ts", Project: "users"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"http.get('*/users', ({ request }) => {",
+		`if (!queryMatches(request, {"page":["2"]})) return`,
+		`return HttpResponse.json({"id":7}, { status: 200, headers: [["Set-Cookie", "session=s1"], ["Content-Type", "application/json"]] })`,
+		"http.get('*/users/:id'",
+		`http.all(new RegExp("^[a-z]+://[^/]+(?:/files/.*)(?:[?#].*)?$"), ({ request }) => {`,
+		"return new HttpResponse(null, { status: 204 })",
+	} {
+		if !strings.Contains(ts, want) {
+			t.Errorf("MSW handlers lack %s:\n%s", want, ts)
+		}
+	}
+
+	py, _, err := ExpectationsToFixtures(exps, FixtureOptions{Lang: "python", Project: "orders-api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`re.compile("^https?://[^/]+(?:/users/[^/]+)(?:[?#].*)?$"),`,
+		`headers=[("Set-Cookie", "session=s1")],`,
+		`content_type="application/json",`,
+		`match=[matchers.query_param_matcher({"page": "2"}, strict_match=False)],`,
+		`    for method in ("GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"):`,
+		"def orders_api_mock():",
+	} {
+		if !strings.Contains(py, want) {
+			t.Errorf("Python fixtures lack %s:\n%s", want, py)
+		}
+	}
+
+	if _, _, err := ExpectationsToFixtures(exps, FixtureOptions{Lang: "ruby"}); err == nil {
+		t.Error("unsupported language accepted")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
 	"github.com/hemantobora/auto-mock/internal/models"
 )
 
@@ -58,7 +59,7 @@ func BuildGraphQLExpectationWithContext() (MockExpectation, error) {
 
 	// Step 7: Optional status code
 	var status int
-	if err := survey.AskOne(&survey.Input{
+	if err := ask.One(&survey.Input{
 		Message: "HTTP status code? (default 200)",
 		Default: "200",
 	}, &status, survey.WithValidator(optionalIntValidator)); err == nil && status > 0 {
@@ -68,6 +69,13 @@ func BuildGraphQLExpectationWithContext() (MockExpectation, error) {
 	}
 	var mock_configurator MockConfigurator
 	mock_configurator.CollectResponseHeader(&exp)
+
+	// Step 8: Error variants clients can select to exercise GraphQL error handling
+	if models.GraphQLErrorsEligible(exp) {
+		if err := ConfigureGraphQLErrors(&exp); err != nil {
+			return exp, err
+		}
+	}
 	mock_configurator.CollectAdvancedFeatures(&exp)
 
 	if err := ReviewGraphQLExpectation(&exp); err != nil {
@@ -84,7 +92,7 @@ func collectGraphQLPath(req *HttpRequest) error {
 	if req.Path != "" {
 		defaultPath = req.Path
 	}
-	if err := survey.AskOne(&survey.Input{
+	if err := ask.One(&survey.Input{
 		Message: "GraphQL endpoint path:",
 		Default: defaultPath,
 		Help:    "Typically '/graphql'. Regex is allowed if you need flexibility.",
@@ -94,7 +102,7 @@ func collectGraphQLPath(req *HttpRequest) error {
 	path = strings.TrimSpace(path)
 	// Optionally allow regex
 	var useRegex bool
-	if err := survey.AskOne(&survey.Confirm{
+	if err := ask.One(&survey.Confirm{
 		Message: "Treat path as regex?",
 		Default: false,
 	}, &useRegex); err != nil {
@@ -111,7 +119,7 @@ func collectGraphQLPath(req *HttpRequest) error {
 
 func selectGraphQLMethod() (string, error) {
 	var method string
-	if err := survey.AskOne(&survey.Select{
+	if err := ask.One(&survey.Select{
 		Message: "HTTP method:",
 		Options: []string{"POST", "GET"},
 		Default: "POST",
@@ -122,7 +130,7 @@ func selectGraphQLMethod() (string, error) {
 }
 
 func collectGraphQLQueryAndOp() (query string, err error) {
-	if err = survey.AskOne(&survey.Multiline{
+	if err = ask.One(&survey.Multiline{
 		Message: "Paste GraphQL query (operation):",
 		Help:    "Example: query GetUser($id:ID!){ user(id:$id){ id name } }",
 	}, &query, survey.WithValidator(survey.Required)); err != nil {
@@ -134,7 +142,7 @@ func collectGraphQLQueryAndOp() (query string, err error) {
 
 func collectGraphQLVariables() (vars map[string]any, err error) {
 	var wantVars bool
-	if err = survey.AskOne(&survey.Confirm{
+	if err = ask.One(&survey.Confirm{
 		Message: "Add variables?",
 		Default: true,
 	}, &wantVars); err != nil {
@@ -144,7 +152,7 @@ func collectGraphQLVariables() (vars map[string]any, err error) {
 		return nil, nil
 	}
 	var raw string
-	if err = survey.AskOne(&survey.Multiline{
+	if err = ask.One(&survey.Multiline{
 		Message: "Variables JSON (e.g., {\"id\":\"123\"}):",
 	}, &raw); err != nil {
 		return nil, err
@@ -176,7 +184,7 @@ func applyPOSTRequest(req *HttpRequest, query string, variables map[string]any)
 	}
 
 	var mt string
-	if err := survey.AskOne(&survey.Select{
+	if err := ask.One(&survey.Select{
 		Message: "Match type for JSON:",
 		Options: []string{string(MatchOnlyMatchingFields), string(MatchStrict)},
 		Default: string(MatchOnlyMatchingFields),
@@ -210,7 +218,7 @@ func applyGETRequest(req *HttpRequest, query string, variables map[string]any) {
 func CollectGraphQLResponseJSON(body string, resp *HttpResponse) error {
 	var payload string
 	if body == "" {
-		if err := survey.AskOne(&survey.Multiline{
+		if err := ask.One(&survey.Multiline{
 			Message: "Response JSON payload (data / errors):",
 			Help:    `Example: {"data":{"user":{"id":"123","name":"Ada"}}}`,
 		}, &payload, survey.WithValidator(survey.Required)); err != nil {
@@ -0,0 +1,52 @@
+package builders
+
+import (
+	"fmt"
+
+	"github.com/hemantobora/auto-mock/internal/graphqlschema"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// DefaultGraphQLPath is the endpoint expectations generated from a schema answer on
+const DefaultGraphQLPath = "/graphql"
+
+// GraphQLSchemaExpectations builds one expectation per query and mutation of a schema: a POST
+// to path matched by operationName alone, answered with example data for the fields of its
+// operation document, plus the GraphQL errors and partial-data variants clients select with
+// the X-GraphQL-Error header. The document is kept in the notes so clients know what to send.
+func GraphQLSchemaExpectations(schema *graphqlschema.Schema, path string) []MockExpectation {
+	if path == "" {
+		path = DefaultGraphQLPath
+	}
+	var exps []MockExpectation
+	for _, op := range schema.Operations() {
+		description := op.Field.Description
+		if description == "" {
+			description = fmt.Sprintf("GraphQL %s %s", op.Type, op.Field.Name)
+		}
+		data := (&SchemaExampler{}).Example(schema.ResponseSchema(op))
+		exp := MockExpectation{
+			Description: description,
+			Notes:       fmt.Sprintf("Generated from the schema; clients send operationName %q, e.g.\n\n```graphql\n%s\n```", op.Name, schema.Document(op)),
+			HttpRequest: &HttpRequest{
+				Method: "POST",
+				Path:   path,
+				Body: map[string]any{
+					"type":      "JSON",
+					"json":      map[string]any{"operationName": op.Name},
+					"matchType": string(MatchOnlyMatchingFields),
+				},
+			},
+			HttpResponse: &HttpResponse{
+				StatusCode: 200,
+				Headers:    []models.NameValues{{Name: "Content-Type", Values: []string{"application/json"}}},
+				Body:       map[string]any{"type": "JSON", "json": map[string]any{"data": data}},
+			},
+		}
+		if models.GraphQLErrorsEligible(exp) {
+			exp.GraphQLErrors = &models.GraphQLErrors{Variants: append([]string(nil), models.GraphQLVariants...)}
+		}
+		exps = append(exps, exp)
+	}
+	return exps
+}
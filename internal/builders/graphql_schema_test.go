@@ -0,0 +1,55 @@
+package builders
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/graphqlschema"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+func TestGraphQLSchemaExpectations(t *testing.T) {
+	schema, err := graphqlschema.Parse("schema.graphql", []byte(`
+type Query {
+  "Find a user"
+  user(id: ID!): User
+}
+type Mutation { deleteUser(id: ID!): Boolean }
+type User { id: ID!, email: String, tags: [String] }
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exps := GraphQLSchemaExpectations(schema, "")
+	if len(exps) != 2 {
+		t.Fatalf("expectations = %d, want 2", len(exps))
+	}
+	user := exps[0]
+	if user.Description != "Find a user" || user.HttpRequest.Method != "POST" || user.HttpRequest.Path != "/graphql" {
+		t.Errorf("request = %s %s (%q)", user.HttpRequest.Method, user.HttpRequest.Path, user.Description)
+	}
+	body := user.HttpRequest.Body.(map[string]any)
+	if op := body["json"].(map[string]any)["operationName"]; op != "User" || body["matchType"] != "ONLY_MATCHING_FIELDS" {
+		t.Errorf("body matcher = %v", body)
+	}
+	if !strings.Contains(user.Notes, "query User($id: ID!)") {
+		t.Errorf("notes lack the operation document: %s", user.Notes)
+	}
+	data := user.HttpResponse.Body.(map[string]any)["json"].(map[string]any)["data"].(map[string]any)
+	got := data["user"].(map[string]any)
+	if got["email"] != "jane.doe@example.com" || len(got["tags"].([]any)) != 2 {
+		t.Errorf("user example = %v", got)
+	}
+	if exps[1].Description != "GraphQL mutation deleteUser" {
+		t.Errorf("mutation description = %q", exps[1].Description)
+	}
+
+	config := &models.MockConfiguration{Expectations: exps}
+	generated, err := config.ApplyGraphQLErrors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if generated != 4 {
+		t.Errorf("variants = %d, want 4 (errors and partial for both operations)", generated)
+	}
+}
@@ -0,0 +1,34 @@
+package builders
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatcherPresetsMatchTheirExamples(t *testing.T) {
+	for _, p := range matcherPresets {
+		re, err := regexp.Compile(`^(?:` + p.Pattern + `)$`)
+		if err != nil {
+			t.Errorf("%s: %v", p.Key, err)
+			continue
+		}
+		if !re.MatchString(p.Example) {
+			t.Errorf("%s: pattern %s rejects its example %q", p.Key, p.Pattern, p.Example)
+		}
+		if len(p.Targets) == 0 {
+			t.Errorf("%s: no targets", p.Key)
+		}
+	}
+
+	uuid, _ := FindMatcherPreset("uuid")
+	if re := regexp.MustCompile(`^(?:` + uuid.Pattern + `)$`); re.MatchString("1234") || re.MatchString("3f2b6c1e-8a4d-4e2f-9b7a-1c2d3e4f5a6") {
+		t.Error("uuid preset accepts a malformed UUID")
+	}
+	jwt, _ := FindMatcherPreset("bearer-jwt")
+	if re := regexp.MustCompile(`^(?:` + jwt.Pattern + `)$`); re.MatchString("Bearer abc123") {
+		t.Error("bearer-jwt preset accepts a token that is not a JWT")
+	}
+	if len(MatcherPresets(PresetHeader)) == 0 || len(MatcherPresets(PresetPath)) == 0 {
+		t.Error("expected presets for headers and path segments")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
 	"github.com/hemantobora/auto-mock/internal/models"
 )
 
@@ -63,9 +64,9 @@ func NewParametersBody(params []NameValues) map[string]any {
 func (mc *MockConfigurator) EditRequestBody(exp *MockExpectation) error {
 	// Choose matcher type
 	var kind string
-	if err := survey.AskOne(&survey.Select{
+	if err := ask.One(&survey.Select{
 		Message: "Choose body matcher type:",
-		Options: []string{"JSON", "REGEX", "PARAMETERS", "STRING (exact text)"},
+		Options: []string{"JSON", "REGEX", "PARAMETERS", "MULTIPART (form-data fields and files)", "STRING (exact text)"},
 		Default: "JSON",
 	}, &kind, survey.WithValidator(survey.Required)); err != nil {
 		return err
@@ -75,7 +76,7 @@ func (mc *MockConfigurator) EditRequestBody(exp *MockExpectation) error {
 	case kind == "JSON":
 		// Ask for JSON and optional matchType
 		var bodyJSON string
-		if err := survey.AskOne(&survey.Multiline{
+		if err := ask.One(&survey.Multiline{
 			Message: "Paste JSON to match (object/array):",
 			Help:    "We’ll wrap as {\"type\":\"JSON\",\"json\":...}.",
 		}, &bodyJSON); err != nil {
@@ -86,7 +87,7 @@ func (mc *MockConfigurator) EditRequestBody(exp *MockExpectation) error {
 		if !json.Valid([]byte(bodyJSON)) {
 			fmt.Println("⚠️  That is not valid JSON. You can still continue.")
 			var cont bool
-			if err := survey.AskOne(&survey.Confirm{
+			if err := ask.One(&survey.Confirm{
 				Message: "Continue anyway (stored as STRING exact match)?",
 				Default: false,
 			}, &cont); err != nil {
@@ -106,7 +107,7 @@ func (mc *MockConfigurator) EditRequestBody(exp *MockExpectation) error {
 		}
 
 		var mt string
-		if err := survey.AskOne(&survey.Select{
+		if err := ask.One(&survey.Select{
 			Message: "Match type for JSON:",
 			Options: []string{string(MatchOnlyMatchingFields), string(MatchStrict)},
 			Default: string(MatchOnlyMatchingFields),
@@ -119,7 +120,7 @@ func (mc *MockConfigurator) EditRequestBody(exp *MockExpectation) error {
 
 	case kind == "REGEX":
 		var pattern string
-		if err := survey.AskOne(&survey.Input{
+		if err := ask.One(&survey.Input{
 			Message: "Enter regex pattern (Go/RE2):",
 			Default: "^(foo|bar)-\\d{3}$",
 		}, &pattern, survey.WithValidator(survey.Required)); err != nil {
@@ -138,7 +139,7 @@ func (mc *MockConfigurator) EditRequestBody(exp *MockExpectation) error {
 		var items []NameValues
 		for {
 			var line string
-			if err := survey.AskOne(&survey.Input{
+			if err := ask.One(&survey.Input{
 				Message: "param (e.g. role=admin,user):",
 			}, &line); err != nil {
 				return err
@@ -169,9 +170,12 @@ func (mc *MockConfigurator) EditRequestBody(exp *MockExpectation) error {
 		exp.HttpRequest.Body = NewParametersBody(items)
 		return nil
 
+	case strings.HasPrefix(kind, "MULTIPART"):
+		return collectMultipartBody(exp)
+
 	default: // STRING (exact text)
 		var s string
-		if err := survey.AskOne(&survey.Multiline{
+		if err := ask.One(&survey.Multiline{
 			Message: "Paste exact body text to match:",
 		}, &s); err != nil {
 			return err
@@ -190,7 +194,7 @@ func (mc *MockConfigurator) CollectRequestBody(exp *MockExpectation, existing st
 	existing = strings.TrimSpace(existing)
 	if existing != "" {
 		var useBody bool
-		if err := survey.AskOne(&survey.Confirm{
+		if err := ask.One(&survey.Confirm{
 			Message: "Use existing request body text as EXACT match?\n" + existing,
 			Default: false,
 			Help:    "This matches the body as raw text (STRING).",
@@ -217,7 +221,7 @@ func (mc *MockConfigurator) CollectRequestBody(exp *MockExpectation, existing st
 	}
 
 	var needsBody bool
-	if err := survey.AskOne(&survey.Confirm{
+	if err := ask.One(&survey.Confirm{
 		Message: "Do you want to match a request body?",
 		Default: false,
 		Help:    "Choose ‘No’ to skip body matching.",
@@ -239,7 +243,7 @@ func (mc *MockConfigurator) CollectQueryParameterMatching(exp *MockExpectation)
 		fmt.Printf("ℹ️  Already configured %d query parameters from path\n", n)
 
 		var addMore bool
-		if err := survey.AskOne(&survey.Confirm{
+		if err := ask.One(&survey.Confirm{
 			Message: "Add additional query parameters?",
 			Default: false,
 		}, &addMore); err != nil {
@@ -251,7 +255,7 @@ func (mc *MockConfigurator) CollectQueryParameterMatching(exp *MockExpectation)
 		}
 	} else {
 		var needs bool
-		if err := survey.AskOne(&survey.Confirm{
+		if err := ask.One(&survey.Confirm{
 			Message: "Does this endpoint require specific query parameters?",
 			Default: false,
 			Help:    "Only specify if you need to match exact query parameter values",
@@ -267,7 +271,7 @@ func (mc *MockConfigurator) CollectQueryParameterMatching(exp *MockExpectation)
 
 	for {
 		var name string
-		if err := survey.AskOne(&survey.Input{
+		if err := ask.One(&survey.Input{
 			Message: "Parameter name (empty to finish):",
 			Help:    "e.g., 'page', 'limit', 'category'",
 		}, &name); err != nil {
@@ -278,8 +282,18 @@ func (mc *MockConfigurator) CollectQueryParameterMatching(exp *MockExpectation)
 			break
 		}
 
+		preset, err := SelectMatcherPreset(PresetQuery, fmt.Sprintf("'%s'", name), name)
+		if err != nil {
+			return err
+		}
+		if preset != nil {
+			SetNameValues(&exp.HttpRequest.QueryStringParameters, name, []string{preset.Pattern})
+			fmt.Printf("✅ Added: %s=%s (%s)\n", name, preset.Pattern, preset.Label)
+			continue
+		}
+
 		var value string
-		if err := survey.AskOne(&survey.Input{
+		if err := ask.One(&survey.Input{
 			Message: fmt.Sprintf("Value(s) for '%s' (comma-separated, regex allowed):", name),
 			Help:    "Example: admin,user  or  ^cat.*$",
 		}, &value); err != nil {
@@ -352,7 +366,7 @@ func (mc *MockConfigurator) CollectPathMatchingStrategy(exp *MockExpectation) er
 	// ─────────────────────────────────────────────────────────────────────────────
 	if !hasBraces {
 		var useRegex bool
-		if err := survey.AskOne(&survey.Confirm{
+		if err := ask.One(&survey.Confirm{
 			Message: "Use regex pattern matching for this path?",
 			Default: false,
 			Help:    "Regex allows flexible matching (e.g. ^/users/[a-z0-9-]+/posts$).",
@@ -362,7 +376,7 @@ func (mc *MockConfigurator) CollectPathMatchingStrategy(exp *MockExpectation) er
 
 		if useRegex {
 			var pattern string
-			if err := survey.AskOne(&survey.Input{
+			if err := ask.One(&survey.Input{
 				Message: "Enter regex for path (as a string):",
 				Default: regexp.QuoteMeta(rawPath),
 			}, &pattern, survey.WithValidator(survey.Required)); err != nil {
@@ -407,8 +421,18 @@ func (mc *MockConfigurator) CollectPathMatchingStrategy(exp *MockExpectation) er
 		}
 		seen[name] = true
 
+		preset, err := SelectMatcherPreset(PresetPath, fmt.Sprintf("{%s}", name), "")
+		if err != nil {
+			return err
+		}
+		if preset != nil {
+			exp.HttpRequest.PathParameters[name] = []string{preset.Pattern}
+			fmt.Printf("✅ {%s}: %s (%s)\n", name, preset.Label, preset.Pattern)
+			continue
+		}
+
 		var valuesLine string
-		if err := survey.AskOne(&survey.Input{
+		if err := ask.One(&survey.Input{
 			Message: fmt.Sprintf("Regex or comma-separated values for {%s}:", name),
 			Default: "[^/]+", // common “any segment” regex
 			Help:    "Examples → values: 123,456  • regex: ^[0-9]{1,6}$  • simple: [A-Z0-9\\-]+",
@@ -455,7 +479,7 @@ func (mc *MockConfigurator) CollectResponseHeader(exp *MockExpectation) error {
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
 	var needsHeaders bool
-	if err := survey.AskOne(&survey.Confirm{
+	if err := ask.One(&survey.Confirm{
 		Message: "Does this response require specific headers?",
 		Default: false,
 		Help:    "e.g., Content-Type, CORS headers",
@@ -469,7 +493,7 @@ func (mc *MockConfigurator) CollectResponseHeader(exp *MockExpectation) error {
 
 	for {
 		var headerName string
-		if err := survey.AskOne(&survey.Input{
+		if err := ask.One(&survey.Input{
 			Message: "Header name (empty to finish):",
 			Help:    "e.g., 'Content-Type'",
 			Default: "Content-Type",
@@ -482,7 +506,7 @@ func (mc *MockConfigurator) CollectResponseHeader(exp *MockExpectation) error {
 		}
 
 		var headerValue string
-		if err := survey.AskOne(&survey.Input{
+		if err := ask.One(&survey.Input{
 			Message: fmt.Sprintf("Value for '%s':", headerName),
 			Help:    "e.g., application/json",
 			Default: "application/json",
@@ -523,7 +547,7 @@ func (mc *MockConfigurator) CollectRequestHeaderMatching(exp *models.MockExpecta
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
 	var needsHeaders bool
-	if err := survey.AskOne(&survey.Confirm{
+	if err := ask.One(&survey.Confirm{
 		Message: "Does this request require specific headers to match?",
 		Default: false,
 		Help:    "e.g., Authorization, Content-Type, API keys",
@@ -542,7 +566,7 @@ func (mc *MockConfigurator) CollectRequestHeaderMatching(exp *models.MockExpecta
 
 	for {
 		var headerName string
-		if err := survey.AskOne(&survey.Input{
+		if err := ask.One(&survey.Input{
 			Message: "Header name (empty to finish):",
 			Help:    "e.g., 'Authorization', 'Content-Type'",
 		}, &headerName); err != nil {
@@ -553,14 +577,23 @@ func (mc *MockConfigurator) CollectRequestHeaderMatching(exp *models.MockExpecta
 			break
 		}
 
-		var valuesCSV string
-		if err := survey.AskOne(&survey.Input{
-			Message: fmt.Sprintf("Exact value(s) for '%s' (comma-separated for multiple):", headerName),
-			Help:    "Examples: 'Bearer abc123' or 'application/json, application/xml'",
-		}, &valuesCSV); err != nil {
+		preset, err := SelectMatcherPreset(PresetHeader, fmt.Sprintf("'%s'", headerName), headerName)
+		if err != nil {
 			return err
 		}
-		values := parseCSVValues(valuesCSV)
+		var values []string
+		if preset != nil {
+			values = []string{preset.Pattern}
+		} else {
+			var valuesCSV string
+			if err := ask.One(&survey.Input{
+				Message: fmt.Sprintf("Exact value(s) for '%s' (comma-separated for multiple):", headerName),
+				Help:    "Examples: 'Bearer abc123' or 'application/json, application/xml'",
+			}, &valuesCSV); err != nil {
+				return err
+			}
+			values = parseCSVValues(valuesCSV)
+		}
 
 		// upsert into []NameValues
 		if idx := headerIndex(exp.HttpRequest.Headers, headerName); idx >= 0 {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
 )
 
 // FeatureFunc represents a function that configures a feature on an expectation
@@ -67,6 +68,54 @@ func Registry() []Category {
 					Apply:       applyCompression(),
 					Description: "Enable gzip/deflate compression",
 				},
+				{
+					Key:         "content-negotiation",
+					Label:       "Content Negotiation (Accept)",
+					Apply:       applyNegotiation(),
+					Description: "Also serve the JSON body as XML or CSV when the Accept header asks",
+				},
+				{
+					Key:         "conditional-get",
+					Label:       "Conditional GET (ETag / 304)",
+					Apply:       applyConditionalGet(),
+					Description: "Send an ETag and answer If-None-Match revalidations with 304 Not Modified",
+				},
+				{
+					Key:         "range-requests",
+					Label:       "Range Requests (206 Partial Content)",
+					Apply:       applyRanges(),
+					Description: "Serve byte ranges of the body with Content-Range, e.g. for downloads and media",
+				},
+				{
+					Key:         "redirect-chain",
+					Label:       "Redirect Chain (3xx)",
+					Apply:       applyRedirects(),
+					Description: "Redirect through hops served by the mock, setting cookies and carrying query parameters (OAuth, payments)",
+				},
+				{
+					Key:         "graphql-errors",
+					Label:       "GraphQL Error Variants",
+					Apply:       applyGraphQLErrors(),
+					Description: "Also answer with a GraphQL errors array (null or partial data) when a header or variable asks",
+				},
+				{
+					Key:         "streaming",
+					Label:       "Streaming (WebSocket / SSE)",
+					Apply:       applyStreaming(),
+					Description: "Play a WebSocket message exchange or a Server-Sent Events sequence (automock serve)",
+				},
+				{
+					Key:         "response-rules",
+					Label:       "Response Rules (Routing)",
+					Apply:       applyRules(),
+					Description: "Serve other responses when request headers, query parameters or body fields meet conditions",
+				},
+				{
+					Key:         "response-script",
+					Label:       "Response Script (JavaScript)",
+					Apply:       applyResponseScript(),
+					Description: "Compute the response from the request at serve time",
+				},
 			},
 		},
 		{
@@ -97,6 +146,12 @@ func Registry() []Category {
 					Apply:       closeSocket(),
 					Description: "Forcefully close the connection after response",
 				},
+				{
+					Key:         "faults",
+					Label:       "Fault Injection (Chaos)",
+					Apply:       applyFaults(),
+					Description: "Random 5xx errors, jittered latency, connection resets and truncated bodies",
+				},
 			},
 		},
 	}
@@ -119,7 +174,7 @@ func PickFeaturesInteractively(reg []Category) ([]FeatureItem, error) {
 	}
 
 	var chosenCatLabels []string
-	if err := survey.AskOne(&survey.MultiSelect{
+	if err := ask.One(&survey.MultiSelect{
 		Message: "Select feature categories:",
 		Options: catLabels,
 		Help:    "Use SPACE to select, ENTER to confirm. Choose categories that interest you.",
@@ -150,7 +205,7 @@ func PickFeaturesInteractively(reg []Category) ([]FeatureItem, error) {
 		}
 
 		var chosenFeatLabels []string
-		if err := survey.AskOne(&survey.MultiSelect{
+		if err := ask.One(&survey.MultiSelect{
 			Message: fmt.Sprintf("Select features from '%s':", cat.Label),
 			Options: featOptions,
 			Help:    "Use SPACE to select multiple, ENTER to confirm",
@@ -194,7 +249,7 @@ func ApplySelectedFeatures(exp *MockExpectation, features []FeatureItem) error {
 
 			// Ask if they want to continue
 			var continueAnyway bool
-			if err := survey.AskOne(&survey.Confirm{
+			if err := ask.One(&survey.Confirm{
 				Message: "Continue with remaining features?",
 				Default: true,
 			}, &continueAnyway); err != nil || !continueAnyway {
@@ -220,7 +275,7 @@ func ApplySelectedFeatures(exp *MockExpectation, features []FeatureItem) error {
 // CollectAdvancedFeaturesInteractive is the main entry point for feature selection and application
 func CollectAdvancedFeaturesInteractive(mc *MockConfigurator, exp *MockExpectation) error {
 	var wantsAdvanced bool
-	if err := survey.AskOne(&survey.Confirm{
+	if err := ask.One(&survey.Confirm{
 		Message: "Configure advanced MockServer features?",
 		Default: false,
 		Help:    "Delays, callbacks, connection control, testing patterns, and more",
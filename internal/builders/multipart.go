@@ -0,0 +1,260 @@
+package builders
+
+import (
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// MultipartContentType is the Content-Type request header matcher paired with multipart
+// body matchers (MockServer header values are regexes, so any boundary matches)
+const MultipartContentType = "multipart/form-data.*"
+
+// maxBinaryBodyBytes caps binary response bodies; they are stored base64-encoded in the
+// project's expectations
+const maxBinaryBodyBytes = 10 << 20
+
+// multipartDisposition starts the regex of one part; names and filenames follow
+const multipartDisposition = `[Cc]ontent-[Dd]isposition: *form-data; *name="`
+
+// MultipartField is a form-data part a multipart request must carry
+type MultipartField struct {
+	Name     string // form field name
+	File     bool   // an uploaded file: the part has a filename
+	Filename string // glob the file name must match, e.g. *.pdf (empty = any name)
+}
+
+// NewMultipartBody matches multipart/form-data requests carrying every field, in the order
+// given. MockServer has no multipart matcher, so the raw body is matched with a regex over
+// the parts' Content-Disposition headers; file contents are not compared.
+func NewMultipartBody(fields []MultipartField) map[string]any {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = multipartDisposition + regexp.QuoteMeta(f.Name) + `"`
+		if f.File {
+			parts[i] += `; *filename="` + filenameRegex(f.Filename) + `"`
+		} else {
+			parts[i] += `\r?\n`
+		}
+	}
+	return NewRegexBody("(?s).*" + strings.Join(parts, ".*") + ".*")
+}
+
+// MultipartFields reads the fields back from a body made by NewMultipartBody
+func MultipartFields(body any) ([]MultipartField, bool) {
+	b, ok := body.(map[string]any)
+	if !ok || !strings.EqualFold(fmt.Sprint(b["type"]), "REGEX") {
+		return nil, false
+	}
+	pattern, _ := b["regex"].(string)
+	if !strings.HasPrefix(pattern, "(?s).*"+multipartDisposition) {
+		return nil, false
+	}
+	var fields []MultipartField
+	for _, part := range strings.Split(pattern, multipartDisposition)[1:] {
+		end := strings.Index(part, `"`)
+		if end < 0 {
+			return nil, false
+		}
+		f := MultipartField{Name: unquoteMeta(part[:end])}
+		if rest := part[end+1:]; strings.HasPrefix(rest, `; *filename="`) {
+			f.File = true
+			rest = strings.TrimPrefix(rest, `; *filename="`)
+			if stop := strings.LastIndex(rest, `"`); stop >= 0 {
+				rest = rest[:stop]
+			}
+			if f.Filename = filenameGlob(rest); f.Filename == "*" {
+				f.Filename = ""
+			}
+		}
+		fields = append(fields, f)
+	}
+	return fields, len(fields) > 0
+}
+
+// DescribeMultipartFields summarizes fields, e.g. `file "avatar" (*.png), field "title"`
+func DescribeMultipartFields(fields []MultipartField) string {
+	out := make([]string, len(fields))
+	for i, f := range fields {
+		switch {
+		case f.File && f.Filename != "":
+			out[i] = fmt.Sprintf("file %q (%s)", f.Name, f.Filename)
+		case f.File:
+			out[i] = fmt.Sprintf("file %q", f.Name)
+		default:
+			out[i] = fmt.Sprintf("field %q", f.Name)
+		}
+	}
+	return strings.Join(out, ", ")
+}
+
+// filenameRegex turns a filename glob into a regex: * is any run of characters and ? one
+func filenameRegex(glob string) string {
+	if glob == "" {
+		glob = "*"
+	}
+	var b strings.Builder
+	for _, c := range glob {
+		switch c {
+		case '*':
+			b.WriteString(`[^"]*`)
+		case '?':
+			b.WriteString(`[^"]`)
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
+
+func filenameGlob(re string) string {
+	re = strings.ReplaceAll(strings.ReplaceAll(re, `[^"]*`, "*"), `[^"]`, "?")
+	return unquoteMeta(re)
+}
+
+// unquoteMeta reverses regexp.QuoteMeta
+func unquoteMeta(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// collectMultipartBody asks for the form fields a multipart request must carry and matches
+// the Content-Type header as multipart/form-data
+func collectMultipartBody(exp *MockExpectation) error {
+	fmt.Println("Enter the form fields the request must carry, in form order. Empty name to finish.")
+	var fields []MultipartField
+	for {
+		var name string
+		if err := ask.One(&survey.Input{Message: "Form field name (e.g. avatar):"}, &name); err != nil {
+			return err
+		}
+		if name = strings.TrimSpace(name); name == "" {
+			break
+		}
+		f := MultipartField{Name: name}
+		if err := ask.One(&survey.Confirm{
+			Message: fmt.Sprintf("Is %q a file upload?", name),
+			Default: len(fields) == 0,
+		}, &f.File); err != nil {
+			return err
+		}
+		if f.File {
+			if err := ask.One(&survey.Input{
+				Message: "File name pattern (optional):",
+				Help:    "A glob such as *.pdf or report-??.csv; leave empty to accept any file name.",
+			}, &f.Filename); err != nil {
+				return err
+			}
+			f.Filename = strings.TrimSpace(f.Filename)
+		}
+		fields = append(fields, f)
+	}
+	if len(fields) == 0 {
+		return fmt.Errorf("no form fields provided")
+	}
+	exp.HttpRequest.Body = NewMultipartBody(fields)
+	SetNameValues(&exp.HttpRequest.Headers, "Content-Type", []string{MultipartContentType})
+	fmt.Printf("✅ Multipart request matching: %s\n", DescribeMultipartFields(fields))
+	return nil
+}
+
+// CollectBinaryResponseBody sets a binary response body (image, PDF, archive, ...) from a file
+// or base64 data, with its Content-Type header
+func CollectBinaryResponseBody(exp *MockExpectation) error {
+	var source string
+	if err := ask.One(&survey.Input{
+		Message: "File path, or base64-encoded bytes:",
+		Help:    "e.g. ./fixtures/avatar.png, or data:image/png;base64,iVBOR... The bytes are stored base64-encoded with the expectation.",
+	}, &source, survey.WithValidator(survey.Required)); err != nil {
+		return err
+	}
+	data, detected, filename, err := readBinarySource(strings.TrimSpace(source))
+	if err != nil {
+		return err
+	}
+	if len(data) > maxBinaryBodyBytes {
+		return fmt.Errorf("binary body is %d bytes; the limit is %d MB", len(data), maxBinaryBodyBytes>>20)
+	}
+
+	var contentType string
+	if err := ask.One(&survey.Input{
+		Message: "Content-Type:",
+		Default: detected,
+	}, &contentType, survey.WithValidator(survey.Required)); err != nil {
+		return err
+	}
+	if exp.HttpResponse == nil {
+		exp.HttpResponse = &HttpResponse{}
+	}
+	exp.HttpResponse.Body = models.BinaryBody(data, contentType)
+	exp.HttpResponse.SchemaRef = ""
+	SetNameValues(&exp.HttpResponse.Headers, "Content-Type", []string{contentType})
+
+	if filename != "" {
+		var download bool
+		if err := ask.One(&survey.Confirm{
+			Message: "Serve it as a download (Content-Disposition: attachment)?",
+			Default: false,
+		}, &download); err != nil {
+			return err
+		}
+		if download {
+			SetNameValues(&exp.HttpResponse.Headers, "Content-Disposition", []string{fmt.Sprintf("attachment; filename=%q", filename)})
+		}
+	}
+	fmt.Printf("✅ Binary response body: %d bytes (%s)\n", len(data), contentType)
+	return nil
+}
+
+// readBinarySource reads a file, a data: URI or plain base64, and guesses the content type
+// from the file extension, the data URI or the bytes themselves
+func readBinarySource(source string) (data []byte, contentType, filename string, err error) {
+	if info, statErr := os.Stat(source); statErr == nil && !info.IsDir() {
+		if data, err = os.ReadFile(source); err != nil {
+			return nil, "", "", err
+		}
+		filename = filepath.Base(source)
+		contentType = mime.TypeByExtension(filepath.Ext(source))
+	} else {
+		encoded := source
+		if rest, ok := strings.CutPrefix(source, "data:"); ok {
+			meta, payload, found := strings.Cut(rest, ",")
+			if !found || !strings.HasSuffix(meta, ";base64") {
+				return nil, "", "", fmt.Errorf("only base64 data: URIs are supported")
+			}
+			contentType, encoded = strings.TrimSuffix(meta, ";base64"), payload
+		}
+		encoded = strings.Join(strings.Fields(encoded), "")
+		if data, err = base64.StdEncoding.DecodeString(encoded); err != nil {
+			if data, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(encoded, "=")); err != nil {
+				return nil, "", "", fmt.Errorf("%q is neither a readable file nor valid base64", truncateSource(source))
+			}
+		}
+	}
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	return data, contentType, filename, nil
+}
+
+func truncateSource(s string) string {
+	if len(s) > 40 {
+		return s[:40] + "..."
+	}
+	return s
+}
@@ -0,0 +1,103 @@
+package builders
+
+import (
+	"bytes"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/matcher"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// multipartRequest builds an upload the way browsers and HTTP clients send it
+func multipartRequest(t *testing.T, filename string, fields ...string) *matcher.Request {
+	t.Helper()
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for _, f := range fields {
+		if err := w.WriteField(f, "value"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if filename != "" {
+		part, err := w.CreateFormFile("avatar", filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write([]byte{0x89, 'P', 'N', 'G', 0xff, '\n', 0x00})
+	}
+	w.Close()
+	return &matcher.Request{
+		Method:  "POST",
+		Path:    "/upload",
+		Headers: map[string][]string{"Content-Type": {w.FormDataContentType()}},
+		Body:    buf.Bytes(),
+	}
+}
+
+func TestMultipartBody(t *testing.T) {
+	fields := []MultipartField{{Name: "title"}, {Name: "avatar", File: true, Filename: "*.png"}}
+	exp := &models.MockExpectation{HttpRequest: &models.HttpRequest{
+		Method:  "POST",
+		Path:    "/upload",
+		Headers: []models.NameValues{{Name: "Content-Type", Values: []string{MultipartContentType}}},
+		Body:    NewMultipartBody(fields),
+	}}
+
+	for name, tc := range map[string]struct {
+		req  *matcher.Request
+		want bool
+	}{
+		"all fields":        {multipartRequest(t, "me.png", "title"), true},
+		"extra fields":      {multipartRequest(t, "me.png", "title", "caption"), true},
+		"missing field":     {multipartRequest(t, "me.png"), false},
+		"wrong file type":   {multipartRequest(t, "me.gif", "title"), false},
+		"missing file part": {multipartRequest(t, "", "title"), false},
+	} {
+		if got := matcher.Evaluate(exp, tc.req).Matched; got != tc.want {
+			t.Errorf("%s: matched = %v, want %v", name, got, tc.want)
+		}
+	}
+
+	got, ok := MultipartFields(exp.HttpRequest.Body)
+	if !ok || !reflect.DeepEqual(got, fields) {
+		t.Errorf("MultipartFields = %+v, %v", got, ok)
+	}
+	if anyName, _ := MultipartFields(NewMultipartBody([]MultipartField{{Name: "doc.v1", File: true}})); anyName[0].Filename != "" || anyName[0].Name != "doc.v1" {
+		t.Errorf("any-name file read back as %+v", anyName)
+	}
+	if _, ok := MultipartFields(NewRegexBody(".*")); ok {
+		t.Error("a plain regex body is not a multipart matcher")
+	}
+	if got := DescribeMultipartFields(fields); got != `field "title", file "avatar" (*.png)` {
+		t.Errorf("description = %s", got)
+	}
+}
+
+func TestReadBinarySource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.pdf")
+	if err := os.WriteFile(path, []byte("%PDF-1.7"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	data, contentType, filename, err := readBinarySource(path)
+	if err != nil || string(data) != "%PDF-1.7" || contentType != "application/pdf" || filename != "report.pdf" {
+		t.Errorf("file: %q %q %q %v", data, contentType, filename, err)
+	}
+
+	data, contentType, filename, err = readBinarySource("data:image/gif;base64,R0lGODlh")
+	if err != nil || string(data) != "GIF89a" || contentType != "image/gif" || filename != "" {
+		t.Errorf("data URI: %q %q %q %v", data, contentType, filename, err)
+	}
+
+	data, contentType, _, err = readBinarySource("iVBORw0KGgo")
+	if err != nil || contentType != "image/png" || len(data) != 8 {
+		t.Errorf("unpadded base64: %q %q %v", data, contentType, err)
+	}
+
+	if _, _, _, err := readBinarySource("./missing.png"); err == nil {
+		t.Error("a missing file that is not base64 must fail")
+	}
+}
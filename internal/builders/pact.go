@@ -0,0 +1,385 @@
+package builders
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// PactStateTag prefixes tags that name a provider state explicitly ("state:user 7 exists")
+const PactStateTag = "state:"
+
+// pactFile is a Pact specification v3 contract between one consumer and one provider
+type pactFile struct {
+	Consumer     pactParty         `json:"consumer"`
+	Provider     pactParty         `json:"provider"`
+	Interactions []pactInteraction `json:"interactions"`
+	Metadata     map[string]any    `json:"metadata"`
+}
+
+type pactParty struct {
+	Name string `json:"name"`
+}
+
+type pactInteraction struct {
+	Description    string       `json:"description"`
+	ProviderStates []pactState  `json:"providerStates,omitempty"`
+	Request        pactRequest  `json:"request"`
+	Response       pactResponse `json:"response"`
+}
+
+type pactState struct {
+	Name   string         `json:"name"`
+	Params map[string]any `json:"params,omitempty"`
+}
+
+type pactRequest struct {
+	Method        string              `json:"method"`
+	Path          string              `json:"path"`
+	Query         map[string][]string `json:"query,omitempty"`
+	Headers       map[string]string   `json:"headers,omitempty"`
+	Body          any                 `json:"body,omitempty"`
+	MatchingRules map[string]any      `json:"matchingRules,omitempty"`
+}
+
+type pactResponse struct {
+	Status        int               `json:"status"`
+	Headers       map[string]string `json:"headers,omitempty"`
+	Body          any               `json:"body,omitempty"`
+	MatchingRules map[string]any    `json:"matchingRules,omitempty"`
+}
+
+// ExpectationsToPactJSON converts expectations into a Pact v3 contract file. Each expectation
+// becomes one interaction in MockServer's match order; its provider states are its "state:"
+// tags, else its iteration variant, else one state per tag. Regex and templated paths, query
+// values and headers are written as an example value plus a regex matching rule, and response
+// bodies are matched by type so the provider may return other values. Forwarded and generated
+// companion expectations are skipped; everything dropped is reported as a warning.
+func ExpectationsToPactJSON(expectations []MockExpectation, consumer, provider string) (string, []string) {
+	pact := pactFile{
+		Consumer:     pactParty{Name: consumer},
+		Provider:     pactParty{Name: provider},
+		Interactions: []pactInteraction{},
+		Metadata: map[string]any{
+			"pactSpecification": map[string]string{"version": "3.0.0"},
+			"automock":          map[string]string{"source": "automock export --format pact"},
+		},
+	}
+	var warnings []string
+	described := map[string]int{}
+	for _, i := range matchOrder(expectations) {
+		exp := expectations[i]
+		if exp.HttpRequest == nil || exp.Generated() || exp.HttpRequest.Path == models.ContractInfoPath {
+			continue
+		}
+		label := strings.TrimSpace(exp.HttpRequest.Method + " " + exp.HttpRequest.Path)
+		interaction, notes, ok := pactInteractionFor(exp)
+		for _, note := range notes {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", label, note))
+		}
+		if !ok {
+			continue
+		}
+		// Pact identifies interactions by description
+		described[interaction.Description]++
+		if n := described[interaction.Description]; n > 1 {
+			interaction.Description = fmt.Sprintf("%s (%d)", interaction.Description, n)
+		}
+		pact.Interactions = append(pact.Interactions, interaction)
+	}
+
+	data, err := json.MarshalIndent(pact, "", "  ")
+	if err != nil {
+		return `{"interactions": []}`, append(warnings, fmt.Sprintf("failed to marshal the pact: %v", err))
+	}
+	return string(data), warnings
+}
+
+func pactInteractionFor(exp MockExpectation) (pactInteraction, []string, bool) {
+	var notes []string
+	req := exp.HttpRequest
+	switch {
+	case exp.Forward != nil:
+		return pactInteraction{}, []string{"forwarded to an upstream; no interaction written"}, false
+	case exp.HttpResponse == nil:
+		return pactInteraction{}, []string{"no static response; no interaction written"}, false
+	}
+	if exp.HttpResponseTemplate != nil {
+		notes = append(notes, "JavaScript response template not convertible; exported its static fallback response")
+	}
+
+	in := pactInteraction{Description: exp.Description, ProviderStates: pactStates(exp)}
+	if in.Description == "" {
+		in.Description = strings.TrimSpace(req.Method + " " + req.Path)
+		if exp.Variant != "" {
+			in.Description += " with " + exp.Variant
+		}
+	}
+
+	// Request
+	in.Request.Method = strings.ToUpper(req.Method)
+	if in.Request.Method == "" {
+		in.Request.Method = http.MethodGet
+		notes = append(notes, "matches any method; Pact needs one, exported as GET")
+	}
+	rules := map[string]any{}
+	path, pattern, ok := pactPath(exp)
+	if !ok {
+		return pactInteraction{}, append(notes, "regex path without an example request; no interaction written"), false
+	}
+	in.Request.Path = path
+	if pattern != "" {
+		rules["path"] = pactRegexRule(pattern)
+	}
+	queryRules := map[string]any{}
+	for _, q := range req.QueryStringParameters {
+		for _, v := range q.Values {
+			example, regex, note := pactValue(v)
+			if note != "" {
+				notes = append(notes, fmt.Sprintf("query %s=%s %s", q.Name, v, note))
+				continue
+			}
+			if in.Request.Query == nil {
+				in.Request.Query = map[string][]string{}
+			}
+			in.Request.Query[q.Name] = append(in.Request.Query[q.Name], example)
+			if regex != "" {
+				queryRules[q.Name] = pactRegexRule(regex)
+			}
+		}
+	}
+	if len(queryRules) > 0 {
+		rules["query"] = queryRules
+	}
+	headerRules := map[string]any{}
+	for _, h := range req.Headers {
+		if len(h.Values) == 0 {
+			continue
+		}
+		example, regex, note := pactValue(h.Values[0])
+		if note != "" {
+			notes = append(notes, fmt.Sprintf("header %s: %s %s", h.Name, h.Values[0], note))
+			continue
+		}
+		if in.Request.Headers == nil {
+			in.Request.Headers = map[string]string{}
+		}
+		in.Request.Headers[h.Name] = example
+		if regex != "" {
+			headerRules[h.Name] = pactRegexRule(regex)
+		}
+	}
+	if len(headerRules) > 0 {
+		rules["header"] = headerRules
+	}
+	if len(rules) > 0 {
+		in.Request.MatchingRules = rules
+	}
+	if req.Body != nil {
+		body, contentType, note := pactRequestBody(req.Body)
+		if note != "" {
+			notes = append(notes, note)
+		}
+		in.Request.Body = body
+		if body != nil && contentType != "" && fixtureHeader(req.Headers, "Content-Type") == "" {
+			if in.Request.Headers == nil {
+				in.Request.Headers = map[string]string{}
+			}
+			in.Request.Headers["Content-Type"] = contentType
+		}
+	}
+
+	// Response
+	resp := exp.HttpResponse
+	in.Response.Status = resp.StatusCode
+	if in.Response.Status == 0 {
+		in.Response.Status = http.StatusOK
+	}
+	for _, h := range resp.Headers {
+		if in.Response.Headers == nil {
+			in.Response.Headers = map[string]string{}
+		}
+		in.Response.Headers[h.Name] = strings.Join(h.Values, ", ")
+	}
+	text, isJSON, note := fixtureBody(resp.Body)
+	if note != "" {
+		notes = append(notes, note)
+	}
+	if strings.Contains(text, "$!") {
+		notes = append(notes, "response placeholders ($!...) are written as-is")
+	}
+	switch {
+	case text == "":
+	case isJSON:
+		var v any
+		_ = json.Unmarshal([]byte(text), &v)
+		in.Response.Body = v
+		in.Response.MatchingRules = map[string]any{"body": map[string]any{"$": map[string]any{"matchers": []any{map[string]any{"match": "type"}}}}}
+		if fixtureHeader(resp.Headers, "Content-Type") == "" {
+			if in.Response.Headers == nil {
+				in.Response.Headers = map[string]string{}
+			}
+			in.Response.Headers["Content-Type"] = "application/json"
+		}
+	default:
+		in.Response.Body = text
+	}
+	return in, notes, true
+}
+
+// pactStates derives the provider states of an expectation
+func pactStates(exp MockExpectation) []pactState {
+	var states []pactState
+	for _, tag := range exp.Tags {
+		if name, ok := strings.CutPrefix(tag, PactStateTag); ok && strings.TrimSpace(name) != "" {
+			states = append(states, pactState{Name: strings.TrimSpace(name)})
+		}
+	}
+	if len(states) > 0 {
+		return states
+	}
+	if exp.Variant != "" {
+		state := pactState{Name: exp.Variant, Params: map[string]any{}}
+		for _, pair := range strings.Split(exp.Variant, ",") {
+			if k, v, ok := strings.Cut(strings.TrimSpace(pair), "="); ok {
+				state.Params[k] = v
+			}
+		}
+		if len(state.Params) == 0 {
+			state.Params = nil
+		}
+		return []pactState{state}
+	}
+	for _, tag := range exp.Tags {
+		states = append(states, pactState{Name: tag})
+	}
+	return states
+}
+
+// pactPath returns a concrete request path and, for regex and templated paths, the regex the
+// provider's path must match. The example comes from the requests merged into the expectation
+// on import, else from the pattern itself.
+func pactPath(exp MockExpectation) (path, pattern string, ok bool) {
+	p := exp.HttpRequest.Path
+	switch {
+	case p == "":
+		return "/", ".*", true
+	case wireMockPathParam.MatchString(p) && !isRegexPath(wireMockPathParam.ReplaceAllString(p, "x")):
+		const param = "\x00"
+		quoted := regexp.QuoteMeta(wireMockPathParam.ReplaceAllString(p, param))
+		pattern = strings.ReplaceAll(quoted, param, "[^/]+")
+	case isRegexPath(p):
+		pattern = p
+	default:
+		return p, "", true
+	}
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return "", "", false
+	}
+	for _, ex := range exp.Examples {
+		if re.MatchString(ex.Path) {
+			return ex.Path, pattern, true
+		}
+	}
+	if wireMockPathParam.MatchString(p) {
+		filled := wireMockPathParam.ReplaceAllStringFunc(p, func(s string) string {
+			if values := exp.HttpRequest.PathParameters[strings.Trim(s, "{}")]; len(values) > 0 && regexp.QuoteMeta(values[0]) == values[0] {
+				return values[0]
+			}
+			return "1"
+		})
+		return filled, pattern, true
+	}
+	if example, ok := regexExample(pattern); ok {
+		return example, pattern, true
+	}
+	return "", "", false
+}
+
+// pactValue turns a MockServer matcher value into an example and, when it is a regex, the
+// regex; a note explains a value that cannot be exported
+func pactValue(v string) (example, regex, note string) {
+	if strings.HasPrefix(v, "!") && len(v) > 1 {
+		return "", "", "is a negated matcher; left out"
+	}
+	if regexp.QuoteMeta(v) == v {
+		return v, "", ""
+	}
+	if example, ok := regexExample(v); ok {
+		return example, v, ""
+	}
+	return "", "", "is a regex without an obvious example; left out"
+}
+
+var regexExamples = strings.NewReplacer(`[^/]+`, "1", `\d+`, "1", `[0-9]+`, "1", `.+`, "x", `.*`, "x", `\.`, ".", `\-`, "-", `\/`, "/")
+
+// regexExample derives a value matching a simple regex ("Bearer .*" → "Bearer x", "/users/\d+"
+// → "/users/1"); complex patterns report false
+func regexExample(pattern string) (string, bool) {
+	example := regexExamples.Replace(strings.TrimSuffix(strings.TrimPrefix(pattern, "^"), "$"))
+	if regexp.QuoteMeta(example) != example {
+		return "", false
+	}
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil || !re.MatchString(example) {
+		return "", false
+	}
+	return example, true
+}
+
+func pactRegexRule(regex string) map[string]any {
+	return map[string]any{"matchers": []any{map[string]any{"match": "regex", "regex": regex}}}
+}
+
+// pactRequestBody converts a MockServer body matcher into the body a consumer sends and its
+// content type ("" when unknown)
+func pactRequestBody(body any) (any, string, string) {
+	m, typed := body.(map[string]any)
+	if !typed {
+		if _, ok := body.(string); ok {
+			return body, "", ""
+		}
+		return body, "application/json", ""
+	}
+	typ, _ := m["type"].(string)
+	switch strings.ToUpper(typ) {
+	case "":
+		return m, "application/json", ""
+	case "JSON":
+		if s, ok := m["json"].(string); ok {
+			var v any
+			if json.Unmarshal([]byte(s), &v) == nil {
+				return v, "application/json", ""
+			}
+			return s, "application/json", ""
+		}
+		return m["json"], "application/json", ""
+	case "STRING":
+		if sub, _ := m["subString"].(bool); sub {
+			return nil, "", "substring body matcher not exported; the interaction has no request body"
+		}
+		return m["string"], "", ""
+	case "XML":
+		return m["xml"], "application/xml", ""
+	case "PARAMETERS":
+		params, _ := m["parameters"].([]any)
+		var pairs []string
+		for _, p := range params {
+			pm, _ := p.(map[string]any)
+			name, _ := pm["name"].(string)
+			if vs, ok := pm["values"].([]any); ok && len(vs) > 0 && name != "" {
+				pairs = append(pairs, url.QueryEscape(name)+"="+url.QueryEscape(fmt.Sprint(vs[0])))
+			}
+		}
+		sort.Strings(pairs)
+		return strings.Join(pairs, "&"), "application/x-www-form-urlencoded", ""
+	}
+	return nil, "", fmt.Sprintf("%s body matcher not exported; the interaction has no request body", typ)
+}
@@ -0,0 +1,82 @@
+package builders
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+func TestExpectationsToPactJSON(t *testing.T) {
+	out, warnings := ExpectationsToPactJSON([]MockExpectation{
+		{
+			Tags:         []string{"state:user 7 exists", "users"},
+			HttpRequest:  &HttpRequest{Method: "GET", Path: "/users/{id}", Headers: []models.NameValues{{Name: "Authorization", Values: []string{"Bearer .*"}}}},
+			HttpResponse: &HttpResponse{StatusCode: 200, Body: map[string]any{"type": "JSON", "json": map[string]any{"id": 7}}},
+			Examples:     []models.Example{{Path: "/users/7"}},
+		},
+		{
+			Variant:      "page=2",
+			HttpRequest:  &HttpRequest{Method: "GET", Path: "/users", QueryStringParameters: []models.NameValues{{Name: "page", Values: []string{"2"}}, {Name: "q", Values: []string{"!x"}}}},
+			HttpResponse: &HttpResponse{StatusCode: 200, Body: `[]`},
+		},
+		{
+			Tags:         []string{"error-cases"},
+			HttpRequest:  &HttpRequest{Method: "POST", Path: "/users", Body: map[string]any{"type": "JSON", "json": map[string]any{"name": "a"}}},
+			HttpResponse: &HttpResponse{StatusCode: 400, Body: map[string]any{"type": "STRING", "string": "bad"}},
+		},
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/users/{id}"}, HttpResponse: &HttpResponse{StatusCode: 304}, NotModified: true},
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/a(b|c)+"}, HttpResponse: &HttpResponse{}},
+	}, "web", "users")
+
+	var pact struct {
+		Consumer, Provider struct{ Name string }
+		Interactions       []struct {
+			Description    string
+			ProviderStates []struct {
+				Name   string
+				Params map[string]any
+			}
+			Request  map[string]any
+			Response map[string]any
+		}
+		Metadata map[string]any
+	}
+	if err := json.Unmarshal([]byte(out), &pact); err != nil {
+		t.Fatal(err)
+	}
+	if pact.Consumer.Name != "web" || pact.Provider.Name != "users" || len(pact.Interactions) != 3 {
+		t.Fatalf("pact:\n%s", out)
+	}
+	user, list, create := pact.Interactions[0], pact.Interactions[1], pact.Interactions[2]
+
+	if len(user.ProviderStates) != 1 || user.ProviderStates[0].Name != "user 7 exists" || user.Request["path"] != "/users/7" {
+		t.Errorf("GET /users/{id}: %+v", user)
+	}
+	rules, _ := json.Marshal(user.Request["matchingRules"])
+	if !strings.Contains(string(rules), `"path":{"matchers":[{"match":"regex","regex":"/users/[^/]+"}]}`) ||
+		!strings.Contains(string(rules), `"Authorization":{"matchers":[{"match":"regex","regex":"Bearer .*"}]}`) {
+		t.Errorf("matching rules %s", rules)
+	}
+	if user.Request["headers"].(map[string]any)["Authorization"] != "Bearer x" || user.Response["matchingRules"] == nil {
+		t.Errorf("GET /users/{id}: %+v", user)
+	}
+
+	if list.Description != "GET /users with page=2" || list.ProviderStates[0].Params["page"] != "2" {
+		t.Errorf("variant interaction: %+v", list)
+	}
+	if q, _ := json.Marshal(list.Request["query"]); string(q) != `{"page":["2"]}` {
+		t.Errorf("query %s", q)
+	}
+
+	if create.ProviderStates[0].Name != "error-cases" || create.Response["status"] != float64(400) || create.Response["body"] != "bad" ||
+		create.Request["headers"].(map[string]any)["Content-Type"] != "application/json" {
+		t.Errorf("POST /users: %+v", create)
+	}
+
+	joined := strings.Join(warnings, "\n")
+	if !strings.Contains(joined, "query q=!x is a negated matcher") || !strings.Contains(joined, "GET /a(b|c)+: regex path without an example request") {
+		t.Errorf("warnings:\n%s", joined)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
 	"github.com/hemantobora/auto-mock/internal/models"
 )
 
@@ -58,7 +59,7 @@ func collectRESTAPIDetails(expectation *MockExpectation) error {
 
 	// HTTP Method selection
 	var method string
-	if err := survey.AskOne(&survey.Select{
+	if err := ask.One(&survey.Select{
 		Message: "Select HTTP method:",
 		Options: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS", "HEAD"},
 		Default: "GET",
@@ -69,7 +70,7 @@ func collectRESTAPIDetails(expectation *MockExpectation) error {
 
 	// Path collection
 	var path string
-	if err := survey.AskOne(&survey.Input{
+	if err := ask.One(&survey.Input{
 		Message: "Enter the API path:",
 		Help:    "Use {param} for path parameters, e.g., /api/users/{id}",
 		Default: "/api/users/{id}",
@@ -89,7 +90,7 @@ func collectRESTAPIDetails(expectation *MockExpectation) error {
 		}
 
 		var useDetected bool
-		if err := survey.AskOne(&survey.Confirm{
+		if err := ask.One(&survey.Confirm{
 			Message: "Auto-configure these query parameters for matching?",
 			Default: true,
 		}, &useDetected); err != nil {
@@ -159,7 +160,7 @@ func collectStatusCode(expectation *MockExpectation) error {
 	}
 
 	var selectedCategory string
-	if err := survey.AskOne(&survey.Select{
+	if err := ask.One(&survey.Select{
 		Message: "Select status code category:",
 		Options: categories,
 		Default: "2xx Success",
@@ -175,7 +176,7 @@ func collectStatusCode(expectation *MockExpectation) error {
 	}
 
 	var selectedCode string
-	if err := survey.AskOne(&survey.Select{
+	if err := ask.One(&survey.Select{
 		Message: "Select specific status code:",
 		Options: codeOptions,
 	}, &selectedCode); err != nil {
@@ -204,11 +205,13 @@ func collectResponseBody(expectation *MockExpectation) error {
 	fmt.Println("━━━━━━━━━━━━━━━━━━━")
 
 	var bodyChoice string
-	if err := survey.AskOne(&survey.Select{
+	if err := ask.One(&survey.Select{
 		Message: "How do you want to provide the response body?",
 		Options: []string{
 			"template - Generate from template",
 			"json - Type/paste JSON directly",
+			"schema - Generate an example from a JSON Schema",
+			"binary - Load a file or base64 data (images, PDFs, ...)",
 		},
 		Default: "json - Type/paste JSON directly",
 	}, &bodyChoice); err != nil {
@@ -223,11 +226,21 @@ func collectResponseBody(expectation *MockExpectation) error {
 			return err
 		}
 
+	case "schema":
+		if err := ResponseBodyFromSchema(expectation); err != nil {
+			return err
+		}
+
+	case "binary":
+		if err := CollectBinaryResponseBody(expectation); err != nil {
+			return err
+		}
+
 	case "json":
 		var responseJSON string
-		if err := survey.AskOne(&survey.Multiline{
+		if err := ask.One(&survey.Multiline{
 			Message: "Enter the response body JSON:",
-			Help:    "Paste your JSON response here. Leave empty for no body.",
+			Help:    "Paste your JSON response here. Leave empty for no body. \"${uuid}\", \"${name}\" or \"${int:1-100}\" are filled with generated values on save; \"${request.path.id}\" or \"${request.body.name}\" echo the request.",
 		}, &responseJSON); err != nil {
 			return err
 		}
@@ -289,7 +302,7 @@ func reviewAndConfirm(expectation *MockExpectation) error {
 	}
 
 	var confirm bool
-	if err := survey.AskOne(&survey.Confirm{
+	if err := ask.One(&survey.Confirm{
 		Message: "Create this expectation?",
 		Default: true,
 	}, &confirm); err != nil {
@@ -0,0 +1,20 @@
+package builders
+
+import "github.com/hemantobora/auto-mock/internal/models"
+
+// SchemaExampler builds realistic example values from a JSON Schema; see models.SchemaExampler
+type SchemaExampler = models.SchemaExampler
+
+// schemaLibrary is the project's named schemas; "schemas/<Name>" references resolve against it
+var schemaLibrary map[string]any
+
+// SetSchemaLibrary makes generated schema examples resolve references to the project's schemas
+func SetSchemaLibrary(schemas map[string]any) {
+	schemaLibrary = schemas
+}
+
+// ExampleFromSchema generates an example for a schema; local references such as
+// "#/$defs/User" resolve within it and "schemas/User" against the project's schema library
+func ExampleFromSchema(schema any) (any, error) {
+	return models.ExampleFromSchema(schema, schemaLibrary)
+}
@@ -0,0 +1,26 @@
+package builders
+
+import "testing"
+
+func TestExampleFromSchemaUsesLibrary(t *testing.T) {
+	SetSchemaLibrary(map[string]any{
+		"User": map[string]any{"type": "object", "properties": map[string]any{"email": map[string]any{"type": "string"}}},
+	})
+	defer SetSchemaLibrary(nil)
+
+	example, err := ExampleFromSchema(map[string]any{"type": "array", "items": map[string]any{"$ref": "schemas/User"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	users, _ := example.([]any)
+	if len(users) == 0 {
+		t.Fatalf("example = %v, want users", example)
+	}
+	if user, _ := users[0].(map[string]any); user["email"] == nil {
+		t.Errorf("user = %v, want the library schema's email", users[0])
+	}
+
+	if _, err := ExampleFromSchema(map[string]any{"$ref": "schemas/Missing"}); err == nil {
+		t.Error("an unknown library reference was accepted")
+	}
+}
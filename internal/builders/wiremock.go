@@ -0,0 +1,431 @@
+package builders
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// wireMockStubs is the WireMock mappings file format ({"mappings": [...]}), loadable from the
+// mappings directory or POSTed to /__admin/mappings/import
+type wireMockStubs struct {
+	Mappings []wireMockMapping `json:"mappings"`
+}
+
+type wireMockMapping struct {
+	Name     string          `json:"name,omitempty"`
+	Priority int             `json:"priority"`
+	Request  wireMockRequest `json:"request"`
+	Response wireMockReply   `json:"response"`
+	Metadata map[string]any  `json:"metadata,omitempty"`
+}
+
+type wireMockRequest struct {
+	Method          string         `json:"method"`
+	URLPath         string         `json:"urlPath,omitempty"`
+	URLPathPattern  string         `json:"urlPathPattern,omitempty"`
+	URLPathTemplate string         `json:"urlPathTemplate,omitempty"`
+	PathParameters  map[string]any `json:"pathParameters,omitempty"`
+	QueryParameters map[string]any `json:"queryParameters,omitempty"`
+	Headers         map[string]any `json:"headers,omitempty"`
+	FormParameters  map[string]any `json:"formParameters,omitempty"`
+	BodyPatterns    []any          `json:"bodyPatterns,omitempty"`
+}
+
+type wireMockReply struct {
+	Status                 int            `json:"status,omitempty"`
+	Headers                map[string]any `json:"headers,omitempty"`
+	Body                   string         `json:"body,omitempty"`
+	JSONBody               any            `json:"jsonBody,omitempty"`
+	Base64Body             string         `json:"base64Body,omitempty"`
+	FixedDelayMilliseconds int            `json:"fixedDelayMilliseconds,omitempty"`
+	Fault                  string         `json:"fault,omitempty"`
+	ProxyBaseURL           string         `json:"proxyBaseUrl,omitempty"`
+	Transformers           []string       `json:"transformers,omitempty"`
+}
+
+// ExpectationsToWireMockJSON converts expectations into a WireMock 3 mappings file.
+// MockServer's "highest priority wins, then declaration order" becomes explicit WireMock
+// priorities (1 = first), and $! response placeholders become Handlebars helpers with the
+// response-template transformer. Features WireMock cannot express are reported as warnings.
+func ExpectationsToWireMockJSON(expectations []MockExpectation) (string, []string) {
+	order := matchOrder(expectations)
+	stubs := wireMockStubs{Mappings: []wireMockMapping{}}
+	var warnings []string
+	for rank, i := range order {
+		exp := expectations[i]
+		if exp.HttpRequest == nil {
+			continue
+		}
+		mapping, notes := wireMockMappingFor(exp)
+		mapping.Priority = rank + 1
+		for _, note := range notes {
+			warnings = append(warnings, fmt.Sprintf("%s %s: %s", exp.HttpRequest.Method, exp.HttpRequest.Path, note))
+		}
+		stubs.Mappings = append(stubs.Mappings, mapping)
+	}
+
+	data, err := json.MarshalIndent(stubs, "", "  ")
+	if err != nil {
+		return `{"mappings": []}`, append(warnings, fmt.Sprintf("failed to marshal mappings: %v", err))
+	}
+	return string(data), warnings
+}
+
+func wireMockMappingFor(exp MockExpectation) (wireMockMapping, []string) {
+	var notes []string
+	req := exp.HttpRequest
+	m := wireMockMapping{Name: exp.Description}
+	if m.Name == "" {
+		m.Name = strings.TrimSpace(req.Method + " " + req.Path)
+	}
+	if exp.ID != "" || len(exp.Tags) > 0 || exp.Notes != "" {
+		m.Metadata = map[string]any{}
+		if exp.ID != "" {
+			m.Metadata["id"] = exp.ID
+		}
+		if len(exp.Tags) > 0 {
+			m.Metadata["tags"] = exp.Tags
+		}
+		if exp.Notes != "" {
+			m.Metadata["notes"] = exp.Notes
+		}
+	}
+
+	// Request
+	m.Request.Method = strings.ToUpper(req.Method)
+	if m.Request.Method == "" {
+		m.Request.Method = "ANY"
+	}
+	switch {
+	case req.Path == "":
+	case wireMockPathParam.MatchString(req.Path) && !isRegexPath(wireMockPathParam.ReplaceAllString(req.Path, "x")):
+		m.Request.URLPathTemplate = req.Path
+		for name, values := range req.PathParameters {
+			if m.Request.PathParameters == nil {
+				m.Request.PathParameters = map[string]any{}
+			}
+			m.Request.PathParameters[name] = wireMockValues(values)
+		}
+	case isRegexPath(req.Path):
+		m.Request.URLPathPattern = req.Path
+	default:
+		m.Request.URLPath = req.Path
+	}
+	for _, q := range req.QueryStringParameters {
+		if m.Request.QueryParameters == nil {
+			m.Request.QueryParameters = map[string]any{}
+		}
+		m.Request.QueryParameters[q.Name] = wireMockValues(q.Values)
+	}
+	for _, h := range req.Headers {
+		if m.Request.Headers == nil {
+			m.Request.Headers = map[string]any{}
+		}
+		m.Request.Headers[h.Name] = wireMockValues(h.Values)
+	}
+	if req.Body != nil {
+		pattern, form, note := wireMockBodyPattern(req.Body)
+		switch {
+		case note != "":
+			notes = append(notes, note)
+		case form != nil:
+			m.Request.FormParameters = form
+		default:
+			m.Request.BodyPatterns = []any{pattern}
+		}
+	}
+	if exp.Times != nil && !exp.Times.Unlimited && exp.Times.RemainingTimes > 0 {
+		notes = append(notes, fmt.Sprintf("times limit (%d) dropped; WireMock stubs answer indefinitely", exp.Times.RemainingTimes))
+	}
+
+	// Response
+	if exp.Forward != nil {
+		scheme := strings.ToLower(exp.Forward.Scheme)
+		if scheme == "" {
+			scheme = "http"
+		}
+		m.Response.ProxyBaseURL = fmt.Sprintf("%s://%s", scheme, exp.Forward.Host)
+		if exp.Forward.Port > 0 {
+			m.Response.ProxyBaseURL += fmt.Sprintf(":%d", exp.Forward.Port)
+		}
+		return m, notes
+	}
+	if exp.HttpResponseTemplate != nil && !models.IsRequestTemplate(exp.HttpResponseTemplate) {
+		notes = append(notes, "JavaScript response template not convertible; exported its static fallback response")
+	}
+	if exp.Dataset != nil {
+		notes = append(notes, fmt.Sprintf("dataset %s not convertible; exported its static fallback response", exp.Dataset.Name))
+	}
+	resp := exp.HttpResponse
+	if resp == nil {
+		resp = &HttpResponse{StatusCode: 200}
+	}
+	m.Response.Status = resp.StatusCode
+	if m.Response.Status == 0 {
+		m.Response.Status = 200
+	}
+	for _, h := range resp.Headers {
+		addWireMockHeader(&m.Response, h.Name, h.Values...)
+	}
+	for _, c := range resp.Cookies {
+		for _, v := range c.Values {
+			addWireMockHeader(&m.Response, "Set-Cookie", c.Name+"="+v)
+		}
+	}
+	if note := setWireMockBody(&m.Response, resp.Body); note != "" {
+		notes = append(notes, note)
+	}
+	if resp.Delay != nil {
+		m.Response.FixedDelayMilliseconds = delayMilliseconds(resp.Delay)
+	}
+	if resp.ConnectionOptions != nil && resp.ConnectionOptions.CloseSocket {
+		m.Response.Fault = "CONNECTION_RESET_BY_PEER"
+	}
+	return m, notes
+}
+
+// matchOrder returns the expectation indices in MockServer's match order: highest priority
+// first, then declaration order
+func matchOrder(expectations []MockExpectation) []int {
+	order := make([]int, len(expectations))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return expectations[order[a]].Priority > expectations[order[b]].Priority
+	})
+	return order
+}
+
+var wireMockPathParam = regexp.MustCompile(`\{[A-Za-z_][A-Za-z0-9_-]*\}`)
+
+// isRegexPath reports whether a MockServer path uses regex syntax rather than a literal path
+func isRegexPath(path string) bool {
+	return strings.ContainsAny(path, "*+?[]()|^$\\") || strings.Contains(path, ".*")
+}
+
+// wireMockValues converts MockServer matcher values (regex-capable, "!" negates) to a WireMock
+// matcher; several values must all be present
+func wireMockValues(values []string) any {
+	if len(values) == 1 {
+		return wireMockValue(values[0])
+	}
+	patterns := make([]any, len(values))
+	for i, v := range values {
+		patterns[i] = wireMockValue(v)
+	}
+	return map[string]any{"includes": patterns}
+}
+
+func wireMockValue(v string) map[string]any {
+	if strings.HasPrefix(v, "!") && len(v) > 1 {
+		return map[string]any{"doesNotMatch": v[1:]}
+	}
+	if regexp.QuoteMeta(v) == v {
+		return map[string]any{"equalTo": v}
+	}
+	return map[string]any{"matches": v}
+}
+
+// wireMockBodyPattern converts a MockServer body matcher; form bodies become form parameters
+func wireMockBodyPattern(body any) (pattern map[string]any, form map[string]any, note string) {
+	m, typed := body.(map[string]any)
+	if !typed {
+		if s, ok := body.(string); ok {
+			return map[string]any{"equalTo": s}, nil, ""
+		}
+		return map[string]any{"equalToJson": body, "ignoreExtraElements": true, "ignoreArrayOrder": true}, nil, ""
+	}
+	typ, _ := m["type"].(string)
+	switch strings.ToUpper(typ) {
+	case "":
+		return map[string]any{"equalToJson": m, "ignoreExtraElements": true, "ignoreArrayOrder": true}, nil, ""
+	case "JSON":
+		p := map[string]any{"equalToJson": m["json"]}
+		if mt, _ := m["matchType"].(string); !strings.EqualFold(mt, "STRICT") {
+			p["ignoreExtraElements"] = true
+			p["ignoreArrayOrder"] = true
+		}
+		return p, nil, ""
+	case "STRING":
+		s, _ := m["string"].(string)
+		if sub, _ := m["subString"].(bool); sub {
+			return map[string]any{"contains": s}, nil, ""
+		}
+		return map[string]any{"equalTo": s}, nil, ""
+	case "REGEX":
+		return map[string]any{"matches": m["regex"]}, nil, ""
+	case "JSON_PATH":
+		return map[string]any{"matchesJsonPath": m["jsonPath"]}, nil, ""
+	case "XPATH":
+		return map[string]any{"matchesXPath": m["xpath"]}, nil, ""
+	case "XML":
+		return map[string]any{"equalToXml": m["xml"]}, nil, ""
+	case "JSON_SCHEMA":
+		schema := m["jsonSchema"]
+		if _, isStr := schema.(string); !isStr {
+			raw, _ := json.Marshal(schema)
+			schema = string(raw)
+		}
+		return map[string]any{"matchesJsonSchema": schema}, nil, ""
+	case "PARAMETERS":
+		form = map[string]any{}
+		params, _ := m["parameters"].([]any)
+		for _, p := range params {
+			pm, _ := p.(map[string]any)
+			name, _ := pm["name"].(string)
+			var values []string
+			if vs, ok := pm["values"].([]any); ok {
+				for _, v := range vs {
+					values = append(values, fmt.Sprint(v))
+				}
+			}
+			if name != "" && len(values) > 0 {
+				form[name] = wireMockValues(values)
+			}
+		}
+		return nil, form, ""
+	}
+	return nil, nil, fmt.Sprintf("%s body matcher not convertible; the stub matches any body", typ)
+}
+
+// setWireMockBody writes a MockServer response body; bodies with $! placeholders are
+// rendered by WireMock's response-template transformer
+func setWireMockBody(r *wireMockReply, body any) string {
+	switch b := body.(type) {
+	case nil:
+		return ""
+	case string:
+		return setWireMockText(r, b)
+	case map[string]any:
+		switch strings.ToUpper(fmt.Sprint(b["type"])) {
+		case "JSON":
+			if s, ok := b["json"].(string); ok {
+				return setWireMockText(r, s)
+			}
+			return setWireMockJSON(r, b["json"])
+		case "STRING":
+			s, _ := b["string"].(string)
+			return setWireMockText(r, s)
+		case "XML":
+			s, _ := b["xml"].(string)
+			return setWireMockText(r, s)
+		case "BINARY":
+			r.Base64Body, _ = b["base64Bytes"].(string)
+			return ""
+		}
+	}
+	return setWireMockJSON(r, body)
+}
+
+func setWireMockJSON(r *wireMockReply, v any) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("response body not serializable: %v", err)
+	}
+	if hasTemplatePlaceholder(string(raw)) {
+		return setWireMockText(r, string(raw))
+	}
+	r.JSONBody = v
+	return ""
+}
+
+func setWireMockText(r *wireMockReply, text string) string {
+	if !hasTemplatePlaceholder(text) {
+		r.Body = text
+		return ""
+	}
+	converted, unknown := VelocityToHandlebars(text)
+	r.Body = converted
+	r.Transformers = []string{"response-template"}
+	if len(unknown) > 0 {
+		return fmt.Sprintf("template placeholder(s) %s left as-is", strings.Join(unknown, ", "))
+	}
+	return ""
+}
+
+var (
+	velocityRequestValue = regexp.MustCompile(`\$!request\.(headers|pathParameters|queryStringParameters)\['([^']+)'\]\[0\]`)
+	velocityPlaceholder  = regexp.MustCompile(`\$!\{?[A-Za-z_][A-Za-z0-9_.]*\}?`)
+	velocityHelpers      = map[string]string{
+		"$!uuid":           "{{randomValue type='UUID'}}",
+		"$!now_epoch":      "{{now format='unix'}}",
+		"$!rand_int_100":   "{{randomInt lower=0 upper=100}}",
+		"$!rand_bytes_64":  "{{randomValue length=64 type='ALPHANUMERIC'}}",
+		"$!request.path":   "{{request.path}}",
+		"$!request.method": "{{request.method}}",
+	}
+	wireMockRequestField = map[string]string{
+		"headers":               "headers",
+		"pathParameters":        "path",
+		"queryStringParameters": "query",
+	}
+)
+
+func hasTemplatePlaceholder(text string) bool {
+	return strings.Contains(text, "$!") || strings.Contains(text, "${request.")
+}
+
+// VelocityToHandlebars rewrites the $! placeholders and ${request...} references used in
+// response bodies into WireMock Handlebars helpers and returns any placeholders it does not
+// know. Reference defaults are dropped.
+func VelocityToHandlebars(text string) (string, []string) {
+	text = models.ReplaceRequestReferences(text, func(source, name string) string {
+		switch {
+		case source == "body" && name != "":
+			return fmt.Sprintf("{{jsonPath request.body '$.%s'}}", name)
+		case name == "":
+			return "{{request." + source + "}}"
+		}
+		return fmt.Sprintf("{{request.%s.[%s]}}", source, name)
+	})
+	text = velocityRequestValue.ReplaceAllStringFunc(text, func(s string) string {
+		m := velocityRequestValue.FindStringSubmatch(s)
+		return fmt.Sprintf("{{request.%s.[%s]}}", wireMockRequestField[m[1]], m[2])
+	})
+	var unknown []string
+	text = velocityPlaceholder.ReplaceAllStringFunc(text, func(s string) string {
+		if helper, ok := velocityHelpers[s]; ok {
+			return helper
+		}
+		unknown = append(unknown, s)
+		return s
+	})
+	return text, unknown
+}
+
+func addWireMockHeader(r *wireMockReply, name string, values ...string) {
+	if r.Headers == nil {
+		r.Headers = map[string]any{}
+	}
+	var all []string
+	switch existing := r.Headers[name].(type) {
+	case string:
+		all = append(all, existing)
+	case []string:
+		all = append(all, existing...)
+	}
+	all = append(all, values...)
+	if len(all) == 1 {
+		r.Headers[name] = all[0]
+		return
+	}
+	r.Headers[name] = all
+}
+
+func delayMilliseconds(d *Delay) int {
+	switch strings.ToUpper(d.TimeUnit) {
+	case "MICROSECONDS":
+		return d.Value / 1000
+	case "SECONDS":
+		return d.Value * 1000
+	case "MINUTES":
+		return d.Value * 60 * 1000
+	}
+	return d.Value
+}
@@ -0,0 +1,86 @@
+package builders
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+func TestExpectationsToWireMockJSON(t *testing.T) {
+	out, warnings := ExpectationsToWireMockJSON([]MockExpectation{
+		{
+			ID:          "user",
+			HttpRequest: &HttpRequest{Method: "GET", Path: "/users/{id}", Headers: []models.NameValues{{Name: "Authorization", Values: []string{"Bearer .*"}}}},
+			HttpResponse: &HttpResponse{
+				StatusCode: 200,
+				Body:       `{"id": "$!request.pathParameters['id'][0]", "trace": "$!uuid", "x": "$!custom"}`,
+				Delay:      &Delay{TimeUnit: "SECONDS", Value: 2},
+			},
+		},
+		{
+			Priority:    10,
+			HttpRequest: &HttpRequest{Method: "POST", Path: "/users", Body: map[string]any{"type": "JSON", "json": map[string]any{"name": "a"}}},
+			HttpResponse: &HttpResponse{StatusCode: 201, Body: map[string]any{"type": "JSON", "json": map[string]any{"ok": true}},
+				Cookies: []models.NameValues{{Name: "session", Values: []string{"s1"}}}},
+			Times: &Times{RemainingTimes: 1},
+		},
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/files/.*"}, HttpResponse: &HttpResponse{ConnectionOptions: &ConnectionOptions{CloseSocket: true}}},
+	})
+
+	var stubs struct {
+		Mappings []struct {
+			Priority int
+			Request  map[string]any
+			Response map[string]any
+		}
+	}
+	if err := json.Unmarshal([]byte(out), &stubs); err != nil {
+		t.Fatal(err)
+	}
+	if len(stubs.Mappings) != 3 {
+		t.Fatalf("got %d mappings", len(stubs.Mappings))
+	}
+	post, user, files := stubs.Mappings[0], stubs.Mappings[1], stubs.Mappings[2]
+	if post.Priority != 1 || post.Request["method"] != "POST" || user.Priority != 2 || files.Priority != 3 {
+		t.Errorf("priorities not in MockServer match order: %+v", stubs.Mappings)
+	}
+
+	if user.Request["urlPathTemplate"] != "/users/{id}" {
+		t.Errorf("path template = %v", user.Request)
+	}
+	if auth := user.Request["headers"].(map[string]any)["Authorization"].(map[string]any); auth["matches"] != "Bearer .*" {
+		t.Errorf("header matcher = %v", auth)
+	}
+	body, _ := user.Response["body"].(string)
+	if !strings.Contains(body, "{{request.path.[id]}}") || !strings.Contains(body, "{{randomValue type='UUID'}}") {
+		t.Errorf("templated body = %s", body)
+	}
+	if user.Response["fixedDelayMilliseconds"] != float64(2000) || user.Response["transformers"] == nil {
+		t.Errorf("response = %v", user.Response)
+	}
+
+	if p := post.Request["bodyPatterns"].([]any)[0].(map[string]any); p["ignoreExtraElements"] != true {
+		t.Errorf("body pattern = %v", p)
+	}
+	if post.Response["jsonBody"] == nil || post.Response["headers"].(map[string]any)["Set-Cookie"] != "session=s1" {
+		t.Errorf("post response = %v", post.Response)
+	}
+	if files.Request["urlPathPattern"] != "/files/.*" || files.Response["fault"] != "CONNECTION_RESET_BY_PEER" {
+		t.Errorf("files mapping = %+v", files)
+	}
+
+	joined := strings.Join(warnings, "\n")
+	if len(warnings) != 2 || !strings.Contains(joined, "$!custom") || !strings.Contains(joined, "times limit (1)") {
+		t.Errorf("warnings = %q", warnings)
+	}
+}
+
+func TestVelocityToHandlebarsRequestReferences(t *testing.T) {
+	got, unknown := VelocityToHandlebars(`{"id": "${request.path.id}", "page": "${request.query.page:1}", "name": "${request.body.customer.name}", "via": "${request.method} ${request.headers.X-Trace}"}`)
+	want := `{"id": "{{request.path.[id]}}", "page": "{{request.query.[page]}}", "name": "{{jsonPath request.body '$.customer.name'}}", "via": "{{request.method}} {{request.headers.[X-Trace]}}"}`
+	if got != want || len(unknown) != 0 {
+		t.Errorf("got %s (unknown %v)", got, unknown)
+	}
+}
@@ -0,0 +1,124 @@
+package checks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+const postScript = `
+var jsonData = pm.response.json();
+pm.environment.set("orderId", jsonData.id);
+
+pm.test("Status code is 201", function () {
+    pm.response.to.have.status(201);
+});
+
+pm.test('Order is returned', () => {
+    pm.expect(jsonData.id).to.be.a('number');
+    pm.expect(jsonData.items.length).to.be.above(0);
+    pm.expect(pm.response.json().status).to.eql("NEW");
+    pm.expect(jsonData).to.have.property('total', 42.5);
+    pm.expect(pm.response.headers.get("Content-Type")).to.include("json");
+    pm.expect(jsonData.status).to.not.eql("CANCELLED");
+});
+
+if (/x/.test("x")) { console.log("not a test") }
+`
+
+func TestParse(t *testing.T) {
+	checks, unsupported := Parse(postScript)
+
+	var got []string
+	for _, c := range checks {
+		got = append(got, c.Name+" | "+Describe(c))
+	}
+	want := []string{
+		`Status code is 201 | status eq 201`,
+		`Order is returned | json id type "number"`,
+		`Order is returned | json items.length above 0`,
+		`Order is returned | json status eq "NEW"`,
+		`Order is returned | json total eq 42.5`,
+		`Order is returned | header Content-Type contains "json"`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("checks:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if len(unsupported) != 1 || !strings.Contains(unsupported[0], "to.not.eql") {
+		t.Errorf("unsupported = %v, want the negated assertion", unsupported)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	resp := &Response{
+		StatusCode: 201,
+		Headers:    http.Header{"Content-Type": []string{"application/json"}},
+		Body:       []byte(`{"id": 7, "status": "NEW", "items": [{"sku": "A"}], "total": 42.5}`),
+	}
+	cases := []struct {
+		check models.ResponseCheck
+		pass  bool
+	}{
+		{models.ResponseCheck{Kind: "status", Op: "eq", Value: float64(201)}, true},
+		{models.ResponseCheck{Kind: "status", Op: "class", Value: float64(2)}, true},
+		{models.ResponseCheck{Kind: "status", Op: "oneOf", Value: []any{float64(200), float64(202)}}, false},
+		{models.ResponseCheck{Kind: "json", Target: "items[0].sku", Op: "eq", Value: "A"}, true},
+		{models.ResponseCheck{Kind: "json", Target: "items", Op: "contains", Value: map[string]any{"sku": "A"}}, true},
+		{models.ResponseCheck{Kind: "json", Target: "missing", Op: "exists"}, false},
+		{models.ResponseCheck{Kind: "header", Target: "content-type", Op: "contains", Value: "json"}, true},
+		{models.ResponseCheck{Kind: "body", Op: "contains", Value: "CANCELLED"}, false},
+	}
+	for _, tc := range cases {
+		err := Evaluate(tc.check, resp)
+		if (err == nil) != tc.pass {
+			t.Errorf("%s: err = %v, want pass=%v", Describe(tc.check), err, tc.pass)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.URL.Path != "/orders/123" || !strings.Contains(string(body), "sku") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": 123, "status": "NEW"}`))
+	}))
+	defer srv.Close()
+
+	exps := []models.MockExpectation{
+		{
+			HttpRequest: &models.HttpRequest{Method: "PUT", Path: "/orders/[^/]+"},
+			Examples:    []models.Example{{Path: "/orders/123", Body: map[string]any{"sku": "A"}}},
+			Checks: []models.ResponseCheck{
+				{Name: "created", Kind: "status", Op: "eq", Value: float64(201)},
+				{Name: "status", Kind: "json", Target: "status", Op: "eq", Value: "SHIPPED"},
+			},
+		},
+		{
+			HttpRequest: &models.HttpRequest{Method: "GET", Path: "/once"},
+			Times:       &models.Times{RemainingTimes: 1},
+			Checks:      []models.ResponseCheck{{Kind: "status", Op: "eq", Value: float64(200)}},
+		},
+		{HttpRequest: &models.HttpRequest{Method: "GET", Path: "/unchecked"}},
+	}
+
+	report := Run(context.Background(), srv.Client(), srv.URL, exps)
+	if len(report.Outcomes) != 2 || report.Failed() != 1 {
+		t.Fatalf("outcomes = %+v", report.Outcomes)
+	}
+	if report.Outcomes[0].Err != nil || report.Outcomes[0].Endpoint != "PUT /orders/123" {
+		t.Errorf("first outcome = %+v", report.Outcomes[0])
+	}
+	if len(report.Skipped) != 1 {
+		t.Errorf("skipped = %v, want the limited expectation", report.Skipped)
+	}
+}
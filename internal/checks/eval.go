@@ -0,0 +1,223 @@
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+var pathSegment = regexp.MustCompile(`([^.\[\]]+)|\[(\d+)\]`)
+
+// Response is what a check is evaluated against
+type Response struct {
+	StatusCode int
+	Headers    http.Header
+	Body       []byte
+	Latency    time.Duration
+}
+
+// Evaluate returns nil when the response satisfies the check, or an error describing the
+// mismatch
+func Evaluate(c models.ResponseCheck, resp *Response) error {
+	switch c.Kind {
+	case "status":
+		return compare(c, float64(resp.StatusCode), true)
+	case "latency":
+		return compare(c, float64(resp.Latency.Milliseconds()), true)
+	case "header":
+		value := resp.Headers.Get(c.Target)
+		return compare(c, value, value != "" || len(resp.Headers.Values(c.Target)) > 0)
+	case "body":
+		return compare(c, string(resp.Body), true)
+	case "json":
+		var doc any
+		if err := json.Unmarshal(resp.Body, &doc); err != nil {
+			return fmt.Errorf("response body is not JSON")
+		}
+		value, found := lookup(doc, c.Target)
+		return compare(c, value, found)
+	}
+	return fmt.Errorf("unknown check kind %q", c.Kind)
+}
+
+// Describe renders a check as a one-line assertion, e.g. "json data.id eq 7"
+func Describe(c models.ResponseCheck) string {
+	parts := []string{c.Kind}
+	if c.Target != "" {
+		parts = append(parts, c.Target)
+	}
+	parts = append(parts, c.Op)
+	if c.Value != nil {
+		v, _ := json.Marshal(c.Value)
+		parts = append(parts, string(v))
+	}
+	return strings.Join(parts, " ")
+}
+
+func compare(c models.ResponseCheck, actual any, found bool) error {
+	if c.Op == "exists" {
+		if !found || actual == nil {
+			return fmt.Errorf("expected %s to exist", subject(c))
+		}
+		return nil
+	}
+	if !found {
+		return fmt.Errorf("%s is missing", subject(c))
+	}
+
+	ok := false
+	switch c.Op {
+	case "eq":
+		ok = equal(actual, c.Value)
+	case "contains":
+		ok = contains(actual, c.Value)
+	case "type":
+		ok = typeOf(actual) == c.Value
+	case "below", "above":
+		a, aok := number(actual)
+		b, bok := number(c.Value)
+		ok = aok && bok && ((c.Op == "below" && a < b) || (c.Op == "above" && a > b))
+	case "oneOf":
+		options, _ := c.Value.([]any)
+		for _, o := range options {
+			ok = ok || equal(actual, o)
+		}
+	case "class":
+		a, aok := number(actual)
+		b, bok := number(c.Value)
+		ok = aok && bok && int(a)/100 == int(b)
+	default:
+		return fmt.Errorf("unknown check operator %q", c.Op)
+	}
+	if ok {
+		return nil
+	}
+	got, _ := json.Marshal(actual)
+	want, _ := json.Marshal(c.Value)
+	return fmt.Errorf("expected %s %s %s, got %s", subject(c), c.Op, want, got)
+}
+
+func subject(c models.ResponseCheck) string {
+	if c.Target == "" {
+		return c.Kind
+	}
+	return c.Kind + " " + c.Target
+}
+
+// lookup resolves a "data.items[0].id" path; "length" on an array or string is its length
+func lookup(doc any, path string) (any, bool) {
+	cur := doc
+	for _, m := range pathSegment.FindAllStringSubmatch(path, -1) {
+		switch node := cur.(type) {
+		case map[string]any:
+			v, ok := node[m[1]+m[2]]
+			if !ok {
+				return nil, false
+			}
+			cur = v
+		case []any:
+			if m[1] == "length" {
+				cur = float64(len(node))
+				continue
+			}
+			i, err := strconv.Atoi(m[2])
+			if err != nil || i >= len(node) {
+				return nil, false
+			}
+			cur = node[i]
+		case string:
+			if m[1] != "length" {
+				return nil, false
+			}
+			cur = float64(len([]rune(node)))
+		default:
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// equal compares JSON values; stored checks come back from JSON, so numbers are float64
+func equal(a, b any) bool {
+	if x, ok := number(a); ok {
+		y, ok := number(b)
+		return ok && x == y
+	}
+	na, nb := normalize(a), normalize(b)
+	return reflect.DeepEqual(na, nb)
+}
+
+// contains follows chai's include: substring, array member or object subset
+func contains(actual, want any) bool {
+	switch a := actual.(type) {
+	case string:
+		return strings.Contains(a, fmt.Sprint(want))
+	case []any:
+		for _, item := range a {
+			if equal(item, want) {
+				return true
+			}
+		}
+	case map[string]any:
+		subset, ok := want.(map[string]any)
+		if !ok {
+			return false
+		}
+		for k, v := range subset {
+			if !equal(a[k], v) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func typeOf(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case float64, int, int64:
+		return "number"
+	case bool:
+		return "boolean"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return "undefined"
+}
+
+func number(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func normalize(v any) any {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out any
+	if json.Unmarshal(b, &out) != nil {
+		return v
+	}
+	return out
+}
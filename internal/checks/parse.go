@@ -0,0 +1,401 @@
+// Package checks converts collection test scripts (pm.test blocks) into structured response
+// checks and verifies a running mock against them.
+package checks
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+var (
+	testStart = regexp.MustCompile(`(?:^|[^.\w$])(?:pm\.)?test\s*\(\s*(["'` + "`" + `])`)
+	testFunc  = regexp.MustCompile(`^\s*,\s*(?:function\s*\w*\s*\([^)]*\)|\([^)]*\)\s*=>|[A-Za-z_$][\w$]*\s*=>)\s*\{`)
+	jsonAlias = regexp.MustCompile(`\b(?:var|let|const)\s+([A-Za-z_$][\w$]*)\s*=\s*pm\.response\.json\(\)`)
+	accessor  = regexp.MustCompile(`^(?:\.([A-Za-z_$][\w$]*)|\[\s*(\d+)\s*\]|\[\s*["']([^"']+)["']\s*\])`)
+	callArgs  = regexp.MustCompile(`^([A-Za-z]+)(?:\((.*)\))?$`)
+)
+
+// Parse extracts the assertions of every test block in a (Postman-normalized) script.
+// Assertions it cannot express as checks - negations, computed values, custom helpers -
+// are returned as "<test name>: <statement>" so callers can report them.
+func Parse(script string) (checks []models.ResponseCheck, unsupported []string) {
+	aliases := map[string]bool{}
+	for _, m := range jsonAlias.FindAllStringSubmatch(script, -1) {
+		aliases[m[1]] = true
+	}
+
+	for _, loc := range testStart.FindAllStringSubmatchIndex(script, -1) {
+		quote := script[loc[2]:loc[3]]
+		rest := script[loc[3]:]
+		end := strings.Index(rest, quote)
+		if end < 0 {
+			continue
+		}
+		name := rest[:end]
+		fn := testFunc.FindStringIndex(rest[end+1:])
+		if fn == nil {
+			continue
+		}
+		body, ok := block(rest[end+fn[1]:])
+		if !ok {
+			continue
+		}
+		for _, stmt := range statements(body) {
+			if !isAssertion(stmt) {
+				continue
+			}
+			parsed, ok := parseAssertion(stmt, aliases)
+			if !ok {
+				unsupported = append(unsupported, name+": "+stmt)
+				continue
+			}
+			for _, c := range parsed {
+				c.Name = name
+				checks = append(checks, c)
+			}
+		}
+	}
+	return checks, unsupported
+}
+
+func isAssertion(stmt string) bool {
+	return strings.Contains(stmt, "expect(") || strings.HasPrefix(stmt, "pm.response.to.")
+}
+
+func parseAssertion(stmt string, aliases map[string]bool) ([]models.ResponseCheck, bool) {
+	stmt = strings.TrimSuffix(strings.TrimSpace(stmt), ";")
+	if chain, ok := strings.CutPrefix(stmt, "pm.response.to."); ok {
+		return responseAssertion(chain)
+	}
+
+	stmt = strings.TrimPrefix(stmt, "pm.")
+	inner, ok := strings.CutPrefix(stmt, "expect(")
+	if !ok {
+		return nil, false
+	}
+	closeAt := matching(inner)
+	if closeAt < 0 {
+		return nil, false
+	}
+	subject, ok := parseSubject(strings.TrimSpace(inner[:closeAt]), aliases)
+	if !ok {
+		return nil, false
+	}
+	check, ok := applyChain(subject, strings.TrimPrefix(inner[closeAt+1:], "."))
+	if !ok {
+		return nil, false
+	}
+	return []models.ResponseCheck{check}, true
+}
+
+// responseAssertion handles pm.response.to.have.status(200), .be.ok, .have.header(...)
+func responseAssertion(chain string) ([]models.ResponseCheck, bool) {
+	chain = stripFillers(chain)
+	m := callArgs.FindStringSubmatch(chain)
+	if m == nil {
+		return nil, false
+	}
+	args, ok := literals(m[2])
+	if !ok {
+		return nil, false
+	}
+	switch m[1] {
+	case "status":
+		if len(args) == 1 {
+			if code, isNum := args[0].(float64); isNum {
+				return []models.ResponseCheck{{Kind: "status", Op: "eq", Value: code}}, true
+			}
+		}
+	case "ok", "success":
+		return []models.ResponseCheck{{Kind: "status", Op: "class", Value: float64(2)}}, true
+	case "clientError":
+		return []models.ResponseCheck{{Kind: "status", Op: "class", Value: float64(4)}}, true
+	case "serverError":
+		return []models.ResponseCheck{{Kind: "status", Op: "class", Value: float64(5)}}, true
+	case "header":
+		if name, isStr := first(args).(string); isStr {
+			if len(args) == 2 {
+				return []models.ResponseCheck{{Kind: "header", Target: name, Op: "eq", Value: args[1]}}, true
+			}
+			return []models.ResponseCheck{{Kind: "header", Target: name, Op: "exists"}}, true
+		}
+	case "jsonBody":
+		if path, isStr := first(args).(string); isStr {
+			if len(args) == 2 {
+				return []models.ResponseCheck{{Kind: "json", Target: path, Op: "eq", Value: args[1]}}, true
+			}
+			return []models.ResponseCheck{{Kind: "json", Target: path, Op: "exists"}}, true
+		}
+	}
+	return nil, false
+}
+
+// parseSubject maps the value under test to a check kind and target
+func parseSubject(s string, aliases map[string]bool) (models.ResponseCheck, bool) {
+	switch s {
+	case "pm.response.code", "pm.response.code()":
+		return models.ResponseCheck{Kind: "status"}, true
+	case "pm.response.responseTime":
+		return models.ResponseCheck{Kind: "latency"}, true
+	case "pm.response.text()":
+		return models.ResponseCheck{Kind: "body"}, true
+	}
+	if rest, ok := strings.CutPrefix(s, "pm.response.headers.get("); ok && strings.HasSuffix(rest, ")") {
+		if name, ok := literal(strings.TrimSuffix(rest, ")")); ok {
+			if str, isStr := name.(string); isStr {
+				return models.ResponseCheck{Kind: "header", Target: str}, true
+			}
+		}
+		return models.ResponseCheck{}, false
+	}
+
+	var rest string
+	if r, ok := strings.CutPrefix(s, "pm.response.json()"); ok {
+		rest = r
+	} else {
+		root := s
+		if i := strings.IndexAny(s, ".["); i >= 0 {
+			root, rest = s[:i], s[i:]
+		}
+		if !aliases[root] {
+			return models.ResponseCheck{}, false
+		}
+	}
+	path, ok := jsonPath(rest)
+	if !ok {
+		return models.ResponseCheck{}, false
+	}
+	return models.ResponseCheck{Kind: "json", Target: path}, true
+}
+
+// jsonPath converts a JS accessor chain (".data.items[0]['id']") to "data.items[0].id"
+func jsonPath(s string) (string, bool) {
+	var path strings.Builder
+	for s != "" {
+		m := accessor.FindStringSubmatch(s)
+		if m == nil {
+			return "", false
+		}
+		switch {
+		case m[2] != "":
+			path.WriteString("[" + m[2] + "]")
+		default:
+			if path.Len() > 0 {
+				path.WriteString(".")
+			}
+			path.WriteString(m[1] + m[3])
+		}
+		s = s[len(m[0]):]
+	}
+	return path.String(), true
+}
+
+// applyChain reads a chai chain such as "to.be.above(0)" or "to.have.property('id', 7)"
+func applyChain(c models.ResponseCheck, chain string) (models.ResponseCheck, bool) {
+	chain = stripFillers(chain)
+	m := callArgs.FindStringSubmatch(chain)
+	if m == nil {
+		return c, false
+	}
+	args, ok := literals(m[2])
+	if !ok {
+		return c, false
+	}
+	single := len(args) == 1
+	switch m[1] {
+	case "eql", "equal", "equals", "eq":
+		c.Op, c.Value = "eq", first(args)
+		return c, single
+	case "include", "includes", "contain", "contains", "string":
+		c.Op, c.Value = "contains", first(args)
+		return c, single
+	case "a", "an":
+		kind, isStr := first(args).(string)
+		c.Op, c.Value = "type", strings.ToLower(kind)
+		return c, single && isStr
+	case "below", "lessThan", "lt":
+		c.Op, c.Value = "below", first(args)
+		_, isNum := c.Value.(float64)
+		return c, single && isNum
+	case "above", "greaterThan", "gt":
+		c.Op, c.Value = "above", first(args)
+		_, isNum := c.Value.(float64)
+		return c, single && isNum
+	case "oneOf":
+		c.Op, c.Value = "oneOf", first(args)
+		_, isList := c.Value.([]any)
+		return c, single && isList
+	case "exist", "ok":
+		c.Op = "exists"
+		return c, len(args) == 0
+	case "true", "false":
+		c.Op, c.Value = "eq", m[1] == "true"
+		return c, len(args) == 0
+	case "lengthOf", "length":
+		if c.Kind != "json" {
+			return c, false
+		}
+		c.Target = joinPath(c.Target, "length")
+		c.Op, c.Value = "eq", first(args)
+		return c, single
+	case "property":
+		name, isStr := first(args).(string)
+		if c.Kind != "json" || !isStr || len(args) > 2 {
+			return c, false
+		}
+		c.Target = joinPath(c.Target, name)
+		c.Op = "exists"
+		if len(args) == 2 {
+			c.Op, c.Value = "eq", args[1]
+		}
+		return c, true
+	}
+	return c, false
+}
+
+// stripFillers drops chai's readability words; negated chains are left unsupported
+func stripFillers(chain string) string {
+	for {
+		trimmed := chain
+		for _, word := range []string{"to.", "be.", "been.", "have.", "has.", "is.", "that.", "which.", "and.", "deep.", "with.", "at."} {
+			trimmed = strings.TrimPrefix(trimmed, word)
+		}
+		if trimmed == chain {
+			return chain
+		}
+		chain = trimmed
+	}
+}
+
+func joinPath(base, key string) string {
+	if base == "" {
+		return key
+	}
+	return base + "." + key
+}
+
+func first(args []any) any {
+	if len(args) == 0 {
+		return nil
+	}
+	return args[0]
+}
+
+// literals parses a comma-separated argument list of JS literals
+func literals(s string) ([]any, bool) {
+	var out []any
+	for _, part := range split(s, ',') {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		v, ok := literal(part)
+		if !ok {
+			return nil, false
+		}
+		out = append(out, v)
+	}
+	return out, true
+}
+
+// literal parses a JS literal by way of JSON: single-quoted and template strings are
+// rewritten to double quotes; identifiers and expressions are rejected
+func literal(s string) (any, bool) {
+	s = strings.TrimSpace(s)
+	var v any
+	if json.Unmarshal([]byte(s), &v) == nil {
+		return v, true
+	}
+	if len(s) >= 2 && (s[0] == '\'' || s[0] == '`') && s[len(s)-1] == s[0] {
+		inner := s[1 : len(s)-1]
+		if s[0] == '`' && strings.Contains(inner, "${") {
+			return nil, false
+		}
+		return strings.ReplaceAll(inner, `\'`, "'"), true
+	}
+	if !strings.Contains(s, `"`) && strings.ContainsAny(s, "[{") {
+		if json.Unmarshal([]byte(strings.ReplaceAll(s, "'", `"`)), &v) == nil {
+			return v, true
+		}
+	}
+	if n, err := strconv.ParseFloat(s, 64); err == nil {
+		return n, true
+	}
+	return nil, false
+}
+
+// block returns the contents of the brace block starting at s[0]
+func block(s string) (string, bool) {
+	if end := matching(s[1:]); end >= 0 {
+		return s[1 : end+1], true
+	}
+	return "", false
+}
+
+// matching returns the index of the bracket closing the one just before s, skipping strings
+func matching(s string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case quote != 0:
+			if ch == '\\' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'' || ch == '`':
+			quote = ch
+		case ch == '(' || ch == '{' || ch == '[':
+			depth++
+		case ch == ')' || ch == '}' || ch == ']':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+// statements splits a function body on semicolons and newlines outside brackets and strings
+func statements(body string) []string {
+	var out []string
+	for _, line := range split(body, ';', '\n') {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "//") {
+			out = append(out, line)
+		}
+	}
+	return out
+}
+
+func split(s string, seps ...byte) []string {
+	var out []string
+	depth, start := 0, 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case quote != 0:
+			if ch == '\\' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'' || ch == '`':
+			quote = ch
+		case ch == '(' || ch == '{' || ch == '[':
+			depth++
+		case ch == ')' || ch == '}' || ch == ']':
+			depth--
+		case depth == 0 && strings.IndexByte(string(seps), ch) >= 0:
+			out = append(out, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(out, s[start:])
+}
@@ -0,0 +1,99 @@
+package checks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/matcher"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// Outcome is the result of one check against the running mock
+type Outcome struct {
+	Endpoint string // "METHOD /path" of the request sent
+	Check    models.ResponseCheck
+	Err      error // nil when the check passed
+}
+
+// Report collects the outcomes of a verification run
+type Report struct {
+	Outcomes []Outcome
+	Skipped  []string // endpoints not exercised, with the reason
+}
+
+// Failed counts the checks that did not pass
+func (r *Report) Failed() int {
+	n := 0
+	for _, o := range r.Outcomes {
+		if o.Err != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// Run sends each expectation's example request to the mock at baseURL and evaluates the
+// checks recorded for it. Expectations limited to a number of responses are skipped so the
+// run does not use them up.
+func Run(ctx context.Context, client *http.Client, baseURL string, exps []models.MockExpectation) *Report {
+	report := &Report{}
+	baseURL = strings.TrimRight(baseURL, "/")
+	for i := range exps {
+		exp := &exps[i]
+		if len(exp.Checks) == 0 {
+			continue
+		}
+		req := matcher.ExampleRequest(exp)
+		endpoint := req.Method + " " + req.Path
+		if exp.Times != nil && !exp.Times.Unlimited && exp.Times.RemainingTimes > 0 {
+			report.Skipped = append(report.Skipped, endpoint+" (answers a limited number of times)")
+			continue
+		}
+
+		resp, err := send(ctx, client, baseURL, req)
+		for _, c := range exp.Checks {
+			outcome := Outcome{Endpoint: endpoint, Check: c, Err: err}
+			if err == nil {
+				outcome.Err = Evaluate(c, resp)
+			}
+			report.Outcomes = append(report.Outcomes, outcome)
+		}
+	}
+	return report
+}
+
+func send(ctx context.Context, client *http.Client, baseURL string, r *matcher.Request) (*Response, error) {
+	target := baseURL + r.Path
+	if q := r.Query.Encode(); q != "" {
+		target += "?" + q
+	}
+	var body io.Reader
+	if len(r.Body) > 0 {
+		body = bytes.NewReader(r.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, r.Method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+	for name, values := range r.Headers {
+		for _, v := range values {
+			req.Header.Add(name, v)
+		}
+	}
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return &Response{StatusCode: resp.StatusCode, Headers: resp.Header, Body: data, Latency: time.Since(start)}, nil
+}
@@ -0,0 +1,262 @@
+package client
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+/* =========================
+   Embedded Gatling templates
+   ========================= */
+
+//go:embed templates/gatling/AutoMockSimulation.scala.tmpl
+var gatlingSimulationTmpl string
+
+//go:embed templates/gatling/pom.xml
+var gatlingPom []byte
+
+//go:embed templates/gatling/user_data.csv
+var gatlingUserDataCSV []byte
+
+//go:embed templates/gatling/LOADTEST_README.md
+var gatlingReadme []byte
+
+//go:embed templates/gatling/run_gatling.sh
+var runGatlingSh []byte
+
+//go:embed templates/gatling/run_gatling.ps1
+var runGatlingPs1 []byte
+
+// Distributed helpers (one instance per machine, each with a share of the users)
+//
+//go:embed templates/gatling/run_gatling_segment.sh
+var runGatlingSegmentSh []byte
+
+//go:embed templates/gatling/run_gatling_segment.ps1
+var runGatlingSegmentPs1 []byte
+
+var gatlingTemplate = template.Must(template.New("simulation").Parse(gatlingSimulationTmpl))
+
+// gatlingSimulation is the data of AutoMockSimulation.scala; strings are Scala expressions
+type gatlingSimulation struct {
+	Users, P95Ms         int
+	MinWaitMs, MaxWaitMs int
+	DefaultHeaders       []scalaHeader
+	Login                *gatlingRequest
+	TokenPath            string
+	AuthHeader           string
+	AuthValue            string
+	Requests             []gatlingRequest // each also gets its own p95 assertion
+}
+
+type gatlingRequest struct {
+	Name    string
+	Share   string // percentage of iterations
+	Call    string // e.g. get("/users")
+	Headers []scalaHeader
+	Body    string // empty for none
+}
+
+type scalaHeader struct {
+	Name, Value string
+}
+
+// buildGatlingSimulation compiles the endpoint spec into the simulation's data
+func buildGatlingSimulation(ls loadSpec, users, p95Ms int) gatlingSimulation {
+	sim := gatlingSimulation{Users: users, P95Ms: p95Ms, DefaultHeaders: scalaHeaders(ls.Config.DefaultHeaders)}
+	sim.MinWaitMs, sim.MaxWaitMs = ls.waitMs()
+	if ls.HasAuth() {
+		sim.Login = &gatlingRequest{
+			Call:    gatlingCall(ls.Auth.Method, ls.Auth.Path),
+			Headers: scalaHeaders(ls.Auth.Headers),
+		}
+		if ls.Auth.Body != "" {
+			sim.Login.Body = scalaExpr(ls.Auth.Body)
+		}
+		path := ls.Auth.TokenJSONPath
+		if !strings.HasPrefix(path, "$") {
+			path = "$." + path
+		}
+		sim.TokenPath = scalaString(path)
+		sim.AuthHeader = scalaString(ls.Auth.HeaderName)
+		sim.AuthValue = scalaString(ls.Auth.HeaderPrefix + "#{authToken}")
+	}
+	shares := weightShares(ls.Endpoints)
+	for i, ep := range ls.Endpoints {
+		req := gatlingRequest{
+			Name:    scalaString(ep.Name),
+			Share:   formatShare(shares[i]),
+			Call:    gatlingCall(ep.Method, withQuery(ep.Path, ep.Params)),
+			Headers: scalaHeaders(ep.Headers),
+		}
+		if body, ok := ep.Body.(string); ok && body != "" {
+			req.Body = scalaExpr(body)
+		}
+		sim.Requests = append(sim.Requests, req)
+	}
+	return sim
+}
+
+// gatlingCall is the request builder call for a method and URL
+func gatlingCall(method, url string) string {
+	switch m := strings.ToUpper(method); m {
+	case "GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS":
+		return fmt.Sprintf("%s(%s)", strings.ToLower(m), scalaExpr(url))
+	default:
+		return fmt.Sprintf("httpRequest(%s, %s)", scalaString(m), scalaExpr(url))
+	}
+}
+
+// withQuery appends query parameters to a path; values keep their placeholders unescaped
+func withQuery(path string, params map[string]string) string {
+	if len(params) == 0 {
+		return path
+	}
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	var b strings.Builder
+	b.WriteString(path)
+	for _, k := range keys {
+		b.WriteString(sep + k + "=" + params[k])
+		sep = "&"
+	}
+	return b.String()
+}
+
+func scalaHeaders(headers map[string]string) []scalaHeader {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	out := make([]scalaHeader, 0, len(names))
+	for _, name := range names {
+		out = append(out, scalaHeader{Name: scalaString(name), Value: scalaExpr(headers[name])})
+	}
+	return out
+}
+
+// scalaExpr turns a spec string into a Scala expression: ${data.X} becomes the Gatling
+// session attribute #{X} (from user_data.csv), ${user.id} the virtual user's 0-based index,
+// and ${env.X} the environment variable, read when the simulation starts
+func scalaExpr(s string) string {
+	var terms []string
+	var lit strings.Builder
+	flush := func() {
+		if lit.Len() > 0 {
+			terms = append(terms, scalaString(lit.String()))
+			lit.Reset()
+		}
+	}
+	splitPlaceholders(s, func(text string) { lit.WriteString(text) }, func(kind, name string) {
+		switch kind {
+		case "data":
+			lit.WriteString("#{" + name + "}")
+		case "user":
+			lit.WriteString("#{userIndex}")
+		case "env":
+			flush()
+			terms = append(terms, fmt.Sprintf("env(%s)", scalaString(name)))
+		}
+	})
+	flush()
+	if len(terms) == 0 {
+		return `""`
+	}
+	return strings.Join(terms, " + ")
+}
+
+// scalaString quotes s as a Scala string literal
+func scalaString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// writeGatlingBundle compiles the endpoint spec into a Gatling simulation (Scala, built and
+// run with Maven) and writes it with the spec it came from and the run scripts
+func writeGatlingBundle(opts Options, spec map[string]any, eps []Endpoint) error {
+	users, p95Ms, err := promptLoadProfile(eps, true)
+	if err != nil {
+		return err
+	}
+	ls, err := parseLoadSpec(spec)
+	if err != nil {
+		return err
+	}
+	var simulation bytes.Buffer
+	if err := gatlingTemplate.Execute(&simulation, buildGatlingSimulation(ls, users, p95Ms)); err != nil {
+		return fmt.Errorf("render simulation: %w", err)
+	}
+	if err := os.MkdirAll(opts.OutDir, 0o755); err != nil {
+		return err
+	}
+	if err := writeJSON(filepath.Join(opts.OutDir, "gatling_endpoints.json"), spec); err != nil {
+		return err
+	}
+	files := []bundleFile{
+		{"AutoMockSimulation.scala", simulation.Bytes(), 0o644},
+		{"pom.xml", gatlingPom, 0o644},
+		{"run_gatling.sh", runGatlingSh, 0o755},
+		{"run_gatling.ps1", runGatlingPs1, 0o644},
+	}
+	distributed := opts.GenerateDistributedHelpers != nil && *opts.GenerateDistributedHelpers
+	if distributed {
+		files = append(files, bundleFile{"run_gatling_segment.sh", runGatlingSegmentSh, 0o755}, bundleFile{"run_gatling_segment.ps1", runGatlingSegmentPs1, 0o644})
+	}
+	samples := []bundleFile{
+		{"user_data.csv", gatlingUserDataCSV, 0o644},
+		{"LOADTEST_README.md", gatlingReadme, 0o644},
+	}
+	if err := writeBundleFiles(opts.OutDir, files, samples); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Gatling bundle written to %s\n", opts.OutDir)
+	fmt.Printf("Next:\n  cd %s\n  AM_HOST=https://api.example.com ./run_gatling.sh    # or use .\\run_gatling.ps1 -AM_HOST ... on Windows\n", opts.OutDir)
+	if distributed {
+		fmt.Println("Distributed mode (one instance per machine, each runs its share of the users):")
+		fmt.Println("  ./run_gatling_segment.sh 1 3    # machine 1 of 3; .\\run_gatling_segment.ps1 -Index 1 -Count 3 on Windows")
+	}
+	fmt.Println()
+	fmt.Println("Tuning:")
+	fmt.Printf("  - AutoMockSimulation.scala ramps to %d users and asserts p95 < %dms and < 1%% failures.\n", users, p95Ms)
+	fmt.Println("  - Override with -Dusers=, -Dramp= and -Dduration= (seconds), e.g. ./run_gatling.sh -Dusers=100.")
+	fmt.Println("  - The simulation is generated from gatling_endpoints.json; regenerate after the collection changes.")
+	fmt.Println("  - Per-user data comes from user_data.csv; use ${data.<column>} placeholders.")
+	return nil
+}
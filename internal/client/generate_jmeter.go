@@ -0,0 +1,205 @@
+package client
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+/* =========================
+   Embedded JMeter templates
+   ========================= */
+
+//go:embed templates/jmeter/automock.jmx.tmpl
+var jmeterPlanTmpl string
+
+//go:embed templates/jmeter/user_data.csv
+var jmeterUserDataCSV []byte
+
+//go:embed templates/jmeter/LOADTEST_README.md
+var jmeterReadme []byte
+
+//go:embed templates/jmeter/run_jmeter.sh
+var runJMeterSh []byte
+
+//go:embed templates/jmeter/run_jmeter.ps1
+var runJMeterPs1 []byte
+
+// Distributed helpers (JMeter remote servers)
+//
+//go:embed templates/jmeter/run_jmeter_distributed.sh
+var runJMeterDistributedSh []byte
+
+//go:embed templates/jmeter/run_jmeter_distributed.ps1
+var runJMeterDistributedPs1 []byte
+
+var jmeterTemplate = template.Must(template.New("plan").Funcs(template.FuncMap{"x": xmlText}).Parse(jmeterPlanTmpl))
+
+// jmeterPlan is the data of automock.jmx; strings are JMeter values (with ${...} functions)
+type jmeterPlan struct {
+	Users                  int
+	MinWaitMs, WaitRangeMs int
+	DefaultHeaders         []jmeterHeader
+	SharedLogin            bool
+	PerUserLogin           bool
+	Login                  *jmeterRequest
+	TokenPath              string
+	AuthHeader             string
+	AuthValue              string
+	Requests               []jmeterRequest
+}
+
+type jmeterRequest struct {
+	Name    string
+	Share   string // percentage of iterations
+	Method  string
+	Path    string
+	Headers []jmeterHeader
+	Body    string // empty for none
+}
+
+type jmeterHeader struct {
+	Name, Value string
+}
+
+// buildJMeterPlan compiles the endpoint spec into the test plan's data
+func buildJMeterPlan(ls loadSpec, users int) jmeterPlan {
+	plan := jmeterPlan{Users: users, DefaultHeaders: jmeterHeaders(ls.Config.DefaultHeaders)}
+	minWait, maxWait := ls.waitMs()
+	plan.MinWaitMs, plan.WaitRangeMs = minWait, maxWait-minWait
+	if ls.HasAuth() {
+		plan.SharedLogin = ls.Auth.Mode == "shared"
+		plan.PerUserLogin = !plan.SharedLogin
+		plan.Login = &jmeterRequest{
+			Name:    "login",
+			Method:  strings.ToUpper(ls.Auth.Method),
+			Path:    jmeterValue(ls.Auth.Path),
+			Headers: jmeterHeaders(ls.Auth.Headers),
+			Body:    jmeterValue(ls.Auth.Body),
+		}
+		path := ls.Auth.TokenJSONPath
+		if !strings.HasPrefix(path, "$") {
+			path = "$." + path
+		}
+		plan.TokenPath = path
+		plan.AuthHeader = ls.Auth.HeaderName
+		// The shared token is handed from the setUp thread group to the users as a property
+		token := "${authToken}"
+		if plan.SharedLogin {
+			token = "${__P(authToken)}"
+		}
+		plan.AuthValue = ls.Auth.HeaderPrefix + token
+	}
+	shares := weightShares(ls.Endpoints)
+	for i, ep := range ls.Endpoints {
+		req := jmeterRequest{
+			Name:    ep.Name,
+			Share:   formatShare(shares[i]),
+			Method:  strings.ToUpper(ep.Method),
+			Path:    jmeterValue(withQuery(ep.Path, ep.Params)),
+			Headers: jmeterHeaders(ep.Headers),
+		}
+		if body, ok := ep.Body.(string); ok {
+			req.Body = jmeterValue(body)
+		}
+		plan.Requests = append(plan.Requests, req)
+	}
+	return plan
+}
+
+func jmeterHeaders(headers map[string]string) []jmeterHeader {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	out := make([]jmeterHeader, 0, len(names))
+	for _, name := range names {
+		out = append(out, jmeterHeader{Name: name, Value: jmeterValue(headers[name])})
+	}
+	return out
+}
+
+// jmeterValue turns a spec string into a JMeter value: ${data.X} becomes the CSV variable
+// ${X} (from user_data.csv), ${user.id} the thread's 0-based number, and ${env.X} the
+// environment variable
+func jmeterValue(s string) string {
+	var b strings.Builder
+	splitPlaceholders(s, func(text string) { b.WriteString(text) }, func(kind, name string) {
+		switch kind {
+		case "data":
+			b.WriteString("${" + name + "}")
+		case "user":
+			b.WriteString("${__intSum(${__threadNum},-1)}")
+		case "env":
+			b.WriteString("${__groovy(System.getenv().getOrDefault('" + name + "'\\,''))}")
+		}
+	})
+	return b.String()
+}
+
+// xmlText escapes s for XML character data and attribute values
+func xmlText(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// writeJMeterBundle compiles the endpoint spec into a JMeter test plan and writes it with the
+// spec it came from and the run scripts
+func writeJMeterBundle(opts Options, spec map[string]any, eps []Endpoint) error {
+	users, _, err := promptLoadProfile(eps, false)
+	if err != nil {
+		return err
+	}
+	ls, err := parseLoadSpec(spec)
+	if err != nil {
+		return err
+	}
+	var plan bytes.Buffer
+	if err := jmeterTemplate.Execute(&plan, buildJMeterPlan(ls, users)); err != nil {
+		return fmt.Errorf("render test plan: %w", err)
+	}
+	if err := os.MkdirAll(opts.OutDir, 0o755); err != nil {
+		return err
+	}
+	if err := writeJSON(filepath.Join(opts.OutDir, "jmeter_endpoints.json"), spec); err != nil {
+		return err
+	}
+	files := []bundleFile{
+		{"automock.jmx", plan.Bytes(), 0o644},
+		{"run_jmeter.sh", runJMeterSh, 0o755},
+		{"run_jmeter.ps1", runJMeterPs1, 0o644},
+	}
+	distributed := opts.GenerateDistributedHelpers != nil && *opts.GenerateDistributedHelpers
+	if distributed {
+		files = append(files, bundleFile{"run_jmeter_distributed.sh", runJMeterDistributedSh, 0o755}, bundleFile{"run_jmeter_distributed.ps1", runJMeterDistributedPs1, 0o644})
+	}
+	samples := []bundleFile{
+		{"user_data.csv", jmeterUserDataCSV, 0o644},
+		{"LOADTEST_README.md", jmeterReadme, 0o644},
+	}
+	if err := writeBundleFiles(opts.OutDir, files, samples); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ JMeter bundle written to %s\n", opts.OutDir)
+	fmt.Printf("Next:\n  cd %s\n  AM_HOST=https://api.example.com ./run_jmeter.sh    # or use .\\run_jmeter.ps1 -AM_HOST ... on Windows\n", opts.OutDir)
+	if distributed {
+		fmt.Println("Distributed mode (JMeter remote servers running jmeter-server, each with user_data.csv):")
+		fmt.Println("  ./run_jmeter_distributed.sh 10.0.0.11,10.0.0.12    # .\\run_jmeter_distributed.ps1 -Servers ... on Windows")
+	}
+	fmt.Println()
+	fmt.Println("Tuning:")
+	fmt.Printf("  - automock.jmx ramps to %d users; override with -Jusers=, -Jramp= and -Jduration= (seconds).\n", users)
+	fmt.Println("  - Each run writes results.jtl and an HTML dashboard to report/.")
+	fmt.Println("  - The plan is generated from jmeter_endpoints.json; regenerate after the collection changes.")
+	fmt.Println("  - Per-user data comes from user_data.csv; use ${data.<column>} placeholders.")
+	return nil
+}
@@ -0,0 +1,204 @@
+package client
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+)
+
+/* =========================
+   Embedded k6 templates
+   ========================= */
+
+//go:embed templates/k6/script.js
+var k6ScriptJs []byte
+
+//go:embed templates/k6/user_data.json
+var k6UserDataJSON []byte
+
+//go:embed templates/k6/LOADTEST_README.md
+var k6Readme []byte
+
+//go:embed templates/k6/run_k6.sh
+var runK6Sh []byte
+
+//go:embed templates/k6/run_k6.ps1
+var runK6Ps1 []byte
+
+// Distributed helpers (k6 execution segments)
+//
+//go:embed templates/k6/run_k6_segment.sh
+var runK6SegmentSh []byte
+
+//go:embed templates/k6/run_k6_segment.ps1
+var runK6SegmentPs1 []byte
+
+// k6Options is k6_options.json: the load profile and the pass/fail criteria
+type k6Options struct {
+	Scenarios  map[string]k6Scenario `json:"scenarios"`
+	Thresholds map[string][]string   `json:"thresholds"`
+}
+
+type k6Scenario struct {
+	Executor         string    `json:"executor"`
+	StartVUs         int       `json:"startVUs"`
+	Stages           []k6Stage `json:"stages"`
+	GracefulRampDown string    `json:"gracefulRampDown"`
+}
+
+type k6Stage struct {
+	Duration string `json:"duration"`
+	Target   int    `json:"target"`
+}
+
+// buildK6Options ramps up to vus, holds and ramps down, and fails the run on more than 1%
+// failed requests or checks, or a p95 response time above p95Ms overall or for any endpoint
+func buildK6Options(eps []Endpoint, vus, p95Ms int) k6Options {
+	limit := fmt.Sprintf("p(95)<%d", p95Ms)
+	thresholds := map[string][]string{
+		"http_req_failed":   {"rate<0.01"},
+		"checks":            {"rate>0.99"},
+		"http_req_duration": {limit},
+	}
+	for _, ep := range eps {
+		if strings.ContainsAny(ep.Name, "{}") {
+			continue // cannot be written as a threshold tag filter
+		}
+		thresholds["http_req_duration{endpoint:"+ep.Name+"}"] = []string{limit}
+	}
+	return k6Options{
+		Scenarios: map[string]k6Scenario{
+			"load": {
+				Executor: "ramping-vus",
+				Stages: []k6Stage{
+					{Duration: "1m", Target: vus},
+					{Duration: "3m", Target: vus},
+					{Duration: "1m", Target: 0},
+				},
+				GracefulRampDown: "30s",
+			},
+		},
+		Thresholds: thresholds,
+	}
+}
+
+// promptLoadProfile asks for the load profile, the latency threshold (when the tool enforces
+// one) and the endpoint weights
+func promptLoadProfile(eps []Endpoint, threshold bool) (vus, p95Ms int, err error) {
+	if vus, err = askPositiveInt("Peak virtual users (VUs):", 20); err != nil {
+		return
+	}
+	if threshold {
+		if p95Ms, err = askPositiveInt("Fail when the 95th percentile response time exceeds (ms):", 500); err != nil {
+			return
+		}
+	}
+	var weigh bool
+	if err = ask.One(&survey.Confirm{
+		Message: "Set endpoint weights (share of traffic per endpoint)?",
+		Default: false,
+		Help:    "Each iteration picks an endpoint in proportion to its weight; all default to 1",
+	}, &weigh); err != nil || !weigh {
+		return
+	}
+	for i := range eps {
+		w, werr := askPositiveInt(fmt.Sprintf("Weight of %s:", eps[i].Name), eps[i].Weight)
+		if werr != nil {
+			return 0, 0, werr
+		}
+		eps[i].Weight = w
+	}
+	return
+}
+
+func askPositiveInt(message string, def int) (int, error) {
+	var value string
+	if err := ask.One(&survey.Input{Message: message, Default: strconv.Itoa(def)}, &value, survey.WithValidator(func(ans interface{}) error {
+		if n, err := strconv.Atoi(strings.TrimSpace(ans.(string))); err != nil || n < 1 {
+			return fmt.Errorf("enter a whole number of 1 or more")
+		}
+		return nil
+	})); err != nil {
+		return 0, err
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(value))
+	return n, nil
+}
+
+// bundleFile is a template written into the bundle
+type bundleFile struct {
+	name string
+	data []byte
+	mode os.FileMode
+}
+
+// writeBundleFiles writes files into dir, and samples only where absent so edits survive
+// regeneration
+func writeBundleFiles(dir string, files, samples []bundleFile) error {
+	for _, f := range files {
+		if err := os.WriteFile(filepath.Join(dir, f.name), f.data, f.mode); err != nil {
+			return err
+		}
+	}
+	for _, f := range samples {
+		path := filepath.Join(dir, f.name)
+		if _, statErr := os.Stat(path); os.IsNotExist(statErr) {
+			if err := os.WriteFile(path, f.data, f.mode); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeK6Bundle writes the k6 runner, its endpoint spec (the same model the Locust bundle
+// uses) and options, and the run scripts
+func writeK6Bundle(opts Options, spec map[string]any, eps []Endpoint) error {
+	vus, p95Ms, err := promptLoadProfile(eps, true)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(opts.OutDir, 0o755); err != nil {
+		return err
+	}
+	if err := writeJSON(filepath.Join(opts.OutDir, "k6_endpoints.json"), spec); err != nil {
+		return err
+	}
+	if err := writeJSON(filepath.Join(opts.OutDir, "k6_options.json"), buildK6Options(eps, vus, p95Ms)); err != nil {
+		return err
+	}
+	files := []bundleFile{
+		{"script.js", k6ScriptJs, 0o644},
+		{"run_k6.sh", runK6Sh, 0o755},
+		{"run_k6.ps1", runK6Ps1, 0o644},
+	}
+	if opts.GenerateDistributedHelpers != nil && *opts.GenerateDistributedHelpers {
+		files = append(files, bundleFile{"run_k6_segment.sh", runK6SegmentSh, 0o755}, bundleFile{"run_k6_segment.ps1", runK6SegmentPs1, 0o644})
+	}
+	samples := []bundleFile{
+		{"user_data.json", k6UserDataJSON, 0o644},
+		{"LOADTEST_README.md", k6Readme, 0o644},
+	}
+	if err := writeBundleFiles(opts.OutDir, files, samples); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ k6 bundle written to %s\n", opts.OutDir)
+	fmt.Printf("Next:\n  cd %s\n  AM_HOST=https://api.example.com ./run_k6.sh    # or use .\\run_k6.ps1 -AM_HOST ... on Windows\n", opts.OutDir)
+	if opts.GenerateDistributedHelpers != nil && *opts.GenerateDistributedHelpers {
+		fmt.Println("Distributed mode (one instance per machine, each runs its segment of the load):")
+		fmt.Println("  ./run_k6_segment.sh 1 3    # machine 1 of 3; .\\run_k6_segment.ps1 -Index 1 -Count 3 on Windows")
+	}
+	fmt.Println()
+	fmt.Println("Tuning:")
+	fmt.Printf("  - k6_options.json holds the scenario (ramp to %d VUs) and thresholds (p95 < %dms, < 1%% failures).\n", vus, p95Ms)
+	fmt.Println("  - Endpoint weights, auth, data placeholders and config live in k6_endpoints.json (same format as Locust's).")
+	fmt.Println("  - Per-user data comes from user_data.json; use ${data.<field>} placeholders.")
+	return nil
+}
@@ -11,7 +11,9 @@ import (
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
 	"github.com/hemantobora/auto-mock/internal/collections"
+	"github.com/hemantobora/auto-mock/internal/loadtest"
 )
 
 /* =========================
@@ -67,36 +69,28 @@ var runWorkerPs1 []byte
 type Options struct {
 	// If empty, we prompt.
 	CollectionPath string
-	CollectionType string // Postman | Insomnia | Bruno | Other (informational only)
+	CollectionType string // Postman | Insomnia | Bruno; empty auto-detects from the file contents
 	OutDir         string // default ./loadtest
 	// If nil, we prompt. If non-nil, use the value (true=headless, false=UI).
 	Headless *bool
 	// If nil, we prompt. If non-nil, use the value.
 	GenerateDistributedHelpers *bool
+	Tool                       string // loadtest.ToolLocust (default), ToolK6, ToolGatling or ToolJMeter
 }
 
 func GenerateLoadtestBundle(opts Options) error {
 	// Ask for missing basics
 	if opts.CollectionPath == "" {
-		if err := survey.AskOne(&survey.Input{
+		if err := ask.One(&survey.Input{
 			Message: "Path to collection file:",
 		}, &opts.CollectionPath, survey.WithValidator(survey.Required)); err != nil {
 			return err
 		}
 	}
-	if opts.CollectionType == "" {
-		if err := survey.AskOne(&survey.Select{
-			Message: "Collection type:",
-			Options: []string{"Postman", "Insomnia", "Bruno"},
-			Default: "Postman",
-		}, &opts.CollectionType); err != nil {
-			return err
-		}
-	}
 	if opts.OutDir == "" {
 		defaultDir := filepath.Join(".", "loadtest")
 
-		if err := survey.AskOne(&survey.Input{
+		if err := ask.One(&survey.Input{
 			Message: "Output directory for load test bundle:",
 			Default: defaultDir,
 			Help:    "Provide a writable path where generated load test files will be stored.",
@@ -176,6 +170,15 @@ func GenerateLoadtestBundle(opts Options) error {
 		"endpoints": eps,
 	}
 
+	switch opts.Tool {
+	case loadtest.ToolK6:
+		return writeK6Bundle(opts, spec, eps)
+	case loadtest.ToolGatling:
+		return writeGatlingBundle(opts, spec, eps)
+	case loadtest.ToolJMeter:
+		return writeJMeterBundle(opts, spec, eps)
+	}
+
 	// Write files
 	if err := os.MkdirAll(opts.OutDir, 0o755); err != nil {
 		return err
@@ -308,7 +311,7 @@ func promptAuthSelection(reqs []collections.APIRequest) (index int, mode, tokenP
 		opts = append(opts, fmt.Sprintf("%s %s", strings.ToUpper(r.Method), toPath(r.URL)))
 	}
 	choice := ""
-	if err = survey.AskOne(&survey.Select{
+	if err = ask.One(&survey.Select{
 		Message:  "Select the authentication request (or None):",
 		Options:  opts,
 		Default:  "None",
@@ -334,7 +337,7 @@ func promptAuthSelection(reqs []collections.APIRequest) (index int, mode, tokenP
 
 	// Auth scope
 	scope := ""
-	if err = survey.AskOne(&survey.Select{
+	if err = ask.One(&survey.Select{
 		Message: "Auth scope:",
 		Options: []string{"shared (once for all users)", "per_user (once per virtual user)"},
 		Default: "shared (once for all users)",
@@ -349,11 +352,11 @@ func promptAuthSelection(reqs []collections.APIRequest) (index int, mode, tokenP
 
 	// Token extraction & header injection
 	tokenPath = "access_token"
-	_ = survey.AskOne(&survey.Input{Message: "Token JSON path in login response (e.g., access_token or data.token):", Default: "access_token"}, &tokenPath)
+	_ = ask.One(&survey.Input{Message: "Token JSON path in login response (e.g., access_token or data.token):", Default: "access_token"}, &tokenPath)
 	headerName = "Authorization"
-	_ = survey.AskOne(&survey.Input{Message: "Header name to carry the token:", Default: "Authorization"}, &headerName)
+	_ = ask.One(&survey.Input{Message: "Header name to carry the token:", Default: "Authorization"}, &headerName)
 	headerPrefix = "Bearer "
-	_ = survey.AskOne(&survey.Input{Message: "Header prefix (empty for none, e.g. ' '):", Default: "Bearer "}, &headerPrefix)
+	_ = ask.One(&survey.Input{Message: "Header prefix (empty for none, e.g. ' '):", Default: "Bearer "}, &headerPrefix)
 
 	return idx, mode, tokenPath, headerName, headerPrefix, nil
 }
@@ -420,7 +423,7 @@ func promptRetainHostSelection(reqs []collections.APIRequest) (map[string]bool,
 		PageSize: 15,
 	}
 
-	if err := survey.AskOne(prompt, &selected); err != nil {
+	if err := ask.One(prompt, &selected); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// loadSpec is the typed view of the endpoint spec (locust_endpoints.json and friends) that
+// the code-generating tools, Gatling and JMeter, compile into a simulation or test plan
+type loadSpec struct {
+	Auth struct {
+		Mode          string            `json:"mode"` // none | shared | per_user
+		Method        string            `json:"method"`
+		Path          string            `json:"path"`
+		Headers       map[string]string `json:"headers"`
+		Body          string            `json:"body"`
+		TokenJSONPath string            `json:"token_json_path"`
+		HeaderName    string            `json:"header_name"`
+		HeaderPrefix  string            `json:"header_prefix"`
+	} `json:"auth"`
+	Config struct {
+		DefaultHeaders map[string]string `json:"default_headers"`
+		MinWaitSeconds float64           `json:"min_wait_seconds"`
+		MaxWaitSeconds float64           `json:"max_wait_seconds"`
+	} `json:"config"`
+	Endpoints []Endpoint `json:"endpoints"`
+}
+
+// parseLoadSpec converts the generated spec into a loadSpec
+func parseLoadSpec(spec map[string]any) (loadSpec, error) {
+	var ls loadSpec
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return ls, err
+	}
+	if err := json.Unmarshal(data, &ls); err != nil {
+		return ls, fmt.Errorf("read endpoint spec: %w", err)
+	}
+	return ls, nil
+}
+
+// HasAuth reports whether requests carry a token from a login request
+func (ls loadSpec) HasAuth() bool {
+	return ls.Auth.Mode == "shared" || ls.Auth.Mode == "per_user"
+}
+
+// waitMs returns the think time range between requests in milliseconds
+func (ls loadSpec) waitMs() (min, max int) {
+	min, max = int(ls.Config.MinWaitSeconds*1000), int(ls.Config.MaxWaitSeconds*1000)
+	if max < min {
+		max = min
+	}
+	return min, max
+}
+
+var specPlaceholder = regexp.MustCompile(`\$\{(env|data|user)\.([A-Za-z0-9_]+)\}`)
+
+// splitPlaceholders walks s, calling lit for literal text and ref for each ${env.X},
+// ${data.X} and ${user.X} placeholder, in order
+func splitPlaceholders(s string, lit func(string), ref func(kind, name string)) {
+	last := 0
+	for _, m := range specPlaceholder.FindAllStringSubmatchIndex(s, -1) {
+		if m[0] > last {
+			lit(s[last:m[0]])
+		}
+		ref(s[m[2]:m[3]], s[m[4]:m[5]])
+		last = m[1]
+	}
+	if last < len(s) {
+		lit(s[last:])
+	}
+}
+
+// weightShares converts endpoint weights into percentages of the traffic, in hundredths of a
+// percent, that add up to exactly 100%
+func weightShares(eps []Endpoint) []int {
+	total := 0
+	for _, ep := range eps {
+		total += max(ep.Weight, 1)
+	}
+	shares := make([]int, len(eps))
+	left := 10000
+	for i, ep := range eps {
+		if i == len(eps)-1 {
+			shares[i] = left
+			break
+		}
+		shares[i] = max(ep.Weight, 1) * 10000 / total
+		left -= shares[i]
+	}
+	return shares
+}
+
+// formatShare prints a share from weightShares as a percentage, e.g. 3333 as "33.33"
+func formatShare(share int) string {
+	return fmt.Sprintf("%d.%02d", share/100, share%100)
+}
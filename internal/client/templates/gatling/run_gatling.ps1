@@ -0,0 +1,33 @@
+param(
+  [string]$AM_HOST = "",
+  [string]$AM_USERS = ""
+)
+
+# Optional: load environment variables from a local .env file
+if (Test-Path ".env") {
+  Get-Content .env | ForEach-Object {
+    if ($_ -match '^\s*#') { return }
+    if ($_ -match '^\s*$') { return }
+    $parts = $_ -split '=', 2
+    if ($parts.Length -eq 2) {
+      $key = $parts[0].Trim()
+      $val = $parts[1].Trim().Trim('"\'')
+      Set-Item -Path Env:$key -Value $val
+    }
+  }
+  Write-Host "Loaded environment variables from .env"
+}
+
+if (-not (Get-Command mvn -ErrorAction SilentlyContinue)) {
+  Write-Error "Maven is not installed (Gatling runs through the gatling-maven-plugin): https://maven.apache.org/install.html"
+  exit 1
+}
+
+if ($AM_HOST -eq "") { $AM_HOST = $env:AM_HOST }
+if ($AM_USERS -eq "") { $AM_USERS = $env:AM_USERS }
+$mvnArgs = @("-q", "gatling:test")
+if ($AM_HOST) { $mvnArgs += "-Dhost=$AM_HOST" }
+if ($AM_USERS) { $mvnArgs += "-Dusers=$AM_USERS" }
+# Extra arguments are passed to Maven, e.g. .\run_gatling.ps1 -- -Dduration=600
+# The HTML report is written under target\gatling\
+mvn @mvnArgs @args
@@ -0,0 +1,29 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+# AM_HOST sets the base URL (default http://localhost:8080)
+# AM_USERS overrides the number of virtual users baked into the simulation
+
+# Optional: load environment variables from a local .env file
+if [ -f ".env" ]; then
+  # shellcheck disable=SC1091
+  set +u
+  set -a
+  . ./.env
+  set +a
+  set -u
+  echo "Loaded environment variables from .env"
+fi
+
+if ! command -v mvn >/dev/null 2>&1; then
+  echo "Maven is not installed (Gatling runs through the gatling-maven-plugin): https://maven.apache.org/install.html" >&2
+  exit 1
+fi
+
+ARGS=()
+if [ -n "${AM_HOST:-}" ]; then ARGS+=("-Dhost=$AM_HOST"); fi
+if [ -n "${AM_USERS:-}" ]; then ARGS+=("-Dusers=$AM_USERS"); fi
+
+# Extra arguments are passed to Maven, e.g. ./run_gatling.sh -Dduration=600
+# The HTML report is written under target/gatling/
+mvn -q gatling:test ${ARGS[@]+"${ARGS[@]}"} "$@"
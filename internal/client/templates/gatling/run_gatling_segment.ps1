@@ -0,0 +1,25 @@
+# Distributed run: start one instance per machine, each with its share of the virtual users.
+#   machine 1: .\run_gatling_segment.ps1 -Index 1 -Count 3
+#   machine 2: .\run_gatling_segment.ps1 -Index 2 -Count 3
+# Users is the total across all machines (default: the number baked into the simulation).
+# Each instance writes its own report under target\gatling\; assertions are evaluated per instance.
+param(
+  [Parameter(Mandatory = $true)][int]$Index,
+  [Parameter(Mandatory = $true)][int]$Count,
+  [int]$Users = 0,
+  [string]$AM_HOST = ""
+)
+
+if ($Index -lt 1 -or $Index -gt $Count) {
+  Write-Error "Index must be between 1 and Count"
+  exit 2
+}
+if ($Users -eq 0) {
+  $match = Select-String -Path "$PSScriptRoot\AutoMockSimulation.scala" -Pattern 'Integer.getInteger\("users", (\d+)\)'
+  $Users = [int]$match.Matches[0].Groups[1].Value
+}
+# Spread the remainder over the first machines so the shares add up to the total
+$share = [math]::Floor($Users / $Count)
+if ($Index -le ($Users % $Count)) { $share += 1 }
+
+& "$PSScriptRoot\run_gatling.ps1" -AM_HOST $AM_HOST -AM_USERS $share
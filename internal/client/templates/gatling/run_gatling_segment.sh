@@ -0,0 +1,22 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+# Distributed run: start one instance per machine, each with its share of the virtual users.
+#   machine 1: ./run_gatling_segment.sh 1 3
+#   machine 2: ./run_gatling_segment.sh 2 3
+#   machine 3: ./run_gatling_segment.sh 3 3
+# USERS is the total across all machines (default: the number baked into the simulation).
+# Each instance writes its own report under target/gatling/; assertions are evaluated per instance.
+INDEX="${1:-${AM_SEGMENT_INDEX:-}}"
+COUNT="${2:-${AM_SEGMENT_COUNT:-}}"
+if [ -z "$INDEX" ] || [ -z "$COUNT" ] || [ "$INDEX" -lt 1 ] || [ "$INDEX" -gt "$COUNT" ]; then
+  echo "usage: USERS=<total> $0 <index 1..count> <count> [maven args...]" >&2
+  exit 2
+fi
+shift $(( $# < 2 ? $# : 2 ))
+
+TOTAL="${USERS:-$(sed -n 's/.*Integer.getInteger("users", \([0-9]*\)).*/\1/p' AutoMockSimulation.scala)}"
+# Spread the remainder over the first machines so the shares add up to the total
+SHARE=$(( TOTAL / COUNT + (INDEX <= TOTAL % COUNT ? 1 : 0) ))
+
+AM_USERS="$SHARE" exec "$(dirname "$0")/run_gatling.sh" "$@"
@@ -0,0 +1,39 @@
+param(
+  [string]$AM_HOST = "",
+  [string]$AM_USERS = ""
+)
+
+# Optional: load environment variables from a local .env file
+if (Test-Path ".env") {
+  Get-Content .env | ForEach-Object {
+    if ($_ -match '^\s*#') { return }
+    if ($_ -match '^\s*$') { return }
+    $parts = $_ -split '=', 2
+    if ($parts.Length -eq 2) {
+      $key = $parts[0].Trim()
+      $val = $parts[1].Trim().Trim('"\'')
+      Set-Item -Path Env:$key -Value $val
+    }
+  }
+  Write-Host "Loaded environment variables from .env"
+}
+
+if (-not (Get-Command jmeter -ErrorAction SilentlyContinue)) {
+  Write-Error "JMeter is not installed: https://jmeter.apache.org/download_jmeter.cgi"
+  exit 1
+}
+
+if ($AM_HOST -eq "") { $AM_HOST = $env:AM_HOST }
+if (-not $AM_HOST) { $AM_HOST = "http://localhost:8080" }
+if ($AM_USERS -eq "") { $AM_USERS = $env:AM_USERS }
+
+# Split AM_HOST into the protocol, host and port properties the plan reads
+$uri = [System.Uri]$AM_HOST
+$jmeterArgs = @("-n", "-t", "automock.jmx", "-l", "results.jtl", "-e", "-o", "report",
+  "-Jprotocol=$($uri.Scheme)", "-Jhost=$($uri.Host)", "-Jport=$($uri.Port)")
+if ($AM_USERS) { $jmeterArgs += "-Jusers=$AM_USERS" }
+
+# Extra arguments are passed to JMeter, e.g. .\run_jmeter.ps1 -- -Jduration=600
+Remove-Item -Recurse -Force report, results.jtl -ErrorAction SilentlyContinue
+jmeter @jmeterArgs @args
+Write-Host "HTML dashboard: report\index.html"
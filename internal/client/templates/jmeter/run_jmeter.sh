@@ -0,0 +1,38 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+# AM_HOST sets the base URL (default http://localhost:8080)
+# AM_USERS overrides the number of virtual users baked into the plan
+
+# Optional: load environment variables from a local .env file
+if [ -f ".env" ]; then
+  # shellcheck disable=SC1091
+  set +u
+  set -a
+  . ./.env
+  set +a
+  set -u
+  echo "Loaded environment variables from .env"
+fi
+
+if ! command -v jmeter >/dev/null 2>&1; then
+  echo "JMeter is not installed: https://jmeter.apache.org/download_jmeter.cgi" >&2
+  exit 1
+fi
+
+# Split AM_HOST into the protocol, host and port properties the plan reads
+: "${AM_HOST:=http://localhost:8080}"
+PROTOCOL="${AM_HOST%%://*}"
+REST="${AM_HOST#*://}"
+REST="${REST%%/*}"
+HOST="${REST%%:*}"
+PORT=""
+if [ "$REST" != "$HOST" ]; then PORT="${REST#*:}"; fi
+
+ARGS=(-Jprotocol="$PROTOCOL" -Jhost="$HOST" -Jport="$PORT")
+if [ -n "${AM_USERS:-}" ]; then ARGS+=(-Jusers="$AM_USERS"); fi
+
+# Extra arguments are passed to JMeter, e.g. ./run_jmeter.sh -Jduration=600
+rm -rf report results.jtl
+jmeter -n -t automock.jmx -l results.jtl -e -o report "${ARGS[@]}" "$@"
+echo "HTML dashboard: report/index.html"
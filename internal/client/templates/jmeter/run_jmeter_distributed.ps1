@@ -0,0 +1,30 @@
+# Distributed run: this machine controls JMeter remote servers (each running jmeter-server
+# with user_data.csv in its working directory), and every server runs the full plan.
+#   .\run_jmeter_distributed.ps1 -Servers 10.0.0.11,10.0.0.12
+# Users is the total across all servers (default: the number baked into the plan); each server
+# gets an equal share. Results are collected here in results.jtl and report\.
+param(
+  [Parameter(Mandatory = $true)][string[]]$Servers,
+  [int]$Users = 0,
+  [string]$AM_HOST = ""
+)
+
+if (-not (Get-Command jmeter -ErrorAction SilentlyContinue)) {
+  Write-Error "JMeter is not installed: https://jmeter.apache.org/download_jmeter.cgi"
+  exit 1
+}
+
+if ($AM_HOST -eq "") { $AM_HOST = $env:AM_HOST }
+if (-not $AM_HOST) { $AM_HOST = "http://localhost:8080" }
+$uri = [System.Uri]$AM_HOST
+if ($Users -eq 0) {
+  $match = Select-String -Path "$PSScriptRoot\automock.jmx" -Pattern '\$\{__P\(users,(\d+)\)\}'
+  $Users = [int]$match.Matches[0].Groups[1].Value
+}
+$share = [math]::Ceiling($Users / $Servers.Count)
+
+# -G sets the properties on the remote servers
+Remove-Item -Recurse -Force report, results.jtl -ErrorAction SilentlyContinue
+jmeter -n -t automock.jmx -R ($Servers -join ",") -l results.jtl -e -o report `
+  "-Gprotocol=$($uri.Scheme)" "-Ghost=$($uri.Host)" "-Gport=$($uri.Port)" "-Gusers=$share" @args
+Write-Host "HTML dashboard: report\index.html"
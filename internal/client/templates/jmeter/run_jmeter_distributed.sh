@@ -0,0 +1,37 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+# Distributed run: this machine controls JMeter remote servers (each running jmeter-server
+# with user_data.csv in its working directory), and every server runs the full plan.
+#   ./run_jmeter_distributed.sh 10.0.0.11,10.0.0.12
+# USERS is the total across all servers (default: the number baked into the plan); each server
+# gets an equal share. Results are collected here in results.jtl and report/.
+SERVERS="${1:-${AM_REMOTE_SERVERS:-}}"
+if [ -z "$SERVERS" ]; then
+  echo "usage: USERS=<total> $0 <server1,server2,...> [jmeter args...]" >&2
+  exit 2
+fi
+shift $(( $# < 1 ? $# : 1 ))
+
+if ! command -v jmeter >/dev/null 2>&1; then
+  echo "JMeter is not installed: https://jmeter.apache.org/download_jmeter.cgi" >&2
+  exit 1
+fi
+
+: "${AM_HOST:=http://localhost:8080}"
+PROTOCOL="${AM_HOST%%://*}"
+REST="${AM_HOST#*://}"
+REST="${REST%%/*}"
+HOST="${REST%%:*}"
+PORT=""
+if [ "$REST" != "$HOST" ]; then PORT="${REST#*:}"; fi
+
+COUNT=$(( $(tr -cd ',' <<<"$SERVERS" | wc -c) + 1 ))
+TOTAL="${USERS:-$(sed -n 's/.*\${__P(users,\([0-9]*\))}.*/\1/p' automock.jmx)}"
+SHARE=$(( (TOTAL + COUNT - 1) / COUNT ))
+
+# -G sets the properties on the remote servers
+rm -rf report results.jtl
+jmeter -n -t automock.jmx -R "$SERVERS" -l results.jtl -e -o report \
+  -Gprotocol="$PROTOCOL" -Ghost="$HOST" -Gport="$PORT" -Gusers="$SHARE" "$@"
+echo "HTML dashboard: report/index.html"
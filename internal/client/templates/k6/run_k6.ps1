@@ -0,0 +1,31 @@
+param(
+  [string]$AM_K6_JSON = "k6_endpoints.json",
+  [string]$AM_K6_OPTIONS = "k6_options.json",
+  [string]$AM_HOST = ""
+)
+
+# Optional: load environment variables from a local .env file
+if (Test-Path ".env") {
+  Get-Content .env | ForEach-Object {
+    if ($_ -match '^\s*#') { return }
+    if ($_ -match '^\s*$') { return }
+    $parts = $_ -split '=', 2
+    if ($parts.Length -eq 2) {
+      $key = $parts[0].Trim()
+      $val = $parts[1].Trim().Trim('"\'')
+      Set-Item -Path Env:$key -Value $val
+    }
+  }
+  Write-Host "Loaded environment variables from .env"
+}
+
+if (-not (Get-Command k6 -ErrorAction SilentlyContinue)) {
+  Write-Error "k6 is not installed: https://grafana.com/docs/k6/latest/set-up/install-k6/"
+  exit 1
+}
+
+$env:AM_K6_JSON = $AM_K6_JSON
+$env:AM_K6_OPTIONS = $AM_K6_OPTIONS
+if ($AM_HOST -ne "") { $env:AM_HOST = $AM_HOST }
+# Extra arguments are passed to k6, e.g. .\run_k6.ps1 -- --vus 50
+k6 run @args script.js
@@ -0,0 +1,26 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+: "${AM_K6_JSON:=k6_endpoints.json}"
+: "${AM_K6_OPTIONS:=k6_options.json}"
+# AM_HOST sets the base URL unless config.host is set in k6_endpoints.json
+
+# Optional: load environment variables from a local .env file
+if [ -f ".env" ]; then
+  # shellcheck disable=SC1091
+  set +u
+  set -a
+  . ./.env
+  set +a
+  set -u
+  echo "Loaded environment variables from .env"
+fi
+
+if ! command -v k6 >/dev/null 2>&1; then
+  echo "k6 is not installed: https://grafana.com/docs/k6/latest/set-up/install-k6/" >&2
+  exit 1
+fi
+
+# Extra arguments are passed to k6, e.g. ./run_k6.sh --vus 50 --duration 10m
+AM_K6_JSON="$AM_K6_JSON" AM_K6_OPTIONS="$AM_K6_OPTIONS" \
+k6 run "$@" script.js
@@ -0,0 +1,23 @@
+# Distributed run: start one instance per machine, each with its own segment of the load.
+#   machine 1: .\run_k6_segment.ps1 -Index 1 -Count 3
+#   machine 2: .\run_k6_segment.ps1 -Index 2 -Count 3
+# Each instance runs its share of the VUs and iterations (k6 execution segments) and writes
+# its summary to summary_segment_<index>.json for merging.
+param(
+  [Parameter(Mandatory = $true)][int]$Index,
+  [Parameter(Mandatory = $true)][int]$Count,
+  [string]$AM_HOST = ""
+)
+
+if ($Index -lt 1 -or $Index -gt $Count) {
+  Write-Error "Index must be between 1 and Count"
+  exit 2
+}
+$sequence = @("0")
+for ($i = 1; $i -lt $Count; $i++) { $sequence += "$i/$Count" }
+$sequence += "1"
+
+& "$PSScriptRoot\run_k6.ps1" -AM_HOST $AM_HOST `
+  --execution-segment "$($Index - 1)/${Count}:$Index/$Count" `
+  --execution-segment-sequence ($sequence -join ",") `
+  --summary-export "summary_segment_$Index.json"
@@ -0,0 +1,25 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+# Distributed run: start one instance per machine, each with its own segment of the load.
+#   machine 1: ./run_k6_segment.sh 1 3
+#   machine 2: ./run_k6_segment.sh 2 3
+#   machine 3: ./run_k6_segment.sh 3 3
+# Each instance runs its share of the VUs and iterations (k6 execution segments) and writes
+# its summary to summary_segment_<index>.json for merging.
+INDEX="${1:-${AM_SEGMENT_INDEX:-}}"
+COUNT="${2:-${AM_SEGMENT_COUNT:-}}"
+if [ -z "$INDEX" ] || [ -z "$COUNT" ] || [ "$INDEX" -lt 1 ] || [ "$INDEX" -gt "$COUNT" ]; then
+  echo "usage: $0 <index 1..count> <count> [k6 args...]" >&2
+  exit 2
+fi
+shift $(( $# < 2 ? $# : 2 ))
+
+SEQUENCE="0"
+for ((i = 1; i < COUNT; i++)); do SEQUENCE="$SEQUENCE,$i/$COUNT"; done
+SEQUENCE="$SEQUENCE,1"
+
+exec "$(dirname "$0")/run_k6.sh" \
+  --execution-segment "$((INDEX - 1))/$COUNT:$INDEX/$COUNT" \
+  --execution-segment-sequence "$SEQUENCE" \
+  --summary-export "summary_segment_$INDEX.json" "$@"
@@ -0,0 +1,230 @@
+// AutoMock k6 load test. Reads the endpoint spec (k6_endpoints.json), the run options
+// (k6_options.json: scenarios and thresholds) and optional per-user data (user_data.json).
+import http from 'k6/http';
+import { check, sleep } from 'k6';
+import exec from 'k6/execution';
+
+// -------------------------------------------------------------------
+// Config / Spec Loading
+// -------------------------------------------------------------------
+
+const JSON_PATH = __ENV.AM_K6_JSON || './k6_endpoints.json';
+const OPTIONS_PATH = __ENV.AM_K6_OPTIONS || './k6_options.json';
+
+function expandEnv(v) {
+  if (typeof v === 'string') {
+    return v.replace(/\$\{env\.([A-Za-z_][A-Za-z0-9_]*)\}/g, (_, name) => __ENV[name] || '');
+  }
+  if (Array.isArray(v)) return v.map(expandEnv);
+  if (v && typeof v === 'object') {
+    const out = {};
+    for (const k of Object.keys(v)) out[k] = expandEnv(v[k]);
+    return out;
+  }
+  return v;
+}
+
+const SPEC = expandEnv(JSON.parse(open(JSON_PATH)));
+const AUTH = SPEC.auth || { mode: 'none' };
+const CFG = SPEC.config || {};
+const HOST = (__ENV.AM_HOST || CFG.host || '').replace(/\/+$/, '');
+
+export const options = JSON.parse(open(OPTIONS_PATH));
+
+// -------------------------------------------------------------------
+// Optional per-user data for parameterization
+// -------------------------------------------------------------------
+
+function loadUserData() {
+  try {
+    const txt = open(__ENV.AM_USER_DATA || './user_data.json').trim();
+    if (!txt) return [];
+    if (txt[0] === '[') return JSON.parse(txt);
+    return txt.split('\n').filter((l) => l.trim()).map((l) => JSON.parse(l)); // NDJSON
+  } catch (e) {
+    return []; // no data file
+  }
+}
+
+const USER_DATA = loadUserData();
+const DATA_ASSIGNMENT = (CFG.data_assignment || 'round_robin').toLowerCase(); // shared | round_robin | random
+const DATA_SHARED_INDEX = parseInt(CFG.data_shared_index || 0, 10) || 0;
+
+function assignUserData(userIndex) {
+  if (!USER_DATA.length) return {};
+  if (DATA_ASSIGNMENT === 'shared') return USER_DATA[DATA_SHARED_INDEX % USER_DATA.length];
+  if (DATA_ASSIGNMENT === 'random') return USER_DATA[Math.floor(Math.random() * USER_DATA.length)];
+  return USER_DATA[userIndex % USER_DATA.length]; // round_robin (default)
+}
+
+function expandRuntime(v, ctx) {
+  if (typeof v === 'string') {
+    return v
+      .replace(/\$\{data\.([A-Za-z_][A-Za-z0-9_]*)\}/g, (_, f) => (ctx.data[f] === undefined ? '' : String(ctx.data[f])))
+      .replace(/\$\{user\.(id|index)\}/g, () => String(ctx.user));
+  }
+  if (Array.isArray(v)) return v.map((x) => expandRuntime(x, ctx));
+  if (v && typeof v === 'object') {
+    const out = {};
+    for (const k of Object.keys(v)) out[k] = expandRuntime(v[k], ctx);
+    return out;
+  }
+  return v;
+}
+
+// -------------------------------------------------------------------
+// Config defaults & helpers
+// -------------------------------------------------------------------
+
+const DEFAULT_HEADERS = CFG.default_headers || {};
+const DEFAULT_PARAMS = CFG.default_params || {};
+const WAIT_STRATEGY = (CFG.wait_strategy || 'between').toLowerCase(); // between | constant | random_exp
+const MIN_WAIT = Number(CFG.min_wait_seconds === undefined ? 0.2 : CFG.min_wait_seconds);
+const MAX_WAIT = Number(CFG.max_wait_seconds === undefined ? 1.0 : CFG.max_wait_seconds);
+const CONST_WAIT = Number(CFG.constant_wait_seconds === undefined ? 1.0 : CFG.constant_wait_seconds);
+const TIMEOUT = `${Number(CFG.request_timeout_seconds || 30)}s`;
+
+function asList(v) {
+  if (Array.isArray(v)) return v.map(String);
+  if (typeof v === 'string' && v.trim()) return v.split(',').map((s) => s.trim());
+  return [];
+}
+const INCLUDE_TAGS = asList(CFG.include_tags);
+const EXCLUDE_TAGS = asList(CFG.exclude_tags);
+
+function url(path, params) {
+  const base = /^https?:\/\//.test(path) ? path : HOST + path;
+  const query = Object.keys(params)
+    .map((k) => `${encodeURIComponent(k)}=${encodeURIComponent(params[k])}`)
+    .join('&');
+  if (!query) return base;
+  return base + (base.indexOf('?') >= 0 ? '&' : '?') + query;
+}
+
+function payload(body, headers) {
+  if (body === undefined || body === null || body === '') return null;
+  if (typeof body === 'string') return body;
+  if (!Object.keys(headers).some((h) => h.toLowerCase() === 'content-type')) headers['Content-Type'] = 'application/json';
+  return JSON.stringify(body);
+}
+
+function think() {
+  if (WAIT_STRATEGY === 'constant') return sleep(CONST_WAIT);
+  const span = Math.max(MAX_WAIT - MIN_WAIT, 0.001);
+  if (WAIT_STRATEGY === 'random_exp') {
+    // Exponential-like wait with mean roughly between MIN and MAX; cap at MAX
+    return sleep(Math.min(MIN_WAIT + -Math.log(1 - Math.random()) * (span / 1.5), MAX_WAIT));
+  }
+  return sleep(MIN_WAIT + Math.random() * span);
+}
+
+// -------------------------------------------------------------------
+// Auth helpers
+// -------------------------------------------------------------------
+
+function jsonGet(d, path) {
+  let cur = d;
+  for (const part of (path || '').split('.')) {
+    if (!cur || typeof cur !== 'object' || !(part in cur)) return null;
+    cur = cur[part];
+  }
+  return cur;
+}
+
+function login(ctx) {
+  const mode = (AUTH.mode || 'none').toLowerCase();
+  if (mode === 'none') return null;
+  const headers = Object.assign({}, expandRuntime(AUTH.headers || {}, ctx));
+  const body = payload(expandRuntime(AUTH.body, ctx), headers);
+  const path = expandRuntime(AUTH.path || '/', ctx);
+  const res = http.request((AUTH.method || 'POST').toUpperCase(), url(path, {}), body, {
+    headers, timeout: TIMEOUT, tags: { endpoint: 'AUTH ' + (AUTH.path || '/') },
+  });
+  if (res.status >= 400) {
+    console.error(`[auth] failed: HTTP ${res.status} - ${String(res.body).slice(0, 200)}`);
+    return null;
+  }
+  let token = null;
+  try {
+    token = jsonGet(res.json(), AUTH.token_json_path || 'access_token');
+  } catch (e) {
+    token = null;
+  }
+  if (!token) console.error(`[auth] token not found at path '${AUTH.token_json_path || 'access_token'}'`);
+  return token;
+}
+
+function withToken(headers, token) {
+  if (!token) return headers;
+  const prefix = AUTH.header_prefix === undefined ? 'Bearer ' : AUTH.header_prefix;
+  headers[AUTH.header_name || 'Authorization'] = prefix + token;
+  return headers;
+}
+
+// -------------------------------------------------------------------
+// Weighted endpoint selection, honoring include/exclude tags
+// -------------------------------------------------------------------
+
+const ENDPOINTS = (SPEC.endpoints || []).filter((ep) => {
+  const tags = ep.tags || [];
+  if (INCLUDE_TAGS.length && !tags.some((t) => INCLUDE_TAGS.indexOf(t) >= 0)) return false;
+  if (EXCLUDE_TAGS.length && tags.some((t) => EXCLUDE_TAGS.indexOf(t) >= 0)) return false;
+  return true;
+});
+const WEIGHTS = [];
+let TOTAL_WEIGHT = 0;
+for (const ep of ENDPOINTS) {
+  TOTAL_WEIGHT += Math.max(parseInt(ep.weight || 1, 10) || 1, 1);
+  WEIGHTS.push(TOTAL_WEIGHT);
+}
+
+function pick() {
+  const r = Math.random() * TOTAL_WEIGHT;
+  for (let i = 0; i < WEIGHTS.length; i++) {
+    if (r < WEIGHTS[i]) return ENDPOINTS[i];
+  }
+  return ENDPOINTS[ENDPOINTS.length - 1];
+}
+
+// -------------------------------------------------------------------
+// Lifecycle
+// -------------------------------------------------------------------
+
+export function setup() {
+  if (!ENDPOINTS.length) throw new Error('no endpoints selected; check include_tags/exclude_tags in ' + JSON_PATH);
+  if (!HOST && ENDPOINTS.some((ep) => !/^https?:\/\//.test(ep.path))) {
+    throw new Error('set AM_HOST (e.g. AM_HOST=https://api.example.com) or config.host in ' + JSON_PATH);
+  }
+  if ((AUTH.mode || 'none').toLowerCase() === 'shared') {
+    const token = login({ data: {}, user: 0 });
+    if (token) console.log('🔐 Auth OK (shared token)');
+    return { token };
+  }
+  return { token: null };
+}
+
+// Per-VU state: each virtual user runs in its own JavaScript runtime
+let vuToken = null;
+let vuData = null;
+
+export default function (shared) {
+  const userIndex = exec.vu.idInTest - 1;
+  if (vuData === null) {
+    vuData = assignUserData(userIndex);
+    if ((AUTH.mode || 'none').toLowerCase() === 'per_user') vuToken = login({ data: vuData, user: userIndex });
+  }
+  const ep = pick();
+  const ctx = { data: vuData, user: userIndex };
+  const method = (ep.method || 'GET').toUpperCase();
+  const name = ep.name || `${method} ${ep.path}`;
+
+  const headers = withToken(expandRuntime(Object.assign({}, DEFAULT_HEADERS, ep.headers || {}), ctx), shared.token || vuToken);
+  const params = expandRuntime(Object.assign({}, DEFAULT_PARAMS, ep.params || {}), ctx);
+  const body = payload(expandRuntime(ep.body, ctx), headers);
+
+  const res = http.request(method, url(expandRuntime(ep.path, ctx), params), body, {
+    headers, timeout: TIMEOUT, tags: { endpoint: name, name },
+  });
+  check(res, { 'status is 2xx/3xx': (r) => r.status >= 200 && r.status < 400 }, { endpoint: name });
+  think();
+}
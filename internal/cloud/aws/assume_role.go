@@ -0,0 +1,122 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/user"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	ststypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
+)
+
+// Environment variables configuring role assumption. The global --assume-role,
+// --external-id and --session-tag flags set them so every cloud operation, including
+// Terraform subprocesses, runs as the assumed role.
+const (
+	EnvAssumeRole  = "AUTOMOCK_ASSUME_ROLE"
+	EnvExternalID  = "AUTOMOCK_EXTERNAL_ID"
+	EnvSessionTags = "AUTOMOCK_SESSION_TAGS" // comma-separated key=value pairs
+)
+
+var roleARNPattern = regexp.MustCompile(`^arn:aws[a-zA-Z-]*:iam::\d{12}:role/[\w+=,.@/-]+$`)
+
+// AssumeRoleConfig describes the role assumed on top of the base credentials
+type AssumeRoleConfig struct {
+	RoleARN     string
+	ExternalID  string
+	SessionName string
+	Tags        map[string]string
+}
+
+// AssumeRoleFromEnv returns the configured role, or nil when no role is requested
+func AssumeRoleFromEnv() (*AssumeRoleConfig, error) {
+	arn := strings.TrimSpace(os.Getenv(EnvAssumeRole))
+	if arn == "" {
+		return nil, nil
+	}
+	if !roleARNPattern.MatchString(arn) {
+		return nil, fmt.Errorf("invalid role ARN %q (expected arn:aws:iam::<account>:role/<name>)", arn)
+	}
+	tags, err := ParseSessionTags(os.Getenv(EnvSessionTags))
+	if err != nil {
+		return nil, err
+	}
+	return &AssumeRoleConfig{
+		RoleARN:     arn,
+		ExternalID:  os.Getenv(EnvExternalID),
+		SessionName: defaultSessionName(),
+		Tags:        tags,
+	}, nil
+}
+
+// ParseSessionTags parses "team=payments,env=dev" into a tag map
+func ParseSessionTags(s string) (map[string]string, error) {
+	tags := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			return nil, fmt.Errorf("invalid session tag %q (expected key=value)", pair)
+		}
+		tags[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return tags, nil
+}
+
+// defaultSessionName identifies the operator in CloudTrail of the target account
+func defaultSessionName() string {
+	name := "automock"
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		name += "-" + u.Username
+	}
+	// Session names allow [\w+=,.@-] and at most 64 characters
+	name = regexp.MustCompile(`[^\w+=,.@-]`).ReplaceAllString(name, "-")
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+// applyAssumeRole swaps the config's credentials for cached assumed-role credentials
+func applyAssumeRole(cfg *aws.Config, role *AssumeRoleConfig) {
+	client := sts.NewFromConfig(*cfg)
+	provider := stscreds.NewAssumeRoleProvider(client, role.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+		o.RoleSessionName = role.SessionName
+		o.Duration = time.Hour
+		if role.ExternalID != "" {
+			o.ExternalID = aws.String(role.ExternalID)
+		}
+		for k, v := range role.Tags {
+			o.Tags = append(o.Tags, ststypes.Tag{Key: aws.String(k), Value: aws.String(v)})
+		}
+	})
+	cfg.Credentials = aws.NewCredentialsCache(provider)
+}
+
+// CredentialEnv returns environment variables that make subprocesses such as Terraform use
+// the assumed role. Without role assumption it returns nil and the profile is used as before.
+func (p *Provider) CredentialEnv(ctx context.Context) ([]string, error) {
+	if !p.assumedRole {
+		return nil, nil
+	}
+	creds, err := p.AWSConfig.Credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("assume role: %w", err)
+	}
+	return []string{
+		"AWS_ACCESS_KEY_ID=" + creds.AccessKeyID,
+		"AWS_SECRET_ACCESS_KEY=" + creds.SecretAccessKey,
+		"AWS_SESSION_TOKEN=" + creds.SessionToken,
+		"AWS_REGION=" + p.region,
+		"AWS_PROFILE=",
+	}, nil
+}
@@ -116,17 +116,25 @@ func (p *Provider) CreateDeploymentConfiguration() *models.DeploymentOptions {
 	options.BucketName = p.BucketName
 	options.Provider = p.GetProviderType()
 	// ── 3) Final confirmation/review ─────────────────────────────────────────
+	if err := options.ApplySizingEnv(); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return nil
+	}
 	promptDeploymentOptionsREPL(options)
 	return options
 }
 
 // CreateTerraformVars creates the terraform.tfvars file
 func (p *Provider) CreateDefaultDeploymentConfiguration() *models.DeploymentOptions {
-	return &models.DeploymentOptions{
+	options := &models.DeploymentOptions{
 		InstanceSize: "small",
 		Region:       p.GetRegion(),
 		BucketName:   p.BucketName,
 		ProjectName:  p.GetProjectName(),
 		Provider:     p.GetProviderType(),
 	}
+	if err := options.ApplySizingEnv(); err != nil {
+		fmt.Printf("⚠️  Ignoring deployment sizing: %v\n", err)
+	}
+	return options
 }
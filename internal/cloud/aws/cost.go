@@ -1,66 +1,198 @@
 package aws
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 
 	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/network"
 )
 
-// DisplayCostEstimateWithSize prints an approximate monthly cost for us-east-1,
-// using your size map -> (cpu units, memory MiB). Assumes 1 ALB, 1 NAT, etc.
-func (p *Provider) DisplayCostEstimate(options *models.DeploymentOptions) {
-	fmt.Println()
-	fmt.Println("APPROX. COST ESTIMATE (us-east-1):")
+const hoursPerMonth = 730.0
+
+// fargatePrice is the Fargate Linux/x86 price per vCPU-hour and GB-hour of a region
+type fargatePrice struct{ vCPUHour, gbHour float64 }
+
+// fargatePrices holds rounded on-demand prices; regions missing here are priced as us-east-1.
+// ALB, NAT and transfer prices scale with the vCPU price, which tracks them closely enough
+// for an estimate.
+var fargatePrices = map[string]fargatePrice{
+	"us-east-1":      {0.04048, 0.004445},
+	"us-east-2":      {0.04048, 0.004445},
+	"us-west-1":      {0.04656, 0.00511},
+	"us-west-2":      {0.04048, 0.004445},
+	"ca-central-1":   {0.04456, 0.00489},
+	"eu-west-1":      {0.04048, 0.004445},
+	"eu-west-2":      {0.04656, 0.00511},
+	"eu-central-1":   {0.04656, 0.00511},
+	"ap-south-1":     {0.04256, 0.00467},
+	"ap-southeast-1": {0.05056, 0.00553},
+	"ap-southeast-2": {0.04856, 0.00532},
+	"ap-northeast-1": {0.05056, 0.00553},
+	"sa-east-1":      {0.0696, 0.0076},
+}
 
-	// --- Assumed unit prices (rounded, us-east-1) ---
+// EstimateCost approximates the monthly cost of the mock stack: Fargate tasks running 24/7 at
+// min tasks, one ALB, the NAT gateway unless an existing one is used, and the optional custom
+// domain and WAF protection
+func (p *Provider) EstimateCost(options *models.DeploymentOptions) *models.CostEstimate {
+	if options == nil {
+		return nil
+	}
 	const (
-		hoursPerMonth = 730.0
-		// Fargate Linux/x86 pricing (per hour):
-		fargatePerVCPUHour = 0.04048
-		fargatePerGBHour   = 0.004445
-
-		// Simple add-ons (you can tune these defaults as needed):
-		albMonthly  = 20.00 // 1 ALB: hourly + a modest LCU buffer
-		natMonthly  = 32.85 // 1 NAT gateway hourly (no per-GB here)
-		dataMonthly = 1.80  // ~20 GB egress @ $0.09/GB
-		storageLogs = 2.70  // CloudWatch logs + S3 small foot-print
+		albMonthly        = 20.00 // 1 ALB: hourly + a modest LCU buffer
+		natMonthly        = 32.85 // 1 NAT gateway hourly (no per-GB here)
+		dataMonthly       = 1.80  // ~20 GB egress @ $0.09/GB
+		storageLogs       = 2.70  // CloudWatch logs + S3 small foot-print
+		domainMonthly     = 1.00  // CloudFront requests/egress at low volume + Route53 queries
+		wafMonthly        = 6.00  // web ACL + 1 rule
+		wafPerMillionReqs = 0.60
 	)
 
+	region := options.Region
+	if region == "" {
+		region = p.GetRegion()
+	}
+	estimate := &models.CostEstimate{Region: region}
+	price, ok := fargatePrices[region]
+	if !ok {
+		price = fargatePrices["us-east-1"]
+		estimate.PricedAs = "us-east-1"
+	}
+	factor := price.vCPUHour / fargatePrices["us-east-1"].vCPUHour
+
 	// Convert ECS CPU units/MiB -> vCPU/GB
 	vCPU := float64(options.CPUUnits) / 1024.0
 	memGB := float64(options.MemoryUnits) / 1024.0
+	perTaskHour := vCPU*price.vCPUHour + memGB*price.gbHour
 
-	// Per-task hourly (Fargate compute)
-	perTaskHour := vCPU*fargatePerVCPUHour + memGB*fargatePerGBHour
+	estimate.Add(models.CostCompute,
+		fmt.Sprintf("Fargate, %d x %s (%.2f vCPU / %.1f GB) 24/7", options.MinTasks, options.InstanceSize, vCPU, memGB),
+		float64(options.MinTasks)*perTaskHour*hoursPerMonth)
+	estimate.Add(models.CostNetworking, "Application Load Balancer", albMonthly*factor)
+	if !options.UseExistingNAT {
+		estimate.Add(models.CostNetworking, "NAT gateway", natMonthly*factor)
+	}
+	if options.Domain != "" {
+		estimate.Add(models.CostNetworking, "CloudFront + Route53 for "+options.Domain, domainMonthly)
+	}
+	estimate.Add(models.CostDataTransfer, "Egress, assumed ~20 GB", dataMonthly*factor)
+	estimate.Add(models.CostStorage, "S3 configs + CloudWatch logs, assumed < 1 GB", storageLogs)
+	if options.Auth != nil {
+		estimate.Add(models.CostProtection, "WAF web ACL ("+options.Auth.Type+")", wafMonthly)
+		estimate.Notes = append(estimate.Notes, fmt.Sprintf("WAF also bills $%.2f per million requests", wafPerMillionReqs))
+	}
 
-	// Base compute (24/7 @ minTasks)
-	baseMonthly := float64(options.MinTasks) * perTaskHour * hoursPerMonth
+	if options.MaxTasks > options.MinTasks {
+		estimate.Notes = append(estimate.Notes, fmt.Sprintf("auto-scaling to %d tasks costs up to $%.3f/hour of compute at peak",
+			options.MaxTasks, float64(options.MaxTasks)*perTaskHour))
+	}
+	estimate.Notes = append(estimate.Notes, fmt.Sprintf("Fargate at $%.5f/vCPU-hr + $%.5f/GB-hr; ALB/NAT/data/logs are rough",
+		price.vCPUHour, price.gbHour))
+	return estimate
+}
 
-	totalMonthly := baseMonthly + albMonthly + dataMonthly + storageLogs
+// costExplorerEndpoint serves the Cost Explorer API, which only exists in us-east-1
+var costExplorerEndpoint = "https://ce.us-east-1.amazonaws.com"
 
-	if len(options.NatGatewayIDs) > 0 {
-		totalMonthly += natMonthly
+// ActualCosts returns the unblended cost of the resources tagged ProjectName=<project> (the
+// tag every AutoMock stack sets) per service, from Cost Explorer. Each call is billed $0.01.
+func (p *Provider) ActualCosts(ctx context.Context, projectID string, from, to time.Time) (*models.CostReport, error) {
+	body, err := json.Marshal(map[string]any{
+		"TimePeriod":  map[string]string{"Start": from.Format("2006-01-02"), "End": to.Format("2006-01-02")},
+		"Granularity": "MONTHLY",
+		"Metrics":     []string{"UnblendedCost"},
+		"Filter": map[string]any{
+			"Tags": map[string]any{"Key": "ProjectName", "Values": []string{projectID}, "MatchOptions": []string{"EQUALS"}},
+		},
+		"GroupBy": []map[string]string{{"Type": "DIMENSION", "Key": "SERVICE"}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if p.AWSConfig.Credentials == nil {
+		return nil, fmt.Errorf("no AWS credentials configured")
+	}
+	creds, err := p.AWSConfig.Credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS credentials: %w", err)
 	}
 
-	fmt.Printf("  Base (24/7, %d x %s @ %.2fvCPU/%.1fGB):  				$%.2f/month\n",
-		options.MinTasks, options.InstanceSize, vCPU, memGB, baseMonthly)
-	fmt.Printf("  ALB (1x):                                				$%.2f/month\n", albMonthly)
-	if len(options.NatGatewayIDs) > 0 {
-		fmt.Printf("  NAT Gateway (1x):                        				$%.2f/month\n", natMonthly)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, costExplorerEndpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "AWSInsightsIndexService.GetCostAndUsage")
+	sum := sha256.Sum256(body)
+	if err := v4.NewSigner().SignHTTP(ctx, creds, req, hex.EncodeToString(sum[:]), "ce", "us-east-1", time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to sign Cost Explorer request: %w", err)
 	}
-	fmt.Printf("  Data Transfer (assumed ~20 GB egress @ $0.09/GB):                 	$%.2f/month\n", dataMonthly)
-	fmt.Printf("  Storage & Logs (assumed < 1 GB):                			$%.2f/month\n", storageLogs)
-	fmt.Printf("  -----------------------------------------------------------------------------\n")
-	fmt.Printf("  Total:                                   				$%.2f/month\n", totalMonthly)
-	fmt.Println()
 
-	if options.MaxTasks > options.MinTasks {
-		peakHourly := float64(options.MaxTasks) * perTaskHour
-		fmt.Printf("  Note: Auto-scaling may increase cost up to %d tasks\n", options.MaxTasks)
-		fmt.Printf("        Peak compute hourly:               				$%.3f/hour\n", peakHourly)
-		fmt.Println()
+	resp, err := network.HTTPClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cost explorer request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	raw, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cost explorer returned %s: %s", resp.Status, bytes.TrimSpace(raw))
 	}
 
-	fmt.Printf("  (Assumes us-east-1 Fargate: $%.5f/vCPU-hr + $%.5f/GB-hr; ALB/NAT/Data/Logs are rough)\n",
-		fargatePerVCPUHour, fargatePerGBHour)
+	report, err := parseCostAndUsage(raw)
+	if err != nil {
+		return nil, err
+	}
+	report.From, report.To = from, to
+	report.Scope = "resources tagged ProjectName=" + projectID
+	report.EmptyHint = "activate the ProjectName cost allocation tag in the AWS Billing console; costs show up about a day later"
+	return report, nil
+}
+
+// parseCostAndUsage sums a GetCostAndUsage response per service over all its periods
+func parseCostAndUsage(raw []byte) (*models.CostReport, error) {
+	var out struct {
+		ResultsByTime []struct {
+			Groups []struct {
+				Keys    []string
+				Metrics map[string]struct{ Amount, Unit string }
+			}
+		}
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("failed to parse Cost Explorer response: %w", err)
+	}
+
+	report := &models.CostReport{}
+	totals := map[string]float64{}
+	for _, period := range out.ResultsByTime {
+		for _, group := range period.Groups {
+			metric, ok := group.Metrics["UnblendedCost"]
+			if !ok || len(group.Keys) == 0 {
+				continue
+			}
+			amount, err := strconv.ParseFloat(metric.Amount, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid cost %q for %s", metric.Amount, group.Keys[0])
+			}
+			totals[group.Keys[0]] += amount
+			report.Currency = metric.Unit
+		}
+	}
+	for service, amount := range totals {
+		report.Services = append(report.Services, models.ServiceCost{Service: service, Amount: amount})
+	}
+	sort.Slice(report.Services, func(i, j int) bool { return report.Services[i].Service < report.Services[j].Service })
+	return report, nil
 }
@@ -0,0 +1,59 @@
+package aws
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+func TestEstimateCost(t *testing.T) {
+	p := &Provider{}
+	options := &models.DeploymentOptions{Region: "us-east-1", InstanceSize: "small", CPUUnits: 256, MemoryUnits: 512, MinTasks: 2, MaxTasks: 6}
+	base := p.EstimateCost(options)
+	if base.PricedAs != "" || len(base.Items) != 5 { // compute, ALB, NAT, data, storage
+		t.Fatalf("estimate %+v", base)
+	}
+	if compute := base.Items[0].Monthly; compute < 17.9 || compute > 18.1 {
+		t.Errorf("2 small tasks cost $%.2f/month", compute)
+	}
+
+	options.UseExistingNAT = true
+	options.Domain = "mock.example.dev"
+	options.Auth = &models.EndpointAuth{Type: models.AuthAPIKey}
+	withExtras := p.EstimateCost(options)
+	var categories []string
+	for _, item := range withExtras.Items {
+		categories = append(categories, item.Category+": "+item.Name)
+	}
+	joined := strings.Join(categories, "\n")
+	if strings.Contains(joined, "NAT") || !strings.Contains(joined, "CloudFront") || !strings.Contains(joined, models.CostProtection) {
+		t.Errorf("items:\n%s", joined)
+	}
+
+	options.Region = "me-south-1"
+	if e := p.EstimateCost(options); e.PricedAs != "us-east-1" {
+		t.Errorf("unknown region priced as %q", e.PricedAs)
+	}
+	options.Region = "sa-east-1"
+	if e := p.EstimateCost(options); e.Items[0].Monthly <= withExtras.Items[0].Monthly {
+		t.Error("sa-east-1 compute not priced above us-east-1")
+	}
+}
+
+func TestParseCostAndUsage(t *testing.T) {
+	raw := `{"ResultsByTime":[
+		{"Groups":[{"Keys":["Amazon Elastic Container Service"],"Metrics":{"UnblendedCost":{"Amount":"10.5","Unit":"USD"}}}]},
+		{"Groups":[{"Keys":["Amazon Elastic Container Service"],"Metrics":{"UnblendedCost":{"Amount":"1.25","Unit":"USD"}}},
+		           {"Keys":["AWS WAF"],"Metrics":{"UnblendedCost":{"Amount":"6","Unit":"USD"}}}]}]}`
+	report, err := parseCostAndUsage([]byte(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Services) != 2 || report.Services[0].Service != "AWS WAF" || report.Services[1].Amount != 11.75 || report.Currency != "USD" {
+		t.Errorf("report %+v", report)
+	}
+	if _, err := parseCostAndUsage([]byte(`{"ResultsByTime":[{"Groups":[{"Keys":["X"],"Metrics":{"UnblendedCost":{"Amount":"n/a"}}}]}]}`)); err == nil {
+		t.Error("invalid amount accepted")
+	}
+}
@@ -0,0 +1,114 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// Environment variables pointing the state store at an S3-compatible service
+// (MinIO, Cloudflare R2, ...). The global --s3-endpoint and --s3-path-style flags set them.
+const (
+	EnvS3Endpoint  = "AUTOMOCK_S3_ENDPOINT"
+	EnvS3PathStyle = "AUTOMOCK_S3_PATH_STYLE"
+)
+
+// S3Endpoint describes a custom S3-compatible endpoint
+type S3Endpoint struct {
+	URL       string
+	PathStyle bool
+}
+
+// S3EndpointFromEnv returns the configured endpoint, or nil for AWS S3
+func S3EndpointFromEnv() (*S3Endpoint, error) {
+	raw := strings.TrimSpace(os.Getenv(EnvS3Endpoint))
+	if raw == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid S3 endpoint %q (expected http(s)://host[:port])", raw)
+	}
+	ep := &S3Endpoint{URL: strings.TrimRight(raw, "/")}
+	if v := os.Getenv(EnvS3PathStyle); v != "" {
+		if ep.PathStyle, err = strconv.ParseBool(v); err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", EnvS3PathStyle, v, err)
+		}
+	}
+	return ep, nil
+}
+
+// newS3Client builds an S3 client honoring a custom endpoint when configured
+func newS3Client(cfg aws.Config) *s3.Client {
+	ep, _ := S3EndpointFromEnv() // validated when the config was loaded
+	if ep == nil {
+		return s3.NewFromConfig(cfg)
+	}
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.BaseEndpoint = aws.String(ep.URL)
+		o.UsePathStyle = ep.PathStyle
+		// Most S3-compatible stores reject the newer default checksum headers
+		o.RequestChecksumCalculation = aws.RequestChecksumCalculationWhenRequired
+		o.ResponseChecksumValidation = aws.ResponseChecksumValidationWhenRequired
+	})
+}
+
+// customEndpoint reports whether the provider talks to an S3-compatible store instead of AWS
+func (p *Provider) customEndpoint() bool {
+	return os.Getenv(EnvS3Endpoint) != ""
+}
+
+// alignRegion rebinds the client to the bucket's region to avoid 301 redirects.
+// S3-compatible stores have no meaningful bucket regions, so it is skipped for them.
+func (p *Provider) alignRegion(ctx context.Context, bucket string) {
+	if p.customEndpoint() {
+		return
+	}
+	loc, err := p.S3Client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{Bucket: aws.String(bucket)})
+	if err != nil {
+		return
+	}
+	resolved := string(loc.LocationConstraint)
+	if resolved == "" { // us-east-1 returns empty per API
+		resolved = "us-east-1"
+	}
+	if resolved != p.region {
+		cfg := p.AWSConfig
+		cfg.Region = resolved
+		p.S3Client = newS3Client(cfg)
+		p.region = resolved
+	}
+}
+
+// serverSideEncryption is AES256 on AWS; S3-compatible stores often lack SSE-S3
+// (MinIO needs a KMS, R2 encrypts implicitly), so the header is omitted there
+func (p *Provider) serverSideEncryption() s3types.ServerSideEncryption {
+	if p.customEndpoint() {
+		return ""
+	}
+	return s3types.ServerSideEncryption("AES256")
+}
+
+// TerraformBackendSettings returns extra s3 backend arguments for a custom endpoint
+// (empty for AWS S3)
+func TerraformBackendSettings() string {
+	ep, err := S3EndpointFromEnv()
+	if err != nil || ep == nil {
+		return ""
+	}
+	return fmt.Sprintf(`    endpoints                   = { s3 = "%s" }
+    use_path_style              = %t
+    skip_credentials_validation = true
+    skip_region_validation      = true
+    skip_requesting_account_id  = true
+    skip_metadata_api_check     = true
+    skip_s3_checksum            = true
+`, ep.URL, ep.PathStyle)
+}
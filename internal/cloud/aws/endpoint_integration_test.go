@@ -0,0 +1,74 @@
+//go:build integration
+
+// Integration test mode for S3-compatible state stores. Run against a disposable MinIO:
+//
+//	docker run -d -p 9000:9000 -e MINIO_ROOT_USER=minio -e MINIO_ROOT_PASSWORD=minio123 minio/minio server /data
+//	AWS_ACCESS_KEY_ID=minio AWS_SECRET_ACCESS_KEY=minio123 \
+//	AUTOMOCK_S3_ENDPOINT=http://localhost:9000 AUTOMOCK_S3_PATH_STYLE=true \
+//	go test -tags integration ./internal/cloud/aws/
+package aws
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+func TestS3CompatibleStateStore(t *testing.T) {
+	if os.Getenv(EnvS3Endpoint) == "" {
+		t.Skipf("%s not set", EnvS3Endpoint)
+	}
+	ctx := context.Background()
+
+	if ok, err := ValidateCredentials(ctx, ""); !ok {
+		t.Fatalf("validate credentials against %s: %v", os.Getenv(EnvS3Endpoint), err)
+	}
+	p, err := NewProvider(ctx)
+	if err != nil {
+		t.Fatalf("new provider: %v", err)
+	}
+
+	project := fmt.Sprintf("it%d", time.Now().Unix()%1000000)
+	if err := p.InitProject(ctx, project); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+	defer func() {
+		if err := p.DeleteProject(project); err != nil {
+			t.Errorf("delete project: %v", err)
+		}
+	}()
+
+	cfg := &models.MockConfiguration{
+		Metadata: models.ConfigMetadata{ProjectID: project, Version: "v1"},
+		Expectations: []models.MockExpectation{{
+			HttpRequest:  &models.HttpRequest{Method: "GET", Path: "/health"},
+			HttpResponse: &models.HttpResponse{StatusCode: 200, Body: map[string]any{"ok": true}},
+		}},
+	}
+	if err := p.SaveConfig(ctx, cfg); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	got, err := p.GetConfig(ctx, project)
+	if err != nil {
+		t.Fatalf("get config: %v", err)
+	}
+	if len(got.Expectations) != 1 || got.Expectations[0].HttpRequest.Path != "/health" {
+		t.Fatalf("round trip mismatch: %+v", got.Expectations)
+	}
+
+	if exists, err := p.ProjectExists(ctx, project); err != nil || !exists {
+		t.Fatalf("project exists = %v, %v", exists, err)
+	}
+	versions, err := p.ListVersions(ctx, project)
+	if err != nil || len(versions) != 1 || versions[0].Version != "v1" {
+		t.Fatalf("list versions = %+v, %v", versions, err)
+	}
+	if _, err := p.DeleteVersion(ctx, project, "v1"); err != nil {
+		t.Fatalf("delete version: %v", err)
+	}
+}
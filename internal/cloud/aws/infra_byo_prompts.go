@@ -10,6 +10,7 @@ import (
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/hemantobora/auto-mock/internal/ask"
 	"github.com/hemantobora/auto-mock/internal/models"
 )
 
@@ -173,7 +174,7 @@ func promptDeploymentOptionsREPL(options *models.DeploymentOptions) error {
 			},
 		}
 
-		if err := survey.AskOne(sizePrompt, &instanceSize); err != nil {
+		if err := ask.One(sizePrompt, &instanceSize); err != nil {
 			return err
 		}
 		options.InstanceSize = instanceSize
@@ -195,7 +196,7 @@ func promptDeploymentOptionsREPL(options *models.DeploymentOptions) error {
 			Help:    "Minimum number of Fargate tasks to run (scales between min and max based on load)",
 		}
 
-		if err := survey.AskOne(minPrompt, &minTask); err != nil {
+		if err := ask.One(minPrompt, &minTask); err != nil {
 			return err
 		}
 
@@ -223,7 +224,7 @@ func promptDeploymentOptionsREPL(options *models.DeploymentOptions) error {
 				Help:    fmt.Sprintf("Maximum number of Fargate tasks to run (scales between min and max based on load). Recommended: %d (min × 6 for optimal scaling)", recommendedMax),
 			}
 
-			if err := survey.AskOne(maxPrompt, &maxTask); err != nil {
+			if err := ask.One(maxPrompt, &maxTask); err != nil {
 				return err
 			}
 
@@ -252,7 +253,7 @@ func promptDeploymentOptionsREPL(options *models.DeploymentOptions) error {
 					Message: "Continue with this configuration anyway?",
 					Default: false,
 				}
-				if err := survey.AskOne(continuePrompt, &continueAnyway); err != nil {
+				if err := ask.One(continuePrompt, &continueAnyway); err != nil {
 					return err
 				}
 
@@ -365,7 +366,7 @@ func promptCapabilitiesSurvey(identity string) (Capability, error) {
 		"IAM roles for ECS (execution & task)",
 	}
 	var netSel []string
-	if err := survey.AskOne(&survey.MultiSelect{
+	if err := ask.One(&survey.MultiSelect{
 		Message: "Create/BYO Networking & IAM Resources (CREATE permissions):",
 		Options: netChoices,
 		Default: netChoices, // sane default: assume greenfield
@@ -387,7 +388,7 @@ func promptInputsForMissingSurvey(cap Capability) (Inputs, error) {
 
 	// Networking BYO
 	if !cap.Networking.VPC {
-		if err := survey.AskOne(&survey.Input{
+		if err := ask.One(&survey.Input{
 			Message: "VPC ID (vpc-xxxx) [Enable DNS support][required if you cannot create one]:",
 		}, &in.VPCID, survey.WithValidator(func(ans interface{}) error {
 			s := strings.TrimSpace(ans.(string))
@@ -402,7 +403,7 @@ func promptInputsForMissingSurvey(cap Capability) (Inputs, error) {
 
 	if !cap.Networking.VPC {
 		var pubCSV, privCSV string
-		if err := survey.AskOne(&survey.Input{
+		if err := ask.One(&survey.Input{
 			Message: "Load balancer Subnet IDs (comma-separated)",
 			Help:    "Example: subnet-aaaa,subnet-bbbb",
 		}, &pubCSV, survey.WithValidator(func(ans interface{}) error {
@@ -410,7 +411,7 @@ func promptInputsForMissingSurvey(cap Capability) (Inputs, error) {
 		})); err != nil {
 			return in, err
 		}
-		if err := survey.AskOne(&survey.Input{
+		if err := ask.One(&survey.Input{
 			Message: "Application Subnet IDs (comma-separated)",
 			Help:    "Example: subnet-aaaa,subnet-bbbb",
 		}, &privCSV, survey.WithValidator(func(ans interface{}) error {
@@ -423,7 +424,7 @@ func promptInputsForMissingSurvey(cap Capability) (Inputs, error) {
 	}
 
 	if !cap.Networking.VPC {
-		if err := survey.AskOne(&survey.Input{
+		if err := ask.One(&survey.Input{
 			Message: "Internet Gateway ID (igw-xxxx)]",
 		}, &in.InternetGatewayID, survey.WithValidator(func(ans interface{}) error {
 			return idOrEmpty(ans.(string), reIGW, "IGW ID")
@@ -434,7 +435,7 @@ func promptInputsForMissingSurvey(cap Capability) (Inputs, error) {
 
 	if !cap.Networking.VPC {
 		var natCSV string
-		if err := survey.AskOne(&survey.Input{
+		if err := ask.One(&survey.Input{
 			Message: "NAT Gateway IDs (comma-separated)",
 		}, &natCSV, survey.WithValidator(func(ans interface{}) error {
 			return listOrEmpty(ans.(string), reNAT, "NAT Gateway IDs")
@@ -445,7 +446,7 @@ func promptInputsForMissingSurvey(cap Capability) (Inputs, error) {
 	}
 
 	if !cap.Networking.SG {
-		if err := survey.AskOne(&survey.Input{
+		if err := ask.One(&survey.Input{
 			Message: "ALB Security Group ID (sg-xxxx)",
 		}, &in.ALBSGID, survey.WithValidator(func(ans interface{}) error {
 			s := strings.TrimSpace(ans.(string))
@@ -456,7 +457,7 @@ func promptInputsForMissingSurvey(cap Capability) (Inputs, error) {
 		})); err != nil {
 			return in, err
 		}
-		if err := survey.AskOne(&survey.Input{
+		if err := ask.One(&survey.Input{
 			Message: "ECS Security Group ID (sg-xxxx)",
 		}, &in.ECSSGID, survey.WithValidator(func(ans interface{}) error {
 			s := strings.TrimSpace(ans.(string))
@@ -473,7 +474,7 @@ func promptInputsForMissingSurvey(cap Capability) (Inputs, error) {
 		// Require both ARNs
 		PrintECSIAMPolicies()
 		fmt.Println("Make sure the roles are created and attach the necessary policies shown above before proceeding.")
-		if err := survey.AskOne(&survey.Input{
+		if err := ask.One(&survey.Input{
 			Message: "Existing ECS Execution Role ARN (required)",
 		}, &in.ExecutionRoleARN, survey.WithValidator(func(ans interface{}) error {
 			s := strings.TrimSpace(ans.(string))
@@ -486,7 +487,7 @@ func promptInputsForMissingSurvey(cap Capability) (Inputs, error) {
 		}
 
 		PrintECSRoleIAMPolicies()
-		if err := survey.AskOne(&survey.Input{
+		if err := ask.One(&survey.Input{
 			Message: "Existing ECS Task Role ARN (required)",
 		}, &in.TaskRoleARN, survey.WithValidator(func(ans interface{}) error {
 			s := strings.TrimSpace(ans.(string))
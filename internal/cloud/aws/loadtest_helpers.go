@@ -14,6 +14,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	core "github.com/hemantobora/auto-mock/internal"
+	"github.com/hemantobora/auto-mock/internal/loadtest"
 	"github.com/hemantobora/auto-mock/internal/models"
 )
 
@@ -32,15 +33,13 @@ func hashFile(path string) (string, int64, error) {
 	return "sha256:" + hex.EncodeToString(h.Sum(nil)), n, nil
 }
 
-// buildBundleFilesMap returns the standard logical-name to object-key mapping for a bundle.
-func buildBundleFilesMap(n core.NamingStrategy, projectID, bundleID string) map[string]string {
-	return map[string]string{
-		"locustfile":   n.LoadTestBundleFileKey(projectID, bundleID, "locustfile.py"),
-		"requirements": n.LoadTestBundleFileKey(projectID, bundleID, "requirements.txt"),
-		"endpoints":    n.LoadTestBundleFileKey(projectID, bundleID, "locust_endpoints.json"),
-		"user_data":    n.LoadTestBundleFileKey(projectID, bundleID, "user_data.yaml"),
-		"manifest":     n.LoadTestBundleFileKey(projectID, bundleID, "manifest.json"),
+// buildBundleFilesMap returns the standard logical-name to object-key mapping for a bundle of the tool.
+func buildBundleFilesMap(n core.NamingStrategy, projectID, bundleID, tool string) map[string]string {
+	files := map[string]string{}
+	for logical, name := range loadtest.LayoutFor(tool).Files {
+		files[logical] = n.LoadTestBundleFileKey(projectID, bundleID, name)
 	}
+	return files
 }
 
 // deleteBundleObjects removes all objects under the bundle directory prefix and returns the number deleted.
@@ -122,17 +121,36 @@ func (p *Provider) loadVersion(ctx context.Context, key string) (*models.LoadTes
 	return &ver, nil
 }
 
+// getJSONObject reads an object and decodes its JSON into v
+func (p *Provider) getJSONObject(ctx context.Context, key string, v any) error {
+	obj, err := p.S3Client.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String(p.BucketName), Key: aws.String(key)})
+	if err != nil {
+		return err
+	}
+	defer obj.Body.Close()
+	data, err := io.ReadAll(obj.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
 // writePointerForVersion constructs and writes current.json for the given version and returns the pointer.
 func (p *Provider) writePointerForVersion(ctx context.Context, ver *models.LoadTestVersion) (*models.LoadTestPointer, error) {
-	files := buildBundleFilesMap(p.naming, ver.ProjectID, ver.BundleID)
+	files := buildBundleFilesMap(p.naming, ver.ProjectID, ver.BundleID, ver.Tool)
 	ptr := models.NewDefaultLoadTestPointer(ver.ProjectID, ver.Version, ver.BundleID, files, &models.LoadTestSummary{
 		Tasks:     ver.Metrics["tasks"],
 		Endpoints: ver.Metrics["endpoints"],
 		HasHost:   ver.Validation != nil && ver.Validation.HostDefined,
 	})
+	ptr.Tool = ver.Tool
 	b, _ := json.MarshalIndent(ptr, "", "  ")
 	pointerKey := p.naming.LoadTestCurrentKey(ver.ProjectID)
-	if err := p.putObject(ctx, pointerKey, b, "application/json"); err != nil {
+	op, _, err := p.loadTestProtection(ver.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.putProtectedObject(ctx, pointerKey, b, "application/json", op); err != nil {
 		return nil, err
 	}
 	return ptr, nil
@@ -143,7 +161,11 @@ func (p *Provider) deleteAllVersionsWithPrefix(ctx context.Context, prefix strin
 	deleted := 0
 	pager := s3.NewListObjectVersionsPaginator(p.S3Client, &s3.ListObjectVersionsInput{Bucket: aws.String(p.BucketName), Prefix: aws.String(prefix)})
 	for pager.HasMorePages() {
-		page, _ := pager.NextPage(ctx)
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			// Stores without versioning support (e.g. Cloudflare R2) reject ListObjectVersions
+			return deleted + p.deleteObjectsWithPrefix(ctx, prefix, func(string) bool { return true })
+		}
 		var objs []types.ObjectIdentifier
 		for _, v := range page.Versions {
 			objs = append(objs, types.ObjectIdentifier{Key: v.Key, VersionId: v.VersionId})
@@ -165,7 +187,10 @@ func (p *Provider) deleteAllVersionsForKey(ctx context.Context, key string) int
 	deleted := 0
 	pager := s3.NewListObjectVersionsPaginator(p.S3Client, &s3.ListObjectVersionsInput{Bucket: aws.String(p.BucketName), Prefix: aws.String(key)})
 	for pager.HasMorePages() {
-		page, _ := pager.NextPage(ctx)
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return deleted + p.deleteObjectsWithPrefix(ctx, key, func(k string) bool { return k == key })
+		}
 		var objs []types.ObjectIdentifier
 		for _, v := range page.Versions {
 			if v.Key != nil && aws.ToString(v.Key) == key {
@@ -185,3 +210,25 @@ func (p *Provider) deleteAllVersionsForKey(ctx context.Context, key string) int
 	}
 	return deleted
 }
+
+// deleteObjectsWithPrefix deletes the current objects under a prefix accepted by match; it is the
+// fallback for unversioned buckets
+func (p *Provider) deleteObjectsWithPrefix(ctx context.Context, prefix string, match func(key string) bool) int {
+	deleted := 0
+	pager := s3.NewListObjectsV2Paginator(p.S3Client, &s3.ListObjectsV2Input{Bucket: aws.String(p.BucketName), Prefix: aws.String(prefix)})
+	for pager.HasMorePages() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return deleted
+		}
+		for _, obj := range page.Contents {
+			if !match(aws.ToString(obj.Key)) {
+				continue
+			}
+			if _, err := p.S3Client.DeleteObject(ctx, &s3.DeleteObjectInput{Bucket: aws.String(p.BucketName), Key: obj.Key}); err == nil {
+				deleted++
+			}
+		}
+	}
+	return deleted
+}
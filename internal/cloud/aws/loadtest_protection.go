@@ -0,0 +1,144 @@
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+// Environment variables protecting load test artifacts (bundles, manifests, version snapshots).
+// The load command's --kms-key and --object-tag flags set them.
+const (
+	EnvLoadTestKMSKey = "AUTOMOCK_LOADTEST_KMS_KEY"
+	EnvLoadTestTags   = "AUTOMOCK_LOADTEST_TAGS"
+)
+
+// Tags every protected artifact carries, so bucket and IAM policies can scope access by
+// project with s3:ExistingObjectTag/automock-project
+const (
+	TagProject  = "automock-project"
+	TagArtifact = "automock-artifact"
+)
+
+// maxObjectTags is S3's limit per object; two are taken by TagProject and TagArtifact
+const maxObjectTags = 10
+
+// ArtifactProtection is the SSE-KMS key and object tags applied to load test artifacts
+type ArtifactProtection struct {
+	KMSKey string            // key ID, ARN or alias; "{project}" becomes the project, e.g. alias/perf-{project}
+	Tags   map[string]string // extra object tags, e.g. classification=confidential
+}
+
+// ArtifactProtectionFromEnv returns the configured protection, or nil when none is set
+func ArtifactProtectionFromEnv() (*ArtifactProtection, error) {
+	key := strings.TrimSpace(os.Getenv(EnvLoadTestKMSKey))
+	rawTags := strings.TrimSpace(os.Getenv(EnvLoadTestTags))
+	if key == "" && rawTags == "" {
+		return nil, nil
+	}
+	tags, err := ParseObjectTags(rawTags)
+	if err != nil {
+		return nil, err
+	}
+	return &ArtifactProtection{KMSKey: key, Tags: tags}, nil
+}
+
+// ParseObjectTags parses "classification=confidential,team=payments" into object tags,
+// enforcing S3's tag limits
+func ParseObjectTags(s string) (map[string]string, error) {
+	tags := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(pair, "=")
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		switch {
+		case !ok || k == "":
+			return nil, fmt.Errorf("invalid object tag %q (expected key=value)", pair)
+		case k == TagProject || k == TagArtifact:
+			return nil, fmt.Errorf("object tag %q is set by automock", k)
+		case len(k) > 128 || len(v) > 256:
+			return nil, fmt.Errorf("object tag %q is too long (keys up to 128, values up to 256 characters)", k)
+		}
+		tags[k] = v
+	}
+	if len(tags) > maxObjectTags-2 {
+		return nil, fmt.Errorf("at most %d object tags can be added, got %d", maxObjectTags-2, len(tags))
+	}
+	return tags, nil
+}
+
+// keyFor is the KMS key of a project's artifacts
+func (a *ArtifactProtection) keyFor(project string) string {
+	return strings.ReplaceAll(a.KMSKey, "{project}", project)
+}
+
+// tagging is the x-amz-tagging value for a project's artifacts
+func (a *ArtifactProtection) tagging(project string) string {
+	values := url.Values{}
+	for k, v := range a.Tags {
+		values.Set(k, v)
+	}
+	values.Set(TagProject, project)
+	values.Set(TagArtifact, "loadtest")
+	return values.Encode()
+}
+
+// Describe summarizes the protection for upload output, e.g. "SSE-KMS alias/perf-users; tags automock-project, automock-artifact, team"
+func (a *ArtifactProtection) Describe(project string) string {
+	var parts []string
+	if a.KMSKey != "" {
+		parts = append(parts, "SSE-KMS "+a.keyFor(project))
+	}
+	keys := []string{TagProject, TagArtifact}
+	for k := range a.Tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys[2:])
+	parts = append(parts, "tags "+strings.Join(keys, ", "))
+	return strings.Join(parts, "; ")
+}
+
+// objectProtection is what an upload of one project's load test artifact sends
+type objectProtection struct {
+	sse      s3types.ServerSideEncryption
+	kmsKeyID *string
+	tagging  *string
+}
+
+// loadTestProtection resolves the protection of a project's load test uploads; without any
+// configured it is the bucket's usual encryption
+func (p *Provider) loadTestProtection(project string) (*objectProtection, *ArtifactProtection, error) {
+	prot, err := ArtifactProtectionFromEnv()
+	if err != nil || prot == nil {
+		return &objectProtection{sse: p.serverSideEncryption()}, nil, err
+	}
+	op := &objectProtection{sse: p.serverSideEncryption(), tagging: aws.String(prot.tagging(project))}
+	if prot.KMSKey != "" {
+		op.sse = s3types.ServerSideEncryptionAwsKms
+		op.kmsKeyID = aws.String(prot.keyFor(project))
+	}
+	return op, prot, nil
+}
+
+// explainArtifactAccess adds what reading a protected artifact takes to an access error; the
+// classification (exit code) of err is kept
+func explainArtifactAccess(err error) error {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	if code := apiErr.ErrorCode(); code == "AccessDenied" || strings.HasPrefix(code, "KMS.") {
+		return fmt.Errorf("%w (reading load test artifacts needs s3:GetObject, and kms:Decrypt on the project's key when they are encrypted with --kms-key)", err)
+	}
+	return err
+}
@@ -0,0 +1,96 @@
+package aws
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+func TestParseObjectTags(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    map[string]string
+		wantErr string
+	}{
+		{in: "", want: map[string]string{}},
+		{in: " classification = confidential , team=payments,", want: map[string]string{"classification": "confidential", "team": "payments"}},
+		{in: "empty=", want: map[string]string{"empty": ""}},
+		{in: "team", wantErr: "expected key=value"},
+		{in: "=payments", wantErr: "expected key=value"},
+		{in: TagProject + "=users", wantErr: "set by automock"},
+		{in: strings.Repeat("k", 129) + "=v", wantErr: "too long"},
+		{in: "k=" + strings.Repeat("v", 257), wantErr: "too long"},
+		{in: "a=1,b=2,c=3,d=4,e=5,f=6,g=7,h=8,i=9", wantErr: "at most 8"},
+	}
+	for _, tt := range tests {
+		got, err := ParseObjectTags(tt.in)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseObjectTags(%q) error = %v, want %q", tt.in, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseObjectTags(%q): %v", tt.in, err)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("ParseObjectTags(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+		for k, v := range tt.want {
+			if got[k] != v {
+				t.Errorf("ParseObjectTags(%q)[%q] = %q, want %q", tt.in, k, got[k], v)
+			}
+		}
+	}
+}
+
+func TestLoadTestProtection(t *testing.T) {
+	p := &Provider{}
+
+	t.Setenv(EnvLoadTestKMSKey, "")
+	t.Setenv(EnvLoadTestTags, "")
+	op, prot, err := p.loadTestProtection("users")
+	if err != nil || prot != nil {
+		t.Fatalf("unprotected: %+v, %v", prot, err)
+	}
+	if op.sse != p.serverSideEncryption() || op.kmsKeyID != nil || op.tagging != nil {
+		t.Errorf("unprotected upload = %+v, want the bucket's usual encryption only", op)
+	}
+
+	t.Setenv(EnvLoadTestKMSKey, "alias/perf-{project}")
+	t.Setenv(EnvLoadTestTags, "classification=confidential")
+	op, prot, err = p.loadTestProtection("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op.sse != s3types.ServerSideEncryptionAwsKms || op.kmsKeyID == nil || *op.kmsKeyID != "alias/perf-users" {
+		t.Errorf("encryption = %q with key %v, want SSE-KMS alias/perf-users", op.sse, op.kmsKeyID)
+	}
+	if op.tagging == nil {
+		t.Fatal("no tagging")
+	}
+	tags, err := url.ParseQuery(*op.tagging)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tags.Get("classification") != "confidential" || tags.Get(TagProject) != "users" || tags.Get(TagArtifact) != "loadtest" {
+		t.Errorf("tagging = %q", *op.tagging)
+	}
+	if got, want := prot.Describe("users"), "SSE-KMS alias/perf-users; tags automock-project, automock-artifact, classification"; got != want {
+		t.Errorf("Describe = %q, want %q", got, want)
+	}
+
+	// Tags alone keep the bucket's encryption
+	t.Setenv(EnvLoadTestKMSKey, "")
+	if op, _, err = p.loadTestProtection("users"); err != nil || op.sse != p.serverSideEncryption() || op.kmsKeyID != nil || op.tagging == nil {
+		t.Errorf("tags only = %+v, %v", op, err)
+	}
+
+	t.Setenv(EnvLoadTestTags, "team")
+	if _, _, err := p.loadTestProtection("users"); err == nil {
+		t.Error("an invalid tag was accepted")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -18,6 +19,7 @@ import (
 
 // UploadLoadTestBundle uploads a generated load test bundle directory to cloud storage and updates pointer & version files.
 // bundleDir must contain at minimum: locustfile.py, requirements.txt, locust_endpoints.json
+// (or, for k6 bundles: script.js, k6_endpoints.json, k6_options.json)
 // user_data.yaml is optional; manifest.json is optional (we generate it if absent)
 func (p *Provider) UploadLoadTestBundle(ctx context.Context, projectID, bundleDir string) (*models.LoadTestPointer, *models.LoadTestVersion, error) {
 	// Ensure bucket context is established (supports REPL uploads without prior init)
@@ -29,41 +31,26 @@ func (p *Provider) UploadLoadTestBundle(ctx context.Context, projectID, bundleDi
 			}
 		} else {
 			// Align region with existing bucket
-			if loc, err := p.S3Client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{Bucket: aws.String(p.BucketName)}); err == nil {
-				resolved := string(loc.LocationConstraint)
-				if resolved == "" {
-					resolved = "us-east-1"
-				}
-				if resolved != p.region && resolved != "" {
-					cfg := p.AWSConfig
-					cfg.Region = resolved
-					p.S3Client = s3.NewFromConfig(cfg)
-					p.region = resolved
-				}
-			}
+			p.alignRegion(ctx, p.BucketName)
 		}
 	}
 	// Defensive: ensure S3 client region matches existing bucket to avoid 301 redirect
 	if p.BucketName != "" {
-		if loc, err := p.S3Client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{Bucket: aws.String(p.BucketName)}); err == nil {
-			resolved := string(loc.LocationConstraint)
-			if resolved == "" {
-				resolved = "us-east-1"
-			}
-			if resolved != p.region && resolved != "" {
-				cfg := p.AWSConfig
-				cfg.Region = resolved
-				p.S3Client = s3.NewFromConfig(cfg)
-				p.region = resolved
-			}
-		}
+		p.alignRegion(ctx, p.BucketName)
 	}
 	baseID := p.naming.ExtractProjectID(projectID)
+	op, prot, err := p.loadTestProtection(baseID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if prot != nil {
+		fmt.Printf("🔐 Protecting load test artifacts: %s\n", prot.Describe(baseID))
+	}
 
 	// Collect files we care about
-	// Ensure the core Locust spec (locust_endpoints.json) is always uploaded alongside the runner.
-	required := []string{"locustfile.py", "requirements.txt", "locust_endpoints.json"}
-	optional := []string{"user_data.yaml", "manifest.json"}
+	// Ensure the endpoint spec (locust_endpoints.json, k6_endpoints.json) is always uploaded alongside the runner.
+	layout := loadtest.LayoutFor(loadtest.DetectTool(bundleDir))
+	required, optional := layout.Required, layout.Optional
 	found := make(map[string]string)
 	hashes := make(map[string]string)
 	var missing []string
@@ -102,8 +89,8 @@ func (p *Provider) UploadLoadTestBundle(ctx context.Context, projectID, bundleDi
 	// Enhanced validation using validator utility
 	valRes, _ := loadtest.ValidateBundle(bundleDir)
 	validation := &models.LoadTestValidationResult{
-		LocustfilePresent:   true,
-		RequirementsPresent: true,
+		LocustfilePresent:   layout.Tool == loadtest.ToolLocust,
+		RequirementsPresent: layout.Tool == loadtest.ToolLocust,
 		UserDataPresent:     found["user_data.yaml"] != "",
 		ManifestPresent:     found["manifest.json"] != "",
 		HostDefined:         valRes != nil && valRes.HostDefined,
@@ -113,9 +100,14 @@ func (p *Provider) UploadLoadTestBundle(ctx context.Context, projectID, bundleDi
 		validation.PlaceholderErrors = valRes.PlaceholderErrors
 	}
 
-	ts := time.Now().UTC()
+	// Resume an interrupted upload of the same content into the same bundle
+	state, resumed := loadUploadState(bundleDir, baseID, hashes, time.Now().UTC())
+	if resumed {
+		fmt.Printf("↩️  Resuming interrupted upload of bundle %s\n", state.BundleID)
+	}
+	ts := state.CreatedAt
 	version := fmt.Sprintf("v%d", ts.Unix())
-	bundleID := fmt.Sprintf("bndl_%d", ts.UnixNano())
+	bundleID := state.BundleID
 
 	// S3 key helpers
 	versionKey := p.naming.LoadTestVersionKey(baseID, version)
@@ -139,7 +131,7 @@ func (p *Provider) UploadLoadTestBundle(ctx context.Context, projectID, bundleDi
 	}
 	manifestWarnings := []string{}
 	if !validation.HostDefined {
-		manifestWarnings = append(manifestWarnings, "No host defined in locustfile; specify 'host =' or set via CLI when running Locust.")
+		manifestWarnings = append(manifestWarnings, layout.HostHint())
 	}
 	if len(validation.PlaceholderErrors) > 0 {
 		manifestWarnings = append(manifestWarnings, fmt.Sprintf("Found %d unresolved placeholders in user_data.yaml", len(validation.PlaceholderErrors)))
@@ -149,7 +141,7 @@ func (p *Provider) UploadLoadTestBundle(ctx context.Context, projectID, bundleDi
 		ProjectID:   baseID,
 		GeneratedAt: ts,
 		Files:       fileRefs,
-		Entrypoints: []string{"locustfile.py"},
+		Entrypoints: []string{layout.Entrypoint},
 		Warnings:    manifestWarnings,
 	}
 
@@ -163,6 +155,7 @@ func (p *Provider) UploadLoadTestBundle(ctx context.Context, projectID, bundleDi
 		ProjectID:  baseID,
 		Version:    version,
 		BundleID:   bundleID,
+		Tool:       layout.Tool,
 		CreatedAt:  ts,
 		Hashes:     hashes,
 		Validation: validation,
@@ -170,44 +163,45 @@ func (p *Provider) UploadLoadTestBundle(ctx context.Context, projectID, bundleDi
 	}
 
 	// Build pointer (include endpoints JSON so consumers/downloader get the full bundle)
-	pointer := models.NewDefaultLoadTestPointer(baseID, version, bundleID, map[string]string{
-		"locustfile":   p.naming.LoadTestBundleFileKey(baseID, bundleID, "locustfile.py"),
-		"requirements": p.naming.LoadTestBundleFileKey(baseID, bundleID, "requirements.txt"),
-		"endpoints":    p.naming.LoadTestBundleFileKey(baseID, bundleID, "locust_endpoints.json"),
-		"user_data":    p.naming.LoadTestBundleFileKey(baseID, bundleID, "user_data.yaml"),
-		"manifest":     p.naming.LoadTestBundleFileKey(baseID, bundleID, "manifest.json"),
-	}, &models.LoadTestSummary{Tasks: metrics["tasks"], Endpoints: metrics["endpoints"], HasHost: validation.HostDefined})
-	// Upload bundle files
+	pointer := models.NewDefaultLoadTestPointer(baseID, version, bundleID, buildBundleFilesMap(p.naming, baseID, bundleID, layout.Tool),
+		&models.LoadTestSummary{Tasks: metrics["tasks"], Endpoints: metrics["endpoints"], HasHost: validation.HostDefined})
+	pointer.Tool = layout.Tool
+	// Upload bundle files (large files in resumable parts, with retry and optional rate limit)
+	limiter := newByteLimiterFromEnv()
 	for name, local := range found {
-		key := p.naming.LoadTestBundleFileKey(baseID, bundleID, name)
-		data, err := os.ReadFile(local)
-		if err != nil {
-			return nil, nil, fmt.Errorf("read %s: %w", name, err)
+		fs := state.file(name)
+		if fs.Done {
+			continue
 		}
-		if err := p.putObject(ctx, key, data, "application/octet-stream"); err != nil {
+		key := p.naming.LoadTestBundleFileKey(baseID, bundleID, name)
+		if err := p.uploadFile(ctx, key, local, "application/octet-stream", op, fs, state, limiter); err != nil {
+			state.save()
 			return nil, nil, fmt.Errorf("upload %s: %w", name, err)
 		}
+		fs.Done = true
+		state.save()
 	}
 	// Upload generated manifest.json (override if existed)
 	manifestJSON, _ := json.MarshalIndent(manifest, "", "  ")
-	if err := p.putObject(ctx, p.naming.LoadTestBundleFileKey(baseID, bundleID, "manifest.json"), manifestJSON, "application/json"); err != nil {
+	if err := p.putProtectedObject(ctx, p.naming.LoadTestBundleFileKey(baseID, bundleID, "manifest.json"), manifestJSON, "application/json", op); err != nil {
 		return nil, nil, fmt.Errorf("upload manifest: %w", err)
 	}
 	// Upload version snapshot
 	versionJSON, _ := json.MarshalIndent(versionSnap, "", "  ")
-	if err := p.putObject(ctx, versionKey, versionJSON, "application/json"); err != nil {
+	if err := p.putProtectedObject(ctx, versionKey, versionJSON, "application/json", op); err != nil {
 		return nil, nil, fmt.Errorf("upload version snapshot: %w", err)
 	}
 	// Upload pointer (current.json)
 	pointerJSON, _ := json.MarshalIndent(pointer, "", "  ")
-	if err := p.putObject(ctx, pointerKey, pointerJSON, "application/json"); err != nil {
+	if err := p.putProtectedObject(ctx, pointerKey, pointerJSON, "application/json", op); err != nil {
 		return nil, nil, fmt.Errorf("upload pointer: %w", err)
 	}
 	// Update metadata index (best effort)
 	idx := models.LoadTestMetadataIndex{ProjectID: baseID, LatestVersion: version, BundleCount: 0, UpdatedAt: ts}
 	idxJSON, _ := json.MarshalIndent(idx, "", "  ")
-	_ = p.putObject(ctx, metadataKey, idxJSON, "application/json")
+	_ = p.putProtectedObject(ctx, metadataKey, idxJSON, "application/json", op)
 
+	state.clear()
 	_ = bundlePrefix // prefix reserved for possible listing operations later
 	return pointer, versionSnap, nil
 }
@@ -251,7 +245,7 @@ func (p *Provider) DownloadLoadTestBundle(ctx context.Context, projectID, destDi
 		}
 		obj, err := p.S3Client.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String(p.BucketName), Key: aws.String(key)})
 		if err != nil {
-			return nil, "", fmt.Errorf("download %s: %w", key, err)
+			return nil, "", fmt.Errorf("download %s: %w", key, explainArtifactAccess(err))
 		}
 		fname := path.Base(key)
 		localPath := filepath.Join(target, fname)
@@ -272,6 +266,75 @@ func (p *Provider) DownloadLoadTestBundle(ctx context.Context, projectID, destDi
 	return ptr, abs, nil
 }
 
+// SaveLoadTestResult stores the summarized results of a run next to the bundle version it ran,
+// protected like the bundle itself
+func (p *Provider) SaveLoadTestResult(ctx context.Context, projectID string, result *models.LoadTestRunResult) (string, error) {
+	baseID := p.naming.ExtractProjectID(projectID)
+	op, _, err := p.loadTestProtection(baseID)
+	if err != nil {
+		return "", err
+	}
+	key := p.naming.LoadTestResultKey(baseID, result.Version, result.RunID)
+	data, _ := json.MarshalIndent(result, "", "  ")
+	if err := p.putProtectedObject(ctx, key, data, "application/json", op); err != nil {
+		return "", fmt.Errorf("upload results: %w", explainArtifactAccess(err))
+	}
+	return key, nil
+}
+
+// ListLoadTestResults reads the results of every run of every version, oldest first
+func (p *Provider) ListLoadTestResults(ctx context.Context, projectID string) ([]models.LoadTestRunResult, error) {
+	baseID := p.naming.ExtractProjectID(projectID)
+	var results []models.LoadTestRunResult
+	pager := s3.NewListObjectsV2Paginator(p.S3Client, &s3.ListObjectsV2Input{Bucket: aws.String(p.BucketName), Prefix: aws.String(p.naming.LoadTestResultsPrefix(baseID))})
+	for pager.HasMorePages() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list results: %w", err)
+		}
+		for _, obj := range page.Contents {
+			key := aws.ToString(obj.Key)
+			if path.Ext(key) != ".json" {
+				continue
+			}
+			var result models.LoadTestRunResult
+			if err := p.getJSONObject(ctx, key, &result); err != nil {
+				return nil, fmt.Errorf("read %s: %w", key, explainArtifactAccess(err))
+			}
+			results = append(results, result)
+		}
+	}
+	models.SortLoadTestResults(results)
+	return results, nil
+}
+
+// SaveLoadTestSchedule records the scheduled runs of the project
+func (p *Provider) SaveLoadTestSchedule(ctx context.Context, schedule *models.LoadTestSchedule) error {
+	baseID := p.naming.ExtractProjectID(schedule.ProjectID)
+	data, _ := json.MarshalIndent(schedule, "", "  ")
+	if err := p.putObject(ctx, p.naming.LoadTestScheduleKey(baseID), data, "application/json"); err != nil {
+		return fmt.Errorf("upload schedule: %w", err)
+	}
+	return nil
+}
+
+// GetLoadTestSchedule returns the scheduled runs of the project
+func (p *Provider) GetLoadTestSchedule(ctx context.Context, projectID string) (*models.LoadTestSchedule, error) {
+	baseID := p.naming.ExtractProjectID(projectID)
+	var schedule models.LoadTestSchedule
+	if err := p.getJSONObject(ctx, p.naming.LoadTestScheduleKey(baseID), &schedule); err != nil {
+		return nil, fmt.Errorf("get schedule: %w", err)
+	}
+	return &schedule, nil
+}
+
+// DeleteLoadTestSchedule removes the schedule record (not the scheduler itself)
+func (p *Provider) DeleteLoadTestSchedule(ctx context.Context, projectID string) error {
+	baseID := p.naming.ExtractProjectID(projectID)
+	_, err := p.S3Client.DeleteObject(ctx, &s3.DeleteObjectInput{Bucket: aws.String(p.BucketName), Key: aws.String(p.naming.LoadTestScheduleKey(baseID))})
+	return err
+}
+
 // DeleteLoadTestPointer removes the current.json pointer (does not delete bundles)
 func (p *Provider) DeleteLoadTestPointer(ctx context.Context, projectID string) error {
 	baseID := p.naming.ExtractProjectID(projectID)
@@ -407,3 +470,75 @@ func (p *Provider) PurgeLoadTestArtifacts(ctx context.Context, projectID string)
 
 	return deleted, bucketDeleted, nil
 }
+
+// ListLoadTestVersions returns every stored load test version snapshot for the project
+func (p *Provider) ListLoadTestVersions(ctx context.Context, projectID string) ([]models.LoadTestVersion, error) {
+	baseID := p.naming.ExtractProjectID(projectID)
+	keys, err := p.listVersionKeys(ctx, baseID)
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]models.LoadTestVersion, 0, len(keys))
+	for _, key := range keys {
+		ver, err := p.loadVersion(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", key, err)
+		}
+		versions = append(versions, *ver)
+	}
+	return versions, nil
+}
+
+// ListLoadTestBundles returns the bundle directories stored for the project, including
+// bundles no version references (e.g. left behind by interrupted uploads)
+func (p *Provider) ListLoadTestBundles(ctx context.Context, projectID string) ([]models.LoadTestBundleInfo, error) {
+	baseID := p.naming.ExtractProjectID(projectID)
+	prefix := p.naming.LoadTestBundlesPrefix(baseID)
+	byID := map[string]*models.LoadTestBundleInfo{}
+	var order []string
+	pager := s3.NewListObjectsV2Paginator(p.S3Client, &s3.ListObjectsV2Input{Bucket: aws.String(p.BucketName), Prefix: aws.String(prefix)})
+	for pager.HasMorePages() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list bundles: %w", err)
+		}
+		for _, obj := range page.Contents {
+			bundleID, _, ok := strings.Cut(strings.TrimPrefix(aws.ToString(obj.Key), prefix), "/")
+			if !ok || bundleID == "" {
+				continue
+			}
+			info, seen := byID[bundleID]
+			if !seen {
+				info = &models.LoadTestBundleInfo{BundleID: bundleID}
+				byID[bundleID] = info
+				order = append(order, bundleID)
+			}
+			info.Objects++
+			info.Size += aws.ToInt64(obj.Size)
+			if modified := aws.ToTime(obj.LastModified); modified.After(info.CreatedAt) {
+				info.CreatedAt = modified
+			}
+		}
+	}
+	bundles := make([]models.LoadTestBundleInfo, 0, len(order))
+	for _, id := range order {
+		bundles = append(bundles, *byID[id])
+	}
+	return bundles, nil
+}
+
+// DeleteLoadTestVersion permanently removes a load test version snapshot (not its bundle)
+func (p *Provider) DeleteLoadTestVersion(ctx context.Context, projectID, version string) (int, error) {
+	baseID := p.naming.ExtractProjectID(projectID)
+	deleted := p.deleteAllVersionsForKey(ctx, p.naming.LoadTestVersionKey(baseID, version))
+	return deleted + p.deleteAllVersionsWithPrefix(ctx, path.Dir(p.naming.LoadTestResultKey(baseID, version, "run"))+"/"), nil
+}
+
+// DeleteLoadTestBundle permanently removes a bundle directory, including noncurrent object versions
+func (p *Provider) DeleteLoadTestBundle(ctx context.Context, projectID, bundleID string) (int, error) {
+	if bundleID == "" {
+		return 0, fmt.Errorf("bundle id is required")
+	}
+	baseID := p.naming.ExtractProjectID(projectID)
+	return p.deleteAllVersionsWithPrefix(ctx, p.naming.LoadTestBundleDir(baseID, bundleID)), nil
+}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"os"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -16,6 +18,7 @@ import (
 	"github.com/hemantobora/auto-mock/internal"
 	"github.com/hemantobora/auto-mock/internal/cloud/naming"
 	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/network"
 )
 
 // Provider holds AWS-specific clients and config
@@ -26,6 +29,8 @@ type Provider struct {
 	BucketName string
 	S3Client   *s3.Client
 	AWSConfig  aws.Config
+
+	assumedRole bool // credentials come from --assume-role
 }
 
 // ProviderOption is a functional option for provider configuration
@@ -50,9 +55,17 @@ func WithProfile(profile string) ProviderOption {
 	}
 }
 
+// LoadConfig loads the AWS configuration used by every AWS call (profile, the global
+// --assume-role and the network timeouts), for callers outside the storage provider
+func LoadConfig(ctx context.Context, profile string) (aws.Config, error) {
+	return loadAWSConfig(ctx, profile)
+}
+
 // loadAWSConfig loads AWS configuration with optional profile
 func loadAWSConfig(ctx context.Context, profile string) (aws.Config, error) {
-	optFns := []func(*config.LoadOptions) error{}
+	optFns := []func(*config.LoadOptions) error{
+		config.WithHTTPClient(&http.Client{Transport: network.Transport()}),
+	}
 	if profile != "" {
 		optFns = append(optFns, config.WithSharedConfigProfile(profile))
 	}
@@ -68,6 +81,28 @@ func loadAWSConfig(ctx context.Context, profile string) (aws.Config, error) {
 	if cfg.Region == "" {
 		cfg.Region = "us-east-1"
 	}
+
+	role, err := AssumeRoleFromEnv()
+	if err != nil {
+		return aws.Config{}, &models.ProviderError{
+			Provider:  "aws",
+			Operation: "assume-role",
+			Resource:  os.Getenv(EnvAssumeRole),
+			Cause:     err,
+		}
+	}
+	if role != nil {
+		applyAssumeRole(&cfg, role)
+	}
+
+	if _, err := S3EndpointFromEnv(); err != nil {
+		return aws.Config{}, &models.ProviderError{
+			Provider:  "aws",
+			Operation: "load-config",
+			Resource:  "s3-endpoint",
+			Cause:     err,
+		}
+	}
 	return cfg, nil
 }
 
@@ -91,7 +126,7 @@ func NewProvider(ctx context.Context, options ...ProviderOption) (*Provider, err
 	}
 
 	// Create S3 client
-	s3Client := s3.NewFromConfig(cfg)
+	s3Client := newS3Client(cfg)
 
 	// Create provider
 	naming := naming.NewDefaultNaming()
@@ -100,6 +135,8 @@ func NewProvider(ctx context.Context, options ...ProviderOption) (*Provider, err
 		naming:    naming,
 		region:    cfg.Region,
 		AWSConfig: cfg,
+
+		assumedRole: os.Getenv(EnvAssumeRole) != "",
 	}
 	return provider, nil
 }
@@ -111,6 +148,12 @@ func ValidateCredentials(ctx context.Context, profile string) (bool, error) {
 		return false, err
 	}
 
+	// S3-compatible stores have no STS; listing buckets proves the credentials instead
+	if ep, _ := S3EndpointFromEnv(); ep != nil {
+		_, err = newS3Client(cfg).ListBuckets(ctx, &s3.ListBucketsInput{})
+		return err == nil, err
+	}
+
 	client := sts.NewFromConfig(cfg)
 	_, err = client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
 	if err != nil {
@@ -160,26 +203,14 @@ func (p *Provider) InitProject(ctx context.Context, projectID string) error {
 		p.projectID = projectID
 		p.BucketName = bucketName
 		// Detect and align region to avoid 301 PermanentRedirect on PutObject
-		if loc, lerr := p.S3Client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{Bucket: aws.String(bucketName)}); lerr == nil {
-			resolved := string(loc.LocationConstraint)
-			if resolved == "" { // us-east-1 returns empty per API
-				resolved = "us-east-1"
-			}
-			if resolved != "" && resolved != p.region {
-				p.region = resolved
-				// Rebuild S3 client bound to correct region to prevent redirects
-				cfg := p.AWSConfig
-				cfg.Region = resolved
-				p.S3Client = s3.NewFromConfig(cfg)
-			}
-		}
+		p.alignRegion(ctx, bucketName)
 		fmt.Printf("✅ Project already initialized: %s\n", projectID)
 		return nil
 	}
 
 	// Create bucket
 	var input *s3.CreateBucketInput
-	if p.region == "us-east-1" {
+	if p.region == "us-east-1" || p.customEndpoint() {
 		input = &s3.CreateBucketInput{
 			Bucket: aws.String(bucketName),
 		}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -23,6 +24,11 @@ func (p *Provider) SaveConfig(ctx context.Context, config *models.MockConfigurat
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	// Derive the stored responses and companions; refuse what MockServer would not serve
+	if err := config.Prepare(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
 	// Set metadata
 	cleanProjectID := p.naming.ExtractProjectID(p.projectID)
 	config.Metadata.ProjectID = cleanProjectID
@@ -73,6 +79,10 @@ func (p *Provider) GetConfig(ctx context.Context, projectID string) (*models.Moc
 		Key:    aws.String(key),
 	})
 	if err != nil {
+		var missing *s3types.NoSuchKey
+		if errors.As(err, &missing) {
+			return nil, &models.ConfigNotFoundError{ProjectID: cleanProjectID, Cause: err}
+		}
 		return nil, fmt.Errorf("failed to get config from S3: %w", err)
 	}
 	defer result.Body.Close()
@@ -92,10 +102,16 @@ func (p *Provider) GetConfig(ctx context.Context, projectID string) (*models.Moc
 
 // UpdateConfig updates an existing configuration
 func (p *Provider) UpdateConfig(ctx context.Context, config *models.MockConfiguration) error {
-	// Get existing config to preserve creation time
+	// Get existing config to preserve creation time and the deployed baseline
 	existing, err := p.GetConfig(ctx, config.Metadata.ProjectID)
 	if err == nil {
 		config.Metadata.CreatedAt = existing.Metadata.CreatedAt
+		if config.Metadata.DeployedVersion == "" {
+			config.Metadata.DeployedVersion = existing.Metadata.DeployedVersion
+		}
+		if config.Metadata.ContractVersion == "" {
+			config.Metadata.ContractVersion = existing.Metadata.ContractVersion
+		}
 	}
 
 	// Generate new version
@@ -111,66 +127,13 @@ func (p *Provider) DeleteProject(projectID string) error {
 	cleanProjectID := p.naming.ExtractProjectID(projectID)
 	ctx := context.Background()
 
-	// Helpers
-	deleteAllVersionsWithPrefix := func(prefix string) error {
-		pager := s3.NewListObjectVersionsPaginator(p.S3Client, &s3.ListObjectVersionsInput{
-			Bucket: aws.String(p.BucketName),
-			Prefix: aws.String(prefix),
-		})
-		for pager.HasMorePages() {
-			page, _ := pager.NextPage(ctx)
-			var objs []s3types.ObjectIdentifier
-			for _, v := range page.Versions {
-				// Defensive: only those within prefix are returned already, but keep as-is
-				objs = append(objs, s3types.ObjectIdentifier{Key: v.Key, VersionId: v.VersionId})
-			}
-			for _, m := range page.DeleteMarkers {
-				objs = append(objs, s3types.ObjectIdentifier{Key: m.Key, VersionId: m.VersionId})
-			}
-			if len(objs) > 0 {
-				_, _ = p.S3Client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
-					Bucket: aws.String(p.BucketName),
-					Delete: &s3types.Delete{Objects: objs, Quiet: aws.Bool(true)},
-				})
-			}
-		}
-		return nil
-	}
-	deleteAllVersionsForKey := func(key string) error {
-		pager := s3.NewListObjectVersionsPaginator(p.S3Client, &s3.ListObjectVersionsInput{
-			Bucket: aws.String(p.BucketName),
-			Prefix: aws.String(key),
-		})
-		for pager.HasMorePages() {
-			page, _ := pager.NextPage(ctx)
-			var objs []s3types.ObjectIdentifier
-			for _, v := range page.Versions {
-				if v.Key != nil && aws.ToString(v.Key) == key {
-					objs = append(objs, s3types.ObjectIdentifier{Key: v.Key, VersionId: v.VersionId})
-				}
-			}
-			for _, m := range page.DeleteMarkers {
-				if m.Key != nil && aws.ToString(m.Key) == key {
-					objs = append(objs, s3types.ObjectIdentifier{Key: m.Key, VersionId: m.VersionId})
-				}
-			}
-			if len(objs) > 0 {
-				_, _ = p.S3Client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
-					Bucket: aws.String(p.BucketName),
-					Delete: &s3types.Delete{Objects: objs, Quiet: aws.Bool(true)},
-				})
-			}
-		}
-		return nil
-	}
-
 	// 1) Delete mockserver keys only
 	mockPrefix := fmt.Sprintf("configs/%s/", cleanProjectID)
-	_ = deleteAllVersionsWithPrefix(mockPrefix)
+	_ = p.deleteAllVersionsWithPrefix(ctx, mockPrefix)
 
 	// mock metadata file (not loadtest)
 	mockMetaKey := fmt.Sprintf("metadata/%s.json", cleanProjectID)
-	_ = deleteAllVersionsForKey(mockMetaKey)
+	_ = p.deleteAllVersionsForKey(ctx, mockMetaKey)
 
 	// NOTE: Do NOT delete deployment metadata here. That file signals a deployed stack.
 	// Removing it without destroying infra would cause drift. Terraform state cleanup is conditional later.
@@ -233,8 +196,8 @@ func (p *Provider) DeleteProject(projectID string) error {
 
 	if !mockArtifactsExist && !mockDeployed && !ltConfigsExist && !loadtestDeployed {
 		// Safe to delete Terraform state for both stacks
-		_ = deleteAllVersionsWithPrefix("terraform/state/")
-		_ = deleteAllVersionsWithPrefix("terraform/loadtest/state/")
+		_ = p.deleteAllVersionsWithPrefix(ctx, "terraform/state/")
+		_ = p.deleteAllVersionsWithPrefix(ctx, "terraform/loadtest/state/")
 
 		// If bucket is empty now, delete it
 		mk := int32(1)
@@ -323,6 +286,17 @@ func (p *Provider) ListVersions(ctx context.Context, projectID string) ([]models
 	return versions, nil
 }
 
+// DeleteVersion permanently removes a version snapshot, including noncurrent object versions
+func (p *Provider) DeleteVersion(ctx context.Context, projectID, version string) (int, error) {
+	cleanProjectID := p.naming.ExtractProjectID(projectID)
+	key := fmt.Sprintf("configs/%s/versions/%s.json", cleanProjectID, version)
+	deleted := p.deleteAllVersionsForKey(ctx, key)
+	if deleted == 0 {
+		return 0, fmt.Errorf("version %s not found or could not be deleted", version)
+	}
+	return deleted, nil
+}
+
 func (p *Provider) ListProjects(ctx context.Context) ([]models.ProjectInfo, error) {
 	fmt.Println("✅ Checking existence of projects")
 	var projects []models.ProjectInfo
@@ -392,12 +366,19 @@ func (p *Provider) GetMetadata(ctx context.Context, projectID string) (*models.C
 // Helper methods
 
 func (p *Provider) putObject(ctx context.Context, key string, data []byte, contentType string) error {
+	return p.putProtectedObject(ctx, key, data, contentType, &objectProtection{sse: p.serverSideEncryption()})
+}
+
+// putProtectedObject writes an object with the given encryption and tags
+func (p *Provider) putProtectedObject(ctx context.Context, key string, data []byte, contentType string, op *objectProtection) error {
 	_, err := p.S3Client.PutObject(ctx, &s3.PutObjectInput{
 		Bucket:               aws.String(p.BucketName),
 		Key:                  aws.String(key),
 		Body:                 bytes.NewReader(data),
 		ContentType:          aws.String(contentType),
-		ServerSideEncryption: s3types.ServerSideEncryption("AES256"),
+		ServerSideEncryption: op.sse,
+		SSEKMSKeyId:          op.kmsKeyID,
+		Tagging:              op.tagging,
 	})
 	return err
 }
@@ -445,7 +426,7 @@ func ListBuckets(ctx context.Context, profile, prefix string) ([]string, error)
 		return nil, err
 	}
 
-	s3Client := s3.NewFromConfig(cfg)
+	s3Client := newS3Client(cfg)
 	out, err := s3Client.ListBuckets(ctx, &s3.ListBucketsInput{})
 	if err != nil {
 		return nil, err
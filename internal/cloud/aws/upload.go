@@ -0,0 +1,329 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+const (
+	// multipartThreshold is the file size above which bundles are uploaded in parts
+	multipartThreshold = 32 << 20
+	// partSize must be at least 5MiB (S3 minimum for all but the last part)
+	partSize = 16 << 20
+
+	defaultUploadConcurrency = 4
+	uploadMaxAttempts        = 5
+	uploadStateFile          = ".automock-upload.json"
+)
+
+// uploadState persists progress of a bundle upload in the bundle directory so an
+// interrupted upload (e.g. a dropped VPN) resumes into the same bundle on the next run
+type uploadState struct {
+	ProjectID string                      `json:"project_id"`
+	BundleID  string                      `json:"bundle_id"`
+	CreatedAt time.Time                   `json:"created_at"`
+	Hashes    map[string]string           `json:"hashes"`
+	Files     map[string]*fileUploadState `json:"files"`
+
+	path string
+	mu   sync.Mutex
+}
+
+type fileUploadState struct {
+	UploadID string `json:"upload_id,omitempty"` // in-progress multipart upload
+	Done     bool   `json:"done"`
+}
+
+// loadUploadState returns the saved state when it belongs to the same project and
+// identical file contents; otherwise a fresh state for a new bundle
+func loadUploadState(bundleDir, projectID string, hashes map[string]string, now time.Time) (*uploadState, bool) {
+	path := filepath.Join(bundleDir, uploadStateFile)
+	fresh := &uploadState{
+		ProjectID: projectID,
+		BundleID:  fmt.Sprintf("bndl_%d", now.UnixNano()),
+		CreatedAt: now,
+		Hashes:    hashes,
+		Files:     map[string]*fileUploadState{},
+		path:      path,
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fresh, false
+	}
+	var saved uploadState
+	if err := json.Unmarshal(data, &saved); err != nil || saved.ProjectID != projectID || len(saved.Hashes) != len(hashes) {
+		return fresh, false
+	}
+	for name, sum := range hashes {
+		if saved.Hashes[name] != sum {
+			return fresh, false
+		}
+	}
+	if saved.Files == nil {
+		saved.Files = map[string]*fileUploadState{}
+	}
+	saved.path = path
+	return &saved, true
+}
+
+func (s *uploadState) file(name string) *fileUploadState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, ok := s.Files[name]
+	if !ok {
+		f = &fileUploadState{}
+		s.Files[name] = f
+	}
+	return f
+}
+
+func (s *uploadState) save() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if data, err := json.MarshalIndent(s, "", "  "); err == nil {
+		_ = os.WriteFile(s.path, data, 0644)
+	}
+}
+
+func (s *uploadState) clear() {
+	_ = os.Remove(s.path)
+}
+
+// byteLimiter spreads uploads so they do not exceed a bytes-per-second budget
+type byteLimiter struct {
+	mu   sync.Mutex
+	rate int64
+	next time.Time
+}
+
+// newByteLimiterFromEnv reads AUTOMOCK_UPLOAD_MAX_MBPS (megabytes per second, 0 = unlimited)
+func newByteLimiterFromEnv() *byteLimiter {
+	mbps, err := strconv.ParseFloat(os.Getenv("AUTOMOCK_UPLOAD_MAX_MBPS"), 64)
+	if err != nil || mbps <= 0 {
+		return nil
+	}
+	return &byteLimiter{rate: int64(mbps * (1 << 20))}
+}
+
+func (l *byteLimiter) wait(ctx context.Context, n int64) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(time.Duration(float64(n) / float64(l.rate) * float64(time.Second)))
+	l.mu.Unlock()
+	return sleepCtx(ctx, delay)
+}
+
+func uploadConcurrency() int {
+	if n, err := strconv.Atoi(os.Getenv("AUTOMOCK_UPLOAD_CONCURRENCY")); err == nil && n > 0 {
+		return n
+	}
+	return defaultUploadConcurrency
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// withRetry runs op with exponential backoff (1s, 2s, 4s, ...)
+func withRetry(ctx context.Context, what string, op func() error) error {
+	var err error
+	for attempt := 1; attempt <= uploadMaxAttempts; attempt++ {
+		if err = op(); err == nil {
+			return nil
+		}
+		if attempt == uploadMaxAttempts || ctx.Err() != nil {
+			break
+		}
+		backoff := time.Duration(1<<(attempt-1)) * time.Second
+		fmt.Printf("   ⚠️  %s failed (attempt %d/%d), retrying in %s: %v\n", what, attempt, uploadMaxAttempts, backoff, err)
+		if serr := sleepCtx(ctx, backoff); serr != nil {
+			return serr
+		}
+	}
+	return err
+}
+
+// uploadFile uploads a local file to key, using a resumable multipart upload for large files
+func (p *Provider) uploadFile(ctx context.Context, key, localPath, contentType string, op *objectProtection, fs *fileUploadState, state *uploadState, limiter *byteLimiter) error {
+	st, err := os.Stat(localPath)
+	if err != nil {
+		return err
+	}
+	if st.Size() <= multipartThreshold {
+		data, err := os.ReadFile(localPath)
+		if err != nil {
+			return err
+		}
+		if err := limiter.wait(ctx, int64(len(data))); err != nil {
+			return err
+		}
+		return withRetry(ctx, "upload "+filepath.Base(localPath), func() error {
+			return p.putProtectedObject(ctx, key, data, contentType, op)
+		})
+	}
+	return p.uploadMultipart(ctx, key, localPath, st.Size(), contentType, op, fs, state, limiter)
+}
+
+func (p *Provider) uploadMultipart(ctx context.Context, key, localPath string, size int64, contentType string, op *objectProtection, fs *fileUploadState, state *uploadState, limiter *byteLimiter) error {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// Resume the previous multipart upload when possible
+	done := map[int32]string{}
+	if fs.UploadID != "" {
+		if parts, err := p.listUploadedParts(ctx, key, fs.UploadID); err == nil {
+			done = parts
+			fmt.Printf("   ↩️  Resuming %s: %d part(s) already uploaded\n", filepath.Base(localPath), len(done))
+		} else {
+			fs.UploadID = "" // upload expired or was aborted; start over
+		}
+	}
+	if fs.UploadID == "" {
+		out, err := p.S3Client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+			Bucket:               aws.String(p.BucketName),
+			Key:                  aws.String(key),
+			ContentType:          aws.String(contentType),
+			ServerSideEncryption: op.sse,
+			SSEKMSKeyId:          op.kmsKeyID,
+			Tagging:              op.tagging,
+		})
+		if err != nil {
+			return fmt.Errorf("start multipart upload: %w", err)
+		}
+		fs.UploadID = aws.ToString(out.UploadId)
+		state.save()
+	}
+
+	totalParts := int32((size + partSize - 1) / partSize)
+	fmt.Printf("   📦 %s: %d MiB in %d part(s)\n", filepath.Base(localPath), size>>20, totalParts)
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		uploadErr error
+		sem       = make(chan struct{}, uploadConcurrency())
+	)
+	var pending []int32
+	for part := int32(1); part <= totalParts; part++ {
+		if _, ok := done[part]; !ok {
+			pending = append(pending, part)
+		}
+	}
+	for _, part := range pending {
+		offset := int64(part-1) * partSize
+		length := int64(partSize)
+		if offset+length > size {
+			length = size - offset
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(part int32, offset, length int64) {
+			defer func() { <-sem; wg.Done() }()
+			if err := limiter.wait(ctx, length); err != nil {
+				mu.Lock()
+				uploadErr = errors.Join(uploadErr, err)
+				mu.Unlock()
+				return
+			}
+			var etag string
+			err := withRetry(ctx, fmt.Sprintf("part %d/%d of %s", part, totalParts, filepath.Base(localPath)), func() error {
+				out, err := p.S3Client.UploadPart(ctx, &s3.UploadPartInput{
+					Bucket:     aws.String(p.BucketName),
+					Key:        aws.String(key),
+					UploadId:   aws.String(fs.UploadID),
+					PartNumber: aws.Int32(part),
+					Body:       io.NewSectionReader(f, offset, length),
+				})
+				if err != nil {
+					return err
+				}
+				etag = aws.ToString(out.ETag)
+				return nil
+			})
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				uploadErr = errors.Join(uploadErr, fmt.Errorf("part %d: %w", part, err))
+				return
+			}
+			done[part] = etag
+		}(part, offset, length)
+	}
+	wg.Wait()
+	if uploadErr != nil {
+		// Keep the multipart upload open so the next run can resume it
+		return fmt.Errorf("%w (re-run the upload to resume)", uploadErr)
+	}
+
+	completed := make([]s3types.CompletedPart, 0, totalParts)
+	for part := int32(1); part <= totalParts; part++ {
+		completed = append(completed, s3types.CompletedPart{PartNumber: aws.Int32(part), ETag: aws.String(done[part])})
+	}
+	if _, err := p.S3Client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(p.BucketName),
+		Key:             aws.String(key),
+		UploadId:        aws.String(fs.UploadID),
+		MultipartUpload: &s3types.CompletedMultipartUpload{Parts: completed},
+	}); err != nil {
+		return fmt.Errorf("complete multipart upload: %w", err)
+	}
+	fs.UploadID = ""
+	return nil
+}
+
+// listUploadedParts returns part number -> ETag for an in-progress multipart upload
+func (p *Provider) listUploadedParts(ctx context.Context, key, uploadID string) (map[int32]string, error) {
+	parts := map[int32]string{}
+	var marker *string
+	for {
+		out, err := p.S3Client.ListParts(ctx, &s3.ListPartsInput{
+			Bucket:           aws.String(p.BucketName),
+			Key:              aws.String(key),
+			UploadId:         aws.String(uploadID),
+			PartNumberMarker: marker,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, part := range out.Parts {
+			parts[aws.ToInt32(part.PartNumber)] = aws.ToString(part.ETag)
+		}
+		if !aws.ToBool(out.IsTruncated) || out.NextPartNumberMarker == nil {
+			return parts, nil
+		}
+		marker = out.NextPartNumberMarker
+	}
+}
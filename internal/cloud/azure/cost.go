@@ -0,0 +1,163 @@
+package azure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/network"
+)
+
+// aciPrice is the Container Instances Linux price per vCPU-hour and GB-hour of a region
+type aciPrice struct{ vCPUHour, gbHour float64 }
+
+// aciPrices holds rounded pay-as-you-go prices; regions missing here are priced as eastus
+var aciPrices = map[string]aciPrice{
+	"eastus":        {0.0486, 0.00533},
+	"eastus2":       {0.0486, 0.00533},
+	"westus2":       {0.0486, 0.00533},
+	"centralus":     {0.0535, 0.00587},
+	"northeurope":   {0.0503, 0.00552},
+	"westeurope":    {0.0535, 0.00587},
+	"uksouth":       {0.0535, 0.00587},
+	"southeastasia": {0.0583, 0.00640},
+	"australiaeast": {0.0632, 0.00693},
+}
+
+// EstimateCost approximates the monthly cost of one Container Instances group running 24/7
+// (MockServer plus the config sync sidecar)
+func (p *Provider) EstimateCost(options *models.DeploymentOptions) *models.CostEstimate {
+	if options == nil {
+		return nil
+	}
+	const (
+		hoursPerMonth = 730.0
+		sidecarVCPU   = 0.25
+		sidecarGB     = 0.5
+		dataMonthly   = 1.74 // ~20 GB egress @ $0.087/GB
+		storageBlobs  = 0.50 // configs, state and logs: well under 1 GB
+	)
+
+	estimate := &models.CostEstimate{Region: p.GetRegion()}
+	price, ok := aciPrices[estimate.Region]
+	if !ok {
+		price = aciPrices[defaultLocation]
+		estimate.PricedAs = defaultLocation
+	}
+
+	cpu, memGB := ContainerSize(options.InstanceSize)
+	perHour := (cpu+sidecarVCPU)*price.vCPUHour + (memGB+sidecarGB)*price.gbHour
+	estimate.Add(models.CostCompute,
+		fmt.Sprintf("Container group 24/7, %s (%.2f vCPU / %.1f GB) + sidecar", options.InstanceSize, cpu, memGB),
+		perHour*hoursPerMonth)
+	estimate.Add(models.CostDataTransfer, "Egress, assumed ~20 GB", dataMonthly)
+	estimate.Add(models.CostStorage, "Blob storage, assumed < 1 GB", storageBlobs)
+	estimate.Notes = append(estimate.Notes, fmt.Sprintf("$%.4f/vCPU-hr + $%.5f/GB-hr; Container Instances does not autoscale, so min/max tasks do not apply",
+		price.vCPUHour, price.gbHour))
+	return estimate
+}
+
+// costManagementEndpoint is the Azure Resource Manager host serving Cost Management queries
+var costManagementEndpoint = "https://management.azure.com"
+
+// ActualCosts returns the actual cost of the resources tagged ProjectName=<project> (the tag
+// every AutoMock stack sets) per service, from a Cost Management query on the subscription
+func (p *Provider) ActualCosts(ctx context.Context, projectID string, from, to time.Time) (*models.CostReport, error) {
+	if p.subscription == "" {
+		return nil, fmt.Errorf("no subscription configured; set %s or pass --profile <subscription-id>", EnvSubscription)
+	}
+	body, err := json.Marshal(map[string]any{
+		"type":      "ActualCost",
+		"timeframe": "Custom",
+		// The query's end is inclusive
+		"timePeriod": map[string]string{"from": from.Format(time.RFC3339), "to": to.Add(-time.Second).Format(time.RFC3339)},
+		"dataset": map[string]any{
+			"granularity": "None",
+			"aggregation": map[string]any{"totalCost": map[string]string{"name": "Cost", "function": "Sum"}},
+			"grouping":    []map[string]string{{"type": "Dimension", "name": "ServiceName"}},
+			"filter": map[string]any{
+				"tags": map[string]any{"name": "ProjectName", "operator": "In", "values": []string{projectID}},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	token, err := p.credential.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{costManagementEndpoint + "/.default"}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get an Azure token: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/subscriptions/%s/providers/Microsoft.CostManagement/query?api-version=2023-03-01", costManagementEndpoint, p.subscription)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token.Token)
+
+	resp, err := network.HTTPClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cost management request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	raw, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cost management returned %s: %s", resp.Status, bytes.TrimSpace(raw))
+	}
+
+	report, err := parseCostQuery(raw)
+	if err != nil {
+		return nil, err
+	}
+	report.From, report.To = from, to
+	report.Scope = "resources tagged ProjectName=" + projectID
+	report.EmptyHint = "Cost Management data lags usage by up to a day"
+	return report, nil
+}
+
+// parseCostQuery reads the rows of a Cost Management query, whose columns are named in the response
+func parseCostQuery(raw []byte) (*models.CostReport, error) {
+	var out struct {
+		Properties struct {
+			Columns []struct{ Name string }
+			Rows    [][]any
+		}
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("failed to parse Cost Management response: %w", err)
+	}
+	column := map[string]int{}
+	for i, c := range out.Properties.Columns {
+		column[c.Name] = i
+	}
+	costAt, ok1 := column["Cost"]
+	serviceAt, ok2 := column["ServiceName"]
+	if !ok1 || !ok2 {
+		return nil, fmt.Errorf("unexpected Cost Management response: columns %v", out.Properties.Columns)
+	}
+	currencyAt, hasCurrency := column["Currency"]
+
+	report := &models.CostReport{}
+	for _, row := range out.Properties.Rows {
+		if len(row) <= costAt || len(row) <= serviceAt {
+			continue
+		}
+		amount, _ := row[costAt].(float64)
+		service, _ := row[serviceAt].(string)
+		report.Services = append(report.Services, models.ServiceCost{Service: service, Amount: amount})
+		if hasCurrency && len(row) > currencyAt {
+			report.Currency, _ = row[currencyAt].(string)
+		}
+	}
+	sort.Slice(report.Services, func(i, j int) bool { return report.Services[i].Service < report.Services[j].Service })
+	return report, nil
+}
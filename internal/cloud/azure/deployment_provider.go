@@ -0,0 +1,137 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+const (
+	deploymentMetadataKey = "deployment-metadata.json"
+	loadTestMetadataKey   = "deployment-metadata-loadtest.json"
+)
+
+// SaveDeploymentMetadata saves deployment metadata to the project container
+func (p *Provider) SaveDeploymentMetadata(output *models.InfrastructureOutputs) error {
+	metadata := &models.DeploymentMetadata{
+		ProjectName:      p.projectID,
+		DeploymentStatus: "deployed",
+		DeployedAt:       time.Now().UTC(),
+		Details:          output,
+	}
+	if err := p.putJSON(context.Background(), deploymentMetadataKey, metadata); err != nil {
+		return fmt.Errorf("failed to upload metadata: %w", err)
+	}
+	return nil
+}
+
+// GetDeploymentMetadata retrieves deployment metadata
+func (p *Provider) GetDeploymentMetadata() (*models.DeploymentMetadata, error) {
+	var metadata models.DeploymentMetadata
+	if err := p.getJSON(context.Background(), deploymentMetadataKey, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to download metadata: %w", err)
+	}
+	return &metadata, nil
+}
+
+// DeleteDeploymentMetadata removes deployment metadata
+func (p *Provider) DeleteDeploymentMetadata() error {
+	_, err := p.Client.DeleteBlob(context.Background(), p.ContainerName, deploymentMetadataKey, nil)
+	return err
+}
+
+// IsDeployed checks if infrastructure is currently deployed
+func (p *Provider) IsDeployed() (bool, error) {
+	metadata, err := p.GetDeploymentMetadata()
+	if err != nil {
+		return false, err
+	}
+	return metadata.DeploymentStatus == "deployed", nil
+}
+
+// SaveLoadTestDeploymentMetadata persists load test deployment metadata (Locust infra)
+func (p *Provider) SaveLoadTestDeploymentMetadata(output *models.LoadTestDeploymentOutputs) error {
+	md := &models.LoadTestDeploymentMetadata{
+		ProjectName:      p.projectID,
+		DeploymentStatus: "deployed",
+		DeployedAt:       time.Now().UTC(),
+		Details:          output,
+	}
+	if err := p.putJSON(context.Background(), loadTestMetadataKey, md); err != nil {
+		return fmt.Errorf("upload loadtest metadata: %w", err)
+	}
+	return nil
+}
+
+// GetLoadTestDeploymentMetadata fetches load test deployment metadata if present
+func (p *Provider) GetLoadTestDeploymentMetadata() (*models.LoadTestDeploymentMetadata, error) {
+	var md models.LoadTestDeploymentMetadata
+	if err := p.getJSON(context.Background(), loadTestMetadataKey, &md); err != nil {
+		return nil, fmt.Errorf("get loadtest metadata: %w", err)
+	}
+	return &md, nil
+}
+
+// DeleteLoadTestDeploymentMetadata removes Locust deployment metadata
+func (p *Provider) DeleteLoadTestDeploymentMetadata() error {
+	_, err := p.Client.DeleteBlob(context.Background(), p.ContainerName, loadTestMetadataKey, nil)
+	return err
+}
+
+// CreateDeploymentConfiguration asks for the container size unless --instance-size (or
+// automock.yaml) chose it; the Container Instances stack creates its own resource group,
+// identity and public endpoint, so there is nothing to bring
+func (p *Provider) CreateDeploymentConfiguration() *models.DeploymentOptions {
+	options := p.CreateDefaultDeploymentConfiguration()
+	if os.Getenv(models.EnvInstanceSize) != "" {
+		return options
+	}
+	if err := ask.One(&survey.Select{
+		Message: "Container size:",
+		Options: []string{"small", "medium", "large", "xlarge"},
+		Default: "small",
+		Description: func(value string, index int) string {
+			cpu, mem := ContainerSize(value)
+			return fmt.Sprintf("%.1f vCPU, %.0f GB", cpu, mem)
+		},
+	}, &options.InstanceSize); err != nil {
+		return nil
+	}
+	return options
+}
+
+// CreateDefaultDeploymentConfiguration returns the options used when nothing is asked
+func (p *Provider) CreateDefaultDeploymentConfiguration() *models.DeploymentOptions {
+	options := &models.DeploymentOptions{
+		InstanceSize:   "small",
+		Region:         p.GetRegion(),
+		BucketName:     p.ContainerName,
+		StorageAccount: p.AccountName,
+		ProjectName:    p.GetProjectName(),
+		Provider:       p.GetProviderType(),
+	}
+	if err := options.ApplySizingEnv(); err != nil {
+		fmt.Printf("⚠️  Ignoring deployment sizing: %v\n", err)
+	}
+	return options
+}
+
+// ContainerSize maps an instance size to the vCPU and memory (GB) of the MockServer container;
+// the Terraform stack uses the same table
+func ContainerSize(size string) (float64, float64) {
+	switch size {
+	case "medium":
+		return 1, 2
+	case "large":
+		return 2, 4
+	case "xlarge":
+		return 4, 8
+	default:
+		return 0.5, 1
+	}
+}
@@ -0,0 +1,436 @@
+package azure
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	awsprovider "github.com/hemantobora/auto-mock/internal/cloud/aws"
+	"github.com/hemantobora/auto-mock/internal/loadtest"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// UploadLoadTestBundle uploads a generated load test bundle directory and updates the pointer
+// and version files, using the same layout as the other providers.
+// bundleDir must contain locustfile.py, requirements.txt and locust_endpoints.json (or, for k6
+// bundles, script.js, k6_endpoints.json and k6_options.json).
+func (p *Provider) UploadLoadTestBundle(ctx context.Context, projectID, bundleDir string) (*models.LoadTestPointer, *models.LoadTestVersion, error) {
+	// Ensure container context is established (supports REPL uploads without prior init)
+	if p.ContainerName == "" {
+		base := p.naming.ExtractProjectID(projectID)
+		if exists, _ := p.ProjectExists(ctx, base); !exists {
+			if err := p.InitProject(ctx, base); err != nil {
+				return nil, nil, fmt.Errorf("init project: %w", err)
+			}
+		}
+	}
+	baseID := p.naming.ExtractProjectID(projectID)
+	if os.Getenv(awsprovider.EnvLoadTestKMSKey) != "" || os.Getenv(awsprovider.EnvLoadTestTags) != "" {
+		fmt.Println("⚠️  --kms-key and --object-tag apply to S3 only; blobs are encrypted with the storage account's keys")
+	}
+
+	layout := loadtest.LayoutFor(loadtest.DetectTool(bundleDir))
+	required, optional := layout.Required, layout.Optional
+	found := make(map[string]string)
+	hashes := make(map[string]string)
+	var missing []string
+	for _, name := range append(append([]string(nil), required...), optional...) {
+		fp := filepath.Join(bundleDir, name)
+		st, err := os.Stat(fp)
+		if err != nil || st.IsDir() {
+			if contains(required, name) {
+				missing = append(missing, name)
+			}
+			continue
+		}
+		if sum, err := hashFile(fp); err == nil {
+			hashes[name] = sum
+		}
+		found[name] = fp
+	}
+	if len(missing) > 0 {
+		return nil, nil, fmt.Errorf("missing required bundle files: %v", missing)
+	}
+
+	valRes, _ := loadtest.ValidateBundle(bundleDir)
+	validation := &models.LoadTestValidationResult{
+		LocustfilePresent:   layout.Tool == loadtest.ToolLocust,
+		RequirementsPresent: layout.Tool == loadtest.ToolLocust,
+		UserDataPresent:     found["user_data.yaml"] != "",
+		ManifestPresent:     found["manifest.json"] != "",
+		HostDefined:         valRes != nil && valRes.HostDefined,
+	}
+	if valRes != nil {
+		validation.PlaceholderErrors = valRes.PlaceholderErrors
+	}
+
+	ts := time.Now().UTC()
+	version := fmt.Sprintf("v%d", ts.Unix())
+	bundleID := fmt.Sprintf("bndl_%d", ts.UnixNano())
+
+	var fileRefs []models.LoadTestFileRef
+	for name, fp := range found {
+		if st, err := os.Stat(fp); err == nil {
+			fileRefs = append(fileRefs, models.LoadTestFileRef{Name: name, Size: st.Size(), SHA256: hashes[name]})
+		}
+	}
+	manifestWarnings := []string{}
+	if !validation.HostDefined {
+		manifestWarnings = append(manifestWarnings, layout.HostHint())
+	}
+	if len(validation.PlaceholderErrors) > 0 {
+		manifestWarnings = append(manifestWarnings, fmt.Sprintf("Found %d unresolved placeholders in user_data.yaml", len(validation.PlaceholderErrors)))
+	}
+	manifest := &models.LoadTestManifest{
+		BundleID:    bundleID,
+		ProjectID:   baseID,
+		GeneratedAt: ts,
+		Files:       fileRefs,
+		Entrypoints: []string{layout.Entrypoint},
+		Warnings:    manifestWarnings,
+	}
+
+	metrics := map[string]int{}
+	if valRes != nil {
+		metrics["tasks"] = valRes.Tasks
+		metrics["endpoints"] = valRes.Endpoints
+	}
+	versionSnap := &models.LoadTestVersion{
+		ProjectID:  baseID,
+		Version:    version,
+		BundleID:   bundleID,
+		Tool:       layout.Tool,
+		CreatedAt:  ts,
+		Hashes:     hashes,
+		Validation: validation,
+		Metrics:    metrics,
+	}
+	pointer := models.NewDefaultLoadTestPointer(baseID, version, bundleID, p.bundleFiles(baseID, bundleID, layout.Tool),
+		&models.LoadTestSummary{Tasks: metrics["tasks"], Endpoints: metrics["endpoints"], HasHost: validation.HostDefined})
+	pointer.Tool = layout.Tool
+
+	// Bundle files go up as block blobs, which the SDK uploads in parallel chunks
+	for name, local := range found {
+		if err := p.uploadFile(ctx, p.naming.LoadTestBundleFileKey(baseID, bundleID, name), local); err != nil {
+			return nil, nil, fmt.Errorf("upload %s: %w", name, err)
+		}
+	}
+	if err := p.putJSON(ctx, p.naming.LoadTestBundleFileKey(baseID, bundleID, "manifest.json"), manifest); err != nil {
+		return nil, nil, fmt.Errorf("upload manifest: %w", err)
+	}
+	if err := p.putJSON(ctx, p.naming.LoadTestVersionKey(baseID, version), versionSnap); err != nil {
+		return nil, nil, fmt.Errorf("upload version snapshot: %w", err)
+	}
+	if err := p.putJSON(ctx, p.naming.LoadTestCurrentKey(baseID), pointer); err != nil {
+		return nil, nil, fmt.Errorf("upload pointer: %w", err)
+	}
+	// Update metadata index (best effort)
+	_ = p.putJSON(ctx, p.naming.LoadTestMetadataKey(baseID), models.LoadTestMetadataIndex{ProjectID: baseID, LatestVersion: version, UpdatedAt: ts})
+
+	return pointer, versionSnap, nil
+}
+
+// GetLoadTestPointer retrieves the current load test pointer (current.json) for a project
+func (p *Provider) GetLoadTestPointer(ctx context.Context, projectID string) (*models.LoadTestPointer, error) {
+	baseID := p.naming.ExtractProjectID(projectID)
+	var ptr models.LoadTestPointer
+	if err := p.getJSON(ctx, p.naming.LoadTestCurrentKey(baseID), &ptr); err != nil {
+		return nil, fmt.Errorf("get loadtest pointer: %w", err)
+	}
+	return &ptr, nil
+}
+
+// DownloadLoadTestBundle downloads the active load test bundle files into destDir/<bundleID>
+// and returns the pointer and the absolute local directory
+func (p *Provider) DownloadLoadTestBundle(ctx context.Context, projectID, destDir string) (*models.LoadTestPointer, string, error) {
+	ptr, err := p.GetLoadTestPointer(ctx, projectID)
+	if err != nil {
+		return nil, "", err
+	}
+	target := filepath.Join(destDir, ptr.BundleID)
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		return nil, "", fmt.Errorf("create dir: %w", err)
+	}
+	for _, key := range ptr.Files {
+		if key == "" {
+			continue
+		}
+		localPath := filepath.Join(target, path.Base(key))
+		f, err := os.Create(localPath)
+		if err != nil {
+			return nil, "", fmt.Errorf("create %s: %w", localPath, err)
+		}
+		_, err = p.Client.DownloadFile(ctx, p.ContainerName, key, f, nil)
+		f.Close()
+		if err != nil {
+			os.Remove(localPath)
+			return nil, "", fmt.Errorf("download %s: %w", key, err)
+		}
+	}
+	abs, _ := filepath.Abs(target)
+	return ptr, abs, nil
+}
+
+// SaveLoadTestResult stores the summarized results of a run next to the bundle version it ran
+func (p *Provider) SaveLoadTestResult(ctx context.Context, projectID string, result *models.LoadTestRunResult) (string, error) {
+	baseID := p.naming.ExtractProjectID(projectID)
+	key := p.naming.LoadTestResultKey(baseID, result.Version, result.RunID)
+	if err := p.putJSON(ctx, key, result); err != nil {
+		return "", fmt.Errorf("upload results: %w", err)
+	}
+	return key, nil
+}
+
+// ListLoadTestResults reads the results of every run of every version, oldest first
+func (p *Provider) ListLoadTestResults(ctx context.Context, projectID string) ([]models.LoadTestRunResult, error) {
+	baseID := p.naming.ExtractProjectID(projectID)
+	items, err := p.listObjects(ctx, p.naming.LoadTestResultsPrefix(baseID))
+	if err != nil {
+		return nil, fmt.Errorf("list results: %w", err)
+	}
+	results := make([]models.LoadTestRunResult, 0, len(items))
+	for _, item := range items {
+		if path.Ext(item.Key) != ".json" {
+			continue
+		}
+		var result models.LoadTestRunResult
+		if err := p.getJSON(ctx, item.Key, &result); err != nil {
+			return nil, fmt.Errorf("read %s: %w", item.Key, err)
+		}
+		results = append(results, result)
+	}
+	models.SortLoadTestResults(results)
+	return results, nil
+}
+
+// SaveLoadTestSchedule records the scheduled runs of the project
+func (p *Provider) SaveLoadTestSchedule(ctx context.Context, schedule *models.LoadTestSchedule) error {
+	baseID := p.naming.ExtractProjectID(schedule.ProjectID)
+	if err := p.putJSON(ctx, p.naming.LoadTestScheduleKey(baseID), schedule); err != nil {
+		return fmt.Errorf("upload schedule: %w", err)
+	}
+	return nil
+}
+
+// GetLoadTestSchedule returns the scheduled runs of the project
+func (p *Provider) GetLoadTestSchedule(ctx context.Context, projectID string) (*models.LoadTestSchedule, error) {
+	baseID := p.naming.ExtractProjectID(projectID)
+	var schedule models.LoadTestSchedule
+	if err := p.getJSON(ctx, p.naming.LoadTestScheduleKey(baseID), &schedule); err != nil {
+		return nil, fmt.Errorf("get schedule: %w", err)
+	}
+	return &schedule, nil
+}
+
+// DeleteLoadTestSchedule removes the schedule record (not the scheduler itself)
+func (p *Provider) DeleteLoadTestSchedule(ctx context.Context, projectID string) error {
+	baseID := p.naming.ExtractProjectID(projectID)
+	_, err := p.Client.DeleteBlob(ctx, p.ContainerName, p.naming.LoadTestScheduleKey(baseID), nil)
+	return err
+}
+
+// DeleteLoadTestPointer removes the current.json pointer (does not delete bundles)
+func (p *Provider) DeleteLoadTestPointer(ctx context.Context, projectID string) error {
+	baseID := p.naming.ExtractProjectID(projectID)
+	_, err := p.Client.DeleteBlob(ctx, p.ContainerName, p.naming.LoadTestCurrentKey(baseID), nil)
+	return err
+}
+
+// DeleteActiveLoadTestBundleAndRollback deletes the active bundle and points current.json at
+// the previous version, or removes the pointer when there is none
+func (p *Provider) DeleteActiveLoadTestBundleAndRollback(ctx context.Context, projectID string) (*models.LoadTestPointer, int, error) {
+	curPtr, err := p.GetLoadTestPointer(ctx, projectID)
+	if err != nil || curPtr == nil || curPtr.ActiveVersion == "" {
+		_ = p.DeleteLoadTestPointer(ctx, projectID)
+		return nil, 0, nil
+	}
+
+	baseID := p.naming.ExtractProjectID(projectID)
+	deleted := p.deletePrefix(ctx, p.naming.LoadTestBundleDir(baseID, curPtr.BundleID))
+
+	items, err := p.listObjects(ctx, p.versionsPrefix(baseID))
+	if err != nil {
+		return nil, deleted, fmt.Errorf("list versions: %w", err)
+	}
+	keys := make([]string, 0, len(items))
+	for _, item := range items {
+		keys = append(keys, item.Key)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	prevKey := ""
+	currentKey := p.naming.LoadTestVersionKey(baseID, curPtr.ActiveVersion)
+	for _, k := range keys {
+		if k < currentKey {
+			prevKey = k
+			break
+		}
+	}
+	if prevKey == "" {
+		_ = p.DeleteLoadTestPointer(ctx, projectID)
+		return nil, deleted, nil
+	}
+
+	var prev models.LoadTestVersion
+	if err := p.getJSON(ctx, prevKey, &prev); err != nil {
+		return nil, deleted, fmt.Errorf("read previous version: %w", err)
+	}
+	ptr := models.NewDefaultLoadTestPointer(prev.ProjectID, prev.Version, prev.BundleID, p.bundleFiles(prev.ProjectID, prev.BundleID, prev.Tool), &models.LoadTestSummary{
+		Tasks:     prev.Metrics["tasks"],
+		Endpoints: prev.Metrics["endpoints"],
+		HasHost:   prev.Validation != nil && prev.Validation.HostDefined,
+	})
+	ptr.Tool = prev.Tool
+	if err := p.putJSON(ctx, p.naming.LoadTestCurrentKey(prev.ProjectID), ptr); err != nil {
+		return nil, deleted, fmt.Errorf("update pointer: %w", err)
+	}
+	return ptr, deleted, nil
+}
+
+// PurgeLoadTestArtifacts deletes all load test blobs (bundles, versions, pointer, metadata).
+// Terraform state and the container go too once neither context has artifacts or deployments.
+func (p *Provider) PurgeLoadTestArtifacts(ctx context.Context, projectID string) (int, bool, error) {
+	baseID := p.naming.ExtractProjectID(projectID)
+	ltID := p.naming.LoadTestProjectID(baseID)
+	deleted := p.deletePrefix(ctx, fmt.Sprintf("configs/%s/", ltID))
+	deleted += p.deleteKey(ctx, fmt.Sprintf("metadata/%s.json", ltID))
+
+	ltArtifactsExist := p.prefixExists(ctx, fmt.Sprintf("configs/%s/", ltID)) || p.objectExists(ctx, fmt.Sprintf("metadata/%s.json", ltID))
+	mockArtifactsExist := p.prefixExists(ctx, fmt.Sprintf("configs/%s/", baseID)) || p.objectExists(ctx, fmt.Sprintf("metadata/%s.json", baseID))
+	mockDeployed := p.objectExists(ctx, deploymentMetadataKey)
+	loadtestDeployed := p.objectExists(ctx, loadTestMetadataKey)
+
+	containerDeleted := false
+	if !ltArtifactsExist && !loadtestDeployed && !mockArtifactsExist && !mockDeployed {
+		deleted += p.deletePrefix(ctx, "terraform/loadtest/state/")
+		deleted += p.deletePrefix(ctx, "terraform/state/")
+		if !p.prefixExists(ctx, "") {
+			if _, err := p.Client.DeleteContainer(ctx, p.ContainerName, nil); err == nil {
+				containerDeleted = true
+			}
+		}
+	}
+	return deleted, containerDeleted, nil
+}
+
+// ListLoadTestVersions returns every stored load test version snapshot for the project
+func (p *Provider) ListLoadTestVersions(ctx context.Context, projectID string) ([]models.LoadTestVersion, error) {
+	baseID := p.naming.ExtractProjectID(projectID)
+	items, err := p.listObjects(ctx, p.versionsPrefix(baseID))
+	if err != nil {
+		return nil, fmt.Errorf("list versions: %w", err)
+	}
+	versions := make([]models.LoadTestVersion, 0, len(items))
+	for _, item := range items {
+		var ver models.LoadTestVersion
+		if err := p.getJSON(ctx, item.Key, &ver); err != nil {
+			return nil, fmt.Errorf("read %s: %w", item.Key, err)
+		}
+		versions = append(versions, ver)
+	}
+	return versions, nil
+}
+
+// ListLoadTestBundles returns the bundle directories stored for the project, including
+// bundles no version references
+func (p *Provider) ListLoadTestBundles(ctx context.Context, projectID string) ([]models.LoadTestBundleInfo, error) {
+	baseID := p.naming.ExtractProjectID(projectID)
+	prefix := p.naming.LoadTestBundlesPrefix(baseID)
+	items, err := p.listObjects(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("list bundles: %w", err)
+	}
+	byID := map[string]*models.LoadTestBundleInfo{}
+	var order []string
+	for _, item := range items {
+		bundleID, _, ok := strings.Cut(strings.TrimPrefix(item.Key, prefix), "/")
+		if !ok || bundleID == "" {
+			continue
+		}
+		info, seen := byID[bundleID]
+		if !seen {
+			info = &models.LoadTestBundleInfo{BundleID: bundleID}
+			byID[bundleID] = info
+			order = append(order, bundleID)
+		}
+		info.Objects++
+		info.Size += item.Size
+		if item.LastModified.After(info.CreatedAt) {
+			info.CreatedAt = item.LastModified
+		}
+	}
+	bundles := make([]models.LoadTestBundleInfo, 0, len(order))
+	for _, id := range order {
+		bundles = append(bundles, *byID[id])
+	}
+	return bundles, nil
+}
+
+// DeleteLoadTestVersion permanently removes a load test version snapshot (not its bundle)
+func (p *Provider) DeleteLoadTestVersion(ctx context.Context, projectID, version string) (int, error) {
+	baseID := p.naming.ExtractProjectID(projectID)
+	deleted := p.deleteKey(ctx, p.naming.LoadTestVersionKey(baseID, version))
+	return deleted + p.deletePrefix(ctx, path.Dir(p.naming.LoadTestResultKey(baseID, version, "run"))+"/"), nil
+}
+
+// DeleteLoadTestBundle permanently removes a bundle directory
+func (p *Provider) DeleteLoadTestBundle(ctx context.Context, projectID, bundleID string) (int, error) {
+	if bundleID == "" {
+		return 0, fmt.Errorf("bundle id is required")
+	}
+	baseID := p.naming.ExtractProjectID(projectID)
+	return p.deletePrefix(ctx, p.naming.LoadTestBundleDir(baseID, bundleID)), nil
+}
+
+func (p *Provider) versionsPrefix(baseID string) string {
+	return fmt.Sprintf("configs/%s/versions/", p.naming.LoadTestProjectID(baseID))
+}
+
+// bundleFiles returns the standard logical-name to blob mapping for a bundle
+func (p *Provider) bundleFiles(projectID, bundleID, tool string) map[string]string {
+	files := map[string]string{}
+	for logical, name := range loadtest.LayoutFor(tool).Files {
+		files[logical] = p.naming.LoadTestBundleFileKey(projectID, bundleID, name)
+	}
+	return files
+}
+
+func (p *Provider) uploadFile(ctx context.Context, key, localPath string) error {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = p.Client.UploadFile(ctx, p.ContainerName, key, f, nil)
+	return err
+}
+
+// hashFile computes a sha256 digest in the format "sha256:<hex>"
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func contains(xs []string, s string) bool {
+	for _, x := range xs {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
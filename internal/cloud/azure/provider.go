@@ -0,0 +1,206 @@
+// Package azure provides the Azure Blob Storage implementation of the provider interface.
+// Every project gets a container in one storage account; deployments run on Azure
+// Container Instances.
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+
+	"github.com/hemantobora/auto-mock/internal"
+	"github.com/hemantobora/auto-mock/internal/cloud/naming"
+	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/network"
+)
+
+// Environment variables selecting the storage account, region and subscription. The global
+// --azure-storage-account and --azure-location flags set the first two.
+const (
+	EnvStorageAccount = "AZURE_STORAGE_ACCOUNT"
+	EnvLocation       = "AZURE_LOCATION"
+	EnvSubscription   = "AZURE_SUBSCRIPTION_ID"
+)
+
+// defaultLocation is used when neither --azure-location nor AZURE_LOCATION is set
+const defaultLocation = "eastus"
+
+// Provider holds the Blob Storage client and project context
+type Provider struct {
+	projectID    string
+	naming       internal.NamingStrategy
+	location     string
+	subscription string
+	credential   azcore.TokenCredential
+
+	AccountName   string
+	ContainerName string
+	Client        *azblob.Client
+}
+
+// ProviderOption is a functional option for provider configuration
+type ProviderOption func(*providerOptions)
+
+type providerOptions struct {
+	subscription string
+	account      string
+	location     string
+}
+
+// WithProfile specifies the subscription ID Terraform deploys into
+func WithProfile(subscription string) ProviderOption {
+	return func(o *providerOptions) {
+		o.subscription = subscription
+	}
+}
+
+// WithAccount specifies the storage account holding the project containers
+func WithAccount(account string) ProviderOption {
+	return func(o *providerOptions) {
+		o.account = account
+	}
+}
+
+// WithLocation specifies the Azure region deployments go to
+func WithLocation(location string) ProviderOption {
+	return func(o *providerOptions) {
+		o.location = location
+	}
+}
+
+// NewProvider creates a Blob Storage provider authenticated with the default Azure
+// credential chain (environment, workload/managed identity, Azure CLI)
+func NewProvider(ctx context.Context, options ...ProviderOption) (*Provider, error) {
+	opts := &providerOptions{
+		subscription: os.Getenv(EnvSubscription),
+		account:      os.Getenv(EnvStorageAccount),
+		location:     os.Getenv(EnvLocation),
+	}
+	for _, opt := range options {
+		opt(opts)
+	}
+	if opts.account == "" {
+		return nil, &models.ProviderError{
+			Provider:  "azure",
+			Operation: "load-config",
+			Resource:  "storage-account",
+			Cause:     fmt.Errorf("no storage account configured; set %s or pass --azure-storage-account", EnvStorageAccount),
+		}
+	}
+	if opts.location == "" {
+		opts.location = defaultLocation
+	}
+
+	clientOptions := azcore.ClientOptions{Transport: &http.Client{Transport: network.Transport()}}
+	cred, err := azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{ClientOptions: clientOptions})
+	if err != nil {
+		return nil, &models.ProviderError{
+			Provider:  "azure",
+			Operation: "load-config",
+			Resource:  "credentials",
+			Cause:     fmt.Errorf("failed to load Azure credentials: %w", err),
+		}
+	}
+	client, err := azblob.NewClient(fmt.Sprintf("https://%s.blob.core.windows.net/", opts.account), cred, &azblob.ClientOptions{ClientOptions: clientOptions})
+	if err != nil {
+		return nil, &models.ProviderError{
+			Provider:  "azure",
+			Operation: "load-config",
+			Resource:  opts.account,
+			Cause:     err,
+		}
+	}
+
+	return &Provider{
+		naming:       naming.NewDefaultNaming(),
+		location:     opts.location,
+		subscription: opts.subscription,
+		credential:   cred,
+		AccountName:  opts.account,
+		Client:       client,
+	}, nil
+}
+
+// ValidateCredentials checks that the credentials can list the storage account's containers
+func ValidateCredentials(ctx context.Context, subscription string) (bool, error) {
+	p, err := NewProvider(ctx, WithProfile(subscription))
+	if err != nil {
+		return false, err
+	}
+	prefix := p.naming.GetPrefix()
+	maxResults := int32(1)
+	pager := p.Client.NewListContainersPager(&azblob.ListContainersOptions{Prefix: &prefix, MaxResults: &maxResults})
+	if _, err := pager.NextPage(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetProviderType returns the provider type
+func (p *Provider) GetProviderType() string {
+	return "azure"
+}
+
+// CredentialEnv points Terraform's azurerm provider and backend at the same subscription and
+// at Entra ID (not account keys) for blob access
+func (p *Provider) CredentialEnv(ctx context.Context) ([]string, error) {
+	env := []string{"ARM_STORAGE_USE_AZUREAD=true", "ARM_USE_AZUREAD=true"}
+	if p.subscription != "" {
+		env = append(env, "ARM_SUBSCRIPTION_ID="+p.subscription)
+	}
+	return env, nil
+}
+
+func (p *Provider) ValidateProjectName(projectID string) error {
+	return p.naming.ValidateProjectID(projectID)
+}
+
+func (p *Provider) GetStorageName() string {
+	return p.ContainerName
+}
+
+func (p *Provider) GetProjectName() string {
+	return p.projectID
+}
+
+func (p *Provider) SetStorageName(name string) {
+	p.ContainerName = name
+}
+
+func (p *Provider) SetProjectName(name string) {
+	p.projectID = name
+}
+
+func (p *Provider) GetRegion() string {
+	return p.location
+}
+
+// InitProject creates the project's container in the storage account
+func (p *Provider) InitProject(ctx context.Context, projectID string) error {
+	containerName := p.naming.GenerateStorageName(projectID)
+	_, err := p.Client.CreateContainer(ctx, containerName, nil)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.ContainerAlreadyExists) {
+			p.projectID = projectID
+			p.ContainerName = containerName
+			fmt.Printf("✅ Project already initialized: %s\n", projectID)
+			return nil
+		}
+		return &models.ProviderError{
+			Provider:  "azure",
+			Operation: "init",
+			Resource:  fmt.Sprintf("%s/%s", p.AccountName, containerName),
+			Cause:     fmt.Errorf("failed to create container: %w", err),
+		}
+	}
+	fmt.Println("✅ Project initialized:", projectID)
+	p.projectID = projectID
+	p.ContainerName = containerName
+	return nil
+}
@@ -0,0 +1,374 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// SaveConfig saves a mock configuration to the project container
+func (p *Provider) SaveConfig(ctx context.Context, config *models.MockConfiguration) error {
+	// Validate configuration
+	if err := models.ValidateConfiguration(config); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Derive the stored responses and companions; refuse what MockServer would not serve
+	if err := config.Prepare(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Set metadata
+	cleanProjectID := p.naming.ExtractProjectID(p.projectID)
+	config.Metadata.ProjectID = cleanProjectID
+	config.Metadata.UpdatedAt = time.Now()
+	if config.Metadata.CreatedAt.IsZero() {
+		config.Metadata.CreatedAt = time.Now()
+	}
+	if config.Metadata.Version == "" {
+		config.Metadata.Version = fmt.Sprintf("v%d", time.Now().Unix())
+	}
+
+	jsonData, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+	config.Metadata.Size = int64(len(jsonData))
+
+	// Save current version
+	key := fmt.Sprintf("configs/%s/current.json", cleanProjectID)
+	if err := p.putObject(ctx, key, jsonData, "application/json"); err != nil {
+		return fmt.Errorf("failed to save current config: %w", err)
+	}
+
+	// What the deployed config-sync sidecar loads into MockServer
+	servedKey := fmt.Sprintf("configs/%s/mockserver.json", cleanProjectID)
+	if err := p.putObject(ctx, servedKey, []byte(models.MockServerOnlyJSON(config.Expectations)), "application/json"); err != nil {
+		return fmt.Errorf("failed to save served expectations: %w", err)
+	}
+
+	// Save versioned copy
+	versionKey := fmt.Sprintf("configs/%s/versions/%s.json", cleanProjectID, config.Metadata.Version)
+	if err := p.putObject(ctx, versionKey, jsonData, "application/json"); err != nil {
+		fmt.Printf("Warning: failed to save version %s: %v\n", config.Metadata.Version, err)
+	}
+
+	// Save metadata index
+	if err := p.putJSON(ctx, fmt.Sprintf("metadata/%s.json", cleanProjectID), config.Metadata); err != nil {
+		fmt.Printf("Warning: failed to update metadata index: %v\n", err)
+	}
+
+	return nil
+}
+
+// GetConfig retrieves the current mock configuration
+func (p *Provider) GetConfig(ctx context.Context, projectID string) (*models.MockConfiguration, error) {
+	cleanProjectID := p.naming.ExtractProjectID(projectID)
+	var config models.MockConfiguration
+	if err := p.getJSON(ctx, fmt.Sprintf("configs/%s/current.json", cleanProjectID), &config); err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return nil, &models.ConfigNotFoundError{ProjectID: cleanProjectID, Cause: err}
+		}
+		return nil, fmt.Errorf("failed to get config from blob storage: %w", err)
+	}
+	return &config, nil
+}
+
+// UpdateConfig updates an existing configuration
+func (p *Provider) UpdateConfig(ctx context.Context, config *models.MockConfiguration) error {
+	// Get existing config to preserve creation time and the deployed baseline
+	existing, err := p.GetConfig(ctx, config.Metadata.ProjectID)
+	if err == nil {
+		config.Metadata.CreatedAt = existing.Metadata.CreatedAt
+		if config.Metadata.DeployedVersion == "" {
+			config.Metadata.DeployedVersion = existing.Metadata.DeployedVersion
+		}
+		if config.Metadata.ContractVersion == "" {
+			config.Metadata.ContractVersion = existing.Metadata.ContractVersion
+		}
+	}
+
+	config.Metadata.Version = fmt.Sprintf("v%d", time.Now().Unix())
+	return p.SaveConfig(ctx, config)
+}
+
+// DeleteProject removes ONLY mockserver blobs for the given project. When no load test
+// artifacts or deployments remain, the Terraform state and the container go too.
+func (p *Provider) DeleteProject(projectID string) error {
+	cleanProjectID := p.naming.ExtractProjectID(projectID)
+	ctx := context.Background()
+
+	mockPrefix := fmt.Sprintf("configs/%s/", cleanProjectID)
+	mockMetaKey := fmt.Sprintf("metadata/%s.json", cleanProjectID)
+	p.deletePrefix(ctx, mockPrefix)
+	p.deleteKey(ctx, mockMetaKey)
+
+	// Deployment metadata stays: it signals a deployed stack, and removing it without
+	// destroying infra would cause drift
+	ltConfigsExist := p.prefixExists(ctx, strings.TrimSuffix(p.naming.LoadTestBundlesPrefix(cleanProjectID), "bundles/")) ||
+		p.objectExists(ctx, p.naming.LoadTestMetadataKey(cleanProjectID))
+	mockDeployed := p.objectExists(ctx, deploymentMetadataKey)
+	loadtestDeployed := p.objectExists(ctx, loadTestMetadataKey)
+	mockArtifactsExist := p.prefixExists(ctx, mockPrefix) || p.objectExists(ctx, mockMetaKey)
+
+	if !mockArtifactsExist && !mockDeployed && !ltConfigsExist && !loadtestDeployed {
+		p.deletePrefix(ctx, "terraform/state/")
+		p.deletePrefix(ctx, "terraform/loadtest/state/")
+
+		if !p.prefixExists(ctx, "") {
+			if _, err := p.Client.DeleteContainer(ctx, p.ContainerName, nil); err != nil {
+				return fmt.Errorf("delete container: %w", err)
+			}
+			fmt.Printf("✅ Project %q deleted (container removed)\n", cleanProjectID)
+			return nil
+		}
+	}
+
+	fmt.Printf("✅ Project %q mock data deleted (terraform/container retained: other context active or deployed)\n", cleanProjectID)
+	return nil
+}
+
+// SaveVersion saves a specific version of a configuration
+func (p *Provider) SaveVersion(ctx context.Context, config *models.MockConfiguration, version string) error {
+	cleanProjectID := p.naming.ExtractProjectID(config.Metadata.ProjectID)
+	return p.putJSON(ctx, fmt.Sprintf("configs/%s/versions/%s.json", cleanProjectID, version), config)
+}
+
+// GetVersion retrieves a specific version of a configuration
+func (p *Provider) GetVersion(ctx context.Context, projectID, version string) (*models.MockConfiguration, error) {
+	cleanProjectID := p.naming.ExtractProjectID(projectID)
+	var config models.MockConfiguration
+	if err := p.getJSON(ctx, fmt.Sprintf("configs/%s/versions/%s.json", cleanProjectID, version), &config); err != nil {
+		return nil, fmt.Errorf("failed to get version %s from blob storage: %w", version, err)
+	}
+	return &config, nil
+}
+
+// ListVersions retrieves version history for a project
+func (p *Provider) ListVersions(ctx context.Context, projectID string) ([]models.VersionInfo, error) {
+	cleanProjectID := p.naming.ExtractProjectID(projectID)
+	items, err := p.listObjects(ctx, fmt.Sprintf("configs/%s/versions/", cleanProjectID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list versions: %w", err)
+	}
+
+	var versions []models.VersionInfo
+	for _, item := range items {
+		parts := strings.Split(item.Key, "/")
+		if len(parts) >= 4 {
+			versions = append(versions, models.VersionInfo{
+				Version:   strings.TrimSuffix(parts[3], ".json"),
+				CreatedAt: item.LastModified,
+				Size:      item.Size,
+			})
+		}
+	}
+	return versions, nil
+}
+
+// DeleteVersion permanently removes a version snapshot. Previous blob versions, when the
+// account keeps them, are left to its lifecycle policy.
+func (p *Provider) DeleteVersion(ctx context.Context, projectID, version string) (int, error) {
+	cleanProjectID := p.naming.ExtractProjectID(projectID)
+	deleted := p.deleteKey(ctx, fmt.Sprintf("configs/%s/versions/%s.json", cleanProjectID, version))
+	if deleted == 0 {
+		return 0, fmt.Errorf("version %s not found or could not be deleted", version)
+	}
+	return deleted, nil
+}
+
+// ListProjects lists the project containers in the storage account
+func (p *Provider) ListProjects(ctx context.Context) ([]models.ProjectInfo, error) {
+	fmt.Println("✅ Checking existence of projects")
+	names, err := p.listContainers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	var projects []models.ProjectInfo
+	for _, name := range names {
+		projectID := p.naming.ExtractProjectID(name)
+		if projectID == "" {
+			continue
+		}
+		projects = append(projects, models.ProjectInfo{
+			ProjectID:   projectID,
+			DisplayName: projectID,
+			StorageName: name,
+		})
+	}
+	return projects, nil
+}
+
+// ProjectExists checks if a project exists
+func (p *Provider) ProjectExists(ctx context.Context, projectID string) (bool, error) {
+	fmt.Printf("✅ Checking existence of project: %s\n", projectID)
+	names, err := p.listContainers(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, name := range names {
+		if p.naming.ExtractProjectID(name) == projectID {
+			p.ContainerName = name
+			p.projectID = projectID
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetMetadata retrieves metadata for a project
+func (p *Provider) GetMetadata(ctx context.Context, projectID string) (*models.ConfigMetadata, error) {
+	cleanProjectID := p.naming.ExtractProjectID(projectID)
+
+	var metadata models.ConfigMetadata
+	if err := p.getJSON(ctx, fmt.Sprintf("metadata/%s.json", cleanProjectID), &metadata); err == nil {
+		return &metadata, nil
+	}
+
+	// Fall back to reading the actual config
+	config, err := p.GetConfig(ctx, cleanProjectID)
+	if err != nil {
+		return nil, err
+	}
+	return &config.Metadata, nil
+}
+
+// Helper methods
+
+// objectInfo is the listing view of a blob
+type objectInfo struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+func (p *Provider) putObject(ctx context.Context, key string, data []byte, contentType string) error {
+	_, err := p.Client.UploadBuffer(ctx, p.ContainerName, key, data, &azblob.UploadBufferOptions{
+		HTTPHeaders: &blob.HTTPHeaders{BlobContentType: to.Ptr(contentType)},
+	})
+	return err
+}
+
+func (p *Provider) putJSON(ctx context.Context, key string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return p.putObject(ctx, key, data, "application/json")
+}
+
+func (p *Provider) getObject(ctx context.Context, key string) ([]byte, error) {
+	resp, err := p.Client.DownloadStream(ctx, p.ContainerName, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+func (p *Provider) getJSON(ctx context.Context, key string, v any) error {
+	data, err := p.getObject(ctx, key)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func (p *Provider) objectExists(ctx context.Context, key string) bool {
+	_, err := p.containerClient().NewBlobClient(key).GetProperties(ctx, nil)
+	return err == nil
+}
+
+func (p *Provider) prefixExists(ctx context.Context, prefix string) bool {
+	maxResults := int32(1)
+	pager := p.containerClient().NewListBlobsFlatPager(&container.ListBlobsFlatOptions{Prefix: &prefix, MaxResults: &maxResults})
+	page, err := pager.NextPage(ctx)
+	return err == nil && page.Segment != nil && len(page.Segment.BlobItems) > 0
+}
+
+func (p *Provider) listObjects(ctx context.Context, prefix string) ([]objectInfo, error) {
+	var out []objectInfo
+	pager := p.Client.NewListBlobsFlatPager(p.ContainerName, &azblob.ListBlobsFlatOptions{Prefix: &prefix})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if page.Segment == nil {
+			continue
+		}
+		for _, item := range page.Segment.BlobItems {
+			if item.Name == nil {
+				continue
+			}
+			info := objectInfo{Key: *item.Name}
+			if item.Properties != nil {
+				if item.Properties.ContentLength != nil {
+					info.Size = *item.Properties.ContentLength
+				}
+				if item.Properties.LastModified != nil {
+					info.LastModified = *item.Properties.LastModified
+				}
+			}
+			out = append(out, info)
+		}
+	}
+	return out, nil
+}
+
+func (p *Provider) listContainers(ctx context.Context) ([]string, error) {
+	prefix := p.naming.GetPrefix()
+	var names []string
+	pager := p.Client.NewListContainersPager(&azblob.ListContainersOptions{Prefix: &prefix})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range page.ContainerItems {
+			if c.Name != nil {
+				names = append(names, *c.Name)
+			}
+		}
+	}
+	return names, nil
+}
+
+// deleteKey deletes a blob with its snapshots and returns 1 when it existed
+func (p *Provider) deleteKey(ctx context.Context, key string) int {
+	_, err := p.Client.DeleteBlob(ctx, p.ContainerName, key, &azblob.DeleteBlobOptions{
+		DeleteSnapshots: to.Ptr(azblob.DeleteSnapshotsOptionTypeInclude),
+	})
+	if err != nil {
+		return 0
+	}
+	return 1
+}
+
+// deletePrefix deletes every blob under a prefix and returns how many were removed
+func (p *Provider) deletePrefix(ctx context.Context, prefix string) int {
+	items, err := p.listObjects(ctx, prefix)
+	if err != nil {
+		return 0
+	}
+	deleted := 0
+	for _, item := range items {
+		deleted += p.deleteKey(ctx, item.Key)
+	}
+	return deleted
+}
+
+func (p *Provider) containerClient() *container.Client {
+	return p.Client.ServiceClient().NewContainerClient(p.ContainerName)
+}
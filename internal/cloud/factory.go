@@ -3,12 +3,21 @@ package cloud
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/hemantobora/auto-mock/internal"
 	"github.com/hemantobora/auto-mock/internal/cloud/aws"
+	"github.com/hemantobora/auto-mock/internal/cloud/azure"
+	"github.com/hemantobora/auto-mock/internal/cloud/git"
+	"github.com/hemantobora/auto-mock/internal/cloud/local"
 	"github.com/hemantobora/auto-mock/internal/cloud/naming"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/network"
 )
 
+// EnvCloud pins the cloud AutoDetectProvider uses ("aws", "azure", "local" or "git"); the global --cloud flag sets it
+const EnvCloud = "AUTOMOCK_CLOUD"
+
 // Factory creates storage providers based on configuration
 type Factory struct {
 	naming internal.NamingStrategy
@@ -22,7 +31,7 @@ func NewFactory() *Factory {
 }
 
 // CreateProvider creates a storage provider for the specified type
-// Supported types: "aws", "gcp", "azure"
+// Supported types: "aws", "gcp", "azure", "local", "git"
 func (f *Factory) CreateProvider(ctx context.Context, providerType string, options ...Option) (internal.Provider, error) {
 	// Apply options
 	opts := &factoryOptions{
@@ -38,7 +47,11 @@ func (f *Factory) CreateProvider(ctx context.Context, providerType string, optio
 	case "gcp":
 		return nil, fmt.Errorf("GCP storage provider not yet implemented")
 	case "azure":
-		return nil, fmt.Errorf("Azure storage provider not yet implemented")
+		return azure.NewProvider(ctx, azure.WithProfile(opts.profile))
+	case local.ProviderType:
+		return local.NewProvider()
+	case git.ProviderType:
+		return git.NewProvider(ctx)
 	default:
 		return nil, fmt.Errorf("unsupported provider type: %s", providerType)
 	}
@@ -63,20 +76,86 @@ func WithProfile(profile string) Option {
 }
 
 // AutoDetectProvider attempts to detect available storage providers
-// Returns the first available provider type
+// Returns the first available provider type, or the one pinned by AUTOMOCK_CLOUD
 func (f *Factory) AutoDetectProvider(ctx context.Context, profile string) (internal.Provider, error) {
+	// The local and git stores need neither the network nor credentials
+	if pinned := os.Getenv(EnvCloud); IsLocalStore(pinned) {
+		return f.CreateProvider(ctx, pinned)
+	}
+	if err := network.Require("the project store"); err != nil {
+		return nil, exitcode.WithHint(exitcode.Network, err,
+			"Offline, 'automock init' writes expectations to a local file, and serve, match and validate take --file")
+	}
+	switch pinned := os.Getenv(EnvCloud); pinned {
+	case "":
+	case "aws", "azure":
+		return f.createPinnedProvider(ctx, pinned, profile)
+	default:
+		return nil, exitcode.New(exitcode.Config, "❌ Unsupported cloud %q in %s (expected aws, azure, local or git)", pinned, EnvCloud)
+	}
+
 	// Try AWS
 	var available []internal.Provider
-	if _, err := aws.ValidateCredentials(ctx, profile); err == nil {
+	_, awsErr := aws.ValidateCredentials(ctx, profile)
+	if awsErr == nil {
 		provider, _ := aws.NewProvider(ctx, aws.WithProfile(profile))
 		available = append(available, provider)
+	} else if network.IsNetworkError(awsErr) {
+		return nil, unreachable("AWS", awsErr)
+	} else if role := os.Getenv(aws.EnvAssumeRole); role != "" {
+		return nil, exitcode.New(exitcode.Auth, "❌ Could not assume role %s: %w", role, awsErr)
 	}
 
 	// TODO: Try GCP
-	// TODO: Try Azure
+
+	// Try Azure (only when a storage account is configured)
+	if len(available) == 0 && os.Getenv(azure.EnvStorageAccount) != "" {
+		_, err := azure.ValidateCredentials(ctx, profile)
+		if err == nil {
+			provider, _ := azure.NewProvider(ctx, azure.WithProfile(profile))
+			available = append(available, provider)
+		} else if network.IsNetworkError(err) {
+			return nil, unreachable("Azure", err)
+		}
+	}
 
 	if len(available) == 0 {
-		return nil, fmt.Errorf("❌ No valid cloud provider credentials found. Please configure AWS, GCP, or Azure credentials. (Currently, only AWS is supported, other providers are coming soon!)")
+		return nil, exitcode.WithHint(exitcode.Auth,
+			fmt.Errorf("❌ No valid cloud provider credentials found. Please configure AWS credentials, or Azure credentials with %s set. (GCP is coming soon!)", azure.EnvStorageAccount),
+			"To work without a cloud account, pass --cloud local to keep projects under ~/.automock")
 	}
 	return available[0], nil
 }
+
+// IsLocalStore reports whether a provider type keeps projects on this machine, where they are
+// served rather than deployed
+func IsLocalStore(providerType string) bool {
+	return providerType == local.ProviderType || providerType == git.ProviderType
+}
+
+// createPinnedProvider validates the credentials of the cloud chosen with --cloud and
+// reports why they do not work instead of falling back to another cloud
+func (f *Factory) createPinnedProvider(ctx context.Context, cloud, profile string) (internal.Provider, error) {
+	switch cloud {
+	case "azure":
+		if _, err := azure.ValidateCredentials(ctx, profile); network.IsNetworkError(err) {
+			return nil, unreachable("Azure", err)
+		} else if err != nil {
+			return nil, exitcode.New(exitcode.Auth, "❌ Azure credentials are not usable: %w", err)
+		}
+	default:
+		if _, err := aws.ValidateCredentials(ctx, profile); network.IsNetworkError(err) {
+			return nil, unreachable("AWS", err)
+		} else if err != nil {
+			return nil, exitcode.New(exitcode.Auth, "❌ AWS credentials are not usable: %w", err)
+		}
+	}
+	return f.CreateProvider(ctx, cloud, WithProfile(profile))
+}
+
+// unreachable reports a cloud that did not answer within --timeout, which says nothing
+// about the credentials
+func unreachable(cloud string, err error) error {
+	return exitcode.WithHint(exitcode.Network, fmt.Errorf("❌ Could not reach %s: %w", cloud, err),
+		"Check your network or VPN, raise --timeout, or pass --offline to work from local files")
+}
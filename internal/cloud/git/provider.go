@@ -0,0 +1,170 @@
+// Package git provides a provider that keeps expectations in a Git working tree, so mock
+// changes are reviewed through pull requests like any other code. Each project is one file,
+// <path>/<project>/expectations.json; every save commits it with a message summarizing the
+// change, and the version history is the file's git log. Load-test bundles and deployment
+// records stay in the local store (see package local), outside the repository.
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/hemantobora/auto-mock/internal/cloud/local"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// EnvPath is the directory holding the projects, relative to the top of the repository
+// containing the current directory unless absolute; the global --git-path flag sets it
+const EnvPath = "AUTOMOCK_GIT_PATH"
+
+// DefaultPath is used when EnvPath is not set
+const DefaultPath = "mocks"
+
+// ProviderType is the --cloud value selecting the git store
+const ProviderType = "git"
+
+// fileName is the file holding a project's current configuration
+const fileName = "expectations.json"
+
+// Provider keeps each project's configuration in a file of a Git working tree
+type Provider struct {
+	*local.Provider // load-test bundles and deployment records
+
+	Path string // directory holding <project>/expectations.json
+}
+
+// ProviderOption is a functional option for provider configuration
+type ProviderOption func(*providerOptions)
+
+type providerOptions struct {
+	path  string
+	local []local.ProviderOption
+}
+
+// WithPath stores projects under path instead of EnvPath or DefaultPath
+func WithPath(path string) ProviderOption {
+	return func(o *providerOptions) {
+		o.path = path
+	}
+}
+
+// WithLocalOptions configures the local store kept for load-test bundles
+func WithLocalOptions(options ...local.ProviderOption) ProviderOption {
+	return func(o *providerOptions) {
+		o.local = append(o.local, options...)
+	}
+}
+
+// NewProvider creates a provider on the repository holding the configured path
+func NewProvider(ctx context.Context, options ...ProviderOption) (*Provider, error) {
+	opts := &providerOptions{path: os.Getenv(EnvPath)}
+	for _, opt := range options {
+		opt(opts)
+	}
+	if opts.path == "" {
+		opts.path = DefaultPath
+	}
+	fail := func(resource string, err error) error {
+		return exitcode.WithHint(exitcode.Config,
+			&models.ProviderError{Provider: ProviderType, Operation: "load-config", Resource: resource, Cause: err},
+			"Run automock inside the repository that holds the mocks, or set --git-path to an absolute directory in one")
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, exitcode.New(exitcode.Config, "--cloud git needs git on the PATH: %w", err)
+	}
+
+	path := opts.path
+	if !filepath.IsAbs(path) {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, fail(path, err)
+		}
+		top, err := run(ctx, wd, "rev-parse", "--show-toplevel")
+		if err != nil {
+			return nil, fail(path, fmt.Errorf("the current directory is not in a git repository: %w", err))
+		}
+		path = filepath.Join(strings.TrimSpace(top), path)
+	} else if _, err := run(ctx, existingParent(path), "rev-parse", "--show-toplevel"); err != nil {
+		return nil, fail(path, fmt.Errorf("%s is not in a git repository: %w", path, err))
+	}
+
+	store, err := local.NewProvider(opts.local...)
+	if err != nil {
+		return nil, err
+	}
+	return &Provider{Provider: store, Path: filepath.Clean(path)}, nil
+}
+
+// GetProviderType returns the provider type
+func (p *Provider) GetProviderType() string {
+	return ProviderType
+}
+
+// GetStorageName returns the directory of the current project in the repository
+func (p *Provider) GetStorageName() string {
+	if p.GetProjectName() == "" {
+		return p.Path
+	}
+	return p.projectDir(p.GetProjectName())
+}
+
+// InitProject creates the project's directory; it is committed with the first configuration
+func (p *Provider) InitProject(ctx context.Context, projectID string) error {
+	if err := p.ValidateProjectName(projectID); err != nil {
+		return err
+	}
+	dir := p.projectDir(projectID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return &models.ProviderError{
+			Provider:  ProviderType,
+			Operation: "init",
+			Resource:  dir,
+			Cause:     fmt.Errorf("failed to create project directory: %w", err),
+		}
+	}
+	fmt.Printf("✅ Project initialized: %s (%s)\n", projectID, dir)
+	p.SetProjectName(projectID)
+	return nil
+}
+
+// projectDir returns the directory of a project in the repository
+func (p *Provider) projectDir(projectID string) string {
+	return filepath.Join(p.Path, filepath.Base(projectID))
+}
+
+// run runs git in dir and returns its standard output
+func run(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return string(out), nil
+}
+
+// existingParent returns path or its nearest ancestor that exists
+func existingParent(path string) string {
+	for {
+		if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+			return path
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return path
+		}
+		path = parent
+	}
+}
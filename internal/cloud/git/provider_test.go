@@ -0,0 +1,138 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/cloud/local"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// newTestProvider creates a provider on a fresh repository
+func newTestProvider(t *testing.T) (*Provider, string) {
+	t.Helper()
+	for _, name := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(name, "automock")
+	}
+	for _, name := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(name, "automock@example.com")
+	}
+	repo := t.TempDir()
+	if out, err := exec.Command("git", "-C", repo, "init", "--quiet").CombinedOutput(); err != nil {
+		t.Skipf("git init: %v: %s", err, out)
+	}
+	p, err := NewProvider(context.Background(), WithPath(filepath.Join(repo, "mocks")), WithLocalOptions(local.WithRoot(t.TempDir())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p, repo
+}
+
+func sampleConfig(paths ...string) *models.MockConfiguration {
+	config := &models.MockConfiguration{Metadata: models.ConfigMetadata{ProjectID: "users"}}
+	for _, path := range paths {
+		config.Expectations = append(config.Expectations, models.MockExpectation{
+			HttpRequest:  &models.HttpRequest{Method: "GET", Path: path},
+			HttpResponse: &models.HttpResponse{StatusCode: 200},
+		})
+	}
+	return config
+}
+
+func gitOutput(t *testing.T, repo string, args ...string) string {
+	t.Helper()
+	out, err := exec.Command("git", append([]string{"-C", repo}, args...)...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v: %s", args, err, out)
+	}
+	return string(out)
+}
+
+func TestSavesAreCommits(t *testing.T) {
+	ctx := context.Background()
+	p, repo := newTestProvider(t)
+
+	if err := p.InitProject(ctx, "users"); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.SaveConfig(ctx, sampleConfig("/users")); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.UpdateConfig(ctx, sampleConfig("/users", "/orders")); err != nil {
+		t.Fatal(err)
+	}
+
+	log := gitOutput(t, repo, "log", "--format=%B")
+	for _, want := range []string{
+		"automock: add users mocks (1 expectation(s))",
+		"automock: update users mocks (1 added, 0 removed, 0 modified endpoint(s))",
+		"- GET /orders: endpoint added",
+		"Automock-Version: v",
+	} {
+		if !strings.Contains(log, want) {
+			t.Errorf("log is missing %q:\n%s", want, log)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(repo, "mocks", "users", "expectations.json")); err != nil {
+		t.Error(err)
+	}
+
+	versions, err := p.ListVersions(ctx, "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 || !strings.HasPrefix(versions[0].Description, "automock: add") {
+		t.Fatalf("versions = %+v", versions)
+	}
+
+	// An update that changes nothing keeps the version and commits nothing
+	if err := p.UpdateConfig(ctx, sampleConfig("/users", "/orders")); err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := p.ListVersions(ctx, "users"); len(again) != 2 || again[1].Version != versions[1].Version {
+		t.Errorf("versions after an unchanged update = %+v", again)
+	}
+
+	first, err := p.GetVersion(ctx, "users", versions[0].Version)
+	if err != nil || len(first.Expectations) != 1 {
+		t.Errorf("first version = %+v, %v", first, err)
+	}
+
+	// A hand edit is a version named by its commit
+	file := filepath.Join(repo, "mocks", "users", "expectations.json")
+	data, _ := os.ReadFile(file)
+	os.WriteFile(file, []byte(strings.Replace(string(data), "/orders", "/invoices", 1)), 0644)
+	gitOutput(t, repo, "commit", "--quiet", "-am", "Rename orders mock")
+	head := strings.TrimSpace(gitOutput(t, repo, "rev-parse", "HEAD"))
+	versions, _ = p.ListVersions(ctx, "users")
+	if len(versions) != 3 || versions[2].Version != head[:12] || versions[2].Description != "Rename orders mock" {
+		t.Errorf("versions after a hand edit = %+v", versions)
+	}
+	edited, err := p.GetVersion(ctx, "users", head[:12])
+	if err != nil || edited.Expectations[1].HttpRequest.Path != "/invoices" {
+		t.Errorf("hand-edited version = %+v, %v", edited, err)
+	}
+
+	// Saving unchanged configuration makes no commit
+	current, _ := p.GetConfig(ctx, "users")
+	if err := p.SaveConfig(ctx, current); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(gitOutput(t, repo, "rev-parse", "HEAD")); got != head {
+		t.Error("an unchanged save made a commit")
+	}
+
+	if _, err := p.DeleteVersion(ctx, "users", versions[0].Version); err == nil {
+		t.Error("deleted a commit")
+	}
+	if err := p.DeleteProject("users"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(gitOutput(t, repo, "log", "-1", "--format=%s"), "delete users mocks") {
+		t.Error("deletion was not committed")
+	}
+}
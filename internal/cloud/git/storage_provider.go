@@ -0,0 +1,348 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/cloud/local"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// trailerKey names the commit trailer recording the configuration version a commit saved
+const trailerKey = "Automock-Version"
+
+// SaveConfig writes a mock configuration to the project's file and commits it
+func (p *Provider) SaveConfig(ctx context.Context, config *models.MockConfiguration) error {
+	// Validate configuration
+	if err := models.ValidateConfiguration(config); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Derive the stored responses and companions; refuse what MockServer would not serve
+	if err := config.Prepare(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Set metadata
+	projectID := p.GetProjectName()
+	if projectID == "" {
+		projectID = config.Metadata.ProjectID
+	}
+	if projectID == "" {
+		return fmt.Errorf("no project selected to save the configuration to")
+	}
+	config.Metadata.ProjectID = projectID
+	config.Metadata.UpdatedAt = time.Now()
+	if config.Metadata.CreatedAt.IsZero() {
+		config.Metadata.CreatedAt = time.Now()
+	}
+	if config.Metadata.Version == "" {
+		config.Metadata.Version = fmt.Sprintf("v%d", time.Now().Unix())
+	}
+
+	previous, _ := p.GetConfig(ctx, projectID)
+	if previous != nil && sameContent(previous, config) {
+		// Keep the file, and so the history, untouched by a save that changes nothing
+		config.Metadata.UpdatedAt = previous.Metadata.UpdatedAt
+	}
+	return p.write(ctx, projectID, config, commitMessage(projectID, previous, config))
+}
+
+// GetConfig reads the project's file as it is in the working tree
+func (p *Provider) GetConfig(ctx context.Context, projectID string) (*models.MockConfiguration, error) {
+	data, err := os.ReadFile(filepath.Join(p.projectDir(projectID), fileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, &models.ConfigNotFoundError{ProjectID: projectID, Cause: err}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config from the git store: %w", err)
+	}
+	var config models.MockConfiguration
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s of %s: %w", fileName, projectID, err)
+	}
+	return &config, nil
+}
+
+// UpdateConfig updates an existing configuration
+func (p *Provider) UpdateConfig(ctx context.Context, config *models.MockConfiguration) error {
+	// Get existing config to preserve creation time and the deployed baseline
+	existing, err := p.GetConfig(ctx, config.Metadata.ProjectID)
+	if err == nil {
+		config.Metadata.CreatedAt = existing.Metadata.CreatedAt
+		if config.Metadata.DeployedVersion == "" {
+			config.Metadata.DeployedVersion = existing.Metadata.DeployedVersion
+		}
+		if config.Metadata.ContractVersion == "" {
+			config.Metadata.ContractVersion = existing.Metadata.ContractVersion
+		}
+	}
+
+	// A save that changes nothing keeps the current version, so SaveConfig commits nothing
+	if existing != nil && config.Prepare() == nil {
+		config.Metadata.Version = existing.Metadata.Version
+		if sameContent(existing, config) {
+			return p.SaveConfig(ctx, config)
+		}
+	}
+	config.Metadata.Version = local.NextVersion(existing)
+	return p.SaveConfig(ctx, config)
+}
+
+// DeleteProject removes the project's file in a commit of its own. History keeps every
+// version, and load-test artifacts stay in the local store.
+func (p *Provider) DeleteProject(projectID string) error {
+	ctx := context.Background()
+	dir := p.projectDir(projectID)
+	_, tracked := run(ctx, dir, "ls-files", "--error-unmatch", "--", fileName)
+	if tracked == nil {
+		// --cached keeps the directory, which git rm would take along, for the commit below
+		if _, err := run(ctx, dir, "rm", "--quiet", "--cached", "--", fileName); err != nil {
+			return fmt.Errorf("delete %s: %w", projectID, err)
+		}
+	}
+	if err := os.Remove(filepath.Join(dir, fileName)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("delete %s: %w", projectID, err)
+	}
+	if tracked == nil {
+		if _, err := run(ctx, dir, "commit", "--quiet", "-m", fmt.Sprintf("automock: delete %s mocks", projectID), "--", fileName); err != nil {
+			return fmt.Errorf("commit the deletion of %s: %w", projectID, err)
+		}
+	}
+	os.Remove(dir) // only when empty
+	fmt.Printf("✅ Project %q deleted from %s (its history stays in git log)\n", projectID, p.Path)
+	return nil
+}
+
+// SaveVersion commits config as the given version, so an imported history is replayed as
+// commits; the file holds the last version saved until the current configuration follows
+func (p *Provider) SaveVersion(ctx context.Context, config *models.MockConfiguration, version string) error {
+	projectID := config.Metadata.ProjectID
+	snapshot := *config
+	snapshot.Metadata.Version = version
+	message := fmt.Sprintf("automock: import %s mocks at %s\n\n%s: %s\n", projectID, version, trailerKey, version)
+	return p.write(ctx, projectID, &snapshot, message)
+}
+
+// GetVersion reads the project's file as committed for a version: the commit whose trailer
+// names it, or any git revision (commit hash, tag, branch)
+func (p *Provider) GetVersion(ctx context.Context, projectID, version string) (*models.MockConfiguration, error) {
+	if version == "" || strings.HasPrefix(version, "-") {
+		return nil, fmt.Errorf("invalid version %q", version)
+	}
+	dir := p.projectDir(projectID)
+	rev := version
+	pattern := fmt.Sprintf("^%s: %s$", trailerKey, regexp.QuoteMeta(version))
+	if out, err := run(ctx, dir, "log", "-1", "--format=%H", "--extended-regexp", "--grep", pattern, "--", fileName); err == nil && strings.TrimSpace(out) != "" {
+		rev = strings.TrimSpace(out)
+	}
+	data, err := run(ctx, dir, "show", rev+":./"+fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get version %s from the git store: %w", version, err)
+	}
+	var config models.MockConfiguration
+	if err := json.Unmarshal([]byte(data), &config); err != nil {
+		return nil, fmt.Errorf("failed to parse version %s of %s: %w", version, projectID, err)
+	}
+	return &config, nil
+}
+
+// ListVersions lists the commits of the project's file. A commit made by automock is named
+// by its version trailer, any other (a hand edit merged through review) by its short hash.
+func (p *Provider) ListVersions(ctx context.Context, projectID string) ([]models.VersionInfo, error) {
+	dir := p.projectDir(projectID)
+	if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	out, err := run(ctx, dir, "log", "--diff-filter=AM", "--format=%H%x1f%cI%x1f%B%x1e", "--", fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list versions: %w", err)
+	}
+	var versions []models.VersionInfo
+	for _, record := range strings.Split(out, "\x1e") {
+		fields := strings.SplitN(strings.TrimSpace(record), "\x1f", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		created, _ := time.Parse(time.RFC3339, fields[1])
+		subject, _, _ := strings.Cut(fields[2], "\n")
+		versions = append(versions, models.VersionInfo{
+			Version:     versionOf(fields[0], fields[2]),
+			CreatedAt:   created,
+			Description: subject,
+		})
+	}
+	// git log lists newest first; the other stores list oldest first
+	for i, j := 0, len(versions)-1; i < j; i, j = i+1, j-1 {
+		versions[i], versions[j] = versions[j], versions[i]
+	}
+	return versions, nil
+}
+
+// versionOf returns the version trailer of a commit message, else the short commit hash
+func versionOf(hash, message string) string {
+	for _, line := range strings.Split(message, "\n") {
+		if v, ok := strings.CutPrefix(strings.TrimSpace(line), trailerKey+": "); ok && v != "" {
+			return v
+		}
+	}
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
+
+// DeleteVersion refuses: versions are commits, and the store never rewrites history
+func (p *Provider) DeleteVersion(ctx context.Context, projectID, version string) (int, error) {
+	return 0, fmt.Errorf("version %s is a git commit; the git store never rewrites history", version)
+}
+
+// ListProjects lists the project directories holding an expectations file
+func (p *Provider) ListProjects(ctx context.Context) ([]models.ProjectInfo, error) {
+	fmt.Println("✅ Checking existence of projects")
+	entries, err := os.ReadDir(p.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	var projects []models.ProjectInfo
+	for _, e := range entries {
+		if !e.IsDir() || p.ValidateProjectName(e.Name()) != nil {
+			continue
+		}
+		config, err := p.GetConfig(ctx, e.Name())
+		if err != nil {
+			continue
+		}
+		projects = append(projects, models.ProjectInfo{
+			ProjectID:        e.Name(),
+			DisplayName:      e.Name(),
+			StorageName:      p.projectDir(e.Name()),
+			CreatedAt:        config.Metadata.CreatedAt,
+			UpdatedAt:        config.Metadata.UpdatedAt,
+			Provider:         ProviderType,
+			HasExpectations:  true,
+			ExpectationCount: len(config.Expectations),
+		})
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].ProjectID < projects[j].ProjectID })
+	return projects, nil
+}
+
+// ProjectExists checks if a project directory exists
+func (p *Provider) ProjectExists(ctx context.Context, projectID string) (bool, error) {
+	fmt.Printf("✅ Checking existence of project: %s\n", projectID)
+	st, err := os.Stat(p.projectDir(projectID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !st.IsDir() {
+		return false, fmt.Errorf("%s is not a directory", p.projectDir(projectID))
+	}
+	p.SetProjectName(projectID)
+	return true, nil
+}
+
+// GetMetadata retrieves metadata for a project
+func (p *Provider) GetMetadata(ctx context.Context, projectID string) (*models.ConfigMetadata, error) {
+	config, err := p.GetConfig(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return &config.Metadata, nil
+}
+
+// write saves config as the project's file and commits it with message; a save that
+// changes nothing makes no commit
+func (p *Provider) write(ctx context.Context, projectID string, config *models.MockConfiguration, message string) error {
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+	config.Metadata.Size = int64(len(data))
+
+	dir := p.projectDir(projectID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, fileName), append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to save current config: %w", err)
+	}
+	if _, err := run(ctx, dir, "add", "--", fileName); err != nil {
+		return fmt.Errorf("failed to stage %s: %w", projectID, err)
+	}
+	if _, err := run(ctx, dir, "diff", "--cached", "--quiet", "--", fileName); err == nil {
+		return nil
+	}
+	if _, err := run(ctx, dir, "commit", "--quiet", "-m", message, "--", fileName); err != nil {
+		return fmt.Errorf("failed to commit %s: %w", projectID, err)
+	}
+	return nil
+}
+
+// sameContent reports whether two configurations differ in no more than their save time
+func sameContent(a, b *models.MockConfiguration) bool {
+	x, y := *a, *b
+	x.Metadata.UpdatedAt, y.Metadata.UpdatedAt = time.Time{}, time.Time{}
+	x.Metadata.Size, y.Metadata.Size = 0, 0
+	dx, errX := json.Marshal(&x)
+	dy, errY := json.Marshal(&y)
+	return errX == nil && errY == nil && bytes.Equal(dx, dy)
+}
+
+// commitMessage summarizes a save for the log: endpoint counts in the subject, one line per
+// change in the body, and the version as a trailer
+func commitMessage(projectID string, previous, config *models.MockConfiguration) string {
+	var b strings.Builder
+	if previous == nil {
+		fmt.Fprintf(&b, "automock: add %s mocks (%d expectation(s))\n", projectID, len(config.Expectations))
+	} else {
+		diff := models.DiffVersions(previous, config)
+		kinds := map[string]string{}
+		var order []string
+		for _, c := range diff.Changes {
+			if _, seen := kinds[c.Endpoint]; !seen {
+				order = append(order, c.Endpoint)
+				kinds[c.Endpoint] = "modified"
+			}
+			if c.Summary == "endpoint added" || c.Summary == "endpoint removed" {
+				kinds[c.Endpoint] = c.Summary
+			}
+		}
+		counts := map[string]int{}
+		for _, ep := range order {
+			counts[kinds[ep]]++
+		}
+		if len(order) == 0 {
+			fmt.Fprintf(&b, "automock: update %s mocks\n", projectID)
+		} else {
+			fmt.Fprintf(&b, "automock: update %s mocks (%d added, %d removed, %d modified endpoint(s))\n",
+				projectID, counts["endpoint added"], counts["endpoint removed"], counts["modified"])
+			b.WriteString("\n")
+		}
+		for _, c := range diff.Changes {
+			fmt.Fprintf(&b, "- %s: %s", c.Endpoint, c.Summary)
+			if c.Severity == models.ChangeBreaking {
+				b.WriteString(" (breaking)")
+			}
+			b.WriteString("\n")
+		}
+	}
+	fmt.Fprintf(&b, "\n%s: %s\n", trailerKey, config.Metadata.Version)
+	return b.String()
+}
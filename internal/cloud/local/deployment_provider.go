@@ -0,0 +1,112 @@
+package local
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// Local projects are served with 'automock serve' rather than deployed, so the deployment
+// metadata below is only ever absent; it is kept file-backed for symmetry with the clouds.
+const (
+	deploymentMetadataKey = "deployment-metadata.json"
+	loadTestMetadataKey   = "deployment-metadata-loadtest.json"
+)
+
+// SaveDeploymentMetadata saves deployment metadata to the project directory
+func (p *Provider) SaveDeploymentMetadata(output *models.InfrastructureOutputs) error {
+	metadata := &models.DeploymentMetadata{
+		ProjectName:      p.projectID,
+		DeploymentStatus: "deployed",
+		DeployedAt:       time.Now().UTC(),
+		Details:          output,
+	}
+	if err := p.dir(p.projectID).putJSON(deploymentMetadataKey, metadata); err != nil {
+		return fmt.Errorf("failed to write metadata: %w", err)
+	}
+	return nil
+}
+
+// GetDeploymentMetadata retrieves deployment metadata
+func (p *Provider) GetDeploymentMetadata() (*models.DeploymentMetadata, error) {
+	var metadata models.DeploymentMetadata
+	if err := p.dir(p.projectID).getJSON(deploymentMetadataKey, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to read metadata: %w", err)
+	}
+	return &metadata, nil
+}
+
+// DeleteDeploymentMetadata removes deployment metadata
+func (p *Provider) DeleteDeploymentMetadata() error {
+	p.dir(p.projectID).remove(deploymentMetadataKey)
+	return nil
+}
+
+// IsDeployed reports whether deployment metadata says the mocks are deployed
+func (p *Provider) IsDeployed() (bool, error) {
+	if p.projectID == "" || !p.dir(p.projectID).exists(deploymentMetadataKey) {
+		return false, nil
+	}
+	metadata, err := p.GetDeploymentMetadata()
+	if err != nil {
+		return false, err
+	}
+	return metadata.DeploymentStatus == "deployed", nil
+}
+
+// SaveLoadTestDeploymentMetadata persists load test deployment metadata
+func (p *Provider) SaveLoadTestDeploymentMetadata(output *models.LoadTestDeploymentOutputs) error {
+	md := &models.LoadTestDeploymentMetadata{
+		ProjectName:      p.projectID,
+		DeploymentStatus: "deployed",
+		DeployedAt:       time.Now().UTC(),
+		Details:          output,
+	}
+	if err := p.dir(p.projectID).putJSON(loadTestMetadataKey, md); err != nil {
+		return fmt.Errorf("write loadtest metadata: %w", err)
+	}
+	return nil
+}
+
+// GetLoadTestDeploymentMetadata fetches load test deployment metadata if present
+func (p *Provider) GetLoadTestDeploymentMetadata() (*models.LoadTestDeploymentMetadata, error) {
+	var md models.LoadTestDeploymentMetadata
+	if err := p.dir(p.projectID).getJSON(loadTestMetadataKey, &md); err != nil {
+		return nil, fmt.Errorf("get loadtest metadata: %w", err)
+	}
+	return &md, nil
+}
+
+// DeleteLoadTestDeploymentMetadata removes load test deployment metadata
+func (p *Provider) DeleteLoadTestDeploymentMetadata() error {
+	p.dir(p.projectID).remove(loadTestMetadataKey)
+	return nil
+}
+
+// CreateDeploymentConfiguration returns the defaults; there is nothing to size locally
+func (p *Provider) CreateDeploymentConfiguration() *models.DeploymentOptions {
+	return p.CreateDefaultDeploymentConfiguration()
+}
+
+// CreateDefaultDeploymentConfiguration returns the options used when nothing is asked
+func (p *Provider) CreateDefaultDeploymentConfiguration() *models.DeploymentOptions {
+	return &models.DeploymentOptions{
+		InstanceSize: "small",
+		Region:       p.GetRegion(),
+		BucketName:   p.GetStorageName(),
+		ProjectName:  p.GetProjectName(),
+		Provider:     p.GetProviderType(),
+	}
+}
+
+// EstimateCost returns nil: local projects run on this machine with 'automock serve'
+func (p *Provider) EstimateCost(options *models.DeploymentOptions) *models.CostEstimate {
+	return nil
+}
+
+// ActualCosts fails: local projects have no cloud resources to bill
+func (p *Provider) ActualCosts(ctx context.Context, projectID string, from, to time.Time) (*models.CostReport, error) {
+	return nil, fmt.Errorf("project %s is stored locally and has no cloud costs", projectID)
+}
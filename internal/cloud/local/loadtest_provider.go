@@ -0,0 +1,399 @@
+package local
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	awsprovider "github.com/hemantobora/auto-mock/internal/cloud/aws"
+	"github.com/hemantobora/auto-mock/internal/loadtest"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// UploadLoadTestBundle copies a generated load test bundle directory into the project and
+// updates the pointer and version files, using the same layout as the other providers
+func (p *Provider) UploadLoadTestBundle(ctx context.Context, projectID, bundleDir string) (*models.LoadTestPointer, *models.LoadTestVersion, error) {
+	baseID := p.naming.ExtractProjectID(projectID)
+	if exists, _ := p.ProjectExists(ctx, baseID); !exists {
+		if err := p.InitProject(ctx, baseID); err != nil {
+			return nil, nil, fmt.Errorf("init project: %w", err)
+		}
+	}
+	if os.Getenv(awsprovider.EnvLoadTestKMSKey) != "" || os.Getenv(awsprovider.EnvLoadTestTags) != "" {
+		fmt.Println("⚠️  --kms-key and --object-tag apply to S3 only; the local store keeps plain files")
+	}
+	d := p.dir(baseID)
+
+	layout := loadtest.LayoutFor(loadtest.DetectTool(bundleDir))
+	required, optional := layout.Required, layout.Optional
+	found := make(map[string][]byte)
+	hashes := make(map[string]string)
+	var missing []string
+	for _, name := range append(append([]string(nil), required...), optional...) {
+		data, err := os.ReadFile(filepath.Join(bundleDir, name))
+		if err != nil {
+			if contains(required, name) {
+				missing = append(missing, name)
+			}
+			continue
+		}
+		sum := sha256.Sum256(data)
+		hashes[name] = "sha256:" + hex.EncodeToString(sum[:])
+		found[name] = data
+	}
+	if len(missing) > 0 {
+		return nil, nil, fmt.Errorf("missing required bundle files: %v", missing)
+	}
+
+	valRes, _ := loadtest.ValidateBundle(bundleDir)
+	validation := &models.LoadTestValidationResult{
+		LocustfilePresent:   layout.Tool == loadtest.ToolLocust,
+		RequirementsPresent: layout.Tool == loadtest.ToolLocust,
+		UserDataPresent:     found["user_data.yaml"] != nil,
+		ManifestPresent:     found["manifest.json"] != nil,
+		HostDefined:         valRes != nil && valRes.HostDefined,
+	}
+	if valRes != nil {
+		validation.PlaceholderErrors = valRes.PlaceholderErrors
+	}
+
+	ts := time.Now().UTC()
+	version := fmt.Sprintf("v%d", ts.Unix())
+	bundleID := fmt.Sprintf("bndl_%d", ts.UnixNano())
+
+	var fileRefs []models.LoadTestFileRef
+	for name, data := range found {
+		fileRefs = append(fileRefs, models.LoadTestFileRef{Name: name, Size: int64(len(data)), SHA256: hashes[name]})
+	}
+	sort.Slice(fileRefs, func(i, j int) bool { return fileRefs[i].Name < fileRefs[j].Name })
+	manifestWarnings := []string{}
+	if !validation.HostDefined {
+		manifestWarnings = append(manifestWarnings, layout.HostHint())
+	}
+	if len(validation.PlaceholderErrors) > 0 {
+		manifestWarnings = append(manifestWarnings, fmt.Sprintf("Found %d unresolved placeholders in user_data.yaml", len(validation.PlaceholderErrors)))
+	}
+	manifest := &models.LoadTestManifest{
+		BundleID:    bundleID,
+		ProjectID:   baseID,
+		GeneratedAt: ts,
+		Files:       fileRefs,
+		Entrypoints: []string{layout.Entrypoint},
+		Warnings:    manifestWarnings,
+	}
+
+	metrics := map[string]int{}
+	if valRes != nil {
+		metrics["tasks"] = valRes.Tasks
+		metrics["endpoints"] = valRes.Endpoints
+	}
+	versionSnap := &models.LoadTestVersion{
+		ProjectID:  baseID,
+		Version:    version,
+		BundleID:   bundleID,
+		Tool:       layout.Tool,
+		CreatedAt:  ts,
+		Hashes:     hashes,
+		Validation: validation,
+		Metrics:    metrics,
+	}
+	pointer := models.NewDefaultLoadTestPointer(baseID, version, bundleID, p.bundleFiles(baseID, bundleID, layout.Tool),
+		&models.LoadTestSummary{Tasks: metrics["tasks"], Endpoints: metrics["endpoints"], HasHost: validation.HostDefined})
+	pointer.Tool = layout.Tool
+
+	for name, data := range found {
+		if err := d.put(p.naming.LoadTestBundleFileKey(baseID, bundleID, name), data); err != nil {
+			return nil, nil, fmt.Errorf("copy %s: %w", name, err)
+		}
+	}
+	if err := d.putJSON(p.naming.LoadTestBundleFileKey(baseID, bundleID, "manifest.json"), manifest); err != nil {
+		return nil, nil, fmt.Errorf("write manifest: %w", err)
+	}
+	if err := d.putJSON(p.naming.LoadTestVersionKey(baseID, version), versionSnap); err != nil {
+		return nil, nil, fmt.Errorf("write version snapshot: %w", err)
+	}
+	if err := d.putJSON(p.naming.LoadTestCurrentKey(baseID), pointer); err != nil {
+		return nil, nil, fmt.Errorf("write pointer: %w", err)
+	}
+	// Update metadata index (best effort)
+	_ = d.putJSON(p.naming.LoadTestMetadataKey(baseID), models.LoadTestMetadataIndex{ProjectID: baseID, LatestVersion: version, UpdatedAt: ts})
+
+	return pointer, versionSnap, nil
+}
+
+// GetLoadTestPointer retrieves the current load test pointer (current.json) for a project
+func (p *Provider) GetLoadTestPointer(ctx context.Context, projectID string) (*models.LoadTestPointer, error) {
+	baseID := p.naming.ExtractProjectID(projectID)
+	var ptr models.LoadTestPointer
+	if err := p.dir(baseID).getJSON(p.naming.LoadTestCurrentKey(baseID), &ptr); err != nil {
+		return nil, fmt.Errorf("get loadtest pointer: %w", err)
+	}
+	return &ptr, nil
+}
+
+// DownloadLoadTestBundle copies the active load test bundle files into destDir/<bundleID>
+// and returns the pointer and the absolute local directory
+func (p *Provider) DownloadLoadTestBundle(ctx context.Context, projectID, destDir string) (*models.LoadTestPointer, string, error) {
+	ptr, err := p.GetLoadTestPointer(ctx, projectID)
+	if err != nil {
+		return nil, "", err
+	}
+	d := p.dir(projectID)
+	target := filepath.Join(destDir, ptr.BundleID)
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		return nil, "", fmt.Errorf("create dir: %w", err)
+	}
+	for _, key := range ptr.Files {
+		if key == "" {
+			continue
+		}
+		data, err := d.get(key)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue // optional file the bundle was uploaded without
+		} else if err != nil {
+			return nil, "", fmt.Errorf("read %s: %w", key, err)
+		}
+		localPath := filepath.Join(target, path.Base(key))
+		if err := os.WriteFile(localPath, data, 0o644); err != nil {
+			return nil, "", fmt.Errorf("write %s: %w", localPath, err)
+		}
+	}
+	abs, _ := filepath.Abs(target)
+	return ptr, abs, nil
+}
+
+// SaveLoadTestResult stores the summarized results of a run next to the bundle version it ran
+func (p *Provider) SaveLoadTestResult(ctx context.Context, projectID string, result *models.LoadTestRunResult) (string, error) {
+	baseID := p.naming.ExtractProjectID(projectID)
+	key := p.naming.LoadTestResultKey(baseID, result.Version, result.RunID)
+	if err := p.dir(baseID).putJSON(key, result); err != nil {
+		return "", fmt.Errorf("write results: %w", err)
+	}
+	return key, nil
+}
+
+// ListLoadTestResults reads the results of every run of every version, oldest first
+func (p *Provider) ListLoadTestResults(ctx context.Context, projectID string) ([]models.LoadTestRunResult, error) {
+	baseID := p.naming.ExtractProjectID(projectID)
+	d := p.dir(baseID)
+	items, err := d.list(p.naming.LoadTestResultsPrefix(baseID))
+	if err != nil {
+		return nil, fmt.Errorf("list results: %w", err)
+	}
+	results := make([]models.LoadTestRunResult, 0, len(items))
+	for _, item := range items {
+		if path.Ext(item.Key) != ".json" {
+			continue
+		}
+		var result models.LoadTestRunResult
+		if err := d.getJSON(item.Key, &result); err != nil {
+			return nil, fmt.Errorf("read %s: %w", item.Key, err)
+		}
+		results = append(results, result)
+	}
+	models.SortLoadTestResults(results)
+	return results, nil
+}
+
+// SaveLoadTestSchedule records the scheduled runs of the project
+func (p *Provider) SaveLoadTestSchedule(ctx context.Context, schedule *models.LoadTestSchedule) error {
+	baseID := p.naming.ExtractProjectID(schedule.ProjectID)
+	if err := p.dir(baseID).putJSON(p.naming.LoadTestScheduleKey(baseID), schedule); err != nil {
+		return fmt.Errorf("write schedule: %w", err)
+	}
+	return nil
+}
+
+// GetLoadTestSchedule returns the scheduled runs of the project
+func (p *Provider) GetLoadTestSchedule(ctx context.Context, projectID string) (*models.LoadTestSchedule, error) {
+	baseID := p.naming.ExtractProjectID(projectID)
+	var schedule models.LoadTestSchedule
+	if err := p.dir(baseID).getJSON(p.naming.LoadTestScheduleKey(baseID), &schedule); err != nil {
+		return nil, fmt.Errorf("get schedule: %w", err)
+	}
+	return &schedule, nil
+}
+
+// DeleteLoadTestSchedule removes the schedule record
+func (p *Provider) DeleteLoadTestSchedule(ctx context.Context, projectID string) error {
+	baseID := p.naming.ExtractProjectID(projectID)
+	p.dir(baseID).remove(p.naming.LoadTestScheduleKey(baseID))
+	return nil
+}
+
+// DeleteLoadTestPointer removes the current.json pointer (does not delete bundles)
+func (p *Provider) DeleteLoadTestPointer(ctx context.Context, projectID string) error {
+	baseID := p.naming.ExtractProjectID(projectID)
+	p.dir(baseID).remove(p.naming.LoadTestCurrentKey(baseID))
+	return nil
+}
+
+// DeleteActiveLoadTestBundleAndRollback deletes the active bundle and points current.json at
+// the previous version, or removes the pointer when there is none
+func (p *Provider) DeleteActiveLoadTestBundleAndRollback(ctx context.Context, projectID string) (*models.LoadTestPointer, int, error) {
+	curPtr, err := p.GetLoadTestPointer(ctx, projectID)
+	if err != nil || curPtr == nil || curPtr.ActiveVersion == "" {
+		_ = p.DeleteLoadTestPointer(ctx, projectID)
+		return nil, 0, nil
+	}
+
+	baseID := p.naming.ExtractProjectID(projectID)
+	d := p.dir(baseID)
+	deleted := d.removePrefix(p.naming.LoadTestBundleDir(baseID, curPtr.BundleID))
+
+	items, err := d.list(p.versionsPrefix(baseID))
+	if err != nil {
+		return nil, deleted, fmt.Errorf("list versions: %w", err)
+	}
+	keys := make([]string, 0, len(items))
+	for _, item := range items {
+		keys = append(keys, item.Key)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	prevKey := ""
+	currentKey := p.naming.LoadTestVersionKey(baseID, curPtr.ActiveVersion)
+	for _, k := range keys {
+		if k < currentKey {
+			prevKey = k
+			break
+		}
+	}
+	if prevKey == "" {
+		_ = p.DeleteLoadTestPointer(ctx, projectID)
+		return nil, deleted, nil
+	}
+
+	var prev models.LoadTestVersion
+	if err := d.getJSON(prevKey, &prev); err != nil {
+		return nil, deleted, fmt.Errorf("read previous version: %w", err)
+	}
+	ptr := models.NewDefaultLoadTestPointer(prev.ProjectID, prev.Version, prev.BundleID, p.bundleFiles(prev.ProjectID, prev.BundleID, prev.Tool), &models.LoadTestSummary{
+		Tasks:     prev.Metrics["tasks"],
+		Endpoints: prev.Metrics["endpoints"],
+		HasHost:   prev.Validation != nil && prev.Validation.HostDefined,
+	})
+	ptr.Tool = prev.Tool
+	if err := d.putJSON(p.naming.LoadTestCurrentKey(prev.ProjectID), ptr); err != nil {
+		return nil, deleted, fmt.Errorf("update pointer: %w", err)
+	}
+	return ptr, deleted, nil
+}
+
+// PurgeLoadTestArtifacts deletes all load test files (bundles, versions, pointer, metadata).
+// The project directory goes too once no mock data remains.
+func (p *Provider) PurgeLoadTestArtifacts(ctx context.Context, projectID string) (int, bool, error) {
+	baseID := p.naming.ExtractProjectID(projectID)
+	ltID := p.naming.LoadTestProjectID(baseID)
+	d := p.dir(baseID)
+	deleted := d.removePrefix(fmt.Sprintf("configs/%s/", ltID))
+	deleted += d.remove(fmt.Sprintf("metadata/%s.json", ltID))
+
+	if !d.hasPrefix("") {
+		if err := os.RemoveAll(string(d)); err == nil {
+			return deleted, true, nil
+		}
+	}
+	return deleted, false, nil
+}
+
+// ListLoadTestVersions returns every stored load test version snapshot for the project
+func (p *Provider) ListLoadTestVersions(ctx context.Context, projectID string) ([]models.LoadTestVersion, error) {
+	baseID := p.naming.ExtractProjectID(projectID)
+	d := p.dir(baseID)
+	items, err := d.list(p.versionsPrefix(baseID))
+	if err != nil {
+		return nil, fmt.Errorf("list versions: %w", err)
+	}
+	versions := make([]models.LoadTestVersion, 0, len(items))
+	for _, item := range items {
+		var ver models.LoadTestVersion
+		if err := d.getJSON(item.Key, &ver); err != nil {
+			return nil, fmt.Errorf("read %s: %w", item.Key, err)
+		}
+		versions = append(versions, ver)
+	}
+	return versions, nil
+}
+
+// ListLoadTestBundles returns the bundle directories stored for the project, including
+// bundles no version references
+func (p *Provider) ListLoadTestBundles(ctx context.Context, projectID string) ([]models.LoadTestBundleInfo, error) {
+	baseID := p.naming.ExtractProjectID(projectID)
+	prefix := p.naming.LoadTestBundlesPrefix(baseID)
+	items, err := p.dir(baseID).list(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("list bundles: %w", err)
+	}
+	byID := map[string]*models.LoadTestBundleInfo{}
+	var order []string
+	for _, item := range items {
+		bundleID, _, ok := strings.Cut(strings.TrimPrefix(item.Key, prefix), "/")
+		if !ok || bundleID == "" {
+			continue
+		}
+		info, seen := byID[bundleID]
+		if !seen {
+			info = &models.LoadTestBundleInfo{BundleID: bundleID}
+			byID[bundleID] = info
+			order = append(order, bundleID)
+		}
+		info.Objects++
+		info.Size += item.Size
+		if item.LastModified.After(info.CreatedAt) {
+			info.CreatedAt = item.LastModified
+		}
+	}
+	bundles := make([]models.LoadTestBundleInfo, 0, len(order))
+	for _, id := range order {
+		bundles = append(bundles, *byID[id])
+	}
+	return bundles, nil
+}
+
+// DeleteLoadTestVersion permanently removes a load test version snapshot (not its bundle)
+func (p *Provider) DeleteLoadTestVersion(ctx context.Context, projectID, version string) (int, error) {
+	baseID := p.naming.ExtractProjectID(projectID)
+	d := p.dir(baseID)
+	deleted := d.remove(p.naming.LoadTestVersionKey(baseID, version))
+	return deleted + d.removePrefix(path.Dir(p.naming.LoadTestResultKey(baseID, version, "run"))+"/"), nil
+}
+
+// DeleteLoadTestBundle permanently removes a bundle directory
+func (p *Provider) DeleteLoadTestBundle(ctx context.Context, projectID, bundleID string) (int, error) {
+	if bundleID == "" {
+		return 0, fmt.Errorf("bundle id is required")
+	}
+	baseID := p.naming.ExtractProjectID(projectID)
+	return p.dir(baseID).removePrefix(p.naming.LoadTestBundleDir(baseID, bundleID)), nil
+}
+
+func (p *Provider) versionsPrefix(baseID string) string {
+	return fmt.Sprintf("configs/%s/versions/", p.naming.LoadTestProjectID(baseID))
+}
+
+// bundleFiles returns the standard logical-name to key mapping for a bundle
+func (p *Provider) bundleFiles(projectID, bundleID, tool string) map[string]string {
+	files := map[string]string{}
+	for logical, name := range loadtest.LayoutFor(tool).Files {
+		files[logical] = p.naming.LoadTestBundleFileKey(projectID, bundleID, name)
+	}
+	return files
+}
+
+func contains(xs []string, s string) bool {
+	for _, x := range xs {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+// Package local provides a filesystem implementation of the provider interface, so projects
+// can be built, edited, versioned and served without a cloud account. Every project is a
+// directory under ~/.automock/projects (or $AUTOMOCK_HOME/projects) holding the same files a
+// cloud project stores as objects; 'automock push' copies a project to a cloud.
+// Local projects cannot be deployed to a cloud: 'automock serve' runs them instead, or
+// 'automock deploy --target kubernetes' runs them on a cluster.
+package local
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hemantobora/auto-mock/internal"
+	"github.com/hemantobora/auto-mock/internal/cloud/naming"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// EnvHome relocates the local store from ~/.automock
+const EnvHome = "AUTOMOCK_HOME"
+
+// ProviderType is the --cloud value selecting the local store
+const ProviderType = "local"
+
+// Provider keeps projects in directories on the local filesystem
+type Provider struct {
+	projectID string
+	naming    internal.NamingStrategy
+
+	Root string // directory holding projects/<name>/
+}
+
+// ProviderOption is a functional option for provider configuration
+type ProviderOption func(*Provider)
+
+// WithRoot stores projects under root instead of ~/.automock
+func WithRoot(root string) ProviderOption {
+	return func(p *Provider) {
+		p.Root = root
+	}
+}
+
+// NewProvider creates a provider on the local store
+func NewProvider(options ...ProviderOption) (*Provider, error) {
+	p := &Provider{naming: naming.NewDefaultNaming(), Root: os.Getenv(EnvHome)}
+	for _, opt := range options {
+		opt(p)
+	}
+	if p.Root == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, &models.ProviderError{
+				Provider:  ProviderType,
+				Operation: "load-config",
+				Resource:  "home",
+				Cause:     fmt.Errorf("cannot locate the home directory; set %s: %w", EnvHome, err),
+			}
+		}
+		p.Root = filepath.Join(home, ".automock")
+	}
+	return p, nil
+}
+
+// GetProviderType returns the provider type
+func (p *Provider) GetProviderType() string {
+	return ProviderType
+}
+
+// CredentialEnv returns nothing: the local store needs no credentials
+func (p *Provider) CredentialEnv(ctx context.Context) ([]string, error) {
+	return nil, nil
+}
+
+func (p *Provider) ValidateProjectName(projectID string) error {
+	return p.naming.ValidateProjectID(projectID)
+}
+
+// GetStorageName returns the directory of the current project
+func (p *Provider) GetStorageName() string {
+	if p.projectID == "" {
+		return ""
+	}
+	return string(p.dir(p.projectID))
+}
+
+func (p *Provider) GetProjectName() string {
+	return p.projectID
+}
+
+// SetStorageName is a no-op: a project's directory follows from its name
+func (p *Provider) SetStorageName(name string) {}
+
+func (p *Provider) SetProjectName(name string) {
+	p.projectID = name
+}
+
+// GetRegion names the machine the store is on
+func (p *Provider) GetRegion() string {
+	if host, err := os.Hostname(); err == nil {
+		return host
+	}
+	return ProviderType
+}
+
+// InitProject creates the project's directory
+func (p *Provider) InitProject(ctx context.Context, projectID string) error {
+	if err := p.naming.ValidateProjectID(projectID); err != nil {
+		return err
+	}
+	d := p.dir(projectID)
+	if _, err := os.Stat(string(d)); err == nil {
+		p.projectID = projectID
+		fmt.Printf("✅ Project already initialized: %s\n", projectID)
+		return nil
+	}
+	if err := os.MkdirAll(string(d), 0o755); err != nil {
+		return &models.ProviderError{
+			Provider:  ProviderType,
+			Operation: "init",
+			Resource:  string(d),
+			Cause:     fmt.Errorf("failed to create project directory: %w", err),
+		}
+	}
+	fmt.Printf("✅ Project initialized: %s (%s)\n", projectID, d)
+	p.projectID = projectID
+	return nil
+}
+
+// dir returns the directory of a project
+func (p *Provider) dir(projectID string) dir {
+	return dir(filepath.Join(p.Root, "projects", p.naming.ExtractProjectID(projectID)))
+}
@@ -0,0 +1,126 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+func newTestProvider(t *testing.T) *Provider {
+	t.Helper()
+	p, err := NewProvider(WithRoot(t.TempDir()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func sampleConfig(path string) *models.MockConfiguration {
+	return &models.MockConfiguration{
+		Metadata: models.ConfigMetadata{ProjectID: "users"},
+		Expectations: []models.MockExpectation{{
+			HttpRequest:  &models.HttpRequest{Method: "GET", Path: path},
+			HttpResponse: &models.HttpResponse{StatusCode: 200},
+		}},
+	}
+}
+
+func TestConfigLifecycle(t *testing.T) {
+	ctx := context.Background()
+	p := newTestProvider(t)
+
+	if exists, _ := p.ProjectExists(ctx, "users"); exists {
+		t.Fatal("project exists before init")
+	}
+	if err := p.InitProject(ctx, "users"); err != nil {
+		t.Fatal(err)
+	}
+	var notFound *models.ConfigNotFoundError
+	if _, err := p.GetConfig(ctx, "users"); !errors.As(err, &notFound) {
+		t.Errorf("GetConfig before the first save = %v, want ConfigNotFoundError", err)
+	}
+	if err := p.SaveConfig(ctx, sampleConfig("/v1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.UpdateConfig(ctx, sampleConfig("/v2")); err != nil {
+		t.Fatal(err)
+	}
+
+	current, err := p.GetConfig(ctx, "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current.Expectations[0].HttpRequest.Path != "/v2" {
+		t.Errorf("current path = %s, want /v2", current.Expectations[0].HttpRequest.Path)
+	}
+	versions, err := p.ListVersions(ctx, "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("versions = %+v, want 2", versions)
+	}
+	first, err := p.GetVersion(ctx, "users", versions[0].Version)
+	if err != nil || first.Expectations[0].HttpRequest.Path != "/v1" {
+		t.Errorf("first version = %+v, %v", first, err)
+	}
+
+	projects, err := p.ListProjects(ctx)
+	if err != nil || len(projects) != 1 || projects[0].ProjectID != "users" || projects[0].Provider != ProviderType {
+		t.Errorf("projects = %+v, %v", projects, err)
+	}
+	if deployed, err := p.IsDeployed(); deployed || err != nil {
+		t.Errorf("IsDeployed = %v, %v", deployed, err)
+	}
+
+	if err := p.DeleteProject("users"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(p.Root, "projects", "users")); !os.IsNotExist(err) {
+		t.Errorf("project directory left behind: %v", err)
+	}
+}
+
+func TestLoadTestBundleRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	p := newTestProvider(t)
+
+	bundle := t.TempDir()
+	files := map[string]string{
+		"locustfile.py":         "from locust import HttpUser\n",
+		"requirements.txt":      "locust\n",
+		"locust_endpoints.json": "[]",
+	}
+	for name, content := range files {
+		os.WriteFile(filepath.Join(bundle, name), []byte(content), 0644)
+	}
+
+	ptr, _, err := p.UploadLoadTestBundle(ctx, "users", bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, dir, err := p.DownloadLoadTestBundle(ctx, "users", t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.BundleID != ptr.BundleID {
+		t.Errorf("pointer bundle = %s, want %s", got.BundleID, ptr.BundleID)
+	}
+	for name, content := range files {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || string(data) != content {
+			t.Errorf("%s = %q, %v", name, data, err)
+		}
+	}
+
+	if bundles, err := p.ListLoadTestBundles(ctx, "users"); err != nil || len(bundles) != 1 {
+		t.Errorf("bundles = %+v, %v", bundles, err)
+	}
+	if _, removed, err := p.PurgeLoadTestArtifacts(ctx, "users"); err != nil || !removed {
+		t.Errorf("purge removed project = %v, %v", removed, err)
+	}
+}
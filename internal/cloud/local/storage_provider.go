@@ -0,0 +1,377 @@
+package local
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// SaveConfig saves a mock configuration to the project directory
+func (p *Provider) SaveConfig(ctx context.Context, config *models.MockConfiguration) error {
+	// Validate configuration
+	if err := models.ValidateConfiguration(config); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Derive the stored responses and companions; refuse what MockServer would not serve
+	if err := config.Prepare(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Set metadata
+	projectID := p.projectID
+	if projectID == "" {
+		projectID = config.Metadata.ProjectID
+	}
+	cleanProjectID := p.naming.ExtractProjectID(projectID)
+	if cleanProjectID == "" {
+		return fmt.Errorf("no project selected to save the configuration to")
+	}
+	config.Metadata.ProjectID = cleanProjectID
+	config.Metadata.UpdatedAt = time.Now()
+	if config.Metadata.CreatedAt.IsZero() {
+		config.Metadata.CreatedAt = time.Now()
+	}
+	if config.Metadata.Version == "" {
+		config.Metadata.Version = fmt.Sprintf("v%d", time.Now().Unix())
+	}
+
+	jsonData, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+	config.Metadata.Size = int64(len(jsonData))
+
+	d := p.dir(cleanProjectID)
+	if err := d.put(fmt.Sprintf("configs/%s/current.json", cleanProjectID), jsonData); err != nil {
+		return fmt.Errorf("failed to save current config: %w", err)
+	}
+	versionKey := fmt.Sprintf("configs/%s/versions/%s.json", cleanProjectID, config.Metadata.Version)
+	if err := d.put(versionKey, jsonData); err != nil {
+		fmt.Printf("Warning: failed to save version %s: %v\n", config.Metadata.Version, err)
+	}
+	if err := d.putJSON(fmt.Sprintf("metadata/%s.json", cleanProjectID), config.Metadata); err != nil {
+		fmt.Printf("Warning: failed to update metadata index: %v\n", err)
+	}
+	return nil
+}
+
+// GetConfig retrieves the current mock configuration
+func (p *Provider) GetConfig(ctx context.Context, projectID string) (*models.MockConfiguration, error) {
+	cleanProjectID := p.naming.ExtractProjectID(projectID)
+	var config models.MockConfiguration
+	if err := p.dir(cleanProjectID).getJSON(fmt.Sprintf("configs/%s/current.json", cleanProjectID), &config); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, &models.ConfigNotFoundError{ProjectID: cleanProjectID, Cause: err}
+		}
+		return nil, fmt.Errorf("failed to get config from the local store: %w", err)
+	}
+	return &config, nil
+}
+
+// UpdateConfig updates an existing configuration
+func (p *Provider) UpdateConfig(ctx context.Context, config *models.MockConfiguration) error {
+	// Get existing config to preserve creation time and the deployed baseline
+	existing, err := p.GetConfig(ctx, config.Metadata.ProjectID)
+	if err == nil {
+		config.Metadata.CreatedAt = existing.Metadata.CreatedAt
+		if config.Metadata.DeployedVersion == "" {
+			config.Metadata.DeployedVersion = existing.Metadata.DeployedVersion
+		}
+		if config.Metadata.ContractVersion == "" {
+			config.Metadata.ContractVersion = existing.Metadata.ContractVersion
+		}
+	}
+
+	config.Metadata.Version = NextVersion(existing)
+	return p.SaveConfig(ctx, config)
+}
+
+// NextVersion names a new version by the clock, moving past the current one when two saves
+// land in the same second; a cloud store keeps both as object versions, a directory or a
+// commit trailer does not
+func NextVersion(existing *models.MockConfiguration) string {
+	now := time.Now().Unix()
+	var last int64
+	if existing != nil {
+		fmt.Sscanf(existing.Metadata.Version, "v%d", &last)
+	}
+	if now <= last {
+		now = last + 1
+	}
+	return fmt.Sprintf("v%d", now)
+}
+
+// DeleteProject removes the project's mock data; the directory goes too once no load test
+// artifacts remain
+func (p *Provider) DeleteProject(projectID string) error {
+	cleanProjectID := p.naming.ExtractProjectID(projectID)
+	d := p.dir(cleanProjectID)
+	d.removePrefix(fmt.Sprintf("configs/%s/", cleanProjectID))
+	d.remove(fmt.Sprintf("metadata/%s.json", cleanProjectID))
+
+	ltID := p.naming.LoadTestProjectID(cleanProjectID)
+	if !d.hasPrefix(fmt.Sprintf("configs/%s/", ltID)) && !d.exists(fmt.Sprintf("metadata/%s.json", ltID)) {
+		if err := os.RemoveAll(string(d)); err != nil {
+			return fmt.Errorf("delete %s: %w", d, err)
+		}
+		fmt.Printf("✅ Project %q deleted (%s removed)\n", cleanProjectID, d)
+		return nil
+	}
+	fmt.Printf("✅ Project %q mock data deleted (load test artifacts kept in %s)\n", cleanProjectID, d)
+	return nil
+}
+
+// SaveVersion saves a specific version of a configuration
+func (p *Provider) SaveVersion(ctx context.Context, config *models.MockConfiguration, version string) error {
+	cleanProjectID := p.naming.ExtractProjectID(config.Metadata.ProjectID)
+	return p.dir(cleanProjectID).putJSON(fmt.Sprintf("configs/%s/versions/%s.json", cleanProjectID, version), config)
+}
+
+// GetVersion retrieves a specific version of a configuration
+func (p *Provider) GetVersion(ctx context.Context, projectID, version string) (*models.MockConfiguration, error) {
+	cleanProjectID := p.naming.ExtractProjectID(projectID)
+	var config models.MockConfiguration
+	if err := p.dir(cleanProjectID).getJSON(fmt.Sprintf("configs/%s/versions/%s.json", cleanProjectID, version), &config); err != nil {
+		return nil, fmt.Errorf("failed to get version %s from the local store: %w", version, err)
+	}
+	return &config, nil
+}
+
+// ListVersions retrieves version history for a project
+func (p *Provider) ListVersions(ctx context.Context, projectID string) ([]models.VersionInfo, error) {
+	cleanProjectID := p.naming.ExtractProjectID(projectID)
+	items, err := p.dir(cleanProjectID).list(fmt.Sprintf("configs/%s/versions/", cleanProjectID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list versions: %w", err)
+	}
+	var versions []models.VersionInfo
+	for _, item := range items {
+		versions = append(versions, models.VersionInfo{
+			Version:   strings.TrimSuffix(filepath.Base(item.Key), ".json"),
+			CreatedAt: item.LastModified,
+			Size:      item.Size,
+		})
+	}
+	return versions, nil
+}
+
+// DeleteVersion permanently removes a version snapshot
+func (p *Provider) DeleteVersion(ctx context.Context, projectID, version string) (int, error) {
+	cleanProjectID := p.naming.ExtractProjectID(projectID)
+	deleted := p.dir(cleanProjectID).remove(fmt.Sprintf("configs/%s/versions/%s.json", cleanProjectID, version))
+	if deleted == 0 {
+		return 0, fmt.Errorf("version %s not found or could not be deleted", version)
+	}
+	return deleted, nil
+}
+
+// ListProjects lists the project directories of the local store
+func (p *Provider) ListProjects(ctx context.Context) ([]models.ProjectInfo, error) {
+	fmt.Println("✅ Checking existence of projects")
+	entries, err := os.ReadDir(filepath.Join(p.Root, "projects"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	var projects []models.ProjectInfo
+	for _, e := range entries {
+		if !e.IsDir() || p.naming.ValidateProjectID(e.Name()) != nil {
+			continue
+		}
+		info := models.ProjectInfo{
+			ProjectID:   e.Name(),
+			DisplayName: e.Name(),
+			StorageName: string(p.dir(e.Name())),
+			Provider:    ProviderType,
+		}
+		if meta, err := p.GetMetadata(ctx, e.Name()); err == nil {
+			info.CreatedAt, info.UpdatedAt = meta.CreatedAt, meta.UpdatedAt
+			info.HasExpectations = true
+		}
+		projects = append(projects, info)
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].ProjectID < projects[j].ProjectID })
+	return projects, nil
+}
+
+// ProjectExists checks if a project directory exists
+func (p *Provider) ProjectExists(ctx context.Context, projectID string) (bool, error) {
+	fmt.Printf("✅ Checking existence of project: %s\n", projectID)
+	st, err := os.Stat(string(p.dir(projectID)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !st.IsDir() {
+		return false, fmt.Errorf("%s is not a directory", p.dir(projectID))
+	}
+	p.projectID = projectID
+	return true, nil
+}
+
+// GetMetadata retrieves metadata for a project
+func (p *Provider) GetMetadata(ctx context.Context, projectID string) (*models.ConfigMetadata, error) {
+	cleanProjectID := p.naming.ExtractProjectID(projectID)
+
+	var metadata models.ConfigMetadata
+	if err := p.dir(cleanProjectID).getJSON(fmt.Sprintf("metadata/%s.json", cleanProjectID), &metadata); err == nil {
+		return &metadata, nil
+	}
+
+	// Fall back to reading the actual config
+	config, err := p.GetConfig(ctx, cleanProjectID)
+	if err != nil {
+		return nil, err
+	}
+	return &config.Metadata, nil
+}
+
+// Helper methods
+
+// dir is a project directory. Keys are slash-separated paths inside it, named like the
+// objects of a cloud project so projects move between stores unchanged.
+type dir string
+
+// objectInfo is the listing view of a file
+type objectInfo struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+func (d dir) file(key string) string {
+	return filepath.Join(string(d), filepath.FromSlash(key))
+}
+
+// put writes a file through a temporary file, so readers never see a partial write
+func (d dir) put(key string, data []byte) error {
+	path := d.file(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (d dir) putJSON(key string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return d.put(key, data)
+}
+
+func (d dir) get(key string) ([]byte, error) {
+	return os.ReadFile(d.file(key))
+}
+
+func (d dir) getJSON(key string, v any) error {
+	data, err := d.get(key)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func (d dir) exists(key string) bool {
+	st, err := os.Stat(d.file(key))
+	return err == nil && !st.IsDir()
+}
+
+func (d dir) hasPrefix(prefix string) bool {
+	items, err := d.list(prefix)
+	return err == nil && len(items) > 0
+}
+
+// list returns the files under a key prefix in key order
+func (d dir) list(prefix string) ([]objectInfo, error) {
+	var out []objectInfo
+	root := d.file(prefix)
+	if !strings.HasSuffix(prefix, "/") {
+		root = filepath.Dir(root)
+	}
+	err := filepath.WalkDir(root, func(path string, e fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".tmp-") {
+			return nil
+		}
+		rel, err := filepath.Rel(string(d), path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		out = append(out, objectInfo{Key: key, Size: info.Size(), LastModified: info.ModTime()})
+		return nil
+	})
+	return out, err
+}
+
+// remove deletes a file and returns 1 when it existed
+func (d dir) remove(key string) int {
+	if err := os.Remove(d.file(key)); err != nil {
+		return 0
+	}
+	d.prune(filepath.Dir(d.file(key)))
+	return 1
+}
+
+// removePrefix deletes every file under a prefix and returns how many were removed
+func (d dir) removePrefix(prefix string) int {
+	items, err := d.list(prefix)
+	if err != nil {
+		return 0
+	}
+	deleted := 0
+	for _, item := range items {
+		deleted += d.remove(item.Key)
+	}
+	return deleted
+}
+
+// prune removes directories left empty, up to the project directory
+func (d dir) prune(path string) {
+	for path != string(d) && strings.HasPrefix(path, string(d)) {
+		if os.Remove(path) != nil { // fails unless empty
+			return
+		}
+		path = filepath.Dir(path)
+	}
+}
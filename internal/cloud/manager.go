@@ -4,13 +4,21 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/hemantobora/auto-mock/internal"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/cloud/naming"
+	"github.com/hemantobora/auto-mock/internal/collections"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
 	"github.com/hemantobora/auto-mock/internal/expectations"
+	"github.com/hemantobora/auto-mock/internal/hooks"
+	"github.com/hemantobora/auto-mock/internal/kubernetes"
 	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/network"
 	"github.com/hemantobora/auto-mock/internal/repl"
 	"github.com/hemantobora/auto-mock/internal/terraform"
 )
@@ -23,6 +31,8 @@ const (
 	ModeInteractive InitializationMode = iota
 	// ModeCollection - Secondary mode: CLI-driven collection import
 	ModeCollection
+	// ModeSchema - CLI-driven GraphQL schema import
+	ModeSchema
 )
 
 // CLIContext holds CLI parameters and determines the initialization mode
@@ -31,8 +41,18 @@ type CLIContext struct {
 	ProjectName string `json:"project_name,omitempty"`
 
 	// Collection import settings (triggers ModeCollection)
-	CollectionFile string `json:"collection_file,omitempty"`
-	CollectionType string `json:"collection_type,omitempty"`
+	CollectionFiles []string `json:"collection_files,omitempty"` // files, globs or directories
+	CollectionType  string   `json:"collection_type,omitempty"`
+	IterationData   string   `json:"iteration_data,omitempty"` // newman -d style CSV/JSON data file
+	ChunkSize       int      `json:"chunk_size,omitempty"`     // requests per execution/review chunk
+	MaxRequests     int      `json:"max_requests,omitempty"`   // refuse larger collection imports
+	Concurrency     int      `json:"concurrency,omitempty"`    // independent requests executed at once
+
+	// GraphQL schema import (triggers ModeSchema)
+	Schema string `json:"schema,omitempty"` // SDL or introspection JSON
+
+	// Generator options (used in both modes)
+	ConditionalGet bool `json:"conditional_get,omitempty"` // ETags plus 304 companions on GET responses
 
 	// Optional CLI overrides (used in both modes)
 	Provider string `json:"provider,omitempty"` // LLM provider preference
@@ -40,7 +60,10 @@ type CLIContext struct {
 
 // GetMode determines which initialization mode to use based on CLI context
 func (c *CLIContext) GetMode() InitializationMode {
-	if c.CollectionFile != "" {
+	if c.Schema != "" {
+		return ModeSchema
+	}
+	if len(c.CollectionFiles) > 0 {
 		return ModeCollection
 	}
 	return ModeInteractive
@@ -56,6 +79,11 @@ type CloudManager struct {
 	profile  string
 	Provider internal.Provider
 	factory  *Factory
+
+	errorTaxonomy *models.ErrorTaxonomy // captured once per session; persisted with generated expectations
+	taxonomyAsked bool
+
+	offlineProject string // project named in offline mode, where there is no Provider
 }
 
 // NewCloudManager creates a new cloud manager instance
@@ -70,6 +98,9 @@ func NewCloudManager(profile string) *CloudManager {
 // It supports both interactive (REPL) and CLI-driven (collection import) workflows.
 func AutoDetectAndInit(profile string, cliContext *CLIContext) error {
 	manager := NewCloudManager(profile)
+	if network.Offline() && !IsLocalStore(os.Getenv(EnvCloud)) {
+		return manager.initOffline(cliContext)
+	}
 	// Step 1: Validate cloud provider credentials
 	if err := manager.AutoDetectProvider(profile); err != nil {
 		return err
@@ -88,6 +119,25 @@ func (m *CloudManager) AutoDetectProvider(profile string) error {
 	return nil
 }
 
+// initOffline generates expectations without the project store: nothing is saved, but the
+// result can be viewed or written to a file for a local MockServer
+func (m *CloudManager) initOffline(cliContext *CLIContext) error {
+	fmt.Println("📴 Offline mode: saving to the project store, deployment and AI generation are disabled")
+	m.offlineProject = cliContext.ProjectName
+	if m.offlineProject == "" {
+		if err := ask.One(&survey.Input{
+			Message: "Project name:",
+			Help:    "Names the expectations file written for a local MockServer",
+		}, &m.offlineProject, survey.WithValidator(survey.Required)); err != nil {
+			return err
+		}
+	}
+	if err := naming.NewDefaultNaming().ValidateProjectID(m.offlineProject); err != nil {
+		return exitcode.Wrap(exitcode.Config, fmt.Errorf("invalid project name: %w", err))
+	}
+	return m.generateMockConfiguration(cliContext)
+}
+
 // startSpinner prints an animated spinner with a message until the returned stop function is called.
 // Keeps dependencies minimal (no external libs). Falls back gracefully on non-TTY environments.
 // spinner removed per user preference
@@ -143,6 +193,50 @@ func (m *CloudManager) Initialize(cliContext *CLIContext) error {
 				return fmt.Errorf("edit failed: %w", err)
 			}
 			refreshConfig = true
+		case models.ActionSchemas:
+			if err := m.handleManageSchemas(expManager, existingConfig); err != nil {
+				return fmt.Errorf("schema management failed: %w", err)
+			}
+			refreshConfig = true
+		case models.ActionExamples:
+			if err := m.handleManageExamples(expManager, existingConfig); err != nil {
+				return fmt.Errorf("example library management failed: %w", err)
+			}
+			refreshConfig = true
+		case models.ActionTenants:
+			if err := m.handleManageTenants(expManager, existingConfig); err != nil {
+				return fmt.Errorf("tenant management failed: %w", err)
+			}
+			refreshConfig = true
+		case models.ActionErrors:
+			if err := m.handleManageErrorTaxonomy(expManager, existingConfig); err != nil {
+				return fmt.Errorf("error taxonomy failed: %w", err)
+			}
+			refreshConfig = true
+		case models.ActionMasking:
+			if err := m.handleManageMaskingProfiles(expManager, existingConfig); err != nil {
+				return fmt.Errorf("masking profiles failed: %w", err)
+			}
+			refreshConfig = true
+		case models.ActionEnvironments:
+			if err := m.handleManageEnvironments(expManager, existingConfig); err != nil {
+				return fmt.Errorf("environments failed: %w", err)
+			}
+			refreshConfig = true
+		case models.ActionFakeData:
+			if err := m.handleManageFakeData(expManager, existingConfig); err != nil {
+				return fmt.Errorf("fake data generators failed: %w", err)
+			}
+			refreshConfig = true
+		case models.ActionDatasets:
+			if err := m.handleManageDatasets(expManager, existingConfig); err != nil {
+				return fmt.Errorf("datasets failed: %w", err)
+			}
+			refreshConfig = true
+		case models.ActionDiff:
+			if err := m.handleDiffVersions(existingConfig); err != nil {
+				return fmt.Errorf("diff failed: %w", err)
+			}
 		case models.ActionRemove:
 			// Manager handles actual removal (data operations)
 			if err := m.handleRemoveExpectations(expManager, existingConfig); err != nil {
@@ -176,7 +270,20 @@ func (m *CloudManager) Initialize(cliContext *CLIContext) error {
 			if deployed {
 				fmt.Println("✅ Infrastructure is already deployed.")
 			} else {
-				if err := deployer.DeployInfrastructureWithTerraform(false); err != nil {
+				if existingConfig != nil {
+					if deployer.Environment, err = expectations.SelectEnvironment(existingConfig); err != nil {
+						return err
+					}
+					if deployer.Selection, err = expectations.SelectTags("Deploy only expectations tagged (none = all):", existingConfig.Expectations); err != nil {
+						return err
+					}
+				}
+				err := deployer.DeployInfrastructureWithTerraform(false, false)
+				if exitcode.Classify(err) == exitcode.Cancelled {
+					fmt.Println("\n❌ Deployment cancelled")
+					return nil
+				}
+				if err != nil {
 					return fmt.Errorf("deployment failed: %w", err)
 				}
 			}
@@ -206,6 +313,12 @@ func (m *CloudManager) addMockConfiguration(cliContext *CLIContext, existingConf
 		return fmt.Errorf("failed to parse additional expectations: %w", err)
 	}
 	existingConfiguration.Expectations = append(existingConfiguration.Expectations, additionalConfigurations.Expectations...)
+	if existingConfiguration.ErrorTaxonomy == nil {
+		existingConfiguration.ErrorTaxonomy = m.errorTaxonomy
+	}
+	if added := existingConfiguration.HarvestBodyExamples("import"); added > 0 {
+		fmt.Printf("📚 Added %d response example(s) to the project library\n", added)
+	}
 	return m.Provider.UpdateConfig(context.Background(), existingConfiguration)
 }
 
@@ -221,7 +334,7 @@ func (m *CloudManager) generateMockConfiguration(cliContext *CLIContext) error {
 func (m *CloudManager) createNewProject(project string) (models.ActionType, error) {
 	var name string
 	if project == "" {
-		if err := survey.AskOne(&survey.Input{
+		if err := ask.One(&survey.Input{
 			Message: "Project name:",
 			Help:    "Choose a unique name for your mock project",
 		}, &name); err != nil {
@@ -298,36 +411,133 @@ func (m *CloudManager) handleInteractiveProject() (models.ActionType, error) {
 func (m *CloudManager) generateMockExpectations(cliContext *CLIContext) (string, error) {
 	fmt.Println("🧠 Starting mock expectation generation...")
 
+	var (
+		generated string
+		err       error
+		mode      string
+	)
 	switch cliContext.GetMode() {
 	case ModeCollection:
 		// CLI-driven: Process collection file with AI assistance
-		return repl.HandleCollectionMode(cliContext.CollectionType, cliContext.CollectionFile, m.getCurrentProject())
+		mode = "collection"
+		generated, err = repl.HandleCollectionMode(cliContext.CollectionType, cliContext.CollectionFiles, collections.ImportOptions{
+			IterationData: cliContext.IterationData,
+			ChunkSize:     cliContext.ChunkSize,
+			MaxRequests:   cliContext.MaxRequests,
+			Concurrency:   cliContext.Concurrency,
+		}, m.getCurrentProject())
+
+	case ModeSchema:
+		// CLI-driven: Expectations per query and mutation of a GraphQL schema
+		mode = "schema"
+		generated, err = repl.HandleSchemaMode(cliContext.Schema, m.getCurrentProject())
 
 	case ModeInteractive:
 		// REPL-driven: Interactive AI-guided configuration (primary experience)
 		// Pass through any CLI provider override (e.g., --provider anthropic)
-		return repl.StartMockGenerationREPL(m.getCurrentProject(), cliContext.Provider)
+		mode = "interactive"
+		if err := m.prepareErrorTaxonomy(); err != nil {
+			return "", err
+		}
+		generated, err = repl.StartMockGenerationREPL(m.getCurrentProject(), cliContext.Provider)
 	default:
 		return "", fmt.Errorf("unsupported initialization mode")
 	}
+	if err != nil {
+		return "", err
+	}
+	if cliContext.ConditionalGet {
+		if generated, err = enableConditionalGet(generated); err != nil {
+			return "", err
+		}
+	}
+	if generated, err = tagGenerated(generated); err != nil {
+		return "", err
+	}
+
+	hooks.RunPost(hooks.PostGenerate, m.getCurrentProject(), m.profile, map[string]string{"mode": mode})
+	return generated, nil
 }
 
-func (m *CloudManager) destroyInfrastructureAndDeleteProject() error {
-	fmt.Println("\n🗑️  Deleting project...")
+// prepareErrorTaxonomy hands the project's error taxonomy to the generators. When the project
+// has none, the wizard is offered once per session so every generator shares one error shape.
+func (m *CloudManager) prepareErrorTaxonomy() error {
+	if m.errorTaxonomy == nil && m.Provider != nil {
+		if existing, err := m.getMockConfiguration(); err == nil && existing != nil {
+			m.errorTaxonomy = existing.ErrorTaxonomy
+		}
+	}
+	if m.errorTaxonomy == nil && !m.taxonomyAsked {
+		m.taxonomyAsked = true
+		var define bool
+		if err := ask.One(&survey.Confirm{
+			Message: "Define the project's error envelope and error codes before generating?",
+			Help:    "Captured once and stored with the project; template and AI generated error responses then share one shape",
+			Default: false,
+		}, &define); err != nil {
+			return err
+		}
+		if define {
+			taxonomy, err := expectations.ErrorTaxonomyWizard(nil)
+			if err != nil {
+				return err
+			}
+			m.errorTaxonomy = taxonomy
+		}
+	}
+	repl.UseErrorTaxonomy(m.errorTaxonomy)
+	return nil
+}
 
-	// Create Terraform manager
-	destroyer, err := terraform.NewManager(m.getCurrentProject(), m.profile, m.getCloudProvider())
+// enableConditionalGet marks every eligible GET in generated MockServer JSON for ETag / 304 support
+func enableConditionalGet(generated string) (string, error) {
+	config, err := models.ParseMockServerJSON(generated)
 	if err != nil {
-		return fmt.Errorf("failed to create terraform manager: %w", err)
+		return "", fmt.Errorf("failed to parse generated expectations: %w", err)
 	}
+	enabled := models.EnableConditionalGet(config.Expectations)
+	fmt.Printf("🏷️  Conditional GET enabled on %d GET expectation(s); ETags and 304 responses are added on save\n", enabled)
+	return models.ExpectationsToMockServerJSON(config.Expectations), nil
+}
+
+// tagGenerated offers to tag the expectations in generated MockServer JSON
+func tagGenerated(generated string) (string, error) {
+	config, err := models.ParseMockServerJSON(generated)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse generated expectations: %w", err)
+	}
+	if err := expectations.TagExpectations(config.Expectations); err != nil {
+		return "", err
+	}
+	return models.ExpectationsToMockServerJSON(config.Expectations), nil
+}
+
+func (m *CloudManager) destroyInfrastructureAndDeleteProject() error {
+	fmt.Println("\n🗑️  Deleting project...")
 
 	fmt.Println("🔄 Checking infrastructure status...")
+	if err := hooks.Run(hooks.PreDestroy, m.getCurrentProject(), m.profile, nil); err != nil {
+		return fmt.Errorf("deletion aborted: %w", err)
+	}
+
 	status, _ := m.Provider.IsDeployed()
+	if meta, _ := m.Provider.GetDeploymentMetadata(); status && meta != nil {
+		if cluster, ok := kubernetes.ManagerFromOutputs(m.getCurrentProject(), meta.Details); ok {
+			fmt.Println("\nDeleting mocks from Kubernetes...")
+			if err := cluster.Destroy(context.Background()); err != nil {
+				return err
+			}
+			status = false
+		}
+	}
 	if status {
+		destroyer, err := terraform.NewManager(m.getCurrentProject(), m.profile, m.getCloudProvider())
+		if err != nil {
+			return fmt.Errorf("failed to create terraform manager: %w", err)
+		}
 		// Destroy infrastructure
 		fmt.Println("\nDestroying infrastructure...")
-		err = destroyer.Destroy()
-		if err != nil {
+		if err := destroyer.Destroy(); err != nil {
 			return err
 		}
 	}
@@ -342,6 +552,9 @@ func (m *CloudManager) getMockConfiguration() (*models.MockConfiguration, error)
 }
 
 func (m *CloudManager) getCurrentProject() string {
+	if m.Provider == nil {
+		return m.offlineProject
+	}
 	return m.Provider.GetProjectName()
 }
 
@@ -450,18 +663,220 @@ func (m *CloudManager) handleEditExpectations(expManager *expectations.Expectati
 	return nil
 }
 
+// handleManageSchemas runs the schema library editor and persists changes
+func (m *CloudManager) handleManageSchemas(expManager *expectations.ExpectationManager, existingConfig *models.MockConfiguration) error {
+	modifiedConfig, err := expManager.ManageSchemas(existingConfig)
+	if err != nil {
+		return err
+	}
+	if modifiedConfig == nil {
+		fmt.Println("✅ Schema library unchanged.")
+		return nil
+	}
+
+	// Saving re-renders every referencing expectation from the updated library
+	if err := m.Provider.UpdateConfig(context.Background(), modifiedConfig); err != nil {
+		return fmt.Errorf("failed to save schemas: %w", err)
+	}
+	fmt.Printf("✅ Schema library saved (%d schema(s))\n", len(modifiedConfig.Schemas))
+	return nil
+}
+
+// handleManageErrorTaxonomy runs the error taxonomy wizard and persists changes
+func (m *CloudManager) handleManageErrorTaxonomy(expManager *expectations.ExpectationManager, existingConfig *models.MockConfiguration) error {
+	modifiedConfig, err := expManager.ManageErrorTaxonomy(existingConfig)
+	if err != nil {
+		return err
+	}
+	if modifiedConfig == nil {
+		fmt.Println("✅ Error taxonomy unchanged.")
+		return nil
+	}
+	if err := m.Provider.UpdateConfig(context.Background(), modifiedConfig); err != nil {
+		return fmt.Errorf("failed to save error taxonomy: %w", err)
+	}
+	m.errorTaxonomy = modifiedConfig.ErrorTaxonomy
+	fmt.Printf("✅ Error taxonomy saved (%d code(s))\n", len(modifiedConfig.ErrorTaxonomy.Codes))
+	return nil
+}
+
+// handleManageMaskingProfiles runs the masking profile editor and persists changes
+func (m *CloudManager) handleManageMaskingProfiles(expManager *expectations.ExpectationManager, existingConfig *models.MockConfiguration) error {
+	modifiedConfig, err := expManager.ManageMaskingProfiles(existingConfig)
+	if err != nil {
+		return err
+	}
+	if modifiedConfig == nil {
+		fmt.Println("✅ Masking profiles unchanged.")
+		return nil
+	}
+	if err := m.Provider.UpdateConfig(context.Background(), modifiedConfig); err != nil {
+		return fmt.Errorf("failed to save masking profiles: %w", err)
+	}
+	fmt.Printf("✅ Masking profiles saved (%d)\n", len(modifiedConfig.MaskingProfiles))
+	return nil
+}
+
+// handleManageEnvironments runs the environment overlay editor and persists changes
+func (m *CloudManager) handleManageEnvironments(expManager *expectations.ExpectationManager, existingConfig *models.MockConfiguration) error {
+	modifiedConfig, err := expManager.ManageEnvironments(existingConfig)
+	if err != nil {
+		return err
+	}
+	if modifiedConfig == nil {
+		fmt.Println("✅ Environments unchanged.")
+		return nil
+	}
+	if err := m.Provider.UpdateConfig(context.Background(), modifiedConfig); err != nil {
+		return fmt.Errorf("failed to save environments: %w", err)
+	}
+	fmt.Printf("✅ Environments saved (%d)\n", len(modifiedConfig.Environments))
+	return nil
+}
+
+// handleManageFakeData runs the fake-data generator editor and persists changes
+func (m *CloudManager) handleManageFakeData(expManager *expectations.ExpectationManager, existingConfig *models.MockConfiguration) error {
+	modifiedConfig, err := expManager.ManageFakeData(existingConfig)
+	if err != nil {
+		return err
+	}
+	if modifiedConfig == nil {
+		fmt.Println("✅ Fake data generators unchanged.")
+		return nil
+	}
+	if err := m.Provider.UpdateConfig(context.Background(), modifiedConfig); err != nil {
+		return fmt.Errorf("failed to save fake data generators: %w", err)
+	}
+	generators := 0
+	if modifiedConfig.FakeData != nil {
+		generators = len(modifiedConfig.FakeData.Generators)
+	}
+	fmt.Printf("✅ Fake data generators saved (%d)\n", generators)
+	return nil
+}
+
+// handleManageDatasets runs the dataset editor and persists changes
+func (m *CloudManager) handleManageDatasets(expManager *expectations.ExpectationManager, existingConfig *models.MockConfiguration) error {
+	modifiedConfig, err := expManager.ManageDatasets(existingConfig)
+	if err != nil {
+		return err
+	}
+	if modifiedConfig == nil {
+		fmt.Println("✅ Datasets unchanged.")
+		return nil
+	}
+	if err := m.Provider.UpdateConfig(context.Background(), modifiedConfig); err != nil {
+		return fmt.Errorf("failed to save datasets: %w", err)
+	}
+	fmt.Printf("✅ Datasets saved (%d)\n", len(modifiedConfig.Datasets))
+	return nil
+}
+
+// handleDiffVersions compares two saved versions picked from the project's history
+func (m *CloudManager) handleDiffVersions(existingConfig *models.MockConfiguration) error {
+	ctx := context.Background()
+	project := m.getCurrentProject()
+	versions, err := m.Provider.ListVersions(ctx, project)
+	if err != nil {
+		return err
+	}
+	if len(versions) < 2 {
+		fmt.Println("ℹ️  Only one saved version; nothing to compare yet.")
+		return nil
+	}
+	sort.SliceStable(versions, func(i, j int) bool { return versions[i].CreatedAt.After(versions[j].CreatedAt) })
+
+	options := make([]string, len(versions))
+	for i, v := range versions {
+		options[i] = fmt.Sprintf("%s - saved %s", v.Version, v.CreatedAt.Local().Format("2006-01-02 15:04"))
+		switch v.Version {
+		case existingConfig.Metadata.Version:
+			options[i] += " (current)"
+		case existingConfig.Metadata.DeployedVersion:
+			options[i] += " (deployed)"
+		}
+	}
+	var from, to string
+	if err := ask.One(&survey.Select{Message: "Compare from:", Options: options, Default: options[1], PageSize: 12}, &from); err != nil {
+		return err
+	}
+	if err := ask.One(&survey.Select{Message: "To:", Options: options, Default: options[0], PageSize: 12}, &to); err != nil {
+		return err
+	}
+
+	load := func(option string) (*models.MockConfiguration, error) {
+		version := strings.Fields(option)[0]
+		if version == existingConfig.Metadata.Version {
+			return existingConfig, nil
+		}
+		return m.Provider.GetVersion(ctx, project, version)
+	}
+	fromCfg, err := load(from)
+	if err != nil {
+		return err
+	}
+	toCfg, err := load(to)
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	fmt.Print(models.DiffVersions(fromCfg, toCfg).Format())
+	return nil
+}
+
+// handleManageExamples runs the response example library editor and persists changes
+func (m *CloudManager) handleManageExamples(expManager *expectations.ExpectationManager, existingConfig *models.MockConfiguration) error {
+	modifiedConfig, err := expManager.ManageExamples(existingConfig)
+	if err != nil {
+		return err
+	}
+	if modifiedConfig == nil {
+		fmt.Println("✅ Example library unchanged.")
+		return nil
+	}
+	if err := m.Provider.UpdateConfig(context.Background(), modifiedConfig); err != nil {
+		return fmt.Errorf("failed to save examples: %w", err)
+	}
+	fmt.Printf("✅ Example library saved (%d example(s))\n", len(modifiedConfig.BodyExamples))
+	return nil
+}
+
+// handleManageTenants runs the tenant editor and persists changes
+func (m *CloudManager) handleManageTenants(expManager *expectations.ExpectationManager, existingConfig *models.MockConfiguration) error {
+	modifiedConfig, err := expManager.ManageTenants(existingConfig)
+	if err != nil {
+		return err
+	}
+	if modifiedConfig == nil {
+		fmt.Println("✅ Tenants unchanged.")
+		return nil
+	}
+
+	// Saving regenerates the tenants' expectations from their overlays
+	if err := m.Provider.UpdateConfig(context.Background(), modifiedConfig); err != nil {
+		return fmt.Errorf("failed to save tenants: %w", err)
+	}
+	fmt.Println("✅ Tenants saved")
+	return nil
+}
+
 // Handle final result
 func (m *CloudManager) handleGeneratedMock(mockConfiguration string) error {
+	options := []string{
+		"save - Save the expectation file",
+		"view - View full JSON configuration",
+		"local - Start MockServer locally",
+		"exit - Exit without saving",
+	}
+	if m.Provider == nil {
+		fmt.Println("📴 Offline: 'local' writes the expectations to a file; upload it with 'automock init' once you are back online")
+		options = options[1:]
+	}
 	for {
 		var action string
-		if err := survey.AskOne(&survey.Select{
+		if err := ask.One(&survey.Select{
 			Message: "What would you like to do with this configuration?",
-			Options: []string{
-				"save - Save the expectation file",
-				"view - View full JSON configuration",
-				"local - Start MockServer locally",
-				"exit - Exit without saving",
-			},
+			Options: options,
 		}, &action); err != nil {
 			return err
 		}
@@ -480,7 +895,7 @@ func (m *CloudManager) handleGeneratedMock(mockConfiguration string) error {
 			fmt.Println("\n⚠️  Are you sure you want to exit without saving?")
 			fmt.Println("   • The uploaded expectations will not be saved")
 			var confirmExit bool
-			if err := survey.AskOne(&survey.Confirm{
+			if err := ask.One(&survey.Confirm{
 				Message: "Exit without saving?",
 				Default: false,
 			}, &confirmExit); err != nil {
@@ -513,6 +928,10 @@ func (m *CloudManager) saveToFile(mockServerJSON string) error {
 	mockConfig.Metadata.Version = fmt.Sprintf("v%d", time.Now().Unix())
 	mockConfig.Metadata.CreatedAt = time.Now()
 	mockConfig.Metadata.UpdatedAt = time.Now()
+	mockConfig.ErrorTaxonomy = m.errorTaxonomy
+	if added := mockConfig.HarvestBodyExamples("import"); added > 0 {
+		fmt.Printf("📚 Added %d response example(s) to the project library\n", added)
+	}
 
 	// Persist via provider (cloud storage abstraction)
 	if err := m.Provider.SaveConfig(ctx, mockConfig); err != nil {
@@ -134,12 +134,14 @@ func NormalizeProjectID(projectID string) string {
 	return normalized
 }
 
-// ===== Load Test (Locust) Naming Helpers =====
+// ===== Load Test (Locust, k6) Naming Helpers =====
 // These helpers generate stable S3 object keys for load test bundles, versions and metadata.
 // Layout (agreed):
 // configs/<project>-loadtest/current.json                -> active pointer to latest uploaded bundle version
 // configs/<project>-loadtest/versions/v<ts>.json         -> immutable pointer snapshot for a version
-// configs/<project>-loadtest/bundles/<bundleID>/...      -> bundle directory (locustfile.py, requirements.txt, user_data.yaml, manifest.json, etc.)
+// configs/<project>-loadtest/bundles/<bundleID>/...      -> bundle directory (locustfile.py or script.js, endpoint spec, user data, manifest.json, etc.)
+// configs/<project>-loadtest/results/<version>/<run>.json -> summarized results of a run of that version
+// configs/<project>-loadtest/schedule.json               -> scheduled runs of the active bundle
 // metadata/<project>-loadtest.json                      -> lightweight summary / index for load test (parallel to mock config metadata)
 //
 // projectID passed in may be either the raw project id or a storage name; we always normalize/extract first.
@@ -183,6 +185,24 @@ func (n *DefaultNaming) LoadTestBundleFileKey(projectID, bundleID, fileName stri
 	return fmt.Sprintf("configs/%s/bundles/%s/%s", lt, bundleID, fileName)
 }
 
+// LoadTestResultKey returns the key for the summarized results of a run of a bundle version
+func (n *DefaultNaming) LoadTestResultKey(projectID, version, runID string) string {
+	lt := n.LoadTestProjectID(projectID)
+	return fmt.Sprintf("configs/%s/results/%s/%s.json", lt, version, runID)
+}
+
+// LoadTestResultsPrefix returns the prefix under which the results of all runs are stored
+func (n *DefaultNaming) LoadTestResultsPrefix(projectID string) string {
+	lt := n.LoadTestProjectID(projectID)
+	return fmt.Sprintf("configs/%s/results/", lt)
+}
+
+// LoadTestScheduleKey returns the key describing the scheduled runs of the project
+func (n *DefaultNaming) LoadTestScheduleKey(projectID string) string {
+	lt := n.LoadTestProjectID(projectID)
+	return fmt.Sprintf("configs/%s/schedule.json", lt)
+}
+
 // LoadTestMetadataKey returns the key for the load test metadata index file
 func (n *DefaultNaming) LoadTestMetadataKey(projectID string) string {
 	lt := n.LoadTestProjectID(projectID)
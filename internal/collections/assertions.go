@@ -0,0 +1,46 @@
+package collections
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hemantobora/auto-mock/internal/checks"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// recordedChecks converts the test assertions of a request's post-script into checks,
+// keeping only those the recorded response satisfied so `automock test` holds the mock to
+// what the real API actually did
+func (cp *CollectionProcessor) recordedChecks(api APIRequest, resp *APIResponse) []models.ResponseCheck {
+	if api.PostScript == "" || resp == nil {
+		return nil
+	}
+	parsed, unsupported := checks.Parse(cp.normalizeScript(api.PostScript))
+	if len(parsed) == 0 && len(unsupported) == 0 {
+		return nil
+	}
+
+	recorded := &checks.Response{StatusCode: resp.StatusCode, Headers: http.Header{}, Body: []byte(resp.Body), Latency: resp.Duration}
+	for name, value := range resp.Headers {
+		recorded.Headers.Set(name, value)
+	}
+	var kept []models.ResponseCheck
+	failed := 0
+	for _, c := range parsed {
+		if checks.Evaluate(c, recorded) != nil {
+			failed++
+			continue
+		}
+		kept = append(kept, c)
+	}
+
+	fmt.Printf("🧪 Captured %d test assertion(s) as checks", len(kept))
+	if failed > 0 {
+		fmt.Printf(", dropped %d the recorded response failed", failed)
+	}
+	fmt.Println()
+	for _, stmt := range unsupported {
+		fmt.Printf("   ⚠️  Not converted: %s\n", stmt)
+	}
+	return kept
+}
@@ -0,0 +1,169 @@
+package collections
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+)
+
+// Defaults for large collection imports
+const (
+	DefaultChunkSize   = 50   // requests executed and reviewed per chunk
+	DefaultMaxRequests = 2000 // larger imports are refused with guidance
+)
+
+// Chunk review modes for matching configuration
+const (
+	reviewEach        = "Review each request"
+	reviewDefaults    = "Use default matching for this chunk"
+	reviewDefaultsAll = "Use default matching for all remaining chunks"
+)
+
+// ImportOptions tunes a collection import beyond the files and their type
+type ImportOptions struct {
+	IterationData string // newman -d style CSV/JSON file
+	ChunkSize     int    // requests per execution/review chunk (0 = DefaultChunkSize)
+	MaxRequests   int    // refuse imports with more requests (0 = DefaultMaxRequests)
+	Concurrency   int    // independent requests executed at once (0 = DefaultConcurrency)
+}
+
+// SetLimits overrides the chunk size and request limit; zero keeps the default
+func (cp *CollectionProcessor) SetLimits(maxRequests, chunkSize int) {
+	if maxRequests > 0 {
+		cp.maxRequests = maxRequests
+	}
+	if chunkSize > 0 {
+		cp.chunkSize = chunkSize
+	}
+}
+
+// checkRequestLimit fails an import that would exceed the request limit
+func (cp *CollectionProcessor) checkRequestLimit(count int) error {
+	if cp.maxRequests <= 0 || count <= cp.maxRequests {
+		return nil
+	}
+	return fmt.Errorf("collection has %d requests, above the limit of %d; split it into smaller files "+
+		"(e.g. export folders separately) and import them one at a time, or raise --max-requests", count, cp.maxRequests)
+}
+
+// executeInChunks runs the requests, asking once per chunk instead of once per request when
+// the collection is larger than one chunk. Variables carry over between chunks.
+func (cp *CollectionProcessor) executeInChunks(nodes []ExecutionNode) error {
+	variables := make(map[string]string) // in-memory only, cleared after execution
+	defer func() {
+		fmt.Println("\n🧹 Clearing in-memory variables...")
+		clear(variables)
+	}()
+
+	if !cp.chunked {
+		return cp.executeAPIs(nodes, variables)
+	}
+
+	chunks := (len(nodes) + cp.chunkSize - 1) / cp.chunkSize
+	fmt.Printf("\n📦 Large collection: %d requests in %d chunks of up to %d\n", len(nodes), chunks, cp.chunkSize)
+	fmt.Println("   Failed requests are recorded and summarized per chunk instead of prompting.")
+	for c := 0; c < chunks; c++ {
+		start, end := c*cp.chunkSize, min((c+1)*cp.chunkSize, len(nodes))
+		chunk := nodes[start:end]
+		fmt.Printf("\n📦 Chunk %d/%d — requests %d-%d: %s\n", c+1, chunks, start+1, end, chunkSummary(chunk))
+
+		var action string
+		if err := ask.One(&survey.Select{
+			Message: "Execute this chunk?",
+			Options: []string{"Execute", "Skip this chunk", "Stop here (keep executed requests)"},
+			Default: "Execute",
+		}, &action); err != nil {
+			return err
+		}
+		switch action {
+		case "Skip this chunk":
+			continue
+		case "Stop here (keep executed requests)":
+			return nil
+		}
+
+		if err := cp.executeAPIs(chunk, variables); err != nil {
+			return err
+		}
+		failed := 0
+		for _, n := range chunk {
+			if n.Response == nil || n.Response.StatusCode >= 500 {
+				failed++
+			}
+		}
+		if failed > 0 {
+			fmt.Printf("⚠️  Chunk %d/%d: %d of %d request(s) failed or returned 5xx\n", c+1, chunks, failed, len(chunk))
+		}
+	}
+	return nil
+}
+
+// askChunkReview asks how matching is configured for the chunk starting at start
+func askChunkReview(nodes []ExecutionNode, start, size int) (string, error) {
+	chunk := nodes[start:min(start+size, len(nodes))]
+	recorded := 0
+	for _, n := range chunk {
+		if n.Response != nil {
+			recorded++
+		}
+	}
+	if recorded == 0 {
+		return reviewDefaults, nil
+	}
+	fmt.Printf("\n📦 Matching for requests %d-%d (%d recorded): %s\n", start+1, start+len(chunk), recorded, chunkSummary(chunk))
+	fmt.Println("   Default matching: method, exact path and recorded query parameters; recorded response, no prompts.")
+
+	var mode string
+	if err := ask.One(&survey.Select{
+		Message: "How should matching be configured?",
+		Options: []string{reviewDefaults, reviewEach, reviewDefaultsAll},
+		Default: reviewDefaults,
+	}, &mode); err != nil {
+		return "", err
+	}
+	return mode, nil
+}
+
+// chunkSummary condenses requests into method counts and hosts, e.g. "30 GET, 20 POST · api.example.com"
+func chunkSummary(nodes []ExecutionNode) string {
+	methods := map[string]int{}
+	hosts := map[string]bool{}
+	for _, n := range nodes {
+		methods[strings.ToUpper(n.API.Method)]++
+		if u, err := url.Parse(n.API.URL); err == nil && u.Host != "" {
+			hosts[u.Host] = true
+		}
+	}
+	names := make([]string, 0, len(methods))
+	for m := range methods {
+		names = append(names, m)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if methods[names[i]] != methods[names[j]] {
+			return methods[names[i]] > methods[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	parts := make([]string, len(names))
+	for i, m := range names {
+		parts[i] = fmt.Sprintf("%d %s", methods[m], m)
+	}
+	summary := strings.Join(parts, ", ")
+
+	hostList := make([]string, 0, len(hosts))
+	for h := range hosts {
+		hostList = append(hostList, h)
+	}
+	sort.Strings(hostList)
+	if len(hostList) > 3 {
+		hostList = append(hostList[:3], fmt.Sprintf("+%d more", len(hostList)-3))
+	}
+	if len(hostList) > 0 {
+		summary += " · " + strings.Join(hostList, ", ")
+	}
+	return summary
+}
@@ -0,0 +1,280 @@
+package collections
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultConcurrency keeps execution sequential; --concurrency opts into parallel stages
+const DefaultConcurrency = 1
+
+var (
+	scriptSetPattern    = regexp.MustCompile(`pm\.(?:environment|globals|collectionVariables|variables)\.set\(`)
+	scriptSetLiteral    = regexp.MustCompile("pm\\.(?:environment|globals|collectionVariables|variables)\\.set\\(\\s*[\"'`]([^\"'`]+)[\"'`]")
+	scriptGetPattern    = regexp.MustCompile(`pm\.(?:environment|globals|collectionVariables|variables)\.get\(`)
+	scriptGetLiteral    = regexp.MustCompile("pm\\.(?:environment|globals|collectionVariables|variables)\\.get\\(\\s*[\"'`]([^\"'`]+)[\"'`]")
+	identifierLikeValue = regexp.MustCompile(`^(?:\d+|[0-9a-fA-F-]{16,})$`)
+)
+
+// SetConcurrency sets how many independent requests may run at once; values below 1 are ignored
+func (cp *CollectionProcessor) SetConcurrency(n int) {
+	if n > 0 {
+		cp.concurrency = n
+	}
+}
+
+// nodeAccess is what a request reads and writes: variable names, plus "resource <host/path>"
+// keys so a write to an endpoint stays ordered with the other requests on that resource
+type nodeAccess struct {
+	reads   []string
+	writes  []string
+	barrier bool // a script sets or reads variables by computed name; order against everything
+}
+
+// accessOf derives a request's variable and resource accesses from its placeholders and scripts
+func (cp *CollectionProcessor) accessOf(api APIRequest) nodeAccess {
+	var acc nodeAccess
+	if api.Documented != nil {
+		return acc // not executed, no scripts run
+	}
+
+	reads := map[string]bool{}
+	for _, v := range cp.ExtractVariablesFromAPI(&api, true) {
+		reads[v] = true
+	}
+	writes := map[string]bool{}
+	for _, script := range []string{api.PreScript, api.PostScript} {
+		if script == "" {
+			continue
+		}
+		script = cp.normalizeScript(script)
+		sets := scriptSetLiteral.FindAllStringSubmatch(script, -1)
+		gets := scriptGetLiteral.FindAllStringSubmatch(script, -1)
+		if len(sets) != len(scriptSetPattern.FindAllString(script, -1)) ||
+			len(gets) != len(scriptGetPattern.FindAllString(script, -1)) {
+			acc.barrier = true
+		}
+		for _, m := range sets {
+			writes[m[1]] = true
+		}
+		for _, m := range gets {
+			reads[m[1]] = true
+		}
+	}
+
+	resource := "resource " + resourceKey(api.URL)
+	reads[resource] = true
+	switch api.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		writes[resource] = true
+	}
+
+	acc.reads = sortedSet(reads)
+	acc.writes = sortedSet(writes)
+	return acc
+}
+
+// resourceKey reduces a URL to host plus the path up to its first identifier segment, so
+// /users, /users/{{id}} and /users/42/orders share the key "<host>/users"
+func resourceKey(rawURL string) string {
+	s := rawURL
+	if i := strings.IndexAny(s, "?#"); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.Index(s, "://"); i >= 0 {
+		s = s[i+3:]
+	}
+	segments := strings.Split(s, "/")
+	key := []string{segments[0]}
+	for _, seg := range segments[1:] {
+		if seg == "" {
+			continue
+		}
+		if strings.Contains(seg, "{{") || strings.Contains(seg, "${") || strings.HasPrefix(seg, ":") ||
+			identifierLikeValue.MatchString(seg) {
+			break
+		}
+		key = append(key, seg)
+	}
+	return strings.Join(key, "/")
+}
+
+// analyzeDependencies returns, for each node, the indices of earlier nodes it must run after:
+// the last writer of anything it reads or writes, and the readers a write would overwrite
+// underneath. Collection order decides between conflicting requests, as in sequential runs.
+func (cp *CollectionProcessor) analyzeDependencies(nodes []ExecutionNode) [][]int {
+	deps := make([][]int, len(nodes))
+	lastWriter := map[string]int{}
+	readers := map[string][]int{} // readers since the last write of each key
+	lastBarrier := -1
+	for i, node := range nodes {
+		acc := cp.accessOf(node.API)
+		set := map[int]bool{}
+		if acc.barrier {
+			for j := max(lastBarrier, 0); j < i; j++ {
+				set[j] = true
+			}
+		} else if lastBarrier >= 0 {
+			set[lastBarrier] = true
+		}
+		for _, key := range acc.reads {
+			if w, ok := lastWriter[key]; ok {
+				set[w] = true
+			}
+		}
+		for _, key := range acc.writes {
+			if w, ok := lastWriter[key]; ok {
+				set[w] = true
+			}
+			for _, r := range readers[key] {
+				set[r] = true
+			}
+		}
+		delete(set, i)
+		for j := range set {
+			deps[i] = append(deps[i], j)
+		}
+		sort.Ints(deps[i])
+
+		if acc.barrier {
+			lastBarrier = i
+			clear(lastWriter)
+			clear(readers)
+			continue
+		}
+		for _, key := range acc.reads {
+			readers[key] = append(readers[key], i)
+		}
+		for _, key := range acc.writes {
+			lastWriter[key] = i
+			readers[key] = nil
+		}
+	}
+	return deps
+}
+
+// executionStages groups node indices so that each stage only depends on earlier stages;
+// the requests inside a stage are independent of each other
+func executionStages(deps [][]int) [][]int {
+	level := make([]int, len(deps))
+	var stages [][]int
+	for i, ds := range deps {
+		for _, d := range ds {
+			level[i] = max(level[i], level[d]+1)
+		}
+		if level[i] == len(stages) {
+			stages = append(stages, nil)
+		}
+		stages[level[i]] = append(stages[level[i]], i)
+	}
+	return stages
+}
+
+// linkDependencies records each node's dependencies (by request ID) and the variables its
+// scripts set, and returns the execution stages
+func (cp *CollectionProcessor) linkDependencies(nodes []ExecutionNode) [][]int {
+	deps := cp.analyzeDependencies(nodes)
+	for i := range nodes {
+		nodes[i].Dependencies = []string{}
+		for _, d := range deps[i] {
+			nodes[i].Dependencies = append(nodes[i].Dependencies, nodeLabel(nodes, d))
+		}
+		nodes[i].Variables = []string{}
+		for _, key := range cp.accessOf(nodes[i].API).writes {
+			if !strings.HasPrefix(key, "resource ") {
+				nodes[i].Variables = append(nodes[i].Variables, key)
+			}
+		}
+	}
+	return executionStages(deps)
+}
+
+// nodeLabel identifies a node by request ID, falling back to its position
+func nodeLabel(nodes []ExecutionNode, i int) string {
+	if nodes[i].API.ID != "" {
+		return nodes[i].API.ID
+	}
+	return fmt.Sprintf("#%d", i+1)
+}
+
+// executeConcurrently runs the nodes stage by stage. Pre-scripts, variable prompts and
+// post-scripts stay sequential in collection order; only the HTTP calls of a stage run in
+// parallel, on up to cp.concurrency workers.
+func (cp *CollectionProcessor) executeConcurrently(nodes []ExecutionNode, variables map[string]string) error {
+	stages := executionStages(cp.analyzeDependencies(nodes))
+	fmt.Printf("\n🚀 EXECUTING APIs IN PARALLEL (%d stage(s), up to %d at a time)\n", len(stages), cp.concurrency)
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	cp.warmupConnections(nodes)
+
+	done := 0
+	for s, stage := range stages {
+		fmt.Printf("\n⚡ Stage %d/%d: %d request(s) — %d/%d done\n", s+1, len(stages), len(stage), done, len(nodes))
+
+		// Resolve variables first: scripts and prompts must not interleave
+		var ready []int
+		for _, i := range stage {
+			node := &nodes[i]
+			fmt.Printf("\n▶️  [%d/%d] Preparing: %s\n", i+1, len(nodes), node.API.Name)
+			if node.API.Documented != nil {
+				node.Response = node.API.Documented
+				fmt.Printf("   📘 Documented response: %d\n", node.Response.StatusCode)
+				continue
+			}
+			ok, err := cp.prepareRequest(node, variables)
+			if err != nil {
+				return err
+			}
+			if ok {
+				ready = append(ready, i)
+			}
+		}
+
+		responses := make([]*APIResponse, len(ready))
+		errs := make([]error, len(ready))
+		if len(ready) > 0 {
+			fmt.Printf("\n   ⏳ Making %d API call(s)...\n", len(ready))
+			jobs := make(chan int)
+			var wg sync.WaitGroup
+			for w := 0; w < min(cp.concurrency, len(ready)); w++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for k := range jobs {
+						responses[k], errs[k] = cp.executeAPI(nodes[ready[k]].API, variables)
+					}
+				}()
+			}
+			for k := range ready {
+				jobs <- k
+			}
+			close(jobs)
+			wg.Wait()
+		}
+
+		for k, i := range ready {
+			fmt.Printf("\n◀️  [%d/%d] %s\n", i+1, len(nodes), nodes[i].API.Name)
+			if err := cp.recordResponse(&nodes[i], responses[k], errs[k], variables); err != nil {
+				return err
+			}
+		}
+		done += len(stage)
+	}
+
+	fmt.Printf("\n🎉 Executed %d APIs successfully!\n", len(nodes))
+	return nil
+}
+
+// sortedSet returns the keys of a set in order
+func sortedSet(set map[string]bool) []string {
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,39 @@
+package collections
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/security"
+)
+
+// Run with -race: the workers of a parallel stage share the processor's client
+func TestExecuteConcurrentlySharesClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"path": %q}`, r.URL.Path)
+	}))
+	defer srv.Close()
+
+	cp, err := NewCollectionProcessor("race", "postman")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp.sanitizer = security.NewCollectionSanitizer()
+	cp.SetConcurrency(4)
+
+	var nodes []ExecutionNode
+	for _, name := range []string{"users", "orders", "invoices"} {
+		nodes = append(nodes, ExecutionNode{API: APIRequest{Name: name, Method: "GET", URL: srv.URL + "/" + name}})
+	}
+	if err := cp.executeAPIs(nodes, map[string]string{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range nodes {
+		if n.Response == nil || n.Response.StatusCode != http.StatusOK {
+			t.Errorf("%s: response %+v", n.API.Name, n.Response)
+		}
+	}
+}
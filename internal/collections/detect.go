@@ -0,0 +1,127 @@
+package collections
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+)
+
+// Collection types understood by the processor ("auto" sniffs the file contents)
+const (
+	CollectionTypeAuto     = "auto"
+	CollectionTypePostman  = "postman"
+	CollectionTypeBruno    = "bruno"
+	CollectionTypeInsomnia = "insomnia"
+	CollectionTypeOpenAPI  = "openapi"
+	CollectionTypeProto    = "proto"
+)
+
+var (
+	bruMetaBlock   = regexp.MustCompile(`(?m)^\s*meta\s*\{`)
+	yamlOpenAPIKey = regexp.MustCompile(`(?m)^\s*["']?(openapi|swagger)["']?\s*:`)
+	yamlInsomnia   = regexp.MustCompile(`(?m)^\s*(_type:\s*export|type:\s*collection\.insomnia\.rest)`)
+	protoSyntax    = regexp.MustCompile(`(?m)^\s*(syntax\s*=\s*["']proto[23]["']|service\s+\w+\s*\{)`)
+)
+
+// sniffCollectionTypes returns every collection format the file looks like,
+// most specific markers first. An empty or multi-entry result means ambiguous.
+func sniffCollectionTypes(data []byte, filePath string) []string {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		text := string(data)
+		var types []string
+		if strings.EqualFold(filepath.Ext(filePath), ".bru") || bruMetaBlock.MatchString(text) {
+			types = append(types, CollectionTypeBruno)
+		}
+		if yamlOpenAPIKey.MatchString(text) {
+			types = append(types, CollectionTypeOpenAPI)
+		}
+		if yamlInsomnia.MatchString(text) {
+			types = append(types, CollectionTypeInsomnia)
+		}
+		if strings.EqualFold(filepath.Ext(filePath), ".proto") || protoSyntax.MatchString(text) {
+			types = append(types, CollectionTypeProto)
+		}
+		return types
+	}
+
+	var types []string
+	if info, ok := doc["info"].(map[string]interface{}); ok {
+		schema, _ := info["schema"].(string)
+		if _, ok := info["_postman_id"]; ok || strings.Contains(schema, "getpostman.com") {
+			types = append(types, CollectionTypePostman)
+		}
+	}
+	if _, ok := doc["_type"]; ok {
+		types = append(types, CollectionTypeInsomnia)
+	} else if resources, ok := doc["resources"].([]interface{}); ok && hasInsomniaResource(resources) {
+		types = append(types, CollectionTypeInsomnia)
+	}
+	if _, ok := doc["brunoConfig"]; ok {
+		types = append(types, CollectionTypeBruno)
+	}
+	if _, ok := doc["openapi"]; ok {
+		types = append(types, CollectionTypeOpenAPI)
+	} else if _, ok := doc["swagger"]; ok {
+		types = append(types, CollectionTypeOpenAPI)
+	}
+	if len(types) > 0 {
+		return types
+	}
+
+	// Weaker structural hints when no explicit marker is present
+	if _, ok := doc["item"].([]interface{}); ok {
+		types = append(types, CollectionTypePostman)
+	}
+	if _, ok := doc["items"].([]interface{}); ok {
+		types = append(types, CollectionTypeBruno)
+	}
+	return types
+}
+
+// hasInsomniaResource reports whether any resource carries an Insomnia _type
+func hasInsomniaResource(resources []interface{}) bool {
+	for _, r := range resources {
+		if m, ok := r.(map[string]interface{}); ok {
+			if _, ok := m["_type"]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// DetectCollectionType sniffs the collection format from its contents,
+// prompting only when the file matches no known format or several of them
+func DetectCollectionType(data []byte, filePath string) (string, error) {
+	types := sniffCollectionTypes(data, filePath)
+	if len(types) == 1 {
+		return types[0], nil
+	}
+
+	options := types
+	message := fmt.Sprintf("%s matches several formats, select collection type:", filepath.Base(filePath))
+	if len(types) == 0 {
+		options = []string{CollectionTypePostman, CollectionTypeBruno, CollectionTypeInsomnia, CollectionTypeOpenAPI, CollectionTypeProto}
+		message = fmt.Sprintf("Could not detect the format of %s, select collection type:", filepath.Base(filePath))
+	}
+
+	var selected string
+	if err := ask.One(&survey.Select{
+		Message: message,
+		Options: options,
+	}, &selected); err != nil {
+		return "", fmt.Errorf("collection type for %s: %w", filePath, err)
+	}
+	return selected, nil
+}
+
+// isAutoCollectionType reports whether the type should be detected per file
+func isAutoCollectionType(collectionType string) bool {
+	return collectionType == "" || collectionType == CollectionTypeAuto
+}
@@ -0,0 +1,212 @@
+package collections
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+)
+
+var iterationDataGet = regexp.MustCompile(`pm\.iterationData\.get\(\s*["']([^"']+)["']\s*\)`)
+
+// LoadIterationData reads a Newman-style data file (newman -d): a CSV file whose header row
+// names the fields, or a JSON array of objects. Each row is one iteration.
+func LoadIterationData(path string) ([]map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read iteration data: %w", err)
+	}
+	trimmed := bytes.TrimSpace(data)
+	var rows []map[string]string
+	if strings.EqualFold(filepath.Ext(path), ".json") || bytes.HasPrefix(trimmed, []byte("[")) {
+		var objects []map[string]interface{}
+		if err := json.Unmarshal(trimmed, &objects); err != nil {
+			return nil, fmt.Errorf("iteration data %s must be a JSON array of objects: %w", path, err)
+		}
+		for _, obj := range objects {
+			row := make(map[string]string, len(obj))
+			for k, v := range obj {
+				switch t := v.(type) {
+				case string:
+					row[k] = t
+				case map[string]interface{}, []interface{}:
+					b, _ := json.Marshal(t)
+					row[k] = string(b)
+				default:
+					row[k] = fmt.Sprint(t)
+				}
+			}
+			rows = append(rows, row)
+		}
+	} else {
+		records, err := csv.NewReader(bytes.NewReader(trimmed)).ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV iteration data %s: %w", path, err)
+		}
+		if len(records) < 2 {
+			return nil, fmt.Errorf("iteration data %s needs a header row and at least one data row", path)
+		}
+		header := records[0]
+		for _, record := range records[1:] {
+			row := make(map[string]string, len(header))
+			for i, name := range header {
+				if i < len(record) {
+					row[strings.TrimSpace(name)] = record[i]
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("iteration data %s has no rows", path)
+	}
+	return rows, nil
+}
+
+// SetIterationData makes requests that reference data fields ({{field}} or
+// pm.iterationData.get) run once per row
+func (cp *CollectionProcessor) SetIterationData(rows []map[string]string) {
+	cp.iterationData = rows
+}
+
+// expandIterations repeats every data-driven request once per iteration row, with the row's
+// values substituted. Requests that reference no data field run once as before.
+func (cp *CollectionProcessor) expandIterations(nodes []ExecutionNode) []ExecutionNode {
+	if len(cp.iterationData) == 0 {
+		return nodes
+	}
+	fieldSet := map[string]bool{}
+	for _, row := range cp.iterationData {
+		for k := range row {
+			fieldSet[k] = true
+		}
+	}
+
+	var out []ExecutionNode
+	driven := 0
+	for _, node := range nodes {
+		fields := referencedFields(node.API, fieldSet)
+		if len(fields) == 0 {
+			out = append(out, node)
+			continue
+		}
+		driven++
+		for i, row := range cp.iterationData {
+			n := node
+			n.API = applyIteration(node.API, row)
+			n.API.Iteration = i + 1
+			n.API.IterationKey = iterationKey(fields, row)
+			n.API.ID = fmt.Sprintf("%s_it%d", node.API.ID, i+1)
+			n.API.Name = fmt.Sprintf("%s [%s]", node.API.Name, n.API.IterationKey)
+			out = append(out, n)
+		}
+	}
+	if driven > 0 {
+		fmt.Printf("🔁 %d data-driven request(s) × %d iteration(s) → %d requests to execute\n", driven, len(cp.iterationData), len(out))
+	} else {
+		fmt.Println("ℹ️  No request references the iteration data fields; each request runs once")
+	}
+	return out
+}
+
+// askIterationVariants asks whether each iteration becomes its own expectation, matched on
+// the values that distinguish it, or whether iterations merge into one with examples
+func (cp *CollectionProcessor) askIterationVariants(nodes []ExecutionNode) error {
+	var keys []string
+	for _, n := range nodes {
+		if n.API.Iteration == 1 && n.Response != nil {
+			keys = append(keys, n.API.IterationKey)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return ask.One(&survey.Confirm{
+		Message: "Create one expectation per iteration (keyed by the data fields each request uses)?",
+		Default: false,
+		Help:    "Yes: each data row gets its own request matcher and recorded response. No: iterations of a request merge into one expectation that keeps every row as an example.",
+	}, &cp.iterationVariants)
+}
+
+// referencedFields lists the data fields a request uses, sorted
+func referencedFields(api APIRequest, fieldSet map[string]bool) []string {
+	texts := []string{api.URL, api.Body, api.PreScript, api.PostScript}
+	for k, v := range api.Headers {
+		texts = append(texts, k, v)
+	}
+	for k, v := range api.QueryParams {
+		texts = append(texts, k, v)
+	}
+	all := strings.Join(texts, "\n")
+
+	var fields []string
+	for field := range fieldSet {
+		if strings.Contains(all, "{{"+field+"}}") {
+			fields = append(fields, field)
+			continue
+		}
+		for _, m := range iterationDataGet.FindAllStringSubmatch(all, -1) {
+			if m[1] == field {
+				fields = append(fields, field)
+				break
+			}
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// applyIteration substitutes a data row into a copy of the request
+func applyIteration(api APIRequest, row map[string]string) APIRequest {
+	subst := func(s string) string {
+		for k, v := range row {
+			s = strings.ReplaceAll(s, "{{"+k+"}}", v)
+		}
+		return s
+	}
+	script := func(s string) string {
+		return iterationDataGet.ReplaceAllStringFunc(subst(s), func(m string) string {
+			field := iterationDataGet.FindStringSubmatch(m)[1]
+			if v, ok := row[field]; ok {
+				return strconv.Quote(v)
+			}
+			return "undefined"
+		})
+	}
+
+	out := api
+	out.URL = subst(api.URL)
+	out.Body = subst(api.Body)
+	out.PreScript = script(api.PreScript)
+	out.PostScript = script(api.PostScript)
+	out.Headers = make(map[string]string, len(api.Headers))
+	for k, v := range api.Headers {
+		out.Headers[subst(k)] = subst(v)
+	}
+	out.QueryParams = make(map[string]string, len(api.QueryParams))
+	for k, v := range api.QueryParams {
+		out.QueryParams[subst(k)] = subst(v)
+	}
+	out.Variables = make(map[string]string, len(api.Variables))
+	for k, v := range api.Variables {
+		out.Variables[k] = v
+	}
+	return out
+}
+
+func iterationKey(fields []string, row map[string]string) string {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = f + "=" + row[f]
+	}
+	return strings.Join(parts, ", ")
+}
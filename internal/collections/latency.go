@@ -0,0 +1,74 @@
+package collections
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// askLatencyPercent asks whether observed response times should become
+// expectation delays, and by how much they are scaled (0 = keep no delays)
+func askLatencyPercent(nodes []ExecutionNode) (int, error) {
+	var total time.Duration
+	var count int
+	for _, n := range nodes {
+		if n.Response != nil && n.Response.Duration > 0 {
+			total += n.Response.Duration
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, nil
+	}
+
+	fmt.Printf("\n⏱️  Observed latency: avg %dms across %d responses\n", (total / time.Duration(count)).Milliseconds(), count)
+
+	var choice string
+	if err := ask.One(&survey.Select{
+		Message: "Use observed response times as expectation delays?",
+		Options: []string{"no", "100% of observed", "50% of observed", "custom %"},
+		Default: "no",
+		Help:    "Makes the mock's timing profile resemble the real API, e.g. for realistic load tests.",
+	}, &choice); err != nil {
+		return 0, err
+	}
+
+	switch choice {
+	case "100% of observed":
+		return 100, nil
+	case "50% of observed":
+		return 50, nil
+	case "custom %":
+		var pctStr string
+		if err := ask.One(&survey.Input{
+			Message: "Percentage of observed latency (e.g., 75):",
+			Default: "100",
+		}, &pctStr, survey.WithValidator(survey.Required)); err != nil {
+			return 0, err
+		}
+		pct, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(pctStr), "%"))
+		if err != nil || pct < 0 {
+			return 0, fmt.Errorf("invalid percentage: %q", pctStr)
+		}
+		return pct, nil
+	default:
+		return 0, nil
+	}
+}
+
+// observedDelay scales a captured response duration into a MockServer delay
+func observedDelay(d time.Duration, percent int) *models.Delay {
+	if d <= 0 || percent <= 0 {
+		return nil
+	}
+	ms := int(d.Milliseconds()) * percent / 100
+	if ms <= 0 {
+		return nil
+	}
+	return &models.Delay{TimeUnit: "MILLISECONDS", Value: ms}
+}
@@ -0,0 +1,124 @@
+package collections
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// collectionExtensions are the file types picked up when a directory is given
+var collectionExtensions = map[string]bool{
+	".json":  true,
+	".bru":   true,
+	".yaml":  true,
+	".yml":   true,
+	".proto": true,
+}
+
+// ExpandCollectionPaths resolves --collection-file arguments into concrete files.
+// Each argument may be a file, a glob pattern (e.g. ./collections/*.json) or a
+// directory, in which case its collection files are used (non-recursive).
+// Duplicates are dropped while keeping the order in which files were given.
+func ExpandCollectionPaths(args []string) ([]string, error) {
+	var files []string
+	seen := map[string]bool{}
+	add := func(path string) {
+		clean := filepath.Clean(path)
+		if !seen[clean] {
+			seen[clean] = true
+			files = append(files, clean)
+		}
+	}
+
+	for _, arg := range args {
+		arg = strings.TrimSpace(arg)
+		if arg == "" {
+			continue
+		}
+
+		if strings.ContainsAny(arg, "*?[") {
+			matches, err := filepath.Glob(arg)
+			if err != nil {
+				return nil, fmt.Errorf("invalid collection glob %q: %w", arg, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no collection files match %q", arg)
+			}
+			sort.Strings(matches)
+			for _, m := range matches {
+				if info, err := os.Stat(m); err == nil && !info.IsDir() {
+					add(m)
+				}
+			}
+			continue
+		}
+
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, fmt.Errorf("collection file %q: %w", arg, err)
+		}
+		if !info.IsDir() {
+			add(arg)
+			continue
+		}
+
+		entries, err := os.ReadDir(arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read collection directory %q: %w", arg, err)
+		}
+		found := 0
+		for _, e := range entries {
+			if e.IsDir() || !collectionExtensions[strings.ToLower(filepath.Ext(e.Name()))] {
+				continue
+			}
+			add(filepath.Join(arg, e.Name()))
+			found++
+		}
+		if found == 0 {
+			return nil, fmt.Errorf("no collection files found in directory %q", arg)
+		}
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no collection files provided")
+	}
+	return files, nil
+}
+
+// collectionPrefix derives the folder-style prefix for requests of a file,
+// e.g. "orders.postman_collection.json" -> "orders"
+func collectionPrefix(filePath string) string {
+	base := filepath.Base(filePath)
+	if i := strings.Index(base, "."); i > 0 {
+		base = base[:i]
+	}
+	return base
+}
+
+// parseCollectionFiles parses every file into one request list. With more
+// than one file, request names are prefixed with the file name ("orders/Get order")
+// so same-named requests from different collections stay distinguishable.
+func (cp *CollectionProcessor) parseCollectionFiles(filePaths []string) ([]APIRequest, error) {
+	var all []APIRequest
+	for _, path := range filePaths {
+		apis, err := cp.ParseCollectionFile(path)
+		if err != nil {
+			return nil, err
+		}
+		prefix := collectionPrefix(path)
+		for i := range apis {
+			apis[i].SourceFile = path
+			if len(filePaths) > 1 {
+				apis[i].Name = prefix + "/" + apis[i].Name
+				apis[i].ID = prefix + "_" + apis[i].ID
+			}
+		}
+		if len(filePaths) > 1 {
+			fmt.Printf("   • %s: %d requests\n", filepath.Base(path), len(apis))
+		}
+		all = append(all, apis...)
+	}
+	return all, nil
+}
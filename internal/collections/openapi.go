@@ -0,0 +1,418 @@
+package collections
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hemantobora/auto-mock/internal/builders"
+	"gopkg.in/yaml.v3"
+)
+
+// openAPIMethods are the operation keys of a path item, in the order they are imported
+var openAPIMethods = []string{"get", "post", "put", "patch", "delete", "head", "options"}
+
+// maxRefDepth bounds how many $ref hops are followed to resolve one value
+const maxRefDepth = 8
+
+// openAPISpec wraps a decoded OpenAPI 3.x or Swagger 2.0 document
+type openAPISpec struct {
+	root     map[string]interface{}
+	swagger  bool                     // Swagger 2.0 rather than OpenAPI 3.x
+	examples *builders.SchemaExampler // generates bodies and parameter values from schemas
+}
+
+// parseOpenAPISpec turns every operation of an OpenAPI 3.x / Swagger 2.0 spec (JSON or
+// YAML) into a request whose response is documented rather than recorded: bodies come
+// from the spec's examples, or are generated from schema examples, defaults and types.
+func (cp *CollectionProcessor) parseOpenAPISpec(data []byte) ([]APIRequest, error) {
+	spec, paths, err := loadOpenAPISpec(data)
+	if err != nil {
+		return nil, err
+	}
+	if spec.swagger {
+		fmt.Printf("📘 Swagger %v spec\n", spec.root["swagger"])
+	} else {
+		fmt.Printf("📘 OpenAPI %v spec\n", spec.root["openapi"])
+	}
+	baseURL := spec.baseURL()
+
+	var apis []APIRequest
+	skipped := 0
+	for _, path := range pathOrder(data, paths) {
+		item, _ := spec.resolve(paths[path]).(map[string]interface{})
+		for _, method := range openAPIMethods {
+			op, ok := spec.resolve(item[method]).(map[string]interface{})
+			if !ok {
+				continue
+			}
+			api, extra := spec.operation(baseURL, path, method, item, op)
+			skipped += extra
+			api.ID = fmt.Sprintf("openapi_%d", len(apis)+1)
+			apis = append(apis, api)
+		}
+	}
+	if skipped > 0 {
+		fmt.Printf("ℹ️  %d additional documented response(s) not mocked; each operation uses its primary success response\n", skipped)
+	}
+	return apis, nil
+}
+
+// loadOpenAPISpec decodes an OpenAPI 3.x or Swagger 2.0 document (JSON or YAML) and returns its paths
+func loadOpenAPISpec(data []byte) (*openAPISpec, map[string]interface{}, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+	root, ok := stringKeys(doc).(map[string]interface{})
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid OpenAPI document: expected a mapping at the top level")
+	}
+	spec := &openAPISpec{root: root}
+	spec.examples = &builders.SchemaExampler{Resolve: func(ref string) any {
+		return spec.resolve(map[string]interface{}{"$ref": ref})
+	}}
+	if _, ok := root["swagger"]; ok {
+		spec.swagger = true
+	} else if _, ok := root["openapi"]; !ok {
+		return nil, nil, fmt.Errorf("not an OpenAPI document: missing 'openapi' or 'swagger' version")
+	}
+
+	paths, _ := root["paths"].(map[string]interface{})
+	if len(paths) == 0 {
+		return nil, nil, fmt.Errorf("OpenAPI document defines no paths")
+	}
+	return spec, paths, nil
+}
+
+// operation converts one operation; it also returns how many other responses were documented
+func (s *openAPISpec) operation(baseURL, path, method string, item, op map[string]interface{}) (APIRequest, int) {
+	api := APIRequest{
+		Name:        openAPIOperationName(op, method, path),
+		Method:      strings.ToUpper(method),
+		URL:         strings.TrimRight(baseURL, "/") + path,
+		Headers:     map[string]string{},
+		QueryParams: map[string]string{},
+		Variables:   map[string]string{},
+	}
+
+	for _, p := range s.parameters(item, op) {
+		name, _ := p["name"].(string)
+		required, _ := p["required"].(bool)
+		switch p["in"] {
+		case "query":
+			if required {
+				api.QueryParams[name] = s.paramValue(p)
+			}
+		case "header":
+			if required && !strings.EqualFold(name, "Content-Type") {
+				api.Headers[name] = s.paramValue(p)
+			}
+		case "body": // Swagger 2.0 request body
+			if body := s.examples.Example(p["schema"]); body != nil {
+				api.Body = marshalSample(body)
+				api.Headers["Content-Type"] = "application/json"
+			}
+		}
+	}
+	if len(api.QueryParams) > 0 {
+		q := make([]string, 0, len(api.QueryParams))
+		for name, value := range api.QueryParams {
+			q = append(q, name+"="+value)
+		}
+		sort.Strings(q)
+		api.URL += "?" + strings.Join(q, "&")
+	}
+
+	if rb, ok := s.resolve(op["requestBody"]).(map[string]interface{}); ok {
+		if mime, media := s.mediaType(rb["content"]); media != nil {
+			if body := s.mediaExample(media); body != nil {
+				api.Body = marshalSample(body)
+				api.Headers["Content-Type"] = mime
+			}
+		}
+	}
+
+	responses, _ := op["responses"].(map[string]interface{})
+	code, resp := primaryResponse(responses)
+	api.Documented = s.response(code, s.resolve(resp))
+	return api, max(len(responses)-1, 0)
+}
+
+// response builds the documented response for a status code
+func (s *openAPISpec) response(code int, resp interface{}) *APIResponse {
+	out := &APIResponse{StatusCode: code, Headers: map[string]string{}, Cookies: map[string]string{}}
+	r, _ := resp.(map[string]interface{})
+	if r == nil {
+		return out
+	}
+
+	var body interface{}
+	mime := ""
+	if s.swagger {
+		if examples, ok := r["examples"].(map[string]interface{}); ok {
+			for _, m := range sortedKeys(examples) {
+				mime, body = m, examples[m]
+				break
+			}
+		}
+		if body == nil && r["schema"] != nil {
+			mime, body = "application/json", s.examples.Example(r["schema"])
+		}
+	} else if m, media := s.mediaType(r["content"]); media != nil {
+		mime, body = m, s.mediaExample(media)
+	}
+	if body != nil {
+		if str, isString := body.(string); isString && !strings.Contains(mime, "json") {
+			out.Body = str
+		} else {
+			out.Body = marshalSample(body)
+		}
+		out.Headers["Content-Type"] = mime
+	}
+
+	if headers, ok := r["headers"].(map[string]interface{}); ok {
+		for name, h := range headers {
+			header, _ := s.resolve(h).(map[string]interface{})
+			v := header["example"]
+			if v == nil {
+				v = s.examples.ExampleFor(name, header["schema"])
+			}
+			if v != nil {
+				out.Headers[name] = fmt.Sprint(v)
+			}
+		}
+	}
+	return out
+}
+
+// parameters merges path-level and operation-level parameters; operation entries win
+func (s *openAPISpec) parameters(item, op map[string]interface{}) []map[string]interface{} {
+	var out []map[string]interface{}
+	index := map[string]int{}
+	for _, list := range []interface{}{item["parameters"], op["parameters"]} {
+		params, _ := list.([]interface{})
+		for _, raw := range params {
+			p, ok := s.resolve(raw).(map[string]interface{})
+			if !ok {
+				continue
+			}
+			key := fmt.Sprintf("%v:%v", p["in"], p["name"])
+			if i, seen := index[key]; seen {
+				out[i] = p
+				continue
+			}
+			index[key] = len(out)
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func (s *openAPISpec) paramValue(p map[string]interface{}) string {
+	v := p["example"]
+	if v == nil {
+		if examples, ok := p["examples"].(map[string]interface{}); ok {
+			for _, name := range sortedKeys(examples) {
+				if ex, ok := s.resolve(examples[name]).(map[string]interface{}); ok {
+					v = ex["value"]
+					break
+				}
+			}
+		}
+	}
+	if v == nil {
+		schema := p["schema"]
+		if s.swagger {
+			schema = p // Swagger 2.0 inlines type/format/default on the parameter
+		}
+		name, _ := p["name"].(string)
+		v = s.examples.ExampleFor(name, schema)
+	}
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// mediaType picks the JSON content entry of a content map, falling back to the first one
+func (s *openAPISpec) mediaType(content interface{}) (string, map[string]interface{}) {
+	c, _ := content.(map[string]interface{})
+	keys := sortedKeys(c)
+	for _, mime := range keys {
+		if strings.Contains(mime, "json") {
+			m, _ := s.resolve(c[mime]).(map[string]interface{})
+			return mime, m
+		}
+	}
+	if len(keys) > 0 {
+		m, _ := s.resolve(c[keys[0]]).(map[string]interface{})
+		return keys[0], m
+	}
+	return "", nil
+}
+
+// mediaExample prefers explicit examples over a generated schema sample
+func (s *openAPISpec) mediaExample(media map[string]interface{}) interface{} {
+	if v, ok := media["example"]; ok {
+		return v
+	}
+	if examples, ok := media["examples"].(map[string]interface{}); ok {
+		for _, name := range sortedKeys(examples) {
+			if ex, ok := s.resolve(examples[name]).(map[string]interface{}); ok && ex["value"] != nil {
+				return ex["value"]
+			}
+		}
+	}
+	return s.examples.Example(media["schema"])
+}
+
+// resolve follows local $ref pointers ("#/components/schemas/User")
+func (s *openAPISpec) resolve(v interface{}) interface{} {
+	for range maxRefDepth {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return v
+		}
+		ref, ok := m["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#/") {
+			return v
+		}
+		var cur interface{} = s.root
+		for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+			node, _ := cur.(map[string]interface{})
+			cur = node[part]
+		}
+		v = cur
+	}
+	return nil
+}
+
+// baseURL reads the first server (OpenAPI 3) or scheme/host/basePath (Swagger 2)
+func (s *openAPISpec) baseURL() string {
+	if s.swagger {
+		host, _ := s.root["host"].(string)
+		if host == "" {
+			host = "localhost"
+		}
+		scheme := "https"
+		if schemes, ok := s.root["schemes"].([]interface{}); ok && len(schemes) > 0 {
+			scheme = fmt.Sprint(schemes[0])
+		}
+		basePath, _ := s.root["basePath"].(string)
+		return scheme + "://" + host + basePath
+	}
+	servers, _ := s.root["servers"].([]interface{})
+	if len(servers) == 0 {
+		return "http://localhost"
+	}
+	server, _ := servers[0].(map[string]interface{})
+	url, _ := server["url"].(string)
+	vars, _ := server["variables"].(map[string]interface{})
+	for name, raw := range vars {
+		if v, ok := raw.(map[string]interface{}); ok {
+			url = strings.ReplaceAll(url, "{"+name+"}", fmt.Sprint(v["default"]))
+		}
+	}
+	if !strings.Contains(url, "://") {
+		url = "http://localhost" + "/" + strings.TrimLeft(url, "/")
+	}
+	return url
+}
+
+// primaryResponse picks the lowest 2xx response, then "default", then the lowest code
+func primaryResponse(responses map[string]interface{}) (int, interface{}) {
+	best, bestCode := "", 0
+	for key := range responses {
+		code, err := strconv.Atoi(strings.ReplaceAll(strings.ToUpper(key), "XX", "00"))
+		if err != nil {
+			continue
+		}
+		better := bestCode == 0 ||
+			(code/100 == 2 && (bestCode/100 != 2 || code < bestCode)) ||
+			(code/100 != 2 && bestCode/100 != 2 && code < bestCode)
+		if better {
+			best, bestCode = key, code
+		}
+	}
+	if bestCode/100 != 2 {
+		if resp, ok := responses["default"]; ok {
+			return http.StatusOK, resp
+		}
+	}
+	if bestCode == 0 {
+		return http.StatusOK, nil
+	}
+	return bestCode, responses[best]
+}
+
+func openAPIOperationName(op map[string]interface{}, method, path string) string {
+	for _, key := range []string{"summary", "operationId"} {
+		if name, ok := op[key].(string); ok && strings.TrimSpace(name) != "" {
+			return strings.TrimSpace(name)
+		}
+	}
+	return strings.ToUpper(method) + " " + path
+}
+
+// pathOrder returns path keys in document order, falling back to sorted order
+func pathOrder(data []byte, paths map[string]interface{}) []string {
+	var doc struct {
+		Paths yaml.Node `yaml:"paths"`
+	}
+	var keys []string
+	if yaml.Unmarshal(data, &doc) == nil && doc.Paths.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(doc.Paths.Content); i += 2 {
+			keys = append(keys, doc.Paths.Content[i].Value)
+		}
+	}
+	if len(keys) != len(paths) {
+		keys = sortedKeys(paths)
+	}
+	return keys
+}
+
+// stringKeys converts YAML mappings with non-string keys (e.g. unquoted 200: responses)
+// into map[string]interface{}
+func stringKeys(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			out[fmt.Sprint(k)] = stringKeys(val)
+		}
+		return out
+	case map[string]interface{}:
+		for k, val := range t {
+			t[k] = stringKeys(val)
+		}
+		return t
+	case []interface{}:
+		for i := range t {
+			t[i] = stringKeys(t[i])
+		}
+		return t
+	}
+	return v
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func marshalSample(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
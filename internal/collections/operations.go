@@ -0,0 +1,115 @@
+package collections
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Operation is one endpoint a spec or collection defines
+type Operation struct {
+	Method string `json:"method"`
+	Path   string `json:"path"` // template with {name} for path parameters, e.g. /v1/users/{id}
+	Name   string `json:"name,omitempty"`
+}
+
+// ListOperations returns the operations a spec or collection defines, without executing any
+// request. OpenAPI paths keep their templates and are prefixed with the first server's base
+// path; collection URLs lose their host and have {{var}} and :var segments turned into
+// {var} parameters. collectionType may be "auto" (or empty) to detect it from the contents.
+func ListOperations(filePath, collectionType string) ([]Operation, error) {
+	if collectionType == "" {
+		collectionType = CollectionTypeAuto
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if isAutoCollectionType(collectionType) {
+		if collectionType, err = DetectCollectionType(data, filePath); err != nil {
+			return nil, err
+		}
+	}
+
+	var ops []Operation
+	switch collectionType {
+	case CollectionTypeOpenAPI:
+		spec, paths, err := loadOpenAPISpec(data)
+		if err != nil {
+			return nil, err
+		}
+		basePath := ""
+		if u, err := url.Parse(spec.baseURL()); err == nil {
+			basePath = strings.TrimRight(u.Path, "/")
+		}
+		for _, path := range pathOrder(data, paths) {
+			item, _ := spec.resolve(paths[path]).(map[string]interface{})
+			for _, method := range openAPIMethods {
+				op, ok := spec.resolve(item[method]).(map[string]interface{})
+				if !ok {
+					continue
+				}
+				ops = append(ops, Operation{Method: strings.ToUpper(method), Path: basePath + path, Name: openAPIOperationName(op, method, path)})
+			}
+		}
+	case CollectionTypeProto:
+		return nil, fmt.Errorf("%s is a gRPC service definition; coverage compares HTTP operations", filePath)
+	default:
+		cp, err := NewCollectionProcessor("", collectionType)
+		if err != nil {
+			return nil, err
+		}
+		apis, err := cp.ParseCollectionFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		seen := map[string]bool{}
+		for _, api := range apis {
+			op := Operation{Method: strings.ToUpper(api.Method), Path: collectionPath(api.URL), Name: api.Name}
+			if op.Method == "" {
+				op.Method = "GET"
+			}
+			if key := op.Method + " " + op.Path; !seen[key] {
+				seen[key] = true
+				ops = append(ops, op)
+			}
+		}
+	}
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("%s defines no operations", filePath)
+	}
+	return ops, nil
+}
+
+// collectionPath turns a collection request URL into a path template:
+// "{{baseUrl}}/users/:id?x=1" and "https://api/users/{{userId}}" both become "/users/{id}" style paths
+func collectionPath(raw string) string {
+	if i := strings.IndexAny(raw, "?#"); i >= 0 {
+		raw = raw[:i]
+	}
+	if i := strings.Index(raw, "://"); i >= 0 {
+		raw = raw[i+3:]
+		if j := strings.Index(raw, "/"); j >= 0 {
+			raw = raw[j:]
+		} else {
+			raw = "/"
+		}
+	} else if strings.HasPrefix(raw, "{{") {
+		// A variable host such as {{baseUrl}}
+		if j := strings.Index(raw, "}}"); j >= 0 {
+			raw = raw[j+2:]
+		}
+	}
+
+	segs := strings.Split(strings.Trim(raw, "/"), "/")
+	for i, seg := range segs {
+		switch {
+		case strings.HasPrefix(seg, ":") && len(seg) > 1:
+			segs[i] = "{" + seg[1:] + "}"
+		case strings.HasPrefix(seg, "{{") && strings.HasSuffix(seg, "}}"):
+			segs[i] = "{" + strings.TrimSpace(seg[2:len(seg)-2]) + "}"
+		}
+	}
+	return "/" + strings.Join(segs, "/")
+}
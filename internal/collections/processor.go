@@ -7,33 +7,52 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
 	"github.com/hemantobora/auto-mock/internal/builders"
 	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/security"
 )
 
 // CollectionProcessor handles import and processing of API collections
 type CollectionProcessor struct {
-	projectName    string
-	collectionType string
+	projectName       string
+	collectionType    string
+	autoDetect        bool // detect collectionType from each file's contents
+	runID             string
+	latencyPercent    int                 // observed latency share applied as response delay (0 = off)
+	iterationData     []map[string]string // newman -d style rows; data-driven requests run once per row
+	iterationVariants bool                // keep one expectation per iteration instead of merging them
+	chunkSize         int                 // requests executed and reviewed per chunk
+	maxRequests       int                 // imports with more requests are refused
+	chunked           bool                // more requests than one chunk: summarized prompts
+	concurrency       int                 // requests of one execution stage run at once
+	client            *http.Client
+	sanitizer         *security.CollectionSanitizer // secrets seen this run, masked in output and expectations
 }
 
 // APIRequest represents a single API request from collection
 type APIRequest struct {
-	ID          string            `json:"id"`
-	Name        string            `json:"name"`
-	Method      string            `json:"method"`
-	URL         string            `json:"url"`
-	Headers     map[string]string `json:"headers"`
-	Body        string            `json:"body"`
-	QueryParams map[string]string `json:"query_params"`
-	PreScript   string            `json:"pre_script"`
-	PostScript  string            `json:"post_script"`
-	Variables   map[string]string `json:"variables"`
+	ID           string            `json:"id"`
+	Name         string            `json:"name"`
+	Method       string            `json:"method"`
+	URL          string            `json:"url"`
+	Headers      map[string]string `json:"headers"`
+	Body         string            `json:"body"`
+	QueryParams  map[string]string `json:"query_params"`
+	PreScript    string            `json:"pre_script"`
+	PostScript   string            `json:"post_script"`
+	Variables    map[string]string `json:"variables"`
+	SourceFile   string            `json:"source_file,omitempty"`   // collection file the request came from
+	Documented   *APIResponse      `json:"documented,omitempty"`    // response described by the source (OpenAPI); not executed
+	Iteration    int               `json:"iteration,omitempty"`     // 1-based data row for data-driven requests
+	IterationKey string            `json:"iteration_key,omitempty"` // data fields the request uses, e.g. "userId=2"
 }
 
 // APIResponse represents recorded response
@@ -67,26 +86,49 @@ func NewCollectionProcessor(projectName, collectionType string) (*CollectionProc
 	return &CollectionProcessor{
 		projectName:    projectName,
 		collectionType: collectionType,
+		autoDetect:     isAutoCollectionType(collectionType),
+		chunkSize:      DefaultChunkSize,
+		maxRequests:    DefaultMaxRequests,
+		concurrency:    DefaultConcurrency,
+		client:         newPooledHTTPClient(), // up front: parallel stages share it
 	}, nil
 }
 
 // ProcessCollection handles the complete collection import workflow
 func (cp *CollectionProcessor) ProcessCollection(filePath string) (string, error) {
-	fmt.Printf("📂 COLLECTION IMPORT: %s\n", strings.ToUpper(cp.collectionType))
+	return cp.ProcessCollections([]string{filePath})
+}
+
+// ProcessCollections imports several collection files as one session: all
+// requests are merged, executed and reviewed together
+func (cp *CollectionProcessor) ProcessCollections(filePaths []string) (string, error) {
+	importType := cp.collectionType
+	if cp.autoDetect {
+		importType = "auto-detect"
+	}
+	fmt.Printf("📂 COLLECTION IMPORT: %s\n", strings.ToUpper(importType))
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	if len(filePaths) > 1 {
+		fmt.Printf("📚 Merging %d collection files into one import session\n", len(filePaths))
+	}
+	cp.runID = models.NewRunID(models.ProvenanceCollection)
+	cp.sanitizer = security.NewCollectionSanitizer()
 
 	// Step 1: Show disclaimer
 	if err := cp.showDisclaimer(); err != nil {
 		return "", err
 	}
 
-	// Step 2: Parse collection file
-	apis, err := cp.ParseCollectionFile(filePath)
+	// Step 2: Parse collection file(s)
+	apis, err := cp.parseCollectionFiles(filePaths)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse collection: %w", err)
 	}
 
 	fmt.Printf("✅ Found %d API endpoints in collection\n", len(apis))
+	if err := cp.checkRequestLimit(len(apis)); err != nil {
+		return "", err
+	}
 
 	// Step 3: Build execution DAG
 	executionNodes, err := cp.buildExecutionDAG(apis)
@@ -94,10 +136,27 @@ func (cp *CollectionProcessor) ProcessCollection(filePath string) (string, error
 		return "", fmt.Errorf("failed to build execution order: %w", err)
 	}
 
-	// Step 4: Execute APIs and record responses
-	if err := cp.executeAPIs(executionNodes); err != nil {
+	if expanded := cp.expandIterations(executionNodes); len(expanded) != len(executionNodes) {
+		executionNodes = expanded
+		cp.linkDependencies(executionNodes)
+	}
+	if err := cp.checkRequestLimit(len(executionNodes)); err != nil {
+		return "", err
+	}
+	cp.chunked = len(executionNodes) > cp.chunkSize
+
+	// Step 4: Execute APIs and record responses (in chunks for large collections)
+	if err := cp.executeInChunks(executionNodes); err != nil {
 		return "", fmt.Errorf("failed to execute APIs: %w", err)
 	}
+	if err := cp.askIterationVariants(executionNodes); err != nil {
+		return "", err
+	}
+
+	// Optionally carry the observed latency into expectation delays
+	if cp.latencyPercent, err = askLatencyPercent(executionNodes); err != nil {
+		return "", err
+	}
 
 	// Step 5: Enhanced scenario detection and matching criteria configuration
 	fmt.Println("\n🔍 ANALYZING APIs FOR SCENARIOS...")
@@ -109,6 +168,15 @@ func (cp *CollectionProcessor) ProcessCollection(filePath string) (string, error
 		return "", fmt.Errorf("failed to configure matching: %w", err)
 	}
 
+	// Collapse repeated calls to the same endpoint into one expectation with examples
+	expectations, merged := models.DeduplicateExpectations(expectations)
+	if report := models.FormatMergeReport(merged); report != "" {
+		fmt.Print("\n" + report)
+	}
+	if masked := cp.sanitizer.SanitizeExpectations(expectations); masked > 0 {
+		fmt.Printf("🔐 Masked %d secret value(s) in the generated expectations\n", masked)
+	}
+
 	fmt.Printf("\n✅ Configured %d mock expectations from collection\n", len(expectations))
 	expectations = builders.ExtendExpectationsForProgressive(expectations)
 
@@ -116,6 +184,7 @@ func (cp *CollectionProcessor) ProcessCollection(filePath string) (string, error
 	if err := cp.reviewExpectations(expectations); err != nil {
 		return "", fmt.Errorf("review failed: %w", err)
 	}
+	fmt.Print(cp.sanitizer.Summary())
 
 	return builders.ExpectationsToMockServerJSON(expectations), nil
 }
@@ -127,6 +196,7 @@ func (cp *CollectionProcessor) showDisclaimer() error {
 	fmt.Println("⚠️  IMPORTANT SECURITY NOTICE:")
 	fmt.Println("   • You are responsible for maintaining secrets/credentials")
 	fmt.Println("   • Remove sensitive data from collection before import")
+	fmt.Println("   • Detected credentials and tokens are masked in output and expectations (see the summary)")
 	fmt.Println("   • Environment variables should be provided via -e or --env-file")
 	fmt.Println("   • Pre/post scripts will be processed automatically")
 	fmt.Println("   • Variables from collection will be extracted and managed")
@@ -135,10 +205,15 @@ func (cp *CollectionProcessor) showDisclaimer() error {
 	fmt.Println("   • If variables are missing, quit and restart after setup")
 	fmt.Println("   • API execution will fail if dependencies are not met")
 	fmt.Println("   • Ensure the API order in the collection is correct")
-	fmt.Println("   • The tool will assume the order is correct and execute sequentially")
+	if cp.concurrency > 1 {
+		fmt.Println("   • Requests run in parallel unless they share variables or write to the same resource;")
+		fmt.Println("     state passed only through the server (no variables) may need --concurrency 1")
+	} else {
+		fmt.Println("   • The tool will assume the order is correct and execute sequentially")
+	}
 
 	var proceed bool
-	if err := survey.AskOne(&survey.Confirm{
+	if err := ask.One(&survey.Confirm{
 		Message: "Assuming you agree to the above, is the order of APIs in the collection correct? Continue?",
 		Default: true,
 	}, &proceed); err != nil {
@@ -154,7 +229,9 @@ func (cp *CollectionProcessor) showDisclaimer() error {
 
 // Step 2: Parse collection file based on type
 func (cp *CollectionProcessor) ParseCollectionFile(filePath string) ([]APIRequest, error) {
-	fmt.Printf("\n📄 Parsing %s collection file: %s\n", cp.collectionType, filePath)
+	if apis, handled, err := cp.parseLargeCollection(filePath); handled {
+		return apis, err
+	}
 
 	data, err := os.ReadFile(filePath)
 	if err != nil {
@@ -165,6 +242,16 @@ func (cp *CollectionProcessor) ParseCollectionFile(filePath string) ([]APIReques
 		}
 	}
 
+	if cp.autoDetect {
+		detected, err := DetectCollectionType(data, filePath)
+		if err != nil {
+			return nil, err
+		}
+		cp.collectionType = detected
+		fmt.Printf("\n🔎 Detected %s collection: %s\n", detected, filePath)
+	}
+	fmt.Printf("\n📄 Parsing %s collection file: %s\n", cp.collectionType, filePath)
+
 	switch cp.collectionType {
 	case "postman":
 		return cp.parsePostmanCollection(data)
@@ -172,6 +259,10 @@ func (cp *CollectionProcessor) ParseCollectionFile(filePath string) ([]APIReques
 		return cp.parseBrunoCollection(data)
 	case "insomnia":
 		return cp.parseInsomniaCollection(data)
+	case "openapi":
+		return cp.parseOpenAPISpec(data)
+	case "proto":
+		return cp.parseProtoFile(filePath)
 	default:
 		return nil, &models.CollectionParsingError{
 			CollectionType: cp.collectionType,
@@ -189,11 +280,19 @@ func (cp *CollectionProcessor) configureIndividualMatching(nodes []ExecutionNode
 
 	var expectations []builders.MockExpectation
 	var mock_configurator builders.MockConfigurator
+	review := reviewEach
 
-	for _, node := range nodes {
+	for i, node := range nodes {
+		if cp.chunked && i%cp.chunkSize == 0 && review != reviewDefaultsAll {
+			var err error
+			if review, err = askChunkReview(nodes, i, cp.chunkSize); err != nil {
+				return nil, err
+			}
+		}
 		if node.Response == nil {
 			continue
 		}
+		interactive := review == reviewEach
 
 		fmt.Printf("\n🔧 Configuring: %s %s - %s\n", node.API.Method, cp.extractPath(node.API.URL), node.API.Name)
 		fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
@@ -211,6 +310,8 @@ func (cp *CollectionProcessor) configureIndividualMatching(nodes []ExecutionNode
 				StatusCode: node.Response.StatusCode,
 				Headers:    []models.NameValues{},
 			},
+			Provenance: models.NewProvenance(models.ProvenanceCollection,
+				fmt.Sprintf("%s › %s", filepath.Base(node.API.SourceFile), node.API.Name), cp.runID),
 		}
 
 		// Handle existing query parameters
@@ -265,15 +366,17 @@ func (cp *CollectionProcessor) configureIndividualMatching(nodes []ExecutionNode
 				}
 
 				var needsBody bool
-				_ = survey.AskOne(&survey.Confirm{
-					Message: "Do you want to match the request body?",
-					Default: false,
-					Help:    "Choose ‘No’ to skip body matching.",
-				}, &needsBody)
+				if interactive {
+					_ = ask.One(&survey.Confirm{
+						Message: "Do you want to match the request body?",
+						Default: false,
+						Help:    "Choose ‘No’ to skip body matching.",
+					}, &needsBody)
+				}
 				if needsBody {
 					// Let the user choose STRICT vs ONLY_MATCHING_FIELDS vs REGEX (full-body)
 					var mode string
-					if err := survey.AskOne(&survey.Select{
+					if err := ask.One(&survey.Select{
 						Message: "GraphQL POST body match mode:",
 						Options: []string{"ONLY_MATCHING_FIELDS", "STRICT"},
 						Default: "ONLY_MATCHING_FIELDS",
@@ -298,7 +401,7 @@ func (cp *CollectionProcessor) configureIndividualMatching(nodes []ExecutionNode
 				}
 			}
 
-		} else {
+		} else if interactive {
 			// Request body for methods that typically have bodies
 			if node.API.Method == "POST" || node.API.Method == "PUT" || node.API.Method == "PATCH" {
 				if err := mock_configurator.CollectRequestBody(&expectation, node.API.Body); err != nil {
@@ -316,24 +419,38 @@ func (cp *CollectionProcessor) configureIndividualMatching(nodes []ExecutionNode
 			}
 		}
 
-		if err := mock_configurator.CollectRequestHeaderMatching(&expectation); err != nil {
-			return nil, err
-		}
+		if interactive {
+			if err := mock_configurator.CollectRequestHeaderMatching(&expectation); err != nil {
+				return nil, err
+			}
 
-		if err := mock_configurator.CollectAdvancedFeatures(&expectation); err != nil {
-			return nil, err
+			if err := mock_configurator.CollectAdvancedFeatures(&expectation); err != nil {
+				return nil, err
+			}
 		}
 
-		var v any
-		if err := json.Unmarshal([]byte(node.Response.Body), &v); err != nil {
-			return nil, err
+		// Observed latency only fills in when no delay was configured explicitly
+		if expectation.HttpResponse.Delay == nil {
+			if delay := observedDelay(node.Response.Duration, cp.latencyPercent); delay != nil {
+				expectation.HttpResponse.Delay = delay
+				fmt.Printf("⏱️  Response delay set to %dms (observed %dms)\n", delay.Value, node.Response.Duration.Milliseconds())
+			}
 		}
-		// Set body wrapper
-		expectation.HttpResponse.Body = map[string]any{
-			"type": "JSON",
-			"json": v,
+
+		if node.Response.Body != "" {
+			var v any
+			if err := json.Unmarshal([]byte(node.Response.Body), &v); err == nil {
+				// Set body wrapper
+				expectation.HttpResponse.Body = map[string]any{
+					"type": "JSON",
+					"json": v,
+				}
+			} else {
+				// Text and XML stay text; images, PDFs and other bytes are stored as base64
+				expectation.HttpResponse.Body = models.BodyFromBytes(node.Response.Headers["Content-Type"], []byte(node.Response.Body))
+			}
+			fmt.Println("✅ Configured response body")
 		}
-		fmt.Println("✅ Configured response body")
 
 		// Merge response headers into expectation.HttpResponse.Headers (slice of models.NameValues)
 		for hk, hv := range node.Response.Headers {
@@ -373,40 +490,71 @@ func (cp *CollectionProcessor) configureIndividualMatching(nodes []ExecutionNode
 			}
 		}
 
+		// GraphQL operations also get selectable errors and partial-data variants
+		if node.ExecutionType == GRAPHQL && models.GraphQLErrorsEligible(expectation) {
+			if interactive {
+				if err := builders.ConfigureGraphQLErrors(&expectation); err != nil {
+					return nil, err
+				}
+			} else {
+				expectation.GraphQLErrors = &models.GraphQLErrors{Variants: models.GraphQLVariants}
+			}
+		}
+
+		expectation.Checks = cp.recordedChecks(node.API, node.Response)
+		if cp.iterationVariants {
+			expectation.Variant = node.API.IterationKey
+		}
+
 		expectations = append(expectations, expectation)
 	}
 
 	return expectations, nil
 }
 
-// Step 3: Execute APIs sequentially with variable resolution
+// Step 3: Build the execution DAG from the variables each request reads and its scripts set
 func (cp *CollectionProcessor) buildExecutionDAG(apis []APIRequest) ([]ExecutionNode, error) {
-	fmt.Println("\n🔗 SEQUENTIAL EXECUTION SETUP")
+	fmt.Println("\n🔗 EXECUTION PLAN")
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Printf("Preparing %d APIs for execution in collection order...\n\n", len(apis))
 
-	// Display all APIs in order
+	executionNodes := make([]ExecutionNode, len(apis))
 	for i, api := range apis {
-		fmt.Printf("%d. %s %s - %s\n", i+1, api.Method, api.URL, api.Name)
+		executionNodes[i] = ExecutionNode{API: api}
 	}
+	stages := cp.linkDependencies(executionNodes)
 
-	// Create execution nodes in order (no dependency analysis needed)
-	var executionNodes []ExecutionNode
-	for _, api := range apis {
-		node := ExecutionNode{
-			API:          api,
-			Dependencies: []string{},
-			Variables:    []string{}, // Will be populated during execution
+	// Display all APIs in order (large collections show the first chunk only)
+	for i, node := range executionNodes {
+		if cp.chunkSize > 0 && i == cp.chunkSize && len(apis) > cp.chunkSize {
+			fmt.Printf("... and %d more\n", len(apis)-i)
+			break
+		}
+		fmt.Printf("%d. %s %s - %s\n", i+1, node.API.Method, node.API.URL, node.API.Name)
+		if len(node.Dependencies) > 0 {
+			fmt.Printf("   ↳ after %s\n", strings.Join(node.Dependencies, ", "))
 		}
-		executionNodes = append(executionNodes, node)
 	}
 
-	fmt.Println("\n✅ APIs will execute in the order shown above")
+	widest := 0
+	for _, stage := range stages {
+		widest = max(widest, len(stage))
+	}
+	fmt.Printf("\n🧩 %d stage(s); up to %d independent request(s) per stage\n", len(stages), widest)
+	if cp.concurrency > 1 {
+		fmt.Printf("✅ Independent requests will run in parallel, %d at a time\n", cp.concurrency)
+	} else {
+		fmt.Println("✅ APIs will execute in the order shown above (use --concurrency to run independent requests in parallel)")
+	}
 	return executionNodes, nil
 }
 
-// Step 4: Execute APIs sequentially with runtime variable resolution and loading indicators
-func (cp *CollectionProcessor) executeAPIs(nodes []ExecutionNode) error {
+// Step 4: Execute APIs with runtime variable resolution and loading indicators
+func (cp *CollectionProcessor) executeAPIs(nodes []ExecutionNode, variables map[string]string) error {
+	if cp.concurrency > 1 {
+		return cp.executeConcurrently(nodes, variables)
+	}
+
 	fmt.Println("\n🚀 EXECUTING APIs SEQUENTIALLY")
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Printf("📊 Progress: [")
@@ -415,8 +563,7 @@ func (cp *CollectionProcessor) executeAPIs(nodes []ExecutionNode) error {
 	}
 	fmt.Printf("] 0/%d\n", len(nodes))
 
-	// In-memory variable map (cleared after all executions)
-	variables := make(map[string]string)
+	cp.warmupConnections(nodes)
 
 	// Process each API in order
 	for i := range nodes {
@@ -439,47 +586,18 @@ func (cp *CollectionProcessor) executeAPIs(nodes []ExecutionNode) error {
 		fmt.Printf("\n\n▶️  [%d/%d] Executing: %s\n", i+1, len(nodes), node.API.Name)
 		fmt.Println("   " + strings.Repeat("─", 50))
 
-		// Step 1: Identify variables needed
-		neededVars := cp.ExtractVariablesFromAPI(&node.API, true)
-		if len(neededVars) > 0 {
-			fmt.Printf("   📋 Variables needed: %v\n", neededVars)
-		} else {
-			fmt.Printf("   📋 No variables needed\n")
-		}
-
-		// Step 2: Run pre-script if available (before variable resolution)
-		if node.API.PreScript != "" {
-			fmt.Printf("   🔧 Running pre-script...\n")
-			// Execute pre-script with collection-type awareness
-			preScriptVars := cp.executePreScript(node.API.PreScript, node.API, variables)
-			if len(preScriptVars) > 0 {
-				fmt.Printf("   📦 Pre-script set variables: ")
-				for k, v := range preScriptVars {
-					variables[k] = v
-					fmt.Printf("%s=%s ", k, v)
-				}
-				fmt.Println()
-			} else {
-				fmt.Printf("   ⚠️  Pre-script did not set any variables\n")
-				fmt.Printf("   💡 Script content:\n%s\n", node.API.PreScript)
-			}
+		// Spec-derived requests carry their documented response instead of a live call
+		if node.API.Documented != nil {
+			node.Response = node.API.Documented
+			fmt.Printf("   📘 Documented response: %d\n", node.Response.StatusCode)
+			continue
 		}
 
-		// Step 3-5: Resolve variables
-		if err := cp.resolveVariables(&node.API, neededVars, variables); err != nil {
-			fmt.Printf("   ❌ Variable resolution failed: %v\n", err)
-
-			var continueOnError bool
-			if err := survey.AskOne(&survey.Confirm{
-				Message: "Continue with remaining APIs?",
-				Default: true,
-			}, &continueOnError); err != nil {
-				return err
-			}
-
-			if !continueOnError {
-				return fmt.Errorf("execution stopped due to variable resolution error")
-			}
+		ok, err := cp.prepareRequest(node, variables)
+		if err != nil {
+			return err
+		}
+		if !ok {
 			continue
 		}
 
@@ -512,35 +630,105 @@ func (cp *CollectionProcessor) executeAPIs(nodes []ExecutionNode) error {
 		response, err := cp.executeAPI(node.API, variables)
 		done <- true
 		fmt.Printf("\r   ")
-		if err != nil {
-			fmt.Printf("   ❌ API execution failed: %v\n", err)
+		if err := cp.recordResponse(node, response, err, variables); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("\n🎉 Executed %d APIs successfully!\n", len(nodes))
+	return nil
+}
+
+// prepareRequest runs the pre-script and resolves the variables a request needs. It returns
+// false when resolution failed and the user chose to skip the request.
+func (cp *CollectionProcessor) prepareRequest(node *ExecutionNode, variables map[string]string) (bool, error) {
+	// Step 1: Identify variables needed
+	neededVars := cp.ExtractVariablesFromAPI(&node.API, true)
+	if len(neededVars) > 0 {
+		fmt.Printf("   📋 Variables needed: %v\n", neededVars)
+	} else {
+		fmt.Printf("   📋 No variables needed\n")
+	}
+
+	// Step 2: Run pre-script if available (before variable resolution)
+	if node.API.PreScript != "" {
+		fmt.Printf("   🔧 Running pre-script...\n")
+		// Execute pre-script with collection-type awareness
+		preScriptVars := cp.executePreScript(node.API.PreScript, node.API, variables)
+		if len(preScriptVars) > 0 {
+			fmt.Printf("   📦 Pre-script set variables: ")
+			for k, v := range preScriptVars {
+				variables[k] = v
+				fmt.Printf("%s=%s ", k, cp.sanitizer.MaskVariable(requestLabel(node.API)+" pre-script", k, v))
+			}
+			fmt.Println()
+		} else {
+			fmt.Printf("   ⚠️  Pre-script did not set any variables\n")
+			fmt.Printf("   💡 Script content:\n%s\n", node.API.PreScript)
+		}
+	}
+
+	// Step 3-5: Resolve variables
+	if err := cp.resolveVariables(&node.API, neededVars, variables); err != nil {
+		fmt.Printf("   ❌ Variable resolution failed: %v\n", err)
+		if cp.chunked {
+			return false, nil // failures are summarized per chunk
+		}
+
+		var continueOnError bool
+		if err := ask.One(&survey.Confirm{
+			Message: "Continue with remaining APIs?",
+			Default: true,
+		}, &continueOnError); err != nil {
+			return false, err
+		}
 
-			var continueOnError bool
-			if err := survey.AskOne(&survey.Confirm{
+		if !continueOnError {
+			return false, fmt.Errorf("execution stopped due to variable resolution error")
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+// recordResponse stores a request's response (or a placeholder 500 when the call failed and
+// the user continues), shows it, and runs the post-script to extract variables
+func (cp *CollectionProcessor) recordResponse(node *ExecutionNode, response *APIResponse, err error, variables map[string]string) error {
+	if err != nil {
+		fmt.Printf("   ❌ API execution failed: %v\n", err)
+
+		continueOnError := cp.chunked // failures are summarized per chunk
+		if !continueOnError {
+			if err := ask.One(&survey.Confirm{
 				Message: "Continue with remaining APIs?",
 				Default: true,
 			}, &continueOnError); err != nil {
 				return err
 			}
+		}
 
-			if !continueOnError {
-				return fmt.Errorf("execution stopped on API error")
-			}
+		if !continueOnError {
+			return fmt.Errorf("execution stopped on API error")
+		}
 
-			// Create mock response for failed request
-			response = &APIResponse{
-				StatusCode: 500,
-				Headers:    map[string]string{"Content-Type": "application/json"},
-				Body:       `{"error": "API execution failed during collection import"}`,
-				Cookies:    map[string]string{},
-				Duration:   0,
-			}
+		// Create mock response for failed request
+		response = &APIResponse{
+			StatusCode: 500,
+			Headers:    map[string]string{"Content-Type": "application/json"},
+			Body:       `{"error": "API execution failed during collection import"}`,
+			Cookies:    map[string]string{},
+			Duration:   0,
 		}
+	}
 
-		node.Response = response
-		fmt.Printf("   ✅ Response: %d, Duration: %dms\n", response.StatusCode, response.Duration.Milliseconds())
+	node.Response = response
+	cp.sanitizer.ScanResponse(requestLabel(node.API), response.Headers, response.Cookies, response.Body)
+	fmt.Printf("   ✅ Response: %d, Duration: %dms\n", response.StatusCode, response.Duration.Milliseconds())
 
-		// Show FULL response for user to pick variables from
+	// Show FULL response for user to pick variables from
+	if cp.chunked {
+		fmt.Printf("   📄 Response body: %d bytes\n", len(response.Body))
+	} else {
 		fmt.Println("   ──────────────────────────────────────────────────")
 		fmt.Println("   📄 FULL RESPONSE BODY (for variable extraction):")
 		fmt.Println("   ──────────────────────────────────────────────────")
@@ -548,42 +736,44 @@ func (cp *CollectionProcessor) executeAPIs(nodes []ExecutionNode) error {
 		var jsonData interface{}
 		if err := json.Unmarshal([]byte(response.Body), &jsonData); err == nil {
 			if prettyJSON, err := json.MarshalIndent(jsonData, "   ", "  "); err == nil {
-				fmt.Println(string(prettyJSON))
+				fmt.Println(cp.sanitizer.Mask(string(prettyJSON)))
 			} else {
-				fmt.Printf("   %s\n", response.Body)
+				fmt.Printf("   %s\n", cp.sanitizer.Mask(response.Body))
 			}
 		} else {
 			// Not JSON, show as-is
-			fmt.Printf("   %s\n", response.Body)
+			fmt.Printf("   %s\n", cp.sanitizer.Mask(response.Body))
 		}
 		fmt.Println("   ──────────────────────────────────────────────────")
+	}
 
-		// Step 7: Run post-script to populate variables (collection-type aware)
-		if node.API.PostScript != "" {
-			fmt.Printf("   🔧 Running post-script...\n")
-			extractedVars := cp.executePostScript(node.API.PostScript, node.API, response, variables)
-			if len(extractedVars) > 0 {
-				fmt.Printf("   📦 Variables extracted from response: ")
-				for k, v := range extractedVars {
-					variables[k] = v
+	// Step 7: Run post-script to populate variables (collection-type aware)
+	if node.API.PostScript != "" {
+		fmt.Printf("   🔧 Running post-script...\n")
+		extractedVars := cp.executePostScript(node.API.PostScript, node.API, response, variables)
+		if len(extractedVars) > 0 {
+			fmt.Printf("   📦 Variables extracted from response: ")
+			for k, v := range extractedVars {
+				variables[k] = v
+				if !slices.Contains(node.Variables, k) {
 					node.Variables = append(node.Variables, k)
-					fmt.Printf("%s=%s ", k, v)
 				}
-				fmt.Println()
-			} else {
-				fmt.Printf("   ⚠️  Post-script did not extract any variables\n")
-				fmt.Printf("   💡 Script content:\n%s\n", node.API.PostScript)
+				fmt.Printf("%s=%s ", k, cp.sanitizer.MaskVariable(requestLabel(node.API)+" post-script", k, v))
 			}
+			fmt.Println()
+		} else {
+			fmt.Printf("   ⚠️  Post-script did not extract any variables\n")
+			fmt.Printf("   💡 Script content:\n%s\n", node.API.PostScript)
 		}
 	}
-
-	fmt.Printf("\n🎉 Executed %d APIs successfully!\n", len(nodes))
-	fmt.Println("\n🧹 Clearing in-memory variables...")
-	variables = nil // Clear the map
-
 	return nil
 }
 
+// requestLabel names a request in security findings, e.g. "POST Login"
+func requestLabel(api APIRequest) string {
+	return api.Method + " " + api.Name
+}
+
 // classifyAPIsByType separates REST and GraphQL APIs
 func (cp *CollectionProcessor) classifyAPIsByType(nodes []ExecutionNode) ([]ExecutionNode, []ExecutionNode) {
 	var restNodes, graphqlNodes []ExecutionNode
@@ -1344,6 +1534,8 @@ func (cp *CollectionProcessor) parseInsomniaCollection(data []byte) ([]APIReques
 
 	// Handle multiple possible Insomnia export formats
 	if resources, ok := collection["resources"].([]interface{}); ok {
+		protos := cp.insomniaProtoFiles(resources)
+
 		// First pass: extract environment variables
 		for _, resource := range resources {
 			if resourceMap, ok := resource.(map[string]interface{}); ok {
@@ -1371,7 +1563,7 @@ func (cp *CollectionProcessor) parseInsomniaCollection(data []byte) ([]APIReques
 					apis = append(apis, api)
 				} else if resourceType == "grpc_request" {
 					// Handle gRPC requests
-					api := cp.parseInsomniaGrpcRequest(resourceMap)
+					api := cp.parseInsomniaGrpcRequest(resourceMap, protos)
 					if api.Method != "" {
 						cp.resolveInsomniaTemplateTags(&api, envVars)
 						apis = append(apis, api)
@@ -1542,27 +1734,6 @@ func (cp *CollectionProcessor) parseInsomniaRequest(resourceMap map[string]inter
 	return api
 }
 
-// parseInsomniaGrpcRequest handles gRPC requests from Insomnia
-func (cp *CollectionProcessor) parseInsomniaGrpcRequest(resourceMap map[string]interface{}) APIRequest {
-	// Convert gRPC to REST-like representation for processing
-	api := APIRequest{
-		ID:     cp.getString(resourceMap, "_id"),
-		Name:   cp.getString(resourceMap, "name") + " (gRPC)",
-		Method: "POST",                                   // gRPC is typically POST-based
-		URL:    cp.getString(resourceMap, "protoFileId"), // Use proto file as identifier
-		Headers: map[string]string{
-			"Content-Type": "application/grpc+proto",
-		},
-	}
-
-	// Extract body from gRPC request
-	if body, ok := resourceMap["body"].(map[string]interface{}); ok {
-		api.Body = cp.getString(body, "text")
-	}
-
-	return api
-}
-
 // parseInsomniaGraphQLRequest handles GraphQL requests from Insomnia
 func (cp *CollectionProcessor) parseInsomniaGraphQLRequest(resourceMap map[string]interface{}) APIRequest {
 	api := APIRequest{
@@ -1697,8 +1868,9 @@ func (cp *CollectionProcessor) executeAPI(api APIRequest, variables map[string]s
 
 	// Create HTTP request
 	var body io.Reader
+	var bodyContent string
 	if api.Body != "" {
-		bodyContent := cp.replaceVariables(api.Body, variables)
+		bodyContent = cp.replaceVariables(api.Body, variables)
 		body = strings.NewReader(bodyContent)
 	}
 
@@ -1721,7 +1893,6 @@ func (cp *CollectionProcessor) executeAPI(api APIRequest, variables map[string]s
 	// 1) If user provided Content-Type, honor it
 	if req.Header.Get("Content-Type") == "" && api.Body != "" {
 		// 2) Try to detect JSON
-		bodyContent := cp.replaceVariables(api.Body, variables)
 		trimmed := strings.TrimSpace(bodyContent)
 		var js interface{}
 		if (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && json.Unmarshal([]byte(trimmed), &js) == nil {
@@ -1742,9 +1913,10 @@ func (cp *CollectionProcessor) executeAPI(api APIRequest, variables map[string]s
 		}
 	}
 
-	// Execute request
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	cp.sanitizer.ScanRequest(requestLabel(api), req, bodyContent)
+
+	// Execute request over the pooled keep-alive transport
+	resp, err := cp.client.Do(req)
 	if err != nil {
 		return nil, &models.APIExecutionError{
 			APIName: api.Name,
@@ -1842,7 +2014,7 @@ func (cp *CollectionProcessor) resolveVariables(api *APIRequest, neededVars []st
 		fmt.Printf("\n   ⚠️  Variable '%s' not found in environment or scripts\n", varName)
 
 		var value string
-		if err := survey.AskOne(&survey.Input{
+		if err := ask.One(&survey.Input{
 			Message: fmt.Sprintf("Enter value for '%s':", varName),
 			Help:    "This variable is needed to execute the API. Enter the value or press Ctrl+C to cancel.",
 		}, &value); err != nil {
@@ -1916,6 +2088,7 @@ func (cp *CollectionProcessor) executePreScript(preScript string, api APIRequest
 
 	// Create script engine
 	engine := NewScriptEngine(existingVars)
+	engine.SetSanitizer(cp.sanitizer, requestLabel(api))
 	// Provide request context for scripts
 	engine.SetRequestData(api.Method, api.URL, api.Body, api.Headers)
 
@@ -1957,6 +2130,7 @@ func (cp *CollectionProcessor) executePostScript(postScript string, api APIReque
 
 	// Create script engine
 	engine := NewScriptEngine(existingVars)
+	engine.SetSanitizer(cp.sanitizer, requestLabel(api))
 	// Provide request context for scripts
 	engine.SetRequestData(api.Method, api.URL, api.Body, api.Headers)
 
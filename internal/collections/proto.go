@@ -0,0 +1,164 @@
+package collections
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hemantobora/auto-mock/internal/protofile"
+)
+
+// protoBaseURL is the host imported gRPC methods are keyed under; only the path is matched
+const protoBaseURL = "http://localhost"
+
+// parseProtoFile turns every RPC of the services declared in a .proto file into a documented
+// request keyed by its gRPC path (POST /pkg.Service/Method, JSON bodies), plus the REST route
+// of its google.api.http annotation. Bodies are samples generated from the message types.
+func (cp *CollectionProcessor) parseProtoFile(filePath string) ([]APIRequest, error) {
+	set, err := protofile.Load(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proto file: %w", err)
+	}
+	var apis []APIRequest
+	for _, svc := range set.Services {
+		if svc.File != filepath.Base(filePath) {
+			continue // declared by an imported file
+		}
+		for _, m := range svc.Methods {
+			apis = append(apis, protoMethodRequests(set, m, protoBaseURL)...)
+		}
+	}
+	if len(apis) == 0 {
+		return nil, fmt.Errorf("%s declares no services", filepath.Base(filePath))
+	}
+	for i := range apis {
+		apis[i].ID = fmt.Sprintf("grpc_%d", i+1)
+	}
+	fmt.Printf("📡 %d gRPC route(s) from %s; serve them natively with 'automock serve --proto %s'\n",
+		len(apis), filepath.Base(filePath), filePath)
+	return apis, nil
+}
+
+// protoMethodRequests builds the JSON-transcoded request for a method, and its REST route
+// when annotated. Streaming messages are JSON arrays of one sample message.
+func protoMethodRequests(set *protofile.Set, m *protofile.Method, baseURL string) []APIRequest {
+	input, output := set.Sample(m.Input), set.Sample(m.Output)
+	if m.ClientStreaming {
+		input = []interface{}{input}
+	}
+	if m.ServerStreaming {
+		output = []interface{}{output}
+	}
+	service := m.Service[strings.LastIndex(m.Service, ".")+1:]
+	rpc := APIRequest{
+		Name:        fmt.Sprintf("%s.%s (gRPC)", service, m.Name),
+		Method:      "POST",
+		URL:         strings.TrimRight(baseURL, "/") + m.Path(),
+		Headers:     map[string]string{"Content-Type": "application/json"},
+		Body:        marshalSample(input),
+		QueryParams: map[string]string{},
+		Variables:   map[string]string{},
+		Documented:  protoResponse(output),
+	}
+	apis := []APIRequest{rpc}
+
+	if m.HTTP != nil && !m.ClientStreaming {
+		rest := rpc
+		rest.Name = fmt.Sprintf("%s.%s (%s %s)", service, m.Name, m.HTTP.Method, m.HTTP.Path)
+		rest.Method = m.HTTP.Method
+		rest.URL = strings.TrimRight(baseURL, "/") + m.HTTP.Path
+		rest.Headers = map[string]string{}
+		rest.Body = ""
+		switch body := m.HTTP.Body; {
+		case body == "*":
+			rest.Body = rpc.Body
+		case body != "":
+			fields, _ := input.(map[string]interface{})
+			for _, f := range set.Messages[m.Input].Fields {
+				if f.Name == body && fields[f.JSONName] != nil {
+					rest.Body = marshalSample(fields[f.JSONName])
+				}
+			}
+		}
+		if rest.Body != "" {
+			rest.Headers["Content-Type"] = "application/json"
+		}
+		apis = append(apis, rest)
+	}
+	return apis
+}
+
+func protoResponse(body interface{}) *APIResponse {
+	return &APIResponse{
+		StatusCode: 200,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+		Body:       marshalSample(body),
+		Cookies:    map[string]string{},
+	}
+}
+
+// insomniaProtoFiles parses the proto_file resources of an Insomnia export, by resource ID.
+// Files that fail to parse (e.g. importing another proto file) are reported and skipped.
+func (cp *CollectionProcessor) insomniaProtoFiles(resources []interface{}) map[string]*protofile.Set {
+	sets := map[string]*protofile.Set{}
+	for _, resource := range resources {
+		r, ok := resource.(map[string]interface{})
+		if !ok || cp.getString(r, "_type") != "proto_file" {
+			continue
+		}
+		set, err := protofile.Parse(cp.getString(r, "name"), cp.getString(r, "protoText"))
+		if err != nil {
+			fmt.Printf("⚠️  Proto file %s: %v\n", cp.getString(r, "name"), err)
+			continue
+		}
+		sets[cp.getString(r, "_id")] = set
+	}
+	return sets
+}
+
+// parseInsomniaGrpcRequest keys an Insomnia gRPC request by its method path with a JSON body.
+// gRPC calls are not executed: the response is a sample built from the request's proto file.
+func (cp *CollectionProcessor) parseInsomniaGrpcRequest(resourceMap map[string]interface{}, protos map[string]*protofile.Set) APIRequest {
+	path := cp.getString(resourceMap, "protoMethodName") // e.g. /shop.v1.OrderService/GetOrder
+	host := cp.getString(resourceMap, "url")
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	api := APIRequest{
+		ID:          cp.getString(resourceMap, "_id"),
+		Name:        cp.getString(resourceMap, "name") + " (gRPC)",
+		Method:      "POST",
+		URL:         "http://" + strings.TrimRight(host, "/") + path,
+		Headers:     map[string]string{"Content-Type": "application/json"},
+		QueryParams: map[string]string{},
+		Variables:   map[string]string{},
+		Documented:  protoResponse(map[string]interface{}{}),
+	}
+	if body, ok := resourceMap["body"].(map[string]interface{}); ok {
+		api.Body = cp.getString(body, "text")
+	}
+	if metadata, ok := resourceMap["metadata"].([]interface{}); ok {
+		for _, item := range metadata {
+			if md, ok := item.(map[string]interface{}); ok && cp.getString(md, "name") != "" {
+				api.Headers[cp.getString(md, "name")] = cp.getString(md, "value")
+			}
+		}
+	}
+
+	set := protos[cp.getString(resourceMap, "protoFileId")]
+	if set == nil {
+		fmt.Printf("⚠️  %s: proto file not in the export; the response body is empty\n", api.Name)
+		return api
+	}
+	if m := set.Method(path); m != nil {
+		output := set.Sample(m.Output)
+		if m.ServerStreaming {
+			output = []interface{}{output}
+		}
+		api.Documented = protoResponse(output)
+		if api.Body == "" {
+			api.Body = marshalSample(set.Sample(m.Input))
+		}
+	}
+	return api
+}
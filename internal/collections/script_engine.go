@@ -7,6 +7,7 @@ import (
 
 	"github.com/dop251/goja"
 	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/security"
 )
 
 // ScriptEngine provides a JavaScript execution environment for pre/post scripts
@@ -25,6 +26,9 @@ type ScriptEngine struct {
 	requestBody    string
 	requestHeaders map[string]string
 	requestObject  map[string]interface{}
+	// secrets in logged variables and console output are masked
+	sanitizer *security.CollectionSanitizer
+	where     string
 }
 
 // NewScriptEngine creates a new JavaScript execution environment
@@ -39,6 +43,17 @@ func NewScriptEngine(existingVars map[string]string) *ScriptEngine {
 	return se
 }
 
+// SetSanitizer masks secrets in what the script logs; where names the request in findings
+func (se *ScriptEngine) SetSanitizer(s *security.CollectionSanitizer, where string) {
+	se.sanitizer = s
+	se.where = where
+}
+
+// shown returns a variable value as logged, masked if it is a secret
+func (se *ScriptEngine) shown(key, value string) string {
+	return se.sanitizer.MaskVariable(se.where+" script", key, value)
+}
+
 // setupEnvironment configures the Postman-compatible JavaScript environment
 func (se *ScriptEngine) setupEnvironment() {
 	// Create pm object (Postman API)
@@ -86,13 +101,13 @@ func (se *ScriptEngine) setupEnvironment() {
 	// Set up console for debugging
 	console := map[string]interface{}{
 		"log": func(args ...interface{}) {
-			fmt.Printf("   [Script Log] %v\n", args)
+			fmt.Printf("   [Script Log] %s\n", se.sanitizer.Mask(fmt.Sprint(args)))
 		},
 		"error": func(args ...interface{}) {
-			fmt.Printf("   [Script Error] %v\n", args)
+			fmt.Printf("   [Script Error] %s\n", se.sanitizer.Mask(fmt.Sprint(args)))
 		},
 		"warn": func(args ...interface{}) {
-			fmt.Printf("   [Script Warn] %v\n", args)
+			fmt.Printf("   [Script Warn] %s\n", se.sanitizer.Mask(fmt.Sprint(args)))
 		},
 	}
 	se.vm.Set("console", console)
@@ -159,7 +174,7 @@ func (se *ScriptEngine) GetExtractedVariables() map[string]string {
 func (se *ScriptEngine) environmentSet(key string, value interface{}) {
 	strValue := fmt.Sprintf("%v", value)
 	se.extractedVars[key] = strValue
-	fmt.Printf("   📝 pm.environment.set('%s', '%s')\n", key, strValue)
+	fmt.Printf("   📝 pm.environment.set('%s', '%s')\n", key, se.shown(key, strValue))
 }
 
 func (se *ScriptEngine) environmentGet(key string) interface{} {
@@ -178,7 +193,7 @@ func (se *ScriptEngine) environmentGet(key string) interface{} {
 func (se *ScriptEngine) globalsSet(key string, value interface{}) {
 	strValue := fmt.Sprintf("%v", value)
 	se.extractedVars[key] = strValue
-	fmt.Printf("   📝 pm.globals.set('%s', '%s')\n", key, strValue)
+	fmt.Printf("   📝 pm.globals.set('%s', '%s')\n", key, se.shown(key, strValue))
 }
 
 func (se *ScriptEngine) globalsGet(key string) interface{} {
@@ -195,7 +210,7 @@ func (se *ScriptEngine) globalsGet(key string) interface{} {
 func (se *ScriptEngine) collectionVariablesSet(key string, value interface{}) {
 	strValue := fmt.Sprintf("%v", value)
 	se.extractedVars[key] = strValue
-	fmt.Printf("   📝 pm.collectionVariables.set('%s', '%s')\n", key, strValue)
+	fmt.Printf("   📝 pm.collectionVariables.set('%s', '%s')\n", key, se.shown(key, strValue))
 }
 
 func (se *ScriptEngine) collectionVariablesGet(key string) interface{} {
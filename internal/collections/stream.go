@@ -0,0 +1,173 @@
+package collections
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	streamThresholdBytes = 20 << 20  // Postman collections from this size are stream-parsed
+	maxLoadedBytes       = 256 << 20 // other formats are loaded whole and refused above this
+)
+
+// looksLikePostman checks the head of a file for Postman collection markers
+func looksLikePostman(head []byte) bool {
+	return bytes.Contains(head, []byte(`"_postman_id"`)) || bytes.Contains(head, []byte("schema.getpostman.com"))
+}
+
+// streamPostmanCollection parses a Postman collection one item at a time, skipping saved
+// example responses, so giant exports do not have to fit in memory as a whole. It stops
+// as soon as the request limit is exceeded.
+func (cp *CollectionProcessor) streamPostmanCollection(r io.Reader) ([]APIRequest, error) {
+	dec := json.NewDecoder(bufio.NewReaderSize(r, 1<<20))
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+	var apis []APIRequest
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch key {
+		case "info":
+			var info map[string]interface{}
+			if err := dec.Decode(&info); err != nil {
+				return nil, err
+			}
+			fmt.Printf("Collection: %s\n", info["name"])
+		case "item":
+			if err := cp.streamPostmanItems(dec, &apis); err != nil {
+				return nil, err
+			}
+		default:
+			if err := skipValue(dec); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return apis, nil
+}
+
+// streamPostmanItems walks an "item" array, descending into folders
+func (cp *CollectionProcessor) streamPostmanItems(dec *json.Decoder, apis *[]APIRequest) error {
+	if err := expectDelim(dec, '['); err != nil {
+		return err
+	}
+	for dec.More() {
+		if err := expectDelim(dec, '{'); err != nil {
+			return err
+		}
+		item := map[string]interface{}{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			switch key {
+			case "item":
+				if err := cp.streamPostmanItems(dec, apis); err != nil {
+					return err
+				}
+			case "response": // saved examples are not used by the import
+				if err := skipValue(dec); err != nil {
+					return err
+				}
+			default:
+				var v interface{}
+				if err := dec.Decode(&v); err != nil {
+					return err
+				}
+				item[fmt.Sprint(key)] = v
+			}
+		}
+		if _, err := dec.Token(); err != nil { // closing '}'
+			return err
+		}
+		if _, ok := item["request"]; !ok {
+			continue
+		}
+		for _, api := range cp.parsePostmanItems([]interface{}{item}) {
+			api.ID = fmt.Sprintf("postman_%d", len(*apis))
+			*apis = append(*apis, api)
+		}
+		if err := cp.checkRequestLimit(len(*apis)); err != nil {
+			return err
+		}
+	}
+	_, err := dec.Token() // closing ']'
+	return err
+}
+
+// parseLargeCollection stream-parses a Postman file above the size threshold and refuses
+// other files too large to load. It returns handled=false when the file is left to the
+// regular parsers.
+func (cp *CollectionProcessor) parseLargeCollection(filePath string) (apis []APIRequest, handled bool, err error) {
+	info, err := os.Stat(filePath)
+	if err != nil || info.Size() < streamThresholdBytes {
+		return nil, false, nil
+	}
+	tooLarge := fmt.Errorf("%s is %d MB, too large to load; split it into smaller files and import them one at a time",
+		filePath, info.Size()>>20)
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, false, nil
+	}
+	defer f.Close()
+
+	head := make([]byte, 64<<10)
+	n, _ := io.ReadFull(f, head)
+	streamable := (isAutoCollectionType(cp.collectionType) || cp.collectionType == CollectionTypePostman) && looksLikePostman(head[:n])
+	if !streamable {
+		if info.Size() > maxLoadedBytes {
+			return nil, true, tooLarge
+		}
+		return nil, false, nil
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, true, err
+	}
+
+	cp.collectionType = CollectionTypePostman
+	fmt.Printf("\n📄 Stream-parsing large postman collection (%d MB): %s\n", info.Size()>>20, filePath)
+	apis, err = cp.streamPostmanCollection(f)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to parse %s: %w", filePath, err)
+	}
+	return apis, true, nil
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != want {
+		return fmt.Errorf("invalid collection JSON: expected %q, got %v", want, tok)
+	}
+	return nil
+}
+
+// skipValue consumes the next JSON value without keeping it
+func skipValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package collections
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	dnsCacheTTL      = 5 * time.Minute
+	warmupThreshold  = 10 // warm connections up only for collections at least this large
+	executionTimeout = 30 * time.Second
+)
+
+// dnsCache memoizes host lookups for the duration of an import so every
+// request to the same API does not pay for a fresh resolution
+type dnsCache struct {
+	mu      sync.Mutex
+	entries map[string]dnsEntry
+}
+
+type dnsEntry struct {
+	addrs   []string
+	expires time.Time
+}
+
+func (c *dnsCache) lookup(ctx context.Context, host string) ([]string, error) {
+	c.mu.Lock()
+	if e, ok := c.entries[host]; ok && time.Now().Before(e.expires) {
+		c.mu.Unlock()
+		return e.addrs, nil
+	}
+	c.mu.Unlock()
+
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[host] = dnsEntry{addrs: addrs, expires: time.Now().Add(dnsCacheTTL)}
+	c.mu.Unlock()
+	return addrs, nil
+}
+
+// dialContext resolves through the cache and tries each address in turn
+func (c *dnsCache) dialContext(dialer *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil || net.ParseIP(host) != nil {
+			return dialer.DialContext(ctx, network, addr)
+		}
+		addrs, err := c.lookup(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		var lastErr error
+		for _, ip := range addrs {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip, port))
+			if err == nil {
+				return conn, nil
+			}
+			lastErr = err
+		}
+		return nil, lastErr
+	}
+}
+
+// newPooledHTTPClient builds the client shared by all executions of an import:
+// keep-alive connections are pooled per host so TLS handshakes happen once
+func newPooledHTTPClient() *http.Client {
+	cache := &dnsCache{entries: map[string]dnsEntry{}}
+	dialer := &net.Dialer{Timeout: 10 * time.Second, KeepAlive: 30 * time.Second}
+
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           cache.dialContext(dialer),
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   16,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	return &http.Client{Timeout: executionTimeout, Transport: transport}
+}
+
+// warmupConnections opens a connection to every distinct origin up front so
+// large collections do not pay DNS + TLS setup inside the first timed requests.
+// URLs still containing unresolved variables are skipped; failures are ignored.
+func (cp *CollectionProcessor) warmupConnections(nodes []ExecutionNode) {
+	if len(nodes) < warmupThreshold {
+		return
+	}
+
+	origins := map[string]bool{}
+	for _, n := range nodes {
+		if n.API.Documented != nil || strings.Contains(n.API.URL, "{{") {
+			continue
+		}
+		u, err := url.Parse(n.API.URL)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			continue
+		}
+		origins[u.Scheme+"://"+u.Host] = true
+	}
+	if len(origins) == 0 {
+		return
+	}
+
+	fmt.Printf("🔌 Warming up connections to %d host(s)...\n", len(origins))
+	client := cp.client
+	var wg sync.WaitGroup
+	for origin := range origins {
+		wg.Add(1)
+		go func(origin string) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			req, err := http.NewRequestWithContext(ctx, http.MethodHead, origin+"/", nil)
+			if err != nil {
+				return
+			}
+			if resp, err := client.Do(req); err == nil {
+				resp.Body.Close()
+			}
+		}(origin)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// ConsumerOptions describes a consumer registration change requested from the CLI
+type ConsumerOptions struct {
+	Add     string
+	Remove  string
+	Webhook string
+	Email   string
+	Tags    []string
+	Paths   []string
+}
+
+// RunConsumers lists, registers or removes consumers of a project's mock contract.
+// With no Add/Remove the registered consumers are listed.
+func RunConsumers(profile, project string, opts ConsumerOptions) error {
+	if project == "" {
+		return fmt.Errorf("--project is required")
+	}
+	if opts.Add != "" && opts.Remove != "" {
+		return fmt.Errorf("flag --add is mutually exclusive with --remove")
+	}
+
+	manager := cloud.NewCloudManager(profile)
+	ctx := context.Background()
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return err
+	}
+	config, err := manager.Provider.GetConfig(ctx, project)
+	if err != nil {
+		return fmt.Errorf("project '%s' has no mock configuration; run 'automock init' first", project)
+	}
+
+	switch {
+	case opts.Add != "":
+		if opts.Webhook == "" && opts.Email == "" {
+			return fmt.Errorf("--webhook or --email is required with --add")
+		}
+		consumer := models.Consumer{
+			Name:         strings.TrimSpace(opts.Add),
+			Webhook:      opts.Webhook,
+			Email:        opts.Email,
+			Tags:         opts.Tags,
+			Paths:        opts.Paths,
+			RegisteredAt: time.Now().UTC(),
+		}
+		if i := config.FindConsumer(consumer.Name); i >= 0 {
+			config.Consumers[i] = consumer
+			fmt.Printf("🔁 Updated consumer %s\n", consumer.Name)
+		} else {
+			config.Consumers = append(config.Consumers, consumer)
+			fmt.Printf("✅ Registered consumer %s\n", consumer.Name)
+		}
+	case opts.Remove != "":
+		i := config.FindConsumer(opts.Remove)
+		if i < 0 {
+			return fmt.Errorf("consumer '%s' is not registered for project '%s'", opts.Remove, project)
+		}
+		config.Consumers = append(config.Consumers[:i], config.Consumers[i+1:]...)
+		fmt.Printf("🗑️  Removed consumer %s\n", opts.Remove)
+	default:
+		printConsumers(project, config.Consumers)
+		return nil
+	}
+
+	if err := manager.Provider.UpdateConfig(ctx, config); err != nil {
+		return fmt.Errorf("failed to save consumers: %w", err)
+	}
+	return nil
+}
+
+func printConsumers(project string, consumers []models.Consumer) {
+	if len(consumers) == 0 {
+		fmt.Printf("ℹ️  No consumers registered for %s.\n", project)
+		fmt.Printf("💡 Register one: automock consumers --project %s --add <team> --webhook <url> --paths '/users/**'\n", project)
+		return
+	}
+	fmt.Printf("\n📣 Consumers of %s (%d)\n", project, len(consumers))
+	fmt.Println(strings.Repeat("━", 60))
+	for _, c := range consumers {
+		fmt.Printf("• %s\n", c.Name)
+		if c.Webhook != "" {
+			fmt.Printf("   Webhook: %s\n", c.Webhook)
+		}
+		if c.Email != "" {
+			fmt.Printf("   Email:   %s\n", c.Email)
+		}
+		scope := "all expectations"
+		var parts []string
+		if len(c.Paths) > 0 {
+			parts = append(parts, "paths "+strings.Join(c.Paths, ", "))
+		}
+		if len(c.Tags) > 0 {
+			parts = append(parts, "tags "+strings.Join(c.Tags, ", "))
+		}
+		if len(parts) > 0 {
+			scope = strings.Join(parts, "; ")
+		}
+		fmt.Printf("   Watches: %s\n", scope)
+	}
+}
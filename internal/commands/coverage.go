@@ -0,0 +1,233 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/collections"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// CoverageOptions configures automock coverage
+type CoverageOptions struct {
+	Spec        string  // OpenAPI spec or collection the expectations are compared against
+	SpecType    string  // collection type of Spec (empty = detected)
+	JSON        string  // write a JSON report to this file
+	HTML        string  // write an HTML report to this file
+	MinCoverage float64 // fail when fewer than this percentage of operations are covered (0 = never)
+}
+
+// coverageReport is the comparison of a project's expectations with a spec
+type coverageReport struct {
+	Project      string                  `json:"project"`
+	Spec         string                  `json:"spec"`
+	GeneratedAt  time.Time               `json:"generated_at"`
+	Operations   int                     `json:"operations"`
+	Percent      float64                 `json:"coverage_percent"`
+	Covered      []coveredOperation      `json:"covered"`
+	Missing      []collections.Operation `json:"missing"`
+	Undocumented []string                `json:"undocumented"` // expectations no operation describes
+}
+
+// coveredOperation is an operation and the expectations serving it
+type coveredOperation struct {
+	collections.Operation
+	Expectations []string `json:"expectations"`
+}
+
+// RunCoverage reports which operations of a spec or collection the project's expectations
+// serve, which they miss, and which expectations the spec does not describe
+func RunCoverage(profile, project string, opts CoverageOptions) error {
+	if project == "" {
+		return exitcode.New(exitcode.Config, "--project is required")
+	}
+	if opts.MinCoverage < 0 || opts.MinCoverage > 100 {
+		return exitcode.New(exitcode.Config, "--min-coverage must be a percentage between 0 and 100")
+	}
+	ops, err := collections.ListOperations(opts.Spec, opts.SpecType)
+	if err != nil {
+		return exitcode.New(exitcode.Config, "failed to read %s: %w", opts.Spec, err)
+	}
+	ctx := context.Background()
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return err
+	}
+	config, err := manager.Provider.GetConfig(ctx, project)
+	if err != nil {
+		return exitcode.New(exitcode.Config, "failed to load project '%s': %w", project, err)
+	}
+
+	report := compareCoverage(ops, config.Expectations)
+	report.Project, report.Spec, report.GeneratedAt = project, opts.Spec, time.Now().UTC()
+	printCoverage(report)
+	if opts.JSON != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(opts.JSON, append(data, '\n'), 0644); err != nil {
+			return fmt.Errorf("failed to write JSON report: %w", err)
+		}
+		fmt.Printf("📄 JSON report: %s\n", opts.JSON)
+	}
+	if opts.HTML != "" {
+		if err := writeCoverageHTML(opts.HTML, report); err != nil {
+			return err
+		}
+		fmt.Printf("📄 HTML report: %s\n", opts.HTML)
+	}
+	if opts.MinCoverage > 0 && report.Percent < opts.MinCoverage {
+		return exitcode.New(exitcode.Validation, "coverage %.1f%% is below the minimum of %.1f%%", report.Percent, opts.MinCoverage)
+	}
+	return nil
+}
+
+// compareCoverage matches every operation against the expectations; an expectation without a
+// method serves every method, and generated companions and the contract info endpoint are ignored
+func compareCoverage(ops []collections.Operation, exps []models.MockExpectation) *coverageReport {
+	report := &coverageReport{Operations: len(ops), Covered: []coveredOperation{}, Missing: []collections.Operation{}, Undocumented: []string{}}
+	used := make([]bool, len(exps))
+	for _, op := range ops {
+		covered := coveredOperation{Operation: op}
+		for i := range exps {
+			exp := &exps[i]
+			if !countsForCoverage(exp) {
+				continue
+			}
+			if (exp.HttpRequest.Method == "" || strings.EqualFold(exp.HttpRequest.Method, op.Method)) && pathServes(exp.HttpRequest.Path, op.Path) {
+				covered.Expectations = append(covered.Expectations, coverageLabel(i, exp))
+				used[i] = true
+			}
+		}
+		if len(covered.Expectations) > 0 {
+			report.Covered = append(report.Covered, covered)
+		} else {
+			report.Missing = append(report.Missing, op)
+		}
+	}
+	for i := range exps {
+		if !used[i] && countsForCoverage(&exps[i]) {
+			report.Undocumented = append(report.Undocumented, coverageLabel(i, &exps[i]))
+		}
+	}
+	if len(ops) > 0 {
+		report.Percent = float64(len(report.Covered)) * 100 / float64(len(ops))
+	}
+	return report
+}
+
+func countsForCoverage(exp *models.MockExpectation) bool {
+	return exp.HttpRequest != nil && exp.HttpRequest.Path != models.ContractInfoPath && !exp.Generated()
+}
+
+func coverageLabel(i int, exp *models.MockExpectation) string {
+	method := exp.HttpRequest.Method
+	if method == "" {
+		method = "*"
+	}
+	return fmt.Sprintf("#%d %s %s", i+1, method, exp.HttpRequest.Path)
+}
+
+// pathServes reports whether an expectation path serves an operation's path template. Segments
+// are compared one by one: a {param} in the template accepts any segment, and an expectation
+// segment may be a literal, a {param} or a regex. A whole-path regex is tried against the
+// template with its parameters filled in.
+func pathServes(expPath, template string) bool {
+	want := strings.Split(strings.Trim(template, "/"), "/")
+	got := strings.Split(strings.Trim(expPath, "/"), "/")
+	if len(want) == len(got) {
+		ok := true
+		for i := range want {
+			if !segmentServes(got[i], want[i]) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	re, err := regexp.Compile("^" + expPath + "$")
+	return err == nil && re.MatchString(templateParam.ReplaceAllString(template, "1"))
+}
+
+var templateParam = regexp.MustCompile(`\{[^/{}]+\}`)
+
+func segmentServes(got, want string) bool {
+	switch {
+	case got == want, templateParam.MatchString(want) && templateParam.ReplaceAllString(want, "") == "":
+		return true
+	case templateParam.MatchString(got):
+		return false // a parameter only serves a parameter
+	}
+	re, err := regexp.Compile("^" + got + "$")
+	return err == nil && re.MatchString(want)
+}
+
+// printCoverage prints the summary and every uncovered operation and undocumented expectation
+func printCoverage(r *coverageReport) {
+	fmt.Printf("📊 %s vs %s: %d of %d operation(s) covered (%.1f%%)\n\n", r.Project, r.Spec, len(r.Covered), r.Operations, r.Percent)
+	for _, c := range r.Covered {
+		fmt.Printf("✅ %-7s %s ← %s\n", c.Method, c.Path, strings.Join(c.Expectations, ", "))
+	}
+	for _, op := range r.Missing {
+		fmt.Printf("❌ %-7s %s — no expectation\n", op.Method, op.Path)
+	}
+	if len(r.Undocumented) > 0 {
+		fmt.Printf("\n⚠️  %d expectation(s) not in the spec:\n", len(r.Undocumented))
+		for _, label := range r.Undocumented {
+			fmt.Printf("   %s\n", label)
+		}
+	}
+	fmt.Println()
+}
+
+var coverageHTML = template.Must(template.New("coverage").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Project}} coverage</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem; color: #222; }
+table { border-collapse: collapse; margin-bottom: 2rem; }
+th, td { text-align: left; padding: .3rem .8rem; border-bottom: 1px solid #ddd; }
+.covered { color: #1a7f37; } .missing { color: #cf222e; } .undocumented { color: #9a6700; }
+code { font-size: .95em; }
+</style>
+</head>
+<body>
+<h1>{{.Project}} coverage: {{printf "%.1f" .Percent}}%</h1>
+<p>{{len .Covered}} of {{.Operations}} operation(s) in <code>{{.Spec}}</code> have an expectation. Generated {{.GeneratedAt.Format "2006-01-02 15:04 MST"}}.</p>
+<table>
+<tr><th></th><th>Method</th><th>Path</th><th>Operation</th><th>Expectations</th></tr>
+{{range .Covered}}<tr class="covered"><td>✔</td><td>{{.Method}}</td><td><code>{{.Path}}</code></td><td>{{.Name}}</td><td>{{range $i, $e := .Expectations}}{{if $i}}, {{end}}{{$e}}{{end}}</td></tr>
+{{end}}{{range .Missing}}<tr class="missing"><td>✘</td><td>{{.Method}}</td><td><code>{{.Path}}</code></td><td>{{.Name}}</td><td>none</td></tr>
+{{end}}</table>
+{{if .Undocumented}}<h2 class="undocumented">Expectations not in the spec ({{len .Undocumented}})</h2>
+<ul>
+{{range .Undocumented}}<li><code>{{.}}</code></li>
+{{end}}</ul>
+{{end}}</body>
+</html>
+`))
+
+func writeCoverageHTML(path string, r *coverageReport) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to write HTML report: %w", err)
+	}
+	defer f.Close()
+	if err := coverageHTML.Execute(f, r); err != nil {
+		return fmt.Errorf("failed to write HTML report: %w", err)
+	}
+	return f.Close()
+}
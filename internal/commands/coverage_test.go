@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/collections"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+func TestCompareCoverage(t *testing.T) {
+	ops := []collections.Operation{
+		{Method: "GET", Path: "/v1/users"},
+		{Method: "GET", Path: "/v1/users/{id}"},
+		{Method: "DELETE", Path: "/v1/users/{id}"},
+		{Method: "GET", Path: "/v1/users/me"},
+		{Method: "POST", Path: "/v1/orders"},
+	}
+	exp := func(method, path string) models.MockExpectation {
+		return models.MockExpectation{HttpRequest: &models.HttpRequest{Method: method, Path: path}, HttpResponse: &models.HttpResponse{StatusCode: 200}}
+	}
+	exps := []models.MockExpectation{
+		exp("GET", "/v1/users"),
+		exp("GET", "/v1/users/7"),
+		exp("GET", "/v1/users/[^/]+"),
+		exp("GET", "/v1/health"),
+		exp("", "/v1/orders"),
+		exp("GET", models.ContractInfoPath),
+	}
+
+	r := compareCoverage(ops, exps)
+	if len(r.Covered) != 4 || len(r.Missing) != 1 || r.Missing[0].Method != "DELETE" || r.Percent != 80 {
+		t.Fatalf("covered %+v, missing %+v, %.1f%%", r.Covered, r.Missing, r.Percent)
+	}
+	if got := strings.Join(r.Covered[1].Expectations, ", "); got != "#2 GET /v1/users/7, #3 GET /v1/users/[^/]+" {
+		t.Errorf("GET /v1/users/{id} served by %s", got)
+	}
+	if got := strings.Join(r.Covered[2].Expectations, ", "); got != "#3 GET /v1/users/[^/]+" {
+		t.Errorf("GET /v1/users/me served by %s", got)
+	}
+	if len(r.Undocumented) != 1 || r.Undocumented[0] != "#4 GET /v1/health" {
+		t.Errorf("undocumented %v", r.Undocumented)
+	}
+
+	r.Project, r.Spec = "users", "openapi.yaml"
+	path := filepath.Join(t.TempDir(), "coverage.html")
+	if err := writeCoverageHTML(path, r); err != nil {
+		t.Fatal(err)
+	}
+	html, _ := os.ReadFile(path)
+	if !strings.Contains(string(html), "users coverage: 80.0%") || !strings.Contains(string(html), "#3 GET /v1/users/[^/]&#43;") {
+		t.Errorf("HTML report:\n%s", html)
+	}
+}
@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/diagnostics"
+)
+
+// RunDebugBundle writes a redacted diagnostic bundle for support tickets.
+// With a project, a manifest of its state store is included; cloud errors are recorded, not fatal.
+func RunDebugBundle(profile, project, outDir, version string) error {
+	bundle := diagnostics.New(version, nil, "")
+
+	if project != "" {
+		manager := cloud.NewCloudManager(profile)
+		if err := manager.AutoDetectProvider(profile); err != nil {
+			bundle.Store = &diagnostics.StoreManifest{Project: project, Error: diagnostics.Redact(err.Error())}
+		} else {
+			bundle.AddStore(context.Background(), manager.Provider, project)
+		}
+	}
+
+	path, err := bundle.Write(outDir)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("🧰 Diagnostic bundle written to %s\n", path)
+	fmt.Println("💡 Review it, then attach it to your support ticket or GitHub issue.")
+	return nil
+}
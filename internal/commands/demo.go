@@ -0,0 +1,347 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/checks"
+	"github.com/hemantobora/auto-mock/internal/collections"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/localmock"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// DemoProject is the name of the sample project the demo creates
+const DemoProject = "automock-demo"
+
+// DemoOptions configures the guided demo
+type DemoOptions struct {
+	Port int  // local mock port; 0 picks a free one
+	Keep bool // keep the demo directory (collection and expectations) afterwards
+}
+
+// RunDemo walks a new user through the mock lifecycle on a sample project: import a small
+// collection, review the expectations, serve them locally and test them. Everything runs on
+// this machine (a built-in sample API stands in for the real one) and is removed at the end.
+func RunDemo(opts DemoOptions) error {
+	dir, err := os.MkdirTemp("", DemoProject+"-")
+	if err != nil {
+		return fmt.Errorf("failed to create the demo directory: %w", err)
+	}
+	defer func() {
+		if opts.Keep {
+			fmt.Printf("\n📁 Demo files kept in %s\n", dir)
+			return
+		}
+		_ = os.RemoveAll(dir)
+		fmt.Println("\n🧹 Demo files removed")
+	}()
+
+	fmt.Println("\n🎓 AUTOMOCK DEMO")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("This tour builds a mock for a sample \"users\" API in project %q:\n", DemoProject)
+	fmt.Println("   1. Collection — the API requests and their tests")
+	fmt.Println("   2. Import     — run the requests and record the responses")
+	fmt.Println("   3. Review     — the expectations the recordings became")
+	fmt.Println("   4. Serve      — answer requests from a local mock, with the real API offline")
+	fmt.Println("   5. Test       — check the mock against the collection's tests")
+	fmt.Println("💡 Nothing is deployed and no cloud credentials are needed; the demo cleans up after itself.")
+	if err := demoContinue(); err != nil {
+		return err
+	}
+
+	api, err := startDemoServer("127.0.0.1:0", newDemoAPI())
+	if err != nil {
+		return fmt.Errorf("failed to start the sample API: %w", err)
+	}
+	defer api.stop()
+
+	// 1. Collection
+	demoStep(1, "Collection",
+		"A sample API is running at "+api.url+", standing in for your real service.",
+		"This Postman collection calls it three times. The pm.test blocks are the collection's tests;",
+		"the import keeps them with the mock as checks.")
+	collection := filepath.Join(dir, DemoProject+".postman_collection.json")
+	if err := writeDemoCollection(collection, api.url); err != nil {
+		return err
+	}
+	fmt.Printf("📄 %s\n", collection)
+	for _, line := range []string{"GET  /users    List users  (tests: status 200, total above 0)",
+		"POST /users    Create user (tests: status 201, name)",
+		"GET  /users/1  Get user    (tests: status 200, id is a number)"} {
+		fmt.Printf("   • %s\n", line)
+	}
+	if err := demoContinue(); err != nil {
+		return err
+	}
+
+	// 2. Import
+	demoStep(2, "Import",
+		"This is the same import 'automock init --collection-file' runs. Its prompts, in order:",
+		"• the disclaimer: confirm the request order is right (it is)",
+		"• response times: whether the mock replays the recorded latency (no, for the demo)",
+		"• per request: what to match on (body, query, path, headers) and extra features;",
+		"  the suggested answers are fine")
+	if err := demoContinue(); err != nil {
+		return err
+	}
+	processor, err := collections.NewCollectionProcessor(DemoProject, "postman")
+	if err != nil {
+		return err
+	}
+	processor.SetConcurrency(1)
+	mockJSON, err := processor.ProcessCollection(collection)
+	if err != nil {
+		return err
+	}
+	cfg, err := models.ParseMockServerJSON(mockJSON)
+	if err != nil {
+		return err
+	}
+	expectationsFile := filepath.Join(dir, "expectations.json")
+	if err := os.WriteFile(expectationsFile, []byte(mockJSON), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", expectationsFile, err)
+	}
+
+	// 3. Review
+	demoStep(3, "Review",
+		"Each recorded response became an expectation: a request matcher plus the response to send.",
+		"With a real project they are saved to your cloud storage; here they are in "+expectationsFile+".")
+	for i, exp := range cfg.Expectations {
+		status := 0
+		if exp.HttpResponse != nil {
+			status = exp.HttpResponse.StatusCode
+		}
+		fmt.Printf("   [%d] %s %s → %d (%d check(s))\n", i+1, exp.HttpRequest.Method, exp.HttpRequest.Path, status, len(exp.Checks))
+	}
+	if len(cfg.Expectations) > 0 {
+		show := false
+		if err := ask.One(&survey.Confirm{Message: "Show the first expectation as MockServer JSON?", Default: false}, &show); err != nil {
+			return err
+		}
+		if show {
+			data, _ := json.MarshalIndent(cfg.Expectations[0], "", "  ")
+			fmt.Println(string(data))
+		}
+	}
+	fmt.Println("💡 To change one later: 'automock init --project <name>' → edit, or edit the JSON and 'automock serve --file'.")
+	if err := demoContinue(); err != nil {
+		return err
+	}
+
+	// 4. Serve
+	api.stop()
+	if err := cfg.Prepare(); err != nil {
+		return err
+	}
+	mock, err := startDemoServer(net.JoinHostPort("localhost", strconv.Itoa(opts.Port)), localmock.New(cfg.Expectations, os.Stdout))
+	if err != nil {
+		return exitcode.WithHint(exitcode.Config, fmt.Errorf("failed to start the local mock: %w", err), "Pick another port with --port, or --port 0 for any free port")
+	}
+	defer mock.stop()
+	demoStep(4, "Serve",
+		"The sample API is now stopped. The local mock answers in its place, as 'automock serve' would:",
+		"curl "+mock.url+"/users",
+		"Requests no expectation matches get a 404; 'automock match' explains why.")
+
+	// 5. Test
+	demoStep(5, "Test",
+		"The collection's tests run against the mock, as 'automock test --project <name>' does against",
+		"a deployed one. Each check passes if the mock still answers the way the real API did.")
+	report := checks.Run(context.Background(), &http.Client{Timeout: 10 * time.Second}, mock.url, cfg.Expectations)
+	failed := printCheckReport(report)
+	if failed > 0 {
+		fmt.Println("💡 A check fails when a matcher or response was changed during the import; re-run the demo with the suggested answers.")
+	}
+
+	fmt.Printf("\n🚀 The mock keeps running at %s until you finish; try it from another terminal.\n", mock.url)
+	for done := false; !done; {
+		if err := ask.One(&survey.Confirm{Message: "Finish the demo and clean up?", Default: true}, &done); err != nil {
+			return err
+		}
+		if !done {
+			fmt.Printf("   Still serving at %s\n", mock.url)
+		}
+	}
+
+	fmt.Println("\n🎉 That's the whole loop. With your own API:")
+	fmt.Println("   automock init --project <name> --collection-file <collection>   # import, saved to cloud storage")
+	fmt.Println("   automock serve --project <name>                                  # local mock")
+	fmt.Println("   automock deploy --project <name>                                 # hosted mock")
+	fmt.Println("   automock test --project <name>                                   # check it")
+	if failed > 0 {
+		return exitcode.New(exitcode.Validation, "%d demo check(s) failed", failed)
+	}
+	return nil
+}
+
+// demoStep prints the heading and explanation of a demo step
+func demoStep(n int, title string, lines ...string) {
+	fmt.Printf("\n📍 STEP %d/5 — %s\n", n, strings.ToUpper(title))
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	for _, line := range lines {
+		fmt.Printf("💡 %s\n", line)
+	}
+}
+
+// demoContinue pauses between steps; declining ends the demo
+func demoContinue() error {
+	proceed := true
+	if err := ask.One(&survey.Confirm{Message: "Continue?", Default: true}, &proceed); err != nil {
+		return err
+	}
+	if !proceed {
+		return exitcode.New(exitcode.Cancelled, "demo stopped")
+	}
+	return nil
+}
+
+// demoServer is an HTTP server running in the background for the demo
+type demoServer struct {
+	url    string
+	server *http.Server
+	once   sync.Once
+}
+
+func startDemoServer(addr string, handler http.Handler) (*demoServer, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	host, _, _ := net.SplitHostPort(addr)
+	port := ln.Addr().(*net.TCPAddr).Port
+	s := &demoServer{url: "http://" + net.JoinHostPort(host, strconv.Itoa(port)), server: &http.Server{Handler: handler}}
+	go func() {
+		if err := s.server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Fprintf(os.Stderr, "⚠️  %s stopped: %v\n", s.url, err)
+		}
+	}()
+	return s, nil
+}
+
+func (s *demoServer) stop() {
+	s.once.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = s.server.Shutdown(ctx)
+	})
+}
+
+// demoUser is a record of the sample API
+type demoUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// newDemoAPI is the sample users API the demo collection is recorded against
+func newDemoAPI() http.Handler {
+	var mu sync.Mutex
+	users := []demoUser{{ID: 1, Name: "Grace Hopper", Role: "admin"}, {ID: 2, Name: "Alan Turing", Role: "member"}}
+	reply := func(w http.ResponseWriter, status int, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodGet:
+			reply(w, http.StatusOK, map[string]any{"users": users, "total": len(users)})
+		case http.MethodPost:
+			var u demoUser
+			if err := json.NewDecoder(r.Body).Decode(&u); err != nil || u.Name == "" {
+				reply(w, http.StatusBadRequest, map[string]string{"error": "name is required"})
+				return
+			}
+			u.ID = len(users) + 1
+			if u.Role == "" {
+				u.Role = "member"
+			}
+			users = append(users, u)
+			reply(w, http.StatusCreated, u)
+		default:
+			reply(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		}
+	})
+	mux.HandleFunc("/users/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		id, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/users/"))
+		for _, u := range users {
+			if u.ID == id {
+				reply(w, http.StatusOK, u)
+				return
+			}
+		}
+		reply(w, http.StatusNotFound, map[string]string{"error": "user not found"})
+	})
+	return mux
+}
+
+// writeDemoCollection writes the demo's Postman collection, calling the sample API at baseURL
+func writeDemoCollection(path, baseURL string) error {
+	request := func(name, method, url, body string, test ...string) map[string]any {
+		req := map[string]any{"method": method, "url": map[string]any{"raw": url}, "header": []any{}}
+		if body != "" {
+			req["header"] = []any{map[string]any{"key": "Content-Type", "value": "application/json"}}
+			req["body"] = map[string]any{"mode": "raw", "raw": body}
+		}
+		return map[string]any{
+			"name":    name,
+			"request": req,
+			"event":   []any{map[string]any{"listen": "test", "script": map[string]any{"type": "text/javascript", "exec": test}}},
+		}
+	}
+	collection := map[string]any{
+		"info": map[string]any{
+			"name":   "AutoMock demo — users API",
+			"schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json",
+		},
+		"item": []any{
+			request("List users", "GET", baseURL+"/users", "",
+				`var jsonData = pm.response.json();`,
+				`pm.test("Status code is 200", function () {`,
+				`    pm.response.to.have.status(200);`,
+				`});`,
+				`pm.test("Users are listed", function () {`,
+				`    pm.expect(jsonData.total).to.be.above(0);`,
+				`});`),
+			request("Create user", "POST", baseURL+"/users", `{"name": "Ada Lovelace", "role": "admin"}`,
+				`var jsonData = pm.response.json();`,
+				`pm.test("User is created", function () {`,
+				`    pm.response.to.have.status(201);`,
+				`    pm.expect(jsonData.name).to.eql("Ada Lovelace");`,
+				`});`),
+			request("Get user", "GET", baseURL+"/users/1", "",
+				`var jsonData = pm.response.json();`,
+				`pm.test("User is returned", function () {`,
+				`    pm.response.to.have.status(200);`,
+				`    pm.expect(jsonData.id).to.be.a('number');`,
+				`});`),
+		},
+	}
+	data, err := json.MarshalIndent(collection, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/ask"
+)
+
+// The demo answers every prompt with its default when unattended, so the whole tour runs:
+// import from the sample API, serve, and pass the collection's checks against the mock
+func TestRunDemoUnattended(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	ask.SetNonInteractive(true)
+	defer ask.SetNonInteractive(false)
+
+	if err := RunDemo(DemoOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	left, err := os.ReadDir(tmp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) != 0 {
+		t.Errorf("demo left %d file(s) behind", len(left))
+	}
+}
@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hemantobora/auto-mock/internal"
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// DiffOptions names the two versions to compare. "current" is the latest save and "deployed"
+// the version last deployed; an empty To means current, an empty From the version before To.
+type DiffOptions struct {
+	From string
+	To   string
+}
+
+// RunDiff prints the changes between two saved versions of a project's expectations
+func RunDiff(profile, project string, opts DiffOptions) error {
+	if project == "" {
+		return fmt.Errorf("--project is required")
+	}
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return err
+	}
+	from, to, err := loadVersionPair(context.Background(), manager.Provider, project, opts.From, opts.To)
+	if err != nil {
+		return err
+	}
+	fmt.Print(models.DiffVersions(from, to).Format())
+	return nil
+}
+
+// loadVersionPair loads the two configurations RunDiff compares
+func loadVersionPair(ctx context.Context, provider internal.Provider, project, from, to string) (*models.MockConfiguration, *models.MockConfiguration, error) {
+	current, err := provider.GetConfig(ctx, project)
+	if err != nil {
+		return nil, nil, exitcode.Wrap(exitcode.Config, fmt.Errorf("failed to load project %s: %w", project, err))
+	}
+	resolve := func(v string) (string, error) {
+		switch v {
+		case "", "current":
+			return current.Metadata.Version, nil
+		case "deployed":
+			if current.Metadata.DeployedVersion == "" {
+				return "", exitcode.New(exitcode.Config, "project %s has not been deployed", project)
+			}
+			return current.Metadata.DeployedVersion, nil
+		}
+		return v, nil
+	}
+	if to, err = resolve(to); err != nil {
+		return nil, nil, err
+	}
+	if from == "" {
+		versions, err := provider.ListVersions(ctx, project)
+		if err != nil {
+			return nil, nil, err
+		}
+		if from = previousVersion(versions, to); from == "" {
+			return nil, nil, exitcode.New(exitcode.Config, "no version of %s precedes %s; pass --from", project, to)
+		}
+	} else if from, err = resolve(from); err != nil {
+		return nil, nil, err
+	}
+
+	load := func(v string) (*models.MockConfiguration, error) {
+		if v == current.Metadata.Version {
+			return current, nil
+		}
+		cfg, err := provider.GetVersion(ctx, project, v)
+		if err != nil {
+			return nil, exitcode.Wrap(exitcode.Config, fmt.Errorf("version %s of %s: %w", v, project, err))
+		}
+		return cfg, nil
+	}
+	fromCfg, err := load(from)
+	if err != nil {
+		return nil, nil, err
+	}
+	toCfg, err := load(to)
+	if err != nil {
+		return nil, nil, err
+	}
+	fromCfg.Metadata.Version, toCfg.Metadata.Version = from, to
+	return fromCfg, toCfg, nil
+}
+
+// previousVersion returns the version saved just before version, or "" when there is none
+func previousVersion(versions []models.VersionInfo, version string) string {
+	sorted := append([]models.VersionInfo(nil), versions...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].CreatedAt.Before(sorted[j].CreatedAt) })
+	for i, v := range sorted {
+		if v.Version == version {
+			if i == 0 {
+				return ""
+			}
+			return sorted[i-1].Version
+		}
+	}
+	if len(sorted) > 0 && sorted[len(sorted)-1].Version != version {
+		return sorted[len(sorted)-1].Version
+	}
+	return ""
+}
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/compose"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// DockerOptions configures the generated Docker Compose bundle
+type DockerOptions struct {
+	Out       string // bundle directory (empty = automock-<project>)
+	Image     string // MockServer image (empty = compose.DefaultImage)
+	Port      int    // host port (0 = 1080)
+	Env       string // environment overlay bundled instead of the base expectations
+	Mask      string // masking profile applied to the bundled response bodies
+	Selection models.TagSelection
+	Force     bool // overwrite an existing bundle
+}
+
+// RunDocker writes a Docker Compose bundle serving a project's expectations, so teammates
+// without access to the project store run the same mocks with 'docker compose up'
+func RunDocker(profile, project string, opts DockerOptions) error {
+	if project == "" {
+		return fmt.Errorf("--project is required")
+	}
+	out := opts.Out
+	if out == "" {
+		out = "automock-" + project
+	}
+	if _, err := os.Stat(filepath.Join(out, compose.ComposeFile)); !errors.Is(err, fs.ErrNotExist) && !opts.Force {
+		return exitcode.WithHint(exitcode.Config, fmt.Errorf("%s already holds a compose bundle", out),
+			"Pass --force to regenerate it, or --out to write another directory")
+	}
+
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return err
+	}
+	config, err := manager.Provider.GetConfig(context.Background(), project)
+	if err != nil {
+		return fmt.Errorf("failed to load expectations for %s: %w", project, err)
+	}
+	if err := narrowExpectations(config, project, opts.Env, opts.Mask, opts.Selection); err != nil {
+		return err
+	}
+	if report := models.Validate(config.Expectations); len(report.Errors()) > 0 {
+		fmt.Printf("🔍 Validation:\n%s", report.Format())
+		return exitcode.WithHint(exitcode.Validation, fmt.Errorf("%d expectation problem(s) would stop MockServer loading the bundle", len(report.Errors())),
+			fmt.Sprintf("Run 'automock validate --project %s' and fix the listed expectations", project))
+	}
+
+	written, err := compose.WriteBundle(out, project, models.MockServerOnlyJSON(config.Expectations),
+		compose.Options{Image: opts.Image, Port: opts.Port})
+	if err != nil {
+		return err
+	}
+	port := opts.Port
+	if port == 0 {
+		port = 1080
+	}
+	fmt.Printf("🐳 Wrote a Docker Compose bundle of %d expectation(s) for %s:\n", len(config.Expectations), project)
+	for _, path := range written {
+		fmt.Printf("   • %s\n", path)
+	}
+	fmt.Printf("💡 Run 'docker compose up -d' in %s; the mocks answer on http://localhost:%d\n", out, port)
+	fmt.Println("💡 Commit or share the directory; it needs only Docker, not automock or cloud access")
+	return nil
+}
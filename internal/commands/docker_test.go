@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/cloud/local"
+	"github.com/hemantobora/auto-mock/internal/compose"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+func TestRunDockerBundlesOnlyMockServerFields(t *testing.T) {
+	t.Setenv(cloud.EnvCloud, local.ProviderType)
+	t.Setenv(local.EnvHome, t.TempDir())
+	provider, err := local.NewProvider()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := provider.SaveConfig(context.Background(), &models.MockConfiguration{
+		Metadata: models.ConfigMetadata{ProjectID: "users"},
+		Expectations: []models.MockExpectation{{
+			ID:             "user",
+			Tags:           []string{"users"},
+			Notes:          "The signed-in user",
+			ConditionalGet: true,
+			HttpRequest:    &models.HttpRequest{Method: "GET", Path: "/users/me"},
+			HttpResponse:   &models.HttpResponse{StatusCode: 200, Body: map[string]any{"id": 7}},
+		}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	out := t.TempDir()
+	if err := RunDocker("", "users", DockerOptions{Out: out}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(out, compose.ExpectationsFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var exps []map[string]any
+	if err := json.Unmarshal(data, &exps); err != nil {
+		t.Fatal(err)
+	}
+	if len(exps) == 0 {
+		t.Fatal("the bundle holds no expectations")
+	}
+	served := map[string]bool{"id": true, "priority": true, "httpRequest": true, "httpResponse": true,
+		"httpResponseTemplate": true, "httpForward": true, "httpError": true, "times": true}
+	for _, exp := range exps {
+		for key := range exp {
+			if !served[key] {
+				t.Errorf("expectation %v carries %q, which MockServer does not load", exp["id"], key)
+			}
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/builders"
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/expectations"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// RunDownload writes a project's expectations to disk, either as one file (MockServer JSON or
+// a WireMock mappings file) or split one-file-per-expectation into a directory. An environment
+// writes that overlay's expectations; a masking profile, when given, rewrites the response
+// bodies written; a tag selection narrows them.
+func RunDownload(profile, project, out, format, env, mask string, split bool, sel models.TagSelection) error {
+	if project == "" {
+		return fmt.Errorf("--project is required")
+	}
+	format = strings.ToLower(format)
+	switch format {
+	case "", "mockserver":
+		format = "mockserver"
+	case "wiremock":
+		if split {
+			return fmt.Errorf("--split writes MockServer files only; a WireMock mappings file already loads from the mappings directory")
+		}
+	default:
+		return fmt.Errorf("unsupported format %q (supported: mockserver, wiremock)", format)
+	}
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return err
+	}
+	config, err := manager.Provider.GetConfig(context.Background(), project)
+	if err != nil {
+		return fmt.Errorf("failed to load expectations for %s: %w", project, err)
+	}
+	if err := narrowExpectations(config, project, env, mask, sel); err != nil {
+		return err
+	}
+
+	if split {
+		if out == "" {
+			out = "expectations"
+		}
+		files, err := expectations.WriteSplit(out, config.Expectations)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Wrote %d expectation file(s) to %s/\n", len(files), out)
+		if !sel.IsEmpty() {
+			fmt.Println("⚠️  Only the selected expectations were written; syncing this directory with automock watch would drop the others")
+			return nil
+		}
+		if env != "" {
+			fmt.Printf("⚠️  These are the %s expectations; syncing this directory with automock watch would replace the base ones\n", env)
+			return nil
+		}
+		fmt.Printf("💡 Edit files and run 'automock watch --project %s --dir %s' to sync changes\n", project, out)
+		return nil
+	}
+
+	var data string
+	switch format {
+	case "wiremock":
+		if out == "" {
+			out = fmt.Sprintf("%s-wiremock.json", project)
+		}
+		var warnings []string
+		data, warnings = builders.ExpectationsToWireMockJSON(config.Expectations)
+		for _, w := range warnings {
+			fmt.Printf("⚠️  %s\n", w)
+		}
+	default:
+		if out == "" {
+			out = fmt.Sprintf("%s-expectations.json", project)
+		}
+		data = models.MockServerOnlyJSON(config.Expectations)
+	}
+	if err := os.WriteFile(out, []byte(data), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	fmt.Printf("✅ Wrote %d expectation(s) to %s (%s)\n", len(config.Expectations), out, format)
+	if format == "wiremock" {
+		fmt.Printf("💡 Copy it into WireMock's mappings/ directory, or: curl -X POST http://localhost:8080/__admin/mappings/import -d @%s\n", out)
+	}
+	return nil
+}
+
+// narrowExpectations replaces the configuration's expectations with those of an environment
+// overlay and a tag selection, and masks their response bodies with a masking profile; empty
+// arguments leave the expectations as they are. The result is written out, never saved.
+func narrowExpectations(config *models.MockConfiguration, project, env, mask string, sel models.TagSelection) error {
+	var err error
+	if env != "" {
+		if config.Expectations, err = config.ForEnvironment(env); err != nil {
+			return exitcode.Wrap(exitcode.Config, err)
+		}
+		fmt.Printf("🌐 Environment %s: %d expectation(s)\n", env, len(config.Expectations))
+	}
+	if !sel.IsEmpty() {
+		var selected []models.MockExpectation
+		for _, i := range sel.Indices(config.Expectations) {
+			selected = append(selected, config.Expectations[i])
+		}
+		fmt.Printf("🏷️  Tags %s: %d of %d expectation(s)\n", sel, len(selected), len(config.Expectations))
+		if len(selected) == 0 {
+			return exitcode.New(exitcode.Validation, "no expectations of %s match the tag selection", project)
+		}
+		config.Expectations = selected
+	}
+	if mask != "" {
+		masking, err := config.FindMaskingProfile(mask)
+		if err != nil {
+			return err
+		}
+		bodies, values := masking.MaskExpectations(config.Expectations)
+		fmt.Printf("🎭 Masking profile %s: masked %d value(s) in %d response body(ies)\n", masking.Name, values, bodies)
+	}
+	return nil
+}
+
+// RunWatch polls a split expectations directory and, on every change, assembles the files
+// and saves them as the project's expectations (a new version each time)
+func RunWatch(profile, project, dir string, interval time.Duration) error {
+	if project == "" || dir == "" {
+		return fmt.Errorf("--project and --dir are required")
+	}
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Printf("👀 Watching %s for changes (every %s). Press Ctrl+C to stop.\n", dir, interval)
+	last := ""
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		fp, err := expectations.SplitFingerprint(dir)
+		if err != nil {
+			return err
+		}
+		if fp != last {
+			if last != "" {
+				syncSplitDir(ctx, manager, project, dir)
+			}
+			last = fp
+		}
+		select {
+		case <-ctx.Done():
+			fmt.Println("\n✅ Stopped watching")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func syncSplitDir(ctx context.Context, manager *cloud.CloudManager, project, dir string) {
+	exps, err := expectations.LoadSplit(dir)
+	if err != nil {
+		fmt.Printf("❌ %v (fix the file; waiting for next change)\n", err)
+		return
+	}
+	config, err := manager.Provider.GetConfig(ctx, project)
+	var notFound *models.ConfigNotFoundError
+	switch {
+	case errors.As(err, &notFound):
+		// The first sync of a project with nothing stored yet
+		config = &models.MockConfiguration{Metadata: models.ConfigMetadata{ProjectID: project}}
+	case err != nil:
+		// Never replace a stored configuration that could not be read
+		fmt.Printf("❌ Failed to load %s: %v (waiting for next change)\n", project, err)
+		return
+	}
+	config.Expectations = exps
+	if err := manager.Provider.UpdateConfig(ctx, config); err != nil {
+		fmt.Printf("❌ Failed to save: %v\n", err)
+		return
+	}
+	fmt.Printf("🔄 %s — synced %d expectation(s) (version %s)\n", time.Now().Format("15:04:05"), len(exps), config.Metadata.Version)
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/cloud/local"
+	"github.com/hemantobora/auto-mock/internal/expectations"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+func TestSyncSplitDir(t *testing.T) {
+	ctx := context.Background()
+	provider, err := local.NewProvider(local.WithRoot(t.TempDir()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager := &cloud.CloudManager{Provider: provider}
+	dir := t.TempDir()
+	if _, err := expectations.WriteSplit(dir, []models.MockExpectation{{
+		HttpRequest:  &models.HttpRequest{Method: "GET", Path: "/users"},
+		HttpResponse: &models.HttpResponse{StatusCode: 200},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Nothing stored yet: the first sync starts the project
+	syncSplitDir(ctx, manager, "users", dir)
+	cfg, err := provider.GetConfig(ctx, "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Expectations) != 1 {
+		t.Fatalf("synced %d expectations, want 1", len(cfg.Expectations))
+	}
+
+	// A stored configuration that cannot be read is left alone
+	current := filepath.Join(provider.Root, "projects", "users", "configs", "users", "current.json")
+	if err := os.WriteFile(current, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	syncSplitDir(ctx, manager, "users", dir)
+	if data, _ := os.ReadFile(current); string(data) != "{not json" {
+		t.Errorf("an unreadable configuration was replaced:\n%s", data)
+	}
+}
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/builders"
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// RunExportManifest writes a Markdown summary of the project's mock for consuming teams
+func RunExportManifest(profile, project, out string) error {
+	ctx := context.Background()
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return err
+	}
+	cfg, err := manager.Provider.GetConfig(ctx, project)
+	if err != nil {
+		return fmt.Errorf("failed to load project %s: %w", project, err)
+	}
+
+	baseURL := ""
+	if meta, err := manager.Provider.GetDeploymentMetadata(); err == nil && meta != nil &&
+		meta.DeploymentStatus == "deployed" && meta.Details != nil {
+		baseURL = meta.Details.MockServerURL
+	}
+
+	if out == "" {
+		out = "MOCK_API.md"
+	}
+	if err := os.WriteFile(out, []byte(models.RenderManifest(cfg, baseURL, time.Now())), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", out, err)
+	}
+	fmt.Printf("✅ Manifest for %s written to %s\n", project, out)
+	fmt.Printf("💡 Commit it next to consumer code; 'automock deploy --project %s --manifest %s' keeps it current.\n", project, out)
+	return nil
+}
+
+// PactOptions configures automock export --format pact
+type PactOptions struct {
+	Out       string              // pact file (default <consumer>-<provider>.json, the Pact naming convention)
+	Consumer  string              // consumer name (default <project>-consumer)
+	Provider  string              // provider name (default the project)
+	Selection models.TagSelection // expectations to export (empty = all)
+}
+
+// RunExportPact writes the project's expectations as a Pact v3 contract, so a provider's Pact
+// verification checks the same interactions the mock serves
+func RunExportPact(profile, project string, opts PactOptions) error {
+	if project == "" {
+		return exitcode.New(exitcode.Config, "--project is required")
+	}
+	if opts.Provider == "" {
+		opts.Provider = project
+	}
+	if opts.Consumer == "" {
+		opts.Consumer = project + "-consumer"
+	}
+	if opts.Out == "" {
+		opts.Out = fmt.Sprintf("%s-%s.json", opts.Consumer, opts.Provider)
+	}
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return err
+	}
+	config, err := manager.Provider.GetConfig(context.Background(), project)
+	if err != nil {
+		return fmt.Errorf("failed to load project %s: %w", project, err)
+	}
+	if err := narrowExpectations(config, project, "", "", opts.Selection); err != nil {
+		return err
+	}
+
+	data, warnings := builders.ExpectationsToPactJSON(config.Expectations, opts.Consumer, opts.Provider)
+	for _, w := range warnings {
+		fmt.Printf("⚠️  %s\n", w)
+	}
+	if err := os.WriteFile(opts.Out, []byte(data+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", opts.Out, err)
+	}
+	fmt.Printf("✅ Pact between %s and %s written to %s\n", opts.Consumer, opts.Provider, opts.Out)
+	fmt.Printf("💡 Publish it to your broker (pact-broker publish %s), or verify the provider against the file\n", opts.Out)
+	return nil
+}
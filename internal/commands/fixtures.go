@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hemantobora/auto-mock/internal/builders"
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// FixtureOptions configures automock generate-fixtures
+type FixtureOptions struct {
+	Lang      string              // go, ts or python
+	Out       string              // output file (default per language)
+	Package   string              // Go package name (default "fixtures")
+	Env       string              // environment overlay whose expectations are converted
+	Mask      string              // masking profile applied to the response bodies
+	Selection models.TagSelection // expectations to convert (empty = all)
+}
+
+// fixtureFiles are the default output files per language
+var fixtureFiles = map[string]string{
+	builders.FixtureLangGo:     "fixtures.go",
+	builders.FixtureLangTS:     "handlers.js",
+	"js":                       "handlers.js",
+	builders.FixtureLangPython: "%s_fixtures.py",
+}
+
+// RunGenerateFixtures writes a project's expectations as language-native test fixtures: a Go
+// httptest handler, Mock Service Worker handlers or a Python responses/pytest module
+func RunGenerateFixtures(profile, project string, opts FixtureOptions) error {
+	if project == "" {
+		return exitcode.New(exitcode.Config, "--project is required")
+	}
+	opts.Lang = strings.ToLower(opts.Lang)
+	out := opts.Out
+	if out == "" {
+		name, ok := fixtureFiles[opts.Lang]
+		if !ok {
+			return exitcode.New(exitcode.Config, "unsupported --lang %q (supported: %s)", opts.Lang, strings.Join(builders.FixtureLangs, ", "))
+		}
+		out = name
+		if strings.Contains(name, "%s") {
+			out = fmt.Sprintf(name, builders.PythonIdentifier(project))
+		}
+	}
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return err
+	}
+	config, err := manager.Provider.GetConfig(context.Background(), project)
+	if err != nil {
+		return fmt.Errorf("failed to load expectations for %s: %w", project, err)
+	}
+	if err := narrowExpectations(config, project, opts.Env, opts.Mask, opts.Selection); err != nil {
+		return err
+	}
+
+	data, warnings, err := builders.ExpectationsToFixtures(config.Expectations, builders.FixtureOptions{
+		Lang:    opts.Lang,
+		Project: project,
+		Package: opts.Package,
+	})
+	if err != nil {
+		return exitcode.Wrap(exitcode.Config, err)
+	}
+	for _, w := range warnings {
+		fmt.Printf("⚠️  %s\n", w)
+	}
+	if err := os.WriteFile(out, []byte(data), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	fmt.Printf("✅ Wrote %s fixtures for %d expectation(s) to %s\n", opts.Lang, len(config.Expectations), out)
+	switch opts.Lang {
+	case builders.FixtureLangGo:
+		pkg := opts.Package
+		if pkg == "" {
+			pkg = "fixtures"
+		}
+		fmt.Printf("💡 In a test: srv := %s.NewServer(t), then point the client at srv.URL\n", pkg)
+	case builders.FixtureLangPython:
+		fmt.Printf("💡 Needs the responses package; request the %s_mock fixture (import it in conftest.py)\n", builders.PythonIdentifier(project))
+	default:
+		fmt.Println("💡 Needs msw v2: setupServer(...handlers) from 'msw/node' in tests, setupWorker(...handlers) from 'msw/browser' in the app")
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/cloud/git"
+)
+
+// orphanGracePeriod protects unreferenced bundles that may belong to an upload still in progress
+const orphanGracePeriod = 24 * time.Hour
+
+// GCOptions is the retention policy applied by RunGC
+type GCOptions struct {
+	KeepLast int           // keep the newest N versions (0 = no count limit)
+	MaxAge   time.Duration // keep versions younger than this (0 = no age limit)
+	DryRun   bool
+}
+
+// gcItem is anything retention applies to: an expectation version or a load test version
+type gcItem struct {
+	ID        string
+	CreatedAt time.Time
+	Protected bool // active/deployed; never pruned
+}
+
+// prunable returns the items the policy removes. An item survives when it is protected,
+// among the newest KeepLast items, or younger than MaxAge; with both limits set an item
+// must fall outside both to be pruned.
+func (o GCOptions) prunable(items []gcItem, now time.Time) []gcItem {
+	sorted := append([]gcItem(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].CreatedAt.After(sorted[j].CreatedAt) })
+
+	var prune []gcItem
+	for i, it := range sorted {
+		if it.Protected {
+			continue
+		}
+		if o.KeepLast > 0 && i < o.KeepLast {
+			continue
+		}
+		if o.MaxAge > 0 && now.Sub(it.CreatedAt) < o.MaxAge {
+			continue
+		}
+		prune = append(prune, it)
+	}
+	return prune
+}
+
+// RunGC prunes old expectation versions, load test versions and their bundles, and orphaned
+// bundles according to the retention policy. With DryRun it only lists what would be removed.
+func RunGC(profile, project string, opts GCOptions) error {
+	if project == "" {
+		return fmt.Errorf("--project is required")
+	}
+	if opts.KeepLast < 0 || opts.MaxAge < 0 {
+		return fmt.Errorf("--keep-last and --older-than must not be negative")
+	}
+	if opts.KeepLast == 0 && opts.MaxAge == 0 {
+		return fmt.Errorf("set a retention policy with --keep-last and/or --older-than")
+	}
+
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return err
+	}
+	provider := manager.Provider
+	ctx := context.Background()
+	if exists, err := provider.ProjectExists(ctx, project); err != nil {
+		return err
+	} else if !exists {
+		return fmt.Errorf("project %q does not exist", project)
+	}
+	now := time.Now()
+
+	// Expectation versions: the current and last-deployed versions are always kept
+	protected := map[string]bool{}
+	if cfg, err := provider.GetConfig(ctx, project); err == nil {
+		protected[cfg.Metadata.Version] = true
+		protected[cfg.Metadata.DeployedVersion] = true
+	}
+	var mockItems []gcItem
+	if provider.GetProviderType() == git.ProviderType {
+		fmt.Println("ℹ️  Expectation versions are commits in the git store; git history is never pruned")
+	} else {
+		versions, err := provider.ListVersions(ctx, project)
+		if err != nil {
+			return err
+		}
+		for _, v := range versions {
+			mockItems = append(mockItems, gcItem{ID: v.Version, CreatedAt: v.CreatedAt, Protected: protected[v.Version]})
+		}
+	}
+	pruneMock := opts.prunable(mockItems, now)
+
+	// Load test versions: the active version is kept; a pruned version takes its bundle with it
+	// unless a retained version still references that bundle
+	activeLT, activeBundle := "", ""
+	if ptr, err := provider.GetLoadTestPointer(ctx, project); err == nil && ptr != nil {
+		activeLT, activeBundle = ptr.ActiveVersion, ptr.BundleID
+	}
+	ltVersions, err := provider.ListLoadTestVersions(ctx, project)
+	if err != nil {
+		return err
+	}
+	bundleOf := map[string]string{}
+	var ltItems []gcItem
+	for _, v := range ltVersions {
+		bundleOf[v.Version] = v.BundleID
+		ltItems = append(ltItems, gcItem{ID: v.Version, CreatedAt: v.CreatedAt, Protected: v.Version == activeLT})
+	}
+	pruneLT := opts.prunable(ltItems, now)
+	pruned := map[string]bool{}
+	for _, it := range pruneLT {
+		pruned[it.ID] = true
+	}
+	referenced := map[string]bool{activeBundle: true}
+	for _, v := range ltVersions {
+		if !pruned[v.Version] {
+			referenced[v.BundleID] = true
+		}
+	}
+
+	bundles, err := provider.ListLoadTestBundles(ctx, project)
+	if err != nil {
+		return err
+	}
+	var pruneBundles []string
+	var bundleBytes int64
+	for _, b := range bundles {
+		if referenced[b.BundleID] {
+			continue
+		}
+		// Bundles of pruned versions go now; true orphans only after the grace period
+		owned := false
+		for _, it := range pruneLT {
+			if bundleOf[it.ID] == b.BundleID {
+				owned = true
+				break
+			}
+		}
+		if !owned && now.Sub(b.CreatedAt) < orphanGracePeriod {
+			continue
+		}
+		pruneBundles = append(pruneBundles, b.BundleID)
+		bundleBytes += b.Size
+	}
+
+	fmt.Printf("🧹 Garbage collection for project %q\n", project)
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("Expectation versions: %d stored, %d to prune\n", len(mockItems), len(pruneMock))
+	for _, it := range pruneMock {
+		fmt.Printf("   - %s (%s)\n", it.ID, it.CreatedAt.Format(time.RFC3339))
+	}
+	fmt.Printf("Load test versions:   %d stored, %d to prune\n", len(ltItems), len(pruneLT))
+	for _, it := range pruneLT {
+		fmt.Printf("   - %s (%s)\n", it.ID, it.CreatedAt.Format(time.RFC3339))
+	}
+	fmt.Printf("Load test bundles:    %d stored, %d to prune (%.1f MiB)\n", len(bundles), len(pruneBundles), float64(bundleBytes)/(1<<20))
+	for _, id := range pruneBundles {
+		fmt.Printf("   - %s\n", id)
+	}
+
+	if len(pruneMock)+len(pruneLT)+len(pruneBundles) == 0 {
+		fmt.Println("✅ Nothing to prune")
+		return nil
+	}
+	if opts.DryRun {
+		fmt.Println("\n💡 Dry run: nothing was deleted. Re-run without --dry-run to prune.")
+		return nil
+	}
+
+	deleted := 0
+	var failures int
+	for _, it := range pruneMock {
+		n, err := provider.DeleteVersion(ctx, project, it.ID)
+		if err != nil {
+			fmt.Printf("⚠️  %s: %v\n", it.ID, err)
+			failures++
+		}
+		deleted += n
+	}
+	for _, it := range pruneLT {
+		n, err := provider.DeleteLoadTestVersion(ctx, project, it.ID)
+		if err != nil {
+			fmt.Printf("⚠️  %s: %v\n", it.ID, err)
+			failures++
+		}
+		deleted += n
+	}
+	for _, id := range pruneBundles {
+		n, err := provider.DeleteLoadTestBundle(ctx, project, id)
+		if err != nil {
+			fmt.Printf("⚠️  %s: %v\n", id, err)
+			failures++
+		}
+		deleted += n
+	}
+
+	fmt.Printf("\n✅ Deleted %d stored object version(s)\n", deleted)
+	if failures > 0 {
+		return fmt.Errorf("%d item(s) could not be pruned", failures)
+	}
+	return nil
+}
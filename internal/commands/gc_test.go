@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"testing"
+	"time"
+)
+
+func gcIDs(items []gcItem) []string {
+	var ids []string
+	for _, it := range items {
+		ids = append(ids, it.ID)
+	}
+	return ids
+}
+
+func TestPrunable(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	items := []gcItem{
+		{ID: "v1", CreatedAt: now.Add(-40 * day), Protected: true},
+		{ID: "v2", CreatedAt: now.Add(-30 * day)},
+		{ID: "v3", CreatedAt: now.Add(-10 * day)},
+		{ID: "v4", CreatedAt: now.Add(-2 * day)},
+		{ID: "v5", CreatedAt: now.Add(-1 * day)},
+	}
+
+	cases := map[string]struct {
+		opts GCOptions
+		want []string
+	}{
+		"keep last":     {GCOptions{KeepLast: 2}, []string{"v3", "v2"}},
+		"max age":       {GCOptions{MaxAge: 7 * day}, []string{"v3", "v2"}},
+		"both limits":   {GCOptions{KeepLast: 3, MaxAge: 5 * day}, []string{"v2"}},
+		"keep all":      {GCOptions{KeepLast: 10}, nil},
+		"protected old": {GCOptions{KeepLast: 1}, []string{"v4", "v3", "v2"}},
+	}
+	for name, tc := range cases {
+		got := gcIDs(tc.opts.prunable(items, now))
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: got %v, want %v", name, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("%s: got %v, want %v", name, got, tc.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,324 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal"
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/loadtest"
+	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/network"
+)
+
+// LoadTestRunOptions configures automock loadtest run
+type LoadTestRunOptions struct {
+	Dir       string        // local bundle to run instead of the project's active bundle
+	Users     int           // peak number of concurrent users
+	SpawnRate float64       // users started per second
+	Duration  time.Duration // how long the test runs
+	Host      string        // target host; defaults to the one in the bundle (or AM_HOST)
+	Deployed  bool          // run on the project's deployed Locust master and workers
+}
+
+// loadStatsInterval is how often live statistics are printed
+const loadStatsInterval = 2 * time.Second
+
+// RunLoadTestRun runs a Locust bundle headless, locally or on the deployed load test
+// infrastructure, prints live statistics while it runs and stores a summary of the results.
+// With --project the results are also uploaded next to the active bundle version.
+func RunLoadTestRun(profile, project string, opts LoadTestRunOptions) error {
+	switch {
+	case project == "" && opts.Dir == "":
+		return exitcode.New(exitcode.Config, "--project or --dir is required")
+	case project != "" && opts.Dir != "":
+		return exitcode.New(exitcode.Config, "--project and --dir cannot be combined")
+	case opts.Deployed && project == "":
+		return exitcode.New(exitcode.Config, "--deployed requires --project")
+	case opts.Users < 1:
+		return exitcode.New(exitcode.Config, "--users must be at least 1")
+	case opts.SpawnRate <= 0:
+		return exitcode.New(exitcode.Config, "--spawn-rate must be positive")
+	case opts.Duration < time.Second:
+		return exitcode.New(exitcode.Config, "--duration must be at least 1s")
+	}
+
+	result := &models.LoadTestRunResult{
+		ProjectID:       project,
+		RunID:           time.Now().UTC().Format("20060102T150405Z"),
+		Target:          "local",
+		Host:            opts.Host,
+		Users:           opts.Users,
+		SpawnRate:       opts.SpawnRate,
+		DurationSeconds: int(opts.Duration / time.Second),
+	}
+	if project == "" {
+		if tool := loadtest.DetectTool(opts.Dir); tool != loadtest.ToolLocust {
+			return exitcode.New(exitcode.Config, "%s is a %s bundle; automock loadtest run only runs Locust bundles", opts.Dir, tool)
+		}
+		resultsDir := filepath.Join(opts.Dir, "results", result.RunID)
+		runErr := runLocustLocal(opts.Dir, resultsDir, opts, result)
+		if runErr != nil && result.Total.Name == "" {
+			return runErr
+		}
+		return finishLoadTestRun(context.Background(), nil, resultsDir, result, runErr)
+	}
+
+	ctx := context.Background()
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return err
+	}
+	if exists, _ := manager.Provider.ProjectExists(ctx, project); !exists {
+		return exitcode.New(exitcode.Config, "project '%s' does not exist", project)
+	}
+	ptr, err := manager.Provider.GetLoadTestPointer(ctx, project)
+	if err != nil || ptr == nil || ptr.ActiveVersion == "" {
+		return exitcode.New(exitcode.Config, "project '%s' has no load test bundle; run 'automock load --project %s --upload' first", project, project)
+	}
+	if tool := loadtest.LayoutFor(ptr.Tool).Tool; tool != loadtest.ToolLocust {
+		return exitcode.New(exitcode.Config, "the active bundle of %s is a %s bundle; automock loadtest run only runs Locust bundles", project, tool)
+	}
+	result.Version, result.BundleID = ptr.ActiveVersion, ptr.BundleID
+
+	workDir, err := os.MkdirTemp("", "automock-loadtest-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(workDir)
+	resultsDir := filepath.Join(workDir, "results")
+
+	var runErr error
+	if opts.Deployed {
+		result.Target = "deployed"
+		meta, err := manager.Provider.GetLoadTestDeploymentMetadata()
+		if err != nil || meta == nil || meta.DeploymentStatus != "deployed" || meta.Details == nil || meta.Details.ALBDNSName == "" {
+			return exitcode.New(exitcode.Config, "no load test infrastructure is deployed for %s; run 'automock deploy --project %s' first", project, project)
+		}
+		runErr = runLocustDeployed(ctx, "http://"+meta.Details.ALBDNSName, opts, result)
+	} else {
+		_, bundleDir, err := manager.Provider.DownloadLoadTestBundle(ctx, project, filepath.Join(workDir, "bundle"))
+		if err != nil {
+			return fmt.Errorf("download bundle: %w", err)
+		}
+		runErr = runLocustLocal(bundleDir, resultsDir, opts, result)
+	}
+	if runErr != nil && result.Total.Name == "" {
+		return runErr
+	}
+	return finishLoadTestRun(ctx, manager.Provider, resultsDir, result, runErr)
+}
+
+// runLocustLocal runs locust headless in the bundle directory, printing the latest totals
+// from its CSV history while it runs, and reads the final statistics into result
+func runLocustLocal(bundleDir, resultsDir string, opts LoadTestRunOptions, result *models.LoadTestRunResult) error {
+	locust, err := exec.LookPath("locust")
+	if err != nil {
+		return exitcode.WithHint(exitcode.Config, fmt.Errorf("locust is not installed"),
+			"install it with 'pip install -r requirements.txt' in the bundle directory")
+	}
+	if err := os.MkdirAll(resultsDir, 0o755); err != nil {
+		return err
+	}
+	prefix := filepath.Join(resultsDir, "run")
+	args := []string{
+		"-f", "locustfile.py", "--headless", "--only-summary",
+		"-u", strconv.Itoa(opts.Users),
+		"-r", strconv.FormatFloat(opts.SpawnRate, 'f', -1, 64),
+		"-t", strconv.Itoa(result.DurationSeconds) + "s",
+		"--csv", prefix, "--csv-full-history",
+	}
+	if opts.Host != "" {
+		args = append(args, "--host", opts.Host)
+	}
+	cmd := exec.Command(locust, args...)
+	cmd.Dir = bundleDir
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	if opts.Host != "" {
+		cmd.Env = append(os.Environ(), "AM_HOST="+opts.Host)
+	}
+
+	// Ctrl-C reaches locust too; it stops the test and still writes its statistics
+	signal.Ignore(os.Interrupt)
+	defer signal.Reset(os.Interrupt)
+
+	fmt.Printf("🚀 Running %s locally: %d users, spawn rate %s/s, %s\n\n", bundleDir, opts.Users, strconv.FormatFloat(opts.SpawnRate, 'f', -1, 64), opts.Duration)
+	result.StartedAt = time.Now().UTC()
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start locust: %w", err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	ticker := time.NewTicker(loadStatsInterval)
+	defer ticker.Stop()
+	var runErr error
+wait:
+	for {
+		select {
+		case runErr = <-done:
+			break wait
+		case <-ticker.C:
+			if f, err := os.Open(prefix + "_stats_history.csv"); err == nil {
+				sample, ok := loadtest.LastHistorySample(f)
+				f.Close()
+				if ok {
+					fmt.Println(sample.Line(time.Since(result.StartedAt)))
+				}
+			}
+		}
+	}
+	if runErr != nil {
+		runErr = fmt.Errorf("locust exited: %w", runErr)
+	}
+
+	f, err := os.Open(prefix + "_stats.csv")
+	if err != nil {
+		if runErr != nil {
+			return runErr
+		}
+		return fmt.Errorf("locust wrote no statistics: %w", err)
+	}
+	defer f.Close()
+	result.Total, result.Endpoints, err = loadtest.ParseStats(f)
+	if err != nil {
+		return err
+	}
+	return runErr
+}
+
+// runLocustDeployed starts a swarm on the deployed Locust master through its web API, polls
+// its statistics until the duration is up (or Ctrl-C), stops it and reads the final statistics
+func runLocustDeployed(ctx context.Context, baseURL string, opts LoadTestRunOptions, result *models.LoadTestRunResult) error {
+	client := network.HTTPClient()
+	form := url.Values{
+		"user_count": {strconv.Itoa(opts.Users)},
+		"spawn_rate": {strconv.FormatFloat(opts.SpawnRate, 'f', -1, 64)},
+	}
+	if opts.Host != "" {
+		form.Set("host", opts.Host)
+	}
+	if _, err := locustRequest(ctx, client, http.MethodPost, baseURL+"/swarm", form); err != nil {
+		return exitcode.Wrap(exitcode.Network, fmt.Errorf("start swarm on %s: %w", baseURL, err))
+	}
+
+	fmt.Printf("🚀 Running on %s: %d users, spawn rate %s/s, %s (Ctrl-C stops early)\n\n", baseURL, opts.Users, strconv.FormatFloat(opts.SpawnRate, 'f', -1, 64), opts.Duration)
+	result.StartedAt = time.Now().UTC()
+	runCtx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+	timer := time.NewTimer(opts.Duration)
+	defer timer.Stop()
+	ticker := time.NewTicker(loadStatsInterval)
+	defer ticker.Stop()
+poll:
+	for {
+		select {
+		case <-timer.C:
+			break poll
+		case <-runCtx.Done():
+			fmt.Println("\n⏹️  Stopping early")
+			break poll
+		case <-ticker.C:
+			data, err := locustRequest(ctx, client, http.MethodGet, baseURL+"/stats/requests", nil)
+			if err != nil {
+				fmt.Printf("⚠️  %v\n", err)
+				continue
+			}
+			if sample, _, err := loadtest.LiveSample(data); err == nil {
+				fmt.Println(sample.Line(time.Since(result.StartedAt)))
+			}
+		}
+	}
+	result.DurationSeconds = int(time.Since(result.StartedAt) / time.Second)
+
+	// Read the statistics before stopping so they cover the whole run
+	data, statsErr := locustRequest(ctx, client, http.MethodGet, baseURL+"/stats/requests/csv", nil)
+	if _, err := locustRequest(ctx, client, http.MethodGet, baseURL+"/stop", nil); err != nil {
+		fmt.Printf("⚠️  Could not stop the test, stop it in the Locust UI at %s: %v\n", baseURL, err)
+	}
+	if statsErr != nil {
+		return exitcode.Wrap(exitcode.Network, fmt.Errorf("read statistics from %s: %w", baseURL, statsErr))
+	}
+	var err error
+	result.Total, result.Endpoints, err = loadtest.ParseStats(bytes.NewReader(data))
+	return err
+}
+
+// locustRequest calls the Locust web API and returns the response body
+func locustRequest(ctx context.Context, client *http.Client, method, target string, form url.Values) ([]byte, error) {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s %s: HTTP %d", method, target, resp.StatusCode)
+	}
+	return data, nil
+}
+
+// finishLoadTestRun prints the summary, writes it to summary.json (kept only for --dir runs)
+// and, for a project, stores it next to the bundle version. runErr is reported last.
+func finishLoadTestRun(ctx context.Context, provider internal.Provider, resultsDir string, result *models.LoadTestRunResult, runErr error) error {
+	printLoadTestSummary(result)
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(resultsDir, 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(resultsDir, "summary.json"), data, 0o644); err != nil {
+		return err
+	}
+	if provider == nil {
+		fmt.Printf("\n📄 Results: %s\n", resultsDir)
+	} else {
+		key, err := provider.SaveLoadTestResult(ctx, result.ProjectID, result)
+		if err != nil {
+			return fmt.Errorf("save results: %w", err)
+		}
+		fmt.Printf("\n📄 Results stored for version %s: %s\n", result.Version, key)
+	}
+	return runErr
+}
+
+// printLoadTestSummary prints the per-endpoint statistics and the totals as a table
+func printLoadTestSummary(result *models.LoadTestRunResult) {
+	fmt.Printf("\n📊 %d requests, %d failed, %.1f req/s over %ds\n\n", result.Total.Requests, result.Total.Failures, result.Total.RPS, result.DurationSeconds)
+	fmt.Printf("%-40s %9s %8s %8s %8s %8s %8s\n", "ENDPOINT", "REQUESTS", "FAILED", "RPS", "P50", "P95", "P99")
+	for _, st := range append(result.Endpoints, result.Total) {
+		name := strings.TrimSpace(st.Method + " " + st.Name)
+		if len(name) > 40 {
+			name = name[:37] + "..."
+		}
+		fmt.Printf("%-40s %9d %8d %8.1f %7.0fms %7.0fms %7.0fms\n", name, st.Requests, st.Failures, st.RPS, st.P50Ms, st.P95Ms, st.P99Ms)
+	}
+}
@@ -0,0 +1,253 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/loadtest"
+	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/terraform"
+)
+
+// LoadTestScheduleOptions configures automock loadtest schedule
+type LoadTestScheduleOptions struct {
+	Cron      string        // standard 5-field expression, UTC; empty shows the current schedule
+	Users     int           // peak number of concurrent users of each run
+	SpawnRate float64       // users started per second
+	Duration  time.Duration // how long each run lasts
+	Host      string        // target host; defaults to the deployed mock
+	Remove    bool          // tear the schedule down
+}
+
+// LoadTestResultsOptions configures automock loadtest results
+type LoadTestResultsOptions struct {
+	Version string // only runs of this bundle version
+	Limit   int    // only the latest runs (0 = all)
+	Format  string // table or json
+}
+
+// RunLoadTestSchedule provisions a cloud scheduler (EventBridge Scheduler and CodeBuild on AWS,
+// a Container Apps job on Azure) that runs the project's active Locust bundle on a cron
+// schedule and stores each run's results next to the bundle version. Without --cron it shows
+// the current schedule; --remove tears it down.
+func RunLoadTestSchedule(profile, project string, opts LoadTestScheduleOptions) error {
+	if project == "" {
+		return exitcode.New(exitcode.Config, "--project is required")
+	}
+	if opts.Remove && opts.Cron != "" {
+		return exitcode.New(exitcode.Config, "--remove and --cron cannot be combined")
+	}
+	var awsCron string
+	if opts.Cron != "" {
+		if _, err := loadtest.ParseCron(opts.Cron); err != nil {
+			return exitcode.Wrap(exitcode.Config, err)
+		}
+		switch {
+		case opts.Users < 1:
+			return exitcode.New(exitcode.Config, "--users must be at least 1")
+		case opts.SpawnRate <= 0:
+			return exitcode.New(exitcode.Config, "--spawn-rate must be positive")
+		case opts.Duration < time.Minute:
+			return exitcode.New(exitcode.Config, "--duration must be at least 1m for scheduled runs")
+		}
+	}
+
+	ctx := context.Background()
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return err
+	}
+	provider := manager.Provider
+	if exists, _ := provider.ProjectExists(ctx, project); !exists {
+		return exitcode.New(exitcode.Config, "project '%s' does not exist", project)
+	}
+	current, _ := provider.GetLoadTestSchedule(ctx, project)
+
+	if opts.Cron == "" && !opts.Remove {
+		if current == nil {
+			fmt.Printf("ℹ️  %s has no scheduled load test runs. Add one with --cron, e.g. --cron \"0 2 * * *\".\n", project)
+			return nil
+		}
+		printLoadTestSchedule(current)
+		return nil
+	}
+
+	scheduler, err := terraform.NewScheduleManager(project, profile, provider)
+	if err != nil {
+		return err
+	}
+	if opts.Remove {
+		if current == nil {
+			fmt.Printf("ℹ️  %s has no scheduled load test runs.\n", project)
+			return nil
+		}
+		fmt.Printf("\nRemoving the load test schedule of %s...\n", project)
+		if err := scheduler.Destroy(scheduleOptions(current)); err != nil {
+			return exitcode.Wrap(exitcode.Deploy, err)
+		}
+		if err := provider.DeleteLoadTestSchedule(ctx, project); err != nil {
+			return err
+		}
+		fmt.Println("✅ Schedule removed; stored results are kept.")
+		return nil
+	}
+
+	if provider.GetProviderType() == "aws" {
+		if awsCron, err = loadtest.AWSCron(opts.Cron); err != nil {
+			return exitcode.Wrap(exitcode.Config, err)
+		}
+	}
+	ptr, err := provider.GetLoadTestPointer(ctx, project)
+	if err != nil || ptr == nil || ptr.ActiveVersion == "" {
+		return exitcode.New(exitcode.Config, "project '%s' has no load test bundle; run 'automock load --project %s --upload' first", project, project)
+	}
+	if tool := loadtest.LayoutFor(ptr.Tool).Tool; tool != loadtest.ToolLocust {
+		return exitcode.New(exitcode.Config, "the active bundle of %s is a %s bundle; scheduled runs need a Locust bundle", project, tool)
+	}
+	host := opts.Host
+	if host == "" {
+		meta, err := provider.GetDeploymentMetadata()
+		if err != nil || meta == nil || meta.DeploymentStatus != "deployed" || meta.Details == nil || meta.Details.MockServerURL == "" {
+			return exitcode.New(exitcode.Config, "%s has no deployed mock to run against; deploy it with 'automock deploy --project %s' or pass --host", project, project)
+		}
+		host = meta.Details.MockServerURL
+		if auth := meta.Details.Auth; auth != nil {
+			fmt.Printf("⚠️  The mocks require the %s header; the bundle's requests must send it or they are refused\n", auth.Header)
+		}
+	}
+
+	schedule := &models.LoadTestSchedule{
+		ProjectID:       project,
+		Cron:            opts.Cron,
+		Users:           opts.Users,
+		SpawnRate:       opts.SpawnRate,
+		DurationSeconds: int(opts.Duration / time.Second),
+		Host:            host,
+		CreatedAt:       time.Now().UTC(),
+	}
+	tfOpts := scheduleOptions(schedule)
+	tfOpts.ScheduleExpression = awsCron
+	fmt.Printf("\nScheduling load test runs of %s (%s UTC)...\n", project, opts.Cron)
+	resources, err := scheduler.Apply(tfOpts)
+	if err != nil {
+		return exitcode.Wrap(exitcode.Deploy, err)
+	}
+	schedule.Resources = resources
+	if err := provider.SaveLoadTestSchedule(ctx, schedule); err != nil {
+		return err
+	}
+	fmt.Println("\n✅ Load test runs scheduled:")
+	printLoadTestSchedule(schedule)
+	fmt.Printf("💡 Follow the trend with 'automock loadtest results --project %s'.\n", project)
+	return nil
+}
+
+// scheduleOptions are the Terraform variables of a schedule
+func scheduleOptions(s *models.LoadTestSchedule) *models.LoadTestScheduleOptions {
+	return &models.LoadTestScheduleOptions{
+		Cron:            s.Cron,
+		Users:           s.Users,
+		SpawnRate:       s.SpawnRate,
+		DurationSeconds: s.DurationSeconds,
+		Host:            s.Host,
+	}
+}
+
+func printLoadTestSchedule(s *models.LoadTestSchedule) {
+	fmt.Printf("🕓 Cron:     %s (UTC)\n", s.Cron)
+	fmt.Printf("👥 Load:     %d users, spawn rate %g/s, %s per run\n", s.Users, s.SpawnRate, time.Duration(s.DurationSeconds)*time.Second)
+	fmt.Printf("🎯 Target:   %s\n", s.Host)
+	if name := s.Resources["schedule_name"]; name != "" {
+		fmt.Printf("☁️  Schedule: %s (logs: %s)\n", name, s.Resources["log_group"])
+	}
+}
+
+// RunLoadTestResults lists the stored results of a project's load test runs (from
+// automock loadtest run and scheduled runs), oldest first, to show trends over time
+func RunLoadTestResults(profile, project string, opts LoadTestResultsOptions) error {
+	if project == "" {
+		return exitcode.New(exitcode.Config, "--project is required")
+	}
+	if opts.Format != "table" && opts.Format != "json" {
+		return exitcode.New(exitcode.Config, "--format must be table or json, got %q", opts.Format)
+	}
+	ctx := context.Background()
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return err
+	}
+	if exists, _ := manager.Provider.ProjectExists(ctx, project); !exists {
+		return exitcode.New(exitcode.Config, "project '%s' does not exist", project)
+	}
+	results, err := manager.Provider.ListLoadTestResults(ctx, project)
+	if err != nil {
+		return err
+	}
+	results = filterLoadTestResults(results, opts.Version, opts.Limit)
+	if opts.Format == "json" {
+		if results == nil {
+			results = []models.LoadTestRunResult{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	}
+	if len(results) == 0 {
+		fmt.Printf("ℹ️  No load test results stored for %s yet. Run 'automock loadtest run --project %s' or schedule runs.\n", project, project)
+		return nil
+	}
+	printLoadTestTrend(os.Stdout, results)
+	return nil
+}
+
+// filterLoadTestResults keeps the runs of a version (all when empty), then the latest limit runs
+func filterLoadTestResults(results []models.LoadTestRunResult, version string, limit int) []models.LoadTestRunResult {
+	if version != "" {
+		kept := results[:0:0]
+		for _, r := range results {
+			if r.Version == version {
+				kept = append(kept, r)
+			}
+		}
+		results = kept
+	}
+	if limit > 0 && len(results) > limit {
+		results = results[len(results)-limit:]
+	}
+	return results
+}
+
+// printLoadTestTrend prints one line per run with the change of p95 latency against the
+// previous run, so regressions stand out
+func printLoadTestTrend(w io.Writer, results []models.LoadTestRunResult) {
+	fmt.Fprintf(w, "%-17s %-16s %-9s %6s %9s %7s %8s %8s %8s %8s %8s\n", "STARTED (UTC)", "VERSION", "TARGET", "USERS", "REQUESTS", "FAIL%", "RPS", "P50", "P95", "P99", "ΔP95")
+	for i, r := range results {
+		failPct := 0.0
+		if r.Total.Requests > 0 {
+			failPct = 100 * float64(r.Total.Failures) / float64(r.Total.Requests)
+		}
+		delta := "-"
+		if i > 0 {
+			if prev := results[i-1].Total.P95Ms; prev > 0 {
+				delta = fmt.Sprintf("%+.0f%%", 100*(r.Total.P95Ms-prev)/prev)
+			}
+		}
+		version := r.Version
+		if version == "" {
+			version = "-"
+		}
+		fmt.Fprintf(w, "%-17s %-16.16s %-9s %6d %9d %6.1f%% %8.1f %6.0fms %6.0fms %6.0fms %8s\n",
+			r.StartedAt.UTC().Format("2006-01-02 15:04"), version, r.Target, r.Users, r.Total.Requests,
+			failPct, r.Total.RPS, r.Total.P50Ms, r.Total.P95Ms, r.Total.P99Ms, delta)
+	}
+	if len(results) > 1 {
+		first, last := results[0].Total, results[len(results)-1].Total
+		fmt.Fprintf(w, "\n%d runs: p95 %.0fms → %.0fms, %.1f → %.1f req/s\n", len(results), first.P95Ms, last.P95Ms, first.RPS, last.RPS)
+	}
+}
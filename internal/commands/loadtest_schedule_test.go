@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+func TestLoadTestTrend(t *testing.T) {
+	start := time.Date(2026, 3, 1, 2, 0, 0, 0, time.UTC)
+	run := func(day int, version string, p95 float64) models.LoadTestRunResult {
+		return models.LoadTestRunResult{
+			Version: version, RunID: start.AddDate(0, 0, day).Format("20060102T150405Z"), Target: "scheduled",
+			Users: 50, StartedAt: start.AddDate(0, 0, day),
+			Total: models.LoadTestRunStats{Name: "Aggregated", Requests: 1000, Failures: 10, RPS: 20, P50Ms: 40, P95Ms: p95, P99Ms: 300},
+		}
+	}
+	results := []models.LoadTestRunResult{run(0, "v1", 100), run(1, "v1", 120), run(2, "v2", 90)}
+
+	if got := filterLoadTestResults(results, "v1", 0); len(got) != 2 {
+		t.Errorf("expected 2 runs of v1, got %d", len(got))
+	}
+	if got := filterLoadTestResults(results, "", 1); len(got) != 1 || got[0].Version != "v2" {
+		t.Errorf("expected the latest run, got %+v", got)
+	}
+
+	var buf bytes.Buffer
+	printLoadTestTrend(&buf, results)
+	out := buf.String()
+	for _, want := range []string{"2026-03-01 02:00", "+20%", "-25%", "1.0%", "3 runs: p95 100ms → 90ms"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("trend output is missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/hemantobora/auto-mock/internal"
+	"github.com/hemantobora/auto-mock/internal/ask"
 	"github.com/hemantobora/auto-mock/internal/client"
 	"github.com/hemantobora/auto-mock/internal/cloud"
 	"github.com/hemantobora/auto-mock/internal/models"
@@ -99,7 +100,7 @@ func handleDeletePointer(ctx context.Context, provider internal.Provider, projec
 	curPtr, _ := provider.GetLoadTestPointer(ctx, project)
 	if curPtr == nil || curPtr.ActiveVersion == "" {
 		var sure bool
-		_ = survey.AskOne(&survey.Confirm{Message: "No active bundle. Delete current pointer file if present?", Default: false}, &sure)
+		_ = ask.One(&survey.Confirm{Message: "No active bundle. Delete current pointer file if present?", Default: false}, &sure)
 		if !sure {
 			return nil
 		}
@@ -111,7 +112,7 @@ func handleDeletePointer(ctx context.Context, provider internal.Provider, projec
 	}
 
 	var confirm bool
-	_ = survey.AskOne(&survey.Confirm{Message: fmt.Sprintf("Delete bundle %s and roll back pointer to previous version?", curPtr.BundleID), Default: false}, &confirm)
+	_ = ask.One(&survey.Confirm{Message: fmt.Sprintf("Delete bundle %s and roll back pointer to previous version?", curPtr.BundleID), Default: false}, &confirm)
 	if !confirm {
 		return nil
 	}
@@ -148,12 +149,12 @@ func handleDeletePointer(ctx context.Context, provider internal.Provider, projec
 func handlePurgeBundle(ctx context.Context, provider internal.Provider, ptr *models.LoadTestPointer) error {
 	ll := models.NewLoader(os.Stdout, "Purging load test artifacts")
 	var confirm bool
-	_ = survey.AskOne(&survey.Confirm{Message: "This will delete all loadtest bundles, versions, pointer, and index. Continue?", Default: false}, &confirm)
+	_ = ask.One(&survey.Confirm{Message: "This will delete all loadtest bundles, versions, pointer, and index. Continue?", Default: false}, &confirm)
 	if !confirm {
 		return nil
 	}
 	var typed string
-	_ = survey.AskOne(&survey.Input{Message: "Type 'permanently delete' to confirm:"}, &typed)
+	_ = ask.One(&survey.Input{Message: "Type 'permanently delete' to confirm:"}, &typed)
 	if strings.TrimSpace(typed) != "permanently delete" {
 		return fmt.Errorf("confirmation mismatch")
 	}
@@ -0,0 +1,196 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/mocklogs"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// LogsExportOptions configures `automock logs export`
+type LogsExportOptions struct {
+	Format  string // har
+	Out     string // output file (default <project>-traffic.har)
+	URL     string // mock base URL; looked up from the deployment when empty
+	Version string // automock version recorded as the HAR creator
+}
+
+// RunLogsExport writes the traffic the mock received as a HAR file
+func RunLogsExport(profile, project string, opts LogsExportOptions) error {
+	if format := strings.ToLower(opts.Format); format != "" && format != "har" {
+		return fmt.Errorf("unsupported export format %q (supported: har)", opts.Format)
+	}
+	ctx := context.Background()
+
+	baseURL := opts.URL
+	var auth *models.EndpointAuth
+	if baseURL == "" {
+		if project == "" {
+			return fmt.Errorf("--project or --url is required")
+		}
+		var err error
+		if baseURL, auth, err = deployedMock(ctx, profile, project); err != nil {
+			return err
+		}
+	}
+
+	client := mocklogs.NewClient(baseURL)
+	client.HTTP.Transport = auth.Transport()
+	exchanges, err := client.RequestResponses(ctx)
+	if err != nil {
+		return err
+	}
+	data, err := mocklogs.ToHAR(exchanges, baseURL, opts.Version).Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to encode HAR: %w", err)
+	}
+
+	out := opts.Out
+	if out == "" {
+		name := project
+		if name == "" {
+			name = "automock"
+		}
+		out = name + "-traffic.har"
+	}
+	if err := os.WriteFile(out, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", out, err)
+	}
+	fmt.Printf("✅ Exported %d request(s) from %s to %s\n", len(exchanges), baseURL, out)
+	if len(exchanges) == 0 {
+		fmt.Println("💡 The mock has not answered any requests since it started or was last reset.")
+	}
+	return nil
+}
+
+// LogsOptions configures `automock logs`
+type LogsOptions struct {
+	URL      string // mock base URL; looked up from the deployment when empty
+	Filter   mocklogs.Filter
+	Limit    int           // most recent matching entries shown first (default 20)
+	Tail     bool          // keep polling and print new entries until Ctrl+C
+	Interval time.Duration // time between polls with Tail (default 2s)
+}
+
+// RunLogs prints the requests the mock received, newest last, explaining unmatched ones
+// against the project's expectations; with Tail it follows the log until Ctrl+C
+func RunLogs(profile, project string, opts LogsOptions) error {
+	if err := opts.Filter.Validate(); err != nil {
+		return exitcode.Wrap(exitcode.Config, err)
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = 20
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = 2 * time.Second
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	baseURL := opts.URL
+	var auth *models.EndpointAuth
+	if baseURL == "" {
+		if project == "" {
+			return exitcode.New(exitcode.Config, "--project or --url is required")
+		}
+		var err error
+		if baseURL, auth, err = deployedMock(ctx, profile, project); err != nil {
+			return exitcode.Wrap(exitcode.Config, err)
+		}
+	}
+	var exps []models.MockExpectation
+	if project != "" {
+		if loaded, err := loadExpectations(profile, project, ""); err == nil {
+			exps = loaded
+		} else {
+			fmt.Printf("⚠️  Unmatched requests are not explained: %v\n", err)
+		}
+	}
+
+	client := mocklogs.NewClient(baseURL)
+	client.HTTP.Transport = auth.Transport()
+	entries, err := client.Log(ctx)
+	if err != nil {
+		return err
+	}
+	var shown []mocklogs.Exchange
+	for _, e := range entries {
+		if opts.Filter.Matches(&e) {
+			shown = append(shown, e)
+		}
+	}
+	if len(shown) > opts.Limit {
+		fmt.Printf("… %d earlier matching request(s) not shown (--limit)\n", len(shown)-opts.Limit)
+		shown = shown[len(shown)-opts.Limit:]
+	}
+	for i := range shown {
+		fmt.Print(mocklogs.FormatEntry(&shown[i], exps))
+	}
+	if !opts.Tail {
+		if len(shown) == 0 {
+			fmt.Printf("ℹ️  No matching requests in the log of %s\n", baseURL)
+		}
+		return nil
+	}
+
+	fmt.Printf("👀 Following %s every %s (Ctrl+C to stop)\n", baseURL, opts.Interval)
+	printed := len(entries)
+	held := -1 // index of a trailing unmatched entry held back one poll: its response may not be logged yet
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		entries, err := client.Log(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			fmt.Printf("⚠️  %v\n", err)
+			continue
+		}
+		if len(entries) < printed {
+			fmt.Println("🔄 The mock's log was reset")
+			printed, held = 0, -1
+		}
+		end := len(entries)
+		if end > printed && entries[end-1].Response == nil && held != end-1 {
+			held = end - 1
+			end--
+		}
+		for i := printed; i < end; i++ {
+			if opts.Filter.Matches(&entries[i]) {
+				fmt.Print(mocklogs.FormatEntry(&entries[i], exps))
+			}
+		}
+		printed = end
+	}
+}
+
+// deployedMock returns the endpoint of a project's deployed mock and the credential it
+// requires (nil when open)
+func deployedMock(ctx context.Context, profile, project string) (string, *models.EndpointAuth, error) {
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return "", nil, err
+	}
+	if exists, _ := manager.Provider.ProjectExists(ctx, project); !exists {
+		return "", nil, fmt.Errorf("project '%s' does not exist", project)
+	}
+	meta, err := manager.Provider.GetDeploymentMetadata()
+	if err != nil || meta == nil || meta.DeploymentStatus != "deployed" || meta.Details == nil || meta.Details.MockServerURL == "" {
+		return "", nil, fmt.Errorf("no deployed mock found for project '%s'; run 'automock deploy --project %s' or pass --url", project, project)
+	}
+	return meta.Details.MockServerURL, meta.Details.Auth, nil
+}
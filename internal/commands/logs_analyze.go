@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/expectations"
+	"github.com/hemantobora/auto-mock/internal/mocklogs"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// analyzeInstruction is what the AI provider is asked for each proposed response body
+const analyzeInstruction = "Clients called this endpoint but the mock had no expectation for it. " +
+	"Replace the placeholder with a realistic response body for it, consistent with the request path, query and body."
+
+// LogsAnalyzeOptions configures `automock logs analyze`
+type LogsAnalyzeOptions struct {
+	URL      string // mock base URL; looked up from the deployment when empty
+	AI       bool   // have an AI provider write the placeholder response bodies
+	Provider string // AI provider (default: the configured one, asked when there are several)
+	Yes      bool   // add every proposal without asking
+}
+
+// RunLogsAnalyze clusters the requests the mock could not answer, proposes an expectation
+// per cluster and appends the ones the user keeps to the project
+func RunLogsAnalyze(profile, project string, opts LogsAnalyzeOptions) error {
+	if project == "" {
+		return exitcode.New(exitcode.Config, "--project is required")
+	}
+	ctx := context.Background()
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return err
+	}
+	config, err := manager.Provider.GetConfig(ctx, project)
+	if err != nil {
+		return exitcode.New(exitcode.Config, "failed to load project '%s': %w", project, err)
+	}
+	baseURL := opts.URL
+	var auth *models.EndpointAuth
+	if baseURL == "" {
+		meta, _ := manager.Provider.GetDeploymentMetadata()
+		if meta == nil || meta.DeploymentStatus != "deployed" || meta.Details == nil || meta.Details.MockServerURL == "" {
+			return exitcode.WithHint(exitcode.Config, fmt.Errorf("no deployed mock found for project '%s'", project),
+				fmt.Sprintf("Run 'automock deploy --project %s', or pass --url to analyze a mock run elsewhere", project))
+		}
+		baseURL, auth = meta.Details.MockServerURL, meta.Details.Auth
+	}
+
+	client := mocklogs.NewClient(baseURL)
+	client.HTTP.Transport = auth.Transport()
+	log, err := client.Log(ctx)
+	if err != nil {
+		return err
+	}
+	proposals := mocklogs.ProposeExpectations(log, config.Expectations, baseURL)
+	if len(proposals) == 0 {
+		fmt.Printf("✅ Every request in the log of %s (%d) matched an expectation\n", baseURL, len(log))
+		return nil
+	}
+
+	unmatched := 0
+	options := make([]string, len(proposals))
+	for i := range proposals {
+		unmatched += proposals[i].Requests
+		options[i] = fmt.Sprintf("[%d] %s", i+1, proposals[i].Describe())
+	}
+	fmt.Printf("\n🔍 %d unmatched request(s) in %d cluster(s):\n", unmatched, len(proposals))
+	for _, option := range options {
+		fmt.Printf("   %s\n", option)
+	}
+
+	keep := proposals
+	if !opts.Yes {
+		var selected []int
+		if err := ask.One(&survey.MultiSelect{
+			Message:  "Add expectations for which clusters?",
+			Options:  options,
+			Default:  options,
+			PageSize: 15,
+			Help:     "Deselect probes, typos or anything else the mock should keep answering with 404.",
+		}, &selected); err != nil {
+			return err
+		}
+		if len(selected) == 0 {
+			return exitcode.New(exitcode.Cancelled, "no clusters selected; nothing saved")
+		}
+		keep = make([]mocklogs.Proposal, 0, len(selected))
+		for _, i := range selected {
+			keep = append(keep, proposals[i])
+		}
+	}
+
+	if opts.AI {
+		if err := generateProposalBodies(ctx, keep, opts.Provider, project); err != nil {
+			return err
+		}
+	}
+
+	added := make([]models.MockExpectation, len(keep))
+	for i := range keep {
+		added[i] = keep[i].Expectation
+	}
+	config.Expectations = append(config.Expectations, added...)
+	if err := manager.Provider.UpdateConfig(ctx, config); err != nil {
+		return fmt.Errorf("failed to save the expectations: %w", err)
+	}
+	fmt.Printf("✅ Added %d expectation(s) to project %s (version %s)\n", len(added), project, config.Metadata.Version)
+	if !opts.AI {
+		fmt.Println("💡 Placeholder responses say TODO; edit them with 'automock init', or rerun with --ai")
+	}
+	fmt.Printf("💡 Serve them with 'automock deploy --project %s'\n", project)
+	return nil
+}
+
+// generateProposalBodies has an AI provider replace the placeholder response bodies; a
+// body that fails to generate keeps its placeholder
+func generateProposalBodies(ctx context.Context, proposals []mocklogs.Proposal, provider, project string) error {
+	if provider == "" {
+		var err error
+		if provider, err = expectations.SelectAvailableProvider(); err != nil {
+			return exitcode.Wrap(exitcode.Config, err)
+		}
+	}
+	for i := range proposals {
+		exp := &proposals[i].Expectation
+		if proposals[i].Borrowed != "" || exp.HttpResponse.Body == nil {
+			continue
+		}
+		fmt.Printf("\n📝 %s %s\n", exp.HttpRequest.Method, exp.HttpRequest.Path)
+		body, err := expectations.GenerateResponseBody(ctx, exp, provider, project, analyzeInstruction)
+		if err != nil {
+			fmt.Printf("⚠️  Keeping the placeholder: %v\n", err)
+			continue
+		}
+		resp := *exp.HttpResponse
+		resp.Body = map[string]any{"type": "JSON", "json": body}
+		exp.HttpResponse = &resp
+	}
+	return nil
+}
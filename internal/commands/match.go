@@ -0,0 +1,215 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/matcher"
+	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/projectarchive"
+)
+
+// MatchOptions describes the request to evaluate and where expectations come from
+type MatchOptions struct {
+	File    string // local MockServer expectations JSON; overrides the project store
+	Request string // JSON request example (method, path, query, headers, body); flags override it
+	Method  string
+	Path    string   // may include a query string
+	Headers []string // "Name: value"
+	Query   []string // "name=value"
+	Body    string   // literal body, or @file
+	Top     int      // near-miss candidates to trace
+}
+
+// RunMatch evaluates a request against a project's expectations offline and prints the trace
+func RunMatch(profile, project string, opts MatchOptions) error {
+	expectations, err := loadExpectations(profile, project, opts.File)
+	if err != nil {
+		return err
+	}
+	req, err := buildMatchRequest(opts)
+	if err != nil {
+		return err
+	}
+
+	top := opts.Top
+	if top <= 0 {
+		top = 3
+	}
+	best, results := matcher.Match(expectations, req)
+	if best >= 0 {
+		if ranked := matcher.Ranked(results); len(ranked) > 1 {
+			fmt.Println(matcher.FormatRanking(ranked))
+		}
+		fmt.Println(matcher.FormatTrace(req, []matcher.Result{results[best]}))
+		if misses := matcher.TopCandidates(results, top); len(misses) > 0 {
+			fmt.Printf("Closest non-matching expectation(s) and why they lose:\n")
+			fmt.Println(matcher.FormatTrace(req, misses))
+		}
+		return nil
+	}
+
+	fmt.Printf("❌ No expectation matched. Closest %d candidate(s):\n", min(top, len(results)))
+	fmt.Println(matcher.FormatTrace(req, matcher.TopCandidates(results, top)))
+	return nil
+}
+
+// loadExpectations reads expectations from a local file (expectations JSON or a project
+// archive) or from the project store
+func loadExpectations(profile, project, file string) ([]models.MockExpectation, error) {
+	cfg, err := loadConfig(profile, project, file)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.Expectations, nil
+}
+
+// loadConfig is loadExpectations keeping the rest of the configuration, e.g. datasets; an
+// expectations JSON file has only the expectations
+func loadConfig(profile, project, file string) (*models.MockConfiguration, error) {
+	if file != "" && projectarchive.IsArchive(file) {
+		archive, err := projectarchive.Read(file)
+		if err != nil {
+			return nil, err
+		}
+		return archive.Current, nil
+	}
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		return models.ParseMockServerJSON(string(data))
+	}
+	if project == "" {
+		return nil, fmt.Errorf("--project or --file is required")
+	}
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return nil, err
+	}
+	cfg, err := manager.Provider.GetConfig(context.Background(), project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load expectations for %s: %w", project, err)
+	}
+	return cfg, nil
+}
+
+// requestExample is the --request file format; header and query values may be a string or a list,
+// and the body may be a JSON value or a string
+type requestExample struct {
+	Method  string                     `json:"method"`
+	Path    string                     `json:"path"`
+	Query   map[string]json.RawMessage `json:"query"`
+	Headers map[string]json.RawMessage `json:"headers"`
+	Body    json.RawMessage            `json:"body"`
+}
+
+// applyRequestFile fills options from a request example and returns its body;
+// explicit flags take precedence
+func applyRequestFile(opts *MatchOptions) ([]byte, error) {
+	data, err := os.ReadFile(opts.Request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request file: %w", err)
+	}
+	var ex requestExample
+	if err := json.Unmarshal(data, &ex); err != nil {
+		return nil, fmt.Errorf("invalid request file %s: %w", opts.Request, err)
+	}
+	if opts.Method == "" {
+		opts.Method = ex.Method
+	}
+	if opts.Path == "" {
+		opts.Path = ex.Path
+	}
+	for name, raw := range ex.Query {
+		values, err := stringOrList(raw)
+		if err != nil {
+			return nil, fmt.Errorf("query %q: %w", name, err)
+		}
+		for _, v := range values {
+			opts.Query = append(opts.Query, name+"="+v)
+		}
+	}
+	for name, raw := range ex.Headers {
+		values, err := stringOrList(raw)
+		if err != nil {
+			return nil, fmt.Errorf("header %q: %w", name, err)
+		}
+		for _, v := range values {
+			opts.Headers = append(opts.Headers, name+": "+v)
+		}
+	}
+	if len(ex.Body) == 0 || string(ex.Body) == "null" {
+		return nil, nil
+	}
+	var text string
+	if err := json.Unmarshal(ex.Body, &text); err == nil {
+		return []byte(text), nil
+	}
+	return ex.Body, nil
+}
+
+func stringOrList(raw json.RawMessage) ([]string, error) {
+	var one string
+	if err := json.Unmarshal(raw, &one); err == nil {
+		return []string{one}, nil
+	}
+	var many []string
+	if err := json.Unmarshal(raw, &many); err != nil {
+		return nil, fmt.Errorf("expected a string or a list of strings")
+	}
+	return many, nil
+}
+
+func buildMatchRequest(opts MatchOptions) (*matcher.Request, error) {
+	var exampleBody []byte
+	if opts.Request != "" {
+		var err error
+		if exampleBody, err = applyRequestFile(&opts); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Path == "" {
+		return nil, fmt.Errorf("--path or --request is required")
+	}
+	u, err := url.Parse(opts.Path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+	method := strings.ToUpper(opts.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	query := u.Query()
+	for _, q := range opts.Query {
+		name, value, _ := strings.Cut(q, "=")
+		query.Add(name, value)
+	}
+	headers := http.Header{}
+	for _, h := range opts.Headers {
+		name, value, ok := strings.Cut(h, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid header %q; use 'Name: value'", h)
+		}
+		headers.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+
+	body := []byte(opts.Body)
+	if opts.Body == "" {
+		body = exampleBody
+	} else if strings.HasPrefix(opts.Body, "@") {
+		if body, err = os.ReadFile(strings.TrimPrefix(opts.Body, "@")); err != nil {
+			return nil, fmt.Errorf("failed to read body file: %w", err)
+		}
+	}
+
+	return &matcher.Request{Method: method, Path: u.Path, Query: query, Headers: headers, Body: body}, nil
+}
@@ -0,0 +1,298 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal"
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/cloud/git"
+	"github.com/hemantobora/auto-mock/internal/cloud/local"
+	"github.com/hemantobora/auto-mock/internal/cloud/naming"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/projectarchive"
+)
+
+// ArchiveOptions selects what an export writes or an import restores
+type ArchiveOptions struct {
+	SkipVersions bool // only the current configuration, not the saved versions
+	SkipLoadTest bool // leave out the active load-test bundle
+}
+
+// RunExportProject writes a project's current configuration, saved versions and active
+// load-test bundle into one archive (out, default <project>.automock.tar.gz)
+func RunExportProject(profile, project, out, automockVersion string, opts ArchiveOptions) error {
+	if project == "" {
+		return fmt.Errorf("--project is required")
+	}
+	if out == "" {
+		out = projectarchive.DefaultName(project)
+	}
+	if !projectarchive.IsArchive(out) {
+		return exitcode.New(exitcode.Config, "--out %s must end in .tar.gz, .tgz or .zip", out)
+	}
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return err
+	}
+	archive, err := buildArchive(context.Background(), manager.Provider, project, automockVersion, opts)
+	if err != nil {
+		return err
+	}
+
+	if err := archive.Write(out); err != nil {
+		return err
+	}
+	fmt.Printf("📦 Exported %s to %s: %s\n", project, out, archive.Summary())
+	fmt.Printf("💡 Restore it elsewhere with 'automock import --file %s' (add --project to rename it)\n", out)
+	return nil
+}
+
+// buildArchive reads a project and its history from provider into an archive
+func buildArchive(ctx context.Context, provider internal.Provider, project, automockVersion string, opts ArchiveOptions) (*projectarchive.Archive, error) {
+	if exists, err := provider.ProjectExists(ctx, project); err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, exitcode.New(exitcode.Config, "project %q does not exist", project)
+	}
+	current, err := provider.GetConfig(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project %s: %w", project, err)
+	}
+
+	archive := &projectarchive.Archive{
+		Manifest: projectarchive.Manifest{
+			Project:         project,
+			ExportedAt:      time.Now().UTC(),
+			AutoMockVersion: automockVersion,
+			Cloud:           provider.GetProviderType(),
+			Region:          provider.GetRegion(),
+		},
+		Current:  current,
+		Versions: map[string]*models.MockConfiguration{},
+	}
+
+	if !opts.SkipVersions {
+		versions, err := provider.ListVersions(ctx, project)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range versions {
+			cfg, err := provider.GetVersion(ctx, project, v.Version)
+			if err != nil {
+				fmt.Printf("⚠️  Skipping version %s: %v\n", v.Version, err)
+				continue
+			}
+			archive.Versions[v.Version] = cfg
+		}
+	}
+
+	if !opts.SkipLoadTest {
+		if ptr, err := provider.GetLoadTestPointer(ctx, project); err == nil && ptr != nil {
+			files, err := downloadLoadTestFiles(ctx, provider, project)
+			if err != nil {
+				return nil, fmt.Errorf("failed to copy the load-test bundle (use --skip-load-test to leave it out): %w", err)
+			}
+			archive.Manifest.LoadTest = ptr
+			archive.LoadTestFiles = files
+		}
+	}
+	return archive, nil
+}
+
+// downloadLoadTestFiles reads the active load-test bundle of project into memory
+func downloadLoadTestFiles(ctx context.Context, provider internal.Provider, project string) (map[string][]byte, error) {
+	tmp, err := os.MkdirTemp("", "automock-export-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	_, dir, err := provider.DownloadLoadTestBundle(ctx, project, tmp)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string][]byte{}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		files[e.Name()] = data
+	}
+	return files, nil
+}
+
+// ImportOptions selects the archive to restore and the project it becomes
+type ImportOptions struct {
+	ArchiveOptions
+	File    string // archive written by export
+	Project string // target project (default: the exported project's name)
+	Force   bool   // replace the current configuration of an existing project
+}
+
+// RunImportProject restores an exported project into the detected cloud account and region.
+// Saved versions keep their names; the current configuration becomes current again, not
+// deployed, and the load-test bundle is uploaded as a new version.
+func RunImportProject(profile string, opts ImportOptions) error {
+	if opts.File == "" {
+		return fmt.Errorf("--file is required")
+	}
+	archive, err := projectarchive.Read(opts.File)
+	if err != nil {
+		return exitcode.Wrap(exitcode.Config, err)
+	}
+	project := opts.Project
+	if project == "" {
+		project = archive.Manifest.Project
+	}
+	if err := naming.NewDefaultNaming().ValidateProjectID(project); err != nil {
+		return exitcode.Wrap(exitcode.Config, fmt.Errorf("invalid project name: %w", err))
+	}
+	fmt.Printf("📦 %s: %s exported from %s %s on %s\n", opts.File, archive.Manifest.Project,
+		archive.Manifest.Cloud, archive.Manifest.Region, archive.Manifest.ExportedAt.Format("2006-01-02"))
+
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return err
+	}
+	return restoreArchive(context.Background(), manager.Provider, archive, project, opts)
+}
+
+// restoreArchive writes an archive into provider as project
+func restoreArchive(ctx context.Context, provider internal.Provider, archive *projectarchive.Archive, project string, opts ImportOptions) error {
+	exists, err := provider.ProjectExists(ctx, project)
+	if err != nil {
+		return err
+	}
+	if exists && !opts.Force {
+		hint := "Add --force to replace its current configuration"
+		if opts.File != "" {
+			hint += ", or --project to import under another name"
+		}
+		return exitcode.WithHint(exitcode.Config, fmt.Errorf("project %q already exists in %s", project, provider.GetProviderType()), hint)
+	}
+	if !exists {
+		if err := provider.InitProject(ctx, project); err != nil {
+			return err
+		}
+	}
+
+	versions := 0
+	if !opts.SkipVersions {
+		var names []string
+		for v := range archive.Versions {
+			names = append(names, v)
+		}
+		sort.Strings(names)
+		for _, v := range names {
+			cfg := archive.Versions[v]
+			cfg.Metadata.ProjectID = project
+			if err := provider.SaveVersion(ctx, cfg, v); err != nil {
+				return fmt.Errorf("failed to import version %s: %w", v, err)
+			}
+			versions++
+		}
+	}
+
+	// Nothing is deployed from this store yet, so the breaking-change baseline starts over
+	current := archive.Current
+	current.Metadata.ProjectID = project
+	current.Metadata.DeployedVersion = ""
+	if err := provider.SaveConfig(ctx, current); err != nil {
+		return fmt.Errorf("failed to import the current configuration: %w", err)
+	}
+	fmt.Printf("✅ Imported %s: %d expectation(s) at %s, %d saved version(s)\n",
+		project, len(current.Expectations), current.Metadata.Version, versions)
+
+	if len(archive.LoadTestFiles) > 0 && !opts.SkipLoadTest {
+		if err := uploadLoadTestFiles(ctx, provider, project, archive.LoadTestFiles); err != nil {
+			return fmt.Errorf("failed to import the load-test bundle (use --skip-load-test to leave it out): %w", err)
+		}
+	}
+	if cloud.IsLocalStore(provider.GetProviderType()) {
+		fmt.Printf("💡 Run 'automock serve --project %s' to serve it\n", project)
+	} else {
+		fmt.Printf("💡 Run 'automock deploy --project %s' to serve it\n", project)
+	}
+	return nil
+}
+
+// RunPushProject copies a project from the local store (or the git store, with --cloud git)
+// to a cloud, the way export followed by import would. to picks the cloud (aws or azure);
+// empty auto-detects it from credentials.
+func RunPushProject(profile, project, to, automockVersion string, opts ArchiveOptions, force bool) error {
+	if project == "" {
+		return fmt.Errorf("--project is required")
+	}
+	ctx := context.Background()
+	var source internal.Provider
+	var err error
+	if os.Getenv(cloud.EnvCloud) == git.ProviderType {
+		source, err = git.NewProvider(ctx)
+	} else {
+		source, err = local.NewProvider()
+	}
+	if err != nil {
+		return err
+	}
+
+	switch to {
+	case "":
+		// --cloud local or git selects the source here, never the target
+		if cloud.IsLocalStore(os.Getenv(cloud.EnvCloud)) {
+			os.Unsetenv(cloud.EnvCloud)
+		}
+	case "aws", "azure":
+		os.Setenv(cloud.EnvCloud, to)
+	default:
+		return exitcode.New(exitcode.Config, "--to must be aws or azure, got %q", to)
+	}
+
+	archive, err := buildArchive(ctx, source, project, automockVersion, opts)
+	if err != nil {
+		return err
+	}
+
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		if exitcode.Classify(err) == exitcode.Auth {
+			return exitcode.WithHint(exitcode.Auth, err, "Push writes to a cloud account: configure its credentials, and pick the cloud with --to")
+		}
+		return err
+	}
+	fmt.Printf("⬆️  Pushing %s (%s) to %s %s\n", project, archive.Summary(),
+		manager.Provider.GetProviderType(), manager.Provider.GetRegion())
+	return restoreArchive(ctx, manager.Provider, archive, project, ImportOptions{ArchiveOptions: opts, Force: force})
+}
+
+// uploadLoadTestFiles uploads an archived load-test bundle as the project's active bundle
+func uploadLoadTestFiles(ctx context.Context, provider internal.Provider, project string, files map[string][]byte) error {
+	dir, err := os.MkdirTemp("", "automock-import-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, filepath.Base(name)), data, 0600); err != nil {
+			return err
+		}
+	}
+	ptr, _, err := provider.UploadLoadTestBundle(ctx, project, dir)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Load-test bundle imported as %s\n", ptr.ActiveVersion)
+	return nil
+}
@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultProjectConcurrency bounds how many projects a multi-project deploy or destroy runs at once
+const DefaultProjectConcurrency = 4
+
+// ProjectResult is the outcome of one project in a multi-project run
+type ProjectResult struct {
+	Project  string
+	Outcome  string // short description, e.g. "deployed", "already deployed", "skipped"
+	Detail   string // e.g. the endpoint
+	Err      error
+	Duration time.Duration
+}
+
+// SplitProjects parses a --project value that may list several projects ("a,b,c"),
+// dropping blanks and duplicates while keeping the given order
+func SplitProjects(value string) []string {
+	seen := map[string]bool{}
+	var projects []string
+	for _, p := range strings.Split(value, ",") {
+		if p = strings.TrimSpace(p); p != "" && !seen[p] {
+			seen[p] = true
+			projects = append(projects, p)
+		}
+	}
+	return projects
+}
+
+// RunProjects runs fn for every project, at most concurrency at a time. Projects are isolated:
+// an error or panic in one is recorded in its result and the others carry on. Results keep
+// the order of projects.
+func RunProjects(projects []string, concurrency int, fn func(project string) (outcome, detail string, err error)) []ProjectResult {
+	if concurrency < 1 {
+		concurrency = DefaultProjectConcurrency
+	}
+	results := make([]ProjectResult, len(projects))
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, project := range projects {
+		wg.Add(1)
+		go func(i int, project string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			start := time.Now()
+			res := ProjectResult{Project: project}
+			defer func() {
+				if r := recover(); r != nil {
+					res.Err = fmt.Errorf("panic: %v", r)
+				}
+				res.Duration = time.Since(start).Round(time.Second)
+				results[i] = res
+			}()
+			res.Outcome, res.Detail, res.Err = fn(project)
+		}(i, project)
+	}
+	wg.Wait()
+	return results
+}
+
+// PrintProjectSummary writes the combined result table of a multi-project run and returns
+// an error naming the projects that failed, so the command exits non-zero
+func PrintProjectSummary(out io.Writer, action string, results []ProjectResult) error {
+	width := len("PROJECT")
+	for _, r := range results {
+		width = max(width, len(r.Project))
+	}
+	fmt.Fprintf(out, "\n📋 %s summary\n", action)
+	fmt.Fprintln(out, strings.Repeat("━", 80))
+	fmt.Fprintf(out, "%-*s  %-8s  %-8s  %s\n", width, "PROJECT", "RESULT", "TIME", "DETAILS")
+
+	var failed []string
+	for _, r := range results {
+		status, details := "✅ ok", r.Outcome
+		if r.Err != nil {
+			status, details = "❌ failed", r.Err.Error()
+			failed = append(failed, r.Project)
+		}
+		if r.Detail != "" {
+			details += " — " + r.Detail
+		}
+		fmt.Fprintf(out, "%-*s  %-8s  %-8s  %s\n", width, r.Project, status, r.Duration, details)
+	}
+	fmt.Fprintf(out, "\n%d succeeded, %d failed\n", len(results)-len(failed), len(failed))
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s failed for %d project(s): %s", strings.ToLower(action), len(failed), strings.Join(failed, ", "))
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSplitProjects(t *testing.T) {
+	got := SplitProjects(" users, orders,,users ,billing")
+	if want := []string{"users", "orders", "billing"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitProjects = %v, want %v", got, want)
+	}
+}
+
+func TestRunProjectsIsolatesFailures(t *testing.T) {
+	var running, peak int32
+	results := RunProjects([]string{"a", "b", "c", "d"}, 2, func(project string) (string, string, error) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		switch project {
+		case "b":
+			return "", "", errors.New("terraform failed")
+		case "c":
+			panic("boom")
+		}
+		return "deployed", "http://" + project, nil
+	})
+
+	if peak > 2 {
+		t.Errorf("%d projects ran at once, limit 2", peak)
+	}
+	for i, want := range []string{"a", "b", "c", "d"} {
+		if results[i].Project != want {
+			t.Fatalf("result %d is %s, want %s", i, results[i].Project, want)
+		}
+	}
+	if results[0].Err != nil || results[3].Outcome != "deployed" || results[1].Err == nil || results[2].Err == nil {
+		t.Errorf("unexpected results %+v", results)
+	}
+
+	var out bytes.Buffer
+	err := PrintProjectSummary(&out, "Deploy", results)
+	if err == nil || !strings.Contains(err.Error(), "2 project(s): b, c") {
+		t.Errorf("summary error = %v", err)
+	}
+	if !strings.Contains(out.String(), "deployed — http://a") || !strings.Contains(out.String(), "2 succeeded, 2 failed") {
+		t.Errorf("summary:\n%s", out.String())
+	}
+}
@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/recorder"
+)
+
+// RecordOptions configures the recording proxy
+type RecordOptions struct {
+	Target string // real backend base URL
+	Host   string
+	Port   int
+	TTL    string // freshness TTL stamped on the recordings, e.g. "7d"; empty never expires
+}
+
+// RunRecord proxies traffic to the target until interrupted, then converts the recorded
+// exchanges into expectations, lets the user review them and saves them to the project
+func RunRecord(profile, project string, opts RecordOptions) error {
+	if project == "" {
+		return exitcode.New(exitcode.Config, "--project is required")
+	}
+	rec, err := recorder.New(opts.Target, os.Stdout)
+	if err != nil {
+		return exitcode.Wrap(exitcode.Config, err)
+	}
+	if opts.TTL != "" {
+		if _, err := models.ParseTTL(opts.TTL); err != nil {
+			return exitcode.Wrap(exitcode.Config, err)
+		}
+	}
+	if opts.Port == 0 {
+		opts.Port = 8080
+	}
+	// Resolve the store first so bad credentials fail before any traffic is recorded
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(opts.Host, strconv.Itoa(opts.Port))
+	server := &http.Server{Addr: addr, Handler: rec}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdown)
+	}()
+
+	host := opts.Host
+	if host == "" {
+		host = "localhost"
+	}
+	fmt.Printf("🎙️  Recording http://%s → %s\n", net.JoinHostPort(host, strconv.Itoa(opts.Port)), opts.Target)
+	fmt.Println("💡 Point your app or tests at the proxy; every request is forwarded and its response recorded.")
+	fmt.Println("   Press Ctrl-C to stop and review the recordings")
+	err = server.ListenAndServe()
+	stop()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	exchanges := rec.Exchanges()
+	fmt.Printf("\n⏹️  Recording stopped: %d exchange(s)\n", len(exchanges))
+	if len(exchanges) == 0 {
+		fmt.Println("ℹ️  Nothing recorded; no changes saved.")
+		return nil
+	}
+	recorded, merged := recorder.Expectations(exchanges, opts.Target, models.NewRunID(models.ProvenanceRecording))
+	for i := range recorded {
+		recorded[i].Freshness.TTL = opts.TTL
+	}
+	if report := models.FormatMergeReport(merged); report != "" {
+		fmt.Print(report)
+	}
+	keep, err := reviewRecordings(recorded)
+	if err != nil {
+		return err
+	}
+	return saveRecordings(manager, project, keep)
+}
+
+// reviewRecordings lists the recorded expectations and returns the ones the user keeps
+func reviewRecordings(recorded []models.MockExpectation) ([]models.MockExpectation, error) {
+	fmt.Println("\n📋 RECORDED EXPECTATIONS")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	options := make([]string, len(recorded))
+	for i, exp := range recorded {
+		options[i] = fmt.Sprintf("[%d] %s %s → %d", i+1, exp.HttpRequest.Method, exp.HttpRequest.Path, exp.HttpResponse.StatusCode)
+		if n := len(exp.Examples); n > 1 {
+			options[i] += fmt.Sprintf(" (%d requests)", n)
+		}
+	}
+	var selected []int
+	if err := ask.One(&survey.MultiSelect{
+		Message:  "Keep which expectations?",
+		Options:  options,
+		Default:  options,
+		PageSize: 15,
+		Help:     "Deselect health checks, assets or anything else the mock should not answer.",
+	}, &selected); err != nil {
+		return nil, err
+	}
+	if len(selected) == 0 {
+		return nil, exitcode.New(exitcode.Cancelled, "no recorded expectations kept; nothing saved")
+	}
+	keep := make([]models.MockExpectation, 0, len(selected))
+	for _, i := range selected {
+		keep = append(keep, recorded[i])
+	}
+	fmt.Println("⚠️  Recorded responses may contain real data; review them (or deploy with --mask) before sharing.")
+	return keep, nil
+}
+
+// saveRecordings adds the expectations to the project, or replaces its expectations with them
+func saveRecordings(manager *cloud.CloudManager, project string, recorded []models.MockExpectation) error {
+	ctx := context.Background()
+	if err := manager.Provider.InitProject(ctx, project); err != nil {
+		return fmt.Errorf("failed to initialize project: %w", err)
+	}
+	config, err := manager.Provider.GetConfig(ctx, project)
+	if err != nil {
+		config = &models.MockConfiguration{Metadata: models.ConfigMetadata{ProjectID: project}}
+	}
+	config.Metadata.ProjectID = project
+
+	if n := len(config.Expectations); n > 0 {
+		add := fmt.Sprintf("Add to the %d existing expectation(s)", n)
+		var choice string
+		if err := ask.One(&survey.Select{
+			Message: fmt.Sprintf("Project %s already has expectations:", project),
+			Options: []string{add, "Replace them with the recordings"},
+			Default: add,
+		}, &choice); err != nil {
+			return err
+		}
+		if choice == add {
+			recorded = append(config.Expectations, recorded...)
+		}
+	}
+	config.Expectations = recorded
+	if added := config.HarvestBodyExamples(models.ProvenanceRecording); added > 0 {
+		fmt.Printf("📚 Added %d response example(s) to the project library\n", added)
+	}
+	if err := manager.Provider.UpdateConfig(ctx, config); err != nil {
+		return fmt.Errorf("failed to save the recordings: %w", err)
+	}
+	fmt.Printf("✅ Saved %d expectation(s) to project %s (version %s)\n", len(config.Expectations), project, config.Metadata.Version)
+	fmt.Printf("💡 Try them locally: automock serve --project %s\n", project)
+	return nil
+}
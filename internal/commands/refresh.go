@@ -0,0 +1,147 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/recorder"
+)
+
+// RefreshOptions configures automock refresh
+type RefreshOptions struct {
+	All     bool     // re-execute every recorded expectation, not only the stale ones
+	Headers []string // "Name: value" headers added to each request, e.g. credentials
+	DryRun  bool     // report upstream changes without saving
+}
+
+// RunRefresh re-executes the requests behind a project's recorded expectations against their
+// upstream and updates the stored responses; changed responses are shown for review first
+func RunRefresh(profile, project string, opts RefreshOptions) error {
+	if project == "" {
+		return exitcode.New(exitcode.Config, "--project is required")
+	}
+	header, err := parseHeaderFlags(opts.Headers)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return err
+	}
+	config, err := manager.Provider.GetConfig(ctx, project)
+	if err != nil {
+		return fmt.Errorf("failed to load project %s: %w", project, err)
+	}
+
+	now := time.Now().UTC()
+	var targets []int
+	if opts.All {
+		for i := range config.Expectations {
+			if exp := &config.Expectations[i]; exp.Freshness != nil && !exp.Generated() {
+				targets = append(targets, i)
+			}
+		}
+	} else {
+		targets = config.StaleExpectations(now)
+	}
+	if len(targets) == 0 {
+		if opts.All {
+			fmt.Printf("ℹ️  Project %s has no recorded expectations.\n", project)
+		} else {
+			fmt.Printf("✅ All recorded expectations in %s are fresh.\n", project)
+		}
+		return nil
+	}
+
+	fmt.Printf("🔄 Refreshing %d recorded expectation(s) in %s\n\n", len(targets), project)
+	client := &http.Client{Timeout: 30 * time.Second}
+	var unchanged, updated, changed, skipped int
+	for _, i := range targets {
+		exp := &config.Expectations[i]
+		label := fmt.Sprintf("%s %s", exp.HttpRequest.Method, exp.HttpRequest.Path)
+		cur, err := recorder.Replay(ctx, client, exp, header)
+		if err != nil {
+			fmt.Printf("⏭️  %s — skipped: %v\n", label, err)
+			skipped++
+			continue
+		}
+		old := exp.HttpResponse
+		if old == nil {
+			old = &models.HttpResponse{}
+		}
+		if (cur.StatusCode == http.StatusUnauthorized || cur.StatusCode == http.StatusForbidden) && cur.StatusCode != old.StatusCode {
+			fmt.Printf("⏭️  %s — skipped: upstream answered %d; pass credentials with --header\n", label, cur.StatusCode)
+			skipped++
+			continue
+		}
+
+		changes := models.DiffResponses(old, cur)
+		if len(changes) == 0 {
+			fmt.Printf("✅ %s — unchanged\n", label)
+			exp.Freshness.RecordedAt = now
+			unchanged++
+			continue
+		}
+		fmt.Printf("🔀 %s — changed upstream:\n", label)
+		for _, c := range changes {
+			fmt.Printf("   • %s\n", c)
+		}
+		changed++
+		if opts.DryRun {
+			continue
+		}
+		update := true
+		if err := ask.One(&survey.Confirm{Message: "Update the recorded response?", Default: true}, &update); err != nil {
+			return err
+		}
+		if !update {
+			continue
+		}
+		exp.Rerecord(cur, now)
+		exp.MarkEdited()
+		updated++
+	}
+
+	fmt.Printf("\n%d unchanged, %d changed (%d updated), %d skipped\n", unchanged, changed, updated, skipped)
+	if opts.DryRun {
+		if changed > 0 {
+			return exitcode.New(exitcode.Validation, "%d recorded response(s) changed upstream", changed)
+		}
+		return nil
+	}
+	if unchanged+updated == 0 {
+		fmt.Println("ℹ️  No changes saved.")
+		return nil
+	}
+	if err := manager.Provider.UpdateConfig(ctx, config); err != nil {
+		return fmt.Errorf("failed to save the refreshed expectations: %w", err)
+	}
+	fmt.Printf("✅ Saved project %s (version %s)\n", project, config.Metadata.Version)
+	if changed > updated {
+		fmt.Printf("💡 %d changed response(s) were kept as recorded; they stay stale until refreshed again.\n", changed-updated)
+	}
+	return nil
+}
+
+// parseHeaderFlags parses repeated --header "Name: value" flags
+func parseHeaderFlags(headers []string) (http.Header, error) {
+	header := http.Header{}
+	for _, h := range headers {
+		name, value, ok := strings.Cut(h, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, exitcode.New(exitcode.Config, "invalid --header %q: use \"Name: value\"", h)
+		}
+		header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	return header, nil
+}
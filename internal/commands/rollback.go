@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/kubernetes"
+	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/repl"
+)
+
+// RollbackOptions selects the version to restore and what happens after
+type RollbackOptions struct {
+	Version       string // saved version, e.g. v1712345678 (or just the timestamp); empty = pick from the list
+	Deploy        bool   // redeploy the mocks with the restored version
+	AllowBreaking bool   // let the redeploy through the breaking-change gate
+}
+
+// RunRollback restores a saved version of a project's expectations as the current configuration.
+// The restore is itself saved as a new version, so a rollback can be rolled back.
+func RunRollback(profile, project string, opts RollbackOptions) error {
+	if project == "" {
+		return fmt.Errorf("--project is required")
+	}
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return err
+	}
+	provider := manager.Provider
+	ctx := context.Background()
+
+	current, err := provider.GetConfig(ctx, project)
+	if err != nil {
+		return exitcode.Wrap(exitcode.Config, fmt.Errorf("failed to load project %s: %w", project, err))
+	}
+	version := normalizeVersion(opts.Version)
+	if version == "current" {
+		version = current.Metadata.Version
+	}
+	if version == "" {
+		if version, err = selectRollbackVersion(ctx, provider, project, current); err != nil {
+			return err
+		}
+	}
+	if version == current.Metadata.Version {
+		if !opts.Deploy {
+			fmt.Printf("ℹ️  %s is already the current version of %s\n", version, project)
+			return nil
+		}
+	} else if err := restoreVersion(ctx, provider, project, current, version); err != nil {
+		return err
+	}
+
+	deploy := opts.Deploy
+	deployed, _ := provider.IsDeployed()
+	if deployed && !deploy {
+		if err := ask.One(&survey.Confirm{
+			Message: "Redeploy the mocks with the restored version?",
+			Help:    "Runs the deploy checks (validation, breaking changes) and applies the infrastructure",
+			Default: false,
+		}, &deploy); err != nil {
+			return err
+		}
+	}
+	if !deploy {
+		if deployed {
+			fmt.Printf("💡 Redeploy later with 'automock rollback --project %s --version current --deploy'\n", project)
+		} else {
+			fmt.Printf("💡 Run 'automock deploy --project %s' to serve the restored version\n", project)
+		}
+		return nil
+	}
+	deployer := repl.NewDeployment(project, profile, provider)
+	if meta, _ := provider.GetDeploymentMetadata(); meta != nil {
+		// Redeploy where the mocks run now
+		if cluster, ok := kubernetes.ManagerFromOutputs(project, meta.Details); ok {
+			deployer.Kubernetes = &cluster.Options
+		}
+	}
+	return deployer.Deploy(true, opts.AllowBreaking)
+}
+
+// restoreVersion shows what the rollback changes and, once confirmed, saves version as current
+func restoreVersion(ctx context.Context, provider internal.Provider, project string, current *models.MockConfiguration, version string) error {
+	target, err := provider.GetVersion(ctx, project, version)
+	if err != nil {
+		return exitcode.WithHint(exitcode.Config, fmt.Errorf("version %s of %s: %w", version, project, err),
+			fmt.Sprintf("Run 'automock rollback --project %s' to pick from the stored versions", project))
+	}
+	target.Metadata.Version = version
+	fmt.Print(models.DiffVersions(current, target).Format())
+	var confirmed bool
+	if err := ask.One(&survey.Confirm{
+		Message: fmt.Sprintf("Restore %s as the current configuration of %s?", version, project),
+		Default: true,
+	}, &confirmed); err != nil {
+		return err
+	}
+	if !confirmed {
+		return exitcode.New(exitcode.Cancelled, "rollback cancelled")
+	}
+
+	restored := rollbackConfig(current, target, version, time.Now())
+	if err := provider.UpdateConfig(ctx, restored); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", version, err)
+	}
+	fmt.Printf("⏪ Restored %s of %s as %s\n", version, project, restored.Metadata.Version)
+	return nil
+}
+
+// normalizeVersion accepts a version as listed (v1712345678), its bare timestamp, or "current"
+func normalizeVersion(version string) string {
+	version = strings.TrimSpace(version)
+	if version == "" || version == "current" || strings.HasPrefix(version, "v") {
+		return version
+	}
+	return "v" + version
+}
+
+// rollbackConfig is the target version made current again. Project history and deploy state
+// (changelog, consumers, deployed and contract versions) stay as they are now.
+func rollbackConfig(current, target *models.MockConfiguration, version string, now time.Time) *models.MockConfiguration {
+	restored := *target
+	restored.Metadata.ProjectID = current.Metadata.ProjectID
+	restored.Metadata.DeployedVersion = current.Metadata.DeployedVersion
+	restored.Metadata.ContractVersion = current.Metadata.ContractVersion
+	restored.Metadata.Description = fmt.Sprintf("Rolled back to %s", version)
+	restored.Metadata.UpdatedAt = now
+	restored.Changelog = current.Changelog
+	restored.Consumers = current.Consumers
+	return &restored
+}
+
+// selectRollbackVersion lists the stored versions, newest first, and returns the one picked
+func selectRollbackVersion(ctx context.Context, provider internal.Provider, project string, current *models.MockConfiguration) (string, error) {
+	versions, err := provider.ListVersions(ctx, project)
+	if err != nil {
+		return "", err
+	}
+	sort.SliceStable(versions, func(i, j int) bool { return versions[i].CreatedAt.After(versions[j].CreatedAt) })
+	var options []string
+	for _, v := range versions {
+		if v.Version == current.Metadata.Version {
+			continue
+		}
+		option := fmt.Sprintf("%s - saved %s", v.Version, v.CreatedAt.Local().Format("2006-01-02 15:04"))
+		if v.Version == current.Metadata.DeployedVersion {
+			option += " (deployed)"
+		}
+		options = append(options, option)
+	}
+	if len(options) == 0 {
+		return "", exitcode.New(exitcode.Config, "project %s has no earlier version to roll back to", project)
+	}
+	fmt.Printf("📚 Current version: %s\n", current.Metadata.Version)
+	var choice string
+	if err := ask.One(&survey.Select{Message: "Roll back to:", Options: options, PageSize: 12}, &choice); err != nil {
+		return "", err
+	}
+	return strings.Fields(choice)[0], nil
+}
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+func TestNormalizeVersion(t *testing.T) {
+	for in, want := range map[string]string{
+		"":              "",
+		" v1712345678 ": "v1712345678",
+		"1712345678":    "v1712345678",
+		"current":       "current",
+	} {
+		if got := normalizeVersion(in); got != want {
+			t.Errorf("normalizeVersion(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRollbackConfigKeepsHistory(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	current := &models.MockConfiguration{
+		Metadata: models.ConfigMetadata{ProjectID: "users", Version: "v3", DeployedVersion: "v3", ContractVersion: "2.0.0"},
+		Expectations: []models.MockExpectation{
+			{HttpRequest: &models.HttpRequest{Method: "GET", Path: "/users"}},
+			{HttpRequest: &models.HttpRequest{Method: "DELETE", Path: "/users/1"}},
+		},
+		Changelog: []models.ChangelogEntry{{Version: "2.0.0"}, {Version: "1.0.0"}},
+		Consumers: []models.Consumer{{Name: "web"}},
+	}
+	target := &models.MockConfiguration{
+		Metadata:     models.ConfigMetadata{ProjectID: "users", Version: "v1", DeployedVersion: "v0", ContractVersion: "1.0.0"},
+		Expectations: []models.MockExpectation{{HttpRequest: &models.HttpRequest{Method: "GET", Path: "/users"}}},
+		Changelog:    []models.ChangelogEntry{{Version: "1.0.0"}},
+	}
+
+	restored := rollbackConfig(current, target, "v1", now)
+	if len(restored.Expectations) != 1 {
+		t.Errorf("restored %d expectations, want the target's 1", len(restored.Expectations))
+	}
+	if restored.Metadata.DeployedVersion != "v3" || restored.Metadata.ContractVersion != "2.0.0" {
+		t.Errorf("deploy state = %s / %s, want the current v3 / 2.0.0", restored.Metadata.DeployedVersion, restored.Metadata.ContractVersion)
+	}
+	if len(restored.Changelog) != 2 || len(restored.Consumers) != 1 {
+		t.Errorf("history rewound: %d changelog entries, %d consumers", len(restored.Changelog), len(restored.Consumers))
+	}
+	if restored.Metadata.Description != "Rolled back to v1" || !restored.Metadata.UpdatedAt.Equal(now) {
+		t.Errorf("metadata = %+v", restored.Metadata)
+	}
+	if target.Metadata.DeployedVersion != "v0" {
+		t.Error("the stored target version was modified")
+	}
+}
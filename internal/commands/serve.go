@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/expectations"
+	"github.com/hemantobora/auto-mock/internal/localmock"
+	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/protofile"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// ServeOptions configures the local mock server
+type ServeOptions struct {
+	File   string // local MockServer expectations JSON; overrides the project store
+	Dir    string // directory of split expectation files ('download --split'); overrides the project store
+	Host   string
+	Port   int
+	Protos []string // proto files whose methods are also answered as native gRPC
+	Trace  bool     // log the closest expectations and why they missed for unmatched requests
+}
+
+// RunServe serves a project's expectations on a local port until interrupted
+func RunServe(profile, project string, opts ServeOptions) error {
+	cfg, err := serveConfig(profile, project, opts)
+	if err != nil {
+		return err
+	}
+	if opts.Port == 0 {
+		opts.Port = 1080
+	}
+
+	mock := localmock.New(cfg.Expectations, os.Stdout)
+	if opts.Trace {
+		mock.EnableTrace()
+	}
+	var handler http.Handler = mock
+	var grpcMethods []*protofile.Method
+	if len(opts.Protos) > 0 {
+		set, err := protofile.Load(opts.Protos...)
+		if err != nil {
+			return err
+		}
+		grpcMethods = set.Methods()
+		mock.EnableGRPC(set)
+		handler = h2c.NewHandler(mock, &http2.Server{}) // gRPC clients speak plaintext HTTP/2
+	}
+
+	addr := net.JoinHostPort(opts.Host, strconv.Itoa(opts.Port))
+	server := &http.Server{Addr: addr, Handler: handler}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdown)
+	}()
+
+	host := opts.Host
+	if host == "" {
+		host = "localhost"
+	}
+	fmt.Printf("🚀 Serving %d expectation(s) on http://%s\n", len(cfg.Expectations), net.JoinHostPort(host, strconv.Itoa(opts.Port)))
+	if len(grpcMethods) > 0 {
+		fmt.Printf("📡 Answering gRPC on the same port for %d method(s):\n", len(grpcMethods))
+		for _, m := range grpcMethods {
+			fmt.Printf("   • %s\n", m.Path())
+		}
+	}
+	fmt.Println("💡 MockServer control endpoints: PUT /mockserver/expectation, /mockserver/reset, /mockserver/retrieve")
+	fmt.Println("   Press Ctrl-C to stop")
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve on %s: %w", addr, err)
+	}
+	fmt.Println("\n👋 Local mock server stopped")
+	return nil
+}
+
+// serveConfig loads the configuration to serve and derives the responses MockServer would
+// serve from it, tenants included, so the local server answers like a deployed mock
+func serveConfig(profile, project string, opts ServeOptions) (*models.MockConfiguration, error) {
+	cfg, err := serveSource(profile, project, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Prepare(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// serveSource loads the expectations to serve from a split directory, a file or the project
+func serveSource(profile, project string, opts ServeOptions) (*models.MockConfiguration, error) {
+	if opts.Dir == "" {
+		return loadConfig(profile, project, opts.File)
+	}
+	if opts.File != "" {
+		return nil, fmt.Errorf("use either --file or --dir")
+	}
+	exps, err := expectations.LoadSplit(opts.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(exps) == 0 {
+		return nil, fmt.Errorf("no expectation files (*.json) in %s", opts.Dir)
+	}
+	return &models.MockConfiguration{Expectations: exps}, nil
+}
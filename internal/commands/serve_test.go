@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/expectations"
+	"github.com/hemantobora/auto-mock/internal/localmock"
+	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/projectarchive"
+)
+
+// A project served from its archive answers tenants as the deployed mock does
+func TestServeConfigTenants(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "plans.automock.tar.gz")
+	archive := &projectarchive.Archive{
+		Manifest: projectarchive.Manifest{Project: "plans"},
+		Current: &models.MockConfiguration{
+			Metadata: models.ConfigMetadata{ProjectID: "plans", Version: "v1"},
+			Expectations: []models.MockExpectation{{
+				ID:           "plan",
+				HttpRequest:  &models.HttpRequest{Method: "GET", Path: "/plan"},
+				HttpResponse: &models.HttpResponse{StatusCode: 200, Body: map[string]any{"plan": "free"}},
+			}},
+			Tenancy: &models.Tenancy{Header: "X-Tenant", Subdomain: true, Tenants: []models.Tenant{
+				{Name: "acme", Overlays: []models.TenantOverlay{{Expectation: "plan", Body: map[string]any{"plan": "enterprise"}}}},
+			}},
+		},
+	}
+	if err := archive.Write(file); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := serveConfig("", "", ServeOptions{File: file})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(localmock.New(cfg.Expectations, nil))
+	defer srv.Close()
+
+	for _, tc := range []struct {
+		header, host, want string
+	}{
+		{"", "", "free"},
+		{"acme", "", "enterprise"},
+		{"", "acme.localhost", "enterprise"},
+		{"beta", "", "free"},
+	} {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"/plan", nil)
+		if tc.header != "" {
+			req.Header.Set("X-Tenant", tc.header)
+		}
+		if tc.host != "" {
+			req.Host = tc.host
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), `"`+tc.want+`"`) {
+			t.Errorf("tenant %q host %q: %d %s, want plan %s", tc.header, tc.host, resp.StatusCode, body, tc.want)
+		}
+	}
+}
+
+func TestServeConfigDir(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := expectations.WriteSplit(dir, []models.MockExpectation{
+		{HttpRequest: &models.HttpRequest{Method: "GET", Path: "/users"}, HttpResponse: &models.HttpResponse{StatusCode: 200}},
+		{HttpRequest: &models.HttpRequest{Method: "POST", Path: "/users"}, HttpResponse: &models.HttpResponse{StatusCode: 201}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := serveConfig("", "", ServeOptions{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Expectations) != 2 {
+		t.Errorf("serving %d expectations, want 2", len(cfg.Expectations))
+	}
+
+	if _, err := serveConfig("", "", ServeOptions{Dir: t.TempDir()}); err == nil {
+		t.Error("an empty directory was served")
+	}
+	if _, err := serveConfig("", "", ServeOptions{Dir: dir, File: "expectations.json"}); err == nil {
+		t.Error("--file and --dir were accepted together")
+	}
+}
@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/mocklogs"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// latencyWindow is how many status round trips the latency percentiles cover
+const latencyWindow = 120
+
+// StatusWatchOptions configures `automock status --watch`
+type StatusWatchOptions struct {
+	Interval time.Duration // time between refreshes (default 5s)
+	URL      string        // mock base URL; looked up from the deployment when empty
+}
+
+// watchSample is one refresh of the dashboard
+type watchSample struct {
+	at      time.Time
+	up      bool
+	err     error // why the mock is down or its traffic unavailable
+	traffic *mocklogs.Traffic
+}
+
+// RunStatusWatch polls the deployed mock's status endpoint and request log, redrawing a live
+// dashboard of health, latency percentiles and per-endpoint traffic until Ctrl+C
+func RunStatusWatch(profile, project string, opts StatusWatchOptions) error {
+	if opts.Interval <= 0 {
+		opts.Interval = 5 * time.Second
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return err
+	}
+	cfg, err := manager.Provider.GetConfig(ctx, project)
+	if err != nil {
+		return exitcode.New(exitcode.Config, "failed to load expectations of project '%s': %w", project, err)
+	}
+	baseURL := opts.URL
+	var auth *models.EndpointAuth
+	if baseURL == "" {
+		meta, _ := manager.Provider.GetDeploymentMetadata()
+		if meta == nil || meta.DeploymentStatus != "deployed" || meta.Details == nil || meta.Details.MockServerURL == "" {
+			return exitcode.WithHint(exitcode.Config, fmt.Errorf("no deployed mock found for project '%s'", project),
+				fmt.Sprintf("Run 'automock deploy --project %s', or pass --url to watch a mock run elsewhere", project))
+		}
+		baseURL, auth = meta.Details.MockServerURL, meta.Details.Auth
+	}
+
+	client := mocklogs.NewClient(baseURL)
+	client.HTTP.Transport = auth.Transport()
+	client.HTTP.Timeout = max(opts.Interval, 10*time.Second)
+	interactive := isTerminal(os.Stdout)
+
+	var latencies []time.Duration
+	previous := -1
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+	for {
+		sample := watchSample{at: time.Now()}
+		if rtt, err := client.Status(ctx); err != nil {
+			sample.err = err
+		} else {
+			sample.up = true
+			latencies = append(latencies, rtt)
+			if len(latencies) > latencyWindow {
+				latencies = latencies[len(latencies)-latencyWindow:]
+			}
+			if received, err := client.Requests(ctx); err != nil {
+				sample.err = err
+			} else {
+				sample.traffic = mocklogs.Summarize(cfg.Expectations, received, 5)
+			}
+		}
+		if ctx.Err() != nil {
+			fmt.Println("\n👋 Stopped watching")
+			return nil
+		}
+
+		if interactive {
+			fmt.Print("\033[H\033[2J") // redraw in place
+		}
+		renderWatch(os.Stdout, project, baseURL, opts.Interval, sample, latencies, previous)
+		if sample.traffic != nil {
+			previous = sample.traffic.Total
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Println("\n👋 Stopped watching")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// renderWatch writes one dashboard frame; previous is the request total of the last frame (-1 = none)
+func renderWatch(w io.Writer, project, baseURL string, interval time.Duration, s watchSample, latencies []time.Duration, previous int) {
+	fmt.Fprintf(w, "🛰️  %s at %s\n", project, baseURL)
+	fmt.Fprintf(w, "   %s, refreshing every %s (Ctrl+C to stop)\n", s.at.Format("15:04:05"), interval)
+	fmt.Fprintln(w, strings.Repeat("━", 80))
+
+	if s.up {
+		fmt.Fprintln(w, "💚 Health: up")
+	} else {
+		fmt.Fprintf(w, "💔 Health: down (%v)\n", s.err)
+	}
+	if len(latencies) > 0 {
+		fmt.Fprintf(w, "⏱️  Latency (last %d status checks): p50 %s, p95 %s, p99 %s\n", len(latencies),
+			roundMs(mocklogs.Percentile(latencies, 50)), roundMs(mocklogs.Percentile(latencies, 95)), roundMs(mocklogs.Percentile(latencies, 99)))
+	}
+	if !s.up {
+		return
+	}
+	if s.traffic == nil {
+		fmt.Fprintf(w, "⚠️  Traffic unavailable: %v\n", s.err)
+		return
+	}
+
+	unmatched := s.traffic.Total - s.traffic.Matched
+	line := fmt.Sprintf("❓ Unmatched: %d", unmatched)
+	if previous >= 0 && s.traffic.Total >= previous {
+		line += fmt.Sprintf(", %d new request(s) since the last refresh", s.traffic.Total-previous)
+	}
+	fmt.Fprintln(w, line)
+	fmt.Fprintln(w)
+	fmt.Fprint(w, s.traffic.Format())
+}
+
+// roundMs rounds a latency for display
+func roundMs(d time.Duration) time.Duration {
+	if d >= time.Millisecond {
+		return d.Round(time.Millisecond)
+	}
+	return d.Round(10 * time.Microsecond)
+}
+
+// isTerminal reports whether f is an interactive terminal rather than a pipe or file
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
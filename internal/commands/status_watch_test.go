@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/mocklogs"
+)
+
+func TestRenderWatch(t *testing.T) {
+	latencies := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 90 * time.Millisecond}
+	traffic := &mocklogs.Traffic{Total: 12, Matched: 9, Endpoints: []mocklogs.EndpointStats{{Endpoint: "GET /users", Hits: 9}}}
+
+	var b bytes.Buffer
+	renderWatch(&b, "users", "http://mock", 5*time.Second, watchSample{at: time.Now(), up: true, traffic: traffic}, latencies, 4)
+	for _, want := range []string{"Health: up", "p50 20ms, p95 90ms, p99 90ms", "Unmatched: 3, 8 new request(s)", "GET /users"} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("frame lacks %q:\n%s", want, b.String())
+		}
+	}
+
+	b.Reset()
+	renderWatch(&b, "users", "http://mock", 5*time.Second, watchSample{at: time.Now(), err: errors.New("connection refused")}, latencies, 12)
+	if out := b.String(); !strings.Contains(out, "Health: down (connection refused)") || strings.Contains(out, "Unmatched") {
+		t.Errorf("down frame:\n%s", out)
+	}
+}
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/checks"
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// RunTest verifies a mock against the checks captured from collection test scripts.
+// baseURL overrides the project's deployed endpoint, e.g. for a local mock.
+func RunTest(profile, project, baseURL string) error {
+	if project == "" {
+		return exitcode.New(exitcode.Config, "--project is required")
+	}
+	ctx := context.Background()
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return err
+	}
+	cfg, err := manager.Provider.GetConfig(ctx, project)
+	if err != nil {
+		return fmt.Errorf("failed to load project %s: %w", project, err)
+	}
+	var auth *models.EndpointAuth
+	if baseURL == "" {
+		if baseURL, auth, err = deployedMock(ctx, profile, project); err != nil {
+			return err
+		}
+	}
+
+	report := checks.Run(ctx, &http.Client{Timeout: 30 * time.Second, Transport: auth.Transport()}, baseURL, cfg.Expectations)
+	if len(report.Outcomes) == 0 && len(report.Skipped) == 0 {
+		fmt.Printf("ℹ️  Project %s has no captured checks.\n", project)
+		fmt.Println("💡 Checks come from pm.test assertions when a collection is imported with 'automock init --collection-file'.")
+		return nil
+	}
+
+	fmt.Printf("🧪 Verifying %s against %s\n\n", project, baseURL)
+	if failed := printCheckReport(report); failed > 0 {
+		return exitcode.New(exitcode.Validation, "%d check(s) failed", failed)
+	}
+	return nil
+}
+
+// printCheckReport prints each check outcome and the totals, and returns the number failed
+func printCheckReport(report *checks.Report) int {
+	for _, o := range report.Outcomes {
+		label := o.Check.Name
+		if label == "" {
+			label = checks.Describe(o.Check)
+		}
+		if o.Err != nil {
+			fmt.Printf("❌ %s — %s: %v\n", o.Endpoint, label, o.Err)
+		} else {
+			fmt.Printf("✅ %s — %s\n", o.Endpoint, label)
+		}
+	}
+	for _, s := range report.Skipped {
+		fmt.Printf("⏭️  Skipped %s\n", s)
+	}
+
+	failed := report.Failed()
+	fmt.Printf("\n%d passed, %d failed\n", len(report.Outcomes)-failed, failed)
+	return failed
+}
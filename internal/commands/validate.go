@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// ValidateOptions selects the expectations to check
+type ValidateOptions struct {
+	File      string // local MockServer expectations JSON; overrides the project store
+	Strict    bool   // fail on warnings too
+	Conflicts bool   // also report overlapping matchers where the more specific one loses
+	Fix       bool   // save the priorities resolving the conflicts to the project; implies Conflicts
+}
+
+// RunValidate checks a project's expectations and prints every problem found
+func RunValidate(profile, project string, opts ValidateOptions) error {
+	var expectations []models.MockExpectation
+	var err error
+	if opts.Fix {
+		if opts.File != "" || project == "" {
+			return exitcode.New(exitcode.Config, "--fix saves the resolved priorities to a project; pass --project instead of --file")
+		}
+		opts.Conflicts = true
+		expectations, err = fixPriorities(profile, project)
+	} else {
+		expectations, err = loadExpectations(profile, project, opts.File)
+	}
+	if err != nil {
+		return err
+	}
+	report := models.Validate(expectations)
+	fmt.Printf("🔍 Checked %d expectation(s)\n", len(expectations))
+	fmt.Print(report.Format())
+
+	unresolved := 0
+	var conflicts []models.Conflict
+	if opts.Conflicts {
+		conflicts = models.FindConflicts(expectations)
+		fmt.Print(models.FormatConflicts(expectations, conflicts))
+		for _, c := range conflicts {
+			if c.Kind == models.ConflictShadowed {
+				unresolved++
+			}
+		}
+		if len(conflicts) > 0 && !opts.Fix {
+			fmt.Println("💡 Re-run with --fix to save the proposed priorities to the project")
+		}
+	}
+
+	if errs := report.Errors(); len(errs) > 0 {
+		return exitcode.New(exitcode.Validation, "%d expectation problem(s) found", len(errs))
+	}
+	if unresolved > 0 {
+		return exitcode.New(exitcode.Validation, "%d expectation(s) never served", unresolved)
+	}
+	if warnings := report.Warnings(); opts.Strict && len(warnings)+len(conflicts) > 0 {
+		return exitcode.New(exitcode.Validation, "%d warning(s) found (--strict)", len(warnings)+len(conflicts))
+	}
+	return nil
+}
+
+// fixPriorities assigns the priorities that let the more specific expectation win every
+// conflict and saves them, returning the saved expectations
+func fixPriorities(profile, project string) ([]models.MockExpectation, error) {
+	ctx := context.Background()
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return nil, err
+	}
+	config, err := manager.Provider.GetConfig(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project %s: %w", project, err)
+	}
+	changes := models.ResolvePriorities(config.Expectations, false)
+	if len(changes) == 0 {
+		fmt.Println("ℹ️  No priority changes needed.")
+		return config.Expectations, nil
+	}
+	fmt.Printf("⚖️  Resolving %d conflict(s):\n", len(changes))
+	for _, c := range changes {
+		exp := config.Expectations[c.Index]
+		fmt.Printf("   • [%d] %s %s: priority %d → %d\n", c.Index, exp.HttpRequest.Method, exp.HttpRequest.Path, c.From, c.To)
+	}
+	if err := manager.Provider.UpdateConfig(ctx, config); err != nil {
+		return nil, fmt.Errorf("failed to save the resolved priorities: %w", err)
+	}
+	fmt.Printf("✅ Saved project %s (version %s)\n", project, config.Metadata.Version)
+	return config.Expectations, nil
+}
@@ -0,0 +1,231 @@
+package commands
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/cloud"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/network"
+	"github.com/hemantobora/auto-mock/internal/recorder"
+)
+
+// VerifyOptions configures automock verify
+type VerifyOptions struct {
+	BaseURL   string              // real environment the requests are replayed against
+	Headers   []string            // "Name: value" headers added to each request, e.g. credentials
+	SafeOnly  bool                // replay only GET, HEAD and OPTIONS requests
+	JUnit     string              // write a JUnit XML report to this file
+	Selection models.TagSelection // expectations to verify (empty = all)
+}
+
+// verifyResult is the outcome of replaying one expectation against the real API
+type verifyResult struct {
+	Endpoint   string
+	Mismatches []string      // contract violations; empty when the response conforms
+	Err        error         // the request could not be made
+	Skipped    string        // why the expectation was not replayed
+	Duration   time.Duration // time taken by the request
+}
+
+// RunVerify replays each expectation's example request against a real environment and reports
+// where the responses break the contract the mock promises (status, headers, body shape), so
+// the stored expectations double as a contract test suite
+func RunVerify(profile, project string, opts VerifyOptions) error {
+	if project == "" {
+		return exitcode.New(exitcode.Config, "--project is required")
+	}
+	if !strings.HasPrefix(opts.BaseURL, "http://") && !strings.HasPrefix(opts.BaseURL, "https://") {
+		return exitcode.New(exitcode.Config, "--base-url must be an http(s) URL, e.g. https://staging.api.example.com")
+	}
+	header, err := parseHeaderFlags(opts.Headers)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	manager := cloud.NewCloudManager(profile)
+	if err := manager.AutoDetectProvider(profile); err != nil {
+		return err
+	}
+	config, err := manager.Provider.GetConfig(ctx, project)
+	if err != nil {
+		return fmt.Errorf("failed to load project %s: %w", project, err)
+	}
+
+	fmt.Printf("🔎 Verifying %s against %s\n\n", project, opts.BaseURL)
+	results := verifyExpectations(ctx, network.HTTPClient(), config.Expectations, header, opts)
+	passed, failed, _ := printVerifyResults(results)
+	if opts.JUnit != "" {
+		if err := writeJUnit(opts.JUnit, project, opts.BaseURL, results); err != nil {
+			return err
+		}
+		fmt.Printf("📄 JUnit report: %s\n", opts.JUnit)
+	}
+	if passed+failed == 0 {
+		fmt.Printf("ℹ️  No expectation of %s was replayed.\n", project)
+		return nil
+	}
+	if failed > 0 {
+		return exitcode.New(exitcode.Validation, "%d of %d expectation(s) do not match %s", failed, passed+failed, opts.BaseURL)
+	}
+	return nil
+}
+
+// verifyExpectations replays the expectations the options select, in order
+func verifyExpectations(ctx context.Context, client *http.Client, exps []models.MockExpectation, header http.Header, opts VerifyOptions) []verifyResult {
+	var results []verifyResult
+	for i := range exps {
+		exp := &exps[i]
+		if exp.HttpRequest == nil || !opts.Selection.Matches(exp) {
+			continue
+		}
+		result := verifyResult{Endpoint: exp.HttpRequest.Method + " " + exp.HttpRequest.Path}
+		if reason := verifySkipReason(exp, opts.SafeOnly); reason != "" {
+			result.Skipped = reason
+			results = append(results, result)
+			continue
+		}
+		start := time.Now()
+		actual, err := recorder.ReplayAt(ctx, client, opts.BaseURL, exp, header)
+		result.Duration = time.Since(start)
+		if err != nil {
+			result.Err = err
+		} else {
+			result.Mismatches = models.VerifyResponse(exp.HttpResponse, actual)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// verifySkipReason explains why an expectation is not replayed, or returns ""
+func verifySkipReason(exp *models.MockExpectation, safeOnly bool) string {
+	switch {
+	case exp.HttpRequest.Path == models.ContractInfoPath:
+		return "automock contract info endpoint"
+	case exp.Generated():
+		return "generated companion"
+	case exp.HttpResponse == nil:
+		return "forwarded, no mocked response"
+	case exp.HttpResponseTemplate != nil:
+		return "templated response"
+	case exp.Stream != nil:
+		return "streaming response"
+	case safeOnly && !isSafeMethod(exp.HttpRequest.Method):
+		return exp.HttpRequest.Method + " skipped by --safe-only"
+	}
+	return ""
+}
+
+func isSafeMethod(method string) bool {
+	switch strings.ToUpper(method) {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// printVerifyResults prints each result and the totals
+func printVerifyResults(results []verifyResult) (passed, failed, skipped int) {
+	for _, r := range results {
+		switch {
+		case r.Skipped != "":
+			fmt.Printf("⏭️  %s — skipped: %s\n", r.Endpoint, r.Skipped)
+			skipped++
+		case r.Err != nil:
+			fmt.Printf("❌ %s — request failed: %v\n", r.Endpoint, r.Err)
+			failed++
+		case len(r.Mismatches) > 0:
+			fmt.Printf("❌ %s\n", r.Endpoint)
+			for _, m := range r.Mismatches {
+				fmt.Printf("   • %s\n", m)
+			}
+			failed++
+		default:
+			fmt.Printf("✅ %s\n", r.Endpoint)
+			passed++
+		}
+	}
+	fmt.Printf("\n%d passed, %d failed, %d skipped\n", passed, failed, skipped)
+	return passed, failed, skipped
+}
+
+// JUnit XML, as read by Jenkins, GitLab, GitHub Actions reporters and most CI systems
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Errors   int         `xml:"errors,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// junitReport converts verification results into one JUnit test suite
+func junitReport(project, baseURL string, results []verifyResult) junitSuites {
+	suite := junitSuite{Name: fmt.Sprintf("automock verify %s (%s)", project, baseURL)}
+	var total time.Duration
+	for _, r := range results {
+		c := junitCase{Name: r.Endpoint, ClassName: "automock." + project, Time: seconds(r.Duration)}
+		switch {
+		case r.Skipped != "":
+			c.Skipped = &junitMessage{Message: r.Skipped}
+			suite.Skipped++
+		case r.Err != nil:
+			c.Error = &junitMessage{Message: "request failed", Text: r.Err.Error()}
+			suite.Errors++
+		case len(r.Mismatches) > 0:
+			c.Failure = &junitMessage{
+				Message: fmt.Sprintf("%d contract mismatch(es)", len(r.Mismatches)),
+				Text:    strings.Join(r.Mismatches, "\n"),
+			}
+			suite.Failures++
+		}
+		total += r.Duration
+		suite.Cases = append(suite.Cases, c)
+	}
+	suite.Tests = len(suite.Cases)
+	suite.Time = seconds(total)
+	return junitSuites{Suites: []junitSuite{suite}}
+}
+
+// writeJUnit writes the results as a JUnit XML file
+func writeJUnit(path, project, baseURL string, results []verifyResult) error {
+	data, err := xml.MarshalIndent(junitReport(project, baseURL, results), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append([]byte(xml.Header), append(data, '\n')...), 0644); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	return nil
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
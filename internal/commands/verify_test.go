@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+func TestVerifyExpectations(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer t" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		switch r.URL.Path {
+		case "/users/1":
+			w.Write([]byte(`{"id": "1", "name": "Jane", "extra": true}`))
+		case "/orders":
+			w.Write([]byte(`{"items": "none"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer api.Close()
+
+	jsonResponse := func(body map[string]any) *models.HttpResponse {
+		return &models.HttpResponse{
+			StatusCode: 200,
+			Headers:    []models.NameValues{{Name: "Content-Type", Values: []string{"application/json"}}},
+			Body:       map[string]any{"type": "JSON", "json": body},
+		}
+	}
+	exps := []models.MockExpectation{
+		{HttpRequest: &models.HttpRequest{Method: "GET", Path: "/users/1"}, HttpResponse: jsonResponse(map[string]any{"id": "7", "name": "Bob"})},
+		{HttpRequest: &models.HttpRequest{Method: "GET", Path: "/orders"}, HttpResponse: jsonResponse(map[string]any{"items": []any{}})},
+		{HttpRequest: &models.HttpRequest{Method: "DELETE", Path: "/users/1"}, HttpResponse: &models.HttpResponse{StatusCode: 204}},
+	}
+	opts := VerifyOptions{BaseURL: api.URL, SafeOnly: true}
+	results := verifyExpectations(context.Background(), api.Client(), exps, http.Header{"Authorization": {"Bearer t"}}, opts)
+	if len(results) != 3 {
+		t.Fatalf("got %d results", len(results))
+	}
+	if r := results[0]; r.Err != nil || len(r.Mismatches) != 0 {
+		t.Errorf("conforming endpoint: err %v, mismatches %v", r.Err, r.Mismatches)
+	}
+	if r := results[1]; len(r.Mismatches) != 1 || !strings.Contains(r.Mismatches[0], "$.items changed type array → string") {
+		t.Errorf("shape mismatch not reported: %v", r.Mismatches)
+	}
+	if r := results[2]; r.Skipped == "" {
+		t.Error("DELETE was replayed with --safe-only")
+	}
+
+	data, err := xml.Marshal(junitReport("users", api.URL, results))
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := string(data)
+	for _, want := range []string{`tests="3"`, `failures="1"`, `skipped="1"`, `<testcase name="GET /orders"`, "<failure message=\"1 contract mismatch(es)\">"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("JUnit report missing %s:\n%s", want, report)
+		}
+	}
+}
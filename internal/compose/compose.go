@@ -0,0 +1,102 @@
+// Package compose renders a Docker Compose bundle running a project's mocks on a teammate's
+// machine: MockServer plus a one-shot initializer container that waits for it and loads the
+// bundled expectations, so 'docker compose up' serves the same mocks as a deployment without
+// cloud access or automock installed.
+package compose
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hemantobora/auto-mock/internal/kubernetes"
+)
+
+// DefaultImage is the MockServer image of the bundle; it matches the cloud and cluster deployments
+const DefaultImage = kubernetes.DefaultImage
+
+// InitImage runs the initializer; it only needs a shell and curl
+const InitImage = "curlimages/curl:8.10.1"
+
+// ComposeFile and ExpectationsFile are the files of a bundle
+const (
+	ComposeFile      = "docker-compose.yaml"
+	ExpectationsFile = "expectations.json"
+)
+
+// Options shapes the rendered bundle
+type Options struct {
+	Image string // MockServer image (empty = DefaultImage)
+	Port  int    // host port published unless AUTOMOCK_PORT overrides it (0 = 1080)
+}
+
+// Render returns the docker-compose.yaml of a project's bundle. The initializer retries until
+// MockServer answers, loads the expectations and exits; compose restarts it on failure.
+func Render(project string, opts Options) []byte {
+	image := opts.Image
+	if image == "" {
+		image = DefaultImage
+	}
+	port := opts.Port
+	if port == 0 {
+		port = 1080
+	}
+	// $$ escapes $ from compose interpolation so the initializer's shell sees it
+	return []byte(fmt.Sprintf(`# MockServer with the %[1]s mocks, generated by 'automock docker --project %[1]s'.
+# Start it with 'docker compose up -d'; the mocks answer on http://localhost:%[2]d
+# (set AUTOMOCK_PORT to publish another port). Regenerate to pick up changed expectations.
+name: automock-%[1]s
+
+services:
+  mockserver:
+    image: %[3]s
+    ports:
+      - "${AUTOMOCK_PORT:-%[2]d}:1080"
+    environment:
+      MOCKSERVER_LOG_LEVEL: INFO
+
+  # Loads %[4]s into MockServer once it is up, then exits
+  load-expectations:
+    image: %[5]s
+    depends_on:
+      - mockserver
+    volumes:
+      - ./%[4]s:/automock/%[4]s:ro
+    entrypoint: ["/bin/sh", "-c"]
+    command:
+      - |
+        tries=0
+        until curl -sf -X PUT http://mockserver:1080/mockserver/status > /dev/null; do
+          tries=$$((tries + 1))
+          if [ $$tries -ge 60 ]; then echo "MockServer did not start" >&2; exit 1; fi
+          sleep 1
+        done
+        curl -sf -X PUT http://mockserver:1080/mockserver/expectation --data-binary @/automock/%[4]s > /dev/null
+        echo "Loaded the %[1]s expectations"
+    restart: on-failure
+`, project, port, image, ExpectationsFile, InitImage))
+}
+
+// WriteBundle writes the compose file and expectations (MockServer JSON) into dir, creating
+// it, and returns the paths written
+func WriteBundle(dir, project, expectations string, opts Options) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{ComposeFile, Render(project, opts)},
+		{ExpectationsFile, []byte(expectations)},
+	}
+	var written []string
+	for _, f := range files {
+		path := filepath.Join(dir, f.name)
+		if err := os.WriteFile(path, f.data, 0o644); err != nil {
+			return written, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
@@ -0,0 +1,57 @@
+package compose
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestRender(t *testing.T) {
+	var file struct {
+		Name     string `yaml:"name"`
+		Services map[string]struct {
+			Image   string   `yaml:"image"`
+			Ports   []string `yaml:"ports"`
+			Volumes []string `yaml:"volumes"`
+			Command []string `yaml:"command"`
+		} `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(Render("users", Options{Port: 8080}), &file); err != nil {
+		t.Fatalf("compose file does not parse: %v", err)
+	}
+	if file.Name != "automock-users" {
+		t.Errorf("name = %q", file.Name)
+	}
+	server, init := file.Services["mockserver"], file.Services["load-expectations"]
+	if server.Image != DefaultImage || len(server.Ports) != 1 || server.Ports[0] != "${AUTOMOCK_PORT:-8080}:1080" {
+		t.Errorf("mockserver service = %+v", server)
+	}
+	if init.Image != InitImage || len(init.Volumes) != 1 || !strings.HasPrefix(init.Volumes[0], "./expectations.json:") {
+		t.Errorf("initializer service = %+v", init)
+	}
+	// Shell variables must be escaped from compose interpolation
+	if len(init.Command) != 1 || !strings.Contains(init.Command[0], "$$tries") || !strings.Contains(init.Command[0], "/mockserver/expectation") {
+		t.Errorf("initializer command = %q", init.Command)
+	}
+}
+
+func TestWriteBundle(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "automock-users")
+	written, err := WriteBundle(dir, "users", `[{"httpRequest":{"path":"/users"}}]`, Options{Image: "registry.local/mockserver:5.15.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(written) != 2 {
+		t.Fatalf("written = %v", written)
+	}
+	compose, _ := os.ReadFile(filepath.Join(dir, ComposeFile))
+	if !strings.Contains(string(compose), "image: registry.local/mockserver:5.15.0") {
+		t.Errorf("custom image not used:\n%s", compose)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, ExpectationsFile)); err != nil || !strings.Contains(string(data), "/users") {
+		t.Errorf("expectations = %s, %v", data, err)
+	}
+}
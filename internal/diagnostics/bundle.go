@@ -0,0 +1,198 @@
+// Package diagnostics builds redacted diagnostic bundles for crash reports and support tickets
+package diagnostics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// maxLogBytes bounds how much of a log file is copied into a bundle
+const maxLogBytes = 64 * 1024
+
+// Bundle is the content of a diagnostic file
+type Bundle struct {
+	GeneratedAt time.Time         `json:"generated_at"`
+	Version     string            `json:"version"`
+	GoVersion   string            `json:"go_version"`
+	Platform    string            `json:"platform"`
+	Command     []string          `json:"command"`
+	Error       string            `json:"error,omitempty"`
+	Stack       string            `json:"stack,omitempty"`
+	Environment map[string]string `json:"environment,omitempty"`
+	Tools       map[string]string `json:"tools,omitempty"`
+	Logs        map[string]string `json:"logs,omitempty"`
+	Store       *StoreManifest    `json:"store,omitempty"`
+}
+
+// StoreManifest summarizes the project's state store without copying expectations
+type StoreManifest struct {
+	Provider     string               `json:"provider"`
+	Region       string               `json:"region,omitempty"`
+	Storage      string               `json:"storage,omitempty"`
+	Project      string               `json:"project"`
+	Expectations int                  `json:"expectations"`
+	Version      string               `json:"version,omitempty"`
+	Versions     []models.VersionInfo `json:"versions,omitempty"`
+	Deployed     bool                 `json:"deployed"`
+	Error        string               `json:"error,omitempty"`
+}
+
+// envPrefixes selects environment variables worth reporting (values are redacted)
+var envPrefixes = []string{"AUTOMOCK_", "AWS_", "TF_", "ANTHROPIC_", "OPENAI_"}
+
+var (
+	secretAssignment = regexp.MustCompile(`(?i)((?:secret|token|password|passwd|api[_-]?key|authorization|credential)[^\s=:"]*["']?\s*[=:]\s*["']?)([^\s"',]+)`)
+	bearerToken      = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/=-]+`)
+	awsAccessKey     = regexp.MustCompile(`\b(AKIA|ASIA)[A-Z0-9]{16}\b`)
+	apiKeyLiteral    = regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{16,}\b`)
+)
+
+// Redact masks credentials, tokens and keys in free text
+func Redact(s string) string {
+	s = bearerToken.ReplaceAllString(s, "${1}[REDACTED]")
+	s = secretAssignment.ReplaceAllString(s, "${1}[REDACTED]")
+	s = awsAccessKey.ReplaceAllString(s, "[REDACTED-AWS-KEY]")
+	return apiKeyLiteral.ReplaceAllString(s, "[REDACTED-API-KEY]")
+}
+
+// New collects everything that does not need cloud access. cause and stack may be empty.
+func New(version string, cause error, stack string) *Bundle {
+	b := &Bundle{
+		GeneratedAt: time.Now().UTC(),
+		Version:     version,
+		GoVersion:   runtime.Version(),
+		Platform:    runtime.GOOS + "/" + runtime.GOARCH,
+		Stack:       stack,
+		Environment: map[string]string{},
+		Tools:       map[string]string{},
+		Logs:        map[string]string{},
+	}
+	for _, arg := range os.Args {
+		b.Command = append(b.Command, Redact(arg))
+	}
+	if cause != nil {
+		b.Error = Redact(cause.Error())
+	}
+
+	for _, kv := range os.Environ() {
+		name, value, _ := strings.Cut(kv, "=")
+		for _, p := range envPrefixes {
+			if strings.HasPrefix(name, p) {
+				b.Environment[name] = redactEnvValue(name, value)
+				break
+			}
+		}
+	}
+
+	b.Tools["terraform"] = toolVersion("terraform", "version")
+	b.Tools["docker"] = toolVersion("docker", "--version")
+
+	if path := os.Getenv("TF_LOG_PATH"); path != "" {
+		if tail, err := tailFile(path, maxLogBytes); err == nil {
+			b.Logs[filepath.Base(path)] = Redact(tail)
+		}
+	}
+	return b
+}
+
+// AddStore records a manifest of the project's store; failures are noted in the bundle
+func (b *Bundle) AddStore(ctx context.Context, provider internal.Provider, project string) {
+	m := &StoreManifest{
+		Provider: provider.GetProviderType(),
+		Region:   provider.GetRegion(),
+		Storage:  provider.GetStorageName(),
+		Project:  project,
+	}
+	b.Store = m
+
+	if cfg, err := provider.GetConfig(ctx, project); err != nil {
+		m.Error = Redact(err.Error())
+	} else {
+		m.Expectations = len(cfg.Expectations)
+		m.Version = cfg.Metadata.Version
+	}
+	if versions, err := provider.ListVersions(ctx, project); err == nil {
+		m.Versions = versions
+	}
+	m.Deployed, _ = provider.IsDeployed()
+}
+
+// Write stores the bundle as JSON in dir and returns the file path
+func (b *Bundle) Write(dir string) (string, error) {
+	if dir == "" {
+		dir = DefaultDir()
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("create diagnostics directory: %w", err)
+	}
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("automock-diagnostic-%s.json", b.GeneratedAt.Format("20060102-150405")))
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", fmt.Errorf("write diagnostic bundle: %w", err)
+	}
+	return path, nil
+}
+
+// DefaultDir is where crash bundles are written when no directory is given
+func DefaultDir() string {
+	return filepath.Join(os.TempDir(), "automock-diagnostics")
+}
+
+// redactEnvValue keeps harmless settings readable and hides anything secret-looking
+func redactEnvValue(name, value string) string {
+	upper := strings.ToUpper(name)
+	for _, marker := range []string{"SECRET", "TOKEN", "PASSWORD", "KEY", "SESSION", "CREDENTIAL"} {
+		if strings.Contains(upper, marker) {
+			if value == "" {
+				return ""
+			}
+			return "[REDACTED]"
+		}
+	}
+	return Redact(value)
+}
+
+func toolVersion(name string, args ...string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, name, args...).Output()
+	if err != nil {
+		return "not available"
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+	return line
+}
+
+func tailFile(path string, max int64) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	if info.Size() > max {
+		if _, err := f.Seek(info.Size()-max, 0); err != nil {
+			return "", err
+		}
+	}
+	data, err := io.ReadAll(io.LimitReader(f, max))
+	return string(data), err
+}
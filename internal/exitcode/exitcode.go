@@ -0,0 +1,182 @@
+// Package exitcode classifies command failures into stable exit codes and a machine-readable
+// error document, so wrapper scripts can branch on the kind of failure instead of parsing text.
+package exitcode
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/network"
+)
+
+// Kind is the category of a failure. Kinds and their exit codes are stable; new kinds get
+// new codes.
+type Kind string
+
+const (
+	Internal   Kind = "internal"   // Unexpected failure (a bug, or an unclassified error)
+	Config     Kind = "config"     // Invalid flags, configuration or input files; unknown project
+	Auth       Kind = "auth"       // Missing, expired or insufficient cloud credentials
+	Validation Kind = "validation" // Expectations, contract checks or a pre-deploy gate failed
+	Deploy     Kind = "deploy"     // Infrastructure provisioning or teardown failed
+	Cancelled  Kind = "cancelled"  // The user declined a confirmation or interrupted a prompt
+	Network    Kind = "network"    // A store, cloud or AI provider did not answer in time, or --offline is on
+)
+
+// Exit codes. 2 is reserved for crashes (panics).
+const (
+	CodeOK         = 0
+	CodeInternal   = 1
+	CodeCrash      = 2
+	CodeConfig     = 3
+	CodeAuth       = 4
+	CodeValidation = 5
+	CodeDeploy     = 6
+	CodeCancelled  = 7
+	CodeNetwork    = 8
+)
+
+var codes = map[Kind]int{
+	Internal:   CodeInternal,
+	Config:     CodeConfig,
+	Auth:       CodeAuth,
+	Validation: CodeValidation,
+	Deploy:     CodeDeploy,
+	Cancelled:  CodeCancelled,
+	Network:    CodeNetwork,
+}
+
+// Code is the process exit code of a kind; the empty kind (no error) is 0
+func (k Kind) Code() int {
+	if k == "" {
+		return CodeOK
+	}
+	if code, ok := codes[k]; ok {
+		return code
+	}
+	return CodeInternal
+}
+
+// Error attaches a kind (and optionally a hint) to an error
+type Error struct {
+	Kind Kind
+	Err  error
+	Hint string // What to do about it, e.g. "run 'automock init' first"
+}
+
+func (e *Error) Error() string { return e.Err.Error() }
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// Wrap classifies err as kind; nil stays nil. An error that already has a kind keeps it.
+func Wrap(kind Kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	var classified *Error
+	if errors.As(err, &classified) {
+		return err
+	}
+	return &Error{Kind: kind, Err: err}
+}
+
+// New returns a classified error with a formatted message
+func New(kind Kind, format string, args ...any) error {
+	return &Error{Kind: kind, Err: fmt.Errorf(format, args...)}
+}
+
+// WithHint returns a classified error with a hint on how to resolve it
+func WithHint(kind Kind, err error, hint string) error {
+	return &Error{Kind: kind, Err: err, Hint: hint}
+}
+
+// authCodes are cloud API error codes that mean the credentials are the problem
+var authCodes = map[string]bool{
+	"AccessDenied": true, "AccessDeniedException": true, "ExpiredToken": true, "ExpiredTokenException": true,
+	"InvalidClientTokenId": true, "InvalidAccessKeyId": true, "SignatureDoesNotMatch": true,
+	"UnrecognizedClientException": true, "AuthorizationFailed": true, "AuthenticationFailed": true,
+	"AuthorizationPermissionMismatch": true, "InvalidAuthenticationInfo": true,
+}
+
+// Classify returns the kind of err: the kind it was wrapped with, else one inferred from
+// well-known error types, else Internal. A nil error has no kind ("").
+func Classify(err error) Kind {
+	if err == nil {
+		return ""
+	}
+	var classified *Error
+	if errors.As(err, &classified) {
+		return classified.Kind
+	}
+	var validation models.ValidationError
+	var parsing *models.CollectionParsingError
+	var apiErr interface{ ErrorCode() string }
+	switch {
+	case errors.Is(err, terminal.InterruptErr):
+		return Cancelled
+	case errors.Is(err, ask.ErrNoAnswer), errors.As(err, &parsing):
+		return Config
+	case errors.As(err, &validation):
+		return Validation
+	case errors.As(err, &apiErr) && authCodes[apiErr.ErrorCode()]:
+		return Auth
+	case errors.Is(err, network.ErrOffline), network.IsNetworkError(err):
+		return Network
+	}
+	return Internal
+}
+
+// Report is the machine-readable error document written to stderr with --error-format json
+type Report struct {
+	Error ReportError `json:"error"`
+}
+
+// ReportError describes the failure
+type ReportError struct {
+	Kind     Kind   `json:"kind"`
+	ExitCode int    `json:"exitCode"`
+	Message  string `json:"message"`
+	Hint     string `json:"hint,omitempty"`
+	Command  string `json:"command,omitempty"`
+
+	Diagnostics string `json:"diagnostics,omitempty"` // Diagnostic bundle written for internal and deploy failures
+}
+
+// NewReport builds the error document for err, raised by command
+func NewReport(err error, command string) Report {
+	kind := Classify(err)
+	r := ReportError{Kind: kind, ExitCode: kind.Code(), Message: plain(err.Error()), Command: command}
+	var classified *Error
+	if errors.As(err, &classified) {
+		r.Hint = plain(classified.Hint)
+	}
+	return Report{Error: r}
+}
+
+// WriteJSON writes the report as one JSON line
+func (r Report) WriteJSON(w io.Writer) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// plain drops the decoration (emoji and other pictographs) from a human-oriented message
+func plain(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.So, r) || unicode.Is(unicode.Variation_Selector, r) {
+			return -1
+		}
+		return r
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
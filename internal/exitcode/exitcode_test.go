@@ -0,0 +1,107 @@
+package exitcode
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/network"
+)
+
+type apiError struct{ code string }
+
+func (e apiError) Error() string     { return "api error: " + e.code }
+func (e apiError) ErrorCode() string { return e.code }
+
+func TestClassify(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want Kind
+	}{
+		{"nil", nil, ""},
+		{"plain", errors.New("boom"), Internal},
+		{"wrapped kind", fmt.Errorf("deploy: %w", New(Deploy, "terraform apply failed")), Deploy},
+		{"interrupt", fmt.Errorf("prompt: %w", terminal.InterruptErr), Cancelled},
+		{"no answer", fmt.Errorf("region: %w", ask.ErrNoAnswer), Config},
+		{"collection", &models.CollectionParsingError{CollectionType: "postman", Cause: errors.New("bad json")}, Config},
+		{"validation", models.ValidationError{Field: "expectations[0]", Message: "missing path"}, Validation},
+		{"expired token", fmt.Errorf("list buckets: %w", apiError{"ExpiredToken"}), Auth},
+		{"other api error", apiError{"Throttling"}, Internal},
+		{"offline", fmt.Errorf("the project store needs the network: %w", network.ErrOffline), Network},
+		{"unreachable", fmt.Errorf("list buckets: %w", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}), Network},
+		{"deadline", fmt.Errorf("generate: %w", context.DeadlineExceeded), Network},
+	}
+	for _, tc := range cases {
+		if got := Classify(tc.err); got != tc.want {
+			t.Errorf("%s: Classify = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestWrapKeepsExistingKind(t *testing.T) {
+	if Wrap(Deploy, nil) != nil {
+		t.Fatal("Wrap(nil) should stay nil")
+	}
+	err := Wrap(Deploy, fmt.Errorf("aborted: %w", New(Validation, "hook failed")))
+	if got := Classify(err); got != Validation {
+		t.Errorf("Classify = %q, want %q", got, Validation)
+	}
+	if got := Classify(Wrap(Deploy, errors.New("apply failed"))); got != Deploy {
+		t.Errorf("Classify = %q, want %q", got, Deploy)
+	}
+}
+
+func TestKindCodes(t *testing.T) {
+	want := map[Kind]int{"": 0, Internal: 1, Config: 3, Auth: 4, Validation: 5, Deploy: 6, Cancelled: 7, "unknown": 1}
+	for kind, code := range want {
+		if got := kind.Code(); got != code {
+			t.Errorf("%q.Code() = %d, want %d", kind, got, code)
+		}
+	}
+}
+
+func TestReportJSON(t *testing.T) {
+	err := WithHint(Config, errors.New("❌ project 'shop' does not exist"), "Run 'automock init' first 💡")
+	var buf bytes.Buffer
+	if werr := NewReport(err, "deploy").WriteJSON(&buf); werr != nil {
+		t.Fatal(werr)
+	}
+	if !strings.HasSuffix(buf.String(), "}\n") || strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("report should be one JSON line, got %q", buf.String())
+	}
+	var doc map[string]map[string]any
+	if jerr := json.Unmarshal(buf.Bytes(), &doc); jerr != nil {
+		t.Fatal(jerr)
+	}
+	got := doc["error"]
+	want := map[string]any{
+		"kind":     "config",
+		"exitCode": float64(3),
+		"message":  "project 'shop' does not exist",
+		"hint":     "Run 'automock init' first",
+		"command":  "deploy",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %v, want %v", k, got[k], v)
+		}
+	}
+	if _, ok := got["diagnostics"]; ok {
+		t.Error("diagnostics should be omitted when empty")
+	}
+}
+
+func TestPlain(t *testing.T) {
+	if got := plain("⚠️  Deployment   failed ✅"); got != "Deployment failed" {
+		t.Errorf("plain = %q", got)
+	}
+}
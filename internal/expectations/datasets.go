@@ -0,0 +1,321 @@
+package expectations
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// ManageDatasets loads, views and removes the project's seed datasets. Returns nil when
+// nothing changed.
+func (em *ExpectationManager) ManageDatasets(config *models.MockConfiguration) (*models.MockConfiguration, error) {
+	if config == nil {
+		return nil, fmt.Errorf("no configuration loaded for project %s", em.projectName)
+	}
+	fmt.Println("\n🗃️  DATASETS")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("💡 Seed rows (users, products, ...) from a CSV or JSON file, stored with the expectations")
+	fmt.Println("   Serve them from an expectation: edit → Response → Dataset (list pages or a row by id)")
+
+	changed := false
+	for {
+		options := []string{
+			"done - Finish",
+			"add - Load a dataset from a CSV or JSON file (replaces one with the same name)",
+			"generate - Generate N rows from a row template, e.g. 95 users to page through",
+		}
+		for _, d := range config.Datasets {
+			options = append(options, fmt.Sprintf("view:%s - View %s (%d rows, key %s)", d.Name, d.Name, len(d.Rows), keyLabel(d.Key)))
+			options = append(options, fmt.Sprintf("remove:%s - Remove %s", d.Name, d.Name))
+		}
+
+		var action string
+		if err := ask.One(&survey.Select{
+			Message:  fmt.Sprintf("Datasets (%d):", len(config.Datasets)),
+			Options:  options,
+			PageSize: 12,
+		}, &action); err != nil {
+			return nil, err
+		}
+
+		token := strings.Fields(action)[0]
+		switch {
+		case token == "done":
+			if !changed {
+				return nil, nil
+			}
+			return config, nil
+		case token == "add", token == "generate":
+			d, err := askDataset()
+			if token == "generate" {
+				d, err = askGeneratedDataset(config)
+			}
+			if err != nil {
+				fmt.Printf("❌ %v\n", err)
+				continue
+			}
+			if i := config.FindDataset(d.Name); i >= 0 {
+				config.Datasets[i] = d
+			} else {
+				config.Datasets = append(config.Datasets, d)
+			}
+			changed = true
+			fmt.Printf("✅ Dataset %s: %d rows, key %s, fields %s\n", d.Name, len(d.Rows), keyLabel(d.Key), strings.Join(d.Fields(), ", "))
+		case strings.HasPrefix(token, "view:"):
+			viewDataset(config.Datasets[config.FindDataset(strings.TrimPrefix(token, "view:"))])
+		case strings.HasPrefix(token, "remove:"):
+			name := strings.TrimPrefix(token, "remove:")
+			if users := config.DatasetUsers(name); len(users) > 0 {
+				fmt.Printf("❌ %d expectation(s) serve %s, e.g. %s; unbind them first (edit → Response → Dataset)\n",
+					len(users), name, endpointOf(config.Expectations[users[0]]))
+				continue
+			}
+			i := config.FindDataset(name)
+			config.Datasets = append(config.Datasets[:i], config.Datasets[i+1:]...)
+			if len(config.Datasets) == 0 {
+				config.Datasets = nil
+			}
+			changed = true
+			fmt.Printf("🗑️  Removed dataset %s\n", name)
+		}
+	}
+}
+
+// askDataset loads a dataset from a file and lets the user confirm its name and key
+func askDataset() (models.Dataset, error) {
+	var file string
+	if err := ask.One(&survey.Input{
+		Message: "CSV or JSON file:",
+		Help:    "CSV: a header row, then one row per line. JSON: an array of objects, e.g. [{\"id\": 1, \"name\": \"Ada\"}]",
+	}, &file, survey.WithValidator(survey.Required)); err != nil {
+		return models.Dataset{}, err
+	}
+	file = strings.TrimSpace(file)
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return models.Dataset{}, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	if err := ask.One(&survey.Input{
+		Message: "Dataset name:",
+		Default: name,
+		Help:    "Used to bind expectations, e.g. users",
+	}, &name, survey.WithValidator(func(ans any) error {
+		if msg := (models.Dataset{Name: strings.TrimSpace(fmt.Sprint(ans))}).Check(); msg != "" {
+			return fmt.Errorf("%s", msg)
+		}
+		return nil
+	})); err != nil {
+		return models.Dataset{}, err
+	}
+	d, err := models.ParseDataset(strings.TrimSpace(name), file, data)
+	if err != nil {
+		return d, err
+	}
+
+	fields := append([]string{"(none)"}, d.Fields()...)
+	key := d.Key
+	if key == "" {
+		key = fields[0]
+	}
+	if err := ask.One(&survey.Select{
+		Message: "Key field (finds a row for GET /{id}):",
+		Options: fields,
+		Default: key,
+	}, &key); err != nil {
+		return d, err
+	}
+	d.Key = ""
+	if key != "(none)" {
+		d.Key = key
+	}
+	if msg := d.Check(); msg != "" {
+		return d, fmt.Errorf("%s", msg)
+	}
+	return d, nil
+}
+
+// askGeneratedDataset builds a dataset of a chosen size from a row template
+func askGeneratedDataset(config *models.MockConfiguration) (models.Dataset, error) {
+	var name, count, row string
+	if err := ask.One(&survey.Input{Message: "Dataset name:", Help: "Used to bind expectations, e.g. users"}, &name, survey.WithValidator(func(ans any) error {
+		if msg := (models.Dataset{Name: strings.TrimSpace(fmt.Sprint(ans))}).Check(); msg != "" {
+			return fmt.Errorf("%s", msg)
+		}
+		return nil
+	})); err != nil {
+		return models.Dataset{}, err
+	}
+	if err := ask.One(&survey.Input{Message: "Number of rows:", Default: "100"}, &count, survey.WithValidator(func(ans any) error {
+		if n, err := strconv.Atoi(strings.TrimSpace(fmt.Sprint(ans))); err != nil || n < 1 {
+			return fmt.Errorf("enter a positive number")
+		}
+		return nil
+	})); err != nil {
+		return models.Dataset{}, err
+	}
+	if err := ask.One(&survey.Multiline{
+		Message: "Row template (JSON object):",
+		Default: `{"id": "${index}", "name": "${name}", "email": "${email}"}`,
+		Help:    "${index} is the row number from 1; fake-data placeholders such as ${uuid} or ${int:1-100} are filled per row",
+	}, &row); err != nil {
+		return models.Dataset{}, err
+	}
+	var template map[string]any
+	if err := json.Unmarshal([]byte(row), &template); err != nil {
+		return models.Dataset{}, fmt.Errorf("row template must be a JSON object: %w", err)
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(count))
+	return config.GenerateDataset(strings.TrimSpace(name), n, template)
+}
+
+func viewDataset(d models.Dataset) {
+	fmt.Printf("\n🗃️  %s · %d rows · key %s · from %s\n", d.Name, len(d.Rows), keyLabel(d.Key), d.Source)
+	shown := d.Rows
+	if len(shown) > 5 {
+		shown = shown[:5]
+	}
+	for _, row := range shown {
+		raw, _ := json.Marshal(row)
+		fmt.Printf("   %s\n", raw)
+	}
+	if len(d.Rows) > len(shown) {
+		fmt.Printf("   … %d more\n", len(d.Rows)-len(shown))
+	}
+}
+
+func keyLabel(key string) string {
+	if key == "" {
+		return "(none)"
+	}
+	return key
+}
+
+func endpointOf(exp models.MockExpectation) string {
+	return fmt.Sprintf("%s %s", exp.HttpRequest.Method, exp.HttpRequest.Path)
+}
+
+// editDataset binds the expectation to a project dataset, or unbinds it
+func editDataset(exp *models.MockExpectation, config *models.MockConfiguration) {
+	if config == nil || len(config.Datasets) == 0 {
+		fmt.Println("⚠️  The project has no datasets. Load one with 'datasets' from the project menu.")
+		if exp.Dataset == nil {
+			return
+		}
+	}
+	fmt.Println("\n🗃️  Dataset")
+	fmt.Println("💡 list: pages of the rows by page number, offset or cursor, with next/prev links")
+	fmt.Println("   (other query parameters naming a field filter the rows)")
+	fmt.Println("   item: the row whose key is in a path parameter, 404 when there is none")
+	fmt.Println("   The body may use ${dataset.rows}, ${dataset.total}, ${dataset.page}, ${dataset.size}, ${dataset.pages},")
+	fmt.Println("   ${dataset.offset}, ${dataset.nextCursor}, ${dataset.next}, ${dataset.prev}, ${dataset.row} and")
+	fmt.Println("   ${dataset.row.<field>}; an empty body serves the rows as JSON")
+
+	const unbind = "(none) - Serve the static response"
+	options := []string{unbind}
+	current := unbind
+	if config != nil {
+		for _, d := range config.Datasets {
+			label := fmt.Sprintf("%s - %d rows", d.Name, len(d.Rows))
+			options = append(options, label)
+			if exp.Dataset != nil && exp.Dataset.Name == d.Name {
+				current = label
+			}
+		}
+	}
+	var choice string
+	if err := ask.One(&survey.Select{Message: "Serve from dataset:", Options: options, Default: current}, &choice); err != nil {
+		return
+	}
+	if choice == unbind {
+		if exp.Dataset != nil {
+			fmt.Println("✅ Dataset unbound")
+		}
+		exp.Dataset = nil
+		return
+	}
+	d := config.Datasets[config.FindDataset(strings.Fields(choice)[0])]
+
+	binding := models.DatasetBinding{Name: d.Name, Mode: models.DatasetList}
+	params := models.PathParameterNames(exp.HttpRequest.Path)
+	if len(params) > 0 {
+		binding.Mode = models.DatasetItem
+	}
+	if exp.Dataset != nil && exp.Dataset.Mode != "" {
+		binding.Mode = exp.Dataset.Mode
+	}
+	if err := ask.One(&survey.Select{Message: "Serve:", Options: models.DatasetModes, Default: binding.Mode}, &binding.Mode); err != nil {
+		return
+	}
+
+	if binding.Mode == models.DatasetItem {
+		if d.Key == "" {
+			fmt.Printf("❌ Dataset %s has no key field; reload it with one to find rows by\n", d.Name)
+			return
+		}
+		if len(params) == 0 {
+			fmt.Printf("❌ Item endpoints need a path parameter holding the %s, e.g. %s/{%s}\n", d.Key, exp.HttpRequest.Path, d.Key)
+			return
+		}
+		binding.Param = params[len(params)-1]
+		if len(params) > 1 {
+			if err := ask.One(&survey.Select{Message: fmt.Sprintf("Path parameter holding the %s:", d.Key), Options: params, Default: binding.Param}, &binding.Param); err != nil {
+				return
+			}
+		}
+	} else {
+		binding.Paging = models.PagingPage
+		if exp.Dataset != nil && exp.Dataset.Mode == models.DatasetList {
+			binding.Paging = exp.Dataset.PagingStyle()
+		}
+		if err := ask.One(&survey.Select{
+			Message: "Pagination style:",
+			Options: models.PagingStyles,
+			Default: binding.Paging,
+			Help:    "page: ?page=2&size=20 · offset: ?offset=40&limit=20 · cursor: ?cursor=<key of the last row seen>&limit=20",
+		}, &binding.Paging); err != nil {
+			return
+		}
+		size := strconv.Itoa(models.DefaultPageSize)
+		if err := ask.One(&survey.Input{Message: "Rows per page:", Default: size}, &size, survey.WithValidator(func(ans any) error {
+			if n, err := strconv.Atoi(strings.TrimSpace(fmt.Sprint(ans))); err != nil || n < 1 {
+				return fmt.Errorf("enter a positive number")
+			}
+			return nil
+		})); err != nil {
+			return
+		}
+		binding.PageSize, _ = strconv.Atoi(strings.TrimSpace(size))
+		if binding.PageSize == models.DefaultPageSize {
+			binding.PageSize = 0
+		}
+		defaultPage, defaultSize := models.DatasetBinding{Paging: binding.Paging}.QueryParams()
+		pageParam, sizeParam := defaultPage, defaultSize
+		if err := ask.One(&survey.Input{Message: fmt.Sprintf("Query parameter with the %s:", map[string]string{
+			models.PagingPage: "page number (1-based)", models.PagingOffset: "offset", models.PagingCursor: "cursor",
+		}[binding.Paging]), Default: pageParam}, &pageParam); err != nil {
+			return
+		}
+		if err := ask.One(&survey.Input{Message: "Page size query parameter:", Default: sizeParam}, &sizeParam); err != nil {
+			return
+		}
+		if binding.Paging == models.PagingPage {
+			binding.Paging = ""
+		}
+		if pageParam = strings.TrimSpace(pageParam); pageParam != defaultPage {
+			binding.PageParam = pageParam
+		}
+		if sizeParam = strings.TrimSpace(sizeParam); sizeParam != defaultSize {
+			binding.SizeParam = sizeParam
+		}
+	}
+	exp.Dataset = &binding
+	fmt.Printf("✅ Serving %s\n", binding.Describe())
+}
@@ -0,0 +1,275 @@
+package expectations
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// ManageEnvironments edits the project's environment overlays (dev, stage, prod). It returns
+// nil when nothing changed.
+func (em *ExpectationManager) ManageEnvironments(config *models.MockConfiguration) (*models.MockConfiguration, error) {
+	if config == nil {
+		return nil, fmt.Errorf("no configuration loaded for project %s", em.projectName)
+	}
+	fmt.Println("\n🌐 ENVIRONMENTS")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("💡 An overlay stores only how an environment differs from the base expectations;")
+	fmt.Println("   serve one with deploy --env <name>, or download it with download --env <name>")
+
+	changed := false
+	for {
+		options := []string{"done - Finish", "add - Add or replace an environment (replacements, delay)"}
+		for _, o := range config.Environments {
+			options = append(options,
+				fmt.Sprintf("edit:%s - Edit the %s expectations (%s)", o.Name, o.Name, o.Summary()),
+				fmt.Sprintf("exclude:%s - Choose base expectations %s does not serve", o.Name, o.Name),
+				fmt.Sprintf("preview:%s - Compare %s with the base", o.Name, o.Name),
+				fmt.Sprintf("remove:%s - Remove %s", o.Name, o.Name))
+		}
+
+		var action string
+		if err := ask.One(&survey.Select{
+			Message:  fmt.Sprintf("Environments (%d):", len(config.Environments)),
+			Options:  options,
+			PageSize: 14,
+		}, &action); err != nil {
+			return nil, err
+		}
+
+		token := strings.Fields(action)[0]
+		verb, name, _ := strings.Cut(token, ":")
+		switch verb {
+		case "done":
+			if !changed {
+				return nil, nil
+			}
+			return config, nil
+		case "add":
+			overlay, err := askEnvironment(config)
+			if err != nil {
+				return nil, err
+			}
+			config.SetEnvironment(overlay)
+			changed = true
+			fmt.Printf("✅ Environment %s: %s\n", overlay.Name, overlay.Summary())
+		case "edit":
+			edited, err := em.EditEnvironment(config, name)
+			if err != nil {
+				return nil, err
+			}
+			changed = changed || edited
+		case "exclude":
+			excluded, err := excludeFromEnvironment(config, name)
+			if err != nil {
+				return nil, err
+			}
+			changed = changed || excluded
+		case "preview":
+			previewEnvironment(config, name)
+		case "remove":
+			for i, o := range config.Environments {
+				if o.Name == name {
+					config.Environments = append(config.Environments[:i], config.Environments[i+1:]...)
+					changed = true
+					break
+				}
+			}
+		}
+	}
+}
+
+// EditEnvironment runs the expectation editor on the expectations of environment name and
+// stores the edits as the overlay's overrides. It reports whether the overlay changed.
+func (em *ExpectationManager) EditEnvironment(config *models.MockConfiguration, name string) (bool, error) {
+	overlay, err := config.FindEnvironment(name)
+	if err != nil {
+		return false, err
+	}
+	base := config.BaseExpectations()
+	view := *config
+	view.Expectations = overlay.Apply(base)
+	fmt.Printf("🌐 Editing the %s expectations; changes are stored as differences from the base\n", overlay.Name)
+	edited, err := em.EditExpectations(&view)
+	if err != nil || edited == nil {
+		return false, err
+	}
+	before := overlay.Summary()
+	overlay.Update(base, edited.Expectations)
+	fmt.Printf("✅ Environment %s: %s (was %s)\n", overlay.Name, overlay.Summary(), before)
+	return true, nil
+}
+
+// askEnvironment captures an overlay's name, text replacements and default delay. Overrides
+// and exclusions of an existing overlay with the same name are kept.
+func askEnvironment(config *models.MockConfiguration) (models.EnvironmentOverlay, error) {
+	var overlay models.EnvironmentOverlay
+	if err := ask.One(&survey.Input{
+		Message: "Environment name:",
+		Help:    "e.g. dev, stage or prod; used as deploy --env <name>",
+	}, &overlay.Name, survey.WithValidator(func(ans interface{}) error {
+		o := models.EnvironmentOverlay{Name: ans.(string)}
+		return o.Validate()
+	})); err != nil {
+		return overlay, err
+	}
+	overlay.Name = strings.TrimSpace(overlay.Name)
+	if existing, err := config.FindEnvironment(overlay.Name); err == nil {
+		overlay = *existing
+		fmt.Printf("✏️  Replacing the replacements and delay of %s; its overrides and exclusions are kept\n", overlay.Name)
+	}
+	if err := ask.One(&survey.Input{Message: "Description (optional):", Default: overlay.Description}, &overlay.Description); err != nil {
+		return overlay, err
+	}
+
+	overlay.Replace = nil
+	for {
+		var from string
+		if err := ask.One(&survey.Input{
+			Message: "Replace text (Enter to finish):",
+			Help:    "Replaced everywhere in the base expectations, e.g. api.example.com -> api.stage.example.com",
+		}, &from); err != nil {
+			return overlay, err
+		}
+		if from = strings.TrimSpace(from); from == "" {
+			break
+		}
+		var to string
+		if err := ask.One(&survey.Input{Message: fmt.Sprintf("Replace %q with:", from)}, &to); err != nil {
+			return overlay, err
+		}
+		if overlay.Replace == nil {
+			overlay.Replace = map[string]string{}
+		}
+		overlay.Replace[from] = to
+	}
+
+	var delay string
+	if err := ask.One(&survey.Input{
+		Message: "Response delay in milliseconds for expectations without their own (Enter for none):",
+	}, &delay, survey.WithValidator(func(ans interface{}) error {
+		if s := strings.TrimSpace(ans.(string)); s != "" {
+			if n, err := strconv.Atoi(s); err != nil || n < 0 {
+				return fmt.Errorf("enter a number of milliseconds")
+			}
+		}
+		return nil
+	})); err != nil {
+		return overlay, err
+	}
+	overlay.Delay = nil
+	if n, _ := strconv.Atoi(strings.TrimSpace(delay)); n > 0 {
+		overlay.Delay = &models.Delay{TimeUnit: "MILLISECONDS", Value: n}
+	}
+	return overlay, overlay.Validate()
+}
+
+// excludeFromEnvironment picks the base expectations an environment leaves out
+func excludeFromEnvironment(config *models.MockConfiguration, name string) (bool, error) {
+	overlay, err := config.FindEnvironment(name)
+	if err != nil {
+		return false, err
+	}
+	base := config.BaseExpectations()
+	keys := models.ExpectationKeys(base)
+	removed := map[string]bool{}
+	for _, key := range overlay.Remove {
+		removed[key] = true
+	}
+	var options, defaults []string
+	for i, label := range buildAPIList(base) {
+		option := fmt.Sprintf("%d. %s", i+1, label)
+		options = append(options, option)
+		if removed[keys[i]] {
+			defaults = append(defaults, option)
+		}
+	}
+
+	var picked []string
+	if err := ask.One(&survey.MultiSelect{
+		Message:  fmt.Sprintf("Expectations %s does not serve:", overlay.Name),
+		Options:  options,
+		Default:  defaults,
+		PageSize: 15,
+	}, &picked); err != nil {
+		return false, err
+	}
+
+	overlay.Remove = nil
+	excluded := map[string]bool{}
+	for _, option := range picked {
+		n, _ := strconv.Atoi(strings.SplitN(option, ".", 2)[0])
+		overlay.Remove = append(overlay.Remove, keys[n-1])
+		excluded[keys[n-1]] = true
+	}
+	// An excluded expectation is not served, so its override has nothing left to replace
+	kept := overlay.Override[:0]
+	for _, ov := range overlay.Override {
+		if !excluded[ov.Key] {
+			kept = append(kept, ov)
+		}
+	}
+	overlay.Override = kept
+	fmt.Printf("✅ Environment %s: %s\n", overlay.Name, overlay.Summary())
+	return true, nil
+}
+
+// previewEnvironment shows how an environment differs from the base, without touching the project
+func previewEnvironment(config *models.MockConfiguration, name string) {
+	overlay, err := config.FindEnvironment(name)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+	base := *config
+	base.Expectations = config.BaseExpectations()
+	env := base
+	env.Expectations = overlay.Apply(base.Expectations)
+	fmt.Printf("\n🌐 %s: %s\n", overlay.Name, overlay.Summary())
+	for _, from := range sortedReplacements(overlay.Replace) {
+		fmt.Printf("   %q → %q\n", from, overlay.Replace[from])
+	}
+	diff := models.DiffConfigurations(&base, &env)
+	fmt.Print(diff.Format())
+	fmt.Println()
+}
+
+func sortedReplacements(replace map[string]string) []string {
+	var keys []string
+	for k := range replace {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SelectEnvironment asks which environment a deploy serves; "" is the base expectations.
+// Projects without overlays are not asked.
+func SelectEnvironment(config *models.MockConfiguration) (string, error) {
+	if config == nil || len(config.Environments) == 0 {
+		return "", nil
+	}
+	const base = "base - The expectations without an overlay"
+	options := []string{base}
+	for _, name := range config.EnvironmentNames() {
+		overlay, _ := config.FindEnvironment(name)
+		options = append(options, fmt.Sprintf("%s - %s", name, overlay.Summary()))
+	}
+	var picked string
+	if err := ask.One(&survey.Select{
+		Message: "Environment to serve:",
+		Options: options,
+		Default: base,
+	}, &picked); err != nil {
+		return "", err
+	}
+	if picked == base {
+		return "", nil
+	}
+	return strings.Fields(picked)[0], nil
+}
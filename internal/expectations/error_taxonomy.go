@@ -0,0 +1,233 @@
+package expectations
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// ManageErrorTaxonomy runs the error taxonomy wizard for the project and optionally reshapes
+// existing error responses into the new envelope. Returns nil when nothing changed.
+func (em *ExpectationManager) ManageErrorTaxonomy(config *models.MockConfiguration) (*models.MockConfiguration, error) {
+	if config == nil {
+		return nil, fmt.Errorf("no configuration loaded for project %s", em.projectName)
+	}
+	taxonomy, err := ErrorTaxonomyWizard(config.ErrorTaxonomy)
+	if err != nil {
+		return nil, err
+	}
+	if taxonomy == nil {
+		return nil, nil
+	}
+	config.ErrorTaxonomy = taxonomy
+
+	errorResponses := 0
+	for _, exp := range config.Expectations {
+		if exp.HttpResponse != nil && exp.HttpResponse.StatusCode >= 400 && exp.HttpResponse.SchemaRef == "" {
+			errorResponses++
+		}
+	}
+	if errorResponses > 0 {
+		var reshape bool
+		if err := ask.One(&survey.Confirm{
+			Message: fmt.Sprintf("Reshape the %d existing error response(s) into the new envelope?", errorResponses),
+			Help:    "Codes from the taxonomy and messages are kept; other codes are replaced by the status's code. Velocity templates are left alone.",
+			Default: true,
+		}, &reshape); err != nil {
+			return nil, err
+		}
+		if reshape {
+			n := models.ApplyErrorTaxonomy(config.Expectations, taxonomy)
+			fmt.Printf("🧯 Reshaped %d error response(s)\n", n)
+		}
+	}
+	return config, nil
+}
+
+// ErrorTaxonomyWizard captures the project's error envelope and error codes, starting from
+// existing when set. It returns nil when the user keeps the current taxonomy unchanged.
+func ErrorTaxonomyWizard(existing *models.ErrorTaxonomy) (*models.ErrorTaxonomy, error) {
+	fmt.Println("\n🧯 ERROR TAXONOMY")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("💡 Generated error responses (templates and AI) use this envelope and these codes")
+
+	taxonomy := &models.ErrorTaxonomy{Codes: models.DefaultErrorCodes()}
+	if existing != nil {
+		taxonomy.Envelope = existing.Envelope
+		taxonomy.Codes = append([]models.ErrorCode(nil), existing.Codes...)
+	}
+
+	envelope, err := askErrorEnvelope(taxonomy.Envelope)
+	if err != nil {
+		return nil, err
+	}
+	taxonomy.Envelope = envelope
+
+	if err := editErrorCodes(taxonomy); err != nil {
+		return nil, err
+	}
+	if err := taxonomy.Validate(); err != nil {
+		return nil, err
+	}
+
+	if existing != nil && taxonomy.Envelope == existing.Envelope && sameErrorCodes(taxonomy.Codes, existing.Codes) {
+		return nil, nil
+	}
+	sample, _ := taxonomy.Body(404)
+	preview, _ := json.MarshalIndent(sample.(map[string]any)["json"], "   ", "  ")
+	fmt.Printf("✅ Error taxonomy ready (%d code(s)); a 404 looks like:\n   %s\n", len(taxonomy.Codes), preview)
+	return taxonomy, nil
+}
+
+// askErrorEnvelope picks a preset envelope, keeps the current one or takes a custom one
+func askErrorEnvelope(current string) (string, error) {
+	var options []string
+	if current != "" {
+		options = append(options, "keep - Keep the current envelope")
+	}
+	for _, p := range models.ErrorEnvelopePresets {
+		options = append(options, p.Name+" - "+p.Envelope)
+	}
+	options = append(options, "custom - Paste your own envelope")
+
+	var choice string
+	if err := ask.One(&survey.Select{
+		Message: "Error envelope:",
+		Options: options,
+		Default: options[0],
+		Help:    "Use {{code}} and {{message}} inside strings and {{status}} for the numeric status",
+	}, &choice); err != nil {
+		return "", err
+	}
+
+	name := strings.Split(choice, " ")[0]
+	switch name {
+	case "keep":
+		return current, nil
+	case "custom":
+		var envelope string
+		if err := ask.One(&survey.Multiline{
+			Message: "Paste the error envelope JSON:",
+			Help:    `Example: {"fault": {"errorCode": "{{code}}", "reason": "{{message}}", "httpStatus": {{status}}}}`,
+			Default: current,
+		}, &envelope, survey.WithValidator(func(ans interface{}) error {
+			return (&models.ErrorTaxonomy{Envelope: strings.TrimSpace(ans.(string))}).Validate()
+		})); err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(envelope), nil
+	}
+	for _, p := range models.ErrorEnvelopePresets {
+		if p.Name == name {
+			return p.Envelope, nil
+		}
+	}
+	return "", fmt.Errorf("unknown envelope choice: %s", choice)
+}
+
+// editErrorCodes lets the user add, change and remove the status -> code entries
+func editErrorCodes(taxonomy *models.ErrorTaxonomy) error {
+	for {
+		sort.SliceStable(taxonomy.Codes, func(i, j int) bool { return taxonomy.Codes[i].Status < taxonomy.Codes[j].Status })
+		options := []string{"done - Use these codes", "add - Add or change a status code"}
+		for _, c := range taxonomy.Codes {
+			options = append(options, fmt.Sprintf("remove:%d - Remove %d %s (%s)", c.Status, c.Status, c.Code, c.Message))
+		}
+
+		var action string
+		if err := ask.One(&survey.Select{
+			Message:  fmt.Sprintf("Error codes (%d):", len(taxonomy.Codes)),
+			Options:  options,
+			PageSize: 14,
+		}, &action); err != nil {
+			return err
+		}
+
+		token := strings.Fields(action)[0]
+		switch {
+		case token == "done":
+			return nil
+		case token == "add":
+			code, err := askErrorCode(taxonomy)
+			if err != nil {
+				return err
+			}
+			setErrorCode(taxonomy, code)
+		case strings.HasPrefix(token, "remove:"):
+			status, _ := strconv.Atoi(strings.TrimPrefix(token, "remove:"))
+			for i, c := range taxonomy.Codes {
+				if c.Status == status {
+					taxonomy.Codes = append(taxonomy.Codes[:i], taxonomy.Codes[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+}
+
+func askErrorCode(taxonomy *models.ErrorTaxonomy) (models.ErrorCode, error) {
+	var statusText string
+	if err := ask.One(&survey.Input{
+		Message: "HTTP status (4xx/5xx):",
+	}, &statusText, survey.WithValidator(func(ans interface{}) error {
+		n, err := strconv.Atoi(strings.TrimSpace(ans.(string)))
+		if err != nil || n < 400 || n > 599 {
+			return fmt.Errorf("enter a status between 400 and 599")
+		}
+		return nil
+	})); err != nil {
+		return models.ErrorCode{}, err
+	}
+	status, _ := strconv.Atoi(strings.TrimSpace(statusText))
+	current := taxonomy.CodeFor(status)
+
+	code := models.ErrorCode{Status: status}
+	if err := ask.One(&survey.Input{
+		Message: "Error code:",
+		Default: current.Code,
+	}, &code.Code, survey.WithValidator(survey.Required)); err != nil {
+		return models.ErrorCode{}, err
+	}
+	if err := ask.One(&survey.Input{
+		Message: "Default message:",
+		Default: current.Message,
+	}, &code.Message); err != nil {
+		return models.ErrorCode{}, err
+	}
+	code.Code = strings.TrimSpace(code.Code)
+	code.Message = strings.TrimSpace(code.Message)
+	return code, nil
+}
+
+// setErrorCode replaces the entry for the code's status or appends it
+func setErrorCode(taxonomy *models.ErrorTaxonomy, code models.ErrorCode) {
+	for i, c := range taxonomy.Codes {
+		if c.Status == code.Status {
+			taxonomy.Codes[i] = code
+			return
+		}
+	}
+	taxonomy.Codes = append(taxonomy.Codes, code)
+}
+
+func sameErrorCodes(a, b []models.ErrorCode) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	byStatus := map[int]models.ErrorCode{}
+	for _, c := range b {
+		byStatus[c.Status] = c
+	}
+	for _, c := range a {
+		if byStatus[c.Status] != c {
+			return false
+		}
+	}
+	return true
+}
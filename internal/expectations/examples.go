@@ -0,0 +1,206 @@
+package expectations
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// ManageExamples lets the user browse, add and delete the project's response example library.
+// Returns nil when nothing changed.
+func (em *ExpectationManager) ManageExamples(config *models.MockConfiguration) (*models.MockConfiguration, error) {
+	fmt.Println("\n📚 EXAMPLE LIBRARY")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("💡 Insert an example while editing a response: Response → Insert Example")
+
+	if config == nil {
+		return nil, fmt.Errorf("no configuration loaded for project %s", em.projectName)
+	}
+
+	changed := false
+	for {
+		options := []string{
+			"add - Add or replace an example",
+			"harvest - Collect examples from current expectation responses",
+		}
+		for _, e := range config.BodyExamples {
+			options = append(options, fmt.Sprintf("view:%s - View %s (%s)", e.Key(), e.Key(), e.Source))
+			options = append(options, fmt.Sprintf("delete:%s - Delete %s", e.Key(), e.Key()))
+		}
+		options = append(options, "done - Finish managing examples")
+
+		var action string
+		if err := ask.One(&survey.Select{
+			Message:  fmt.Sprintf("Examples (%d stored):", len(config.BodyExamples)),
+			Options:  options,
+			PageSize: 12,
+		}, &action); err != nil {
+			return nil, err
+		}
+
+		token, _, _ := strings.Cut(action, " - ")
+		switch {
+		case token == "add":
+			if addExample(config) {
+				changed = true
+			}
+		case token == "harvest":
+			added := config.HarvestBodyExamples("import")
+			fmt.Printf("✅ Added %d example(s) from expectation responses\n", added)
+			changed = changed || added > 0
+		case token == "done":
+			if !changed {
+				return nil, nil
+			}
+			return config, nil
+		case strings.HasPrefix(token, "view:"):
+			viewExample(config, strings.TrimPrefix(token, "view:"))
+		case strings.HasPrefix(token, "delete:"):
+			if config.RemoveBodyExample(strings.TrimPrefix(token, "delete:")) {
+				fmt.Printf("✅ Deleted example %s\n", strings.TrimPrefix(token, "delete:"))
+				changed = true
+			}
+		}
+	}
+}
+
+func addExample(config *models.MockConfiguration) bool {
+	var contentType string
+	if err := ask.One(&survey.Input{
+		Message: "Content type:",
+		Default: "application/json",
+		Suggest: func(string) []string { return config.BodyExampleContentTypes() },
+	}, &contentType, survey.WithValidator(survey.Required)); err != nil {
+		return false
+	}
+	var entity string
+	if err := ask.One(&survey.Input{
+		Message: "Entity:",
+		Help:    "Example: Order, OrderList, Error",
+	}, &entity, survey.WithValidator(survey.Required)); err != nil {
+		return false
+	}
+	var body string
+	if err := ask.One(&survey.Multiline{
+		Message: fmt.Sprintf("Paste the %s example body:", strings.TrimSpace(entity)),
+	}, &body); err != nil {
+		return false
+	}
+	return storeExample(config, models.BodyExample{ContentType: contentType, Entity: entity, Body: body, Source: "manual"})
+}
+
+// storeExample adds an example, validating JSON bodies and confirming replacements
+func storeExample(config *models.MockConfiguration, example models.BodyExample) bool {
+	if strings.Contains(example.ContentType, "json") {
+		if _, ok := example.ResponseBody().(map[string]any); !ok {
+			fmt.Println("❌ Invalid JSON")
+			return false
+		}
+	}
+	replace := true
+	if existing, ok := config.FindBodyExample(example.ContentType, example.Entity); ok {
+		if err := ask.One(&survey.Confirm{
+			Message: fmt.Sprintf("Replace the existing %s example?", existing.Key()),
+			Default: false,
+		}, &replace); err != nil {
+			return false
+		}
+	}
+	if !config.AddBodyExample(example, replace) {
+		fmt.Println("ℹ️  Example library unchanged")
+		return false
+	}
+	stored, _ := config.FindBodyExample(example.ContentType, example.Entity)
+	fmt.Printf("✅ Stored example %s\n", stored.Key())
+	return true
+}
+
+func viewExample(config *models.MockConfiguration, key string) {
+	for _, e := range config.BodyExamples {
+		if strings.EqualFold(e.Key(), key) {
+			fmt.Printf("\n📚 %s (%s, added %s)\n%s\n\n", e.Key(), e.Source, e.AddedAt.Format("2006-01-02"), e.Body)
+			return
+		}
+	}
+	fmt.Printf("⚠️ Example %s not found\n", key)
+}
+
+// insertLibraryExample replaces a response body with an example picked by content type and entity
+func insertLibraryExample(expectation *models.MockExpectation, config *models.MockConfiguration) {
+	if config == nil || len(config.BodyExamples) == 0 {
+		fmt.Println("⚠️ The example library is empty. Use 'examples' from the project menu, or 'Save Body as Example'.")
+		return
+	}
+	contentType := config.BodyExampleContentTypes()[0]
+	if types := config.BodyExampleContentTypes(); len(types) > 1 {
+		if err := ask.One(&survey.Select{Message: "Content type:", Options: types}, &contentType); err != nil {
+			return
+		}
+	}
+	examples := config.BodyExamplesFor(contentType)
+	entities := make([]string, len(examples))
+	for i, e := range examples {
+		entities[i] = e.Entity
+	}
+	var entity string
+	if err := ask.One(&survey.Select{
+		Message: fmt.Sprintf("Insert example (%s):", contentType),
+		Options: entities,
+		Description: func(value string, index int) string {
+			return preview(examples[index].Body)
+		},
+	}, &entity); err != nil {
+		return
+	}
+	for _, e := range examples {
+		if e.Entity != entity {
+			continue
+		}
+		fmt.Printf("\n%s\n\n", e.Body)
+		expectation.HttpResponse.Body = e.ResponseBody()
+		expectation.HttpResponse.SchemaRef = ""
+		setHeader(&expectation.HttpResponse.Headers, "Content-Type", e.ContentType)
+		fmt.Printf("✅ Response body set from %s — edit it from here to adjust\n", e.Key())
+		return
+	}
+}
+
+// saveBodyAsExample stores the current response body in the example library
+func saveBodyAsExample(expectation *models.MockExpectation, config *models.MockConfiguration) {
+	if config == nil {
+		return
+	}
+	example, ok := models.BodyExampleFromExpectation(*expectation)
+	if !ok {
+		fmt.Println("⚠️ This response has no literal body to save")
+		return
+	}
+	if err := ask.One(&survey.Input{
+		Message: "Entity:",
+		Default: example.Entity,
+	}, &example.Entity, survey.WithValidator(survey.Required)); err != nil {
+		return
+	}
+	example.Source = "manual"
+	storeExample(config, example)
+}
+
+func setHeader(headers *[]models.NameValues, name, value string) {
+	if i := findNameIndex(*headers, name); i >= 0 {
+		(*headers)[i].Values = []string{value}
+		return
+	}
+	*headers = append(*headers, models.NameValues{Name: name, Values: []string{value}})
+}
+
+// preview collapses a body to one short line for option descriptions
+func preview(s string) string {
+	line := strings.Join(strings.Fields(s), " ")
+	if len(line) > 60 {
+		line = line[:57] + "..."
+	}
+	return line
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
 	"github.com/hemantobora/auto-mock/internal/builders"
 	"github.com/hemantobora/auto-mock/internal/models"
 )
@@ -14,6 +15,8 @@ import (
 // ExpectationManager handles CRUD operations on mock expectations
 type ExpectationManager struct {
 	projectName string
+	schemas     map[string]any            // project schema library, refreshed on each edit session
+	library     *models.MockConfiguration // owner of the example library being edited
 }
 
 // NewExpectationManager creates a new expectation manager
@@ -33,7 +36,18 @@ func (em *ExpectationManager) ViewExpectations(config *models.MockConfiguration)
 		return nil
 	}
 
-	expectations := config.Expectations
+	indices, sel, err := filterByTags("Show only expectations tagged (none = all):", config.Expectations)
+	if err != nil {
+		return err
+	}
+	expectations := pickExpectations(config.Expectations, indices)
+	if !sel.IsEmpty() {
+		config = &models.MockConfiguration{Expectations: expectations}
+	}
+	if len(expectations) == 0 {
+		fmt.Println("📫 No expectations carry those tags.")
+		return nil
+	}
 
 	if len(expectations) == 1 {
 		fmt.Printf("🔍 Found 1 expectation\n\n")
@@ -52,7 +66,7 @@ func (em *ExpectationManager) ViewExpectations(config *models.MockConfiguration)
 		options = append(options, "🔙 Back - Return to main menu")
 
 		var selected string
-		if err := survey.AskOne(&survey.Select{
+		if err := ask.One(&survey.Select{
 			Message: "Select expectation to view:",
 			Options: options,
 		}, &selected); err != nil {
@@ -100,6 +114,25 @@ func displaySingleExpectation(expectation *models.MockExpectation) error {
 	fmt.Printf("🏷️  Name: %s\n", name)
 	fmt.Printf("🔗 Method: %s %s\n", expectation.HttpRequest.Method, expectation.HttpRequest.Path)
 	fmt.Printf("📊 Status: %d\n", expectation.HttpResponse.StatusCode)
+	fmt.Printf("🧬 Origin: %s\n", expectation.Provenance)
+	if expectation.Redirects != nil {
+		fmt.Printf("↪️  Redirects: %s\n", expectation.Redirects.Describe())
+	}
+	if len(expectation.Tags) > 0 {
+		fmt.Printf("🏷️  Tags: %s\n", strings.Join(expectation.Tags, ", "))
+	}
+	if expectation.Faults != nil {
+		fmt.Printf("💥 Faults: %s\n", expectation.Faults.Describe())
+	}
+	if expectation.Dataset != nil {
+		fmt.Printf("🗃️  Dataset: %s\n", expectation.Dataset.Describe())
+	}
+	for i, r := range expectation.Rules {
+		fmt.Printf("🔀 Rule %s: %s\n", r.Label(i), r.Describe())
+	}
+	if expectation.Notes != "" {
+		fmt.Printf("📝 Notes:\n%s\n", expectation.Notes)
+	}
 
 	return nil
 }
@@ -127,13 +160,17 @@ func (em *ExpectationManager) EditExpectations(config *models.MockConfiguration)
 	}
 
 	expectations := config.Expectations
+	em.schemas = config.Schemas
+	builders.SetErrorTaxonomy(config.ErrorTaxonomy)
+	builders.SetSchemaLibrary(config.Schemas)
+	em.library = config
 
 	for {
 		if len(expectations) == 1 {
 			fmt.Printf("🔍 Found 1 expectation: %s %s\n", expectations[0].HttpRequest.Method, expectations[0].HttpRequest.Path)
 
 			var confirmEdit bool
-			if err := survey.AskOne(&survey.Confirm{
+			if err := ask.One(&survey.Confirm{
 				Message: "Edit this expectation?",
 				Default: true,
 			}, &confirmEdit); err != nil {
@@ -145,19 +182,20 @@ func (em *ExpectationManager) EditExpectations(config *models.MockConfiguration)
 				return nil, nil
 			}
 
-			if err := editSingleExpectation(&expectations[0]); err != nil {
+			if err := em.editSingleExpectation(&expectations[0]); err != nil {
 				return nil, fmt.Errorf("edit failed: %w", err)
 			}
+			expectations[0].MarkEdited()
 
 			config.Expectations = expectations
 			return config, nil
 		}
 
 		apiList := buildAPIList(expectations)
-		apiList = append(apiList, "🔙 Finish editing and save changes")
+		apiList = append(apiList, "📝 Open all in editor ($EDITOR, JSON)", "🔙 Finish editing and save changes")
 
 		var selectedAPI string
-		if err := survey.AskOne(&survey.Select{
+		if err := ask.One(&survey.Select{
 			Message: "Select API to edit:",
 			Options: apiList,
 		}, &selectedAPI); err != nil {
@@ -167,19 +205,30 @@ func (em *ExpectationManager) EditExpectations(config *models.MockConfiguration)
 		if strings.Contains(selectedAPI, "Finish editing") {
 			break
 		}
+		if strings.Contains(selectedAPI, "Open all in editor") {
+			edited, err := em.editAllInEditor(expectations)
+			if err != nil {
+				fmt.Printf("❌ Edit failed: %v\n", err)
+			} else if edited != nil {
+				expectations = edited
+				config.Expectations = expectations
+			}
+			continue
+		}
 
 		selectedIndex := findExpectationIndex(apiList, selectedAPI)
 		if selectedIndex == -1 {
 			continue
 		}
 
-		if err := editSingleExpectation(&expectations[selectedIndex]); err != nil {
+		if err := em.editSingleExpectation(&expectations[selectedIndex]); err != nil {
 			fmt.Printf("❌ Edit failed: %v\n", err)
 			continue
 		}
+		expectations[selectedIndex].MarkEdited()
 
 		var editMore bool
-		if err := survey.AskOne(&survey.Confirm{
+		if err := ask.One(&survey.Confirm{
 			Message: "Edit another expectation?",
 			Default: false,
 		}, &editMore); err != nil {
@@ -222,6 +271,48 @@ func buildAPIList(expectations []models.MockExpectation) []string {
 		} else {
 			displayName = fmt.Sprintf("%s %s%s (%d)", method, path, queryInfo, statusCode)
 		}
+		if exp.Provenance != nil {
+			displayName += " · " + exp.Provenance.Source
+		}
+		if len(exp.Tags) > 0 {
+			displayName += " · #" + strings.Join(exp.Tags, " #")
+		}
+		if exp.Notes != "" {
+			displayName += " · 📝"
+		}
+		if exp.NegotiatedFormat != "" {
+			displayName += " · " + exp.NegotiatedFormat + " variant (generated)"
+		}
+		if exp.NotModified {
+			displayName += " · If-None-Match (generated)"
+		}
+		if exp.PartialContent {
+			displayName += " · Range (generated)"
+		}
+		if exp.RedirectHop != 0 {
+			displayName += fmt.Sprintf(" · redirect hop %d (generated)", exp.RedirectHop)
+		} else if exp.Redirects != nil {
+			displayName += " · ↪️ " + exp.Redirects.Describe()
+		}
+		if exp.Tenant != "" {
+			displayName += " · tenant " + exp.Tenant + " (generated)"
+		}
+		if exp.GraphQLVariant != "" {
+			displayName += " · GraphQL " + exp.GraphQLVariant + " (generated)"
+		}
+		if exp.RuleVariant != "" {
+			displayName += " · rule " + exp.RuleVariant + " (generated)"
+		} else if len(exp.Rules) > 0 {
+			displayName += fmt.Sprintf(" · 🔀 %d rule(s)", len(exp.Rules))
+		}
+		if exp.Dataset != nil && !exp.Generated() {
+			displayName += " · 🗃️ " + exp.Dataset.Describe()
+		}
+		if exp.FaultInjected {
+			displayName += " · faults (generated)"
+		} else if exp.Faults != nil {
+			displayName += " · 💥 " + exp.Faults.Describe()
+		}
 
 		apiList = append(apiList, displayName)
 	}
@@ -254,7 +345,40 @@ func (em *ExpectationManager) DownloadExpectations(config *models.MockConfigurat
 	fmt.Println("\n💾 DOWNLOAD EXPECTATIONS")
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
-	mockServerJSON := models.ExpectationsToMockServerJSON(config.Expectations)
+	indices, sel, err := filterByTags("Download only expectations tagged (none = all):", config.Expectations)
+	if err != nil {
+		return err
+	}
+	if len(indices) == 0 {
+		fmt.Println("📫 No expectations carry those tags.")
+		return nil
+	}
+	selected := pickExpectations(config.Expectations, indices)
+
+	var layout string
+	if err := ask.One(&survey.Select{
+		Message: "Download as:",
+		Options: []string{"single - One MockServer JSON file", "split - One file per expectation (Git-friendly)"},
+		Default: "single - One MockServer JSON file",
+	}, &layout); err != nil {
+		return err
+	}
+	if strings.HasPrefix(layout, "split") {
+		dir := fmt.Sprintf("%s-expectations", em.projectName)
+		files, err := WriteSplit(dir, selected)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("\n✅ Wrote %d expectation file(s) to %s/\n", len(files), dir)
+		if !sel.IsEmpty() {
+			fmt.Println("⚠️  Only the tagged expectations were written; syncing this directory with automock watch would drop the others")
+			return nil
+		}
+		fmt.Printf("💡 Sync edits back with: automock watch --project %s --dir %s\n", em.projectName, dir)
+		return nil
+	}
+
+	mockServerJSON := models.MockServerOnlyJSON(selected)
 	filename := fmt.Sprintf("%s-expectations.json", em.projectName)
 
 	if err := os.WriteFile(filename, []byte(mockServerJSON), 0644); err != nil {
@@ -263,7 +387,7 @@ func (em *ExpectationManager) DownloadExpectations(config *models.MockConfigurat
 
 	fmt.Printf("\n✅ Expectations downloaded successfully!\n")
 	fmt.Printf("📁 File: %s\n", filename)
-	fmt.Printf("📊 Expectations: %d\n", len(config.Expectations))
+	fmt.Printf("📊 Expectations: %d\n", len(selected))
 	fmt.Printf("💾 Size: %d bytes\n", len(mockServerJSON))
 	fmt.Printf("\n💡 You can now use this file with MockServer:\n")
 	fmt.Printf("   curl -X PUT http://localhost:1080/mockserver/expectation -d @%s\n", filename)
@@ -279,7 +403,7 @@ func (em *ExpectationManager) ReplaceExpectationsPrompt() error {
 	fmt.Println("💾 Previous version will be saved in version history")
 
 	var confirmReplace bool
-	if err := survey.AskOne(&survey.Confirm{
+	if err := ask.One(&survey.Confirm{
 		Message: "Continue with replacing expectations?",
 		Default: false,
 	}, &confirmReplace); err != nil {
@@ -304,10 +428,18 @@ func (em *ExpectationManager) RemoveExpectations(config *models.MockConfiguratio
 		return nil, nil
 	}
 
-	apiList := buildAPIList(config.Expectations)
+	candidates, _, err := filterByTags("Remove from expectations tagged (none = all):", config.Expectations)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		fmt.Println("📭 No expectations carry those tags.")
+		return nil, nil
+	}
+	apiList := buildAPIList(pickExpectations(config.Expectations, candidates))
 
 	var selectedAPIs []string
-	if err := survey.AskOne(&survey.MultiSelect{
+	if err := ask.One(&survey.MultiSelect{
 		Message: "Select expectations to remove:",
 		Options: apiList,
 	}, &selectedAPIs); err != nil {
@@ -325,7 +457,7 @@ func (em *ExpectationManager) RemoveExpectations(config *models.MockConfiguratio
 	}
 
 	var confirmRemoval bool
-	if err := survey.AskOne(&survey.Confirm{
+	if err := ask.One(&survey.Confirm{
 		Message: "Continue with removal?",
 		Default: false,
 	}, &confirmRemoval); err != nil {
@@ -337,7 +469,10 @@ func (em *ExpectationManager) RemoveExpectations(config *models.MockConfiguratio
 		return nil, nil
 	}
 
-	indices := findExpectationIndices(apiList, selectedAPIs)
+	var indices []int
+	for _, i := range findExpectationIndices(apiList, selectedAPIs) {
+		indices = append(indices, candidates[i])
+	}
 
 	if len(indices) == len(config.Expectations) {
 		return handleRemoveAllExpectations()
@@ -355,7 +490,7 @@ func handleRemoveAllExpectations() ([]int, error) {
 	fmt.Println("   • Project can be reused later")
 
 	var confirmClear bool
-	if err := survey.AskOne(&survey.Confirm{
+	if err := ask.One(&survey.Confirm{
 		Message: "Continue clearing all expectations?",
 		Default: false,
 	}, &confirmClear); err != nil {
@@ -382,7 +517,7 @@ func (em *ExpectationManager) DeleteProjectPrompt() error {
 	fmt.Println("\n❌ THIS CANNOT BE UNDONE!")
 
 	var confirmDelete bool
-	if err := survey.AskOne(&survey.Confirm{
+	if err := ask.One(&survey.Confirm{
 		Message: "Are you absolutely sure you want to delete this project?",
 		Default: false,
 	}, &confirmDelete); err != nil {
@@ -395,7 +530,7 @@ func (em *ExpectationManager) DeleteProjectPrompt() error {
 	}
 
 	var finalConfirm string
-	if err := survey.AskOne(&survey.Input{
+	if err := ask.One(&survey.Input{
 		Message: fmt.Sprintf("Type '%s' to confirm deletion:", em.projectName),
 	}, &finalConfirm); err != nil {
 		return err
@@ -410,9 +545,30 @@ func (em *ExpectationManager) DeleteProjectPrompt() error {
 }
 
 // Tree-style sectional editor: click section to expand/collapse, click item to run handler.
-func editSingleExpectation(exp *models.MockExpectation) error {
+func (em *ExpectationManager) editSingleExpectation(exp *models.MockExpectation) error {
 	fmt.Printf("\n📝 Editing: %s %s\n", exp.HttpRequest.Method, exp.HttpRequest.Path)
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	if exp.NegotiatedFormat != "" {
+		fmt.Printf("⚠️  This %s variant is regenerated from its JSON expectation on save; edit that one instead.\n", exp.NegotiatedFormat)
+	}
+	if exp.NotModified {
+		fmt.Println("⚠️  This 304 response is regenerated from its GET expectation on save; edit that one instead.")
+	}
+	if exp.PartialContent {
+		fmt.Println("⚠️  This Range response is regenerated from its GET expectation on save; edit that one instead.")
+	}
+	if exp.RedirectHop != 0 {
+		fmt.Printf("⚠️  This redirect hop %d is regenerated from its expectation's redirect chain on save; edit that instead.\n", exp.RedirectHop)
+	}
+	if exp.Tenant != "" {
+		fmt.Printf("⚠️  This response of tenant %s is regenerated from the project's tenants on save; edit those instead.\n", exp.Tenant)
+	}
+	if exp.GraphQLVariant != "" {
+		fmt.Printf("⚠️  This GraphQL %s variant is regenerated from its expectation on save; edit that one instead.\n", exp.GraphQLVariant)
+	}
+	if exp.RuleVariant != "" {
+		fmt.Printf("⚠️  This response for rule %q is regenerated from its expectation's rules on save; edit those instead.\n", exp.RuleVariant)
+	}
 
 	type handler func(*models.MockExpectation)
 
@@ -454,6 +610,65 @@ func editSingleExpectation(exp *models.MockExpectation) error {
 				{"Status", editStatusCode, nil},
 				{"Headers", editResponseHeaders, nil},
 				{"Body", editResponseBody, nil},
+				{"Schema", func(e *models.MockExpectation) {
+					editResponseSchemaRef(e, em.schemas)
+				}, nil},
+				{"Content Negotiation", func(e *models.MockExpectation) {
+					if err := builders.ConfigureNegotiation(e); err != nil {
+						fmt.Printf("❌ Failed to configure content negotiation: %v\n", err)
+					}
+				}, nil},
+				{"Conditional GET (ETag)", func(e *models.MockExpectation) {
+					if err := builders.ConfigureConditionalGet(e); err != nil {
+						fmt.Printf("❌ Failed to configure conditional GET: %v\n", err)
+					}
+				}, nil},
+				{"Range Requests (206)", func(e *models.MockExpectation) {
+					if err := builders.ConfigureRanges(e); err != nil {
+						fmt.Printf("❌ Failed to configure range requests: %v\n", err)
+					}
+				}, nil},
+				{"Redirect Chain", func(e *models.MockExpectation) {
+					if err := builders.ConfigureRedirects(e); err != nil {
+						fmt.Printf("❌ Failed to configure the redirect chain: %v\n", err)
+					}
+				}, nil},
+				{"GraphQL Error Variants", func(e *models.MockExpectation) {
+					if err := builders.ConfigureGraphQLErrors(e); err != nil {
+						fmt.Printf("❌ Failed to configure GraphQL error variants: %v\n", err)
+					}
+				}, func(e *models.MockExpectation) bool {
+					return e.GraphQLErrors != nil || models.GraphQLErrorsEligible(*e)
+				}},
+				{"Dataset", func(e *models.MockExpectation) {
+					editDataset(e, em.library)
+				}, nil},
+				{"Response Rules", func(e *models.MockExpectation) {
+					if err := builders.ConfigureRules(e); err != nil {
+						fmt.Printf("❌ Failed to configure response rules: %v\n", err)
+					}
+				}, nil},
+				{"Fault Injection", func(e *models.MockExpectation) {
+					if err := builders.ConfigureFaults(e); err != nil {
+						fmt.Printf("❌ Failed to configure fault injection: %v\n", err)
+					}
+				}, func(e *models.MockExpectation) bool {
+					return e.Faults != nil || models.FaultsEligible(*e)
+				}},
+				{"Streaming (WebSocket / SSE)", func(e *models.MockExpectation) {
+					if err := builders.ConfigureStream(e); err != nil {
+						fmt.Printf("❌ Failed to configure streaming: %v\n", err)
+					}
+				}, nil},
+				{"Insert Example", func(e *models.MockExpectation) {
+					insertLibraryExample(e, em.library)
+				}, nil},
+				{"Save Body as Example", func(e *models.MockExpectation) {
+					saveBodyAsExample(e, em.library)
+				}, nil},
+				{"Regenerate Body with AI", func(e *models.MockExpectation) {
+					regenerateResponseBodyWithAI(e, em.projectName)
+				}, nil},
 			},
 		},
 		{
@@ -462,6 +677,8 @@ func editSingleExpectation(exp *models.MockExpectation) error {
 			Items: []item{
 				{"Priority", editPriority, nil},
 				{"Times", editTimes, nil},
+				{"Notes", editNotes, nil},
+				{"Tags", em.editTags, nil},
 			},
 		},
 		{
@@ -469,6 +686,7 @@ func editSingleExpectation(exp *models.MockExpectation) error {
 			Open: false,
 			Items: []item{
 				{"View Current Configuration", viewCurrentConfig, nil},
+				{"Open in Editor (JSON)", em.editExpectationInEditor, nil},
 			},
 		},
 	}
@@ -509,7 +727,7 @@ func editSingleExpectation(exp *models.MockExpectation) error {
 		actions = append(actions, action{kind: "done"})
 
 		var choice string
-		if err := survey.AskOne(&survey.Select{
+		if err := ask.One(&survey.Select{
 			Message:  "Select:",
 			Options:  options,
 			PageSize: 12,
@@ -547,8 +765,8 @@ func editSingleExpectation(exp *models.MockExpectation) error {
 
 func editPriority(expectation *models.MockExpectation) {
 	var priority int
-	if err := survey.AskOne(&survey.Input{
-		Message: "Enter expectation priority (lower number = higher priority):",
+	if err := ask.One(&survey.Input{
+		Message: "Enter expectation priority (higher number wins):",
 		Default: fmt.Sprintf("%d", expectation.Priority),
 		Help:    "Example: 1, 5, 10",
 	}, &priority); err == nil && priority >= 0 {
@@ -557,6 +775,26 @@ func editPriority(expectation *models.MockExpectation) {
 	}
 }
 
+// editNotes edits the Markdown notes explaining why the mock behaves as it does
+func editNotes(expectation *models.MockExpectation) {
+	notes := expectation.Notes
+	if err := ask.One(&survey.Editor{
+		Message:       "Notes (Markdown, e.g. \"simulates the bank timing out after 3 calls\"):",
+		Default:       notes,
+		AppendDefault: true,
+		HideDefault:   true,
+		FileName:      "*.md",
+	}, &notes); err != nil {
+		return
+	}
+	expectation.Notes = strings.TrimSpace(notes)
+	if expectation.Notes == "" {
+		fmt.Println("✅ Notes cleared")
+		return
+	}
+	fmt.Println("✅ Updated notes")
+}
+
 func editTimes(expectation *models.MockExpectation) {
 	var times int
 	// Determine default value: 0 for unlimited or unset, otherwise current remaining times
@@ -564,7 +802,7 @@ func editTimes(expectation *models.MockExpectation) {
 	if expectation.Times != nil && !expectation.Times.Unlimited {
 		defaultTimes = expectation.Times.RemainingTimes
 	}
-	if err := survey.AskOne(&survey.Input{
+	if err := ask.One(&survey.Input{
 		Message: "Enter number of times this expectation should be matched (0 = unlimited):",
 		Default: fmt.Sprintf("%d", defaultTimes),
 		Help:    "Example: 0, 1, 5",
@@ -581,7 +819,7 @@ func editTimes(expectation *models.MockExpectation) {
 
 func editMethod(expectation *models.MockExpectation) {
 	var newMethod string
-	if err := survey.AskOne(&survey.Select{
+	if err := ask.One(&survey.Select{
 		Message: "Select HTTP method:",
 		Options: []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS", "HEAD"},
 		Default: expectation.HttpRequest.Method,
@@ -599,7 +837,7 @@ func editPath(expectation *models.MockExpectation) {
 
 func editStatusCode(expectation *models.MockExpectation) {
 	var statusCode string
-	if err := survey.AskOne(&survey.Input{
+	if err := ask.One(&survey.Input{
 		Message: "Enter status code:",
 		Default: fmt.Sprintf("%d", expectation.HttpResponse.StatusCode),
 		Help:    "Example: 200, 404, 500",
@@ -610,7 +848,7 @@ func editStatusCode(expectation *models.MockExpectation) {
 			if (newStatus == 204 || newStatus == 304) && expectation.HttpResponse.Body != nil {
 				fmt.Println("⚠️ Response body is not allowed for status code 204 or 304.")
 				var confirm bool
-				survey.AskOne(&survey.Confirm{
+				ask.One(&survey.Confirm{
 					Message: "Do you want to clear the existing response body?",
 					Default: true,
 				}, &confirm)
@@ -628,6 +866,15 @@ func editStatusCode(expectation *models.MockExpectation) {
 }
 
 func getCurrentBody(body any) string {
+	if data, contentType, ok := models.BinaryBodyBytes(body); ok {
+		if contentType == "" {
+			contentType = "no content type"
+		}
+		return fmt.Sprintf("<binary: %d bytes, %s>", len(data), contentType)
+	}
+	if fields, ok := builders.MultipartFields(body); ok {
+		return "multipart/form-data with " + builders.DescribeMultipartFields(fields)
+	}
 	currentBody := ""
 	if body, ok := body.(string); ok {
 		currentBody = body
@@ -667,7 +914,7 @@ func promtUserActionForBody(body any) (string, string) {
 	}
 	editOptions = append(editOptions, "done - Finish editing request body")
 	var editOption string
-	if err := survey.AskOne(&survey.Select{
+	if err := ask.One(&survey.Select{
 		Message: "What would you like to do?",
 		Options: editOptions,
 	}, &editOption); err != nil {
@@ -703,9 +950,9 @@ func editResponseBody(expectation *models.MockExpectation) {
 	for {
 		currentBody := getCurrentBody(expectation.HttpResponse.Body)
 		var editChoice string
-		if err := survey.AskOne(&survey.Select{
+		if err := ask.One(&survey.Select{
 			Message: "How would you like to edit the response body?",
-			Options: []string{"json - Edit as JSON", "template - Use JSON template", "view - View current body", "done - Finish editing response body"},
+			Options: []string{"json - Edit as JSON", "template - Use JSON template", "binary - Load a file or base64 data", "view - View current body", "done - Finish editing response body"},
 		}, &editChoice); err == nil {
 			editChoice = strings.Split(editChoice, " ")[0]
 			switch editChoice {
@@ -714,22 +961,34 @@ func editResponseBody(expectation *models.MockExpectation) {
 			case "json":
 				var jsonData interface{}
 				var newBody string
-				if err := survey.AskOne(&survey.Multiline{Message: "Enter JSON response body:"}, &newBody); err == nil {
+				if err := ask.One(&survey.Multiline{Message: "Enter JSON response body:"}, &newBody); err == nil {
 					if json.Unmarshal([]byte(newBody), &jsonData) == nil {
 						expectation.HttpResponse.Body = map[string]any{
 							"type": "JSON",
 							"json": jsonData,
 						}
+						expectation.HttpResponse.SchemaRef = ""
+						// A previous binary body left its media type behind
+						if i := findNameIndex(expectation.HttpResponse.Headers, "Content-Type"); i >= 0 && len(expectation.HttpResponse.Headers[i].Values) > 0 &&
+							models.IsBinaryContentType(expectation.HttpResponse.Headers[i].Values[0]) {
+							expectation.HttpResponse.Headers[i].Values = []string{"application/json"}
+						}
 						fmt.Println("✅ Updated JSON response body")
 					} else {
 						fmt.Println("❌ Invalid JSON")
 					}
 				}
 				return
+			case "binary":
+				if err := builders.CollectBinaryResponseBody(expectation); err != nil {
+					fmt.Printf("❌ Failed to set the binary body: %v\n", err)
+				}
+				return
 			case "template":
 				if err := builders.GenerateResponseTemplate(expectation); err != nil {
 					fmt.Printf("❌ Failed to generate response template: %v\n", err)
 				} else {
+					expectation.HttpResponse.SchemaRef = ""
 					fmt.Println("✅ Updated response body to use JSON template")
 				}
 				return
@@ -745,7 +1004,7 @@ func editResponseHeaders(expectation *models.MockExpectation) {
 	if expectation.HttpResponse.Headers == nil {
 		expectation.HttpResponse.Headers = []models.NameValues{}
 	}
-	editNameValuesList(&expectation.HttpResponse.Headers, "header")
+	editNameValuesList(&expectation.HttpResponse.Headers, "header", "")
 }
 
 func editRequestHeaders(expectation *models.MockExpectation) {
@@ -753,7 +1012,7 @@ func editRequestHeaders(expectation *models.MockExpectation) {
 	if expectation.HttpRequest.Headers == nil {
 		expectation.HttpRequest.Headers = []models.NameValues{}
 	}
-	editNameValuesList(&expectation.HttpRequest.Headers, "header")
+	editNameValuesList(&expectation.HttpRequest.Headers, "header", builders.PresetHeader)
 }
 
 // helper: find header index by name (case-insensitive)
@@ -784,7 +1043,9 @@ func parseCSVValues(s string) []string {
 
 // --- Generic NameValues editor helpers --------------------------------------
 
-func editNameValuesList(list *[]models.NameValues, nounSingular string) {
+// editNameValuesList edits request or response name/values; presetTarget, when set, offers
+// the matcher presets for request matchers
+func editNameValuesList(list *[]models.NameValues, nounSingular, presetTarget string) {
 	for {
 		var action string
 
@@ -792,13 +1053,16 @@ func editNameValuesList(list *[]models.NameValues, nounSingular string) {
 			fmt.Sprintf("add - Add new %s", nounSingular),
 			fmt.Sprintf("view - View current %ss", nounSingular),
 		}
+		if presetTarget != "" {
+			options = append(options, fmt.Sprintf("preset - Add a %s matching a preset (UUID, ISO date, Bearer JWT, ...)", nounSingular))
+		}
 		for _, nv := range *list {
 			options = append(options, fmt.Sprintf("edit:%s - Edit %s", nv.Name, nv.Name))
 			options = append(options, fmt.Sprintf("delete:%s - Delete %s", nv.Name, nv.Name))
 		}
 		options = append(options, fmt.Sprintf("done - Finish editing %ss", nounSingular))
 
-		if err := survey.AskOne(&survey.Select{Message: fmt.Sprintf("%s actions:", strings.Title(nounSingular)), Options: options}, &action); err != nil {
+		if err := ask.One(&survey.Select{Message: fmt.Sprintf("%s actions:", strings.Title(nounSingular)), Options: options}, &action); err != nil {
 			return
 		}
 
@@ -812,6 +1076,9 @@ func editNameValuesList(list *[]models.NameValues, nounSingular string) {
 		case actionToken == "add":
 			addNameValue(list, nounSingular)
 
+		case actionToken == "preset":
+			addPresetNameValue(list, nounSingular, presetTarget)
+
 		case actionToken == "view":
 			viewNameValues(*list, nounSingular)
 
@@ -842,14 +1109,14 @@ func editNameValuesList(list *[]models.NameValues, nounSingular string) {
 
 func addNameValue(list *[]models.NameValues, noun string) {
 	var name, valueCSV string
-	if err := survey.AskOne(&survey.Input{Message: fmt.Sprintf("%s name:", strings.Title(noun))}, &name); err != nil {
+	if err := ask.One(&survey.Input{Message: fmt.Sprintf("%s name:", strings.Title(noun))}, &name); err != nil {
 		return
 	}
 	name = strings.TrimSpace(name)
 	if name == "" {
 		return
 	}
-	if err := survey.AskOne(&survey.Input{Message: fmt.Sprintf("%s value (comma-separated for multiple):", strings.Title(noun))}, &valueCSV); err != nil {
+	if err := ask.One(&survey.Input{Message: fmt.Sprintf("%s value (comma-separated for multiple):", strings.Title(noun))}, &valueCSV); err != nil {
 		return
 	}
 	values := parseCSVValues(valueCSV)
@@ -865,6 +1132,24 @@ func addNameValue(list *[]models.NameValues, noun string) {
 	fmt.Printf("✅ Added %s %s\n", noun, name)
 }
 
+// addPresetNameValue adds a request matcher whose value is a matcher preset's regex
+func addPresetNameValue(list *[]models.NameValues, noun, target string) {
+	preset, err := builders.SelectMatcherPreset(target, "the "+noun, "")
+	if err != nil || preset == nil {
+		return
+	}
+	var name string
+	if err := ask.One(&survey.Input{
+		Message: fmt.Sprintf("%s name:", strings.Title(noun)),
+		Default: preset.Name,
+	}, &name, survey.WithValidator(survey.Required)); err != nil {
+		return
+	}
+	name = strings.TrimSpace(name)
+	builders.SetNameValues(list, name, []string{preset.Pattern})
+	fmt.Printf("✅ %s %s matches %s (%s)\n", strings.Title(noun), name, preset.Label, preset.Pattern)
+}
+
 func editNameValue(list *[]models.NameValues, name string, noun string) {
 	if *list == nil {
 		*list = []models.NameValues{}
@@ -877,7 +1162,7 @@ func editNameValue(list *[]models.NameValues, name string, noun string) {
 	}
 	defaultValue := strings.Join((*list)[idx].Values, ", ")
 	var newVal string
-	if err := survey.AskOne(&survey.Input{Message: fmt.Sprintf("New value for %s (comma-separated for multiple):", name), Default: defaultValue}, &newVal); err != nil {
+	if err := ask.One(&survey.Input{Message: fmt.Sprintf("New value for %s (comma-separated for multiple):", name), Default: defaultValue}, &newVal); err != nil {
 		return
 	}
 	(*list)[idx].Values = parseCSVValues(newVal)
@@ -900,7 +1185,7 @@ func editQueryParams(expectation *models.MockExpectation) {
 	if expectation.HttpRequest.QueryStringParameters == nil {
 		expectation.HttpRequest.QueryStringParameters = []models.NameValues{}
 	}
-	editNameValuesList(&expectation.HttpRequest.QueryStringParameters, "parameter")
+	editNameValuesList(&expectation.HttpRequest.QueryStringParameters, "parameter", builders.PresetQuery)
 }
 
 // Removed legacy map-based query param editors; handled by generic NameValues editor.
@@ -0,0 +1,185 @@
+package expectations
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// editorErrorPrefix marks the lines reporting why the last attempt was rejected; they are
+// stripped before parsing, so the file can be fixed and saved as is
+const editorErrorPrefix = "// "
+
+// openInEditor round-trips expectations through $EDITOR (or $VISUAL) as indented JSON, like
+// kubectl edit. A file that does not parse or that check rejects is reopened with the error on
+// top. It returns nil when the file is saved unchanged or emptied.
+func openInEditor(expectations any, check func([]byte) error) ([]byte, error) {
+	original, err := json.MarshalIndent(expectations, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	content := string(original)
+	for {
+		var edited string
+		if err := ask.One(&survey.Editor{
+			Message:       "Edit as JSON (save and close the editor to apply):",
+			Default:       content,
+			AppendDefault: true,
+			HideDefault:   true,
+			FileName:      "automock-*.json",
+		}, &edited); err != nil {
+			return nil, err
+		}
+		data := []byte(stripEditorErrors(edited))
+		if len(bytes.TrimSpace(data)) == 0 || jsonEqual(data, original) {
+			return nil, nil
+		}
+		if err := check(data); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			var retry bool
+			if err := ask.One(&survey.Confirm{Message: "Reopen the editor to fix it?", Default: true}, &retry); err != nil {
+				return nil, err
+			}
+			if !retry {
+				return nil, nil
+			}
+			content = editorErrors(err) + string(data)
+			continue
+		}
+		return data, nil
+	}
+}
+
+// editorErrors renders err as comment lines above the JSON
+func editorErrors(err error) string {
+	var b strings.Builder
+	b.WriteString(editorErrorPrefix + "Please fix the problems below; these lines are ignored.\n")
+	for _, line := range strings.Split(err.Error(), "\n") {
+		b.WriteString(editorErrorPrefix + line + "\n")
+	}
+	return b.String()
+}
+
+// stripEditorErrors drops the leading comment lines added by editorErrors
+func stripEditorErrors(s string) string {
+	lines := strings.Split(s, "\n")
+	for len(lines) > 0 && strings.HasPrefix(strings.TrimSpace(lines[0]), strings.TrimSpace(editorErrorPrefix)) {
+		lines = lines[1:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// jsonEqual reports whether two JSON documents hold the same value, ignoring formatting
+func jsonEqual(a, b []byte) bool {
+	var va, vb any
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	ja, _ := json.Marshal(va)
+	jb, _ := json.Marshal(vb)
+	return bytes.Equal(ja, jb)
+}
+
+// decodeExpectations parses edited JSON strictly, so a misspelled field is reported instead of dropped
+func decodeExpectations(data []byte, out any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(out); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	return nil
+}
+
+// checkEdited validates the expectations a save would store: the structure the store requires,
+// then the problems automock validate reports as errors. Warnings are printed but accepted.
+func (em *ExpectationManager) checkEdited(expectations []models.MockExpectation) error {
+	candidate := models.MockConfiguration{Expectations: expectations}
+	if em.library != nil {
+		candidate.Metadata = em.library.Metadata
+	}
+	if candidate.Metadata.ProjectID == "" {
+		candidate.Metadata.ProjectID = em.projectName
+	}
+	if err := models.ValidateConfiguration(&candidate); err != nil {
+		return err
+	}
+	report := models.Validate(expectations)
+	if err := report.Err(); err != nil {
+		return err
+	}
+	if len(report) > 0 {
+		fmt.Print(report.Format())
+	}
+	return nil
+}
+
+// editExpectationInEditor opens one expectation in $EDITOR and applies the result
+func (em *ExpectationManager) editExpectationInEditor(exp *models.MockExpectation) {
+	index := -1
+	if em.library != nil {
+		for i := range em.library.Expectations {
+			if &em.library.Expectations[i] == exp {
+				index = i
+			}
+		}
+	}
+	var edited models.MockExpectation
+	data, err := openInEditor(exp, func(data []byte) error {
+		edited = models.MockExpectation{}
+		if err := decodeExpectations(data, &edited); err != nil {
+			return err
+		}
+		all := []models.MockExpectation{edited}
+		if index >= 0 {
+			all = append([]models.MockExpectation(nil), em.library.Expectations...)
+			all[index] = edited
+		}
+		return em.checkEdited(all)
+	})
+	if err != nil {
+		fmt.Printf("❌ Editor failed: %v\n", err)
+		return
+	}
+	if data == nil {
+		fmt.Println("✅ No changes")
+		return
+	}
+	*exp = edited
+	fmt.Println("✅ Expectation updated from the editor")
+}
+
+// editAllInEditor opens every expectation in $EDITOR as one JSON array and returns the edited
+// list, or nil when nothing changed. Expectations that differ from before are marked edited.
+func (em *ExpectationManager) editAllInEditor(expectations []models.MockExpectation) ([]models.MockExpectation, error) {
+	var edited []models.MockExpectation
+	data, err := openInEditor(expectations, func(data []byte) error {
+		edited = nil
+		if err := decodeExpectations(data, &edited); err != nil {
+			return err
+		}
+		return em.checkEdited(edited)
+	})
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	before := map[string]bool{}
+	for _, exp := range expectations {
+		b, _ := json.Marshal(exp)
+		before[string(b)] = true
+	}
+	changed := 0
+	for i := range edited {
+		if b, _ := json.Marshal(edited[i]); !before[string(b)] {
+			edited[i].MarkEdited()
+			changed++
+		}
+	}
+	fmt.Printf("✅ %d expectation(s) now, %d changed or added\n", len(edited), changed)
+	return edited, nil
+}
@@ -0,0 +1,50 @@
+package expectations
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+func TestEditorErrorsRoundTrip(t *testing.T) {
+	body := "[\n  {\"priority\": 1}\n]"
+	content := editorErrors(errors.New("expectations[0].httpRequest: HTTP request is required\nsecond line")) + body
+	if !strings.HasPrefix(content, "// Please fix") || !strings.Contains(content, "// second line\n") {
+		t.Fatalf("unexpected error header:\n%s", content)
+	}
+	if got := stripEditorErrors(content); got != body {
+		t.Errorf("stripEditorErrors = %q, want %q", got, body)
+	}
+	if !jsonEqual([]byte(body), []byte(`[{"priority":1}]`)) || jsonEqual([]byte(body), []byte(`[{"priority":2}]`)) {
+		t.Error("jsonEqual should ignore formatting only")
+	}
+}
+
+func TestCheckEdited(t *testing.T) {
+	em := &ExpectationManager{projectName: "users"}
+
+	var exp models.MockExpectation
+	if err := decodeExpectations([]byte(`{"httpRequest": {"method": "GET", "path": "/users"}, "httpResponse": {"statusCode": 200}, "prority": 5}`), &exp); err == nil {
+		t.Error("a misspelled field was accepted")
+	}
+
+	missing := []models.MockExpectation{{HttpRequest: &models.HttpRequest{Method: "GET", Path: "/users"}}}
+	if err := em.checkEdited(missing); err == nil || !strings.Contains(err.Error(), "httpResponse") {
+		t.Errorf("missing response: err = %v", err)
+	}
+
+	badRegex := []models.MockExpectation{{
+		HttpRequest:  &models.HttpRequest{Method: "POST", Path: "/search", Body: map[string]any{"type": "REGEX", "regex": "name=(a"}},
+		HttpResponse: &models.HttpResponse{StatusCode: 200},
+	}}
+	if err := em.checkEdited(badRegex); err == nil {
+		t.Error("an invalid body regex was accepted")
+	}
+
+	ok := []models.MockExpectation{{HttpRequest: &models.HttpRequest{Method: "GET", Path: "/users"}, HttpResponse: &models.HttpResponse{StatusCode: 204}}}
+	if err := em.checkEdited(ok); err != nil {
+		t.Errorf("valid expectation rejected: %v", err)
+	}
+}
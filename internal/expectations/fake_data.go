@@ -0,0 +1,185 @@
+package expectations
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/faker"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// ManageFakeData adds and removes the project's fake-data generators and sets the seed used
+// to fill in ${...} placeholders. Returns nil when nothing changed.
+func (em *ExpectationManager) ManageFakeData(config *models.MockConfiguration) (*models.MockConfiguration, error) {
+	if config == nil {
+		return nil, fmt.Errorf("no configuration loaded for project %s", em.projectName)
+	}
+	fmt.Println("\n🎲 FAKE DATA GENERATORS")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("💡 Response bodies, headers and cookies may use ${name} or ${name:arg}; values are generated on save")
+	for _, e := range faker.Registered() {
+		fmt.Printf("   ${%s} %s\n", e.Name, e.Description)
+	}
+
+	data := models.FakeData{}
+	if config.FakeData != nil {
+		data = *config.FakeData
+		data.Generators = append([]models.DataGenerator(nil), config.FakeData.Generators...)
+	}
+	changed := false
+	for {
+		seed := "random"
+		if data.Seed != 0 {
+			seed = strconv.FormatInt(data.Seed, 10)
+		}
+		options := []string{
+			"done - Finish",
+			"add - Add or replace a project generator",
+			fmt.Sprintf("seed - Set the seed (now: %s)", seed),
+			"try - Expand a sample text",
+		}
+		for _, g := range data.Generators {
+			options = append(options, fmt.Sprintf("remove:%s - Remove ${%s} (%s)", g.Name, g.Name, describeGenerator(g)))
+		}
+
+		var action string
+		if err := ask.One(&survey.Select{
+			Message:  fmt.Sprintf("Project generators (%d):", len(data.Generators)),
+			Options:  options,
+			PageSize: 14,
+		}, &action); err != nil {
+			return nil, err
+		}
+
+		token := strings.Fields(action)[0]
+		switch {
+		case token == "done":
+			if !changed {
+				return nil, nil
+			}
+			if data.Seed == 0 && len(data.Generators) == 0 {
+				config.FakeData = nil
+			} else {
+				config.FakeData = &data
+			}
+			return config, nil
+		case token == "add":
+			g, err := askDataGenerator()
+			if err != nil {
+				return nil, err
+			}
+			setDataGenerator(&data, g)
+			changed = true
+			fmt.Printf("✅ ${%s}: %s\n", g.Name, describeGenerator(g))
+		case token == "seed":
+			if err := ask.One(&survey.Input{
+				Message: "Seed (0 = random values on every save):",
+				Default: strconv.FormatInt(data.Seed, 10),
+				Help:    "With a seed, saving the same expectations generates the same values (dates start at " + faker.SeededNow.Format("2006-01-02") + ")",
+			}, &seed, survey.WithValidator(func(ans interface{}) error {
+				_, err := strconv.ParseInt(strings.TrimSpace(ans.(string)), 10, 64)
+				return err
+			})); err != nil {
+				return nil, err
+			}
+			data.Seed, _ = strconv.ParseInt(strings.TrimSpace(seed), 10, 64)
+			changed = true
+		case token == "try":
+			var sample string
+			if err := ask.One(&survey.Input{
+				Message: "Sample text:",
+				Default: `{"id": "${uuid}", "name": "${name}", "createdAt": "${datetime:-3d}"}`,
+			}, &sample); err != nil {
+				return nil, err
+			}
+			preview := &models.MockConfiguration{FakeData: &data}
+			f, err := preview.Faker()
+			if err != nil {
+				fmt.Printf("❌ %v\n", err)
+				continue
+			}
+			expanded, err := f.Expand(sample)
+			if err != nil {
+				fmt.Printf("❌ %v\n", err)
+				continue
+			}
+			fmt.Printf("   → %s\n", expanded)
+		case strings.HasPrefix(token, "remove:"):
+			name := strings.TrimPrefix(token, "remove:")
+			for i, g := range data.Generators {
+				if g.Name == name {
+					data.Generators = append(data.Generators[:i], data.Generators[i+1:]...)
+					changed = true
+					break
+				}
+			}
+		}
+	}
+}
+
+// askDataGenerator captures a generator's name and its values or pattern
+func askDataGenerator() (models.DataGenerator, error) {
+	var g models.DataGenerator
+	if err := ask.One(&survey.Input{
+		Message: "Generator name:",
+		Help:    "Used as ${name}; a built-in generator with the same name is replaced in this project",
+	}, &g.Name, survey.WithValidator(func(ans interface{}) error {
+		return models.DataGenerator{Name: strings.TrimSpace(ans.(string)), Pattern: "#"}.Validate()
+	})); err != nil {
+		return g, err
+	}
+	g.Name = strings.TrimSpace(g.Name)
+	if err := ask.One(&survey.Input{Message: "Description (optional):"}, &g.Description); err != nil {
+		return g, err
+	}
+
+	var kind string
+	if err := ask.One(&survey.Select{
+		Message: "Values:",
+		Options: []string{
+			"values - Pick one of a list (e.g. active, pending, closed)",
+			"pattern - Fill a pattern: # digit, ? letter, * either (e.g. ORD-####)",
+		},
+	}, &kind); err != nil {
+		return g, err
+	}
+	if strings.HasPrefix(kind, "values") {
+		var list string
+		if err := ask.One(&survey.Input{
+			Message: "Values (comma-separated):",
+		}, &list, survey.WithValidator(survey.Required)); err != nil {
+			return g, err
+		}
+		for _, v := range strings.Split(list, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				g.Values = append(g.Values, v)
+			}
+		}
+	} else if err := ask.One(&survey.Input{
+		Message: "Pattern:",
+	}, &g.Pattern, survey.WithValidator(survey.Required)); err != nil {
+		return g, err
+	}
+	return g, g.Validate()
+}
+
+// setDataGenerator replaces the generator with the same name or appends it
+func setDataGenerator(data *models.FakeData, g models.DataGenerator) {
+	for i, existing := range data.Generators {
+		if existing.Name == g.Name {
+			data.Generators[i] = g
+			return
+		}
+	}
+	data.Generators = append(data.Generators, g)
+}
+
+func describeGenerator(g models.DataGenerator) string {
+	if g.Pattern != "" {
+		return "pattern " + g.Pattern
+	}
+	return "one of " + strings.Join(g.Values, ", ")
+}
@@ -0,0 +1,213 @@
+package expectations
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// ManageMaskingProfiles adds and removes the project's masking profiles, used by
+// deploy --mask and download --mask. Returns nil when nothing changed.
+func (em *ExpectationManager) ManageMaskingProfiles(config *models.MockConfiguration) (*models.MockConfiguration, error) {
+	if config == nil {
+		return nil, fmt.Errorf("no configuration loaded for project %s", em.projectName)
+	}
+	fmt.Println("\n🎭 MASKING PROFILES")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("💡 Deploy or download with --mask <profile> to rewrite response bodies, e.g. for a demo environment")
+	for _, p := range models.MaskingPresets {
+		fmt.Printf("   built-in %-8s %s\n", p.Name, p.Description)
+	}
+
+	changed := false
+	for {
+		options := []string{"done - Finish", "add - Add or replace a profile"}
+		for _, p := range config.MaskingProfiles {
+			options = append(options, fmt.Sprintf("preview:%s - Preview %s on this project (%d rule(s))", p.Name, p.Name, len(p.Rules)))
+			options = append(options, fmt.Sprintf("remove:%s - Remove %s", p.Name, p.Name))
+		}
+		for i, p := range models.MaskingPresets {
+			if found, _ := config.FindMaskingProfile(p.Name); found == &models.MaskingPresets[i] { // not overridden
+				options = append(options, fmt.Sprintf("preview:%s - Preview built-in %s on this project", p.Name, p.Name))
+			}
+		}
+
+		var action string
+		if err := ask.One(&survey.Select{
+			Message:  fmt.Sprintf("Masking profiles (%d):", len(config.MaskingProfiles)),
+			Options:  options,
+			PageSize: 14,
+		}, &action); err != nil {
+			return nil, err
+		}
+
+		token := strings.Fields(action)[0]
+		switch {
+		case token == "done":
+			if !changed {
+				return nil, nil
+			}
+			return config, nil
+		case token == "add":
+			profile, err := askMaskingProfile()
+			if err != nil {
+				return nil, err
+			}
+			setMaskingProfile(config, profile)
+			changed = true
+			fmt.Printf("✅ Profile %s: %d rule(s)\n", profile.Name, len(profile.Rules))
+		case strings.HasPrefix(token, "preview:"):
+			profile, err := config.FindMaskingProfile(strings.TrimPrefix(token, "preview:"))
+			if err != nil {
+				return nil, err
+			}
+			previewMasking(config, profile)
+		case strings.HasPrefix(token, "remove:"):
+			name := strings.TrimPrefix(token, "remove:")
+			for i, p := range config.MaskingProfiles {
+				if p.Name == name {
+					config.MaskingProfiles = append(config.MaskingProfiles[:i], config.MaskingProfiles[i+1:]...)
+					changed = true
+					break
+				}
+			}
+		}
+	}
+}
+
+// askMaskingProfile captures a profile's name and rules
+func askMaskingProfile() (models.MaskingProfile, error) {
+	var profile models.MaskingProfile
+	if err := ask.One(&survey.Input{
+		Message: "Profile name:",
+		Help:    "Used as deploy --mask <name>; a built-in profile with the same name is replaced",
+	}, &profile.Name, survey.WithValidator(func(ans interface{}) error {
+		name := strings.TrimSpace(ans.(string))
+		if name == "" || strings.ContainsAny(name, " \t") {
+			return fmt.Errorf("enter a name without spaces")
+		}
+		return nil
+	})); err != nil {
+		return profile, err
+	}
+	profile.Name = strings.TrimSpace(profile.Name)
+	if err := ask.One(&survey.Input{Message: "Description (optional):"}, &profile.Description); err != nil {
+		return profile, err
+	}
+
+	for {
+		rule, err := askMaskRule()
+		if err != nil {
+			return profile, err
+		}
+		profile.Rules = append(profile.Rules, rule)
+
+		var more bool
+		if err := ask.One(&survey.Confirm{Message: "Add another rule?", Default: true}, &more); err != nil {
+			return profile, err
+		}
+		if !more {
+			break
+		}
+	}
+	return profile, profile.Validate()
+}
+
+func askMaskRule() (models.MaskRule, error) {
+	var rule models.MaskRule
+	var match string
+	if err := ask.One(&survey.Select{
+		Message: "Match:",
+		Options: []string{
+			"email - Email addresses",
+			"token - Tokens, secrets and passwords (by field name), JWTs and bearer values",
+			"name - Person names (firstName, lastName, fullName, ...; \"name\" next to an email or phone)",
+			"phone - Phone numbers (by field name, or +<digits>)",
+			"card - Card numbers (13-19 digits passing the Luhn check)",
+			"field - Any value under matching field names",
+		},
+	}, &match); err != nil {
+		return rule, err
+	}
+	rule.Match = strings.Fields(match)[0]
+	if rule.Match == "field" {
+		var glob string
+		if err := ask.One(&survey.Input{
+			Message: "Field name pattern:",
+			Help:    "Case-insensitive glob, e.g. accountNumber, *Id or internal*",
+		}, &glob, survey.WithValidator(survey.Required)); err != nil {
+			return rule, err
+		}
+		rule.Match = "field:" + strings.TrimSpace(glob)
+	}
+
+	var action string
+	if err := ask.One(&survey.Select{
+		Message: "Action:",
+		Options: []string{
+			"mask - Hide all but a few characters (j***@***.com, ********4242)",
+			"fake - Replace with a stable fake value (Alex Morgan, alex.morgan@example.com)",
+			"truncate - Keep the first characters (eyJhbG…)",
+			"redact - Replace with " + models.MaskRedacted,
+		},
+	}, &action); err != nil {
+		return rule, err
+	}
+	rule.Action = strings.Fields(action)[0]
+	return rule, nil
+}
+
+// setMaskingProfile replaces the profile with the same name or appends it
+func setMaskingProfile(config *models.MockConfiguration, profile models.MaskingProfile) {
+	for i, p := range config.MaskingProfiles {
+		if strings.EqualFold(p.Name, profile.Name) {
+			config.MaskingProfiles[i] = profile
+			return
+		}
+	}
+	config.MaskingProfiles = append(config.MaskingProfiles, profile)
+}
+
+// previewMasking shows what a profile would change, without touching the project
+func previewMasking(config *models.MockConfiguration, profile *models.MaskingProfile) {
+	fmt.Printf("\n🎭 %s:", profile.Name)
+	for _, r := range profile.Rules {
+		fmt.Printf(" [%s]", r)
+	}
+	fmt.Println()
+	shown := 0
+	for _, exp := range config.Expectations {
+		if exp.HttpResponse == nil || exp.HttpResponse.Body == nil {
+			continue
+		}
+		masked, n := profile.MaskBody(exp.HttpResponse.Body)
+		if n == 0 {
+			continue
+		}
+		if shown < 3 {
+			text, _ := masked.(string)
+			if text == "" {
+				b, _ := json.Marshal(masked)
+				text = string(b)
+			}
+			if len(text) > 200 {
+				text = text[:197] + "..."
+			}
+			label := "(any request)"
+			if exp.HttpRequest != nil {
+				label = exp.HttpRequest.Method + " " + exp.HttpRequest.Path
+			}
+			fmt.Printf("\n   %s (%d value(s)):\n   %s\n", label, n, text)
+		}
+		shown++
+	}
+	if shown == 0 {
+		fmt.Println("   Nothing in this project's response bodies matches.")
+	} else if shown > 3 {
+		fmt.Printf("\n   ...and %d more response body(ies)\n", shown-3)
+	}
+}
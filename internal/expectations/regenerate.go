@@ -0,0 +1,168 @@
+package expectations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/mcp"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// regenerateResponseBodyWithAI asks an AI provider to rewrite the response body of a
+// single expectation. Only the body is replaced; the request matcher, status code,
+// headers and every other expectation in the configuration are left untouched.
+func regenerateResponseBodyWithAI(expectation *models.MockExpectation, projectName string) {
+	if expectation.HttpRequest == nil || expectation.HttpResponse == nil {
+		fmt.Println("⚠️ Expectation has no request/response to regenerate from.")
+		return
+	}
+	if expectation.HttpResponse.StatusCode == 204 || expectation.HttpResponse.StatusCode == 304 {
+		fmt.Println("⚠️ Response body is not allowed for status code 204 or 304, skipping regeneration. Change the status code first.")
+		return
+	}
+
+	provider, err := SelectAvailableProvider()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+
+	var instruction string
+	if err := ask.One(&survey.Multiline{
+		Message: "Describe the change you want in the response body:",
+		Help:    "Example: add a 'createdAt' ISO timestamp and return 3 items instead of 1",
+	}, &instruction); err != nil {
+		return
+	}
+	if strings.TrimSpace(instruction) == "" {
+		fmt.Println("❌ Instruction cannot be empty.")
+		return
+	}
+
+	body, err := GenerateResponseBody(context.Background(), expectation, provider, projectName, instruction)
+	fmt.Println()
+	if err != nil {
+		fmt.Printf("❌ AI regeneration failed: %v\n", err)
+		return
+	}
+
+	preview, _ := json.MarshalIndent(body, "", "  ")
+	fmt.Println("\n📦 Regenerated response body:")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println(string(preview))
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	var apply bool
+	if err := ask.One(&survey.Confirm{
+		Message: "Replace the current response body with this one?",
+		Default: true,
+	}, &apply); err != nil || !apply {
+		fmt.Println("✅ Response body left unchanged.")
+		return
+	}
+
+	expectation.HttpResponse.Body = map[string]any{
+		"type": "JSON",
+		"json": body,
+	}
+	expectation.HttpResponse.SchemaRef = ""
+	fmt.Println("✅ Updated response body from AI")
+}
+
+// GenerateResponseBody asks an AI provider for the response body of an expectation, written
+// for its request and the given instruction; the expectation itself is not changed
+func GenerateResponseBody(ctx context.Context, expectation *models.MockExpectation, provider, projectName, instruction string) (any, error) {
+	res, err := mcp.GenerateWithProvider(ctx, buildRegenerateBodyPrompt(expectation, instruction), provider, projectName)
+	if err != nil {
+		return nil, err
+	}
+	return parseRegeneratedBody(res.MockServerJSON)
+}
+
+// SelectAvailableProvider returns a configured AI provider, prompting when more than one is available.
+func SelectAvailableProvider() (string, error) {
+	var available []string
+	for _, pi := range mcp.ListProviders() {
+		if pi.Available {
+			available = append(available, pi.Name)
+		}
+	}
+	switch len(available) {
+	case 0:
+		return "", fmt.Errorf("no AI provider configured; set ANTHROPIC_API_KEY, OPENAI_API_KEY or GEMINI_API_KEY, or AWS credentials for Bedrock")
+	case 1:
+		fmt.Printf("🤖 Using provider: %s\n", available[0])
+		return available[0], nil
+	}
+
+	var provider string
+	if err := ask.One(&survey.Select{
+		Message: "Choose an AI provider:",
+		Options: available,
+		Default: available[0],
+	}, &provider); err != nil {
+		return "", err
+	}
+	return provider, nil
+}
+
+// buildRegenerateBodyPrompt gives the model the request context and current body so the
+// rewrite stays consistent with what the expectation matches on.
+func buildRegenerateBodyPrompt(expectation *models.MockExpectation, instruction string) string {
+	requestJSON, _ := json.MarshalIndent(expectation.HttpRequest, "", "  ")
+	currentBody := getCurrentBody(expectation.HttpResponse.Body)
+	if currentBody == "" {
+		currentBody = "(none)"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Return ONLY the JSON value to use as an HTTP response body. No prose, no markdown, no code fences.\n")
+	sb.WriteString("Do not wrap it in a MockServer envelope ({\"type\":\"JSON\",...}); return the body itself.\n\n")
+	if expectation.Description != "" {
+		sb.WriteString("Endpoint summary: " + expectation.Description + "\n")
+	}
+	sb.WriteString(fmt.Sprintf("Response status code: %d\n\n", expectation.HttpResponse.StatusCode))
+	sb.WriteString("Matched request:\n")
+	sb.Write(requestJSON)
+	sb.WriteString("\n\nCurrent response body:\n")
+	sb.WriteString(currentBody)
+	sb.WriteString("\n\nRequested change:\n")
+	sb.WriteString(strings.TrimSpace(instruction))
+	sb.WriteString("\n\nOutput strictly the raw JSON body. Nothing else.\n")
+	return sb.String()
+}
+
+// parseRegeneratedBody decodes the model output, unwrapping a MockServer JSON body
+// envelope if the model returned one despite the instructions.
+func parseRegeneratedBody(raw string) (any, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, fmt.Errorf("provider returned an empty body")
+	}
+	var body any
+	if err := json.Unmarshal([]byte(raw), &body); err != nil {
+		return nil, &models.JSONValidationError{
+			Context: "AI regenerated response body",
+			Content: raw,
+			Cause:   err,
+		}
+	}
+	if m, ok := body.(map[string]any); ok {
+		if t, _ := m["type"].(string); strings.EqualFold(t, "JSON") {
+			if inner, ok := m["json"]; ok {
+				if s, ok := inner.(string); ok {
+					var decoded any
+					if json.Unmarshal([]byte(s), &decoded) == nil {
+						return decoded, nil
+					}
+				}
+				return inner, nil
+			}
+		}
+	}
+	return body, nil
+}
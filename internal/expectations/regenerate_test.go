@@ -0,0 +1,56 @@
+package expectations
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+func TestParseRegeneratedBody(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    any
+		wantErr string // error substring
+		invalid bool   // the error is a JSONValidationError carrying the output
+	}{
+		{name: "empty", raw: "  \n", wantErr: "empty body"},
+		{name: "invalid JSON", raw: `{"id": 1,}`, wantErr: "AI regenerated response body", invalid: true},
+		{name: "prose", raw: "Here is the body you asked for", wantErr: "AI regenerated response body", invalid: true},
+		{name: "object", raw: ` {"id": 1} `, want: map[string]any{"id": float64(1)}},
+		{name: "envelope", raw: `{"type": "JSON", "json": {"id": 1}}`, want: map[string]any{"id": float64(1)}},
+		{name: "lowercase envelope", raw: `{"type": "json", "json": [1, 2]}`, want: []any{float64(1), float64(2)}},
+		{name: "envelope with encoded JSON", raw: `{"type": "JSON", "json": "{\"id\": 1}"}`, want: map[string]any{"id": float64(1)}},
+		{name: "envelope with plain string", raw: `{"type": "JSON", "json": "not json"}`, want: "not json"},
+		{name: "other envelope kept", raw: `{"type": "STRING", "string": "hi"}`, want: map[string]any{"type": "STRING", "string": "hi"}},
+		{name: "envelope without json", raw: `{"type": "JSON"}`, want: map[string]any{"type": "JSON"}},
+		{name: "array", raw: `[{"id": 1}]`, want: []any{map[string]any{"id": float64(1)}}},
+		{name: "string", raw: `"ok"`, want: "ok"},
+		{name: "number", raw: `42`, want: float64(42)},
+		{name: "null", raw: `null`, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRegeneratedBody(tt.raw)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				var jsonErr *models.JSONValidationError
+				if tt.invalid && (!errors.As(err, &jsonErr) || jsonErr.Content != strings.TrimSpace(tt.raw)) {
+					t.Errorf("error %#v is not a JSONValidationError with the output", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("body = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package expectations
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+var schemaNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// ManageSchemas lets the user register, view and delete named JSON Schemas for the project.
+// Returns nil when nothing changed.
+func (em *ExpectationManager) ManageSchemas(config *models.MockConfiguration) (*models.MockConfiguration, error) {
+	fmt.Println("\n📐 SCHEMA LIBRARY")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("💡 Reference a schema from a response body with {\"$ref\": \"schemas/<Name>\"}")
+
+	if config == nil {
+		return nil, fmt.Errorf("no configuration loaded for project %s", em.projectName)
+	}
+	if config.Schemas == nil {
+		config.Schemas = map[string]any{}
+	}
+
+	changed := false
+	for {
+		names := config.SchemaNames()
+		options := []string{"add - Register or replace a schema"}
+		for _, name := range names {
+			refs := len(config.SchemaReferences(name))
+			options = append(options, fmt.Sprintf("view:%s - View %s (%d reference(s))", name, name, refs))
+			options = append(options, fmt.Sprintf("delete:%s - Delete %s", name, name))
+		}
+		options = append(options, "done - Finish managing schemas")
+
+		var action string
+		if err := ask.One(&survey.Select{
+			Message:  fmt.Sprintf("Schemas (%d registered):", len(names)),
+			Options:  options,
+			PageSize: 12,
+		}, &action); err != nil {
+			return nil, err
+		}
+
+		token := strings.Fields(action)[0]
+		switch {
+		case token == "add":
+			if addSchema(config) {
+				changed = true
+			}
+		case token == "done":
+			if !changed {
+				return nil, nil
+			}
+			return config, nil
+		case strings.HasPrefix(token, "view:"):
+			viewSchema(config, strings.TrimPrefix(token, "view:"))
+		case strings.HasPrefix(token, "delete:"):
+			if deleteSchema(config, strings.TrimPrefix(token, "delete:")) {
+				changed = true
+			}
+		}
+	}
+}
+
+func addSchema(config *models.MockConfiguration) bool {
+	var name string
+	if err := ask.One(&survey.Input{
+		Message: "Schema name:",
+		Help:    "Example: User, Order, Error",
+	}, &name, survey.WithValidator(func(ans interface{}) error {
+		if !schemaNamePattern.MatchString(strings.TrimSpace(ans.(string))) {
+			return fmt.Errorf("use letters, digits, '-' or '_' and start with a letter")
+		}
+		return nil
+	})); err != nil {
+		return false
+	}
+	name = strings.TrimSpace(name)
+
+	var raw string
+	if err := ask.One(&survey.Multiline{
+		Message: fmt.Sprintf("Paste JSON Schema for %s:", name),
+		Help:    "Add \"example\" values to control the rendered body; nested schemas can use {\"$ref\": \"schemas/<Name>\"}",
+	}, &raw); err != nil {
+		return false
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(strings.TrimSpace(raw)), &schema); err != nil {
+		fmt.Printf("❌ Invalid JSON Schema: %v\n", err)
+		return false
+	}
+
+	// Render once so broken references surface before anything is saved
+	library := make(map[string]any, len(config.Schemas)+1)
+	for k, v := range config.Schemas {
+		library[k] = v
+	}
+	library[name] = schema
+	example, err := models.ExampleFromSchema(schema, library)
+	if err != nil {
+		fmt.Printf("❌ Schema cannot be rendered: %v\n", err)
+		return false
+	}
+
+	preview, _ := json.MarshalIndent(example, "", "  ")
+	fmt.Printf("\n📄 Rendered example for %s:\n%s\n\n", name, string(preview))
+
+	if _, exists := config.Schemas[name]; exists {
+		refs := len(config.SchemaReferences(name))
+		fmt.Printf("⚠️  Replacing %s will update %d referencing expectation(s) on next save/deploy.\n", name, refs)
+	}
+	config.Schemas[name] = schema
+	fmt.Printf("✅ Registered schema %s\n", name)
+	return true
+}
+
+func viewSchema(config *models.MockConfiguration, name string) {
+	schema, ok := config.Schemas[name]
+	if !ok {
+		fmt.Printf("⚠️ Schema %s not found\n", name)
+		return
+	}
+	out, _ := json.MarshalIndent(schema, "", "  ")
+	fmt.Printf("\n📐 %s%s\n%s\n", models.SchemaRefPrefix, name, string(out))
+	if refs := config.SchemaReferences(name); len(refs) > 0 {
+		fmt.Println("🔗 Referenced by:")
+		for _, i := range refs {
+			req := config.Expectations[i].HttpRequest
+			fmt.Printf("   • %s %s\n", req.Method, req.Path)
+		}
+	}
+	fmt.Println()
+}
+
+func deleteSchema(config *models.MockConfiguration, name string) bool {
+	if refs := config.SchemaReferences(name); len(refs) > 0 {
+		fmt.Printf("❌ Schema %s is referenced by %d expectation(s); change those responses first.\n", name, len(refs))
+		return false
+	}
+	delete(config.Schemas, name)
+	fmt.Printf("✅ Deleted schema %s\n", name)
+	return true
+}
+
+// editResponseSchemaRef binds (or unbinds) an expectation's response body to a project schema
+func editResponseSchemaRef(expectation *models.MockExpectation, schemas map[string]any) {
+	if len(schemas) == 0 {
+		fmt.Println("⚠️ No schemas registered for this project. Use 'schemas' from the project menu first.")
+		return
+	}
+	lib := &models.MockConfiguration{Schemas: schemas}
+	options := lib.SchemaNames()
+	if expectation.HttpResponse.SchemaRef != "" {
+		options = append(options, "none - Detach schema (keep current body)")
+	}
+
+	prompt := &survey.Select{
+		Message: "Render response body from schema:",
+		Options: options,
+	}
+	if current := models.SchemaRefName(expectation.HttpResponse.SchemaRef); current != "" {
+		if _, ok := schemas[current]; ok {
+			prompt.Default = current
+		}
+	}
+	var choice string
+	if err := ask.One(prompt, &choice); err != nil {
+		return
+	}
+	if strings.HasPrefix(choice, "none") {
+		expectation.HttpResponse.SchemaRef = ""
+		fmt.Println("✅ Response body detached from schema")
+		return
+	}
+
+	example, err := models.ExampleFromSchema(schemas[choice], schemas)
+	if err != nil {
+		fmt.Printf("❌ Schema cannot be rendered: %v\n", err)
+		return
+	}
+	expectation.HttpResponse.SchemaRef = models.SchemaRefPrefix + choice
+	expectation.HttpResponse.Body = map[string]any{
+		"type": "JSON",
+		"json": example,
+	}
+	fmt.Printf("✅ Response body now rendered from %s%s\n", models.SchemaRefPrefix, choice)
+}
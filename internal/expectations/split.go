@@ -0,0 +1,131 @@
+package expectations
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+var splitNameUnsafe = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// SplitFileName derives the per-expectation file name, e.g. GET /users/{id} → GET_users_id.json.
+// Non-200 responses get the status as suffix so variants sit next to each other.
+func SplitFileName(exp *models.MockExpectation) string {
+	method, path := "ANY", ""
+	if exp.HttpRequest != nil {
+		if exp.HttpRequest.Method != "" {
+			method = strings.ToUpper(exp.HttpRequest.Method)
+		}
+		path = exp.HttpRequest.Path
+	}
+	var parts []string
+	for _, seg := range strings.Split(path, "/") {
+		seg = splitNameUnsafe.ReplaceAllString(seg, "")
+		if seg != "" {
+			parts = append(parts, seg)
+		}
+	}
+	if len(parts) == 0 {
+		parts = []string{"root"}
+	}
+	name := method + "_" + strings.Join(parts, "_")
+	if exp.HttpResponse != nil && exp.HttpResponse.StatusCode != 0 && exp.HttpResponse.StatusCode != 200 {
+		name += fmt.Sprintf("_%d", exp.HttpResponse.StatusCode)
+	}
+	return name + ".json"
+}
+
+// WriteSplit writes one file per expectation into dir. Existing .json files in dir are
+// replaced so removed expectations disappear from the layout. Returns the written file names.
+func WriteSplit(dir string, exps []models.MockExpectation) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	stale, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range stale {
+		if err := os.Remove(f); err != nil {
+			return nil, fmt.Errorf("failed to remove stale %s: %w", f, err)
+		}
+	}
+
+	used := map[string]int{}
+	var written []string
+	for i := range exps {
+		name := SplitFileName(&exps[i])
+		used[name]++
+		if n := used[name]; n > 1 {
+			name = fmt.Sprintf("%s_%d.json", strings.TrimSuffix(name, ".json"), n)
+		}
+		data, err := json.MarshalIndent(exps[i], "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %w", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), append(data, '\n'), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		written = append(written, name)
+	}
+	return written, nil
+}
+
+// LoadSplit assembles expectations from every .json file in dir, in file name order.
+// A file may hold a single expectation object or an array of them.
+func LoadSplit(dir string) ([]models.MockExpectation, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	var out []models.MockExpectation
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", f, err)
+		}
+		trimmed := strings.TrimSpace(string(data))
+		if strings.HasPrefix(trimmed, "[") {
+			var many []models.MockExpectation
+			if err := json.Unmarshal([]byte(trimmed), &many); err != nil {
+				return nil, fmt.Errorf("%s: %w", filepath.Base(f), err)
+			}
+			out = append(out, many...)
+			continue
+		}
+		var one models.MockExpectation
+		if err := json.Unmarshal([]byte(trimmed), &one); err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(f), err)
+		}
+		out = append(out, one)
+	}
+	return out, nil
+}
+
+// SplitFingerprint summarizes the .json files in dir (names, sizes, mtimes) for change polling
+func SplitFingerprint(dir string) (string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+	h := sha256.New()
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			continue // removed between glob and stat; next poll will settle
+		}
+		fmt.Fprintf(h, "%s|%d|%d\n", filepath.Base(f), info.Size(), info.ModTime().UnixNano())
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,41 @@
+package expectations
+
+import (
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+func TestWriteSplitLoadSplit_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	exps := []models.MockExpectation{
+		{HttpRequest: &models.HttpRequest{Method: "GET", Path: "/users/{id}"}, HttpResponse: &models.HttpResponse{StatusCode: 200}},
+		{HttpRequest: &models.HttpRequest{Method: "GET", Path: "/users/{id}"}, HttpResponse: &models.HttpResponse{StatusCode: 404}},
+		{HttpRequest: &models.HttpRequest{Method: "GET", Path: "/users/{id}"}, HttpResponse: &models.HttpResponse{StatusCode: 200}},
+	}
+	files, err := WriteSplit(dir, exps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"GET_users_id.json", "GET_users_id_404.json", "GET_users_id_2.json"}
+	for i, f := range want {
+		if files[i] != f {
+			t.Fatalf("file %d: expected %s, got %s", i, f, files[i])
+		}
+	}
+	loaded, err := LoadSplit(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded) != 3 {
+		t.Fatalf("expected 3 expectations, got %d", len(loaded))
+	}
+
+	// Rewriting with fewer expectations removes stale files
+	if _, err := WriteSplit(dir, exps[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if loaded, _ = LoadSplit(dir); len(loaded) != 1 {
+		t.Fatalf("expected stale files to be removed, got %d expectations", len(loaded))
+	}
+}
@@ -0,0 +1,165 @@
+package expectations
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// SelectTags asks which tags to narrow the expectations to. Nothing picked, or a project
+// without tags, selects every expectation.
+func SelectTags(message string, expectations []models.MockExpectation) (models.TagSelection, error) {
+	tags, counts := models.ProjectTags(expectations)
+	if len(tags) == 0 {
+		return models.TagSelection{}, nil
+	}
+	options := make([]string, len(tags))
+	for i, tag := range tags {
+		options[i] = fmt.Sprintf("%s (%d)", tag, counts[tag])
+	}
+	var picked []string
+	if err := ask.One(&survey.MultiSelect{
+		Message:  message,
+		Options:  options,
+		Help:     "Leave everything unselected to include all expectations",
+		PageSize: 12,
+	}, &picked); err != nil {
+		return models.TagSelection{}, err
+	}
+	var sel models.TagSelection
+	for _, option := range picked {
+		sel.Only = append(sel.Only, option[:strings.LastIndex(option, " (")])
+	}
+	return sel, nil
+}
+
+// filterByTags offers the tag filter and returns the positions of the expectations kept
+func filterByTags(message string, expectations []models.MockExpectation) ([]int, models.TagSelection, error) {
+	sel, err := SelectTags(message, expectations)
+	if err != nil {
+		return nil, sel, err
+	}
+	indices := sel.Indices(expectations)
+	if !sel.IsEmpty() {
+		fmt.Printf("🏷️  %d of %d expectation(s) tagged %s\n", len(indices), len(expectations), strings.Join(sel.Only, " or "))
+	}
+	return indices, sel, nil
+}
+
+// pickExpectations returns the expectations at indices, in order
+func pickExpectations(expectations []models.MockExpectation, indices []int) []models.MockExpectation {
+	picked := make([]models.MockExpectation, 0, len(indices))
+	for _, i := range indices {
+		picked = append(picked, expectations[i])
+	}
+	return picked
+}
+
+// TagExpectations offers to label freshly imported or built expectations. Each round names a
+// tag and picks the expectations carrying it; an empty name finishes.
+func TagExpectations(expectations []models.MockExpectation) error {
+	if len(expectations) == 0 {
+		return nil
+	}
+	var tag bool
+	if err := ask.One(&survey.Confirm{
+		Message: "Tag expectations for filtering (e.g. auth, error-cases)?",
+		Help:    "Tags narrow what view, remove, download and deploy work on",
+		Default: false,
+	}, &tag); err != nil || !tag {
+		return err
+	}
+	for {
+		name, err := askTagName(expectations)
+		if err != nil || name == "" {
+			return err
+		}
+		apiList := buildAPIList(expectations)
+		var defaults []string
+		for i := range expectations {
+			if hasTag(expectations[i].Tags, name) {
+				defaults = append(defaults, apiList[i])
+			}
+		}
+		var selected []string
+		if err := ask.One(&survey.MultiSelect{
+			Message:  fmt.Sprintf("Expectations tagged %q:", name),
+			Options:  apiList,
+			Default:  defaults,
+			PageSize: 15,
+		}, &selected); err != nil {
+			return err
+		}
+		tagged := map[int]bool{}
+		for _, i := range findExpectationIndices(apiList, selected) {
+			tagged[i] = true
+		}
+		for i := range expectations {
+			setTag(&expectations[i], name, tagged[i])
+		}
+		fmt.Printf("🏷️  %d expectation(s) tagged %s\n", len(tagged), name)
+	}
+}
+
+// askTagName asks for the next tag to apply, listing the ones already in use
+func askTagName(expectations []models.MockExpectation) (string, error) {
+	help := "Empty finishes tagging"
+	if known, _ := models.ProjectTags(expectations); len(known) > 0 {
+		help = "In use: " + strings.Join(known, ", ") + ". " + help
+	}
+	var name string
+	if err := ask.One(&survey.Input{Message: "Tag (empty to finish):", Help: help}, &name); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(name), nil
+}
+
+// editTags edits the tags of one expectation as a comma-separated list
+func (em *ExpectationManager) editTags(expectation *models.MockExpectation) {
+	help := "Comma-separated, e.g. auth, error-cases"
+	if em.library != nil {
+		if known, _ := models.ProjectTags(em.library.Expectations); len(known) > 0 {
+			help += ". In use: " + strings.Join(known, ", ")
+		}
+	}
+	var value string
+	if err := ask.One(&survey.Input{
+		Message: "Tags:",
+		Default: strings.Join(expectation.Tags, ", "),
+		Help:    help,
+	}, &value); err != nil {
+		return
+	}
+	expectation.Tags = models.NormalizeTags(strings.Split(value, ","))
+	if len(expectation.Tags) == 0 {
+		fmt.Println("✅ Tags cleared")
+		return
+	}
+	fmt.Printf("✅ Tags: %s\n", strings.Join(expectation.Tags, ", "))
+}
+
+// setTag adds or removes one tag on an expectation
+func setTag(exp *models.MockExpectation, tag string, on bool) {
+	kept := exp.Tags[:0:0]
+	for _, t := range exp.Tags {
+		if !strings.EqualFold(t, tag) {
+			kept = append(kept, t)
+		}
+	}
+	if on {
+		kept = append(kept, tag)
+	}
+	exp.Tags = models.NormalizeTags(kept)
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+package expectations
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// ManageTenants edits the per-tenant responses of the project: how requests name the tenant
+// and each tenant's overlays of the shared expectations. Returns nil when nothing changed.
+func (em *ExpectationManager) ManageTenants(config *models.MockConfiguration) (*models.MockConfiguration, error) {
+	fmt.Println("\n🏢 TENANTS")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("💡 One deployment, other responses per tenant: requests naming a tenant by header (X-Tenant: acme)")
+	fmt.Println("   or subdomain (acme.mock.example.com) get its overlays; others get the shared responses")
+
+	if config == nil {
+		return nil, fmt.Errorf("no configuration loaded for project %s", em.projectName)
+	}
+
+	changed := false
+	for {
+		status := "off"
+		options := []string{"select - Choose how requests name the tenant (header, subdomain)"}
+		if config.Tenancy != nil {
+			status = config.Tenancy.Describe()
+			options = append(options, "add - Give a tenant its own response for an expectation")
+			for _, t := range config.Tenancy.Tenants {
+				names := make([]string, len(t.Overlays))
+				for i, o := range t.Overlays {
+					names[i] = o.Expectation
+				}
+				options = append(options, fmt.Sprintf("remove:%s - Remove tenant %s: %s", t.Name, t.Name, strings.Join(names, ", ")))
+			}
+			options = append(options, "off - Remove every tenant")
+		}
+		options = append(options, "done - Finish managing tenants")
+
+		var action string
+		if err := ask.One(&survey.Select{
+			Message:  fmt.Sprintf("Tenants (%s):", status),
+			Options:  options,
+			PageSize: 12,
+		}, &action); err != nil {
+			return nil, err
+		}
+
+		token := strings.Fields(action)[0]
+		switch {
+		case token == "done":
+			if !changed {
+				return nil, nil
+			}
+			return config, nil
+		case token == "select":
+			if err := askTenantSelection(config); err != nil {
+				fmt.Printf("❌ %v\n", err)
+				continue
+			}
+			changed = true
+			fmt.Printf("✅ Tenants selected by %s\n", config.Tenancy.Describe())
+		case token == "add":
+			tenant, o, err := askTenantOverlay(config)
+			if err != nil {
+				fmt.Printf("❌ %v\n", err)
+				continue
+			}
+			setTenantOverlay(config.Tenancy, tenant, o)
+			changed = true
+			fmt.Printf("✅ Tenant %s: own response for %s\n", tenant, o.Expectation)
+		case strings.HasPrefix(token, "remove:"):
+			i := config.Tenancy.FindTenant(strings.TrimPrefix(token, "remove:"))
+			config.Tenancy.Tenants = append(config.Tenancy.Tenants[:i], config.Tenancy.Tenants[i+1:]...)
+			changed = true
+		case token == "off":
+			config.Tenancy = nil
+			changed = true
+			fmt.Println("🗑️  Removed every tenant")
+		}
+	}
+}
+
+func askTenantSelection(config *models.MockConfiguration) error {
+	t := models.Tenancy{Header: "X-Tenant"}
+	if config.Tenancy != nil {
+		t = *config.Tenancy
+	}
+	if err := ask.One(&survey.Input{
+		Message: "Header naming the tenant (empty for none):",
+		Default: t.Header,
+	}, &t.Header); err != nil {
+		return err
+	}
+	t.Header = strings.TrimSpace(t.Header)
+	if err := ask.One(&survey.Confirm{
+		Message: "Also name it by subdomain (acme.mock.example.com → acme)?",
+		Default: t.Subdomain || t.Header == "",
+		Help:    "The header wins when both are sent; needs a wildcard DNS name pointing at the mock",
+	}, &t.Subdomain); err != nil {
+		return err
+	}
+	if t.Header == "" && !t.Subdomain {
+		return fmt.Errorf("tenants need a header or the subdomain to be selected by")
+	}
+	config.Tenancy = &t
+	return nil
+}
+
+// askTenantOverlay asks for a tenant's response of one of the project's expectations
+func askTenantOverlay(config *models.MockConfiguration) (string, models.TenantOverlay, error) {
+	var o models.TenantOverlay
+	var keys []string
+	var shared []*models.MockExpectation
+	for i := range config.Expectations {
+		if exp := &config.Expectations[i]; !exp.Generated() && exp.HttpRequest != nil {
+			keys = append(keys, models.OverlayKey(*exp))
+			shared = append(shared, exp)
+		}
+	}
+	if len(keys) == 0 {
+		return "", o, fmt.Errorf("the project has no expectations to overlay")
+	}
+
+	var tenant string
+	if err := ask.One(&survey.Input{Message: "Tenant name:", Help: "As sent in the header or subdomain, e.g. acme"}, &tenant, survey.WithValidator(func(ans any) error {
+		if !models.ValidTenantName(strings.TrimSpace(fmt.Sprint(ans))) {
+			return fmt.Errorf("enter a single word of letters, digits, - or _, e.g. acme")
+		}
+		return nil
+	})); err != nil {
+		return "", o, err
+	}
+	tenant = strings.TrimSpace(tenant)
+
+	if err := ask.One(&survey.Select{Message: "Expectation to overlay:", Options: keys, PageSize: 12}, &o.Expectation); err != nil {
+		return "", o, err
+	}
+	exp := shared[indexOf(keys, o.Expectation)]
+
+	status := ""
+	if err := ask.One(&survey.Input{
+		Message: "Status code (empty keeps the shared one):",
+	}, &status, survey.WithValidator(func(ans any) error {
+		if s := strings.TrimSpace(fmt.Sprint(ans)); s != "" {
+			if code, err := strconv.Atoi(s); err != nil || code < 100 || code > 599 {
+				return fmt.Errorf("enter an HTTP status code, e.g. 200")
+			}
+		}
+		return nil
+	})); err != nil {
+		return "", o, err
+	}
+	o.StatusCode, _ = strconv.Atoi(strings.TrimSpace(status))
+
+	if exp.HttpResponse != nil && exp.HttpResponse.Body != nil {
+		fmt.Printf("Shared body:\n%s\n", getCurrentBody(exp.HttpResponse.Body))
+	}
+	var raw string
+	if err := ask.One(&survey.Editor{
+		Message:       "Tenant's body changes (a JSON object is merged into the shared body, null removes a field; anything else replaces it):",
+		Default:       "{}",
+		AppendDefault: true,
+		HideDefault:   true,
+		FileName:      "*.json",
+	}, &raw); err != nil {
+		return "", o, err
+	}
+	if raw = strings.TrimSpace(raw); raw != "" && raw != "{}" {
+		var body any
+		if err := json.Unmarshal([]byte(raw), &body); err != nil {
+			body = raw // not JSON: served as written
+		}
+		o.Body = body
+	}
+	if o.StatusCode == 0 && o.Body == nil {
+		return "", o, fmt.Errorf("the tenant's response is the shared one; change the status or the body")
+	}
+	return tenant, o, nil
+}
+
+func setTenantOverlay(t *models.Tenancy, tenant string, o models.TenantOverlay) {
+	i := t.FindTenant(tenant)
+	if i < 0 {
+		t.Tenants = append(t.Tenants, models.Tenant{Name: tenant})
+		i = len(t.Tenants) - 1
+	}
+	for j, existing := range t.Tenants[i].Overlays {
+		if existing.Expectation == o.Expectation {
+			t.Tenants[i].Overlays[j] = o
+			return
+		}
+	}
+	t.Tenants[i].Overlays = append(t.Tenants[i].Overlays, o)
+}
+
+func indexOf(list []string, s string) int {
+	for i, v := range list {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,165 @@
+package faker
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+var (
+	firstNames = []string{"Alex", "Jordan", "Taylor", "Morgan", "Casey", "Riley", "Avery", "Quinn", "Jamie", "Drew",
+		"Sam", "Charlie", "Robin", "Skyler", "Emerson", "Harper", "Reese", "Rowan", "Sage", "Parker"}
+	lastNames = []string{"Smith", "Johnson", "Lee", "Garcia", "Brown", "Miller", "Davis", "Wilson", "Moore", "Clark",
+		"Lewis", "Walker", "Hall", "Young", "King", "Wright", "Scott", "Green", "Baker", "Adams"}
+	companies = []string{"Acme Corp", "Globex", "Initech", "Umbrella Labs", "Stark Industries", "Wayne Enterprises",
+		"Hooli", "Vandelay Industries", "Soylent Co", "Cyberdyne Systems"}
+	cities    = []string{"Springfield", "Riverton", "Lakeside", "Fairview", "Greenville", "Franklin", "Clinton", "Madison", "Georgetown", "Salem"}
+	countries = []string{"US", "GB", "DE", "FR", "CA", "AU", "IN", "JP", "BR", "NL"}
+	streets   = []string{"Main St", "Oak Ave", "Pine Rd", "Maple Dr", "Cedar Ln", "Elm St", "Lake View Rd", "Hill St"}
+	words     = []string{"alpha", "bravo", "delta", "echo", "nova", "orbit", "pixel", "quartz", "river", "summit",
+		"vector", "willow", "amber", "cobalt", "ember", "falcon", "harbor", "lumen", "meadow", "zephyr"}
+	domains = []string{"example.com", "example.org", "example.net"}
+)
+
+func init() {
+	Register("uuid", "Random UUID v4", func(r *rand.Rand, _ time.Time, _ string) (any, error) {
+		var b [16]byte
+		r.Read(b[:])
+		b[6] = b[6]&0x0f | 0x40
+		b[8] = b[8]&0x3f | 0x80
+		h := hex.EncodeToString(b[:])
+		return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:], nil
+	})
+	Register("firstName", "First name", OneOf(firstNames))
+	Register("lastName", "Last name", OneOf(lastNames))
+	Register("name", "Full name", func(r *rand.Rand, _ time.Time, _ string) (any, error) {
+		return firstNames[r.Intn(len(firstNames))] + " " + lastNames[r.Intn(len(lastNames))], nil
+	})
+	Register("username", "Username, e.g. alex.smith42", func(r *rand.Rand, _ time.Time, _ string) (any, error) {
+		return fmt.Sprintf("%s.%s%d", strings.ToLower(firstNames[r.Intn(len(firstNames))]),
+			strings.ToLower(lastNames[r.Intn(len(lastNames))]), r.Intn(100)), nil
+	})
+	Register("email", "Email address at an example domain", func(r *rand.Rand, _ time.Time, _ string) (any, error) {
+		return fmt.Sprintf("%s.%s@%s", strings.ToLower(firstNames[r.Intn(len(firstNames))]),
+			strings.ToLower(lastNames[r.Intn(len(lastNames))]), domains[r.Intn(len(domains))]), nil
+	})
+	Register("phone", "Phone number, e.g. +1-555-0142", func(r *rand.Rand, _ time.Time, _ string) (any, error) {
+		return fillPattern(r, "+1-555-01##"), nil
+	})
+	Register("company", "Company name", OneOf(companies))
+	Register("city", "City", OneOf(cities))
+	Register("country", "ISO country code", OneOf(countries))
+	Register("address", "Street address", func(r *rand.Rand, _ time.Time, _ string) (any, error) {
+		return fmt.Sprintf("%d %s", 1+r.Intn(9999), streets[r.Intn(len(streets))]), nil
+	})
+	Register("word", "Random word", OneOf(words))
+	Register("sentence", "Short sentence; ${sentence:N} for N words", func(r *rand.Rand, _ time.Time, arg string) (any, error) {
+		n, err := length(arg, 6)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]string, n)
+		for i := range out {
+			out[i] = words[r.Intn(len(words))]
+		}
+		s := strings.Join(out, " ")
+		return strings.ToUpper(s[:1]) + s[1:] + ".", nil
+	})
+	Register("url", "URL at an example domain", func(r *rand.Rand, _ time.Time, _ string) (any, error) {
+		return fmt.Sprintf("https://%s/%s", domains[r.Intn(len(domains))], words[r.Intn(len(words))]), nil
+	})
+	Register("ipv4", "IPv4 address from the documentation range", func(r *rand.Rand, _ time.Time, _ string) (any, error) {
+		return fmt.Sprintf("192.0.2.%d", 1+r.Intn(254)), nil
+	})
+	Register("int", "Integer; ${int:min-max} (default 0-1000)", func(r *rand.Rand, _ time.Time, arg string) (any, error) {
+		lo, hi, err := intRange(arg, 0, 1000)
+		if err != nil {
+			return nil, err
+		}
+		return lo + r.Intn(hi-lo+1), nil
+	})
+	Register("float", "Decimal with two places; ${float:min-max} (default 0-1000)", func(r *rand.Rand, _ time.Time, arg string) (any, error) {
+		lo, hi, err := intRange(arg, 0, 1000)
+		if err != nil {
+			return nil, err
+		}
+		return float64(lo*100+r.Intn((hi-lo)*100+1)) / 100, nil
+	})
+	Register("bool", "true or false", func(r *rand.Rand, _ time.Time, _ string) (any, error) {
+		return r.Intn(2) == 1, nil
+	})
+	Register("datetime", "RFC 3339 timestamp; ${datetime:-7d} or ${datetime:2h} shifts it", func(_ *rand.Rand, now time.Time, arg string) (any, error) {
+		t, err := shift(now, arg)
+		if err != nil {
+			return nil, err
+		}
+		return t.Format(time.RFC3339), nil
+	})
+	Register("date", "Date (YYYY-MM-DD); ${date:-30d} shifts it", func(_ *rand.Rand, now time.Time, arg string) (any, error) {
+		t, err := shift(now, arg)
+		if err != nil {
+			return nil, err
+		}
+		return t.Format(time.DateOnly), nil
+	})
+	Register("epoch", "Unix timestamp in seconds", func(_ *rand.Rand, now time.Time, arg string) (any, error) {
+		t, err := shift(now, arg)
+		if err != nil {
+			return nil, err
+		}
+		return t.Unix(), nil
+	})
+	Register("hex", "Hex string; ${hex:N} for N characters (default 16)", func(r *rand.Rand, _ time.Time, arg string) (any, error) {
+		n, err := length(arg, 16)
+		if err != nil {
+			return nil, err
+		}
+		b := make([]byte, (n+1)/2)
+		r.Read(b)
+		return hex.EncodeToString(b)[:n], nil
+	})
+	Register("alphanumeric", "Letters and digits; ${alphanumeric:N} (default 12)", func(r *rand.Rand, _ time.Time, arg string) (any, error) {
+		n, err := length(arg, 12)
+		if err != nil {
+			return nil, err
+		}
+		return fillPattern(r, strings.Repeat("*", n)), nil
+	})
+	Register("bytes", "Base64 of random bytes; ${bytes:N} (default 32)", func(r *rand.Rand, _ time.Time, arg string) (any, error) {
+		n, err := length(arg, 32)
+		if err != nil {
+			return nil, err
+		}
+		b := make([]byte, n)
+		r.Read(b)
+		return base64.StdEncoding.EncodeToString(b), nil
+	})
+	Register("oneOf", "One of the listed values; ${oneOf:active|pending|closed}", func(r *rand.Rand, now time.Time, arg string) (any, error) {
+		return OneOf(strings.Split(arg, "|"))(r, now, "")
+	})
+	Register("pattern", "Fill a pattern: # digit, ? letter, * either; ${pattern:ORD-####}", func(r *rand.Rand, _ time.Time, arg string) (any, error) {
+		return fillPattern(r, arg), nil
+	})
+}
+
+// shift moves t by an optional offset such as -7d, 36h or -90m
+func shift(t time.Time, arg string) (time.Time, error) {
+	if arg == "" {
+		return t, nil
+	}
+	if days, ok := strings.CutSuffix(arg, "d"); ok {
+		var n int
+		if _, err := fmt.Sscanf(days, "%d", &n); err != nil {
+			return t, fmt.Errorf("invalid offset %q: use e.g. -7d or 36h", arg)
+		}
+		return t.AddDate(0, 0, n), nil
+	}
+	d, err := time.ParseDuration(arg)
+	if err != nil {
+		return t, fmt.Errorf("invalid offset %q: use e.g. -7d or 36h", arg)
+	}
+	return t.Add(d), nil
+}
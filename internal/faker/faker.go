@@ -0,0 +1,249 @@
+// Package faker expands fake-data placeholders such as ${uuid}, ${name} or ${int:1-100} into
+// concrete values when expectations are generated. Generators live in a registry that callers
+// can extend; a seeded Faker produces the same values on every run.
+package faker
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Generator produces one value. arg is the text after the colon in ${name:arg} ("" when
+// absent). Numbers and booleans stay typed when a placeholder is a whole JSON string.
+type Generator func(r *rand.Rand, now time.Time, arg string) (any, error)
+
+// Entry is a registered generator and the help shown for it
+type Entry struct {
+	Name        string
+	Description string
+	Generate    Generator
+}
+
+var (
+	mu       sync.RWMutex
+	registry = map[string]Entry{}
+)
+
+// Register adds (or replaces) a generator available to every Faker
+func Register(name, description string, g Generator) {
+	mu.Lock()
+	defer mu.Unlock()
+	registry[name] = Entry{Name: name, Description: description, Generate: g}
+}
+
+// Registered lists the registered generators by name
+func Registered() []Entry {
+	mu.RLock()
+	defer mu.RUnlock()
+	entries := make([]Entry, 0, len(registry))
+	for _, e := range registry {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries
+}
+
+// SeededNow is the clock of seeded Fakers, so dates are as reproducible as everything else
+var SeededNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// Faker expands placeholders with values from the registry and its own generators
+type Faker struct {
+	rand   *rand.Rand
+	now    time.Time
+	custom map[string]Generator
+}
+
+// New returns a Faker. A non-zero seed makes the values deterministic; zero seeds from the clock.
+func New(seed int64) *Faker {
+	now := time.Now().UTC().Truncate(time.Second)
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	} else {
+		now = SeededNow
+	}
+	return &Faker{rand: rand.New(rand.NewSource(seed)), now: now, custom: map[string]Generator{}}
+}
+
+// Define adds a generator to this Faker only, taking precedence over the registry
+func (f *Faker) Define(name string, g Generator) {
+	f.custom[name] = g
+}
+
+// placeholder matches ${name} and ${name:arg}, and the $!helpers of MockServer's Velocity
+// templates that do not depend on the request
+var placeholder = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::([^}]*))?\}|\$!\{?(uuid|now_epoch|rand_int_100|rand_bytes_64)\b\}?`)
+
+var velocityHelpers = map[string][2]string{
+	"uuid":          {"uuid", ""},
+	"now_epoch":     {"epoch", ""},
+	"rand_int_100":  {"int", "0-100"},
+	"rand_bytes_64": {"bytes", "64"},
+}
+
+// Contains reports whether text holds a placeholder this Faker can expand
+func (f *Faker) Contains(text string) bool {
+	for _, m := range placeholder.FindAllStringSubmatch(text, -1) {
+		if _, ok := f.lookup(m[1]); ok || m[3] != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Expand replaces every known placeholder in text. Unknown names are left as written.
+func (f *Faker) Expand(text string) (string, error) {
+	var firstErr error
+	out := placeholder.ReplaceAllStringFunc(text, func(s string) string {
+		v, ok, err := f.value(s)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		if !ok || err != nil {
+			return s
+		}
+		return fmt.Sprint(v)
+	})
+	return out, firstErr
+}
+
+// ExpandValue expands placeholders throughout a decoded JSON value. A string that is exactly
+// one placeholder takes the generator's type, so "${int:1-9}" becomes a number.
+func (f *Faker) ExpandValue(v any) (any, error) {
+	switch t := v.(type) {
+	case string:
+		if loc := placeholder.FindStringIndex(t); loc != nil && loc[0] == 0 && loc[1] == len(t) {
+			if value, ok, err := f.value(t); ok || err != nil {
+				return value, err
+			}
+		}
+		return f.Expand(t)
+	case map[string]any:
+		// Sorted keys keep seeded values stable across runs
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		out := make(map[string]any, len(t))
+		for _, k := range keys {
+			expanded, err := f.ExpandValue(t[k])
+			if err != nil {
+				return nil, err
+			}
+			out[k] = expanded
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(t))
+		for i, item := range t {
+			expanded, err := f.ExpandValue(item)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = expanded
+		}
+		return out, nil
+	}
+	return v, nil
+}
+
+// value generates the value of one placeholder; ok is false for unknown names
+func (f *Faker) value(s string) (any, bool, error) {
+	m := placeholder.FindStringSubmatch(s)
+	name, arg := m[1], m[2]
+	if m[3] != "" {
+		name, arg = velocityHelpers[m[3]][0], velocityHelpers[m[3]][1]
+	}
+	g, ok := f.lookup(name)
+	if !ok {
+		return nil, false, nil
+	}
+	v, err := g(f.rand, f.now, arg)
+	if err != nil {
+		return nil, true, fmt.Errorf("%s: %w", s, err)
+	}
+	return v, true, nil
+}
+
+func (f *Faker) lookup(name string) (Generator, bool) {
+	if g, ok := f.custom[name]; ok {
+		return g, true
+	}
+	mu.RLock()
+	defer mu.RUnlock()
+	e, ok := registry[name]
+	return e.Generate, ok
+}
+
+// OneOf returns a generator picking one of values
+func OneOf(values []string) Generator {
+	return func(r *rand.Rand, _ time.Time, _ string) (any, error) {
+		if len(values) == 0 {
+			return "", fmt.Errorf("no values to pick from")
+		}
+		return values[r.Intn(len(values))], nil
+	}
+}
+
+// Pattern returns a generator filling a pattern: # is a digit, ? a letter, * a letter or
+// digit; anything else is kept, e.g. "ORD-####"
+func Pattern(pattern string) Generator {
+	return func(r *rand.Rand, _ time.Time, _ string) (any, error) {
+		return fillPattern(r, pattern), nil
+	}
+}
+
+const (
+	digits   = "0123456789"
+	letters  = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	alphanum = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+func fillPattern(r *rand.Rand, pattern string) string {
+	var b strings.Builder
+	for _, c := range pattern {
+		switch c {
+		case '#':
+			b.WriteByte(digits[r.Intn(len(digits))])
+		case '?':
+			b.WriteByte(letters[r.Intn(len(letters))])
+		case '*':
+			b.WriteByte(alphanum[r.Intn(len(alphanum))])
+		default:
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+// intRange parses "min-max" (default 0-1000)
+func intRange(arg string, min, max int) (int, int, error) {
+	if arg == "" {
+		return min, max, nil
+	}
+	lo, hi, ok := strings.Cut(arg, "-")
+	a, errA := strconv.Atoi(strings.TrimSpace(lo))
+	b, errB := strconv.Atoi(strings.TrimSpace(hi))
+	if !ok || errA != nil || errB != nil || a > b {
+		return 0, 0, fmt.Errorf("invalid range %q: use min-max", arg)
+	}
+	return a, b, nil
+}
+
+// length parses an optional length argument
+func length(arg string, def int) (int, error) {
+	if arg == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(arg)
+	if err != nil || n <= 0 || n > 4096 {
+		return 0, fmt.Errorf("invalid length %q", arg)
+	}
+	return n, nil
+}
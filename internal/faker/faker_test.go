@@ -0,0 +1,72 @@
+package faker
+
+import (
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExpandSeeded(t *testing.T) {
+	text := `{"id": "${uuid}", "name": "${name}", "at": "${datetime:-1d}", "note": "${unknown}", "n": $!rand_int_100}`
+	a, err := New(42).Expand(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := New(42).Expand(text)
+	if a != b {
+		t.Errorf("seeded expansion differs:\n%s\n%s", a, b)
+	}
+	if !regexp.MustCompile(`"id": "[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}"`).MatchString(a) {
+		t.Errorf("uuid not expanded: %s", a)
+	}
+	if !strings.Contains(a, `"at": "2024-12-31T12:00:00Z"`) || !strings.Contains(a, `"note": "${unknown}"`) || strings.Contains(a, "$!") {
+		t.Errorf("unexpected expansion: %s", a)
+	}
+	if _, err := New(1).Expand("${int:9-1}"); err == nil {
+		t.Error("expected an inverted range to fail")
+	}
+}
+
+func TestExpandValueKeepsTypes(t *testing.T) {
+	f := New(7)
+	f.Define("status", OneOf([]string{"active"}))
+	v, err := f.ExpandValue(map[string]any{
+		"count":  "${int:5-5}",
+		"ok":     "${bool}",
+		"status": "${status}",
+		"ref":    "order ${pattern:ORD-##}",
+		"tags":   []any{"${word}", 3.0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := v.(map[string]any)
+	if m["count"] != 5 || m["status"] != "active" {
+		t.Errorf("count/status = %v/%v", m["count"], m["status"])
+	}
+	if _, isBool := m["ok"].(bool); !isBool {
+		t.Errorf("ok = %T, want bool", m["ok"])
+	}
+	if !regexp.MustCompile(`^order ORD-\d\d$`).MatchString(m["ref"].(string)) {
+		t.Errorf("ref = %v", m["ref"])
+	}
+	if tags := m["tags"].([]any); strings.Contains(tags[0].(string), "$") || tags[1] != 3.0 {
+		t.Errorf("tags = %v", tags)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register("sku", "Test SKU", func(r *rand.Rand, _ time.Time, arg string) (any, error) {
+		return "SKU-" + arg, nil
+	})
+	defer func() {
+		mu.Lock()
+		delete(registry, "sku")
+		mu.Unlock()
+	}()
+	if got, _ := New(0).Expand("${sku:42}"); got != "SKU-42" {
+		t.Errorf("custom generator = %q", got)
+	}
+}
@@ -0,0 +1,169 @@
+package graphqlschema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const shopSDL = `
+"""
+The shop API
+"""
+schema { query: Root, mutation: Mutation }
+
+scalar DateTime @specifiedBy(url: "https://scalars.graphql.org/andimarek/date-time")
+
+directive @auth(role: String = "user") repeatable on FIELD_DEFINITION | OBJECT
+
+enum Status { ACTIVE, "Gone" SUSPENDED @deprecated(reason: "unused") }
+
+interface Node { id: ID! }
+
+type User implements Node @auth {
+  id: ID!
+  "Full name"
+  name: String
+  email: String!
+  status: Status
+  createdAt: DateTime
+  friends(first: Int = 10): [User!]!
+  orders(status: Status!): [Order]
+}
+
+type Order implements Node { id: ID!, total: Float, buyer: User }
+
+union SearchResult = | User | Order
+
+input NewUser { name: String!, tags: [String] = ["a", "b"], meta: Filter = { limit: 1 } }
+input Filter { limit: Int }
+
+type Root {
+  # Looks a user up
+  "Find a user"
+  user(id: ID!): User
+  search(term: String!): [SearchResult!]!
+  ping: String
+}
+
+type Mutation { createUser(input: NewUser!): User! }
+
+extend type Root { node(id: ID!): Node }
+
+type Subscription { userCreated: User }
+`
+
+func TestParseSDL(t *testing.T) {
+	s, err := Parse("shop.graphql", []byte(shopSDL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Query != "Root" || s.Mutation != "Mutation" || s.Subscription != "Subscription" {
+		t.Fatalf("roots = %q %q %q", s.Query, s.Mutation, s.Subscription)
+	}
+	var names []string
+	for _, op := range s.Operations() {
+		names = append(names, op.Type+" "+op.Name)
+	}
+	want := []string{"query User", "query Search", "query Ping", "query Node", "mutation CreateUser"}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("operations = %v, want %v", names, want)
+	}
+	if got := s.Types["Node"].PossibleTypes; !reflect.DeepEqual(got, []string{"Order", "User"}) {
+		t.Errorf("Node implementations = %v", got)
+	}
+	if got := s.Types["Status"].EnumValues; !reflect.DeepEqual(got, []string{"ACTIVE", "SUSPENDED"}) {
+		t.Errorf("Status values = %v", got)
+	}
+	if got := s.Types["Root"].Fields[0].Description; got != "Find a user" {
+		t.Errorf("user description = %q", got)
+	}
+	if got := s.Types["User"].Fields[5].Type.String(); got != "[User!]!" {
+		t.Errorf("friends type = %q", got)
+	}
+}
+
+func TestDocumentAndResponseSchema(t *testing.T) {
+	s, err := Parse("shop.graphql", []byte(shopSDL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ops := s.Operations()
+
+	doc := s.Document(ops[0])
+	for _, want := range []string{"query User($id: ID!) {\n  user(id: $id) {\n    id\n", "    friends {\n      id\n", "      friends {\n        id\n"} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("document lacks %q:\n%s", want, doc)
+		}
+	}
+	if strings.Contains(doc, "orders") {
+		t.Errorf("fields with required arguments must not be selected:\n%s", doc)
+	}
+	if strings.Count(doc, "friends {") != 2 {
+		t.Errorf("selection must stop at depth %d:\n%s", maxSelectionDepth, doc)
+	}
+
+	user := s.ResponseSchema(ops[0])["properties"].(map[string]any)["user"].(map[string]any)
+	props := user["properties"].(map[string]any)
+	if got := props["createdAt"]; !reflect.DeepEqual(got, map[string]any{"type": "string", "format": "date-time"}) {
+		t.Errorf("createdAt schema = %v", got)
+	}
+	if got := props["status"].(map[string]any)["enum"]; !reflect.DeepEqual(got, []any{"ACTIVE", "SUSPENDED"}) {
+		t.Errorf("status enum = %v", got)
+	}
+
+	search := s.Document(ops[1])
+	if !strings.Contains(search, "    __typename\n    ... on Order {\n      id\n") {
+		t.Errorf("union selection:\n%s", search)
+	}
+	if got := s.Document(ops[2]); got != "query Ping {\n  ping\n}" {
+		t.Errorf("ping document = %q", got)
+	}
+	if got := s.Document(ops[4]); !strings.HasPrefix(got, "mutation CreateUser($input: NewUser!) {\n  createUser(input: $input) {") {
+		t.Errorf("mutation document = %q", got)
+	}
+}
+
+func TestParseIntrospection(t *testing.T) {
+	const introspection = `{"data": {"__schema": {
+	  "queryType": {"name": "Query"}, "mutationType": null, "subscriptionType": null,
+	  "types": [
+	    {"kind": "OBJECT", "name": "Query", "fields": [
+	      {"name": "books", "args": [{"name": "limit", "type": {"kind": "SCALAR", "name": "Int"}}],
+	       "type": {"kind": "NON_NULL", "ofType": {"kind": "LIST", "ofType": {"kind": "OBJECT", "name": "Book"}}}}
+	    ]},
+	    {"kind": "OBJECT", "name": "Book", "fields": [
+	      {"name": "title", "args": [], "type": {"kind": "SCALAR", "name": "String"}}
+	    ]},
+	    {"kind": "OBJECT", "name": "__Type", "fields": []}
+	  ]}}}`
+	s, err := Parse("schema.json", []byte(introspection))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.Types["__Type"]; ok {
+		t.Error("introspection types must be skipped")
+	}
+	ops := s.Operations()
+	if len(ops) != 1 || ops[0].Name != "Books" || ops[0].Field.Type.String() != "[Book]!" {
+		t.Fatalf("operations = %+v", ops)
+	}
+	want := "query Books($limit: Int) {\n  books(limit: $limit) {\n    title\n  }\n}"
+	if got := s.Document(ops[0]); got != want {
+		t.Errorf("document = %q, want %q", got, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for name, src := range map[string]string{
+		"no roots":     "type User { id: ID }",
+		"unterminated": "type Query { user: User",
+		"bad keyword":  "typo Query { id: ID }",
+		"kind clash":   "type Query { id: ID }\nenum Query { A }",
+		"json":         `{"data": {}}`,
+	} {
+		if _, err := Parse("schema.graphql", []byte(src)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package graphqlschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// introspectionType is one entry of __schema.types, or a nested type reference
+type introspectionType struct {
+	Kind          string                  `json:"kind"`
+	Name          string                  `json:"name"`
+	Description   string                  `json:"description"`
+	Fields        []introspectionField    `json:"fields"`
+	InputFields   []introspectionInput    `json:"inputFields"`
+	Interfaces    []introspectionType     `json:"interfaces"`
+	PossibleTypes []introspectionType     `json:"possibleTypes"`
+	EnumValues    []struct{ Name string } `json:"enumValues"`
+	OfType        *introspectionType      `json:"ofType"`
+}
+
+type introspectionField struct {
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Args        []introspectionInput `json:"args"`
+	Type        *introspectionType   `json:"type"`
+}
+
+type introspectionInput struct {
+	Name string             `json:"name"`
+	Type *introspectionType `json:"type"`
+}
+
+type introspectionSchema struct {
+	QueryType        *struct{ Name string } `json:"queryType"`
+	MutationType     *struct{ Name string } `json:"mutationType"`
+	SubscriptionType *struct{ Name string } `json:"subscriptionType"`
+	Types            []introspectionType    `json:"types"`
+}
+
+// parseIntrospection reads the result of the standard introspection query
+func parseIntrospection(data []byte) (*Schema, error) {
+	var doc struct {
+		Data *struct {
+			Schema *introspectionSchema `json:"__schema"`
+		} `json:"data"`
+		Schema *introspectionSchema `json:"__schema"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid introspection JSON: %w", err)
+	}
+	raw := doc.Schema
+	if doc.Data != nil && doc.Data.Schema != nil {
+		raw = doc.Data.Schema
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("JSON schema files must hold an introspection result with a __schema object")
+	}
+
+	s := newSchema()
+	if raw.QueryType != nil {
+		s.Query = raw.QueryType.Name
+	}
+	if raw.MutationType != nil {
+		s.Mutation = raw.MutationType.Name
+	}
+	if raw.SubscriptionType != nil {
+		s.Subscription = raw.SubscriptionType.Name
+	}
+	for _, it := range raw.Types {
+		if it.Name == "" || strings.HasPrefix(it.Name, "__") {
+			continue
+		}
+		t := &Type{Name: it.Name, Kind: it.Kind, Description: it.Description}
+		for _, f := range it.Fields {
+			field := &Field{Name: f.Name, Description: f.Description, Type: f.Type.ref()}
+			for _, a := range f.Args {
+				field.Args = append(field.Args, &InputValue{Name: a.Name, Type: a.Type.ref()})
+			}
+			t.Fields = append(t.Fields, field)
+		}
+		for _, f := range it.InputFields {
+			t.InputFields = append(t.InputFields, &InputValue{Name: f.Name, Type: f.Type.ref()})
+		}
+		for _, v := range it.EnumValues {
+			t.EnumValues = append(t.EnumValues, v.Name)
+		}
+		for _, i := range it.Interfaces {
+			t.Interfaces = append(t.Interfaces, i.Name)
+		}
+		for _, p := range it.PossibleTypes {
+			t.PossibleTypes = append(t.PossibleTypes, p.Name)
+		}
+		s.Types[t.Name] = t
+	}
+	return s, nil
+}
+
+// ref converts an introspection type reference; a missing one reads as String
+func (t *introspectionType) ref() *TypeRef {
+	switch {
+	case t == nil:
+		return &TypeRef{Name: "String"}
+	case t.Kind == "NON_NULL":
+		return &TypeRef{NonNull: true, OfType: t.OfType.ref()}
+	case t.Kind == "LIST":
+		return &TypeRef{List: true, OfType: t.OfType.ref()}
+	}
+	return &TypeRef{Name: t.Name}
+}
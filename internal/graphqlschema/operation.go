@@ -0,0 +1,229 @@
+package graphqlschema
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxSelectionDepth bounds how far selection sets reach into nested objects; the deepest
+// level selects only its scalar and enum fields, which also ends recursive types
+const maxSelectionDepth = 3
+
+// exampleListItems is how many items list fields get in example responses
+const exampleListItems = 2
+
+// Operation is a root Query or Mutation field clients can call
+type Operation struct {
+	Type  string // "query" or "mutation"
+	Name  string // operationName clients send: the field name capitalized, e.g. user → User
+	Field *Field
+}
+
+// Operations lists the Query fields, then the Mutation fields, in declaration order.
+// Subscriptions are left out: they are not served over plain HTTP.
+func (s *Schema) Operations() []Operation {
+	var ops []Operation
+	for _, root := range []struct{ typ, name string }{{"query", s.Query}, {"mutation", s.Mutation}} {
+		t := s.Types[root.name]
+		if root.name == "" || t == nil {
+			continue
+		}
+		for _, f := range t.Fields {
+			if strings.HasPrefix(f.Name, "__") {
+				continue
+			}
+			ops = append(ops, Operation{Type: root.typ, Name: strings.ToUpper(f.Name[:1]) + f.Name[1:], Field: f})
+		}
+	}
+	return ops
+}
+
+// Document writes an operation document calling op with every argument as a variable and
+// selecting the fields ResponseSchema describes, e.g.
+//
+//	query User($id: ID!) {
+//	  user(id: $id) {
+//	    id
+//	    name
+//	  }
+//	}
+func (s *Schema) Document(op Operation) string {
+	var vars, args []string
+	for _, a := range op.Field.Args {
+		vars = append(vars, "$"+a.Name+": "+a.Type.String())
+		args = append(args, a.Name+": $"+a.Name)
+	}
+	var b strings.Builder
+	b.WriteString(op.Type + " " + op.Name)
+	if len(vars) > 0 {
+		b.WriteString("(" + strings.Join(vars, ", ") + ")")
+	}
+	b.WriteString(" {\n  " + op.Field.Name)
+	if len(args) > 0 {
+		b.WriteString("(" + strings.Join(args, ", ") + ")")
+	}
+	lines, _ := s.selectType(op.Field.Type, 1)
+	if len(lines) > 0 {
+		b.WriteString(" {\n")
+		for _, line := range lines {
+			b.WriteString("    " + line + "\n")
+		}
+		b.WriteString("  }")
+	}
+	b.WriteString("\n}")
+	return b.String()
+}
+
+// ResponseSchema is a JSON Schema of the data object answering op, covering the fields its
+// Document selects
+func (s *Schema) ResponseSchema(op Operation) map[string]any {
+	_, schema := s.selectType(op.Field.Type, 1)
+	return map[string]any{
+		"type":       "object",
+		"properties": map[string]any{op.Field.Name: schema},
+	}
+}
+
+// selectType returns the selection set lines (unindented for the first level) and the JSON
+// Schema of a value of ref
+func (s *Schema) selectType(ref *TypeRef, depth int) ([]string, map[string]any) {
+	switch {
+	case ref.NonNull:
+		return s.selectType(ref.OfType, depth)
+	case ref.List:
+		lines, items := s.selectType(ref.OfType, depth)
+		return lines, map[string]any{"type": "array", "items": items, "minItems": exampleListItems}
+	}
+	t := s.Types[ref.Name]
+	if t == nil {
+		return nil, scalarSchema(ref.Name)
+	}
+	switch t.Kind {
+	case KindEnum:
+		values := make([]any, len(t.EnumValues))
+		for i, v := range t.EnumValues {
+			values[i] = v
+		}
+		return nil, map[string]any{"type": "string", "enum": values}
+	case KindObject:
+		return s.selectFields(t, depth)
+	case KindInterface, KindUnion:
+		concrete := s.concrete(t)
+		if concrete == nil {
+			if t.Kind == KindInterface {
+				return s.selectFields(t, depth)
+			}
+			return []string{"__typename"}, map[string]any{"type": "object", "properties": map[string]any{"__typename": map[string]any{"const": t.Name}}}
+		}
+		lines, schema := s.selectFields(concrete, depth)
+		schema["properties"].(map[string]any)["__typename"] = map[string]any{"const": concrete.Name}
+		if len(lines) == 1 && lines[0] == "__typename" {
+			return lines, schema
+		}
+		out := []string{"__typename", "... on " + concrete.Name + " {"}
+		for _, line := range lines {
+			out = append(out, "  "+line)
+		}
+		return append(out, "}"), schema
+	}
+	return nil, scalarSchema(t.Name)
+}
+
+// selectFields selects every field of an object or interface that takes no required
+// argument; below maxSelectionDepth only scalar and enum fields are selected
+func (s *Schema) selectFields(t *Type, depth int) ([]string, map[string]any) {
+	var lines []string
+	props := map[string]any{}
+	for _, f := range t.Fields {
+		if strings.HasPrefix(f.Name, "__") || hasRequiredArgs(f) {
+			continue
+		}
+		leaf := s.isLeaf(f.Type)
+		if !leaf && depth >= maxSelectionDepth {
+			continue
+		}
+		sub, schema := s.selectType(f.Type, depth+1)
+		props[f.Name] = schema
+		if leaf {
+			lines = append(lines, f.Name)
+			continue
+		}
+		lines = append(lines, f.Name+" {")
+		for _, line := range sub {
+			lines = append(lines, "  "+line)
+		}
+		lines = append(lines, "}")
+	}
+	if len(lines) == 0 {
+		lines = []string{"__typename"}
+		props["__typename"] = map[string]any{"const": t.Name}
+	}
+	return lines, map[string]any{"type": "object", "properties": props}
+}
+
+// concrete picks the object type examples of an interface or union use: the first by name
+func (s *Schema) concrete(t *Type) *Type {
+	names := append([]string(nil), t.PossibleTypes...)
+	sort.Strings(names)
+	for _, name := range names {
+		if c := s.Types[name]; c != nil && c.Kind == KindObject {
+			return c
+		}
+	}
+	return nil
+}
+
+func (s *Schema) isLeaf(ref *TypeRef) bool {
+	t := s.Types[ref.Named()]
+	return t == nil || t.Kind == KindScalar || t.Kind == KindEnum
+}
+
+func hasRequiredArgs(f *Field) bool {
+	for _, a := range f.Args {
+		if a.Type.NonNull {
+			return true
+		}
+	}
+	return false
+}
+
+// scalarSchema maps a scalar to a JSON Schema type. Custom scalars are recognized by common
+// names such as DateTime, UUID, URL or JSON; anything else is a string.
+func scalarSchema(name string) map[string]any {
+	switch name {
+	case "Int":
+		return map[string]any{"type": "integer"}
+	case "Float":
+		return map[string]any{"type": "number"}
+	case "Boolean":
+		return map[string]any{"type": "boolean"}
+	case "ID", "String":
+		return map[string]any{"type": "string"}
+	}
+	n := strings.ToLower(name)
+	format := ""
+	switch {
+	case strings.Contains(n, "datetime") || strings.Contains(n, "timestamp") || n == "instant":
+		format = "date-time"
+	case strings.Contains(n, "date"):
+		format = "date"
+	case strings.Contains(n, "time"):
+		format = "time"
+	case strings.Contains(n, "uuid"):
+		format = "uuid"
+	case strings.Contains(n, "email"):
+		format = "email"
+	case strings.Contains(n, "url") || strings.Contains(n, "uri"):
+		format = "uri"
+	case strings.Contains(n, "json") || n == "object" || n == "map":
+		return map[string]any{"type": "object"}
+	case strings.Contains(n, "long") || strings.Contains(n, "bigint") || strings.HasSuffix(n, "int"):
+		return map[string]any{"type": "integer"}
+	case strings.Contains(n, "decimal") || strings.Contains(n, "float") || strings.Contains(n, "money"):
+		return map[string]any{"type": "number"}
+	}
+	if format == "" {
+		return map[string]any{"type": "string"}
+	}
+	return map[string]any{"type": "string", "format": format}
+}
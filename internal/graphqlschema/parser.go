@@ -0,0 +1,716 @@
+// Package graphqlschema reads GraphQL schemas, written in SDL or as an introspection result,
+// so the queries and mutations they declare can be mocked without a running server.
+package graphqlschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Type kinds, as reported by introspection
+const (
+	KindScalar      = "SCALAR"
+	KindObject      = "OBJECT"
+	KindInterface   = "INTERFACE"
+	KindUnion       = "UNION"
+	KindEnum        = "ENUM"
+	KindInputObject = "INPUT_OBJECT"
+)
+
+// Schema is every type of a GraphQL schema and its root operation types
+type Schema struct {
+	Query        string // Root type names; empty when the schema has no such operations
+	Mutation     string
+	Subscription string
+	Types        map[string]*Type
+}
+
+// Type is a named type of the schema
+type Type struct {
+	Name          string
+	Kind          string
+	Description   string
+	Fields        []*Field      // Objects and interfaces
+	InputFields   []*InputValue // Input objects
+	EnumValues    []string
+	Interfaces    []string // Interfaces an object or interface implements
+	PossibleTypes []string // Objects implementing an interface, or members of a union
+}
+
+// Field is a field of an object or interface
+type Field struct {
+	Name        string
+	Description string
+	Args        []*InputValue
+	Type        *TypeRef
+}
+
+// InputValue is an argument or an input object field
+type InputValue struct {
+	Name string
+	Type *TypeRef
+}
+
+// TypeRef is a possibly wrapped type, e.g. [User!]!
+type TypeRef struct {
+	Name    string   // Named type; empty for wrappers
+	List    bool     // A list of OfType
+	NonNull bool     // A non-null OfType
+	OfType  *TypeRef // Wrapped type
+}
+
+// String renders the reference as written in SDL
+func (t *TypeRef) String() string {
+	switch {
+	case t.NonNull:
+		return t.OfType.String() + "!"
+	case t.List:
+		return "[" + t.OfType.String() + "]"
+	}
+	return t.Name
+}
+
+// Named is the named type inside the wrappers
+func (t *TypeRef) Named() string {
+	for t.OfType != nil {
+		t = t.OfType
+	}
+	return t.Name
+}
+
+// builtinScalars are defined by every schema
+var builtinScalars = []string{"Int", "Float", "String", "Boolean", "ID"}
+
+// Load reads a schema file: introspection JSON (a {"data": {"__schema": ...}} response or the
+// bare __schema object) or SDL
+func Load(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return Parse(filepath.Base(path), data)
+}
+
+// Parse reads a schema from introspection JSON or SDL, detected from its contents
+func Parse(name string, data []byte) (*Schema, error) {
+	var s *Schema
+	var err error
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "{") && json.Valid(data) {
+		s, err = parseIntrospection(data)
+	} else {
+		s, err = parseSDL(name, string(data))
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.link()
+	if s.Query == "" && s.Mutation == "" {
+		return nil, fmt.Errorf("%s declares no Query or Mutation type", name)
+	}
+	return s, nil
+}
+
+func newSchema() *Schema {
+	s := &Schema{Types: map[string]*Type{}}
+	for _, name := range builtinScalars {
+		s.Types[name] = &Type{Name: name, Kind: KindScalar}
+	}
+	return s
+}
+
+// link fills in the conventional root types and the implementations of each interface
+func (s *Schema) link() {
+	defaults := []struct {
+		root *string
+		name string
+	}{{&s.Query, "Query"}, {&s.Mutation, "Mutation"}, {&s.Subscription, "Subscription"}}
+	for _, d := range defaults {
+		if *d.root == "" && s.Types[d.name] != nil && s.Types[d.name].Kind == KindObject {
+			*d.root = d.name
+		}
+	}
+	for _, t := range s.Types {
+		for _, iface := range t.Interfaces {
+			if it := s.Types[iface]; it != nil && it.Kind == KindInterface && !contains(it.PossibleTypes, t.Name) && t.Kind == KindObject {
+				it.PossibleTypes = append(it.PossibleTypes, t.Name)
+			}
+		}
+	}
+	for _, t := range s.Types {
+		sort.Strings(t.PossibleTypes)
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ─── SDL ───────────────────────────────────────────────────────────────────────
+
+type parser struct {
+	name        string
+	tokens      []token
+	pos         int
+	schema      *Schema
+	description string // The description string read before the current definition or field
+}
+
+type token struct {
+	text   string
+	line   int
+	string bool // A quoted or block string
+}
+
+func parseSDL(name, source string) (*Schema, error) {
+	tokens, err := tokenize(strings.TrimPrefix(source, "\ufeff"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	p := &parser{name: name, tokens: tokens, schema: newSchema()}
+	if err := p.parseDocument(); err != nil {
+		return nil, err
+	}
+	return p.schema, nil
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	line := 0
+	if p.pos < len(p.tokens) {
+		line = p.tokens[p.pos].line
+	} else if len(p.tokens) > 0 {
+		line = p.tokens[len(p.tokens)-1].line
+	}
+	return fmt.Errorf("%s:%d: %s", p.name, line, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.tokens) && !p.tokens[p.pos].string {
+		return p.tokens[p.pos].text
+	}
+	return ""
+}
+
+func (p *parser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *parser) expect(want string) error {
+	if got := p.next(); got != want {
+		return p.errorf("expected %q, found %q", want, got)
+	}
+	return nil
+}
+
+func (p *parser) parseName() (string, error) {
+	tok := p.next()
+	if !isName(tok) {
+		return "", p.errorf("expected a name, found %q", tok)
+	}
+	return tok, nil
+}
+
+// readDescription consumes a description string, if one comes next
+func (p *parser) readDescription() string {
+	if p.pos < len(p.tokens) && p.tokens[p.pos].string {
+		d := p.tokens[p.pos].text
+		p.pos++
+		return d
+	}
+	return ""
+}
+
+func (p *parser) parseDocument() error {
+	for p.pos < len(p.tokens) {
+		p.description = p.readDescription()
+		if p.pos >= len(p.tokens) {
+			break
+		}
+		keyword := p.next()
+		if keyword == "extend" {
+			keyword = p.next()
+		}
+		var err error
+		switch keyword {
+		case "schema":
+			err = p.parseSchemaDefinition()
+		case "type":
+			err = p.parseFieldsType(KindObject)
+		case "interface":
+			err = p.parseFieldsType(KindInterface)
+		case "input":
+			err = p.parseInputType()
+		case "enum":
+			err = p.parseEnum()
+		case "union":
+			err = p.parseUnion()
+		case "scalar":
+			err = p.parseScalar()
+		case "directive":
+			err = p.skipDirectiveDefinition()
+		default:
+			p.pos--
+			return p.errorf("unexpected %q", keyword)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// define returns the type called name, creating it; extensions add to an existing type
+func (p *parser) define(name, kind string) (*Type, error) {
+	t := p.schema.Types[name]
+	if t == nil {
+		t = &Type{Name: name, Kind: kind}
+		p.schema.Types[name] = t
+	} else if t.Kind != kind {
+		return nil, p.errorf("%s is both %s and %s", name, strings.ToLower(t.Kind), strings.ToLower(kind))
+	}
+	if t.Description == "" {
+		t.Description = p.description
+	}
+	return t, nil
+}
+
+func (p *parser) parseSchemaDefinition() error {
+	if err := p.skipDirectives(); err != nil {
+		return err
+	}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for p.peek() != "}" {
+		op := p.next()
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+		name, err := p.parseName()
+		if err != nil {
+			return err
+		}
+		switch op {
+		case "query":
+			p.schema.Query = name
+		case "mutation":
+			p.schema.Mutation = name
+		case "subscription":
+			p.schema.Subscription = name
+		default:
+			return p.errorf("unknown root operation %q", op)
+		}
+	}
+	return p.expect("}")
+}
+
+func (p *parser) parseFieldsType(kind string) error {
+	name, err := p.parseName()
+	if err != nil {
+		return err
+	}
+	t, err := p.define(name, kind)
+	if err != nil {
+		return err
+	}
+	if p.peek() == "implements" {
+		p.next()
+		for {
+			if p.peek() == "&" {
+				p.next()
+			}
+			iface, err := p.parseName()
+			if err != nil {
+				return err
+			}
+			t.Interfaces = append(t.Interfaces, iface)
+			if p.peek() != "&" {
+				break
+			}
+		}
+	}
+	if err := p.skipDirectives(); err != nil {
+		return err
+	}
+	if p.peek() != "{" {
+		return nil
+	}
+	p.next()
+	for p.peek() != "}" {
+		if p.pos >= len(p.tokens) {
+			return p.errorf("unterminated %s %s", strings.ToLower(kind), name)
+		}
+		f := &Field{Description: p.readDescription()}
+		if f.Name, err = p.parseName(); err != nil {
+			return err
+		}
+		if p.peek() == "(" {
+			if f.Args, err = p.parseInputValues("(", ")"); err != nil {
+				return err
+			}
+		}
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+		if f.Type, err = p.parseTypeRef(); err != nil {
+			return err
+		}
+		if err := p.skipDirectives(); err != nil {
+			return err
+		}
+		t.Fields = append(t.Fields, f)
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) parseInputType() error {
+	name, err := p.parseName()
+	if err != nil {
+		return err
+	}
+	t, err := p.define(name, KindInputObject)
+	if err != nil {
+		return err
+	}
+	if err := p.skipDirectives(); err != nil {
+		return err
+	}
+	if p.peek() != "{" {
+		return nil
+	}
+	fields, err := p.parseInputValues("{", "}")
+	if err != nil {
+		return err
+	}
+	t.InputFields = append(t.InputFields, fields...)
+	return nil
+}
+
+// parseInputValues reads arguments or input fields between open and close, skipping their
+// descriptions, default values and directives
+func (p *parser) parseInputValues(open, close string) ([]*InputValue, error) {
+	if err := p.expect(open); err != nil {
+		return nil, err
+	}
+	var values []*InputValue
+	for p.peek() != close {
+		if p.pos >= len(p.tokens) {
+			return nil, p.errorf("expected %q", close)
+		}
+		p.readDescription()
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		typ, err := p.parseTypeRef()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() == "=" {
+			p.next()
+			if err := p.skipValue(); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.skipDirectives(); err != nil {
+			return nil, err
+		}
+		values = append(values, &InputValue{Name: name, Type: typ})
+	}
+	p.next()
+	return values, nil
+}
+
+func (p *parser) parseTypeRef() (*TypeRef, error) {
+	var t *TypeRef
+	if p.peek() == "[" {
+		p.next()
+		inner, err := p.parseTypeRef()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		t = &TypeRef{List: true, OfType: inner}
+	} else {
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		t = &TypeRef{Name: name}
+	}
+	if p.peek() == "!" {
+		p.next()
+		t = &TypeRef{NonNull: true, OfType: t}
+	}
+	return t, nil
+}
+
+func (p *parser) parseEnum() error {
+	name, err := p.parseName()
+	if err != nil {
+		return err
+	}
+	t, err := p.define(name, KindEnum)
+	if err != nil {
+		return err
+	}
+	if err := p.skipDirectives(); err != nil {
+		return err
+	}
+	if p.peek() != "{" {
+		return nil
+	}
+	p.next()
+	for p.peek() != "}" {
+		p.readDescription()
+		value, err := p.parseName()
+		if err != nil {
+			return err
+		}
+		if err := p.skipDirectives(); err != nil {
+			return err
+		}
+		t.EnumValues = append(t.EnumValues, value)
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) parseUnion() error {
+	name, err := p.parseName()
+	if err != nil {
+		return err
+	}
+	t, err := p.define(name, KindUnion)
+	if err != nil {
+		return err
+	}
+	if err := p.skipDirectives(); err != nil {
+		return err
+	}
+	if p.peek() != "=" {
+		return nil
+	}
+	p.next()
+	if p.peek() == "|" {
+		p.next()
+	}
+	for {
+		member, err := p.parseName()
+		if err != nil {
+			return err
+		}
+		t.PossibleTypes = append(t.PossibleTypes, member)
+		if p.peek() != "|" {
+			return nil
+		}
+		p.next()
+	}
+}
+
+func (p *parser) parseScalar() error {
+	name, err := p.parseName()
+	if err != nil {
+		return err
+	}
+	if _, err := p.define(name, KindScalar); err != nil {
+		return err
+	}
+	return p.skipDirectives()
+}
+
+// skipDirectiveDefinition skips `directive @name(args) repeatable on A | B`
+func (p *parser) skipDirectiveDefinition() error {
+	if err := p.expect("@"); err != nil {
+		return err
+	}
+	if _, err := p.parseName(); err != nil {
+		return err
+	}
+	if p.peek() == "(" {
+		if _, err := p.parseInputValues("(", ")"); err != nil {
+			return err
+		}
+	}
+	if p.peek() == "repeatable" {
+		p.next()
+	}
+	if err := p.expect("on"); err != nil {
+		return err
+	}
+	if p.peek() == "|" {
+		p.next()
+	}
+	for {
+		if _, err := p.parseName(); err != nil {
+			return err
+		}
+		if p.peek() != "|" {
+			return nil
+		}
+		p.next()
+	}
+}
+
+// skipDirectives skips applied directives such as @deprecated(reason: "...")
+func (p *parser) skipDirectives() error {
+	for p.peek() == "@" {
+		p.next()
+		if _, err := p.parseName(); err != nil {
+			return err
+		}
+		if p.peek() == "(" {
+			if err := p.skipBalanced("(", ")"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// skipValue skips a constant value: a scalar, an enum value, a list or an object
+func (p *parser) skipValue() error {
+	switch p.peek() {
+	case "[":
+		return p.skipBalanced("[", "]")
+	case "{":
+		return p.skipBalanced("{", "}")
+	}
+	if p.pos >= len(p.tokens) {
+		return p.errorf("expected a value")
+	}
+	p.pos++
+	return nil
+}
+
+func (p *parser) skipBalanced(open, close string) error {
+	depth := 0
+	for p.pos < len(p.tokens) {
+		switch p.next() {
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return nil
+			}
+		}
+	}
+	return p.errorf("expected %q", close)
+}
+
+func isName(tok string) bool {
+	if tok == "" || unicode.IsDigit(rune(tok[0])) {
+		return false
+	}
+	for _, c := range tok {
+		if c != '_' && !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenize splits SDL into names, numbers, punctuators and strings; commas are insignificant
+// and dropped, and strings keep their contents only
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	line := 1
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r' || c == ',':
+			i++
+		case c == '#':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], `"""`):
+			end := strings.Index(src[i+3:], `"""`)
+			for end >= 0 && src[i+3+end-1] == '\\' {
+				next := strings.Index(src[i+3+end+3:], `"""`)
+				if next < 0 {
+					end = -1
+					break
+				}
+				end += 3 + next
+			}
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated block string", line)
+			}
+			text := src[i+3 : i+3+end]
+			tokens = append(tokens, token{text: strings.TrimSpace(text), line: line, string: true})
+			line += strings.Count(text, "\n")
+			i += end + 6
+		case c == '"':
+			j := i + 1
+			for j < len(src) && src[j] != '"' {
+				if src[j] == '\\' {
+					j++
+				}
+				if j < len(src) && src[j] == '\n' {
+					return nil, fmt.Errorf("line %d: unterminated string", line)
+				}
+				j++
+			}
+			if j >= len(src) {
+				return nil, fmt.Errorf("line %d: unterminated string", line)
+			}
+			text := src[i+1 : j]
+			if unquoted, err := jsonUnquote(src[i : j+1]); err == nil {
+				text = unquoted
+			}
+			tokens = append(tokens, token{text: text, line: line, string: true})
+			i = j + 1
+		case c == '_' || unicode.IsLetter(rune(c)):
+			j := i + 1
+			for j < len(src) && (src[j] == '_' || unicode.IsLetter(rune(src[j])) || unicode.IsDigit(rune(src[j]))) {
+				j++
+			}
+			tokens = append(tokens, token{text: src[i:j], line: line})
+			i = j
+		case c == '-' || unicode.IsDigit(rune(c)):
+			// Numbers only appear in default values, which are skipped
+			j := i + 1
+			for j < len(src) && (unicode.IsDigit(rune(src[j])) || src[j] == '.' || src[j] == 'e' || src[j] == 'E' ||
+				(src[j] == '+' || src[j] == '-') && (src[j-1] == 'e' || src[j-1] == 'E')) {
+				j++
+			}
+			tokens = append(tokens, token{text: src[i:j], line: line})
+			i = j
+		case strings.HasPrefix(src[i:], "..."):
+			tokens = append(tokens, token{text: "...", line: line})
+			i += 3
+		default:
+			tokens = append(tokens, token{text: string(c), line: line})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+func jsonUnquote(s string) (string, error) {
+	var out string
+	err := json.Unmarshal([]byte(s), &out)
+	return out, err
+}
@@ -0,0 +1,193 @@
+// Package hooks runs user-defined commands and webhooks around CLI lifecycle events.
+//
+// Hooks are configured in automock.yaml (current directory, or AUTOMOCK_CONFIG):
+//
+//	hooks:
+//	  pre-deploy:
+//	    - command: ./scripts/require-approval.sh
+//	  post-deploy:
+//	    - webhook: https://hooks.slack.com/services/...
+//	    - command: ./scripts/invalidate-cache.sh
+//	      timeout: 2m
+//
+// A failing pre-* hook aborts the operation; post-* hook failures are reported only.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Lifecycle events hooks can attach to
+const (
+	PreDeploy    = "pre-deploy"
+	PostDeploy   = "post-deploy"
+	PostGenerate = "post-generate"
+	PreDestroy   = "pre-destroy"
+)
+
+// DefaultConfigFile is looked up in the working directory
+const DefaultConfigFile = "automock.yaml"
+
+const defaultTimeout = 5 * time.Minute
+
+// Hook is a single command or webhook bound to an event
+type Hook struct {
+	Command string `yaml:"command,omitempty"`
+	Webhook string `yaml:"webhook,omitempty"`
+	Timeout string `yaml:"timeout,omitempty"` // Go duration, default 5m
+}
+
+// Config maps event names to the hooks run for them
+type Config struct {
+	Hooks map[string][]Hook `yaml:"hooks"`
+}
+
+// Event is passed to hooks as AUTOMOCK_* environment variables (commands)
+// or as the JSON body (webhooks)
+type Event struct {
+	Name      string            `json:"event"`
+	Project   string            `json:"project"`
+	Profile   string            `json:"profile,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+	Data      map[string]string `json:"data,omitempty"`
+}
+
+// Load reads hook configuration; a missing file yields an empty config
+func Load() (*Config, error) {
+	path := os.Getenv("AUTOMOCK_CONFIG")
+	if path == "" {
+		path = DefaultConfigFile
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &Config{}, nil
+		}
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	for event, hooks := range cfg.Hooks {
+		switch event {
+		case PreDeploy, PostDeploy, PostGenerate, PreDestroy:
+		default:
+			return nil, fmt.Errorf("%s: unknown hook event %q", path, event)
+		}
+		for i, h := range hooks {
+			if (h.Command == "") == (h.Webhook == "") {
+				return nil, fmt.Errorf("%s: %s hook #%d must set exactly one of command or webhook", path, event, i+1)
+			}
+			if h.Timeout != "" {
+				if _, err := time.ParseDuration(h.Timeout); err != nil {
+					return nil, fmt.Errorf("%s: %s hook #%d: invalid timeout %q", path, event, i+1, h.Timeout)
+				}
+			}
+		}
+	}
+	return &cfg, nil
+}
+
+// Run loads the configuration and runs every hook bound to the event in order.
+// It stops at the first failure and returns it.
+func Run(event, project, profile string, data map[string]string) error {
+	cfg, err := Load()
+	if err != nil {
+		return err
+	}
+	hooks := cfg.Hooks[event]
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	ev := Event{Name: event, Project: project, Profile: profile, Timestamp: time.Now().UTC(), Data: data}
+	fmt.Printf("🪝 Running %d %s hook(s)\n", len(hooks), event)
+	for i, h := range hooks {
+		timeout := defaultTimeout
+		if h.Timeout != "" {
+			timeout, _ = time.ParseDuration(h.Timeout)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		if h.Command != "" {
+			err = runCommand(ctx, h.Command, ev)
+		} else {
+			err = postWebhook(ctx, h.Webhook, ev)
+		}
+		cancel()
+		if err != nil {
+			return fmt.Errorf("%s hook #%d failed: %w", event, i+1, err)
+		}
+	}
+	return nil
+}
+
+// RunPost runs post-* hooks, reporting failures without failing the operation
+func RunPost(event, project, profile string, data map[string]string) {
+	if err := Run(event, project, profile, data); err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+	}
+}
+
+func runCommand(ctx context.Context, command string, ev Event) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"AUTOMOCK_EVENT="+ev.Name,
+		"AUTOMOCK_PROJECT="+ev.Project,
+		"AUTOMOCK_PROFILE="+ev.Profile,
+	)
+	for k, v := range ev.Data {
+		cmd.Env = append(cmd.Env, "AUTOMOCK_"+strings.ToUpper(strings.ReplaceAll(k, "-", "_"))+"="+v)
+	}
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("%q timed out", command)
+		}
+		return fmt.Errorf("%q: %w", command, err)
+	}
+	return nil
+}
+
+func postWebhook(ctx context.Context, url string, ev Event) error {
+	b, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "auto-mock")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("POST %s: %s\n%s", url, resp.Status, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, DefaultConfigFile)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AUTOMOCK_CONFIG", path)
+	return dir
+}
+
+func TestLoad_MissingFileIsEmpty(t *testing.T) {
+	t.Setenv("AUTOMOCK_CONFIG", filepath.Join(t.TempDir(), "none.yaml"))
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.Hooks) != 0 {
+		t.Fatalf("expected no hooks, got %v", cfg.Hooks)
+	}
+}
+
+func TestLoad_RejectsInvalidHooks(t *testing.T) {
+	cases := map[string]string{
+		"unknown event": "hooks:\n  mid-deploy:\n    - command: echo\n",
+		"both kinds":    "hooks:\n  pre-deploy:\n    - command: echo\n      webhook: http://x\n",
+		"bad timeout":   "hooks:\n  pre-deploy:\n    - command: echo\n      timeout: soon\n",
+	}
+	for name, content := range cases {
+		writeConfig(t, content)
+		if _, err := Load(); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestRun_CommandReceivesEventEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	dir := writeConfig(t, "")
+	out := filepath.Join(dir, "out.txt")
+	writeConfig(t, "hooks:\n  post-deploy:\n    - command: echo \"$AUTOMOCK_EVENT $AUTOMOCK_PROJECT $AUTOMOCK_ENDPOINT\" > "+out+"\n")
+
+	if err := Run(PostDeploy, "orders", "", map[string]string{"endpoint": "http://mock"}); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	b, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(b)); got != "post-deploy orders http://mock" {
+		t.Fatalf("unexpected hook output %q", got)
+	}
+}
+
+func TestRun_PreHookFailureIsReturned(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	writeConfig(t, "hooks:\n  pre-deploy:\n    - command: exit 3\n")
+	if err := Run(PreDeploy, "orders", "", nil); err == nil {
+		t.Fatal("expected failing pre-deploy hook to return an error")
+	}
+}
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"context"
+	"time"
 
 	"github.com/hemantobora/auto-mock/internal/models"
 )
@@ -19,6 +20,8 @@ type Provider interface {
 	SaveVersion(ctx context.Context, config *models.MockConfiguration, version string) error
 	GetVersion(ctx context.Context, projectID, version string) (*models.MockConfiguration, error)
 	ListVersions(ctx context.Context, projectID string) ([]models.VersionInfo, error)
+	// DeleteVersion permanently removes a version snapshot (all stored object versions)
+	DeleteVersion(ctx context.Context, projectID, version string) (int, error)
 
 	// Project management
 	ListProjects(ctx context.Context) ([]models.ProjectInfo, error)
@@ -29,6 +32,8 @@ type Provider interface {
 
 	// Provider info
 	GetProviderType() string // Returns "aws", "gcp", "azure", etc.
+	// CredentialEnv returns env vars passing assumed-role credentials to subprocesses (nil if none)
+	CredentialEnv(ctx context.Context) ([]string, error)
 
 	// Ensure resources
 	InitProject(ctx context.Context, projectID string) error
@@ -47,7 +52,10 @@ type Provider interface {
 	IsDeployed() (bool, error)
 
 	CreateDeploymentConfiguration() *models.DeploymentOptions
-	DisplayCostEstimate(options *models.DeploymentOptions)
+	// EstimateCost approximates the monthly cost of a deployment; nil = nothing to pay for
+	EstimateCost(options *models.DeploymentOptions) *models.CostEstimate
+	// ActualCosts returns what the project's cloud resources cost in [from, to)
+	ActualCosts(ctx context.Context, projectID string, from, to time.Time) (*models.CostReport, error)
 	CreateDefaultDeploymentConfiguration() *models.DeploymentOptions
 
 	// Load test bundle management
@@ -55,6 +63,11 @@ type Provider interface {
 	GetLoadTestPointer(ctx context.Context, projectID string) (*models.LoadTestPointer, error)
 	DownloadLoadTestBundle(ctx context.Context, projectID, destDir string) (*models.LoadTestPointer, string, error)
 	DeleteLoadTestPointer(ctx context.Context, projectID string) error
+	// SaveLoadTestResult stores the summarized results of a run next to the bundle version it
+	// ran (result.Version) and returns the object key
+	SaveLoadTestResult(ctx context.Context, projectID string, result *models.LoadTestRunResult) (string, error)
+	// ListLoadTestResults returns the stored results of all runs, oldest first
+	ListLoadTestResults(ctx context.Context, projectID string) ([]models.LoadTestRunResult, error)
 
 	// Advanced load test artifact lifecycle
 	// DeleteActiveLoadTestBundleAndRollback deletes the bundle referenced by the current pointer
@@ -66,10 +79,23 @@ type Provider interface {
 	// whether the underlying storage bucket/container was also deleted as a consequence.
 	PurgeLoadTestArtifacts(ctx context.Context, projectID string) (int, bool, error)
 
+	// Load test garbage collection
+	ListLoadTestVersions(ctx context.Context, projectID string) ([]models.LoadTestVersion, error)
+	ListLoadTestBundles(ctx context.Context, projectID string) ([]models.LoadTestBundleInfo, error)
+	// DeleteLoadTestVersion and DeleteLoadTestBundle permanently remove a version snapshot (with
+	// the results of its runs) or a bundle directory and return the number of deleted object versions
+	DeleteLoadTestVersion(ctx context.Context, projectID, version string) (int, error)
+	DeleteLoadTestBundle(ctx context.Context, projectID, bundleID string) (int, error)
+
 	// Load test (Locust) deployment metadata management
 	SaveLoadTestDeploymentMetadata(metadata *models.LoadTestDeploymentOutputs) error
 	GetLoadTestDeploymentMetadata() (*models.LoadTestDeploymentMetadata, error)
 	DeleteLoadTestDeploymentMetadata() error
+
+	// Scheduled load test runs
+	SaveLoadTestSchedule(ctx context.Context, schedule *models.LoadTestSchedule) error
+	GetLoadTestSchedule(ctx context.Context, projectID string) (*models.LoadTestSchedule, error)
+	DeleteLoadTestSchedule(ctx context.Context, projectID string) error
 }
 
 // NamingStrategy defines how project names are converted to storage names
@@ -98,5 +124,8 @@ type NamingStrategy interface {
 	LoadTestBundlesPrefix(projectID string) string
 	LoadTestBundleDir(projectID, bundleID string) string
 	LoadTestBundleFileKey(projectID, bundleID, fileName string) string
+	LoadTestResultKey(projectID, version, runID string) string
+	LoadTestResultsPrefix(projectID string) string
+	LoadTestScheduleKey(projectID string) string
 	LoadTestMetadataKey(projectID string) string
 }
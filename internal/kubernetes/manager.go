@@ -0,0 +1,160 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// rolloutTimeout bounds how long Deploy waits for the MockServer pods to become ready
+const rolloutTimeout = "180s"
+
+// Manager deploys one project's mocks to a cluster with kubectl
+type Manager struct {
+	ProjectName string
+	Options     Options
+}
+
+// NewManager creates a manager for a project's mocks on the cluster opts select
+func NewManager(projectName string, opts Options) *Manager {
+	return &Manager{ProjectName: projectName, Options: opts}
+}
+
+// ManagerFromOutputs returns the manager of a deployment Deploy recorded; false when the
+// outputs describe a cloud deployment
+func ManagerFromOutputs(projectName string, outputs *models.InfrastructureOutputs) (*Manager, bool) {
+	if outputs == nil || outputs.InfrastructureSummary["target"] != Target {
+		return nil, false
+	}
+	summary := outputs.InfrastructureSummary
+	text := func(key string) string {
+		s, _ := summary[key].(string)
+		return s
+	}
+	opts := Options{Context: text("context"), Namespace: text("namespace"), Image: text("image")}
+	if n, ok := summary["replicas"].(float64); ok { // JSON numbers decode as float64
+		opts.Replicas = int(n)
+	} else if n, ok := summary["replicas"].(int); ok {
+		opts.Replicas = n
+	}
+	return NewManager(projectName, opts), true
+}
+
+// CheckKubectlInstalled verifies kubectl is on the PATH
+func CheckKubectlInstalled() error {
+	output, err := exec.Command("kubectl", "version", "--client").Output()
+	if err != nil {
+		return fmt.Errorf("kubectl not found in PATH. Please install kubectl: https://kubernetes.io/docs/tasks/tools/")
+	}
+	fmt.Printf("🔧 Found %s\n", strings.Split(strings.TrimSpace(string(output)), "\n")[0])
+	return nil
+}
+
+// Render returns the manifests serving the configuration on the manager's cluster
+func (m *Manager) Render(config *models.MockConfiguration) ([]byte, error) {
+	return Render(m.ProjectName, config, m.Options)
+}
+
+// Deploy applies the manifests, waits for the rollout and returns the outputs recorded as
+// deployment metadata. An unset namespace is resolved from the kubeconfig first, so the
+// record names the namespace the mocks landed in.
+func (m *Manager) Deploy(ctx context.Context, config *models.MockConfiguration) (*models.InfrastructureOutputs, error) {
+	if m.Options.Namespace == "" {
+		namespace, err := m.kubectl(ctx, "config", "view", "--minify", "--output", "jsonpath={..namespace}")
+		if err != nil {
+			return nil, err
+		}
+		if m.Options.Namespace = strings.TrimSpace(namespace); m.Options.Namespace == "" {
+			m.Options.Namespace = "default"
+		}
+	}
+	manifests, err := m.Render(config)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.run(ctx, manifests, "apply", "--filename", "-"); err != nil {
+		return nil, fmt.Errorf("kubectl apply failed: %w", err)
+	}
+	if err := m.run(ctx, nil, "rollout", "status", "deployment/"+Name(m.ProjectName), "--timeout", rolloutTimeout); err != nil {
+		return nil, fmt.Errorf("MockServer did not become ready: %w", err)
+	}
+	return m.outputs(), nil
+}
+
+// Destroy deletes the project's ConfigMap, Deployment and Service; missing ones are ignored
+func (m *Manager) Destroy(ctx context.Context) error {
+	args := append([]string{"delete", "--ignore-not-found"}, Resources(m.ProjectName)...)
+	if err := m.run(ctx, nil, args...); err != nil {
+		return fmt.Errorf("kubectl delete failed: %w", err)
+	}
+	return nil
+}
+
+// Status returns kubectl's view of the project's Deployment and Service
+func (m *Manager) Status(ctx context.Context) (string, error) {
+	return m.kubectl(ctx, append([]string{"get", "--output", "wide"}, Resources(m.ProjectName)[1:]...)...)
+}
+
+// outputs records where the mocks run and how to reach them
+func (m *Manager) outputs() *models.InfrastructureOutputs {
+	name := Name(m.ProjectName)
+	prefix := strings.Join(append([]string{"kubectl"}, m.flags()...), " ")
+	return &models.InfrastructureOutputs{
+		MockServerURL: fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", name, m.Options.Namespace, Port),
+		CLICommands: map[string]string{
+			"port_forward": fmt.Sprintf("%s port-forward service/%s %d:%d", prefix, name, Port, Port),
+			"logs":         fmt.Sprintf("%s logs deployment/%s", prefix, name),
+		},
+		InfrastructureSummary: map[string]interface{}{
+			"target":    Target,
+			"context":   m.Options.Context,
+			"namespace": m.Options.Namespace,
+			"image":     m.Options.Image,
+			"replicas":  m.Options.Replicas,
+			"resources": Resources(m.ProjectName),
+		},
+	}
+}
+
+// flags selects the manager's context and namespace on a kubectl command line
+func (m *Manager) flags() []string {
+	var flags []string
+	if m.Options.Context != "" {
+		flags = append(flags, "--context", m.Options.Context)
+	}
+	if m.Options.Namespace != "" {
+		flags = append(flags, "--namespace", m.Options.Namespace)
+	}
+	return flags
+}
+
+// run runs kubectl with its output passed through, feeding stdin when given
+func (m *Manager) run(ctx context.Context, stdin []byte, args ...string) error {
+	cmd := exec.CommandContext(ctx, "kubectl", append(m.flags(), args...)...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// kubectl runs kubectl and returns its standard output
+func (m *Manager) kubectl(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "kubectl", append(m.flags(), args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("kubectl %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("kubectl %s: %w", args[0], err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,163 @@
+// Package kubernetes deploys mocks to an existing Kubernetes cluster instead of creating cloud
+// resources: MockServer runs as a Deployment behind a ClusterIP Service, with the served
+// expectations in a ConfigMap mounted as its initialization file. Manifests are rendered here
+// and applied with the user's kubectl and kubeconfig, so no cloud account is involved.
+package kubernetes
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Target is the deploy --target value selecting a Kubernetes cluster
+const Target = "kubernetes"
+
+// DefaultImage is the MockServer image deployed unless Options.Image is set; it matches the cloud deployments
+const DefaultImage = "mockserver/mockserver:5.15.0"
+
+// Port is the port MockServer listens on and the Service exposes
+const Port = 1080
+
+// maxConfigMapBytes keeps the expectations under the 1 MiB ConfigMap limit, leaving room for metadata
+const maxConfigMapBytes = 1000 * 1024
+
+// expectationsFile is the ConfigMap key and mounted file name of the served expectations
+const expectationsFile = "expectations.json"
+
+// Options selects the cluster and shapes the deployed resources
+type Options struct {
+	Context   string `json:"context,omitempty"`   // kubeconfig context (empty = current)
+	Namespace string `json:"namespace,omitempty"` // empty = the context's namespace
+	Image     string `json:"image,omitempty"`     // empty = DefaultImage
+	Replicas  int    `json:"replicas,omitempty"`  // 0 = 1
+}
+
+// Name is the name of the Deployment and Service of a project
+func Name(project string) string {
+	return "automock-" + project
+}
+
+// configMapName is the name of the ConfigMap holding a project's expectations
+func configMapName(project string) string {
+	return Name(project) + "-expectations"
+}
+
+// Resources lists the objects rendered for a project, as kubectl kind/name arguments
+func Resources(project string) []string {
+	return []string{
+		"configmap/" + configMapName(project),
+		"deployment/" + Name(project),
+		"service/" + Name(project),
+	}
+}
+
+// Render returns the ConfigMap, Deployment and Service serving the configuration's expectations
+// as one multi-document YAML stream. The pod template carries a checksum of the expectations,
+// so applying changed expectations rolls the pods instead of waiting for the volume to refresh.
+func Render(project string, config *models.MockConfiguration, opts Options) ([]byte, error) {
+	expectations := models.MockServerOnlyJSON(config.Expectations)
+	if len(expectations) > maxConfigMapBytes {
+		return nil, fmt.Errorf("expectations are %d KiB; a ConfigMap holds at most %d KiB", len(expectations)/1024, maxConfigMapBytes/1024)
+	}
+	image := opts.Image
+	if image == "" {
+		image = DefaultImage
+	}
+	replicas := opts.Replicas
+	if replicas <= 0 {
+		replicas = 1
+	}
+	sum := sha256.Sum256([]byte(expectations))
+
+	selector := map[string]string{
+		"app.kubernetes.io/name":     "mockserver",
+		"app.kubernetes.io/instance": project,
+	}
+	labels := map[string]string{"app.kubernetes.io/managed-by": "automock"}
+	for k, v := range selector {
+		labels[k] = v
+	}
+	metadata := func(name string) map[string]any {
+		m := map[string]any{"name": name, "labels": labels}
+		if opts.Namespace != "" {
+			m["namespace"] = opts.Namespace
+		}
+		return m
+	}
+
+	objects := []map[string]any{
+		{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   metadata(configMapName(project)),
+			"data":       map[string]string{expectationsFile: expectations},
+		},
+		{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   metadata(Name(project)),
+			"spec": map[string]any{
+				"replicas": replicas,
+				"selector": map[string]any{"matchLabels": selector},
+				"template": map[string]any{
+					"metadata": map[string]any{
+						"labels":      labels,
+						"annotations": map[string]string{"automock/expectations-sha256": hex.EncodeToString(sum[:])},
+					},
+					"spec": map[string]any{
+						"containers": []map[string]any{{
+							"name":  "mockserver",
+							"image": image,
+							"ports": []map[string]any{{"name": "http", "containerPort": Port}},
+							"env": []map[string]string{
+								{"name": "MOCKSERVER_SERVER_PORT", "value": fmt.Sprint(Port)},
+								{"name": "MOCKSERVER_LOG_LEVEL", "value": "INFO"},
+								{"name": "MOCKSERVER_INITIALIZATION_JSON_PATH", "value": "/config/" + expectationsFile},
+							},
+							"volumeMounts": []map[string]any{{"name": "expectations", "mountPath": "/config", "readOnly": true}},
+							"readinessProbe": map[string]any{
+								"tcpSocket":           map[string]any{"port": "http"},
+								"initialDelaySeconds": 5,
+								"periodSeconds":       10,
+							},
+						}},
+						"volumes": []map[string]any{{
+							"name":      "expectations",
+							"configMap": map[string]any{"name": configMapName(project)},
+						}},
+					},
+				},
+			},
+		},
+		{
+			"apiVersion": "v1",
+			"kind":       "Service",
+			"metadata":   metadata(Name(project)),
+			"spec": map[string]any{
+				"type":     "ClusterIP",
+				"selector": selector,
+				"ports":    []map[string]any{{"name": "http", "port": Port, "targetPort": "http"}},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# MockServer for the %s mocks, rendered by automock\n", project)
+	for i, object := range objects {
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(object); err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", object["kind"], err)
+		}
+		enc.Close()
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,133 @@
+package kubernetes
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+func sampleConfig(path string) *models.MockConfiguration {
+	return &models.MockConfiguration{Expectations: []models.MockExpectation{{
+		HttpRequest:  &models.HttpRequest{Method: "GET", Path: path},
+		HttpResponse: &models.HttpResponse{StatusCode: 200},
+	}}}
+}
+
+// decode splits a rendered stream into its objects
+func decode(t *testing.T, manifests []byte) []map[string]any {
+	t.Helper()
+	var objects []map[string]any
+	dec := yaml.NewDecoder(bytes.NewReader(manifests))
+	for {
+		var object map[string]any
+		if err := dec.Decode(&object); errors.Is(err, io.EOF) {
+			return objects
+		} else if err != nil {
+			t.Fatalf("rendered YAML does not parse: %v\n%s", err, manifests)
+		}
+		objects = append(objects, object)
+	}
+}
+
+// podAnnotation returns the expectations checksum on a rendered Deployment's pod template
+func podAnnotation(deployment map[string]any) any {
+	template := deployment["spec"].(map[string]any)["template"].(map[string]any)
+	return template["metadata"].(map[string]any)["annotations"].(map[string]any)["automock/expectations-sha256"]
+}
+
+func TestRender(t *testing.T) {
+	manifests, err := Render("users", sampleConfig("/users"), Options{Namespace: "mocks", Replicas: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objects := decode(t, manifests)
+	if len(objects) != 3 {
+		t.Fatalf("rendered %d objects, want 3", len(objects))
+	}
+	var kinds []string
+	for _, object := range objects {
+		kinds = append(kinds, object["kind"].(string))
+		if ns := object["metadata"].(map[string]any)["namespace"]; ns != "mocks" {
+			t.Errorf("%s namespace = %v", object["kind"], ns)
+		}
+	}
+	if strings.Join(kinds, ",") != "ConfigMap,Deployment,Service" {
+		t.Errorf("kinds = %v", kinds)
+	}
+
+	served := objects[0]["data"].(map[string]any)["expectations.json"].(string)
+	var expectations []models.MockExpectation
+	if err := json.Unmarshal([]byte(served), &expectations); err != nil || expectations[0].HttpRequest.Path != "/users" {
+		t.Errorf("mounted expectations = %s, %v", served, err)
+	}
+	if replicas := objects[1]["spec"].(map[string]any)["replicas"]; replicas != 2 {
+		t.Errorf("replicas = %v", replicas)
+	}
+	if !strings.Contains(string(manifests), DefaultImage) {
+		t.Error("default image not used")
+	}
+
+	// Changed expectations change the pod template, so applying them rolls the pods
+	changed, _ := Render("users", sampleConfig("/orders"), Options{Namespace: "mocks"})
+	if podAnnotation(decode(t, changed)[1]) == podAnnotation(objects[1]) {
+		t.Error("expectations checksum did not change")
+	}
+
+	unscoped, _ := Render("users", sampleConfig("/users"), Options{})
+	if strings.Contains(string(unscoped), "namespace:") {
+		t.Error("namespace rendered although none was given")
+	}
+}
+
+func TestRenderRejectsOversizedExpectations(t *testing.T) {
+	config := sampleConfig("/users")
+	config.Expectations[0].HttpResponse.Body = strings.Repeat("x", maxConfigMapBytes)
+	if _, err := Render("users", config, Options{}); err == nil {
+		t.Error("rendered expectations too large for a ConfigMap")
+	}
+}
+
+func TestRenderMountsOnlyMockServerFields(t *testing.T) {
+	config := sampleConfig("/users")
+	config.Expectations[0].Tags = []string{"users"}
+	config.Expectations[0].Notes = "Lists every user"
+	manifests, err := Render("users", config, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	served := decode(t, manifests)[0]["data"].(map[string]any)["expectations.json"].(string)
+	var expectations []map[string]any
+	if err := json.Unmarshal([]byte(served), &expectations); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"tags", "notes"} {
+		if _, ok := expectations[0][key]; ok {
+			t.Errorf("mounted expectations carry %q, which MockServer does not load:\n%s", key, served)
+		}
+	}
+}
+
+func TestManagerFromOutputs(t *testing.T) {
+	m := NewManager("users", Options{Context: "shared", Namespace: "mocks", Replicas: 3})
+	data, _ := json.Marshal(m.outputs())
+	var outputs models.InfrastructureOutputs
+	if err := json.Unmarshal(data, &outputs); err != nil {
+		t.Fatal(err)
+	}
+	got, ok := ManagerFromOutputs("users", &outputs)
+	if !ok || got.Options != m.Options {
+		t.Errorf("manager from outputs = %+v, %v", got, ok)
+	}
+	if outputs.MockServerURL != "http://automock-users.mocks.svc.cluster.local:1080" {
+		t.Errorf("URL = %s", outputs.MockServerURL)
+	}
+	if _, ok := ManagerFromOutputs("users", &models.InfrastructureOutputs{MockServerURL: "https://alb"}); ok {
+		t.Error("cloud outputs taken for a cluster deployment")
+	}
+}
@@ -0,0 +1,124 @@
+package loadtest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Load testing tools a bundle can be generated for
+const (
+	ToolLocust  = "locust"
+	ToolK6      = "k6"
+	ToolGatling = "gatling"
+	ToolJMeter  = "jmeter"
+)
+
+// Tools lists the tools in the order they are offered
+var Tools = []string{ToolLocust, ToolK6, ToolGatling, ToolJMeter}
+
+// Layout names the files of a bundle for one tool
+type Layout struct {
+	Tool       string
+	Required   []string          // files an upload cannot do without
+	Optional   []string          // uploaded when present
+	Entrypoint string            // file the tool runs
+	Files      map[string]string // logical name (as stored in the pointer) -> file name
+}
+
+// LayoutFor returns the bundle layout of a tool; unknown tools get the Locust layout
+func LayoutFor(tool string) Layout {
+	switch strings.ToLower(tool) {
+	case ToolK6:
+		return Layout{
+			Tool:       ToolK6,
+			Required:   []string{"script.js", "k6_endpoints.json", "k6_options.json"},
+			Optional:   []string{"user_data.json", "manifest.json"},
+			Entrypoint: "script.js",
+			Files: map[string]string{
+				"script":    "script.js",
+				"endpoints": "k6_endpoints.json",
+				"options":   "k6_options.json",
+				"user_data": "user_data.json",
+				"manifest":  "manifest.json",
+			},
+		}
+	case ToolGatling:
+		return Layout{
+			Tool:       ToolGatling,
+			Required:   []string{"AutoMockSimulation.scala", "pom.xml", "gatling_endpoints.json"},
+			Optional:   []string{"user_data.csv", "manifest.json"},
+			Entrypoint: "AutoMockSimulation.scala",
+			Files: map[string]string{
+				"simulation": "AutoMockSimulation.scala",
+				"pom":        "pom.xml",
+				"endpoints":  "gatling_endpoints.json",
+				"user_data":  "user_data.csv",
+				"manifest":   "manifest.json",
+			},
+		}
+	case ToolJMeter:
+		return Layout{
+			Tool:       ToolJMeter,
+			Required:   []string{"automock.jmx", "jmeter_endpoints.json"},
+			Optional:   []string{"user_data.csv", "manifest.json"},
+			Entrypoint: "automock.jmx",
+			Files: map[string]string{
+				"plan":      "automock.jmx",
+				"endpoints": "jmeter_endpoints.json",
+				"user_data": "user_data.csv",
+				"manifest":  "manifest.json",
+			},
+		}
+	}
+	return Layout{
+		Tool:       ToolLocust,
+		Required:   []string{"locustfile.py", "requirements.txt", "locust_endpoints.json"},
+		Optional:   []string{"user_data.yaml", "manifest.json"},
+		Entrypoint: "locustfile.py",
+		Files: map[string]string{
+			"locustfile":   "locustfile.py",
+			"requirements": "requirements.txt",
+			"endpoints":    "locust_endpoints.json",
+			"user_data":    "user_data.yaml",
+			"manifest":     "manifest.json",
+		},
+	}
+}
+
+// DetectTool reports which tool a bundle directory was generated for
+func DetectTool(dir string) string {
+	for _, tool := range Tools[1:] {
+		if _, err := os.Stat(filepath.Join(dir, LayoutFor(tool).Files["endpoints"])); err == nil {
+			return tool
+		}
+	}
+	return ToolLocust
+}
+
+// ParseTool validates a --tool value; empty means Locust
+func ParseTool(s string) (string, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return ToolLocust, true
+	}
+	for _, tool := range Tools {
+		if s == tool {
+			return tool, true
+		}
+	}
+	return "", false
+}
+
+// HostHint is the manifest warning for a bundle without a host
+func (l Layout) HostHint() string {
+	switch l.Tool {
+	case ToolK6:
+		return "No host defined; set AM_HOST (e.g. AM_HOST=https://api.example.com ./run_k6.sh) when running k6."
+	case ToolGatling:
+		return "No host defined; set AM_HOST (e.g. AM_HOST=https://api.example.com ./run_gatling.sh) when running Gatling."
+	case ToolJMeter:
+		return "No host defined; set AM_HOST (e.g. AM_HOST=https://api.example.com ./run_jmeter.sh) when running JMeter."
+	}
+	return "No host defined in locustfile; specify 'host =' or set via CLI when running Locust."
+}
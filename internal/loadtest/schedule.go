@@ -0,0 +1,129 @@
+package loadtest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// cronField describes one field of a standard cron expression
+type cronField struct {
+	name     string
+	min, max int
+	names    []string // symbolic values, indexed from min
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+// ParseCron validates a standard 5-field cron expression (minute hour day-of-month month
+// day-of-week, evaluated in UTC) and returns its fields
+func ParseCron(expr string) ([]string, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields (minute hour day-of-month month day-of-week), e.g. \"0 2 * * *\"", expr)
+	}
+	for i, f := range fields {
+		if err := cronFields[i].validate(f); err != nil {
+			return nil, fmt.Errorf("cron expression %q: %w", expr, err)
+		}
+	}
+	return fields, nil
+}
+
+func (c cronField) validate(field string) error {
+	for _, part := range strings.Split(field, ",") {
+		rng, step, hasStep := strings.Cut(part, "/")
+		if hasStep {
+			if n, err := strconv.Atoi(step); err != nil || n < 1 {
+				return fmt.Errorf("invalid step %q in %s field", step, c.name)
+			}
+		}
+		if rng == "*" {
+			continue
+		}
+		lo, hi, isRange := strings.Cut(rng, "-")
+		if _, err := c.value(lo); err != nil {
+			return err
+		}
+		if isRange {
+			if _, err := c.value(hi); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// value resolves a number or symbolic name of the field
+func (c cronField) value(s string) (int, error) {
+	for i, name := range c.names {
+		if strings.EqualFold(s, name) {
+			return c.min + i, nil
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < c.min || n > c.max {
+		return 0, fmt.Errorf("invalid %s %q (%d-%d)", c.name, s, c.min, c.max)
+	}
+	return n, nil
+}
+
+// AWSCron converts a standard cron expression to an EventBridge Scheduler expression,
+// cron(minute hour day-of-month month day-of-week year). EventBridge needs one of the two
+// day fields to be "?" and numbers the days of the week from 1 (Sunday).
+func AWSCron(expr string) (string, error) {
+	fields, err := ParseCron(expr)
+	if err != nil {
+		return "", err
+	}
+	dom, dow := fields[2], fields[4]
+	switch {
+	case dow == "*":
+		dow = "?"
+	case dom == "*":
+		dom = "?"
+		dow = awsWeekdays(dow)
+	default:
+		return "", fmt.Errorf("cron expression %q restricts both the day of month and the day of week, which AWS schedules do not support", expr)
+	}
+	return fmt.Sprintf("cron(%s %s %s %s %s *)", fields[0], fields[1], dom, fields[3], dow), nil
+}
+
+// awsWeekdays renumbers the numeric days of a day-of-week field from 0-7 (Sunday = 0 or 7)
+// to 1-7 (Sunday = 1); symbolic days are kept
+func awsWeekdays(field string) string {
+	renumber := func(s string) string {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return s
+		}
+		return strconv.Itoa(n%7 + 1)
+	}
+	parts := strings.Split(field, ",")
+	for i, part := range parts {
+		rng, step, hasStep := strings.Cut(part, "/")
+		if rng != "*" {
+			lo, hi, isRange := strings.Cut(rng, "-")
+			switch {
+			case !isRange:
+				rng = renumber(lo)
+			case hi == "7" && !hasStep:
+				// A range ending on Sunday (7) wraps to 1: 5-7 becomes 6-7,1
+				rng = renumber(lo) + "-7,1"
+			default:
+				rng = renumber(lo) + "-" + renumber(hi)
+			}
+		}
+		if hasStep {
+			rng += "/" + step
+		}
+		parts[i] = rng
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,41 @@
+package loadtest
+
+import "testing"
+
+func TestParseCron(t *testing.T) {
+	for _, expr := range []string{"0 2 * * *", "*/15 8-18 * * MON-FRI", "30 1 1,15 * *", "0 0 * JAN,JUL 0"} {
+		if _, err := ParseCron(expr); err != nil {
+			t.Errorf("%q: unexpected error %v", expr, err)
+		}
+	}
+	for _, expr := range []string{"0 2 * *", "60 2 * * *", "0 24 * * *", "0 2 * * FUNDAY", "*/0 * * * *", "0 2 * * * *"} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("%q: expected an error", expr)
+		}
+	}
+}
+
+func TestAWSCron(t *testing.T) {
+	cases := map[string]string{
+		"0 2 * * *":         "cron(0 2 * * ? *)",
+		"30 1 1,15 * *":     "cron(30 1 1,15 * ? *)",
+		"0 6 * * 1-5":       "cron(0 6 ? * 2-6 *)",
+		"0 6 * * 0":         "cron(0 6 ? * 1 *)",
+		"0 6 * * 5-7":       "cron(0 6 ? * 6-7,1 *)",
+		"0 6 * * MON-FRI":   "cron(0 6 ? * MON-FRI *)",
+		"*/15 8-18 * * 1,3": "cron(*/15 8-18 ? * 2,4 *)",
+	}
+	for expr, want := range cases {
+		got, err := AWSCron(expr)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", expr, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%q: got %q, want %q", expr, got, want)
+		}
+	}
+	if _, err := AWSCron("0 2 1 * MON"); err == nil {
+		t.Errorf("expected an error when both day fields are restricted")
+	}
+}
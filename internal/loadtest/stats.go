@@ -0,0 +1,162 @@
+package loadtest
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// aggregatedName is the row Locust reports the totals of all requests under
+const aggregatedName = "Aggregated"
+
+// Sample is a live snapshot of a running Locust test
+type Sample struct {
+	Users        int
+	RPS          float64
+	FailuresPerS float64
+	P50Ms        float64
+	P95Ms        float64
+	Requests     int
+	Failures     int
+}
+
+// Line formats the sample as one terminal line
+func (s Sample) Line(elapsed time.Duration) string {
+	return fmt.Sprintf("%7s  users %-5d rps %-8.1f fail/s %-6.1f p50 %-6s p95 %-6s requests %d (%d failed)",
+		elapsed.Truncate(time.Second), s.Users, s.RPS, s.FailuresPerS, ms(s.P50Ms), ms(s.P95Ms), s.Requests, s.Failures)
+}
+
+func ms(v float64) string {
+	return strconv.FormatFloat(v, 'f', 0, 64) + "ms"
+}
+
+// csvRows reads a CSV with a header row into one map per row, keyed by column name
+func csvRows(r io.Reader) ([]map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	header := records[0]
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, rec := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, name := range header {
+			if i < len(rec) {
+				row[name] = rec[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func num(row map[string]string, column string) float64 {
+	v, _ := strconv.ParseFloat(strings.TrimSpace(row[column]), 64)
+	return v
+}
+
+// LastHistorySample returns the latest totals in a Locust <prefix>_stats_history.csv
+// (written with --csv-full-history), or false while there are none yet
+func LastHistorySample(r io.Reader) (Sample, bool) {
+	rows, err := csvRows(r)
+	if err != nil {
+		return Sample{}, false
+	}
+	for i := len(rows) - 1; i >= 0; i-- {
+		row := rows[i]
+		if row["Name"] != aggregatedName {
+			continue
+		}
+		return Sample{
+			Users:        int(num(row, "User Count")),
+			RPS:          num(row, "Requests/s"),
+			FailuresPerS: num(row, "Failures/s"),
+			P50Ms:        num(row, "50%"),
+			P95Ms:        num(row, "95%"),
+			Requests:     int(num(row, "Total Request Count")),
+			Failures:     int(num(row, "Total Failure Count")),
+		}, true
+	}
+	return Sample{}, false
+}
+
+// LiveSample reads the JSON of a Locust master's /stats/requests endpoint
+func LiveSample(data []byte) (Sample, string, error) {
+	var live struct {
+		State       string             `json:"state"`
+		UserCount   int                `json:"user_count"`
+		TotalRPS    float64            `json:"total_rps"`
+		Percentiles map[string]float64 `json:"current_response_time_percentiles"`
+		Stats       []struct {
+			Name         string  `json:"name"`
+			NumRequests  int     `json:"num_requests"`
+			NumFailures  int     `json:"num_failures"`
+			FailuresPerS float64 `json:"current_fail_per_sec"`
+		} `json:"stats"`
+	}
+	if err := json.Unmarshal(data, &live); err != nil {
+		return Sample{}, "", fmt.Errorf("read Locust stats: %w", err)
+	}
+	s := Sample{
+		Users: live.UserCount,
+		RPS:   live.TotalRPS,
+		P50Ms: live.Percentiles["response_time_percentile_0.5"],
+		P95Ms: live.Percentiles["response_time_percentile_0.95"],
+	}
+	for _, st := range live.Stats {
+		if st.Name == aggregatedName {
+			s.Requests, s.Failures, s.FailuresPerS = st.NumRequests, st.NumFailures, st.FailuresPerS
+		}
+	}
+	return s, live.State, nil
+}
+
+// ParseStats reads Locust's request statistics CSV (<prefix>_stats.csv, or a master's
+// /stats/requests/csv) into the totals and the per-endpoint statistics
+func ParseStats(r io.Reader) (models.LoadTestRunStats, []models.LoadTestRunStats, error) {
+	var total models.LoadTestRunStats
+	rows, err := csvRows(r)
+	if err != nil {
+		return total, nil, fmt.Errorf("read Locust stats: %w", err)
+	}
+	var endpoints []models.LoadTestRunStats
+	found := false
+	for _, row := range rows {
+		st := models.LoadTestRunStats{
+			Method:       row["Type"],
+			Name:         row["Name"],
+			Requests:     int(num(row, "Request Count")),
+			Failures:     int(num(row, "Failure Count")),
+			RPS:          num(row, "Requests/s"),
+			FailuresPerS: num(row, "Failures/s"),
+			AvgMs:        num(row, "Average Response Time"),
+			MinMs:        num(row, "Min Response Time"),
+			MaxMs:        num(row, "Max Response Time"),
+			P50Ms:        num(row, "50%"),
+			P90Ms:        num(row, "90%"),
+			P95Ms:        num(row, "95%"),
+			P99Ms:        num(row, "99%"),
+		}
+		if st.Name == aggregatedName {
+			st.Method = ""
+			total, found = st, true
+			continue
+		}
+		endpoints = append(endpoints, st)
+	}
+	if !found {
+		return total, nil, fmt.Errorf("read Locust stats: no %s row", aggregatedName)
+	}
+	return total, endpoints, nil
+}
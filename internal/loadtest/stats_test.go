@@ -0,0 +1,78 @@
+package loadtest
+
+import (
+	"strings"
+	"testing"
+)
+
+const statsCSV = `Type,Name,Request Count,Failure Count,Median Response Time,Average Response Time,Min Response Time,Max Response Time,Average Content Size,Requests/s,Failures/s,50%,66%,75%,80%,90%,95%,98%,99%,99.9%,99.99%,100%
+GET,/users,120,2,41,45.5,12,310,512,4.0,0.07,41,50,55,60,80,120,200,250,300,310,310
+POST,/orders,30,0,90,95.1,60,400,128,1.0,0.0,90,100,110,115,150,180,300,350,400,400,400
+,Aggregated,150,2,45,55.4,12,400,435,5.0,0.07,45,60,70,75,100,150,250,300,400,400,400
+`
+
+func TestParseStats(t *testing.T) {
+	total, endpoints, err := ParseStats(strings.NewReader(statsCSV))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if total.Name != "Aggregated" || total.Requests != 150 || total.Failures != 2 || total.RPS != 5.0 || total.P95Ms != 150 || total.P99Ms != 300 {
+		t.Errorf("unexpected total: %+v", total)
+	}
+	if len(endpoints) != 2 {
+		t.Fatalf("expected 2 endpoints, got %d", len(endpoints))
+	}
+	if ep := endpoints[1]; ep.Method != "POST" || ep.Name != "/orders" || ep.AvgMs != 95.1 || ep.P90Ms != 150 {
+		t.Errorf("unexpected endpoint: %+v", ep)
+	}
+
+	if _, _, err := ParseStats(strings.NewReader("Type,Name,Request Count\nGET,/users,1\n")); err == nil {
+		t.Errorf("expected an error without an Aggregated row")
+	}
+}
+
+func TestLastHistorySample(t *testing.T) {
+	history := `Timestamp,User Count,Type,Name,Requests/s,Failures/s,50%,66%,75%,80%,90%,95%,98%,99%,99.9%,99.99%,100%,Total Request Count,Total Failure Count
+1700000000,5,,Aggregated,2.0,0.0,40,45,50,55,60,70,80,90,100,100,100,10,0
+1700000000,5,GET,/users,2.0,0.0,40,45,50,55,60,70,80,90,100,100,100,10,0
+1700000002,10,,Aggregated,4.5,0.5,42,48,52,56,65,90,95,99,120,120,120,19,1
+`
+	sample, ok := LastHistorySample(strings.NewReader(history))
+	if !ok {
+		t.Fatalf("expected a sample")
+	}
+	if sample.Users != 10 || sample.RPS != 4.5 || sample.FailuresPerS != 0.5 || sample.P50Ms != 42 || sample.P95Ms != 90 || sample.Requests != 19 || sample.Failures != 1 {
+		t.Errorf("unexpected sample: %+v", sample)
+	}
+
+	if _, ok := LastHistorySample(strings.NewReader("Timestamp,User Count,Type,Name\n")); ok {
+		t.Errorf("expected no sample before the first row")
+	}
+}
+
+func TestLiveSample(t *testing.T) {
+	data := []byte(`{
+		"state": "running",
+		"user_count": 50,
+		"total_rps": 120.5,
+		"current_response_time_percentiles": {"response_time_percentile_0.5": 35, "response_time_percentile_0.95": 140},
+		"stats": [
+			{"name": "/users", "num_requests": 900, "num_failures": 3, "current_fail_per_sec": 0.2},
+			{"name": "Aggregated", "num_requests": 1200, "num_failures": 4, "current_fail_per_sec": 0.3}
+		]
+	}`)
+	sample, state, err := LiveSample(data)
+	if err != nil {
+		t.Fatalf("live sample: %v", err)
+	}
+	if state != "running" {
+		t.Errorf("expected state running, got %q", state)
+	}
+	if sample.Users != 50 || sample.RPS != 120.5 || sample.P50Ms != 35 || sample.P95Ms != 140 || sample.Requests != 1200 || sample.Failures != 4 || sample.FailuresPerS != 0.3 {
+		t.Errorf("unexpected sample: %+v", sample)
+	}
+
+	if _, _, err := LiveSample([]byte("<html>")); err == nil {
+		t.Errorf("expected an error for a non-JSON response")
+	}
+}
@@ -2,6 +2,7 @@ package loadtest
 
 import (
 	"bufio"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -10,6 +11,7 @@ import (
 
 // Result captures validation findings for a generated bundle.
 type Result struct {
+	Tool              string // ToolLocust, ToolK6, ToolGatling or ToolJMeter
 	HostDefined       bool
 	PlaceholderErrors []string
 	Tasks             int
@@ -21,22 +23,26 @@ var (
 	taskClassPattern   = regexp.MustCompile(`class\s+\w+\s*\(.*HttpUser.*\):`)
 	endpointPattern    = regexp.MustCompile(`self\.client\.(get|post|put|delete|patch|options|head)\(\s*['"][^'"]+['"]`)
 	placeholderPattern = regexp.MustCompile(`{{[^{}]+}}`)
+	scenarioPattern    = regexp.MustCompile(`\bscenario\(`)
+	threadGroupPattern = regexp.MustCompile(`<ThreadGroup\s`)
 )
 
-// ValidateBundle inspects key files (locustfile.py, user_data.yaml) returning signals.
-// Non-fatal errors are accumulated in PlaceholderErrors.
+// ValidateBundle inspects key files (locustfile.py, or the endpoint spec and the k6 options,
+// Gatling simulation or JMeter plan, and the user data file) returning signals. Non-fatal
+// errors are accumulated in PlaceholderErrors.
 func ValidateBundle(dir string) (*Result, error) {
-	res := &Result{}
-	// locustfile
-	lf := filepath.Join(dir, "locustfile.py")
-	if data, err := os.ReadFile(lf); err == nil {
+	res := &Result{Tool: DetectTool(dir)}
+	layout := LayoutFor(res.Tool)
+	if res.Tool != ToolLocust {
+		validateSpec(dir, layout, res)
+	} else if data, err := os.ReadFile(filepath.Join(dir, "locustfile.py")); err == nil {
 		content := string(data)
 		res.HostDefined = hostPattern.FindStringIndex(content) != nil
 		res.Tasks = len(taskClassPattern.FindAllString(content, -1))
 		res.Endpoints = len(endpointPattern.FindAllString(content, -1))
 	}
-	// user_data.yaml placeholder scan
-	ud := filepath.Join(dir, "user_data.yaml")
+	// user data placeholder scan
+	ud := filepath.Join(dir, layout.Files["user_data"])
 	if f, err := os.Open(ud); err == nil {
 		defer f.Close()
 		scanner := bufio.NewScanner(f)
@@ -55,3 +61,36 @@ func ValidateBundle(dir string) (*Result, error) {
 	}
 	return res, nil
 }
+
+// validateSpec reads the endpoint count and host from the endpoint spec and counts the
+// scenarios (tasks) in the k6 options, the Gatling simulation or the JMeter thread groups
+func validateSpec(dir string, layout Layout, res *Result) {
+	var spec struct {
+		Config struct {
+			Host string `json:"host"`
+		} `json:"config"`
+		Endpoints []json.RawMessage `json:"endpoints"`
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, layout.Files["endpoints"])); err == nil && json.Unmarshal(data, &spec) == nil {
+		res.Endpoints = len(spec.Endpoints)
+		res.HostDefined = strings.TrimSpace(spec.Config.Host) != ""
+	}
+	switch layout.Tool {
+	case ToolGatling:
+		if data, err := os.ReadFile(filepath.Join(dir, layout.Entrypoint)); err == nil {
+			res.Tasks = len(scenarioPattern.FindAll(data, -1))
+		}
+		return
+	case ToolJMeter:
+		if data, err := os.ReadFile(filepath.Join(dir, layout.Entrypoint)); err == nil {
+			res.Tasks = len(threadGroupPattern.FindAll(data, -1))
+		}
+		return
+	}
+	var options struct {
+		Scenarios map[string]json.RawMessage `json:"scenarios"`
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "k6_options.json")); err == nil && json.Unmarshal(data, &options) == nil {
+		res.Tasks = len(options.Scenarios)
+	}
+}
@@ -52,3 +52,51 @@ func TestValidateBundleNoHost(t *testing.T) {
 		t.Errorf("did not expect host defined")
 	}
 }
+
+func TestValidateBundleK6(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "k6_endpoints.json", `{"config": {"host": "https://api.example.com"}, "endpoints": [{"name": "a"}, {"name": "b"}]}`)
+	writeFile(t, dir, "k6_options.json", `{"scenarios": {"load": {"executor": "ramping-vus"}}}`)
+	writeFile(t, dir, "user_data.json", `[{"account": "{{TODO}}"}]`)
+	res, err := ValidateBundle(dir)
+	if err != nil {
+		t.Fatalf("validate error: %v", err)
+	}
+	if res.Tool != ToolK6 || !res.HostDefined || res.Endpoints != 2 || res.Tasks != 1 || len(res.PlaceholderErrors) != 1 {
+		t.Errorf("unexpected result: %+v", res)
+	}
+	if got := LayoutFor(res.Tool).Entrypoint; got != "script.js" {
+		t.Errorf("k6 entrypoint = %q", got)
+	}
+}
+
+func TestValidateBundleGatlingAndJMeter(t *testing.T) {
+	gatling := t.TempDir()
+	writeFile(t, gatling, "gatling_endpoints.json", `{"endpoints": [{"name": "a"}]}`)
+	writeFile(t, gatling, "AutoMockSimulation.scala", "private val scn = scenario(\"AutoMock\")\n")
+	jmeter := t.TempDir()
+	writeFile(t, jmeter, "jmeter_endpoints.json", `{"endpoints": [{"name": "a"}, {"name": "b"}]}`)
+	writeFile(t, jmeter, "automock.jmx", `<SetupThreadGroup testname="Login"/><ThreadGroup testname="Virtual users"/>`)
+
+	for _, tc := range []struct {
+		dir, tool        string
+		endpoints, tasks int
+	}{
+		{gatling, ToolGatling, 1, 1},
+		{jmeter, ToolJMeter, 2, 1},
+	} {
+		res, err := ValidateBundle(tc.dir)
+		if err != nil {
+			t.Fatalf("validate error: %v", err)
+		}
+		if res.Tool != tc.tool || res.Endpoints != tc.endpoints || res.Tasks != tc.tasks || res.HostDefined {
+			t.Errorf("%s: unexpected result: %+v", tc.tool, res)
+		}
+	}
+	if tool, ok := ParseTool("JMeter"); !ok || tool != ToolJMeter {
+		t.Errorf("ParseTool(JMeter) = %q, %v", tool, ok)
+	}
+	if _, ok := ParseTool("artillery"); ok {
+		t.Errorf("ParseTool accepted an unknown tool")
+	}
+}
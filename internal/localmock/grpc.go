@@ -0,0 +1,268 @@
+package localmock
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/matcher"
+	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/protofile"
+	"github.com/hemantobora/auto-mock/internal/scripting"
+)
+
+// gRPC status codes used by the bridge
+const (
+	grpcOK            = 0
+	grpcUnknown       = 2
+	grpcInvalidArg    = 3
+	grpcUnimplemented = 12
+	grpcInternal      = 13
+)
+
+// maxGRPCMessage bounds a single request message, like gRPC's default 4 MiB limit
+const maxGRPCMessage = 4 << 20
+
+// EnableGRPC answers native gRPC calls (HTTP/2, application/grpc) for the methods in set.
+// A call is matched as the JSON request proto imports generate (POST /pkg.Service/Method)
+// and the matched JSON response is encoded back into protobuf. The handler must be served
+// over HTTP/2, e.g. wrapped with h2c for plaintext.
+func (s *Server) EnableGRPC(set *protofile.Set) {
+	s.protos = set
+}
+
+func (s *Server) isGRPC(r *http.Request) bool {
+	return s.protos != nil && r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc")
+}
+
+// serveGRPC bridges one gRPC call to the expectations. Streaming calls are answered whole:
+// client-streamed messages form a JSON array, and an array response is streamed message by message.
+func (s *Server) serveGRPC(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/grpc+proto")
+	m := s.protos.Method(r.URL.Path)
+	if m == nil {
+		s.logf("✗ gRPC %s → UNIMPLEMENTED (method not in the proto files)", r.URL.Path)
+		grpcFinish(w, grpcUnimplemented, "unknown method "+r.URL.Path)
+		return
+	}
+	frames, err := readGRPCFrames(r.Body, r.Header.Get("Grpc-Encoding"))
+	if err != nil {
+		grpcFinish(w, grpcInternal, err.Error())
+		return
+	}
+	messages := make([]any, 0, len(frames))
+	for _, frame := range frames {
+		msg, err := s.protos.Decode(m.Input, frame)
+		if err != nil {
+			grpcFinish(w, grpcInvalidArg, fmt.Sprintf("invalid %s: %v", m.Input, err))
+			return
+		}
+		messages = append(messages, msg)
+	}
+	var input any = map[string]any{}
+	if m.ClientStreaming {
+		input = messages
+	} else if len(messages) > 0 {
+		input = messages[0]
+	}
+	body, _ := json.Marshal(input)
+	headers := r.Header.Clone()
+	headers.Set("Content-Type", "application/json")
+	req := &matcher.Request{Method: http.MethodPost, Path: r.URL.Path, Query: url.Values{}, Headers: headers, Body: body}
+
+	entry := exchange{
+		HttpRequest: message{Method: req.Method, Path: req.Path, Headers: req.Headers, Body: string(body)},
+		Timestamp:   time.Now().Format("2006-01-02T15:04:05.000Z07:00"),
+	}
+	defer func() { s.record(entry) }()
+
+	exp := s.engine.Handle(req)
+	if exp == nil {
+		s.logf("✗ gRPC %s → UNIMPLEMENTED (no expectation matched; run 'automock match' to see why)", req.Path)
+		grpcFinish(w, grpcUnimplemented, "no expectation matched "+req.Path)
+		return
+	}
+	if exp.Forward != nil {
+		grpcFinish(w, grpcUnimplemented, "forwarding is not supported for gRPC calls")
+		return
+	}
+	resp, err := scripting.Respond(exp, req)
+	if err != nil {
+		s.logf("⚠️  gRPC %s: response template failed (%v); serving the static response", req.Path, err)
+		resp = exp.HttpResponse
+	}
+	if resp == nil {
+		resp = &models.HttpResponse{StatusCode: http.StatusOK}
+	}
+	if d := delayOf(resp.Delay); d > 0 {
+		select {
+		case <-time.After(d):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	status := resp.StatusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+	payload, _ := responsePayload(resp.Body)
+	entry.HttpResponse = &message{StatusCode: status, Body: string(payload), Delay: resp.Delay}
+
+	code, text := grpcCode(status), ""
+	for _, nv := range resp.Headers {
+		if len(nv.Values) == 0 {
+			continue
+		}
+		switch strings.ToLower(nv.Name) {
+		case "grpc-status":
+			if n, err := strconv.Atoi(nv.Values[0]); err == nil {
+				code = n
+			}
+		case "grpc-message":
+			text = nv.Values[0]
+		case "content-type", "content-length":
+		default:
+			for _, v := range nv.Values {
+				w.Header().Add(nv.Name, v) // response metadata
+			}
+		}
+	}
+	if code != grpcOK {
+		if text == "" {
+			text = errorMessage(payload, status)
+		}
+		s.logf("→ gRPC %s → status %d (%s %s)", req.Path, code, exp.HttpRequest.Method, exp.HttpRequest.Path)
+		grpcFinish(w, code, text)
+		return
+	}
+
+	var out any = map[string]any{}
+	if len(bytes.TrimSpace(payload)) > 0 {
+		if err := json.Unmarshal(payload, &out); err != nil {
+			grpcFinish(w, grpcInternal, "response body is not JSON: "+err.Error())
+			return
+		}
+	}
+	replies := []any{out}
+	if list, ok := out.([]any); ok && m.ServerStreaming {
+		replies = list
+	}
+	encoded := make([][]byte, 0, len(replies))
+	for _, reply := range replies {
+		data, err := s.protos.Encode(m.Output, reply)
+		if err != nil {
+			s.logf("⚠️  gRPC %s: response does not fit %s: %v", req.Path, m.Output, err)
+			grpcFinish(w, grpcInternal, fmt.Sprintf("response does not fit %s: %v", m.Output, err))
+			return
+		}
+		encoded = append(encoded, data)
+	}
+	s.logf("→ gRPC %s → OK, %d message(s) (%s %s)", req.Path, len(encoded), exp.HttpRequest.Method, exp.HttpRequest.Path)
+	w.WriteHeader(http.StatusOK)
+	for _, data := range encoded {
+		frame := make([]byte, 5, 5+len(data))
+		binary.BigEndian.PutUint32(frame[1:], uint32(len(data)))
+		_, _ = w.Write(append(frame, data...))
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+	grpcFinish(w, grpcOK, "")
+}
+
+// grpcFinish sends the call status as HTTP/2 trailers
+func grpcFinish(w http.ResponseWriter, code int, text string) {
+	w.Header().Set(http.TrailerPrefix+"Grpc-Status", strconv.Itoa(code))
+	if text != "" {
+		w.Header().Set(http.TrailerPrefix+"Grpc-Message", url.PathEscape(text))
+	}
+}
+
+// readGRPCFrames splits a request body into its length-prefixed messages
+func readGRPCFrames(body io.Reader, encoding string) ([][]byte, error) {
+	var frames [][]byte
+	header := make([]byte, 5)
+	for {
+		if _, err := io.ReadFull(body, header); err == io.EOF {
+			return frames, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("truncated gRPC frame: %w", err)
+		}
+		size := binary.BigEndian.Uint32(header[1:])
+		if size > maxGRPCMessage {
+			return nil, fmt.Errorf("gRPC message of %d bytes exceeds %d", size, maxGRPCMessage)
+		}
+		data := make([]byte, size)
+		if _, err := io.ReadFull(body, data); err != nil {
+			return nil, fmt.Errorf("truncated gRPC message: %w", err)
+		}
+		if header[0] == 1 {
+			if encoding != "gzip" {
+				return nil, fmt.Errorf("unsupported grpc-encoding %q", encoding)
+			}
+			zr, err := gzip.NewReader(bytes.NewReader(data))
+			if err != nil {
+				return nil, err
+			}
+			if data, err = io.ReadAll(io.LimitReader(zr, maxGRPCMessage)); err != nil {
+				return nil, err
+			}
+		}
+		frames = append(frames, data)
+	}
+}
+
+// grpcCode maps an expectation's HTTP status to the gRPC status code, as gRPC-gateway does in reverse
+func grpcCode(status int) int {
+	switch status {
+	case 400:
+		return grpcInvalidArg
+	case 401:
+		return 16 // UNAUTHENTICATED
+	case 403:
+		return 7 // PERMISSION_DENIED
+	case 404:
+		return 5 // NOT_FOUND
+	case 409:
+		return 6 // ALREADY_EXISTS
+	case 412:
+		return 9 // FAILED_PRECONDITION
+	case 429:
+		return 8 // RESOURCE_EXHAUSTED
+	case 499:
+		return 1 // CANCELLED
+	case 500:
+		return grpcInternal
+	case 501:
+		return grpcUnimplemented
+	case 503:
+		return 14 // UNAVAILABLE
+	case 504:
+		return 4 // DEADLINE_EXCEEDED
+	}
+	if status >= 200 && status < 300 {
+		return grpcOK
+	}
+	return grpcUnknown
+}
+
+// errorMessage takes grpc-message from an error body's "message" (or "error") field
+func errorMessage(payload []byte, status int) string {
+	var body map[string]any
+	if json.Unmarshal(payload, &body) == nil {
+		for _, key := range []string{"message", "error"} {
+			if s, ok := body[key].(string); ok && s != "" {
+				return s
+			}
+		}
+	}
+	return http.StatusText(status)
+}
@@ -0,0 +1,114 @@
+package localmock
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/protofile"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+const greeterProto = `
+syntax = "proto3";
+package demo;
+message HelloRequest { string name = 1; }
+message HelloReply { string message = 1; int64 count = 2; }
+service Greeter {
+  rpc SayHello(HelloRequest) returns (HelloReply);
+  rpc Stream(HelloRequest) returns (stream HelloReply);
+}
+`
+
+// grpcCall makes a unary or server-streaming call over h2c and returns the decoded replies
+func grpcCall(t *testing.T, set *protofile.Set, url, method string, in any) ([]any, string, string) {
+	t.Helper()
+	m := set.Method(method)
+	data, err := set.Encode(m.Input, in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	frame := make([]byte, 5, 5+len(data))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(data)))
+	req, _ := http.NewRequest(http.MethodPost, url+method, bytes.NewReader(append(frame, data...)))
+	req.Header.Set("Content-Type", "application/grpc")
+	client := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			return net.Dial(network, addr)
+		},
+	}}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	frames, err := readGRPCFrames(bytes.NewReader(body), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var replies []any
+	for _, f := range frames {
+		reply, err := set.Decode(m.Output, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		replies = append(replies, reply)
+	}
+	return replies, resp.Trailer.Get("Grpc-Status"), resp.Trailer.Get("Grpc-Message")
+}
+
+func TestServeGRPC(t *testing.T) {
+	set, err := protofile.Parse("greeter.proto", greeterProto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock := New([]models.MockExpectation{
+		{
+			HttpRequest:  &models.HttpRequest{Method: "POST", Path: "/demo.Greeter/SayHello", Body: map[string]any{"type": "JSON", "json": `{"name": "ada"}`}},
+			HttpResponse: &models.HttpResponse{StatusCode: 200, Body: `{"message": "hi ada", "count": "2"}`},
+		},
+		{
+			HttpRequest:  &models.HttpRequest{Method: "POST", Path: "/demo.Greeter/SayHello"},
+			HttpResponse: &models.HttpResponse{StatusCode: 404, Body: `{"message": "no such user"}`},
+		},
+		{
+			HttpRequest:  &models.HttpRequest{Method: "POST", Path: "/demo.Greeter/Stream"},
+			HttpResponse: &models.HttpResponse{StatusCode: 200, Body: `[{"message": "a"}, {"message": "b"}]`},
+		},
+	}, nil)
+	mock.EnableGRPC(set)
+	srv := httptest.NewServer(h2c.NewHandler(mock, &http2.Server{}))
+	defer srv.Close()
+
+	replies, status, _ := grpcCall(t, set, srv.URL, "/demo.Greeter/SayHello", map[string]any{"name": "ada"})
+	if status != "0" || !reflect.DeepEqual(replies, []any{map[string]any{"message": "hi ada", "count": "2"}}) {
+		t.Errorf("unary call: status %s, replies %#v", status, replies)
+	}
+	if _, status, msg := grpcCall(t, set, srv.URL, "/demo.Greeter/SayHello", map[string]any{"name": "bob"}); status != "5" || msg != "no%20such%20user" {
+		t.Errorf("404 expectation: status %s, message %q", status, msg)
+	}
+	if replies, status, _ := grpcCall(t, set, srv.URL, "/demo.Greeter/Stream", map[string]any{}); status != "0" || len(replies) != 2 {
+		t.Errorf("server streaming: status %s, %d replies", status, len(replies))
+	}
+
+	// Plain HTTP requests to the same path still get the JSON expectation
+	resp, err := http.Post(srv.URL+"/demo.Greeter/SayHello", "application/json", bytes.NewReader([]byte(`{"name":"ada"}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Errorf("JSON call got %d", resp.StatusCode)
+	}
+}
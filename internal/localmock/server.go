@@ -0,0 +1,364 @@
+// Package localmock serves expectations over HTTP with MockServer semantics, in process,
+// so mocks can be exercised without deploying infrastructure or running MockServer itself.
+package localmock
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/matcher"
+	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/protofile"
+	"github.com/hemantobora/auto-mock/internal/scripting"
+)
+
+// maxLogged caps the request log kept for /mockserver/retrieve
+const maxLogged = 10000
+
+// traceCandidates is how many near misses are traced for an unmatched request; see EnableTrace
+const traceCandidates = 3
+
+// Server answers requests from expectations: the highest-priority match wins, limited
+// expectations run out, delays are honored and JavaScript templates are rendered.
+// Unmatched requests get 404, as with MockServer.
+type Server struct {
+	engine  *matcher.Engine
+	initial []models.MockExpectation
+	out     io.Writer      // one line per request; nil for quiet
+	protos  *protofile.Set // methods answered as native gRPC; see EnableGRPC
+	trace   bool           // log why unmatched requests missed; see EnableTrace
+
+	mu  sync.Mutex
+	log []exchange
+}
+
+type exchange struct {
+	HttpRequest  message  `json:"httpRequest"`
+	HttpResponse *message `json:"httpResponse,omitempty"`
+	Timestamp    string   `json:"timestamp"`
+}
+
+type message struct {
+	Method                string              `json:"method,omitempty"`
+	Path                  string              `json:"path,omitempty"`
+	QueryStringParameters map[string][]string `json:"queryStringParameters,omitempty"`
+	Headers               map[string][]string `json:"headers,omitempty"`
+	Body                  string              `json:"body,omitempty"`
+	StatusCode            int                 `json:"statusCode,omitempty"`
+	Delay                 *models.Delay       `json:"delay,omitempty"`
+}
+
+// New creates a server for the expectations; out receives the request log lines
+func New(expectations []models.MockExpectation, out io.Writer) *Server {
+	return &Server{
+		engine:  matcher.NewEngine(expectations),
+		initial: append([]models.MockExpectation(nil), expectations...),
+		out:     out,
+	}
+}
+
+// EnableTrace logs, for each unmatched request, the closest expectations and the checks
+// they failed, as 'automock match' prints them
+func (s *Server) EnableTrace() {
+	s.trace = true
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/mockserver/") && r.Method == http.MethodPut {
+		s.admin(w, r)
+		return
+	}
+	if s.isGRPC(r) {
+		s.serveGRPC(w, r)
+		return
+	}
+	req, err := matcher.FromHTTP(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entry := exchange{
+		HttpRequest: message{Method: req.Method, Path: req.Path, QueryStringParameters: req.Query, Headers: req.Headers, Body: string(req.Body)},
+		Timestamp:   time.Now().Format("2006-01-02T15:04:05.000Z07:00"),
+	}
+	defer func() { s.record(entry) }()
+
+	exp := s.engine.Handle(req)
+	if exp == nil {
+		if s.trace {
+			trace := matcher.FormatTrace(req, matcher.TopCandidates(s.engine.Explain(req), traceCandidates))
+			s.logf("✗ %s %s → 404, no expectation matched. Closest candidate(s):\n%s", req.Method, r.URL.RequestURI(), strings.TrimRight(trace, "\n"))
+		} else {
+			s.logf("✗ %s %s → 404 (no expectation matched; run 'automock match' to see why)", req.Method, r.URL.RequestURI())
+		}
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if exp.Stream != nil {
+		entry.HttpResponse = &message{StatusCode: s.serveStream(w, r, exp)}
+		return
+	}
+	if exp.HttpError != nil {
+		s.logf("✗ %s %s → connection dropped (%s %s)", req.Method, r.URL.RequestURI(), exp.HttpRequest.Method, exp.HttpRequest.Path)
+		s.dropConnection(w, r, exp.HttpError)
+		return
+	}
+	if exp.Forward != nil {
+		s.forward(w, r, req, exp.Forward)
+		entry.HttpResponse = &message{StatusCode: http.StatusOK}
+		return
+	}
+	resp, err := scripting.Respond(exp, req)
+	if err != nil {
+		s.logf("⚠️  %s %s: response template failed (%v); serving the static response", req.Method, req.Path, err)
+		resp = exp.HttpResponse
+	}
+	if resp == nil {
+		resp = &models.HttpResponse{StatusCode: http.StatusOK}
+	}
+
+	if d := delayOf(resp.Delay); d > 0 {
+		select {
+		case <-time.After(d):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	status := resp.StatusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+	body, contentType := responsePayload(resp.Body)
+	entry.HttpResponse = &message{StatusCode: status, Body: string(body), Delay: resp.Delay}
+	s.logf("→ %s %s → %d (%s %s)", req.Method, r.URL.RequestURI(), status, exp.HttpRequest.Method, exp.HttpRequest.Path)
+
+	if resp.ConnectionOptions != nil && resp.ConnectionOptions.CloseSocket {
+		if hj, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hj.Hijack(); err == nil {
+				conn.Close()
+				return
+			}
+		}
+	}
+
+	h := w.Header()
+	for _, nv := range resp.Headers {
+		for _, v := range nv.Values {
+			h.Add(nv.Name, v)
+		}
+	}
+	for _, c := range resp.Cookies {
+		for _, v := range c.Values {
+			h.Add("Set-Cookie", c.Name+"="+v)
+		}
+	}
+	if h.Get("Content-Type") == "" && contentType != "" && len(body) > 0 {
+		h.Set("Content-Type", contentType)
+	}
+	if resp.ConnectionOptions != nil && resp.ConnectionOptions.SuppressContentLengthHeader {
+		h.Del("Content-Length")
+	} else if h.Get("Content-Length") == "" {
+		h.Set("Content-Length", strconv.Itoa(len(body)))
+	}
+	w.WriteHeader(status)
+	if r.Method != http.MethodHead {
+		_, _ = w.Write(body)
+	}
+}
+
+// dropConnection answers an httpError action: after its delay it writes the raw response
+// bytes, if any, and closes the connection
+func (s *Server) dropConnection(w http.ResponseWriter, r *http.Request, e *models.HttpError) {
+	if d := delayOf(e.Delay); d > 0 {
+		select {
+		case <-time.After(d):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "connection cannot be dropped", http.StatusInternalServerError)
+		return
+	}
+	conn, buf, err := hj.Hijack()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	if raw, err := base64.StdEncoding.DecodeString(e.ResponseBytes); err == nil && len(raw) > 0 {
+		_, _ = buf.Write(raw)
+		_ = buf.Flush()
+	}
+}
+
+// admin implements the parts of the MockServer control API the CLI uses
+func (s *Server) admin(w http.ResponseWriter, r *http.Request) {
+	switch strings.TrimPrefix(r.URL.Path, "/mockserver/") {
+	case "expectation":
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "{") {
+			data = []byte("[" + trimmed + "]")
+		}
+		cfg, err := models.ParseMockServerJSON(string(data))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.engine.Add(cfg.Expectations...)
+		s.logf("➕ Added %d expectation(s)", len(cfg.Expectations))
+		w.WriteHeader(http.StatusCreated)
+	case "reset":
+		s.engine.Reset(s.initial)
+		s.mu.Lock()
+		s.log = nil
+		s.mu.Unlock()
+		s.logf("🔄 Reset to the %d loaded expectation(s)", len(s.initial))
+		w.WriteHeader(http.StatusOK)
+	case "retrieve":
+		s.retrieve(w, r.URL.Query().Get("type"))
+	case "status":
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"expectations": %d}`, s.engine.Len())
+	default:
+		http.Error(w, "unsupported control endpoint", http.StatusNotImplemented)
+	}
+}
+
+func (s *Server) retrieve(w http.ResponseWriter, kind string) {
+	s.mu.Lock()
+	logged := append([]exchange(nil), s.log...)
+	s.mu.Unlock()
+
+	var out any
+	switch strings.ToUpper(kind) {
+	case "", "REQUESTS":
+		requests := make([]message, len(logged))
+		for i, e := range logged {
+			requests[i] = e.HttpRequest
+		}
+		out = requests
+	case "REQUEST_RESPONSES":
+		matched := make([]exchange, 0, len(logged))
+		for _, e := range logged {
+			if e.HttpResponse != nil {
+				matched = append(matched, e)
+			}
+		}
+		out = matched
+	default:
+		http.Error(w, fmt.Sprintf("unsupported retrieve type %q", kind), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+// forward proxies the request to the expectation's target, like MockServer's httpForward
+func (s *Server) forward(w http.ResponseWriter, r *http.Request, req *matcher.Request, fwd *models.HttpForward) {
+	scheme := strings.ToLower(fwd.Scheme)
+	if scheme == "" {
+		scheme = "http"
+	}
+	host := fwd.Host
+	if fwd.Port > 0 {
+		host = fmt.Sprintf("%s:%d", fwd.Host, fwd.Port)
+	}
+	target := &url.URL{Scheme: scheme, Host: host}
+	s.logf("↪ %s %s → forwarded to %s", req.Method, r.URL.RequestURI(), target)
+	r.Body = io.NopCloser(strings.NewReader(string(req.Body)))
+	r.ContentLength = int64(len(req.Body))
+	r.Host = host
+	httputil.NewSingleHostReverseProxy(target).ServeHTTP(w, r)
+}
+
+func (s *Server) record(e exchange) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.log = append(s.log, e)
+	if len(s.log) > maxLogged {
+		s.log = s.log[len(s.log)-maxLogged:]
+	}
+}
+
+func (s *Server) logf(format string, args ...any) {
+	if s.out != nil {
+		fmt.Fprintf(s.out, format+"\n", args...)
+	}
+}
+
+// responsePayload flattens a MockServer response body into bytes and its implied content
+// type; a body's own contentType wins over the one its type implies
+func responsePayload(body any) ([]byte, string) {
+	data, contentType := bodyBytes(body)
+	if b, ok := body.(map[string]any); ok {
+		if ct, _ := b["contentType"].(string); ct != "" {
+			contentType = ct
+		}
+	}
+	return data, contentType
+}
+
+func bodyBytes(body any) ([]byte, string) {
+	switch b := body.(type) {
+	case nil:
+		return nil, ""
+	case string:
+		if json.Valid([]byte(b)) && (strings.HasPrefix(strings.TrimSpace(b), "{") || strings.HasPrefix(strings.TrimSpace(b), "[")) {
+			return []byte(b), "application/json"
+		}
+		return []byte(b), "text/plain; charset=utf-8"
+	case map[string]any:
+		switch strings.ToUpper(fmt.Sprint(b["type"])) {
+		case "JSON":
+			if s, ok := b["json"].(string); ok {
+				return []byte(s), "application/json"
+			}
+			out, _ := json.Marshal(b["json"])
+			return out, "application/json"
+		case "STRING":
+			s, _ := b["string"].(string)
+			return []byte(s), "text/plain; charset=utf-8"
+		case "XML":
+			s, _ := b["xml"].(string)
+			return []byte(s), "application/xml"
+		case "BINARY":
+			s, _ := b["base64Bytes"].(string)
+			out, _ := base64.StdEncoding.DecodeString(s)
+			return out, "application/octet-stream"
+		}
+	}
+	out, _ := json.Marshal(body)
+	return out, "application/json"
+}
+
+func delayOf(d *models.Delay) time.Duration {
+	if d == nil || d.Value <= 0 {
+		return 0
+	}
+	unit := time.Millisecond
+	switch strings.ToUpper(d.TimeUnit) {
+	case "MICROSECONDS":
+		unit = time.Microsecond
+	case "SECONDS":
+		unit = time.Second
+	case "MINUTES":
+		unit = time.Minute
+	}
+	return time.Duration(d.Value) * unit
+}
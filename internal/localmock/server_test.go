@@ -0,0 +1,234 @@
+package localmock
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func put(t *testing.T, url, body string) (int, string) {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodPut, url, strings.NewReader(body))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	out, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(out)
+}
+
+func TestServerSemantics(t *testing.T) {
+	srv := httptest.NewServer(New([]models.MockExpectation{
+		{
+			HttpRequest:  &models.HttpRequest{Method: "GET", Path: "/users/.*"},
+			HttpResponse: &models.HttpResponse{StatusCode: 200, Body: map[string]any{"type": "JSON", "json": map[string]any{"name": "any"}}},
+		},
+		{
+			Priority:     10,
+			HttpRequest:  &models.HttpRequest{Method: "GET", Path: "/users/1"},
+			HttpResponse: &models.HttpResponse{StatusCode: 201, Body: "first", Delay: &models.Delay{TimeUnit: "MILLISECONDS", Value: 50}},
+			Times:        &models.Times{RemainingTimes: 1},
+		},
+	}, nil))
+	defer srv.Close()
+
+	start := time.Now()
+	if status, body := get(t, srv.URL+"/users/1"); status != 201 || body != "first" {
+		t.Fatalf("priority match: %d %q", status, body)
+	}
+	if time.Since(start) < 50*time.Millisecond {
+		t.Error("delay not applied")
+	}
+	if status, body := get(t, srv.URL+"/users/1"); status != 200 || !strings.Contains(body, `"any"`) {
+		t.Fatalf("after times ran out: %d %q", status, body)
+	}
+	if status, _ := get(t, srv.URL+"/orders"); status != 404 {
+		t.Fatalf("unmatched request got %d", status)
+	}
+
+	if status, _ := put(t, srv.URL+"/mockserver/expectation", `{"httpRequest":{"path":"/orders"},"httpResponse":{"statusCode":202}}`); status != 201 {
+		t.Fatalf("creating an expectation returned %d", status)
+	}
+	if status, _ := get(t, srv.URL+"/orders"); status != 202 {
+		t.Fatalf("added expectation not served: %d", status)
+	}
+
+	_, body := put(t, srv.URL+"/mockserver/retrieve?type=REQUEST_RESPONSES&format=JSON", "")
+	var logged []struct {
+		HttpRequest  struct{ Path string }
+		HttpResponse struct{ StatusCode int }
+	}
+	if err := json.Unmarshal([]byte(body), &logged); err != nil {
+		t.Fatal(err)
+	}
+	if len(logged) != 3 || logged[0].HttpRequest.Path != "/users/1" || logged[2].HttpResponse.StatusCode != 202 {
+		t.Errorf("retrieve returned %s", body)
+	}
+
+	put(t, srv.URL+"/mockserver/reset", "")
+	if status, _ := get(t, srv.URL+"/users/1"); status != 201 {
+		t.Errorf("reset did not restore times: %d", status)
+	}
+	if status, _ := get(t, srv.URL+"/orders"); status != 404 {
+		t.Errorf("reset kept the added expectation: %d", status)
+	}
+}
+
+func TestServerTemplate(t *testing.T) {
+	srv := httptest.NewServer(New([]models.MockExpectation{{
+		HttpRequest: &models.HttpRequest{Method: "GET", Path: "/echo"},
+		HttpResponseTemplate: &models.HttpTemplate{
+			TemplateType: "JAVASCRIPT",
+			Template:     `return {statusCode: 200, body: request.queryStringParameters['q'][0]};`,
+		},
+	}}, nil))
+	defer srv.Close()
+
+	if status, body := get(t, srv.URL+"/echo?q=hello"); status != 200 || body != "hello" {
+		t.Errorf("template response: %d %q", status, body)
+	}
+}
+
+func TestServerTenants(t *testing.T) {
+	cfg := &models.MockConfiguration{
+		Expectations: []models.MockExpectation{{
+			ID:           "plan",
+			HttpRequest:  &models.HttpRequest{Method: "GET", Path: "/plan"},
+			HttpResponse: &models.HttpResponse{StatusCode: 200, Body: map[string]any{"plan": "free"}},
+		}},
+		Tenancy: &models.Tenancy{Header: "X-Tenant", Subdomain: true, Tenants: []models.Tenant{
+			{Name: "acme", Overlays: []models.TenantOverlay{{Expectation: "plan", Body: map[string]any{"plan": "enterprise"}}}},
+			{Name: "beta", Overlays: []models.TenantOverlay{{Expectation: "plan", Body: map[string]any{"plan": "beta"}}}},
+		}},
+	}
+	if _, err := cfg.ApplyTenants(); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(New(cfg.Expectations, nil))
+	defer srv.Close()
+
+	for _, tc := range []struct {
+		header, host, want string
+	}{
+		{"", "", "free"},
+		{"acme", "", "enterprise"},
+		{"", "beta.mock.example.com", "beta"},
+		{"acme", "beta.mock.example.com", "enterprise"}, // the header wins
+		{"unknown", "", "free"},
+	} {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"/plan", nil)
+		if tc.header != "" {
+			req.Header.Set("X-Tenant", tc.header)
+		}
+		if tc.host != "" {
+			req.Host = tc.host
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if !strings.Contains(string(body), `"`+tc.want+`"`) {
+			t.Errorf("tenant %q host %q: body %s, want plan %s", tc.header, tc.host, body, tc.want)
+		}
+	}
+}
+
+func TestServerTracesMisses(t *testing.T) {
+	var out strings.Builder
+	s := New([]models.MockExpectation{{
+		HttpRequest:  &models.HttpRequest{Method: "GET", Path: "/users/1"},
+		HttpResponse: &models.HttpResponse{StatusCode: 200},
+	}}, &out)
+	miss := func() {
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/2", nil))
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("status = %d, want 404", rec.Code)
+		}
+	}
+
+	miss()
+	if strings.Contains(out.String(), "Closest candidate") {
+		t.Errorf("traced without EnableTrace:\n%s", out.String())
+	}
+
+	out.Reset()
+	s.EnableTrace()
+	miss()
+	log := out.String()
+	if !strings.Contains(log, "Closest candidate") || !strings.Contains(log, "#1 GET /users/1") || !strings.Contains(log, `got "/users/2"`) {
+		t.Errorf("miss not traced:\n%s", log)
+	}
+}
+
+func TestServerDropsConnection(t *testing.T) {
+	cfg := &models.MockConfiguration{Expectations: []models.MockExpectation{
+		{
+			HttpRequest:  &models.HttpRequest{Method: "GET", Path: "/reset"},
+			HttpResponse: &models.HttpResponse{StatusCode: 200, Body: "never sent"},
+			Faults:       &models.Faults{Reset: true},
+		},
+		{
+			HttpRequest:  &models.HttpRequest{Method: "GET", Path: "/download"},
+			HttpResponse: &models.HttpResponse{StatusCode: 200, Body: "0123456789"},
+			Faults:       &models.Faults{Truncate: true},
+		},
+	}}
+	if _, err := cfg.ApplyFaults(); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(New(cfg.Expectations, nil))
+	defer srv.Close()
+
+	if _, err := http.Get(srv.URL + "/reset"); err == nil {
+		t.Error("reset: got a response")
+	}
+	resp, err := http.Get(srv.URL + "/download")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err == nil || string(body) != "01234" {
+		t.Errorf("truncated body: %q, err %v", body, err)
+	}
+}
+
+func TestServerBinaryBody(t *testing.T) {
+	png := []byte{0x89, 'P', 'N', 'G', 0x00, 0xff}
+	srv := httptest.NewServer(New([]models.MockExpectation{{
+		HttpRequest:  &models.HttpRequest{Method: "GET", Path: "/logo"},
+		HttpResponse: &models.HttpResponse{StatusCode: 200, Body: models.BinaryBody(png, "image/png")},
+	}}, nil))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/logo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != string(png) || resp.Header.Get("Content-Type") != "image/png" {
+		t.Errorf("binary response: %q as %s", body, resp.Header.Get("Content-Type"))
+	}
+}
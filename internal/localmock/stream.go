@@ -0,0 +1,170 @@
+package localmock
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// serveStream plays a streaming expectation on the connection and returns the status logged
+// for it. It returns when the stream closes or the client leaves.
+func (s *Server) serveStream(w http.ResponseWriter, r *http.Request, exp *models.MockExpectation) int {
+	if exp.Stream.Type == models.StreamWebSocket {
+		return s.serveWebSocket(w, r, exp)
+	}
+	return s.serveSSE(w, r, exp)
+}
+
+// serveSSE sends the stream as text/event-stream events
+func (s *Server) serveSSE(w http.ResponseWriter, r *http.Request, exp *models.MockExpectation) int {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported on this connection", http.StatusInternalServerError)
+		return http.StatusInternalServerError
+	}
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	stream := exp.Stream
+	s.logf("→ %s %s → SSE stream (%s %s)", r.Method, r.URL.RequestURI(), exp.HttpRequest.Method, exp.HttpRequest.Path)
+	ctx := r.Context()
+	sent := s.playSequence(ctx, stream, func(m models.StreamMessage) error {
+		if _, err := io.WriteString(w, m.SSEEvent()); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	})
+	if !sent {
+		return http.StatusOK
+	}
+	if stream.Close != nil {
+		sleep(ctx, delayOf(stream.Close.After))
+		s.logf("  ✂ SSE %s closed by the stream", r.URL.Path)
+		return http.StatusOK
+	}
+	<-ctx.Done() // stay open until the client leaves
+	return http.StatusOK
+}
+
+// serveWebSocket upgrades the connection and plays the exchange: the sequence after connecting,
+// replies to matching client messages, and the close conditions
+func (s *Server) serveWebSocket(w http.ResponseWriter, r *http.Request, exp *models.MockExpectation) int {
+	if !isWebSocketUpgrade(r) {
+		s.logf("✗ %s %s → 426 (expects a WebSocket upgrade)", r.Method, r.URL.RequestURI())
+		w.Header().Set("Upgrade", "websocket")
+		http.Error(w, "this endpoint expects a WebSocket upgrade", http.StatusUpgradeRequired)
+		return http.StatusUpgradeRequired
+	}
+	conn, err := upgradeWebSocket(w, r)
+	if err != nil {
+		s.logf("✗ %s %s → 400 (%v)", r.Method, r.URL.RequestURI(), err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return http.StatusBadRequest
+	}
+	stream := exp.Stream
+	s.logf("→ %s %s → WebSocket (%s %s)", r.Method, r.URL.RequestURI(), exp.HttpRequest.Method, exp.HttpRequest.Path)
+
+	// A hijacked connection outlives the request context; the exchange ends when either side closes
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	code, reason := 1000, ""
+	if stream.Close != nil {
+		if stream.Close.Code != 0 {
+			code = stream.Close.Code
+		}
+		reason = stream.Close.Reason
+	}
+	closeStream := func(why string) {
+		s.logf("  ✂ WebSocket %s closed (%s, code %d)", r.URL.Path, why, code)
+		conn.close(code, reason)
+		cancel()
+	}
+
+	go func() {
+		defer cancel()
+		for {
+			msg, err := conn.readMessage()
+			if err != nil {
+				return
+			}
+			s.logf("  ⇢ %s", truncateLog(msg))
+			if stream.ClosesOn(msg) {
+				closeStream("client message")
+				return
+			}
+			for _, reply := range stream.RepliesTo(msg) {
+				if !sleep(ctx, messageDelay(stream, reply)) || conn.send(reply.Text()) != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	sent := s.playSequence(ctx, stream, func(m models.StreamMessage) error {
+		return conn.send(m.Text())
+	})
+	if sent && stream.Close != nil && (stream.Close.After != nil || stream.Close.OnMessage == "") {
+		if sleep(ctx, delayOf(stream.Close.After)) {
+			closeStream("end of sequence")
+		}
+	}
+	<-ctx.Done()
+	conn.close(1000, "")
+	return http.StatusSwitchingProtocols
+}
+
+// playSequence sends the stream's sequence, repeating it if asked, until ctx ends. It reports
+// whether the whole sequence was sent.
+func (s *Server) playSequence(ctx context.Context, stream *models.Stream, send func(models.StreamMessage) error) bool {
+	seq := stream.Sequence()
+	for {
+		for _, m := range seq {
+			if !sleep(ctx, messageDelay(stream, m)) {
+				return false
+			}
+			if err := send(m); err != nil {
+				return false
+			}
+		}
+		if !stream.Repeat || len(seq) == 0 {
+			return true
+		}
+	}
+}
+
+// messageDelay is the wait before a message: its own delay, else the stream interval
+func messageDelay(stream *models.Stream, m models.StreamMessage) time.Duration {
+	if m.Delay != nil {
+		return delayOf(m.Delay)
+	}
+	return delayOf(stream.Interval)
+}
+
+// sleep waits for d and reports false when ctx ends first
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func truncateLog(s string) string {
+	if len(s) > 120 {
+		return s[:117] + "..."
+	}
+	return s
+}
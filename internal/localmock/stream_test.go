@@ -0,0 +1,87 @@
+package localmock
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+	"golang.org/x/net/websocket"
+)
+
+func TestServeSSE(t *testing.T) {
+	srv := httptest.NewServer(New([]models.MockExpectation{{
+		HttpRequest: &models.HttpRequest{Method: "GET", Path: "/events"},
+		Stream: &models.Stream{
+			Type:     models.StreamSSE,
+			Interval: &models.Delay{TimeUnit: "MILLISECONDS", Value: 30},
+			Messages: []models.StreamMessage{{Data: "one", Event: "tick"}, {Data: "two"}},
+			Close:    &models.StreamClose{},
+		},
+	}}, nil))
+	defer srv.Close()
+
+	start := time.Now()
+	resp, err := http.Get(srv.URL + "/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	var lines []string
+	for sc := bufio.NewScanner(resp.Body); sc.Scan(); {
+		lines = append(lines, sc.Text())
+	}
+	if got := strings.Join(lines, "|"); got != "event: tick|data: one||data: two|" {
+		t.Errorf("events = %q", got)
+	}
+	if time.Since(start) < 60*time.Millisecond {
+		t.Error("interval not applied")
+	}
+}
+
+func TestServeWebSocket(t *testing.T) {
+	srv := httptest.NewServer(New([]models.MockExpectation{{
+		HttpRequest: &models.HttpRequest{Method: "GET", Path: "/ws"},
+		Stream: &models.Stream{
+			Type: models.StreamWebSocket,
+			Messages: []models.StreamMessage{
+				{Data: map[string]any{"type": "welcome"}},
+				{Data: "pong", Reply: `^ping$`},
+			},
+			Close: &models.StreamClose{OnMessage: `^bye$`, Code: 4000, Reason: "done"},
+		},
+	}}, nil))
+	defer srv.Close()
+
+	if resp, err := http.Get(srv.URL + "/ws"); err != nil || resp.StatusCode != http.StatusUpgradeRequired {
+		t.Fatalf("plain GET: %v %v", resp, err)
+	}
+
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws", "", srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	var msg string
+	if err := websocket.Message.Receive(ws, &msg); err != nil || msg != `{"type":"welcome"}` {
+		t.Fatalf("first message %q, %v", msg, err)
+	}
+	if err := websocket.Message.Send(ws, "ping"); err != nil {
+		t.Fatal(err)
+	}
+	if err := websocket.Message.Receive(ws, &msg); err != nil || msg != "pong" {
+		t.Fatalf("reply %q, %v", msg, err)
+	}
+	if err := websocket.Message.Send(ws, "bye"); err != nil {
+		t.Fatal(err)
+	}
+	if err := websocket.Message.Receive(ws, &msg); err == nil {
+		t.Errorf("connection still open after the close message; got %q", msg)
+	}
+}
@@ -0,0 +1,209 @@
+package localmock
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Minimal RFC 6455 server side: enough to play mock message exchanges. Fragmented messages
+// are reassembled, pings answered, and extensions (e.g. permessage-deflate) never negotiated.
+
+// WebSocket opcodes
+const (
+	wsContinuation = 0x0
+	wsText         = 0x1
+	wsBinary       = 0x2
+	wsClose        = 0x8
+	wsPing         = 0x9
+	wsPong         = 0xA
+)
+
+// wsGUID is appended to the client key to compute Sec-WebSocket-Accept
+const wsGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// maxWSMessage bounds a client message
+const maxWSMessage = 1 << 20
+
+type wsConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+
+	mu     sync.Mutex // serializes frame writes
+	closed bool
+}
+
+// isWebSocketUpgrade reports whether the request opens a WebSocket
+func isWebSocketUpgrade(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") && headerHasToken(r.Header, "Connection", "upgrade")
+}
+
+// upgradeWebSocket completes the opening handshake and takes over the connection
+func upgradeWebSocket(w http.ResponseWriter, r *http.Request) (*wsConn, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if r.Method != http.MethodGet || key == "" || r.Header.Get("Sec-WebSocket-Version") != "13" {
+		return nil, errors.New("not a WebSocket version 13 handshake")
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		return nil, errors.New("the connection cannot be upgraded")
+	}
+	conn, rw, err := hj.Hijack()
+	if err != nil {
+		return nil, err
+	}
+	sum := sha1.Sum([]byte(key + wsGUID))
+	response := "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(sum[:]) + "\r\n"
+	if protocols := r.Header.Get("Sec-WebSocket-Protocol"); protocols != "" {
+		response += "Sec-WebSocket-Protocol: " + strings.TrimSpace(strings.Split(protocols, ",")[0]) + "\r\n"
+	}
+	if _, err := conn.Write([]byte(response + "\r\n")); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &wsConn{conn: conn, r: rw.Reader}, nil
+}
+
+// send writes a text message
+func (c *wsConn) send(text string) error {
+	return c.writeFrame(wsText, []byte(text))
+}
+
+// close sends a close frame with the status code and reason, then drops the connection
+func (c *wsConn) close(code int, reason string) {
+	payload := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	_ = c.writeFrame(wsClose, append(payload, reason...))
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	c.conn.Close()
+}
+
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return net.ErrClosed
+	}
+	header := []byte{0x80 | opcode, 0} // FIN; server frames are not masked
+	switch n := len(payload); {
+	case n < 126:
+		header[1] = byte(n)
+	case n <= 0xFFFF:
+		header[1] = 126
+		header = binary.BigEndian.AppendUint16(header, uint16(n))
+	default:
+		header[1] = 127
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+	_, err := c.conn.Write(append(header, payload...))
+	return err
+}
+
+// readMessage returns the next text or binary message. Pings are answered on the way; a
+// close frame is echoed and ends the exchange with io.EOF.
+func (c *wsConn) readMessage() (string, error) {
+	var message []byte
+	for {
+		opcode, fin, payload, err := c.readFrame()
+		if err != nil {
+			return "", err
+		}
+		switch opcode {
+		case wsPing:
+			if err := c.writeFrame(wsPong, payload); err != nil {
+				return "", err
+			}
+			continue
+		case wsPong:
+			continue
+		case wsClose:
+			code := 1000
+			if len(payload) >= 2 {
+				code = int(binary.BigEndian.Uint16(payload))
+			}
+			c.close(code, "")
+			return "", io.EOF
+		}
+		if len(message)+len(payload) > maxWSMessage {
+			c.close(1009, "message too big")
+			return "", fmt.Errorf("client message exceeds %d bytes", maxWSMessage)
+		}
+		message = append(message, payload...)
+		if fin {
+			return string(message), nil
+		}
+	}
+}
+
+func (c *wsConn) readFrame() (opcode byte, fin bool, payload []byte, err error) {
+	var head [2]byte
+	if _, err = io.ReadFull(c.r, head[:]); err != nil {
+		return
+	}
+	fin, opcode = head[0]&0x80 != 0, head[0]&0x0F
+	size := uint64(head[1] & 0x7F)
+	switch size {
+	case 126:
+		var ext [2]byte
+		if _, err = io.ReadFull(c.r, ext[:]); err != nil {
+			return
+		}
+		size = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err = io.ReadFull(c.r, ext[:]); err != nil {
+			return
+		}
+		size = binary.BigEndian.Uint64(ext[:])
+	}
+	if size > maxWSMessage {
+		c.close(1009, "message too big")
+		return 0, false, nil, fmt.Errorf("client frame of %d bytes exceeds %d", size, maxWSMessage)
+	}
+	var mask [4]byte
+	masked := head[1]&0x80 != 0
+	if masked {
+		if _, err = io.ReadFull(c.r, mask[:]); err != nil {
+			return
+		}
+	}
+	payload = make([]byte, size)
+	if _, err = io.ReadFull(c.r, payload); err != nil {
+		return
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	switch opcode {
+	case wsContinuation, wsText, wsBinary, wsClose, wsPing, wsPong:
+	default:
+		c.close(1002, "unsupported opcode")
+		return 0, false, nil, fmt.Errorf("unsupported WebSocket opcode %#x", opcode)
+	}
+	return opcode, fin, payload, nil
+}
+
+// headerHasToken reports whether a comma-separated header contains token (case-insensitive)
+func headerHasToken(h http.Header, name, token string) bool {
+	for _, v := range h.Values(name) {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
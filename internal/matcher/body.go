@@ -0,0 +1,195 @@
+package matcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// matchBody evaluates a MockServer body matcher; returns (ok, expected summary, failure reason)
+func matchBody(matcher any, body []byte) (bool, string, string) {
+	// Normalize builder-made values ([]map[string]any, typed slices) to their JSON shape
+	if raw, err := json.Marshal(matcher); err == nil {
+		var normalized any
+		if json.Unmarshal(raw, &normalized) == nil {
+			matcher = normalized
+		}
+	}
+	m, ok := matcher.(map[string]any)
+	if !ok {
+		// Plain string/JSON value without a type wrapper
+		if s, isStr := matcher.(string); isStr {
+			return string(body) == s, truncate(s, 120), "body differs"
+		}
+		return matchJSONBody(matcher, body, "ONLY_MATCHING_FIELDS")
+	}
+
+	typ, _ := m["type"].(string)
+	switch strings.ToUpper(typ) {
+	case "JSON":
+		mt, _ := m["matchType"].(string)
+		if mt == "" {
+			mt = "ONLY_MATCHING_FIELDS"
+		}
+		expected := m["json"]
+		if s, isStr := expected.(string); isStr {
+			var v any
+			if err := json.Unmarshal([]byte(s), &v); err == nil {
+				expected = v
+			}
+		}
+		return matchJSONBody(expected, body, strings.ToUpper(mt))
+	case "JSON_SCHEMA":
+		return matchSchemaBody(m["jsonSchema"], body)
+	case "JSON_PATH":
+		expr, _ := m["jsonPath"].(string)
+		return matchJSONPathBody(expr, body)
+	case "STRING":
+		s, _ := m["string"].(string)
+		if sub, _ := m["subString"].(bool); sub {
+			return strings.Contains(string(body), s), "contains " + truncate(s, 100), "substring not found"
+		}
+		return string(body) == s, truncate(s, 120), "body differs"
+	case "REGEX":
+		pattern, _ := m["regex"].(string)
+		re, err := regexp.Compile("^(?s:" + pattern + ")$")
+		if err != nil {
+			return false, pattern, fmt.Sprintf("invalid regex: %v", err)
+		}
+		return re.Match(body), "regex " + pattern, "body does not match regex"
+	case "PARAMETERS":
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return false, "form parameters", fmt.Sprintf("body is not form-encoded: %v", err)
+		}
+		params, _ := m["parameters"].([]any)
+		var names []string
+		for _, p := range params {
+			pm, _ := p.(map[string]any)
+			name, _ := pm["name"].(string)
+			names = append(names, name)
+			var values []string
+			if vs, ok := pm["values"].([]any); ok {
+				for _, v := range vs {
+					values = append(values, fmt.Sprint(v))
+				}
+			}
+			if ok, reason := matchValues(values, form[name], false); !ok {
+				return false, "parameters " + strings.Join(names, ","), fmt.Sprintf("parameter %s: %s", name, reason)
+			}
+		}
+		return true, "parameters " + strings.Join(names, ","), ""
+	default:
+		return true, typ, fmt.Sprintf("%s body matchers are not evaluated locally", typ)
+	}
+}
+
+func matchJSONBody(expected any, body []byte, matchType string) (bool, string, string) {
+	summary := matchType + " " + truncate(compactJSON(expected), 100)
+	var actual any
+	if err := json.Unmarshal(body, &actual); err != nil {
+		return false, summary, "body is not valid JSON"
+	}
+	if reason := compareJSON(expected, actual, "$", matchType == "STRICT"); reason != "" {
+		return false, summary, reason
+	}
+	return true, summary, ""
+}
+
+// compareJSON returns "" when actual satisfies expected, or a reason naming the first failing field.
+// Lenient mode (ONLY_MATCHING_FIELDS) ignores extra fields and array order.
+func compareJSON(expected, actual any, path string, strict bool) string {
+	switch e := expected.(type) {
+	case map[string]any:
+		a, ok := actual.(map[string]any)
+		if !ok {
+			return fmt.Sprintf("%s expected object, got %s", path, jsonType(actual))
+		}
+		keys := make([]string, 0, len(e))
+		for k := range e {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			ev := e[k]
+			av, present := a[k]
+			if !present {
+				return fmt.Sprintf("%s.%s missing", path, k)
+			}
+			if r := compareJSON(ev, av, path+"."+k, strict); r != "" {
+				return r
+			}
+		}
+		if strict && len(a) != len(e) {
+			for k := range a {
+				if _, ok := e[k]; !ok {
+					return fmt.Sprintf("%s.%s not allowed (STRICT)", path, k)
+				}
+			}
+		}
+		return ""
+	case []any:
+		a, ok := actual.([]any)
+		if !ok {
+			return fmt.Sprintf("%s expected array, got %s", path, jsonType(actual))
+		}
+		if strict {
+			if len(a) != len(e) {
+				return fmt.Sprintf("%s expected %d item(s), got %d", path, len(e), len(a))
+			}
+			for i := range e {
+				if r := compareJSON(e[i], a[i], fmt.Sprintf("%s[%d]", path, i), true); r != "" {
+					return r
+				}
+			}
+			return ""
+		}
+		for i, ev := range e {
+			found := false
+			for _, av := range a {
+				if compareJSON(ev, av, path, false) == "" {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Sprintf("%s[%d] has no matching item", path, i)
+			}
+		}
+		return ""
+	default:
+		if !reflect.DeepEqual(expected, actual) {
+			return fmt.Sprintf("%s expected %s, got %s", path, compactJSON(expected), compactJSON(actual))
+		}
+		return ""
+	}
+}
+
+func jsonType(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	default:
+		return "number"
+	}
+}
+
+func compactJSON(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
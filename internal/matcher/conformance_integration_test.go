@@ -0,0 +1,137 @@
+//go:build integration
+
+// Replays the conformance cases against real MockServer containers so the JSON we emit is proven
+// to behave as intended, and the local engine is proven to agree with MockServer. Requires Docker:
+//
+//	go test -tags integration -run TestMockServerConformance ./internal/matcher/
+//
+// AUTOMOCK_MOCKSERVER_VERSIONS selects the image tags (comma-separated, default 5.15.0).
+// The Docker CLI is driven directly, the same way the rest of the tool shells out to docker and
+// terraform, so the suite needs no extra module dependencies.
+package matcher
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+const defaultMockServerVersions = "5.15.0"
+
+func TestMockServerConformance(t *testing.T) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		t.Skip("docker not available")
+	}
+	versions := os.Getenv("AUTOMOCK_MOCKSERVER_VERSIONS")
+	if versions == "" {
+		versions = defaultMockServerVersions
+	}
+	cases := loadConformanceCases(t)
+
+	for _, version := range strings.Split(versions, ",") {
+		version = strings.TrimSpace(version)
+		t.Run("mockserver-"+version, func(t *testing.T) {
+			base := startMockServer(t, version)
+			for _, c := range cases {
+				t.Run(c.Name, func(t *testing.T) {
+					mockServerCall(t, http.MethodPut, base+"/mockserver/reset", nil)
+					payload := models.MockServerOnlyJSON([]models.MockExpectation{c.Expectation})
+					mockServerCall(t, http.MethodPut, base+"/mockserver/expectation", []byte(payload))
+
+					for i, cr := range c.Requests {
+						req := cr.toRequest(t)
+						matched := fireRequest(t, base, req)
+						if matched != cr.Match {
+							t.Errorf("request #%d %s %s: MockServer matched=%v, want %v", i+1, req.Method, req.Path, matched, cr.Match)
+						}
+						if local := Evaluate(&c.Expectation, req).Matched; local != matched {
+							t.Errorf("request #%d %s %s: local engine matched=%v but MockServer matched=%v", i+1, req.Method, req.Path, local, matched)
+						}
+					}
+				})
+			}
+		})
+	}
+}
+
+// startMockServer runs a disposable container and returns its base URL once it is ready
+func startMockServer(t *testing.T, version string) string {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "docker", "run", "-d", "--rm", "-p", "127.0.0.1::1080",
+		"mockserver/mockserver:"+version).Output()
+	if err != nil {
+		t.Fatalf("start mockserver %s: %v", version, err)
+	}
+	id := strings.TrimSpace(string(out))
+	t.Cleanup(func() { _ = exec.Command("docker", "rm", "-f", id).Run() })
+
+	out, err = exec.CommandContext(ctx, "docker", "port", id, "1080/tcp").Output()
+	if err != nil {
+		t.Fatalf("resolve mockserver port: %v", err)
+	}
+	addr := strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0])
+	base := "http://" + addr
+
+	deadline := time.Now().Add(90 * time.Second)
+	for time.Now().Before(deadline) {
+		req, _ := http.NewRequest(http.MethodPut, base+"/mockserver/status", nil)
+		if resp, err := http.DefaultClient.Do(req); err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return base
+			}
+		}
+		time.Sleep(time.Second)
+	}
+	t.Fatalf("mockserver %s did not become ready", version)
+	return ""
+}
+
+func mockServerCall(t *testing.T, method, url string, body []byte) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(resp.Body)
+		t.Fatalf("%s %s: %s\n%s", method, url, resp.Status, msg)
+	}
+}
+
+// fireRequest sends req to MockServer; an unmatched request is answered with 404
+func fireRequest(t *testing.T, base string, r *Request) bool {
+	t.Helper()
+	target := base + r.Path
+	if q := r.Query.Encode(); q != "" {
+		target += "?" + q
+	}
+	req, err := http.NewRequest(r.Method, target, bytes.NewReader(r.Body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header = r.Headers.Clone()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", r.Method, target, err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode != http.StatusNotFound
+}
@@ -0,0 +1,122 @@
+package matcher
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// Conformance cases live in testdata/conformance so the same files can be replayed against a
+// real MockServer; the local engine must agree with every expected outcome.
+
+type conformanceCase struct {
+	Name        string                 `json:"name"`
+	Expectation models.MockExpectation `json:"expectation"`
+	Requests    []conformanceRequest   `json:"requests"`
+}
+
+type conformanceRequest struct {
+	Request struct {
+		Method  string                     `json:"method"`
+		Path    string                     `json:"path"`
+		Query   map[string]json.RawMessage `json:"query"`
+		Headers map[string]json.RawMessage `json:"headers"`
+		Body    json.RawMessage            `json:"body"`
+	} `json:"request"`
+	Match bool `json:"match"`
+}
+
+func loadConformanceCases(t *testing.T) []conformanceCase {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join("testdata", "conformance", "*.json"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no conformance cases found: %v", err)
+	}
+	var cases []conformanceCase
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var fileCases []conformanceCase
+		if err := json.Unmarshal(data, &fileCases); err != nil {
+			t.Fatalf("%s: %v", f, err)
+		}
+		cases = append(cases, fileCases...)
+	}
+	return cases
+}
+
+// toRequest converts a case request; header and query values may be a string or a list,
+// and the body a JSON value or a raw string
+func (cr conformanceRequest) toRequest(t *testing.T) *Request {
+	t.Helper()
+	values := func(raw json.RawMessage) []string {
+		var one string
+		if json.Unmarshal(raw, &one) == nil {
+			return []string{one}
+		}
+		var many []string
+		if err := json.Unmarshal(raw, &many); err != nil {
+			t.Fatalf("bad value %s", raw)
+		}
+		return many
+	}
+	req := &Request{Method: cr.Request.Method, Path: cr.Request.Path, Query: url.Values{}, Headers: http.Header{}}
+	for name, raw := range cr.Request.Query {
+		req.Query[name] = values(raw)
+	}
+	for name, raw := range cr.Request.Headers {
+		for _, v := range values(raw) {
+			req.Headers.Add(name, v)
+		}
+	}
+	if len(cr.Request.Body) > 0 {
+		var text string
+		if json.Unmarshal(cr.Request.Body, &text) == nil {
+			req.Body = []byte(text)
+		} else {
+			req.Body = cr.Request.Body
+		}
+	}
+	return req
+}
+
+func TestConformance(t *testing.T) {
+	for _, c := range loadConformanceCases(t) {
+		t.Run(c.Name, func(t *testing.T) {
+			for i, cr := range c.Requests {
+				req := cr.toRequest(t)
+				res := Evaluate(&c.Expectation, req)
+				if res.Matched != cr.Match {
+					t.Errorf("request #%d %s %s: matched=%v, want %v\n%s",
+						i+1, req.Method, req.Path, res.Matched, cr.Match, FormatTrace(req, []Result{res}))
+				}
+			}
+		})
+	}
+}
+
+func TestEngine_PriorityAndRemainingTimes(t *testing.T) {
+	engine := NewEngine([]models.MockExpectation{
+		{HttpRequest: &models.HttpRequest{Path: "/x"}, HttpResponse: &models.HttpResponse{StatusCode: 200}},
+		{HttpRequest: &models.HttpRequest{Path: "/x"}, HttpResponse: &models.HttpResponse{StatusCode: 503},
+			Priority: 10, Times: &models.Times{RemainingTimes: 1}},
+	})
+	req := &Request{Method: "GET", Path: "/x", Query: url.Values{}, Headers: http.Header{}}
+
+	if got := engine.Handle(req); got == nil || got.HttpResponse.StatusCode != 503 {
+		t.Fatalf("first call should hit the one-shot 503, got %+v", got)
+	}
+	if got := engine.Handle(req); got == nil || got.HttpResponse.StatusCode != 200 {
+		t.Fatalf("second call should fall back to 200, got %+v", got)
+	}
+	if got := engine.Handle(&Request{Method: "GET", Path: "/y"}); got != nil {
+		t.Fatalf("expected no match, got %+v", got)
+	}
+}
@@ -0,0 +1,93 @@
+package matcher
+
+import (
+	"sync"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// Engine serves expectations the way MockServer does: the highest-priority match wins
+// (declaration order breaks ties) and limited expectations stop matching once their
+// remainingTimes are used up. It is safe for concurrent use.
+type Engine struct {
+	mu           sync.Mutex
+	expectations []models.MockExpectation
+	remaining    []int // -1 = unlimited
+}
+
+// NewEngine loads expectations into a fresh engine
+func NewEngine(expectations []models.MockExpectation) *Engine {
+	e := &Engine{}
+	e.Reset(expectations)
+	return e
+}
+
+// Reset replaces the expectations and their remaining-times counters
+func (e *Engine) Reset(expectations []models.MockExpectation) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.expectations = append([]models.MockExpectation(nil), expectations...)
+	e.remaining = make([]int, len(expectations))
+	for i, exp := range e.expectations {
+		e.remaining[i] = -1
+		if exp.Times != nil && !exp.Times.Unlimited && exp.Times.RemainingTimes > 0 {
+			e.remaining[i] = exp.Times.RemainingTimes
+		}
+	}
+}
+
+// Handle finds the expectation answering req and consumes one of its remaining times.
+// It returns nil when nothing matches.
+func (e *Engine) Handle(req *Request) *models.MockExpectation {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	best := -1
+	for i := range e.expectations {
+		if e.remaining[i] == 0 {
+			continue
+		}
+		if !Evaluate(&e.expectations[i], req).Matched {
+			continue
+		}
+		if best < 0 || e.expectations[i].Priority > e.expectations[best].Priority {
+			best = i
+		}
+	}
+	if best < 0 {
+		return nil
+	}
+	if e.remaining[best] > 0 {
+		e.remaining[best]--
+	}
+	exp := e.expectations[best]
+	return &exp
+}
+
+// Explain evaluates req against every loaded expectation, as Match does, to trace a miss
+func (e *Engine) Explain(req *Request) []Result {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, results := Match(e.expectations, req)
+	return results
+}
+
+// Add appends expectations, keeping the counters of those already loaded
+func (e *Engine) Add(expectations ...models.MockExpectation) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, exp := range expectations {
+		remaining := -1
+		if exp.Times != nil && !exp.Times.Unlimited && exp.Times.RemainingTimes > 0 {
+			remaining = exp.Times.RemainingTimes
+		}
+		e.expectations = append(e.expectations, exp)
+		e.remaining = append(e.remaining, remaining)
+	}
+}
+
+// Len returns the number of loaded expectations
+func (e *Engine) Len() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.expectations)
+}
@@ -0,0 +1,64 @@
+package matcher
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// ExampleRequest builds a concrete request an expectation should answer: the first recorded
+// example when the expectation was merged on import, otherwise its own matcher values
+func ExampleRequest(exp *models.MockExpectation) *Request {
+	req := &Request{Method: "GET", Path: "/", Query: url.Values{}, Headers: http.Header{}}
+	if exp.HttpRequest == nil {
+		return req
+	}
+	if exp.HttpRequest.Method != "" {
+		req.Method = exp.HttpRequest.Method
+	}
+	if exp.HttpRequest.Path != "" {
+		req.Path = exp.HttpRequest.Path
+	}
+	for _, q := range exp.HttpRequest.QueryStringParameters {
+		req.Query[q.Name] = q.Values
+	}
+	for _, h := range exp.HttpRequest.Headers {
+		req.Headers[h.Name] = h.Values
+	}
+	req.Body = bodyPayload(exp.HttpRequest.Body)
+	if len(exp.Examples) > 0 {
+		ex := exp.Examples[0]
+		if ex.Path != "" {
+			req.Path = ex.Path
+		}
+		if ex.Body != nil {
+			req.Body = bodyPayload(ex.Body)
+		}
+	}
+	return req
+}
+
+func bodyPayload(body any) []byte {
+	switch b := body.(type) {
+	case nil:
+		return nil
+	case string:
+		return []byte(b)
+	case map[string]any:
+		// MockServer body matchers wrap the payload, e.g. {"type": "JSON", "json": {...}}
+		if inner, ok := b["json"]; ok {
+			if s, isString := inner.(string); isString {
+				return []byte(s)
+			}
+			out, _ := json.Marshal(inner)
+			return out
+		}
+		if s, ok := b["string"].(string); ok {
+			return []byte(s)
+		}
+	}
+	out, _ := json.Marshal(body)
+	return out
+}
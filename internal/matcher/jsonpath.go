@@ -0,0 +1,302 @@
+package matcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// matchJSONPathBody evaluates a JSON_PATH body matcher: the body matches when the path selects
+// something. Locally only a field path ($.order.id) and a filter on the root object
+// ($[?(@.amount > 1000 && @.currency == 'EUR')]) are evaluated; other expressions pass, as
+// MockServer decides them when deployed.
+func matchJSONPathBody(expr string, body []byte) (bool, string, string) {
+	summary := "JSON path " + truncate(expr, 100)
+	var doc any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return false, summary, "body is not valid JSON"
+	}
+	p := &jsonPathParser{src: strings.TrimSpace(expr)}
+	ok, err := p.document(doc)
+	if err != nil {
+		return true, summary, fmt.Sprintf("JSON path not evaluated locally: %v", err)
+	}
+	if !ok {
+		return false, summary, "JSON path selects nothing"
+	}
+	return true, summary, ""
+}
+
+type jsonPathParser struct {
+	src string
+	pos int
+}
+
+func (p *jsonPathParser) document(doc any) (bool, error) {
+	if !p.eat("$") {
+		return false, fmt.Errorf("expression must start with $")
+	}
+	if p.eat("[?(") {
+		ok, err := p.filter(doc)
+		if err != nil {
+			return false, err
+		}
+		if !p.eat(")]") || p.pos != len(p.src) {
+			return false, fmt.Errorf("unsupported expression")
+		}
+		return ok, nil
+	}
+	v, found, err := p.path(doc)
+	if err != nil {
+		return false, err
+	}
+	if p.pos != len(p.src) {
+		return false, fmt.Errorf("unsupported expression")
+	}
+	return found && v != nil, nil
+}
+
+// filter evaluates `cond && cond ...` against the root object
+func (p *jsonPathParser) filter(doc any) (bool, error) {
+	result := true
+	for {
+		ok, err := p.condition(doc)
+		if err != nil {
+			return false, err
+		}
+		result = result && ok
+		p.space()
+		if !p.eat("&&") {
+			return result, nil
+		}
+		p.space()
+	}
+}
+
+func (p *jsonPathParser) condition(doc any) (bool, error) {
+	if !p.eat("@") {
+		return false, fmt.Errorf("filter conditions must start with @")
+	}
+	v, found, err := p.path(doc)
+	if err != nil {
+		return false, err
+	}
+	p.space()
+	op := ""
+	for _, candidate := range []string{"==", "!=", ">=", "<=", "=~", ">", "<"} {
+		if p.eat(candidate) {
+			op = candidate
+			break
+		}
+	}
+	if op == "" {
+		return found, nil // existence check
+	}
+	p.space()
+	if op == "=~" {
+		re, err := p.regex()
+		if err != nil {
+			return false, err
+		}
+		s, isText := v.(string)
+		return found && isText && re.MatchString(s), nil
+	}
+	lit, err := p.literal()
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		return false, nil
+	}
+	return compareJSONPath(v, op, lit), nil
+}
+
+// path follows .name, ['name'] and [index] steps
+func (p *jsonPathParser) path(v any) (any, bool, error) {
+	found := true
+	for {
+		var key any
+		switch {
+		case p.eat("['"):
+			s, err := p.quoted()
+			if err != nil {
+				return nil, false, err
+			}
+			if !p.eat("]") {
+				return nil, false, fmt.Errorf("unterminated ['...']")
+			}
+			key = s
+		case p.peek("[") && !p.peek("[?"):
+			p.pos++
+			end := strings.IndexByte(p.src[p.pos:], ']')
+			if end < 0 {
+				return nil, false, fmt.Errorf("unterminated [")
+			}
+			n, err := strconv.Atoi(p.src[p.pos : p.pos+end])
+			if err != nil {
+				return nil, false, fmt.Errorf("unsupported step [%s]", p.src[p.pos:p.pos+end])
+			}
+			p.pos += end + 1
+			key = n
+		case p.eat("."):
+			start := p.pos
+			for p.pos < len(p.src) && isIdentByte(p.src[p.pos]) {
+				p.pos++
+			}
+			if start == p.pos {
+				return nil, false, fmt.Errorf("unsupported step at %d", start)
+			}
+			key = p.src[start:p.pos]
+		default:
+			return v, found, nil
+		}
+		switch k := key.(type) {
+		case string:
+			obj, isObj := v.(map[string]any)
+			v, found = nil, false
+			if isObj {
+				v, found = obj[k]
+			}
+		case int:
+			list, isList := v.([]any)
+			v, found = nil, false
+			if isList && k >= 0 && k < len(list) {
+				v, found = list[k], true
+			}
+		}
+	}
+}
+
+func (p *jsonPathParser) literal() (any, error) {
+	if p.eat("'") {
+		return p.quoted()
+	}
+	switch {
+	case p.eat("true"):
+		return true, nil
+	case p.eat("false"):
+		return false, nil
+	case p.eat("null"):
+		return nil, nil
+	}
+	start := p.pos
+	for p.pos < len(p.src) && strings.IndexByte("+-.0123456789eE", p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+	n, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported literal at %d", start)
+	}
+	return n, nil
+}
+
+// quoted reads a single-quoted string whose opening quote was consumed
+func (p *jsonPathParser) quoted() (string, error) {
+	var b strings.Builder
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		p.pos++
+		switch {
+		case c == '\\' && p.pos < len(p.src):
+			b.WriteByte(p.src[p.pos])
+			p.pos++
+		case c == '\'':
+			return b.String(), nil
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", fmt.Errorf("unterminated string")
+}
+
+// regex reads /pattern/flags; Jayway matches the whole value
+func (p *jsonPathParser) regex() (*regexp.Regexp, error) {
+	if !p.eat("/") {
+		return nil, fmt.Errorf("=~ needs a /regex/")
+	}
+	var b strings.Builder
+	for p.pos < len(p.src) && p.src[p.pos] != '/' {
+		if p.src[p.pos] == '\\' && p.pos+1 < len(p.src) && p.src[p.pos+1] == '/' {
+			p.pos++
+		}
+		b.WriteByte(p.src[p.pos])
+		p.pos++
+	}
+	if !p.eat("/") {
+		return nil, fmt.Errorf("unterminated /regex/")
+	}
+	flags := ""
+	if p.eat("i") {
+		flags = "(?i)"
+	}
+	return regexp.Compile(flags + "^(?:" + b.String() + ")$")
+}
+
+func compareJSONPath(v any, op string, lit any) bool {
+	if a, ok := v.(float64); ok {
+		b, ok := lit.(float64)
+		if !ok {
+			return op == "!="
+		}
+		switch op {
+		case "==":
+			return a == b
+		case "!=":
+			return a != b
+		case ">":
+			return a > b
+		case ">=":
+			return a >= b
+		case "<":
+			return a < b
+		case "<=":
+			return a <= b
+		}
+	}
+	switch op {
+	case "==":
+		return fmt.Sprintf("%T %v", v, v) == fmt.Sprintf("%T %v", lit, lit)
+	case "!=":
+		return fmt.Sprintf("%T %v", v, v) != fmt.Sprintf("%T %v", lit, lit)
+	}
+	a, aText := v.(string)
+	b, bText := lit.(string)
+	if !aText || !bText {
+		return false
+	}
+	switch op {
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	}
+	return false
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func (p *jsonPathParser) eat(s string) bool {
+	if strings.HasPrefix(p.src[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *jsonPathParser) peek(s string) bool {
+	return strings.HasPrefix(p.src[p.pos:], s)
+}
+
+func (p *jsonPathParser) space() {
+	for p.pos < len(p.src) && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+}
@@ -0,0 +1,332 @@
+// Package matcher evaluates incoming requests against MockServer expectations in Go,
+// recording a matcher-by-matcher trace so mismatches can be explained
+package matcher
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// Request is the normalized request evaluated against expectations
+type Request struct {
+	Method  string
+	Path    string
+	Query   url.Values
+	Headers http.Header
+	Body    []byte
+}
+
+// FromHTTP reads an *http.Request into a Request (the body is consumed)
+func FromHTTP(r *http.Request) (*Request, error) {
+	var body []byte
+	if r.Body != nil {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		body = b
+	}
+	headers := r.Header
+	if r.Host != "" && headers.Get("Host") == "" {
+		// net/http moves Host out of the headers; MockServer matches it like any other
+		headers = r.Header.Clone()
+		headers.Set("Host", r.Host)
+	}
+	return &Request{
+		Method:  r.Method,
+		Path:    r.URL.Path,
+		Query:   r.URL.Query(),
+		Headers: headers,
+		Body:    body,
+	}, nil
+}
+
+// Check is the outcome of a single matcher (method, path, one header, the body, ...)
+type Check struct {
+	Matcher  string `json:"matcher"` // e.g. "method", "path", "header:Authorization", "body"
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+	Passed   bool   `json:"passed"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// Result is the evaluation of one expectation against a request
+type Result struct {
+	Index       int                     `json:"index"`
+	Expectation *models.MockExpectation `json:"-"`
+	Checks      []Check                 `json:"checks"`
+	Matched     bool                    `json:"matched"`
+}
+
+// Passed returns how many matchers succeeded; used to rank near misses
+func (r *Result) Passed() int {
+	n := 0
+	for _, c := range r.Checks {
+		if c.Passed {
+			n++
+		}
+	}
+	return n
+}
+
+// Failed returns the failing checks
+func (r *Result) Failed() []Check {
+	var out []Check
+	for _, c := range r.Checks {
+		if !c.Passed {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Evaluate runs every matcher of the expectation against the request.
+// All matchers are evaluated (no short-circuit) so the trace is complete.
+func Evaluate(exp *models.MockExpectation, req *Request) Result {
+	res := Result{Expectation: exp}
+	hr := exp.HttpRequest
+	if hr == nil {
+		hr = &models.HttpRequest{}
+	}
+
+	if hr.Method != "" {
+		res.add("method", hr.Method, req.Method, matchValue(hr.Method, req.Method, true), "")
+	}
+	if hr.Path != "" {
+		ok, reason := matchPath(hr.Path, hr.PathParameters, req.Path)
+		res.add("path", hr.Path, req.Path, ok, reason)
+	}
+	for _, q := range hr.QueryStringParameters {
+		actual := req.Query[q.Name]
+		ok, reason := matchValues(q.Values, actual, false)
+		res.add("query:"+q.Name, strings.Join(q.Values, ","), strings.Join(actual, ","), ok, reason)
+	}
+	for _, h := range hr.Headers {
+		actual := req.Headers.Values(h.Name)
+		ok, reason := matchValues(h.Values, actual, false)
+		res.add("header:"+h.Name, strings.Join(h.Values, ","), strings.Join(actual, ","), ok, reason)
+	}
+	if hr.Body != nil {
+		ok, expected, reason := matchBody(hr.Body, req.Body)
+		res.add("body", expected, truncate(string(req.Body), 120), ok, reason)
+	}
+
+	res.Matched = len(res.Failed()) == 0
+	return res
+}
+
+func (r *Result) add(matcher, expected, actual string, ok bool, reason string) {
+	r.Checks = append(r.Checks, Check{Matcher: matcher, Expected: expected, Actual: actual, Passed: ok, Reason: reason})
+}
+
+// Match evaluates all expectations and returns the index of the winning expectation
+// (highest priority, then declaration order), or -1, together with every result.
+func Match(expectations []models.MockExpectation, req *Request) (int, []Result) {
+	results := make([]Result, len(expectations))
+	best := -1
+	for i := range expectations {
+		results[i] = Evaluate(&expectations[i], req)
+		results[i].Index = i
+		if !results[i].Matched {
+			continue
+		}
+		if best < 0 || expectations[i].Priority > expectations[best].Priority {
+			best = i
+		}
+	}
+	return best, results
+}
+
+// Ranked returns the matching results in the order MockServer would try them:
+// highest priority first, then declaration order. The first entry is the winner.
+func Ranked(results []Result) []Result {
+	var matched []Result
+	for _, r := range results {
+		if r.Matched {
+			matched = append(matched, r)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return priority(matched[i]) > priority(matched[j])
+	})
+	return matched
+}
+
+// FormatRanking explains the winner and why every other matching expectation loses to it
+func FormatRanking(ranked []Result) string {
+	if len(ranked) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "🏁 %d expectation(s) match; evaluation order:\n", len(ranked))
+	winner := ranked[0]
+	for i, r := range ranked {
+		fmt.Fprintf(&b, "   %d. #%d %s (priority %d)", i+1, r.Index+1, resultLabel(r), priority(r))
+		switch {
+		case i == 0:
+			b.WriteString(" ← wins")
+		case priority(r) < priority(winner):
+			fmt.Fprintf(&b, " — loses: lower priority than #%d", winner.Index+1)
+		default:
+			fmt.Fprintf(&b, " — loses: same priority, declared after #%d", winner.Index+1)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func priority(r Result) int {
+	if r.Expectation == nil {
+		return 0
+	}
+	return r.Expectation.Priority
+}
+
+func resultLabel(r Result) string {
+	if r.Expectation == nil || r.Expectation.HttpRequest == nil {
+		return ""
+	}
+	label := fmt.Sprintf("%s %s", r.Expectation.HttpRequest.Method, r.Expectation.HttpRequest.Path)
+	if r.Expectation.HttpResponse != nil {
+		label += fmt.Sprintf(" → %d", r.Expectation.HttpResponse.StatusCode)
+	}
+	return label
+}
+
+// TopCandidates returns up to n non-matching results ranked by how close they came
+func TopCandidates(results []Result, n int) []Result {
+	var misses []Result
+	for _, r := range results {
+		if !r.Matched {
+			misses = append(misses, r)
+		}
+	}
+	sort.SliceStable(misses, func(i, j int) bool {
+		ri, rj := misses[i], misses[j]
+		if len(ri.Failed()) != len(rj.Failed()) {
+			return len(ri.Failed()) < len(rj.Failed())
+		}
+		return ri.Passed() > rj.Passed()
+	})
+	if len(misses) > n {
+		misses = misses[:n]
+	}
+	return misses
+}
+
+// FormatTrace renders results as a human-readable matcher-by-matcher trace
+func FormatTrace(req *Request, results []Result) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "🔎 %s %s\n", req.Method, req.Path)
+	if len(results) == 0 {
+		b.WriteString("   (no expectations to compare)\n")
+	}
+	for _, r := range results {
+		status := "❌ no match"
+		if r.Matched {
+			status = "✅ match"
+		}
+		fmt.Fprintf(&b, "\n#%d %s  %s\n", r.Index+1, resultLabel(r), status)
+		for _, c := range r.Checks {
+			mark := "✓"
+			if !c.Passed {
+				mark = "✗"
+			}
+			fmt.Fprintf(&b, "   %s %-24s expected %q, got %q", mark, c.Matcher, c.Expected, c.Actual)
+			if c.Reason != "" {
+				fmt.Fprintf(&b, " — %s", c.Reason)
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// matchValue compares a MockServer matcher value with an actual value: exact first, then as a
+// full-string regex (MockServer treats most string matchers as regex-capable). A leading "!"
+// negates the matcher, as in MockServer's not-matchers.
+func matchValue(expected, actual string, foldCase bool) bool {
+	if strings.HasPrefix(expected, "!") && len(expected) > 1 {
+		return !matchValue(expected[1:], actual, foldCase)
+	}
+	if expected == actual || (foldCase && strings.EqualFold(expected, actual)) {
+		return true
+	}
+	pattern := "^(?:" + expected + ")$"
+	if foldCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	return err == nil && re.MatchString(actual)
+}
+
+// matchValues requires every expected value to match at least one actual value
+func matchValues(expected, actual []string, foldCase bool) (bool, string) {
+	if len(actual) == 0 {
+		for _, e := range expected {
+			if !strings.HasPrefix(e, "!") {
+				return false, "missing"
+			}
+		}
+		return true, "" // only not-matchers: an absent value satisfies them
+	}
+	for _, e := range expected {
+		found := false
+		for _, a := range actual {
+			if matchValue(e, a, foldCase) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, fmt.Sprintf("no value matches %q", e)
+		}
+	}
+	return true, ""
+}
+
+var pathParamPattern = regexp.MustCompile(`\{([^/{}]+)\}`)
+
+func matchPath(expected string, params map[string][]string, actual string) (bool, string) {
+	if !strings.Contains(expected, "{") {
+		if matchValue(expected, actual, false) {
+			return true, ""
+		}
+		return false, "path differs"
+	}
+
+	// Templated path: /users/{id} — each placeholder matches one segment
+	names := pathParamPattern.FindAllStringSubmatch(expected, -1)
+	quoted := regexp.QuoteMeta(expected)
+	for _, n := range names {
+		quoted = strings.Replace(quoted, regexp.QuoteMeta(n[0]), "([^/]+)", 1)
+	}
+	m := regexp.MustCompile("^" + quoted + "$").FindStringSubmatch(actual)
+	if m == nil {
+		return false, "path does not fit template"
+	}
+	for i, n := range names {
+		allowed, ok := params[n[1]]
+		if !ok || len(allowed) == 0 {
+			continue
+		}
+		if ok, _ := matchValues(allowed, []string{m[i+1]}, false); !ok {
+			return false, fmt.Sprintf("path parameter %s=%q not allowed", n[1], m[i+1])
+		}
+	}
+	return true, ""
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "…"
+}
@@ -0,0 +1,210 @@
+package matcher
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+func TestMatch_TracesFailingMatchers(t *testing.T) {
+	exps := []models.MockExpectation{
+		{
+			HttpRequest: &models.HttpRequest{
+				Method:  "POST",
+				Path:    "/users/{id}",
+				Headers: []models.NameValues{{Name: "Authorization", Values: []string{"Bearer .*"}}},
+				Body: map[string]any{"type": "JSON", "matchType": "ONLY_MATCHING_FIELDS",
+					"json": map[string]any{"role": "admin"}},
+			},
+			HttpResponse: &models.HttpResponse{StatusCode: 200},
+		},
+		{
+			HttpRequest:  &models.HttpRequest{Method: "GET", Path: "/health"},
+			HttpResponse: &models.HttpResponse{StatusCode: 200},
+		},
+	}
+	req := &Request{
+		Method:  "POST",
+		Path:    "/users/42",
+		Query:   url.Values{},
+		Headers: http.Header{"Authorization": []string{"Bearer abc"}},
+		Body:    []byte(`{"role":"viewer","name":"x"}`),
+	}
+
+	best, results := Match(exps, req)
+	if best != -1 {
+		t.Fatalf("expected no match, got #%d", best)
+	}
+	top := TopCandidates(results, 1)
+	if len(top) != 1 || top[0].Index != 0 {
+		t.Fatalf("expected first expectation as closest candidate, got %+v", top)
+	}
+	failed := top[0].Failed()
+	if len(failed) != 1 || failed[0].Matcher != "body" || !strings.Contains(failed[0].Reason, "$.role") {
+		t.Fatalf("expected body failure on $.role, got %+v", failed)
+	}
+
+	req.Body = []byte(`{"role":"admin","name":"x"}`)
+	if best, _ := Match(exps, req); best != 0 {
+		t.Fatalf("expected lenient body match, got %d", best)
+	}
+}
+
+func TestRanked_PriorityThenDeclarationOrder(t *testing.T) {
+	exps := []models.MockExpectation{
+		{HttpRequest: &models.HttpRequest{Method: "GET", Path: "/users/.*"}},
+		{HttpRequest: &models.HttpRequest{Method: "GET", Path: "/users/42"}, Priority: 5},
+		{HttpRequest: &models.HttpRequest{Path: "/users/42"}},
+		{HttpRequest: &models.HttpRequest{Method: "POST", Path: "/users/42"}, Priority: 9},
+	}
+	req := &Request{Method: "GET", Path: "/users/42", Query: url.Values{}, Headers: http.Header{}}
+
+	best, results := Match(exps, req)
+	ranked := Ranked(results)
+	if len(ranked) != 3 || ranked[0].Index != best || best != 1 {
+		t.Fatalf("expected #2 to win among 3 matches, got best=%d ranked=%+v", best, ranked)
+	}
+	if ranked[1].Index != 0 || ranked[2].Index != 2 {
+		t.Fatalf("expected ties in declaration order, got %d, %d", ranked[1].Index, ranked[2].Index)
+	}
+	out := FormatRanking(ranked)
+	if !strings.Contains(out, "lower priority than #2") {
+		t.Fatalf("expected loser explanation, got:\n%s", out)
+	}
+}
+
+func TestMatch_NegotiatedVariantsFollowAccept(t *testing.T) {
+	cfg := &models.MockConfiguration{Expectations: []models.MockExpectation{{
+		HttpRequest:  &models.HttpRequest{Method: "GET", Path: "/orders/{id}"},
+		HttpResponse: &models.HttpResponse{StatusCode: 200, Body: map[string]any{"type": "JSON", "json": map[string]any{"id": "o_1"}}},
+		Negotiation:  &models.Negotiation{Formats: []string{"xml", "csv"}},
+	}}}
+	if _, err := cfg.ApplyNegotiation(); err != nil {
+		t.Fatal(err)
+	}
+
+	for accept, want := range map[string]string{
+		"text/xml":                         "xml",
+		"application/xml;q=0.9, */*;q=0.8": "xml",
+		"text/csv":                         "csv",
+		"application/json":                 "",
+		"":                                 "",
+	} {
+		req := &Request{Method: "GET", Path: "/orders/o_1", Query: url.Values{}, Headers: http.Header{}}
+		if accept != "" {
+			req.Headers.Set("Accept", accept)
+		}
+		best, _ := Match(cfg.Expectations, req)
+		if best < 0 || cfg.Expectations[best].NegotiatedFormat != want {
+			t.Errorf("Accept %q: matched #%d, want format %q", accept, best, want)
+		}
+	}
+}
+
+func TestMatch_ConditionalGetAnswers304(t *testing.T) {
+	cfg := &models.MockConfiguration{Expectations: []models.MockExpectation{{
+		HttpRequest:    &models.HttpRequest{Method: "GET", Path: "/orders"},
+		HttpResponse:   &models.HttpResponse{StatusCode: 200, Body: map[string]any{"type": "JSON", "json": []any{"o_1"}}},
+		Negotiation:    &models.Negotiation{Formats: []string{"csv"}},
+		ConditionalGet: true,
+	}}}
+	if _, err := cfg.ApplyNegotiation(); err != nil {
+		t.Fatal(err)
+	}
+	cfg.ApplyConditionalGet()
+	etag := models.ETag(cfg.Expectations[len(cfg.Expectations)-1].HttpResponse.Body)
+
+	for _, tc := range []struct {
+		accept, ifNoneMatch string
+		status              int
+	}{
+		{"", etag, 304},
+		{"", `W/` + etag + `, "other"`, 304},
+		{"", "*", 304},
+		{"", `"stale"`, 200},
+		{"", "", 200},
+		{"text/csv", etag, 200}, // each representation has its own ETag
+	} {
+		req := &Request{Method: "GET", Path: "/orders", Query: url.Values{}, Headers: http.Header{}}
+		if tc.accept != "" {
+			req.Headers.Set("Accept", tc.accept)
+		}
+		if tc.ifNoneMatch != "" {
+			req.Headers.Set("If-None-Match", tc.ifNoneMatch)
+		}
+		best, _ := Match(cfg.Expectations, req)
+		if best < 0 || cfg.Expectations[best].HttpResponse.StatusCode != tc.status {
+			t.Errorf("Accept %q, If-None-Match %q: matched #%d, want %d", tc.accept, tc.ifNoneMatch, best, tc.status)
+		}
+	}
+}
+
+func TestMatch_ResponseRulesRouteByRequestValues(t *testing.T) {
+	config := &models.MockConfiguration{Expectations: []models.MockExpectation{{
+		HttpRequest: &models.HttpRequest{Method: "POST", Path: "/payments",
+			Body: map[string]any{"type": "JSON", "json": map[string]any{"currency": "EUR"}}},
+		HttpResponse: &models.HttpResponse{StatusCode: 201},
+		Rules: []models.ResponseRule{
+			{Name: "timeout", When: []models.RuleCondition{{Source: "header", Field: "X-Test-Case", Op: "eq", Value: "timeout"}},
+				Response: &models.HttpResponse{StatusCode: 504}},
+			{When: []models.RuleCondition{{Source: "body", Field: "amount", Op: "gt", Value: float64(1000)}},
+				Response: &models.HttpResponse{StatusCode: 402}},
+			{When: []models.RuleCondition{{Source: "body", Field: "card.number", Op: "matches", Value: "4000.*"}, {Source: "query", Field: "mode", Op: "exists"}},
+				Response: &models.HttpResponse{StatusCode: 422}},
+		},
+	}}}
+	if n, err := config.ApplyRules(); err != nil || n != 3 {
+		t.Fatalf("ApplyRules = %d, %v", n, err)
+	}
+
+	for name, tc := range map[string]struct {
+		header, query, body string
+		want                int
+	}{
+		"plain":                 {"", "", `{"currency": "EUR", "amount": 10}`, 201},
+		"timeout header":        {"timeout", "", `{"currency": "EUR", "amount": 5000}`, 504},
+		"large amount":          {"", "", `{"currency": "EUR", "amount": 5000}`, 402},
+		"large amount, USD":     {"", "", `{"currency": "USD", "amount": 5000}`, -1},
+		"declined card":         {"", "mode=test", `{"currency": "EUR", "card": {"number": "4000123"}}`, 422},
+		"declined card, no qry": {"", "", `{"currency": "EUR", "card": {"number": "4000123"}}`, 201},
+	} {
+		query, _ := url.ParseQuery(tc.query)
+		req := &Request{Method: "POST", Path: "/payments", Query: query, Headers: http.Header{}, Body: []byte(tc.body)}
+		if tc.header != "" {
+			req.Headers.Set("X-Test-Case", tc.header)
+		}
+		best, _ := Match(config.Expectations, req)
+		got := -1
+		if best >= 0 {
+			got = config.Expectations[best].HttpResponse.StatusCode
+		}
+		if got != tc.want {
+			t.Errorf("%s: status %d, want %d", name, got, tc.want)
+		}
+	}
+}
+
+func TestMatchJSONPathBody(t *testing.T) {
+	body := []byte(`{"amount": 1500, "tier": "gold", "items": [{"sku": "A-1"}], "odd key": true, "coupon": null}`)
+	for expr, want := range map[string]bool{
+		"$.items[0].sku": true,
+		"$.missing":      false,
+		"$[?(@.amount >= 1500 && @.tier == 'gold')]":  true,
+		"$[?(@.amount < 1000)]":                       false,
+		"$[?(@.tier != 'silver')]":                    true,
+		"$[?(@.items[0].sku =~ /a-\\d/i)]":            true,
+		"$[?(@['odd key'] == true)]":                  true,
+		"$[?(@.coupon)]":                              true,
+		"$[?(@.missing != 'x')]":                      false,
+		"$.store.book[?(@.price < 10)]":               true, // not evaluated locally
+		"$[?(@.amount > 1000 || @.tier == 'silver')]": true, // not evaluated locally
+		"$[?(@.tier == 'it\\'s')]":                    false,
+	} {
+		if got, _, _ := matchJSONPathBody(expr, body); got != want {
+			t.Errorf("%s: matched = %v, want %v", expr, got, want)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package matcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// validateSchema checks a decoded JSON value against the JSON Schema keywords MockServer users
+// rely on in body matchers (type, properties, required, additionalProperties, items, enum,
+// const, numeric/string/array bounds, pattern, allOf/anyOf/oneOf/not). It returns "" when the
+// value is valid, or a reason naming the first failing location.
+func validateSchema(schema any, value any, path string) string {
+	s, ok := schema.(map[string]any)
+	if !ok {
+		if b, isBool := schema.(bool); isBool && !b {
+			return path + " not allowed by schema"
+		}
+		return ""
+	}
+
+	if t, ok := s["type"]; ok {
+		if !schemaTypeMatches(t, value) {
+			return fmt.Sprintf("%s expected type %s, got %s", path, compactJSON(t), jsonType(value))
+		}
+	}
+	if enum, ok := s["enum"].([]any); ok {
+		found := false
+		for _, e := range enum {
+			if reflect.DeepEqual(e, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Sprintf("%s %s not in enum %s", path, compactJSON(value), compactJSON(enum))
+		}
+	}
+	if c, ok := s["const"]; ok && !reflect.DeepEqual(c, value) {
+		return fmt.Sprintf("%s expected %s, got %s", path, compactJSON(c), compactJSON(value))
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		if r := validateObject(s, v, path); r != "" {
+			return r
+		}
+	case []any:
+		if n, ok := number(s["minItems"]); ok && float64(len(v)) < n {
+			return fmt.Sprintf("%s has %d item(s), minimum %v", path, len(v), n)
+		}
+		if n, ok := number(s["maxItems"]); ok && float64(len(v)) > n {
+			return fmt.Sprintf("%s has %d item(s), maximum %v", path, len(v), n)
+		}
+		if items, ok := s["items"]; ok {
+			for i, item := range v {
+				if r := validateSchema(items, item, fmt.Sprintf("%s[%d]", path, i)); r != "" {
+					return r
+				}
+			}
+		}
+	case string:
+		length := float64(len([]rune(v)))
+		if n, ok := number(s["minLength"]); ok && length < n {
+			return fmt.Sprintf("%s shorter than %v", path, n)
+		}
+		if n, ok := number(s["maxLength"]); ok && length > n {
+			return fmt.Sprintf("%s longer than %v", path, n)
+		}
+		if p, ok := s["pattern"].(string); ok {
+			if re, err := regexp.Compile(p); err == nil && !re.MatchString(v) {
+				return fmt.Sprintf("%s %q does not match pattern %s", path, v, p)
+			}
+		}
+	case float64:
+		if n, ok := number(s["minimum"]); ok && v < n {
+			return fmt.Sprintf("%s %v below minimum %v", path, v, n)
+		}
+		if n, ok := number(s["maximum"]); ok && v > n {
+			return fmt.Sprintf("%s %v above maximum %v", path, v, n)
+		}
+		if n, ok := number(s["exclusiveMinimum"]); ok && v <= n {
+			return fmt.Sprintf("%s %v not above %v", path, v, n)
+		}
+		if n, ok := number(s["exclusiveMaximum"]); ok && v >= n {
+			return fmt.Sprintf("%s %v not below %v", path, v, n)
+		}
+	}
+
+	if all, ok := s["allOf"].([]any); ok {
+		for _, sub := range all {
+			if r := validateSchema(sub, value, path); r != "" {
+				return r
+			}
+		}
+	}
+	if alternatives, ok := s["anyOf"].([]any); ok {
+		matched := false
+		for _, sub := range alternatives {
+			if validateSchema(sub, value, path) == "" {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return path + " matches none of anyOf"
+		}
+	}
+	if one, ok := s["oneOf"].([]any); ok {
+		count := 0
+		for _, sub := range one {
+			if validateSchema(sub, value, path) == "" {
+				count++
+			}
+		}
+		if count != 1 {
+			return fmt.Sprintf("%s matches %d of oneOf (exactly 1 required)", path, count)
+		}
+	}
+	if not, ok := s["not"]; ok && validateSchema(not, value, path) == "" {
+		return path + " matches a schema it must not match"
+	}
+	return ""
+}
+
+func validateObject(s map[string]any, v map[string]any, path string) string {
+	if required, ok := s["required"].([]any); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, present := v[name]; !present {
+				return fmt.Sprintf("%s.%s required", path, name)
+			}
+		}
+	}
+	props, _ := s["properties"].(map[string]any)
+	keys := make([]string, 0, len(v))
+	for k := range v {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if sub, ok := props[k]; ok {
+			if r := validateSchema(sub, v[k], path+"."+k); r != "" {
+				return r
+			}
+			continue
+		}
+		switch extra := s["additionalProperties"].(type) {
+		case bool:
+			if !extra {
+				return fmt.Sprintf("%s.%s not allowed (additionalProperties)", path, k)
+			}
+		case map[string]any:
+			if r := validateSchema(extra, v[k], path+"."+k); r != "" {
+				return r
+			}
+		}
+	}
+	return ""
+}
+
+func schemaTypeMatches(t any, value any) bool {
+	switch tt := t.(type) {
+	case string:
+		return typeIs(tt, value)
+	case []any:
+		for _, one := range tt {
+			if name, ok := one.(string); ok && typeIs(name, value) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func typeIs(name string, value any) bool {
+	switch name {
+	case "integer":
+		f, ok := value.(float64)
+		return ok && f == math.Trunc(f)
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	default:
+		return jsonType(value) == name
+	}
+}
+
+func number(v any) (float64, bool) {
+	f, ok := v.(float64)
+	return f, ok
+}
+
+// matchSchemaBody evaluates a JSON_SCHEMA body matcher; the schema may be inline or a JSON string
+func matchSchemaBody(schema any, body []byte) (bool, string, string) {
+	if s, isStr := schema.(string); isStr {
+		var decoded any
+		if err := json.Unmarshal([]byte(s), &decoded); err != nil {
+			return false, "schema", fmt.Sprintf("invalid schema: %v", err)
+		}
+		schema = decoded
+	}
+	summary := "schema " + truncate(compactJSON(schema), 100)
+	var actual any
+	if err := json.Unmarshal(body, &actual); err != nil {
+		return false, summary, "body is not valid JSON"
+	}
+	if reason := validateSchema(schema, actual, "$"); reason != "" {
+		return false, summary, strings.TrimSpace(reason)
+	}
+	return true, summary, ""
+}
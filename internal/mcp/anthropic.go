@@ -2,10 +2,19 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"strings"
+
+	"github.com/hemantobora/auto-mock/internal/network"
 )
 
+// anthropicURL is the messages endpoint; a variable so tests can point it elsewhere
+var anthropicURL = "https://api.anthropic.com/v1/messages"
+
 type anthropicProvider struct{}
 
 func (a anthropicProvider) Name() string     { return "anthropic" }
@@ -19,54 +28,103 @@ func (a anthropicProvider) Generate(ctx context.Context, in GenerateInput) (Resu
 		return Result{}, ErrMissingKey("ANTHROPIC_API_KEY")
 	}
 
-	model := os.Getenv("ANTHROPIC_MODEL")
-	if model == "" {
-		model = "claude-sonnet-4-5"
-	}
+	model := modelFor("ANTHROPIC_MODEL", "claude-sonnet-4-5")
 
 	payload := map[string]any{
 		"model":       model,
 		"max_tokens":  4096,
 		"temperature": 0,
-		"messages": []map[string]string{
-			{"role": "user", "content": buildAnthropicUserPrompt(in)},
-		},
+		"stream":      true,
+		"messages":    chatMessages(turns(in.History, buildAnthropicUserPrompt(in))),
 	}
-
-	var raw struct {
-		Content []struct {
-			Text string `json:"text"`
-			Type string `json:"type"`
-		} `json:"content"`
-		Usage struct {
-			InputTokens  int `json:"input_tokens"`
-			OutputTokens int `json:"output_tokens"`
-		} `json:"usage"`
+	if in.System != "" {
+		payload["system"] = in.System
 	}
 
-	err := doJSON(ctx, "POST", "https://api.anthropic.com/v1/messages",
+	// Bounded by the context rather than the client timeout, like the OpenAI stream
+	client := &http.Client{Transport: network.Transport()}
+	resp, err := sendJSON(ctx, client, "POST", anthropicURL,
 		map[string]string{
 			"x-api-key":         key,
 			"anthropic-version": "2023-06-01",
-		}, payload, &raw)
+		}, payload)
 	if err != nil {
 		return Result{}, err
 	}
+	defer resp.Body.Close()
 
-	var builder strings.Builder
-	for _, c := range raw.Content {
-		if c.Type == "text" {
-			builder.WriteString(c.Text)
-		}
+	text, tokens, err := readAnthropicStream(resp.Body, in.OnText)
+	if err != nil {
+		return Result{}, err
 	}
 
 	return Result{
 		Provider:       a.Name(),
-		MockServerJSON: trimFences(builder.String()),
-		TokensUsed:     raw.Usage.InputTokens + raw.Usage.OutputTokens,
+		Model:          model,
+		MockServerJSON: trimFences(text),
+		TokensUsed:     tokens,
 	}, nil
 }
 
+// anthropicEvent is one server-sent event of a streamed message
+type anthropicEvent struct {
+	Type    string `json:"type"`
+	Message struct {
+		Usage struct {
+			InputTokens int `json:"input_tokens"`
+		} `json:"usage"`
+	} `json:"message"` // message_start
+	Delta struct {
+		Type       string `json:"type"`
+		Text       string `json:"text"`        // content_block_delta
+		StopReason string `json:"stop_reason"` // message_delta
+	} `json:"delta"`
+	Usage struct {
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"` // message_delta
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// readAnthropicStream collects the streamed text and the input plus output tokens, passing
+// each piece of text to onText as it arrives
+func readAnthropicStream(r io.Reader, onText func(string)) (string, int, error) {
+	var text strings.Builder
+	input, output := 0, 0
+	err := readSSE(r, func(data string) (bool, error) {
+		var ev anthropicEvent
+		if err := json.Unmarshal([]byte(data), &ev); err != nil {
+			return false, err
+		}
+		switch ev.Type {
+		case "message_start":
+			input = ev.Message.Usage.InputTokens
+		case "content_block_delta":
+			if ev.Delta.Type == "text_delta" {
+				text.WriteString(ev.Delta.Text)
+				if onText != nil && ev.Delta.Text != "" {
+					onText(ev.Delta.Text)
+				}
+			}
+		case "message_delta":
+			output = ev.Usage.OutputTokens
+			if ev.Delta.StopReason == "max_tokens" {
+				return false, fmt.Errorf("the response hit the model's output limit; describe fewer endpoints or pick a larger model with --model")
+			}
+		case "message_stop":
+			return true, nil
+		case "error":
+			return false, fmt.Errorf("%s", ev.Error.Message)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return "", 0, fmt.Errorf("anthropic stream: %w", err)
+	}
+	return text.String(), input + output, nil
+}
+
 // buildAnthropicUserPrompt is just a thin passthrough.
 func buildAnthropicUserPrompt(in GenerateInput) string {
 	return in.Prompt
@@ -0,0 +1,52 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadAnthropicStream(t *testing.T) {
+	stream := strings.Join([]string{
+		`event: message_start`,
+		`data: {"type":"message_start","message":{"id":"msg_1","usage":{"input_tokens":25,"output_tokens":1}}}`,
+		``,
+		`event: content_block_start`,
+		`data: {"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+		``,
+		`event: ping`,
+		`data: {"type": "ping"}`,
+		``,
+		`event: content_block_delta`,
+		`data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"[{\"a\""}}`,
+		``,
+		`event: content_block_delta`,
+		`data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":": 1}]"}}`,
+		``,
+		`event: message_delta`,
+		`data: {"type":"message_delta","delta":{"stop_reason":"end_turn"},"usage":{"output_tokens":15}}`,
+		``,
+		`event: message_stop`,
+		`data: {"type":"message_stop"}`,
+		``,
+	}, "\n")
+
+	var deltas []string
+	text, tokens, err := readAnthropicStream(strings.NewReader(stream), func(d string) { deltas = append(deltas, d) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != `[{"a": 1}]` || tokens != 40 || len(deltas) != 2 {
+		t.Errorf("got %q, %d tokens, deltas %q", text, tokens, deltas)
+	}
+}
+
+func TestReadAnthropicStreamErrors(t *testing.T) {
+	for name, stream := range map[string]string{
+		"error event": `data: {"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}`,
+		"truncated":   `data: {"type":"message_delta","delta":{"stop_reason":"max_tokens"},"usage":{"output_tokens":4096}}`,
+	} {
+		if _, _, err := readAnthropicStream(strings.NewReader(stream), nil); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package mcp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsprovider "github.com/hemantobora/auto-mock/internal/cloud/aws"
+	"github.com/hemantobora/auto-mock/internal/network"
+)
+
+// EnvBedrockRegion picks the Bedrock region when it differs from the AWS profile's region
+const EnvBedrockRegion = "AUTOMOCK_BEDROCK_REGION"
+
+// bedrockEndpoint is the runtime endpoint of a region; a variable so tests can point it elsewhere
+var bedrockEndpoint = func(region string) string {
+	return "https://bedrock-runtime." + region + ".amazonaws.com"
+}
+
+// bedrockProvider runs Claude through AWS Bedrock with the AWS credential chain (AWS_PROFILE,
+// access keys, SSO, instance roles and the global --assume-role) instead of an API key
+type bedrockProvider struct{}
+
+func (b bedrockProvider) Name() string     { return "bedrock" }
+func (b bedrockProvider) CostHint() string { return "$$ (AWS bill)" }
+func (b bedrockProvider) Available() bool  { return awsCredentialsConfigured() }
+func init()                                { register(bedrockProvider{}) }
+
+func (b bedrockProvider) Generate(ctx context.Context, in GenerateInput) (Result, error) {
+	cfg, err := awsprovider.LoadConfig(ctx, "")
+	if err != nil {
+		return Result{}, err
+	}
+	if region := os.Getenv(EnvBedrockRegion); region != "" {
+		cfg.Region = region
+	}
+	creds, err := cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return Result{}, fmt.Errorf("bedrock: no AWS credentials (set AWS_PROFILE or run 'aws sso login'): %w", err)
+	}
+
+	model := modelFor("BEDROCK_MODEL_ID", "us.anthropic.claude-sonnet-4-5-20250929-v1:0")
+
+	payload := map[string]any{
+		"anthropic_version": "bedrock-2023-05-31",
+		"max_tokens":        4096,
+		"temperature":       0,
+		"messages":          chatMessages(turns(in.History, buildAnthropicUserPrompt(in))),
+	}
+	if in.System != "" {
+		payload["system"] = in.System
+	}
+
+	var raw struct {
+		Content []struct {
+			Text string `json:"text"`
+			Type string `json:"type"`
+		} `json:"content"`
+		Usage struct {
+			InputTokens  int `json:"input_tokens"`
+			OutputTokens int `json:"output_tokens"`
+		} `json:"usage"`
+	}
+
+	signer := v4.NewSigner()
+	sign := func(req *http.Request, body []byte) error {
+		sum := sha256.Sum256(body)
+		return signer.SignHTTP(ctx, creds, req, hex.EncodeToString(sum[:]), "bedrock", cfg.Region, time.Now())
+	}
+	endpoint := bedrockEndpoint(cfg.Region) + "/model/" + url.PathEscape(model) + "/invoke"
+	resp, err := sendSignedJSON(ctx, network.HTTPClient(), "POST", endpoint,
+		map[string]string{"Accept": "application/json"}, payload, sign)
+	if err != nil {
+		return Result{}, err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return Result{}, err
+	}
+
+	var builder strings.Builder
+	for _, c := range raw.Content {
+		if c.Type == "text" {
+			builder.WriteString(c.Text)
+		}
+	}
+
+	return Result{
+		Provider:       b.Name(),
+		Model:          model,
+		MockServerJSON: trimFences(builder.String()),
+		TokensUsed:     raw.Usage.InputTokens + raw.Usage.OutputTokens,
+	}, nil
+}
+
+// awsCredentialsConfigured is a cheap, offline check for AWS credentials: environment keys,
+// a profile, web identity or container credentials, or a shared credentials/config file
+func awsCredentialsConfigured() bool {
+	for _, env := range []string{"AWS_ACCESS_KEY_ID", "AWS_PROFILE", "AWS_WEB_IDENTITY_TOKEN_FILE",
+		"AWS_CONTAINER_CREDENTIALS_RELATIVE_URI", "AWS_CONTAINER_CREDENTIALS_FULL_URI",
+		"AWS_SHARED_CREDENTIALS_FILE", "AWS_CONFIG_FILE"} {
+		if os.Getenv(env) != "" {
+			return true
+		}
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return false
+	}
+	for _, file := range []string{"credentials", "config"} {
+		if _, err := os.Stat(filepath.Join(home, ".aws", file)); err == nil {
+			return true
+		}
+	}
+	return false
+}
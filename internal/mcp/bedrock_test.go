@@ -0,0 +1,52 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBedrockGenerate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDTEST/") || !strings.Contains(auth, "/eu-west-1/bedrock/aws4_request") {
+			t.Errorf("not signed for bedrock in eu-west-1: %q", auth)
+		}
+		if r.URL.Path != "/model/anthropic.claude-3-5-haiku-20241022-v1:0/invoke" {
+			t.Errorf("path %s", r.URL.Path)
+		}
+		var payload map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		if payload["anthropic_version"] != "bedrock-2023-05-31" {
+			t.Errorf("payload %v", payload)
+		}
+		w.Write([]byte(`{"content":[{"type":"text","text":"[]"}],"usage":{"input_tokens":10,"output_tokens":5}}`))
+	}))
+	defer srv.Close()
+
+	old := bedrockEndpoint
+	defer func() { bedrockEndpoint = old }()
+	bedrockEndpoint = func(string) string { return srv.URL }
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDTEST")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_CA_BUNDLE", "")
+	t.Setenv("AWS_CONFIG_FILE", "/nonexistent")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/nonexistent")
+	t.Setenv(EnvBedrockRegion, "eu-west-1")
+	t.Setenv("BEDROCK_MODEL_ID", "anthropic.claude-3-5-haiku-20241022-v1:0")
+
+	if !(bedrockProvider{}).Available() {
+		t.Error("not available with AWS access keys")
+	}
+	res, err := bedrockProvider{}.Generate(context.Background(), GenerateInput{Prompt: "users"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.MockServerJSON != "[]" || res.TokensUsed != 15 || res.Provider != "bedrock" {
+		t.Errorf("result %+v", res)
+	}
+}
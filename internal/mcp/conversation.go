@@ -0,0 +1,72 @@
+package mcp
+
+import (
+	"context"
+	"io"
+)
+
+// maxHistoryMessages bounds the earlier turns sent with each request; the first exchange (the
+// original description and its result) is always kept, then the latest turns
+const maxHistoryMessages = 12
+
+// Conversation is a multi-turn generation: the system instructions plus the turns so far, so
+// each refinement ("make all IDs uuids") is answered with the earlier requests and results in
+// context instead of starting over
+type Conversation struct {
+	Provider string
+	Project  string
+	System   string
+	History  []Message
+}
+
+// NewConversation starts a conversation with a provider
+func NewConversation(provider, project, system string) *Conversation {
+	return &Conversation{Provider: provider, Project: project, System: system}
+}
+
+// Send streams the provider's answer to prompt to w, with the conversation so far as context.
+// The turn is not remembered until the caller accepts it with Record.
+func (c *Conversation) Send(ctx context.Context, prompt string, w io.Writer) (Result, error) {
+	return generate(ctx, c.Provider, GenerateInput{
+		ProjectName: c.Project,
+		Prompt:      prompt,
+		System:      c.System,
+		History:     c.window(),
+	}, w)
+}
+
+// Record remembers an exchange; answer is what later turns should see as the model's reply,
+// such as the normalized expectations rather than the raw output
+func (c *Conversation) Record(prompt, answer string) {
+	c.History = append(c.History,
+		Message{Role: "user", Content: prompt},
+		Message{Role: "assistant", Content: answer})
+}
+
+// Turns is the number of recorded exchanges
+func (c *Conversation) Turns() int {
+	return len(c.History) / 2
+}
+
+// window is the history sent with the next request: the first exchange and the latest ones
+func (c *Conversation) window() []Message {
+	if len(c.History) <= maxHistoryMessages {
+		return c.History
+	}
+	out := append([]Message{}, c.History[:2]...)
+	return append(out, c.History[len(c.History)-(maxHistoryMessages-2):]...)
+}
+
+// turns is the history followed by the new user prompt, the message list a provider sends
+func turns(history []Message, prompt string) []Message {
+	return append(append([]Message{}, history...), Message{Role: "user", Content: prompt})
+}
+
+// chatMessages renders messages in the role/content shape of the Anthropic and OpenAI APIs
+func chatMessages(msgs []Message) []map[string]string {
+	out := make([]map[string]string, len(msgs))
+	for i, m := range msgs {
+		out[i] = map[string]string{"role": m.Role, "content": m.Content}
+	}
+	return out
+}
@@ -0,0 +1,59 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+)
+
+// recordingFake remembers the input of its last call
+type recordingFake struct{ last *GenerateInput }
+
+func (f recordingFake) Name() string     { return "recorder" }
+func (f recordingFake) CostHint() string { return "" }
+func (f recordingFake) Available() bool  { return true }
+
+func (f recordingFake) Generate(_ context.Context, in GenerateInput) (Result, error) {
+	*f.last = in
+	return Result{MockServerJSON: "[]"}, nil
+}
+
+func TestConversationSendsHistory(t *testing.T) {
+	fake := recordingFake{last: &GenerateInput{}}
+	withFake(t, fake)
+
+	conv := NewConversation("recorder", "users", "rules")
+	if _, err := conv.Send(context.Background(), "describe", io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if fake.last.System != "rules" || len(fake.last.History) != 0 || fake.last.Prompt != "describe" {
+		t.Errorf("first turn input %+v", *fake.last)
+	}
+	conv.Record("describe", `[{"id":1}]`)
+
+	if _, err := conv.Send(context.Background(), "make all IDs uuids", io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	h := fake.last.History
+	if len(h) != 2 || h[0].Role != "user" || h[1].Role != "assistant" || h[1].Content != `[{"id":1}]` {
+		t.Errorf("history %+v", h)
+	}
+	if conv.Turns() != 1 {
+		t.Errorf("turns %d: an unrecorded turn is not remembered", conv.Turns())
+	}
+}
+
+func TestConversationWindowKeepsFirstExchange(t *testing.T) {
+	conv := NewConversation("p", "proj", "")
+	for i := 0; i < 10; i++ {
+		conv.Record(fmt.Sprintf("ask %d", i), fmt.Sprintf("answer %d", i))
+	}
+	w := conv.window()
+	if len(w) != maxHistoryMessages {
+		t.Fatalf("window of %d messages", len(w))
+	}
+	if w[0].Content != "ask 0" || w[1].Content != "answer 0" || w[2].Content != "ask 5" || w[len(w)-1].Content != "answer 9" {
+		t.Errorf("window %+v", w)
+	}
+}
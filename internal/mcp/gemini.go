@@ -0,0 +1,103 @@
+package mcp
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// geminiBaseURL is the Gemini API root; a variable so tests can point it elsewhere
+var geminiBaseURL = "https://generativelanguage.googleapis.com/v1beta"
+
+// geminiProvider calls Google Gemini with GEMINI_API_KEY (or GOOGLE_API_KEY), falling back to
+// Application Default Credentials (gcloud auth application-default login, or a service
+// account key in GOOGLE_APPLICATION_CREDENTIALS)
+type geminiProvider struct{}
+
+func (g geminiProvider) Name() string     { return "gemini" }
+func (g geminiProvider) CostHint() string { return "$" }
+func (g geminiProvider) Available() bool  { return geminiAPIKey() != "" || adcFile() != "" }
+func init()                               { register(geminiProvider{}) }
+
+func (g geminiProvider) Generate(ctx context.Context, in GenerateInput) (Result, error) {
+	headers := map[string]string{}
+	if key := geminiAPIKey(); key != "" {
+		headers["x-goog-api-key"] = key
+	} else {
+		token, quotaProject, err := adcAccessToken(ctx)
+		if err != nil {
+			return Result{}, err
+		}
+		headers["Authorization"] = "Bearer " + token
+		if quotaProject != "" {
+			headers["x-goog-user-project"] = quotaProject
+		}
+	}
+
+	model := modelFor("GEMINI_MODEL", "gemini-2.5-flash")
+
+	var contents []map[string]any
+	for _, m := range turns(in.History, buildGeminiUserPrompt(in)) {
+		role := m.Role
+		if role == "assistant" {
+			role = "model" // Gemini's name for the assistant
+		}
+		contents = append(contents, map[string]any{"role": role, "parts": []map[string]string{{"text": m.Content}}})
+	}
+	payload := map[string]any{
+		"contents": contents,
+		"generationConfig": map[string]any{
+			"temperature":      0,
+			"responseMimeType": "application/json",
+		},
+	}
+	if in.System != "" {
+		payload["systemInstruction"] = map[string]any{"parts": []map[string]string{{"text": in.System}}}
+	}
+
+	var raw struct {
+		Candidates []struct {
+			Content struct {
+				Parts []struct {
+					Text string `json:"text"`
+				} `json:"parts"`
+			} `json:"content"`
+		} `json:"candidates"`
+		UsageMetadata struct {
+			TotalTokenCount int `json:"totalTokenCount"`
+		} `json:"usageMetadata"`
+	}
+
+	err := doJSON(ctx, "POST", geminiBaseURL+"/models/"+url.PathEscape(model)+":generateContent",
+		headers, payload, &raw)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var builder strings.Builder
+	if len(raw.Candidates) > 0 {
+		for _, p := range raw.Candidates[0].Content.Parts {
+			builder.WriteString(p.Text)
+		}
+	}
+
+	return Result{
+		Provider:       g.Name(),
+		Model:          model,
+		MockServerJSON: trimFences(builder.String()),
+		TokensUsed:     raw.UsageMetadata.TotalTokenCount,
+	}, nil
+}
+
+func geminiAPIKey() string {
+	if key := os.Getenv("GEMINI_API_KEY"); key != "" {
+		return key
+	}
+	return os.Getenv("GOOGLE_API_KEY")
+}
+
+// buildGeminiUserPrompt is just a thin passthrough.
+func buildGeminiUserPrompt(in GenerateInput) string {
+	return in.Prompt
+}
@@ -0,0 +1,127 @@
+package mcp
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGeminiGenerate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/models/gemini-2.5-pro:generateContent" || r.Header.Get("x-goog-api-key") != "g-key" {
+			t.Errorf("request %s with key %q", r.URL.Path, r.Header.Get("x-goog-api-key"))
+		}
+		var payload struct {
+			GenerationConfig map[string]any `json:"generationConfig"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		if payload.GenerationConfig["responseMimeType"] != "application/json" {
+			t.Errorf("generationConfig %v", payload.GenerationConfig)
+		}
+		w.Write([]byte(`{"candidates":[{"content":{"parts":[{"text":"[{\"a\":"},{"text":"1}]"}]}}],"usageMetadata":{"totalTokenCount":17}}`))
+	}))
+	defer srv.Close()
+
+	old := geminiBaseURL
+	defer func() { geminiBaseURL = old }()
+	geminiBaseURL = srv.URL
+	t.Setenv("GEMINI_API_KEY", "g-key")
+	t.Setenv(EnvModel, "gemini-2.5-pro")
+
+	res, err := geminiProvider{}.Generate(context.Background(), GenerateInput{Prompt: "users"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.MockServerJSON != `[{"a":1}]` || res.TokensUsed != 17 || res.Model != "gemini-2.5-pro" {
+		t.Errorf("result %+v", res)
+	}
+}
+
+func TestGeminiAvailableWithADC(t *testing.T) {
+	t.Setenv("GEMINI_API_KEY", "")
+	t.Setenv("GOOGLE_API_KEY", "")
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("APPDATA", os.Getenv("HOME"))
+	if (geminiProvider{}).Available() {
+		t.Fatal("available without a key or credentials")
+	}
+	path := filepath.Join(t.TempDir(), "adc.json")
+	os.WriteFile(path, []byte(`{"type":"authorized_user"}`), 0600)
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", path)
+	if !(geminiProvider{}).Available() {
+		t.Error("not available with GOOGLE_APPLICATION_CREDENTIALS")
+	}
+}
+
+func TestADCAccessTokenAuthorizedUser(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("grant_type") != "refresh_token" || r.Form.Get("refresh_token") != "rt" {
+			t.Errorf("form %v", r.Form)
+		}
+		w.Write([]byte(`{"access_token":"at","expires_in":3599}`))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "adc.json")
+	creds, _ := json.Marshal(adcCredentials{Type: "authorized_user", ClientID: "c", ClientSecret: "s",
+		RefreshToken: "rt", QuotaProjectID: "billing-project", TokenURI: srv.URL})
+	os.WriteFile(path, creds, 0600)
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", path)
+
+	token, project, err := adcAccessToken(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "at" || project != "billing-project" {
+		t.Errorf("got %q, %q", token, project)
+	}
+}
+
+func TestServiceAccountJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, _ := x509.MarshalPKCS8PrivateKey(key)
+	creds := adcCredentials{
+		Type:         "service_account",
+		ClientEmail:  "mocks@proj.iam.gserviceaccount.com",
+		PrivateKeyID: "kid-1",
+		PrivateKey:   string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+	}
+	now := time.Unix(1700000000, 0)
+	jwt, err := serviceAccountJWT(creds, defaultTokenURI, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("jwt %q", jwt)
+	}
+	sig, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	sum := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, sum[:], sig); err != nil {
+		t.Errorf("signature: %v", err)
+	}
+	raw, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	var claims map[string]any
+	json.Unmarshal(raw, &claims)
+	if claims["iss"] != creds.ClientEmail || claims["aud"] != defaultTokenURI || claims["exp"] != float64(now.Unix()+3600) {
+		t.Errorf("claims %v", claims)
+	}
+}
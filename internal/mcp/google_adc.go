@@ -0,0 +1,165 @@
+package mcp
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/network"
+)
+
+// adcScopes are requested for service account credentials; user credentials carry the scopes
+// granted at 'gcloud auth application-default login'
+const adcScopes = "https://www.googleapis.com/auth/cloud-platform https://www.googleapis.com/auth/generative-language"
+
+const defaultTokenURI = "https://oauth2.googleapis.com/token"
+
+// adcCredentials is the subset of an Application Default Credentials file AutoMock reads
+type adcCredentials struct {
+	Type           string `json:"type"` // authorized_user or service_account
+	ClientID       string `json:"client_id"`
+	ClientSecret   string `json:"client_secret"`
+	RefreshToken   string `json:"refresh_token"`
+	QuotaProjectID string `json:"quota_project_id"`
+	ClientEmail    string `json:"client_email"`
+	PrivateKeyID   string `json:"private_key_id"`
+	PrivateKey     string `json:"private_key"`
+	TokenURI       string `json:"token_uri"`
+}
+
+// adcFile is the Application Default Credentials file: GOOGLE_APPLICATION_CREDENTIALS, else the
+// one 'gcloud auth application-default login' writes; empty when neither exists
+func adcFile() string {
+	if path := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"); path != "" {
+		return path
+	}
+	var dir string
+	if runtime.GOOS == "windows" {
+		dir = filepath.Join(os.Getenv("APPDATA"), "gcloud")
+	} else if home, err := os.UserHomeDir(); err == nil {
+		dir = filepath.Join(home, ".config", "gcloud")
+	}
+	if dir == "" {
+		return ""
+	}
+	path := filepath.Join(dir, "application_default_credentials.json")
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// adcAccessToken exchanges the Application Default Credentials for an OAuth access token and
+// returns it with the project to bill (quota_project_id, else GOOGLE_CLOUD_PROJECT)
+func adcAccessToken(ctx context.Context) (token, quotaProject string, err error) {
+	path := adcFile()
+	if path == "" {
+		return "", "", ErrMissingKey("GEMINI_API_KEY (or Application Default Credentials)")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", fmt.Errorf("read application default credentials: %w", err)
+	}
+	var creds adcCredentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return "", "", fmt.Errorf("parse application default credentials %s: %w", path, err)
+	}
+	quotaProject = creds.QuotaProjectID
+	if quotaProject == "" {
+		quotaProject = os.Getenv("GOOGLE_CLOUD_PROJECT")
+	}
+	tokenURI := creds.TokenURI
+	if tokenURI == "" {
+		tokenURI = defaultTokenURI
+	}
+
+	form := url.Values{}
+	switch creds.Type {
+	case "authorized_user":
+		form.Set("grant_type", "refresh_token")
+		form.Set("client_id", creds.ClientID)
+		form.Set("client_secret", creds.ClientSecret)
+		form.Set("refresh_token", creds.RefreshToken)
+	case "service_account":
+		assertion, err := serviceAccountJWT(creds, tokenURI, time.Now())
+		if err != nil {
+			return "", "", err
+		}
+		form.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+		form.Set("assertion", assertion)
+	default:
+		return "", "", fmt.Errorf("application default credentials of type %q are not supported; use a user login or a service account key", creds.Type)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", tokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := network.HTTPClient().Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return "", "", fmt.Errorf("application default credentials token exchange: %s\n%s", resp.Status, string(body))
+	}
+	var out struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", "", err
+	}
+	return out.AccessToken, quotaProject, nil
+}
+
+// serviceAccountJWT signs the RS256 assertion a service account exchanges for an access token
+func serviceAccountJWT(creds adcCredentials, tokenURI string, now time.Time) (string, error) {
+	block, _ := pem.Decode([]byte(creds.PrivateKey))
+	if block == nil {
+		return "", fmt.Errorf("service account %s: private_key is not PEM", creds.ClientEmail)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		if parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes); err != nil {
+			return "", fmt.Errorf("service account %s: %w", creds.ClientEmail, err)
+		}
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return "", fmt.Errorf("service account %s: private_key is not an RSA key", creds.ClientEmail)
+	}
+
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": creds.PrivateKeyID})
+	claims, _ := json.Marshal(map[string]any{
+		"iss":   creds.ClientEmail,
+		"scope": adcScopes,
+		"aud":   tokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+	sum := sha256.Sum256([]byte(unsigned))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + enc.EncodeToString(sig), nil
+}
@@ -7,36 +7,102 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
+
+	"github.com/hemantobora/auto-mock/internal/network"
 )
 
-func doJSON(ctx context.Context, method, url string, headers map[string]string, payload any, out any) error {
-	b, err := json.Marshal(payload)
-	if err != nil {
-		return err
+// Retries of a rate limited (429) call, waiting retryBaseDelay, then twice as long each time
+// unless the provider says how long in Retry-After
+const (
+	maxRateLimitRetries = 4
+	retryBaseDelay      = 2 * time.Second
+	maxRetryDelay       = 60 * time.Second
+)
+
+// sleep waits d or until ctx is done; replaced in tests
+var sleep = func(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
 	}
-	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(b))
+}
+
+func doJSON(ctx context.Context, method, url string, headers map[string]string, payload any, out any) error {
+	resp, err := sendJSON(ctx, network.HTTPClient(), method, url, headers, payload)
 	if err != nil {
 		return err
 	}
-	for k, v := range headers {
-		req.Header.Set(k, v)
-	}
-	if req.Header.Get("Content-Type") == "" {
-		req.Header.Set("Content-Type", "application/json")
+	defer resp.Body.Close()
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
 	}
-	client := &http.Client{Timeout: 60 * time.Second}
-	resp, err := client.Do(req)
+	return nil
+}
+
+// sendJSON sends payload and returns the successful response, whose body the caller closes.
+// Rate limited calls are retried with backoff; any other error status fails with the body.
+func sendJSON(ctx context.Context, client *http.Client, method, url string, headers map[string]string, payload any) (*http.Response, error) {
+	return sendSignedJSON(ctx, client, method, url, headers, payload, nil)
+}
+
+// sendSignedJSON is sendJSON with sign applied to every attempt, for APIs that authenticate
+// by signing the request and its body (AWS SigV4)
+func sendSignedJSON(ctx context.Context, client *http.Client, method, url string, headers map[string]string, payload any, sign func(*http.Request, []byte) error) (*http.Response, error) {
+	b, err := json.Marshal(payload)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode >= 300 {
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		if req.Header.Get("Content-Type") == "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if sign != nil {
+			if err := sign(req, b); err != nil {
+				return nil, err
+			}
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode < 300 {
+			return resp, nil
+		}
 		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
-		return fmt.Errorf("%s %s: %s\n%s", method, url, resp.Status, string(body))
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusTooManyRequests || attempt == maxRateLimitRetries {
+			return nil, fmt.Errorf("%s %s: %s\n%s", method, url, resp.Status, string(body))
+		}
+		wait := retryDelay(attempt, resp.Header.Get("Retry-After"))
+		fmt.Printf("\n⏳ Rate limited by the provider, retrying in %s (%d/%d)", wait, attempt+1, maxRateLimitRetries)
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
 	}
-	if out != nil {
-		return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// retryDelay is the wait before retry attempt+1: Retry-After when the provider sent seconds,
+// otherwise exponential backoff, capped at maxRetryDelay
+func retryDelay(attempt int, retryAfter string) time.Duration {
+	d := retryBaseDelay << attempt
+	if s, err := strconv.Atoi(retryAfter); err == nil && s >= 0 {
+		d = time.Duration(s) * time.Second
 	}
-	return nil
+	if d > maxRetryDelay {
+		d = maxRetryDelay
+	}
+	return d
 }
@@ -2,9 +2,23 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"strings"
+
+	"github.com/hemantobora/auto-mock/internal/network"
 )
 
+// openaiChatURL is the chat completions endpoint; a variable so tests can point it elsewhere
+var openaiChatURL = "https://api.openai.com/v1/chat/completions"
+
+// openaiSystemPrompt asks for the JSON mode wrapper: JSON mode only allows an object at the top
+// level, while callers want arrays (expectations) or any value (response bodies)
+const openaiSystemPrompt = `You produce JSON for a mock server tool. Reply with a JSON object of the form {"result": <answer>}, where <answer> is exactly the JSON the user asks for (an array, an object or any other JSON value). No other keys.`
+
 type openaiProvider struct{}
 
 func (o openaiProvider) Name() string     { return "openai" }
@@ -18,49 +32,110 @@ func (o openaiProvider) Generate(ctx context.Context, in GenerateInput) (Result,
 		return Result{}, ErrMissingKey("OPENAI_API_KEY")
 	}
 
-	model := os.Getenv("OPENAI_MODEL")
-	if model == "" {
-		model = "gpt-5-mini"
-	}
+	model := modelFor("OPENAI_MODEL", "gpt-4o-mini")
 
 	payload := map[string]any{
-		"model":       model,
-		"temperature": 0,
-		"input":       buildOpenAIUserPrompt(in),
+		"model":           model,
+		"temperature":     0,
+		"response_format": map[string]string{"type": "json_object"},
+		"stream":          true,
+		"stream_options":  map[string]bool{"include_usage": true},
+		"messages": chatMessages(append(
+			[]Message{{Role: "system", Content: strings.TrimSpace(openaiSystemPrompt + "\n\n" + in.System)}},
+			turns(in.History, buildOpenAIUserPrompt(in))...)),
 	}
 
-	var raw struct {
-		Output []struct {
-			Content []struct {
-				Type string `json:"type"`
-				Text string `json:"text"`
-			} `json:"content"`
-		} `json:"output"`
-		Usage struct {
-			TotalTokens int `json:"total_tokens"`
-		} `json:"usage"`
-	}
-
-	err := doJSON(ctx, "POST", "https://api.openai.com/v1/responses",
+	// The stream is bounded by the context rather than the client timeout, so long generations
+	// are not cut off while tokens keep arriving; the transport still bounds the wait for headers
+	client := &http.Client{Transport: network.Transport()}
+	resp, err := sendJSON(ctx, client, "POST", openaiChatURL,
 		map[string]string{
 			"Authorization": "Bearer " + key,
-		}, payload, &raw)
+			"Accept":        "text/event-stream",
+		}, payload)
 	if err != nil {
 		return Result{}, err
 	}
+	defer resp.Body.Close()
 
-	text := ""
-	if len(raw.Output) > 0 && len(raw.Output[0].Content) > 0 {
-		text = raw.Output[0].Content[0].Text
+	text, tokens, err := readOpenAIStream(resp.Body, in.OnText)
+	if err != nil {
+		return Result{}, err
 	}
 
 	return Result{
 		Provider:       o.Name(),
-		MockServerJSON: trimFences(text),
-		TokensUsed:     raw.Usage.TotalTokens,
+		Model:          model,
+		MockServerJSON: unwrapJSONResult(trimFences(text)),
+		TokensUsed:     tokens,
 	}, nil
 }
 
+// openaiChunk is one server-sent event of a streamed chat completion
+type openaiChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+		FinishReason string `json:"finish_reason"`
+	} `json:"choices"`
+	Usage *struct {
+		TotalTokens int `json:"total_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// readOpenAIStream collects the streamed completion text and the total tokens reported in the
+// final usage chunk, passing each piece of text to onText as it arrives
+func readOpenAIStream(r io.Reader, onText func(string)) (string, int, error) {
+	var text strings.Builder
+	tokens := 0
+	err := readSSE(r, func(data string) (bool, error) {
+		if data == "[DONE]" {
+			return true, nil
+		}
+		var chunk openaiChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return false, err
+		}
+		if chunk.Error != nil {
+			return false, fmt.Errorf("%s", chunk.Error.Message)
+		}
+		for _, c := range chunk.Choices {
+			text.WriteString(c.Delta.Content)
+			if onText != nil && c.Delta.Content != "" {
+				onText(c.Delta.Content)
+			}
+			if c.FinishReason == "length" {
+				return false, fmt.Errorf("the response hit the model's output limit; describe fewer endpoints or pick a larger model with --model")
+			}
+		}
+		if chunk.Usage != nil {
+			tokens = chunk.Usage.TotalTokens
+		}
+		return false, nil
+	})
+	if err != nil {
+		return "", 0, fmt.Errorf("openai stream: %w", err)
+	}
+	return text.String(), tokens, nil
+}
+
+// unwrapJSONResult returns the value under "result" of a JSON mode reply, or s unchanged when
+// the model answered without the wrapper
+func unwrapJSONResult(s string) string {
+	var wrapper map[string]json.RawMessage
+	if json.Unmarshal([]byte(s), &wrapper) != nil || len(wrapper) != 1 {
+		return s
+	}
+	if inner, ok := wrapper["result"]; ok {
+		return strings.TrimSpace(string(inner))
+	}
+	return s
+}
+
 // buildOpenAIUserPrompt same idea.
 func buildOpenAIUserPrompt(in GenerateInput) string {
 	return in.Prompt
@@ -0,0 +1,129 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadOpenAIStream(t *testing.T) {
+	stream := strings.Join([]string{
+		`data: {"choices":[{"delta":{"role":"assistant","content":""}}]}`,
+		``,
+		`data: {"choices":[{"delta":{"content":"{\"result\": "}}]}`,
+		``,
+		`: keep-alive`,
+		`data: {"choices":[{"delta":{"content":"[1, 2]}"},"finish_reason":"stop"}]}`,
+		``,
+		`data: {"choices":[],"usage":{"prompt_tokens":30,"completion_tokens":12,"total_tokens":42}}`,
+		``,
+		`data: [DONE]`,
+		``,
+	}, "\n")
+
+	var deltas []string
+	text, tokens, err := readOpenAIStream(strings.NewReader(stream), func(d string) { deltas = append(deltas, d) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != `{"result": [1, 2]}` || tokens != 42 {
+		t.Errorf("got %q, %d tokens", text, tokens)
+	}
+	if len(deltas) != 2 || strings.Join(deltas, "") != text {
+		t.Errorf("deltas %q", deltas)
+	}
+}
+
+func TestReadOpenAIStreamErrors(t *testing.T) {
+	for name, stream := range map[string]string{
+		"error event": `data: {"error":{"message":"model overloaded"}}`,
+		"truncated":   `data: {"choices":[{"delta":{"content":"[{"},"finish_reason":"length"}]}`,
+		"bad chunk":   `data: {not json`,
+	} {
+		if _, _, err := readOpenAIStream(strings.NewReader(stream), nil); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestUnwrapJSONResult(t *testing.T) {
+	cases := map[string]string{
+		`{"result": [{"a": 1}]}`: `[{"a": 1}]`,
+		`{"result": "text"}`:     `"text"`,
+		`{"id": 1}`:              `{"id": 1}`, // answered without the wrapper
+		`{"result": 1, "x": 2}`:  `{"result": 1, "x": 2}`,
+		`[1]`:                    `[1]`,
+	}
+	for in, want := range cases {
+		if got := unwrapJSONResult(in); got != want {
+			t.Errorf("unwrapJSONResult(%s) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	if d := retryDelay(0, ""); d != retryBaseDelay {
+		t.Errorf("first retry waits %s", d)
+	}
+	if d := retryDelay(2, ""); d != 4*retryBaseDelay {
+		t.Errorf("third retry waits %s", d)
+	}
+	if d := retryDelay(0, "7"); d != 7*time.Second {
+		t.Errorf("Retry-After ignored: %s", d)
+	}
+	if d := retryDelay(10, "3600"); d != maxRetryDelay {
+		t.Errorf("delay not capped: %s", d)
+	}
+}
+
+func TestOpenAIGenerateRetriesRateLimit(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, `{"error":{"message":"rate limited"}}`, http.StatusTooManyRequests)
+			return
+		}
+		var payload struct {
+			Model          string            `json:"model"`
+			Stream         bool              `json:"stream"`
+			ResponseFormat map[string]string `json:"response_format"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Error(err)
+		}
+		if payload.Model != "gpt-4o" || !payload.Stream || payload.ResponseFormat["type"] != "json_object" {
+			t.Errorf("payload %+v", payload)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"{\\\"result\\\": []}\"}}]}\n\n")
+		fmt.Fprint(w, "data: {\"choices\":[],\"usage\":{\"total_tokens\":9}}\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer srv.Close()
+
+	oldURL, oldSleep := openaiChatURL, sleep
+	defer func() { openaiChatURL, sleep = oldURL, oldSleep }()
+	openaiChatURL = srv.URL
+	var waits []time.Duration
+	sleep = func(_ context.Context, d time.Duration) error { waits = append(waits, d); return nil }
+	t.Setenv("OPENAI_API_KEY", "test")
+	t.Setenv(EnvModel, "gpt-4o")
+
+	res, err := openaiProvider{}.Generate(context.Background(), GenerateInput{Prompt: "users"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 || len(waits) != 2 || waits[0] != time.Second {
+		t.Errorf("%d calls, waits %v", calls, waits)
+	}
+	if res.MockServerJSON != "[]" || res.TokensUsed != 9 || res.Model != "gpt-4o" {
+		t.Errorf("result %+v", res)
+	}
+}
@@ -3,7 +3,10 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"io"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -32,11 +35,23 @@ func ListProviders() []ProviderInfo {
 			Cost:      p.CostHint(),
 		})
 	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
 	return out
 }
 
 // Single public entry your CLI uses
 func GenerateWithProvider(ctx context.Context, prompt, providerName, projectName string) (Result, error) {
+	return generate(ctx, providerName, GenerateInput{ProjectName: projectName, Prompt: prompt}, nil)
+}
+
+// StreamWithProvider is GenerateWithProvider echoing the model's output to w token by token
+// while a streaming provider (anthropic, openai) generates; the others show the usual dots.
+// Cancelling ctx aborts the generation mid-stream and returns ctx's error.
+func StreamWithProvider(ctx context.Context, prompt, providerName, projectName string, w io.Writer) (Result, error) {
+	return generate(ctx, providerName, GenerateInput{ProjectName: projectName, Prompt: prompt}, w)
+}
+
+func generate(ctx context.Context, providerName string, in GenerateInput, echo io.Writer) (Result, error) {
 	regMu.RLock()
 	p, ok := providers[providerName]
 	regMu.RUnlock()
@@ -47,27 +62,51 @@ func GenerateWithProvider(ctx context.Context, prompt, providerName, projectName
 	start := time.Now()
 	fmt.Print("🤖 Generating with AI")
 	done := make(chan struct{})
+	var out sync.Mutex        // keeps the ticker from writing into echoed text
+	var received atomic.Int64 // characters streamed so far, 0 for providers that do not stream
 
-	// background ticker that prints dots every 2 s
+	// background ticker that prints dots every 2 s, or the streamed size while a provider streams
 	go func() {
-		ticker := time.NewTicker(2 * time.Second)
+		ticker := time.NewTicker(500 * time.Millisecond)
 		defer ticker.Stop()
-		for {
+		for tick := 1; ; tick++ {
 			select {
 			case <-done:
 				return
 			case <-ticker.C:
-				fmt.Print(".")
+				n := received.Load()
+				out.Lock()
+				switch {
+				case n > 0 && echo == nil:
+					fmt.Printf("\r🤖 Generating with AI... %d chars received", n)
+				case n == 0 && tick%4 == 0:
+					fmt.Print(".")
+				}
+				out.Unlock()
 			}
 		}
 	}()
 
-	res, err := p.Generate(ctx, GenerateInput{
-		ProjectName: projectName,
-		Prompt:      prompt,
-	})
+	onText := func(delta string) {
+		if echo != nil {
+			out.Lock()
+			if received.Load() == 0 {
+				fmt.Fprint(echo, "\n")
+			}
+			fmt.Fprint(echo, delta)
+			out.Unlock()
+		}
+		received.Add(int64(len(delta)))
+	}
+	in.OnText = onText
+	res, err := p.Generate(ctx, in)
 	close(done)
+	out.Lock() // wait out a tick in progress
+	defer out.Unlock()
 	if err != nil {
+		if ctx.Err() != nil {
+			return Result{}, ctx.Err()
+		}
 		return Result{}, err
 	}
 	if res.Provider == "" {
@@ -76,5 +115,21 @@ func GenerateWithProvider(ctx context.Context, prompt, providerName, projectName
 	if res.GenerationTime == "" {
 		res.GenerationTime = time.Since(start).Round(100 * time.Millisecond).String()
 	}
+	fmt.Printf("\n✅ Generated with %s in %s%s\n", describeModel(res), res.GenerationTime, describeTokens(res.TokensUsed))
 	return res, nil
 }
+
+// describeModel names the provider and, when known, the model, e.g. "openai (gpt-4o-mini)"
+func describeModel(res Result) string {
+	if res.Model == "" {
+		return res.Provider
+	}
+	return fmt.Sprintf("%s (%s)", res.Provider, res.Model)
+}
+
+func describeTokens(tokens int) string {
+	if tokens <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(", %d tokens", tokens)
+}
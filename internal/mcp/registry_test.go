@@ -0,0 +1,65 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+// streamingFake streams its pieces, stopping early when the context is cancelled
+type streamingFake struct {
+	pieces []string
+	cancel context.CancelFunc // called after the first piece when set
+}
+
+func (f streamingFake) Name() string     { return "fake" }
+func (f streamingFake) CostHint() string { return "" }
+func (f streamingFake) Available() bool  { return true }
+
+func (f streamingFake) Generate(ctx context.Context, in GenerateInput) (Result, error) {
+	for _, p := range f.pieces {
+		if err := ctx.Err(); err != nil {
+			return Result{}, errors.New("stream closed")
+		}
+		in.OnText(p)
+		if f.cancel != nil {
+			f.cancel()
+		}
+	}
+	return Result{MockServerJSON: "[]", TokensUsed: 3}, nil
+}
+
+func withFake(t *testing.T, p Provider) {
+	register(p)
+	t.Cleanup(func() {
+		regMu.Lock()
+		delete(providers, p.Name())
+		regMu.Unlock()
+	})
+}
+
+func TestStreamWithProviderEchoes(t *testing.T) {
+	withFake(t, streamingFake{pieces: []string{`[`, `{"a":1}`, `]`}})
+	var out bytes.Buffer
+	res, err := StreamWithProvider(context.Background(), "p", "fake", "proj", &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "\n[{\"a\":1}]" || res.Provider != "fake" {
+		t.Errorf("echoed %q, result %+v", out.String(), res)
+	}
+}
+
+func TestStreamWithProviderAbort(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	withFake(t, streamingFake{pieces: []string{`[`, `]`}, cancel: cancel})
+	var out bytes.Buffer
+	_, err := StreamWithProvider(ctx, "p", "fake", "proj", &out)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want context.Canceled", err)
+	}
+	if out.String() != "\n[" {
+		t.Errorf("echoed %q after abort", out.String())
+	}
+}
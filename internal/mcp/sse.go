@@ -0,0 +1,25 @@
+package mcp
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// readSSE calls fn with the data of each server-sent event until the stream ends, fn returns
+// done, or fn fails. Event names, comments and blank separators are skipped.
+func readSSE(r io.Reader, fn func(data string) (done bool, err error)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		done, err := fn(strings.TrimSpace(data))
+		if err != nil || done {
+			return err
+		}
+	}
+	return scanner.Err()
+}
@@ -7,6 +7,7 @@ import (
 // What your CLI expects back
 type Result struct {
 	Provider       string
+	Model          string
 	MockServerJSON string
 	TokensUsed     int
 	GenerationTime string // e.g., "3.2s"
@@ -28,9 +29,23 @@ type Provider interface {
 	Generate(ctx context.Context, in GenerateInput) (Result, error)
 }
 
+// Message is one turn of a conversation with a provider
+type Message struct {
+	Role    string // "user" or "assistant"
+	Content string
+}
+
 // Input shape passed to providers.
 type GenerateInput struct {
 	ProjectName string
 	Prompt      string   // already shaped by your caller (REST/GraphQL hints etc.)
 	StyleHints  []string // optional extra hints
+
+	// Multi-turn generation: instructions kept across turns, and the earlier turns (oldest
+	// first) that Prompt follows up on
+	System  string
+	History []Message
+
+	// OnText, when set, is called by streaming providers with each piece of text as it arrives
+	OnText func(delta string)
 }
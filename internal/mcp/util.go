@@ -1,6 +1,9 @@
 package mcp
 
-import "strings"
+import (
+	"os"
+	"strings"
+)
 
 // trimFences removes ```json / ``` wrappers if a model returns them.
 func trimFences(s string) string {
@@ -15,3 +18,17 @@ func trimFences(s string) string {
 	}
 	return strings.TrimSpace(s)
 }
+
+// EnvModel is set by the global --model flag and overrides the provider's own model variable
+const EnvModel = "AUTOMOCK_MODEL"
+
+// modelFor picks the model: --model, then the provider's variable (e.g. OPENAI_MODEL), then def
+func modelFor(providerEnv, def string) string {
+	if m := strings.TrimSpace(os.Getenv(EnvModel)); m != "" {
+		return m
+	}
+	if m := strings.TrimSpace(os.Getenv(providerEnv)); m != "" {
+		return m
+	}
+	return def
+}
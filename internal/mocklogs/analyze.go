@@ -0,0 +1,126 @@
+package mocklogs
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hemantobora/auto-mock/internal/matcher"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// placeholderBody is the response of a proposal no existing expectation could lend one to
+var placeholderBody = map[string]any{"type": "JSON", "json": map[string]any{"message": "TODO: response for an endpoint the mock did not answer"}}
+
+// Proposal is a new expectation for a cluster of unmatched requests
+type Proposal struct {
+	Expectation models.MockExpectation
+	Requests    int    // unmatched requests in the cluster
+	Borrowed    string // endpoint of the existing expectation whose response was reused ("" = placeholder)
+}
+
+// ProposeExpectations clusters the unmatched requests of a log by method, path (ID-like
+// segments ignored), query parameter names and body shape, as collection imports merge
+// requests, and proposes one expectation per cluster, most requested first. A cluster whose
+// path an existing expectation already serves (the request failed on a header, query or body
+// matcher) gets a copy of that expectation's response; the others a placeholder. source
+// describes where the log was read, e.g. the mock's URL.
+func ProposeExpectations(log []Exchange, exps []models.MockExpectation, source string) []Proposal {
+	runID := models.NewRunID(models.ProvenanceRecording)
+	var drafts []models.MockExpectation
+	lenders := map[*models.Provenance]string{} // a merged expectation keeps its first member's provenance
+	for i := range log {
+		e := &log[i]
+		if e.Response != nil || strings.HasPrefix(e.Request.Path, "/mockserver/") {
+			continue
+		}
+		req := &models.HttpRequest{Method: strings.ToUpper(e.Request.Method), Path: e.Request.Path}
+		names := make([]string, 0, len(e.Request.Query))
+		for name := range e.Request.Query {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			req.QueryStringParameters = append(req.QueryStringParameters, models.NameValues{Name: name, Values: e.Request.Query[name]})
+		}
+		if v, ok := requestJSON(&e.Request); ok {
+			req.Body = map[string]any{"type": "JSON", "json": v, "matchType": "ONLY_MATCHING_FIELDS"}
+		}
+
+		resp, lender := borrowResponse(exps, &e.Request)
+		provenance := models.NewProvenance(models.ProvenanceRecording, fmt.Sprintf("unmatched %s %s on %s", req.Method, req.Path, source), runID)
+		lenders[provenance] = lender
+		drafts = append(drafts, models.MockExpectation{HttpRequest: req, HttpResponse: resp, Provenance: provenance})
+	}
+
+	merged, groups := models.DeduplicateExpectations(drafts)
+	proposals := make([]Proposal, len(merged))
+	for i := range merged {
+		proposals[i] = Proposal{Expectation: merged[i], Requests: 1, Borrowed: lenders[merged[i].Provenance]}
+	}
+	for _, g := range groups {
+		proposals[g.Index].Requests = len(g.Paths)
+	}
+	sort.SliceStable(proposals, func(i, j int) bool { return proposals[i].Requests > proposals[j].Requests })
+	return proposals
+}
+
+// Describe summarizes the proposal on one line
+func (p *Proposal) Describe() string {
+	req := p.Expectation.HttpRequest
+	line := fmt.Sprintf("%s %s → %d ← %d request(s)", req.Method, req.Path, p.Expectation.HttpResponse.StatusCode, p.Requests)
+	var shape []string
+	for _, q := range req.QueryStringParameters {
+		shape = append(shape, "?"+q.Name)
+	}
+	if req.Body != nil {
+		shape = append(shape, "JSON body")
+	}
+	if len(shape) > 0 {
+		line += " [" + strings.Join(shape, " ") + "]"
+	}
+	if p.Borrowed != "" {
+		line += ", response of " + p.Borrowed
+	}
+	return line
+}
+
+// borrowResponse copies the response of the closest expectation when it serves the request's
+// method and path, and otherwise returns a placeholder with the method's usual status
+func borrowResponse(exps []models.MockExpectation, req *Message) (*models.HttpResponse, string) {
+	if len(exps) > 0 {
+		_, results := matcher.Match(exps, toMatcherRequest(req))
+		if top := matcher.TopCandidates(results, 1); len(top) == 1 && targetsEndpoint(top[0]) && top[0].Expectation.HttpResponse != nil {
+			resp := *top[0].Expectation.HttpResponse
+			return &resp, endpointLabel(top[0].Expectation)
+		}
+	}
+	switch strings.ToUpper(req.Method) {
+	case http.MethodPost:
+		return &models.HttpResponse{StatusCode: http.StatusCreated, Body: placeholderBody}, ""
+	case http.MethodDelete:
+		return &models.HttpResponse{StatusCode: http.StatusNoContent}, ""
+	}
+	return &models.HttpResponse{StatusCode: http.StatusOK, Body: placeholderBody}, ""
+}
+
+// requestJSON parses a request body that is JSON by content type (or, without one, by content)
+func requestJSON(m *Message) (any, bool) {
+	if strings.TrimSpace(m.Body) == "" {
+		return nil, false
+	}
+	if ct := m.Header("Content-Type"); ct != "" {
+		mt, _, _ := mime.ParseMediaType(ct)
+		if mt != "application/json" && !strings.HasSuffix(mt, "+json") {
+			return nil, false
+		}
+	}
+	var v any
+	if err := json.Unmarshal([]byte(m.Body), &v); err != nil {
+		return nil, false
+	}
+	return v, true
+}
@@ -0,0 +1,209 @@
+package mocklogs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// HAR is an HTTP Archive 1.2 document, limited to the fields devtools and Charles read
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Entries []HAREntry `json:"entries"`
+}
+
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type HAREntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+}
+
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type HARContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type HARTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// ToHAR converts recorded exchanges to a HAR log. baseURL supplies scheme and host for
+// requests that carry no Host header. The simulated response delay is reported as wait time.
+func ToHAR(exchanges []Exchange, baseURL, version string) *HAR {
+	har := &HAR{Log: HARLog{
+		Version: "1.2",
+		Creator: HARCreator{Name: "automock", Version: version},
+		Entries: make([]HAREntry, 0, len(exchanges)),
+	}}
+	base, _ := url.Parse(baseURL)
+	for _, ex := range exchanges {
+		started := ex.Timestamp
+		if started.IsZero() {
+			started = time.Now()
+		}
+		entry := HAREntry{
+			StartedDateTime: started.Format(time.RFC3339Nano),
+			Request:         harRequest(&ex.Request, base),
+			Response: HARResponse{
+				Status: 404, StatusText: "Not Found", HTTPVersion: "HTTP/1.1",
+				Cookies: []HARNameValue{}, Headers: []HARNameValue{},
+				Content: HARContent{MimeType: "text/plain"}, HeadersSize: -1, BodySize: 0,
+			},
+		}
+		if resp := ex.Response; resp != nil {
+			status := resp.StatusCode
+			if status == 0 {
+				status = http.StatusOK
+			}
+			reason := resp.Reason
+			if reason == "" {
+				reason = http.StatusText(status)
+			}
+			mime := resp.Header("Content-Type")
+			if mime == "" {
+				mime = guessMime(resp.Body)
+			}
+			entry.Response = HARResponse{
+				Status:      status,
+				StatusText:  reason,
+				HTTPVersion: "HTTP/1.1",
+				Cookies:     nameValues(singleValues(resp.Cookies)),
+				Headers:     nameValues(resp.Headers),
+				Content:     HARContent{Size: len(resp.Body), MimeType: mime, Text: resp.Body},
+				RedirectURL: resp.Header("Location"),
+				HeadersSize: -1,
+				BodySize:    len(resp.Body),
+			}
+			entry.Timings.Wait = float64(resp.DelayMs)
+			entry.Time = entry.Timings.Wait
+		}
+		har.Log.Entries = append(har.Log.Entries, entry)
+	}
+	return har
+}
+
+// Marshal renders the HAR as indented JSON
+func (h *HAR) Marshal() ([]byte, error) {
+	return json.MarshalIndent(h, "", "  ")
+}
+
+func harRequest(m *Message, base *url.URL) HARRequest {
+	u := url.URL{Scheme: "http", Path: m.Path}
+	if m.Secure {
+		u.Scheme = "https"
+	}
+	if host := m.Header("Host"); host != "" {
+		u.Host = host
+	} else if base != nil {
+		u.Scheme, u.Host = base.Scheme, base.Host
+	}
+	q := url.Values{}
+	for name, values := range m.Query {
+		q[name] = values
+	}
+	u.RawQuery = q.Encode()
+
+	req := HARRequest{
+		Method:      m.Method,
+		URL:         u.String(),
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     nameValues(singleValues(m.Cookies)),
+		Headers:     nameValues(m.Headers),
+		QueryString: nameValues(m.Query),
+		HeadersSize: -1,
+		BodySize:    len(m.Body),
+	}
+	if m.Body != "" {
+		mime := m.Header("Content-Type")
+		if mime == "" {
+			mime = guessMime(m.Body)
+		}
+		req.PostData = &HARPostData{MimeType: mime, Text: m.Body}
+	}
+	return req
+}
+
+// nameValues flattens multi-valued fields into HAR name/value pairs, sorted by name
+func nameValues(m map[string][]string) []HARNameValue {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	out := []HARNameValue{}
+	for _, name := range names {
+		for _, v := range m[name] {
+			out = append(out, HARNameValue{Name: name, Value: v})
+		}
+	}
+	return out
+}
+
+func singleValues(m map[string]string) map[string][]string {
+	out := make(map[string][]string, len(m))
+	for k, v := range m {
+		out[k] = []string{v}
+	}
+	return out
+}
+
+func guessMime(body string) string {
+	trimmed := strings.TrimSpace(body)
+	if json.Valid([]byte(trimmed)) && (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) {
+		return "application/json"
+	}
+	return "text/plain"
+}
@@ -0,0 +1,145 @@
+package mocklogs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hemantobora/auto-mock/internal/matcher"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// Log returns every request the mock received, oldest first; matched ones carry the response
+// they got. MockServer keeps matched requests and all requests in separate views, so requests
+// without a matched counterpart are the unmatched ones. All requests are read first: one
+// arriving between the two reads then only shows up in the next call instead of as unmatched.
+func (c *Client) Log(ctx context.Context) ([]Exchange, error) {
+	received, err := c.Requests(ctx)
+	if err != nil {
+		return nil, err
+	}
+	matched, err := c.RequestResponses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pending := map[string][]Exchange{}
+	for _, ex := range matched {
+		key := requestKey(&ex.Request)
+		pending[key] = append(pending[key], ex)
+	}
+	out := make([]Exchange, 0, len(received))
+	for _, req := range received {
+		key := requestKey(&req)
+		if queue := pending[key]; len(queue) > 0 {
+			out = append(out, queue[0])
+			pending[key] = queue[1:]
+			continue
+		}
+		out = append(out, Exchange{Request: req})
+	}
+	return out, nil
+}
+
+// Status is the status code the request got: 404 when no expectation matched, as MockServer
+// answers, and 200 when the expectation leaves it unset
+func (e *Exchange) Status() int {
+	switch {
+	case e.Response == nil:
+		return http.StatusNotFound
+	case e.Response.StatusCode == 0:
+		return http.StatusOK
+	}
+	return e.Response.StatusCode
+}
+
+// Filter selects log entries; empty fields match everything
+type Filter struct {
+	Path   string // exact path, or a pattern where * matches any characters
+	Method string
+	Status string // a code (404), a class (4xx) or "unmatched"
+}
+
+// Validate checks the status filter
+func (f Filter) Validate() error {
+	switch s := strings.ToLower(f.Status); {
+	case s == "", s == "unmatched":
+	case len(s) == 3 && s[1:] == "xx" && s[0] >= '1' && s[0] <= '5':
+	default:
+		if code, err := strconv.Atoi(s); err != nil || code < 100 || code > 599 {
+			return fmt.Errorf("--status must be a status code (404), a class (4xx) or unmatched, got %q", f.Status)
+		}
+	}
+	return nil
+}
+
+// Matches reports whether the entry passes every set filter
+func (f Filter) Matches(e *Exchange) bool {
+	if f.Method != "" && !strings.EqualFold(f.Method, e.Request.Method) {
+		return false
+	}
+	if f.Path != "" && !pathPattern(f.Path).MatchString(e.Request.Path) {
+		return false
+	}
+	switch s := strings.ToLower(f.Status); {
+	case s == "":
+	case s == "unmatched":
+		return e.Response == nil
+	case strings.HasSuffix(s, "xx"):
+		return strconv.Itoa(e.Status())[0] == s[0]
+	default:
+		return strconv.Itoa(e.Status()) == s
+	}
+	return true
+}
+
+func pathPattern(pattern string) *regexp.Regexp {
+	quoted := strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*")
+	return regexp.MustCompile("^" + quoted + "$")
+}
+
+// FormatEntry renders one log entry on a line; an unmatched one is followed by the expectation
+// that came closest to answering it and the matchers it failed (none without expectations)
+func FormatEntry(e *Exchange, exps []models.MockExpectation) string {
+	var b strings.Builder
+	at := strings.Repeat(" ", 12)
+	if !e.Timestamp.IsZero() {
+		at = e.Timestamp.Local().Format("15:04:05.000")
+	}
+	target := e.Request.Path
+	if q := url.Values(e.Request.Query).Encode(); q != "" {
+		target += "?" + q
+	}
+	fmt.Fprintf(&b, "%s  %-7s %s → %d", at, e.Request.Method, target, e.Status())
+	if e.Response == nil {
+		b.WriteString(" unmatched\n")
+		if len(exps) > 0 {
+			req := toMatcherRequest(&e.Request)
+			_, results := matcher.Match(exps, req)
+			if top := matcher.TopCandidates(results, 1); len(top) == 1 {
+				fmt.Fprintf(&b, "%s    closest: #%d %s\n", strings.Repeat(" ", 12), top[0].Index+1, endpointLabel(top[0].Expectation))
+				for _, c := range top[0].Failed() {
+					fmt.Fprintf(&b, "%s      ✗ %s expected %q, got %q", strings.Repeat(" ", 12), c.Matcher, c.Expected, c.Actual)
+					if c.Reason != "" {
+						fmt.Fprintf(&b, " — %s", c.Reason)
+					}
+					b.WriteString("\n")
+				}
+			}
+		}
+		return b.String()
+	}
+	if e.Response.DelayMs > 0 {
+		fmt.Fprintf(&b, " (%dms delay)", e.Response.DelayMs)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// requestKey identifies a request across MockServer's log views
+func requestKey(m *Message) string {
+	return strings.ToUpper(m.Method) + " " + m.Path + "?" + url.Values(m.Query).Encode() + "\n" + m.Body
+}
@@ -0,0 +1,285 @@
+// Package mocklogs reads the traffic a running MockServer recorded through its admin API.
+package mocklogs
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Exchange is one request the mock received and the response it sent
+type Exchange struct {
+	Request   Message
+	Response  *Message // nil for requests no expectation answered
+	Timestamp time.Time
+}
+
+// Message is a request or response as MockServer logs it
+type Message struct {
+	Method     string
+	Path       string
+	Query      map[string][]string
+	Headers    map[string][]string
+	Cookies    map[string]string
+	Body       string
+	Secure     bool
+	StatusCode int
+	Reason     string
+	DelayMs    int
+}
+
+// Header returns the first value of a header, case-insensitively
+func (m *Message) Header(name string) string {
+	for k, v := range m.Headers {
+		if strings.EqualFold(k, name) && len(v) > 0 {
+			return v[0]
+		}
+	}
+	return ""
+}
+
+// Client talks to the MockServer admin API of a deployed or local mock
+type Client struct {
+	BaseURL string
+	HTTP    *http.Client
+}
+
+// NewClient creates a client for the mock at baseURL
+func NewClient(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimRight(baseURL, "/"), HTTP: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// RequestResponses returns the matched requests with the responses they received, oldest first
+func (c *Client) RequestResponses(ctx context.Context) ([]Exchange, error) {
+	var raw []struct {
+		HttpRequest  rawMessage  `json:"httpRequest"`
+		HttpResponse *rawMessage `json:"httpResponse"`
+		Timestamp    string      `json:"timestamp"`
+	}
+	if err := c.retrieve(ctx, "REQUEST_RESPONSES", &raw); err != nil {
+		return nil, err
+	}
+	out := make([]Exchange, 0, len(raw))
+	for _, r := range raw {
+		ex := Exchange{Request: r.HttpRequest.message(), Timestamp: parseTimestamp(r.Timestamp)}
+		if r.HttpResponse != nil {
+			resp := r.HttpResponse.message()
+			ex.Response = &resp
+		}
+		out = append(out, ex)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Timestamp.Before(out[j].Timestamp) })
+	return out, nil
+}
+
+// Requests returns every request the mock received, matched or not, oldest first
+func (c *Client) Requests(ctx context.Context) ([]Message, error) {
+	var raw []rawMessage
+	if err := c.retrieve(ctx, "REQUESTS", &raw); err != nil {
+		return nil, err
+	}
+	out := make([]Message, len(raw))
+	for i, r := range raw {
+		out[i] = r.message()
+	}
+	return out, nil
+}
+
+// Status checks that the mock is up through MockServer's status endpoint and returns the
+// round-trip time of the check
+func (c *Client) Status(ctx context.Context) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.BaseURL+"/mockserver/status", nil)
+	if err != nil {
+		return 0, err
+	}
+	start := time.Now()
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reach mock admin API at %s: %w", c.BaseURL, err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	elapsed := time.Since(start)
+	if resp.StatusCode != http.StatusOK {
+		return elapsed, fmt.Errorf("mock status returned %s", resp.Status)
+	}
+	return elapsed, nil
+}
+
+// Percentile returns the nearest-rank p-th percentile (0-100) of samples; zero without samples
+func Percentile(samples []time.Duration, p float64) time.Duration {
+	if len(samples) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[min(max(rank, 1), len(sorted))-1]
+}
+
+func (c *Client) retrieve(ctx context.Context, kind string, v any) error {
+	url := fmt.Sprintf("%s/mockserver/retrieve?type=%s&format=JSON", c.BaseURL, kind)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader([]byte("{}")))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach mock admin API at %s: %w", c.BaseURL, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read mock logs: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("mock admin API returned %s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("unexpected mock log format: %w", err)
+	}
+	return nil
+}
+
+// rawMessage mirrors MockServer's JSON; multi-valued fields come either as an object of
+// name -> values or as a [{name, values}] list depending on the MockServer version
+type rawMessage struct {
+	Method                string          `json:"method"`
+	Path                  string          `json:"path"`
+	QueryStringParameters json.RawMessage `json:"queryStringParameters"`
+	Headers               json.RawMessage `json:"headers"`
+	Cookies               json.RawMessage `json:"cookies"`
+	Body                  json.RawMessage `json:"body"`
+	Secure                bool            `json:"secure"`
+	StatusCode            int             `json:"statusCode"`
+	ReasonPhrase          string          `json:"reasonPhrase"`
+	Delay                 *struct {
+		TimeUnit string `json:"timeUnit"`
+		Value    int    `json:"value"`
+	} `json:"delay"`
+}
+
+func (r rawMessage) message() Message {
+	m := Message{
+		Method:     r.Method,
+		Path:       r.Path,
+		Query:      multiValues(r.QueryStringParameters),
+		Headers:    multiValues(r.Headers),
+		Cookies:    map[string]string{},
+		Body:       bodyText(r.Body),
+		Secure:     r.Secure,
+		StatusCode: r.StatusCode,
+		Reason:     r.ReasonPhrase,
+	}
+	for name, values := range multiValues(r.Cookies) {
+		if len(values) > 0 {
+			m.Cookies[name] = values[0]
+		}
+	}
+	if r.Delay != nil {
+		m.DelayMs = r.Delay.Value
+		switch strings.ToUpper(r.Delay.TimeUnit) {
+		case "SECONDS":
+			m.DelayMs *= 1000
+		case "MINUTES":
+			m.DelayMs *= 60000
+		}
+	}
+	return m
+}
+
+func multiValues(raw json.RawMessage) map[string][]string {
+	out := map[string][]string{}
+	if len(raw) == 0 {
+		return out
+	}
+	var obj map[string]any
+	if json.Unmarshal(raw, &obj) == nil {
+		for name, v := range obj {
+			out[name] = stringValues(v)
+		}
+		return out
+	}
+	var list []struct {
+		Name   string `json:"name"`
+		Value  any    `json:"value"`
+		Values any    `json:"values"`
+	}
+	if json.Unmarshal(raw, &list) == nil {
+		for _, nv := range list {
+			if nv.Values != nil {
+				out[nv.Name] = append(out[nv.Name], stringValues(nv.Values)...)
+			} else {
+				out[nv.Name] = append(out[nv.Name], stringValues(nv.Value)...)
+			}
+		}
+	}
+	return out
+}
+
+func stringValues(v any) []string {
+	switch t := v.(type) {
+	case []any:
+		out := make([]string, 0, len(t))
+		for _, one := range t {
+			out = append(out, fmt.Sprint(one))
+		}
+		return out
+	case nil:
+		return nil
+	default:
+		return []string{fmt.Sprint(t)}
+	}
+}
+
+// bodyText flattens MockServer body forms: a plain string, a JSON value, or a typed
+// wrapper such as {"type": "JSON", "json": ...}, {"type": "STRING", "string": ...} or rawBytes
+func bodyText(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var wrapped map[string]json.RawMessage
+	if json.Unmarshal(raw, &wrapped) == nil {
+		if _, typed := wrapped["type"]; typed {
+			for _, key := range []string{"json", "string", "xml"} {
+				if v, ok := wrapped[key]; ok {
+					if json.Unmarshal(v, &s) == nil {
+						return s
+					}
+					return string(v)
+				}
+			}
+			if v, ok := wrapped["rawBytes"]; ok && json.Unmarshal(v, &s) == nil {
+				if decoded, err := base64.StdEncoding.DecodeString(s); err == nil {
+					return string(decoded)
+				}
+			}
+		}
+	}
+	return string(raw)
+}
+
+func parseTimestamp(s string) time.Time {
+	for _, layout := range []string{"2006-01-02 15:04:05.000", time.RFC3339Nano, "2006-01-02T15:04:05.000"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,240 @@
+package mocklogs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+const retrieved = `[
+  {
+    "httpRequest": {
+      "method": "POST",
+      "path": "/orders",
+      "queryStringParameters": {"dry": ["true"]},
+      "headers": {"Host": ["mock.example.com"], "Content-Type": ["application/json"]},
+      "body": {"type": "JSON", "json": "{\"qty\":2}", "rawBytes": "eyJxdHkiOjJ9"}
+    },
+    "httpResponse": {
+      "statusCode": 201,
+      "headers": [{"name": "Content-Type", "values": ["application/json"]}],
+      "body": "{\"id\":7}",
+      "delay": {"timeUnit": "MILLISECONDS", "value": 120}
+    },
+    "timestamp": "2025-03-01 10:00:00.250"
+  }
+]`
+
+func TestRequestResponsesToHAR(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/mockserver/retrieve" || r.URL.Query().Get("type") != "REQUEST_RESPONSES" {
+			http.Error(w, "unexpected "+r.Method+" "+r.URL.String(), http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(retrieved))
+	}))
+	defer srv.Close()
+
+	exchanges, err := NewClient(srv.URL).RequestResponses(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	har := ToHAR(exchanges, srv.URL, "test")
+	if len(har.Log.Entries) != 1 {
+		t.Fatalf("entries = %d", len(har.Log.Entries))
+	}
+	e := har.Log.Entries[0]
+	if e.Request.URL != "http://mock.example.com/orders?dry=true" {
+		t.Errorf("url = %s", e.Request.URL)
+	}
+	if e.Request.PostData == nil || e.Request.PostData.Text != `{"qty":2}` || e.Request.PostData.MimeType != "application/json" {
+		t.Errorf("postData = %+v", e.Request.PostData)
+	}
+	if e.Response.Status != 201 || e.Response.StatusText != "Created" || e.Response.Content.Text != `{"id":7}` {
+		t.Errorf("response = %+v", e.Response)
+	}
+	if e.Time != 120 || e.StartedDateTime[:19] != "2025-03-01T10:00:00" {
+		t.Errorf("time = %v, started = %s", e.Time, e.StartedDateTime)
+	}
+}
+
+func TestSummarize_HitsNearMissesAndUnmatched(t *testing.T) {
+	exps := []models.MockExpectation{
+		{
+			HttpRequest:  &models.HttpRequest{Method: "GET", Path: "/users/{id}"},
+			HttpResponse: &models.HttpResponse{StatusCode: 200, Delay: &models.Delay{TimeUnit: "MILLISECONDS", Value: 100}},
+		},
+		{
+			HttpRequest: &models.HttpRequest{Method: "POST", Path: "/orders",
+				Headers: []models.NameValues{{Name: "Authorization", Values: []string{"Bearer .*"}}}},
+			HttpResponse: &models.HttpResponse{StatusCode: 201},
+		},
+		{HttpRequest: &models.HttpRequest{Method: "GET", Path: "/unused"}, HttpResponse: &models.HttpResponse{StatusCode: 200}},
+	}
+	received := []Message{
+		{Method: "GET", Path: "/users/1"},
+		{Method: "GET", Path: "/users/2"},
+		{Method: "POST", Path: "/orders", Headers: map[string][]string{"Authorization": {"Bearer x"}}},
+		{Method: "POST", Path: "/orders"},
+		{Method: "DELETE", Path: "/nowhere"},
+	}
+
+	traffic := Summarize(exps, received, 1)
+	if traffic.Total != 5 || traffic.Matched != 3 {
+		t.Fatalf("total/matched = %d/%d", traffic.Total, traffic.Matched)
+	}
+	users, orders, unused := traffic.Endpoints[0], traffic.Endpoints[1], traffic.Endpoints[2]
+	if users.Hits != 2 || users.AvgDelayMs() != 100 || users.MatchRate() != 1 {
+		t.Errorf("users = %+v", users)
+	}
+	if orders.Hits != 1 || orders.NearMisses != 1 || orders.MatchRate() != 0.5 {
+		t.Errorf("orders = %+v", orders)
+	}
+	if unused.Hits != 0 || unused.Endpoint != "GET /unused" {
+		t.Errorf("unused = %+v", unused)
+	}
+	if len(traffic.Unmatched) != 1 || traffic.Unmatched[0].Path != "/nowhere" {
+		t.Errorf("unmatched = %+v", traffic.Unmatched)
+	}
+	if out := traffic.Format(); !strings.Contains(out, "POST /orders") || !strings.Contains(out, "DELETE /nowhere") {
+		t.Errorf("format:\n%s", out)
+	}
+}
+
+func TestStatusAndPercentile(t *testing.T) {
+	up := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/mockserver/status" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		if !up {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"ports":[1080]}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	if rtt, err := client.Status(context.Background()); err != nil || rtt <= 0 {
+		t.Errorf("status: %v, %v", rtt, err)
+	}
+	up = false
+	if _, err := client.Status(context.Background()); err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("down mock reported %v", err)
+	}
+
+	samples := []time.Duration{5, 1, 4, 2, 3, 10, 9, 8, 7, 6}
+	for p, want := range map[float64]time.Duration{50: 5, 95: 10, 99: 10, 0: 1} {
+		if got := Percentile(samples, p); got != want {
+			t.Errorf("p%.0f = %d, want %d", p, got, want)
+		}
+	}
+	if Percentile(nil, 50) != 0 {
+		t.Error("percentile of no samples")
+	}
+}
+
+func TestLogFilterAndFormat(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("type") == "REQUEST_RESPONSES" {
+			w.Write([]byte(`[{"httpRequest":{"method":"GET","path":"/users"},"httpResponse":{"statusCode":200},"timestamp":"2025-03-01 10:00:00.250"},
+				{"httpRequest":{"method":"GET","path":"/users"},"httpResponse":{"statusCode":500},"timestamp":"2025-03-01 10:00:01.000"}]`))
+			return
+		}
+		w.Write([]byte(`[{"method":"GET","path":"/users"},{"method":"GET","path":"/users/7"},{"method":"GET","path":"/users"}]`))
+	}))
+	defer srv.Close()
+
+	log, err := NewClient(srv.URL).Log(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(log) != 3 || log[0].Status() != 200 || log[1].Response != nil || log[1].Status() != 404 || log[2].Status() != 500 {
+		t.Fatalf("log %+v", log)
+	}
+
+	count := func(f Filter) int {
+		n := 0
+		for i := range log {
+			if f.Matches(&log[i]) {
+				n++
+			}
+		}
+		return n
+	}
+	for f, want := range map[Filter]int{
+		{}:                              3,
+		{Status: "unmatched"}:           1,
+		{Status: "5xx"}:                 1,
+		{Status: "404"}:                 1,
+		{Path: "/users/*"}:              1,
+		{Path: "/users", Method: "get"}: 2,
+		{Method: "POST"}:                0,
+	} {
+		if got := count(f); got != want {
+			t.Errorf("%+v matched %d, want %d", f, got, want)
+		}
+	}
+	for _, status := range []string{"5x", "600", "ok"} {
+		if (Filter{Status: status}).Validate() == nil {
+			t.Errorf("status filter %q accepted", status)
+		}
+	}
+
+	exps := []models.MockExpectation{{
+		HttpRequest:  &models.HttpRequest{Method: "GET", Path: "/users/me"},
+		HttpResponse: &models.HttpResponse{StatusCode: 200},
+	}}
+	out := FormatEntry(&log[1], exps)
+	if !strings.Contains(out, "/users/7 → 404 unmatched") || !strings.Contains(out, "closest: #1 GET /users/me") || !strings.Contains(out, `✗ path expected "/users/me", got "/users/7"`) {
+		t.Errorf("unmatched entry:\n%s", out)
+	}
+}
+
+func TestProposeExpectations(t *testing.T) {
+	log := []Exchange{
+		{Request: Message{Method: "GET", Path: "/users/7"}},
+		{Request: Message{Method: "GET", Path: "/users/9"}},
+		{Request: Message{Method: "GET", Path: "/users"}, Response: &Message{StatusCode: 200}},
+		{Request: Message{Method: "POST", Path: "/orders", Headers: map[string][]string{"Content-Type": {"application/json"}}, Body: `{"qty":2}`}},
+		{Request: Message{Method: "DELETE", Path: "/users/7"}},
+		{Request: Message{Method: "GET", Path: "/mockserver/status"}},
+	}
+	exps := []models.MockExpectation{{
+		HttpRequest:  &models.HttpRequest{Method: "POST", Path: "/orders", Headers: []models.NameValues{{Name: "X-Api-Key", Values: []string{"secret"}}}},
+		HttpResponse: &models.HttpResponse{StatusCode: 202, Body: map[string]any{"type": "JSON", "json": map[string]any{"id": 1}}},
+	}}
+
+	proposals := ProposeExpectations(log, exps, "http://mock")
+	if len(proposals) != 3 {
+		t.Fatalf("got %d proposals, want 3: %+v", len(proposals), proposals)
+	}
+	users := proposals[0]
+	if users.Requests != 2 || users.Expectation.HttpRequest.Path != "/users/[^/]+" || users.Expectation.HttpResponse.StatusCode != 200 || users.Borrowed != "" {
+		t.Errorf("GET /users/{id} cluster: %+v", users)
+	}
+	if p := users.Expectation.Provenance; p == nil || p.Source != models.ProvenanceRecording || !strings.Contains(p.Detail, "http://mock") {
+		t.Errorf("provenance %+v", p)
+	}
+
+	byMethod := map[string]Proposal{}
+	for _, p := range proposals[1:] {
+		byMethod[p.Expectation.HttpRequest.Method] = p
+	}
+	orders := byMethod["POST"]
+	if orders.Borrowed != "POST /orders" || orders.Expectation.HttpResponse.StatusCode != 202 || orders.Expectation.HttpRequest.Body == nil {
+		t.Errorf("POST /orders should borrow the existing response and match the body: %+v", orders)
+	}
+	if !strings.Contains(orders.Describe(), "JSON body") || !strings.Contains(orders.Describe(), "response of POST /orders") {
+		t.Errorf("describe: %s", orders.Describe())
+	}
+	if del := byMethod["DELETE"]; del.Expectation.HttpResponse.StatusCode != 204 || del.Expectation.HttpResponse.Body != nil {
+		t.Errorf("DELETE placeholder: %+v", del.Expectation.HttpResponse)
+	}
+}
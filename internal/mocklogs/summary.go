@@ -0,0 +1,173 @@
+package mocklogs
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hemantobora/auto-mock/internal/matcher"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// EndpointStats aggregates the traffic one endpoint (method + path) received
+type EndpointStats struct {
+	Endpoint   string
+	Hits       int // requests answered by the endpoint
+	NearMisses int // requests for the endpoint's method and path that failed another matcher
+	DelayMs    int // total simulated latency of the hits
+}
+
+// MatchRate is the share of requests aimed at the endpoint that it answered
+func (s EndpointStats) MatchRate() float64 {
+	if s.Hits+s.NearMisses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.NearMisses)
+}
+
+// AvgDelayMs is the average simulated latency of the hits
+func (s EndpointStats) AvgDelayMs() float64 {
+	if s.Hits == 0 {
+		return 0
+	}
+	return float64(s.DelayMs) / float64(s.Hits)
+}
+
+// Traffic summarizes received requests against the expectations the mock serves
+type Traffic struct {
+	Total     int
+	Matched   int
+	Endpoints []EndpointStats // declaration order, unused endpoints included
+	Unmatched []Message       // most recent first
+}
+
+// Summarize replays received requests through the local match engine, attributing each one
+// to the endpoint that answered it (or, for unmatched requests, to the endpoint whose method
+// and path it targeted). Up to recent unmatched requests are kept.
+func Summarize(exps []models.MockExpectation, received []Message, recent int) *Traffic {
+	t := &Traffic{Total: len(received)}
+	index := map[string]int{}
+	endpointOf := make([]int, len(exps))
+	for i := range exps {
+		label := endpointLabel(&exps[i])
+		pos, ok := index[label]
+		if !ok {
+			pos = len(t.Endpoints)
+			index[label] = pos
+			t.Endpoints = append(t.Endpoints, EndpointStats{Endpoint: label})
+		}
+		endpointOf[i] = pos
+	}
+
+	var unmatched []Message
+	for _, msg := range received {
+		req := toMatcherRequest(&msg)
+		best, results := matcher.Match(exps, req)
+		if best >= 0 {
+			t.Matched++
+			stats := &t.Endpoints[endpointOf[best]]
+			stats.Hits++
+			stats.DelayMs += delayMs(exps[best].HttpResponse)
+			continue
+		}
+		unmatched = append(unmatched, msg)
+		if top := matcher.TopCandidates(results, 1); len(top) == 1 && targetsEndpoint(top[0]) {
+			t.Endpoints[endpointOf[top[0].Index]].NearMisses++
+		}
+	}
+	for i := len(unmatched) - 1; i >= 0 && len(t.Unmatched) < recent; i-- {
+		t.Unmatched = append(t.Unmatched, unmatched[i])
+	}
+	return t
+}
+
+// Format renders the per-endpoint table and the recent unmatched requests
+func (t *Traffic) Format() string {
+	var b strings.Builder
+	rate := 0.0
+	if t.Total > 0 {
+		rate = float64(t.Matched) / float64(t.Total) * 100
+	}
+	fmt.Fprintf(&b, "📈 Traffic: %d request(s), %d matched (%.0f%%)\n", t.Total, t.Matched, rate)
+	if len(t.Endpoints) > 0 {
+		width := len("ENDPOINT")
+		for _, e := range t.Endpoints {
+			width = max(width, len(e.Endpoint))
+		}
+		fmt.Fprintf(&b, "   %-*s  %6s  %10s  %9s\n", width, "ENDPOINT", "HITS", "MATCH RATE", "AVG DELAY")
+		for _, e := range t.Endpoints {
+			matchRate, delay := "-", "-"
+			if e.Hits+e.NearMisses > 0 {
+				matchRate = fmt.Sprintf("%.0f%%", e.MatchRate()*100)
+			}
+			if e.Hits > 0 {
+				delay = fmt.Sprintf("%.0fms", e.AvgDelayMs())
+			}
+			fmt.Fprintf(&b, "   %-*s  %6d  %10s  %9s\n", width, e.Endpoint, e.Hits, matchRate, delay)
+		}
+	}
+	if len(t.Unmatched) > 0 {
+		b.WriteString("\n❓ Most recent unmatched requests:\n")
+		for _, m := range t.Unmatched {
+			target := m.Path
+			if q := url.Values(m.Query).Encode(); q != "" {
+				target += "?" + q
+			}
+			fmt.Fprintf(&b, "   • %s %s\n", m.Method, target)
+		}
+		b.WriteString("💡 Trace one with: automock match --project <name> --method <M> --path <path>\n")
+	}
+	return b.String()
+}
+
+func endpointLabel(exp *models.MockExpectation) string {
+	if exp.HttpRequest == nil {
+		return "(any request)"
+	}
+	method := exp.HttpRequest.Method
+	if method == "" {
+		method = "*"
+	}
+	path := exp.HttpRequest.Path
+	if path == "" {
+		path = "*"
+	}
+	return strings.ToUpper(method) + " " + path
+}
+
+// targetsEndpoint reports whether a failed result still passed its method and path matchers
+func targetsEndpoint(r matcher.Result) bool {
+	for _, c := range r.Failed() {
+		if c.Matcher == "method" || c.Matcher == "path" {
+			return false
+		}
+	}
+	return true
+}
+
+func toMatcherRequest(m *Message) *matcher.Request {
+	req := &matcher.Request{Method: m.Method, Path: m.Path, Query: url.Values{}, Headers: http.Header{}, Body: []byte(m.Body)}
+	for name, values := range m.Query {
+		req.Query[name] = values
+	}
+	for name, values := range m.Headers {
+		for _, v := range values {
+			req.Headers.Add(name, v)
+		}
+	}
+	return req
+}
+
+func delayMs(resp *models.HttpResponse) int {
+	if resp == nil || resp.Delay == nil {
+		return 0
+	}
+	switch strings.ToUpper(resp.Delay.TimeUnit) {
+	case "SECONDS":
+		return resp.Delay.Value * 1000
+	case "MINUTES":
+		return resp.Delay.Value * 60000
+	}
+	return resp.Delay.Value
+}
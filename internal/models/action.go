@@ -17,4 +17,14 @@ const (
 	ActionLocal    ActionType = "local"
 	ActionSave     ActionType = "save"
 	ActionDeploy   ActionType = "deploy"
+	ActionSchemas  ActionType = "schemas"
+	ActionExamples ActionType = "examples"
+	ActionTenants  ActionType = "tenants"
+	ActionErrors   ActionType = "errors"
+	ActionMasking  ActionType = "masking"
+	ActionFakeData ActionType = "generators"
+	ActionDiff     ActionType = "diff"
+
+	ActionEnvironments ActionType = "environments"
+	ActionDatasets     ActionType = "datasets"
 )
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"strings"
+	"unicode/utf8"
+)
+
+// BinaryBody wraps raw bytes as a MockServer BINARY body; contentType is optional and, when
+// set, is what MockServer sends as the Content-Type of the response
+func BinaryBody(data []byte, contentType string) map[string]any {
+	body := map[string]any{"type": "BINARY", "base64Bytes": base64.StdEncoding.EncodeToString(data)}
+	if contentType != "" {
+		body["contentType"] = contentType
+	}
+	return body
+}
+
+// BinaryBodyBytes decodes a BINARY body and returns its content type ("" when unset)
+func BinaryBodyBytes(body any) ([]byte, string, bool) {
+	b, ok := body.(map[string]any)
+	if !ok || !strings.EqualFold(fmt.Sprint(b["type"]), "BINARY") {
+		return nil, "", false
+	}
+	encoded, _ := b["base64Bytes"].(string)
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, "", false
+	}
+	contentType, _ := b["contentType"].(string)
+	return data, contentType, true
+}
+
+// IsBinaryContentType reports whether a media type carries bytes rather than text: images,
+// audio, video, fonts, PDFs, archives and application/octet-stream. SVG is text.
+func IsBinaryContentType(contentType string) bool {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mt = strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	}
+	switch {
+	case mt == "image/svg+xml":
+		return false
+	case strings.HasPrefix(mt, "image/"), strings.HasPrefix(mt, "audio/"), strings.HasPrefix(mt, "video/"), strings.HasPrefix(mt, "font/"):
+		return true
+	}
+	switch mt {
+	case "application/octet-stream", "application/pdf", "application/zip", "application/gzip", "application/x-tar",
+		"application/x-7z-compressed", "application/vnd.rar", "application/wasm", "application/x-protobuf", "application/grpc",
+		"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "application/msword", "application/vnd.ms-excel":
+		return true
+	}
+	return false
+}
+
+// BodyFromBytes stores a captured non-JSON body so it survives MockServer JSON: binary media
+// types and bytes that are not UTF-8 become base64 BINARY bodies, anything else stays text
+func BodyFromBytes(contentType string, data []byte) any {
+	if len(data) == 0 {
+		return nil
+	}
+	if IsBinaryContentType(contentType) || !utf8.Valid(data) {
+		return BinaryBody(data, contentType)
+	}
+	return string(data)
+}
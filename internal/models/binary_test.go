@@ -0,0 +1,39 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBodyFromBytes(t *testing.T) {
+	png := []byte{0x89, 'P', 'N', 'G'}
+	if got := BodyFromBytes("image/png", png); !reflect.DeepEqual(got, map[string]any{"type": "BINARY", "base64Bytes": "iVBORw==", "contentType": "image/png"}) {
+		t.Errorf("image body = %v", got)
+	}
+	if _, _, ok := BinaryBodyBytes(BodyFromBytes("", []byte{0xff, 0xfe})); !ok {
+		t.Error("bytes that are not UTF-8 must be stored as BINARY")
+	}
+	if got := BodyFromBytes("image/svg+xml; charset=utf-8", []byte("<svg/>")); got != "<svg/>" {
+		t.Errorf("SVG body = %v", got)
+	}
+	if got := BodyFromBytes("text/csv", nil); got != nil {
+		t.Errorf("empty body = %v", got)
+	}
+
+	data, contentType, ok := BinaryBodyBytes(BinaryBody(png, ""))
+	if !ok || !reflect.DeepEqual(data, png) || contentType != "" {
+		t.Errorf("round trip = %v %q %v", data, contentType, ok)
+	}
+}
+
+func TestValidateBinaryBody(t *testing.T) {
+	exps := []MockExpectation{
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/logo"}, HttpResponse: &HttpResponse{StatusCode: 200, Body: BinaryBody([]byte("x"), "image/png")}},
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/raw"}, HttpResponse: &HttpResponse{StatusCode: 200, Body: BinaryBody([]byte("x"), "")}},
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/bad"}, HttpResponse: &HttpResponse{StatusCode: 200, Body: map[string]any{"type": "BINARY", "base64Bytes": "not base64!"}}},
+	}
+	report := Validate(exps)
+	if len(report) != 2 || report[0].Index != 1 || report[0].Severity != IssueWarning || report[1].Index != 2 || report[1].Severity != IssueError {
+		t.Errorf("issues:\n%s", report.Format())
+	}
+}
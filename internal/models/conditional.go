@@ -0,0 +1,122 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+)
+
+// Response headers a 304 repeats from the 200 it stands for (RFC 9110 §15.4.5)
+var notModifiedHeaders = []string{"Cache-Control", "Content-Location", "Expires", "Vary"}
+
+// Generated reports whether the expectation is derived from another one on save
+// (a negotiated representation, a 304, a Range companion, a redirect hop, a tenant's response, a
+// GraphQL error variant, a fault companion or a response rule) rather than edited directly
+func (e MockExpectation) Generated() bool {
+	return e.NegotiatedFormat != "" || e.NotModified || e.PartialContent || e.RedirectHop != 0 || e.Tenant != "" || e.GraphQLVariant != "" || e.FaultInjected || e.RuleVariant != ""
+}
+
+// ConditionalGetEligible reports whether an expectation can carry an ETag: a GET with a
+// static 2xx body (templates and forwards have no body to hash)
+func ConditionalGetEligible(exp MockExpectation) bool {
+	if exp.HttpRequest == nil || exp.HttpResponse == nil || exp.HttpResponseTemplate != nil || exp.Forward != nil {
+		return false
+	}
+	status := exp.HttpResponse.StatusCode
+	if status == 0 {
+		status = 200
+	}
+	return strings.EqualFold(exp.HttpRequest.Method, "GET") && status >= 200 && status < 300 && exp.HttpResponse.Body != nil
+}
+
+// EnableConditionalGet turns on conditional GET for every eligible expectation and returns
+// how many were enabled
+func EnableConditionalGet(expectations []MockExpectation) int {
+	enabled := 0
+	for i := range expectations {
+		if expectations[i].Generated() || expectations[i].ConditionalGet || !ConditionalGetEligible(expectations[i]) {
+			continue
+		}
+		expectations[i].ConditionalGet = true
+		enabled++
+	}
+	return enabled
+}
+
+// ApplyConditionalGet regenerates the 304 companions of every expectation with conditional
+// GET enabled. Each such response gets an ETag derived from its body, and a companion placed
+// right before it (so it wins ties) answers 304 Not Modified when If-None-Match carries that
+// ETag. Run it after ApplyNegotiation so every representation gets its own ETag.
+// It returns the number of companions generated.
+func (c *MockConfiguration) ApplyConditionalGet() int {
+	out := make([]MockExpectation, 0, len(c.Expectations))
+	generated := 0
+	for _, exp := range c.Expectations {
+		if exp.NotModified {
+			continue // regenerated below from the expectation it answers for
+		}
+		if !exp.ConditionalGet || !ConditionalGetEligible(exp) {
+			out = append(out, exp)
+			continue
+		}
+		etag := ETag(exp.HttpResponse.Body)
+		resp := *exp.HttpResponse
+		resp.Headers = withHeader(append([]NameValues(nil), resp.Headers...), "ETag", etag)
+		exp.HttpResponse = &resp
+
+		out = append(out, NotModifiedCompanion(exp, etag), exp)
+		generated++
+	}
+	c.Expectations = out
+	return generated
+}
+
+// NotModifiedCompanion builds the expectation answering 304 for requests revalidating etag
+func NotModifiedCompanion(exp MockExpectation, etag string) MockExpectation {
+	v := exp
+	v.ConditionalGet = false
+	v.NotModified = true
+	v.Negotiation = nil
+	v.GraphQLErrors = nil
+	v.Checks = nil
+	v.Examples = nil
+	v.Notes = ""
+	v.Freshness = nil
+	if exp.ID != "" {
+		v.ID = exp.ID + "-304"
+	}
+	req := *exp.HttpRequest
+	req.Headers = withHeader(append([]NameValues(nil), req.Headers...), "If-None-Match", IfNoneMatchPattern(etag))
+	v.HttpRequest = &req
+
+	resp := &HttpResponse{StatusCode: 304, Delay: exp.HttpResponse.Delay}
+	resp.Headers = []NameValues{{Name: "ETag", Values: []string{etag}}}
+	for _, name := range notModifiedHeaders {
+		for _, h := range exp.HttpResponse.Headers {
+			if strings.EqualFold(h.Name, name) {
+				resp.Headers = append(resp.Headers, h)
+			}
+		}
+	}
+	v.HttpResponse = resp
+	return v
+}
+
+// ETag derives a strong entity tag from a response body, so it changes whenever the body does
+func ETag(body any) string {
+	var data []byte
+	if s, ok := body.(string); ok {
+		data = []byte(s)
+	} else {
+		data, _ = json.Marshal(body) // map keys marshal sorted, so equal bodies hash equally
+	}
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// IfNoneMatchPattern is the MockServer header regex matching an If-None-Match that lists etag
+// (strong or weak) or is "*"
+func IfNoneMatchPattern(etag string) string {
+	return `.*` + strings.Trim(etag, `"`) + `.*|\*`
+}
@@ -0,0 +1,41 @@
+package models
+
+import "testing"
+
+func TestApplyConditionalGet(t *testing.T) {
+	body := map[string]any{"type": "JSON", "json": map[string]any{"id": "u_1"}}
+	cfg := &MockConfiguration{Expectations: []MockExpectation{
+		{
+			ID:           "user",
+			HttpRequest:  &HttpRequest{Method: "GET", Path: "/users/1"},
+			HttpResponse: &HttpResponse{StatusCode: 200, Body: body, Headers: []NameValues{{Name: "Cache-Control", Values: []string{"max-age=60"}}}},
+		},
+		{HttpRequest: &HttpRequest{Method: "POST", Path: "/users"}, HttpResponse: &HttpResponse{StatusCode: 201, Body: body}},
+	}}
+	if n := EnableConditionalGet(cfg.Expectations); n != 1 {
+		t.Fatalf("enabled %d expectations, want only the GET", n)
+	}
+	cfg.ApplyConditionalGet()
+	// Saving again must regenerate rather than accumulate companions
+	if n := cfg.ApplyConditionalGet(); n != 1 || len(cfg.Expectations) != 3 {
+		t.Fatalf("second apply: generated %d, %d expectations", n, len(cfg.Expectations))
+	}
+
+	companion, canonical := cfg.Expectations[0], cfg.Expectations[1]
+	etag := ETag(body)
+	if !companion.NotModified || companion.ID != "user-304" || companion.HttpResponse.StatusCode != 304 || companion.HttpResponse.Body != nil {
+		t.Fatalf("unexpected companion: %+v", companion)
+	}
+	if h := companion.HttpResponse.Headers; len(h) != 2 || h[0].Values[0] != etag || h[1].Name != "Cache-Control" {
+		t.Errorf("companion headers = %+v", h)
+	}
+	if got := canonical.HttpResponse.Headers[1]; got.Name != "ETag" || got.Values[0] != etag {
+		t.Errorf("canonical ETag = %+v", got)
+	}
+
+	cfg.Expectations[1].HttpResponse.Body = map[string]any{"type": "JSON", "json": map[string]any{"id": "u_2"}}
+	cfg.ApplyConditionalGet()
+	if cfg.Expectations[1].HttpResponse.Headers[1].Values[0] == etag {
+		t.Error("ETag did not change with the body")
+	}
+}
@@ -16,13 +16,35 @@ type ConfigMetadata struct {
 	Description string    `json:"description,omitempty"`
 	Provider    string    `json:"provider,omitempty"` // AI provider used (anthropic, openai, template)
 	Size        int64     `json:"size,omitempty"`     // Size in bytes
+
+	DeployedVersion string `json:"deployed_version,omitempty"` // Version last deployed; baseline for breaking-change checks
+	ContractVersion string `json:"contract_version,omitempty"` // Semantic version of the deployed contract, e.g. "1.4.0"
+
+	Selection *TagSelection `json:"selection,omitempty"` // Tag selection of the last deploy (nil = all expectations)
+	Masking   string        `json:"masking,omitempty"`   // Masking profile of the last deploy (empty = original bodies)
+
+	Environment string `json:"environment,omitempty"` // Environment overlay of the last deploy (empty = base expectations)
 }
 
 // MockConfiguration represents a complete MockServer configuration
 type MockConfiguration struct {
-	Metadata     ConfigMetadata    `json:"metadata"`
-	Expectations []MockExpectation `json:"expectations"`
-	Settings     ConfigSettings    `json:"settings,omitempty"`
+	Metadata        ConfigMetadata        `json:"metadata"`
+	Expectations    []MockExpectation     `json:"expectations"`
+	Settings        ConfigSettings        `json:"settings,omitempty"`
+	Schemas         map[string]any        `json:"schemas,omitempty"`          // Named JSON Schemas referenced as "schemas/<Name>"
+	Changelog       []ChangelogEntry      `json:"changelog,omitempty"`        // Newest first; one entry per deployed contract release
+	Consumers       []Consumer            `json:"consumers,omitempty"`        // Teams notified when expectations they depend on change
+	Withheld        []WithheldExpectation `json:"withheld,omitempty"`         // Expectations the deploy tag selection keeps out of the mock
+	BodyExamples    []BodyExample         `json:"body_examples,omitempty"`    // Canonical payloads by content type and entity
+	ErrorTaxonomy   *ErrorTaxonomy        `json:"error_taxonomy,omitempty"`   // Error envelope and codes used for generated error responses
+	Tenancy         *Tenancy              `json:"tenancy,omitempty"`          // Per-tenant responses selected by header or subdomain
+	MaskingProfiles []MaskingProfile      `json:"masking_profiles,omitempty"` // Project masking profiles for deploy/download --mask
+	Masked          []MaskedBody          `json:"masked,omitempty"`           // Original bodies of the last masked deploy
+	FakeData        *FakeData             `json:"fake_data,omitempty"`        // Seed and project generators for ${...} placeholders
+	Datasets        []Dataset             `json:"datasets,omitempty"`         // Seed data served by expectations bound to it
+
+	Environments        []EnvironmentOverlay `json:"environments,omitempty"`         // Per-environment differences from the base expectations
+	DeployedEnvironment *DeployedEnvironment `json:"deployed_environment,omitempty"` // Base of the last environment deploy
 }
 
 // ConfigSettings contains additional configuration options
@@ -133,6 +155,12 @@ func ParseMockServerJSON(jsonData string) (*MockConfiguration, error) {
 	if err := json.Unmarshal([]byte(jsonData), &expectations); err != nil {
 		return nil, fmt.Errorf("failed to parse MockServer JSON: %w", err)
 	}
+	for i := range expectations {
+		// Templated responses and errors carry no static response in MockServer JSON; restore the fallback
+		if (expectations[i].HttpResponseTemplate != nil || expectations[i].HttpError != nil) && expectations[i].HttpResponse == nil {
+			expectations[i].HttpResponse = &HttpResponse{StatusCode: 200}
+		}
+	}
 
 	config := &MockConfiguration{
 		Metadata: ConfigMetadata{
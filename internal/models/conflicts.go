@@ -0,0 +1,433 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Conflict kinds
+const (
+	ConflictShadowed = "shadowed" // never served: the winner accepts every request it does
+	ConflictOrder    = "order"    // more specific than the winner, which takes the requests both accept
+	ConflictTie      = "tie"      // equally specific; priority or declaration order picks the winner
+)
+
+// Conflict is a pair of expectations accepting some of the same requests where the more
+// specific one does not win them. MockServer tries the highest priority first, then the
+// declaration order.
+type Conflict struct {
+	Kind     string `json:"kind"`
+	Index    int    `json:"index"`    // The expectation losing the shared requests
+	Winner   int    `json:"winner"`   // The expectation answering them
+	Proposed int    `json:"proposed"` // Priority letting Index win; unset for ties
+}
+
+// Resolvable reports whether a priority change resolves the conflict
+func (c Conflict) Resolvable() bool {
+	return c.Kind != ConflictTie
+}
+
+// Describe explains the conflict and its resolution
+func (c Conflict) Describe(exps []MockExpectation) string {
+	loser, winner := exps[c.Index], exps[c.Winner]
+	why := "it is listed first"
+	if winner.Priority != loser.Priority {
+		why = fmt.Sprintf("its priority is higher (%d vs %d)", winner.Priority, loser.Priority)
+	}
+	switch c.Kind {
+	case ConflictShadowed:
+		return fmt.Sprintf("[%d] %s is never served: [%d] %s accepts every request it does and wins because %s; give [%d] priority %d",
+			c.Index, endpointLabel(loser), c.Winner, endpointLabel(winner), why, c.Index, c.Proposed)
+	case ConflictOrder:
+		return fmt.Sprintf("[%d] %s is more specific than [%d] %s, which wins the requests both accept because %s; give [%d] priority %d",
+			c.Index, endpointLabel(loser), c.Winner, endpointLabel(winner), why, c.Index, c.Proposed)
+	}
+	return fmt.Sprintf("[%d] %s and [%d] %s are equally specific and accept some of the same requests; [%d] wins them because %s",
+		c.Winner, endpointLabel(winner), c.Index, endpointLabel(loser), c.Winner, why)
+}
+
+// FormatConflicts renders conflicts for the terminal, never-served expectations first
+func FormatConflicts(exps []MockExpectation, conflicts []Conflict) string {
+	if len(conflicts) == 0 {
+		return "✅ No conflicting matchers\n"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "⚖️  %d conflicting matcher pair(s):\n", len(conflicts))
+	for _, kind := range []string{ConflictShadowed, ConflictOrder, ConflictTie} {
+		for _, c := range conflicts {
+			if c.Kind != kind {
+				continue
+			}
+			icon := "⚠️ "
+			if kind == ConflictShadowed {
+				icon = "❌"
+			}
+			fmt.Fprintf(&b, "  %s %s\n", icon, c.Describe(exps))
+		}
+	}
+	return b.String()
+}
+
+// FindConflicts finds the pairs of expectations whose request matchers overlap where the more
+// specific one does not win. One matcher is more specific than another when it accepts a
+// subset of its requests, or else when it constrains more: a method, literal path segments,
+// constrained path parameters, query, header and body matchers. Expectations generated on
+// save, times-limited ones and identical matchers (reported by Validate) are left out; body
+// matchers that differ are taken not to overlap.
+func FindConflicts(exps []MockExpectation) []Conflict {
+	shapes := make([]*matcherShape, len(exps))
+	for i, exp := range exps {
+		if exp.HttpRequest != nil && !exp.Generated() && !limitedTimes(exp.Times) {
+			shapes[i] = newMatcherShape(exp.HttpRequest)
+		}
+	}
+	var out []Conflict
+	for i := range exps {
+		for j := i + 1; j < len(exps); j++ {
+			a, b := shapes[i], shapes[j]
+			if a == nil || b == nil || !a.overlaps(b) {
+				continue
+			}
+			if a.contains(b) && b.contains(a) {
+				continue
+			}
+			winner, loser := i, j
+			if exps[j].Priority > exps[i].Priority {
+				winner, loser = j, i
+			}
+			c := Conflict{Index: loser, Winner: winner, Proposed: exps[winner].Priority + 1}
+			switch w, l := shapes[winner], shapes[loser]; {
+			case w.contains(l):
+				c.Kind = ConflictShadowed
+			case l.contains(w) || l.score < w.score:
+				continue // the more specific one already wins
+			case l.score > w.score:
+				c.Kind = ConflictOrder
+			default:
+				c.Kind = ConflictTie
+				c.Proposed = 0
+			}
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// PriorityChange is a priority assigned by ResolvePriorities
+type PriorityChange struct {
+	Index int `json:"index"`
+	From  int `json:"from"`
+	To    int `json:"to"`
+}
+
+// ResolvePriorities raises the priorities of conflicting expectations until the more specific
+// one wins: only never-served ones when shadowedOnly is set, otherwise every resolvable
+// conflict. It returns the changes made, by expectation index.
+func ResolvePriorities(exps []MockExpectation, shadowedOnly bool) []PriorityChange {
+	from := map[int]int{}
+	for pass := 0; pass <= len(exps); pass++ {
+		changed := false
+		for _, c := range FindConflicts(exps) {
+			if !c.Resolvable() || (shadowedOnly && c.Kind != ConflictShadowed) {
+				continue
+			}
+			want := exps[c.Winner].Priority + 1
+			if exps[c.Index].Priority >= want {
+				continue
+			}
+			if _, seen := from[c.Index]; !seen {
+				from[c.Index] = exps[c.Index].Priority
+			}
+			exps[c.Index].Priority = want
+			changed = true
+		}
+		if !changed {
+			break
+		}
+	}
+	var changes []PriorityChange
+	for i, p := range from {
+		if exps[i].Priority != p {
+			changes = append(changes, PriorityChange{Index: i, From: p, To: exps[i].Priority})
+		}
+	}
+	sort.Slice(changes, func(a, b int) bool { return changes[a].Index < changes[b].Index })
+	return changes
+}
+
+// ApplyPriorities raises the priority of every expectation another one keeps from ever being
+// served, so the more specific matcher wins. Run it before the Apply steps generating
+// variants, which inherit their expectation's priority.
+func (c *MockConfiguration) ApplyPriorities() []PriorityChange {
+	return ResolvePriorities(c.Expectations, true)
+}
+
+// matcherShape is a request matcher prepared for overlap and containment checks
+type matcherShape struct {
+	method   string
+	path     string
+	segments []string       // nil for regex paths
+	re       *regexp.Regexp // anchored path pattern
+	params   map[string][]string
+	query    []NameValues
+	headers  []NameValues
+	body     string // JSON of the body matcher; "" for none
+	score    int
+}
+
+var pathRegexChars = regexp.MustCompile(`[*+?()\[\]|^$\\]`)
+
+func newMatcherShape(req *HttpRequest) *matcherShape {
+	s := &matcherShape{method: strings.ToUpper(req.Method), path: req.Path, params: req.PathParameters,
+		query: req.QueryStringParameters, headers: req.Headers}
+	if s.method != "" {
+		s.score++
+	}
+	pattern := ""
+	if pathRegexChars.MatchString(pathParamName.ReplaceAllString(req.Path, "")) {
+		pattern = req.Path
+		s.score++
+	} else {
+		s.segments = strings.Split(strings.Trim(req.Path, "/"), "/")
+		var parts []string
+		for _, seg := range s.segments {
+			if name, ok := templateParam(seg); ok {
+				parts = append(parts, "[^/]+")
+				if len(s.params[name]) > 0 {
+					s.score++
+				}
+				continue
+			}
+			parts = append(parts, regexp.QuoteMeta(seg))
+			if seg != "" {
+				s.score += 2
+			}
+		}
+		pattern = "/" + strings.Join(parts, "/")
+	}
+	if req.Path == "" {
+		pattern = ".*"
+	}
+	s.re, _ = regexp.Compile("^(?:" + pattern + ")$")
+	for _, nv := range append(append([]NameValues(nil), req.QueryStringParameters...), req.Headers...) {
+		for _, v := range nv.Values {
+			s.score++
+			if literalValue(v) {
+				s.score++
+			}
+		}
+	}
+	if req.Body != nil {
+		raw, _ := json.Marshal(req.Body)
+		s.body = string(raw)
+		s.score += 2
+	}
+	return s
+}
+
+// overlaps reports whether some request may match both
+func (s *matcherShape) overlaps(o *matcherShape) bool {
+	if s.method != "" && o.method != "" && s.method != o.method {
+		return false
+	}
+	if s.body != "" && o.body != "" && s.body != o.body {
+		return false
+	}
+	return s.pathOverlaps(o) && valuesOverlap(s.query, o.query, false) && valuesOverlap(s.headers, o.headers, true)
+}
+
+// contains reports whether s accepts every request o accepts
+func (s *matcherShape) contains(o *matcherShape) bool {
+	if s.method != "" && s.method != o.method {
+		return false
+	}
+	if s.body != "" && s.body != o.body {
+		return false
+	}
+	return s.pathContains(o) && valuesContain(s.query, o.query, false) && valuesContain(s.headers, o.headers, true)
+}
+
+func (s *matcherShape) pathOverlaps(o *matcherShape) bool {
+	switch {
+	case s.path == "" || o.path == "":
+		return true
+	case s.segments != nil && o.segments != nil:
+		if len(s.segments) != len(o.segments) {
+			return false
+		}
+		for i, a := range s.segments {
+			b := o.segments[i]
+			_, aParam := templateParam(a)
+			_, bParam := templateParam(b)
+			switch {
+			case aParam && bParam:
+			case aParam:
+				if !s.segmentAccepts(a, b) {
+					return false
+				}
+			case bParam:
+				if !o.segmentAccepts(b, a) {
+					return false
+				}
+			case a != b:
+				return false
+			}
+		}
+		return true
+	case s.segments == nil && o.segments == nil:
+		return s.path == o.path
+	case s.segments == nil:
+		return s.re != nil && s.re.MatchString(o.sample("1"))
+	default:
+		return o.re != nil && o.re.MatchString(s.sample("1"))
+	}
+}
+
+func (s *matcherShape) pathContains(o *matcherShape) bool {
+	switch {
+	case s.path == "":
+		return true
+	case o.path == "":
+		return false
+	case s.segments != nil && o.segments != nil:
+		if len(s.segments) != len(o.segments) {
+			return false
+		}
+		for i, a := range s.segments {
+			b := o.segments[i]
+			name, aParam := templateParam(a)
+			other, bParam := templateParam(b)
+			switch {
+			case aParam && bParam:
+				if len(s.params[name]) > 0 && strings.Join(s.params[name], "\x00") != strings.Join(o.params[other], "\x00") {
+					return false
+				}
+			case aParam:
+				if !s.segmentAccepts(a, b) {
+					return false
+				}
+			case bParam || a != b:
+				return false
+			}
+		}
+		return true
+	case s.segments == nil && o.segments == nil:
+		return s.path == o.path
+	case s.segments == nil:
+		return s.re != nil && s.re.MatchString(o.sample("1")) && s.re.MatchString(o.sample("a-Z_9"))
+	}
+	return false
+}
+
+// segmentAccepts checks a literal segment against a {name} segment and its pathParameters
+func (s *matcherShape) segmentAccepts(param, literal string) bool {
+	name, _ := templateParam(param)
+	values := s.params[name]
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if valueAccepts(v, literal) {
+			return true
+		}
+	}
+	return false
+}
+
+// sample is a concrete path the template accepts
+func (s *matcherShape) sample(value string) string {
+	return pathParamName.ReplaceAllString(s.path, value)
+}
+
+func findValues(list []NameValues, name string, foldCase bool) ([]string, bool) {
+	for _, nv := range list {
+		if nv.Name == name || (foldCase && strings.EqualFold(nv.Name, name)) {
+			return nv.Values, true
+		}
+	}
+	return nil, false
+}
+
+func valuesOverlap(a, b []NameValues, foldCase bool) bool {
+	for _, nv := range a {
+		others, ok := findValues(b, nv.Name, foldCase)
+		if !ok {
+			continue
+		}
+		for _, x := range nv.Values {
+			for _, y := range others {
+				if !valuesCompatible(x, y) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// valuesContain reports whether every matcher of a is implied by one of b
+func valuesContain(a, b []NameValues, foldCase bool) bool {
+	for _, nv := range a {
+		others, ok := findValues(b, nv.Name, foldCase)
+		if !ok {
+			return false
+		}
+		for _, x := range nv.Values {
+			implied := false
+			for _, y := range others {
+				if x == y || (literalValue(y) && valueAccepts(x, y)) {
+					implied = true
+					break
+				}
+			}
+			if !implied {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// valuesCompatible reports whether some value may satisfy both matchers
+func valuesCompatible(x, y string) bool {
+	switch {
+	case literalValue(x) && literalValue(y):
+		return x == y
+	case literalValue(y):
+		return valueAccepts(x, y)
+	case literalValue(x):
+		return valueAccepts(y, x)
+	}
+	return x != "!"+y && y != "!"+x
+}
+
+// valueAccepts applies a MockServer value matcher (a literal or a regex, "!" negating) to a value
+func valueAccepts(matcher, value string) bool {
+	negated := strings.HasPrefix(matcher, "!") && len(matcher) > 1
+	pattern := matcher
+	if negated {
+		pattern = matcher[1:]
+	}
+	ok := pattern == value
+	if !ok {
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		ok = err == nil && re.MatchString(value)
+	}
+	return ok != negated
+}
+
+// templateParam returns the name of a {name} path segment
+func templateParam(segment string) (string, bool) {
+	m := pathParamName.FindStringSubmatch(segment)
+	if m == nil || m[0] != segment {
+		return "", false
+	}
+	return m[1], true
+}
+
+func literalValue(v string) bool {
+	return !strings.HasPrefix(v, "!") && regexp.QuoteMeta(v) == v
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestFindConflicts(t *testing.T) {
+	get := func(path string) *HttpRequest { return &HttpRequest{Method: "GET", Path: path} }
+	withQuery := func(req *HttpRequest, name, value string) *HttpRequest {
+		req.QueryStringParameters = append(req.QueryStringParameters, NameValues{Name: name, Values: []string{value}})
+		return req
+	}
+	withHeader := func(req *HttpRequest, name, value string) *HttpRequest {
+		req.Headers = append(req.Headers, NameValues{Name: name, Values: []string{value}})
+		return req
+	}
+	ok := &HttpResponse{StatusCode: 200}
+	exps := []MockExpectation{
+		// 0 shadows 1 and 2, which tie on /users/me?fields=name
+		{HttpRequest: get("/users/{id}"), HttpResponse: ok},
+		{HttpRequest: get("/users/me"), HttpResponse: ok},
+		{HttpRequest: withQuery(get("/users/{id}"), "fields", "name"), HttpResponse: ok},
+		// 3 wins against the more specific 4; 5 ties with 3
+		{HttpRequest: withHeader(get("/orders"), "X-Tenant", "a"), HttpResponse: ok},
+		{HttpRequest: withQuery(withQuery(get("/orders"), "page", "1"), "size", "10"), HttpResponse: ok},
+		{HttpRequest: withQuery(get("/orders"), "status", "open"), HttpResponse: ok},
+		// no overlap: numeric ids only, another method, another literal path, other header values
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/items/{id}", PathParameters: map[string][]string{"id": {"[0-9]+"}}}, HttpResponse: ok},
+		{HttpRequest: get("/items/new"), HttpResponse: ok},
+		{HttpRequest: &HttpRequest{Method: "DELETE", Path: "/users/me"}, HttpResponse: ok},
+		{HttpRequest: withHeader(get("/carts"), "X-Tenant", "a"), HttpResponse: ok},
+		{HttpRequest: withHeader(get("/carts"), "x-tenant", "b"), HttpResponse: ok},
+		// the specific one is listed first, or wins by priority
+		{HttpRequest: get("/files/readme"), HttpResponse: ok},
+		{HttpRequest: get("/files/.*"), HttpResponse: ok},
+		{Priority: 1, HttpRequest: get("/blobs/{id}/raw"), HttpResponse: ok},
+		{Priority: 2, HttpRequest: get("/blobs/.*"), HttpResponse: ok},
+		// generated and times-limited expectations are left out
+		{HttpRequest: get("/users/{id}"), HttpResponse: ok, RuleVariant: "rule 1"},
+		{HttpRequest: get("/users/1"), HttpResponse: ok, Times: &Times{RemainingTimes: 1}},
+	}
+
+	var got []string
+	for _, c := range FindConflicts(exps) {
+		got = append(got, fmt.Sprintf("%s %d<%d %d", c.Kind, c.Index, c.Winner, c.Proposed))
+	}
+	want := []string{"shadowed 1<0 1", "shadowed 2<0 1", "tie 2<1 0", "order 4<3 1", "tie 5<3 0", "shadowed 13<14 3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("conflicts = %q, want %q", got, want)
+	}
+}
+
+func TestResolvePriorities(t *testing.T) {
+	exps := []MockExpectation{
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/users/.*"}},
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/users/{id}"}},
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/users/me"}},
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/orders", Headers: []NameValues{{Name: "X-Tenant", Values: []string{"a"}}}}},
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/orders", QueryStringParameters: []NameValues{{Name: "page", Values: []string{"1"}}, {Name: "size", Values: []string{"10"}}}}},
+	}
+	config := &MockConfiguration{Expectations: exps}
+	changes := config.ApplyPriorities()
+	want := []PriorityChange{{Index: 1, From: 0, To: 1}, {Index: 2, From: 0, To: 2}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("save-time changes = %+v, want %+v", changes, want)
+	}
+
+	changes = ResolvePriorities(exps, false)
+	if want := []PriorityChange{{Index: 4, From: 0, To: 1}}; !reflect.DeepEqual(changes, want) {
+		t.Errorf("changes = %+v, want %+v", changes, want)
+	}
+	if conflicts := FindConflicts(exps); len(conflicts) != 0 {
+		t.Errorf("unresolved: %+v", conflicts)
+	}
+}
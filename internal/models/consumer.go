@@ -0,0 +1,82 @@
+package models
+
+import (
+	"path"
+	"strings"
+	"time"
+)
+
+// Consumer is a team that registered interest in a project's contract
+type Consumer struct {
+	Name         string    `json:"name"`
+	Webhook      string    `json:"webhook,omitempty"`
+	Email        string    `json:"email,omitempty"`
+	Tags         []string  `json:"tags,omitempty"`  // Notify when expectations with any of these tags change
+	Paths        []string  `json:"paths,omitempty"` // Path patterns, e.g. "/users/*" or "/orders/**"
+	RegisteredAt time.Time `json:"registered_at"`
+}
+
+// FindConsumer returns the index of the named consumer, or -1
+func (c *MockConfiguration) FindConsumer(name string) int {
+	for i, consumer := range c.Consumers {
+		if strings.EqualFold(consumer.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// DependsOn reports whether the consumer subscribed to an endpoint with the given path and tags.
+// A consumer without tags or paths depends on every endpoint.
+func (cons *Consumer) DependsOn(endpointPath string, tags []string) bool {
+	if len(cons.Tags) == 0 && len(cons.Paths) == 0 {
+		return true
+	}
+	for _, pattern := range cons.Paths {
+		if matchPathPattern(pattern, endpointPath) {
+			return true
+		}
+	}
+	for _, want := range cons.Tags {
+		for _, tag := range tags {
+			if strings.EqualFold(want, tag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchPathPattern matches shell-style patterns per segment; a trailing "/**" matches any suffix
+func matchPathPattern(pattern, p string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "/**"); ok {
+		return p == prefix || strings.HasPrefix(p, prefix+"/")
+	}
+	ok, err := path.Match(pattern, p)
+	return err == nil && ok
+}
+
+// ChangesForConsumer filters a diff down to the changes a consumer depends on.
+// Tags are looked up on the expectations of both versions so removals are still attributed.
+func ChangesForConsumer(consumer *Consumer, diff *ConfigDiff, versions ...*MockConfiguration) []ExpectationChange {
+	tagsByEndpoint := map[string][]string{}
+	for _, cfg := range versions {
+		for ep, exps := range groupByEndpoint(cfg) {
+			for _, exp := range exps {
+				tagsByEndpoint[ep] = append(tagsByEndpoint[ep], exp.Tags...)
+			}
+		}
+	}
+
+	var out []ExpectationChange
+	for _, change := range diff.Changes {
+		endpointPath := change.Endpoint
+		if i := strings.Index(endpointPath, " "); i >= 0 {
+			endpointPath = endpointPath[i+1:]
+		}
+		if consumer.DependsOn(endpointPath, tagsByEndpoint[change.Endpoint]) {
+			out = append(out, change)
+		}
+	}
+	return out
+}
@@ -0,0 +1,27 @@
+package models
+
+import "testing"
+
+func TestChangesForConsumer_MatchesPathsAndTags(t *testing.T) {
+	from := &MockConfiguration{Expectations: []MockExpectation{
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/users/1"}, HttpResponse: &HttpResponse{StatusCode: 200}},
+		{HttpRequest: &HttpRequest{Method: "POST", Path: "/login"}, HttpResponse: &HttpResponse{StatusCode: 200}, Tags: []string{"auth"}},
+	}}
+	to := &MockConfiguration{Expectations: []MockExpectation{
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/orders"}, HttpResponse: &HttpResponse{StatusCode: 200}},
+	}}
+	diff := DiffConfigurations(from, to)
+
+	byPath := &Consumer{Name: "web", Paths: []string{"/users/**"}}
+	if got := ChangesForConsumer(byPath, diff, from, to); len(got) != 1 || got[0].Endpoint != "GET /users/1" {
+		t.Fatalf("path subscription: unexpected changes %v", got)
+	}
+	byTag := &Consumer{Name: "mobile", Tags: []string{"AUTH"}}
+	if got := ChangesForConsumer(byTag, diff, from, to); len(got) != 1 || got[0].Endpoint != "POST /login" {
+		t.Fatalf("tag subscription: unexpected changes %v", got)
+	}
+	everything := &Consumer{Name: "qa"}
+	if got := ChangesForConsumer(everything, diff, from, to); len(got) != 3 {
+		t.Fatalf("expected all 3 changes, got %v", got)
+	}
+}
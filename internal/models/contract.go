@@ -0,0 +1,186 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContractInfoPath is the reserved endpoint that exposes the deployed contract version and changelog
+const ContractInfoPath = "/__automock/info"
+
+// maxChangelogEntries bounds the changelog kept in the configuration
+const maxChangelogEntries = 20
+
+// ChangelogEntry is the human-readable record of one deployed contract release
+type ChangelogEntry struct {
+	Version         string    `json:"version"`
+	PreviousVersion string    `json:"previous_version,omitempty"`
+	ConfigVersion   string    `json:"config_version,omitempty"`
+	ReleasedAt      time.Time `json:"released_at"`
+	Breaking        []string  `json:"breaking,omitempty"`
+	Changes         []string  `json:"changes,omitempty"`
+}
+
+// BumpContractVersion derives the next semantic version from a classified diff:
+// major for breaking changes, minor for added endpoints/variants/fields, patch otherwise.
+func BumpContractVersion(current string, diff *ConfigDiff) string {
+	major, minor, patch, ok := parseSemver(current)
+	if !ok {
+		return "1.0.0"
+	}
+	switch {
+	case diff.HasBreaking():
+		return fmt.Sprintf("%d.0.0", major+1)
+	case hasAdditions(diff):
+		return fmt.Sprintf("%d.%d.0", major, minor+1)
+	default:
+		return fmt.Sprintf("%d.%d.%d", major, minor, patch+1)
+	}
+}
+
+func hasAdditions(diff *ConfigDiff) bool {
+	for _, c := range diff.Changes {
+		if c.Severity == ChangeNonBreaking && strings.Contains(c.Summary, "added") {
+			return true
+		}
+	}
+	return false
+}
+
+func parseSemver(v string) (int, int, int, bool) {
+	parts := strings.Split(strings.TrimPrefix(strings.TrimSpace(v), "v"), ".")
+	if len(parts) != 3 {
+		return 0, 0, 0, false
+	}
+	nums := make([]int, 3)
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return 0, 0, 0, false
+		}
+		nums[i] = n
+	}
+	return nums[0], nums[1], nums[2], true
+}
+
+// RecordRelease versions the contract against the previously deployed configuration,
+// prepends a changelog entry and refreshes the info endpoint. previous is nil for a first
+// deploy, or when the deployed version could not be loaded; then a deployed configuration
+// continues its own contract version and changelog, released as breaking since nothing was
+// compared. Otherwise the result depends only on previous and c, so retrying a failed deploy
+// does not double-bump.
+// Returns nil when nothing changed since the previous release.
+func (c *MockConfiguration) RecordRelease(previous *MockConfiguration, releasedAt time.Time) *ChangelogEntry {
+	var (
+		base       string
+		history    []ChangelogEntry
+		uncompared bool
+	)
+	switch {
+	case previous != nil:
+		base = previous.Metadata.ContractVersion
+		history = previous.Changelog
+	case c.Metadata.DeployedVersion != "" && c.Metadata.ContractVersion != "":
+		base, history, uncompared = c.Metadata.ContractVersion, c.Changelog, true
+	}
+
+	diff := DiffConfigurations(previous, c)
+	if uncompared {
+		// Nothing shows the release keeps consumers working
+		diff = &ConfigDiff{Changes: []ExpectationChange{{
+			Endpoint: "All endpoints",
+			Severity: ChangeBreaking,
+			Summary:  fmt.Sprintf("not compared with %s; the deployed configuration could not be loaded", base),
+		}}}
+	}
+	if previous != nil && base != "" && len(diff.Changes) == 0 {
+		c.Metadata.ContractVersion = base
+		c.Changelog = history
+		c.UpsertInfoExpectation()
+		return nil
+	}
+
+	entry := ChangelogEntry{
+		Version:         BumpContractVersion(base, diff),
+		PreviousVersion: base,
+		ConfigVersion:   c.Metadata.Version,
+		ReleasedAt:      releasedAt.UTC(),
+	}
+	if previous == nil && !uncompared {
+		entry.Changes = []string{fmt.Sprintf("Initial contract with %d expectation(s)", len(c.Expectations))}
+	}
+	for _, ch := range diff.Changes {
+		line := ch.Endpoint + ": " + ch.Summary
+		if ch.Severity == ChangeBreaking {
+			entry.Breaking = append(entry.Breaking, line)
+		} else {
+			entry.Changes = append(entry.Changes, line)
+		}
+	}
+
+	c.Metadata.ContractVersion = entry.Version
+	c.Changelog = append([]ChangelogEntry{entry}, history...)
+	if len(c.Changelog) > maxChangelogEntries {
+		c.Changelog = c.Changelog[:maxChangelogEntries]
+	}
+	c.UpsertInfoExpectation()
+	return &entry
+}
+
+// UpsertInfoExpectation (re)creates the GET /__automock/info expectation from the configuration metadata
+func (c *MockConfiguration) UpsertInfoExpectation() {
+	kept := make([]MockExpectation, 0, len(c.Expectations)+1)
+	for _, exp := range c.Expectations {
+		if !isInfoExpectation(&exp) {
+			kept = append(kept, exp)
+		}
+	}
+	c.Expectations = kept
+
+	info := map[string]any{
+		"project":          c.Metadata.ProjectID,
+		"contract_version": c.Metadata.ContractVersion,
+		"config_version":   c.Metadata.Version,
+		"expectations":     len(c.Expectations),
+		"changelog":        c.Changelog,
+	}
+	c.Expectations = append(c.Expectations, MockExpectation{
+		ID:          "automock-info",
+		Description: "Contract version and changelog (managed by auto-mock)",
+		Priority:    1000,
+		HttpRequest: &HttpRequest{Method: "GET", Path: ContractInfoPath},
+		HttpResponse: &HttpResponse{
+			StatusCode: 200,
+			Headers:    []NameValues{{Name: "Content-Type", Values: []string{"application/json"}}},
+			Body:       map[string]any{"type": "JSON", "json": info},
+		},
+		Times: &Times{Unlimited: true},
+	})
+}
+
+func isInfoExpectation(exp *MockExpectation) bool {
+	return exp.HttpRequest != nil && exp.HttpRequest.Path == ContractInfoPath
+}
+
+// FormatChangelog renders changelog entries as human-readable text
+func FormatChangelog(entries []ChangelogEntry) string {
+	var b strings.Builder
+	for _, e := range entries {
+		fmt.Fprintf(&b, "## %s (%s)\n", e.Version, e.ReleasedAt.Format("2006-01-02"))
+		if len(e.Breaking) > 0 {
+			b.WriteString("  💥 Breaking:\n")
+			for _, l := range e.Breaking {
+				fmt.Fprintf(&b, "    - %s\n", l)
+			}
+		}
+		if len(e.Changes) > 0 {
+			b.WriteString("  ✨ Changes:\n")
+			for _, l := range e.Changes {
+				fmt.Fprintf(&b, "    - %s\n", l)
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordRelease_BumpsSemver(t *testing.T) {
+	v1 := &MockConfiguration{Expectations: []MockExpectation{{
+		HttpRequest:  &HttpRequest{Method: "GET", Path: "/users"},
+		HttpResponse: &HttpResponse{StatusCode: 200},
+	}}}
+	if entry := v1.RecordRelease(nil, time.Now()); entry == nil || entry.Version != "1.0.0" {
+		t.Fatalf("expected initial release 1.0.0, got %+v", entry)
+	}
+
+	// Additive change → minor bump; info endpoint is not part of the contract diff
+	v2 := &MockConfiguration{Metadata: v1.Metadata, Expectations: append([]MockExpectation{}, v1.Expectations...)}
+	v2.Expectations = append(v2.Expectations, MockExpectation{
+		HttpRequest:  &HttpRequest{Method: "GET", Path: "/orders"},
+		HttpResponse: &HttpResponse{StatusCode: 200},
+	})
+	if entry := v2.RecordRelease(v1, time.Now()); entry == nil || entry.Version != "1.1.0" {
+		t.Fatalf("expected minor bump to 1.1.0, got %+v", entry)
+	}
+
+	// Removal → major bump, recorded as breaking
+	v3 := &MockConfiguration{Metadata: v2.Metadata, Expectations: v1.Expectations}
+	entry := v3.RecordRelease(v2, time.Now())
+	if entry == nil || entry.Version != "2.0.0" || len(entry.Breaking) != 1 {
+		t.Fatalf("expected breaking release 2.0.0, got %+v", entry)
+	}
+	if len(v3.Changelog) != 3 {
+		t.Fatalf("expected 3 changelog entries, got %d", len(v3.Changelog))
+	}
+	info := 0
+	for _, exp := range v3.Expectations {
+		if exp.HttpRequest.Path == ContractInfoPath {
+			info++
+		}
+	}
+	if info != 1 {
+		t.Fatalf("expected exactly one info expectation, got %d", info)
+	}
+}
+
+// A deployed configuration whose deployed version cannot be loaded keeps its contract history
+func TestRecordRelease_UnavailableDeployedVersion(t *testing.T) {
+	previous := ChangelogEntry{Version: "2.1.0", PreviousVersion: "2.0.0"}
+	c := &MockConfiguration{
+		Metadata:  ConfigMetadata{Version: "v9", DeployedVersion: "v7", ContractVersion: "2.1.0"},
+		Changelog: []ChangelogEntry{previous},
+		Expectations: []MockExpectation{{
+			HttpRequest:  &HttpRequest{Method: "GET", Path: "/users"},
+			HttpResponse: &HttpResponse{StatusCode: 200},
+		}},
+	}
+	entry := c.RecordRelease(nil, time.Now())
+	if entry == nil || entry.Version != "3.0.0" || entry.PreviousVersion != "2.1.0" || len(entry.Breaking) != 1 {
+		t.Fatalf("release = %+v, want an uncompared 3.0.0 from 2.1.0", entry)
+	}
+	if c.Metadata.ContractVersion != "3.0.0" || len(c.Changelog) != 2 || c.Changelog[1].Version != previous.Version {
+		t.Errorf("contract %s, changelog %+v; want the history kept", c.Metadata.ContractVersion, c.Changelog)
+	}
+
+	// A first deploy retried after its release was saved is still the initial release
+	first := &MockConfiguration{Metadata: ConfigMetadata{ContractVersion: "1.0.0"}, Expectations: c.Expectations[:1]}
+	if entry := first.RecordRelease(nil, time.Now()); entry == nil || entry.Version != "1.0.0" {
+		t.Errorf("retried first release = %+v, want 1.0.0", entry)
+	}
+}
@@ -0,0 +1,138 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Cost categories of a deployment estimate
+const (
+	CostCompute      = "compute"
+	CostNetworking   = "networking"
+	CostDataTransfer = "data transfer"
+	CostStorage      = "storage"
+	CostProtection   = "protection"
+)
+
+// CostItem is one line of a monthly cost estimate, in USD
+type CostItem struct {
+	Category string
+	Name     string
+	Monthly  float64
+}
+
+// CostEstimate approximates what a deployment costs per month before it is deployed
+type CostEstimate struct {
+	Region   string
+	PricedAs string // region whose prices were used when Region has none of its own ("" = Region)
+	Items    []CostItem
+	Notes    []string
+}
+
+// Add appends a line to the estimate
+func (e *CostEstimate) Add(category, name string, monthly float64) {
+	e.Items = append(e.Items, CostItem{Category: category, Name: name, Monthly: monthly})
+}
+
+// Total is the estimated monthly cost
+func (e *CostEstimate) Total() float64 {
+	total := 0.0
+	for _, item := range e.Items {
+		total += item.Monthly
+	}
+	return total
+}
+
+// ByCategory sums the estimate per category, in the order categories first appear
+func (e *CostEstimate) ByCategory() []CostItem {
+	var sums []CostItem
+	index := map[string]int{}
+	for _, item := range e.Items {
+		i, ok := index[item.Category]
+		if !ok {
+			i = len(sums)
+			index[item.Category] = i
+			sums = append(sums, CostItem{Category: item.Category})
+		}
+		sums[i].Monthly += item.Monthly
+	}
+	return sums
+}
+
+// CheckBudget fails when the estimate exceeds maxMonthly; zero or less means no limit
+func (e *CostEstimate) CheckBudget(maxMonthly float64) error {
+	if maxMonthly <= 0 || e.Total() <= maxMonthly {
+		return nil
+	}
+	return fmt.Errorf("estimated cost $%.2f/month exceeds the limit of $%.2f/month", e.Total(), maxMonthly)
+}
+
+// Format renders the estimate line by line with its total and per-category breakdown
+func (e *CostEstimate) Format() string {
+	var b strings.Builder
+	region := e.Region
+	if e.PricedAs != "" && e.PricedAs != e.Region {
+		region = fmt.Sprintf("%s, priced as %s", e.Region, e.PricedAs)
+	}
+	fmt.Fprintf(&b, "\nAPPROX. MONTHLY COST ESTIMATE (%s):\n", region)
+	for _, item := range e.Items {
+		fmt.Fprintf(&b, "  %-14s %-58s $%8.2f\n", item.Category, item.Name, item.Monthly)
+	}
+	fmt.Fprintf(&b, "  %s\n", strings.Repeat("-", 84))
+	fmt.Fprintf(&b, "  %-73s $%8.2f/month\n", "Total", e.Total())
+	var parts []string
+	for _, sum := range e.ByCategory() {
+		parts = append(parts, fmt.Sprintf("%s $%.2f", sum.Category, sum.Monthly))
+	}
+	fmt.Fprintf(&b, "  By category: %s\n", strings.Join(parts, ", "))
+	for _, note := range e.Notes {
+		fmt.Fprintf(&b, "  (%s)\n", note)
+	}
+	return b.String()
+}
+
+// ServiceCost is what one cloud service cost over a report's period
+type ServiceCost struct {
+	Service string
+	Amount  float64
+}
+
+// CostReport is what a project's cloud resources actually cost over [From, To)
+type CostReport struct {
+	From, To  time.Time
+	Currency  string
+	Scope     string // what was counted, e.g. "resources tagged ProjectName=users"
+	Services  []ServiceCost
+	EmptyHint string // why nothing may have been recorded, shown when Services is empty
+}
+
+// Total is the cost over the period
+func (r *CostReport) Total() float64 {
+	total := 0.0
+	for _, s := range r.Services {
+		total += s.Amount
+	}
+	return total
+}
+
+// Format renders the period total and its services, most expensive first
+func (r *CostReport) Format(label string) string {
+	var b strings.Builder
+	currency := r.Currency
+	if currency == "" {
+		currency = "USD"
+	}
+	last := r.To.AddDate(0, 0, -1)
+	fmt.Fprintf(&b, "  %s (%s to %s): %.2f %s\n", label, r.From.Format("2006-01-02"), last.Format("2006-01-02"), r.Total(), currency)
+	services := append([]ServiceCost(nil), r.Services...)
+	sort.SliceStable(services, func(i, j int) bool { return services[i].Amount > services[j].Amount })
+	for _, s := range services {
+		fmt.Fprintf(&b, "    %-48s %10.2f\n", s.Service, s.Amount)
+	}
+	if len(services) == 0 && r.EmptyHint != "" {
+		fmt.Fprintf(&b, "    Nothing recorded; %s\n", r.EmptyHint)
+	}
+	return b.String()
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCostEstimate(t *testing.T) {
+	e := &CostEstimate{Region: "me-south-1", PricedAs: "us-east-1"}
+	e.Add(CostCompute, "Fargate", 14.78)
+	e.Add(CostNetworking, "ALB", 20)
+	e.Add(CostNetworking, "NAT gateway", 32.85)
+	e.Add(CostStorage, "S3", 2.70)
+
+	if total := e.Total(); total < 70.32 || total > 70.34 {
+		t.Errorf("total %.2f", total)
+	}
+	sums := e.ByCategory()
+	if len(sums) != 3 || sums[1].Category != CostNetworking || sums[1].Monthly != 52.85 {
+		t.Errorf("by category %+v", sums)
+	}
+	out := e.Format()
+	for _, want := range []string{"me-south-1, priced as us-east-1", "$   70.33/month", "networking $52.85"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("estimate lacks %q:\n%s", want, out)
+		}
+	}
+
+	if err := e.CheckBudget(0); err != nil {
+		t.Errorf("no limit: %v", err)
+	}
+	if err := e.CheckBudget(75); err != nil {
+		t.Errorf("within limit: %v", err)
+	}
+	if err := e.CheckBudget(50); err == nil || !strings.Contains(err.Error(), "$70.33/month exceeds the limit of $50.00/month") {
+		t.Errorf("over limit: %v", err)
+	}
+}
+
+func TestCostReport(t *testing.T) {
+	from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	r := &CostReport{From: from, To: from.AddDate(0, 1, 0), Currency: "USD", Services: []ServiceCost{
+		{Service: "Amazon Simple Storage Service", Amount: 0.12},
+		{Service: "Amazon Elastic Container Service", Amount: 14.5},
+	}}
+	out := r.Format("Last month")
+	if !strings.Contains(out, "Last month (2026-09-01 to 2026-09-30): 14.62 USD") {
+		t.Errorf("report:\n%s", out)
+	}
+	if strings.Index(out, "Container Service") > strings.Index(out, "Storage Service") {
+		t.Errorf("services not most expensive first:\n%s", out)
+	}
+
+	empty := &CostReport{From: from, To: from.AddDate(0, 1, 0), EmptyHint: "activate the tag"}
+	if out := empty.Format("Last month"); !strings.Contains(out, "Nothing recorded; activate the tag") {
+		t.Errorf("empty report:\n%s", out)
+	}
+}
@@ -0,0 +1,368 @@
+package models
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Dataset modes
+const (
+	DatasetList = "list" // a page of the rows, filtered by query parameters naming a row field
+	DatasetItem = "item" // the row whose key is in a path parameter; 404 when there is none
+)
+
+// DatasetModes lists the ways an expectation serves a dataset
+var DatasetModes = []string{DatasetList, DatasetItem}
+
+// Pagination styles of list endpoints
+const (
+	PagingPage   = "page"   // ?page=2&size=20, 1-based
+	PagingOffset = "offset" // ?offset=40&limit=20
+	PagingCursor = "cursor" // ?cursor=<key of the last row of the previous page>&limit=20
+)
+
+// PagingStyles lists the pagination styles of list endpoints
+var PagingStyles = []string{PagingPage, PagingOffset, PagingCursor}
+
+// Default paging of list responses
+const (
+	DefaultPageSize   = 20
+	DefaultSizeParam  = "size"  // Size parameter of page-number paging
+	DefaultLimitParam = "limit" // Size parameter of offset and cursor paging
+)
+
+// Dataset is seed data stored with a project's expectations, e.g. users or products; list
+// endpoints bound to it return pages of its rows and item endpoints the matching row
+type Dataset struct {
+	Name   string           `json:"name"`
+	Key    string           `json:"key,omitempty"` // Field identifying a row, e.g. id; required by item endpoints
+	Rows   []map[string]any `json:"rows"`
+	Source string           `json:"source,omitempty"` // File the rows were loaded from
+}
+
+// DatasetBinding serves an expectation's response from a project dataset. The response body
+// may reference ${dataset.rows}, ${dataset.total}, ${dataset.page}, ${dataset.size},
+// ${dataset.pages}, ${dataset.offset}, ${dataset.nextCursor} and the ${dataset.next} and
+// ${dataset.prev} links (list), or ${dataset.row} and ${dataset.row.<field>} (item). An empty
+// body serves the page with its counts and links (see datasetDefaultBody) or the row itself.
+type DatasetBinding struct {
+	Name      string `json:"name"`
+	Mode      string `json:"mode"`                // DatasetList or DatasetItem
+	Param     string `json:"param,omitempty"`     // Item: path parameter holding the key (default: the last {name} of the path)
+	Paging    string `json:"paging,omitempty"`    // List: PagingPage (default), PagingOffset or PagingCursor
+	PageSize  int    `json:"pageSize,omitempty"`  // List: rows per page (default 20)
+	PageParam string `json:"pageParam,omitempty"` // List: query parameter with the page, offset or cursor (default: the style's name)
+	SizeParam string `json:"sizeParam,omitempty"` // List: query parameter overriding the page size (default size, or limit)
+}
+
+// PagingStyle returns the pagination style of a list binding
+func (b DatasetBinding) PagingStyle() string {
+	if b.Paging == "" {
+		return PagingPage
+	}
+	return b.Paging
+}
+
+// QueryParams returns the names of the query parameters selecting the page and its size
+func (b DatasetBinding) QueryParams() (page, size string) {
+	page, size = b.PageParam, b.SizeParam
+	if page == "" {
+		page = b.PagingStyle()
+	}
+	if size == "" && b.PagingStyle() == PagingPage {
+		size = DefaultSizeParam
+	} else if size == "" {
+		size = DefaultLimitParam
+	}
+	return page, size
+}
+
+// Describe summarizes the binding for listings
+func (b DatasetBinding) Describe() string {
+	if b.Mode == DatasetItem && b.Param == "" {
+		return b.Name + " row by path"
+	}
+	if b.Mode == DatasetItem {
+		return fmt.Sprintf("%s row by {%s}", b.Name, b.Param)
+	}
+	size := b.PageSize
+	if size == 0 {
+		size = DefaultPageSize
+	}
+	if b.PagingStyle() != PagingPage {
+		return fmt.Sprintf("%s, %d per page by %s", b.Name, size, b.PagingStyle())
+	}
+	return fmt.Sprintf("%s, %d per page", b.Name, size)
+}
+
+// Fields returns the fields of the rows, sorted
+func (d Dataset) Fields() []string {
+	seen := map[string]bool{}
+	var fields []string
+	for _, row := range d.Rows {
+		for f := range row {
+			if !seen[f] {
+				seen[f] = true
+				fields = append(fields, f)
+			}
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Check returns why the dataset cannot be served, or ""
+func (d Dataset) Check() string {
+	if strings.TrimSpace(d.Name) == "" || strings.ContainsAny(d.Name, " .${}") {
+		return fmt.Sprintf("dataset name %q must be a single word, e.g. users", d.Name)
+	}
+	if d.Key == "" {
+		return ""
+	}
+	seen := map[string]int{}
+	for i, row := range d.Rows {
+		v, ok := row[d.Key]
+		if !ok || v == nil {
+			return fmt.Sprintf("dataset %s: row %d has no %s", d.Name, i+1, d.Key)
+		}
+		k := fmt.Sprint(v)
+		if j, dup := seen[k]; dup {
+			return fmt.Sprintf("dataset %s: rows %d and %d share %s %s", d.Name, j+1, i+1, d.Key, k)
+		}
+		seen[k] = i
+	}
+	return ""
+}
+
+// DetectKey picks the field identifying the rows: id, else the first field named like an ID
+// (userId, user_id) with a unique value in every row; "" when there is none
+func (d Dataset) DetectKey() string {
+	candidates := []string{"id"}
+	for _, f := range d.Fields() {
+		lower := strings.ToLower(f)
+		if f != "id" && (lower == "id" || strings.HasSuffix(lower, "_id") || strings.HasSuffix(f, "Id") || strings.HasSuffix(f, "ID")) {
+			candidates = append(candidates, f)
+		}
+	}
+	for _, key := range candidates {
+		probe := d
+		probe.Key = key
+		if len(d.Rows) > 0 && probe.Check() == "" {
+			return key
+		}
+	}
+	return ""
+}
+
+// ParseDataset reads rows from a CSV file (a header row, then one row per line) or a JSON
+// array of objects, also accepted under a single field, e.g. {"users": [...]}. CSV values
+// that read as JSON numbers, booleans or null keep their type.
+func ParseDataset(name, filename string, data []byte) (Dataset, error) {
+	d := Dataset{Name: name, Source: filepath.Base(filename)}
+	var err error
+	if strings.EqualFold(filepath.Ext(filename), ".csv") {
+		d.Rows, err = csvDatasetRows(data)
+	} else {
+		d.Rows, err = jsonDatasetRows(data)
+	}
+	if err != nil {
+		return d, fmt.Errorf("%s: %w", d.Source, err)
+	}
+	if len(d.Rows) == 0 {
+		return d, fmt.Errorf("%s has no rows", d.Source)
+	}
+	d.Key = d.DetectKey()
+	if msg := d.Check(); msg != "" {
+		return d, fmt.Errorf("%s", msg)
+	}
+	return d, nil
+}
+
+func csvDatasetRows(data []byte) ([]map[string]any, error) {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no header row")
+	}
+	header := records[0]
+	var rows []map[string]any
+	for _, rec := range records[1:] {
+		row := make(map[string]any, len(header))
+		for i, field := range header {
+			if i < len(rec) {
+				row[strings.TrimSpace(field)] = csvValue(rec[i])
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func csvValue(s string) any {
+	var v any
+	if json.Unmarshal([]byte(s), &v) == nil {
+		switch v.(type) {
+		case float64, bool, nil:
+			return v
+		}
+	}
+	return s
+}
+
+func jsonDatasetRows(data []byte) ([]map[string]any, error) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("not valid JSON: %w", err)
+	}
+	if obj, ok := v.(map[string]any); ok && len(obj) == 1 {
+		for _, inner := range obj {
+			v = inner
+		}
+	}
+	list, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("expected a JSON array of objects")
+	}
+	rows := make([]map[string]any, len(list))
+	for i, item := range list {
+		if rows[i], ok = item.(map[string]any); !ok {
+			return nil, fmt.Errorf("item %d is not an object", i+1)
+		}
+	}
+	return rows, nil
+}
+
+// PathParameterNames returns the {name} parameters of a request path, in order
+func PathParameterNames(path string) []string {
+	names, _ := pathTemplate(path)
+	return names
+}
+
+// DatasetUsers returns the indexes of the expectations bound to the named dataset
+func (c *MockConfiguration) DatasetUsers(name string) []int {
+	var users []int
+	for i, exp := range c.Expectations {
+		if exp.Dataset != nil && exp.Dataset.Name == name && !exp.Generated() {
+			users = append(users, i)
+		}
+	}
+	return users
+}
+
+// FindDataset returns the index of the named dataset, or -1
+func (c *MockConfiguration) FindDataset(name string) int {
+	for i, d := range c.Datasets {
+		if d.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// datasetSpec checks exp's dataset binding against the project datasets and its path, and
+// returns what the response template reads of it
+func (c *MockConfiguration) datasetSpec(exp MockExpectation) (map[string]any, error) {
+	b := *exp.Dataset
+	i := c.FindDataset(b.Name)
+	if i < 0 {
+		return nil, fmt.Errorf("unknown dataset %q", b.Name)
+	}
+	d := &c.Datasets[i]
+	if msg := d.Check(); msg != "" {
+		return nil, fmt.Errorf("%s", msg)
+	}
+	spec := map[string]any{"mode": b.Mode, "rows": d.Rows}
+	switch b.Mode {
+	case DatasetItem:
+		if d.Key == "" {
+			return nil, fmt.Errorf("dataset %s has no key field to find a row by", d.Name)
+		}
+		path := ""
+		if exp.HttpRequest != nil {
+			path = exp.HttpRequest.Path
+		}
+		names, _ := pathTemplate(path)
+		if b.Param == "" && len(names) > 0 {
+			b.Param = names[len(names)-1]
+		}
+		if b.Param == "" {
+			return nil, fmt.Errorf("dataset item endpoints need a path parameter holding the %s, e.g. %s/{%s}", d.Key, path, d.Key)
+		}
+		if !contains(names, b.Param) {
+			return nil, fmt.Errorf("dataset item endpoints need {%s} in the request path %q", b.Param, path)
+		}
+		spec["key"], spec["param"] = d.Key, b.Param
+		return spec, nil
+	case DatasetList:
+		if b.PageSize < 0 {
+			return nil, fmt.Errorf("dataset page size must be positive")
+		}
+		if !contains(PagingStyles, b.PagingStyle()) {
+			return nil, fmt.Errorf("unknown pagination style %q (use %s)", b.Paging, strings.Join(PagingStyles, ", "))
+		}
+	default:
+		return nil, fmt.Errorf("unknown dataset mode %q (use %s)", b.Mode, strings.Join(DatasetModes, " or "))
+	}
+	pageParam, sizeParam := b.QueryParams()
+	if pageParam == sizeParam {
+		return nil, fmt.Errorf("dataset page and size query parameters are both %q", pageParam)
+	}
+	spec["paging"], spec["pageSize"], spec["pageParam"], spec["sizeParam"] = b.PagingStyle(), b.PageSize, pageParam, sizeParam
+	if b.PageSize == 0 {
+		spec["pageSize"] = DefaultPageSize
+	}
+	// Cursors are row keys; without one they are positions
+	spec["key"] = d.Key
+	return spec, nil
+}
+
+// datasetDefaultBody is the JSON served by bound expectations without a response body
+func datasetDefaultBody(mode, paging string) any {
+	links := map[string]any{"next": "${dataset.next}", "prev": "${dataset.prev}"}
+	switch {
+	case mode == DatasetItem:
+		return "${dataset.row}"
+	case paging == PagingOffset:
+		return map[string]any{"data": "${dataset.rows}", "offset": "${dataset.offset}", "limit": "${dataset.size}", "total": "${dataset.total}", "links": links}
+	case paging == PagingCursor:
+		return map[string]any{"data": "${dataset.rows}", "nextCursor": "${dataset.nextCursor}", "limit": "${dataset.size}", "total": "${dataset.total}", "links": links}
+	}
+	return map[string]any{"data": "${dataset.rows}", "page": "${dataset.page}", "size": "${dataset.size}", "total": "${dataset.total}", "pages": "${dataset.pages}", "links": links}
+}
+
+// GenerateDataset builds count rows from a row template: ${index} is the row number (from 1)
+// and fake-data placeholders are filled with the project's generators, e.g.
+// {"id": "${index}", "name": "${name}"}
+func (c *MockConfiguration) GenerateDataset(name string, count int, row map[string]any) (Dataset, error) {
+	d := Dataset{Name: name, Source: "generated"}
+	if count < 1 {
+		return d, fmt.Errorf("generate at least one row")
+	}
+	f, err := c.Faker()
+	if err != nil {
+		return d, err
+	}
+	for i := 1; i <= count; i++ {
+		index := i
+		f.Define("index", func(*rand.Rand, time.Time, string) (any, error) { return index, nil })
+		v, err := f.ExpandValue(row)
+		if err != nil {
+			return d, err
+		}
+		d.Rows = append(d.Rows, v.(map[string]any))
+	}
+	d.Key = d.DetectKey()
+	if msg := d.Check(); msg != "" {
+		return d, fmt.Errorf("%s", msg)
+	}
+	return d, nil
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDataset(t *testing.T) {
+	d, err := ParseDataset("products", "data/products.csv", []byte("sku_id,name,price,active\nA-1,Pen,1.5,true\n007,Ink,,false\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []map[string]any{
+		{"sku_id": "A-1", "name": "Pen", "price": 1.5, "active": true},
+		{"sku_id": "007", "name": "Ink", "price": "", "active": false},
+	}
+	if d.Key != "sku_id" || d.Source != "products.csv" || !reflect.DeepEqual(d.Rows, want) {
+		t.Errorf("dataset = %+v", d)
+	}
+
+	d, err = ParseDataset("users", "users.json", []byte(`{"users": [{"id": 1, "name": "Ada"}, {"id": 2, "name": "Grace"}]}`))
+	if err != nil || d.Key != "id" || len(d.Rows) != 2 {
+		t.Errorf("wrapped JSON = %+v, %v", d, err)
+	}
+	if d, _ := ParseDataset("tags", "tags.json", []byte(`[{"id": 1}, {"id": 1}]`)); d.Key != "" {
+		t.Errorf("key of rows with duplicate ids = %q", d.Key)
+	} else if d.Key = "id"; d.Check() != "dataset tags: rows 1 and 2 share id 1" {
+		t.Errorf("check = %q", d.Check())
+	}
+
+	for _, bad := range []struct{ file, data, msg string }{
+		{"users.json", `{"id": 1}`, "array of objects"},
+		{"users.json", `[1, 2]`, "item 1 is not an object"},
+		{"users.csv", "id,name\n", "has no rows"},
+	} {
+		if _, err := ParseDataset("users", bad.file, []byte(bad.data)); err == nil || !strings.Contains(err.Error(), bad.msg) {
+			t.Errorf("%s: error = %v, want %q", bad.data, err, bad.msg)
+		}
+	}
+}
+
+func TestApplyRequestTemplatesDatasetChecks(t *testing.T) {
+	users := Dataset{Name: "users", Key: "id", Rows: []map[string]any{{"id": 1.0}}}
+	for _, tc := range []struct {
+		path    string
+		binding *DatasetBinding
+		body    any
+		msg     string
+	}{
+		{"/users", &DatasetBinding{Name: "people", Mode: DatasetList}, nil, `unknown dataset "people"`},
+		{"/users", &DatasetBinding{Name: "users", Mode: DatasetItem}, nil, "need a path parameter holding the id"},
+		{"/users/{id}", &DatasetBinding{Name: "users", Mode: DatasetItem, Param: "userId"}, nil, "need {userId}"},
+		{"/users", &DatasetBinding{Name: "users", Mode: "table"}, nil, `unknown dataset mode "table"`},
+		{"/users", &DatasetBinding{Name: "users", Mode: DatasetList}, `{"first": "${dataset.row}"}`, "list endpoints serve"},
+		{"/users/{id}", &DatasetBinding{Name: "users", Mode: DatasetItem}, `{"all": "${dataset.rows}"}`, "item endpoints serve"},
+		{"/users", nil, `{"all": "${dataset.rows}"}`, "not bound to a dataset"},
+		{"/users", &DatasetBinding{Name: "users", Mode: DatasetList, Paging: "keyset"}, nil, `unknown pagination style "keyset"`},
+		{"/users", &DatasetBinding{Name: "users", Mode: DatasetList, Paging: PagingOffset, PageParam: "limit"}, nil, `both "limit"`},
+	} {
+		config := &MockConfiguration{Datasets: []Dataset{users}, Expectations: []MockExpectation{{
+			HttpRequest:  &HttpRequest{Method: "GET", Path: tc.path},
+			HttpResponse: &HttpResponse{StatusCode: 200, Body: tc.body},
+			Dataset:      tc.binding,
+		}}}
+		if _, err := config.ApplyRequestTemplates(); err == nil || !strings.Contains(err.Error(), tc.msg) {
+			t.Errorf("%s %+v: error = %v, want %q", tc.path, tc.binding, err, tc.msg)
+		}
+	}
+}
+
+func TestGenerateDataset(t *testing.T) {
+	config := &MockConfiguration{FakeData: &FakeData{Seed: 7, Generators: []DataGenerator{{Name: "tier", Values: []string{"gold"}}}}}
+	d, err := config.GenerateDataset("users", 3, map[string]any{"id": "${index}", "ref": "U-${index}", "tier": "${tier}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []map[string]any{
+		{"id": 1, "ref": "U-1", "tier": "gold"},
+		{"id": 2, "ref": "U-2", "tier": "gold"},
+		{"id": 3, "ref": "U-3", "tier": "gold"},
+	}
+	if d.Key != "id" || d.Source != "generated" || !reflect.DeepEqual(d.Rows, want) {
+		t.Errorf("dataset = %+v", d)
+	}
+	if _, err := config.GenerateDataset("users", 0, map[string]any{"id": "${index}"}); err == nil {
+		t.Error("generated an empty dataset")
+	}
+}
@@ -0,0 +1,244 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Example is one concrete request/response folded into an expectation during import
+type Example struct {
+	Path       string `json:"path"`
+	Body       any    `json:"body,omitempty"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	Response   any    `json:"response,omitempty"`
+	Source     string `json:"source,omitempty"`
+}
+
+// MergeGroup reports the expectations collapsed into one by DeduplicateExpectations
+type MergeGroup struct {
+	Key   string   // normalized "METHOD /path" shared by the group
+	Index int      // position of the merged expectation in the result
+	Paths []string // concrete paths of the merged requests, in input order
+}
+
+var idSegment = regexp.MustCompile(`^(\d+|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|[0-9a-fA-F]{16,}|\{[^/]+\})$`)
+
+// DeduplicateExpectations collapses expectations for identical requests - same method,
+// normalized path (ID-like segments ignored), query parameter names, request body shape
+// and response status - into one expectation carrying every request as an example.
+// When the merged paths differ the path becomes a pattern, and when the bodies differ the
+// body matcher becomes a JSON schema of their shared shape. Iteration variants are kept.
+func DeduplicateExpectations(exps []MockExpectation) ([]MockExpectation, []MergeGroup) {
+	var out []MockExpectation
+	var groups []MergeGroup
+	members := map[string][]int{} // key -> input indexes
+	var order []string
+	for i := range exps {
+		key := dedupKey(&exps[i])
+		if key == "" {
+			order = append(order, fmt.Sprintf("#%d", i))
+			members[order[len(order)-1]] = []int{i}
+			continue
+		}
+		if _, seen := members[key]; !seen {
+			order = append(order, key)
+		}
+		members[key] = append(members[key], i)
+	}
+
+	for _, key := range order {
+		idx := members[key]
+		exp := exps[idx[0]]
+		if len(idx) == 1 {
+			out = append(out, exp)
+			continue
+		}
+		group := MergeGroup{Key: strings.SplitN(key, "\x00", 2)[0], Index: len(out)}
+		req := *exp.HttpRequest
+		var paths []string
+		var bodies []any
+		for _, i := range idx {
+			other := exps[i]
+			paths = append(paths, other.HttpRequest.Path)
+			body := examplePayload(other.HttpRequest.Body)
+			bodies = append(bodies, body)
+			ex := Example{
+				Path:       other.HttpRequest.Path,
+				Body:       body,
+				StatusCode: other.HttpResponse.StatusCode,
+				Response:   examplePayload(other.HttpResponse.Body),
+			}
+			if other.Provenance != nil {
+				ex.Source = other.Provenance.Detail
+			}
+			exp.Examples = append(exp.Examples, ex)
+		}
+		group.Paths = paths
+		req.Path = mergedPath(paths)
+		if !allEqual(bodies) {
+			req.Body = map[string]any{"type": "JSON_SCHEMA", "jsonSchema": shapeSchema(bodies[0])}
+		}
+		exp.HttpRequest = &req
+		out = append(out, exp)
+		groups = append(groups, group)
+	}
+	return out, groups
+}
+
+// FormatMergeReport describes what DeduplicateExpectations merged
+func FormatMergeReport(groups []MergeGroup) string {
+	if len(groups) == 0 {
+		return ""
+	}
+	merged := 0
+	for _, g := range groups {
+		merged += len(g.Paths)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "🧹 Merged %d duplicate request(s) into %d expectation(s):\n", merged, len(groups))
+	for _, g := range groups {
+		shown := g.Paths
+		if len(shown) > 3 {
+			shown = append(shown[:3:3], fmt.Sprintf("… %d more", len(g.Paths)-3))
+		}
+		fmt.Fprintf(&b, "   • %s ← %d requests (%s)\n", g.Key, len(g.Paths), strings.Join(shown, ", "))
+	}
+	return b.String()
+}
+
+// dedupKey normalizes a request; "" marks expectations that are never merged
+func dedupKey(exp *MockExpectation) string {
+	if exp.HttpRequest == nil || exp.HttpResponse == nil || exp.HttpResponseTemplate != nil || exp.Forward != nil || exp.Variant != "" ||
+		exp.Negotiation != nil || exp.Generated() {
+		return ""
+	}
+	r := exp.HttpRequest
+	var queryNames []string
+	for _, q := range r.QueryStringParameters {
+		queryNames = append(queryNames, q.Name)
+	}
+	sort.Strings(queryNames)
+
+	shape := ""
+	if body, ok := responseJSON(r.Body); ok {
+		shape = bodyShape(body)
+	} else if r.Body != nil {
+		raw, _ := json.Marshal(r.Body)
+		shape = "raw:" + string(raw)
+	}
+	return fmt.Sprintf("%s %s\x00%s\x00%s\x00%d", strings.ToUpper(r.Method), normalizePath(r.Path),
+		strings.Join(queryNames, "&"), shape, exp.HttpResponse.StatusCode)
+}
+
+func normalizePath(p string) string {
+	segs := strings.Split(strings.Trim(p, "/"), "/")
+	kept := segs[:0]
+	for _, s := range segs {
+		if s == "" {
+			continue
+		}
+		if idSegment.MatchString(s) {
+			s = "{id}"
+		}
+		kept = append(kept, s)
+	}
+	return "/" + strings.Join(kept, "/")
+}
+
+// mergedPath keeps a shared path as is and turns segments that differ into a pattern
+func mergedPath(paths []string) string {
+	if allEqual(toAny(paths)) {
+		return paths[0]
+	}
+	segs := strings.Split(strings.Trim(paths[0], "/"), "/")
+	for _, p := range paths[1:] {
+		other := strings.Split(strings.Trim(p, "/"), "/")
+		for i := range segs {
+			if i < len(other) && other[i] != segs[i] {
+				segs[i] = "[^/]+"
+			}
+		}
+	}
+	return "/" + strings.Join(segs, "/")
+}
+
+// bodyShape renders a JSON value's structure without its values
+func bodyShape(v any) string {
+	switch t := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			parts[i] = k + ":" + bodyShape(t[k])
+		}
+		return "{" + strings.Join(parts, ",") + "}"
+	case []any:
+		if len(t) == 0 {
+			return "[]"
+		}
+		return "[" + bodyShape(t[0]) + "]"
+	}
+	return jsonKind(v)
+}
+
+// shapeSchema derives a JSON schema requiring the same structure as v
+func shapeSchema(v any) map[string]any {
+	switch t := v.(type) {
+	case map[string]any:
+		props := map[string]any{}
+		required := make([]any, 0, len(t))
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			props[k] = shapeSchema(t[k])
+			required = append(required, k)
+		}
+		return map[string]any{"type": "object", "properties": props, "required": required}
+	case []any:
+		s := map[string]any{"type": "array"}
+		if len(t) > 0 {
+			s["items"] = shapeSchema(t[0])
+		}
+		return s
+	case nil:
+		return map[string]any{"type": "null"}
+	}
+	return map[string]any{"type": jsonKind(v)}
+}
+
+// examplePayload unwraps JSON body envelopes and keeps any other body as is
+func examplePayload(body any) any {
+	if v, ok := responseJSON(body); ok {
+		return v
+	}
+	return body
+}
+
+func allEqual(values []any) bool {
+	first, _ := json.Marshal(values[0])
+	for _, v := range values[1:] {
+		b, _ := json.Marshal(v)
+		if string(b) != string(first) {
+			return false
+		}
+	}
+	return true
+}
+
+func toAny(ss []string) []any {
+	out := make([]any, len(ss))
+	for i, s := range ss {
+		out[i] = s
+	}
+	return out
+}
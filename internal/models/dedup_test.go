@@ -0,0 +1,64 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDeduplicateExpectations_MergesSameShape(t *testing.T) {
+	get := func(path string, id float64) MockExpectation {
+		return MockExpectation{
+			HttpRequest:  &HttpRequest{Method: "GET", Path: path},
+			HttpResponse: &HttpResponse{StatusCode: 200, Body: map[string]any{"type": "JSON", "json": map[string]any{"id": id}}},
+		}
+	}
+	post := func(name string) MockExpectation {
+		return MockExpectation{
+			HttpRequest: &HttpRequest{Method: "POST", Path: "/users/",
+				Body: map[string]any{"type": "JSON", "json": map[string]any{"name": name}}},
+			HttpResponse: &HttpResponse{StatusCode: 201},
+		}
+	}
+	failed := post("x")
+	failed.HttpResponse = &HttpResponse{StatusCode: 400}
+
+	in := []MockExpectation{get("/users/1", 1), post("ann"), get("/users/2", 2), post("bob"), failed, get("/health", 0)}
+	out, groups := DeduplicateExpectations(in)
+
+	if len(out) != 4 || len(groups) != 2 {
+		t.Fatalf("got %d expectations / %d groups, want 4 / 2", len(out), len(groups))
+	}
+	users := out[0]
+	if users.HttpRequest.Path != "/users/[^/]+" || len(users.Examples) != 2 || users.Examples[1].Path != "/users/2" {
+		t.Fatalf("GET merge = %s %+v", users.HttpRequest.Path, users.Examples)
+	}
+	body, _ := out[1].HttpRequest.Body.(map[string]any)
+	if body["type"] != "JSON_SCHEMA" || out[1].HttpRequest.Path != "/users/" {
+		t.Fatalf("POST merge body = %+v", out[1].HttpRequest.Body)
+	}
+	if out[2].HttpResponse.StatusCode != 400 || out[2].Examples != nil {
+		t.Fatalf("different status must not merge: %+v", out[2])
+	}
+	if in[0].Examples != nil || in[0].HttpRequest.Path != "/users/1" {
+		t.Fatalf("input was modified: %+v", in[0])
+	}
+
+	report := FormatMergeReport(groups)
+	if !strings.Contains(report, "Merged 4 duplicate request(s) into 2") || !strings.Contains(report, "GET /users/{id} ← 2 requests (/users/1, /users/2)") {
+		t.Fatalf("report:\n%s", report)
+	}
+}
+
+func TestDeduplicateExpectations_KeepsIterationVariants(t *testing.T) {
+	variant := func(id, key string) MockExpectation {
+		return MockExpectation{
+			HttpRequest:  &HttpRequest{Method: "GET", Path: "/users/" + id},
+			HttpResponse: &HttpResponse{StatusCode: 200},
+			Variant:      key,
+		}
+	}
+	out, groups := DeduplicateExpectations([]MockExpectation{variant("1", "userId=1"), variant("2", "userId=2")})
+	if len(out) != 2 || len(groups) != 0 || out[1].HttpRequest.Path != "/users/2" {
+		t.Fatalf("variants must stay separate: %+v / %+v", out, groups)
+	}
+}
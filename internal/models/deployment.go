@@ -3,6 +3,9 @@ package models
 
 import (
 	"fmt"
+	"os"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -23,6 +26,7 @@ type InfrastructureOutputs struct {
 	IntegrationSummary    map[string]interface{} `json:"integration_summary"`
 	CLICommands           map[string]string      `json:"cli_integration_commands"`
 	InfrastructureSummary map[string]interface{} `json:"infrastructure_summary"`
+	Auth                  *EndpointAuth          `json:"auth,omitempty"` // Credential every request must carry (nil = open)
 }
 
 // DeploymentOptions configures the infrastructure deployment
@@ -55,15 +59,72 @@ type DeploymentOptions struct {
 	TaskRoleARN         string `json:"task_role_arn,omitempty"`      // ECS task role (app permissions)
 
 	// === App Settings ===
-	ProjectName string `json:"-"`
-	Region      string `json:"-"`
-	BucketName  string `json:"-"`
-	Provider    string `json:"provider,omitempty"`
+	ProjectName    string `json:"-"`
+	Region         string `json:"-"`
+	BucketName     string `json:"-"`
+	StorageAccount string `json:"-"` // Azure storage account holding BucketName (the container)
+	Provider       string `json:"provider,omitempty"`
+
+	// === Custom Domain (AWS) ===
+	Domain       string `json:"domain,omitempty"`         // Served over HTTPS through CloudFront (empty = ALB URL only)
+	HostedZoneID string `json:"hosted_zone_id,omitempty"` // Route53 zone of Domain (empty = zone of its parent)
+
+	// === Endpoint Protection (AWS) ===
+	Auth *EndpointAuth `json:"-"` // Required on every request through a WAF on the load balancer (nil = open)
+}
+
+// domainPattern matches lowercase host names with at least two labels
+var domainPattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)+[a-z]{2,}$`)
+
+// ValidateDomain checks a custom domain before anything is provisioned for it
+func ValidateDomain(domain string) error {
+	if !domainPattern.MatchString(domain) {
+		return fmt.Errorf("%q is not a host name such as mock.mycompany.dev", domain)
+	}
+	return nil
+}
+
+// Sizing chosen up front (deploy flags or automock.yaml) so the deployment prompts can skip it
+const (
+	EnvInstanceSize = "AUTOMOCK_INSTANCE_SIZE"
+	EnvMinTasks     = "AUTOMOCK_MIN_TASKS"
+	EnvMaxTasks     = "AUTOMOCK_MAX_TASKS"
+)
+
+// ApplySizingEnv fills instance size and task counts from AUTOMOCK_INSTANCE_SIZE,
+// AUTOMOCK_MIN_TASKS and AUTOMOCK_MAX_TASKS when they are set
+func (d *DeploymentOptions) ApplySizingEnv() error {
+	if size := os.Getenv(EnvInstanceSize); size != "" {
+		switch size {
+		case "small", "medium", "large", "xlarge":
+			d.InstanceSize = size
+		default:
+			return fmt.Errorf("%s must be small, medium, large or xlarge, got %q", EnvInstanceSize, size)
+		}
+	}
+	for env, field := range map[string]*int{EnvMinTasks: &d.MinTasks, EnvMaxTasks: &d.MaxTasks} {
+		raw := os.Getenv(env)
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("%s must be a positive number, got %q", env, raw)
+		}
+		*field = n
+	}
+	if d.MaxTasks != 0 && d.MaxTasks < d.MinTasks {
+		return fmt.Errorf("max tasks (%d) must be at least min tasks (%d)", d.MaxTasks, d.MinTasks)
+	}
+	return nil
 }
 
 // CreateTerraformVars renders terraform.tfvars as HCL based on DeploymentOptions.
 // It supports both BYO and "tool creates" modes by emitting explicit use_existing_* flags.
 func (d *DeploymentOptions) CreateTerraformVars() string {
+	if d.Provider == "azure" {
+		return d.createAzureTerraformVars()
+	}
 	var b strings.Builder
 
 	fmt.Fprintf(&b, `# AutoMock Terraform Variables
@@ -144,9 +205,44 @@ cloud_provider       = "%s"
 		}
 	}
 
+	// Custom domain (ACM certificate, CloudFront and Route53 records)
+	if d.Domain != "" {
+		fmt.Fprintf(&b, "\ndomain             = \"%s\"\n", d.Domain)
+		if d.HostedZoneID != "" {
+			fmt.Fprintf(&b, "hosted_zone_id     = \"%s\"\n", d.HostedZoneID)
+		}
+	}
+
+	// Endpoint protection (WAF web ACL on the ALB)
+	if d.Auth != nil {
+		fmt.Fprintf(&b, "\nauth_header        = \"%s\"\n", d.Auth.Header)
+		fmt.Fprintf(&b, "auth_value         = \"%s\"\n", d.Auth.Value())
+	}
+
 	return b.String()
 }
 
+// createAzureTerraformVars renders the variables of the Azure Container Instances stack;
+// networking and identities are always created by the stack, so there are no BYO toggles
+func (d *DeploymentOptions) createAzureTerraformVars() string {
+	return fmt.Sprintf(`# AutoMock Terraform Variables
+# Generated automatically - do not edit manually
+
+project_name         = "%s"
+azure_location       = "%s"
+instance_size        = "%s"
+storage_account_name = "%s"
+container_name       = "%s"
+cloud_provider       = "azure"
+`,
+		d.ProjectName,
+		d.Region,
+		d.InstanceSize,
+		d.StorageAccount,
+		d.BucketName,
+	)
+}
+
 func formatStringList(xs []string) string {
 	quoted := make([]string, 0, len(xs))
 	for _, s := range xs {
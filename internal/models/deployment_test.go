@@ -0,0 +1,79 @@
+package models
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDeploymentOptions_DomainTerraformVars(t *testing.T) {
+	opts := &DeploymentOptions{ProjectName: "demo", Region: "us-east-1", InstanceSize: "small", BucketName: "auto-mock-demo-1234", Provider: "aws",
+		Domain: "mock.mycompany.dev", HostedZoneID: "Z0123456789ABC"}
+	vars := opts.CreateTerraformVars()
+	for _, c := range []string{"domain             = \"mock.mycompany.dev\"", "hosted_zone_id     = \"Z0123456789ABC\""} {
+		if !containsLine(vars, c) {
+			t.Fatalf("expected tfvars to contain line: %s\nGot:\n%s", c, vars)
+		}
+	}
+
+	opts.Domain, opts.HostedZoneID = "", ""
+	if vars := opts.CreateTerraformVars(); strings.Contains(vars, "domain ") {
+		t.Fatalf("domain emitted without one set:\n%s", vars)
+	}
+}
+
+func TestValidateDomain(t *testing.T) {
+	for _, domain := range []string{"mock.mycompany.dev", "api-mocks.eu.example.com"} {
+		if err := ValidateDomain(domain); err != nil {
+			t.Errorf("ValidateDomain(%q) = %v", domain, err)
+		}
+	}
+	for _, domain := range []string{"", "localhost", "https://mock.mycompany.dev", "Mock.MyCompany.dev", "-mock.mycompany.dev", "mock.mycompany.dev/"} {
+		if err := ValidateDomain(domain); err == nil {
+			t.Errorf("ValidateDomain(%q) accepted", domain)
+		}
+	}
+}
+
+func TestEndpointAuth(t *testing.T) {
+	key, err := ResolveEndpointAuth(AuthAPIKey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.Header != APIKeyHeader || !strings.HasPrefix(key.Value(), "amk_") || len(key.Secret) < 32 {
+		t.Errorf("api key = %+v", key)
+	}
+	basic, _ := NewEndpointAuth(AuthBasic)
+	if basic.Header != "Authorization" || !strings.HasPrefix(basic.Value(), "Basic ") {
+		t.Errorf("basic = %+v, value %q", basic, basic.Value())
+	}
+	if again, _ := NewEndpointAuth(AuthAPIKey); again.Secret == key.Secret {
+		t.Error("credentials repeat")
+	}
+
+	if kept, _ := ResolveEndpointAuth("", key); kept != key {
+		t.Error("recorded credential not kept")
+	}
+	if removed, _ := ResolveEndpointAuth(AuthNone, key); removed != nil {
+		t.Error("none kept the credential")
+	}
+	if _, err := ResolveEndpointAuth("token", nil); err == nil {
+		t.Error("unknown kind accepted")
+	}
+
+	opts := &DeploymentOptions{ProjectName: "demo", Region: "us-east-1", Provider: "aws", Auth: basic}
+	if vars := opts.CreateTerraformVars(); !containsLine(vars, `auth_header        = "Authorization"`) || !containsLine(vars, `auth_value         = "`+basic.Value()+`"`) {
+		t.Errorf("tfvars:\n%s", vars)
+	}
+
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { got = r.Header.Get(APIKeyHeader) }))
+	defer server.Close()
+	if _, err := (&http.Client{Transport: key.Transport()}).Get(server.URL); err != nil || got != key.Secret {
+		t.Errorf("transport sent %q, %v", got, err)
+	}
+	if (*EndpointAuth)(nil).Transport() != nil {
+		t.Error("open deployment got a transport")
+	}
+}
@@ -0,0 +1,523 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeSeverity classifies an expectation change from the consumer's point of view
+type ChangeSeverity string
+
+const (
+	ChangeBreaking    ChangeSeverity = "breaking"
+	ChangeNonBreaking ChangeSeverity = "non-breaking"
+)
+
+// ExpectationChange describes a single difference between two configuration versions
+type ExpectationChange struct {
+	Endpoint string         `json:"endpoint"` // e.g. "GET /users/{id}"
+	Severity ChangeSeverity `json:"severity"`
+	Summary  string         `json:"summary"`
+}
+
+// ConfigDiff is the classified difference between two configuration versions
+type ConfigDiff struct {
+	FromVersion string              `json:"from_version,omitempty"`
+	ToVersion   string              `json:"to_version,omitempty"`
+	Changes     []ExpectationChange `json:"changes"`
+
+	detailed bool // also report changes that do not affect the contract; see DiffVersions
+}
+
+// HasBreaking reports whether any change would break an existing consumer
+func (d *ConfigDiff) HasBreaking() bool {
+	return len(d.Breaking()) > 0
+}
+
+// Breaking returns only the breaking changes
+func (d *ConfigDiff) Breaking() []ExpectationChange {
+	var out []ExpectationChange
+	for _, c := range d.Changes {
+		if c.Severity == ChangeBreaking {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Format renders the changes grouped by endpoint, with a summary of added, removed and
+// modified endpoints
+func (d *ConfigDiff) Format() string {
+	var b strings.Builder
+	if len(d.Changes) == 0 {
+		fmt.Fprintf(&b, "No differences between %s and %s\n", d.FromVersion, d.ToVersion)
+		return b.String()
+	}
+	var order []string
+	byEndpoint := map[string][]ExpectationChange{}
+	for _, c := range d.Changes {
+		if _, seen := byEndpoint[c.Endpoint]; !seen {
+			order = append(order, c.Endpoint)
+		}
+		byEndpoint[c.Endpoint] = append(byEndpoint[c.Endpoint], c)
+	}
+	added, removed := 0, 0
+	for _, ep := range order {
+		changes := byEndpoint[ep]
+		switch {
+		case len(changes) == 1 && changes[0].Summary == "endpoint added":
+			added++
+			fmt.Fprintf(&b, "➕ %s\n", ep)
+			continue
+		case len(changes) == 1 && changes[0].Summary == "endpoint removed":
+			removed++
+			fmt.Fprintf(&b, "➖ %s\n", ep)
+			continue
+		}
+		fmt.Fprintf(&b, "✏️  %s\n", ep)
+		for _, c := range changes {
+			icon := "   •"
+			if c.Severity == ChangeBreaking {
+				icon = "   💥"
+			}
+			fmt.Fprintf(&b, "%s %s\n", icon, c.Summary)
+		}
+	}
+	fmt.Fprintf(&b, "\n%s → %s: %d endpoint(s) added, %d removed, %d modified; %d breaking change(s)\n",
+		d.FromVersion, d.ToVersion, added, removed, len(order)-added-removed, len(d.Breaking()))
+	return b.String()
+}
+
+func (d *ConfigDiff) add(endpoint string, severity ChangeSeverity, format string, args ...any) {
+	d.Changes = append(d.Changes, ExpectationChange{
+		Endpoint: endpoint,
+		Severity: severity,
+		Summary:  fmt.Sprintf(format, args...),
+	})
+}
+
+// DiffConfigurations compares two configuration versions and classifies every change.
+// Removed endpoints, changed status codes, removed/retyped response fields and narrowed
+// request matchers are breaking; additions and widened matchers are not.
+func DiffConfigurations(from, to *MockConfiguration) *ConfigDiff {
+	return diffConfigurations(from, to, false)
+}
+
+// DiffVersions is DiffConfigurations plus the changes that leave the contract intact:
+// response values, templates, priorities, times limits, tags, descriptions and notes.
+// It backs automock diff, where every edit between two saved versions matters.
+func DiffVersions(from, to *MockConfiguration) *ConfigDiff {
+	return diffConfigurations(from, to, true)
+}
+
+func diffConfigurations(from, to *MockConfiguration, detailed bool) *ConfigDiff {
+	diff := &ConfigDiff{detailed: detailed}
+	if from != nil {
+		diff.FromVersion = from.Metadata.Version
+	}
+	if to != nil {
+		diff.ToVersion = to.Metadata.Version
+	}
+
+	oldGroups := groupByEndpoint(from)
+	newGroups := groupByEndpoint(to)
+
+	endpoints := make([]string, 0, len(oldGroups)+len(newGroups))
+	for ep := range oldGroups {
+		endpoints = append(endpoints, ep)
+	}
+	for ep := range newGroups {
+		if _, ok := oldGroups[ep]; !ok {
+			endpoints = append(endpoints, ep)
+		}
+	}
+	sort.Strings(endpoints)
+
+	for _, ep := range endpoints {
+		olds, news := oldGroups[ep], newGroups[ep]
+		switch {
+		case len(news) == 0:
+			diff.add(ep, ChangeBreaking, "endpoint removed")
+			continue
+		case len(olds) == 0:
+			diff.add(ep, ChangeNonBreaking, "endpoint added")
+			continue
+		}
+
+		pairs, removed, added := pairVariants(olds, news)
+		for _, p := range pairs {
+			diffExpectation(diff, ep, p[0], p[1])
+		}
+		for _, e := range removed {
+			diff.add(ep, ChangeBreaking, "response variant %s removed", variantLabel(e))
+		}
+		for _, e := range added {
+			diff.add(ep, ChangeNonBreaking, "response variant %s added", variantLabel(e))
+		}
+	}
+	return diff
+}
+
+func groupByEndpoint(config *MockConfiguration) map[string][]*MockExpectation {
+	groups := map[string][]*MockExpectation{}
+	if config == nil {
+		return groups
+	}
+	for i := range config.Expectations {
+		exp := &config.Expectations[i]
+		if exp.HttpRequest == nil || isInfoExpectation(exp) || exp.Generated() {
+			continue // negotiated variants and 304 companions are derived from another expectation
+		}
+		method := strings.ToUpper(exp.HttpRequest.Method)
+		if method == "" {
+			method = "ANY"
+		}
+		ep := method + " " + exp.HttpRequest.Path
+		groups[ep] = append(groups[ep], exp)
+	}
+	return groups
+}
+
+// pairVariants matches expectations of one endpoint across versions: by ID first, then by
+// status code plus request matcher signature, then by status code alone. A lone leftover on
+// each side is treated as the same expectation so that status code changes are reported.
+func pairVariants(olds, news []*MockExpectation) (pairs [][2]*MockExpectation, removed, added []*MockExpectation) {
+	usedOld := make([]bool, len(olds))
+	usedNew := make([]bool, len(news))
+
+	match := func(key func(*MockExpectation) string) {
+		for i, o := range olds {
+			if usedOld[i] || key(o) == "" {
+				continue
+			}
+			for j, n := range news {
+				if !usedNew[j] && key(o) == key(n) {
+					pairs = append(pairs, [2]*MockExpectation{o, n})
+					usedOld[i], usedNew[j] = true, true
+					break
+				}
+			}
+		}
+	}
+	match(func(e *MockExpectation) string { return e.ID })
+	match(func(e *MockExpectation) string { return variantLabel(e) + "|" + requestSignature(e.HttpRequest) })
+	match(variantLabel)
+
+	for i, o := range olds {
+		if !usedOld[i] {
+			removed = append(removed, o)
+		}
+	}
+	for j, n := range news {
+		if !usedNew[j] {
+			added = append(added, n)
+		}
+	}
+	if len(removed) == 1 && len(added) == 1 {
+		pairs = append(pairs, [2]*MockExpectation{removed[0], added[0]})
+		return pairs, nil, nil
+	}
+	return pairs, removed, added
+}
+
+func variantLabel(e *MockExpectation) string {
+	if e.HttpResponse == nil {
+		if e.Forward != nil {
+			return "forward"
+		}
+		return "no-response"
+	}
+	return fmt.Sprintf("%d", e.HttpResponse.StatusCode)
+}
+
+func requestSignature(req *HttpRequest) string {
+	if req == nil {
+		return ""
+	}
+	var parts []string
+	for _, q := range req.QueryStringParameters {
+		parts = append(parts, "q:"+q.Name+"="+strings.Join(q.Values, ","))
+	}
+	for _, h := range req.Headers {
+		parts = append(parts, "h:"+strings.ToLower(h.Name)+"="+strings.Join(h.Values, ","))
+	}
+	if req.Body != nil {
+		b, _ := json.Marshal(req.Body)
+		parts = append(parts, "b:"+string(b))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, "&")
+}
+
+func diffExpectation(diff *ConfigDiff, ep string, old, cur *MockExpectation) {
+	diffRequestMatchers(diff, ep, old.HttpRequest, cur.HttpRequest)
+	if diff.detailed {
+		diffSettings(diff, ep, old, cur)
+	}
+
+	switch {
+	case old.HttpResponse == nil && cur.HttpResponse == nil:
+		return
+	case old.HttpResponse == nil:
+		diff.add(ep, ChangeBreaking, "forward replaced by a mocked %d response", cur.HttpResponse.StatusCode)
+		return
+	case cur.HttpResponse == nil:
+		diff.add(ep, ChangeBreaking, "%d response replaced by a forward", old.HttpResponse.StatusCode)
+		return
+	}
+
+	oldResp, newResp := old.HttpResponse, cur.HttpResponse
+	label := variantLabel(old)
+	if oldResp.StatusCode != newResp.StatusCode {
+		diff.add(ep, ChangeBreaking, "status code changed %d → %d", oldResp.StatusCode, newResp.StatusCode)
+		label = fmt.Sprintf("%d→%d", oldResp.StatusCode, newResp.StatusCode)
+	}
+
+	oldHeaders := nameValuesMap(oldResp.Headers, true)
+	newHeaders := nameValuesMap(newResp.Headers, true)
+	for _, name := range sortedKeys(oldHeaders) {
+		if _, ok := newHeaders[name]; !ok {
+			diff.add(ep, ChangeBreaking, "[%s] response header %s removed", label, name)
+		} else if name == "content-type" && !reflect.DeepEqual(oldHeaders[name], newHeaders[name]) {
+			diff.add(ep, ChangeBreaking, "[%s] Content-Type changed %s → %s", label,
+				strings.Join(oldHeaders[name], ","), strings.Join(newHeaders[name], ","))
+		}
+	}
+	for _, name := range sortedKeys(newHeaders) {
+		if _, ok := oldHeaders[name]; !ok {
+			diff.add(ep, ChangeNonBreaking, "[%s] response header %s added", label, name)
+		}
+	}
+
+	oldBody, oldIsJSON := responseJSON(oldResp.Body)
+	newBody, newIsJSON := responseJSON(newResp.Body)
+	switch {
+	case oldIsJSON && newIsJSON:
+		diffJSONShape(diff, ep, label, "$", oldBody, newBody)
+	case oldIsJSON != newIsJSON && oldResp.Body != nil:
+		diff.add(ep, ChangeBreaking, "[%s] response body format changed", label)
+	case !reflect.DeepEqual(oldResp.Body, newResp.Body):
+		diff.add(ep, ChangeNonBreaking, "[%s] response body changed", label)
+	}
+
+	if !reflect.DeepEqual(oldResp.Delay, newResp.Delay) {
+		diff.add(ep, ChangeNonBreaking, "[%s] response delay changed", label)
+	}
+}
+
+func diffRequestMatchers(diff *ConfigDiff, ep string, old, cur *HttpRequest) {
+	if old == nil || cur == nil {
+		return
+	}
+	compare := func(kind string, oldNV, newNV []NameValues, fold bool) {
+		oldMap, newMap := nameValuesMap(oldNV, fold), nameValuesMap(newNV, fold)
+		for _, name := range sortedKeys(newMap) {
+			oldVals, existed := oldMap[name]
+			switch {
+			case !existed:
+				diff.add(ep, ChangeBreaking, "matcher narrowed: %s %s now required", kind, name)
+			case !valuesWidened(oldVals, newMap[name]):
+				diff.add(ep, ChangeBreaking, "matcher narrowed: %s %s values changed %v → %v", kind, name, oldVals, newMap[name])
+			case !reflect.DeepEqual(oldVals, newMap[name]):
+				diff.add(ep, ChangeNonBreaking, "matcher widened: %s %s accepts more values", kind, name)
+			}
+		}
+		for _, name := range sortedKeys(oldMap) {
+			if _, ok := newMap[name]; !ok {
+				diff.add(ep, ChangeNonBreaking, "matcher widened: %s %s no longer required", kind, name)
+			}
+		}
+	}
+	compare("query parameter", old.QueryStringParameters, cur.QueryStringParameters, false)
+	compare("header", old.Headers, cur.Headers, true)
+
+	switch {
+	case old.Body == nil && cur.Body != nil:
+		diff.add(ep, ChangeBreaking, "matcher narrowed: request body matcher added")
+	case old.Body != nil && cur.Body == nil:
+		diff.add(ep, ChangeNonBreaking, "matcher widened: request body matcher removed")
+	case !reflect.DeepEqual(old.Body, cur.Body):
+		if bodyMatchType(old.Body) != "STRICT" && bodyMatchType(cur.Body) == "STRICT" {
+			diff.add(ep, ChangeBreaking, "matcher narrowed: request body match type is now STRICT")
+		} else {
+			diff.add(ep, ChangeBreaking, "matcher narrowed: request body matcher changed")
+		}
+	}
+}
+
+// valuesWidened reports whether every previously accepted value is still accepted
+func valuesWidened(oldVals, newVals []string) bool {
+	accepted := make(map[string]bool, len(newVals))
+	for _, v := range newVals {
+		accepted[v] = true
+	}
+	for _, v := range oldVals {
+		if !accepted[v] {
+			return false
+		}
+	}
+	return true
+}
+
+func bodyMatchType(body any) string {
+	if m, ok := body.(map[string]any); ok {
+		if mt, ok := m["matchType"].(string); ok {
+			return strings.ToUpper(mt)
+		}
+	}
+	return ""
+}
+
+// responseJSON extracts the JSON payload of a response body, unwrapping the
+// {"type":"JSON","json":...} envelope and JSON-encoded strings
+func responseJSON(body any) (any, bool) {
+	switch b := body.(type) {
+	case nil:
+		return nil, false
+	case map[string]any:
+		if t, ok := b["type"].(string); ok {
+			if strings.EqualFold(t, "JSON") {
+				if s, ok := b["json"].(string); ok {
+					return responseJSON(s)
+				}
+				return b["json"], true
+			}
+			return nil, false
+		}
+		return b, true
+	case []any:
+		return b, true
+	case string:
+		var v any
+		trimmed := strings.TrimSpace(b)
+		if (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && json.Unmarshal([]byte(trimmed), &v) == nil {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func diffJSONShape(diff *ConfigDiff, ep, label, path string, old, cur any) {
+	if jsonKind(old) != jsonKind(cur) && old != nil && cur != nil {
+		diff.add(ep, ChangeBreaking, "[%s] response field %s changed type %s → %s", label, path, jsonKind(old), jsonKind(cur))
+		return
+	}
+	switch o := old.(type) {
+	case map[string]any:
+		n, _ := cur.(map[string]any)
+		for _, k := range sortedKeys(o) {
+			if _, ok := n[k]; !ok {
+				diff.add(ep, ChangeBreaking, "[%s] response field %s.%s removed", label, path, k)
+				continue
+			}
+			diffJSONShape(diff, ep, label, path+"."+k, o[k], n[k])
+		}
+		for _, k := range sortedKeys(n) {
+			if _, ok := o[k]; !ok {
+				diff.add(ep, ChangeNonBreaking, "[%s] response field %s.%s added", label, path, k)
+			}
+		}
+	case []any:
+		n, _ := cur.([]any)
+		if !diff.detailed {
+			if len(o) > 0 && len(n) > 0 {
+				diffJSONShape(diff, ep, label, path+"[]", o[0], n[0])
+			}
+			return
+		}
+		if len(o) != len(n) {
+			diff.add(ep, ChangeNonBreaking, "[%s] response array %s has %d → %d item(s)", label, path, len(o), len(n))
+		}
+		for i := 0; i < len(o) && i < len(n); i++ {
+			diffJSONShape(diff, ep, label, fmt.Sprintf("%s[%d]", path, i), o[i], n[i])
+		}
+	default:
+		if diff.detailed && cur != nil && !reflect.DeepEqual(old, cur) {
+			diff.add(ep, ChangeNonBreaking, "[%s] response value %s changed %s → %s", label, path, jsonText(old), jsonText(cur))
+		}
+	}
+}
+
+// diffSettings reports the changes DiffVersions adds to a pair of expectations
+func diffSettings(diff *ConfigDiff, ep string, old, cur *MockExpectation) {
+	label := variantLabel(old)
+	if old.Priority != cur.Priority {
+		diff.add(ep, ChangeNonBreaking, "[%s] priority changed %d → %d", label, old.Priority, cur.Priority)
+	}
+	if timesLabel(old.Times) != timesLabel(cur.Times) {
+		diff.add(ep, ChangeNonBreaking, "[%s] times changed %s → %s", label, timesLabel(old.Times), timesLabel(cur.Times))
+	}
+	if strings.Join(old.Tags, ",") != strings.Join(cur.Tags, ",") {
+		diff.add(ep, ChangeNonBreaking, "[%s] tags changed [%s] → [%s]", label, strings.Join(old.Tags, ", "), strings.Join(cur.Tags, ", "))
+	}
+	if old.Description != cur.Description {
+		diff.add(ep, ChangeNonBreaking, "[%s] description changed %q → %q", label, old.Description, cur.Description)
+	}
+	if old.Notes != cur.Notes {
+		diff.add(ep, ChangeNonBreaking, "[%s] notes changed", label)
+	}
+	if !reflect.DeepEqual(old.HttpResponseTemplate, cur.HttpResponseTemplate) {
+		diff.add(ep, ChangeNonBreaking, "[%s] response template changed", label)
+	}
+	if !reflect.DeepEqual(old.Forward, cur.Forward) && old.Forward != nil && cur.Forward != nil {
+		diff.add(ep, ChangeNonBreaking, "[%s] forward target changed", label)
+	}
+}
+
+func timesLabel(t *Times) string {
+	if t == nil || t.Unlimited || t.RemainingTimes <= 0 {
+		return "unlimited"
+	}
+	return fmt.Sprint(t.RemainingTimes)
+}
+
+// jsonText renders a JSON value for a change summary, shortening long strings
+func jsonText(v any) string {
+	b, _ := json.Marshal(v)
+	if r := []rune(string(b)); len(r) > 40 {
+		return string(r[:37]) + "..."
+	}
+	return string(b)
+}
+
+func jsonKind(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64, int, int64, json.Number:
+		return "number"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func nameValuesMap(nvs []NameValues, foldCase bool) map[string][]string {
+	out := make(map[string][]string, len(nvs))
+	for _, nv := range nvs {
+		name := nv.Name
+		if foldCase {
+			name = strings.ToLower(name)
+		}
+		out[name] = append(out[name], nv.Values...)
+	}
+	return out
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
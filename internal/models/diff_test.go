@@ -0,0 +1,122 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func jsonBody(v map[string]any) map[string]any {
+	return map[string]any{"type": "JSON", "json": v}
+}
+
+func TestDiffConfigurations_ClassifiesChanges(t *testing.T) {
+	from := &MockConfiguration{Expectations: []MockExpectation{
+		{
+			HttpRequest:  &HttpRequest{Method: "GET", Path: "/users/1"},
+			HttpResponse: &HttpResponse{StatusCode: 200, Body: jsonBody(map[string]any{"id": "1", "email": "a@b.c"})},
+		},
+		{
+			HttpRequest:  &HttpRequest{Method: "POST", Path: "/orders"},
+			HttpResponse: &HttpResponse{StatusCode: 201},
+		},
+		{
+			HttpRequest:  &HttpRequest{Method: "DELETE", Path: "/users/1"},
+			HttpResponse: &HttpResponse{StatusCode: 204},
+		},
+	}}
+	to := &MockConfiguration{Expectations: []MockExpectation{
+		{
+			HttpRequest:  &HttpRequest{Method: "GET", Path: "/users/1"},
+			HttpResponse: &HttpResponse{StatusCode: 200, Body: jsonBody(map[string]any{"id": "1", "name": "Ada"})},
+		},
+		{
+			HttpRequest: &HttpRequest{Method: "POST", Path: "/orders",
+				Headers: []NameValues{{Name: "X-Api-Key", Values: []string{"secret"}}}},
+			HttpResponse: &HttpResponse{StatusCode: 200},
+		},
+		{
+			HttpRequest:  &HttpRequest{Method: "GET", Path: "/health"},
+			HttpResponse: &HttpResponse{StatusCode: 200},
+		},
+	}}
+
+	diff := DiffConfigurations(from, to)
+	want := map[string]ChangeSeverity{
+		"DELETE /users/1|endpoint removed":                             ChangeBreaking,
+		"GET /health|endpoint added":                                   ChangeNonBreaking,
+		"GET /users/1|[200] response field $.email removed":            ChangeBreaking,
+		"GET /users/1|[200] response field $.name added":               ChangeNonBreaking,
+		"POST /orders|status code changed 201 → 200":                   ChangeBreaking,
+		"POST /orders|matcher narrowed: header x-api-key now required": ChangeBreaking,
+	}
+	got := map[string]ChangeSeverity{}
+	for _, c := range diff.Changes {
+		got[c.Endpoint+"|"+c.Summary] = c.Severity
+	}
+	for k, sev := range want {
+		if got[k] != sev {
+			t.Errorf("expected %q to be %s, got %q (all: %v)", k, sev, got[k], got)
+		}
+	}
+	if len(diff.Breaking()) != 4 {
+		t.Errorf("expected 4 breaking changes, got %d: %v", len(diff.Breaking()), diff.Breaking())
+	}
+}
+
+func TestDiffConfigurations_WidenedMatcherIsNotBreaking(t *testing.T) {
+	req := func(values ...string) *HttpRequest {
+		return &HttpRequest{Method: "GET", Path: "/search",
+			QueryStringParameters: []NameValues{{Name: "q", Values: values}}}
+	}
+	from := &MockConfiguration{Expectations: []MockExpectation{{HttpRequest: req("a"), HttpResponse: &HttpResponse{StatusCode: 200}}}}
+	to := &MockConfiguration{Expectations: []MockExpectation{{HttpRequest: req("a", "b"), HttpResponse: &HttpResponse{StatusCode: 200}}}}
+
+	if diff := DiffConfigurations(from, to); diff.HasBreaking() {
+		t.Fatalf("expected no breaking changes, got %v", diff.Breaking())
+	}
+	if diff := DiffConfigurations(to, from); !diff.HasBreaking() {
+		t.Fatal("expected dropping an accepted query value to be breaking")
+	}
+}
+
+func TestDiffVersions_ReportsValueAndSettingChanges(t *testing.T) {
+	from := &MockConfiguration{Metadata: ConfigMetadata{Version: "v1"}, Expectations: []MockExpectation{
+		{
+			HttpRequest:  &HttpRequest{Method: "GET", Path: "/users/1"},
+			HttpResponse: &HttpResponse{StatusCode: 200, Body: jsonBody(map[string]any{"name": "Ada", "roles": []any{"admin"}})},
+		},
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/health"}, HttpResponse: &HttpResponse{StatusCode: 200}},
+	}}
+	to := &MockConfiguration{Metadata: ConfigMetadata{Version: "v2"}, Expectations: []MockExpectation{
+		{
+			Priority:     5,
+			Notes:        "Admin user",
+			HttpRequest:  &HttpRequest{Method: "GET", Path: "/users/1"},
+			HttpResponse: &HttpResponse{StatusCode: 200, Body: jsonBody(map[string]any{"name": "Grace", "roles": []any{"admin", "ops"}})},
+		},
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/health"}, HttpResponse: &HttpResponse{StatusCode: 200}},
+	}}
+
+	if diff := DiffConfigurations(from, to); len(diff.Changes) != 0 {
+		t.Fatalf("contract diff reported value changes: %v", diff.Changes)
+	}
+	diff := DiffVersions(from, to)
+	got := map[string]bool{}
+	for _, c := range diff.Changes {
+		got[c.Summary] = true
+	}
+	for _, want := range []string{
+		`[200] priority changed 0 → 5`,
+		`[200] notes changed`,
+		`[200] response value $.name changed "Ada" → "Grace"`,
+		`[200] response array $.roles has 1 → 2 item(s)`,
+	} {
+		if !got[want] {
+			t.Errorf("missing %q in %v", want, diff.Changes)
+		}
+	}
+	out := diff.Format()
+	if !strings.Contains(out, "✏️  GET /users/1") || !strings.Contains(out, "v1 → v2: 0 endpoint(s) added, 0 removed, 1 modified") {
+		t.Errorf("unexpected format:\n%s", out)
+	}
+}
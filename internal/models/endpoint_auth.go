@@ -0,0 +1,92 @@
+package models
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+)
+
+// Credential kinds 'deploy --auth' protects a deployment with
+const (
+	AuthAPIKey = "api-key"
+	AuthBasic  = "basic"
+	AuthNone   = "none" // removes the protection of an earlier deploy
+)
+
+// APIKeyHeader carries the key of an api-key protected deployment; it is distinct from
+// X-API-Key so expectations can still match the keys of the mocked API itself
+const APIKeyHeader = "X-AutoMock-Key"
+
+// basicAuthUser is the user name of basic auth protected deployments
+const basicAuthUser = "automock"
+
+// EndpointAuth is the credential a protected deployment requires on every request. It is
+// recorded in the deployment metadata, so status shows it and automock's own clients send it.
+type EndpointAuth struct {
+	Type     string `json:"type"` // api-key or basic
+	Header   string `json:"header"`
+	Username string `json:"username,omitempty"` // basic only
+	Secret   string `json:"secret"`             // the API key or the basic auth password
+}
+
+// NewEndpointAuth generates a credential of the given kind
+func NewEndpointAuth(kind string) (*EndpointAuth, error) {
+	raw := make([]byte, 24)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate credential: %w", err)
+	}
+	secret := base64.RawURLEncoding.EncodeToString(raw)
+	switch kind {
+	case AuthAPIKey:
+		return &EndpointAuth{Type: kind, Header: APIKeyHeader, Secret: "amk_" + secret}, nil
+	case AuthBasic:
+		return &EndpointAuth{Type: kind, Header: "Authorization", Username: basicAuthUser, Secret: secret}, nil
+	}
+	return nil, fmt.Errorf("--auth must be %s, %s or %s, got %q", AuthAPIKey, AuthBasic, AuthNone, kind)
+}
+
+// ResolveEndpointAuth returns the credential a deploy protects the mocks with: kind "" keeps
+// the recorded one (nil = open), none removes it, and api-key or basic issue a new one
+func ResolveEndpointAuth(kind string, recorded *EndpointAuth) (*EndpointAuth, error) {
+	switch kind {
+	case "":
+		return recorded, nil
+	case AuthNone:
+		return nil, nil
+	}
+	return NewEndpointAuth(kind)
+}
+
+// Value returns the exact header value requests must carry
+func (a *EndpointAuth) Value() string {
+	if a.Type == AuthBasic {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(a.Username+":"+a.Secret))
+	}
+	return a.Secret
+}
+
+// Describe tells how to call the protected mocks, e.g. in status output
+func (a *EndpointAuth) Describe() string {
+	if a.Type == AuthBasic {
+		return fmt.Sprintf("basic auth, user %s, password %s", a.Username, a.Secret)
+	}
+	return fmt.Sprintf("header %s: %s", a.Header, a.Secret)
+}
+
+// Transport returns an HTTP transport sending the credential with every request; nil
+// (an open deployment) returns nil, which http.Client treats as the default transport
+func (a *EndpointAuth) Transport() http.RoundTripper {
+	if a == nil {
+		return nil
+	}
+	return authTransport{auth: a}
+}
+
+type authTransport struct{ auth *EndpointAuth }
+
+func (t authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(t.auth.Header, t.auth.Value())
+	return http.DefaultTransport.RoundTrip(req)
+}
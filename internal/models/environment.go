@@ -0,0 +1,333 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EnvironmentOverlay describes how one environment (dev, stage, prod) differs from the base
+// expectations. Only the differences are stored: text replacements and a default delay for the
+// base expectations, expectations overridden or added, and base expectations left out.
+type EnvironmentOverlay struct {
+	Name        string                `json:"name"`
+	Description string                `json:"description,omitempty"`
+	Replace     map[string]string     `json:"replace,omitempty"`  // Text replaced in base expectations, e.g. hostnames
+	Delay       *Delay                `json:"delay,omitempty"`    // Response delay of base expectations without their own
+	Override    []EnvironmentOverride `json:"override,omitempty"` // Served as given, instead of a base expectation or in addition
+	Remove      []string              `json:"remove,omitempty"`   // Keys of base expectations not served (see ExpectationKeys)
+}
+
+// EnvironmentOverride replaces the base expectation with Key, or adds Expectation when Key is empty
+type EnvironmentOverride struct {
+	Key         string          `json:"key,omitempty"`
+	Expectation MockExpectation `json:"expectation"`
+}
+
+// DeployedEnvironment keeps what an environment deploy replaced so the next deploy can restore it
+type DeployedEnvironment struct {
+	Base    []MockExpectation  `json:"base"`    // Served expectations before the overlay
+	Overlay EnvironmentOverlay `json:"overlay"` // The overlay as deployed
+}
+
+// ExpectationKeys identifies expectations across an overlay and its base: the ID when set,
+// otherwise method, path, status and request matchers. Repeats get a #n suffix.
+func ExpectationKeys(exps []MockExpectation) []string {
+	keys := make([]string, len(exps))
+	seen := map[string]int{}
+	for i := range exps {
+		key := expectationKey(&exps[i])
+		seen[key]++
+		if n := seen[key]; n > 1 {
+			key = fmt.Sprintf("%s#%d", key, n)
+		}
+		keys[i] = key
+	}
+	return keys
+}
+
+func expectationKey(exp *MockExpectation) string {
+	if exp.ID != "" {
+		return exp.ID
+	}
+	if exp.HttpRequest == nil {
+		return variantLabel(exp)
+	}
+	method := strings.ToUpper(exp.HttpRequest.Method)
+	if method == "" {
+		method = "ANY"
+	}
+	key := method + " " + exp.HttpRequest.Path + " " + variantLabel(exp)
+	if sig := requestSignature(exp.HttpRequest); sig != "" {
+		key += " ?" + sig
+	}
+	return key
+}
+
+// Validate checks the overlay has a usable name and well-formed replacements
+func (o *EnvironmentOverlay) Validate() error {
+	name := strings.TrimSpace(o.Name)
+	if name == "" || strings.ContainsAny(name, " ,/") {
+		return fmt.Errorf("environment name %q must be a single word such as dev, stage or prod", o.Name)
+	}
+	for from := range o.Replace {
+		if from == "" {
+			return fmt.Errorf("environment %s: replacement text must not be empty", o.Name)
+		}
+	}
+	return nil
+}
+
+// Summary describes the overlay in one line
+func (o *EnvironmentOverlay) Summary() string {
+	replaced, added := 0, 0
+	for _, ov := range o.Override {
+		if ov.Key == "" {
+			added++
+		} else {
+			replaced++
+		}
+	}
+	parts := []string{
+		fmt.Sprintf("%d replacement(s)", len(o.Replace)),
+		fmt.Sprintf("%d overridden", replaced),
+		fmt.Sprintf("%d added", added),
+		fmt.Sprintf("%d removed", len(o.Remove)),
+	}
+	if o.Delay != nil {
+		parts = append(parts, fmt.Sprintf("delay %d %s", o.Delay.Value, strings.ToLower(o.Delay.TimeUnit)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Apply returns the expectations served in the environment: base with its overrides and
+// removals, replacements and default delay applied. base is not modified.
+func (o *EnvironmentOverlay) Apply(base []MockExpectation) []MockExpectation {
+	exps, _ := o.apply(base)
+	return exps
+}
+
+// apply also returns, for each served expectation, the index of the base expectation it came
+// from (-1 for added ones)
+func (o *EnvironmentOverlay) apply(base []MockExpectation) ([]MockExpectation, []int) {
+	removed := map[string]bool{}
+	for _, key := range o.Remove {
+		removed[key] = true
+	}
+	overrides := map[string]MockExpectation{}
+	for _, ov := range o.Override {
+		if ov.Key != "" {
+			overrides[ov.Key] = ov.Expectation
+		}
+	}
+
+	var exps []MockExpectation
+	var from []int
+	for i, key := range ExpectationKeys(base) {
+		if removed[key] {
+			continue
+		}
+		if ov, ok := overrides[key]; ok {
+			exps = append(exps, copyExpectation(ov))
+		} else {
+			exps = append(exps, o.transform(base[i]))
+		}
+		from = append(from, i)
+	}
+	for _, ov := range o.Override {
+		if ov.Key == "" {
+			exps = append(exps, copyExpectation(ov.Expectation))
+			from = append(from, -1)
+		}
+	}
+	return exps, from
+}
+
+// transform applies the replacements and default delay to a copy of a base expectation
+func (o *EnvironmentOverlay) transform(exp MockExpectation) MockExpectation {
+	if len(o.Replace) > 0 {
+		data, err := json.Marshal(exp)
+		if err == nil {
+			var pairs []string
+			for _, from := range sortedKeys(o.Replace) {
+				pairs = append(pairs, jsonStringContent(from), jsonStringContent(o.Replace[from]))
+			}
+			var replaced MockExpectation
+			if json.Unmarshal([]byte(strings.NewReplacer(pairs...).Replace(string(data))), &replaced) == nil {
+				replaced.Progressive = exp.Progressive
+				exp = replaced
+			}
+		}
+	} else {
+		exp = copyExpectation(exp)
+	}
+	if o.Delay != nil && exp.HttpResponse != nil && exp.HttpResponse.Delay == nil {
+		delay := *o.Delay
+		exp.HttpResponse.Delay = &delay
+	}
+	return exp
+}
+
+// Update stores edited, the environment's expectations after editing, as the overlay's
+// differences from base. Expectations matching a base expectation that changed become
+// overrides, unmatched ones are added and base expectations missing from edited are removed.
+func (o *EnvironmentOverlay) Update(base, edited []MockExpectation) {
+	plain := EnvironmentOverlay{Replace: o.Replace, Delay: o.Delay}
+	transformed, _ := plain.apply(base)
+	baseKeys := ExpectationKeys(base)
+	index := map[string]int{}
+	for i, key := range ExpectationKeys(transformed) {
+		index[key] = i
+	}
+
+	o.Override, o.Remove = nil, nil
+	matched := make([]bool, len(base))
+	for j, key := range ExpectationKeys(edited) {
+		if i, ok := index[key]; ok && !matched[i] {
+			matched[i] = true
+			if !sameJSON(edited[j], transformed[i]) {
+				o.Override = append(o.Override, EnvironmentOverride{Key: baseKeys[i], Expectation: edited[j]})
+			}
+			continue
+		}
+		o.Override = append(o.Override, EnvironmentOverride{Expectation: edited[j]})
+	}
+	for i, ok := range matched {
+		if !ok {
+			o.Remove = append(o.Remove, baseKeys[i])
+		}
+	}
+}
+
+// FindEnvironment returns the overlay called name
+func (c *MockConfiguration) FindEnvironment(name string) (*EnvironmentOverlay, error) {
+	for i := range c.Environments {
+		if strings.EqualFold(c.Environments[i].Name, name) {
+			return &c.Environments[i], nil
+		}
+	}
+	if len(c.Environments) == 0 {
+		return nil, fmt.Errorf("unknown environment %q: the project has no environment overlays", name)
+	}
+	return nil, fmt.Errorf("unknown environment %q (available: %s)", name, strings.Join(c.EnvironmentNames(), ", "))
+}
+
+// EnvironmentNames lists the project's overlays in name order
+func (c *MockConfiguration) EnvironmentNames() []string {
+	var names []string
+	for _, o := range c.Environments {
+		names = append(names, o.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetEnvironment adds the overlay, replacing one with the same name
+func (c *MockConfiguration) SetEnvironment(o EnvironmentOverlay) {
+	for i := range c.Environments {
+		if strings.EqualFold(c.Environments[i].Name, o.Name) {
+			c.Environments[i] = o
+			return
+		}
+	}
+	c.Environments = append(c.Environments, o)
+}
+
+// BaseExpectations returns the expectations without the overlay of a deployed environment
+func (c *MockConfiguration) BaseExpectations() []MockExpectation {
+	if c.DeployedEnvironment == nil {
+		return c.Expectations
+	}
+	restored := *c
+	restored.RestoreEnvironment()
+	return restored.Expectations
+}
+
+// ForEnvironment returns the expectations served in environment name; "" returns the base
+func (c *MockConfiguration) ForEnvironment(name string) ([]MockExpectation, error) {
+	base := c.BaseExpectations()
+	if name == "" {
+		return base, nil
+	}
+	o, err := c.FindEnvironment(name)
+	if err != nil {
+		return nil, err
+	}
+	return o.Apply(base), nil
+}
+
+// ApplyEnvironment restores the base expectations of a previous environment deploy, then
+// serves the overlay o (nil serves the base), keeping the base so a later deploy can restore it
+func (c *MockConfiguration) ApplyEnvironment(o *EnvironmentOverlay) {
+	c.RestoreEnvironment()
+	if o == nil {
+		c.Metadata.Environment = ""
+		return
+	}
+	c.DeployedEnvironment = &DeployedEnvironment{Base: c.Expectations, Overlay: *o}
+	c.Expectations = o.Apply(c.Expectations)
+	c.Metadata.Environment = o.Name
+}
+
+// RestoreEnvironment puts back the base expectations of an environment deploy. Expectations
+// edited, added or removed since then keep the change; base expectations the overlay left out
+// come back at the end.
+func (c *MockConfiguration) RestoreEnvironment() {
+	d := c.DeployedEnvironment
+	if d == nil {
+		return
+	}
+	applied, from := d.Overlay.apply(d.Base)
+	served := make([]bool, len(d.Base))
+	for _, i := range from {
+		if i >= 0 {
+			served[i] = true
+		}
+	}
+	index := map[string]int{}
+	for i, key := range ExpectationKeys(applied) {
+		index[key] = i
+	}
+	used := make([]bool, len(d.Base))
+	var restored []MockExpectation
+	for j, key := range ExpectationKeys(c.Expectations) {
+		i, ok := index[key]
+		switch {
+		case !ok || !sameJSON(c.Expectations[j], applied[i]):
+			restored = append(restored, c.Expectations[j]) // edited or added since the deploy
+		case from[i] >= 0:
+			restored = append(restored, d.Base[from[i]])
+		}
+		if ok && from[i] >= 0 {
+			used[from[i]] = true
+		}
+		delete(index, key)
+	}
+	for i := range d.Base {
+		if !served[i] && !used[i] {
+			restored = append(restored, d.Base[i]) // left out by the overlay
+		}
+	}
+	c.Expectations = restored
+	c.DeployedEnvironment = nil
+}
+
+func copyExpectation(exp MockExpectation) MockExpectation {
+	data, err := json.Marshal(exp)
+	if err != nil {
+		return exp
+	}
+	var cp MockExpectation
+	if json.Unmarshal(data, &cp) != nil {
+		return exp
+	}
+	cp.Progressive = exp.Progressive
+	return cp
+}
+
+// jsonStringContent is s as it appears inside a JSON string
+func jsonStringContent(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data[1 : len(data)-1])
+}
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func envBase() []MockExpectation {
+	return []MockExpectation{
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/users"},
+			HttpResponse: &HttpResponse{StatusCode: 200, Body: `{"next":"https://api.example.com/users?page=2"}`}},
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/orders"},
+			HttpResponse: &HttpResponse{StatusCode: 200, Body: `{"orders":[]}`, Delay: &Delay{TimeUnit: "MILLISECONDS", Value: 5}}},
+		{HttpRequest: &HttpRequest{Method: "DELETE", Path: "/users/1"},
+			HttpResponse: &HttpResponse{StatusCode: 204}},
+	}
+}
+
+func TestEnvironmentOverlayApply(t *testing.T) {
+	base := envBase()
+	keys := ExpectationKeys(base)
+	overlay := EnvironmentOverlay{
+		Name:    "stage",
+		Replace: map[string]string{"api.example.com": "api.stage.example.com"},
+		Delay:   &Delay{TimeUnit: "MILLISECONDS", Value: 300},
+		Override: []EnvironmentOverride{
+			{Key: keys[1], Expectation: MockExpectation{HttpRequest: &HttpRequest{Method: "GET", Path: "/orders"},
+				HttpResponse: &HttpResponse{StatusCode: 200, Body: `{"orders":[{"id":1}]}`}}},
+			{Expectation: MockExpectation{HttpRequest: &HttpRequest{Method: "GET", Path: "/health"},
+				HttpResponse: &HttpResponse{StatusCode: 200}}},
+		},
+		Remove: []string{keys[2]},
+	}
+
+	exps := overlay.Apply(base)
+	if len(exps) != 3 {
+		t.Fatalf("got %d expectations, want 3", len(exps))
+	}
+	if body := exps[0].HttpResponse.Body.(string); !strings.Contains(body, "api.stage.example.com") {
+		t.Errorf("hostname not replaced: %s", body)
+	}
+	if d := exps[0].HttpResponse.Delay; d == nil || d.Value != 300 {
+		t.Errorf("default delay not applied: %+v", d)
+	}
+	if exps[1].HttpResponse.Body != `{"orders":[{"id":1}]}` || exps[1].HttpResponse.Delay != nil {
+		t.Errorf("override not served as given: %+v", exps[1].HttpResponse)
+	}
+	if exps[2].HttpRequest.Path != "/health" {
+		t.Errorf("added expectation = %s", exps[2].HttpRequest.Path)
+	}
+	if base[0].HttpResponse.Body.(string) != `{"next":"https://api.example.com/users?page=2"}` || base[0].HttpResponse.Delay != nil {
+		t.Error("base was modified")
+	}
+	if got := overlay.Summary(); got != "1 replacement(s), 1 overridden, 1 added, 1 removed, delay 300 milliseconds" {
+		t.Errorf("summary = %q", got)
+	}
+}
+
+func TestEnvironmentOverlayUpdate(t *testing.T) {
+	base := envBase()
+	overlay := EnvironmentOverlay{Name: "dev", Replace: map[string]string{"api.example.com": "localhost:8080"}}
+
+	edited := overlay.Apply(base)
+	edited[1].HttpResponse.Body = `{"orders":[{"id":7}]}`
+	edited = append(edited[:2], MockExpectation{HttpRequest: &HttpRequest{Method: "POST", Path: "/seed"},
+		HttpResponse: &HttpResponse{StatusCode: 201}})
+	overlay.Update(base, edited)
+
+	if len(overlay.Override) != 2 || overlay.Override[0].Key == "" || overlay.Override[1].Key != "" {
+		t.Fatalf("overrides = %+v", overlay.Override)
+	}
+	if len(overlay.Remove) != 1 || !strings.HasPrefix(overlay.Remove[0], "DELETE /users/1") {
+		t.Errorf("removed = %v", overlay.Remove)
+	}
+	// The replaced-only expectation is not stored: the replacement still produces it
+	again := overlay.Apply(base)
+	if !sameJSON(again, edited) {
+		t.Errorf("round trip differs:\n%+v\n%+v", again, edited)
+	}
+}
+
+func TestApplyAndRestoreEnvironment(t *testing.T) {
+	cfg := &MockConfiguration{Expectations: envBase(), Environments: []EnvironmentOverlay{{
+		Name:    "prod",
+		Replace: map[string]string{"api.example.com": "api.prod.example.com"},
+		Remove:  ExpectationKeys(envBase())[2:],
+	}}}
+	overlay, err := cfg.FindEnvironment("PROD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.ApplyEnvironment(overlay)
+	if len(cfg.Expectations) != 2 || cfg.Metadata.Environment != "prod" || cfg.DeployedEnvironment == nil {
+		t.Fatalf("applied: %d expectations, environment %q", len(cfg.Expectations), cfg.Metadata.Environment)
+	}
+	if base := cfg.BaseExpectations(); !sameJSON(base, envBase()) || cfg.DeployedEnvironment == nil {
+		t.Error("BaseExpectations did not restore a copy")
+	}
+
+	// An edit made while deployed is kept; the removed expectation comes back
+	cfg.Expectations[1].HttpResponse.Body = `{"orders":["edited"]}`
+	cfg.ApplyEnvironment(nil)
+	if len(cfg.Expectations) != 3 || cfg.DeployedEnvironment != nil || cfg.Metadata.Environment != "" {
+		t.Fatalf("restored %d expectations", len(cfg.Expectations))
+	}
+	if !strings.Contains(cfg.Expectations[0].HttpResponse.Body.(string), "//api.example.com") {
+		t.Errorf("replacement not undone: %v", cfg.Expectations[0].HttpResponse.Body)
+	}
+	if cfg.Expectations[1].HttpResponse.Body != `{"orders":["edited"]}` || cfg.Expectations[2].HttpRequest.Method != "DELETE" {
+		t.Errorf("after restore: %v / %v", cfg.Expectations[1].HttpResponse.Body, cfg.Expectations[2].HttpRequest.Method)
+	}
+
+	if _, err := cfg.FindEnvironment("qa"); err == nil || !strings.Contains(err.Error(), "prod") {
+		t.Errorf("unknown environment error = %v", err)
+	}
+}
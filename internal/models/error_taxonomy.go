@@ -0,0 +1,256 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Placeholders an error envelope uses for the values of each error
+const (
+	ErrorCodePlaceholder    = "{{code}}"
+	ErrorMessagePlaceholder = "{{message}}"
+	ErrorStatusPlaceholder  = "{{status}}"
+)
+
+// ErrorTaxonomy is a project's error envelope and error codes. Every generator uses it
+// for error responses so the whole project shares one error shape.
+type ErrorTaxonomy struct {
+	Envelope string      `json:"envelope"`        // JSON with {{code}}, {{message}} and (unquoted) {{status}} placeholders
+	Codes    []ErrorCode `json:"codes,omitempty"` // One entry per status
+}
+
+// ErrorCode is the code and default message used for one HTTP status
+type ErrorCode struct {
+	Status  int    `json:"status"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ErrorEnvelopePreset is a ready-made envelope offered by the error taxonomy wizard
+type ErrorEnvelopePreset struct {
+	Name     string
+	Envelope string
+}
+
+// ErrorEnvelopePresets are the common error shapes, nested envelope first
+var ErrorEnvelopePresets = []ErrorEnvelopePreset{
+	{Name: "nested", Envelope: `{"error": {"code": "{{code}}", "message": "{{message}}"}}`},
+	{Name: "flat", Envelope: `{"code": "{{code}}", "message": "{{message}}"}`},
+	{Name: "problem+json", Envelope: `{"type": "https://errors.example.com/{{code}}", "title": "{{message}}", "status": {{status}}, "code": "{{code}}"}`},
+	{Name: "errors-array", Envelope: `{"errors": [{"status": "{{status}}", "code": "{{code}}", "detail": "{{message}}"}]}`},
+}
+
+// DefaultErrorCodes seeds the wizard with the usual statuses
+func DefaultErrorCodes() []ErrorCode {
+	return []ErrorCode{
+		{Status: 400, Code: "BAD_REQUEST", Message: "The request is invalid"},
+		{Status: 401, Code: "UNAUTHORIZED", Message: "Authentication is required"},
+		{Status: 403, Code: "FORBIDDEN", Message: "You do not have access to this resource"},
+		{Status: 404, Code: "NOT_FOUND", Message: "The resource was not found"},
+		{Status: 409, Code: "CONFLICT", Message: "The resource already exists or was modified"},
+		{Status: 422, Code: "VALIDATION_FAILED", Message: "One or more fields are invalid"},
+		{Status: 429, Code: "RATE_LIMITED", Message: "Too many requests"},
+		{Status: 500, Code: "INTERNAL_ERROR", Message: "An unexpected error occurred"},
+		{Status: 503, Code: "SERVICE_UNAVAILABLE", Message: "The service is temporarily unavailable"},
+	}
+}
+
+// Validate checks that the envelope carries a code and renders to valid JSON
+func (t *ErrorTaxonomy) Validate() error {
+	if !strings.Contains(t.Envelope, ErrorCodePlaceholder) {
+		return fmt.Errorf("error envelope must contain %s", ErrorCodePlaceholder)
+	}
+	if _, err := t.render(500, ErrorCode{Code: "CODE", Message: `a "quoted" message`}); err != nil {
+		return err
+	}
+	seen := map[int]bool{}
+	for _, c := range t.Codes {
+		if c.Status < 400 || c.Status > 599 {
+			return fmt.Errorf("error code %s: status %d is not a 4xx/5xx status", c.Code, c.Status)
+		}
+		if strings.TrimSpace(c.Code) == "" {
+			return fmt.Errorf("status %d has no error code", c.Status)
+		}
+		if seen[c.Status] {
+			return fmt.Errorf("status %d is listed more than once", c.Status)
+		}
+		seen[c.Status] = true
+	}
+	return nil
+}
+
+// CodeFor returns the code registered for status, or a generic one derived from the status text
+func (t *ErrorTaxonomy) CodeFor(status int) ErrorCode {
+	for _, c := range t.Codes {
+		if c.Status == status {
+			return c
+		}
+	}
+	text := http.StatusText(status)
+	if text == "" {
+		text = "Error"
+		if status >= 500 {
+			text = "Server Error"
+		}
+	}
+	return ErrorCode{
+		Status:  status,
+		Code:    strings.ToUpper(strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(text)),
+		Message: text,
+	}
+}
+
+// Body renders the error response body for status, wrapped as a MockServer JSON body
+func (t *ErrorTaxonomy) Body(status int) (any, error) {
+	v, err := t.render(status, t.CodeFor(status))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"type": "JSON", "json": v}, nil
+}
+
+// Reshape rewrites an error body into the envelope. A code or message found in the body is
+// kept when the code belongs to the taxonomy; otherwise the status's registered code is used.
+// Template bodies ($! Velocity) and non-JSON bodies are left alone.
+func (t *ErrorTaxonomy) Reshape(status int, body any) (any, bool) {
+	doc, ok := jsonBodyValue(body)
+	if !ok {
+		return body, false
+	}
+	code := t.CodeFor(status)
+	if found := findStringField(doc, "code", "error_code", "errorCode"); found != "" && t.known(found) {
+		code.Code = found
+	}
+	if found := findStringField(doc, "message", "detail", "title", "error_description"); found != "" {
+		code.Message = found
+	}
+	v, err := t.render(status, code)
+	if err != nil {
+		return body, false
+	}
+	return map[string]any{"type": "JSON", "json": v}, true
+}
+
+// PromptRules describes the envelope and codes for an AI provider prompt
+func (t *ErrorTaxonomy) PromptRules() string {
+	var sb strings.Builder
+	sb.WriteString("- Every 4xx/5xx response body MUST use this error envelope, with the placeholders filled in:\n  ")
+	sb.WriteString(t.Envelope)
+	sb.WriteString("\n- Use only these error codes:\n")
+	codes := append([]ErrorCode(nil), t.Codes...)
+	sort.SliceStable(codes, func(i, j int) bool { return codes[i].Status < codes[j].Status })
+	for _, c := range codes {
+		fmt.Fprintf(&sb, "  %d %s (%s)\n", c.Status, c.Code, c.Message)
+	}
+	return sb.String()
+}
+
+// ApplyErrorTaxonomy reshapes the JSON body of every 4xx/5xx expectation into the envelope.
+// Expectations rendered from a schema are skipped. It returns the number of bodies changed.
+func ApplyErrorTaxonomy(exps []MockExpectation, t *ErrorTaxonomy) int {
+	if t == nil {
+		return 0
+	}
+	changed := 0
+	for i := range exps {
+		resp := exps[i].HttpResponse
+		if resp == nil || resp.StatusCode < 400 || resp.SchemaRef != "" {
+			continue
+		}
+		if body, ok := t.Reshape(resp.StatusCode, resp.Body); ok {
+			resp.Body = body
+			changed++
+		}
+	}
+	return changed
+}
+
+func (t *ErrorTaxonomy) known(code string) bool {
+	for _, c := range t.Codes {
+		if c.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// render fills the envelope placeholders; code and message are JSON-escaped in place
+func (t *ErrorTaxonomy) render(status int, code ErrorCode) (any, error) {
+	out := strings.NewReplacer(
+		ErrorCodePlaceholder, jsonEscape(code.Code),
+		ErrorMessagePlaceholder, jsonEscape(code.Message),
+		ErrorStatusPlaceholder, fmt.Sprintf("%d", status),
+	).Replace(t.Envelope)
+	var v any
+	if err := json.Unmarshal([]byte(out), &v); err != nil {
+		return nil, fmt.Errorf("error envelope is not valid JSON once filled in: %w", err)
+	}
+	return v, nil
+}
+
+// jsonEscape returns s escaped for use inside a JSON string literal
+func jsonEscape(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b[1 : len(b)-1])
+}
+
+// jsonBodyValue decodes a MockServer response body (raw string or JSON wrapper) into a JSON value
+func jsonBodyValue(body any) (any, bool) {
+	switch v := body.(type) {
+	case string:
+		return decodeJSONText(v)
+	case map[string]any:
+		// MockServer body types are upper case; a lower-case "type" is payload (e.g. problem+json)
+		if t, _ := v["type"].(string); t != "" && t == strings.ToUpper(t) {
+			if t != "JSON" {
+				return nil, false
+			}
+			if text, ok := v["json"].(string); ok {
+				return decodeJSONText(text)
+			}
+			return v["json"], v["json"] != nil
+		}
+		return v, true
+	case []any:
+		return v, true
+	}
+	return nil, false
+}
+
+// decodeJSONText parses JSON text; Velocity templates are not touched
+func decodeJSONText(text string) (any, bool) {
+	if strings.Contains(text, "$!") {
+		return nil, false
+	}
+	var doc any
+	if json.Unmarshal([]byte(text), &doc) != nil {
+		return nil, false
+	}
+	return doc, true
+}
+
+// findStringField returns the first string value under one of the keys, searching breadth first
+func findStringField(doc any, keys ...string) string {
+	queue := []any{doc}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		switch v := node.(type) {
+		case map[string]any:
+			for _, k := range keys {
+				if s, ok := v[k].(string); ok && strings.TrimSpace(s) != "" {
+					return s
+				}
+			}
+			for _, k := range sortedKeys(v) {
+				queue = append(queue, v[k])
+			}
+		case []any:
+			queue = append(queue, v...)
+		}
+	}
+	return ""
+}
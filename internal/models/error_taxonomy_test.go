@@ -0,0 +1,97 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestErrorTaxonomyBodyAndReshape(t *testing.T) {
+	tax := &ErrorTaxonomy{Envelope: ErrorEnvelopePresets[0].Envelope, Codes: DefaultErrorCodes()}
+	if err := tax.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	body, err := tax.Body(404)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{"type": "JSON", "json": map[string]any{
+		"error": map[string]any{"code": "NOT_FOUND", "message": "The resource was not found"},
+	}}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("404 body = %#v", body)
+	}
+
+	// Unregistered statuses fall back to the status text
+	if c := tax.CodeFor(418); c.Code != "IM_A_TEAPOT" {
+		t.Errorf("418 code = %q", c.Code)
+	}
+
+	// A known code and the message survive; an invented code is replaced
+	reshaped, ok := tax.Reshape(409, `{"status":"fail","errorCode":"NOT_FOUND","detail":"User \"u_1\" exists"}`)
+	if !ok {
+		t.Fatal("reshape refused a JSON body")
+	}
+	inner := reshaped.(map[string]any)["json"].(map[string]any)["error"].(map[string]any)
+	if inner["code"] != "NOT_FOUND" || inner["message"] != `User "u_1" exists` {
+		t.Errorf("reshaped = %#v", inner)
+	}
+	reshaped, _ = tax.Reshape(409, map[string]any{"type": "JSON", "json": `{"err":{"code":"DUP","message":"dup"}}`})
+	if inner := reshaped.(map[string]any)["json"].(map[string]any)["error"].(map[string]any); inner["code"] != "CONFLICT" {
+		t.Errorf("invented code kept: %#v", inner)
+	}
+
+	if _, ok := tax.Reshape(500, `{"error":"$!request.path"}`); ok {
+		t.Error("Velocity template was reshaped")
+	}
+}
+
+func TestErrorTaxonomyProblemJSON(t *testing.T) {
+	tax := &ErrorTaxonomy{Envelope: ErrorEnvelopePresets[2].Envelope, Codes: DefaultErrorCodes()}
+	body, err := tax.Body(422)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := body.(map[string]any)["json"].(map[string]any)
+	if doc["status"] != float64(422) || doc["type"] != "https://errors.example.com/VALIDATION_FAILED" {
+		t.Errorf("problem body = %#v", doc)
+	}
+	// A bare problem+json payload is not mistaken for a MockServer body wrapper
+	if _, ok := tax.Reshape(422, doc); !ok {
+		t.Error("bare problem+json body was not reshaped")
+	}
+}
+
+func TestErrorTaxonomyValidate(t *testing.T) {
+	cases := map[string]*ErrorTaxonomy{
+		"no code":        {Envelope: `{"message": "{{message}}"}`},
+		"invalid json":   {Envelope: `{"code": {{code}}}`},
+		"non-error code": {Envelope: ErrorEnvelopePresets[1].Envelope, Codes: []ErrorCode{{Status: 200, Code: "OK"}}},
+		"duplicate":      {Envelope: ErrorEnvelopePresets[1].Envelope, Codes: []ErrorCode{{Status: 400, Code: "A"}, {Status: 400, Code: "B"}}},
+	}
+	for name, tax := range cases {
+		if err := tax.Validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestApplyErrorTaxonomy(t *testing.T) {
+	tax := &ErrorTaxonomy{Envelope: ErrorEnvelopePresets[1].Envelope, Codes: DefaultErrorCodes()}
+	exps := []MockExpectation{
+		{HttpResponse: &HttpResponse{StatusCode: 200, Body: `{"ok":true}`}},
+		{HttpResponse: &HttpResponse{StatusCode: 401, Body: map[string]any{"type": "JSON", "json": map[string]any{"msg": "no"}}}},
+		{HttpResponse: &HttpResponse{StatusCode: 404, SchemaRef: "schemas/Error"}},
+	}
+	if n := ApplyErrorTaxonomy(exps, tax); n != 1 {
+		t.Fatalf("changed %d bodies, want 1", n)
+	}
+	got := exps[1].HttpResponse.Body.(map[string]any)["json"].(map[string]any)
+	if got["code"] != "UNAUTHORIZED" || !strings.Contains(got["message"].(string), "Authentication") {
+		t.Errorf("401 body = %#v", got)
+	}
+	if !strings.Contains(tax.PromptRules(), "401 UNAUTHORIZED") {
+		t.Errorf("prompt rules lack codes:\n%s", tax.PromptRules())
+	}
+}
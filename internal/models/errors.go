@@ -102,6 +102,21 @@ func (e *ProviderError) Unwrap() error {
 	return e.Cause
 }
 
+// ConfigNotFoundError reports that a project has no stored configuration yet, as opposed to
+// one that could not be read
+type ConfigNotFoundError struct {
+	ProjectID string
+	Cause     error
+}
+
+func (e *ConfigNotFoundError) Error() string {
+	return fmt.Sprintf("no configuration stored for project '%s': %v", e.ProjectID, e.Cause)
+}
+
+func (e *ConfigNotFoundError) Unwrap() error {
+	return e.Cause
+}
+
 // DeploymentError represents infrastructure deployment errors
 type DeploymentError struct {
 	ProjectName string
@@ -0,0 +1,252 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// BodyExample is a canonical response payload in the project example library,
+// keyed by content type and entity (e.g. application/json / Order)
+type BodyExample struct {
+	ContentType string    `json:"content_type"`
+	Entity      string    `json:"entity"`
+	Body        string    `json:"body"`             // Payload text; JSON is stored pretty-printed
+	Source      string    `json:"source,omitempty"` // import, manual
+	AddedAt     time.Time `json:"added_at"`
+}
+
+// Key returns the library key, e.g. "application/json / Order"
+func (e BodyExample) Key() string {
+	return e.ContentType + " / " + e.Entity
+}
+
+// ResponseBody renders the example as a MockServer response body
+func (e BodyExample) ResponseBody() any {
+	switch {
+	case strings.Contains(e.ContentType, "json"):
+		var v any
+		if json.Unmarshal([]byte(e.Body), &v) == nil {
+			return map[string]any{"type": "JSON", "json": v}
+		}
+	case strings.Contains(e.ContentType, "xml"):
+		return map[string]any{"type": "XML", "xml": e.Body}
+	}
+	return e.Body
+}
+
+// AddBodyExample stores an example, replacing the entry with the same content type and
+// entity when replace is set. It reports whether the library changed.
+func (c *MockConfiguration) AddBodyExample(example BodyExample, replace bool) bool {
+	example.ContentType = normalizeContentType(example.ContentType)
+	example.Entity = strings.TrimSpace(example.Entity)
+	if example.ContentType == "" || example.Entity == "" || strings.TrimSpace(example.Body) == "" {
+		return false
+	}
+	if example.AddedAt.IsZero() {
+		example.AddedAt = time.Now()
+	}
+	for i, existing := range c.BodyExamples {
+		if strings.EqualFold(existing.Key(), example.Key()) {
+			if !replace || existing.Body == example.Body {
+				return false
+			}
+			c.BodyExamples[i] = example
+			return true
+		}
+	}
+	c.BodyExamples = append(c.BodyExamples, example)
+	sort.SliceStable(c.BodyExamples, func(i, j int) bool {
+		return strings.ToLower(c.BodyExamples[i].Key()) < strings.ToLower(c.BodyExamples[j].Key())
+	})
+	return true
+}
+
+// FindBodyExample looks up the example for a content type and entity
+func (c *MockConfiguration) FindBodyExample(contentType, entity string) (BodyExample, bool) {
+	key := normalizeContentType(contentType) + " / " + strings.TrimSpace(entity)
+	for _, e := range c.BodyExamples {
+		if strings.EqualFold(e.Key(), key) {
+			return e, true
+		}
+	}
+	return BodyExample{}, false
+}
+
+// RemoveBodyExample deletes the example with the given key
+func (c *MockConfiguration) RemoveBodyExample(key string) bool {
+	for i, existing := range c.BodyExamples {
+		if strings.EqualFold(existing.Key(), key) {
+			c.BodyExamples = append(c.BodyExamples[:i], c.BodyExamples[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// BodyExampleContentTypes lists the content types in the library, sorted
+func (c *MockConfiguration) BodyExampleContentTypes() []string {
+	seen := map[string]bool{}
+	var types []string
+	for _, e := range c.BodyExamples {
+		if !seen[e.ContentType] {
+			seen[e.ContentType] = true
+			types = append(types, e.ContentType)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// BodyExamplesFor returns the examples of one content type
+func (c *MockConfiguration) BodyExamplesFor(contentType string) []BodyExample {
+	var out []BodyExample
+	for _, e := range c.BodyExamples {
+		if e.ContentType == normalizeContentType(contentType) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// HarvestBodyExamples adds one example per content type and entity found in the
+// expectations' response bodies. Existing entries are kept. It returns the number added.
+func (c *MockConfiguration) HarvestBodyExamples(source string) int {
+	added := 0
+	for _, exp := range c.Expectations {
+		example, ok := BodyExampleFromExpectation(exp)
+		if !ok {
+			continue
+		}
+		example.Source = source
+		if c.AddBodyExample(example, false) {
+			added++
+		}
+	}
+	return added
+}
+
+// BodyExampleFromExpectation derives a library example from an expectation's response:
+// the entity comes from the path ("/orders/{id}" → Order, a JSON array → OrderList) and
+// error statuses are filed under Error
+func BodyExampleFromExpectation(exp MockExpectation) (BodyExample, bool) {
+	if exp.HttpRequest == nil || exp.HttpResponse == nil || exp.HttpResponse.Body == nil {
+		return BodyExample{}, false
+	}
+	resp := exp.HttpResponse
+	contentType, text, isArray := bodyText(resp.Body)
+	for _, h := range resp.Headers {
+		if strings.EqualFold(h.Name, "Content-Type") && len(h.Values) > 0 {
+			contentType = h.Values[0]
+		}
+	}
+	if strings.TrimSpace(text) == "" || contentType == "" {
+		return BodyExample{}, false
+	}
+
+	entity := EntityFromPath(exp.HttpRequest.Path)
+	switch {
+	case resp.StatusCode >= 400:
+		entity = "Error"
+	case entity == "":
+		return BodyExample{}, false
+	case isArray:
+		entity += "List"
+	}
+	return BodyExample{ContentType: contentType, Entity: entity, Body: text}, true
+}
+
+var versionSegment = regexp.MustCompile(`^v\d+$`)
+
+// EntityFromPath names the resource a path addresses: the last literal segment,
+// singular and in CamelCase ("/api/v1/order-items/{id}" → OrderItem)
+func EntityFromPath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		seg := strings.ToLower(segments[i])
+		if seg == "" || seg == "api" || versionSegment.MatchString(seg) || strings.ContainsAny(seg, "{}[]()*+?:$^\\.") {
+			continue
+		}
+		if strings.Trim(seg, "0123456789-") == "" {
+			continue
+		}
+		words := strings.FieldsFunc(seg, func(r rune) bool { return r == '-' || r == '_' })
+		if len(words) == 0 {
+			continue
+		}
+		words[len(words)-1] = singular(words[len(words)-1])
+		for j, w := range words {
+			words[j] = strings.ToUpper(w[:1]) + w[1:]
+		}
+		return strings.Join(words, "")
+	}
+	return ""
+}
+
+func singular(word string) string {
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 3:
+		return word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "sses"), strings.HasSuffix(word, "xes"), strings.HasSuffix(word, "ches"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") && len(word) > 1:
+		return word[:len(word)-1]
+	}
+	return word
+}
+
+// bodyText extracts a response body as text with its implied content type
+func bodyText(body any) (contentType, text string, isArray bool) {
+	switch b := body.(type) {
+	case string:
+		var v any
+		if json.Unmarshal([]byte(b), &v) == nil {
+			if _, isObj := v.(map[string]any); isObj {
+				return "application/json", prettyJSON(v), false
+			}
+			if _, isArr := v.([]any); isArr {
+				return "application/json", prettyJSON(v), true
+			}
+		}
+		return "text/plain", b, false
+	case []any:
+		return "application/json", prettyJSON(b), true
+	case map[string]any:
+		switch strings.ToUpper(fmt.Sprint(b["type"])) {
+		case "JSON":
+			_, isArr := b["json"].([]any)
+			if s, ok := b["json"].(string); ok {
+				return bodyText(s)
+			}
+			return "application/json", prettyJSON(b["json"]), isArr
+		case "XML":
+			s, _ := b["xml"].(string)
+			return "application/xml", s, false
+		case "STRING":
+			s, _ := b["string"].(string)
+			return "text/plain", s, false
+		}
+		if _, typed := b["type"]; typed {
+			return "", "", false // regex, binary and other non-literal bodies
+		}
+		return "application/json", prettyJSON(b), false
+	}
+	return "", "", false
+}
+
+func prettyJSON(v any) string {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return ""
+	}
+	return string(out)
+}
+
+// normalizeContentType drops parameters and case: "application/json; charset=utf-8" → "application/json"
+func normalizeContentType(contentType string) string {
+	ct, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(ct))
+}
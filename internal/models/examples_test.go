@@ -0,0 +1,75 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEntityFromPath(t *testing.T) {
+	cases := map[string]string{
+		"/api/v1/orders/{id}":     "Order",
+		"/orders/[^/]+":           "Order",
+		"/order-items":            "OrderItem",
+		"/categories/42":          "Category",
+		"/users/me/addresses":     "Address",
+		"/api/v2":                 "",
+		"/search/.*/results/123/": "Result",
+	}
+	for path, want := range cases {
+		if got := EntityFromPath(path); got != want {
+			t.Errorf("EntityFromPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestHarvestBodyExamples(t *testing.T) {
+	cfg := &MockConfiguration{
+		Expectations: []MockExpectation{
+			{
+				HttpRequest:  &HttpRequest{Method: "GET", Path: "/orders/{id}"},
+				HttpResponse: &HttpResponse{StatusCode: 200, Body: map[string]any{"type": "JSON", "json": map[string]any{"id": "o_1"}}},
+			},
+			{
+				HttpRequest:  &HttpRequest{Method: "GET", Path: "/orders"},
+				HttpResponse: &HttpResponse{StatusCode: 200, Body: `[{"id":"o_1"}]`},
+			},
+			{
+				HttpRequest: &HttpRequest{Method: "GET", Path: "/orders/{id}"},
+				HttpResponse: &HttpResponse{
+					StatusCode: 404,
+					Headers:    []NameValues{{Name: "Content-Type", Values: []string{"application/problem+json; charset=utf-8"}}},
+					Body:       map[string]any{"type": "JSON", "json": map[string]any{"title": "Not Found"}},
+				},
+			},
+			{
+				HttpRequest:  &HttpRequest{Method: "GET", Path: "/feed"},
+				HttpResponse: &HttpResponse{StatusCode: 200, Body: map[string]any{"type": "XML", "xml": "<feed/>"}},
+			},
+			{
+				HttpRequest:  &HttpRequest{Method: "GET", Path: "/orders/{id}"},
+				HttpResponse: &HttpResponse{StatusCode: 200, Body: map[string]any{"type": "JSON", "json": map[string]any{"id": "o_2"}}},
+			},
+		},
+	}
+	cfg.AddBodyExample(BodyExample{ContentType: "application/json", Entity: "Order", Body: `{"id": "manual"}`, Source: "manual"}, true)
+
+	if added := cfg.HarvestBodyExamples("import"); added != 3 {
+		t.Fatalf("added = %d, want 3", added)
+	}
+	var keys []string
+	for _, e := range cfg.BodyExamples {
+		keys = append(keys, e.Key())
+	}
+	want := "application/json / Order, application/json / OrderList, application/problem+json / Error, application/xml / Feed"
+	if got := strings.Join(keys, ", "); got != want {
+		t.Errorf("keys = %s\nwant %s", got, want)
+	}
+	if order := cfg.BodyExamplesFor("application/json")[0]; order.Body != `{"id": "manual"}` {
+		t.Errorf("manual example was overwritten: %q", order.Body)
+	}
+
+	body := cfg.BodyExamplesFor("application/json")[1].ResponseBody().(map[string]any)
+	if items, ok := body["json"].([]any); body["type"] != "JSON" || !ok || len(items) != 1 {
+		t.Errorf("ResponseBody() = %v", body)
+	}
+}
@@ -9,16 +9,55 @@ import (
 // This is the primary model used throughout the application for building and managing expectations
 type MockExpectation struct {
 	// Identification
-	ID          string `json:"id,omitempty"`          // Unique identifier for the expectation
-	Description string `json:"description,omitempty"` // Optional detailed description
-	Priority    int    `json:"priority,omitempty"`
+	ID          string   `json:"id,omitempty"`          // Unique identifier for the expectation
+	Description string   `json:"description,omitempty"` // Optional detailed description
+	Priority    int      `json:"priority,omitempty"`
+	Tags        []string `json:"tags,omitempty"`  // Labels used for grouping, filtering and consumer subscriptions
+	Notes       string   `json:"notes,omitempty"` // Markdown on the intent behind the mock; exported with it
 
 	HttpRequest  *HttpRequest  `json:"httpRequest,omitempty"`
 	HttpResponse *HttpResponse `json:"httpResponse,omitempty"`
-	Forward      *HttpForward  `json:"httpForward,omitempty"`
+	// HttpResponseTemplate computes the response from the request at serve time. When set it
+	// replaces HttpResponse in MockServer JSON; HttpResponse remains the static fallback.
+	HttpResponseTemplate *HttpTemplate `json:"httpResponseTemplate,omitempty"`
+	Forward              *HttpForward  `json:"httpForward,omitempty"`
+	HttpError            *HttpError    `json:"httpError,omitempty"` // Drops the connection; replaces the response in MockServer JSON
 
 	Times       *Times       `json:"times,omitempty"`
 	Progressive *Progressive `json:"-"`
+
+	Provenance *Provenance     `json:"provenance,omitempty"` // Where this expectation came from
+	Freshness  *Freshness      `json:"freshness,omitempty"`  // Upstream and TTL of a recorded response; see automock refresh
+	Examples   []Example       `json:"examples,omitempty"`   // Requests merged into this expectation on import
+	Checks     []ResponseCheck `json:"checks,omitempty"`     // Assertions the recorded API satisfied (from pm.test)
+	Variant    string          `json:"variant,omitempty"`    // Iteration-data values this expectation is keyed by; never merged
+
+	Negotiation      *Negotiation `json:"negotiation,omitempty"`      // Extra Accept-selected representations of the JSON body
+	NegotiatedFormat string       `json:"negotiatedFormat,omitempty"` // Set on variants generated from a negotiation spec
+
+	ConditionalGet bool `json:"conditionalGet,omitempty"` // Send an ETag and answer If-None-Match revalidations with 304
+	NotModified    bool `json:"notModified,omitempty"`    // Set on 304 companions generated for conditional GET
+
+	Ranges         bool `json:"ranges,omitempty"`         // Answer Range requests with 206 Partial Content
+	PartialContent bool `json:"partialContent,omitempty"` // Set on Range companions generated for Ranges
+
+	Redirects   *Redirects `json:"redirects,omitempty"`   // Answers with a 3xx leading through hops; see ApplyRedirects
+	RedirectHop int        `json:"redirectHop,omitempty"` // Set on expectations generated from Redirects (the hop served)
+
+	Tenant string `json:"tenant,omitempty"` // Set on expectations generated from a tenant overlay (the tenant's name)
+
+	GraphQLErrors  *GraphQLErrors `json:"graphqlErrors,omitempty"`  // Also answer with GraphQL errors or partial data when selected
+	GraphQLVariant string         `json:"graphqlVariant,omitempty"` // Set on variants generated from GraphQLErrors
+
+	Stream *Stream `json:"stream,omitempty"` // WebSocket or SSE messages served by automock serve; see ApplyStreams
+
+	Faults        *Faults `json:"faults,omitempty"`        // Errors, latency and broken connections injected into the response
+	FaultInjected bool    `json:"faultInjected,omitempty"` // Set on companions generated from Faults
+
+	Rules       []ResponseRule `json:"rules,omitempty"`       // Alternative responses selected by request values; the first rule that holds wins
+	RuleVariant string         `json:"ruleVariant,omitempty"` // Set on expectations generated from Rules (the rule's label)
+
+	Dataset *DatasetBinding `json:"dataset,omitempty"` // Serves pages or rows of a project dataset; see ApplyRequestTemplates
 }
 
 type Progressive struct {
@@ -43,8 +82,27 @@ type HttpResponse struct {
 	Cookies           []NameValues       `json:"cookies,omitempty"`
 	Delay             *Delay             `json:"delay,omitempty"`
 	ConnectionOptions *ConnectionOptions `json:"connectionOptions,omitempty"`
+	SchemaRef         string             `json:"schemaRef,omitempty"` // e.g. "schemas/User"; body is rendered from the project schema
+}
+
+// ResponseCheck is one structured assertion about a response, e.g. converted from a pm.test block
+type ResponseCheck struct {
+	Name   string `json:"name"`             // Test title
+	Kind   string `json:"kind"`             // status, status-class, header, json, body, latency
+	Target string `json:"target,omitempty"` // Header name or JSON path ("data.items[0].id")
+	Op     string `json:"op"`               // eq, contains, exists, type, below, above
+	Value  any    `json:"value,omitempty"`
+}
+
+// HttpTemplate is a MockServer response template
+type HttpTemplate struct {
+	TemplateType string `json:"templateType"` // TemplateJavaScript
+	Template     string `json:"template"`
 }
 
+// TemplateJavaScript templates are a function body that receives `request` and returns the response
+const TemplateJavaScript = "JAVASCRIPT"
+
 type NameValues struct {
 	Name   string   `json:"name,omitempty"`
 	Values []string `json:"values,omitempty"`
@@ -100,12 +158,76 @@ const (
 	BodyRegex   RequestBodyMatchingStrategy = "regex"
 )
 
-// ExpectationsToMockServerJSON converts expectations to MockServer JSON format
+// ExpectationsToMockServerJSON converts expectations to MockServer JSON format, keeping
+// automock's own fields (tags, notes, rules, ...) so automock can read the JSON back; use
+// MockServerOnlyJSON for what MockServer loads
 func ExpectationsToMockServerJSON(expectations []MockExpectation) string {
-	jsonBytes, err := json.MarshalIndent(expectations, "", "  ")
+	out := make([]MockExpectation, len(expectations))
+	for i, exp := range expectations {
+		out[i] = singleAction(exp)
+	}
+	jsonBytes, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		fmt.Printf("❌ Failed to marshal expectations: %v\n", err)
+		return "[]"
+	}
+	return string(jsonBytes)
+}
+
+// mockServerExpectation is an expectation with only the fields MockServer defines
+type mockServerExpectation struct {
+	ID                   string        `json:"id,omitempty"`
+	Priority             int           `json:"priority,omitempty"`
+	HttpRequest          *HttpRequest  `json:"httpRequest,omitempty"`
+	HttpResponse         *HttpResponse `json:"httpResponse,omitempty"`
+	HttpResponseTemplate *HttpTemplate `json:"httpResponseTemplate,omitempty"`
+	Forward              *HttpForward  `json:"httpForward,omitempty"`
+	HttpError            *HttpError    `json:"httpError,omitempty"`
+	Times                *Times        `json:"times,omitempty"`
+}
+
+// MockServerOnlyJSON converts expectations to the JSON MockServer loads: automock's own
+// fields, such as tags, provenance, rules, faults and the markers of generated companions,
+// are left out. Everything MockServer serves is loaded from it.
+func MockServerOnlyJSON(expectations []MockExpectation) string {
+	out := make([]mockServerExpectation, len(expectations))
+	for i, exp := range expectations {
+		exp = singleAction(exp)
+		out[i] = mockServerExpectation{
+			ID:                   exp.ID,
+			Priority:             exp.Priority,
+			HttpRequest:          exp.HttpRequest,
+			HttpResponse:         exp.HttpResponse,
+			HttpResponseTemplate: exp.HttpResponseTemplate,
+			Forward:              exp.Forward,
+			HttpError:            exp.HttpError,
+			Times:                exp.Times,
+		}
+	}
+	jsonBytes, err := json.MarshalIndent(out, "", "  ")
 	if err != nil {
 		fmt.Printf("❌ Failed to marshal expectations: %v\n", err)
 		return "[]"
 	}
 	return string(jsonBytes)
 }
+
+// singleAction prepares an expectation for MockServer JSON. MockServer accepts a single action
+// per expectation, so templated responses and errors drop the static one. A response's
+// schemaRef stays in the stored configuration: its body is already rendered, and MockServer's
+// httpResponse schema does not define the field.
+func singleAction(exp MockExpectation) MockExpectation {
+	if exp.HttpResponse != nil && exp.HttpResponse.SchemaRef != "" {
+		resp := *exp.HttpResponse
+		resp.SchemaRef = ""
+		exp.HttpResponse = &resp
+	}
+	if exp.HttpError != nil {
+		exp.HttpResponse = nil
+		exp.HttpResponseTemplate = nil
+	}
+	if exp.HttpResponseTemplate != nil {
+		exp.HttpResponse = nil
+	}
+	return exp
+}
@@ -0,0 +1,177 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hemantobora/auto-mock/internal/faker"
+)
+
+// FakeData configures how ${uuid}, ${name} and similar placeholders in response bodies are
+// filled in when the project is saved
+type FakeData struct {
+	Seed       int64           `json:"seed,omitempty"`       // Non-zero makes the generated values reproducible
+	Generators []DataGenerator `json:"generators,omitempty"` // Project generators, used as ${name}
+}
+
+// DataGenerator is a project-defined generator: it picks one of Values or fills Pattern
+type DataGenerator struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Values      []string `json:"values,omitempty"`
+	Pattern     string   `json:"pattern,omitempty"` // # is a digit, ? a letter, * either, e.g. "ORD-####"
+}
+
+var generatorName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Validate checks the generator's name and that it has exactly one source of values
+func (g DataGenerator) Validate() error {
+	if !generatorName.MatchString(g.Name) {
+		return fmt.Errorf("generator name %q must be letters, digits and _ (used as ${%s})", g.Name, g.Name)
+	}
+	if (len(g.Values) == 0) == (g.Pattern == "") {
+		return fmt.Errorf("generator %s needs either values or a pattern", g.Name)
+	}
+	return nil
+}
+
+// Faker returns a faker seeded and extended with the project's generators
+func (c *MockConfiguration) Faker() (*faker.Faker, error) {
+	if c.FakeData == nil {
+		return faker.New(0), nil
+	}
+	f := faker.New(c.FakeData.Seed)
+	for _, g := range c.FakeData.Generators {
+		if err := g.Validate(); err != nil {
+			return nil, ValidationError{Field: "fake_data.generators", Message: err.Error()}
+		}
+		if len(g.Values) > 0 {
+			f.Define(g.Name, faker.OneOf(g.Values))
+		} else {
+			f.Define(g.Name, faker.Pattern(g.Pattern))
+		}
+	}
+	return f, nil
+}
+
+// ApplyFakeData replaces the fake-data placeholders in response bodies, headers and cookies
+// (rule responses included) with generated values. Expansion happens once: the stored
+// expectations keep the values. Bodies rendered from a schema and generated companions are
+// left alone. It returns the number of expectations changed.
+func (c *MockConfiguration) ApplyFakeData() (int, error) {
+	f, err := c.Faker()
+	if err != nil {
+		return 0, err
+	}
+	changed := 0
+	for i := range c.Expectations {
+		exp := &c.Expectations[i]
+		if exp.Generated() {
+			continue
+		}
+		field := fmt.Sprintf("expectations[%d].httpResponse", i)
+		touched, err := expandResponse(f, exp.HttpResponse, field)
+		if err != nil {
+			return changed, err
+		}
+		for j, rule := range exp.Rules {
+			ruleTouched, err := expandResponse(f, rule.Response, fmt.Sprintf("expectations[%d].rules[%d].response", i, j))
+			if err != nil {
+				return changed, err
+			}
+			touched = touched || ruleTouched
+		}
+		if touched {
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+// expandResponse expands the placeholders of one response in place; it reports whether any
+// were found
+func expandResponse(f *faker.Faker, resp *HttpResponse, field string) (bool, error) {
+	if resp == nil {
+		return false, nil
+	}
+	touched := false
+	if resp.SchemaRef == "" {
+		body, ok, err := expandBody(f, resp.Body)
+		if err != nil {
+			return false, ValidationError{Field: field + ".body", Message: err.Error()}
+		}
+		if ok {
+			resp.Body = body
+			touched = true
+		}
+	}
+	for _, list := range [][]NameValues{resp.Headers, resp.Cookies} {
+		for j := range list {
+			for k, v := range list[j].Values {
+				if !f.Contains(v) {
+					continue
+				}
+				expanded, err := f.Expand(v)
+				if err != nil {
+					return false, ValidationError{Field: field + "." + list[j].Name, Message: err.Error()}
+				}
+				list[j].Values[k] = expanded
+				touched = true
+			}
+		}
+	}
+	return touched, nil
+}
+
+// expandBody expands the placeholders of a response body; ok is false when it has none. A
+// text body that becomes JSON is stored as JSON.
+func expandBody(f *faker.Faker, body any) (any, bool, error) {
+	switch b := body.(type) {
+	case string:
+		if !f.Contains(b) {
+			return body, false, nil
+		}
+		text, err := f.Expand(b)
+		if err != nil {
+			return nil, false, err
+		}
+		var v any
+		if json.Unmarshal([]byte(text), &v) == nil && !strings.Contains(text, "$!") {
+			switch v.(type) {
+			case map[string]any, []any:
+				return map[string]any{"type": "JSON", "json": v}, true, nil
+			}
+		}
+		return text, true, nil
+	case map[string]any:
+		key := ""
+		switch strings.ToUpper(fmt.Sprint(b["type"])) {
+		case "JSON":
+			key = "json"
+		case "STRING":
+			key = "string"
+		case "XML":
+			key = "xml"
+		}
+		if key == "" {
+			return body, false, nil
+		}
+		raw, _ := json.Marshal(b[key])
+		if !f.Contains(string(raw)) {
+			return body, false, nil
+		}
+		expanded, err := f.ExpandValue(b[key])
+		if err != nil {
+			return nil, false, err
+		}
+		out := make(map[string]any, len(b))
+		for k, v := range b {
+			out[k] = v
+		}
+		out[key] = expanded
+		return out, true, nil
+	}
+	return body, false, nil
+}
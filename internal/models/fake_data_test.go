@@ -0,0 +1,54 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplyFakeData(t *testing.T) {
+	cfg := &MockConfiguration{
+		FakeData: &FakeData{Seed: 3, Generators: []DataGenerator{{Name: "orderId", Pattern: "ORD-####"}}},
+		Expectations: []MockExpectation{
+			{
+				HttpRequest: &HttpRequest{Method: "GET", Path: "/orders/1"},
+				HttpResponse: &HttpResponse{
+					StatusCode: 200,
+					Headers:    []NameValues{{Name: "X-Request-Id", Values: []string{"${uuid}"}}},
+					Body:       map[string]any{"type": "JSON", "json": map[string]any{"id": "${orderId}", "qty": "${int:2-2}"}},
+				},
+			},
+			{
+				HttpRequest:  &HttpRequest{Method: "POST", Path: "/orders"},
+				HttpResponse: &HttpResponse{StatusCode: 201, Body: `{"id": "$!uuid", "path": "$!request.path"}`},
+			},
+			{HttpRequest: &HttpRequest{Method: "GET", Path: "/health"}, HttpResponse: &HttpResponse{StatusCode: 200, Body: "ok"}},
+		},
+	}
+	n, err := cfg.ApplyFakeData()
+	if err != nil || n != 2 {
+		t.Fatalf("changed %d, err %v", n, err)
+	}
+	first := cfg.Expectations[0].HttpResponse
+	body := first.Body.(map[string]any)["json"].(map[string]any)
+	if id, _ := body["id"].(string); !strings.HasPrefix(id, "ORD-") || len(id) != 8 || body["qty"] != 2 {
+		t.Errorf("body = %v", body)
+	}
+	if v := first.Headers[0].Values[0]; strings.Contains(v, "$") || len(v) != 36 {
+		t.Errorf("header = %q", v)
+	}
+	// Request-dependent Velocity placeholders stay, so the body stays text
+	second, _ := cfg.Expectations[1].HttpResponse.Body.(string)
+	if strings.Contains(second, "$!uuid") || !strings.Contains(second, "$!request.path") {
+		t.Errorf("second body = %v", cfg.Expectations[1].HttpResponse.Body)
+	}
+
+	// Values are stored; saving again changes nothing
+	if n, _ := cfg.ApplyFakeData(); n != 0 {
+		t.Errorf("second apply changed %d expectations", n)
+	}
+
+	cfg.FakeData.Generators = append(cfg.FakeData.Generators, DataGenerator{Name: "bad name", Values: []string{"x"}})
+	if _, err := cfg.ApplyFakeData(); err == nil {
+		t.Error("expected an invalid generator name to be rejected")
+	}
+}
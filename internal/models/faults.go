@@ -0,0 +1,210 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Fault injection: an expectation with Faults set gets a companion placed right before it that
+// serves the response unreliably, so clients can be tested for retries, timeouts and partial
+// reads. Latency alone becomes a MockServer delay; an error rate or jitter becomes a
+// JavaScript response template (run by MockServer, and by automock serve) that draws the
+// outcome per request; a connection reset or truncated body becomes an httpError action,
+// which MockServer applies to every matching request.
+
+// Faults configures the failures injected into an expectation's response
+type Faults struct {
+	ErrorRate   int  `json:"errorRate,omitempty"`       // Percentage (1-100) of requests answered with ErrorStatus instead
+	ErrorStatus int  `json:"errorStatus,omitempty"`     // 5xx status of injected errors; default 503
+	LatencyMs   int  `json:"latencyMs,omitempty"`       // Fixed delay before every response
+	JitterMs    int  `json:"jitterMs,omitempty"`        // Random extra delay of up to this many ms per request
+	Reset       bool `json:"connectionReset,omitempty"` // Drop the connection without answering
+	Truncate    bool `json:"truncateBody,omitempty"`    // Send the headers and half the body, then drop the connection
+}
+
+// HttpError is MockServer's httpError action: it drops the connection, optionally after
+// sending raw bytes
+type HttpError struct {
+	Delay          *Delay `json:"delay,omitempty"`
+	DropConnection bool   `json:"dropConnection,omitempty"`
+	ResponseBytes  string `json:"responseBytes,omitempty"` // base64
+}
+
+// DefaultFaultStatus is the status of injected errors when none is configured
+const DefaultFaultStatus = http.StatusServiceUnavailable
+
+// Status is the status injected errors answer with
+func (f *Faults) Status() int {
+	if f.ErrorStatus == 0 {
+		return DefaultFaultStatus
+	}
+	return f.ErrorStatus
+}
+
+// Describe summarizes the faults, e.g. "20% 503, 200ms ±300ms latency"
+func (f *Faults) Describe() string {
+	var parts []string
+	if f.ErrorRate > 0 {
+		parts = append(parts, fmt.Sprintf("%d%% %d", f.ErrorRate, f.Status()))
+	}
+	switch {
+	case f.JitterMs > 0:
+		parts = append(parts, fmt.Sprintf("%dms ±%dms latency", f.LatencyMs, f.JitterMs))
+	case f.LatencyMs > 0:
+		parts = append(parts, fmt.Sprintf("%dms latency", f.LatencyMs))
+	}
+	if f.Reset {
+		parts = append(parts, "connection reset")
+	}
+	if f.Truncate {
+		parts = append(parts, "truncated body")
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// IsZero reports whether the spec injects nothing
+func (f *Faults) IsZero() bool {
+	return f == nil || *f == Faults{}
+}
+
+// FaultsEligible reports whether faults can be injected into an expectation: a static
+// response (templates, forwards and streams decide their own response)
+func FaultsEligible(exp MockExpectation) bool {
+	return exp.HttpRequest != nil && exp.HttpResponse != nil && exp.HttpResponseTemplate == nil && exp.Forward == nil && exp.Stream == nil
+}
+
+// Check reports what is wrong with a fault spec, or ""
+func (f *Faults) Check(exp MockExpectation) string {
+	switch {
+	case !FaultsEligible(exp):
+		return "faults need a static response (not a template, forward or stream)"
+	case f.ErrorRate < 0 || f.ErrorRate > 100:
+		return fmt.Sprintf("error rate must be a percentage between 0 and 100, got %d", f.ErrorRate)
+	case f.ErrorStatus != 0 && (f.ErrorStatus < 500 || f.ErrorStatus > 599):
+		return fmt.Sprintf("error status must be a 5xx status, got %d", f.ErrorStatus)
+	case f.LatencyMs < 0 || f.JitterMs < 0:
+		return "latency and jitter cannot be negative"
+	case f.Reset && f.Truncate:
+		return "choose either a connection reset or a truncated body"
+	case (f.Reset || f.Truncate) && (f.ErrorRate > 0 || f.JitterMs > 0):
+		return "a connection reset or truncated body applies to every request and cannot be combined with an error rate or jitter"
+	}
+	if f.Truncate {
+		if data, ok := ResponseBytes(exp.HttpResponse.Body); !ok || len(data) < 2 {
+			return "a truncated body needs a response body of at least 2 bytes"
+		}
+	}
+	return ""
+}
+
+// ApplyFaults regenerates the fault companions of every expectation with Faults set. Each
+// companion is placed right before its expectation, after the 304, Range and negotiated
+// companions, so those keep answering reliably. Run it after ApplyRanges.
+// It returns the number of companions generated.
+func (c *MockConfiguration) ApplyFaults() (int, error) {
+	out := make([]MockExpectation, 0, len(c.Expectations))
+	generated := 0
+	for i, exp := range c.Expectations {
+		if exp.FaultInjected {
+			continue // regenerated below from the expectation it disrupts
+		}
+		if exp.Faults.IsZero() || exp.Generated() {
+			out = append(out, exp)
+			continue
+		}
+		if msg := exp.Faults.Check(exp); msg != "" {
+			return generated, ValidationError{Field: fmt.Sprintf("expectations[%d].faults", i), Message: msg}
+		}
+		out = append(out, FaultCompanion(exp), exp)
+		generated++
+	}
+	c.Expectations = out
+	return generated, nil
+}
+
+// FaultCompanion builds the expectation serving exp's response with its faults injected
+func FaultCompanion(exp MockExpectation) MockExpectation {
+	f := exp.Faults
+	v := exp
+	v.Faults = nil
+	v.FaultInjected = true
+	v.ConditionalGet = false
+	v.Ranges = false
+	v.Negotiation = nil
+	v.GraphQLErrors = nil
+	v.Checks = nil
+	v.Examples = nil
+	v.Notes = ""
+	v.Freshness = nil
+	if exp.ID != "" {
+		v.ID = exp.ID + "-faults"
+	}
+	resp := *exp.HttpResponse
+	v.HttpResponse = &resp
+
+	var delay *Delay
+	if f.LatencyMs > 0 {
+		delay = &Delay{TimeUnit: "MILLISECONDS", Value: f.LatencyMs}
+	}
+	switch {
+	case f.Reset:
+		v.HttpError = &HttpError{Delay: delay, DropConnection: true}
+	case f.Truncate:
+		v.HttpError = &HttpError{Delay: delay, DropConnection: true, ResponseBytes: truncatedResponse(exp.HttpResponse)}
+	case f.ErrorRate > 0 || f.JitterMs > 0:
+		v.HttpResponseTemplate = &HttpTemplate{TemplateType: TemplateJavaScript, Template: faultTemplate(exp.HttpResponse, f)}
+	default:
+		resp.Delay = delay
+	}
+	return v
+}
+
+// faultTemplate draws each request's latency and whether it fails. It is ES5 so it runs on
+// MockServer's JavaScript engine as well as locally.
+func faultTemplate(resp *HttpResponse, f *Faults) string {
+	status := resp.StatusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+	data, _ := ResponseBytes(resp.Body)
+	headerJSON, _ := json.Marshal(staticHeaders(resp))
+	body, _ := json.Marshal(base64.StdEncoding.EncodeToString(data))
+	errorBody, _ := json.Marshal(fmt.Sprintf(`{"error": %q, "injected": true}`, http.StatusText(f.Status())))
+	return fmt.Sprintf(`var delay = {timeUnit: 'MILLISECONDS', value: %d + Math.floor(Math.random() * %d)};
+if (Math.random() * 100 < %d) {
+  return {statusCode: %d, headers: {'Content-Type': 'application/json'}, body: %s, delay: delay};
+}
+return {statusCode: %d, headers: %s, body: {type: 'BINARY', base64Bytes: %s}, delay: delay};`,
+		f.LatencyMs, f.JitterMs+1, f.ErrorRate, f.Status(), errorBody, status, headerJSON, body)
+}
+
+// truncatedResponse is a raw HTTP response announcing the whole body but carrying only the
+// first half, base64 encoded for httpError's responseBytes
+func truncatedResponse(resp *HttpResponse) string {
+	status := resp.StatusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+	data, _ := ResponseBytes(resp.Body)
+	var b strings.Builder
+	fmt.Fprintf(&b, "HTTP/1.1 %d %s\r\n", status, http.StatusText(status))
+	headers := staticHeaders(resp)
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names) // stable bytes, so saving again does not change the companion
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s: %s\r\n", name, headers[name])
+	}
+	fmt.Fprintf(&b, "Content-Length: %d\r\nConnection: close\r\n\r\n", len(data))
+	b.Write(data[:len(data)/2])
+	return base64.StdEncoding.EncodeToString([]byte(b.String()))
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestApplyFaults(t *testing.T) {
+	body := map[string]any{"type": "JSON", "json": map[string]any{"id": "u_1"}}
+	cfg := &MockConfiguration{Expectations: []MockExpectation{
+		{
+			ID:           "user",
+			HttpRequest:  &HttpRequest{Method: "GET", Path: "/users/1"},
+			HttpResponse: &HttpResponse{StatusCode: 200, Body: body},
+			Faults:       &Faults{ErrorRate: 20, LatencyMs: 100, JitterMs: 50},
+		},
+		{
+			HttpRequest:  &HttpRequest{Method: "GET", Path: "/slow"},
+			HttpResponse: &HttpResponse{StatusCode: 200, Body: body},
+			Faults:       &Faults{LatencyMs: 800},
+		},
+		{
+			HttpRequest:  &HttpRequest{Method: "GET", Path: "/download"},
+			HttpResponse: &HttpResponse{StatusCode: 200, Body: "0123456789"},
+			Faults:       &Faults{Truncate: true},
+		},
+	}}
+	cfg.ApplyFaults()
+	// Saving again must regenerate rather than accumulate companions
+	if n, err := cfg.ApplyFaults(); err != nil || n != 3 || len(cfg.Expectations) != 6 {
+		t.Fatalf("second apply: generated %d, %d expectations, err %v", n, len(cfg.Expectations), err)
+	}
+
+	jittered := cfg.Expectations[0]
+	if !jittered.FaultInjected || jittered.ID != "user-faults" || jittered.Faults != nil || !jittered.Generated() {
+		t.Fatalf("unexpected companion: %+v", jittered)
+	}
+	if tmpl := jittered.HttpResponseTemplate; tmpl == nil || !strings.Contains(tmpl.Template, "statusCode: 503") {
+		t.Errorf("error rate template = %+v", tmpl)
+	}
+	if d := cfg.Expectations[2].HttpResponse.Delay; d == nil || d.Value != 800 || cfg.Expectations[2].HttpResponseTemplate != nil {
+		t.Errorf("fixed latency companion = %+v", cfg.Expectations[2])
+	}
+
+	cut := cfg.Expectations[4].HttpError
+	if cut == nil || !cut.DropConnection {
+		t.Fatalf("truncation companion = %+v", cfg.Expectations[4])
+	}
+	raw, _ := base64.StdEncoding.DecodeString(cut.ResponseBytes)
+	if !strings.HasPrefix(string(raw), "HTTP/1.1 200 OK\r\n") || !strings.Contains(string(raw), "Content-Length: 10\r\n") || !strings.HasSuffix(string(raw), "\r\n\r\n01234") {
+		t.Errorf("truncated response = %q", raw)
+	}
+	if out := ExpectationsToMockServerJSON(cfg.Expectations[4:5]); !strings.Contains(out, `"httpError"`) || strings.Contains(out, `"httpResponse"`) {
+		t.Errorf("MockServer JSON must carry only the httpError action:\n%s", out)
+	}
+
+	for _, bad := range []*Faults{{ErrorRate: 120}, {ErrorRate: 10, ErrorStatus: 404}, {Reset: true, JitterMs: 10}, {Reset: true, Truncate: true}} {
+		cfg := &MockConfiguration{Expectations: []MockExpectation{{
+			HttpRequest:  &HttpRequest{Method: "GET", Path: "/users"},
+			HttpResponse: &HttpResponse{StatusCode: 200, Body: body},
+			Faults:       bad,
+		}}}
+		if _, err := cfg.ApplyFaults(); err == nil {
+			t.Errorf("faults %+v accepted", *bad)
+		}
+	}
+}
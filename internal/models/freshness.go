@@ -0,0 +1,96 @@
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Freshness ties an expectation recorded from live traffic to its upstream, so that
+// automock refresh can re-execute the request and update the response once it goes stale
+type Freshness struct {
+	Origin     string    `json:"origin"`        // Upstream base URL the request was recorded against
+	RecordedAt time.Time `json:"recordedAt"`    // When the response was recorded or last confirmed
+	TTL        string    `json:"ttl,omitempty"` // How long the response stays fresh, e.g. "12h" or "7d"; empty never expires
+}
+
+// ParseTTL parses a freshness TTL: a Go duration ("36h", "90m") or a number of days ("7d")
+func ParseTTL(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	var d time.Duration
+	var err error
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		var n int
+		if n, err = strconv.Atoi(days); err == nil {
+			d = time.Duration(n) * 24 * time.Hour
+		}
+	} else {
+		d, err = time.ParseDuration(s)
+	}
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid TTL %q: use a positive duration such as 12h or 7d", s)
+	}
+	return d, nil
+}
+
+// Stale reports whether the recorded response has outlived its TTL at now
+func (f *Freshness) Stale(now time.Time) bool {
+	if f == nil || f.TTL == "" {
+		return false
+	}
+	ttl, err := ParseTTL(f.TTL)
+	return err == nil && now.Sub(f.RecordedAt) > ttl
+}
+
+// StaleExpectations returns the indexes of the recorded expectations past their TTL
+func (c *MockConfiguration) StaleExpectations(now time.Time) []int {
+	var stale []int
+	for i := range c.Expectations {
+		if exp := &c.Expectations[i]; !exp.Generated() && exp.Freshness.Stale(now) {
+			stale = append(stale, i)
+		}
+	}
+	return stale
+}
+
+// DiffResponses describes how a freshly recorded response differs from the stored one: the
+// status, header names and JSON shape as in DiffConfigurations, and otherwise changed values.
+// Header values other than Content-Type are ignored; they tend to change on every request.
+func DiffResponses(old, cur *HttpResponse) []string {
+	// The delay is configured rather than recorded, so it is not compared
+	replayed := *cur
+	replayed.Delay = old.Delay
+	diff := &ConfigDiff{}
+	diffExpectation(diff, "", &MockExpectation{HttpResponse: old}, &MockExpectation{HttpResponse: &replayed})
+	var changes []string
+	for _, c := range diff.Changes {
+		changes = append(changes, c.Summary)
+	}
+	if len(changes) > 0 {
+		return changes
+	}
+	oldBody, oldIsJSON := responseJSON(old.Body)
+	newBody, newIsJSON := responseJSON(cur.Body)
+	if oldIsJSON && newIsJSON && !reflect.DeepEqual(oldBody, newBody) {
+		changes = append(changes, fmt.Sprintf("[%d] response values changed", old.StatusCode))
+	}
+	return changes
+}
+
+// Rerecord replaces the recorded response with one freshly recorded at the given time, keeping
+// the configured delay, and updates the first request example that answered with it
+func (e *MockExpectation) Rerecord(resp *HttpResponse, at time.Time) {
+	if e.HttpResponse != nil {
+		resp.Delay = e.HttpResponse.Delay
+	}
+	e.HttpResponse = resp
+	if len(e.Examples) > 0 {
+		e.Examples[0].StatusCode = resp.StatusCode
+		e.Examples[0].Response = examplePayload(resp.Body)
+	}
+	if e.Freshness != nil {
+		e.Freshness.RecordedAt = at
+	}
+}
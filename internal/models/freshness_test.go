@@ -0,0 +1,105 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTTL(t *testing.T) {
+	for in, want := range map[string]time.Duration{"7d": 7 * 24 * time.Hour, "36h": 36 * time.Hour, " 90m ": 90 * time.Minute} {
+		if got, err := ParseTTL(in); err != nil || got != want {
+			t.Errorf("ParseTTL(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "0d", "-1h", "week", "1.5d"} {
+		if _, err := ParseTTL(in); err == nil {
+			t.Errorf("ParseTTL(%q) should fail", in)
+		}
+	}
+}
+
+func TestStaleExpectations(t *testing.T) {
+	now := time.Date(2026, 5, 10, 12, 0, 0, 0, time.UTC)
+	recorded := func(ago time.Duration, ttl string) MockExpectation {
+		return MockExpectation{
+			HttpRequest:  &HttpRequest{Method: "GET", Path: "/users"},
+			HttpResponse: &HttpResponse{StatusCode: 200},
+			Freshness:    &Freshness{Origin: "https://api.example.com", RecordedAt: now.Add(-ago), TTL: ttl},
+		}
+	}
+	cfg := &MockConfiguration{Expectations: []MockExpectation{
+		recorded(8*24*time.Hour, "7d"),
+		recorded(time.Hour, "7d"),
+		recorded(365*24*time.Hour, ""),
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/health"}},
+		recorded(2*time.Hour, "1h"),
+	}}
+	if got := cfg.StaleExpectations(now); len(got) != 2 || got[0] != 0 || got[1] != 4 {
+		t.Fatalf("stale = %v, want [0 4]", got)
+	}
+}
+
+func TestDiffResponses(t *testing.T) {
+	body := func(v map[string]any) any { return map[string]any{"type": "JSON", "json": v} }
+	old := &HttpResponse{StatusCode: 200, Body: body(map[string]any{"id": 1.0, "name": "a"})}
+
+	if changes := DiffResponses(old, &HttpResponse{StatusCode: 200, Body: body(map[string]any{"id": 1.0, "name": "a"}), Delay: &Delay{TimeUnit: "SECONDS", Value: 1}}); len(changes) != 0 {
+		t.Errorf("identical responses reported %v", changes)
+	}
+	if changes := DiffResponses(old, &HttpResponse{StatusCode: 200, Body: body(map[string]any{"id": 1.0, "name": "b"})}); len(changes) != 1 || changes[0] != "[200] response values changed" {
+		t.Errorf("changed value reported %v", changes)
+	}
+	changes := DiffResponses(old, &HttpResponse{StatusCode: 200, Body: body(map[string]any{"id": 1.0, "name": "a", "email": "a@x"})})
+	if len(changes) != 1 || !strings.Contains(changes[0], "email") {
+		t.Errorf("added field reported %v", changes)
+	}
+	if changes := DiffResponses(&HttpResponse{StatusCode: 200, Body: "ok"}, &HttpResponse{StatusCode: 200, Body: "OK"}); len(changes) != 1 || changes[0] != "[200] response body changed" {
+		t.Errorf("changed text body reported %v", changes)
+	}
+}
+
+func TestRerecordKeepsDelayAndUpdatesExample(t *testing.T) {
+	at := time.Date(2026, 5, 10, 12, 0, 0, 0, time.UTC)
+	exp := &MockExpectation{
+		HttpResponse: &HttpResponse{StatusCode: 200, Delay: &Delay{TimeUnit: "MILLISECONDS", Value: 50}},
+		Examples:     []Example{{Path: "/users/1", StatusCode: 200}},
+		Freshness:    &Freshness{Origin: "https://api.example.com"},
+	}
+	exp.Rerecord(&HttpResponse{StatusCode: 201, Body: map[string]any{"type": "JSON", "json": map[string]any{"id": 1.0}}}, at)
+	if exp.HttpResponse.StatusCode != 201 || exp.HttpResponse.Delay == nil || exp.HttpResponse.Delay.Value != 50 {
+		t.Errorf("response = %+v", exp.HttpResponse)
+	}
+	if ex := exp.Examples[0]; ex.StatusCode != 201 || ex.Response.(map[string]any)["id"] != 1.0 {
+		t.Errorf("example = %+v", ex)
+	}
+	if !exp.Freshness.RecordedAt.Equal(at) {
+		t.Errorf("recordedAt = %v", exp.Freshness.RecordedAt)
+	}
+}
+
+func TestVerifyResponse(t *testing.T) {
+	expected := &HttpResponse{
+		StatusCode: 200,
+		Headers:    []NameValues{{Name: "Content-Type", Values: []string{"application/json"}}, {Name: "X-Request-Id", Values: []string{"abc"}}},
+		Body:       map[string]any{"type": "JSON", "json": map[string]any{"id": "u1", "age": 30.0, "tags": []any{"a"}}},
+	}
+	actual := &HttpResponse{
+		StatusCode: 200,
+		Headers:    []NameValues{{Name: "content-type", Values: []string{"application/json; charset=utf-8"}}, {Name: "X-Request-Id", Values: []string{"zzz"}}},
+		Body:       `{"id": "u2", "age": 41, "tags": ["b", "c"], "extra": true}`,
+	}
+	if got := VerifyResponse(expected, actual); len(got) != 0 {
+		t.Fatalf("compatible response reported: %v", got)
+	}
+
+	actual.StatusCode = 201
+	actual.Headers = actual.Headers[:1]
+	actual.Body = `{"id": 7, "tags": []}`
+	got := strings.Join(VerifyResponse(expected, actual), "\n")
+	for _, want := range []string{"status code changed 200 → 201", "response header x-request-id removed", "$.age removed", "$.id changed type string → number"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("mismatches missing %q:\n%s", want, got)
+		}
+	}
+}
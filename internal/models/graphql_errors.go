@@ -0,0 +1,326 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// GraphQLErrors makes a GraphQL expectation also answer with GraphQL's error semantics:
+// an errors array next to null or partial data, still with HTTP 200. Each variant is
+// selected by naming it in a request header or a GraphQL variable, so clients can
+// exercise their error handling against the same operation.
+type GraphQLErrors struct {
+	Variants []string `json:"variants"`           // Variants to generate: errors, partial
+	Header   string   `json:"header,omitempty"`   // Request header naming the variant (default X-GraphQL-Error)
+	Variable string   `json:"variable,omitempty"` // GraphQL variable naming the variant, instead of a header
+	Field    string   `json:"field,omitempty"`    // Dotted path of the failing field under data, e.g. "user.email" or "users.0.email"
+	Message  string   `json:"message,omitempty"`  // Error message (default: derived from the failing field)
+	Code     string   `json:"code,omitempty"`     // extensions.code of the error (default INTERNAL_SERVER_ERROR)
+}
+
+// GraphQL error variants
+const (
+	GraphQLVariantErrors  = "errors"  // data is null and the error points at the root field
+	GraphQLVariantPartial = "partial" // the recorded data with the failing field null, plus its error
+)
+
+// GraphQLVariants lists the supported variants in the order they are generated
+var GraphQLVariants = []string{GraphQLVariantErrors, GraphQLVariantPartial}
+
+// DefaultGraphQLErrorHeader selects a variant when no variable is configured
+const DefaultGraphQLErrorHeader = "X-GraphQL-Error"
+
+const defaultGraphQLErrorCode = "INTERNAL_SERVER_ERROR"
+
+// Selector describes how a request selects the variant, e.g. `X-GraphQL-Error: partial`
+func (g *GraphQLErrors) Selector(variant string) string {
+	if g.Variable != "" {
+		return fmt.Sprintf("variables.%s = %q", g.Variable, variant)
+	}
+	return g.header() + ": " + variant
+}
+
+func (g *GraphQLErrors) header() string {
+	if g.Header == "" {
+		return DefaultGraphQLErrorHeader
+	}
+	return g.Header
+}
+
+// GraphQLErrorsEligible reports whether an expectation can get GraphQL error variants: its
+// static JSON response holds a GraphQL data object
+func GraphQLErrorsEligible(exp MockExpectation) bool {
+	if exp.HttpRequest == nil || exp.HttpResponse == nil || exp.HttpResponseTemplate != nil || exp.Forward != nil || exp.Stream != nil {
+		return false
+	}
+	_, ok := graphQLData(exp.HttpResponse.Body)
+	return ok
+}
+
+// DefaultGraphQLErrorField picks the field that fails in the partial variant: the first
+// field (other than id and __typename) of the first root field, looking into the first
+// element of a list
+func DefaultGraphQLErrorField(exp MockExpectation) string {
+	if exp.HttpResponse == nil {
+		return ""
+	}
+	data, ok := graphQLData(exp.HttpResponse.Body)
+	if !ok {
+		return ""
+	}
+	root := sortedKeys(data)[0]
+	path := []string{root}
+	value := data[root]
+	if list, isList := value.([]any); isList && len(list) > 0 {
+		path = append(path, "0")
+		value = list[0]
+	}
+	obj, isObj := value.(map[string]any)
+	if !isObj || len(obj) == 0 {
+		return root
+	}
+	keys := sortedKeys(obj)
+	field := keys[0]
+	for _, k := range keys {
+		if k != "id" && k != "__typename" {
+			field = k
+			break
+		}
+	}
+	return strings.Join(append(path, field), ".")
+}
+
+// ApplyGraphQLErrors regenerates the error variants of every expectation with a GraphQL
+// errors spec. Variants are placed right before their expectation so they win ties
+// (declaration order); requests that select no variant get the recorded data.
+// It returns the number of variants generated.
+func (c *MockConfiguration) ApplyGraphQLErrors() (int, error) {
+	out := make([]MockExpectation, 0, len(c.Expectations))
+	generated := 0
+	for i, exp := range c.Expectations {
+		if exp.GraphQLVariant != "" {
+			continue // regenerated below from the expectation it was derived from
+		}
+		if exp.GraphQLErrors == nil || len(exp.GraphQLErrors.Variants) == 0 || exp.Generated() {
+			out = append(out, exp)
+			continue
+		}
+		variants, err := GraphQLErrorVariants(exp)
+		if err != nil {
+			return generated, ValidationError{
+				Field:   fmt.Sprintf("expectations[%d].graphqlErrors", i),
+				Message: err.Error(),
+			}
+		}
+		out = append(out, variants...)
+		generated += len(variants)
+		out = append(out, exp)
+	}
+	c.Expectations = out
+	return generated, nil
+}
+
+// GraphQLErrorVariants builds one expectation per configured variant, each matching the
+// original request plus its selector and answering with a GraphQL errors payload
+func GraphQLErrorVariants(exp MockExpectation) ([]MockExpectation, error) {
+	spec := exp.GraphQLErrors
+	if !GraphQLErrorsEligible(exp) {
+		return nil, fmt.Errorf("GraphQL error variants need a static JSON response with a data object")
+	}
+	if spec.Header != "" && spec.Variable != "" {
+		return nil, fmt.Errorf("select variants by a header or a variable, not both")
+	}
+	data, _ := graphQLData(exp.HttpResponse.Body)
+	field := spec.Field
+	if field == "" {
+		field = DefaultGraphQLErrorField(exp)
+	}
+	path, err := graphQLPath(data, field)
+	if err != nil {
+		return nil, err
+	}
+	message := spec.Message
+	if message == "" {
+		message = fmt.Sprintf("Failed to resolve field '%v'", path[len(path)-1])
+	}
+	code := spec.Code
+	if code == "" {
+		code = defaultGraphQLErrorCode
+	}
+
+	var variants []MockExpectation
+	for _, name := range spec.Variants {
+		name = strings.ToLower(name)
+		var payload map[string]any
+		switch name {
+		case GraphQLVariantErrors:
+			rootMessage := spec.Message
+			if rootMessage == "" {
+				rootMessage = fmt.Sprintf("Failed to resolve field '%v'", path[0])
+			}
+			payload = map[string]any{"data": nil, "errors": []any{graphQLError(rootMessage, path[:1], code)}}
+		case GraphQLVariantPartial:
+			partial := copyJSON(data).(map[string]any)
+			setJSONPath(partial, path, nil)
+			payload = map[string]any{"data": partial, "errors": []any{graphQLError(message, path, code)}}
+		default:
+			return nil, fmt.Errorf("unsupported GraphQL error variant %q (supported: %s)", name, strings.Join(GraphQLVariants, ", "))
+		}
+
+		v := exp
+		v.GraphQLErrors = nil
+		v.GraphQLVariant = name
+		v.Negotiation = nil
+		v.ConditionalGet = false
+		v.Ranges = false
+		v.Checks = nil
+		v.Examples = nil
+		v.Notes = ""
+		v.Freshness = nil
+		if exp.ID != "" {
+			v.ID = exp.ID + "-graphql-" + name
+		}
+		req, err := selectGraphQLVariant(*exp.HttpRequest, spec, name)
+		if err != nil {
+			return nil, err
+		}
+		v.HttpRequest = &req
+		resp := *exp.HttpResponse
+		resp.SchemaRef = ""
+		resp.Body = map[string]any{"type": "JSON", "json": payload}
+		resp.Headers = withHeader(append([]NameValues(nil), resp.Headers...), "Content-Type", "application/json")
+		v.HttpResponse = &resp
+		variants = append(variants, v)
+	}
+	return variants, nil
+}
+
+// selectGraphQLVariant narrows the request matcher to requests naming the variant
+func selectGraphQLVariant(req HttpRequest, spec *GraphQLErrors, name string) (HttpRequest, error) {
+	if spec.Variable == "" {
+		req.Headers = withHeader(append([]NameValues(nil), req.Headers...), spec.header(), name)
+		return req, nil
+	}
+
+	// GET operations carry their variables as a JSON-encoded query parameter
+	if strings.EqualFold(req.Method, "GET") {
+		pattern := fmt.Sprintf(`.*"%s"\s*:\s*"%s".*`, regexp.QuoteMeta(spec.Variable), regexp.QuoteMeta(name))
+		query := make([]NameValues, 0, len(req.QueryStringParameters)+1)
+		for _, q := range req.QueryStringParameters {
+			if q.Name != "variables" {
+				query = append(query, q)
+			}
+		}
+		req.QueryStringParameters = append(query, NameValues{Name: "variables", Values: []string{pattern}})
+		return req, nil
+	}
+
+	envelope := map[string]any{}
+	matchType := "ONLY_MATCHING_FIELDS"
+	if req.Body != nil {
+		body, ok := req.Body.(map[string]any)
+		if !ok || !strings.EqualFold(fmt.Sprint(body["type"]), "JSON") {
+			return req, fmt.Errorf("variable %q can only select variants of a JSON body matcher; use a header instead", spec.Variable)
+		}
+		decoded, ok := responseJSON(body)
+		if obj, isObj := decoded.(map[string]any); ok && isObj {
+			envelope = copyJSON(obj).(map[string]any)
+		} else if ok {
+			return req, fmt.Errorf("variable %q can only select variants of a JSON object body; use a header instead", spec.Variable)
+		}
+		if mt, ok := body["matchType"].(string); ok && mt != "" {
+			matchType = mt
+		}
+	}
+	variables, _ := envelope["variables"].(map[string]any)
+	if variables == nil {
+		variables = map[string]any{}
+	}
+	variables[spec.Variable] = name
+	envelope["variables"] = variables
+	req.Body = map[string]any{"type": "JSON", "json": envelope, "matchType": matchType}
+	return req, nil
+}
+
+// graphQLData returns the data object of a GraphQL JSON response
+func graphQLData(body any) (map[string]any, bool) {
+	v, ok := canonicalJSON(body)
+	if !ok {
+		return nil, false
+	}
+	obj, _ := v.(map[string]any)
+	data, _ := obj["data"].(map[string]any)
+	return data, len(data) > 0
+}
+
+// graphQLPath resolves a dotted field path against the data object into a GraphQL error
+// path, in which list indexes are numbers
+func graphQLPath(data map[string]any, field string) ([]any, error) {
+	var path []any
+	var cur any = data
+	for _, seg := range strings.Split(field, ".") {
+		switch node := cur.(type) {
+		case map[string]any:
+			next, ok := node[seg]
+			if !ok {
+				return nil, fmt.Errorf("field %q not found in the response data", field)
+			}
+			path = append(path, seg)
+			cur = next
+		case []any:
+			idx, err := strconv.Atoi(seg)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return nil, fmt.Errorf("field %q: %q is not an index of a %d-element list", field, seg, len(node))
+			}
+			path = append(path, idx)
+			cur = node[idx]
+		default:
+			return nil, fmt.Errorf("field %q not found in the response data", field)
+		}
+	}
+	if len(path) == 0 {
+		return nil, fmt.Errorf("empty GraphQL error field")
+	}
+	return path, nil
+}
+
+func graphQLError(message string, path []any, code string) map[string]any {
+	return map[string]any{
+		"message":    message,
+		"path":       append([]any(nil), path...),
+		"extensions": map[string]any{"code": code},
+	}
+}
+
+// setJSONPath replaces the value at a path resolved by graphQLPath
+func setJSONPath(root map[string]any, path []any, value any) {
+	var cur any = root
+	for i, seg := range path {
+		last := i == len(path)-1
+		switch node := cur.(type) {
+		case map[string]any:
+			if last {
+				node[seg.(string)] = value
+				return
+			}
+			cur = node[seg.(string)]
+		case []any:
+			if last {
+				node[seg.(int)] = value
+				return
+			}
+			cur = node[seg.(int)]
+		}
+	}
+}
+
+// copyJSON deep-copies a decoded JSON value
+func copyJSON(v any) any {
+	raw, _ := json.Marshal(v)
+	var out any
+	_ = json.Unmarshal(raw, &out)
+	return out
+}
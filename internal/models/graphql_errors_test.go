@@ -0,0 +1,107 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func graphQLUser() MockExpectation {
+	return MockExpectation{
+		ID: "get-user",
+		HttpRequest: &HttpRequest{Method: "POST", Path: "/graphql", Body: map[string]any{
+			"type":      "JSON",
+			"json":      map[string]any{"query": "query GetUser($id: ID!) { user(id: $id) { id name email } }", "variables": map[string]any{"id": "1"}},
+			"matchType": "STRICT",
+		}},
+		HttpResponse: &HttpResponse{StatusCode: 200, Body: map[string]any{"type": "JSON", "json": map[string]any{
+			"data": map[string]any{"user": map[string]any{"id": "1", "name": "Ada", "email": "ada@example.com"}},
+		}}},
+	}
+}
+
+func TestApplyGraphQLErrors(t *testing.T) {
+	exp := graphQLUser()
+	exp.GraphQLErrors = &GraphQLErrors{Variants: GraphQLVariants}
+	cfg := &MockConfiguration{Expectations: []MockExpectation{exp}}
+
+	if n, err := cfg.ApplyGraphQLErrors(); err != nil || n != 2 {
+		t.Fatalf("generated %d, err %v", n, err)
+	}
+	// Saving again must regenerate rather than accumulate variants
+	if n, err := cfg.ApplyGraphQLErrors(); err != nil || n != 2 || len(cfg.Expectations) != 3 {
+		t.Fatalf("second apply: generated %d, %d expectations, err %v", n, len(cfg.Expectations), err)
+	}
+
+	errorsVariant, partial, base := cfg.Expectations[0], cfg.Expectations[1], cfg.Expectations[2]
+	if errorsVariant.GraphQLVariant != "errors" || partial.ID != "get-user-graphql-partial" || base.GraphQLErrors == nil || !partial.Generated() {
+		t.Fatalf("unexpected order: %q %q %v", errorsVariant.GraphQLVariant, partial.ID, base.GraphQLErrors)
+	}
+	if got := partial.HttpRequest.Headers; len(got) != 1 || got[0].Name != "X-GraphQL-Error" || got[0].Values[0] != "partial" {
+		t.Errorf("partial matcher headers = %+v", got)
+	}
+	if len(base.HttpRequest.Headers) != 0 {
+		t.Errorf("base request gained headers: %+v", base.HttpRequest.Headers)
+	}
+
+	var got map[string]any
+	raw, _ := json.Marshal(partial.HttpResponse.Body.(map[string]any)["json"])
+	_ = json.Unmarshal(raw, &got)
+	want := map[string]any{
+		"data": map[string]any{"user": map[string]any{"id": "1", "name": "Ada", "email": nil}},
+		"errors": []any{map[string]any{
+			"message":    "Failed to resolve field 'email'",
+			"path":       []any{"user", "email"},
+			"extensions": map[string]any{"code": "INTERNAL_SERVER_ERROR"},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("partial body = %s", raw)
+	}
+	if data := errorsVariant.HttpResponse.Body.(map[string]any)["json"].(map[string]any)["data"]; data != nil {
+		t.Errorf("errors variant data = %v, want null", data)
+	}
+	if email := base.HttpResponse.Body.(map[string]any)["json"].(map[string]any)["data"].(map[string]any)["user"].(map[string]any)["email"]; email != "ada@example.com" {
+		t.Errorf("base response was modified: email = %v", email)
+	}
+
+	cfg.Expectations[2].GraphQLErrors = nil
+	if n, _ := cfg.ApplyGraphQLErrors(); n != 0 || len(cfg.Expectations) != 1 {
+		t.Errorf("turning variants off left %d expectations", len(cfg.Expectations))
+	}
+}
+
+func TestGraphQLErrorVariantsByVariable(t *testing.T) {
+	exp := graphQLUser()
+	exp.HttpResponse.Body = map[string]any{"type": "JSON", "json": map[string]any{
+		"data": map[string]any{"users": []any{map[string]any{"id": "1", "email": "a@example.com"}}},
+	}}
+	exp.GraphQLErrors = &GraphQLErrors{Variants: []string{"partial"}, Variable: "mockError", Field: "users.0.email", Code: "FORBIDDEN"}
+
+	variants, err := GraphQLErrorVariants(exp)
+	if err != nil || len(variants) != 1 {
+		t.Fatalf("variants %d, err %v", len(variants), err)
+	}
+	body := variants[0].HttpRequest.Body.(map[string]any)
+	vars := body["json"].(map[string]any)["variables"].(map[string]any)
+	if vars["mockError"] != "partial" || vars["id"] != "1" || body["matchType"] != "STRICT" {
+		t.Errorf("variant body matcher = %v", body)
+	}
+	if orig := exp.HttpRequest.Body.(map[string]any)["json"].(map[string]any)["variables"].(map[string]any); len(orig) != 1 {
+		t.Errorf("original variables were modified: %v", orig)
+	}
+	errs := variants[0].HttpResponse.Body.(map[string]any)["json"].(map[string]any)["errors"].([]any)
+	if e := errs[0].(map[string]any); !reflect.DeepEqual(e["path"], []any{"users", 0, "email"}) || e["extensions"].(map[string]any)["code"] != "FORBIDDEN" {
+		t.Errorf("error entry = %v", e)
+	}
+
+	exp.GraphQLErrors.Field = "users.3.email"
+	if _, err := GraphQLErrorVariants(exp); err == nil {
+		t.Error("expected an out-of-range field to be rejected")
+	}
+	exp.GraphQLErrors.Field = ""
+	exp.HttpResponse.Body = map[string]any{"type": "JSON", "json": map[string]any{"errors": []any{}}}
+	if GraphQLErrorsEligible(exp) {
+		t.Error("a response without data should not be eligible")
+	}
+}
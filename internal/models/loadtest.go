@@ -1,13 +1,17 @@
 package models
 
-import "time"
+import (
+	"sort"
+	"time"
+)
 
 // LoadTestPointer represents the active pointer for a project's load test bundle
 // Stored at: configs/<project>-loadtest/current.json
 // References an immutable version file under versions/
 type LoadTestPointer struct {
 	ProjectID     string            `json:"project_id"`
-	ArtifactType  string            `json:"artifact_type"` // always: "loadtest_bundle"
+	ArtifactType  string            `json:"artifact_type"`  // always: "loadtest_bundle"
+	Tool          string            `json:"tool,omitempty"` // locust (when empty) or k6
 	ActiveVersion string            `json:"active_version"`
 	BundleID      string            `json:"bundle_id"`
 	UpdatedAt     time.Time         `json:"updated_at"`
@@ -29,12 +33,65 @@ type LoadTestVersion struct {
 	ProjectID  string                    `json:"project_id"`
 	Version    string                    `json:"version"`
 	BundleID   string                    `json:"bundle_id"`
+	Tool       string                    `json:"tool,omitempty"` // locust (when empty) or k6
 	CreatedAt  time.Time                 `json:"created_at"`
 	Hashes     map[string]string         `json:"hashes"`     // filename -> sha256:hex
 	Validation *LoadTestValidationResult `json:"validation"` // validation outcome
 	Metrics    map[string]int            `json:"metrics,omitempty"`
 }
 
+// LoadTestRunResult summarizes one run of a bundle version
+// Stored at: configs/<project>-loadtest/results/<version>/<run_id>.json
+type LoadTestRunResult struct {
+	ProjectID       string             `json:"project_id,omitempty"`
+	Version         string             `json:"version,omitempty"`
+	BundleID        string             `json:"bundle_id,omitempty"`
+	RunID           string             `json:"run_id"`
+	Target          string             `json:"target"` // local | deployed
+	Host            string             `json:"host,omitempty"`
+	Users           int                `json:"users"`
+	SpawnRate       float64            `json:"spawn_rate"`
+	StartedAt       time.Time          `json:"started_at"`
+	DurationSeconds int                `json:"duration_seconds"`
+	Total           LoadTestRunStats   `json:"total"`
+	Endpoints       []LoadTestRunStats `json:"endpoints"`
+}
+
+// SortLoadTestResults orders run results oldest first
+func SortLoadTestResults(results []LoadTestRunResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if !results[i].StartedAt.Equal(results[j].StartedAt) {
+			return results[i].StartedAt.Before(results[j].StartedAt)
+		}
+		return results[i].RunID < results[j].RunID
+	})
+}
+
+// LoadTestRunStats are the request statistics of one endpoint, or of all requests
+type LoadTestRunStats struct {
+	Method       string  `json:"method,omitempty"`
+	Name         string  `json:"name"`
+	Requests     int     `json:"requests"`
+	Failures     int     `json:"failures"`
+	RPS          float64 `json:"rps"`
+	FailuresPerS float64 `json:"failures_per_s"`
+	AvgMs        float64 `json:"avg_ms"`
+	MinMs        float64 `json:"min_ms"`
+	MaxMs        float64 `json:"max_ms"`
+	P50Ms        float64 `json:"p50_ms"`
+	P90Ms        float64 `json:"p90_ms"`
+	P95Ms        float64 `json:"p95_ms"`
+	P99Ms        float64 `json:"p99_ms"`
+}
+
+// LoadTestBundleInfo describes a stored bundle directory (used for garbage collection)
+type LoadTestBundleInfo struct {
+	BundleID  string    `json:"bundle_id"`
+	CreatedAt time.Time `json:"created_at"` // newest object in the bundle
+	Objects   int       `json:"objects"`
+	Size      int64     `json:"size"`
+}
+
 // LoadTestValidationResult captures validation signals for a bundle upload
 type LoadTestValidationResult struct {
 	LocustfilePresent   bool     `json:"locustfile_present"`
@@ -30,10 +30,11 @@ type LoadTestDeploymentMetadata struct {
 
 // LoadTestDeploymentOptions configures the Locust infrastructure deployment
 type LoadTestDeploymentOptions struct {
-	ProjectName string `json:"-"`
-	Region      string `json:"-"`
-	BucketName  string `json:"-"`
-	Provider    string `json:"provider,omitempty"`
+	ProjectName    string `json:"-"`
+	Region         string `json:"-"`
+	BucketName     string `json:"-"`
+	StorageAccount string `json:"-"` // Azure storage account holding BucketName (the container)
+	Provider       string `json:"provider,omitempty"`
 
 	// Sizing
 	CPUUnits           int `json:"cpu_units"`
@@ -63,6 +64,9 @@ type LoadTestDeploymentOptions struct {
 
 // CreateTerraformVars renders terraform.tfvars for the loadtest stack
 func (o *LoadTestDeploymentOptions) CreateTerraformVars() string {
+	if o.Provider == "azure" {
+		return o.createAzureTerraformVars()
+	}
 	// Base variables (networking/IAM handled internally; BYO/env handled separately)
 	base := fmt.Sprintf(`# AutoMock LoadTest Terraform Variables
 # Generated automatically - do not edit manually
@@ -124,6 +128,37 @@ worker_desired_count = %d
 		}
 	}
 
+	return base + o.extraEnvironmentVars()
+}
+
+// createAzureTerraformVars renders the variables of the Azure Container Instances Locust stack
+func (o *LoadTestDeploymentOptions) createAzureTerraformVars() string {
+	base := fmt.Sprintf(`# AutoMock LoadTest Terraform Variables
+# Generated automatically - do not edit manually
+
+project_name         = "%s"
+azure_location       = "%s"
+storage_account_name = "%s"
+container_name       = "%s"
+cloud_provider       = "azure"
+
+cpu_units            = %d
+memory_units         = %d
+worker_desired_count = %d
+`,
+		o.ProjectName,
+		o.Region,
+		o.StorageAccount,
+		o.BucketName,
+		o.CPUUnits,
+		o.MemoryUnits,
+		o.WorkerDesiredCount,
+	)
+	return base + o.extraEnvironmentVars()
+}
+
+func (o *LoadTestDeploymentOptions) extraEnvironmentVars() string {
+	base := ""
 	if len(o.ExtraEnvironment) > 0 {
 		base += "extra_environment = {\n"
 		keys := make([]string, 0, len(o.ExtraEnvironment))
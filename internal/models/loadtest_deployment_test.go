@@ -1,6 +1,9 @@
 package models
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestLoadTestDeploymentOptions_TerraformVars(t *testing.T) {
 	opts := &LoadTestDeploymentOptions{ProjectName: "demo", Region: "us-east-1", BucketName: "bucket123", Provider: "aws", CPUUnits: 256, MemoryUnits: 512, WorkerDesiredCount: 3}
@@ -13,6 +16,20 @@ func TestLoadTestDeploymentOptions_TerraformVars(t *testing.T) {
 	}
 }
 
+func TestLoadTestDeploymentOptions_AzureTerraformVars(t *testing.T) {
+	opts := &LoadTestDeploymentOptions{ProjectName: "demo", Region: "westeurope", BucketName: "auto-mock-demo-abcd1234", StorageAccount: "mocksacct", Provider: "azure", CPUUnits: 1024, MemoryUnits: 2048, WorkerDesiredCount: 2, ExtraEnvironment: map[string]string{"TOKEN": "x"}}
+	vars := opts.CreateTerraformVars()
+	checks := []string{"azure_location       = \"westeurope\"", "storage_account_name = \"mocksacct\"", "container_name       = \"auto-mock-demo-abcd1234\"", "worker_desired_count = 2", "  TOKEN = \"x\""}
+	for _, c := range checks {
+		if !containsLine(vars, c) {
+			t.Fatalf("expected tfvars to contain line: %s\nGot:\n%s", c, vars)
+		}
+	}
+	if strings.Contains(vars, "aws_region") || strings.Contains(vars, "use_existing_vpc") {
+		t.Fatalf("azure tfvars must not carry AWS variables:\n%s", vars)
+	}
+}
+
 func containsLine(s, line string) bool {
 	for _, l := range splitLines(s) {
 		if l == line {
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// LoadTestSchedule describes the scheduled runs of a project's active load test bundle
+// Stored at: configs/<project>-loadtest/schedule.json
+type LoadTestSchedule struct {
+	ProjectID       string            `json:"project_id"`
+	Cron            string            `json:"cron"` // standard 5-field expression, UTC
+	Users           int               `json:"users"`
+	SpawnRate       float64           `json:"spawn_rate"`
+	DurationSeconds int               `json:"duration_seconds"`
+	Host            string            `json:"host,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+	Resources       map[string]string `json:"resources,omitempty"` // schedule_name, runner_name, log_group
+}
+
+// LoadTestScheduleOptions configures the scheduled load test infrastructure
+type LoadTestScheduleOptions struct {
+	ProjectName        string
+	Region             string
+	BucketName         string
+	StorageAccount     string // Azure storage account holding BucketName (the container)
+	Provider           string
+	Cron               string // standard 5-field expression
+	ScheduleExpression string // AWS only: the EventBridge Scheduler form of Cron
+	Users              int
+	SpawnRate          float64
+	DurationSeconds    int
+	Host               string
+}
+
+// CreateTerraformVars renders terraform.tfvars for the scheduled load test stack
+func (o *LoadTestScheduleOptions) CreateTerraformVars() string {
+	var location string
+	if o.Provider == "azure" {
+		location = fmt.Sprintf(`azure_location       = %q
+storage_account_name = %q
+container_name       = %q
+cloud_provider       = "azure"
+`, o.Region, o.StorageAccount, o.BucketName)
+	} else {
+		location = fmt.Sprintf(`aws_region           = %q
+existing_bucket_name = %q
+cloud_provider       = %q
+schedule_expression  = %q
+`, o.Region, o.BucketName, o.Provider, o.ScheduleExpression)
+	}
+	return fmt.Sprintf(`# AutoMock LoadTest Schedule Terraform Variables
+# Generated automatically - do not edit manually
+
+project_name         = %q
+%s
+cron             = %q
+users            = %d
+spawn_rate       = %s
+duration_seconds = %d
+target_host      = %q
+`,
+		o.ProjectName,
+		location,
+		o.Cron,
+		o.Users,
+		strconv.FormatFloat(o.SpawnRate, 'f', -1, 64),
+		o.DurationSeconds,
+		o.Host,
+	)
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadTestScheduleOptions_TerraformVars(t *testing.T) {
+	opts := &LoadTestScheduleOptions{ProjectName: "demo", Region: "us-east-1", BucketName: "bucket123", Provider: "aws", Cron: "0 2 * * *", ScheduleExpression: "cron(0 2 * * ? *)", Users: 50, SpawnRate: 2.5, DurationSeconds: 300, Host: "https://demo.example.com"}
+	vars := opts.CreateTerraformVars()
+	checks := []string{"existing_bucket_name = \"bucket123\"", "schedule_expression  = \"cron(0 2 * * ? *)\"", "cron             = \"0 2 * * *\"", "users            = 50", "spawn_rate       = 2.5", "duration_seconds = 300", "target_host      = \"https://demo.example.com\""}
+	for _, c := range checks {
+		if !containsLine(vars, c) {
+			t.Fatalf("expected tfvars to contain line: %s\nGot:\n%s", c, vars)
+		}
+	}
+
+	opts.Provider, opts.StorageAccount = "azure", "mocksacct"
+	vars = opts.CreateTerraformVars()
+	if !containsLine(vars, "storage_account_name = \"mocksacct\"") || strings.Contains(vars, "schedule_expression") {
+		t.Fatalf("unexpected azure tfvars:\n%s", vars)
+	}
+}
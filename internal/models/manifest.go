@@ -0,0 +1,218 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// authHeaderHints mark request headers and query parameters that carry credentials
+var authHeaderHints = []string{"authorization", "api-key", "apikey", "api_key", "token", "cookie", "x-auth"}
+
+// RenderManifest writes a Markdown summary of what a project's mock serves, meant to be
+// committed next to consumer code: base URL, versions, endpoints, authentication and error
+// envelopes. baseURL may be empty when the mock is not deployed.
+func RenderManifest(cfg *MockConfiguration, baseURL string, generatedAt time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s mock API\n\n", cfg.Metadata.ProjectID)
+	b.WriteString("_Generated by `automock export manifest`; regenerate instead of editing._\n\n")
+
+	if baseURL == "" {
+		baseURL = "(not deployed)"
+	}
+	fmt.Fprintf(&b, "| | |\n|---|---|\n")
+	fmt.Fprintf(&b, "| Base URL | %s |\n", baseURL)
+	if cfg.Metadata.ContractVersion != "" {
+		fmt.Fprintf(&b, "| Contract version | %s (live at `GET %s`) |\n", cfg.Metadata.ContractVersion, ContractInfoPath)
+	}
+	fmt.Fprintf(&b, "| Config version | %s |\n", cfg.Metadata.Version)
+	if cfg.Metadata.Selection != nil {
+		fmt.Fprintf(&b, "| Tag selection | %s |\n", cfg.Metadata.Selection)
+	}
+	fmt.Fprintf(&b, "| Generated | %s |\n\n", generatedAt.UTC().Format("2006-01-02 15:04 MST"))
+
+	var endpoints []MockExpectation
+	for _, exp := range cfg.Expectations {
+		if exp.HttpRequest != nil && !isInfoExpectation(&exp) {
+			endpoints = append(endpoints, exp)
+		}
+	}
+
+	b.WriteString("## Endpoints\n\n")
+	if len(endpoints) == 0 {
+		b.WriteString("No endpoints.\n\n")
+	} else {
+		b.WriteString("| Method | Path | Status | Auth | Notes |\n|---|---|---|---|---|\n")
+		for _, exp := range endpoints {
+			method := exp.HttpRequest.Method
+			if method == "" {
+				method = "ANY"
+			}
+			fmt.Fprintf(&b, "| %s | `%s` | %s | %s | %s |\n", method, exp.HttpRequest.Path,
+				manifestStatus(&exp), mdCell(strings.Join(authNames(exp.HttpRequest), ", ")), mdCell(manifestNotes(&exp)))
+		}
+		b.WriteString("\n")
+	}
+
+	writeNotesSection(&b, endpoints)
+	writeAuthSection(&b, endpoints)
+	writeErrorSection(&b, endpoints)
+
+	if len(cfg.Changelog) > 0 {
+		b.WriteString("## Recent changes\n\n```\n")
+		entries := cfg.Changelog
+		if len(entries) > 3 {
+			entries = entries[:3]
+		}
+		b.WriteString(FormatChangelog(entries))
+		b.WriteString("```\n")
+	}
+	return b.String()
+}
+
+// writeNotesSection renders the notes of each endpoint that has them, Markdown kept as written
+func writeNotesSection(b *strings.Builder, endpoints []MockExpectation) {
+	var noted []MockExpectation
+	for _, exp := range endpoints {
+		if strings.TrimSpace(exp.Notes) != "" {
+			noted = append(noted, exp)
+		}
+	}
+	if len(noted) == 0 {
+		return
+	}
+	b.WriteString("## Notes\n\n")
+	for _, exp := range noted {
+		method := exp.HttpRequest.Method
+		if method == "" {
+			method = "ANY"
+		}
+		fmt.Fprintf(b, "### %s `%s` (%s)\n\n%s\n\n", method, exp.HttpRequest.Path, manifestStatus(&exp), strings.TrimSpace(exp.Notes))
+	}
+}
+
+func writeAuthSection(b *strings.Builder, endpoints []MockExpectation) {
+	required := map[string][]string{} // credential -> endpoints
+	for _, exp := range endpoints {
+		for _, name := range authNames(exp.HttpRequest) {
+			required[name] = append(required[name], exp.HttpRequest.Method+" "+exp.HttpRequest.Path)
+		}
+	}
+	b.WriteString("## Authentication\n\n")
+	if len(required) == 0 {
+		b.WriteString("No endpoint requires credentials.\n\n")
+		return
+	}
+	names := make([]string, 0, len(required))
+	for name := range required {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(b, "- %s — required by %d endpoint(s)\n", name, len(required[name]))
+	}
+	b.WriteString("\n")
+}
+
+// writeErrorSection lists each distinct error body shape once with the statuses that use it
+func writeErrorSection(b *strings.Builder, endpoints []MockExpectation) {
+	type envelope struct {
+		statuses map[int]bool
+		example  any
+	}
+	var order []string
+	shapes := map[string]*envelope{}
+	for _, exp := range endpoints {
+		if exp.HttpResponse == nil || exp.HttpResponse.StatusCode < 400 {
+			continue
+		}
+		body, ok := responseJSON(exp.HttpResponse.Body)
+		if !ok {
+			continue
+		}
+		key := bodyShape(body)
+		env, seen := shapes[key]
+		if !seen {
+			env = &envelope{statuses: map[int]bool{}, example: body}
+			shapes[key] = env
+			order = append(order, key)
+		}
+		env.statuses[exp.HttpResponse.StatusCode] = true
+	}
+	if len(order) == 0 {
+		return
+	}
+	b.WriteString("## Error envelopes\n\n")
+	for _, key := range order {
+		env := shapes[key]
+		var codes []int
+		for c := range env.statuses {
+			codes = append(codes, c)
+		}
+		sort.Ints(codes)
+		labels := make([]string, len(codes))
+		for i, c := range codes {
+			labels[i] = fmt.Sprint(c)
+		}
+		example, _ := json.MarshalIndent(env.example, "", "  ")
+		fmt.Fprintf(b, "Status %s:\n\n```json\n%s\n```\n\n", strings.Join(labels, ", "), example)
+	}
+}
+
+// authNames lists the credential headers and query parameters a request matcher requires
+func authNames(r *HttpRequest) []string {
+	var names []string
+	add := func(kind, name string) {
+		lower := strings.ToLower(name)
+		for _, hint := range authHeaderHints {
+			if strings.Contains(lower, hint) {
+				names = append(names, fmt.Sprintf("%s `%s`", kind, name))
+				return
+			}
+		}
+	}
+	for _, h := range r.Headers {
+		add("header", h.Name)
+	}
+	for _, q := range r.QueryStringParameters {
+		add("query", q.Name)
+	}
+	return names
+}
+
+func manifestStatus(exp *MockExpectation) string {
+	switch {
+	case exp.HttpResponseTemplate != nil:
+		return "computed"
+	case exp.Forward != nil:
+		return "forwarded"
+	case exp.HttpResponse != nil:
+		return fmt.Sprint(exp.HttpResponse.StatusCode)
+	}
+	return "-"
+}
+
+func manifestNotes(exp *MockExpectation) string {
+	var notes []string
+	if exp.Description != "" {
+		notes = append(notes, exp.Description)
+	}
+	if len(exp.Tags) > 0 {
+		notes = append(notes, "tags: "+strings.Join(exp.Tags, ", "))
+	}
+	if strings.TrimSpace(exp.Notes) != "" {
+		notes = append(notes, "see Notes")
+	}
+	if exp.Times != nil && !exp.Times.Unlimited && exp.Times.RemainingTimes > 0 {
+		notes = append(notes, fmt.Sprintf("answers %d time(s)", exp.Times.RemainingTimes))
+	}
+	return strings.Join(notes, "; ")
+}
+
+// mdCell keeps table cells on one line
+func mdCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderManifest(t *testing.T) {
+	errBody := func(code string) any {
+		return map[string]any{"type": "JSON", "json": map[string]any{"error": map[string]any{"code": code, "message": "x"}}}
+	}
+	cfg := &MockConfiguration{
+		Metadata: ConfigMetadata{ProjectID: "orders", Version: "v3", ContractVersion: "1.2.0"},
+		Expectations: []MockExpectation{
+			{
+				HttpRequest: &HttpRequest{Method: "GET", Path: "/orders/{id}",
+					Headers: []NameValues{{Name: "Authorization", Values: []string{"Bearer .*"}}}},
+				HttpResponse: &HttpResponse{StatusCode: 200},
+				Tags:         []string{"v2-preview"},
+				Notes:        "Simulates the bank timing out\nafter **3** calls.",
+			},
+			{HttpRequest: &HttpRequest{Method: "GET", Path: "/orders/404"}, HttpResponse: &HttpResponse{StatusCode: 404, Body: errBody("NOT_FOUND")}},
+			{HttpRequest: &HttpRequest{Method: "POST", Path: "/orders"}, HttpResponse: &HttpResponse{StatusCode: 422, Body: errBody("INVALID")}},
+			{HttpRequest: &HttpRequest{Method: "GET", Path: ContractInfoPath}, HttpResponse: &HttpResponse{StatusCode: 200}},
+		},
+	}
+
+	out := RenderManifest(cfg, "https://orders.mock.example.com", time.Date(2025, 1, 2, 3, 4, 0, 0, time.UTC))
+	for _, want := range []string{
+		"# orders mock API",
+		"| Base URL | https://orders.mock.example.com |",
+		"| Contract version | 1.2.0",
+		"| GET | `/orders/{id}` | 200 | header `Authorization` | tags: v2-preview; see Notes |",
+		"### GET `/orders/{id}` (200)\n\nSimulates the bank timing out\nafter **3** calls.\n",
+		"- header `Authorization` — required by 1 endpoint(s)",
+		"Status 404, 422:",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("manifest missing %q\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "| GET | `"+ContractInfoPath) {
+		t.Errorf("info endpoint listed as an endpoint")
+	}
+}
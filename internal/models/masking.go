@@ -0,0 +1,487 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Kinds of values a masking rule matches; field:<glob> matches by field name instead
+const (
+	MaskEmail = "email"
+	MaskToken = "token"
+	MaskName  = "name"
+	MaskPhone = "phone"
+	MaskCard  = "card"
+
+	maskFieldPrefix = "field:"
+)
+
+// What a masking rule does to a matched string value
+const (
+	MaskActionMask     = "mask"     // Hide all but a few characters: j***@***.com, ********4242
+	MaskActionTruncate = "truncate" // Keep the first characters: eyJhbG…
+	MaskActionFake     = "fake"     // Replace with a stable fake value: "Alex Morgan"
+	MaskActionRedact   = "redact"   // Replace with [REDACTED]
+)
+
+// MaskRedacted is the replacement used by the redact action
+const MaskRedacted = "[REDACTED]"
+
+// MaskingProfile rewrites response bodies for a deployment or export, e.g. for a
+// customer-facing demo environment. The first rule matching a string value applies.
+type MaskingProfile struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Rules       []MaskRule `json:"rules"`
+}
+
+// MaskRule pairs what to match with what to do to it
+type MaskRule struct {
+	Match  string `json:"match"`  // email, token, name, phone, card, or field:<glob> (e.g. field:*Id)
+	Action string `json:"action"` // mask, truncate, fake, redact
+}
+
+// MaskedBody is a response body a masked deploy replaced; the original is restored on the next deploy
+type MaskedBody struct {
+	Position int `json:"position"` // Index in the served expectation list
+	Original any `json:"original"`
+	Masked   any `json:"masked"`
+}
+
+// MaskingPresets are the built-in profiles; a project profile with the same name wins
+var MaskingPresets = []MaskingProfile{
+	{
+		Name:        "demo",
+		Description: "Fake names and emails, masked phones and cards, truncated tokens",
+		Rules: []MaskRule{
+			{Match: MaskToken, Action: MaskActionTruncate},
+			{Match: MaskEmail, Action: MaskActionFake},
+			{Match: MaskName, Action: MaskActionFake},
+			{Match: MaskPhone, Action: MaskActionMask},
+			{Match: MaskCard, Action: MaskActionMask},
+		},
+	},
+	{
+		Name:        "redact",
+		Description: "Redact every email, name, phone, card and token",
+		Rules: []MaskRule{
+			{Match: MaskToken, Action: MaskActionRedact},
+			{Match: MaskEmail, Action: MaskActionRedact},
+			{Match: MaskName, Action: MaskActionRedact},
+			{Match: MaskPhone, Action: MaskActionRedact},
+			{Match: MaskCard, Action: MaskActionRedact},
+		},
+	},
+}
+
+var (
+	emailValue     = regexp.MustCompile(`^[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}$`)
+	emailInText    = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	jwtValue       = regexp.MustCompile(`^eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*$`)
+	jwtInText      = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	bearerValue    = regexp.MustCompile(`(?i)^bearer\s+\S+$`)
+	tokenField     = regexp.MustCompile(`(?i)(token|secret|password|passwd|api_?key|authorization|credential|session_?id)`)
+	tokenMetaField = regexp.MustCompile(`(?i)(type|expires_?in|expires_?at|expiry)$`)
+	phoneField     = regexp.MustCompile(`(?i)(phone|mobile|^tel$|^fax$)`)
+	phoneValue     = regexp.MustCompile(`^\+\d[\d\s().-]{6,}$`)
+	cardInText     = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+	personFields   = map[string]bool{
+		"firstname": true, "lastname": true, "fullname": true, "givenname": true, "familyname": true,
+		"surname": true, "middlename": true, "displayname": true, "username": true,
+		"contactname": true, "customername": true, "ownername": true,
+	}
+	firstNames = []string{"Alex", "Jordan", "Taylor", "Morgan", "Casey", "Riley", "Avery", "Quinn", "Jamie", "Drew"}
+	lastNames  = []string{"Smith", "Garcia", "Chen", "Patel", "Johnson", "Kim", "Nguyen", "Brown", "Silva", "Novak"}
+)
+
+// Validate checks that every rule has a known match and action
+func (p *MaskingProfile) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("masking profile has no name")
+	}
+	if len(p.Rules) == 0 {
+		return fmt.Errorf("masking profile %s has no rules", p.Name)
+	}
+	for _, r := range p.Rules {
+		switch {
+		case r.Match == MaskEmail, r.Match == MaskToken, r.Match == MaskName, r.Match == MaskPhone, r.Match == MaskCard:
+		case strings.HasPrefix(r.Match, maskFieldPrefix):
+			if _, err := path.Match(strings.TrimPrefix(r.Match, maskFieldPrefix), ""); err != nil {
+				return fmt.Errorf("masking profile %s: bad field pattern %q: %w", p.Name, r.Match, err)
+			}
+		default:
+			return fmt.Errorf("masking profile %s: unknown match %q (email, token, name, phone, card or field:<glob>)", p.Name, r.Match)
+		}
+		switch r.Action {
+		case MaskActionMask, MaskActionTruncate, MaskActionFake, MaskActionRedact:
+		default:
+			return fmt.Errorf("masking profile %s: unknown action %q (mask, truncate, fake, redact)", p.Name, r.Action)
+		}
+	}
+	return nil
+}
+
+// String renders a rule as "match → action"
+func (r MaskRule) String() string {
+	return r.Match + " → " + r.Action
+}
+
+// FindMaskingProfile returns the project profile called name, falling back to the presets
+func (c *MockConfiguration) FindMaskingProfile(name string) (*MaskingProfile, error) {
+	for i := range c.MaskingProfiles {
+		if strings.EqualFold(c.MaskingProfiles[i].Name, name) {
+			return &c.MaskingProfiles[i], nil
+		}
+	}
+	for i := range MaskingPresets {
+		if strings.EqualFold(MaskingPresets[i].Name, name) {
+			return &MaskingPresets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown masking profile %q (available: %s)", name, strings.Join(c.MaskingProfileNames(), ", "))
+}
+
+// MaskBody returns a masked copy of a MockServer response body and the number of values
+// changed. JSON bodies are masked field by field; other text only by value shape (emails,
+// JWTs, card numbers). body itself is not modified.
+func (p *MaskingProfile) MaskBody(body any) (any, int) {
+	switch v := body.(type) {
+	case string:
+		return p.maskBodyText(v)
+	case map[string]any:
+		t, _ := v["type"].(string)
+		if t == "" || t != strings.ToUpper(t) {
+			return p.maskValue("", v, nil)
+		}
+		out := make(map[string]any, len(v))
+		for k, val := range v {
+			out[k] = val
+		}
+		switch t {
+		case "JSON":
+			if text, ok := v["json"].(string); ok {
+				masked, n := p.maskBodyText(text)
+				out["json"] = masked
+				return out, n
+			}
+			masked, n := p.maskValue("", v["json"], nil)
+			out["json"] = masked
+			return out, n
+		case "STRING":
+			if text, ok := v["string"].(string); ok {
+				masked, n := p.maskText(text)
+				out["string"] = masked
+				return out, n
+			}
+		}
+		return body, 0
+	case []any:
+		return p.maskValue("", v, nil)
+	}
+	return body, 0
+}
+
+// MaskExpectations masks every response body in place and returns the number of bodies and
+// values changed
+func (p *MaskingProfile) MaskExpectations(exps []MockExpectation) (bodies, values int) {
+	for i := range exps {
+		resp := exps[i].HttpResponse
+		if resp == nil || resp.Body == nil {
+			continue
+		}
+		if masked, n := p.MaskBody(resp.Body); n > 0 {
+			resp.Body = masked
+			bodies++
+			values += n
+		}
+	}
+	return bodies, values
+}
+
+// ApplyMasking restores bodies masked by a previous deploy, then masks the served bodies with
+// p, keeping the originals so a later deploy without masking serves them again. It returns
+// the number of bodies and values masked.
+func (c *MockConfiguration) ApplyMasking(p *MaskingProfile) (bodies, values int) {
+	c.RestoreMasked()
+	if p == nil {
+		c.Metadata.Masking = ""
+		return 0, 0
+	}
+	for i := range c.Expectations {
+		resp := c.Expectations[i].HttpResponse
+		if resp == nil || resp.Body == nil {
+			continue
+		}
+		masked, n := p.MaskBody(resp.Body)
+		if n == 0 {
+			continue
+		}
+		c.Masked = append(c.Masked, MaskedBody{Position: i, Original: resp.Body, Masked: masked})
+		resp.Body = masked
+		bodies++
+		values += n
+	}
+	c.Metadata.Masking = p.Name
+	return bodies, values
+}
+
+// RestoreMasked puts back the original bodies of a masked deploy. A body edited since then
+// keeps the edit. It returns the number of bodies restored.
+func (c *MockConfiguration) RestoreMasked() int {
+	restored := 0
+	for _, m := range c.Masked {
+		if m.Position >= len(c.Expectations) {
+			continue
+		}
+		resp := c.Expectations[m.Position].HttpResponse
+		if resp == nil || !sameJSON(resp.Body, m.Masked) {
+			continue
+		}
+		resp.Body = m.Original
+		restored++
+	}
+	c.Masked = nil
+	return restored
+}
+
+// maskBodyText masks JSON text field by field, and anything else by value shape
+func (p *MaskingProfile) maskBodyText(text string) (string, int) {
+	var doc any
+	if strings.Contains(text, "$!") || json.Unmarshal([]byte(text), &doc) != nil {
+		return p.maskText(text)
+	}
+	masked, n := p.maskValue("", doc, nil)
+	if n == 0 {
+		return text, 0
+	}
+	out, err := json.Marshal(masked)
+	if err != nil {
+		return text, 0
+	}
+	return string(out), n
+}
+
+// maskValue masks a decoded JSON value; key is the field holding it and siblings the object
+// it belongs to (used to tell a person's "name" from a product's)
+func (p *MaskingProfile) maskValue(key string, v any, siblings map[string]any) (any, int) {
+	switch val := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		total := 0
+		for _, k := range sortedKeys(val) {
+			masked, n := p.maskValue(k, val[k], val)
+			out[k] = masked
+			total += n
+		}
+		return out, total
+	case []any:
+		out := make([]any, len(val))
+		total := 0
+		for i, item := range val {
+			masked, n := p.maskValue(key, item, siblings)
+			out[i] = masked
+			total += n
+		}
+		return out, total
+	case string:
+		for _, r := range p.Rules {
+			if kind, ok := matchMaskRule(r, key, val, siblings); ok {
+				if masked := applyMaskAction(r.Action, kind, key, val); masked != val {
+					return masked, 1
+				}
+				return val, 0
+			}
+		}
+	}
+	return v, 0
+}
+
+// maskText replaces emails, JWTs and card numbers found anywhere in non-JSON text
+func (p *MaskingProfile) maskText(text string) (string, int) {
+	total := 0
+	for _, r := range p.Rules {
+		var pattern *regexp.Regexp
+		switch r.Match {
+		case MaskEmail:
+			pattern = emailInText
+		case MaskToken:
+			pattern = jwtInText
+		case MaskCard:
+			pattern = cardInText
+		default:
+			continue
+		}
+		action, kind := r.Action, r.Match
+		text = pattern.ReplaceAllStringFunc(text, func(s string) string {
+			if kind == MaskCard && !luhnValid(s) {
+				return s
+			}
+			masked := applyMaskAction(action, kind, "", s)
+			if masked != s {
+				total++
+			}
+			return masked
+		})
+	}
+	return text, total
+}
+
+// matchMaskRule reports whether r matches the string value under key, and as which kind
+func matchMaskRule(r MaskRule, key, value string, siblings map[string]any) (string, bool) {
+	if strings.TrimSpace(value) == "" {
+		return "", false
+	}
+	switch r.Match {
+	case MaskEmail:
+		return r.Match, emailValue.MatchString(value)
+	case MaskToken:
+		return r.Match, (key != "" && tokenField.MatchString(key) && !tokenMetaField.MatchString(key)) || jwtValue.MatchString(value) || bearerValue.MatchString(value)
+	case MaskName:
+		return r.Match, isPersonNameField(key, siblings)
+	case MaskPhone:
+		return r.Match, (key != "" && phoneField.MatchString(key)) || phoneValue.MatchString(value)
+	case MaskCard:
+		return r.Match, cardInText.FindString(value) == strings.TrimSpace(value) && luhnValid(value)
+	}
+	if glob, ok := strings.CutPrefix(r.Match, maskFieldPrefix); ok && key != "" {
+		matched, _ := path.Match(strings.ToLower(glob), strings.ToLower(key))
+		return "field", matched
+	}
+	return "", false
+}
+
+// isPersonNameField recognises first/last/full-name style keys; a bare "name" counts only next
+// to an email or phone field, so product and category names are left alone
+func isPersonNameField(key string, siblings map[string]any) bool {
+	k := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+	if personFields[k] {
+		return true
+	}
+	if k != "name" {
+		return false
+	}
+	for sk := range siblings {
+		if strings.Contains(strings.ToLower(sk), "email") || phoneField.MatchString(sk) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyMaskAction transforms one matched value
+func applyMaskAction(action, kind, key, value string) string {
+	switch action {
+	case MaskActionRedact:
+		return MaskRedacted
+	case MaskActionTruncate:
+		runes := []rune(value)
+		keep := min(6, len(runes)/2)
+		return string(runes[:keep]) + "…"
+	case MaskActionFake:
+		return fakeValue(kind, key, value)
+	}
+	// mask
+	if kind == MaskEmail {
+		if at, dot := strings.LastIndex(value, "@"), strings.LastIndex(value, "."); at > 0 && dot > at {
+			return value[:1] + "***@***" + value[dot:]
+		}
+	}
+	runes := []rune(value)
+	visible := 4
+	if len(runes) <= 8 {
+		visible = 0
+	}
+	for i, r := range runes {
+		if i < len(runes)-visible && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			runes[i] = '*'
+		}
+	}
+	return string(runes)
+}
+
+// fakeValue returns a stable stand-in for value, so the same input always fakes the same way
+func fakeValue(kind, key, value string) string {
+	h := fnv.New32a()
+	h.Write([]byte(value))
+	sum := h.Sum32()
+	first := firstNames[sum%uint32(len(firstNames))]
+	last := lastNames[(sum/uint32(len(firstNames)))%uint32(len(lastNames))]
+	switch kind {
+	case MaskEmail:
+		return strings.ToLower(first+"."+last) + "@example.com"
+	case MaskName:
+		k := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+		switch {
+		case k == "username":
+			return strings.ToLower(first + last)
+		case strings.Contains(k, "first") || strings.Contains(k, "given"):
+			return first
+		case strings.Contains(k, "last") || strings.Contains(k, "family") || k == "surname":
+			return last
+		}
+		return first + " " + last
+	case MaskPhone:
+		return fmt.Sprintf("+1 555-01%02d", sum%100)
+	case MaskCard:
+		return "4242 4242 4242 4242"
+	case MaskToken:
+		return fmt.Sprintf("demo_%08x%08x", sum, sum^0x5f3759df)
+	}
+	return fmt.Sprintf("demo-%08x", sum)
+}
+
+// luhnValid reports whether the digits in s pass the Luhn check used by card numbers
+func luhnValid(s string) bool {
+	var digits []int
+	for _, r := range s {
+		if unicode.IsDigit(r) {
+			digits = append(digits, int(r-'0'))
+		}
+	}
+	if len(digits) < 13 || len(digits) > 19 {
+		return false
+	}
+	sum := 0
+	for i := range digits {
+		d := digits[len(digits)-1-i]
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// sameJSON compares two decoded bodies by their JSON encoding
+func sameJSON(a, b any) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(ja) == string(jb)
+}
+
+// MaskingProfileNames lists project profiles followed by the presets they don't override
+func (c *MockConfiguration) MaskingProfileNames() []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, p := range c.MaskingProfiles {
+		names = append(names, p.Name)
+		seen[strings.ToLower(p.Name)] = true
+	}
+	var presets []string
+	for _, p := range MaskingPresets {
+		if !seen[strings.ToLower(p.Name)] {
+			presets = append(presets, p.Name)
+		}
+	}
+	sort.Strings(presets)
+	return append(names, presets...)
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMaskBodyDemoProfile(t *testing.T) {
+	demo := &MaskingPresets[0]
+	body := `{"user":{"firstName":"Priya","email":"priya.k@internal.corp","phone":"+44 7700 900123","name":"Priya K"},` +
+		`"product":{"name":"Widget"},"access_token":"eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.sig","token_type":"Bearer",` +
+		`"card":"4111 1111 1111 1111"}`
+
+	masked, n := demo.MaskBody(body)
+	if n != 6 {
+		t.Errorf("masked %d values, want 6: %s", n, masked)
+	}
+	out := masked.(string)
+	for _, leak := range []string{"Priya", "internal.corp", "900123", "eyJzdWIi", "4111 1111 1111 1111"} {
+		if strings.Contains(out, leak) {
+			t.Errorf("masked body still contains %q: %s", leak, out)
+		}
+	}
+	for _, kept := range []string{`"Widget"`, `"Bearer"`, "@example.com", "1111\"", `"eyJhbG…"`} {
+		if !strings.Contains(out, kept) {
+			t.Errorf("masked body lacks %q: %s", kept, out)
+		}
+	}
+
+	// Fakes are stable, so related responses stay consistent
+	again, _ := demo.MaskBody(body)
+	if again != masked {
+		t.Error("masking the same body twice gave different results")
+	}
+}
+
+func TestMaskBodyWrappersAndText(t *testing.T) {
+	p := &MaskingProfile{Name: "p", Rules: []MaskRule{
+		{Match: MaskEmail, Action: MaskActionMask},
+		{Match: "field:*Id", Action: MaskActionRedact},
+	}}
+	wrapped := map[string]any{"type": "JSON", "json": map[string]any{"ownerId": "u_42", "contact": "ann@corp.io"}}
+	masked, n := p.MaskBody(wrapped)
+	if n != 2 {
+		t.Fatalf("masked %d values, want 2", n)
+	}
+	doc := masked.(map[string]any)["json"].(map[string]any)
+	if doc["ownerId"] != MaskRedacted || doc["contact"] != "a***@***.io" {
+		t.Errorf("masked = %#v", doc)
+	}
+	if wrapped["json"].(map[string]any)["contact"] != "ann@corp.io" {
+		t.Error("original body was modified")
+	}
+
+	text, n := p.MaskBody("Contact ann@corp.io or bob@corp.io for access")
+	if n != 2 || strings.Contains(text.(string), "corp") {
+		t.Errorf("text body = %q (%d)", text, n)
+	}
+}
+
+func TestApplyAndRestoreMasking(t *testing.T) {
+	cfg := &MockConfiguration{Expectations: []MockExpectation{
+		{HttpResponse: &HttpResponse{StatusCode: 200, Body: `{"email":"a@corp.io"}`}},
+		{HttpResponse: &HttpResponse{StatusCode: 200, Body: `{"email":"b@corp.io"}`}},
+		{HttpResponse: &HttpResponse{StatusCode: 204}},
+	}}
+	profile, err := cfg.FindMaskingProfile("DEMO")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bodies, _ := cfg.ApplyMasking(profile); bodies != 2 || len(cfg.Masked) != 2 || cfg.Metadata.Masking != "demo" {
+		t.Fatalf("masked %d bodies, recorded %d, profile %q", bodies, len(cfg.Masked), cfg.Metadata.Masking)
+	}
+
+	// An edit made while masked wins over the stored original
+	cfg.Expectations[1].HttpResponse.Body = `{"email":"edited@example.com"}`
+	if restored := cfg.RestoreMasked(); restored != 1 {
+		t.Errorf("restored %d bodies, want 1", restored)
+	}
+	if cfg.Expectations[0].HttpResponse.Body != `{"email":"a@corp.io"}` ||
+		cfg.Expectations[1].HttpResponse.Body != `{"email":"edited@example.com"}` || cfg.Masked != nil {
+		t.Errorf("after restore: %v / %v", cfg.Expectations[0].HttpResponse.Body, cfg.Expectations[1].HttpResponse.Body)
+	}
+
+	if _, err := cfg.FindMaskingProfile("nope"); err == nil || !strings.Contains(err.Error(), "demo") {
+		t.Errorf("unknown profile error = %v", err)
+	}
+}
+
+func TestMaskingProfileValidate(t *testing.T) {
+	bad := []MaskingProfile{
+		{Name: "", Rules: []MaskRule{{Match: MaskEmail, Action: MaskActionMask}}},
+		{Name: "x"},
+		{Name: "x", Rules: []MaskRule{{Match: "ssn", Action: MaskActionMask}}},
+		{Name: "x", Rules: []MaskRule{{Match: MaskEmail, Action: "hash"}}},
+		{Name: "x", Rules: []MaskRule{{Match: "field:[", Action: MaskActionMask}}},
+	}
+	for i, p := range bad {
+		if err := p.Validate(); err == nil {
+			t.Errorf("case %d: expected an error", i)
+		}
+	}
+	for _, p := range MaskingPresets {
+		if err := p.Validate(); err != nil {
+			t.Errorf("preset %s: %v", p.Name, err)
+		}
+	}
+}
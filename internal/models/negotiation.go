@@ -0,0 +1,337 @@
+package models
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Negotiation makes one expectation answer in several representations chosen by the
+// request's Accept header. The expectation's JSON body is canonical; the other formats
+// are converted from it whenever the configuration is saved.
+type Negotiation struct {
+	Formats []string `json:"formats"`           // Extra representations besides JSON: xml, csv
+	XMLRoot string   `json:"xmlRoot,omitempty"` // Root element for XML (default: entity from the path)
+}
+
+// Negotiable representation formats
+const (
+	FormatXML = "xml"
+	FormatCSV = "csv"
+)
+
+// NegotiationFormats lists the supported formats in the order their variants are matched
+var NegotiationFormats = []string{FormatXML, FormatCSV}
+
+var negotiationMedia = map[string]struct {
+	contentType string
+	accept      string // MockServer header regex for the Accept value
+}{
+	FormatXML: {"application/xml", ".*(application|text)/xml.*"},
+	FormatCSV: {"text/csv", ".*text/csv.*"},
+}
+
+// ApplyNegotiation regenerates the Accept-matched variants of every expectation with a
+// negotiation spec. Variants are placed right before their canonical expectation so they
+// win ties (declaration order); the canonical one keeps answering every other Accept.
+// It returns the number of variants generated.
+func (c *MockConfiguration) ApplyNegotiation() (int, error) {
+	out := make([]MockExpectation, 0, len(c.Expectations))
+	generated := 0
+	for i, exp := range c.Expectations {
+		if exp.NegotiatedFormat != "" {
+			continue // regenerated below from the canonical expectation
+		}
+		if exp.Negotiation == nil || len(exp.Negotiation.Formats) == 0 || exp.HttpResponse == nil {
+			out = append(out, exp)
+			continue
+		}
+		variants, err := NegotiatedVariants(exp)
+		if err != nil {
+			return generated, ValidationError{
+				Field:   fmt.Sprintf("expectations[%d].negotiation", i),
+				Message: err.Error(),
+			}
+		}
+		out = append(out, variants...)
+		generated += len(variants)
+
+		canonical := exp
+		resp := *exp.HttpResponse
+		resp.Headers = withHeader(append([]NameValues(nil), resp.Headers...), "Vary", "Accept")
+		canonical.HttpResponse = &resp
+		out = append(out, canonical)
+	}
+	c.Expectations = out
+	return generated, nil
+}
+
+// NegotiatedVariants converts an expectation's canonical JSON body into one expectation
+// per negotiated format, each matching its media type in the Accept header
+func NegotiatedVariants(exp MockExpectation) ([]MockExpectation, error) {
+	if exp.HttpResponseTemplate != nil {
+		return nil, fmt.Errorf("templated responses cannot be negotiated")
+	}
+	canonical, ok := canonicalJSON(exp.HttpResponse.Body)
+	if !ok {
+		return nil, fmt.Errorf("content negotiation needs a JSON response body")
+	}
+	root := exp.Negotiation.XMLRoot
+	if root == "" && exp.HttpRequest != nil {
+		root = EntityFromPath(exp.HttpRequest.Path)
+		if _, isArray := canonical.([]any); isArray && root != "" {
+			root += "List"
+		}
+	}
+
+	var variants []MockExpectation
+	for _, format := range exp.Negotiation.Formats {
+		media, known := negotiationMedia[strings.ToLower(format)]
+		if !known {
+			return nil, fmt.Errorf("unsupported negotiation format %q (supported: %s)", format, strings.Join(NegotiationFormats, ", "))
+		}
+		var body string
+		var err error
+		switch strings.ToLower(format) {
+		case FormatXML:
+			body, err = JSONToXML(canonical, root)
+		case FormatCSV:
+			body, err = JSONToCSV(canonical)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("convert to %s: %w", format, err)
+		}
+
+		v := exp
+		v.Negotiation = nil
+		v.GraphQLErrors = nil
+		v.NegotiatedFormat = strings.ToLower(format)
+		v.Checks = nil
+		v.Examples = nil
+		v.Notes = ""
+		v.Freshness = nil
+		if exp.ID != "" {
+			v.ID = exp.ID + "-" + v.NegotiatedFormat
+		}
+		req := *exp.HttpRequest
+		req.Headers = withHeader(append([]NameValues(nil), req.Headers...), "Accept", media.accept)
+		v.HttpRequest = &req
+		resp := *exp.HttpResponse
+		resp.SchemaRef = ""
+		resp.Body = body
+		resp.Headers = withHeader(append([]NameValues(nil), resp.Headers...), "Content-Type", media.contentType)
+		resp.Headers = withHeader(resp.Headers, "Vary", "Accept")
+		v.HttpResponse = &resp
+		variants = append(variants, v)
+	}
+	return variants, nil
+}
+
+// canonicalJSON returns the decoded JSON payload of a response body
+func canonicalJSON(body any) (any, bool) {
+	contentType, text, _ := bodyText(body)
+	if contentType != "application/json" {
+		return nil, false
+	}
+	var v any
+	if err := json.Unmarshal([]byte(text), &v); err != nil {
+		return nil, false
+	}
+	return v, true
+}
+
+// withHeader sets a header to a single value, replacing any existing one (case-insensitive)
+func withHeader(headers []NameValues, name, value string) []NameValues {
+	for i, h := range headers {
+		if strings.EqualFold(h.Name, name) {
+			out := append([]NameValues(nil), headers...)
+			out[i] = NameValues{Name: h.Name, Values: []string{value}}
+			return out
+		}
+	}
+	return append(headers, NameValues{Name: name, Values: []string{value}})
+}
+
+var xmlNameInvalid = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+// JSONToXML renders a decoded JSON value as an XML document. Object keys become elements,
+// arrays repeat an <item> element inside their parent.
+func JSONToXML(v any, root string) (string, error) {
+	if root == "" {
+		root = "response"
+	}
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	if err := writeXML(&b, xmlName(root), v, 0); err != nil {
+		return "", err
+	}
+	b.WriteString("\n")
+	return b.String(), nil
+}
+
+func writeXML(b *bytes.Buffer, name string, v any, depth int) error {
+	indent := strings.Repeat("  ", depth)
+	switch t := v.(type) {
+	case map[string]any:
+		fmt.Fprintf(b, "%s<%s>", indent, name)
+		if len(t) == 0 {
+			fmt.Fprintf(b, "</%s>", name)
+			break
+		}
+		b.WriteString("\n")
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if err := writeXML(b, xmlName(k), t[k], depth+1); err != nil {
+				return err
+			}
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(b, "%s</%s>", indent, name)
+	case []any:
+		fmt.Fprintf(b, "%s<%s>", indent, name)
+		if len(t) == 0 {
+			fmt.Fprintf(b, "</%s>", name)
+			break
+		}
+		b.WriteString("\n")
+		for _, item := range t {
+			if err := writeXML(b, "item", item, depth+1); err != nil {
+				return err
+			}
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(b, "%s</%s>", indent, name)
+	case nil:
+		fmt.Fprintf(b, "%s<%s/>", indent, name)
+	default:
+		fmt.Fprintf(b, "%s<%s>", indent, name)
+		if err := xml.EscapeText(b, []byte(scalarText(t))); err != nil {
+			return err
+		}
+		fmt.Fprintf(b, "</%s>", name)
+	}
+	return nil
+}
+
+// xmlName turns a JSON key into a valid element name
+func xmlName(key string) string {
+	name := xmlNameInvalid.ReplaceAllString(key, "_")
+	if name == "" || !(name[0] == '_' || (name[0]|0x20 >= 'a' && name[0]|0x20 <= 'z')) {
+		name = "_" + name
+	}
+	return name
+}
+
+// JSONToCSV renders a decoded JSON value as CSV: an array of objects (or an object holding
+// exactly one such array, e.g. {"data": [...]}) becomes one row per element, any other
+// object a single row. Nested objects flatten to dotted columns; arrays are JSON-encoded.
+func JSONToCSV(v any) (string, error) {
+	rows, err := csvRows(v)
+	if err != nil {
+		return "", err
+	}
+	columnSet := map[string]bool{}
+	flat := make([]map[string]string, len(rows))
+	for i, row := range rows {
+		flat[i] = map[string]string{}
+		flatten("", row, flat[i])
+		for k := range flat[i] {
+			columnSet[k] = true
+		}
+	}
+	columns := make([]string, 0, len(columnSet))
+	for k := range columnSet {
+		columns = append(columns, k)
+	}
+	sort.Strings(columns)
+
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	if err := w.Write(columns); err != nil {
+		return "", err
+	}
+	for _, row := range flat {
+		record := make([]string, len(columns))
+		for i, col := range columns {
+			record[i] = row[col]
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	return b.String(), w.Error()
+}
+
+func csvRows(v any) ([]any, error) {
+	switch t := v.(type) {
+	case []any:
+		return t, nil
+	case map[string]any:
+		var nested []any
+		for _, field := range t {
+			if list, ok := field.([]any); ok && len(list) > 0 {
+				if _, isObj := list[0].(map[string]any); isObj {
+					if nested != nil {
+						return []any{t}, nil // several lists: keep the object as one row
+					}
+					nested = list
+				}
+			}
+		}
+		if nested != nil && len(t) == 1 {
+			return nested, nil
+		}
+		return []any{t}, nil
+	}
+	return nil, fmt.Errorf("CSV needs a JSON object or array body")
+}
+
+func flatten(prefix string, v any, out map[string]string) {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, field := range t {
+			key := k
+			if prefix != "" {
+				key = prefix + "." + k
+			}
+			flatten(key, field, out)
+		}
+	case []any:
+		raw, _ := json.Marshal(t)
+		out[columnName(prefix)] = string(raw)
+	default:
+		out[columnName(prefix)] = scalarText(t)
+	}
+}
+
+func columnName(prefix string) string {
+	if prefix == "" {
+		return "value"
+	}
+	return prefix
+}
+
+func scalarText(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(t)
+	}
+	return fmt.Sprint(v)
+}
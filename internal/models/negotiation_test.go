@@ -0,0 +1,71 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplyNegotiation(t *testing.T) {
+	cfg := &MockConfiguration{
+		Expectations: []MockExpectation{
+			{
+				ID:          "orders",
+				HttpRequest: &HttpRequest{Method: "GET", Path: "/api/v1/orders"},
+				HttpResponse: &HttpResponse{StatusCode: 200, Body: map[string]any{"type": "JSON", "json": []any{
+					map[string]any{"id": float64(1), "total": 9.5, "customer": map[string]any{"name": "Ada"}},
+					map[string]any{"id": float64(2), "note": "a, \"b\"", "customer": map[string]any{"name": "Bo"}},
+				}}},
+				Negotiation: &Negotiation{Formats: []string{"xml", "csv"}},
+			},
+			{HttpRequest: &HttpRequest{Method: "GET", Path: "/health"}, HttpResponse: &HttpResponse{StatusCode: 200}},
+		},
+	}
+
+	n, err := cfg.ApplyNegotiation()
+	if err != nil || n != 2 {
+		t.Fatalf("generated %d, err %v", n, err)
+	}
+	// Saving again must regenerate rather than accumulate variants
+	if n, err := cfg.ApplyNegotiation(); err != nil || n != 2 || len(cfg.Expectations) != 4 {
+		t.Fatalf("second apply: generated %d, %d expectations, err %v", n, len(cfg.Expectations), err)
+	}
+
+	xmlVariant, csvVariant, canonical := cfg.Expectations[0], cfg.Expectations[1], cfg.Expectations[2]
+	if xmlVariant.NegotiatedFormat != "xml" || xmlVariant.ID != "orders-xml" || canonical.Negotiation == nil {
+		t.Fatalf("unexpected order: %q %q %v", xmlVariant.NegotiatedFormat, csvVariant.NegotiatedFormat, canonical.Negotiation)
+	}
+	if got := xmlVariant.HttpRequest.Headers[0]; got.Name != "Accept" || !strings.Contains(got.Values[0], "xml") {
+		t.Errorf("xml variant matcher = %+v", got)
+	}
+	if len(canonical.HttpRequest.Headers) != 0 {
+		t.Errorf("canonical request gained headers: %+v", canonical.HttpRequest.Headers)
+	}
+
+	xmlBody := xmlVariant.HttpResponse.Body.(string)
+	for _, want := range []string{"<OrderList>", "<item>", "<name>Ada</name>", "<total>9.5</total>"} {
+		if !strings.Contains(xmlBody, want) {
+			t.Errorf("xml body lacks %s:\n%s", want, xmlBody)
+		}
+	}
+	wantCSV := "customer.name,id,note,total\nAda,1,,9.5\nBo,2,\"a, \"\"b\"\"\",\n"
+	if got := csvVariant.HttpResponse.Body.(string); got != wantCSV {
+		t.Errorf("csv body:\n%s\nwant:\n%s", got, wantCSV)
+	}
+
+	canonical.Negotiation = nil
+	cfg.Expectations[2] = canonical
+	if n, _ := cfg.ApplyNegotiation(); n != 0 || len(cfg.Expectations) != 2 {
+		t.Errorf("turning negotiation off left %d expectations", len(cfg.Expectations))
+	}
+}
+
+func TestNegotiatedVariantsNeedJSON(t *testing.T) {
+	exp := MockExpectation{
+		HttpRequest:  &HttpRequest{Method: "GET", Path: "/feed"},
+		HttpResponse: &HttpResponse{StatusCode: 200, Body: map[string]any{"type": "XML", "xml": "<feed/>"}},
+		Negotiation:  &Negotiation{Formats: []string{"csv"}},
+	}
+	if _, err := NegotiatedVariants(exp); err == nil {
+		t.Error("expected an XML canonical body to be rejected")
+	}
+}
@@ -0,0 +1,58 @@
+package models
+
+// Prepare derives everything stored and served from the edited expectations: rendered bodies,
+// priorities, and the companions of redirects, tenants, rules, request templates, GraphQL
+// errors, negotiation, conditional GET, ranges, faults and streams. Every step regenerates
+// what an earlier run generated, so it runs on each save and on configurations loaded to
+// serve. It fails on expectations MockServer would reject or never serve.
+func (c *MockConfiguration) Prepare() error {
+	// Render schema-backed response bodies so stored expectations are MockServer-ready
+	if _, err := c.ApplySchemaRefs(); err != nil {
+		return err
+	}
+	// Fill in ${uuid}, ${name}, ... placeholders once; the generated values are stored
+	if _, err := c.ApplyFakeData(); err != nil {
+		return err
+	}
+	// An expectation a broader one never lets answer gets a higher priority; variants inherit it
+	c.ApplyPriorities()
+	// Redirect chains answer with their first hop; the hops after it become expectations
+	if _, err := c.ApplyRedirects(); err != nil {
+		return err
+	}
+	// Tenant overlays become expectations matching the tenant, ahead of the shared response
+	if _, err := c.ApplyTenants(); err != nil {
+		return err
+	}
+	// Response rules become expectations matching their conditions, ahead of the original
+	if _, err := c.ApplyRules(); err != nil {
+		return err
+	}
+	// Responses echoing ${request...} values become response templates; bad references fail here
+	if _, err := c.ApplyRequestTemplates(); err != nil {
+		return err
+	}
+	// GraphQL error variants are derived from the rendered data
+	if _, err := c.ApplyGraphQLErrors(); err != nil {
+		return err
+	}
+	// Regenerate Accept-matched variants from the (possibly re-rendered) canonical bodies
+	if _, err := c.ApplyNegotiation(); err != nil {
+		return err
+	}
+	// Then the ETags and 304 companions, one per representation
+	c.ApplyConditionalGet()
+	if _, err := c.ApplyRanges(); err != nil {
+		return err
+	}
+	// Fault companions go last, so 304s, ranges and negotiated variants answer reliably
+	if _, err := c.ApplyFaults(); err != nil {
+		return err
+	}
+	// Streaming expectations get the static response deployed MockServer serves for them
+	if _, err := c.ApplyStreams(); err != nil {
+		return err
+	}
+	// Refuse expectations MockServer would reject or never serve
+	return Validate(c.Expectations).Err()
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPrepareRegenerates(t *testing.T) {
+	config := &MockConfiguration{
+		Expectations: []MockExpectation{
+			{
+				ID:             "plan",
+				HttpRequest:    &HttpRequest{Method: "GET", Path: "/plan"},
+				HttpResponse:   &HttpResponse{StatusCode: 200, Body: map[string]any{"plan": "free"}},
+				ConditionalGet: true,
+			},
+			{
+				HttpRequest:  &HttpRequest{Method: "GET", Path: "/login"},
+				HttpResponse: &HttpResponse{StatusCode: 200},
+				Redirects:    &Redirects{Hops: []RedirectHop{{Location: "/consent"}, {Location: "https://app.example/cb"}}},
+			},
+		},
+		Tenancy: &Tenancy{Header: "X-Tenant", Tenants: []Tenant{
+			{Name: "acme", Overlays: []TenantOverlay{{Expectation: "plan", Body: map[string]any{"plan": "enterprise"}}}},
+		}},
+	}
+	if err := config.Prepare(); err != nil {
+		t.Fatal(err)
+	}
+	var tenants, hops, notModified int
+	for _, exp := range config.Expectations {
+		switch {
+		case exp.Tenant != "":
+			tenants++
+		case exp.RedirectHop != 0:
+			hops++
+		case exp.NotModified:
+			notModified++
+		}
+	}
+	if tenants != 1 || hops != 1 || notModified != 1 {
+		t.Fatalf("companions: %d tenant, %d hop, %d 304; want one each", tenants, hops, notModified)
+	}
+
+	// A second save regenerates the same expectations
+	first, _ := json.Marshal(config.Expectations)
+	if err := config.Prepare(); err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := json.Marshal(config.Expectations); string(again) != string(first) {
+		t.Errorf("Prepare is not idempotent:\n%s\n%s", first, again)
+	}
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"time"
+)
+
+// Provenance sources
+const (
+	ProvenanceCollection = "collection" // executed from a Postman/Bruno/Insomnia collection
+	ProvenanceAI         = "ai"         // LLM generation run
+	ProvenanceBuilder    = "builder"    // interactive REST/GraphQL builder
+	ProvenanceRecording  = "recording"  // recorded from live traffic
+	ProvenanceImport     = "import"     // imported from a file (MockServer JSON, OpenAPI, ...)
+)
+
+// Provenance records where an expectation came from; it survives edits
+type Provenance struct {
+	Source    string    `json:"source"`
+	Detail    string    `json:"detail,omitempty"` // e.g. "orders.postman.json › Create order", "anthropic generation"
+	RunID     string    `json:"runId,omitempty"`  // groups expectations created in the same session
+	CreatedBy string    `json:"createdBy,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	EditedBy  string    `json:"editedBy,omitempty"`
+	EditedAt  time.Time `json:"editedAt,omitempty"`
+}
+
+// NewProvenance creates a provenance record stamped with the current user and time
+func NewProvenance(source, detail, runID string) *Provenance {
+	return &Provenance{
+		Source:    source,
+		Detail:    detail,
+		RunID:     runID,
+		CreatedBy: CurrentUser(),
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// NewRunID returns an identifier for one generation/import session
+func NewRunID(source string) string {
+	return fmt.Sprintf("%s-%d", source, time.Now().Unix())
+}
+
+// StampProvenance sets provenance on expectations that do not have one yet
+func StampProvenance(exps []MockExpectation, source, detail, runID string) {
+	for i := range exps {
+		if exps[i].Provenance == nil {
+			exps[i].Provenance = NewProvenance(source, detail, runID)
+		}
+	}
+}
+
+// MarkEdited records who last edited the expectation, keeping the original origin
+func (e *MockExpectation) MarkEdited() {
+	if e.Provenance == nil {
+		e.Provenance = &Provenance{Source: "unknown"}
+	}
+	e.Provenance.EditedBy = CurrentUser()
+	e.Provenance.EditedAt = time.Now().UTC()
+}
+
+// String renders provenance for list/view output
+func (p *Provenance) String() string {
+	if p == nil {
+		return "unknown origin"
+	}
+	s := p.Source
+	if p.Detail != "" {
+		s += ": " + p.Detail
+	}
+	if p.CreatedBy != "" {
+		s += " by " + p.CreatedBy
+	}
+	if !p.CreatedAt.IsZero() {
+		s += " on " + p.CreatedAt.Local().Format("2006-01-02 15:04")
+	}
+	if !p.EditedAt.IsZero() {
+		s += fmt.Sprintf(" (edited by %s on %s)", p.EditedBy, p.EditedAt.Local().Format("2006-01-02 15:04"))
+	}
+	return s
+}
+
+// CurrentUser returns the OS user name used to attribute changes
+func CurrentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return os.Getenv("USERNAME")
+}
@@ -0,0 +1,204 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Range requests: an expectation with Ranges set gets "Accept-Ranges: bytes" and a companion
+// that answers requests carrying a Range header. The companion is a JavaScript response
+// template (run by MockServer, and by automock serve) holding the body as base64; it slices
+// the requested bytes and answers 206 with Content-Range, or 416 when the range lies past
+// the end. Multi-range and malformed Range headers get the full body, as RFC 9110 allows.
+
+// RangesEligible reports whether an expectation can serve byte ranges: a GET with a static
+// 200 body
+func RangesEligible(exp MockExpectation) bool {
+	if exp.HttpRequest == nil || exp.HttpResponse == nil || exp.HttpResponseTemplate != nil || exp.Forward != nil {
+		return false
+	}
+	status := exp.HttpResponse.StatusCode
+	if status == 0 {
+		status = 200
+	}
+	_, ok := ResponseBytes(exp.HttpResponse.Body)
+	return strings.EqualFold(exp.HttpRequest.Method, "GET") && status == 200 && ok
+}
+
+// ApplyRanges regenerates the Range companions of every expectation with Ranges set. Each
+// companion is placed right before its expectation (after any 304 companion, which is
+// evaluated first as RFC 9110 requires), so run it after ApplyConditionalGet.
+// It returns the number of companions generated.
+func (c *MockConfiguration) ApplyRanges() (int, error) {
+	out := make([]MockExpectation, 0, len(c.Expectations))
+	generated := 0
+	for i, exp := range c.Expectations {
+		if exp.PartialContent {
+			continue // regenerated below from the expectation it slices
+		}
+		if !exp.Ranges {
+			out = append(out, exp)
+			continue
+		}
+		if !RangesEligible(exp) {
+			return generated, ValidationError{
+				Field:   fmt.Sprintf("expectations[%d].ranges", i),
+				Message: "range requests need a GET with a static 200 response body",
+			}
+		}
+		resp := *exp.HttpResponse
+		resp.Headers = withHeader(append([]NameValues(nil), resp.Headers...), "Accept-Ranges", "bytes")
+		exp.HttpResponse = &resp
+
+		out = append(out, RangeCompanion(exp), exp)
+		generated++
+	}
+	c.Expectations = out
+	return generated, nil
+}
+
+// RangeCompanion builds the expectation answering Range requests for exp's body
+func RangeCompanion(exp MockExpectation) MockExpectation {
+	data, _ := ResponseBytes(exp.HttpResponse.Body)
+
+	headers := staticHeaders(exp.HttpResponse)
+	headers["Accept-Ranges"] = "bytes"
+	headerJSON, _ := json.Marshal(headers)
+	encoded, _ := json.Marshal(base64.StdEncoding.EncodeToString(data))
+
+	v := exp
+	v.Ranges = false
+	v.PartialContent = true
+	v.ConditionalGet = false
+	v.Negotiation = nil
+	v.GraphQLErrors = nil
+	v.Checks = nil
+	v.Examples = nil
+	v.Notes = ""
+	v.Freshness = nil
+	if exp.ID != "" {
+		v.ID = exp.ID + "-range"
+	}
+	req := *exp.HttpRequest
+	req.Headers = withHeader(append([]NameValues(nil), req.Headers...), "Range", "bytes=.*")
+	v.HttpRequest = &req
+	v.HttpResponse = &HttpResponse{StatusCode: 206, Delay: exp.HttpResponse.Delay}
+	v.HttpResponseTemplate = &HttpTemplate{
+		TemplateType: TemplateJavaScript,
+		Template:     fmt.Sprintf(rangeTemplate, encoded, len(data), headerJSON),
+	}
+	return v
+}
+
+// rangeTemplate is ES5 so it runs on MockServer's JavaScript engine as well as locally
+const rangeTemplate = `var data = %s, total = %d, headers = %s;
+var abc = 'ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/';
+function decode(s) {
+  var out = [], buf = 0, bits = 0;
+  for (var i = 0; i < s.length; i++) {
+    var c = abc.indexOf(s.charAt(i));
+    if (c < 0) continue;
+    buf = (buf << 6) | c; bits += 6;
+    if (bits >= 8) { bits -= 8; out.push((buf >> bits) & 255); buf &= (1 << bits) - 1; }
+  }
+  return out;
+}
+function encode(b) {
+  var s = '';
+  for (var i = 0; i < b.length; i += 3) {
+    var n = (b[i] << 16) | ((i + 1 < b.length ? b[i + 1] : 0) << 8) | (i + 2 < b.length ? b[i + 2] : 0);
+    s += abc.charAt(n >> 18 & 63) + abc.charAt(n >> 12 & 63) +
+      (i + 1 < b.length ? abc.charAt(n >> 6 & 63) : '=') + (i + 2 < b.length ? abc.charAt(n & 63) : '=');
+  }
+  return s;
+}
+var range = '';
+for (var name in request.headers) {
+  if (name.toLowerCase() === 'range') {
+    var v = request.headers[name];
+    range = typeof v === 'string' ? v : String(v[0]);
+  }
+}
+var m = /^bytes=(\d*)-(\d*)$/.exec(range.replace(/\s/g, ''));
+if (!m || (m[1] === '' && m[2] === '')) {
+  return {statusCode: 200, headers: headers, body: {type: 'BINARY', base64Bytes: data}};
+}
+var start, end;
+if (m[1] === '') {
+  start = Math.max(total - parseInt(m[2], 10), 0); end = total - 1;
+} else {
+  start = parseInt(m[1], 10); end = m[2] === '' ? total - 1 : Math.min(parseInt(m[2], 10), total - 1);
+}
+if (start >= total || start > end) {
+  headers['Content-Range'] = 'bytes */' + total;
+  return {statusCode: 416, headers: headers};
+}
+headers['Content-Range'] = 'bytes ' + start + '-' + end + '/' + total;
+headers['Content-Length'] = String(end - start + 1);
+var from = Math.floor(start / 3), to = Math.ceil((end + 1) / 3);
+var bytes = decode(data.substring(from * 4, to * 4)).slice(start - from * 3, end + 1 - from * 3);
+return {statusCode: 206, headers: headers, body: {type: 'BINARY', base64Bytes: encode(bytes)}};`
+
+// ResponseBytes returns the bytes a static MockServer response body puts on the wire
+func ResponseBytes(body any) ([]byte, bool) {
+	switch b := body.(type) {
+	case nil:
+		return nil, false
+	case string:
+		return []byte(b), true
+	case map[string]any:
+		switch strings.ToUpper(fmt.Sprint(b["type"])) {
+		case "JSON":
+			if s, ok := b["json"].(string); ok {
+				return []byte(s), true
+			}
+			out, err := json.Marshal(b["json"])
+			return out, err == nil
+		case "STRING":
+			s, ok := b["string"].(string)
+			return []byte(s), ok
+		case "XML":
+			s, ok := b["xml"].(string)
+			return []byte(s), ok
+		case "BINARY":
+			s, _ := b["base64Bytes"].(string)
+			out, err := base64.StdEncoding.DecodeString(s)
+			return out, err == nil
+		}
+		if _, typed := b["type"]; typed {
+			return nil, false
+		}
+	}
+	out, err := json.Marshal(body)
+	return out, err == nil
+}
+
+// staticHeaders are the headers a template re-serving a static response sends: the first value
+// of each (Content-Length aside, as the template sets the body) and a Content-Type
+func staticHeaders(resp *HttpResponse) map[string]string {
+	headers := map[string]string{}
+	for _, h := range resp.Headers {
+		if len(h.Values) > 0 && !strings.EqualFold(h.Name, "Content-Length") {
+			headers[h.Name] = h.Values[0]
+		}
+	}
+	if !hasHeader(resp.Headers, "Content-Type") {
+		if contentType, _, _ := bodyText(resp.Body); contentType != "" {
+			headers["Content-Type"] = contentType
+		} else {
+			headers["Content-Type"] = "application/octet-stream"
+		}
+	}
+	return headers
+}
+
+func hasHeader(headers []NameValues, name string) bool {
+	for _, h := range headers {
+		if strings.EqualFold(h.Name, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,327 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Redirect chains: an expectation with Redirects answers with a 3xx whose Location leads the
+// client through a list of hops, e.g. GET /oauth/authorize → /login → /consent → the client's
+// redirect_uri. ApplyRedirects compiles every hop the chain continues from into a GET
+// expectation on its path that redirects to the next hop; the last Location is where the chain
+// ends (another expectation, or an external URL). Query parameters named in Carry are appended
+// to the Locations served by the mock, so the last one can echo them, e.g.
+// ${request.query.redirect_uri}?code=abc&state=${request.query.state}. Locations echoing the
+// request are filled in by a JavaScript response template (run by MockServer, and by automock
+// serve); the static response keeps the Location as written. With RequireCookies the hops only
+// answer requests that send back the cookies set before them, as a browser would.
+
+// RedirectStatuses are the statuses a redirect may answer with
+var RedirectStatuses = []int{301, 302, 303, 307, 308}
+
+// redirectTemplateHeader starts the templates ApplyRedirects generates, so a save can tell them
+// from user scripts
+const redirectTemplateHeader = "// automock: redirect\n"
+
+var (
+	// redirectReference is what a Location may echo: ${request.query.<name>},
+	// ${request.headers.<name>}, ${request.path} or ${request.method}, with an optional :default
+	redirectReference = regexp.MustCompile(`^\$\{request\.(?:(?:query|headers)\.[^}:]+|path|method)(?::[^}]*)?\}$`)
+	// anyRedirectReference finds everything written like a reference, so typos are reported
+	anyRedirectReference = regexp.MustCompile(`\$\{request\b[^}]*\}?`)
+)
+
+// Redirects answers an expectation with a redirect and serves the hops it leads through
+type Redirects struct {
+	Status         int           `json:"status,omitempty"`         // Status of every hop without its own (default 302)
+	Hops           []RedirectHop `json:"hops"`                     // Where the client is sent, in order
+	Carry          []string      `json:"carry,omitempty"`          // Query parameters of the first request passed along to every hop, e.g. state
+	RequireCookies bool          `json:"requireCookies,omitempty"` // Hops only answer requests sending the cookies set before them
+}
+
+// RedirectHop is one Location of a chain and the response sending the client there
+type RedirectHop struct {
+	Location string       `json:"location"`          // Path on the mock, or the final URL; may use ${request...} references
+	Status   int          `json:"status,omitempty"`  // Status of the response sending the client here (default: the chain's)
+	Cookies  []NameValues `json:"cookies,omitempty"` // Set (for every path) by the response sending the client here
+}
+
+// status returns the status of the response sending the client to hop i
+func (r Redirects) status(i int) int {
+	switch {
+	case r.Hops[i].Status != 0:
+		return r.Hops[i].Status
+	case r.Status != 0:
+		return r.Status
+	}
+	return 302
+}
+
+// Describe summarizes the chain, e.g. "302 → /login → /consent → https://app.example/cb"
+func (r Redirects) Describe() string {
+	if len(r.Hops) == 0 {
+		return "no hops"
+	}
+	parts := []string{fmt.Sprint(r.status(0))}
+	for _, h := range r.Hops {
+		parts = append(parts, h.Location)
+	}
+	return strings.Join(parts, " → ")
+}
+
+// Check reports what is wrong with the chain, or ""
+func (r Redirects) Check() string {
+	if len(r.Hops) == 0 {
+		return "a redirect chain needs at least one hop"
+	}
+	if r.Status != 0 && !containsInt(RedirectStatuses, r.Status) {
+		return fmt.Sprintf("redirect status must be one of %v, got %d", RedirectStatuses, r.Status)
+	}
+	seen := map[string]int{}
+	for i, h := range r.Hops {
+		switch {
+		case strings.TrimSpace(h.Location) == "":
+			return fmt.Sprintf("hop %d needs a location", i+1)
+		case h.Status != 0 && !containsInt(RedirectStatuses, h.Status):
+			return fmt.Sprintf("hop %d: redirect status must be one of %v, got %d", i+1, RedirectStatuses, h.Status)
+		}
+		for _, c := range h.Cookies {
+			if strings.TrimSpace(c.Name) == "" || len(c.Values) != 1 {
+				return fmt.Sprintf("hop %d: cookies need a name and one value", i+1)
+			}
+		}
+		for _, ref := range anyRedirectReference.FindAllString(h.Location, -1) {
+			if !redirectReference.MatchString(ref) {
+				return fmt.Sprintf("hop %d: %s is not a reference a location can echo: use ${request.query.<name>}, ${request.headers.<name>}, ${request.path} or ${request.method}", i+1, ref)
+			}
+		}
+		if i == len(r.Hops)-1 {
+			break
+		}
+		path := hopPath(h.Location)
+		if !strings.HasPrefix(path, "/") || strings.Contains(path, "${") {
+			return fmt.Sprintf("hop %d: %q must be a path on the mock, e.g. /login, since the chain continues from it", i+1, h.Location)
+		}
+		if j, dup := seen[path]; dup {
+			return fmt.Sprintf("hops %d and %d both serve %s", j+1, i+1, path)
+		}
+		seen[path] = i
+	}
+	for _, name := range r.Carry {
+		if strings.TrimSpace(name) == "" || strings.ContainsAny(name, "&=?#${}") {
+			return fmt.Sprintf("carried query parameter %q must be a plain name, e.g. state", name)
+		}
+	}
+	return ""
+}
+
+// location is the Location header sending the client to hop i; hops served by the mock
+// receive the carried query parameters
+func (r Redirects) location(i int) string {
+	loc := r.Hops[i].Location
+	if i == len(r.Hops)-1 || len(r.Carry) == 0 {
+		return loc
+	}
+	params := make([]string, len(r.Carry))
+	for j, name := range r.Carry {
+		params[j] = fmt.Sprintf("%s=${request.query.%s}", name, name)
+	}
+	sep := "?"
+	if strings.Contains(loc, "?") {
+		sep = "&"
+	}
+	return loc + sep + strings.Join(params, "&")
+}
+
+// respond makes resp send the client to hop i: the status, the Location and the hop's
+// cookies, set with Path=/ so the client sends them to the next hops wherever they are
+func (r Redirects) respond(resp *HttpResponse, i int) {
+	resp.StatusCode = r.status(i)
+	headers := []NameValues{{Name: "Location", Values: []string{r.location(i)}}}
+	var cookies []string
+	for _, c := range r.Hops[i].Cookies {
+		cookies = append(cookies, RedirectSetCookie(c))
+	}
+	for _, h := range resp.Headers {
+		switch {
+		case strings.EqualFold(h.Name, "Location"):
+		case strings.EqualFold(h.Name, "Set-Cookie"):
+			cookies = append(cookies, keepCookies(h.Values, r.Hops[i].Cookies)...)
+		default:
+			headers = append(headers, h)
+		}
+	}
+	if len(cookies) > 0 {
+		headers = append(headers, NameValues{Name: "Set-Cookie", Values: cookies})
+	}
+	resp.Headers = headers
+}
+
+// IsRedirectTemplate reports whether a response template was generated by ApplyRedirects
+func IsRedirectTemplate(t *HttpTemplate) bool {
+	return t != nil && strings.HasPrefix(t.Template, redirectTemplateHeader)
+}
+
+// redirectResponseTemplate fills in the request references of a redirect's headers at serve
+// time; it returns nil when the headers echo nothing
+func redirectResponseTemplate(resp *HttpResponse) *HttpTemplate {
+	raw, _ := json.Marshal(resp.Headers)
+	if !anyRedirectReference.Match(raw) {
+		return nil
+	}
+	headers := map[string][]string{}
+	for _, h := range resp.Headers {
+		headers[h.Name] = append(headers[h.Name], h.Values...)
+	}
+	spec, _ := json.Marshal(map[string]any{"statusCode": resp.StatusCode, "headers": headers})
+	return &HttpTemplate{
+		TemplateType: TemplateJavaScript,
+		Template:     redirectTemplateHeader + fmt.Sprintf(redirectTemplate, spec),
+	}
+}
+
+// redirectTemplate is ES5 so it runs on MockServer's JavaScript engine as well as locally
+const redirectTemplate = `var response = %s;
+function first(values, name, fold) {
+  for (var key in values || {}) {
+    if (key === name || (fold && key.toLowerCase() === name.toLowerCase())) {
+      var v = values[key];
+      return typeof v === 'string' ? v : v && v.length ? String(v[0]) : undefined;
+    }
+  }
+}
+function fill(s) {
+  return s.replace(/\$\{request\.([A-Za-z]+)(?:\.([^}:]*))?(?::([^}]*))?\}/g, function (ref, source, name, fallback) {
+    var v = source === 'path' ? request.path : source === 'method' ? request.method :
+      source === 'query' ? first(request.queryStringParameters, name, false) : first(request.headers, name, true);
+    return v === undefined ? (fallback === undefined ? '' : fallback) : v;
+  });
+}
+for (var name in response.headers) {
+  for (var i = 0; i < response.headers[name].length; i++) response.headers[name][i] = fill(response.headers[name][i]);
+}
+return response;`
+
+// RedirectSetCookie is the Set-Cookie value of a cookie set by a redirect hop
+func RedirectSetCookie(c NameValues) string {
+	return c.Name + "=" + c.Values[0] + "; Path=/"
+}
+
+// keepCookies returns the Set-Cookie values not setting one of cookies
+func keepCookies(values []string, cookies []NameValues) []string {
+	var kept []string
+	for _, v := range values {
+		name, _, _ := strings.Cut(v, "=")
+		set := false
+		for _, c := range cookies {
+			set = set || strings.TrimSpace(name) == c.Name
+		}
+		if !set {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
+
+// hopPath is the path of a Location, without its query and fragment
+func hopPath(location string) string {
+	if i := strings.IndexAny(location, "?#"); i >= 0 {
+		return location[:i]
+	}
+	return location
+}
+
+// cookieMatcher matches a Cookie header sending name=value among other cookies
+func cookieMatcher(c NameValues) string {
+	return `(.*;\s*)?` + regexp.QuoteMeta(c.Name) + "=" + regexp.QuoteMeta(c.Values[0]) + `(;.*)?`
+}
+
+func containsInt(list []int, n int) bool {
+	for _, v := range list {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyRedirects makes every expectation with Redirects answer with the redirect to its first
+// hop, and regenerates the expectations serving the hops after it. Hops are placed right before
+// their expectation, so they win over other expectations on the same paths. Run it before the
+// features deriving companions from static bodies (negotiation, conditional GET, ranges). It
+// returns the number of expectations generated.
+func (c *MockConfiguration) ApplyRedirects() (int, error) {
+	out := make([]MockExpectation, 0, len(c.Expectations))
+	generated := 0
+	for i, exp := range c.Expectations {
+		if exp.RedirectHop != 0 {
+			continue // regenerated below from the expectation it belongs to
+		}
+		if IsRedirectTemplate(exp.HttpResponseTemplate) {
+			exp.HttpResponseTemplate = nil // regenerated below, or dropped with the chain
+		}
+		if exp.Redirects == nil || exp.Generated() || exp.HttpRequest == nil {
+			out = append(out, exp)
+			continue
+		}
+		hops, err := RedirectExpectations(&exp)
+		if err != nil {
+			return generated, ValidationError{Field: fmt.Sprintf("expectations[%d].redirects", i), Message: err.Error()}
+		}
+		out = append(append(out, hops...), exp)
+		generated += len(hops)
+	}
+	c.Expectations = out
+	return generated, nil
+}
+
+// RedirectExpectations sets exp's response to the redirect to its first hop and returns the
+// expectations answering the hops the chain continues from
+func RedirectExpectations(exp *MockExpectation) ([]MockExpectation, error) {
+	r := *exp.Redirects
+	if msg := r.Check(); msg != "" {
+		return nil, fmt.Errorf("%s", msg)
+	}
+	if exp.Forward != nil || exp.HttpResponseTemplate != nil && !IsRedirectTemplate(exp.HttpResponseTemplate) {
+		return nil, fmt.Errorf("a redirect chain replaces the response; remove the forward or response script first")
+	}
+	if exp.HttpResponse == nil {
+		exp.HttpResponse = &HttpResponse{}
+	}
+	r.respond(exp.HttpResponse, 0)
+	exp.HttpResponseTemplate = redirectResponseTemplate(exp.HttpResponse)
+
+	var hops []MockExpectation
+	var cookies []NameValues
+	for i := 0; i < len(r.Hops)-1; i++ {
+		cookies = append(cookies, r.Hops[i].Cookies...)
+		req := &HttpRequest{Method: "GET", Path: hopPath(r.Hops[i].Location)}
+		if r.RequireCookies && len(cookies) > 0 {
+			matchers := make([]string, len(cookies))
+			for j, c := range cookies {
+				matchers[j] = cookieMatcher(c)
+			}
+			req.Headers = []NameValues{{Name: "Cookie", Values: matchers}}
+		}
+		resp := &HttpResponse{}
+		r.respond(resp, i+1)
+		hop := MockExpectation{
+			Priority:             exp.Priority,
+			Tags:                 exp.Tags,
+			HttpRequest:          req,
+			HttpResponse:         resp,
+			HttpResponseTemplate: redirectResponseTemplate(resp),
+			RedirectHop:          i + 1,
+		}
+		if exp.ID != "" {
+			hop.ID = fmt.Sprintf("%s-hop-%d", exp.ID, i+1)
+		}
+		if exp.Description != "" {
+			hop.Description = fmt.Sprintf("%s (redirect hop %d)", exp.Description, i+1)
+		}
+		hops = append(hops, hop)
+	}
+	return hops, nil
+}
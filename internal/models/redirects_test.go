@@ -0,0 +1,94 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestApplyRedirects(t *testing.T) {
+	config := &MockConfiguration{Expectations: []MockExpectation{
+		{
+			ID:           "authorize",
+			HttpRequest:  &HttpRequest{Method: "GET", Path: "/oauth/authorize"},
+			HttpResponse: &HttpResponse{StatusCode: 200, Headers: []NameValues{{Name: "Location", Values: []string{"/stale"}}}},
+			Redirects: &Redirects{
+				Hops: []RedirectHop{
+					{Location: "/login?prompt=1", Cookies: []NameValues{{Name: "flow", Values: []string{"f1"}}}},
+					{Location: "/consent", Status: 303, Cookies: []NameValues{{Name: "session", Values: []string{"s.1"}}}},
+					{Location: "${request.query.redirect_uri}?code=abc&state=${request.query.state}"},
+				},
+				Carry:          []string{"redirect_uri", "state"},
+				RequireCookies: true,
+			},
+		},
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/login"}, HttpResponse: &HttpResponse{StatusCode: 200}},
+	}}
+
+	for pass := 0; pass < 2; pass++ { // saving again regenerates the same hops
+		if n, err := config.ApplyRedirects(); err != nil || n != 2 {
+			t.Fatalf("pass %d: ApplyRedirects = %d, %v", pass, n, err)
+		}
+	}
+	if len(config.Expectations) != 4 {
+		t.Fatalf("expectations = %d, want 4", len(config.Expectations))
+	}
+
+	login, consent, authorize := config.Expectations[0], config.Expectations[1], config.Expectations[2]
+	carried := "redirect_uri=${request.query.redirect_uri}&state=${request.query.state}"
+	if want := (HttpResponse{
+		StatusCode: 302,
+		Headers: []NameValues{
+			{Name: "Location", Values: []string{"/login?prompt=1&" + carried}},
+			{Name: "Set-Cookie", Values: []string{"flow=f1; Path=/"}},
+		},
+	}); !reflect.DeepEqual(*authorize.HttpResponse, want) {
+		t.Errorf("authorize response = %+v", *authorize.HttpResponse)
+	}
+	if login.ID != "authorize-hop-1" || login.RedirectHop != 1 || login.HttpRequest.Path != "/login" ||
+		!reflect.DeepEqual(login.HttpRequest.Headers, []NameValues{{Name: "Cookie", Values: []string{`(.*;\s*)?flow=f1(;.*)?`}}}) {
+		t.Errorf("login hop = %+v %+v", login, login.HttpRequest)
+	}
+	if login.HttpResponse.StatusCode != 303 || login.HttpResponse.Headers[0].Values[0] != "/consent?"+carried {
+		t.Errorf("login hop response = %+v", login.HttpResponse)
+	}
+	if len(consent.HttpRequest.Headers[0].Values) != 2 || consent.HttpResponse.StatusCode != 302 ||
+		consent.HttpResponse.Headers[0].Values[0] != "${request.query.redirect_uri}?code=abc&state=${request.query.state}" {
+		t.Errorf("consent hop = %+v %+v", consent.HttpRequest, consent.HttpResponse)
+	}
+
+	// The Locations echo the request through response templates
+	for _, exp := range config.Expectations[:3] {
+		if !IsRedirectTemplate(exp.HttpResponseTemplate) {
+			t.Errorf("%s: template = %+v", exp.ID, exp.HttpResponseTemplate)
+		}
+	}
+
+	// Without the chain the expectation loses its redirect template and hops
+	config.Expectations[2].Redirects = nil
+	if n, err := config.ApplyRedirects(); err != nil || n != 0 || len(config.Expectations) != 2 || config.Expectations[0].HttpResponseTemplate != nil {
+		t.Errorf("removed chain: %d, %v, %+v", n, err, config.Expectations)
+	}
+}
+
+func TestRedirectsCheck(t *testing.T) {
+	for _, tc := range []struct {
+		r   Redirects
+		msg string
+	}{
+		{Redirects{}, "at least one hop"},
+		{Redirects{Status: 200, Hops: []RedirectHop{{Location: "/a"}}}, "redirect status must be one of"},
+		{Redirects{Hops: []RedirectHop{{Location: "https://idp.example/login"}, {Location: "/b"}}}, "must be a path on the mock"},
+		{Redirects{Hops: []RedirectHop{{Location: "/a"}, {Location: "/a?again=1"}, {Location: "/c"}}}, "hops 1 and 2 both serve /a"},
+		{Redirects{Hops: []RedirectHop{{Location: "/a", Cookies: []NameValues{{Name: "sid"}}}}}, "cookies need a name and one value"},
+		{Redirects{Hops: []RedirectHop{{Location: "/a"}}, Carry: []string{"state&x"}}, "must be a plain name"},
+		{Redirects{Hops: []RedirectHop{{Location: "${request.body.uri}"}}}, "not a reference a location can echo"},
+	} {
+		if msg := tc.r.Check(); !strings.Contains(msg, tc.msg) {
+			t.Errorf("%+v: check = %q, want %q", tc.r, msg, tc.msg)
+		}
+	}
+	if msg := (Redirects{Hops: []RedirectHop{{Location: "https://app.example/cb"}}}).Check(); msg != "" {
+		t.Errorf("single external hop: %q", msg)
+	}
+}
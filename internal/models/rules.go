@@ -0,0 +1,388 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Response routing: an expectation with Rules answers some requests with another response,
+// e.g. 504 when the X-Test-Case header is "timeout" or 402 when the body's amount exceeds
+// 1000. Every condition of a rule must hold. ApplyRules compiles each rule into an
+// expectation matching the original request plus the rule's conditions, placed before the
+// original in rule order, so the first rule that holds wins and other requests get the
+// original response. Body conditions become one MockServer JSON_PATH filter.
+
+// Rule condition sources
+const (
+	RuleHeader = "header"
+	RuleQuery  = "query"
+	RuleBody   = "body"
+)
+
+// RuleSources lists the condition sources in the order the editor offers them
+var RuleSources = []string{RuleHeader, RuleQuery, RuleBody}
+
+// ruleOps are the operators of each source: headers and query parameters compile to
+// MockServer value matchers, body fields to JSON path filters
+var ruleOps = map[string][]string{
+	RuleHeader: {"eq", "ne", "matches", "exists"},
+	RuleQuery:  {"eq", "ne", "matches", "exists"},
+	RuleBody:   {"eq", "ne", "gt", "gte", "lt", "lte", "matches", "exists"},
+}
+
+var ruleOpSymbols = map[string]string{
+	"eq": "==", "ne": "!=", "gt": ">", "gte": ">=", "lt": "<", "lte": "<=", "matches": "=~",
+}
+
+// ResponseRule is an alternative response served when every condition holds
+type ResponseRule struct {
+	Name     string          `json:"name,omitempty"`
+	When     []RuleCondition `json:"when"`
+	Response *HttpResponse   `json:"response"`
+}
+
+// RuleCondition tests one request value
+type RuleCondition struct {
+	Source string `json:"source"`          // header, query or body
+	Field  string `json:"field"`           // Header or parameter name, or body field path (amount, items.0.sku)
+	Op     string `json:"op"`              // eq, ne, matches (regex) or exists; body fields also gt, gte, lt and lte
+	Value  any    `json:"value,omitempty"` // Compared value; a number for gt, gte, lt and lte
+}
+
+// RuleOps returns the operators a condition source supports
+func RuleOps(source string) []string {
+	return ruleOps[source]
+}
+
+// Label names the rule in listings: its name, or its position
+func (r ResponseRule) Label(index int) string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return fmt.Sprintf("rule %d", index+1)
+}
+
+// Describe summarizes the rule, e.g. `header X-Test-Case == "timeout" → 504`
+func (r ResponseRule) Describe() string {
+	conds := make([]string, len(r.When))
+	for i, c := range r.When {
+		conds[i] = c.Describe()
+	}
+	status := 200
+	if r.Response != nil && r.Response.StatusCode != 0 {
+		status = r.Response.StatusCode
+	}
+	return fmt.Sprintf("%s → %d", strings.Join(conds, " and "), status)
+}
+
+// Describe summarizes the condition, e.g. `body amount > 1000`
+func (c RuleCondition) Describe() string {
+	if c.Op == "exists" {
+		return fmt.Sprintf("%s %s exists", c.Source, c.Field)
+	}
+	value, _ := json.Marshal(c.Value)
+	return fmt.Sprintf("%s %s %s %s", c.Source, c.Field, ruleOpSymbols[c.Op], value)
+}
+
+// Check reports what is wrong with the condition, or ""
+func (c RuleCondition) Check() string {
+	ops, ok := ruleOps[c.Source]
+	switch {
+	case !ok:
+		return fmt.Sprintf("condition source must be %s, got %q", strings.Join(RuleSources, ", "), c.Source)
+	case strings.TrimSpace(c.Field) == "":
+		return fmt.Sprintf("a %s condition needs a field", c.Source)
+	case !contains(ops, c.Op):
+		return fmt.Sprintf("%s conditions support %s, got %q", c.Source, strings.Join(ops, ", "), c.Op)
+	case c.Op == "exists":
+		return ""
+	case c.Value == nil:
+		return fmt.Sprintf("%s %s %s needs a value", c.Source, c.Field, c.Op)
+	}
+	if c.Source == RuleBody {
+		if _, err := bodyFieldPath(c.Field); err != nil {
+			return err.Error()
+		}
+	}
+	switch c.Op {
+	case "gt", "gte", "lt", "lte":
+		if _, ok := ruleNumber(c.Value); !ok {
+			return fmt.Sprintf("%s %s %s needs a number, got %v", c.Source, c.Field, c.Op, c.Value)
+		}
+	case "matches":
+		pattern, ok := c.Value.(string)
+		if !ok {
+			return fmt.Sprintf("%s %s matches needs a regex string", c.Source, c.Field)
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Sprintf("%s %s matches: invalid regex: %v", c.Source, c.Field, err)
+		}
+	}
+	return ""
+}
+
+// Check reports what is wrong with the rule, or ""
+func (r ResponseRule) Check() string {
+	if len(r.When) == 0 {
+		return "a rule needs at least one condition"
+	}
+	if r.Response == nil {
+		return "a rule needs a response"
+	}
+	if s := r.Response.StatusCode; s != 0 && (s < 100 || s > 599) {
+		return fmt.Sprintf("response status must be between 100 and 599, got %d", s)
+	}
+	for _, c := range r.When {
+		if msg := c.Check(); msg != "" {
+			return msg
+		}
+	}
+	return ""
+}
+
+// ApplyRules regenerates the rule expectations of every expectation with Rules. Each is placed
+// right before its expectation, ahead of the variants and companions other features add, so
+// rules decide first. Run it before ApplyRequestTemplates, so rule responses can echo the
+// request too. It returns the number of expectations generated.
+func (c *MockConfiguration) ApplyRules() (int, error) {
+	out := make([]MockExpectation, 0, len(c.Expectations))
+	generated := 0
+	for i, exp := range c.Expectations {
+		if exp.RuleVariant != "" {
+			continue // regenerated below from the expectation it belongs to
+		}
+		if len(exp.Rules) == 0 || exp.Generated() || exp.HttpRequest == nil {
+			out = append(out, exp)
+			continue
+		}
+		for j, rule := range exp.Rules {
+			v, err := RuleExpectation(exp, j)
+			if err != nil {
+				return generated, ValidationError{Field: fmt.Sprintf("expectations[%d].rules[%d]", i, j), Message: fmt.Sprintf("%s: %v", rule.Label(j), err)}
+			}
+			out = append(out, v)
+			generated++
+		}
+		out = append(out, exp)
+	}
+	c.Expectations = out
+	return generated, nil
+}
+
+// RuleExpectation builds the expectation serving rule i of exp
+func RuleExpectation(exp MockExpectation, i int) (MockExpectation, error) {
+	rule := exp.Rules[i]
+	if msg := rule.Check(); msg != "" {
+		return MockExpectation{}, fmt.Errorf("%s", msg)
+	}
+
+	v := exp
+	v.Rules = nil
+	v.RuleVariant = rule.Label(i)
+	v.HttpResponseTemplate = nil
+	v.HttpError = nil
+	v.Forward = nil
+	v.Stream = nil
+	v.Faults = nil
+	v.Negotiation = nil
+	v.ConditionalGet = false
+	v.Ranges = false
+	v.GraphQLErrors = nil
+	v.Checks = nil
+	v.Examples = nil
+	v.Notes = ""
+	v.Freshness = nil
+	v.Redirects = nil
+	if exp.ID != "" {
+		v.ID = fmt.Sprintf("%s-rule-%d", exp.ID, i+1)
+	}
+	if rule.Name != "" {
+		v.Description = strings.TrimSpace(exp.Description + " (" + rule.Name + ")")
+	}
+	resp := *rule.Response
+	v.HttpResponse = &resp
+	if raw, _ := json.Marshal(resp); !datasetReference.Match(raw) {
+		v.Dataset = nil // the rule answers with its own response, not the dataset's
+	}
+
+	req := *exp.HttpRequest
+	req.Headers = append([]NameValues(nil), req.Headers...)
+	req.QueryStringParameters = append([]NameValues(nil), req.QueryStringParameters...)
+	var filters []string
+	for _, c := range rule.When {
+		switch c.Source {
+		case RuleHeader:
+			req.Headers = addMatcherValue(req.Headers, c.Field, ruleValueMatcher(c))
+		case RuleQuery:
+			req.QueryStringParameters = addMatcherValue(req.QueryStringParameters, c.Field, ruleValueMatcher(c))
+		case RuleBody:
+			filter, err := ruleFilter(c)
+			if err != nil {
+				return MockExpectation{}, err
+			}
+			filters = append(filters, filter)
+		}
+	}
+	if len(filters) > 0 {
+		matched, err := jsonBodyFilters(req.Body)
+		if err != nil {
+			return MockExpectation{}, err
+		}
+		req.Body = map[string]any{"type": "JSON_PATH", "jsonPath": "$[?(" + strings.Join(append(matched, filters...), " && ") + ")]"}
+	}
+	v.HttpRequest = &req
+	return v, nil
+}
+
+// addMatcherValue adds a value matcher for name; MockServer requires every listed value to match
+func addMatcherValue(list []NameValues, name, value string) []NameValues {
+	for i, nv := range list {
+		if strings.EqualFold(nv.Name, name) {
+			list[i] = NameValues{Name: nv.Name, Values: append(append([]string(nil), nv.Values...), value)}
+			return list
+		}
+	}
+	return append(list, NameValues{Name: name, Values: []string{value}})
+}
+
+// ruleValueMatcher is the MockServer header or query value matcher of a condition; values are
+// regexes and a leading ! negates them
+func ruleValueMatcher(c RuleCondition) string {
+	switch c.Op {
+	case "exists":
+		return ".*"
+	case "matches":
+		return fmt.Sprint(c.Value)
+	case "ne":
+		return "!" + regexp.QuoteMeta(fmt.Sprint(c.Value))
+	}
+	return regexp.QuoteMeta(fmt.Sprint(c.Value))
+}
+
+// ruleFilter is the JSON path filter expression of a body condition, e.g. @.amount > 1000
+func ruleFilter(c RuleCondition) (string, error) {
+	path, err := bodyFieldPath(c.Field)
+	if err != nil {
+		return "", err
+	}
+	if c.Op == "exists" {
+		return path, nil
+	}
+	if c.Op == "matches" {
+		return fmt.Sprintf("%s =~ /%s/", path, strings.ReplaceAll(fmt.Sprint(c.Value), "/", `\/`)), nil
+	}
+	return fmt.Sprintf("%s %s %s", path, ruleOpSymbols[c.Op], jsonPathLiteral(c.Value)), nil
+}
+
+var jsonPathIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// bodyFieldPath converts a field path (customer.name, items.0.sku or items[0].sku) to the
+// JSON path of the filtered object, e.g. @.items[0].sku
+func bodyFieldPath(field string) (string, error) {
+	field = strings.TrimPrefix(bodyIndex.ReplaceAllString(strings.TrimSpace(field), ".$1"), ".")
+	var b strings.Builder
+	b.WriteString("@")
+	for _, part := range strings.Split(field, ".") {
+		switch {
+		case part == "":
+			return "", fmt.Errorf("malformed body field path %q", field)
+		case isIndex(part):
+			b.WriteString("[" + part + "]")
+		case jsonPathIdentifier.MatchString(part):
+			b.WriteString("." + part)
+		default:
+			b.WriteString("['" + strings.ReplaceAll(part, "'", `\'`) + "']")
+		}
+	}
+	return b.String(), nil
+}
+
+func isIndex(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil && !strings.HasPrefix(s, "-")
+}
+
+func jsonPathLiteral(v any) string {
+	switch t := v.(type) {
+	case string:
+		return "'" + strings.ReplaceAll(strings.ReplaceAll(t, `\`, `\\`), "'", `\'`) + "'"
+	case nil:
+		return "null"
+	}
+	raw, _ := json.Marshal(v)
+	return string(raw)
+}
+
+func ruleNumber(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// jsonBodyFilters turns the expectation's own body matcher into JSON path filters, so rules
+// with body conditions keep matching only what the expectation matches. MockServer allows one
+// body matcher per request; only JSON bodies of plain fields can be combined.
+func jsonBodyFilters(body any) ([]string, error) {
+	if body == nil {
+		return nil, nil
+	}
+	b, ok := body.(map[string]any)
+	var fields any = body
+	if ok {
+		if _, typed := b["type"]; typed {
+			mt, _ := b["matchType"].(string)
+			if !strings.EqualFold(fmt.Sprint(b["type"]), "JSON") || strings.EqualFold(mt, "STRICT") {
+				return nil, fmt.Errorf("body conditions cannot be combined with the request's %v body matcher", b["type"])
+			}
+			fields = b["json"]
+			if s, isText := fields.(string); isText {
+				if err := json.Unmarshal([]byte(s), &fields); err != nil {
+					return nil, fmt.Errorf("the request's JSON body matcher is not valid JSON")
+				}
+			}
+		}
+	}
+	var filters []string
+	var walk func(prefix string, v any) error
+	walk = func(prefix string, v any) error {
+		switch t := v.(type) {
+		case map[string]any:
+			keys := make([]string, 0, len(t))
+			for k := range t {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				if err := walk(strings.TrimPrefix(prefix+"."+k, "."), t[k]); err != nil {
+					return err
+				}
+			}
+		case []any:
+			return fmt.Errorf("body conditions cannot be combined with a request body matcher holding arrays (%s)", prefix)
+		default:
+			path, err := bodyFieldPath(prefix)
+			if err != nil {
+				return err
+			}
+			filters = append(filters, path+" == "+jsonPathLiteral(t))
+		}
+		return nil
+	}
+	if _, isObj := fields.(map[string]any); !isObj {
+		return nil, fmt.Errorf("body conditions need the request's JSON body matcher to be an object")
+	}
+	return filters, walk("", fields)
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRuleExpectation(t *testing.T) {
+	exp := MockExpectation{
+		ID:           "pay",
+		HttpRequest:  &HttpRequest{Method: "POST", Path: "/payments", Headers: []NameValues{{Name: "X-Test-Case", Values: []string{".+"}}}},
+		HttpResponse: &HttpResponse{StatusCode: 201},
+		Faults:       &Faults{ErrorRate: 10},
+		Rules: []ResponseRule{{
+			Name: "large",
+			When: []RuleCondition{
+				{Source: RuleHeader, Field: "x-test-case", Op: "ne", Value: "skip.me"},
+				{Source: RuleBody, Field: "items[0].price", Op: "gte", Value: float64(1000)},
+				{Source: RuleBody, Field: "customer.tier", Op: "eq", Value: "gold's"},
+			},
+			Response: &HttpResponse{StatusCode: 402},
+		}},
+	}
+	v, err := RuleExpectation(exp, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.ID != "pay-rule-1" || v.RuleVariant != "large" || v.Faults != nil || v.Rules != nil || v.HttpResponse.StatusCode != 402 {
+		t.Errorf("variant = %+v", v)
+	}
+	if h := v.HttpRequest.Headers; len(h) != 1 || strings.Join(h[0].Values, " ") != `.+ !skip\.me` {
+		t.Errorf("headers = %+v", h)
+	}
+	if len(exp.HttpRequest.Headers[0].Values) != 1 {
+		t.Error("the original request matcher must not change")
+	}
+	want := `$[?(@.items[0].price >= 1000 && @.customer.tier == 'gold\'s')]`
+	if got := v.HttpRequest.Body.(map[string]any)["jsonPath"]; got != want {
+		t.Errorf("jsonPath = %v, want %s", got, want)
+	}
+	if got := exp.Rules[0].Describe(); got != `header x-test-case != "skip.me" and body items[0].price >= 1000 and body customer.tier == "gold's" → 402` {
+		t.Errorf("describe = %s", got)
+	}
+}
+
+func TestApplyRulesChecks(t *testing.T) {
+	base := func(body any, rule ResponseRule) *MockConfiguration {
+		return &MockConfiguration{Expectations: []MockExpectation{{
+			HttpRequest:  &HttpRequest{Method: "POST", Path: "/orders", Body: body},
+			HttpResponse: &HttpResponse{StatusCode: 200},
+			Rules:        []ResponseRule{rule},
+		}}}
+	}
+	gt := []RuleCondition{{Source: RuleBody, Field: "total", Op: "gt", Value: float64(5)}}
+	for name, tc := range map[string]struct {
+		config *MockConfiguration
+		want   string
+	}{
+		"no conditions":   {base(nil, ResponseRule{Response: &HttpResponse{}}), "at least one condition"},
+		"no response":     {base(nil, ResponseRule{When: gt}), "needs a response"},
+		"bad op":          {base(nil, ResponseRule{When: []RuleCondition{{Source: RuleHeader, Field: "X", Op: "gt", Value: "1"}}, Response: &HttpResponse{}}), "header conditions support"},
+		"not a number":    {base(nil, ResponseRule{When: []RuleCondition{{Source: RuleBody, Field: "total", Op: "lt", Value: "5"}}, Response: &HttpResponse{}}), "needs a number"},
+		"bad regex":       {base(nil, ResponseRule{When: []RuleCondition{{Source: RuleQuery, Field: "q", Op: "matches", Value: "("}}, Response: &HttpResponse{}}), "invalid regex"},
+		"regex body":      {base(map[string]any{"type": "REGEX", "regex": ".*"}, ResponseRule{When: gt, Response: &HttpResponse{}}), "cannot be combined"},
+		"array json body": {base(map[string]any{"type": "JSON", "json": map[string]any{"tags": []any{"a"}}}, ResponseRule{When: gt, Response: &HttpResponse{}}), "holding arrays"},
+	} {
+		_, err := tc.config.ApplyRules()
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, want %q", name, err, tc.want)
+		}
+	}
+
+	// Saving again regenerates the rule expectations instead of adding more
+	config := base(nil, ResponseRule{When: gt, Response: &HttpResponse{StatusCode: 409}})
+	for i := 0; i < 2; i++ {
+		if n, err := config.ApplyRules(); err != nil || n != 1 || len(config.Expectations) != 2 || config.Expectations[0].RuleVariant != "rule 1" {
+			t.Fatalf("save %d: %d, %v, %d expectations", i+1, n, err, len(config.Expectations))
+		}
+	}
+}
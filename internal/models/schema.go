@@ -0,0 +1,93 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SchemaRefPrefix is the prefix used when referencing a project schema, e.g. "schemas/User".
+const SchemaRefPrefix = "schemas/"
+
+// SchemaRefName strips the "schemas/" prefix from a reference. Bare names are returned as-is.
+func SchemaRefName(ref string) string {
+	return strings.TrimPrefix(strings.TrimSpace(ref), SchemaRefPrefix)
+}
+
+// SchemaNames returns the registered schema names in stable order
+func (c *MockConfiguration) SchemaNames() []string {
+	names := make([]string, 0, len(c.Schemas))
+	for name := range c.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ApplySchemaRefs renders the response body of every expectation that references a
+// project schema. Bodies are always re-rendered from the library so a schema change
+// propagates to all referencing expectations. It returns the number of bodies rendered.
+func (c *MockConfiguration) ApplySchemaRefs() (int, error) {
+	rendered := 0
+	for i := range c.Expectations {
+		resp := c.Expectations[i].HttpResponse
+		if resp == nil {
+			continue
+		}
+		if resp.SchemaRef == "" {
+			resp.SchemaRef = bodySchemaRef(resp.Body)
+		}
+		if resp.SchemaRef == "" {
+			continue
+		}
+		name := SchemaRefName(resp.SchemaRef)
+		schema, ok := c.Schemas[name]
+		if !ok {
+			return rendered, ValidationError{
+				Field:   fmt.Sprintf("expectations[%d].httpResponse.schemaRef", i),
+				Message: fmt.Sprintf("schema %q is not registered for this project", name),
+			}
+		}
+		example, err := ExampleFromSchema(schema, c.Schemas)
+		if err != nil {
+			return rendered, fmt.Errorf("render schema %q: %w", name, err)
+		}
+		resp.SchemaRef = SchemaRefPrefix + name
+		resp.Body = map[string]any{
+			"type": "JSON",
+			"json": example,
+		}
+		rendered++
+	}
+	return rendered, nil
+}
+
+// bodySchemaRef detects a body written as {"$ref":"schemas/User"}, either bare or
+// inside a MockServer JSON body wrapper.
+func bodySchemaRef(body any) string {
+	m, ok := body.(map[string]any)
+	if !ok {
+		return ""
+	}
+	if inner, ok := m["json"].(map[string]any); ok {
+		m = inner
+	}
+	if len(m) != 1 {
+		return ""
+	}
+	if ref, ok := m["$ref"].(string); ok && strings.HasPrefix(ref, SchemaRefPrefix) {
+		return ref
+	}
+	return ""
+}
+
+// SchemaReferences returns the indices of expectations whose response references the named schema
+func (c *MockConfiguration) SchemaReferences(name string) []int {
+	var out []int
+	for i, exp := range c.Expectations {
+		if exp.HttpResponse != nil && exp.HttpResponse.SchemaRef != "" && SchemaRefName(exp.HttpResponse.SchemaRef) == name {
+			out = append(out, i)
+		}
+	}
+	return out
+}
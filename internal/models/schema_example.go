@@ -0,0 +1,420 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// maxSchemaDepth bounds example generation for deeply nested or recursive schemas
+const maxSchemaDepth = 8
+
+// maxExampleItems caps the items generated for an array whose minItems is very large
+const maxExampleItems = 50
+
+// SchemaExampler builds realistic example values from a JSON Schema. Explicit example,
+// default, const and enum values win; otherwise values follow the declared type, format and
+// bounds (minimum/maximum, minLength/maxLength, minItems/maxItems), and property names such
+// as email, createdAt or price pick plausible values. Output is deterministic.
+type SchemaExampler struct {
+	// Resolve returns the schema a "$ref" points to, or nil when it is unknown
+	Resolve func(ref string) any
+
+	expanding map[string]bool // $refs being expanded, to stop recursive schemas
+}
+
+// ExampleFromSchema builds a representative JSON value from a JSON Schema document with a
+// SchemaExampler. "$ref" values of the form "schemas/<Name>" are resolved against the project
+// library, and local ones such as "#/$defs/User" within the schema itself.
+func ExampleFromSchema(schema any, library map[string]any) (any, error) {
+	if _, ok := schema.(map[string]any); !ok {
+		return nil, fmt.Errorf("schema must be a JSON object")
+	}
+	unknown := ""
+	e := &SchemaExampler{Resolve: func(ref string) any {
+		if strings.HasPrefix(ref, "#") {
+			return ResolvePointer(schema, ref)
+		}
+		target, ok := library[SchemaRefName(ref)]
+		if !ok && unknown == "" {
+			unknown = ref
+		}
+		return target
+	}}
+	example := e.Example(schema)
+	if unknown != "" {
+		return nil, fmt.Errorf("unknown schema reference %q", unknown)
+	}
+	return example, nil
+}
+
+// ResolvePointer follows a local JSON pointer ("#/components/schemas/User") within root
+func ResolvePointer(root any, ref string) any {
+	if !strings.HasPrefix(ref, "#") {
+		return nil
+	}
+	cur := root
+	for _, part := range strings.Split(strings.TrimPrefix(strings.TrimPrefix(ref, "#"), "/"), "/") {
+		if part == "" {
+			continue
+		}
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		node, ok := cur.(map[string]any)
+		if !ok {
+			return nil
+		}
+		cur = node[part]
+	}
+	return cur
+}
+
+// Example generates a value for schema
+func (e *SchemaExampler) Example(schema any) any {
+	return e.example(schema, "", 0, 0)
+}
+
+// ExampleFor generates a value for a schema describing a property or parameter called name
+func (e *SchemaExampler) ExampleFor(name string, schema any) any {
+	return e.example(schema, name, 0, 0)
+}
+
+// example generates the value of schema; name is the property it describes and index its
+// position within an array, so array items and their ids differ
+func (e *SchemaExampler) example(raw any, name string, index, depth int) any {
+	if depth > maxSchemaDepth {
+		return nil
+	}
+	s, ok := raw.(map[string]any)
+	if !ok {
+		return nil
+	}
+	if ref, ok := s["$ref"].(string); ok {
+		if e.Resolve == nil {
+			return nil
+		}
+		if e.expanding == nil {
+			e.expanding = map[string]bool{}
+		}
+		// A schema referring back to itself ends in an empty value instead of unrolling
+		if e.expanding[ref] {
+			return nil
+		}
+		e.expanding[ref] = true
+		defer delete(e.expanding, ref)
+		return e.example(e.Resolve(ref), name, index, depth+1)
+	}
+
+	for _, key := range []string{"example", "default", "const"} {
+		if v, ok := s[key]; ok {
+			return v
+		}
+	}
+	if arr, ok := s["examples"].([]any); ok && len(arr) > 0 {
+		return arr[0]
+	}
+	if arr, ok := s["enum"].([]any); ok && len(arr) > 0 {
+		return arr[index%len(arr)]
+	}
+	if all, ok := s["allOf"].([]any); ok && len(all) > 0 {
+		merged := map[string]any{}
+		for _, part := range all {
+			if obj, ok := e.example(part, name, index, depth+1).(map[string]any); ok {
+				for k, v := range obj {
+					merged[k] = v
+				}
+			}
+		}
+		return merged
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if options, ok := s[key].([]any); ok && len(options) > 0 {
+			return e.example(options[0], name, index, depth+1)
+		}
+	}
+
+	switch exampleType(s) {
+	case "object":
+		obj := map[string]any{}
+		props, _ := s["properties"].(map[string]any)
+		keys := make([]string, 0, len(props))
+		for k := range props {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if v := e.example(props[k], k, index, depth+1); v != nil {
+				obj[k] = v
+			}
+		}
+		if extra, ok := s["additionalProperties"].(map[string]any); ok && len(props) == 0 {
+			if v := e.example(extra, "", index, depth+1); v != nil {
+				obj["key"] = v
+			}
+		}
+		return obj
+	case "array":
+		n := 1
+		if lo, ok := schemaNumber(s["minItems"]); ok && int(lo) > n {
+			n = min(int(lo), maxExampleItems)
+		}
+		if hi, ok := schemaNumber(s["maxItems"]); ok && int(hi) < n {
+			n = int(hi)
+		}
+		items := make([]any, 0, n)
+		for i := 0; i < n; i++ {
+			if v := e.example(s["items"], singular(name), i, depth+1); v != nil {
+				items = append(items, v)
+			}
+		}
+		return items
+	case "string":
+		return exampleString(s, name, index)
+	case "integer":
+		return exampleNumber(s, name, index, true)
+	case "number":
+		return exampleNumber(s, name, index, false)
+	case "boolean":
+		return true
+	}
+	return nil
+}
+
+// exampleType returns the declared type, the first non-null one of a type list, or the type
+// implied by properties or items
+func exampleType(s map[string]any) string {
+	switch t := s["type"].(type) {
+	case string:
+		return t
+	case []any:
+		for _, v := range t {
+			if str, ok := v.(string); ok && str != "null" {
+				return str
+			}
+		}
+	}
+	if _, ok := s["properties"]; ok {
+		return "object"
+	}
+	if _, ok := s["items"]; ok {
+		return "array"
+	}
+	return ""
+}
+
+// exampleString follows the format, then the property name, then the length bounds
+func exampleString(s map[string]any, name string, index int) string {
+	v := formatExample(s["format"], index)
+	if v == "" {
+		v = namedString(name, index)
+	}
+	if lo, ok := schemaNumber(s["minLength"]); ok && len(v) < int(lo) {
+		v += strings.Repeat("x", int(lo)-len(v))
+	}
+	if hi, ok := schemaNumber(s["maxLength"]); ok && len(v) > int(hi) {
+		v = v[:int(hi)]
+	}
+	return v
+}
+
+// formatExample is a valid value of a JSON Schema / OpenAPI string format, or "" when unknown
+func formatExample(format any, index int) string {
+	switch format {
+	case "date-time":
+		return fmt.Sprintf("2024-01-%02dT10:30:00Z", 15+index%14)
+	case "date":
+		return fmt.Sprintf("2024-01-%02d", 15+index%14)
+	case "time":
+		return "10:30:00Z"
+	case "duration":
+		return "PT1H30M"
+	case "email", "idn-email":
+		return exampleEmails[index%len(exampleEmails)]
+	case "uuid":
+		return exampleUUID(index)
+	case "uri", "url", "iri":
+		return "https://example.com/resource"
+	case "uri-reference":
+		return "/resource"
+	case "hostname", "idn-hostname":
+		return "api.example.com"
+	case "ipv4":
+		return fmt.Sprintf("192.0.2.%d", 1+index%254)
+	case "ipv6":
+		return fmt.Sprintf("2001:db8::%x", 1+index)
+	case "byte":
+		return "c3RyaW5n"
+	case "binary":
+		return "binary"
+	case "password":
+		return "********"
+	case "phone":
+		return examplePhone(index)
+	}
+	return ""
+}
+
+var (
+	exampleFirstNames = []string{"Jane", "John", "Maria", "Wei", "Amara"}
+	exampleLastNames  = []string{"Doe", "Smith", "Garcia", "Chen", "Okafor"}
+	exampleEmails     = []string{"jane.doe@example.com", "john.smith@example.com", "maria.garcia@example.com", "wei.chen@example.com", "amara.okafor@example.com"}
+	exampleCities     = []string{"Springfield", "Austin", "Berlin", "Singapore", "Nairobi"}
+)
+
+// namedString picks a plausible value from the property name, e.g. email, firstName, city
+func namedString(name string, index int) string {
+	n := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+	switch {
+	case n == "":
+		return "string"
+	case n == "id" || n == "uuid" || strings.HasSuffix(n, "uuid"):
+		return exampleUUID(index)
+	case isIDName(name):
+		return fmt.Sprintf("%s_%d", strings.TrimSuffix(n, "id"), 1001+index)
+	case strings.Contains(n, "email"):
+		return exampleEmails[index%len(exampleEmails)]
+	case n == "firstname" || n == "givenname":
+		return exampleFirstNames[index%len(exampleFirstNames)]
+	case n == "lastname" || n == "surname" || n == "familyname":
+		return exampleLastNames[index%len(exampleLastNames)]
+	case n == "name" || n == "fullname" || n == "displayname" || n == "username":
+		full := exampleFirstNames[index%len(exampleFirstNames)] + " " + exampleLastNames[index%len(exampleLastNames)]
+		if n == "username" {
+			return strings.ToLower(strings.ReplaceAll(full, " ", "."))
+		}
+		return full
+	case strings.Contains(n, "phone") || strings.Contains(n, "mobile"):
+		return examplePhone(index)
+	case n == "city":
+		return exampleCities[index%len(exampleCities)]
+	case n == "country" || n == "countrycode":
+		return "US"
+	case n == "currency":
+		return "USD"
+	case n == "locale" || n == "language":
+		return "en-US"
+	case strings.Contains(n, "url") || strings.Contains(n, "website") || strings.Contains(n, "link"):
+		return "https://example.com/" + strings.TrimSuffix(n, "url")
+	case isTimeName(name):
+		return formatExample("date-time", index)
+	case strings.Contains(n, "date"):
+		return formatExample("date", index)
+	case n == "status" || n == "state":
+		return "active"
+	case n == "type" || n == "kind":
+		return "standard"
+	case strings.Contains(n, "description") || n == "summary" || n == "message":
+		return "Example " + strings.ToLower(name)
+	case strings.Contains(n, "address") || n == "street":
+		return fmt.Sprintf("%d Main Street", 100+index)
+	case n == "zip" || n == "zipcode" || n == "postalcode":
+		return "94105"
+	case n == "token" || strings.HasSuffix(n, "token"):
+		return "tok_3f9a2c7e1b"
+	case n == "title":
+		return "Example title"
+	}
+	return "example " + name
+}
+
+// exampleNumber picks a plausible value for the property name and fits it to the schema's
+// minimum, maximum and multipleOf
+func exampleNumber(s map[string]any, name string, index int, integer bool) any {
+	v := namedNumber(name, index, integer)
+	lo, hasLo := schemaNumber(s["minimum"])
+	hi, hasHi := schemaNumber(s["maximum"])
+	step := 1.0
+	if !integer {
+		step = 0.01
+	}
+	// exclusiveMinimum/Maximum are numbers since draft 6 and booleans in OpenAPI 3.0
+	if x, ok := schemaNumber(s["exclusiveMinimum"]); ok {
+		lo, hasLo = x+step, true
+	} else if b, _ := s["exclusiveMinimum"].(bool); b && hasLo {
+		lo += step
+	}
+	if x, ok := schemaNumber(s["exclusiveMaximum"]); ok {
+		hi, hasHi = x-step, true
+	} else if b, _ := s["exclusiveMaximum"].(bool); b && hasHi {
+		hi -= step
+	}
+	if hasLo && v < lo {
+		v = lo
+	}
+	if hasHi && v > hi {
+		v = hi
+	}
+	if m, ok := schemaNumber(s["multipleOf"]); ok && m > 0 {
+		v = math.Ceil(v/m) * m
+		if hasHi && v > hi {
+			v -= m
+		}
+	}
+	if integer {
+		return int(math.Round(v))
+	}
+	return math.Round(v*100) / 100
+}
+
+// namedNumber is the default number for a property name, e.g. price 19.99 or age 30
+func namedNumber(name string, index int, integer bool) float64 {
+	n := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+	switch {
+	case n == "id" || isIDName(name):
+		return float64(1001 + index)
+	case strings.Contains(n, "price") || strings.Contains(n, "amount") || strings.Contains(n, "cost"):
+		return 19.99 + float64(index)*10
+	case n == "age":
+		return 30
+	case strings.Contains(n, "page") && !strings.Contains(n, "size"):
+		return 1
+	case strings.Contains(n, "size") || strings.Contains(n, "limit"):
+		return 20
+	case strings.Contains(n, "count") || strings.Contains(n, "quantity") || strings.Contains(n, "total"):
+		return float64(1 + index)
+	case strings.Contains(n, "rating") || strings.Contains(n, "score"):
+		return 4.5
+	case n == "lat" || n == "latitude":
+		return 37.77
+	case n == "lon" || n == "lng" || n == "longitude":
+		return -122.42
+	case isTimeName(name):
+		return 1705314600 + float64(index)*86400
+	}
+	if integer {
+		return float64(1 + index)
+	}
+	return 1.5 + float64(index)
+}
+
+// isIDName reports names like userId, user_id or orderID
+func isIDName(name string) bool {
+	return strings.HasSuffix(name, "Id") || strings.HasSuffix(name, "ID") || strings.HasSuffix(strings.ToLower(name), "_id")
+}
+
+// isTimeName reports names like createdAt, updated_at or timestamp
+func isTimeName(name string) bool {
+	lower := strings.ToLower(name)
+	return strings.HasSuffix(name, "At") || strings.HasSuffix(lower, "_at") || strings.Contains(lower, "timestamp")
+}
+
+// schemaNumber reads a numeric keyword decoded from JSON or YAML
+func schemaNumber(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func exampleUUID(index int) string {
+	return fmt.Sprintf("3fa85f64-5717-4562-b3fc-2c963f66a%03x", index%4096)
+}
+
+func examplePhone(index int) string {
+	return fmt.Sprintf("+1-555-01%02d", index%100)
+}
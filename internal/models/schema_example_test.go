@@ -0,0 +1,74 @@
+package models
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+)
+
+func TestExampleFromSchema(t *testing.T) {
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(`{
+		"type": "object",
+		"properties": {
+			"users": {"type": "array", "minItems": 3, "maxItems": 5, "items": {"$ref": "#/$defs/User"}},
+			"total": {"type": "integer", "minimum": 10, "maximum": 20},
+			"price": {"type": "number", "exclusiveMinimum": 100, "multipleOf": 0.5},
+			"tags": {"type": "array", "maxItems": 0, "items": {"type": "string"}},
+			"code": {"type": "string", "minLength": 8, "maxLength": 8}
+		},
+		"$defs": {
+			"User": {
+				"type": "object",
+				"properties": {
+					"id": {"type": "string", "format": "uuid"},
+					"email": {"type": "string"},
+					"role": {"enum": ["admin", "member"]},
+					"createdAt": {"type": "string"},
+					"manager": {"$ref": "#/$defs/User"}
+				}
+			}
+		}
+	}`), &schema); err != nil {
+		t.Fatal(err)
+	}
+	example, err := ExampleFromSchema(schema, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := example.(map[string]any)
+
+	users := got["users"].([]any)
+	if len(users) != 3 {
+		t.Fatalf("users has %d items, want minItems 3", len(users))
+	}
+	first, second := users[0].(map[string]any), users[1].(map[string]any)
+	if first["id"] == second["id"] || first["role"] != "admin" || second["role"] != "member" {
+		t.Errorf("array items are not varied: %v / %v", first, second)
+	}
+	if !regexp.MustCompile(`^[0-9a-f-]{36}$`).MatchString(first["id"].(string)) {
+		t.Errorf("uuid = %v", first["id"])
+	}
+	if !regexp.MustCompile(`^\S+@\S+\.\S+$`).MatchString(first["email"].(string)) {
+		t.Errorf("email = %v", first["email"])
+	}
+	if !regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T`).MatchString(first["createdAt"].(string)) {
+		t.Errorf("createdAt = %v", first["createdAt"])
+	}
+	if _, ok := first["manager"]; ok {
+		t.Error("a recursive reference was expanded")
+	}
+
+	if total := got["total"].(int); total < 10 || total > 20 {
+		t.Errorf("total = %d, want within [10, 20]", total)
+	}
+	if price := got["price"].(float64); price <= 100 || price != float64(int(price*2))/2 {
+		t.Errorf("price = %v, want a multiple of 0.5 above 100", price)
+	}
+	if tags := got["tags"].([]any); len(tags) != 0 {
+		t.Errorf("tags = %v, want none (maxItems 0)", tags)
+	}
+	if code := got["code"].(string); len(code) != 8 {
+		t.Errorf("code = %q, want 8 characters", code)
+	}
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestApplySchemaRefs_RendersAndPropagates(t *testing.T) {
+	var user map[string]any
+	_ = json.Unmarshal([]byte(`{"type":"object","properties":{"id":{"type":"string","example":"u_1001"},"email":{"type":"string","format":"email"}}}`), &user)
+
+	cfg := &MockConfiguration{
+		Schemas: map[string]any{"User": user},
+		Expectations: []MockExpectation{
+			{
+				HttpRequest:  &HttpRequest{Method: "GET", Path: "/me"},
+				HttpResponse: &HttpResponse{StatusCode: 200, Body: map[string]any{"type": "JSON", "json": map[string]any{"$ref": "schemas/User"}}},
+			},
+			{
+				HttpRequest:  &HttpRequest{Method: "GET", Path: "/health"},
+				HttpResponse: &HttpResponse{StatusCode: 200, Body: map[string]any{"type": "JSON", "json": map[string]any{"ok": true}}},
+			},
+		},
+	}
+
+	n, err := cfg.ApplySchemaRefs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("expected 1 rendered body, got %d", n)
+	}
+	resp := cfg.Expectations[0].HttpResponse
+	if resp.SchemaRef != "schemas/User" {
+		t.Fatalf("expected schemaRef to be recorded, got %q", resp.SchemaRef)
+	}
+	body := resp.Body.(map[string]any)["json"].(map[string]any)
+	if body["id"] != "u_1001" || body["email"] != "jane.doe@example.com" {
+		t.Fatalf("unexpected rendered body: %v", body)
+	}
+
+	// A schema change must reach the referencing expectation on the next render
+	user["properties"].(map[string]any)["id"] = map[string]any{"type": "string", "example": "u_2002"}
+	if _, err := cfg.ApplySchemaRefs(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body = cfg.Expectations[0].HttpResponse.Body.(map[string]any)["json"].(map[string]any)
+	if body["id"] != "u_2002" {
+		t.Fatalf("schema change did not propagate: %v", body)
+	}
+
+	// MockServer gets the rendered body only; the reference stays in the stored configuration
+	for _, exported := range []string{ExpectationsToMockServerJSON(cfg.Expectations), MockServerOnlyJSON(cfg.Expectations)} {
+		if strings.Contains(exported, "schemaRef") {
+			t.Errorf("exported expectations carry the schema reference:\n%s", exported)
+		}
+	}
+	if cfg.Expectations[0].HttpResponse.SchemaRef != "schemas/User" {
+		t.Error("exporting dropped the stored schema reference")
+	}
+}
+
+func TestApplySchemaRefs_UnknownSchema(t *testing.T) {
+	cfg := &MockConfiguration{
+		Expectations: []MockExpectation{{
+			HttpRequest:  &HttpRequest{Method: "GET", Path: "/orders/1"},
+			HttpResponse: &HttpResponse{StatusCode: 200, SchemaRef: "schemas/Order"},
+		}},
+	}
+	if _, err := cfg.ApplySchemaRefs(); err == nil {
+		t.Fatal("expected error for unregistered schema")
+	}
+}
+
+func TestExampleFromSchema_RecursiveRef(t *testing.T) {
+	node := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"name":  map[string]any{"type": "string"},
+			"child": map[string]any{"$ref": "schemas/Node"},
+		},
+	}
+	v, err := ExampleFromSchema(node, map[string]any{"Node": node})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := v.(map[string]any)["child"]; !ok {
+		t.Fatalf("expected child key in %v", v)
+	}
+}
@@ -0,0 +1,158 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// TagSelection picks the labeled expectations a deployment serves (deploy --only-tags/--exclude-tags)
+type TagSelection struct {
+	Only    []string `json:"only,omitempty"`    // Serve only expectations with any of these tags
+	Exclude []string `json:"exclude,omitempty"` // Never serve expectations with any of these tags
+}
+
+// WithheldExpectation is an expectation kept out of the deployment by a tag selection
+type WithheldExpectation struct {
+	Position    int             `json:"position"` // Index in the full expectation list
+	Expectation MockExpectation `json:"expectation"`
+}
+
+// IsEmpty reports whether the selection serves every expectation
+func (s TagSelection) IsEmpty() bool {
+	return len(s.Only) == 0 && len(s.Exclude) == 0
+}
+
+// Includes reports whether the selection serves exp. The contract info endpoint is always served.
+func (s TagSelection) Includes(exp *MockExpectation) bool {
+	return isInfoExpectation(exp) || s.Matches(exp)
+}
+
+// Matches reports whether exp's tags pass the selection, with no exception for the info endpoint
+func (s TagSelection) Matches(exp *MockExpectation) bool {
+	if hasAnyTag(exp.Tags, s.Exclude) {
+		return false
+	}
+	return len(s.Only) == 0 || hasAnyTag(exp.Tags, s.Only)
+}
+
+// Indices returns the positions of the expectations whose tags pass the selection
+func (s TagSelection) Indices(expectations []MockExpectation) []int {
+	indices := make([]int, 0, len(expectations))
+	for i := range expectations {
+		if s.Matches(&expectations[i]) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// ProjectTags lists the distinct tags used by the expectations, sorted, with how many
+// expectations carry each. Tags differing only in case count as one.
+func ProjectTags(expectations []MockExpectation) ([]string, map[string]int) {
+	var tags []string
+	counts := map[string]int{}
+	canonical := map[string]string{}
+	for _, exp := range expectations {
+		seen := map[string]bool{}
+		for _, tag := range exp.Tags {
+			key := strings.ToLower(strings.TrimSpace(tag))
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			name, ok := canonical[key]
+			if !ok {
+				name = strings.TrimSpace(tag)
+				canonical[key] = name
+				tags = append(tags, name)
+			}
+			counts[name]++
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool { return strings.ToLower(tags[i]) < strings.ToLower(tags[j]) })
+	return tags, counts
+}
+
+// NormalizeTags trims tags and drops empty and case-insensitive duplicates, keeping the order
+func NormalizeTags(tags []string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		key := strings.ToLower(tag)
+		if tag == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, tag)
+	}
+	return out
+}
+
+// String renders the selection for deploy and status output
+func (s TagSelection) String() string {
+	var parts []string
+	if len(s.Only) > 0 {
+		parts = append(parts, "only "+strings.Join(s.Only, ", "))
+	}
+	if len(s.Exclude) > 0 {
+		parts = append(parts, "excluding "+strings.Join(s.Exclude, ", "))
+	}
+	if len(parts) == 0 {
+		return "all expectations"
+	}
+	return strings.Join(parts, "; ")
+}
+
+// ApplyTagSelection restores expectations withheld by a previous selection, then withholds the
+// ones sel does not serve. Withheld expectations stay in the stored configuration, so a later
+// deploy without a selection brings them back in their original order. It returns the number
+// of expectations withheld.
+func (c *MockConfiguration) ApplyTagSelection(sel TagSelection) int {
+	c.RestoreWithheld()
+	if sel.IsEmpty() {
+		c.Metadata.Selection = nil
+		return 0
+	}
+	served := make([]MockExpectation, 0, len(c.Expectations))
+	for i, exp := range c.Expectations {
+		if sel.Includes(&exp) {
+			served = append(served, exp)
+		} else {
+			c.Withheld = append(c.Withheld, WithheldExpectation{Position: i, Expectation: exp})
+		}
+	}
+	c.Expectations = served
+	c.Metadata.Selection = &sel
+	return len(c.Withheld)
+}
+
+// RestoreWithheld puts withheld expectations back at their original positions
+func (c *MockConfiguration) RestoreWithheld() {
+	if len(c.Withheld) == 0 {
+		return
+	}
+	sort.SliceStable(c.Withheld, func(i, j int) bool { return c.Withheld[i].Position < c.Withheld[j].Position })
+	all := make([]MockExpectation, 0, len(c.Expectations)+len(c.Withheld))
+	served := c.Expectations
+	for _, w := range c.Withheld {
+		for len(all) < w.Position && len(served) > 0 {
+			all = append(all, served[0])
+			served = served[1:]
+		}
+		all = append(all, w.Expectation)
+	}
+	c.Expectations = append(all, served...)
+	c.Withheld = nil
+}
+
+func hasAnyTag(tags, wanted []string) bool {
+	for _, w := range wanted {
+		for _, t := range tags {
+			if strings.EqualFold(strings.TrimSpace(w), t) {
+				return true
+			}
+		}
+	}
+	return false
+}
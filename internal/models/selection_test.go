@@ -0,0 +1,55 @@
+package models
+
+import "testing"
+
+func TestApplyTagSelection_WithholdsAndRestoresInOrder(t *testing.T) {
+	exp := func(path string, tags ...string) MockExpectation {
+		return MockExpectation{HttpRequest: &HttpRequest{Method: "GET", Path: path}, HttpResponse: &HttpResponse{StatusCode: 200}, Tags: tags}
+	}
+	cfg := &MockConfiguration{Expectations: []MockExpectation{
+		exp("/a"), exp("/b", "v2-preview"), exp("/c", "v2-preview", "flaky"), exp(ContractInfoPath), exp("/d"),
+	}}
+
+	withheld := cfg.ApplyTagSelection(TagSelection{Only: []string{"V2-Preview"}, Exclude: []string{"flaky"}})
+	if withheld != 3 || len(cfg.Expectations) != 2 || cfg.Expectations[0].HttpRequest.Path != "/b" {
+		t.Fatalf("withheld %d, served %+v", withheld, cfg.Expectations)
+	}
+	if cfg.Metadata.Selection == nil || cfg.Metadata.Selection.String() != "only V2-Preview; excluding flaky" {
+		t.Fatalf("selection = %v", cfg.Metadata.Selection)
+	}
+
+	if withheld := cfg.ApplyTagSelection(TagSelection{}); withheld != 0 || cfg.Metadata.Selection != nil || cfg.Withheld != nil {
+		t.Fatalf("clearing the selection left %d withheld", withheld)
+	}
+	var paths []string
+	for _, e := range cfg.Expectations {
+		paths = append(paths, e.HttpRequest.Path)
+	}
+	if got := len(paths); got != 5 || paths[0] != "/a" || paths[2] != "/c" || paths[4] != "/d" {
+		t.Fatalf("restored order = %v", paths)
+	}
+}
+
+func TestProjectTagsAndIndices(t *testing.T) {
+	exps := []MockExpectation{
+		{HttpRequest: &HttpRequest{Path: "/login"}, Tags: []string{"auth", " Auth"}},
+		{HttpRequest: &HttpRequest{Path: "/users"}},
+		{HttpRequest: &HttpRequest{Path: "/users/404"}, Tags: []string{"error-cases", "AUTH"}},
+		{HttpRequest: &HttpRequest{Path: ContractInfoPath}},
+	}
+	tags, counts := ProjectTags(exps)
+	if len(tags) != 2 || tags[0] != "auth" || tags[1] != "error-cases" || counts["auth"] != 2 || counts["error-cases"] != 1 {
+		t.Fatalf("ProjectTags = %v %v", tags, counts)
+	}
+
+	if got := (TagSelection{Only: []string{"auth"}, Exclude: []string{"error-cases"}}).Indices(exps); len(got) != 1 || got[0] != 0 {
+		t.Errorf("Indices = %v, want [0] (the info endpoint is not special-cased)", got)
+	}
+	if got := (TagSelection{}).Indices(exps); len(got) != 4 {
+		t.Errorf("an empty selection matched %d of 4", len(got))
+	}
+
+	if got := NormalizeTags([]string{" auth", "", "Auth", "slow "}); len(got) != 2 || got[0] != "auth" || got[1] != "slow" {
+		t.Errorf("NormalizeTags = %q", got)
+	}
+}
@@ -0,0 +1,190 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Streaming endpoints: an expectation with a Stream answers with a WebSocket message exchange
+// or a Server-Sent Events sequence instead of a single response. automock serve holds the
+// connection and plays the stream. MockServer cannot, so ApplyStreams gives each stream a
+// static fallback response for deployed mocks: the whole event sequence at once for SSE, and
+// 501 Not Implemented for a WebSocket handshake.
+
+// Stream types
+const (
+	StreamSSE       = "sse"
+	StreamWebSocket = "websocket"
+)
+
+// StreamTypes lists the stream types in the order the editor offers them
+var StreamTypes = []string{StreamSSE, StreamWebSocket}
+
+// Stream is the message sequence of a streaming endpoint
+type Stream struct {
+	Type     string          `json:"type"` // sse or websocket
+	Messages []StreamMessage `json:"messages,omitempty"`
+	Interval *Delay          `json:"interval,omitempty"` // Wait before each message that has no delay of its own
+	Repeat   bool            `json:"repeat,omitempty"`   // Restart the sequence after its last message
+	Close    *StreamClose    `json:"close,omitempty"`    // nil keeps the connection open until the client leaves
+}
+
+// StreamMessage is one server message. Messages with Reply answer matching WebSocket client
+// messages; the others form the sequence sent after connecting.
+type StreamMessage struct {
+	Data  any    `json:"data"`            // Text, or any other JSON value sent serialized
+	Delay *Delay `json:"delay,omitempty"` // Wait before sending; overrides the stream interval
+	Event string `json:"event,omitempty"` // SSE event type
+	ID    string `json:"id,omitempty"`    // SSE event ID
+	Reply string `json:"reply,omitempty"` // WebSocket: regex of the client messages this answers
+}
+
+// StreamClose is when the server ends the stream
+type StreamClose struct {
+	After     *Delay `json:"after,omitempty"`     // Wait after the sequence, then close
+	OnMessage string `json:"onMessage,omitempty"` // WebSocket: close when a client message matches this regex
+	Code      int    `json:"code,omitempty"`      // WebSocket close status (default 1000)
+	Reason    string `json:"reason,omitempty"`    // WebSocket close reason
+}
+
+// Validate checks a stream definition
+func (s *Stream) Validate() error {
+	if s.Type != StreamSSE && s.Type != StreamWebSocket {
+		return fmt.Errorf("stream type must be %s, got %q", strings.Join(StreamTypes, " or "), s.Type)
+	}
+	if len(s.Messages) == 0 && s.Type == StreamSSE {
+		return fmt.Errorf("an SSE stream needs at least one event")
+	}
+	for i, m := range s.Messages {
+		if m.Reply == "" {
+			continue
+		}
+		if s.Type == StreamSSE {
+			return fmt.Errorf("message %d: SSE clients cannot send messages to reply to", i+1)
+		}
+		if _, err := regexp.Compile(m.Reply); err != nil {
+			return fmt.Errorf("message %d: invalid reply pattern: %w", i+1, err)
+		}
+	}
+	if s.Close == nil {
+		return nil
+	}
+	if s.Close.After != nil && s.Repeat {
+		return fmt.Errorf("a repeating stream never finishes its sequence, so it cannot close after it")
+	}
+	if s.Close.OnMessage != "" {
+		if s.Type == StreamSSE {
+			return fmt.Errorf("SSE clients cannot send the message a stream closes on")
+		}
+		if _, err := regexp.Compile(s.Close.OnMessage); err != nil {
+			return fmt.Errorf("invalid close pattern: %w", err)
+		}
+	}
+	if c := s.Close.Code; c != 0 && (c < 1000 || c > 4999 || c == 1005 || c == 1006 || c == 1015) {
+		return fmt.Errorf("close code %d cannot be sent (use 1000-4999, except 1005, 1006 and 1015)", c)
+	}
+	return nil
+}
+
+// Sequence returns the messages sent after connecting, in order
+func (s *Stream) Sequence() []StreamMessage {
+	var seq []StreamMessage
+	for _, m := range s.Messages {
+		if m.Reply == "" {
+			seq = append(seq, m)
+		}
+	}
+	return seq
+}
+
+// RepliesTo returns the messages answering a WebSocket client message
+func (s *Stream) RepliesTo(msg string) []StreamMessage {
+	var replies []StreamMessage
+	for _, m := range s.Messages {
+		if m.Reply != "" && matchesPattern(m.Reply, msg) {
+			replies = append(replies, m)
+		}
+	}
+	return replies
+}
+
+// ClosesOn reports whether a WebSocket client message ends the stream
+func (s *Stream) ClosesOn(msg string) bool {
+	return s.Close != nil && s.Close.OnMessage != "" && matchesPattern(s.Close.OnMessage, msg)
+}
+
+// Text is the message payload as sent: strings as they are, other values as JSON
+func (m StreamMessage) Text() string {
+	if s, ok := m.Data.(string); ok {
+		return s
+	}
+	out, _ := json.Marshal(m.Data)
+	return string(out)
+}
+
+// SSEEvent formats the message as a text/event-stream event
+func (m StreamMessage) SSEEvent() string {
+	var b strings.Builder
+	if m.ID != "" {
+		fmt.Fprintf(&b, "id: %s\n", m.ID)
+	}
+	if m.Event != "" {
+		fmt.Fprintf(&b, "event: %s\n", m.Event)
+	}
+	for _, line := range strings.Split(m.Text(), "\n") {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// StreamFallback is the static response MockServer serves for a streaming expectation
+func StreamFallback(s *Stream) *HttpResponse {
+	if s.Type == StreamWebSocket {
+		return &HttpResponse{
+			StatusCode: 501,
+			Headers:    []NameValues{{Name: "Content-Type", Values: []string{"text/plain; charset=utf-8"}}},
+			Body:       "WebSocket mocks are served by 'automock serve'",
+		}
+	}
+	var b strings.Builder
+	for _, m := range s.Sequence() {
+		b.WriteString(m.SSEEvent())
+	}
+	return &HttpResponse{
+		StatusCode: 200,
+		Headers: []NameValues{
+			{Name: "Content-Type", Values: []string{"text/event-stream"}},
+			{Name: "Cache-Control", Values: []string{"no-cache"}},
+		},
+		Body: b.String(),
+	}
+}
+
+// ApplyStreams validates every streaming expectation and refreshes its static fallback
+// response. It returns the number of streaming expectations.
+func (c *MockConfiguration) ApplyStreams() (int, error) {
+	streams := 0
+	for i := range c.Expectations {
+		exp := &c.Expectations[i]
+		if exp.Stream == nil {
+			continue
+		}
+		if err := exp.Stream.Validate(); err != nil {
+			return streams, ValidationError{Field: fmt.Sprintf("expectations[%d].stream", i), Message: err.Error()}
+		}
+		if exp.Stream.Type == StreamWebSocket && exp.HttpRequest != nil && !strings.EqualFold(exp.HttpRequest.Method, "GET") {
+			return streams, ValidationError{Field: fmt.Sprintf("expectations[%d].stream", i), Message: "a WebSocket handshake is a GET request"}
+		}
+		exp.HttpResponse = StreamFallback(exp.Stream)
+		streams++
+	}
+	return streams, nil
+}
+
+func matchesPattern(pattern, s string) bool {
+	re, err := regexp.Compile(pattern)
+	return err == nil && re.MatchString(s)
+}
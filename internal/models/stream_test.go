@@ -0,0 +1,64 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStreamFallbackAndValidation(t *testing.T) {
+	cfg := &MockConfiguration{Expectations: []MockExpectation{
+		{
+			HttpRequest: &HttpRequest{Method: "GET", Path: "/events"},
+			Stream: &Stream{Type: StreamSSE, Messages: []StreamMessage{
+				{Data: "line one\nline two", Event: "note", ID: "1"},
+				{Data: map[string]any{"n": 2}},
+			}},
+		},
+		{
+			HttpRequest: &HttpRequest{Method: "GET", Path: "/ws"},
+			Stream: &Stream{Type: StreamWebSocket, Messages: []StreamMessage{
+				{Data: "hello"},
+				{Data: "pong", Reply: `^ping$`},
+			}},
+		},
+	}}
+	n, err := cfg.ApplyStreams()
+	if err != nil || n != 2 {
+		t.Fatalf("ApplyStreams = %d, %v", n, err)
+	}
+	sse := cfg.Expectations[0].HttpResponse
+	want := "id: 1\nevent: note\ndata: line one\ndata: line two\n\ndata: {\"n\":2}\n\n"
+	if sse.StatusCode != 200 || sse.Body != want || !hasHeader(sse.Headers, "Content-Type") {
+		t.Errorf("SSE fallback = %d %q", sse.StatusCode, sse.Body)
+	}
+	if cfg.Expectations[1].HttpResponse.StatusCode != 501 {
+		t.Errorf("WebSocket fallback status = %d", cfg.Expectations[1].HttpResponse.StatusCode)
+	}
+
+	ws := cfg.Expectations[1].Stream
+	if len(ws.Sequence()) != 1 || len(ws.RepliesTo("ping")) != 1 || len(ws.RepliesTo("pingpong")) != 0 {
+		t.Errorf("sequence/replies split wrong: %+v", ws.Messages)
+	}
+
+	invalid := map[string]*Stream{
+		"empty sse":       {Type: StreamSSE},
+		"sse reply":       {Type: StreamSSE, Messages: []StreamMessage{{Data: "x", Reply: "a"}}},
+		"bad pattern":     {Type: StreamWebSocket, Messages: []StreamMessage{{Data: "x", Reply: "("}}},
+		"repeat and wait": {Type: StreamWebSocket, Repeat: true, Close: &StreamClose{After: &Delay{TimeUnit: "SECONDS", Value: 1}}},
+		"reserved code":   {Type: StreamWebSocket, Close: &StreamClose{Code: 1006}},
+		"unknown type":    {Type: "grpc"},
+	}
+	for name, s := range invalid {
+		if err := s.Validate(); err == nil {
+			t.Errorf("%s: expected a validation error", name)
+		}
+	}
+
+	post := &MockConfiguration{Expectations: []MockExpectation{{
+		HttpRequest: &HttpRequest{Method: "POST", Path: "/ws"},
+		Stream:      &Stream{Type: StreamWebSocket},
+	}}}
+	if _, err := post.ApplyStreams(); err == nil || !strings.Contains(err.Error(), "GET") {
+		t.Errorf("POST WebSocket handshake accepted: %v", err)
+	}
+}
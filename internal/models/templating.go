@@ -0,0 +1,488 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Request references: ${request.path.id}, ${request.query.page}, ${request.headers.X-Request-Id}
+// and ${request.body.customer.name} in a response body, header or cookie echo the matching
+// request value; ${request.path} and ${request.method} echo the path and method. A default
+// follows a colon, e.g. ${request.query.limit:20}. The $!request... Velocity placeholders of
+// the generated response templates are read the same way. Expectations bound to a dataset
+// may also reference it: ${dataset.rows}, ${dataset.row.name}, ... (see DatasetBinding).
+//
+// ApplyRequestTemplates compiles such a response into a JavaScript response template (run by
+// MockServer, and by automock serve). The static response and the dataset stay the source:
+// the template is regenerated on every save.
+
+// requestTemplateHeader starts generated templates, so a save can tell them from user scripts
+const requestTemplateHeader = "// automock: response echoing the request\n"
+
+var (
+	// requestReference is the syntax the generated template resolves; keep it in sync with
+	// the reference regex of requestTemplate
+	requestReference = regexp.MustCompile(`\$\{request\.([A-Za-z]+)(?:\.([^}:]*))?(?::([^}]*))?\}`)
+	// anyRequestReference finds everything written like a reference, so typos are reported
+	anyRequestReference      = regexp.MustCompile(`\$\{request\b[^}]*\}?`)
+	datasetReference         = regexp.MustCompile(`\$\{dataset\b`)
+	velocityRequestReference = regexp.MustCompile(`\$!request\.(?:(headers|pathParameters|queryStringParameters)\['([^']+)'\]\[0\]|(path|method)\b)`)
+	bodyIndex                = regexp.MustCompile(`\[(\d+)\]`)
+	requestSources           = map[string]string{
+		"headers":               "headers",
+		"pathParameters":        "path",
+		"queryStringParameters": "query",
+	}
+)
+
+// IsRequestTemplate reports whether a response template was generated by ApplyRequestTemplates
+func IsRequestTemplate(t *HttpTemplate) bool {
+	return t != nil && strings.HasPrefix(t.Template, requestTemplateHeader)
+}
+
+// HasRequestReferences reports whether a static response echoes request values
+func HasRequestReferences(resp *HttpResponse) bool {
+	if resp == nil {
+		return false
+	}
+	raw, _ := json.Marshal([]any{resp.Body, resp.Headers, resp.Cookies})
+	return anyRequestReference.Match(raw) || velocityRequestReference.Match(raw) || datasetReference.Match(raw)
+}
+
+// ApplyRequestTemplates regenerates the response template of every expectation whose static
+// response references the request or that is bound to a dataset, and drops generated templates
+// whose expectation no longer needs one. Features that need a static body (ETags, ranges,
+// negotiation, GraphQL errors, faults) do not apply to these responses, so run it before them.
+// It returns the number of templates generated.
+func (c *MockConfiguration) ApplyRequestTemplates() (int, error) {
+	generated := 0
+	for i := range c.Expectations {
+		exp := &c.Expectations[i]
+		if exp.Generated() && exp.Tenant == "" && exp.RuleVariant == "" {
+			continue // tenant and rule responses are written by hand, so they may echo the request too
+		}
+		if IsRequestTemplate(exp.HttpResponseTemplate) {
+			// Read from MockServer JSON, the template is all there is of the response (and of the
+			// dataset, which MockServer JSON does not carry)
+			resp := exp.HttpResponse
+			empty := resp == nil || resp.Body == nil && len(resp.Headers) == 0 && len(resp.Cookies) == 0
+			if empty && (exp.Dataset == nil || c.FindDataset(exp.Dataset.Name) < 0) {
+				continue
+			}
+			exp.HttpResponseTemplate = nil
+		}
+		if exp.HttpResponseTemplate != nil || exp.Forward != nil || exp.HttpError != nil || exp.Stream != nil ||
+			exp.Dataset == nil && !HasRequestReferences(exp.HttpResponse) {
+			continue
+		}
+		var dataset map[string]any
+		if exp.Dataset != nil {
+			var err error
+			if dataset, err = c.datasetSpec(*exp); err != nil {
+				return generated, ValidationError{Field: fmt.Sprintf("expectations[%d].dataset", i), Message: err.Error()}
+			}
+		}
+		tmpl, err := compileRequestTemplate(*exp, dataset)
+		if err != nil {
+			return generated, ValidationError{Field: fmt.Sprintf("expectations[%d].httpResponse", i), Message: err.Error()}
+		}
+		exp.HttpResponseTemplate = tmpl
+		generated++
+	}
+	return generated, nil
+}
+
+// RequestTemplate compiles exp's static response into a template that fills in its request
+// references; it fails on references that cannot be resolved
+func RequestTemplate(exp MockExpectation) (*HttpTemplate, error) {
+	return compileRequestTemplate(exp, nil)
+}
+
+// compileRequestTemplate compiles the response, serving the rows of dataset (a datasetSpec) if set
+func compileRequestTemplate(exp MockExpectation, dataset map[string]any) (*HttpTemplate, error) {
+	resp := exp.HttpResponse
+	if resp == nil {
+		resp = &HttpResponse{}
+	}
+	path := ""
+	if exp.HttpRequest != nil {
+		path = exp.HttpRequest.Path
+	}
+	pathNames, pathPattern := pathTemplate(path)
+	refs := &referenceRewriter{path: path, pathNames: pathNames}
+	if dataset != nil {
+		refs.datasetMode = dataset["mode"].(string)
+	}
+
+	status := resp.StatusCode
+	if status == 0 {
+		status = 200
+	}
+	headers := map[string][]string{}
+	for _, h := range resp.Headers {
+		if !strings.EqualFold(h.Name, "Content-Length") {
+			headers[h.Name] = append(headers[h.Name], refs.all(h.Values)...)
+		}
+	}
+	for _, c := range resp.Cookies {
+		for _, v := range refs.all(c.Values) {
+			headers["Set-Cookie"] = append(headers["Set-Cookie"], c.Name+"="+v)
+		}
+	}
+	body, isJSON := templateBody(resp.Body)
+	if contentType, _, _ := bodyText(resp.Body); contentType != "" && !hasHeader(resp.Headers, "Content-Type") {
+		headers["Content-Type"] = []string{contentType}
+	}
+	if body == nil && dataset != nil {
+		paging, _ := dataset["paging"].(string)
+		body, isJSON = datasetDefaultBody(refs.datasetMode, paging), true
+		if !hasHeader(resp.Headers, "Content-Type") {
+			headers["Content-Type"] = []string{"application/json"}
+		}
+	}
+	spec := map[string]any{"statusCode": status, "headers": headers, "json": isJSON}
+	if body != nil {
+		spec["body"] = refs.value(body)
+	}
+	if dataset != nil {
+		spec["dataset"] = dataset
+	}
+	if resp.Delay != nil {
+		spec["delay"] = resp.Delay
+	}
+	if refs.err != nil {
+		return nil, refs.err
+	}
+
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("response is not serializable: %w", err)
+	}
+	namesJSON, _ := json.Marshal(pathNames)
+	patternJSON, _ := json.Marshal(pathPattern)
+	return &HttpTemplate{
+		TemplateType: TemplateJavaScript,
+		Template:     requestTemplateHeader + fmt.Sprintf(requestTemplate, specJSON, namesJSON, patternJSON),
+	}, nil
+}
+
+// templateBody decodes the body the template renders; JSON bodies are rendered as values so
+// a lone reference keeps the type of the request value
+func templateBody(body any) (any, bool) {
+	switch b := body.(type) {
+	case nil:
+		return nil, false
+	case string:
+		var v any
+		if json.Unmarshal([]byte(b), &v) == nil {
+			switch v.(type) {
+			case map[string]any, []any:
+				return v, true
+			}
+		}
+		return b, false
+	case map[string]any:
+		switch strings.ToUpper(fmt.Sprint(b["type"])) {
+		case "JSON":
+			if s, ok := b["json"].(string); ok {
+				return templateBody(s)
+			}
+			return b["json"], true
+		case "STRING":
+			return b["string"], false
+		case "XML":
+			return b["xml"], false
+		}
+		if _, typed := b["type"]; typed {
+			return body, false // BINARY and matchers have nothing to fill in
+		}
+	}
+	return body, true
+}
+
+// pathTemplate returns the parameter names of a /users/{id} path and the regex capturing them
+func pathTemplate(path string) ([]string, string) {
+	names := []string{}
+	if !strings.Contains(path, "{") {
+		return names, ""
+	}
+	pattern := regexp.QuoteMeta(path)
+	for _, m := range pathParamName.FindAllStringSubmatch(path, -1) {
+		names = append(names, m[1])
+		pattern = strings.Replace(pattern, regexp.QuoteMeta(m[0]), "([^/]+)", 1)
+	}
+	return names, "^" + pattern + "$"
+}
+
+var pathParamName = regexp.MustCompile(`\{([^/{}]+)\}`)
+
+// referenceRewriter checks the references of a response and writes them the way the template
+// reads them; the first problem is kept in err
+type referenceRewriter struct {
+	path        string
+	pathNames   []string
+	datasetMode string // mode of the bound dataset; "" when there is none
+	err         error
+}
+
+func (r *referenceRewriter) all(values []string) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = r.text(v)
+	}
+	return out
+}
+
+func (r *referenceRewriter) value(v any) any {
+	switch t := v.(type) {
+	case string:
+		return r.text(t)
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for k, item := range t {
+			out[k] = r.value(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(t))
+		for i, item := range t {
+			out[i] = r.value(item)
+		}
+		return out
+	}
+	return v
+}
+
+func (r *referenceRewriter) text(s string) string {
+	s = velocityRequestReference.ReplaceAllStringFunc(s, func(ref string) string {
+		m := velocityRequestReference.FindStringSubmatch(ref)
+		if m[3] != "" {
+			return "${request." + m[3] + "}"
+		}
+		return fmt.Sprintf("${request.%s.%s}", requestSources[m[1]], m[2])
+	})
+	s = datasetReference.ReplaceAllLiteralString(s, "${request.dataset")
+	return anyRequestReference.ReplaceAllStringFunc(s, func(ref string) string {
+		canonical, err := r.reference(ref)
+		if err != nil && r.err == nil {
+			r.err = err
+		}
+		return canonical
+	})
+}
+
+// reference validates one ${request...} reference and normalizes body paths to a.0.b
+func (r *referenceRewriter) reference(ref string) (string, error) {
+	m := requestReference.FindStringSubmatch(ref)
+	if m == nil || m[0] != ref {
+		if len(ref) > 40 {
+			ref = ref[:40] + "..."
+		}
+		return ref, fmt.Errorf("malformed request reference %s: use ${request.<source>.<name>}", ref)
+	}
+	source, name := m[1], m[2]
+	switch source {
+	case "method":
+		if name != "" {
+			return ref, fmt.Errorf("%s: method takes no name", ref)
+		}
+	case "query", "headers":
+		if name == "" {
+			return ref, fmt.Errorf("%s needs a name, e.g. ${request.%s.%s}", ref, source, map[string]string{"query": "page", "headers": "X-Request-Id"}[source])
+		}
+	case "path":
+		if name != "" && !contains(r.pathNames, name) {
+			return ref, fmt.Errorf("%s needs {%s} in the request path %q", ref, name, r.path)
+		}
+	case "body":
+		normalized := strings.TrimPrefix(bodyIndex.ReplaceAllString(name, ".$1"), ".")
+		if strings.Contains(normalized, "..") || strings.HasSuffix(normalized, ".") {
+			return ref, fmt.Errorf("%s: malformed body field path", ref)
+		}
+		if normalized != name {
+			ref = "${request.body." + normalized + strings.TrimPrefix(ref, "${request.body."+name)
+		}
+	case "dataset":
+		if normalized := bodyIndex.ReplaceAllString(name, ".$1"); normalized != name {
+			ref = "${request.dataset." + normalized + strings.TrimPrefix(ref, "${request.dataset."+name)
+			name = normalized
+		}
+		display := "${dataset" + strings.TrimPrefix(ref, "${request.dataset")
+		field, _, _ := strings.Cut(name, ".")
+		switch {
+		case r.datasetMode == "":
+			return display, fmt.Errorf("%s: the expectation is not bound to a dataset", display)
+		case r.datasetMode == DatasetItem && field != "row":
+			return display, fmt.Errorf("%s: item endpoints serve ${dataset.row} or ${dataset.row.<field>}", display)
+		case r.datasetMode == DatasetList && (name != field || !contains(datasetListValues, field)):
+			return display, fmt.Errorf("%s: list endpoints serve ${dataset.<name>} for %s", display, strings.Join(datasetListValues, ", "))
+		}
+	default:
+		return ref, fmt.Errorf("%s: unknown request source %q (use method, path, query, headers or body)", ref, source)
+	}
+	return ref, nil
+}
+
+// datasetListValues are the ${dataset.<name>} references of list endpoints
+var datasetListValues = []string{"rows", "total", "page", "size", "pages", "offset", "nextCursor", "next", "prev"}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// requestTemplate is ES5 so it runs on MockServer's JavaScript engine as well as locally
+const requestTemplate = `var response = %s;
+var pathNames = %s, pathPattern = %s;
+var reference = /\$\{request\.([A-Za-z]+)(?:\.([^}:]*))?(?::([^}]*))?\}/g;
+var whole = new RegExp('^' + reference.source + '$');
+var parsed = {};
+function first(values, name, fold) {
+  for (var key in values || {}) {
+    if (key === name || (fold && key.toLowerCase() === name.toLowerCase())) {
+      var v = values[key];
+      return typeof v === 'string' ? v : v && v.length ? String(v[0]) : undefined;
+    }
+  }
+}
+function pathParameter(name) {
+  var m = pathPattern ? new RegExp(pathPattern).exec(request.path) : null;
+  var i = pathNames.indexOf(name);
+  if (!m || i < 0) return undefined;
+  try { return decodeURIComponent(m[i + 1]); } catch (e) { return m[i + 1]; }
+}
+function body() {
+  if (!('value' in parsed)) {
+    var b = request.body;
+    if (b && typeof b === 'object' && 'json' in b) b = b.json;
+    else if (b && typeof b === 'object' && 'string' in b) b = b.string;
+    if (typeof b === 'string') { try { b = JSON.parse(b); } catch (e) {} }
+    parsed.value = b === undefined || b === '' ? undefined : b;
+  }
+  return parsed.value;
+}
+// link is the request URL with the query parameter param set to value, or removed when null
+function link(param, value) {
+  var q = request.queryStringParameters || {}, names = [param], parts = [];
+  for (var k in q) if (k !== param) names.push(k);
+  names.sort();
+  for (var n = 0; n < names.length; n++) {
+    var values = names[n] === param ? (value === null ? [] : [value]) : typeof q[names[n]] === 'string' ? [q[names[n]]] : q[names[n]];
+    for (var i = 0; i < values.length; i++) parts.push(encodeURIComponent(names[n]) + '=' + encodeURIComponent(values[i]));
+  }
+  return request.path + (parts.length ? '?' + parts.join('&') : '');
+}
+function selectRows(d) {
+  var i, k, rows = [], q = request.queryStringParameters;
+  if (d.mode === 'item') {
+    var id = pathParameter(d.param);
+    for (i = 0; i < d.rows.length; i++) {
+      if (d.rows[i][d.key] !== undefined && String(d.rows[i][d.key]) === id) return {row: d.rows[i]};
+    }
+    return {failure: [404, 'not found', 'no ' + d.key + ' ' + id]};
+  }
+  var fields = {}, filters = [];
+  for (i = 0; i < d.rows.length; i++) for (k in d.rows[i]) fields[k] = true;
+  for (k in q || {}) {
+    if (fields[k] && k !== d.pageParam && k !== d.sizeParam) filters.push([k, first(q, k, false)]);
+  }
+  for (i = 0; i < d.rows.length; i++) {
+    var keep = true;
+    for (var f = 0; f < filters.length; f++) {
+      var v = d.rows[i][filters[f][0]];
+      if (v === undefined || v === null || text(v) !== filters[f][1]) keep = false;
+    }
+    if (keep) rows.push(d.rows[i]);
+  }
+  // Cursors are the key of the last row served, or the number of rows served without a key
+  function cursorOf(n) {
+    return d.key ? text(rows[n - 1][d.key]) : String(n);
+  }
+  var size = parseInt(first(q, d.sizeParam, false), 10);
+  if (!(size > 0)) size = d.pageSize;
+  var at = first(q, d.pageParam, false), start = 0;
+  if (d.paging === 'offset') {
+    start = Math.max(parseInt(at, 10) || 0, 0);
+  } else if (d.paging === 'cursor' && at) {
+    for (start = rows.length; start > 0 && cursorOf(start) !== at; start--) {}
+    if (start === 0) return {failure: [400, 'bad request', 'unknown ' + d.pageParam + ' ' + at]};
+  } else if (d.paging !== 'cursor') {
+    start = (Math.max(parseInt(at, 10) || 1, 1) - 1) * size;
+  }
+  var end = Math.min(start + size, rows.length), back = Math.max(start - size, 0);
+  var out = {rows: rows.slice(start, end), total: rows.length, page: Math.floor(start / size) + 1, size: size,
+    pages: Math.ceil(rows.length / size), offset: start, nextCursor: null, next: null, prev: null};
+  if (end < rows.length) {
+    out.nextCursor = cursorOf(end);
+    out.next = link(d.pageParam, d.paging === 'offset' ? String(end) : d.paging === 'cursor' ? out.nextCursor : String(out.page + 1));
+  }
+  if (start > 0) {
+    out.prev = link(d.pageParam, d.paging === 'offset' ? String(back) : d.paging === 'cursor' ? (back ? cursorOf(back) : null) : String(out.page - 1));
+  }
+  return out;
+}
+var dataset = response.dataset ? selectRows(response.dataset) : undefined;
+if (dataset && dataset.failure) {
+  return {statusCode: dataset.failure[0], headers: {'Content-Type': ['application/json']},
+    body: JSON.stringify({error: dataset.failure[1], message: dataset.failure[2]})};
+}
+function lookup(source, name) {
+  if (source === 'method') return request.method;
+  if (source === 'path') return name ? pathParameter(name) : request.path;
+  if (source === 'query') return first(request.queryStringParameters, name, false);
+  if (source === 'headers') return first(request.headers, name, true);
+  var v = source === 'dataset' ? dataset : body();
+  var parts = name ? name.split('.') : [];
+  for (var i = 0; i < parts.length; i++) {
+    if (v === null || typeof v !== 'object') return undefined;
+    v = v[parts[i]];
+  }
+  return v;
+}
+function text(v) {
+  return v !== null && typeof v === 'object' ? JSON.stringify(v) : String(v);
+}
+function render(v, typed) {
+  if (typeof v === 'string') {
+    var m = typed ? whole.exec(v) : null;
+    if (m) {
+      var x = lookup(m[1], m[2] || '');
+      return x !== undefined ? x : m[3] != null ? m[3] : null;
+    }
+    return v.replace(reference, function (s, source, name, def) {
+      var x = lookup(source, name || '');
+      return x !== undefined ? text(x) : def || '';
+    });
+  }
+  if (v instanceof Array) {
+    var a = [];
+    for (var i = 0; i < v.length; i++) a.push(render(v[i], typed));
+    return a;
+  }
+  if (v !== null && typeof v === 'object') {
+    var o = {};
+    for (var k in v) o[k] = render(v[k], typed);
+    return o;
+  }
+  return v;
+}
+var out = {statusCode: response.statusCode, headers: render(response.headers, false)};
+if (response.json) out.body = JSON.stringify(render(response.body, true));
+else if (response.body !== undefined) out.body = render(response.body, false);
+if (response.delay) out.delay = response.delay;
+return out;`
+
+// ReplaceRequestReferences rewrites each well-formed ${request...} reference of text with
+// fn(source, name); exporters use it to translate references into their own template syntax
+func ReplaceRequestReferences(text string, fn func(source, name string) string) string {
+	return requestReference.ReplaceAllStringFunc(text, func(ref string) string {
+		m := requestReference.FindStringSubmatch(ref)
+		return fn(m[1], m[2])
+	})
+}
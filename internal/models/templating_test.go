@@ -0,0 +1,99 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func templated(path string, body any) MockExpectation {
+	return MockExpectation{
+		HttpRequest:  &HttpRequest{Method: "GET", Path: path},
+		HttpResponse: &HttpResponse{StatusCode: 200, Body: body},
+	}
+}
+
+func TestApplyRequestTemplatesValidation(t *testing.T) {
+	for body, want := range map[string]string{
+		`{"id": "${request.path.id}"}`:      "needs {id} in the request path",
+		`{"q": "${request.query}"}`:         "needs a name",
+		`{"c": "${request.cookies.sid}"}`:   `unknown request source "cookies"`,
+		`{"m": "${request.method.name}"}`:   "method takes no name",
+		`{"b": "${request.body.a..b}"}`:     "malformed body field path",
+		`{"x": "${request}"}`:               "malformed request reference",
+		`{"x": "${request.query.page"}`:     "malformed request reference",
+		`{"id": "${request.path.userId}"}`:  "",
+		`{"q": "${request.query.q:none}"}`:  "",
+		`{"a": "${request.body.items[0]}"}`: "",
+	} {
+		config := &MockConfiguration{Expectations: []MockExpectation{templated("/users/{userId}", body)}}
+		_, err := config.ApplyRequestTemplates()
+		switch {
+		case want == "" && err != nil:
+			t.Errorf("%s: %v", body, err)
+		case want != "" && (err == nil || !strings.Contains(err.Error(), want)):
+			t.Errorf("%s: err = %v, want %q", body, err, want)
+		}
+	}
+}
+
+func TestApplyRequestTemplatesRegenerates(t *testing.T) {
+	config := &MockConfiguration{Expectations: []MockExpectation{
+		templated("/users/{id}", `{"id": "${request.path.id}", "trace": "${uuid}"}`),
+		templated("/health", `{"status": "ok"}`),
+	}}
+	if _, err := config.ApplyFakeData(); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := config.ApplyRequestTemplates(); err != nil || n != 1 {
+		t.Fatalf("ApplyRequestTemplates = %d, %v", n, err)
+	}
+	exp := config.Expectations[0]
+	if raw, _ := ResponseBytes(exp.HttpResponse.Body); !IsRequestTemplate(exp.HttpResponseTemplate) || !strings.Contains(string(raw), "${request.path.id}") {
+		t.Fatalf("expectation = %+v", exp)
+	}
+	if config.Expectations[1].HttpResponseTemplate != nil {
+		t.Error("a static response must stay static")
+	}
+
+	// Removing the reference drops the generated template on the next save
+	exp.HttpResponse = &HttpResponse{StatusCode: 200, Body: `{"id": "u1"}`}
+	config.Expectations[0] = exp
+	if _, err := config.ApplyRequestTemplates(); err != nil || config.Expectations[0].HttpResponseTemplate != nil {
+		t.Errorf("template kept after the reference was removed: %v", err)
+	}
+
+	// Read back from MockServer JSON, the template is all there is of the response
+	source := templated("/users/{id}", `{"id": "${request.path.id}"}`)
+	tmpl, err := RequestTemplate(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	source.HttpResponseTemplate = tmpl
+	loaded, err := ParseMockServerJSON(ExpectationsToMockServerJSON([]MockExpectation{source}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loaded.ApplyRequestTemplates(); err != nil || !IsRequestTemplate(loaded.Expectations[0].HttpResponseTemplate) {
+		t.Errorf("template read from MockServer JSON dropped: %v", err)
+	}
+}
+
+func TestApplyRequestTemplatesTenants(t *testing.T) {
+	exp := templated("/users/{id}", map[string]any{"id": "${request.path.id}", "plan": "free"})
+	exp.ID = "user"
+	config := &MockConfiguration{
+		Expectations: []MockExpectation{exp},
+		Tenancy: &Tenancy{Header: "X-Tenant", Tenants: []Tenant{
+			{Name: "acme", Overlays: []TenantOverlay{{Expectation: "user", Body: map[string]any{"plan": "enterprise"}}}},
+		}},
+	}
+	if _, err := config.ApplyTenants(); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := config.ApplyRequestTemplates(); err != nil || n != 2 {
+		t.Fatalf("ApplyRequestTemplates = %d, %v", n, err)
+	}
+	if tenant := config.Expectations[0]; tenant.Tenant != "acme" || !IsRequestTemplate(tenant.HttpResponseTemplate) {
+		t.Errorf("tenant response does not echo the request: %+v", tenant)
+	}
+}
@@ -0,0 +1,276 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Tenancy lets one deployment answer per tenant, e.g. with the fixtures of tenant acme to
+// requests sending X-Tenant: acme or addressed to acme.mock.example.com. The expectations are
+// the shared skeleton; each tenant overlays the responses of some of them. ApplyTenants turns
+// every overlay into a companion expectation matching the tenant, placed ahead of its
+// expectation, so requests naming no known tenant get the shared response.
+type Tenancy struct {
+	Header    string   `json:"header,omitempty"`    // Request header naming the tenant, e.g. X-Tenant
+	Subdomain bool     `json:"subdomain,omitempty"` // Else the first label of the Host names it: acme.mock.example.com → acme
+	Tenants   []Tenant `json:"tenants,omitempty"`
+}
+
+// Tenant is the data of one tenant: its responses of some expectations
+type Tenant struct {
+	Name     string          `json:"name"`
+	Overlays []TenantOverlay `json:"overlays"`
+}
+
+// TenantOverlay is a tenant's response of one expectation
+type TenantOverlay struct {
+	Expectation string       `json:"expectation"`          // ID of the expectation, or "METHOD /path" when it has none
+	StatusCode  int          `json:"statusCode,omitempty"` // Default: the shared response's
+	Headers     []NameValues `json:"headers,omitempty"`    // Set over the shared response's headers
+	Body        any          `json:"body,omitempty"`       // A JSON object is merged into a JSON body (null removes a field); anything else replaces it
+}
+
+var tenantName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// ValidTenantName reports whether name can name a tenant: a single word, as in a subdomain
+func ValidTenantName(name string) bool {
+	return tenantName.MatchString(name)
+}
+
+// OverlayKey names an expectation in tenant overlays: its ID, or "METHOD /path"
+func OverlayKey(exp MockExpectation) string {
+	if exp.ID != "" || exp.HttpRequest == nil {
+		return exp.ID
+	}
+	return strings.ToUpper(exp.HttpRequest.Method) + " " + exp.HttpRequest.Path
+}
+
+// Describe summarizes how the tenant is selected, e.g. "header X-Tenant, 2 tenant(s)"
+func (t Tenancy) Describe() string {
+	var by []string
+	if t.Header != "" {
+		by = append(by, "header "+t.Header)
+	}
+	if t.Subdomain {
+		by = append(by, "subdomain")
+	}
+	return fmt.Sprintf("%s, %d tenant(s)", strings.Join(by, " or "), len(t.Tenants))
+}
+
+// FindTenant returns the index of the named tenant, or -1
+func (t Tenancy) FindTenant(name string) int {
+	for i, tenant := range t.Tenants {
+		if tenant.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkTenancy reports what is wrong with the tenant overlays, or ""
+func (c *MockConfiguration) checkTenancy() string {
+	t := c.Tenancy
+	if strings.ContainsAny(t.Header, " :") {
+		return fmt.Sprintf("tenant header %q is not a header name", t.Header)
+	}
+	if strings.TrimSpace(t.Header) == "" && !t.Subdomain {
+		return "tenants are selected by a header, the subdomain or both; set one"
+	}
+	keys := map[string]int{}
+	for _, exp := range c.Expectations {
+		if !exp.Generated() {
+			keys[OverlayKey(exp)]++
+		}
+	}
+	seen := map[string]bool{}
+	for _, tenant := range t.Tenants {
+		switch {
+		case !ValidTenantName(tenant.Name):
+			return fmt.Sprintf("tenant name %q must be a single word, e.g. acme", tenant.Name)
+		case seen[tenant.Name]:
+			return fmt.Sprintf("tenant %s is listed twice", tenant.Name)
+		}
+		seen[tenant.Name] = true
+		overlaid := map[string]bool{}
+		for _, o := range tenant.Overlays {
+			switch {
+			case keys[o.Expectation] == 0:
+				return fmt.Sprintf("tenant %s overlays unknown expectation %q", tenant.Name, o.Expectation)
+			case keys[o.Expectation] > 1:
+				return fmt.Sprintf("tenant %s: several expectations answer %s; give them IDs to overlay one", tenant.Name, o.Expectation)
+			case overlaid[o.Expectation]:
+				return fmt.Sprintf("tenant %s overlays %s twice", tenant.Name, o.Expectation)
+			}
+			overlaid[o.Expectation] = true
+		}
+	}
+	return ""
+}
+
+// ApplyTenants regenerates the tenant companions of the expectations the tenants overlay: one
+// matching the tenant header and one matching the subdomain, in that order, so the header wins
+// when both are sent. Companions serve their response as written, so run it before the features
+// deriving companions from the shared response (negotiation, conditional GET, ranges). It
+// returns the number of companions generated.
+func (c *MockConfiguration) ApplyTenants() (int, error) {
+	out := make([]MockExpectation, 0, len(c.Expectations))
+	generated := 0
+	if c.Tenancy != nil {
+		if msg := c.checkTenancy(); msg != "" {
+			return generated, ValidationError{Field: "tenancy", Message: msg}
+		}
+	}
+	for i, exp := range c.Expectations {
+		if exp.Tenant != "" {
+			continue // regenerated below from the expectation it overlays
+		}
+		if c.Tenancy == nil || exp.Generated() || exp.HttpRequest == nil {
+			out = append(out, exp)
+			continue
+		}
+		if exp.Forward != nil && c.Tenancy.overlays(OverlayKey(exp)) {
+			return generated, ValidationError{Field: fmt.Sprintf("expectations[%d]", i), Message: "tenants cannot overlay a forwarded request"}
+		}
+		var byHost []MockExpectation
+		for _, tenant := range c.Tenancy.Tenants {
+			for _, o := range tenant.Overlays {
+				if o.Expectation != OverlayKey(exp) {
+					continue
+				}
+				if c.Tenancy.Header != "" {
+					out = append(out, TenantExpectation(exp, tenant.Name, o, c.Tenancy.Header, regexp.QuoteMeta(tenant.Name)))
+					generated++
+				}
+				if c.Tenancy.Subdomain {
+					byHost = append(byHost, TenantExpectation(exp, tenant.Name, o, "Host", regexp.QuoteMeta(tenant.Name)+`\..+`))
+					generated++
+				}
+			}
+		}
+		out = append(append(out, byHost...), exp)
+	}
+	c.Expectations = out
+	return generated, nil
+}
+
+func (t Tenancy) overlays(key string) bool {
+	for _, tenant := range t.Tenants {
+		for _, o := range tenant.Overlays {
+			if o.Expectation == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// TenantExpectation builds the companion serving tenant's overlay of exp to requests whose
+// header matches value
+func TenantExpectation(exp MockExpectation, tenant string, o TenantOverlay, header, value string) MockExpectation {
+	v := exp
+	v.Tenant = tenant
+	v.Negotiation = nil
+	v.ConditionalGet = false
+	v.Ranges = false
+	v.Redirects = nil
+	v.Rules = nil
+	v.Checks = nil
+	v.Examples = nil
+	v.HttpResponseTemplate = nil // the overlay is the response
+	if exp.ID != "" {
+		v.ID = exp.ID + "-" + tenant
+	}
+	if exp.Description != "" {
+		v.Description = fmt.Sprintf("%s (tenant %s)", exp.Description, tenant)
+	}
+	req := *exp.HttpRequest
+	req.Headers = withHeader(append([]NameValues(nil), req.Headers...), header, value)
+	v.HttpRequest = &req
+
+	resp := HttpResponse{}
+	if exp.HttpResponse != nil {
+		resp = *exp.HttpResponse
+	}
+	if exp.ConditionalGet {
+		resp.Headers = withoutHeader(resp.Headers, "ETag") // the shared body's, set by an earlier save
+	}
+	if o.StatusCode != 0 {
+		resp.StatusCode = o.StatusCode
+	}
+	if len(o.Headers) > 0 {
+		headers := append([]NameValues(nil), resp.Headers...)
+		for _, h := range o.Headers {
+			headers = setHeaderValues(headers, h)
+		}
+		resp.Headers = headers
+	}
+	if o.Body != nil {
+		resp.Body = overlayBody(resp.Body, o.Body)
+		if raw, _ := json.Marshal(o.Body); !datasetReference.Match(raw) {
+			v.Dataset = nil // the tenant answers with its own body, not the dataset's
+		}
+	}
+	v.HttpResponse = &resp
+	return v
+}
+
+// withoutHeader returns a copy of headers without the named one (case-insensitive)
+func withoutHeader(headers []NameValues, name string) []NameValues {
+	var out []NameValues
+	for _, h := range headers {
+		if !strings.EqualFold(h.Name, name) {
+			out = append(out, h)
+		}
+	}
+	return out
+}
+
+// setHeaderValues replaces the values of a header (case-insensitive), or adds it
+func setHeaderValues(headers []NameValues, h NameValues) []NameValues {
+	for i := range headers {
+		if strings.EqualFold(headers[i].Name, h.Name) {
+			headers[i] = NameValues{Name: headers[i].Name, Values: h.Values}
+			return headers
+		}
+	}
+	return append(headers, h)
+}
+
+// overlayBody merges a JSON object overlay into a JSON object body as a JSON merge patch
+// (RFC 7386); any other overlay replaces the body
+func overlayBody(body, overlay any) any {
+	patch, ok := overlay.(map[string]any)
+	if !ok {
+		return overlay
+	}
+	base, ok := canonicalJSON(body)
+	if !ok {
+		return overlay
+	}
+	return mergePatch(base, patch)
+}
+
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = map[string]any{}
+	}
+	out := make(map[string]any, len(t)+len(p))
+	for k, v := range t {
+		out[k] = v
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(out, k)
+		} else {
+			out[k] = mergePatch(out[k], v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestApplyTenants(t *testing.T) {
+	config := &MockConfiguration{
+		Expectations: []MockExpectation{
+			{
+				ID:           "plan",
+				HttpRequest:  &HttpRequest{Method: "GET", Path: "/plan"},
+				HttpResponse: &HttpResponse{StatusCode: 200, Body: map[string]any{"plan": "free", "seats": 5, "trial": true}},
+				Ranges:       true,
+			},
+			{HttpRequest: &HttpRequest{Method: "GET", Path: "/users"}, HttpResponse: &HttpResponse{StatusCode: 200, Body: `["ann"]`}},
+		},
+		Tenancy: &Tenancy{Header: "X-Tenant", Subdomain: true, Tenants: []Tenant{
+			{Name: "acme", Overlays: []TenantOverlay{{Expectation: "plan", Body: map[string]any{"plan": "enterprise", "trial": nil}}}},
+			{Name: "beta", Overlays: []TenantOverlay{{Expectation: "GET /users", StatusCode: 403, Body: "suspended"}}},
+		}},
+	}
+
+	for pass := 0; pass < 2; pass++ { // saving again regenerates the same companions
+		if n, err := config.ApplyTenants(); err != nil || n != 4 {
+			t.Fatalf("pass %d: ApplyTenants = %d, %v", pass, n, err)
+		}
+	}
+	if len(config.Expectations) != 6 {
+		t.Fatalf("expectations = %d, want 6", len(config.Expectations))
+	}
+
+	byHeader, byHost := config.Expectations[0], config.Expectations[1]
+	if byHeader.ID != "plan-acme" || byHeader.Tenant != "acme" || byHeader.Ranges || !byHeader.Generated() ||
+		!reflect.DeepEqual(byHeader.HttpRequest.Headers, []NameValues{{Name: "X-Tenant", Values: []string{"acme"}}}) {
+		t.Errorf("header companion = %+v %+v", byHeader, byHeader.HttpRequest)
+	}
+	if !reflect.DeepEqual(byHost.HttpRequest.Headers, []NameValues{{Name: "Host", Values: []string{`acme\..+`}}}) {
+		t.Errorf("subdomain companion request = %+v", byHost.HttpRequest)
+	}
+	if want := map[string]any{"plan": "enterprise", "seats": float64(5)}; !reflect.DeepEqual(byHeader.HttpResponse.Body, want) {
+		t.Errorf("merged body = %#v", byHeader.HttpResponse.Body)
+	}
+	if shared := config.Expectations[2]; shared.ID != "plan" || shared.HttpResponse.Body.(map[string]any)["plan"] != "free" {
+		t.Errorf("shared expectation = %+v", shared)
+	}
+	if beta := config.Expectations[3]; beta.HttpResponse.StatusCode != 403 || beta.HttpResponse.Body != "suspended" || beta.ID != "" {
+		t.Errorf("beta companion = %+v", beta.HttpResponse)
+	}
+
+	// Without tenants the companions go
+	config.Tenancy = nil
+	if n, err := config.ApplyTenants(); err != nil || n != 0 || len(config.Expectations) != 2 {
+		t.Errorf("no tenancy: %d, %v, %d expectations", n, err, len(config.Expectations))
+	}
+}
+
+func TestTenantExpectationDropsSharedETag(t *testing.T) {
+	config := &MockConfiguration{
+		Expectations: []MockExpectation{{
+			ID:             "plan",
+			HttpRequest:    &HttpRequest{Method: "GET", Path: "/plan"},
+			HttpResponse:   &HttpResponse{StatusCode: 200, Body: map[string]any{"plan": "free"}},
+			ConditionalGet: true,
+		}},
+		Tenancy: &Tenancy{Header: "X-Tenant", Tenants: []Tenant{
+			{Name: "acme", Overlays: []TenantOverlay{{Expectation: "plan", Body: map[string]any{"plan": "enterprise"}}}},
+		}},
+	}
+	for pass := 0; pass < 2; pass++ { // the second save sees the ETag the first one set
+		if _, err := config.ApplyTenants(); err != nil {
+			t.Fatal(err)
+		}
+		config.ApplyConditionalGet()
+	}
+	if tenant := config.Expectations[0]; tenant.Tenant != "acme" || len(tenant.HttpResponse.Headers) != 0 {
+		t.Errorf("tenant response carries the shared headers: %+v", tenant.HttpResponse)
+	}
+}
+
+func TestCheckTenancy(t *testing.T) {
+	expectations := []MockExpectation{
+		{ID: "plan", HttpRequest: &HttpRequest{Method: "GET", Path: "/plan"}},
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/users"}},
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/users"}, Priority: 5},
+	}
+	for _, tc := range []struct {
+		tenancy Tenancy
+		msg     string
+	}{
+		{Tenancy{}, "set one"},
+		{Tenancy{Header: "X Tenant"}, "is not a header name"},
+		{Tenancy{Header: "X-Tenant", Tenants: []Tenant{{Name: "acme.corp"}}}, "must be a single word"},
+		{Tenancy{Subdomain: true, Tenants: []Tenant{{Name: "acme"}, {Name: "acme"}}}, "listed twice"},
+		{Tenancy{Subdomain: true, Tenants: []Tenant{{Name: "acme", Overlays: []TenantOverlay{{Expectation: "GET /orders"}}}}}, "unknown expectation"},
+		{Tenancy{Subdomain: true, Tenants: []Tenant{{Name: "acme", Overlays: []TenantOverlay{{Expectation: "GET /users"}}}}}, "give them IDs"},
+		{Tenancy{Subdomain: true, Tenants: []Tenant{{Name: "acme", Overlays: []TenantOverlay{{Expectation: "plan"}, {Expectation: "plan"}}}}}, "overlays plan twice"},
+	} {
+		config := &MockConfiguration{Expectations: expectations, Tenancy: &tc.tenancy}
+		if _, err := config.ApplyTenants(); err == nil || !strings.Contains(err.Error(), tc.msg) {
+			t.Errorf("%+v: err = %v, want %q", tc.tenancy, err, tc.msg)
+		}
+	}
+}
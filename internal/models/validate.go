@@ -0,0 +1,356 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Severity of a validation issue
+const (
+	IssueError   = "error"   // MockServer would reject or never serve the expectation; blocks save and deploy
+	IssueWarning = "warning" // Likely a mistake, but served as written
+)
+
+// ValidationIssue is one problem found in an expectation
+type ValidationIssue struct {
+	Index    int    `json:"index"`    // Position in the expectation list
+	Endpoint string `json:"endpoint"` // "GET /users/{id}"
+	Field    string `json:"field"`    // e.g. "httpResponse.body"
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+func (i ValidationIssue) String() string {
+	return fmt.Sprintf("[%d] %s %s: %s", i.Index, i.Endpoint, i.Field, i.Message)
+}
+
+// ValidationReport lists the issues of a set of expectations in order
+type ValidationReport []ValidationIssue
+
+// Errors returns the blocking issues
+func (r ValidationReport) Errors() ValidationReport {
+	return r.bySeverity(IssueError)
+}
+
+// Warnings returns the non-blocking issues
+func (r ValidationReport) Warnings() ValidationReport {
+	return r.bySeverity(IssueWarning)
+}
+
+func (r ValidationReport) bySeverity(severity string) ValidationReport {
+	var out ValidationReport
+	for _, i := range r {
+		if i.Severity == severity {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// Err returns a ValidationError describing the blocking issues, or nil when there are none
+func (r ValidationReport) Err() error {
+	errs := r.Errors()
+	if len(errs) == 0 {
+		return nil
+	}
+	msg := errs[0].Endpoint + ": " + errs[0].Message
+	if len(errs) > 1 {
+		msg += fmt.Sprintf(" (and %d more; run automock validate for the full list)", len(errs)-1)
+	}
+	return ValidationError{Field: fmt.Sprintf("expectations[%d].%s", errs[0].Index, errs[0].Field), Message: msg}
+}
+
+// Format renders the report for the terminal, errors first
+func (r ValidationReport) Format() string {
+	if len(r) == 0 {
+		return "✅ No problems found\n"
+	}
+	var b strings.Builder
+	for _, i := range r.Errors() {
+		fmt.Fprintf(&b, "  ❌ %s\n", i)
+	}
+	for _, i := range r.Warnings() {
+		fmt.Fprintf(&b, "  ⚠️  %s\n", i)
+	}
+	fmt.Fprintf(&b, "%d error(s), %d warning(s)\n", len(r.Errors()), len(r.Warnings()))
+	return b.String()
+}
+
+// javaOnlyRegex spots constructs MockServer's Java regexes accept but Go's do not
+// (lookarounds, backreferences, possessive quantifiers); such patterns are not checked
+var javaOnlyRegex = regexp.MustCompile(`\(\?<?[=!]|\\[1-9]|[*+?}]\+`)
+
+// Validate checks expectations for problems MockServer would reject or silently mishandle:
+// invalid regexes, bodies on 204/304 responses, malformed JSON bodies, text bodies without a
+// Content-Type, duplicate or conflicting request matchers and priority collisions.
+// Expectations generated on save are checked through the ones they derive from.
+func Validate(expectations []MockExpectation) ValidationReport {
+	var r ValidationReport
+	for i, exp := range expectations {
+		if exp.Generated() {
+			continue
+		}
+		v := validator{index: i, endpoint: endpointLabel(exp)}
+		v.request(exp.HttpRequest)
+		if exp.HttpResponseTemplate == nil && exp.Forward == nil {
+			v.response(exp.HttpResponse)
+		}
+		r = append(r, v.issues...)
+	}
+	return append(r, matcherCollisions(expectations)...)
+}
+
+type validator struct {
+	index    int
+	endpoint string
+	issues   ValidationReport
+}
+
+func (v *validator) add(severity, field, format string, args ...any) {
+	v.issues = append(v.issues, ValidationIssue{
+		Index: v.index, Endpoint: v.endpoint, Field: field, Severity: severity, Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) request(req *HttpRequest) {
+	if req == nil {
+		return
+	}
+	if !strings.Contains(req.Path, "{") {
+		v.regex(IssueWarning, "httpRequest.path", req.Path)
+	}
+	for name, values := range req.PathParameters {
+		for _, value := range values {
+			v.regex(IssueWarning, "httpRequest.pathParameters."+name, value)
+		}
+	}
+	for _, q := range req.QueryStringParameters {
+		for _, value := range q.Values {
+			v.regex(IssueWarning, "httpRequest.queryStringParameters."+q.Name, value)
+		}
+	}
+	for _, h := range req.Headers {
+		for _, value := range h.Values {
+			v.regex(IssueWarning, "httpRequest.headers."+h.Name, value)
+		}
+	}
+
+	body, ok := req.Body.(map[string]any)
+	if !ok {
+		return
+	}
+	switch strings.ToUpper(fmt.Sprint(body["type"])) {
+	case "REGEX":
+		if pattern, ok := body["regex"].(string); ok {
+			v.regex(IssueError, "httpRequest.body.regex", pattern)
+		}
+	case "JSON":
+		if text, ok := body["json"].(string); ok && !json.Valid([]byte(text)) {
+			v.add(IssueError, "httpRequest.body.json", "request body matcher is not valid JSON")
+		}
+	}
+}
+
+// regex reports a matcher value that does not compile as a regex. MockServer then compares
+// it as literal text only, which is a warning for values and paths but an error for a REGEX
+// body, which has no literal fallback. A leading "!" negates the matcher.
+func (v *validator) regex(severity, field, value string) {
+	pattern := value
+	if strings.HasPrefix(pattern, "!") && len(pattern) > 1 {
+		pattern = pattern[1:]
+	}
+	_, err := regexp.Compile(pattern)
+	if err == nil || javaOnlyRegex.MatchString(pattern) {
+		return
+	}
+	reason := strings.TrimPrefix(err.Error(), "error parsing regexp: ")
+	if severity == IssueWarning {
+		v.add(severity, field, "%q is not a valid regex (%s); it only matches as literal text", value, reason)
+		return
+	}
+	v.add(severity, field, "invalid regex %q: %s", value, reason)
+}
+
+func (v *validator) response(resp *HttpResponse) {
+	if resp == nil {
+		return
+	}
+	hasBody := !emptyBody(resp.Body)
+	if hasBody && (resp.StatusCode == 204 || resp.StatusCode == 304 || (resp.StatusCode >= 100 && resp.StatusCode < 200)) {
+		v.add(IssueError, "httpResponse.body", "a %d response cannot have a body; clients never read it", resp.StatusCode)
+		return
+	}
+	if !hasBody || resp.SchemaRef != "" {
+		return
+	}
+
+	contentType := headerValue(resp.Headers, "Content-Type")
+	switch b := resp.Body.(type) {
+	case string:
+		if strings.Contains(b, "$!") {
+			return // Velocity placeholders; not JSON until rendered
+		}
+		looksJSON := strings.HasPrefix(strings.TrimSpace(b), "{") || strings.HasPrefix(strings.TrimSpace(b), "[")
+		if isJSONMedia(contentType) && !json.Valid([]byte(b)) {
+			v.add(IssueError, "httpResponse.body", "body is not valid JSON but Content-Type is %s", contentType)
+		} else if contentType == "" && looksJSON && !json.Valid([]byte(b)) {
+			v.add(IssueError, "httpResponse.body", "body looks like JSON but does not parse")
+		} else if contentType == "" {
+			v.add(IssueWarning, "httpResponse.headers", "text body without a Content-Type header")
+		}
+	case map[string]any:
+		switch strings.ToUpper(fmt.Sprint(b["type"])) {
+		case "JSON":
+			if text, ok := b["json"].(string); ok && !strings.Contains(text, "$!") && !json.Valid([]byte(text)) {
+				v.add(IssueError, "httpResponse.body.json", "response body is not valid JSON")
+			}
+		case "STRING":
+			if _, typed := b["contentType"]; !typed && contentType == "" {
+				v.add(IssueWarning, "httpResponse.headers", "text body without a Content-Type header")
+			}
+		case "BINARY":
+			if _, _, ok := BinaryBodyBytes(b); !ok {
+				v.add(IssueError, "httpResponse.body.base64Bytes", "binary body is not valid base64")
+			} else if _, typed := b["contentType"]; !typed && contentType == "" {
+				v.add(IssueWarning, "httpResponse.headers", "binary body without a Content-Type header; clients will guess the media type")
+			}
+		}
+	}
+}
+
+func emptyBody(body any) bool {
+	switch b := body.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(b) == ""
+	case map[string]any:
+		if len(b) == 0 {
+			return true
+		}
+		if s, ok := b["string"].(string); ok && strings.ToUpper(fmt.Sprint(b["type"])) == "STRING" {
+			return s == ""
+		}
+	}
+	return false
+}
+
+func headerValue(headers []NameValues, name string) string {
+	for _, h := range headers {
+		if strings.EqualFold(h.Name, name) && len(h.Values) > 0 {
+			return h.Values[0]
+		}
+	}
+	return ""
+}
+
+func isJSONMedia(contentType string) bool {
+	mt := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	return mt == "application/json" || strings.HasSuffix(mt, "+json")
+}
+
+// matcherCollisions finds expectations MockServer can never tell apart. Identical matchers at
+// the same priority: the later one is never served (an error when it answers differently).
+// The same method and path at the same explicit priority with different matchers: which one
+// answers a request both accept depends on their order.
+func matcherCollisions(expectations []MockExpectation) ValidationReport {
+	var r ValidationReport
+	firstByMatcher := map[string]int{}
+	byRoute := map[string][]int{}
+	for i, exp := range expectations {
+		if exp.Generated() || exp.HttpRequest == nil || limitedTimes(exp.Times) {
+			continue
+		}
+		key := fmt.Sprintf("%d|%s", exp.Priority, matcherKey(exp.HttpRequest))
+		label := endpointLabel(exp)
+		if j, seen := firstByMatcher[key]; seen {
+			switch {
+			case sameResponse(expectations[j], exp):
+				r = append(r, ValidationIssue{Index: i, Endpoint: label, Field: "httpRequest", Severity: IssueWarning,
+					Message: fmt.Sprintf("duplicate of expectation [%d]; remove one", j)})
+			case !sameTags(expectations[j].Tags, exp.Tags):
+				r = append(r, ValidationIssue{Index: i, Endpoint: label, Field: "httpRequest", Severity: IssueWarning,
+					Message: fmt.Sprintf("same request matcher and priority as [%d]; only a tag selection that keeps one of them out serves this response", j)})
+			default:
+				r = append(r, ValidationIssue{Index: i, Endpoint: label, Field: "httpRequest", Severity: IssueError,
+					Message: fmt.Sprintf("same request matcher and priority as [%d] but a different response; it is never served (change the matcher or priority)", j)})
+			}
+			continue
+		}
+		firstByMatcher[key] = i
+		if exp.Priority != 0 {
+			route := fmt.Sprintf("%d|%s %s", exp.Priority, strings.ToUpper(exp.HttpRequest.Method), exp.HttpRequest.Path)
+			byRoute[route] = append(byRoute[route], i)
+		}
+	}
+
+	routes := make([]string, 0, len(byRoute))
+	for route := range byRoute {
+		routes = append(routes, route)
+	}
+	sort.Strings(routes)
+	for _, route := range routes {
+		indexes := byRoute[route]
+		for _, i := range indexes[1:] {
+			r = append(r, ValidationIssue{Index: i, Endpoint: endpointLabel(expectations[i]), Field: "priority", Severity: IssueWarning,
+				Message: fmt.Sprintf("priority %d is shared with [%d] on the same endpoint; requests both match go to the one listed first", expectations[i].Priority, indexes[0])})
+		}
+	}
+	return r
+}
+
+func sameTags(a, b []string) bool {
+	a, b = append([]string(nil), a...), append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	return strings.Join(a, ",") == strings.Join(b, ",")
+}
+
+func limitedTimes(t *Times) bool {
+	return t != nil && !t.Unlimited && t.RemainingTimes > 0
+}
+
+// matcherKey normalizes a request matcher so that header and query order do not matter
+func matcherKey(req *HttpRequest) string {
+	norm := *req
+	norm.Method = strings.ToUpper(req.Method)
+	norm.Headers = sortedNameValues(req.Headers, true)
+	norm.QueryStringParameters = sortedNameValues(req.QueryStringParameters, false)
+	raw, _ := json.Marshal(norm)
+	return string(raw)
+}
+
+func sortedNameValues(list []NameValues, foldCase bool) []NameValues {
+	out := make([]NameValues, len(list))
+	for i, nv := range list {
+		values := append([]string(nil), nv.Values...)
+		sort.Strings(values)
+		name := nv.Name
+		if foldCase {
+			name = strings.ToLower(name)
+		}
+		out[i] = NameValues{Name: name, Values: values}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func sameResponse(a, b MockExpectation) bool {
+	ra, _ := json.Marshal([]any{a.HttpResponse, a.HttpResponseTemplate, a.Forward})
+	rb, _ := json.Marshal([]any{b.HttpResponse, b.HttpResponseTemplate, b.Forward})
+	return string(ra) == string(rb)
+}
+
+func endpointLabel(exp MockExpectation) string {
+	if exp.HttpRequest == nil {
+		return "(any request)"
+	}
+	method := exp.HttpRequest.Method
+	if method == "" {
+		method = "ANY"
+	}
+	return method + " " + exp.HttpRequest.Path
+}
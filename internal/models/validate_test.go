@@ -0,0 +1,68 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	jsonHeader := []NameValues{{Name: "Content-Type", Values: []string{"application/json"}}}
+	expectations := []MockExpectation{
+		// 0: fine
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/users/.*"}, HttpResponse: &HttpResponse{StatusCode: 200, Headers: jsonHeader, Body: `{"ok": true}`}},
+		// 1: broken REGEX body; Accept */* only matches literally
+		{
+			HttpRequest: &HttpRequest{Method: "POST", Path: "/search", Headers: []NameValues{{Name: "Accept", Values: []string{"*/*"}}},
+				Body: map[string]any{"type": "REGEX", "regex": "name=(a"}},
+			HttpResponse: &HttpResponse{StatusCode: 204, Body: "gone"},
+		},
+		// 2: malformed JSON; lookarounds are MockServer regexes Go cannot check
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/(?!admin).*"}, HttpResponse: &HttpResponse{StatusCode: 200, Headers: jsonHeader, Body: `{"ok": `}},
+		// 3: same matcher as 0, different response
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/users/.*"}, HttpResponse: &HttpResponse{StatusCode: 200, Headers: jsonHeader, Body: `{"ok": false}`}},
+		// 4: exact duplicate of 0
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/users/.*"}, HttpResponse: &HttpResponse{StatusCode: 200, Headers: jsonHeader, Body: `{"ok": true}`}},
+		// 5: text without a Content-Type
+		{HttpRequest: &HttpRequest{Method: "GET", Path: "/health"}, HttpResponse: &HttpResponse{StatusCode: 200, Body: "ok"}},
+		// 6 and 7: same endpoint and explicit priority
+		{Priority: 5, HttpRequest: &HttpRequest{Method: "GET", Path: "/orders", QueryStringParameters: []NameValues{{Name: "page", Values: []string{"1"}}}},
+			HttpResponse: &HttpResponse{StatusCode: 200, Headers: jsonHeader, Body: `[]`}},
+		{Priority: 5, HttpRequest: &HttpRequest{Method: "GET", Path: "/orders"}, HttpResponse: &HttpResponse{StatusCode: 200, Headers: jsonHeader, Body: `[1]`}},
+		// 8: same matcher as 0 but kept apart by tags
+		{Tags: []string{"errors"}, HttpRequest: &HttpRequest{Method: "GET", Path: "/users/.*"}, HttpResponse: &HttpResponse{StatusCode: 500}},
+	}
+
+	report := Validate(expectations)
+	got := map[string]bool{}
+	for _, i := range report {
+		got[fmt.Sprintf("%d %s %s", i.Index, i.Severity, i.Field)] = true
+	}
+	want := []string{
+		"1 error httpRequest.body.regex",
+		"1 warning httpRequest.headers.Accept",
+		"1 error httpResponse.body",
+		"2 error httpResponse.body",
+		"3 error httpRequest",
+		"4 warning httpRequest",
+		"5 warning httpResponse.headers",
+		"7 warning priority",
+		"8 warning httpRequest",
+	}
+	for _, w := range want {
+		if !got[w] {
+			t.Errorf("missing issue %q in:\n%s", w, report.Format())
+		}
+	}
+	if len(report) != len(want) {
+		t.Errorf("got %d issues, want %d:\n%s", len(report), len(want), report.Format())
+	}
+
+	err := report.Err()
+	if err == nil || !strings.Contains(err.Error(), "expectations[1]") || !strings.Contains(err.Error(), "3 more") {
+		t.Errorf("Err() = %v", err)
+	}
+	if Validate(expectations[:1]).Err() != nil {
+		t.Error("a valid expectation was rejected")
+	}
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"mime"
+	"strings"
+)
+
+// VerifyResponse lists where a real API's response breaks the contract an expectation
+// promises: a different status code, a missing response header, another Content-Type media
+// type, or a JSON body missing fields or with fields of another type. Extra headers and
+// fields, and different values, are allowed; a mock only promises the shape it serves.
+func VerifyResponse(expected, actual *HttpResponse) []string {
+	exp, act := *expected, *actual
+	exp.Headers, act.Headers = mediaTypeHeaders(exp.Headers), mediaTypeHeaders(act.Headers)
+	act.Delay = exp.Delay
+	diff := &ConfigDiff{}
+	diffExpectation(diff, "", &MockExpectation{HttpResponse: &exp}, &MockExpectation{HttpResponse: &act})
+	var mismatches []string
+	for _, c := range diff.Breaking() {
+		mismatches = append(mismatches, c.Summary)
+	}
+	return mismatches
+}
+
+// mediaTypeHeaders reduces Content-Type to its media type, so "application/json; charset=utf-8"
+// satisfies "application/json"
+func mediaTypeHeaders(headers []NameValues) []NameValues {
+	out := make([]NameValues, len(headers))
+	for i, h := range headers {
+		out[i] = h
+		if strings.EqualFold(h.Name, "Content-Type") {
+			values := make([]string, len(h.Values))
+			for j, v := range h.Values {
+				if mt, _, err := mime.ParseMediaType(v); err == nil {
+					v = mt
+				}
+				values[j] = strings.ToLower(v)
+			}
+			out[i].Values = values
+		}
+	}
+	return out
+}
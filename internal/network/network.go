@@ -0,0 +1,89 @@
+// Package network holds the process-wide network settings: the --timeout applied to store,
+// cloud and AI provider calls, and --offline, which turns off everything that needs the network
+// so a dropped VPN fails fast with a clear message instead of hanging.
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Environment variables set by the global --timeout and --offline flags
+const (
+	EnvTimeout = "AUTOMOCK_TIMEOUT"
+	EnvOffline = "AUTOMOCK_OFFLINE"
+)
+
+// DefaultTimeout bounds a single network call when --timeout is not set
+const DefaultTimeout = 60 * time.Second
+
+// maxDialTimeout caps connection setup; a host that does not answer a dial in this long is down
+const maxDialTimeout = 10 * time.Second
+
+// ErrOffline is returned by Require in offline mode
+var ErrOffline = errors.New("offline mode is on (--offline)")
+
+// Timeout is the configured per-call timeout, DefaultTimeout when unset or invalid
+func Timeout() time.Duration {
+	if d, err := time.ParseDuration(os.Getenv(EnvTimeout)); err == nil && d > 0 {
+		return d
+	}
+	return DefaultTimeout
+}
+
+// Offline reports whether offline mode is on
+func Offline() bool {
+	on, _ := strconv.ParseBool(os.Getenv(EnvOffline))
+	return on
+}
+
+// Require fails with ErrOffline when offline mode is on; feature names what needs the network
+func Require(feature string) error {
+	if Offline() {
+		return fmt.Errorf("%s needs the network: %w", feature, ErrOffline)
+	}
+	return nil
+}
+
+// Transport bounds connecting, the TLS handshake and the wait for response headers by the
+// timeout, but not the body, so large uploads and downloads still complete
+func Transport() *http.Transport {
+	dial := Timeout()
+	if dial > maxDialTimeout {
+		dial = maxDialTimeout
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.DialContext = (&net.Dialer{Timeout: dial, KeepAlive: 30 * time.Second}).DialContext
+	t.TLSHandshakeTimeout = dial
+	t.ResponseHeaderTimeout = Timeout()
+	return t
+}
+
+// HTTPClient is a client whose whole request, body included, is bounded by the timeout
+func HTTPClient() *http.Client {
+	return &http.Client{Transport: Transport(), Timeout: Timeout()}
+}
+
+// IsNetworkError reports whether err means a host could not be reached or did not answer in
+// time, as opposed to answering with an error
+func IsNetworkError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var timeout interface{ Timeout() bool }
+	if errors.As(err, &timeout) && timeout.Timeout() {
+		return true
+	}
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	return errors.As(err, &opErr) || errors.As(err, &dnsErr)
+}
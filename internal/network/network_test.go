@@ -0,0 +1,59 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestSettingsFromEnv(t *testing.T) {
+	t.Setenv(EnvTimeout, "")
+	t.Setenv(EnvOffline, "")
+	if Timeout() != DefaultTimeout || Offline() || Require("deploy") != nil {
+		t.Fatal("defaults: want the default timeout and online")
+	}
+
+	t.Setenv(EnvTimeout, "5s")
+	t.Setenv(EnvOffline, "true")
+	if Timeout() != 5*time.Second {
+		t.Errorf("Timeout() = %s, want 5s", Timeout())
+	}
+	if tr := Transport(); tr.TLSHandshakeTimeout != 5*time.Second || tr.ResponseHeaderTimeout != 5*time.Second {
+		t.Errorf("transport timeouts = %s / %s", tr.TLSHandshakeTimeout, tr.ResponseHeaderTimeout)
+	}
+	if err := Require("deploy"); !errors.Is(err, ErrOffline) {
+		t.Errorf("Require offline = %v, want ErrOffline", err)
+	}
+
+	t.Setenv(EnvTimeout, "-1s")
+	if Timeout() != DefaultTimeout {
+		t.Errorf("negative timeout not ignored: %s", Timeout())
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string { return "i/o timeout" }
+func (timeoutError) Timeout() bool { return true }
+
+func TestIsNetworkError(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("AccessDenied"), false},
+		{fmt.Errorf("get config: %w", context.DeadlineExceeded), true},
+		{fmt.Errorf("list buckets: %w", timeoutError{}), true},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, true},
+		{fmt.Errorf("resolve: %w", &net.DNSError{Name: "s3.amazonaws.com", IsNotFound: true}), true},
+	}
+	for _, tc := range cases {
+		if got := IsNetworkError(tc.err); got != tc.want {
+			t.Errorf("IsNetworkError(%v) = %v, want %v", tc.err, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Package notify delivers contract change notifications to registered consumers
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// ContractChange is the payload delivered to consumers (webhook body / email content)
+type ContractChange struct {
+	Project         string                     `json:"project"`
+	Consumer        string                     `json:"consumer"`
+	ContractVersion string                     `json:"contract_version,omitempty"`
+	FromVersion     string                     `json:"from_version,omitempty"`
+	ToVersion       string                     `json:"to_version,omitempty"`
+	Breaking        bool                       `json:"breaking"`
+	Changes         []models.ExpectationChange `json:"changes"`
+}
+
+// NotifyConsumers sends each consumer the subset of the diff it depends on.
+// Consumers with no relevant changes are skipped. Returns the number notified and any delivery errors.
+func NotifyConsumers(ctx context.Context, project string, previous, current *models.MockConfiguration, diff *models.ConfigDiff) (int, []error) {
+	var (
+		sent int
+		errs []error
+	)
+	for i := range current.Consumers {
+		consumer := &current.Consumers[i]
+		changes := models.ChangesForConsumer(consumer, diff, previous, current)
+		if len(changes) == 0 {
+			continue
+		}
+		msg := ContractChange{
+			Project:         project,
+			Consumer:        consumer.Name,
+			ContractVersion: current.Metadata.ContractVersion,
+			FromVersion:     diff.FromVersion,
+			ToVersion:       diff.ToVersion,
+			Changes:         changes,
+		}
+		for _, c := range changes {
+			if c.Severity == models.ChangeBreaking {
+				msg.Breaking = true
+				break
+			}
+		}
+
+		delivered := false
+		if consumer.Webhook != "" {
+			if err := postWebhook(ctx, consumer.Webhook, msg); err != nil {
+				errs = append(errs, fmt.Errorf("webhook for %s: %w", consumer.Name, err))
+			} else {
+				delivered = true
+			}
+		}
+		if consumer.Email != "" {
+			if err := sendEmail(consumer.Email, msg); err != nil {
+				errs = append(errs, fmt.Errorf("email for %s: %w", consumer.Name, err))
+			} else {
+				delivered = true
+			}
+		}
+		if delivered {
+			sent++
+		}
+	}
+	return sent, errs
+}
+
+func postWebhook(ctx context.Context, url string, msg ContractChange) error {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "auto-mock")
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("POST %s: %s\n%s", url, resp.Status, string(body))
+	}
+	return nil
+}
+
+// sendEmail uses SMTP settings from AUTOMOCK_SMTP_HOST, AUTOMOCK_SMTP_PORT (default 587),
+// AUTOMOCK_SMTP_USER, AUTOMOCK_SMTP_PASSWORD and AUTOMOCK_SMTP_FROM
+func sendEmail(to string, msg ContractChange) error {
+	host := os.Getenv("AUTOMOCK_SMTP_HOST")
+	if host == "" {
+		return fmt.Errorf("AUTOMOCK_SMTP_HOST is not set")
+	}
+	port := os.Getenv("AUTOMOCK_SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+	from := os.Getenv("AUTOMOCK_SMTP_FROM")
+	if from == "" {
+		from = "automock@" + host
+	}
+	var auth smtp.Auth
+	if user := os.Getenv("AUTOMOCK_SMTP_USER"); user != "" {
+		auth = smtp.PlainAuth("", user, os.Getenv("AUTOMOCK_SMTP_PASSWORD"), host)
+	}
+
+	subject := fmt.Sprintf("[auto-mock] %s contract changed", msg.Project)
+	if msg.Breaking {
+		subject = fmt.Sprintf("[auto-mock] BREAKING: %s contract changed", msg.Project)
+	}
+	var body strings.Builder
+	fmt.Fprintf(&body, "From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n", from, to, subject)
+	fmt.Fprintf(&body, "Hi %s,\r\n\r\nExpectations you depend on in project %s changed", msg.Consumer, msg.Project)
+	if msg.ContractVersion != "" {
+		fmt.Fprintf(&body, " (contract version %s)", msg.ContractVersion)
+	}
+	body.WriteString(":\r\n\r\n")
+	for _, c := range msg.Changes {
+		fmt.Fprintf(&body, "  [%s] %s — %s\r\n", c.Severity, c.Endpoint, c.Summary)
+	}
+	return smtp.SendMail(host+":"+port, auth, from, []string{to}, []byte(body.String()))
+}
@@ -0,0 +1,339 @@
+// Package projectarchive packs a whole project into one tar.gz or zip file, so it can be moved
+// to another account, region or machine, or kept as a backup:
+//
+//	manifest.json        project, source cloud and region, versions, load-test pointer
+//	current.json         the current configuration
+//	versions/<v>.json    saved versions
+//	loadtest/<file>      the active load-test bundle
+//
+// The archive's current.json is a regular configuration file, so it also serves locally.
+package projectarchive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// FormatVersion is the archive layout written; newer layouts are refused on import
+const FormatVersion = 1
+
+const (
+	manifestFile = "manifest.json"
+	currentFile  = "current.json"
+	versionsDir  = "versions/"
+	loadTestDir  = "loadtest/"
+
+	maxEntrySize = 256 << 20 // guards against decompression bombs
+)
+
+// Manifest describes the archive's contents and where they came from
+type Manifest struct {
+	FormatVersion   int                     `json:"format_version"`
+	Project         string                  `json:"project"`
+	ExportedAt      time.Time               `json:"exported_at"`
+	AutoMockVersion string                  `json:"automock_version,omitempty"`
+	Cloud           string                  `json:"cloud,omitempty"`  // provider the project was exported from
+	Region          string                  `json:"region,omitempty"` // its region or location
+	CurrentVersion  string                  `json:"current_version"`
+	Versions        []string                `json:"versions,omitempty"`
+	LoadTest        *models.LoadTestPointer `json:"load_test,omitempty"` // pointer of the bundle under loadtest/
+}
+
+// Archive is an exported project
+type Archive struct {
+	Manifest      Manifest
+	Current       *models.MockConfiguration
+	Versions      map[string]*models.MockConfiguration
+	LoadTestFiles map[string][]byte // file name -> content of the active load-test bundle
+}
+
+// IsArchive reports whether path names a project archive rather than an expectations file
+func IsArchive(path string) bool {
+	_, ok := formatOf(path)
+	return ok
+}
+
+// DefaultName is the archive written for a project when no output is given
+func DefaultName(project string) string {
+	return project + ".automock.tar.gz"
+}
+
+func formatOf(name string) (string, bool) {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return "zip", true
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return "tar.gz", true
+	}
+	return "", false
+}
+
+// Write stores the archive at path, as zip or tar.gz by its extension
+func (a *Archive) Write(path string) error {
+	format, ok := formatOf(path)
+	if !ok {
+		return fmt.Errorf("archive %s must end in .tar.gz, .tgz or .zip", path)
+	}
+	entries, err := a.entries()
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if format == "zip" {
+		err = writeZip(&buf, entries, a.Manifest.ExportedAt)
+	} else {
+		err = writeTarGz(&buf, entries, a.Manifest.ExportedAt)
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
+
+type entry struct {
+	name string
+	data []byte
+}
+
+// entries lists the files of the archive, manifest first and the rest in name order
+func (a *Archive) entries() ([]entry, error) {
+	if a.Current == nil {
+		return nil, fmt.Errorf("archive of %s has no current configuration", a.Manifest.Project)
+	}
+	m := a.Manifest
+	m.FormatVersion = FormatVersion
+	m.CurrentVersion = a.Current.Metadata.Version
+	m.Versions = nil
+	for v := range a.Versions {
+		m.Versions = append(m.Versions, v)
+	}
+	sort.Strings(m.Versions)
+
+	marshal := func(name string, v any) (entry, error) {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return entry{}, fmt.Errorf("marshal %s: %w", name, err)
+		}
+		return entry{name, data}, nil
+	}
+	var entries []entry
+	for _, e := range []struct {
+		name string
+		v    any
+	}{{manifestFile, m}, {currentFile, a.Current}} {
+		en, err := marshal(e.name, e.v)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, en)
+	}
+	for _, v := range m.Versions {
+		en, err := marshal(versionsDir+v+".json", a.Versions[v])
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, en)
+	}
+	var files []string
+	for name := range a.LoadTestFiles {
+		files = append(files, name)
+	}
+	sort.Strings(files)
+	for _, name := range files {
+		entries = append(entries, entry{loadTestDir + path.Base(name), a.LoadTestFiles[name]})
+	}
+	return entries, nil
+}
+
+func writeTarGz(w io.Writer, entries []entry, modTime time.Time) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		if err := tw.WriteHeader(&tar.Header{Name: e.name, Mode: 0600, Size: int64(len(e.data)), ModTime: modTime}); err != nil {
+			return err
+		}
+		if _, err := tw.Write(e.data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func writeZip(w io.Writer, entries []entry, modTime time.Time) error {
+	zw := zip.NewWriter(w)
+	for _, e := range entries {
+		f, err := zw.CreateHeader(&zip.FileHeader{Name: e.name, Method: zip.Deflate, Modified: modTime})
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(e.data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// Read loads an archive written by Write
+func Read(path string) (*Archive, error) {
+	format, ok := formatOf(path)
+	if !ok {
+		return nil, fmt.Errorf("archive %s must end in .tar.gz, .tgz or .zip", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	var entries []entry
+	if format == "zip" {
+		entries, err = readZip(data)
+	} else {
+		entries, err = readTarGz(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	a, err := parse(entries)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return a, nil
+}
+
+func readTarGz(data []byte) ([]entry, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("not a tar.gz archive: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	var entries []entry
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if h.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := readEntry(tr)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", h.Name, err)
+		}
+		entries = append(entries, entry{h.Name, content})
+	}
+}
+
+func readZip(data []byte) ([]entry, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("not a zip archive: %w", err)
+	}
+	var entries []entry
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		content, err := readEntry(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		entries = append(entries, entry{f.Name, content})
+	}
+	return entries, nil
+}
+
+func readEntry(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxEntrySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxEntrySize {
+		return nil, fmt.Errorf("larger than %d MB", maxEntrySize>>20)
+	}
+	return data, nil
+}
+
+// parse assembles an archive from its entries; files outside the layout are ignored
+func parse(entries []entry) (*Archive, error) {
+	a := &Archive{Versions: map[string]*models.MockConfiguration{}}
+	haveManifest := false
+	for _, e := range entries {
+		name := path.Clean(strings.TrimPrefix(e.name, "./"))
+		switch {
+		case name == manifestFile:
+			if err := json.Unmarshal(e.data, &a.Manifest); err != nil {
+				return nil, fmt.Errorf("%s: %w", manifestFile, err)
+			}
+			haveManifest = true
+		case name == currentFile:
+			var cfg models.MockConfiguration
+			if err := json.Unmarshal(e.data, &cfg); err != nil {
+				return nil, fmt.Errorf("%s: %w", currentFile, err)
+			}
+			a.Current = &cfg
+		case strings.HasPrefix(name, versionsDir) && path.Ext(name) == ".json":
+			var cfg models.MockConfiguration
+			if err := json.Unmarshal(e.data, &cfg); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			a.Versions[strings.TrimSuffix(path.Base(name), ".json")] = &cfg
+		case strings.HasPrefix(name, loadTestDir):
+			if a.LoadTestFiles == nil {
+				a.LoadTestFiles = map[string][]byte{}
+			}
+			a.LoadTestFiles[path.Base(name)] = e.data // base name only, so entries cannot escape the bundle directory
+		}
+	}
+	if !haveManifest {
+		return nil, fmt.Errorf("not a project archive: %s is missing", manifestFile)
+	}
+	if a.Manifest.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("archive format %d is newer than this automock supports (%d); upgrade automock",
+			a.Manifest.FormatVersion, FormatVersion)
+	}
+	if a.Current == nil {
+		return nil, fmt.Errorf("archive of %s is missing %s", a.Manifest.Project, currentFile)
+	}
+	return a, nil
+}
+
+// Summary describes the archive's contents in one line
+func (a *Archive) Summary() string {
+	parts := []string{fmt.Sprintf("%d expectation(s) at %s", len(a.Current.Expectations), a.Current.Metadata.Version)}
+	parts = append(parts, fmt.Sprintf("%d saved version(s)", len(a.Versions)))
+	if len(a.LoadTestFiles) > 0 {
+		tool := "locust"
+		if a.Manifest.LoadTest != nil && a.Manifest.LoadTest.Tool != "" {
+			tool = a.Manifest.LoadTest.Tool
+		}
+		parts = append(parts, fmt.Sprintf("%s load-test bundle (%d file(s))", tool, len(a.LoadTestFiles)))
+	}
+	return strings.Join(parts, ", ")
+}
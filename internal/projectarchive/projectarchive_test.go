@@ -0,0 +1,117 @@
+package projectarchive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+func sampleArchive() *Archive {
+	config := func(version, path string) *models.MockConfiguration {
+		return &models.MockConfiguration{
+			Metadata: models.ConfigMetadata{ProjectID: "users", Version: version},
+			Expectations: []models.MockExpectation{{
+				HttpRequest:  &models.HttpRequest{Method: "GET", Path: path},
+				HttpResponse: &models.HttpResponse{StatusCode: 200, Body: `{"ok":true}`},
+			}},
+		}
+	}
+	return &Archive{
+		Manifest: Manifest{Project: "users", ExportedAt: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), Cloud: "aws", Region: "eu-west-1"},
+		Current:  config("v200", "/users"),
+		Versions: map[string]*models.MockConfiguration{"v100": config("v100", "/old"), "v200": config("v200", "/users")},
+		LoadTestFiles: map[string][]byte{
+			"locustfile.py":         []byte("from locust import HttpUser\n"),
+			"locust_endpoints.json": []byte("[]"),
+		},
+	}
+}
+
+func TestWriteReadRoundTrip(t *testing.T) {
+	for _, name := range []string{"users.automock.tar.gz", "users.zip"} {
+		path := filepath.Join(t.TempDir(), name)
+		if err := sampleArchive().Write(path); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		a, err := Read(path)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if a.Manifest.Project != "users" || a.Manifest.FormatVersion != FormatVersion || a.Manifest.CurrentVersion != "v200" ||
+			strings.Join(a.Manifest.Versions, ",") != "v100,v200" || a.Manifest.Region != "eu-west-1" {
+			t.Errorf("%s: manifest = %+v", name, a.Manifest)
+		}
+		if a.Current.Expectations[0].HttpRequest.Path != "/users" || a.Versions["v100"].Expectations[0].HttpRequest.Path != "/old" {
+			t.Errorf("%s: configurations not restored", name)
+		}
+		if string(a.LoadTestFiles["locustfile.py"]) != "from locust import HttpUser\n" || len(a.LoadTestFiles) != 2 {
+			t.Errorf("%s: load-test files = %v", name, a.LoadTestFiles)
+		}
+		if got := a.Summary(); got != "1 expectation(s) at v200, 2 saved version(s), locust load-test bundle (2 file(s))" {
+			t.Errorf("%s: summary = %q", name, got)
+		}
+	}
+
+	if err := sampleArchive().Write(filepath.Join(t.TempDir(), "users.json")); err == nil {
+		t.Error("wrote an archive without a known extension")
+	}
+}
+
+func TestReadRejects(t *testing.T) {
+	write := func(files map[string]string) string {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gz)
+		for name, content := range files {
+			tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content))})
+			tw.Write([]byte(content))
+		}
+		tw.Close()
+		gz.Close()
+		path := filepath.Join(t.TempDir(), "a.tgz")
+		os.WriteFile(path, buf.Bytes(), 0600)
+		return path
+	}
+
+	for name, tc := range map[string]struct {
+		files map[string]string
+		want  string
+	}{
+		"no manifest": {map[string]string{"current.json": `{}`}, "manifest.json is missing"},
+		"no current":  {map[string]string{"manifest.json": `{"format_version":1}`}, "missing current.json"},
+		"newer":       {map[string]string{"manifest.json": `{"format_version":9}`, "current.json": `{}`}, "upgrade automock"},
+	} {
+		if _, err := Read(write(tc.files)); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, want %q", name, err, tc.want)
+		}
+	}
+
+	// Load-test entries keep their base name only
+	a, err := Read(write(map[string]string{
+		"manifest.json":          `{"format_version":1}`,
+		"current.json":           `{}`,
+		"loadtest/../../evil.py": "x",
+		"loadtest/locustfile.py": "y",
+		"versions/v1.json":       `{"metadata":{"version":"v1"}}`,
+		"versions/notes.txt":     "ignored",
+		"unrelated/readme.md":    "ignored",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Versions) != 1 || a.Versions["v1"] == nil {
+		t.Errorf("versions = %v", a.Versions)
+	}
+	for name := range a.LoadTestFiles {
+		if strings.Contains(name, "/") || strings.Contains(name, "..") {
+			t.Errorf("load-test file name %q escapes the bundle", name)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+// Package projectconfig reads the project-level automock.yaml committed at the repository root,
+// so a team shares one project name, AI provider, cloud, collection paths and deployment sizing:
+//
+//	project: payments-mock
+//	provider: anthropic
+//	cloud: aws
+//	region: eu-west-1
+//	collections:
+//	  files: [api/payments.postman_collection.json]
+//	deploy:
+//	  instance_size: medium
+//	  min_tasks: 2
+//
+// Values in the file are defaults only: command-line flags win, then environment variables,
+// then the file. The hooks section of the same file is read by the hooks package.
+package projectconfig
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/hemantobora/auto-mock/internal/hooks"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvConfig points at a config file other than ./automock.yaml
+const EnvConfig = "AUTOMOCK_CONFIG"
+
+// File is the project configuration
+type File struct {
+	Project        string      `yaml:"project,omitempty"`
+	Provider       string      `yaml:"provider,omitempty"` // AI provider used by init
+	Model          string      `yaml:"model,omitempty"`
+	Cloud          string      `yaml:"cloud,omitempty"` // aws, azure, local or git
+	Profile        string      `yaml:"profile,omitempty"`
+	Region         string      `yaml:"region,omitempty"`          // AWS region or Azure location
+	StorageAccount string      `yaml:"storage_account,omitempty"` // Azure only
+	GitPath        string      `yaml:"git_path,omitempty"`        // git store only
+	Collections    Collections `yaml:"collections,omitempty"`
+	Matching       Matching    `yaml:"matching,omitempty"`
+	Deploy         Deploy      `yaml:"deploy,omitempty"`
+	Hooks          yaml.Node   `yaml:"hooks,omitempty"` // see package hooks
+
+	// Path is where the file was read from
+	Path string `yaml:"-"`
+}
+
+// Collections are the collection import settings used by init
+type Collections struct {
+	Files         []string `yaml:"files,omitempty"`
+	Type          string   `yaml:"type,omitempty"`
+	IterationData string   `yaml:"iteration_data,omitempty"`
+	ChunkSize     int      `yaml:"chunk_size,omitempty"`
+	MaxRequests   int      `yaml:"max_requests,omitempty"`
+	Concurrency   int      `yaml:"concurrency,omitempty"`
+}
+
+// Matching holds the generation defaults applied to new expectations
+type Matching struct {
+	ConditionalGet bool `yaml:"conditional_get,omitempty"`
+}
+
+// Deploy sizes the mock infrastructure, picks the cluster it runs on or the domain it is served at
+type Deploy struct {
+	InstanceSize string `yaml:"instance_size,omitempty"` // small, medium, large or xlarge
+	MinTasks     int    `yaml:"min_tasks,omitempty"`
+	MaxTasks     int    `yaml:"max_tasks,omitempty"`
+	Target       string `yaml:"target,omitempty"` // cloud or kubernetes
+	Namespace    string `yaml:"namespace,omitempty"`
+	KubeContext  string `yaml:"kube_context,omitempty"`
+	Image        string `yaml:"image,omitempty"`
+	Replicas     int    `yaml:"replicas,omitempty"`
+	Domain       string `yaml:"domain,omitempty"` // served over HTTPS by cloud deploys on AWS
+	HostedZoneID string `yaml:"hosted_zone_id,omitempty"`
+	// Estimated monthly cost (USD) above which cloud deploys are refused
+	MaxMonthlyCost float64 `yaml:"max_monthly_cost,omitempty"`
+}
+
+// Path returns the config file location: AUTOMOCK_CONFIG, or automock.yaml in the working directory
+func Path() string {
+	if path := os.Getenv(EnvConfig); path != "" {
+		return path
+	}
+	return hooks.DefaultConfigFile
+}
+
+// Load reads the project configuration; a missing file yields nil
+func Load() (*File, error) {
+	path := Path()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	f, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	f.Path = path
+	return f, nil
+}
+
+// Parse decodes a config file, rejecting unknown keys so typos do not go unnoticed
+func Parse(data []byte) (*File, error) {
+	var f File
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	switch f.Cloud {
+	case "", "aws", "azure", "local", "git":
+	default:
+		return nil, fmt.Errorf("cloud must be aws, azure, local or git, got %q", f.Cloud)
+	}
+	switch f.Deploy.Target {
+	case "", "cloud", "kubernetes":
+	default:
+		return nil, fmt.Errorf("deploy.target must be cloud or kubernetes, got %q", f.Deploy.Target)
+	}
+	if f.Deploy.MinTasks < 0 || f.Deploy.MaxTasks < 0 || f.Deploy.Replicas < 0 {
+		return nil, fmt.Errorf("deploy.min_tasks, deploy.max_tasks and deploy.replicas must not be negative")
+	}
+	if f.Deploy.MaxMonthlyCost < 0 {
+		return nil, fmt.Errorf("deploy.max_monthly_cost must not be negative")
+	}
+	if f.Deploy.MaxTasks != 0 && f.Deploy.MaxTasks < f.Deploy.MinTasks {
+		return nil, fmt.Errorf("deploy.max_tasks (%d) is below deploy.min_tasks (%d)", f.Deploy.MaxTasks, f.Deploy.MinTasks)
+	}
+	return &f, nil
+}
+
+// GlobalFlags maps the global CLI flags to the values the file gives them
+func (f *File) GlobalFlags() map[string][]string {
+	values := map[string][]string{}
+	set := func(flag, value string) {
+		if value != "" {
+			values[flag] = []string{value}
+		}
+	}
+	set("profile", f.Profile)
+	set("cloud", f.Cloud)
+	set("model", f.Model)
+	set("azure-storage-account", f.StorageAccount)
+	set("git-path", f.GitPath)
+	if f.Cloud == "azure" {
+		set("azure-location", f.Region)
+	}
+	return values
+}
+
+// CommandFlags maps the flags of a command to the values the file gives them
+func (f *File) CommandFlags(command string) map[string][]string {
+	values := map[string][]string{}
+	set := func(flag, value string) {
+		if value != "" && value != "0" && value != "false" {
+			values[flag] = []string{value}
+		}
+	}
+	switch command {
+	case "run": // load run takes --project or --dir, so a default project would rule out --dir
+	case "import": // an archive is imported under its own name unless --project renames it
+	default:
+		set("project", f.Project)
+	}
+	switch command {
+	case "init":
+		set("provider", f.Provider)
+		if len(f.Collections.Files) > 0 {
+			values["collection-file"] = f.Collections.Files
+		}
+		set("collection-type", f.Collections.Type)
+		set("iteration-data", f.Collections.IterationData)
+		set("chunk-size", strconv.Itoa(f.Collections.ChunkSize))
+		set("max-requests", strconv.Itoa(f.Collections.MaxRequests))
+		set("concurrency", strconv.Itoa(f.Collections.Concurrency))
+		set("conditional-get", strconv.FormatBool(f.Matching.ConditionalGet))
+	case "deploy":
+		set("instance-size", f.Deploy.InstanceSize)
+		set("min-tasks", strconv.Itoa(f.Deploy.MinTasks))
+		set("max-tasks", strconv.Itoa(f.Deploy.MaxTasks))
+		set("target", f.Deploy.Target)
+		set("namespace", f.Deploy.Namespace)
+		set("kube-context", f.Deploy.KubeContext)
+		set("image", f.Deploy.Image)
+		set("replicas", strconv.Itoa(f.Deploy.Replicas))
+		set("domain", f.Deploy.Domain)
+		set("hosted-zone-id", f.Deploy.HostedZoneID)
+		set("max-monthly-cost", strconv.FormatFloat(f.Deploy.MaxMonthlyCost, 'f', -1, 64))
+	}
+	return values
+}
+
+// AWSRegion is the region exported as AWS_REGION when the file targets AWS
+func (f *File) AWSRegion() string {
+	if f.Cloud == "azure" || f.Cloud == "local" || f.Cloud == "git" {
+		return ""
+	}
+	return f.Region
+}
+
+// Scaffold renders an annotated automock.yaml for a project
+func Scaffold(project string) []byte {
+	return []byte(fmt.Sprintf(`# AutoMock project configuration. Commit this file so everyone shares the same settings.
+# Command-line flags override these values, then environment variables, then this file.
+
+project: %s
+
+# AI provider for 'automock init' (anthropic, openai, gemini, bedrock, template) and model
+# provider: anthropic
+# model: claude-sonnet-4-5
+
+# Cloud (aws, azure, local for ~/.automock, or git to commit mocks to this repository;
+# auto-detected when omitted), credential profile and region
+# cloud: aws
+# profile: dev
+# region: us-east-1
+# storage_account: mymocks   # Azure only
+# git_path: mocks            # git only: project directory, relative to the repository top
+
+# Collections imported by 'automock init'
+# collections:
+#   files:
+#     - api/postman_collection.json
+#   type: postman
+#   iteration_data: api/data.csv
+#   chunk_size: 25
+#   max_requests: 500
+#   concurrency: 4
+
+# Defaults for generated expectations
+# matching:
+#   conditional_get: true
+
+# Infrastructure sizing for 'automock deploy'
+# deploy:
+#   instance_size: small   # small, medium, large or xlarge
+#   min_tasks: 1
+#   max_tasks: 4
+#   target: kubernetes     # deploy to a cluster with kubectl instead of the cloud
+#   namespace: mocks
+#   kube_context: shared-dev
+#   replicas: 2
+#   domain: mock.mycompany.dev   # AWS only: HTTPS via ACM, CloudFront and Route53
+#   hosted_zone_id: Z0123456789ABC
+#   max_monthly_cost: 50         # refuse cloud deploys estimated above $50/month
+
+# Lifecycle hooks (pre-deploy, post-deploy, post-generate, pre-destroy)
+# hooks:
+#   post-deploy:
+#     - command: ./scripts/smoke-test.sh
+`, strconv.Quote(project)))
+}
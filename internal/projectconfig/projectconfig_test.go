@@ -0,0 +1,118 @@
+package projectconfig
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAndFlags(t *testing.T) {
+	f, err := Parse([]byte(`
+project: users
+provider: openai
+cloud: azure
+region: westeurope
+storage_account: mocks
+collections:
+  files: [a.json, b.json]
+  chunk_size: 20
+matching:
+  conditional_get: true
+deploy:
+  instance_size: large
+  max_tasks: 4
+  target: kubernetes
+  namespace: mocks
+  domain: mock.example.dev
+  max_monthly_cost: 42.5
+hooks:
+  post-deploy:
+    - command: ./smoke.sh
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	global := f.GlobalFlags()
+	if global["cloud"][0] != "azure" || global["azure-location"][0] != "westeurope" || global["azure-storage-account"][0] != "mocks" {
+		t.Errorf("global flags %v", global)
+	}
+	if _, ok := global["profile"]; ok {
+		t.Error("unset profile mapped")
+	}
+	if f.AWSRegion() != "" {
+		t.Error("azure region exported as AWS region")
+	}
+
+	initFlags := f.CommandFlags("init")
+	want := map[string][]string{
+		"project":         {"users"},
+		"provider":        {"openai"},
+		"collection-file": {"a.json", "b.json"},
+		"chunk-size":      {"20"},
+		"conditional-get": {"true"},
+	}
+	if !reflect.DeepEqual(initFlags, want) {
+		t.Errorf("init flags %v", initFlags)
+	}
+	deploy := f.CommandFlags("deploy")
+	if deploy["instance-size"][0] != "large" || deploy["max-tasks"][0] != "4" || deploy["provider"] != nil ||
+		deploy["target"][0] != "kubernetes" || deploy["namespace"][0] != "mocks" || deploy["replicas"] != nil ||
+		deploy["domain"][0] != "mock.example.dev" || deploy["hosted-zone-id"] != nil || deploy["max-monthly-cost"][0] != "42.5" {
+		t.Errorf("deploy flags %v", deploy)
+	}
+	if _, ok := f.CommandFlags("run")["project"]; ok {
+		t.Error("load run given a default project")
+	}
+	if _, ok := f.CommandFlags("import")["project"]; ok {
+		t.Error("import given a default project")
+	}
+
+	g, err := Parse([]byte("cloud: git\ngit_path: api/mocks\nregion: eu-west-1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flags := g.GlobalFlags(); flags["cloud"][0] != "git" || flags["git-path"][0] != "api/mocks" {
+		t.Errorf("git flags %v", flags)
+	}
+	if g.AWSRegion() != "" {
+		t.Error("git store region exported as AWS region")
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	for name, doc := range map[string]string{
+		"unknown key":     "projct: users",
+		"bad cloud":       "cloud: gcp",
+		"max < min":       "deploy: {min_tasks: 4, max_tasks: 2}",
+		"negative budget": "deploy: {max_monthly_cost: -1}",
+		"bad target":      "deploy: {target: nomad}",
+	} {
+		if _, err := Parse([]byte(doc)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "automock.yaml")
+	t.Setenv(EnvConfig, path)
+	if f, err := Load(); f != nil || err != nil {
+		t.Fatalf("missing file: %v, %v", f, err)
+	}
+	if err := os.WriteFile(path, Scaffold("users"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Project != "users" || f.Path != path {
+		t.Errorf("scaffold loaded as %+v", f)
+	}
+	os.WriteFile(path, []byte("cloud: gcp\n"), 0644)
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("error does not name the file: %v", err)
+	}
+}
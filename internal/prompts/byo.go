@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
 	"github.com/hemantobora/auto-mock/internal/cloud/aws"
 	"github.com/hemantobora/auto-mock/internal/models"
 )
@@ -17,7 +18,7 @@ import (
 // Returns error if user confirms BYO but provides invalid input
 func PromptBYONetworking(opts *models.LoadTestDeploymentOptions) error {
 	var useBYO bool
-	_ = survey.AskOne(&survey.Confirm{
+	_ = ask.One(&survey.Confirm{
 		Message: "Bring your own networking (VPC & subnets)?",
 		Default: false,
 	}, &useBYO)
@@ -30,7 +31,7 @@ func PromptBYONetworking(opts *models.LoadTestDeploymentOptions) error {
 	opts.UseExistingSubnets = true
 
 	var vpcID string
-	_ = survey.AskOne(&survey.Input{
+	_ = ask.One(&survey.Input{
 		Message: "Network ID (e.g., AWS VPC ID vpc-xxxx):",
 	}, &vpcID)
 	vpcID = strings.TrimSpace(vpcID)
@@ -40,7 +41,7 @@ func PromptBYONetworking(opts *models.LoadTestDeploymentOptions) error {
 	opts.VpcID = vpcID
 
 	var subnetsCSV string
-	_ = survey.AskOne(&survey.Input{
+	_ = ask.One(&survey.Input{
 		Message: "Public subnet IDs (comma-separated):",
 		Help:    "e.g., AWS: subnet-aaaa,subnet-bbbb",
 	}, &subnetsCSV)
@@ -75,7 +76,7 @@ func PromptBYOIGW(opts *models.LoadTestDeploymentOptions) error {
 	}
 	opts.UseExistingIGW = true
 	var igwID string
-	_ = survey.AskOne(&survey.Input{Message: "Internet Gateway ID (e.g., igw-xxxx):"}, &igwID)
+	_ = ask.One(&survey.Input{Message: "Internet Gateway ID (e.g., igw-xxxx):"}, &igwID)
 	igwID = strings.TrimSpace(igwID)
 	// IGW ID is optional for our module when BYO VPC is true, but capture if provided
 	opts.InternetGatewayID = igwID
@@ -91,10 +92,10 @@ func PromptExtraEnvironment(opts *models.LoadTestDeploymentOptions) error {
 
 	// Ask for .env file path (optional)
 	var useEnvFile bool
-	_ = survey.AskOne(&survey.Confirm{Message: "Load environment variables from a .env file?", Default: false}, &useEnvFile)
+	_ = ask.One(&survey.Confirm{Message: "Load environment variables from a .env file?", Default: false}, &useEnvFile)
 	if useEnvFile {
 		var path string
-		_ = survey.AskOne(&survey.Input{Message: "Path to .env file:"}, &path)
+		_ = ask.One(&survey.Input{Message: "Path to .env file:"}, &path)
 		path = strings.TrimSpace(path)
 		if path != "" {
 			if err := loadEnvFileInto(path, opts.ExtraEnvironment); err != nil {
@@ -105,11 +106,11 @@ func PromptExtraEnvironment(opts *models.LoadTestDeploymentOptions) error {
 
 	// Allow manual additions
 	var addManual bool
-	_ = survey.AskOne(&survey.Confirm{Message: "Add environment variables manually?", Default: len(opts.ExtraEnvironment) == 0}, &addManual)
+	_ = ask.One(&survey.Confirm{Message: "Add environment variables manually?", Default: len(opts.ExtraEnvironment) == 0}, &addManual)
 	if addManual {
 		for {
 			var kv string
-			_ = survey.AskOne(&survey.Input{Message: "Enter KEY=VALUE (blank to finish):"}, &kv)
+			_ = ask.One(&survey.Input{Message: "Enter KEY=VALUE (blank to finish):"}, &kv)
 			kv = strings.TrimSpace(kv)
 			if kv == "" {
 				break
@@ -176,7 +177,7 @@ func loadEnvFileInto(path string, dst map[string]string) error {
 // Returns error if user confirms BYO but provides invalid input
 func PromptBYOIAM(opts *models.LoadTestDeploymentOptions) error {
 	var useIAM bool
-	_ = survey.AskOne(&survey.Confirm{
+	_ = ask.One(&survey.Confirm{
 		Message: "Use existing IAM roles for ECS (execution & task)?",
 		Default: false,
 	}, &useIAM)
@@ -189,11 +190,11 @@ func PromptBYOIAM(opts *models.LoadTestDeploymentOptions) error {
 
 	aws.PrintECSIAMPolicies()
 	var execArn, taskArn string
-	_ = survey.AskOne(&survey.Input{
+	_ = ask.One(&survey.Input{
 		Message: "Execution role (ARN on AWS):",
 	}, &execArn)
 	aws.PrintECSRoleIAMPolicies()
-	_ = survey.AskOne(&survey.Input{
+	_ = ask.One(&survey.Input{
 		Message: "Task role (ARN on AWS; press Enter to reuse execution role):",
 	}, &taskArn)
 
@@ -217,7 +218,7 @@ func PromptBYOIAM(opts *models.LoadTestDeploymentOptions) error {
 // Returns error if user confirms BYO but provides invalid input
 func PromptBYOSecurityGroups(opts *models.LoadTestDeploymentOptions) error {
 	var useSG bool
-	_ = survey.AskOne(&survey.Confirm{
+	_ = ask.One(&survey.Confirm{
 		Message: "Use existing Security Groups (ALB & ECS)?",
 		Default: false,
 	}, &useSG)
@@ -229,10 +230,10 @@ func PromptBYOSecurityGroups(opts *models.LoadTestDeploymentOptions) error {
 	opts.UseExistingSecurityGroups = true
 
 	var albSG, ecsSG string
-	_ = survey.AskOne(&survey.Input{
+	_ = ask.One(&survey.Input{
 		Message: "ALB Security Group ID:",
 	}, &albSG)
-	_ = survey.AskOne(&survey.Input{
+	_ = ask.One(&survey.Input{
 		Message: "ECS Tasks Security Group ID:",
 	}, &ecsSG)
 
@@ -253,7 +254,7 @@ func PromptBYOSecurityGroups(opts *models.LoadTestDeploymentOptions) error {
 // Returns error if input cannot be parsed as a non-negative integer
 func PromptWorkerCount(opts *models.LoadTestDeploymentOptions) error {
 	var workerStr string
-	_ = survey.AskOne(&survey.Input{
+	_ = ask.One(&survey.Input{
 		Message: "Desired worker count (0 for none):",
 		Default: "0",
 	}, &workerStr)
@@ -275,24 +276,27 @@ func PromptWorkerCount(opts *models.LoadTestDeploymentOptions) error {
 // PromptAllBYOOptions prompts for all BYO options in sequence
 // This is a convenience function that combines all BYO prompts
 func PromptAllBYOOptions(opts *models.LoadTestDeploymentOptions) error {
-	// Prompt for networking
-	if err := PromptBYONetworking(opts); err != nil {
-		return err
-	}
+	// The Azure stack creates its own network and identity; there is nothing to bring
+	if opts.Provider != "azure" {
+		// Prompt for networking
+		if err := PromptBYONetworking(opts); err != nil {
+			return err
+		}
 
-	// Optional: Internet Gateway prompt for BYO VPC
-	if err := PromptBYOIGW(opts); err != nil {
-		return err
-	}
+		// Optional: Internet Gateway prompt for BYO VPC
+		if err := PromptBYOIGW(opts); err != nil {
+			return err
+		}
 
-	// Prompt for security groups
-	if err := PromptBYOSecurityGroups(opts); err != nil {
-		return err
-	}
+		// Prompt for security groups
+		if err := PromptBYOSecurityGroups(opts); err != nil {
+			return err
+		}
 
-	// Prompt for IAM
-	if err := PromptBYOIAM(opts); err != nil {
-		return err
+		// Prompt for IAM
+		if err := PromptBYOIAM(opts); err != nil {
+			return err
+		}
 	}
 
 	// Prompt for extra environment (.env + manual)
@@ -0,0 +1,599 @@
+package protofile
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Wire types of the protobuf encoding
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var scalarTypes = map[string]bool{
+	"double": true, "float": true, "int32": true, "int64": true, "uint32": true, "uint64": true,
+	"sint32": true, "sint64": true, "fixed32": true, "fixed64": true, "sfixed32": true, "sfixed64": true,
+	"bool": true, "string": true, "bytes": true,
+}
+
+func isScalar(typ string) bool {
+	return scalarTypes[typ]
+}
+
+// Well-known types with a special JSON mapping
+const (
+	typeTimestamp = "google.protobuf.Timestamp"
+	typeDuration  = "google.protobuf.Duration"
+	typeEmpty     = "google.protobuf.Empty"
+)
+
+// wrapperTypes maps each google.protobuf wrapper message to its value type
+var wrapperTypes = map[string]string{
+	"google.protobuf.DoubleValue": "double", "google.protobuf.FloatValue": "float",
+	"google.protobuf.Int64Value": "int64", "google.protobuf.UInt64Value": "uint64",
+	"google.protobuf.Int32Value": "int32", "google.protobuf.UInt32Value": "uint32",
+	"google.protobuf.BoolValue": "bool", "google.protobuf.StringValue": "string",
+	"google.protobuf.BytesValue": "bytes",
+}
+
+func wellKnownMessages() []*Message {
+	seconds := func() []*Field {
+		return []*Field{
+			{Name: "seconds", JSONName: "seconds", Number: 1, Type: "int64"},
+			{Name: "nanos", JSONName: "nanos", Number: 2, Type: "int32"},
+		}
+	}
+	out := []*Message{
+		{FullName: typeTimestamp, Fields: seconds()},
+		{FullName: typeDuration, Fields: seconds()},
+		{FullName: typeEmpty},
+	}
+	for name, typ := range wrapperTypes {
+		out = append(out, &Message{FullName: name, Fields: []*Field{{Name: "value", JSONName: "value", Number: 1, Type: typ}}})
+	}
+	return out
+}
+
+// ── Encoding (JSON -> wire) ──────────────────────────────────────────────────
+
+// Encode converts the proto3 JSON form of a typeName message into wire format. Fields are
+// looked up by JSON name or original name; unknown JSON fields are ignored.
+func (s *Set) Encode(typeName string, v any) ([]byte, error) {
+	msg, ok := s.Messages[typeName]
+	if !ok {
+		return nil, fmt.Errorf("unknown message %s", typeName)
+	}
+	return s.encodeMessage(msg, v)
+}
+
+func (s *Set) encodeMessage(msg *Message, v any) ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if special, ok, err := s.encodeWellKnown(msg, v); ok {
+		return special, err
+	}
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: expected a JSON object, got %T", msg.FullName, v)
+	}
+	return s.encodeFields(msg, obj)
+}
+
+// encodeFields encodes the object's values of the message's fields
+func (s *Set) encodeFields(msg *Message, obj map[string]any) ([]byte, error) {
+	var buf []byte
+	for _, f := range msg.Fields {
+		val, ok := obj[f.JSONName]
+		if !ok {
+			val, ok = obj[f.Name]
+		}
+		if !ok || val == nil {
+			continue
+		}
+		var err error
+		if buf, err = s.encodeField(buf, f, val); err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", msg.FullName, f.Name, err)
+		}
+	}
+	return buf, nil
+}
+
+func (s *Set) encodeField(buf []byte, f *Field, val any) ([]byte, error) {
+	if entry, ok := s.Messages[f.Type]; ok && entry.MapEntry {
+		obj, ok := val.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected a JSON object for a map field, got %T", val)
+		}
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			var key any = k
+			if keyType := entry.Fields[0].Type; keyType == "bool" {
+				key = k == "true"
+			}
+			data, err := s.encodeFields(entry, map[string]any{"key": key, "value": obj[k]})
+			if err != nil {
+				return nil, err
+			}
+			buf = appendTag(buf, f.Number, wireBytes)
+			buf = appendBytes(buf, data)
+		}
+		return buf, nil
+	}
+	if !f.Repeated {
+		return s.encodeValue(buf, f, val)
+	}
+	list, ok := val.([]any)
+	if !ok {
+		return nil, fmt.Errorf("expected a JSON array for a repeated field, got %T", val)
+	}
+	if packable(f.Type, s) {
+		var packed []byte
+		for _, item := range list {
+			var err error
+			if packed, err = s.appendScalar(packed, f.Type, item); err != nil {
+				return nil, err
+			}
+		}
+		buf = appendTag(buf, f.Number, wireBytes)
+		return appendBytes(buf, packed), nil
+	}
+	for _, item := range list {
+		var err error
+		if buf, err = s.encodeValue(buf, f, item); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// encodeValue appends one tagged value of the field's type
+func (s *Set) encodeValue(buf []byte, f *Field, val any) ([]byte, error) {
+	if msg, ok := s.Messages[f.Type]; ok {
+		data, err := s.encodeMessage(msg, val)
+		if err != nil {
+			return nil, err
+		}
+		buf = appendTag(buf, f.Number, wireBytes)
+		return appendBytes(buf, data), nil
+	}
+	buf = appendTag(buf, f.Number, wireTypeOf(f.Type, s))
+	return s.appendScalar(buf, f.Type, val)
+}
+
+// appendScalar appends an untagged scalar or enum value
+func (s *Set) appendScalar(buf []byte, typ string, val any) ([]byte, error) {
+	if enum, ok := s.Enums[typ]; ok {
+		n, err := enumNumber(enum, val)
+		if err != nil {
+			return nil, err
+		}
+		return binary.AppendUvarint(buf, uint64(int64(n))), nil
+	}
+	switch typ {
+	case "string":
+		str, ok := val.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string, got %T", val)
+		}
+		return appendBytes(buf, []byte(str)), nil
+	case "bytes":
+		str, ok := val.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected base64 bytes, got %T", val)
+		}
+		data, err := base64.StdEncoding.DecodeString(str)
+		if err != nil {
+			if data, err = base64.URLEncoding.DecodeString(str); err != nil {
+				return nil, fmt.Errorf("bytes value is not base64")
+			}
+		}
+		return appendBytes(buf, data), nil
+	case "bool":
+		b, ok := val.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected a boolean, got %T", val)
+		}
+		if b {
+			return append(buf, 1), nil
+		}
+		return append(buf, 0), nil
+	case "double":
+		f, err := toFloat(val)
+		return binary.LittleEndian.AppendUint64(buf, math.Float64bits(f)), err
+	case "float":
+		f, err := toFloat(val)
+		return binary.LittleEndian.AppendUint32(buf, math.Float32bits(float32(f))), err
+	}
+	n, err := toInt(val)
+	if err != nil {
+		return nil, err
+	}
+	switch typ {
+	case "int32", "int64", "uint32", "uint64":
+		return binary.AppendUvarint(buf, uint64(n)), nil
+	case "sint32", "sint64":
+		return binary.AppendUvarint(buf, uint64((n<<1)^(n>>63))), nil
+	case "fixed32", "sfixed32":
+		return binary.LittleEndian.AppendUint32(buf, uint32(n)), nil
+	case "fixed64", "sfixed64":
+		return binary.LittleEndian.AppendUint64(buf, uint64(n)), nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", typ)
+}
+
+// encodeWellKnown handles the types whose JSON form is not an object of their fields
+func (s *Set) encodeWellKnown(msg *Message, v any) ([]byte, bool, error) {
+	switch msg.FullName {
+	case typeTimestamp:
+		str, ok := v.(string)
+		if !ok {
+			return nil, true, fmt.Errorf("timestamp must be an RFC 3339 string")
+		}
+		t, err := time.Parse(time.RFC3339Nano, str)
+		if err != nil {
+			return nil, true, fmt.Errorf("timestamp: %w", err)
+		}
+		data, err := s.encodeFields(msg, map[string]any{"seconds": float64(t.Unix()), "nanos": float64(t.Nanosecond())})
+		return data, true, err
+	case typeDuration:
+		str, ok := v.(string)
+		if !ok || !strings.HasSuffix(str, "s") {
+			return nil, true, fmt.Errorf(`duration must be a string such as "1.5s"`)
+		}
+		secs, err := strconv.ParseFloat(strings.TrimSuffix(str, "s"), 64)
+		if err != nil {
+			return nil, true, fmt.Errorf("duration: %w", err)
+		}
+		whole := math.Trunc(secs)
+		data, err := s.encodeFields(msg, map[string]any{"seconds": whole, "nanos": math.Round((secs - whole) * 1e9)})
+		return data, true, err
+	}
+	if _, ok := wrapperTypes[msg.FullName]; ok {
+		if _, isObject := v.(map[string]any); !isObject {
+			data, err := s.encodeFields(msg, map[string]any{"value": v})
+			return data, true, err
+		}
+	}
+	return nil, false, nil
+}
+
+func packable(typ string, s *Set) bool {
+	if _, ok := s.Enums[typ]; ok {
+		return true
+	}
+	return isScalar(typ) && typ != "string" && typ != "bytes"
+}
+
+func wireTypeOf(typ string, s *Set) int {
+	switch typ {
+	case "double", "fixed64", "sfixed64":
+		return wireFixed64
+	case "float", "fixed32", "sfixed32":
+		return wireFixed32
+	case "string", "bytes":
+		return wireBytes
+	}
+	if _, ok := s.Messages[typ]; ok {
+		return wireBytes
+	}
+	return wireVarint
+}
+
+func appendTag(buf []byte, number, wireType int) []byte {
+	return binary.AppendUvarint(buf, uint64(number)<<3|uint64(wireType))
+}
+
+func appendBytes(buf, data []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(data)))
+	return append(buf, data...)
+}
+
+func enumNumber(enum *Enum, val any) (int, error) {
+	if name, ok := val.(string); ok {
+		for _, v := range enum.Values {
+			if v.Name == name {
+				return v.Number, nil
+			}
+		}
+		return 0, fmt.Errorf("%q is not a value of %s", name, enum.FullName)
+	}
+	n, err := toInt(val)
+	return int(n), err
+}
+
+// toInt accepts JSON numbers and the quoted integers proto3 JSON uses for 64-bit fields
+func toInt(val any) (int64, error) {
+	switch v := val.(type) {
+	case float64:
+		return int64(v), nil
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case json.Number:
+		return v.Int64()
+	case string:
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n, nil
+		}
+		u, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not an integer", v)
+		}
+		return int64(u), nil
+	}
+	return 0, fmt.Errorf("expected an integer, got %T", val)
+}
+
+func toFloat(val any) (float64, error) {
+	switch v := val.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		switch v {
+		case "NaN":
+			return math.NaN(), nil
+		case "Infinity":
+			return math.Inf(1), nil
+		case "-Infinity":
+			return math.Inf(-1), nil
+		}
+		return strconv.ParseFloat(v, 64)
+	}
+	return 0, fmt.Errorf("expected a number, got %T", val)
+}
+
+// ── Decoding (wire -> JSON) ──────────────────────────────────────────────────
+
+var errTruncated = errors.New("truncated message")
+
+// Decode converts a wire-format typeName message into its proto3 JSON form. Unset fields are
+// omitted, 64-bit integers are strings and enums are names, as protoc's JSON printer does.
+func (s *Set) Decode(typeName string, data []byte) (any, error) {
+	msg, ok := s.Messages[typeName]
+	if !ok {
+		return nil, fmt.Errorf("unknown message %s", typeName)
+	}
+	return s.decodeMessage(msg, data)
+}
+
+func (s *Set) decodeMessage(msg *Message, data []byte) (any, error) {
+	byNumber := make(map[int]*Field, len(msg.Fields))
+	for _, f := range msg.Fields {
+		byNumber[f.Number] = f
+	}
+	out := map[string]any{}
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, errTruncated
+		}
+		data = data[n:]
+		number, wireType := int(tag>>3), int(tag&7)
+		raw, rest, err := splitValue(data, wireType)
+		if err != nil {
+			return nil, err
+		}
+		data = rest
+		f, known := byNumber[number]
+		if !known {
+			continue
+		}
+		if err := s.decodeField(out, f, wireType, raw); err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", msg.FullName, f.Name, err)
+		}
+	}
+	return s.decodeWellKnown(msg, out), nil
+}
+
+func (s *Set) decodeField(out map[string]any, f *Field, wireType int, raw []byte) error {
+	if entry, ok := s.Messages[f.Type]; ok && entry.MapEntry {
+		decoded, err := s.decodeMessage(entry, raw)
+		if err != nil {
+			return err
+		}
+		kv, _ := decoded.(map[string]any)
+		m, _ := out[f.JSONName].(map[string]any)
+		if m == nil {
+			m = map[string]any{}
+			out[f.JSONName] = m
+		}
+		value := kv["value"]
+		if value == nil {
+			value = s.zero(entry.Fields[1].Type)
+		}
+		key := kv["key"]
+		if key == nil {
+			key = s.zero(entry.Fields[0].Type)
+		}
+		m[fmt.Sprint(key)] = value
+		return nil
+	}
+
+	var values []any
+	if f.Repeated && wireType == wireBytes && packable(f.Type, s) {
+		for len(raw) > 0 {
+			item, rest, err := splitValue(raw, wireTypeOf(f.Type, s))
+			if err != nil {
+				return err
+			}
+			raw = rest
+			v, err := s.decodeScalar(f.Type, item)
+			if err != nil {
+				return err
+			}
+			values = append(values, v)
+		}
+	} else {
+		var v any
+		var err error
+		if msg, ok := s.Messages[f.Type]; ok {
+			v, err = s.decodeMessage(msg, raw)
+		} else {
+			v, err = s.decodeScalar(f.Type, raw)
+		}
+		if err != nil {
+			return err
+		}
+		values = []any{v}
+	}
+
+	if !f.Repeated {
+		out[f.JSONName] = values[len(values)-1]
+		return nil
+	}
+	list, _ := out[f.JSONName].([]any)
+	out[f.JSONName] = append(list, values...)
+	return nil
+}
+
+// splitValue cuts one value of the wire type off data; varints and fixed values are returned
+// as their raw bytes
+func splitValue(data []byte, wireType int) (value, rest []byte, err error) {
+	switch wireType {
+	case wireVarint:
+		_, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, nil, errTruncated
+		}
+		return data[:n], data[n:], nil
+	case wireFixed64:
+		if len(data) < 8 {
+			return nil, nil, errTruncated
+		}
+		return data[:8], data[8:], nil
+	case wireFixed32:
+		if len(data) < 4 {
+			return nil, nil, errTruncated
+		}
+		return data[:4], data[4:], nil
+	case wireBytes:
+		size, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < size {
+			return nil, nil, errTruncated
+		}
+		end := n + int(size)
+		return data[n:end], data[end:], nil
+	}
+	return nil, nil, fmt.Errorf("unsupported wire type %d", wireType)
+}
+
+func (s *Set) decodeScalar(typ string, raw []byte) (any, error) {
+	varint := func() uint64 {
+		v, _ := binary.Uvarint(raw)
+		return v
+	}
+	if enum, ok := s.Enums[typ]; ok {
+		n := int(int32(varint()))
+		for _, v := range enum.Values {
+			if v.Number == n {
+				return v.Name, nil
+			}
+		}
+		return n, nil
+	}
+	switch typ {
+	case "string":
+		return string(raw), nil
+	case "bytes":
+		return base64.StdEncoding.EncodeToString(raw), nil
+	case "bool":
+		return varint() != 0, nil
+	case "int32":
+		return int(int32(varint())), nil
+	case "uint32":
+		return int(uint32(varint())), nil
+	case "sint32":
+		v := varint()
+		return int(int32(v>>1) ^ -int32(v&1)), nil
+	case "int64":
+		return strconv.FormatInt(int64(varint()), 10), nil
+	case "uint64":
+		return strconv.FormatUint(varint(), 10), nil
+	case "sint64":
+		v := varint()
+		return strconv.FormatInt(int64(v>>1)^-int64(v&1), 10), nil
+	case "fixed32":
+		return int(binary.LittleEndian.Uint32(raw)), nil
+	case "sfixed32":
+		return int(int32(binary.LittleEndian.Uint32(raw))), nil
+	case "fixed64":
+		return strconv.FormatUint(binary.LittleEndian.Uint64(raw), 10), nil
+	case "sfixed64":
+		return strconv.FormatInt(int64(binary.LittleEndian.Uint64(raw)), 10), nil
+	case "float":
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(raw))), nil
+	case "double":
+		return math.Float64frombits(binary.LittleEndian.Uint64(raw)), nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", typ)
+}
+
+// decodeWellKnown turns decoded Timestamp, Duration and wrapper fields into their JSON form
+func (s *Set) decodeWellKnown(msg *Message, fields map[string]any) any {
+	seconds := func() (int64, int64) {
+		sec, _ := toInt(fields["seconds"])
+		nanos, _ := toInt(fields["nanos"])
+		return sec, nanos
+	}
+	switch msg.FullName {
+	case typeTimestamp:
+		sec, nanos := seconds()
+		return time.Unix(sec, nanos).UTC().Format(time.RFC3339Nano)
+	case typeDuration:
+		sec, nanos := seconds()
+		d := float64(sec) + float64(nanos)/1e9
+		return strconv.FormatFloat(d, 'f', -1, 64) + "s"
+	}
+	if typ, ok := wrapperTypes[msg.FullName]; ok {
+		if v, set := fields["value"]; set {
+			return v
+		}
+		return s.zero(typ)
+	}
+	return fields
+}
+
+// zero is the JSON form of a type's default value
+func (s *Set) zero(typ string) any {
+	if enum, ok := s.Enums[typ]; ok {
+		for _, v := range enum.Values {
+			if v.Number == 0 {
+				return v.Name
+			}
+		}
+		return 0
+	}
+	switch typ {
+	case "string", "bytes":
+		return ""
+	case "bool":
+		return false
+	case "int64", "uint64", "sint64", "fixed64", "sfixed64":
+		return "0"
+	}
+	if isScalar(typ) {
+		return 0
+	}
+	return map[string]any{}
+}
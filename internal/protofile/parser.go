@@ -0,0 +1,855 @@
+// Package protofile parses .proto files and converts messages between the protobuf wire
+// format and their proto3 JSON mapping, so gRPC methods can be mocked without generated code.
+package protofile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Set is every message, enum and service declared by a group of .proto files and their imports
+type Set struct {
+	Messages map[string]*Message // By full name, e.g. "shop.v1.Order"
+	Enums    map[string]*Enum
+	Services []*Service
+	Files    []string // Files loaded, imports included
+}
+
+// Service is a gRPC service
+type Service struct {
+	Name     string
+	FullName string // e.g. "shop.v1.OrderService"
+	File     string // Name of the declaring file
+	Methods  []*Method
+}
+
+// Method is one RPC of a service
+type Method struct {
+	Name            string
+	Service         string // Full service name
+	Input           string // Full message name of the request
+	Output          string // Full message name of the response
+	ClientStreaming bool
+	ServerStreaming bool
+	HTTP            *HTTPRule // google.api.http transcoding, when annotated
+}
+
+// HTTPRule is a method's google.api.http annotation
+type HTTPRule struct {
+	Method string // GET, POST, PUT, PATCH, DELETE
+	Path   string // Template with {field} placeholders, e.g. /v1/orders/{id}
+	Body   string // "*", a request field name, or empty for no body
+}
+
+// Message is a message type; map fields point at a synthetic MapEntry message
+type Message struct {
+	FullName string
+	Fields   []*Field
+	MapEntry bool // key = 1, value = 2
+}
+
+// Field is one field of a message
+type Field struct {
+	Name     string
+	JSONName string // lowerCamelCase, or the json_name option
+	Number   int
+	Type     string // Scalar type (int32, string, ...) or the full name of a message or enum
+	Repeated bool
+	Oneof    string
+}
+
+// Enum is an enum type
+type Enum struct {
+	FullName string
+	Values   []EnumValue
+}
+
+// EnumValue is one named enum constant
+type EnumValue struct {
+	Name   string
+	Number int
+}
+
+// Path is the HTTP/2 path gRPC clients call, e.g. /shop.v1.OrderService/GetOrder
+func (m *Method) Path() string {
+	return "/" + m.Service + "/" + m.Name
+}
+
+// Method finds a method by its gRPC path
+func (s *Set) Method(path string) *Method {
+	for _, svc := range s.Services {
+		for _, m := range svc.Methods {
+			if m.Path() == path {
+				return m
+			}
+		}
+	}
+	return nil
+}
+
+// Methods returns every method of every service, in declaration order
+func (s *Set) Methods() []*Method {
+	var out []*Method
+	for _, svc := range s.Services {
+		out = append(out, svc.Methods...)
+	}
+	return out
+}
+
+// Load parses .proto files and, recursively, the files they import. Imports are looked up
+// relative to the importing file's directory and its parents; the google/protobuf well-known
+// types are built in, and other missing imports (e.g. google/api/annotations.proto) are
+// skipped as long as no type from them is used.
+func Load(paths ...string) (*Set, error) {
+	set := newSet()
+	var pending []*parsedFile
+	loaded := map[string]bool{}
+	var load func(path string) error
+	load = func(path string) error {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			abs = path
+		}
+		if loaded[abs] {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", path, err)
+		}
+		loaded[abs] = true
+		file, err := parse(filepath.Base(path), string(data))
+		if err != nil {
+			return err
+		}
+		set.Files = append(set.Files, path)
+		pending = append(pending, file)
+		for _, imp := range file.imports {
+			if strings.HasPrefix(imp, "google/protobuf/") {
+				continue
+			}
+			if found := findImport(filepath.Dir(path), imp); found != "" {
+				if err := load(found); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	for _, p := range paths {
+		if err := load(p); err != nil {
+			return nil, err
+		}
+	}
+	if err := set.add(pending...); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// Parse parses a single .proto source without following its imports
+func Parse(name, source string) (*Set, error) {
+	file, err := parse(name, source)
+	if err != nil {
+		return nil, err
+	}
+	set := newSet()
+	set.Files = []string{name}
+	if err := set.add(file); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// findImport looks for an import path in dir and its parents
+func findImport(dir, imp string) string {
+	for {
+		candidate := filepath.Join(dir, filepath.FromSlash(imp))
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func newSet() *Set {
+	set := &Set{Messages: map[string]*Message{}, Enums: map[string]*Enum{}}
+	for _, m := range wellKnownMessages() {
+		set.Messages[m.FullName] = m
+	}
+	return set
+}
+
+// parsedFile is one file's declarations before type names are resolved
+type parsedFile struct {
+	name     string
+	pkg      string
+	imports  []string
+	messages []*Message
+	enums    []*Enum
+	services []*Service
+	scopes   map[*Field]string // Scope each field's type name is resolved from
+}
+
+// add registers the files' declarations, then resolves every field and method type name
+func (s *Set) add(files ...*parsedFile) error {
+	for _, f := range files {
+		for _, m := range f.messages {
+			if _, dup := s.Messages[m.FullName]; dup {
+				return fmt.Errorf("%s: message %s is declared twice", f.name, m.FullName)
+			}
+			s.Messages[m.FullName] = m
+		}
+		for _, e := range f.enums {
+			s.Enums[e.FullName] = e
+		}
+		s.Services = append(s.Services, f.services...)
+	}
+	for _, f := range files {
+		for field, scope := range f.scopes {
+			if isScalar(field.Type) {
+				continue
+			}
+			full, ok := s.resolve(scope, field.Type)
+			if !ok {
+				return fmt.Errorf("%s: unknown type %s for field %s (is its file imported?)", f.name, field.Type, field.Name)
+			}
+			field.Type = full
+		}
+		for _, svc := range f.services {
+			for _, m := range svc.Methods {
+				for _, name := range []*string{&m.Input, &m.Output} {
+					full, ok := s.resolve(f.pkg, *name)
+					if _, isMessage := s.Messages[full]; !ok || !isMessage {
+						return fmt.Errorf("%s: unknown message %s in rpc %s.%s", f.name, *name, svc.Name, m.Name)
+					}
+					*name = full
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// resolve finds a type name the way protoc does: from the innermost scope outwards
+func (s *Set) resolve(scope, name string) (string, bool) {
+	if strings.HasPrefix(name, ".") {
+		name = name[1:]
+		return name, s.exists(name)
+	}
+	for {
+		candidate := name
+		if scope != "" {
+			candidate = scope + "." + name
+		}
+		if s.exists(candidate) {
+			return candidate, true
+		}
+		if scope == "" {
+			return "", false
+		}
+		if i := strings.LastIndex(scope, "."); i >= 0 {
+			scope = scope[:i]
+		} else {
+			scope = ""
+		}
+	}
+}
+
+func (s *Set) exists(name string) bool {
+	_, isMessage := s.Messages[name]
+	_, isEnum := s.Enums[name]
+	return isMessage || isEnum
+}
+
+// ── Parser ───────────────────────────────────────────────────────────────────
+
+type parser struct {
+	file   *parsedFile
+	tokens []string
+	lines  []int
+	pos    int
+}
+
+func parse(name, source string) (*parsedFile, error) {
+	tokens, lines, err := tokenize(source)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	p := &parser{file: &parsedFile{name: name, scopes: map[*Field]string{}}, tokens: tokens, lines: lines}
+	if err := p.parseFile(); err != nil {
+		return nil, err
+	}
+	return p.file, nil
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	line := 0
+	if p.pos < len(p.lines) {
+		line = p.lines[p.pos]
+	} else if len(p.lines) > 0 {
+		line = p.lines[len(p.lines)-1]
+	}
+	return fmt.Errorf("%s:%d: %s", p.file.name, line, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *parser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *parser) expect(want string) error {
+	if got := p.next(); got != want {
+		return p.errorf("expected %q, found %q", want, got)
+	}
+	return nil
+}
+
+func (p *parser) parseFile() error {
+	for p.pos < len(p.tokens) {
+		switch tok := p.next(); tok {
+		case ";":
+		case "syntax", "edition":
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		case "package":
+			p.file.pkg = p.next()
+			if err := p.expect(";"); err != nil {
+				return err
+			}
+		case "import":
+			if t := p.peek(); t == "public" || t == "weak" {
+				p.next()
+			}
+			imp, err := unquote(p.next())
+			if err != nil {
+				return p.errorf("bad import: %v", err)
+			}
+			p.file.imports = append(p.file.imports, imp)
+			if err := p.expect(";"); err != nil {
+				return err
+			}
+		case "option":
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		case "message":
+			if err := p.parseMessage(p.file.pkg); err != nil {
+				return err
+			}
+		case "enum":
+			if err := p.parseEnum(p.file.pkg); err != nil {
+				return err
+			}
+		case "service":
+			if err := p.parseService(); err != nil {
+				return err
+			}
+		case "extend":
+			p.next()
+			if err := p.skipBlock(); err != nil {
+				return err
+			}
+		default:
+			return p.errorf("unexpected %q", tok)
+		}
+	}
+	return nil
+}
+
+func qualify(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+func (p *parser) parseMessage(scope string) error {
+	msg := &Message{FullName: qualify(scope, p.next())}
+	p.file.messages = append(p.file.messages, msg)
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	return p.parseMessageBody(msg, "")
+}
+
+// parseMessageBody reads fields and nested declarations up to the closing brace; oneof
+// bodies are read with the oneof's name
+func (p *parser) parseMessageBody(msg *Message, oneof string) error {
+	for {
+		switch tok := p.next(); tok {
+		case "}":
+			return nil
+		case "":
+			return p.errorf("unexpected end of file in message %s", msg.FullName)
+		case ";":
+		case "message":
+			if err := p.parseMessage(msg.FullName); err != nil {
+				return err
+			}
+		case "enum":
+			if err := p.parseEnum(msg.FullName); err != nil {
+				return err
+			}
+		case "oneof":
+			name := p.next()
+			if err := p.expect("{"); err != nil {
+				return err
+			}
+			if err := p.parseMessageBody(msg, name); err != nil {
+				return err
+			}
+		case "option", "reserved", "extensions":
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		case "extend":
+			p.next()
+			if err := p.skipBlock(); err != nil {
+				return err
+			}
+		case "group":
+			return p.errorf("proto2 groups are not supported (message %s)", msg.FullName)
+		case "map":
+			if err := p.parseMapField(msg); err != nil {
+				return err
+			}
+		default:
+			field := &Field{Oneof: oneof}
+			switch tok {
+			case "repeated":
+				field.Repeated = true
+				tok = p.next()
+			case "optional", "required":
+				tok = p.next()
+			}
+			field.Type = tok
+			if err := p.parseFieldRest(field); err != nil {
+				return err
+			}
+			msg.Fields = append(msg.Fields, field)
+			p.file.scopes[field] = msg.FullName
+		}
+	}
+}
+
+// parseFieldRest reads "name = number [options];"
+func (p *parser) parseFieldRest(field *Field) error {
+	field.Name = p.next()
+	if !isIdent(field.Name) {
+		return p.errorf("expected a field name, found %q", field.Name)
+	}
+	if err := p.expect("="); err != nil {
+		return err
+	}
+	n, err := parseNumber(p.next())
+	if err != nil {
+		return p.errorf("field %s: bad field number", field.Name)
+	}
+	field.Number = n
+	field.JSONName = jsonName(field.Name)
+	if p.peek() == "[" {
+		p.next()
+		for depth := 1; depth > 0; {
+			tok := p.next()
+			switch tok {
+			case "":
+				return p.errorf("unterminated options for field %s", field.Name)
+			case "[", "{":
+				depth++
+			case "]", "}":
+				depth--
+			case "json_name":
+				if p.peek() == "=" {
+					p.next()
+					if v, err := unquote(p.next()); err == nil {
+						field.JSONName = v
+					}
+				}
+			}
+		}
+	}
+	return p.expect(";")
+}
+
+// parseMapField turns map<K, V> name = N; into a repeated field of a synthetic entry message
+func (p *parser) parseMapField(msg *Message) error {
+	if err := p.expect("<"); err != nil {
+		return err
+	}
+	keyType := p.next()
+	if err := p.expect(","); err != nil {
+		return err
+	}
+	valueType := p.next()
+	if err := p.expect(">"); err != nil {
+		return err
+	}
+	field := &Field{Repeated: true}
+	if err := p.parseFieldRest(field); err != nil {
+		return err
+	}
+	entry := &Message{FullName: msg.FullName + "." + entryName(field.Name), MapEntry: true}
+	key := &Field{Name: "key", JSONName: "key", Number: 1, Type: keyType}
+	value := &Field{Name: "value", JSONName: "value", Number: 2, Type: valueType}
+	entry.Fields = []*Field{key, value}
+	p.file.messages = append(p.file.messages, entry)
+	p.file.scopes[value] = msg.FullName
+	field.Type = entry.FullName
+	msg.Fields = append(msg.Fields, field)
+	return nil
+}
+
+func (p *parser) parseEnum(scope string) error {
+	enum := &Enum{FullName: qualify(scope, p.next())}
+	p.file.enums = append(p.file.enums, enum)
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for {
+		switch tok := p.next(); tok {
+		case "}":
+			return nil
+		case "":
+			return p.errorf("unexpected end of file in enum %s", enum.FullName)
+		case ";":
+		case "option", "reserved":
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		default:
+			if err := p.expect("="); err != nil {
+				return err
+			}
+			n, err := parseNumber(p.next())
+			if err != nil {
+				return p.errorf("enum %s: bad value for %s", enum.FullName, tok)
+			}
+			enum.Values = append(enum.Values, EnumValue{Name: tok, Number: n})
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (p *parser) parseService() error {
+	name := p.next()
+	svc := &Service{Name: name, FullName: qualify(p.file.pkg, name), File: p.file.name}
+	p.file.services = append(p.file.services, svc)
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for {
+		switch tok := p.next(); tok {
+		case "}":
+			return nil
+		case "":
+			return p.errorf("unexpected end of file in service %s", svc.Name)
+		case ";":
+		case "option":
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		case "rpc":
+			m, err := p.parseRPC(svc)
+			if err != nil {
+				return err
+			}
+			svc.Methods = append(svc.Methods, m)
+		default:
+			return p.errorf("unexpected %q in service %s", tok, svc.Name)
+		}
+	}
+}
+
+// parseRPC reads "rpc Name (stream In) returns (stream Out);" or the same with an options block
+func (p *parser) parseRPC(svc *Service) (*Method, error) {
+	m := &Method{Name: p.next(), Service: svc.FullName}
+	readType := func() (string, bool, error) {
+		if err := p.expect("("); err != nil {
+			return "", false, err
+		}
+		stream := false
+		if p.peek() == "stream" {
+			p.next()
+			stream = true
+		}
+		name := p.next()
+		return name, stream, p.expect(")")
+	}
+	var err error
+	if m.Input, m.ClientStreaming, err = readType(); err != nil {
+		return nil, err
+	}
+	if err := p.expect("returns"); err != nil {
+		return nil, err
+	}
+	if m.Output, m.ServerStreaming, err = readType(); err != nil {
+		return nil, err
+	}
+	switch p.next() {
+	case ";":
+		return m, nil
+	case "{":
+	default:
+		return nil, p.errorf("expected ; or { after rpc %s", m.Name)
+	}
+	for {
+		switch tok := p.next(); tok {
+		case "}":
+			return m, nil
+		case "":
+			return nil, p.errorf("unexpected end of file in rpc %s", m.Name)
+		case ";":
+		case "option":
+			if p.peek() == "(" && p.pos+2 < len(p.tokens) && p.tokens[p.pos+1] == "google.api.http" {
+				p.pos += 3
+				if err := p.expect("="); err != nil {
+					return nil, err
+				}
+				if m.HTTP, err = p.parseHTTPRule(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if err := p.skipStatement(); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, p.errorf("unexpected %q in rpc %s", tok, m.Name)
+		}
+	}
+}
+
+// parseHTTPRule reads the google.api.http option value; the first binding is used
+func (p *parser) parseHTTPRule() (*HTTPRule, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	rule := &HTTPRule{}
+	for depth := 1; depth > 0; {
+		tok := p.next()
+		switch tok {
+		case "":
+			return nil, p.errorf("unterminated google.api.http option")
+		case "{":
+			depth++
+		case "}":
+			depth--
+		case "get", "post", "put", "patch", "delete", "body":
+			if p.peek() != ":" || depth != 1 {
+				continue
+			}
+			p.next()
+			value, err := unquote(p.next())
+			if err != nil {
+				return nil, p.errorf("google.api.http %s: %v", tok, err)
+			}
+			if tok == "body" {
+				rule.Body = value
+			} else if rule.Method == "" {
+				rule.Method = strings.ToUpper(tok)
+				rule.Path = templatePath(value)
+			}
+		}
+	}
+	if p.peek() == ";" {
+		p.next()
+	}
+	if rule.Method == "" {
+		return nil, nil
+	}
+	return rule, nil
+}
+
+// skipStatement skips to the end of the statement, across nested option values
+func (p *parser) skipStatement() error {
+	depth := 0
+	for {
+		switch p.next() {
+		case "":
+			return p.errorf("unexpected end of file")
+		case "{", "[", "(":
+			depth++
+		case "}", "]", ")":
+			depth--
+		case ";":
+			if depth <= 0 {
+				return nil
+			}
+		}
+	}
+}
+
+// skipBlock skips a { ... } block
+func (p *parser) skipBlock() error {
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for depth := 1; depth > 0; {
+		switch p.next() {
+		case "":
+			return p.errorf("unexpected end of file")
+		case "{":
+			depth++
+		case "}":
+			depth--
+		}
+	}
+	return nil
+}
+
+// ── Tokenizer ────────────────────────────────────────────────────────────────
+
+// tokenize splits proto source into identifiers (dotted names included), numbers, quoted
+// strings and single-character symbols, dropping comments. It also returns each token's line.
+func tokenize(src string) ([]string, []int, error) {
+	var tokens []string
+	var lines []int
+	line := 1
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, nil, fmt.Errorf("line %d: unterminated comment", line)
+			}
+			line += strings.Count(src[i:i+2+end], "\n")
+			i += end + 4
+		case c == '"' || c == '\'':
+			j := i + 1
+			for j < len(src) && src[j] != c {
+				if src[j] == '\\' {
+					j++
+				}
+				if j < len(src) && src[j] == '\n' {
+					return nil, nil, fmt.Errorf("line %d: unterminated string", line)
+				}
+				j++
+			}
+			if j >= len(src) {
+				return nil, nil, fmt.Errorf("line %d: unterminated string", line)
+			}
+			tokens = append(tokens, src[i:j+1])
+			lines = append(lines, line)
+			i = j + 1
+		case c == '_' || c == '.' || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c)) || c == '-' || c == '+':
+			j := i + 1
+			for j < len(src) && (src[j] == '_' || src[j] == '.' || unicode.IsLetter(rune(src[j])) || unicode.IsDigit(rune(src[j])) ||
+				((src[j] == '-' || src[j] == '+') && (src[j-1] == 'e' || src[j-1] == 'E') && unicode.IsDigit(rune(src[i])))) {
+				j++
+			}
+			tokens = append(tokens, src[i:j])
+			lines = append(lines, line)
+			i = j
+		default:
+			tokens = append(tokens, string(c))
+			lines = append(lines, line)
+			i++
+		}
+	}
+	return tokens, lines, nil
+}
+
+func unquote(tok string) (string, error) {
+	if len(tok) < 2 || (tok[0] != '"' && tok[0] != '\'') {
+		return "", fmt.Errorf("expected a quoted string, found %q", tok)
+	}
+	if tok[0] == '\'' {
+		tok = `"` + strings.ReplaceAll(tok[1:len(tok)-1], `"`, `\"`) + `"`
+	}
+	return strconv.Unquote(tok)
+}
+
+// parseNumber reads a decimal, hex or octal integer literal
+func parseNumber(tok string) (int, error) {
+	n, err := strconv.ParseInt(tok, 0, 32)
+	return int(n), err
+}
+
+func isIdent(s string) bool {
+	if s == "" || unicode.IsDigit(rune(s[0])) {
+		return false
+	}
+	for _, r := range s {
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonName converts a field name to lowerCamelCase, as protoc does for JSON
+func jsonName(name string) string {
+	var sb strings.Builder
+	upper := false
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// entryName is the synthetic map entry message name protoc uses: order_items -> OrderItemsEntry
+func entryName(field string) string {
+	camel := jsonName(field)
+	return strings.ToUpper(camel[:1]) + camel[1:] + "Entry"
+}
+
+// templatePath reduces path template variables to their field name: {name=shelves/*} -> {name}
+func templatePath(path string) string {
+	var sb strings.Builder
+	for {
+		open := strings.Index(path, "{")
+		if open < 0 {
+			break
+		}
+		end := strings.Index(path[open:], "}")
+		if end < 0 {
+			break
+		}
+		field := path[open+1 : open+end]
+		if eq := strings.Index(field, "="); eq >= 0 {
+			field = field[:eq]
+		}
+		sb.WriteString(path[:open] + "{" + field + "}")
+		path = path[open+end+1:]
+	}
+	sb.WriteString(path)
+	return sb.String()
+}
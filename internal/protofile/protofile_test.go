@@ -0,0 +1,152 @@
+package protofile
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const shopProto = `
+syntax = "proto3";
+package shop.v1;
+
+import "google/api/annotations.proto";
+import "google/protobuf/timestamp.proto";
+
+/* Orders placed by customers */
+message Order {
+  string id = 1;
+  int64 total_cents = 2;
+  Status status = 3;
+  repeated Item items = 4;
+  map<string, string> labels = 5;
+  google.protobuf.Timestamp created_at = 6;
+  repeated int32 quantities = 7;
+  oneof payment {
+    string card_token = 8;
+    string voucher = 9 [json_name = "voucherCode"];
+  }
+  sint32 adjustment = 10;
+
+  message Item {
+    string sku = 1;
+    double price = 2;
+  }
+  enum Status {
+    STATUS_UNSPECIFIED = 0;
+    PLACED = 1;
+    SHIPPED = 2;
+  }
+}
+
+message GetOrderRequest { string id = 1; }
+
+service OrderService {
+  rpc GetOrder(GetOrderRequest) returns (Order) {
+    option (google.api.http) = { get: "/v1/orders/{id=*}" };
+  }
+  // Streams order updates
+  rpc Watch(GetOrderRequest) returns (stream Order);
+}
+`
+
+func TestParseServicesAndTypes(t *testing.T) {
+	set, err := Parse("shop.proto", shopProto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	methods := set.Methods()
+	if len(methods) != 2 {
+		t.Fatalf("methods = %d, want 2", len(methods))
+	}
+	get := set.Method("/shop.v1.OrderService/GetOrder")
+	if get == nil || get.Input != "shop.v1.GetOrderRequest" || get.Output != "shop.v1.Order" {
+		t.Fatalf("GetOrder = %+v", get)
+	}
+	if get.HTTP == nil || get.HTTP.Method != "GET" || get.HTTP.Path != "/v1/orders/{id}" {
+		t.Errorf("http rule = %+v", get.HTTP)
+	}
+	if !methods[1].ServerStreaming || methods[1].ClientStreaming {
+		t.Errorf("Watch streaming = %v/%v", methods[1].ClientStreaming, methods[1].ServerStreaming)
+	}
+
+	order := set.Messages["shop.v1.Order"]
+	types := map[string]string{}
+	for _, f := range order.Fields {
+		types[f.JSONName] = f.Type
+	}
+	if types["status"] != "shop.v1.Order.Status" || types["items"] != "shop.v1.Order.Item" ||
+		types["createdAt"] != "google.protobuf.Timestamp" || types["voucherCode"] != "string" {
+		t.Errorf("field types = %v", types)
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	set, err := Parse("shop.proto", shopProto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var in map[string]any
+	_ = json.Unmarshal([]byte(`{
+		"id": "o-1", "total_cents": "1999", "status": "SHIPPED",
+		"items": [{"sku": "A", "price": 9.5}, {"sku": "B"}],
+		"labels": {"gift": "yes"}, "createdAt": "2024-01-15T10:30:00Z",
+		"quantities": [1, 2, 300], "voucherCode": "SAVE10", "adjustment": -5
+	}`), &in)
+
+	wire, err := set.Encode("shop.v1.Order", in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := set.Decode("shop.v1.Order", wire)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"id": "o-1", "totalCents": "1999", "status": "SHIPPED",
+		"items":  []any{map[string]any{"sku": "A", "price": 9.5}, map[string]any{"sku": "B"}},
+		"labels": map[string]any{"gift": "yes"}, "createdAt": "2024-01-15T10:30:00Z",
+		"quantities": []any{1, 2, 300}, "voucherCode": "SAVE10", "adjustment": -5,
+	}
+	if !reflect.DeepEqual(out, want) {
+		t.Errorf("round trip:\n got %#v\nwant %#v", out, want)
+	}
+
+	// Field 1 "o-1": tag 0x0a, length 3
+	if wire[0] != 0x0a || wire[1] != 3 || string(wire[2:5]) != "o-1" {
+		t.Errorf("unexpected wire prefix % x", wire[:5])
+	}
+	if _, err := set.Encode("shop.v1.Order", map[string]any{"status": "LOST"}); err == nil {
+		t.Error("unknown enum name was encoded")
+	}
+}
+
+func TestSample(t *testing.T) {
+	set, err := Parse("shop.proto", shopProto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sample := set.Sample("shop.v1.Order").(map[string]any)
+	if sample["status"] != "PLACED" || sample["totalCents"] != "1" || sample["createdAt"] != "2024-01-15T10:30:00Z" {
+		t.Errorf("sample = %#v", sample)
+	}
+	if _, both := sample["voucherCode"]; both {
+		t.Error("sample sets two members of a oneof")
+	}
+	if _, err := set.Encode("shop.v1.Order", sample); err != nil {
+		t.Errorf("sample does not encode: %v", err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := map[string]string{
+		"unknown type": `syntax = "proto3"; message A { Missing m = 1; }`,
+		"unknown rpc":  `syntax = "proto3"; service S { rpc M(A) returns (A); }`,
+		"unterminated": `message A { string a = 1;`,
+	}
+	for name, src := range cases {
+		if _, err := Parse("bad.proto", src); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package protofile
+
+import "strings"
+
+// maxSampleDepth bounds sample generation for recursive messages
+const maxSampleDepth = 6
+
+// Sample builds an example of a message in its JSON form, with every field set to a plausible
+// value. Recursive fields stop at maxSampleDepth.
+func (s *Set) Sample(typeName string) any {
+	return s.sample(typeName, "", 0, map[string]bool{})
+}
+
+func (s *Set) sample(typ, field string, depth int, expanding map[string]bool) any {
+	switch typ {
+	case typeTimestamp:
+		return "2024-01-15T10:30:00Z"
+	case typeDuration:
+		return "1.5s"
+	}
+	if scalar, ok := wrapperTypes[typ]; ok {
+		return s.sample(scalar, field, depth, expanding)
+	}
+	if enum, ok := s.Enums[typ]; ok {
+		// Prefer the first real value over the conventional *_UNSPECIFIED zero value
+		for _, v := range enum.Values {
+			if v.Number != 0 {
+				return v.Name
+			}
+		}
+		if len(enum.Values) > 0 {
+			return enum.Values[0].Name
+		}
+		return 0
+	}
+	if msg, ok := s.Messages[typ]; ok {
+		if depth >= maxSampleDepth || expanding[typ] {
+			return nil
+		}
+		expanding[typ] = true
+		defer delete(expanding, typ)
+		obj := map[string]any{}
+		oneofs := map[string]bool{}
+		for _, f := range msg.Fields {
+			if f.Oneof != "" {
+				if oneofs[f.Oneof] {
+					continue // only the first member of a oneof is set
+				}
+				oneofs[f.Oneof] = true
+			}
+			if entry, ok := s.Messages[f.Type]; ok && entry.MapEntry {
+				key := "key"
+				if keyType := entry.Fields[0].Type; keyType != "string" {
+					key = "1"
+					if keyType == "bool" {
+						key = "true"
+					}
+				}
+				if v := s.sample(entry.Fields[1].Type, f.Name, depth+1, expanding); v != nil {
+					obj[f.JSONName] = map[string]any{key: v}
+				}
+				continue
+			}
+			v := s.sample(f.Type, f.Name, depth+1, expanding)
+			if v == nil {
+				continue
+			}
+			if f.Repeated {
+				v = []any{v}
+			}
+			obj[f.JSONName] = v
+		}
+		return obj
+	}
+
+	switch typ {
+	case "string":
+		return sampleString(field)
+	case "bytes":
+		return "c3RyaW5n"
+	case "bool":
+		return true
+	case "double", "float":
+		return 1.5
+	case "int64", "uint64", "sint64", "fixed64", "sfixed64":
+		return "1" // proto3 JSON writes 64-bit integers as strings
+	}
+	return 1
+}
+
+// sampleString picks a value that suits the field name, as the OpenAPI importer does by format
+func sampleString(field string) string {
+	name := strings.ToLower(field)
+	switch {
+	case strings.Contains(name, "email"):
+		return "user@example.com"
+	case name == "id" || strings.HasSuffix(name, "_id") || strings.HasSuffix(name, "uuid"):
+		return "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	case strings.HasSuffix(name, "url") || strings.HasSuffix(name, "uri"):
+		return "https://example.com"
+	case strings.HasSuffix(name, "_time") || strings.HasSuffix(name, "_at") || strings.HasSuffix(name, "date"):
+		return "2024-01-15T10:30:00Z"
+	case name == "name" || strings.HasSuffix(name, "_name"):
+		return "Example " + strings.ReplaceAll(strings.TrimSuffix(name, "_name"), "_", " ")
+	}
+	return "string"
+}
@@ -0,0 +1,235 @@
+// Package recorder proxies live traffic to a real backend and turns the recorded
+// request/response pairs into mock expectations.
+package recorder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// maxBody bounds the request and response bodies kept per exchange; larger bodies are still
+// proxied but the exchange is not recorded
+const maxBody = 10 << 20
+
+// Exchange is one proxied request and the backend's response
+type Exchange struct {
+	Method          string
+	Path            string
+	Query           url.Values
+	RequestHeaders  http.Header
+	RequestBody     []byte
+	StatusCode      int
+	ResponseHeaders http.Header
+	ResponseBody    []byte
+	Duration        time.Duration
+}
+
+// Recorder is a reverse proxy to the target backend that records every exchange
+type Recorder struct {
+	target *url.URL
+	proxy  *httputil.ReverseProxy
+	out    io.Writer
+
+	mu        sync.Mutex
+	exchanges []Exchange
+}
+
+// New returns a recorder proxying to target (scheme and host, optionally a base path).
+// Each exchange is logged to out.
+func New(target string, out io.Writer) (*Recorder, error) {
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid target %q: use http(s)://host[:port][/base]", target)
+	}
+	r := &Recorder{target: u, out: out}
+	r.proxy = &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(u)
+			pr.Out.Host = u.Host
+			// Let the transport negotiate (and undo) compression so bodies are recorded as sent
+			pr.Out.Header.Del("Accept-Encoding")
+		},
+		ModifyResponse: r.capture,
+		ErrorHandler: func(w http.ResponseWriter, req *http.Request, err error) {
+			r.logf("✗ %s %s → 502 (%v)", req.Method, req.URL.RequestURI(), err)
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	}
+	return r, nil
+}
+
+// ServeHTTP proxies the request and records the exchange
+func (r *Recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	p := &pending{start: time.Now()}
+	if req.Body != nil {
+		body, err := io.ReadAll(io.LimitReader(req.Body, maxBody+1))
+		req.Body.Close()
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		p.body = body
+	}
+	r.proxy.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), pendingKey{}, p)))
+}
+
+// pending is what the proxy keeps about a request until its response arrives
+type pending struct {
+	body  []byte
+	start time.Time
+}
+
+type pendingKey struct{}
+
+// capture reads the backend response, records the exchange and hands the body on to the client
+func (r *Recorder) capture(resp *http.Response) error {
+	req := resp.Request
+	p, _ := req.Context().Value(pendingKey{}).(*pending)
+	line := fmt.Sprintf("%s %s → %d", req.Method, req.URL.RequestURI(), resp.StatusCode)
+	if resp.StatusCode == http.StatusSwitchingProtocols || isStream(resp.Header) {
+		r.logf("→ %s (not recorded: streaming)", line)
+		return nil
+	}
+	if len(p.body) > maxBody {
+		r.logf("→ %s (not recorded: request body over %d MB)", line, maxBody>>20)
+		return nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBody+1))
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if len(body) > maxBody {
+		r.logf("→ %s (not recorded: response body over %d MB)", line, maxBody>>20)
+		return nil
+	}
+
+	ex := Exchange{
+		Method:          req.Method,
+		Path:            strings.TrimPrefix(req.URL.Path, strings.TrimSuffix(r.target.Path, "/")),
+		Query:           req.URL.Query(),
+		RequestHeaders:  req.Header.Clone(),
+		RequestBody:     p.body,
+		StatusCode:      resp.StatusCode,
+		ResponseHeaders: resp.Header.Clone(),
+		ResponseBody:    body,
+		Duration:        time.Since(p.start),
+	}
+	if ex.Path == "" {
+		ex.Path = "/"
+	}
+	r.mu.Lock()
+	r.exchanges = append(r.exchanges, ex)
+	n := len(r.exchanges)
+	r.mu.Unlock()
+	r.logf("● %s (%dms) #%d", line, ex.Duration.Milliseconds(), n)
+	return nil
+}
+
+// Exchanges returns the exchanges recorded so far, in order
+func (r *Recorder) Exchanges() []Exchange {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Exchange(nil), r.exchanges...)
+}
+
+func (r *Recorder) logf(format string, args ...any) {
+	if r.out != nil {
+		fmt.Fprintf(r.out, "%s %s\n", time.Now().Format("15:04:05"), fmt.Sprintf(format, args...))
+	}
+}
+
+// droppedResponseHeaders are set per response by the server serving the mock
+var droppedResponseHeaders = map[string]bool{
+	"Content-Length": true, "Content-Encoding": true, "Transfer-Encoding": true, "Connection": true,
+	"Keep-Alive": true, "Date": true, "Trailer": true, "Upgrade": true,
+}
+
+// Expectations converts exchanges into expectations: the path, query and (for JSON) the body
+// fields of each request are matched, and the recorded response is returned. Repeated requests
+// for the same endpoint are collapsed as with collection imports.
+func Expectations(exchanges []Exchange, target, runID string) ([]models.MockExpectation, []models.MergeGroup) {
+	var exps []models.MockExpectation
+	for _, ex := range exchanges {
+		req := &models.HttpRequest{Method: ex.Method, Path: ex.Path}
+		names := make([]string, 0, len(ex.Query))
+		for name := range ex.Query {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			req.QueryStringParameters = append(req.QueryStringParameters, models.NameValues{Name: name, Values: ex.Query[name]})
+		}
+		if v, ok := jsonBody(ex.RequestHeaders, ex.RequestBody); ok {
+			req.Body = map[string]any{"type": "JSON", "json": v, "matchType": "ONLY_MATCHING_FIELDS"}
+		}
+
+		exps = append(exps, models.MockExpectation{
+			HttpRequest:  req,
+			HttpResponse: Response(ex.StatusCode, ex.ResponseHeaders, ex.ResponseBody),
+			Provenance:   models.NewProvenance(models.ProvenanceRecording, fmt.Sprintf("%s %s%s", ex.Method, strings.TrimSuffix(target, "/"), ex.Path), runID),
+			Freshness:    &models.Freshness{Origin: target, RecordedAt: time.Now().UTC()},
+		})
+	}
+	return models.DeduplicateExpectations(exps)
+}
+
+// Response converts a recorded response: headers the serving mock sets itself are dropped,
+// JSON bodies are stored as JSON and binary ones (images, PDFs, ...) as base64
+func Response(status int, header http.Header, body []byte) *models.HttpResponse {
+	resp := &models.HttpResponse{StatusCode: status}
+	var names []string
+	for name := range header {
+		if !droppedResponseHeaders[http.CanonicalHeaderKey(name)] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		resp.Headers = append(resp.Headers, models.NameValues{Name: name, Values: header[name]})
+	}
+	if v, ok := jsonBody(header, body); ok {
+		resp.Body = map[string]any{"type": "JSON", "json": v}
+	} else if len(body) > 0 {
+		resp.Body = models.BodyFromBytes(header.Get("Content-Type"), body)
+	}
+	return resp
+}
+
+// jsonBody parses a body that is JSON by content type (or, without one, by content)
+func jsonBody(h http.Header, body []byte) (any, bool) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, false
+	}
+	if ct := h.Get("Content-Type"); ct != "" {
+		mt, _, _ := mime.ParseMediaType(ct)
+		if mt != "application/json" && !strings.HasSuffix(mt, "+json") {
+			return nil, false
+		}
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, false
+	}
+	return v, true
+}
+
+func isStream(h http.Header) bool {
+	mt, _, _ := mime.ParseMediaType(h.Get("Content-Type"))
+	return mt == "text/event-stream"
+}
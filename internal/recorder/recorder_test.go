@@ -0,0 +1,152 @@
+package recorder
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+func backend(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/users/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		id := strings.TrimPrefix(r.URL.Path, "/api/users/")
+		if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			w.Header().Set("Content-Encoding", "gzip")
+			gz := gzip.NewWriter(w)
+			defer gz.Close()
+			_, _ = io.WriteString(gz, `{"id":`+id+`,"name":"user `+id+`"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"id":`+id+`,"name":"user `+id+`"}`)
+	})
+	mux.HandleFunc("/api/orders", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "abc")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(body)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRecordAndConvert(t *testing.T) {
+	api := backend(t)
+	rec, err := New(api.URL+"/api", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy := httptest.NewServer(rec)
+	defer proxy.Close()
+
+	for _, id := range []string{"1", "2"} {
+		resp, err := http.Get(proxy.URL + "/users/" + id + "?expand=roles")
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if want := `{"id":` + id + `,"name":"user ` + id + `"}`; string(body) != want {
+			t.Errorf("client got %q, want %q", body, want)
+		}
+	}
+	resp, err := http.Post(proxy.URL+"/orders", "application/json", strings.NewReader(`{"sku":"A-1","qty":2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	exchanges := rec.Exchanges()
+	if len(exchanges) != 3 {
+		t.Fatalf("recorded %d exchanges, want 3", len(exchanges))
+	}
+	if got := string(exchanges[0].ResponseBody); got != `{"id":1,"name":"user 1"}` {
+		t.Errorf("recorded body %q, want it decompressed", got)
+	}
+
+	exps, groups := Expectations(exchanges, api.URL+"/api", "recording-1")
+	if len(exps) != 2 || len(groups) != 1 {
+		t.Fatalf("got %d expectations and %d merge groups, want 2 and 1", len(exps), len(groups))
+	}
+
+	users := exps[0]
+	if users.HttpRequest.Method != "GET" || len(users.Examples) != 2 || !strings.HasPrefix(users.HttpRequest.Path, "/users/") {
+		t.Errorf("users expectation = %s %s with %d examples", users.HttpRequest.Method, users.HttpRequest.Path, len(users.Examples))
+	}
+	if q := users.HttpRequest.QueryStringParameters; len(q) != 1 || q[0].Name != "expand" || q[0].Values[0] != "roles" {
+		t.Errorf("query parameters = %+v", q)
+	}
+
+	orders := exps[1]
+	body, _ := orders.HttpRequest.Body.(map[string]any)
+	if body["matchType"] != "ONLY_MATCHING_FIELDS" {
+		t.Errorf("request body matcher = %v", orders.HttpRequest.Body)
+	}
+	if orders.HttpResponse.StatusCode != 201 {
+		t.Errorf("status = %d", orders.HttpResponse.StatusCode)
+	}
+	var headers []string
+	for _, h := range orders.HttpResponse.Headers {
+		headers = append(headers, h.Name)
+	}
+	if got := strings.Join(headers, ","); got != "Content-Type,X-Request-Id" {
+		t.Errorf("response headers = %s", got)
+	}
+	data, _ := json.Marshal(orders.HttpResponse.Body)
+	if string(data) != `{"json":{"qty":2,"sku":"A-1"},"type":"JSON"}` {
+		t.Errorf("response body = %s", data)
+	}
+	if p := orders.Provenance; p == nil || p.Source != models.ProvenanceRecording || p.Detail != "POST "+api.URL+"/api/orders" {
+		t.Errorf("provenance = %+v", p)
+	}
+	if f := orders.Freshness; f == nil || f.Origin != api.URL+"/api" || f.RecordedAt.IsZero() {
+		t.Errorf("freshness = %+v", f)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	api := backend(t)
+	exps, _ := Expectations([]Exchange{{
+		Method:          "POST",
+		Path:            "/orders",
+		RequestHeaders:  http.Header{"Content-Type": {"application/json"}},
+		RequestBody:     []byte(`{"sku":"A-1","qty":2}`),
+		StatusCode:      201,
+		ResponseHeaders: http.Header{"Content-Type": {"application/json"}, "X-Request-Id": {"old"}},
+		ResponseBody:    []byte(`{"sku":"A-1","qty":1}`),
+	}}, api.URL+"/api", "recording-1")
+
+	resp, err := Replay(context.Background(), http.DefaultClient, &exps[0], http.Header{"Authorization": {"Bearer t"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(resp.Body)
+	if resp.StatusCode != 201 || string(data) != `{"json":{"qty":2,"sku":"A-1"},"type":"JSON"}` {
+		t.Errorf("replayed %d %s", resp.StatusCode, data)
+	}
+	if changes := models.DiffResponses(exps[0].HttpResponse, resp); len(changes) != 1 || changes[0] != "[201] response values changed" {
+		t.Errorf("changes = %v", changes)
+	}
+
+	if _, err := Replay(context.Background(), http.DefaultClient, &models.MockExpectation{HttpRequest: &models.HttpRequest{Method: "GET", Path: "/"}}, nil); err == nil {
+		t.Error("replaying an expectation without an origin should fail")
+	}
+}
+
+func TestNewRejectsBadTarget(t *testing.T) {
+	for _, target := range []string{"", "real-api.example.com", "ftp://host"} {
+		if _, err := New(target, nil); err == nil {
+			t.Errorf("New(%q) should fail", target)
+		}
+	}
+}
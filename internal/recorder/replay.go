@@ -0,0 +1,64 @@
+package recorder
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hemantobora/auto-mock/internal/matcher"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// Replay re-executes the request a recorded expectation was made from against its origin and
+// returns the upstream response, converted as on recording. header is added to the request,
+// e.g. to supply credentials the recording did not keep.
+func Replay(ctx context.Context, client *http.Client, exp *models.MockExpectation, header http.Header) (*models.HttpResponse, error) {
+	if exp.Freshness == nil || exp.Freshness.Origin == "" {
+		return nil, fmt.Errorf("not recorded from an upstream")
+	}
+	return ReplayAt(ctx, client, exp.Freshness.Origin, exp, header)
+}
+
+// ReplayAt sends the example request of an expectation to baseURL (e.g. a staging
+// environment) and returns the response, converted as on recording
+func ReplayAt(ctx context.Context, client *http.Client, baseURL string, exp *models.MockExpectation, header http.Header) (*models.HttpResponse, error) {
+	r := matcher.ExampleRequest(exp)
+	target := strings.TrimSuffix(baseURL, "/") + r.Path
+	if q := r.Query.Encode(); q != "" {
+		target += "?" + q
+	}
+	var body io.Reader
+	if len(r.Body) > 0 {
+		body = bytes.NewReader(r.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, r.Method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range r.Headers {
+		req.Header[name] = values
+	}
+	if len(r.Body) > 0 && req.Header.Get("Content-Type") == "" && bytes.HasPrefix(bytes.TrimSpace(r.Body), []byte("{")) {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBody+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxBody {
+		return nil, fmt.Errorf("response body over %d MB", maxBody>>20)
+	}
+	return Response(resp.StatusCode, resp.Header, data), nil
+}
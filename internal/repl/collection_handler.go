@@ -6,7 +6,11 @@ import (
 	"fmt"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/builders"
 	"github.com/hemantobora/auto-mock/internal/collections"
+	"github.com/hemantobora/auto-mock/internal/graphqlschema"
+	"github.com/hemantobora/auto-mock/internal/models"
 )
 
 // generateFromCollectionWithMenu imports from API collections with deployment menu
@@ -16,16 +20,19 @@ func generateFromCollectionWithMenu(projectName string) (string, error) {
 
 	// Get collection type
 	var collectionType string
-	if err := survey.AskOne(&survey.Select{
+	if err := ask.One(&survey.Select{
 		Message: "Select collection type:",
-		Options: []string{"postman", "bruno", "insomnia"},
+		Options: []string{"auto-detect", "postman", "bruno", "insomnia", "openapi", "proto"},
 	}, &collectionType); err != nil {
 		return "", err
 	}
+	if collectionType == "auto-detect" {
+		collectionType = collections.CollectionTypeAuto
+	}
 
 	// Get file path
 	var filePath string
-	if err := survey.AskOne(&survey.Input{
+	if err := ask.One(&survey.Input{
 		Message: "Enter path to collection file:",
 	}, &filePath); err != nil {
 		return "", err
@@ -40,13 +47,17 @@ func generateFromCollectionWithMenu(projectName string) (string, error) {
 	return processor.ProcessCollection(filePath)
 }
 
-// handleCollectionMode processes collection files with AI assistance
-func HandleCollectionMode(collectionType, collectionFile, projectName string) (string, error) {
+// handleCollectionMode processes one or more collection files with AI assistance.
+// collectionFiles may contain files, glob patterns or directories.
+func HandleCollectionMode(collectionType string, collectionFiles []string, opts collections.ImportOptions, projectName string) (string, error) {
+	if collectionType == "" {
+		collectionType = collections.CollectionTypeAuto
+	}
 	fmt.Printf("📂 Processing %s collection for project: %s\n", collectionType, projectName)
 
-	// Validate collection parameters
-	if collectionType == "" {
-		return "", fmt.Errorf("collection-type is required when using collection-file")
+	files, err := collections.ExpandCollectionPaths(collectionFiles)
+	if err != nil {
+		return "", err
 	}
 
 	// Create collection processor
@@ -54,8 +65,42 @@ func HandleCollectionMode(collectionType, collectionFile, projectName string) (s
 	if err != nil {
 		return "", fmt.Errorf("failed to create collection processor: %w", err)
 	}
+	processor.SetLimits(opts.MaxRequests, opts.ChunkSize)
+	processor.SetConcurrency(opts.Concurrency)
+	if opts.IterationData != "" {
+		rows, err := collections.LoadIterationData(opts.IterationData)
+		if err != nil {
+			return "", err
+		}
+		fmt.Printf("🔁 Loaded %d iteration(s) from %s\n", len(rows), opts.IterationData)
+		processor.SetIterationData(rows)
+	}
 
-	// Process the collection using the full workflow
-	return processor.ProcessCollection(collectionFile)
+	// Process the collection(s) using the full workflow
+	return processor.ProcessCollections(files)
+}
 
+// HandleSchemaMode generates an expectation per query and mutation of a GraphQL schema
+// (SDL or introspection JSON), matched by operationName on /graphql
+func HandleSchemaMode(schemaFile string, projectName string) (string, error) {
+	fmt.Printf("🧬 Importing GraphQL schema %s for project: %s\n", schemaFile, projectName)
+	schema, err := graphqlschema.Load(schemaFile)
+	if err != nil {
+		return "", fmt.Errorf("invalid GraphQL schema: %w", err)
+	}
+	exps := builders.GraphQLSchemaExpectations(schema, builders.DefaultGraphQLPath)
+	if len(exps) == 0 {
+		return "", fmt.Errorf("%s declares no queries or mutations", schemaFile)
+	}
+
+	fmt.Printf("✅ %d operation(s) on POST %s:\n", len(exps), builders.DefaultGraphQLPath)
+	for _, op := range schema.Operations() {
+		fmt.Printf("   %-8s %-24s operationName %q\n", op.Type, op.Field.Name, op.Name)
+	}
+	if schema.Subscription != "" {
+		fmt.Println("ℹ️  Subscriptions are skipped; they are not served over plain HTTP")
+	}
+	fmt.Printf("💡 Send '%s: errors' or '%s: partial' to get the GraphQL error variants\n",
+		models.DefaultGraphQLErrorHeader, models.DefaultGraphQLErrorHeader)
+	return builders.ExpectationsToMockServerJSON(exps), nil
 }
@@ -3,9 +3,17 @@ package repl
 import (
 	"context"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/hemantobora/auto-mock/internal"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/hooks"
+	"github.com/hemantobora/auto-mock/internal/kubernetes"
+	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/notify"
 	"github.com/hemantobora/auto-mock/internal/terraform"
 )
 
@@ -13,6 +21,17 @@ type Deployment struct {
 	ProjectName string
 	Provider    internal.Provider
 	Profile     string
+	Selection   models.TagSelection // Labeled expectations to serve (empty = all)
+	Manifest    string              // Consumer manifest file regenerated after a successful deploy
+	Masking     string              // Masking profile applied to the served response bodies (empty = none)
+	Environment string              // Environment overlay served instead of the base expectations (empty = base)
+	Kubernetes  *kubernetes.Options // Cluster the mocks are deployed to instead of the cloud (nil = cloud)
+	RenderTo    string              // With Kubernetes, write the manifests to this file instead of applying them
+	ExportTo    string              // Write the Terraform module to this directory instead of applying it
+	Domain      string              // Custom domain the cloud stack serves the mocks at over HTTPS (empty = none)
+	HostedZone  string              // Route53 hosted zone of Domain (empty = the zone of its parent)
+	Auth        string              // Credential kind to protect the cloud stack with (api-key, basic, none; empty = keep)
+	MaxCost     float64             // Estimated monthly cost (USD) above which the cloud stack is not deployed (0 = no limit)
 }
 
 // NewDeployment creates a new Deployment instance
@@ -24,8 +43,17 @@ func NewDeployment(projectName, profile string, provider internal.Provider) *Dep
 	}
 }
 
-// DeployInfrastructureWithTerraform deploys actual infrastructure using Terraform
-func (d *Deployment) DeployInfrastructureWithTerraform(skip_confirmation bool) error {
+// Deploy deploys the mocks to the Kubernetes cluster when one is set, otherwise with Terraform
+func (d *Deployment) Deploy(skip_confirmation, allowBreaking bool) error {
+	if d.Kubernetes != nil {
+		return d.DeployToKubernetes(skip_confirmation, allowBreaking)
+	}
+	return d.DeployInfrastructureWithTerraform(skip_confirmation, allowBreaking)
+}
+
+// DeployInfrastructureWithTerraform deploys actual infrastructure using Terraform.
+// Breaking changes since the last deployed version block the deploy unless allowBreaking is set.
+func (d *Deployment) DeployInfrastructureWithTerraform(skip_confirmation, allowBreaking bool) error {
 	fmt.Println("\n🏗️  Complete Infrastructure Deployment")
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
@@ -34,50 +62,395 @@ func (d *Deployment) DeployInfrastructureWithTerraform(skip_confirmation bool) e
 	if err != nil {
 		return fmt.Errorf("failed to create terraform manager: %w", err)
 	}
+	// Keep the domain and credential an earlier deploy set up unless told otherwise
+	var recorded *models.InfrastructureOutputs
+	if meta, _ := d.Provider.GetDeploymentMetadata(); meta != nil && meta.DeploymentStatus == "deployed" {
+		recorded = meta.Details
+	}
+	if d.Domain == "" {
+		d.Domain, d.HostedZone = terraform.DomainFromOutputs(recorded)
+	}
+	var previousAuth *models.EndpointAuth
+	if recorded != nil {
+		previousAuth = recorded.Auth
+	}
+	auth, err := models.ResolveEndpointAuth(d.Auth, previousAuth)
+	if err != nil {
+		return exitcode.Wrap(exitcode.Config, err)
+	}
+	if d.Provider.GetProviderType() == "azure" {
+		if d.Domain != "" {
+			return exitcode.WithHint(exitcode.Config, fmt.Errorf("--domain is supported for AWS deployments only"),
+				"Deploy without --domain; Azure Container Instances serve the mocks on their own address")
+		}
+		if auth != nil {
+			return exitcode.WithHint(exitcode.Config, fmt.Errorf("--auth is supported for AWS deployments only"),
+				"Deploy without --auth, or put the mocks behind your own gateway")
+		}
+	}
 
-	// ── 1) Check Project Configuration ───────────────────────────────────────
-	if _, err := manager.Provider.GetConfig(context.Background(), d.ProjectName); err != nil {
-		return fmt.Errorf("project configuration does not exist, nothing to deploy; please run 'auto-mock init' first")
+	// ── 1-2) Prepare the served configuration and release the contract ───────
+	config, previous, err := d.prepare(allowBreaking)
+	if err != nil {
+		return err
+	}
+
+	if d.ExportTo != "" {
+		return d.exportTerraform(manager, auth)
 	}
 
 	// Check Terraform installation
 	if err := terraform.CheckTerraformInstalled(); err != nil {
-		return fmt.Errorf("terraform not found: %w\nPlease install from https://terraform.io/downloads", err)
+		return exitcode.WithHint(exitcode.Config, fmt.Errorf("terraform not found: %w", err), "Install Terraform from https://terraform.io/downloads")
 	}
 
 	options := d.Provider.CreateDeploymentConfiguration()
 	// <-- IMPORTANT: make these options the ones we deploy with
+	if options == nil {
+		return exitcode.New(exitcode.Config, "could not collect deployment options")
+	}
+	options.Domain, options.HostedZoneID = d.Domain, d.HostedZone
+	options.Auth = auth
 
-	// ── 3) Ask for size / min / max (fills remaining fields on d.Options) ─────
-
-	// Optional: show cost estimate (AWS)
-	d.Provider.DisplayCostEstimate(options)
+	// ── 3) Estimate the monthly cost and hold it to --max-monthly-cost ───────
+	if err := d.checkCost(options); err != nil {
+		return err
+	}
 	fmt.Println()
 
-	// ── 4) Confirm ────────────────────────────────────────────────────────────
+	// ── 4-5) Confirm and run the pre-deploy hooks ─────────────────────────────
+	if err := d.confirm(config, skip_confirmation, "This will create necessary resources and deploy your mocks to the cloud provider."); err != nil {
+		return err
+	}
+
+	// ── 6) Deploy ─────────────────────────────────────────────────────────────
+	fmt.Println("\n🚀 Deploying infrastructure with Terraform...")
+	outputs, err := manager.Deploy(options) // uses the options we just assembled
+	if err != nil {
+		return exitcode.New(exitcode.Deploy, "deployment failed: %w", err)
+	}
+	outputs.Auth = auth
+	d.finish(config, previous, outputs)
+	if d.Domain != "" {
+		fmt.Printf("🔒 Mocks served at %s; DNS may take a few minutes to reach every resolver\n", outputs.MockServerURL)
+	}
+	printEndpointAuth(auth, d.Auth != "")
+	return nil
+}
+
+// printEndpointAuth tells how to call protected mocks; a credential issued by this deploy is
+// shown in full, a kept one is only named
+func printEndpointAuth(auth *models.EndpointAuth, issued bool) {
+	switch {
+	case auth == nil:
+	case issued:
+		fmt.Printf("🔑 Requests need the credential (%s)\n", auth.Describe())
+		fmt.Printf("   e.g. curl -H '%s: %s' ...\n", auth.Header, auth.Value())
+	default:
+		fmt.Printf("🔑 Requests need the %s header; 'automock status --detailed' shows the credential\n", auth.Header)
+	}
+}
+
+// checkCost shows the provider's cost estimate for options and fails when it exceeds MaxCost
+func (d *Deployment) checkCost(options *models.DeploymentOptions) error {
+	estimate := d.Provider.EstimateCost(options)
+	if estimate == nil {
+		return nil
+	}
+	fmt.Print(estimate.Format())
+	if err := estimate.CheckBudget(d.MaxCost); err != nil {
+		return exitcode.WithHint(exitcode.Validation, err, "Choose a smaller --instance-size or fewer --min-tasks, or raise --max-monthly-cost")
+	}
+	if d.MaxCost > 0 {
+		fmt.Printf("✅ Within the limit of $%.2f/month\n", d.MaxCost)
+	}
+	return nil
+}
+
+// exportTerraform writes the stack to ExportTo for another pipeline to apply. The served
+// configuration is already saved, and the stack loads it from the project store once applied.
+func (d *Deployment) exportTerraform(manager *terraform.Manager, auth *models.EndpointAuth) error {
+	options := d.Provider.CreateDeploymentConfiguration()
+	if options == nil {
+		fmt.Println("⚠️  Could not collect deployment options; exporting the defaults (edit terraform.tfvars)")
+		options = d.Provider.CreateDefaultDeploymentConfiguration()
+	}
+	options.Domain, options.HostedZoneID = d.Domain, d.HostedZone
+	options.Auth = auth
+	if err := d.checkCost(options); err != nil {
+		return err
+	}
+	written, err := manager.Export(d.ExportTo, options)
+	if err != nil {
+		return exitcode.Wrap(exitcode.Config, err)
+	}
+	fmt.Printf("\n📦 Terraform module for %s written to %s (%d files)\n", d.ProjectName, d.ExportTo, len(written))
+	fmt.Println("💡 Apply it with terraform or tofu (init, plan, apply); see its README.md for the state backend")
+	fmt.Println("💡 Nothing was deployed, so 'automock status' does not report it")
+	if auth != nil {
+		fmt.Printf("🔑 The stack requires the %s header; terraform.tfvars holds its value (%s)\n", auth.Header, auth.Describe())
+	}
+	return nil
+}
+
+// DeployToKubernetes deploys the mocks to the cluster in d.Kubernetes with kubectl, or only
+// writes the manifests when RenderTo is set. It needs no cloud account, so projects in the
+// local and git stores deploy this way too.
+func (d *Deployment) DeployToKubernetes(skip_confirmation, allowBreaking bool) error {
+	fmt.Println("\n☸️  Kubernetes Deployment")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	manager := kubernetes.NewManager(d.ProjectName, *d.Kubernetes)
+	if d.RenderTo == "" {
+		if err := kubernetes.CheckKubectlInstalled(); err != nil {
+			return exitcode.WithHint(exitcode.Config, err, "Install kubectl, or pass --render-to to write the manifests for your own tooling")
+		}
+	}
+
+	config, previous, err := d.prepare(allowBreaking)
+	if err != nil {
+		return err
+	}
+	manifests, err := manager.Render(config)
+	if err != nil {
+		return exitcode.WithHint(exitcode.Validation, err, "Deploy fewer expectations with --only-tags or --exclude-tags")
+	}
+
+	if d.RenderTo != "" {
+		if err := os.WriteFile(d.RenderTo, manifests, 0644); err != nil {
+			return fmt.Errorf("failed to write manifests: %w", err)
+		}
+		fmt.Printf("📄 Manifests written to %s (ConfigMap, Deployment and Service %s)\n", d.RenderTo, kubernetes.Name(d.ProjectName))
+		fmt.Printf("💡 Apply them with 'kubectl apply -f %s'; nothing was deployed, so status and destroy do not track them\n", d.RenderTo)
+		return nil
+	}
+
+	cluster := manager.Options.Context
+	if cluster == "" {
+		cluster = "current kubeconfig context"
+	}
+	namespace := manager.Options.Namespace
+	if namespace == "" {
+		namespace = "the context's namespace"
+	}
+	fmt.Printf("📦 %s in %s on %s, %d expectation(s)\n\n", kubernetes.Name(d.ProjectName), namespace, cluster, len(config.Expectations))
+
+	if err := d.confirm(config, skip_confirmation, "This will apply a ConfigMap, Deployment and Service running MockServer to the cluster."); err != nil {
+		return err
+	}
+
+	fmt.Println("\n🚀 Applying manifests with kubectl...")
+	outputs, err := manager.Deploy(context.Background(), config)
+	if err != nil {
+		return exitcode.New(exitcode.Deploy, "deployment failed: %w", err)
+	}
+	fmt.Printf("\n✅ Mocks serving in the cluster at %s\n", outputs.MockServerURL)
+	fmt.Printf("💡 From your machine: %s\n", outputs.CLICommands["port_forward"])
+	d.finish(config, previous, outputs)
+	return nil
+}
+
+// prepare loads the project configuration and turns it into what is served: schema bodies,
+// environment overlay, tag selection and masking applied, validated, checked for breaking
+// changes and released as a contract version. It returns the configuration and the last
+// deployed version (nil on first deploy).
+func (d *Deployment) prepare(allowBreaking bool) (*models.MockConfiguration, *models.MockConfiguration, error) {
+	// ── 1) Check Project Configuration ───────────────────────────────────────
+	config, err := d.Provider.GetConfig(context.Background(), d.ProjectName)
+	if err != nil {
+		return nil, nil, exitcode.New(exitcode.Config, "project configuration does not exist, nothing to deploy; please run 'auto-mock init' first")
+	}
+
+	// Re-render schema-backed bodies so library changes reach the deployed mocks
+	if len(config.Schemas) > 0 {
+		rendered, err := config.ApplySchemaRefs()
+		if err != nil {
+			return nil, nil, exitcode.New(exitcode.Validation, "failed to render schema references: %w", err)
+		}
+		if rendered > 0 {
+			if err := d.Provider.UpdateConfig(context.Background(), config); err != nil {
+				return nil, nil, fmt.Errorf("failed to save rendered expectations: %w", err)
+			}
+			fmt.Printf("📐 Rendered %d response body(ies) from the schema library\n", rendered)
+		}
+	}
+
+	if stale := config.StaleExpectations(time.Now()); len(stale) > 0 {
+		fmt.Printf("⏳ %d recorded expectation(s) are past their freshness TTL; run 'automock refresh --project %s'\n", len(stale), d.ProjectName)
+	}
+
+	// Bring back bodies a previous masked deploy replaced; positions refer to the old selection
+	wasMasked := config.Metadata.Masking != ""
+	config.RestoreMasked()
+
+	// Serve the environment's overlay on the base; the base stays in the stored config
+	config.RestoreWithheld()
+	previousEnv := config.Metadata.Environment
+	if d.Environment != "" {
+		overlay, err := config.FindEnvironment(d.Environment)
+		if err != nil {
+			return nil, nil, exitcode.Wrap(exitcode.Config, err)
+		}
+		config.ApplyEnvironment(overlay)
+		fmt.Printf("🌐 Environment %s: %s\n", overlay.Name, overlay.Summary())
+	} else if previousEnv != "" {
+		config.ApplyEnvironment(nil)
+		fmt.Printf("🌐 Previous environment %s cleared; serving the base expectations\n", previousEnv)
+	}
+
+	// Serve only the selected expectation groups; withheld ones stay in the stored config
+	hadSelection := config.Metadata.Selection != nil
+	if withheld := config.ApplyTagSelection(d.Selection); withheld > 0 || !d.Selection.IsEmpty() {
+		fmt.Printf("🏷️  Tag selection (%s): serving %d expectation(s), withholding %d\n",
+			d.Selection, len(config.Expectations), withheld)
+		if len(config.Expectations) == 0 {
+			return nil, nil, exitcode.New(exitcode.Validation, "tag selection %s matches no expectations", d.Selection)
+		}
+	} else if hadSelection {
+		fmt.Println("🏷️  Previous tag selection cleared; serving all expectations")
+	}
+
+	// Check what will be served; expectations MockServer would reject or never serve stop the deploy
+	if report := models.Validate(config.Expectations); len(report) > 0 {
+		fmt.Printf("🔍 Validation:\n%s", report.Format())
+		if len(report.Errors()) > 0 {
+			return nil, nil, exitcode.WithHint(exitcode.Validation, fmt.Errorf("%d expectation problem(s) must be fixed before deploying", len(report.Errors())),
+				fmt.Sprintf("Run 'automock validate --project %s' and fix the listed expectations", d.ProjectName))
+		}
+	}
+
+	// Mask the served bodies (e.g. for a demo environment); originals stay in the stored config
+	if d.Masking != "" {
+		profile, err := config.FindMaskingProfile(d.Masking)
+		if err != nil {
+			return nil, nil, exitcode.Wrap(exitcode.Config, err)
+		}
+		bodies, values := config.ApplyMasking(profile)
+		fmt.Printf("🎭 Masking profile %s: masked %d value(s) in %d response body(ies)\n", profile.Name, values, bodies)
+	} else if wasMasked {
+		config.ApplyMasking(nil)
+		fmt.Println("🎭 Previous masking cleared; serving original response bodies")
+	}
+
+	// ── 2) Breaking-change gate ──────────────────────────────────────────────
+	previous, err := d.checkBreakingChanges(config, allowBreaking)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Version the contract and refresh /__automock/info before the mocks go live
+	if entry := config.RecordRelease(previous, time.Now()); entry != nil {
+		fmt.Printf("🏷️  Contract version %s", entry.Version)
+		if entry.PreviousVersion != "" {
+			fmt.Printf(" (from %s)", entry.PreviousVersion)
+		}
+		fmt.Println()
+		fmt.Print(models.FormatChangelog([]models.ChangelogEntry{*entry}))
+	}
+	if err := d.Provider.UpdateConfig(context.Background(), config); err != nil {
+		return nil, nil, fmt.Errorf("failed to save contract release: %w", err)
+	}
+
+	return config, previous, nil
+}
+
+// confirm asks before deploying unless skipped, then lets the pre-deploy hooks (e.g. approvals) veto the deploy
+func (d *Deployment) confirm(config *models.MockConfiguration, skip_confirmation bool, help string) error {
 	if !skip_confirmation {
 		var confirmed bool
 		confirmPrompt := &survey.Confirm{
 			Message: "Proceed with infrastructure deployment?",
 			Default: true,
-			Help:    "This will create necessary resources and deploy your mocks to the cloud provider.",
+			Help:    help,
 		}
-		if err := survey.AskOne(confirmPrompt, &confirmed); err != nil {
+		if err := ask.One(confirmPrompt, &confirmed); err != nil {
 			return err
 		}
 		if !confirmed {
-			fmt.Println("\n❌ Deployment cancelled")
-			return nil
+			return exitcode.New(exitcode.Cancelled, "deployment cancelled")
 		}
 	}
+	if err := hooks.Run(hooks.PreDeploy, d.ProjectName, d.Profile, map[string]string{
+		"contract_version": config.Metadata.ContractVersion,
+	}); err != nil {
+		return exitcode.New(exitcode.Validation, "deployment aborted: %w", err)
+	}
+	return nil
+}
 
-	// ── 6) Deploy ─────────────────────────────────────────────────────────────
-	fmt.Println("\n🚀 Deploying infrastructure with Terraform...")
-	outputs, err := manager.Deploy(options) // uses the options we just assembled
+// finish records a successful deploy: deployment metadata, post-deploy hooks, the consumer
+// manifest, the deployed version baseline and consumer notifications
+func (d *Deployment) finish(config, previous *models.MockConfiguration, outputs *models.InfrastructureOutputs) {
+	d.Provider.SaveDeploymentMetadata(outputs)
+	hooks.RunPost(hooks.PostDeploy, d.ProjectName, d.Profile, map[string]string{
+		"endpoint":         outputs.MockServerURL,
+		"contract_version": config.Metadata.ContractVersion,
+	})
+
+	if d.Manifest != "" {
+		if err := os.WriteFile(d.Manifest, []byte(models.RenderManifest(config, outputs.MockServerURL, time.Now())), 0644); err != nil {
+			fmt.Printf("⚠️  Failed to write manifest %s: %v\n", d.Manifest, err)
+		} else {
+			fmt.Printf("📘 Manifest regenerated: %s\n", d.Manifest)
+		}
+	}
+
+	// Record the deployed version as the baseline for the next breaking-change check
+	config.Metadata.DeployedVersion = config.Metadata.Version
+	if err := d.Provider.UpdateConfig(context.Background(), config); err != nil {
+		fmt.Printf("⚠️  Failed to record deployed version: %v\n", err)
+	}
+
+	// Tell registered consumers about the changes they depend on
+	if previous != nil && len(config.Consumers) > 0 {
+		diff := models.DiffConfigurations(previous, config)
+		sent, errs := notify.NotifyConsumers(context.Background(), d.ProjectName, previous, config, diff)
+		for _, err := range errs {
+			fmt.Printf("⚠️  Notification failed: %v\n", err)
+		}
+		if sent > 0 {
+			fmt.Printf("📣 Notified %d consumer(s) of contract changes\n", sent)
+		}
+	}
+}
+
+// checkBreakingChanges diffs the configuration against the last deployed version and
+// returns that version (nil on first deploy) as the baseline for the contract release.
+// A deployed version that cannot be loaded fails the check unless allowBreaking.
+func (d *Deployment) checkBreakingChanges(config *models.MockConfiguration, allowBreaking bool) (*models.MockConfiguration, error) {
+	baseline := config.Metadata.DeployedVersion
+	if baseline == "" {
+		return nil, nil
+	}
+	previous, err := d.Provider.GetVersion(context.Background(), d.ProjectName, baseline)
+	if err != nil && !allowBreaking {
+		return nil, exitcode.WithHint(exitcode.Classify(err),
+			fmt.Errorf("could not load deployed version %s for the breaking-change check: %w", baseline, err),
+			"Retry, or re-run with --allow-breaking to deploy without the check")
+	}
 	if err != nil {
-		return fmt.Errorf("deployment failed: %w", err)
+		fmt.Printf("⚠️  Could not load deployed version %s; deploying without the breaking-change check (--allow-breaking): %v\n", baseline, err)
+		return nil, nil
 	}
 
-	d.Provider.SaveDeploymentMetadata(outputs)
-	return nil
+	diff := models.DiffConfigurations(previous, config)
+	breaking := diff.Breaking()
+	fmt.Printf("\n🔍 Changes since deployed version %s: %d total, %d breaking\n", baseline, len(diff.Changes), len(breaking))
+	for _, c := range diff.Changes {
+		icon := "  ➕"
+		if c.Severity == models.ChangeBreaking {
+			icon = "  💥"
+		}
+		fmt.Printf("%s %s — %s\n", icon, c.Endpoint, c.Summary)
+	}
+	if len(breaking) == 0 {
+		return previous, nil
+	}
+	if !allowBreaking {
+		return nil, exitcode.WithHint(exitcode.Validation,
+			fmt.Errorf("%d breaking change(s) detected since version %s", len(breaking), baseline),
+			"Re-run with --allow-breaking to deploy anyway")
+	}
+	fmt.Println("⚠️  Deploying breaking changes (--allow-breaking)")
+	return previous, nil
 }
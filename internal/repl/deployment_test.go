@@ -0,0 +1,67 @@
+package repl
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// storeWithoutVersions serves the current configuration but fails to load any version
+type storeWithoutVersions struct {
+	internal.Provider
+	config *models.MockConfiguration
+}
+
+func (s *storeWithoutVersions) GetConfig(ctx context.Context, projectID string) (*models.MockConfiguration, error) {
+	return s.config, nil
+}
+
+func (s *storeWithoutVersions) UpdateConfig(ctx context.Context, config *models.MockConfiguration) error {
+	s.config = config
+	return nil
+}
+
+func (s *storeWithoutVersions) GetVersion(ctx context.Context, projectID, version string) (*models.MockConfiguration, error) {
+	return nil, errors.New("connection reset by peer")
+}
+
+func TestPrepareKeepsContractWithoutDeployedVersion(t *testing.T) {
+	store := &storeWithoutVersions{config: &models.MockConfiguration{
+		Metadata:  models.ConfigMetadata{ProjectID: "users", Version: "v9", DeployedVersion: "v7", ContractVersion: "2.1.0"},
+		Changelog: []models.ChangelogEntry{{Version: "2.1.0"}, {Version: "2.0.0"}},
+		Expectations: []models.MockExpectation{{
+			HttpRequest:  &models.HttpRequest{Method: "GET", Path: "/users"},
+			HttpResponse: &models.HttpResponse{StatusCode: 200},
+		}},
+	}}
+	d := NewDeployment("users", "", store)
+
+	// The breaking-change check cannot run, so the deploy stops unless it is allowed
+	_, _, err := d.prepare(false)
+	var classified *exitcode.Error
+	if !errors.As(err, &classified) || !strings.Contains(classified.Hint, "--allow-breaking") {
+		t.Fatalf("prepare without --allow-breaking: %v, want an error pointing at --allow-breaking", err)
+	}
+	if store.config.Metadata.ContractVersion != "2.1.0" {
+		t.Fatalf("a failed check released contract %s", store.config.Metadata.ContractVersion)
+	}
+
+	config, previous, err := d.prepare(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if previous != nil {
+		t.Errorf("previous = %+v, want none", previous)
+	}
+	if config.Metadata.ContractVersion != "3.0.0" {
+		t.Errorf("contract version = %s, want 3.0.0 continuing 2.1.0", config.Metadata.ContractVersion)
+	}
+	if n := len(store.config.Changelog); n != 3 || store.config.Changelog[1].Version != "2.1.0" || store.config.Changelog[2].Version != "2.0.0" {
+		t.Errorf("saved changelog = %+v, want the release ahead of 2.1.0 and 2.0.0", store.config.Changelog)
+	}
+}
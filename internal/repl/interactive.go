@@ -5,7 +5,9 @@ import (
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
 	"github.com/hemantobora/auto-mock/internal/builders"
+	"github.com/hemantobora/auto-mock/internal/models"
 )
 
 // StartInteractiveBuilder starts the 7-step interactive mock expectation builder
@@ -22,6 +24,7 @@ func generateInteractiveWithMenu() (string, error) {
 	}
 
 	var expectations []builders.MockExpectation
+	runID := models.NewRunID(models.ProvenanceBuilder)
 
 	// Build expectations based on API type
 	for {
@@ -39,12 +42,15 @@ func generateInteractiveWithMenu() (string, error) {
 		if err != nil {
 			return "", fmt.Errorf("failed to build expectation: %w", err)
 		}
+		if expectation.Provenance == nil {
+			expectation.Provenance = models.NewProvenance(models.ProvenanceBuilder, apiType+" builder", runID)
+		}
 
 		expectations = append(expectations, expectation)
 
 		// Ask if user wants to add more expectations
 		var addMore bool
-		if err := survey.AskOne(&survey.Confirm{
+		if err := ask.One(&survey.Confirm{
 			Message: "Add another expectation?",
 			Default: false,
 		}, &addMore); err != nil {
@@ -66,7 +72,7 @@ func generateInteractiveWithMenu() (string, error) {
 // chooseAPIType lets user choose between REST and GraphQL
 func chooseAPIType() (string, error) {
 	var apiType string
-	if err := survey.AskOne(&survey.Select{
+	if err := ask.One(&survey.Select{
 		Message: "Select API type:",
 		Options: []string{
 			"REST - Traditional REST API",
@@ -12,6 +12,7 @@ import (
 
 	"github.com/AlecAivazis/survey/v2"
 	core "github.com/hemantobora/auto-mock/internal"
+	"github.com/hemantobora/auto-mock/internal/ask"
 	"github.com/hemantobora/auto-mock/internal/client"
 	"github.com/hemantobora/auto-mock/internal/models"
 	"github.com/hemantobora/auto-mock/internal/prompts"
@@ -34,7 +35,7 @@ func StartLoadTestREPL(provider core.Provider, project string) error {
 		if selected.ProjectID == "" {
 			// create new
 			var name string
-			if err := survey.AskOne(&survey.Input{Message: "Enter new project name:"}, &name, survey.WithValidator(survey.Required)); err != nil {
+			if err := ask.One(&survey.Input{Message: "Enter new project name:"}, &name, survey.WithValidator(survey.Required)); err != nil {
 				return err
 			}
 			if err := provider.InitProject(ctx, name); err != nil {
@@ -86,7 +87,7 @@ func StartLoadTestREPL(provider core.Provider, project string) error {
 		fmt.Println("    Dry-run prompts let you simulate uploads without persisting objects.")
 
 		var choice string
-		if err := survey.AskOne(&survey.Select{
+		if err := ask.One(&survey.Select{
 			Message: fmt.Sprintf("LoadTest REPL — project: %s", project),
 			Options: options,
 			Default: options[0],
@@ -167,9 +168,9 @@ func handleGenerateLocal(ctx context.Context, provider core.Provider, project st
 		CollectionType string `survey:"collectionType"`
 		OutDir         string `survey:"outDir"`
 	}
-	_ = survey.Ask([]*survey.Question{
+	_ = ask.Many([]*survey.Question{
 		{Name: "collectionFile", Prompt: &survey.Input{Message: "Collection file (Postman/Bruno/Insomnia path):"}},
-		{Name: "collectionType", Prompt: &survey.Select{Message: "Collection type:", Options: []string{"postman", "bruno", "insomnia"}, Default: "postman"}},
+		{Name: "collectionType", Prompt: &survey.Select{Message: "Collection type:", Options: []string{"auto", "postman", "bruno", "insomnia"}, Default: "auto"}},
 		{Name: "outDir", Prompt: &survey.Input{Message: "Output directory:", Default: fmt.Sprintf("loadtest_%d", time.Now().Unix())}},
 	}, &answers)
 
@@ -189,7 +190,7 @@ func handleGenerateLocal(ctx context.Context, provider core.Provider, project st
 	fmt.Printf("✅ Bundle generated at: %s\n", answers.OutDir)
 
 	var doUpload bool
-	_ = survey.AskOne(&survey.Confirm{Message: "Upload this bundle now?", Default: false}, &doUpload)
+	_ = ask.One(&survey.Confirm{Message: "Upload this bundle now?", Default: false}, &doUpload)
 	if doUpload {
 		pointer, version, err := provider.UploadLoadTestBundle(ctx, project, answers.OutDir)
 		if err != nil {
@@ -208,7 +209,7 @@ func handleGenerateLocal(ctx context.Context, provider core.Provider, project st
 // handleUploadLocalDir uploads an existing local bundle directory
 func handleUploadLocalDir(ctx context.Context, provider core.Provider, project string) error {
 	var dir string
-	_ = survey.AskOne(&survey.Input{Message: "Directory to upload:", Default: "./loadtest"}, &dir)
+	_ = ask.One(&survey.Input{Message: "Directory to upload:", Default: "./loadtest"}, &dir)
 	dir = strings.TrimSpace(dir)
 	if dir == "" {
 		return fmt.Errorf("no directory specified")
@@ -240,7 +241,7 @@ func handleEditCurrent(ctx context.Context, provider core.Provider, project stri
 	fmt.Printf("📦 Downloaded active bundle (version %s) to: %s\n", ptr.ActiveVersion, localDir)
 
 	var re bool
-	_ = survey.AskOne(&survey.Confirm{Message: "Re-upload now as a new version?", Default: false}, &re)
+	_ = ask.One(&survey.Confirm{Message: "Re-upload now as a new version?", Default: false}, &re)
 	if re {
 		pointer, version, err := provider.UploadLoadTestBundle(ctx, project, localDir)
 		if err != nil {
@@ -262,7 +263,7 @@ func handleDeletePointer(ctx context.Context, provider core.Provider, project st
 	curPtr, _ := provider.GetLoadTestPointer(ctx, project)
 	if curPtr == nil || curPtr.ActiveVersion == "" {
 		var sure bool
-		_ = survey.AskOne(&survey.Confirm{Message: "No active bundle. Delete current pointer file if present?", Default: false}, &sure)
+		_ = ask.One(&survey.Confirm{Message: "No active bundle. Delete current pointer file if present?", Default: false}, &sure)
 		if !sure {
 			return nil
 		}
@@ -274,7 +275,7 @@ func handleDeletePointer(ctx context.Context, provider core.Provider, project st
 	}
 
 	var confirm bool
-	_ = survey.AskOne(&survey.Confirm{Message: fmt.Sprintf("Delete bundle %s and roll back pointer to previous version?", curPtr.BundleID), Default: false}, &confirm)
+	_ = ask.One(&survey.Confirm{Message: fmt.Sprintf("Delete bundle %s and roll back pointer to previous version?", curPtr.BundleID), Default: false}, &confirm)
 	if !confirm {
 		return nil
 	}
@@ -294,12 +295,12 @@ func handleDeletePointer(ctx context.Context, provider core.Provider, project st
 // handlePurgeBundle deletes all loadtest-related objects for a project
 func handlePurgeBundle(ctx context.Context, provider core.Provider, ptr *models.LoadTestPointer) error {
 	var confirm bool
-	_ = survey.AskOne(&survey.Confirm{Message: "This will delete all loadtest bundles, versions, pointer, and index. Continue?", Default: false}, &confirm)
+	_ = ask.One(&survey.Confirm{Message: "This will delete all loadtest bundles, versions, pointer, and index. Continue?", Default: false}, &confirm)
 	if !confirm {
 		return nil
 	}
 	var typed string
-	_ = survey.AskOne(&survey.Input{Message: "Type 'permanently delete' to confirm:"}, &typed)
+	_ = ask.One(&survey.Input{Message: "Type 'permanently delete' to confirm:"}, &typed)
 	if strings.TrimSpace(typed) != "permanently delete" {
 		return fmt.Errorf("confirmation mismatch")
 	}
@@ -326,7 +327,7 @@ func handleLoadTestDeploy(ctx context.Context, provider core.Provider, projectNa
 		return nil
 	}
 
-	opts := &models.LoadTestDeploymentOptions{WorkerDesiredCount: 0}
+	opts := &models.LoadTestDeploymentOptions{WorkerDesiredCount: 0, Provider: provider.GetProviderType()}
 
 	// Collect BYO options using shared prompts package
 	if err := prompts.PromptAllBYOOptions(opts); err != nil {
@@ -367,7 +368,7 @@ func handleLoadTestScaleWorkers(ctx context.Context, provider core.Provider, pro
 	}
 
 	var desiredStr string
-	_ = survey.AskOne(&survey.Input{Message: "Enter desired worker count:"}, &desiredStr)
+	_ = ask.One(&survey.Input{Message: "Enter desired worker count:"}, &desiredStr)
 	desiredStr = strings.TrimSpace(desiredStr)
 	if desiredStr == "" {
 		return fmt.Errorf("no worker count provided")
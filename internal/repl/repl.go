@@ -5,19 +5,35 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/atotto/clipboard"
+	"github.com/hemantobora/auto-mock/internal/ask"
+	"github.com/hemantobora/auto-mock/internal/builders"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
 	"github.com/hemantobora/auto-mock/internal/mcp"
 	"github.com/hemantobora/auto-mock/internal/models"
+	"github.com/hemantobora/auto-mock/internal/network"
 	// aws specific purge (best-effort) only if underlying concrete type is AWS provider
 )
 
 const defaultBodyMatchType = "ONLY_MATCHING_FIELDS"
 
+// errorTaxonomy is the project's error envelope and codes; generated error responses follow it
+var errorTaxonomy *models.ErrorTaxonomy
+
+// UseErrorTaxonomy applies the project's error taxonomy to every generator: the AI prompt and
+// its output here, and the builders' error templates
+func UseErrorTaxonomy(t *models.ErrorTaxonomy) {
+	errorTaxonomy = t
+	builders.SetErrorTaxonomy(t)
+}
+
 // StartMockGenerationREPL is the main entry point for mock generation
 // StartMockGenerationREPL starts the interactive generation REPL.
 // If providerOverride is non-empty it will be used as the preselected MCP provider
@@ -30,16 +46,21 @@ func StartMockGenerationREPL(projectName string, providerOverride string) (strin
 
 	// Simple mock config generation for now
 	// Step 1: Choose generation method
+	options := []string{
+		"interactive - Build endpoints step-by-step (7-step builder)",
+		"collection - Import from Postman/Bruno/Insomnia",
+		"describe - Describe your API in natural language (AI-powered)",
+		"upload - Upload expectation file directly (JSON)",
+	}
+	if network.Offline() {
+		fmt.Println("📴 Offline: AI generation (describe) is unavailable")
+		options = append(options[:2], options[3:]...)
+	}
 	var method string
-	if err := survey.AskOne(&survey.Select{
+	if err := ask.One(&survey.Select{
 		Message: "How do you want to generate your mock configuration?",
-		Options: []string{
-			"interactive - Build endpoints step-by-step (7-step builder)",
-			"collection - Import from Postman/Bruno/Insomnia",
-			"describe - Describe your API in natural language (AI-powered)",
-			"upload - Upload expectation file directly (JSON)",
-		},
-		Default: "interactive - Build endpoints step-by-step (7-step builder)",
+		Options: options,
+		Default: options[0],
 	}, &method); err != nil {
 		return "", err
 	}
@@ -78,7 +99,7 @@ func ResolveProjectInteractively(existing []models.ProjectInfo) (models.ProjectI
 	options = append(options, "📝 Create New Project")
 
 	var choice string
-	if err := survey.AskOne(&survey.Select{
+	if err := ask.One(&survey.Select{
 		Message: "Select project:",
 		Options: options,
 	}, &choice); err != nil {
@@ -107,6 +128,15 @@ func SelectProjectAction(projectName string, existingConfig *models.MockConfigur
 			"replace - Replace ALL existing expectations with new ones",
 			"delete - Delete the project expectation and tear down infrastructure (if running)",
 			"add - Add new expectations to existing ones",
+			"schemas - Manage reusable JSON schemas (User, Order, Error)",
+			"examples - Browse the response example library (by content type and entity)",
+			"tenants - Serve other responses per tenant, selected by header or subdomain",
+			"errors - Define the project's error envelope and error codes",
+			"masking - Define masking profiles for demo deployments and exports",
+			"generators - Fake-data generators for ${uuid}, ${name}, ... placeholders and their seed",
+			"datasets - Seed data (CSV/JSON) served as list pages and rows by id",
+			"environments - Overlays for dev/stage/prod (hostnames, delays, data sets) served with deploy --env",
+			"diff - Compare two saved versions of the expectations",
 			"deploy - Deploy current expectations to cloud infrastructure",
 			"exit - Cancel the operation and exit",
 		}
@@ -118,7 +148,7 @@ func SelectProjectAction(projectName string, existingConfig *models.MockConfigur
 		}
 	}
 
-	survey.AskOne(&survey.Select{
+	ask.One(&survey.Select{
 		Message: fmt.Sprintf("Project: %s - What would you like to do?", projectName),
 		Options: options,
 	}, &action)
@@ -149,9 +179,13 @@ func generateMockConfiguration(method, projectName, providerOverride string) (st
 // - No file I/O, no sanitization (we show a short disclaimer).
 // - Provider selection with API key prompt (env first).
 // - REST / GraphQL prompt hint.
-// - One optional regenerate pass.
+// - Output streams as the provider generates it; Ctrl+C aborts the generation.
+// - Multi-turn refinement with conversation memory and a diff per turn.
 // - Returns MockServer JSON string produced from []models.MockExpectation.
 func generateFromDescription(ctx context.Context, projectName string, providerOverride string) (string, error) {
+	if err := network.Require("AI generation"); err != nil {
+		return "", err
+	}
 	fmt.Println("🤖 AI-Powered Generation")
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Println("⚠️  Disclaimer: Review inputs for any secrets/tokens before use.")
@@ -178,6 +212,9 @@ func generateFromDescription(ctx context.Context, projectName string, providerOv
 		opts := make([]string, 0, len(infos))
 		for _, pi := range infos {
 			label := pi.Name
+			if pi.Cost != "" {
+				label += " " + pi.Cost
+			}
 			if !pi.Available {
 				label += " (not configured)"
 			}
@@ -198,7 +235,7 @@ func generateFromDescription(ctx context.Context, projectName string, providerOv
 		if len(opts) == 0 {
 			return "", fmt.Errorf("no providers available to choose from")
 		}
-		if err := survey.AskOne(&survey.Select{
+		if err := ask.One(&survey.Select{
 			Message: "Choose an AI provider:",
 			Options: opts,
 			Default: opts[0],
@@ -214,7 +251,7 @@ func generateFromDescription(ctx context.Context, projectName string, providerOv
 
 	// 4) API style
 	var apiStyle string
-	if err := survey.AskOne(&survey.Select{
+	if err := ask.One(&survey.Select{
 		Message: "API style?",
 		Options: []string{"REST", "GraphQL"},
 		Default: "REST",
@@ -225,7 +262,7 @@ func generateFromDescription(ctx context.Context, projectName string, providerOv
 	// 5) Example preview
 	var description string
 	var useExample bool
-	_ = survey.AskOne(&survey.Confirm{
+	_ = ask.One(&survey.Confirm{
 		Message: "Would you like to view some example API descriptions?",
 		Default: false,
 	}, &useExample)
@@ -237,7 +274,7 @@ func generateFromDescription(ctx context.Context, projectName string, providerOv
 		}
 
 		var choice string
-		if err := survey.AskOne(&survey.Select{
+		if err := ask.One(&survey.Select{
 			Message: "Select an example to preview:",
 			Options: opts,
 		}, &choice); err != nil {
@@ -251,7 +288,7 @@ func generateFromDescription(ctx context.Context, projectName string, providerOv
 				fmt.Println("───────────────────────────────")
 
 				var copyIt bool
-				_ = survey.AskOne(&survey.Confirm{
+				_ = ask.One(&survey.Confirm{
 					Message: "Copy this example to clipboard so you can edit externally?",
 					Default: true,
 				}, &copyIt)
@@ -265,7 +302,7 @@ func generateFromDescription(ctx context.Context, projectName string, providerOv
 				}
 
 				var useIt bool
-				_ = survey.AskOne(&survey.Confirm{
+				_ = ask.One(&survey.Confirm{
 					Message: "Use this example as your description (without editing)?",
 					Default: false,
 				}, &useIt)
@@ -279,7 +316,7 @@ func generateFromDescription(ctx context.Context, projectName string, providerOv
 
 	if description == "" {
 		// Now open the multiline editor, prefilled if we picked an example above
-		if err := survey.AskOne(&survey.Multiline{
+		if err := ask.One(&survey.Multiline{
 			Message: "Describe your API (endpoints/types, fields, status codes, etc.):",
 			Help:    "Tip: list endpoints/operations, inputs/outputs, auth headers, error envelope, pagination. Include at least one error case.",
 			Default: description, // <<— this pre-fills with example if selected
@@ -294,43 +331,66 @@ func generateFromDescription(ctx context.Context, projectName string, providerOv
 
 	// 6) Optional hints toggle (kept tiny)
 	var addHints bool
-	_ = survey.AskOne(&survey.Confirm{
+	_ = ask.One(&survey.Confirm{
 		Message: "Add minimal hints (JSON-only request bodies STRlCT/ONLY_MATCHING_FIELDS; Velocity rule for responses)?",
 		Default: true,
 	}, &addHints)
 
-	// 7) First generation
-	prompt := buildPrompt(description, apiStyle, projectName, addHints)
-	jsonPreview, exp, err := callAndNormalize(ctx, provider, projectName, prompt)
+	// 7) First generation; the conversation keeps the rules as system instructions and every
+	// turn as context for the refinements
+	conv := mcp.NewConversation(provider, projectName, buildSystemPrompt(apiStyle, projectName, addHints))
+	prompt := buildDescriptionPrompt(description)
+	jsonPreview, exp, err := callAndNormalize(ctx, conv, prompt)
+	if errors.Is(err, errGenerationAborted) {
+		return "", exitcode.New(exitcode.Cancelled, "AI generation aborted")
+	}
 	if err != nil {
 		return "", err
 	}
+	conv.Record(prompt, models.ExpectationsToMockServerJSON(exp))
 	fmt.Println("\n📦 Preview (first ~40 lines):")
 	printFirstLines(jsonPreview, 40)
 
-	// 8) Optional regenerate pass
-	var again bool
-	_ = survey.AskOne(&survey.Confirm{
-		Message: "Regenerate with revised instructions?",
-		Default: false,
-	}, &again)
+	// 8) Refinement turns until the user is done; each shows what changed since the last version
+	for {
+		var refinement string
+		if err := ask.One(&survey.Input{
+			Message: "Refine the expectations (Enter to finish):",
+			Help:    `e.g. "make all IDs uuids", "add pagination to list endpoints", "add a 429 case"`,
+		}, &refinement); err != nil {
+			return "", err
+		}
+		if strings.TrimSpace(refinement) == "" {
+			break
+		}
+		prompt := buildRefinementPrompt(refinement)
+		refinedPreview, refined, err := callAndNormalize(ctx, conv, prompt)
+		switch {
+		case errors.Is(err, errGenerationAborted):
+			fmt.Println("↩️  Keeping the previous version.")
+			continue
+		case err != nil:
+			fmt.Printf("❌ %v\n↩️  Keeping the previous version.\n", err)
+			continue
+		}
 
-	if again {
-		var delta string
-		if err := survey.AskOne(&survey.Multiline{
-			Message: "Add constraints or changes:",
-		}, &delta); err != nil {
+		fmt.Println("\n🔍 Changes:")
+		fmt.Print(refinementDiff(exp, refined, conv.Turns()).Format())
+		var keep bool
+		if err := ask.One(&survey.Confirm{
+			Message: "Keep this version?",
+			Default: true,
+		}, &keep); err != nil {
 			return "", err
 		}
-		if strings.TrimSpace(delta) != "" {
-			prompt = prompt + "\n\nRefinements:\n" + strings.TrimSpace(delta)
-			jsonPreview, exp, err = callAndNormalize(ctx, provider, projectName, prompt)
-			if err != nil {
-				return "", err
-			}
-			fmt.Println("\n📦 Preview (first ~40 lines):")
-			printFirstLines(jsonPreview, 40)
+		if !keep {
+			fmt.Println("↩️  Keeping the previous version.")
+			continue
 		}
+		conv.Record(prompt, models.ExpectationsToMockServerJSON(refined))
+		jsonPreview, exp = refinedPreview, refined
+		fmt.Println("\n📦 Preview (first ~40 lines):")
+		printFirstLines(jsonPreview, 40)
 	}
 
 	// 9) Return final MockServer JSON (coexists with other generators)
@@ -339,7 +399,8 @@ func generateFromDescription(ctx context.Context, projectName string, providerOv
 
 // --- helpers (kept minimal) ---
 
-func buildPrompt(description, apiStyle, projectName string, addHints bool) string {
+// buildSystemPrompt holds the output rules and project context, kept across refinement turns
+func buildSystemPrompt(apiStyle, projectName string, addHints bool) string {
 	var sb strings.Builder
 
 	sb.WriteString("Return ONLY a valid JSON array of MockServer expectations. No prose, no markdown, no code fences.\n\n")
@@ -399,38 +460,77 @@ func buildPrompt(description, apiStyle, projectName string, addHints bool) strin
 		sb.WriteString("- Use ISO 8601 timestamps and deterministic IDs (e.g., u_1001, order_001).\n")
 		sb.WriteString("- Prefer compact responses over verbose ones.\n")
 		sb.WriteString("- Always include \"times\": { \"unlimited\": true } unless a finite repetition is intended.\n")
-		sb.WriteString("- Include at least one error response with envelope: {\"error\":{\"code\":\"<CODE>\",\"message\":\"<DETAIL>\"}}.\n")
+		if errorTaxonomy == nil {
+			sb.WriteString("- Include at least one error response with envelope: {\"error\":{\"code\":\"<CODE>\",\"message\":\"<DETAIL>\"}}.\n")
+		}
+	}
+
+	if errorTaxonomy != nil {
+		sb.WriteString("\nProject Error Taxonomy:\n")
+		sb.WriteString(errorTaxonomy.PromptRules())
 	}
 
 	sb.WriteString("\nProject Context:\n")
 	sb.WriteString("This is for project: " + projectName + "\n")
 
-	sb.WriteString("\nUser Description:\n")
-	sb.WriteString(strings.TrimSpace(description))
-	sb.WriteString("\n\nOutput strictly as a raw JSON array. Nothing else.\n")
-
 	return sb.String()
 }
 
-func callAndNormalize(ctx context.Context, provider, project, prompt string) (pretty string, exps []models.MockExpectation, err error) {
-	// call MCP
-	res, err := mcp.GenerateWithProvider(ctx, prompt, provider, project)
+// buildDescriptionPrompt is the first turn: the user's API description
+func buildDescriptionPrompt(description string) string {
+	return "User Description:\n" + strings.TrimSpace(description) + "\n\nOutput strictly as a raw JSON array. Nothing else.\n"
+}
+
+// buildRefinementPrompt asks for the whole updated set, so each answer replaces the last one
+func buildRefinementPrompt(instruction string) string {
+	return "Refine the current expectations: " + strings.TrimSpace(instruction) + "\n\n" +
+		"Return the complete updated JSON array (every expectation, changed or not) following the same rules. Nothing else.\n"
+}
+
+// refinementDiff compares the expectation set before and after refinement turn n
+func refinementDiff(before, after []models.MockExpectation, n int) *models.ConfigDiff {
+	return models.DiffVersions(
+		&models.MockConfiguration{Metadata: models.ConfigMetadata{Version: fmt.Sprintf("turn %d", n)}, Expectations: before},
+		&models.MockConfiguration{Metadata: models.ConfigMetadata{Version: fmt.Sprintf("turn %d", n+1)}, Expectations: after},
+	)
+}
+
+// errGenerationAborted is returned by callAndNormalize when the user pressed Ctrl+C mid-generation
+var errGenerationAborted = errors.New("generation aborted")
+
+// maxRepairAttempts is how often output that does not parse or validate is sent back to the
+// provider with the error before the failure is shown
+const maxRepairAttempts = 2
+
+func callAndNormalize(ctx context.Context, conv *mcp.Conversation, prompt string) (pretty string, exps []models.MockExpectation, err error) {
+	res, err := streamTurn(ctx, conv, prompt)
 	if err != nil {
 		return "", nil, err
 	}
-	raw := strings.TrimSpace(res.MockServerJSON)
-	if raw == "" {
-		return "", nil, fmt.Errorf("provider returned empty JSON")
+	tmp, problem := parseExpectations(res.MockServerJSON)
+	for attempt := 1; problem != nil; attempt++ {
+		if attempt > maxRepairAttempts {
+			return "", nil, fmt.Errorf("%w (still failing after %d repair attempt(s))", problem, maxRepairAttempts)
+		}
+		fmt.Printf("\n🩹 Repair attempt %d/%d: the output was not usable: %v\n", attempt, maxRepairAttempts, problem)
+		// The repair sees the request and the broken answer; neither is remembered by conv
+		repair := *conv
+		repair.History = append(append([]mcp.Message{}, conv.History...),
+			mcp.Message{Role: "user", Content: prompt},
+			mcp.Message{Role: "assistant", Content: res.MockServerJSON})
+		if res, err = streamTurn(ctx, &repair, buildRepairPrompt(problem)); err != nil {
+			return "", nil, err
+		}
+		tmp, problem = parseExpectations(res.MockServerJSON)
+		if problem == nil {
+			fmt.Printf("✅ Repaired on attempt %d\n", attempt)
+		}
 	}
 
-	// unmarshal to your model
-	var tmp []models.MockExpectation
-	if err := json.Unmarshal([]byte(raw), &tmp); err != nil {
-		return "", nil, fmt.Errorf("invalid JSON from provider: %w", err)
+	if n := models.ApplyErrorTaxonomy(tmp, errorTaxonomy); n > 0 {
+		fmt.Printf("\n🧯 Reshaped %d error response(s) into the project error envelope\n", n)
 	}
-
-	// normalize per your strict rules
-	normalizeExpectations(&tmp)
+	models.StampProvenance(tmp, models.ProvenanceAI, fmt.Sprintf("%s generation", res.Provider), models.NewRunID(models.ProvenanceAI))
 
 	// pretty preview
 	out := models.ExpectationsToMockServerJSON(tmp)
@@ -441,6 +541,47 @@ func callAndNormalize(ctx context.Context, provider, project, prompt string) (pr
 	return out, tmp, nil
 }
 
+// streamTurn sends one turn, streaming the output as it arrives; Ctrl+C aborts only the generation
+func streamTurn(ctx context.Context, conv *mcp.Conversation, prompt string) (mcp.Result, error) {
+	genCtx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+	fmt.Println("(Ctrl+C aborts the generation)")
+	res, err := conv.Send(genCtx, prompt, os.Stdout)
+	if genCtx.Err() != nil && ctx.Err() == nil {
+		fmt.Println("\n⏹  Generation aborted.")
+		return mcp.Result{}, errGenerationAborted
+	}
+	return res, err
+}
+
+// parseExpectations decodes and normalizes provider output, failing when it is not a JSON
+// array of expectations or when the expectations have blocking validation errors
+func parseExpectations(raw string) ([]models.MockExpectation, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, fmt.Errorf("provider returned empty JSON")
+	}
+	var tmp []models.MockExpectation
+	if err := json.Unmarshal([]byte(raw), &tmp); err != nil {
+		return nil, fmt.Errorf("invalid JSON from provider: %w", err)
+	}
+	normalizeExpectations(&tmp)
+	if errs := models.Validate(tmp).Errors(); len(errs) > 0 {
+		lines := make([]string, len(errs))
+		for i, issue := range errs {
+			lines[i] = issue.String()
+		}
+		return nil, fmt.Errorf("%d validation error(s):\n%s", len(errs), strings.Join(lines, "\n"))
+	}
+	return tmp, nil
+}
+
+// buildRepairPrompt sends the parse or validation error back with the rules for the fix
+func buildRepairPrompt(problem error) string {
+	return "Your previous answer could not be used:\n" + problem.Error() + "\n\n" +
+		"Fix these problems and return the complete corrected JSON array of expectations (indexes refer to positions in it), following the same rules. Nothing else.\n"
+}
+
 func normalizeExpectations(exps *[]models.MockExpectation) {
 	for i := range *exps {
 		e := &(*exps)[i]
@@ -508,21 +649,32 @@ func coerceResponseBody(body any) any {
 }
 
 func ensureProviderAPIKey(provider string) bool {
+	for _, pi := range mcp.ListProviders() {
+		if pi.Name == provider && pi.Available {
+			return true
+		}
+	}
+	if strings.EqualFold(provider, "bedrock") {
+		// Bedrock signs with AWS credentials; there is no key to enter
+		fmt.Println("❌ Bedrock needs AWS credentials: set AWS_PROFILE, AWS_ACCESS_KEY_ID or run 'aws sso login'")
+		return false
+	}
 	envByProvider := map[string]string{
 		"anthropic": "ANTHROPIC_API_KEY",
 		"openai":    "OPENAI_API_KEY",
+		"gemini":    "GEMINI_API_KEY",
 	}
 	envName := envByProvider[strings.ToLower(provider)]
 	if envName == "" {
 		// unknown provider: ask custom var
 		var name, val string
-		_ = survey.AskOne(&survey.Input{Message: "Env var name for this provider API key:"}, &name)
+		_ = ask.One(&survey.Input{Message: "Env var name for this provider API key:"}, &name)
 		name = strings.TrimSpace(name)
 		if name == "" {
 			return false
 		}
 		if os.Getenv(name) == "" {
-			_ = survey.AskOne(&survey.Password{Message: "Enter API key:"}, &val)
+			_ = ask.One(&survey.Password{Message: "Enter API key:"}, &val)
 			val = strings.TrimSpace(val)
 			if val == "" {
 				return false
@@ -536,7 +688,7 @@ func ensureProviderAPIKey(provider string) bool {
 		return true
 	}
 	var v string
-	_ = survey.AskOne(&survey.Password{Message: fmt.Sprintf("Enter %s:", envName)}, &v)
+	_ = ask.One(&survey.Password{Message: fmt.Sprintf("Enter %s:", envName)}, &v)
 	v = strings.TrimSpace(v)
 	if v == "" {
 		return false
@@ -0,0 +1,26 @@
+package repl
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseExpectations(t *testing.T) {
+	valid := `[{"httpRequest":{"method":"GET","path":"/users"},"httpResponse":{"statusCode":200,"body":{"type":"JSON","json":"{\"users\":[]}"}}}]`
+	exps, err := parseExpectations("\n" + valid + "\n")
+	if err != nil || len(exps) != 1 {
+		t.Fatalf("valid output: %d expectations, %v", len(exps), err)
+	}
+
+	for name, c := range map[string]struct{ raw, want string }{
+		"empty":      {"  ", "empty JSON"},
+		"not json":   {`[{"httpRequest":`, "invalid JSON"},
+		"not array":  {`{"httpRequest":{}}`, "invalid JSON"},
+		"validation": {`[{"httpRequest":{"method":"DELETE","path":"/users/1"},"httpResponse":{"statusCode":204,"body":"gone"}}]`, "1 validation error(s):\n[0] DELETE /users/1 httpResponse.body"},
+	} {
+		_, err := parseExpectations(c.raw)
+		if err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("%s: got %v, want an error containing %q", name, err, c.want)
+		}
+	}
+}
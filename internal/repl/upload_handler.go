@@ -8,7 +8,9 @@ import (
 	"os"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/hemantobora/auto-mock/internal/ask"
 	"github.com/hemantobora/auto-mock/internal/builders"
+	"github.com/hemantobora/auto-mock/internal/models"
 )
 
 // configureUploadedExpectationWithMenu handles file upload with deployment menu
@@ -17,7 +19,7 @@ func configureUploadedExpectationWithMenu(projectName string) (string, error) {
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━")
 
 	var filePath string
-	if err := survey.AskOne(&survey.Input{
+	if err := ask.One(&survey.Input{
 		Message: "Enter path to expectation json file:",
 	}, &filePath); err != nil {
 		return "", err
@@ -48,7 +50,8 @@ func configureUploadedExpectationWithMenu(projectName string) (string, error) {
 	// Validate
 	validCount := 0
 	for i, exp := range expectations {
-		if exp.HttpRequest.Method != "" && exp.HttpRequest.Path != "" && exp.HttpResponse.StatusCode != 0 {
+		if exp.HttpRequest != nil && exp.HttpRequest.Method != "" && exp.HttpRequest.Path != "" &&
+			(exp.HttpResponseTemplate != nil || (exp.HttpResponse != nil && exp.HttpResponse.StatusCode != 0)) {
 			validCount++
 		} else {
 			fmt.Printf("⚠️  Warning: Expectation %d is missing required fields\n", i+1)
@@ -61,6 +64,11 @@ func configureUploadedExpectationWithMenu(projectName string) (string, error) {
 
 	fmt.Printf("✅ Found %d expectation(s) in file (%d valid)\n", len(expectations), validCount)
 
+	expectations, merged := models.DeduplicateExpectations(expectations)
+	if report := models.FormatMergeReport(merged); report != "" {
+		fmt.Print(report)
+	}
+
 	mockServerJSON := builders.ExpectationsToMockServerJSON(expectations)
 	return mockServerJSON, nil
 }
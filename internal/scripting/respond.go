@@ -0,0 +1,128 @@
+// Package scripting runs response templates that compute a mock response from the request.
+package scripting
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/hemantobora/auto-mock/internal/matcher"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// ScriptTimeout bounds a single response template run so a runaway loop cannot stall serving
+const ScriptTimeout = 500 * time.Millisecond
+
+// Respond computes the response exp gives to req. A JavaScript response template runs against
+// the request the same way MockServer runs it; otherwise the static response is returned.
+func Respond(exp *models.MockExpectation, req *matcher.Request) (*models.HttpResponse, error) {
+	if exp.HttpResponseTemplate == nil {
+		return exp.HttpResponse, nil
+	}
+	return Render(exp.HttpResponseTemplate, req)
+}
+
+// Render runs a response template. The template is a function body with `request`
+// ({method, path, headers, queryStringParameters, body}) in scope that returns
+// {statusCode, headers, body, delay}; headers map a name to a string or a list of strings, a
+// non-string body is sent as JSON and delay is {timeUnit, value}.
+func Render(tmpl *models.HttpTemplate, req *matcher.Request) (*models.HttpResponse, error) {
+	if t := strings.ToUpper(tmpl.TemplateType); t != models.TemplateJavaScript {
+		return nil, fmt.Errorf("unsupported template type %q (only %s templates run locally)", tmpl.TemplateType, models.TemplateJavaScript)
+	}
+
+	vm := goja.New()
+	timer := time.AfterFunc(ScriptTimeout, func() { vm.Interrupt("timed out") })
+	defer timer.Stop()
+
+	fn, err := vm.RunString("(function(request) {\n" + tmpl.Template + "\n})")
+	if err != nil {
+		return nil, fmt.Errorf("template does not compile: %w", err)
+	}
+	call, ok := goja.AssertFunction(fn)
+	if !ok {
+		return nil, fmt.Errorf("template is not a function body")
+	}
+	result, err := call(goja.Undefined(), vm.ToValue(scriptRequest(req)))
+	if err != nil {
+		return nil, fmt.Errorf("template failed: %w", err)
+	}
+	if goja.IsUndefined(result) || goja.IsNull(result) {
+		return nil, fmt.Errorf("template returned no response")
+	}
+	out, ok := result.Export().(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("template must return an object, got %s", result.ExportType())
+	}
+	return scriptResponse(out)
+}
+
+// scriptRequest exposes req with MockServer's field names; multi-valued fields are lists
+func scriptRequest(req *matcher.Request) map[string]any {
+	headers := map[string]any{}
+	for name, values := range req.Headers {
+		headers[strings.ToLower(name)] = append([]string(nil), values...)
+	}
+	query := map[string]any{}
+	for name, values := range req.Query {
+		query[name] = append([]string(nil), values...)
+	}
+	return map[string]any{
+		"method":                req.Method,
+		"path":                  req.Path,
+		"headers":               headers,
+		"queryStringParameters": query,
+		"body":                  string(req.Body),
+	}
+}
+
+func scriptResponse(out map[string]any) (*models.HttpResponse, error) {
+	resp := &models.HttpResponse{StatusCode: 200, Body: out["body"]}
+	if v, ok := out["statusCode"]; ok {
+		switch code := v.(type) {
+		case int64:
+			resp.StatusCode = int(code)
+		case float64:
+			resp.StatusCode = int(code)
+		default:
+			return nil, fmt.Errorf("statusCode must be a number, got %v", v)
+		}
+	}
+	if raw, ok := out["delay"].(map[string]any); ok {
+		delay := &models.Delay{TimeUnit: "MILLISECONDS"}
+		if unit, ok := raw["timeUnit"].(string); ok {
+			delay.TimeUnit = unit
+		}
+		switch v := raw["value"].(type) {
+		case int64:
+			delay.Value = int(v)
+		case float64:
+			delay.Value = int(v)
+		default:
+			return nil, fmt.Errorf("delay value must be a number, got %v", raw["value"])
+		}
+		resp.Delay = delay
+	}
+	if raw, ok := out["headers"].(map[string]any); ok {
+		names := make([]string, 0, len(raw))
+		for name := range raw {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			var values []string
+			switch v := raw[name].(type) {
+			case []any:
+				for _, one := range v {
+					values = append(values, fmt.Sprint(one))
+				}
+			default:
+				values = []string{fmt.Sprint(v)}
+			}
+			resp.Headers = append(resp.Headers, models.NameValues{Name: name, Values: values})
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,334 @@
+package scripting
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hemantobora/auto-mock/internal/matcher"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+func TestRespond_ComputesTotalFromLineItems(t *testing.T) {
+	exp := &models.MockExpectation{
+		HttpRequest:  &models.HttpRequest{Method: "POST", Path: "/orders"},
+		HttpResponse: &models.HttpResponse{StatusCode: 200},
+		HttpResponseTemplate: &models.HttpTemplate{
+			TemplateType: models.TemplateJavaScript,
+			Template: `var order = JSON.parse(request.body);
+var total = order.items.reduce(function (sum, i) { return sum + i.price * i.qty; }, 0);
+return {
+  statusCode: 201,
+  headers: { 'content-type': 'application/json', 'x-method': [request.method] },
+  body: { total: total }
+};`,
+		},
+	}
+	req := &matcher.Request{Method: "POST", Path: "/orders", Query: url.Values{}, Headers: http.Header{},
+		Body: []byte(`{"items":[{"price":2.5,"qty":2},{"price":10,"qty":1}]}`)}
+
+	resp, err := Respond(exp, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != 201 {
+		t.Fatalf("statusCode = %d, want 201", resp.StatusCode)
+	}
+	body, _ := resp.Body.(map[string]any)
+	if body["total"] != int64(15) && body["total"] != float64(15) {
+		t.Fatalf("total = %v, want 15", body["total"])
+	}
+	if len(resp.Headers) != 2 || resp.Headers[1].Name != "x-method" || resp.Headers[1].Values[0] != "POST" {
+		t.Fatalf("headers = %+v", resp.Headers)
+	}
+}
+
+func TestRender_Errors(t *testing.T) {
+	req := &matcher.Request{Method: "GET", Path: "/x"}
+	cases := map[string]string{
+		"while (true) {}":          "timed out",
+		"return 42;":               "must return an object",
+		"return {statusCode: 'x'}": "statusCode must be a number",
+		"return {":                 "does not compile",
+	}
+	for script, want := range cases {
+		_, err := Render(&models.HttpTemplate{TemplateType: models.TemplateJavaScript, Template: script}, req)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%q: err = %v, want %q", script, err, want)
+		}
+	}
+}
+
+func TestRespond_RangeCompanionSlicesBody(t *testing.T) {
+	data := []byte("0123456789abcdefghij") // 20 bytes
+	cfg := &models.MockConfiguration{Expectations: []models.MockExpectation{{
+		HttpRequest: &models.HttpRequest{Method: "GET", Path: "/files/report.bin"},
+		HttpResponse: &models.HttpResponse{StatusCode: 200, Body: map[string]any{"type": "BINARY", "base64Bytes": base64.StdEncoding.EncodeToString(data)},
+			Headers: []models.NameValues{{Name: "Content-Type", Values: []string{"application/pdf"}}}},
+		Ranges: true,
+	}}}
+	if n, err := cfg.ApplyRanges(); err != nil || n != 1 || len(cfg.Expectations) != 2 {
+		t.Fatalf("generated %d, err %v", n, err)
+	}
+	companion := &cfg.Expectations[0]
+
+	for _, tc := range []struct {
+		rangeHeader  string
+		status       int
+		contentRange string
+		body         string
+	}{
+		{"bytes=0-4", 206, "bytes 0-4/20", "01234"},
+		{"bytes=7-11", 206, "bytes 7-11/20", "789ab"},
+		{"bytes=15-", 206, "bytes 15-19/20", "fghij"},
+		{"bytes=-3", 206, "bytes 17-19/20", "hij"},
+		{"bytes=18-100", 206, "bytes 18-19/20", "ij"},
+		{"bytes=20-25", 416, "bytes */20", ""},
+		{"bytes=0-1,5-6", 200, "", string(data)},
+	} {
+		req := &matcher.Request{Method: "GET", Path: "/files/report.bin", Query: url.Values{}, Headers: http.Header{}}
+		req.Headers.Set("Range", tc.rangeHeader)
+		resp, err := Respond(companion, req)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.rangeHeader, err)
+		}
+		var got string
+		if b, ok := resp.Body.(map[string]any); ok {
+			raw, _ := base64.StdEncoding.DecodeString(b["base64Bytes"].(string))
+			got = string(raw)
+		}
+		var contentRange, contentType string
+		for _, h := range resp.Headers {
+			switch h.Name {
+			case "Content-Range":
+				contentRange = h.Values[0]
+			case "Content-Type":
+				contentType = h.Values[0]
+			}
+		}
+		if resp.StatusCode != tc.status || contentRange != tc.contentRange || got != tc.body || contentType != "application/pdf" {
+			t.Errorf("%s: %d %q %q (%s)", tc.rangeHeader, resp.StatusCode, contentRange, got, contentType)
+		}
+	}
+}
+
+func TestRespond_RedirectLocationEchoesRequest(t *testing.T) {
+	cfg := &models.MockConfiguration{Expectations: []models.MockExpectation{{
+		HttpRequest: &models.HttpRequest{Method: "GET", Path: "/consent"},
+		Redirects: &models.Redirects{Hops: []models.RedirectHop{
+			{Location: "${request.query.redirect_uri}?code=abc&state=${request.query.state}&lang=${request.headers.Accept-Language:en}"},
+		}},
+	}}}
+	if _, err := cfg.ApplyRedirects(); err != nil {
+		t.Fatal(err)
+	}
+	req := &matcher.Request{Method: "GET", Path: "/consent", Headers: http.Header{},
+		Query: url.Values{"redirect_uri": {"https://app.example/cb"}, "state": {"xyz"}}}
+
+	resp, err := Respond(&cfg.Expectations[0], req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != 302 || len(resp.Headers) != 1 || resp.Headers[0].Values[0] != "https://app.example/cb?code=abc&state=xyz&lang=en" {
+		t.Fatalf("response = %d %+v", resp.StatusCode, resp.Headers)
+	}
+}
+
+func TestRespond_FaultTemplate(t *testing.T) {
+	cfg := &models.MockConfiguration{Expectations: []models.MockExpectation{{
+		HttpRequest:  &models.HttpRequest{Method: "GET", Path: "/users"},
+		HttpResponse: &models.HttpResponse{StatusCode: 200, Body: `{"users": []}`},
+		Faults:       &models.Faults{ErrorRate: 100, ErrorStatus: 502, LatencyMs: 100, JitterMs: 50},
+	}}}
+	if _, err := cfg.ApplyFaults(); err != nil {
+		t.Fatal(err)
+	}
+	req := &matcher.Request{Method: "GET", Path: "/users", Query: url.Values{}, Headers: http.Header{}}
+	resp, err := Respond(&cfg.Expectations[0], req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != 502 || resp.Delay == nil || resp.Delay.Value < 100 || resp.Delay.Value > 150 {
+		t.Errorf("injected error: %d, delay %+v", resp.StatusCode, resp.Delay)
+	}
+
+	cfg.Expectations[1].Faults.ErrorRate = 0
+	if _, err := cfg.ApplyFaults(); err != nil {
+		t.Fatal(err)
+	}
+	if resp, err = Respond(&cfg.Expectations[0], req); err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := base64.StdEncoding.DecodeString(resp.Body.(map[string]any)["base64Bytes"].(string))
+	if resp.StatusCode != 200 || string(raw) != `{"users": []}` || resp.Delay.Value < 100 {
+		t.Errorf("jittered response: %d %q, delay %+v", resp.StatusCode, raw, resp.Delay)
+	}
+}
+
+func TestRespond_RequestTemplateEchoesRequest(t *testing.T) {
+	exp := models.MockExpectation{
+		HttpRequest: &models.HttpRequest{Method: "POST", Path: "/users/{id}/orders"},
+		HttpResponse: &models.HttpResponse{
+			StatusCode: 201,
+			Headers:    []models.NameValues{{Name: "X-Request-Id", Values: []string{"${request.headers.x-request-id}"}}},
+			Body: map[string]any{"type": "JSON", "json": map[string]any{
+				"userId":   "$!request.pathParameters['id'][0]",
+				"page":     "${request.query.page:1}",
+				"qty":      "${request.body.items[0].qty}",
+				"customer": "${request.body.customer}",
+				"summary":  "${request.method} ${request.path} for ${request.body.customer.name}",
+				"missing":  "${request.body.coupon}",
+			}},
+		},
+	}
+	config := &models.MockConfiguration{Expectations: []models.MockExpectation{exp}}
+	if n, err := config.ApplyRequestTemplates(); err != nil || n != 1 {
+		t.Fatalf("ApplyRequestTemplates = %d, %v", n, err)
+	}
+	req := &matcher.Request{Method: "POST", Path: "/users/u%2042/orders", Query: url.Values{},
+		Headers: http.Header{"X-Request-Id": {"req-7"}},
+		Body:    []byte(`{"customer": {"name": "Ada"}, "items": [{"qty": 3}]}`)}
+
+	resp, err := Respond(&config.Expectations[0], req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != 201 || len(resp.Headers) != 2 || resp.Headers[1].Name != "X-Request-Id" || resp.Headers[1].Values[0] != "req-7" {
+		t.Fatalf("response = %d %+v", resp.StatusCode, resp.Headers)
+	}
+	var body map[string]any
+	if err := json.Unmarshal([]byte(resp.Body.(string)), &body); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"userId":   "u 42",
+		"page":     "1",
+		"qty":      float64(3),
+		"customer": map[string]any{"name": "Ada"},
+		"summary":  "POST /users/u%2042/orders for Ada",
+		"missing":  nil,
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("body = %v", body)
+	}
+}
+
+func TestRespond_DatasetPagesAndRows(t *testing.T) {
+	users, err := models.ParseDataset("users", "users.csv", []byte("id,name,role\n1,Ada,admin\n2,Grace,user\n3,Linus,user\n4,Ken,user\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &models.MockConfiguration{
+		Datasets: []models.Dataset{users},
+		Expectations: []models.MockExpectation{
+			{
+				HttpRequest:  &models.HttpRequest{Method: "GET", Path: "/users"},
+				HttpResponse: &models.HttpResponse{StatusCode: 200},
+				Dataset:      &models.DatasetBinding{Name: "users", Mode: models.DatasetList, PageSize: 2},
+			},
+			{
+				HttpRequest: &models.HttpRequest{Method: "GET", Path: "/users/{userId}"},
+				HttpResponse: &models.HttpResponse{StatusCode: 200, Body: map[string]any{"type": "JSON", "json": map[string]any{
+					"user": "${dataset.row}", "greeting": "Hi ${dataset.row.name}",
+				}}},
+				Dataset: &models.DatasetBinding{Name: "users", Mode: models.DatasetItem},
+			},
+		},
+	}
+	if n, err := config.ApplyRequestTemplates(); err != nil || n != 2 {
+		t.Fatalf("ApplyRequestTemplates = %d, %v", n, err)
+	}
+
+	respond := func(i int, path string, query url.Values) (int, map[string]any) {
+		t.Helper()
+		resp, err := Respond(&config.Expectations[i], &matcher.Request{Method: "GET", Path: path, Query: query, Headers: http.Header{}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var body map[string]any
+		if err := json.Unmarshal([]byte(resp.Body.(string)), &body); err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode, body
+	}
+
+	_, page := respond(0, "/users", url.Values{"page": {"2"}})
+	if want := map[string]any{"data": []any{
+		map[string]any{"id": float64(3), "name": "Linus", "role": "user"},
+		map[string]any{"id": float64(4), "name": "Ken", "role": "user"},
+	}, "page": float64(2), "size": float64(2), "total": float64(4), "pages": float64(2),
+		"links": map[string]any{"next": nil, "prev": "/users?page=1"}}; !reflect.DeepEqual(page, want) {
+		t.Errorf("page 2 = %v", page)
+	}
+	if _, filtered := respond(0, "/users", url.Values{"role": {"user"}, "size": {"10"}, "sort": {"name"}}); filtered["total"] != float64(3) || len(filtered["data"].([]any)) != 3 {
+		t.Errorf("filtered = %v", filtered)
+	}
+
+	status, row := respond(1, "/users/2", url.Values{})
+	if want := map[string]any{"user": map[string]any{"id": float64(2), "name": "Grace", "role": "user"}, "greeting": "Hi Grace"}; status != 200 || !reflect.DeepEqual(row, want) {
+		t.Errorf("row = %d %v", status, row)
+	}
+	if status, missing := respond(1, "/users/9", url.Values{}); status != 404 || missing["error"] != "not found" {
+		t.Errorf("missing row = %d %v", status, missing)
+	}
+}
+
+func TestRespond_DatasetPagingStyles(t *testing.T) {
+	rows := make([]map[string]any, 5)
+	for i := range rows {
+		rows[i] = map[string]any{"sku": fmt.Sprintf("P%d", i+1), "color": []string{"red", "blue"}[i%2]}
+	}
+	list := func(paging string) models.MockExpectation {
+		return models.MockExpectation{
+			HttpRequest:  &models.HttpRequest{Method: "GET", Path: "/products"},
+			HttpResponse: &models.HttpResponse{StatusCode: 200},
+			Dataset:      &models.DatasetBinding{Name: "products", Mode: models.DatasetList, Paging: paging, PageSize: 2},
+		}
+	}
+	config := &models.MockConfiguration{
+		Datasets:     []models.Dataset{{Name: "products", Key: "sku", Rows: rows}},
+		Expectations: []models.MockExpectation{list(models.PagingOffset), list(models.PagingCursor)},
+	}
+	if _, err := config.ApplyRequestTemplates(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		exp    int
+		query  url.Values
+		status int
+		want   string
+	}{
+		{0, url.Values{"offset": {"1"}}, 200,
+			`{"data":[{"color":"blue","sku":"P2"},{"color":"red","sku":"P3"}],"limit":2,"links":{"next":"/products?offset=3","prev":"/products?offset=0"},"offset":1,"total":5}`},
+		{0, url.Values{"offset": {"4"}, "limit": {"3"}}, 200,
+			`{"data":[{"color":"red","sku":"P5"}],"limit":3,"links":{"next":null,"prev":"/products?limit=3&offset=1"},"offset":4,"total":5}`},
+		{1, url.Values{}, 200,
+			`{"data":[{"color":"red","sku":"P1"},{"color":"blue","sku":"P2"}],"limit":2,"links":{"next":"/products?cursor=P2","prev":null},"nextCursor":"P2","total":5}`},
+		{1, url.Values{"cursor": {"P4"}}, 200,
+			`{"data":[{"color":"red","sku":"P5"}],"limit":2,"links":{"next":null,"prev":"/products?cursor=P2"},"nextCursor":null,"total":5}`},
+		{1, url.Values{"cursor": {"P2"}}, 200,
+			`{"data":[{"color":"red","sku":"P3"},{"color":"blue","sku":"P4"}],"limit":2,"links":{"next":"/products?cursor=P4","prev":"/products"},"nextCursor":"P4","total":5}`},
+		{1, url.Values{"color": {"red"}, "cursor": {"P3"}, "limit": {"1"}}, 200,
+			`{"data":[{"color":"red","sku":"P5"}],"limit":1,"links":{"next":null,"prev":"/products?color=red&cursor=P1&limit=1"},"nextCursor":null,"total":3}`},
+		{1, url.Values{"cursor": {"P9"}}, 400, `{"error":"bad request","message":"unknown cursor P9"}`},
+	} {
+		resp, err := Respond(&config.Expectations[tc.exp], &matcher.Request{Method: "GET", Path: "/products", Query: tc.query, Headers: http.Header{}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got, want any
+		if err := json.Unmarshal([]byte(resp.Body.(string)), &got); err != nil {
+			t.Fatal(err)
+		}
+		json.Unmarshal([]byte(tc.want), &want)
+		if resp.StatusCode != tc.status || !reflect.DeepEqual(got, want) {
+			t.Errorf("%d %v = %d %s, want %d %s", tc.exp, tc.query, resp.StatusCode, resp.Body, tc.status, tc.want)
+		}
+	}
+}
@@ -0,0 +1,656 @@
+// Package security detects credentials and tokens while a collection is executed, and masks
+// them in console output and in the expectations generated from it.
+package security
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// Kinds of secrets the sanitizer reports
+const (
+	KindAuthorization = "authorization header"
+	KindAPIKey        = "API key"
+	KindToken         = "token"
+	KindPassword      = "password"
+	KindCookie        = "session cookie"
+	KindCloudKey      = "cloud access key"
+)
+
+// minLearned is the shortest value remembered as a secret. Shorter ones are masked only where
+// they were found, so that short strings such as "true" are not masked everywhere.
+const minLearned = 6
+
+// maxListed bounds the findings listed in the summary
+const maxListed = 15
+
+var (
+	secretName     = regexp.MustCompile(`(?i)(token|secret|password|passwd|^pwd$|api[_-]?key|^key$|authorization|credential|session[_-]?id|private[_-]?key|access[_-]?key|signature)`)
+	secretMetaName = regexp.MustCompile(`(?i)(type|expires?[_-]?in|expires?[_-]?at|expiry|url|uri|endpoint)$`)
+	apiKeyName     = regexp.MustCompile(`(?i)(api[_-]?key|^key$|access[_-]?key)`)
+	sessionCookie  = regexp.MustCompile(`(?i)(sess|sid|auth|token|jwt|remember)`)
+	authScheme     = regexp.MustCompile(`(?i)\b(bearer|basic)\s+([A-Za-z0-9._~+/=-]{8,})`)
+	jwtPattern     = regexp.MustCompile(`eyJ[A-Za-z0-9_-]{4,}\.[A-Za-z0-9_-]{4,}\.[A-Za-z0-9_-]*`)
+	awsKeyPattern  = regexp.MustCompile(`\b(?:AKIA|ASIA)[A-Z0-9]{16}\b`)
+	apiKeyPattern  = regexp.MustCompile(`\b(?:sk-[A-Za-z0-9_-]{16,}|[sr]k_(?:live|test)_[A-Za-z0-9]{16,}|gh[pousr]_[A-Za-z0-9]{20,})\b`)
+	placeholder    = regexp.MustCompile(`^(\{\{[^}]+\}\}|\$\{[^}]+\})$`)
+)
+
+// Finding is one secret detected during an import
+type Finding struct {
+	Kind  string // one of the Kind constants
+	Where string // e.g. "POST Login: request header Authorization"
+}
+
+// CollectionSanitizer remembers the secrets seen in requests, responses and script variables
+// of one import, so that the same values can be masked wherever they appear later. It is safe
+// for concurrent use; a nil sanitizer detects and masks nothing.
+type CollectionSanitizer struct {
+	mu       sync.Mutex
+	secrets  map[string]string // secret value → kind
+	findings []Finding
+	reported map[Finding]bool
+	masked   int // values masked in generated expectations
+}
+
+// NewCollectionSanitizer returns a sanitizer that has seen no secrets yet
+func NewCollectionSanitizer() *CollectionSanitizer {
+	return &CollectionSanitizer{secrets: map[string]string{}, reported: map[Finding]bool{}}
+}
+
+// ScanRequest records the secrets in an executed request: credential headers and cookies,
+// secret-named query parameters and body fields, and token-shaped values anywhere.
+// body is the request body as sent.
+func (s *CollectionSanitizer) ScanRequest(where string, req *http.Request, body string) {
+	if s == nil || req == nil {
+		return
+	}
+	for name, values := range req.Header {
+		for _, v := range values {
+			kind := headerKind(name)
+			if kind == "" {
+				kind = valueKind(v)
+			}
+			if kind != "" {
+				s.learn(kind, where+": request header "+name, v)
+			}
+		}
+	}
+	for _, c := range req.Cookies() {
+		if sessionCookie.MatchString(c.Name) {
+			s.learn(KindCookie, where+": request cookie "+c.Name, c.Value)
+		}
+	}
+	for name, values := range req.URL.Query() {
+		for _, v := range values {
+			s.scanField(where+": query parameter "+name, name, v)
+		}
+	}
+	s.scanBody(where+": request body", body)
+}
+
+// ScanResponse records the secrets in a recorded response: credential headers, session cookies,
+// secret-named body fields (e.g. access_token) and token-shaped values
+func (s *CollectionSanitizer) ScanResponse(where string, headers, cookies map[string]string, body string) {
+	if s == nil {
+		return
+	}
+	for name, v := range headers {
+		if strings.EqualFold(name, "Set-Cookie") {
+			continue // parsed into cookies
+		}
+		if kind := headerKind(name); kind != "" {
+			s.learn(kind, where+": response header "+name, v)
+		} else if kind := valueKind(v); kind != "" {
+			s.learn(kind, where+": response header "+name, v)
+		}
+	}
+	for name, v := range cookies {
+		if sessionCookie.MatchString(name) {
+			s.learn(KindCookie, where+": response cookie "+name, v)
+		}
+	}
+	s.scanBody(where+": response body", body)
+}
+
+// MaskVariable records a script or environment variable holding a secret and returns the value
+// to display: masked when the name or value marks it as a secret
+func (s *CollectionSanitizer) MaskVariable(where, name, value string) string {
+	if s == nil {
+		return value
+	}
+	s.scanField(where+": variable "+name, name, value)
+	if nameKind(name) != "" || s.isSecret(value) {
+		return maskValue(value)
+	}
+	return s.Mask(value)
+}
+
+// Mask replaces every secret seen so far, and any token-shaped value, in free text
+func (s *CollectionSanitizer) Mask(text string) string {
+	if s == nil || text == "" {
+		return text
+	}
+	for _, v := range s.knownSecrets() {
+		text = strings.ReplaceAll(text, v, maskValue(v))
+	}
+	text = maskAuth(text)
+	text = jwtPattern.ReplaceAllStringFunc(text, maskValue)
+	text = awsKeyPattern.ReplaceAllStringFunc(text, maskValue)
+	return apiKeyPattern.ReplaceAllStringFunc(text, maskValue)
+}
+
+// SanitizeExpectations masks the secrets in generated expectations in place and returns the
+// number of values changed. Request matchers on a secret accept any value instead (a header
+// "Bearer .+", a body without the field), so the mock still matches real clients; response
+// values and request examples are masked.
+func (s *CollectionSanitizer) SanitizeExpectations(exps []models.MockExpectation) int {
+	if s == nil {
+		return 0
+	}
+	n := 0
+	for i := range exps {
+		e := &exps[i]
+		if req := e.HttpRequest; req != nil {
+			for j := range req.Headers {
+				n += s.wildcardValues(&req.Headers[j], headerKind(req.Headers[j].Name))
+			}
+			for j := range req.QueryStringParameters {
+				n += s.wildcardValues(&req.QueryStringParameters[j], nameKind(req.QueryStringParameters[j].Name))
+			}
+			var c int
+			req.Body, c = s.sanitizeRequestBody(req.Body)
+			n += c
+		}
+		if resp := e.HttpResponse; resp != nil {
+			for j := range resp.Headers {
+				h := &resp.Headers[j]
+				for k, v := range h.Values {
+					masked := v
+					switch {
+					case strings.EqualFold(h.Name, "Set-Cookie"):
+						masked = s.maskSetCookie(v)
+					case headerKind(h.Name) != "":
+						masked = maskValue(v)
+					default:
+						masked = s.Mask(v)
+					}
+					if masked != v {
+						h.Values[k] = masked
+						n++
+					}
+				}
+			}
+			for j := range resp.Cookies {
+				c := &resp.Cookies[j]
+				for k, v := range c.Values {
+					if sessionCookie.MatchString(c.Name) || s.isSecret(v) {
+						if masked := maskValue(v); masked != v {
+							c.Values[k] = masked
+							n++
+						}
+					}
+				}
+			}
+			var c int
+			resp.Body, c = s.maskBody(resp.Body)
+			n += c
+		}
+		for j := range e.Examples {
+			var c int
+			e.Examples[j].Body, c = s.maskJSON("", e.Examples[j].Body)
+			n += c
+			e.Examples[j].Response, c = s.maskJSON("", e.Examples[j].Response)
+			n += c
+		}
+	}
+	s.mu.Lock()
+	s.masked += n
+	s.mu.Unlock()
+	return n
+}
+
+// Findings returns the secrets detected so far, in the order they were found
+func (s *CollectionSanitizer) Findings() []Finding {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Finding(nil), s.findings...)
+}
+
+// Summary renders the end-of-import security report
+func (s *CollectionSanitizer) Summary() string {
+	findings := s.Findings()
+	var b strings.Builder
+	b.WriteString("\n🔐 SECURITY SUMMARY\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	if len(findings) == 0 {
+		b.WriteString("✅ No credentials or tokens detected\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "⚠️  Detected %d secret(s) during the import:\n", len(findings))
+	for i, f := range findings {
+		if i == maxListed {
+			fmt.Fprintf(&b, "   … and %d more\n", len(findings)-maxListed)
+			break
+		}
+		fmt.Fprintf(&b, "   • %s — %s\n", f.Kind, f.Where)
+	}
+	s.mu.Lock()
+	masked := s.masked
+	s.mu.Unlock()
+	fmt.Fprintf(&b, "🛡️  Masked in console output and in %d value(s) of the generated expectations\n", masked)
+	b.WriteString("   Request matchers on secrets accept any value; masked responses return placeholders such as \"eyJh****\"\n")
+	b.WriteString("💡 Keep credentials in an environment file (--env-file) rather than in the collection\n")
+	return b.String()
+}
+
+// learn remembers value as a secret of the given kind and records the finding once
+func (s *CollectionSanitizer) learn(kind, where, value string) {
+	value = strings.TrimSpace(value)
+	if value == "" || placeholder.MatchString(value) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(value) >= minLearned {
+		s.secrets[value] = kind
+		if m := findAuth(value); m != nil {
+			s.secrets[value[m[0][4]:m[0][5]]] = kind // the credential alone, e.g. a token reused in a body
+		}
+	}
+	f := Finding{Kind: kind, Where: where}
+	if !s.reported[f] {
+		s.reported[f] = true
+		s.findings = append(s.findings, f)
+	}
+}
+
+// scanField learns a named value that is secret by its name or shape
+func (s *CollectionSanitizer) scanField(where, name, value string) {
+	if kind := nameKind(name); kind != "" {
+		s.learn(kind, where, value)
+	} else if kind := valueKind(value); kind != "" {
+		s.learn(kind, where, value)
+	}
+}
+
+// scanBody learns secrets from a JSON, form-encoded or text body
+func (s *CollectionSanitizer) scanBody(where, body string) {
+	trimmed := strings.TrimSpace(body)
+	if trimmed == "" {
+		return
+	}
+	var doc any
+	if json.Unmarshal([]byte(trimmed), &doc) == nil {
+		s.scanJSON(where, "", doc)
+		return
+	}
+	if form, err := url.ParseQuery(trimmed); err == nil && strings.Contains(trimmed, "=") && !strings.ContainsAny(trimmed, " \n") {
+		for name, values := range form {
+			for _, v := range values {
+				s.scanField(where+" field "+name, name, v)
+			}
+		}
+		return
+	}
+	for _, m := range findAuth(trimmed) {
+		s.learn(KindAuthorization, where, trimmed[m[0]:m[1]])
+	}
+	for _, pattern := range []*regexp.Regexp{jwtPattern, awsKeyPattern, apiKeyPattern} {
+		for _, v := range pattern.FindAllString(trimmed, -1) {
+			s.learn(valueKind(v), where, v)
+		}
+	}
+}
+
+func (s *CollectionSanitizer) scanJSON(where, key string, v any) {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, item := range val {
+			s.scanJSON(where, k, item)
+		}
+	case []any:
+		for _, item := range val {
+			s.scanJSON(where, key, item)
+		}
+	case string:
+		s.scanField(where+" field "+key, key, val)
+	}
+}
+
+// isSecret reports whether value was seen as a secret or is token-shaped
+func (s *CollectionSanitizer) isSecret(value string) bool {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return false
+	}
+	s.mu.Lock()
+	_, known := s.secrets[value]
+	s.mu.Unlock()
+	return known || valueKind(value) != ""
+}
+
+// containsSecret reports whether text holds a known or token-shaped secret
+func (s *CollectionSanitizer) containsSecret(text string) bool {
+	return s.Mask(text) != text
+}
+
+// knownSecrets returns the remembered values, longest first so that a value is masked before
+// any shorter secret it contains
+func (s *CollectionSanitizer) knownSecrets() []string {
+	s.mu.Lock()
+	values := make([]string, 0, len(s.secrets))
+	for v := range s.secrets {
+		values = append(values, v)
+	}
+	s.mu.Unlock()
+	sort.Slice(values, func(i, j int) bool {
+		if len(values[i]) != len(values[j]) {
+			return len(values[i]) > len(values[j])
+		}
+		return values[i] < values[j]
+	})
+	return values
+}
+
+// wildcardValues turns request matcher values that are secrets into patterns accepting any
+// value, keeping an auth scheme: "Bearer abc…" becomes "Bearer .+"
+func (s *CollectionSanitizer) wildcardValues(nv *models.NameValues, kind string) int {
+	n := 0
+	for i, v := range nv.Values {
+		if strings.Contains(v, ".*") || strings.Contains(v, ".+") {
+			continue // already a pattern
+		}
+		if kind == "" && !s.containsSecret(v) {
+			continue
+		}
+		wildcard := ".+"
+		if m := findAuth(v); m != nil {
+			wildcard = v[m[0][2]:m[0][3]] + " .+"
+		}
+		if v != wildcard {
+			nv.Values[i] = wildcard
+			n++
+		}
+	}
+	return n
+}
+
+// sanitizeRequestBody drops secret fields from JSON body matchers and turns text matchers that
+// contain a secret into regexes accepting any value in its place
+func (s *CollectionSanitizer) sanitizeRequestBody(body any) (any, int) {
+	switch b := body.(type) {
+	case string:
+		if !s.containsSecret(b) {
+			return body, 0
+		}
+		return map[string]any{"type": "REGEX", "regex": s.secretRegex(b)}, 1
+	case map[string]any:
+		switch b["type"] {
+		case "JSON":
+			doc := b["json"]
+			if text, ok := doc.(string); ok {
+				if json.Unmarshal([]byte(text), &doc) != nil {
+					return body, 0
+				}
+			}
+			stripped, n := s.dropSecretFields("", doc)
+			if n == 0 {
+				return body, 0
+			}
+			out := copyMap(b)
+			out["json"] = stripped
+			if mt, _ := b["matchType"].(string); mt == "STRICT" {
+				out["matchType"] = "ONLY_MATCHING_FIELDS"
+			}
+			return out, n
+		case "STRING":
+			text, _ := b["string"].(string)
+			if sub, _ := b["subString"].(bool); sub || !s.containsSecret(text) {
+				return body, 0
+			}
+			return map[string]any{"type": "REGEX", "regex": s.secretRegex(text)}, 1
+		case "PARAMETERS":
+			params, _ := b["parameters"].([]any)
+			n := 0
+			for _, p := range params {
+				pm, _ := p.(map[string]any)
+				name, _ := pm["name"].(string)
+				values, _ := pm["values"].([]any)
+				for i, v := range values {
+					str, _ := v.(string)
+					if str != ".+" && (nameKind(name) != "" || s.isSecret(str)) {
+						values[i] = ".+"
+						n++
+					}
+				}
+			}
+			return body, n
+		}
+	}
+	return body, 0
+}
+
+// dropSecretFields removes object fields that hold secrets from a decoded JSON value
+func (s *CollectionSanitizer) dropSecretFields(key string, v any) (any, int) {
+	switch val := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		n := 0
+		for k, item := range val {
+			if str, ok := item.(string); ok && (nameKind(k) != "" || s.isSecret(str)) {
+				n++
+				continue
+			}
+			stripped, c := s.dropSecretFields(k, item)
+			out[k] = stripped
+			n += c
+		}
+		return out, n
+	case []any:
+		out := make([]any, len(val))
+		n := 0
+		for i, item := range val {
+			var c int
+			out[i], c = s.dropSecretFields(key, item)
+			n += c
+		}
+		return out, n
+	}
+	return v, 0
+}
+
+// secretRegex quotes text, with every secret in it replaced by ".+"
+func (s *CollectionSanitizer) secretRegex(text string) string {
+	const sentinel = "\x00"
+	for _, v := range s.knownSecrets() {
+		text = strings.ReplaceAll(text, v, sentinel)
+	}
+	for _, pattern := range []*regexp.Regexp{jwtPattern, awsKeyPattern, apiKeyPattern} {
+		text = pattern.ReplaceAllString(text, sentinel)
+	}
+	parts := strings.Split(text, sentinel)
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+	return strings.Join(parts, ".+")
+}
+
+// maskBody masks secrets in a MockServer response body
+func (s *CollectionSanitizer) maskBody(body any) (any, int) {
+	switch b := body.(type) {
+	case string:
+		if masked := s.Mask(b); masked != b {
+			return masked, 1
+		}
+	case map[string]any:
+		t, _ := b["type"].(string)
+		switch {
+		case t == "JSON":
+			masked, n := s.maskJSON("", b["json"])
+			if n == 0 {
+				return body, 0
+			}
+			out := copyMap(b)
+			out["json"] = masked
+			return out, n
+		case t == "STRING":
+			text, _ := b["string"].(string)
+			if masked := s.Mask(text); masked != text {
+				out := copyMap(b)
+				out["string"] = masked
+				return out, 1
+			}
+		case t == "" || t != strings.ToUpper(t):
+			return s.maskJSON("", b) // a raw JSON object
+		}
+	}
+	return body, 0
+}
+
+// maskJSON masks secret-named fields and secret values in a decoded JSON value (or JSON text)
+func (s *CollectionSanitizer) maskJSON(key string, v any) (any, int) {
+	switch val := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		n := 0
+		for k, item := range val {
+			var c int
+			out[k], c = s.maskJSON(k, item)
+			n += c
+		}
+		return out, n
+	case []any:
+		out := make([]any, len(val))
+		n := 0
+		for i, item := range val {
+			var c int
+			out[i], c = s.maskJSON(key, item)
+			n += c
+		}
+		return out, n
+	case string:
+		masked := s.Mask(val)
+		if key != "" && nameKind(key) != "" {
+			masked = maskValue(val)
+		}
+		if masked != val {
+			return masked, 1
+		}
+	}
+	return v, 0
+}
+
+// maskSetCookie masks the value of a Set-Cookie header, keeping its attributes
+func (s *CollectionSanitizer) maskSetCookie(header string) string {
+	pair, attrs, _ := strings.Cut(header, ";")
+	name, value, ok := strings.Cut(pair, "=")
+	if !ok || (!sessionCookie.MatchString(name) && !s.isSecret(value)) {
+		return header
+	}
+	masked := strings.TrimSpace(name) + "=" + maskValue(value)
+	if attrs != "" {
+		masked += ";" + attrs
+	}
+	return masked
+}
+
+// headerKind classifies a request or response header by name
+func headerKind(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case lower == "authorization" || lower == "proxy-authorization":
+		return KindAuthorization
+	case lower == "cookie":
+		return KindCookie
+	case strings.HasPrefix(lower, "access-control-"):
+		return "" // CORS headers name credentials, they don't carry them
+	}
+	return nameKind(name)
+}
+
+// nameKind classifies a header, parameter, field or variable by name
+func nameKind(name string) string {
+	if name == "" || !secretName.MatchString(name) || secretMetaName.MatchString(name) {
+		return ""
+	}
+	lower := strings.ToLower(name)
+	switch {
+	case strings.Contains(lower, "authorization"):
+		return KindAuthorization
+	case strings.Contains(lower, "pass") || lower == "pwd":
+		return KindPassword
+	case apiKeyName.MatchString(lower):
+		return KindAPIKey
+	}
+	return KindToken
+}
+
+// valueKind classifies a value by its shape, or returns "" for ordinary values
+func valueKind(v string) string {
+	switch {
+	case findAuth(v) != nil:
+		return KindAuthorization
+	case jwtPattern.MatchString(v):
+		return KindToken
+	case awsKeyPattern.MatchString(v):
+		return KindCloudKey
+	case apiKeyPattern.MatchString(v):
+		return KindAPIKey
+	}
+	return ""
+}
+
+// maskValue hides a secret, keeping its auth scheme and the first characters of long values
+// so masked values can still be told apart: "Bearer eyJh****"
+func maskValue(v string) string {
+	if findAuth(v) != nil {
+		return maskAuth(v)
+	}
+	if len(v) >= 12 {
+		return v[:4] + "****"
+	}
+	return "****"
+}
+
+// findAuth returns the submatch indexes of "Bearer <credential>" style values in text. Scheme
+// words followed by prose ("Basic information") are not credentials and are skipped.
+func findAuth(text string) [][]int {
+	var found [][]int
+	for _, m := range authScheme.FindAllStringSubmatchIndex(text, -1) {
+		cred := text[m[4]:m[5]]
+		if strings.ContainsAny(cred, "0123456789._~+/=-") || strings.ToLower(cred[1:]) != cred[1:] {
+			found = append(found, m)
+		}
+	}
+	return found
+}
+
+// maskAuth masks the credential of every auth scheme value in text, keeping the scheme
+func maskAuth(text string) string {
+	found := findAuth(text)
+	for i := len(found) - 1; i >= 0; i-- {
+		m := found[i]
+		text = text[:m[4]] + maskValue(text[m[4]:m[5]]) + text[m[5]:]
+	}
+	return text
+}
+
+func copyMap(m map[string]any) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
@@ -20,6 +20,31 @@ var mockTemplates embed.FS
 //go:embed infra/loadtest/*.tf
 var loadtestTemplates embed.FS
 
+// Embedded Terraform templates for the Azure Container Instances mock stack.
+//
+//go:embed infra/azure/mock/*.tf
+var azureMockTemplates embed.FS
+
+// Embedded Terraform templates for the Azure Container Instances loadtest stack.
+//
+//go:embed infra/azure/loadtest/*.tf
+var azureLoadtestTemplates embed.FS
+
+// Embedded Terraform templates for the scheduled load test stack.
+//
+//go:embed infra/loadtest-schedule/*.tf
+var scheduleTemplates embed.FS
+
+// Embedded Terraform templates for the Azure Container Apps scheduled load test stack.
+//
+//go:embed infra/azure/loadtest-schedule/*.tf
+var azureScheduleTemplates embed.FS
+
+// scheduleRunner is the script the scheduled load test job runs on both clouds
+//
+//go:embed infra/loadtest-schedule/runner.py
+var scheduleRunner []byte
+
 // writeEmbeddedTemplates copies all embedded *.tf files from the given FS root
 // into the target directory, preserving base filenames.
 func writeEmbeddedTemplates(fsys embed.FS, targetDir string) error {
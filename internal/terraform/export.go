@@ -0,0 +1,106 @@
+package terraform
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// exportHeader starts the terraform.tfvars of every export, so re-exporting into the same
+// directory is told apart from overwriting someone else's configuration
+const exportHeader = "# AutoMock Terraform Variables"
+
+// Export writes the mock stack Deploy would apply into dir as a root module, for platform teams
+// that run infrastructure changes through their own Terraform or OpenTofu pipelines. Nothing is
+// applied: terraform.tfvars holds the options, backend.tf.example the state backend Deploy
+// uses, and the pipeline brings its own credentials. It returns the paths written.
+func (m *Manager) Export(dir string, options *models.DeploymentOptions) ([]string, error) {
+	if err := checkExportDir(dir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	if err := writeEmbeddedTemplates(templatesFor(m.Provider, mockTemplates, azureMockTemplates), dir); err != nil {
+		return nil, fmt.Errorf("failed to write terraform templates: %w", err)
+	}
+
+	backend := backendBlock(m.Provider, m.ExistingBucketName, m.Region, "terraform/state/terraform.tfstate")
+	files := []struct{ name, content string }{
+		{"terraform.tfvars", options.CreateTerraformVars()},
+		{"backend.tf.example", backend},
+		{"README.md", m.exportReadme(options)},
+	}
+	for _, f := range files {
+		if err := os.WriteFile(filepath.Join(dir, f.name), []byte(f.content), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var written []string
+	for _, e := range entries {
+		written = append(written, filepath.Join(dir, e.Name()))
+	}
+	return written, nil
+}
+
+// checkExportDir refuses a directory holding anything but an earlier export
+func checkExportDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && len(entries) == 0) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	vars, err := os.ReadFile(filepath.Join(dir, "terraform.tfvars"))
+	if err != nil || !strings.HasPrefix(string(vars), exportHeader) {
+		return fmt.Errorf("%s is not empty and holds no earlier automock export; pick an empty directory", dir)
+	}
+	return nil
+}
+
+// exportReadme explains how the exported module is applied and how it relates to automock
+func (m *Manager) exportReadme(options *models.DeploymentOptions) string {
+	stack := fmt.Sprintf("MockServer on ECS Fargate behind an Application Load Balancer, with IAM roles, "+
+		"security groups and auto-scaling. A sidecar loads the expectations from "+
+		"s3://%s/configs/%s/current.json and reloads them when they change.", m.ExistingBucketName, m.ProjectName)
+	remote := "\nThe ECS service is the `modules/aws/ecs` module of https://github.com/hemantobora/automock-terraform, " +
+		"fetched by `init`. Mirror it and change its `source` in main.tf if your pipeline cannot reach GitHub.\n"
+	if options.Provider == "azure" {
+		stack = fmt.Sprintf("MockServer on Azure Container Instances with a managed identity. A sidecar loads the "+
+			"expectations from configs/%s/mockserver.json in the %s container and reloads them when they change.",
+			m.ProjectName, m.ExistingBucketName)
+		remote = ""
+	}
+	return fmt.Sprintf(`# AutoMock mocks: %[1]s
+
+Generated by 'automock deploy --project %[1]s --export-terraform'. This is the stack 'automock deploy'
+applies: %[2]s
+%[3]s
+## Apply
+
+    terraform init    # or: tofu init
+    terraform plan
+    terraform apply
+
+Inputs are in terraform.tfvars; variables.tf documents the rest. Configure the state backend your
+pipeline uses. backend.tf.example is the one 'automock deploy' uses; rename it to backend.tf to share
+state with automock, so 'automock destroy' can tear the stack down.
+
+## Changing the mocks
+
+Expectations saved with automock reach the running stack without another apply. Export again
+to serve another tag selection, masking profile or environment; only changes to the *.tf files or
+terraform.tfvars need your pipeline to apply.
+`, m.ProjectName, stack, remote)
+}
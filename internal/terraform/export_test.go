@@ -0,0 +1,49 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	localprovider "github.com/hemantobora/auto-mock/internal/cloud/local"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+func TestExport(t *testing.T) {
+	store, err := localprovider.NewProvider(localprovider.WithRoot(t.TempDir()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &Manager{ProjectName: "users", Provider: store, ExistingBucketName: "auto-mock-users-1234", Region: "eu-west-1"}
+	options := &models.DeploymentOptions{ProjectName: "users", Region: "eu-west-1", InstanceSize: "medium", BucketName: "auto-mock-users-1234", Provider: "aws"}
+
+	dir := filepath.Join(t.TempDir(), "infra")
+	written, err := m.Export(dir, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"main.tf", "variables.tf", "outputs.tf", "domain.tf", "terraform.tfvars", "backend.tf.example", "README.md"} {
+		if !slices.Contains(written, filepath.Join(dir, name)) {
+			t.Errorf("%s not exported: %v", name, written)
+		}
+	}
+	vars, _ := os.ReadFile(filepath.Join(dir, "terraform.tfvars"))
+	if !strings.Contains(string(vars), `project_name         = "users"`) || !strings.Contains(string(vars), `instance_size        = "medium"`) {
+		t.Errorf("terraform.tfvars:\n%s", vars)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "backend.tf")); err == nil {
+		t.Error("backend written active; the pipeline owns the state backend")
+	}
+
+	// An earlier export is replaced; anything else is left alone
+	if _, err := m.Export(dir, options); err != nil {
+		t.Errorf("re-export refused: %v", err)
+	}
+	other := t.TempDir()
+	os.WriteFile(filepath.Join(other, "main.tf"), []byte("# platform stack\n"), 0644)
+	if _, err := m.Export(other, options); err == nil {
+		t.Error("exported over an unrelated Terraform directory")
+	}
+}
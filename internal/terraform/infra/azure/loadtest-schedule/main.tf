@@ -0,0 +1,126 @@
+# Scheduled load test runs on Azure
+# A Container Apps job with a cron trigger downloads the active bundle
+# (configs/<project>-loadtest/current.json) with its managed identity, runs Locust headless
+# and stores a summary next to the bundle version (runner.py).
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "~> 3.100"
+    }
+  }
+}
+
+provider "azurerm" {
+  features {}
+  storage_use_azuread = true
+}
+
+locals {
+  name_prefix = "automock-${var.project_name}-lt-schedule"
+  # Container Apps job names are limited to 32 characters
+  job_name = trim(substr("amlt-${var.project_name}", 0, 32), "-")
+
+  common_tags = {
+    ManagedBy   = "AutoMock-Terraform"
+    Project     = "AutoMock"
+    ProjectName = var.project_name
+  }
+
+  runner_env = {
+    STORE              = "blob"
+    STORAGE_ACCOUNT    = var.storage_account_name
+    STORAGE_CONTAINER  = var.container_name
+    IDENTITY_CLIENT_ID = azurerm_user_assigned_identity.runner.client_id
+    PROJECT            = var.project_name
+    USERS              = tostring(var.users)
+    SPAWN_RATE         = tostring(var.spawn_rate)
+    DURATION_SECONDS   = tostring(var.duration_seconds)
+    TARGET_HOST        = var.target_host
+  }
+}
+
+resource "azurerm_resource_group" "schedule" {
+  name     = "${local.name_prefix}-rg"
+  location = var.azure_location
+  tags     = local.common_tags
+}
+
+data "azurerm_resources" "storage_account" {
+  type = "Microsoft.Storage/storageAccounts"
+  name = var.storage_account_name
+}
+
+resource "azurerm_user_assigned_identity" "runner" {
+  name                = "${local.name_prefix}-id"
+  resource_group_name = azurerm_resource_group.schedule.name
+  location            = azurerm_resource_group.schedule.location
+  tags                = local.common_tags
+}
+
+# Reads the bundle and writes the results
+resource "azurerm_role_assignment" "results_writer" {
+  scope                = "${data.azurerm_resources.storage_account.resources[0].id}/blobServices/default/containers/${var.container_name}"
+  role_definition_name = "Storage Blob Data Contributor"
+  principal_id         = azurerm_user_assigned_identity.runner.principal_id
+}
+
+resource "azurerm_log_analytics_workspace" "runs" {
+  name                = "${local.name_prefix}-logs"
+  resource_group_name = azurerm_resource_group.schedule.name
+  location            = azurerm_resource_group.schedule.location
+  sku                 = "PerGB2018"
+  retention_in_days   = var.log_retention_days
+  tags                = local.common_tags
+}
+
+resource "azurerm_container_app_environment" "schedule" {
+  name                       = "${local.name_prefix}-env"
+  resource_group_name        = azurerm_resource_group.schedule.name
+  location                   = azurerm_resource_group.schedule.location
+  log_analytics_workspace_id = azurerm_log_analytics_workspace.runs.id
+  tags                       = local.common_tags
+}
+
+resource "azurerm_container_app_job" "runner" {
+  name                         = local.job_name
+  resource_group_name          = azurerm_resource_group.schedule.name
+  location                     = azurerm_resource_group.schedule.location
+  container_app_environment_id = azurerm_container_app_environment.schedule.id
+  replica_timeout_in_seconds   = var.duration_seconds + 1200
+  replica_retry_limit          = 0
+  tags                         = local.common_tags
+
+  identity {
+    type         = "UserAssigned"
+    identity_ids = [azurerm_user_assigned_identity.runner.id]
+  }
+
+  schedule_trigger_config {
+    cron_expression          = var.cron
+    parallelism              = 1
+    replica_completion_count = 1
+  }
+
+  template {
+    container {
+      name    = "locust"
+      image   = var.locust_container_image
+      cpu     = var.cpu
+      memory  = var.memory
+      command = ["python", "-u", "-c", file("${path.module}/runner.py")]
+
+      dynamic "env" {
+        for_each = local.runner_env
+        content {
+          name  = env.key
+          value = env.value
+        }
+      }
+    }
+  }
+
+  depends_on = [azurerm_role_assignment.results_writer]
+}
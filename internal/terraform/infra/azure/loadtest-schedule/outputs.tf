@@ -0,0 +1,16 @@
+# Output names match the AWS stack; the job plays both the schedule and the runner
+
+output "schedule_name" {
+  description = "Container Apps job started on the schedule"
+  value       = azurerm_container_app_job.runner.name
+}
+
+output "runner_name" {
+  description = "Container Apps job running the tests"
+  value       = azurerm_container_app_job.runner.name
+}
+
+output "log_group" {
+  description = "Log Analytics workspace of the runs"
+  value       = azurerm_log_analytics_workspace.runs.name
+}
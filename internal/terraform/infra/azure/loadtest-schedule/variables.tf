@@ -0,0 +1,79 @@
+variable "project_name" {
+  type        = string
+  description = "Project name (base for resource names)"
+}
+
+variable "azure_location" {
+  type        = string
+  description = "Azure region"
+  default     = "eastus"
+}
+
+variable "storage_account_name" {
+  type        = string
+  description = "Storage account holding the project container"
+}
+
+variable "container_name" {
+  type        = string
+  description = "Existing blob container holding the load test bundles and results"
+}
+
+variable "cloud_provider" {
+  type        = string
+  description = "Cloud provider (azure)"
+  default     = "azure"
+}
+
+variable "cron" {
+  type        = string
+  description = "Cron expression of the schedule (UTC)"
+}
+
+variable "users" {
+  type        = number
+  description = "Peak number of concurrent users"
+  default     = 10
+}
+
+variable "spawn_rate" {
+  type        = number
+  description = "Users started per second"
+  default     = 2
+}
+
+variable "duration_seconds" {
+  type        = number
+  description = "How long each run lasts"
+  default     = 300
+}
+
+variable "target_host" {
+  type        = string
+  description = "Host the bundle runs against (the deployed mock by default)"
+  default     = ""
+}
+
+variable "locust_container_image" {
+  type        = string
+  description = "Container image of the load generator (Locust and Python)"
+  default     = "locustio/locust:2.31.2"
+}
+
+variable "cpu" {
+  type        = number
+  description = "vCPUs of the load generator"
+  default     = 2
+}
+
+variable "memory" {
+  type        = string
+  description = "Memory of the load generator (must match the vCPUs, 2 GiB per vCPU)"
+  default     = "4Gi"
+}
+
+variable "log_retention_days" {
+  type        = number
+  description = "Log Analytics retention for run logs"
+  default     = 30
+}
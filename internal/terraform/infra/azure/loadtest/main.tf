@@ -0,0 +1,150 @@
+# Locust on Azure Container Instances
+# Master and workers share one container group, so workers reach the master on localhost.
+# Each container downloads the active bundle (configs/<project>-loadtest/current.json)
+# with the group's managed identity before starting Locust.
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "~> 3.100"
+    }
+  }
+}
+
+provider "azurerm" {
+  features {}
+  storage_use_azuread = true
+}
+
+locals {
+  name_prefix = "automock-${var.project_name}-loadtest"
+
+  common_tags = {
+    ManagedBy   = "AutoMock-Terraform"
+    Project     = "AutoMock"
+    ProjectName = var.project_name
+  }
+
+  bootstrap_script = <<-PY
+    import json, os, subprocess, sys, urllib.error, urllib.request
+
+    ACCOUNT = os.environ["STORAGE_ACCOUNT"]
+    CONTAINER = os.environ["STORAGE_CONTAINER"]
+    CLIENT_ID = os.environ["IDENTITY_CLIENT_ID"]
+
+    def token():
+        url = ("http://169.254.169.254/metadata/identity/oauth2/token?api-version=2018-02-01"
+               "&resource=https%3A%2F%2Fstorage.azure.com%2F&client_id=" + CLIENT_ID)
+        with urllib.request.urlopen(urllib.request.Request(url, headers={"Metadata": "true"}), timeout=10) as r:
+            return json.load(r)["access_token"]
+
+    def get(blob):
+        url = "https://" + ACCOUNT + ".blob.core.windows.net/" + CONTAINER + "/" + blob
+        req = urllib.request.Request(url, headers={"Authorization": "Bearer " + token(), "x-ms-version": "2021-08-06"})
+        with urllib.request.urlopen(req, timeout=60) as r:
+            return r.read()
+
+    pointer = json.loads(get(os.environ["POINTER_BLOB"]))
+    os.makedirs("/tmp/bundle", exist_ok=True)
+    os.chdir("/tmp/bundle")
+    for key in (pointer.get("files") or {}).values():
+        if not key:
+            continue
+        try:
+            data = get(key)
+        except urllib.error.HTTPError as e:
+            if e.code == 404:
+                continue  # optional files such as user_data.yaml
+            raise
+        with open(os.path.basename(key), "wb") as f:
+            f.write(data)
+    print("bundle %s (%s) downloaded" % (pointer.get("bundle_id"), pointer.get("active_version")), flush=True)
+    if os.path.exists("requirements.txt"):
+        subprocess.check_call([sys.executable, "-m", "pip", "install", "--quiet", "--user", "-r", "requirements.txt"])
+    args = ["locust", "-f", "locustfile.py"] + os.environ["LOCUST_ROLE_ARGS"].split()
+    os.execvp(args[0], args)
+  PY
+
+  bundle_env = merge(var.extra_environment, {
+    STORAGE_ACCOUNT    = var.storage_account_name
+    STORAGE_CONTAINER  = var.container_name
+    POINTER_BLOB       = "configs/${var.project_name}-loadtest/current.json"
+    IDENTITY_CLIENT_ID = azurerm_user_assigned_identity.locust.client_id
+  })
+}
+
+resource "azurerm_resource_group" "locust" {
+  name     = "${local.name_prefix}-rg"
+  location = var.azure_location
+  tags     = local.common_tags
+}
+
+data "azurerm_resources" "storage_account" {
+  type = "Microsoft.Storage/storageAccounts"
+  name = var.storage_account_name
+}
+
+resource "azurerm_user_assigned_identity" "locust" {
+  name                = "${local.name_prefix}-id"
+  resource_group_name = azurerm_resource_group.locust.name
+  location            = azurerm_resource_group.locust.location
+  tags                = local.common_tags
+}
+
+resource "azurerm_role_assignment" "bundle_reader" {
+  scope                = "${data.azurerm_resources.storage_account.resources[0].id}/blobServices/default/containers/${var.container_name}"
+  role_definition_name = "Storage Blob Data Reader"
+  principal_id         = azurerm_user_assigned_identity.locust.principal_id
+}
+
+resource "azurerm_container_group" "locust" {
+  name                = local.name_prefix
+  resource_group_name = azurerm_resource_group.locust.name
+  location            = azurerm_resource_group.locust.location
+  os_type             = "Linux"
+  ip_address_type     = "Public"
+  dns_name_label      = local.name_prefix
+  restart_policy      = "OnFailure"
+  tags                = local.common_tags
+
+  identity {
+    type         = "UserAssigned"
+    identity_ids = [azurerm_user_assigned_identity.locust.id]
+  }
+
+  container {
+    name     = "locust-master"
+    image    = var.locust_container_image
+    cpu      = var.master_cpu
+    memory   = var.master_memory
+    commands = ["python", "-u", "-c", local.bootstrap_script]
+
+    ports {
+      port     = var.master_port
+      protocol = "TCP"
+    }
+
+    environment_variables = merge(local.bundle_env, {
+      LOCUST_ROLE_ARGS = "--master --web-port ${var.master_port}"
+    })
+  }
+
+  dynamic "container" {
+    for_each = range(var.worker_desired_count)
+    content {
+      name     = "locust-worker-${container.value}"
+      image    = var.locust_container_image
+      cpu      = var.cpu_units / 1024
+      memory   = var.memory_units / 1024
+      commands = ["python", "-u", "-c", local.bootstrap_script]
+
+      environment_variables = merge(local.bundle_env, {
+        LOCUST_ROLE_ARGS = "--worker --master-host 127.0.0.1"
+      })
+    }
+  }
+
+  depends_on = [azurerm_role_assignment.bundle_reader]
+}
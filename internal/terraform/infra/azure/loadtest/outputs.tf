@@ -0,0 +1,32 @@
+# Output names match the AWS stack; the container group plays the cluster, and the
+# master UI address takes the load balancer's place
+
+output "cluster_name" {
+  description = "Container group running Locust"
+  value       = azurerm_container_group.locust.name
+}
+
+output "master_service_name" {
+  description = "Master container name"
+  value       = "locust-master"
+}
+
+output "worker_service_name" {
+  description = "Worker container name prefix"
+  value       = "locust-worker"
+}
+
+output "worker_desired_count" {
+  description = "Desired worker count"
+  value       = var.worker_desired_count
+}
+
+output "alb_dns_name" {
+  description = "Locust UI address (host:port)"
+  value       = "${azurerm_container_group.locust.fqdn}:${var.master_port}"
+}
+
+output "cloud_map_master_fqdn" {
+  description = "Master address seen by the workers"
+  value       = "127.0.0.1"
+}
@@ -0,0 +1,74 @@
+variable "project_name" {
+  type        = string
+  description = "Project name (base for resource names)"
+}
+
+variable "azure_location" {
+  type        = string
+  description = "Azure region"
+  default     = "eastus"
+}
+
+variable "storage_account_name" {
+  type        = string
+  description = "Storage account holding the project container"
+}
+
+variable "container_name" {
+  type        = string
+  description = "Existing blob container for backend/state and bundles"
+}
+
+variable "cloud_provider" {
+  type        = string
+  description = "Cloud provider (azure)"
+  default     = "azure"
+}
+
+variable "cpu_units" {
+  type        = number
+  description = "CPU units per worker (1024 = 1 vCPU, as on the AWS stack)"
+  default     = 256
+}
+
+variable "memory_units" {
+  type        = number
+  description = "Memory (MiB) per worker"
+  default     = 512
+}
+
+variable "master_cpu" {
+  type        = number
+  description = "vCPU for the master container"
+  default     = 1
+}
+
+variable "master_memory" {
+  type        = number
+  description = "Memory (GB) for the master container"
+  default     = 2
+}
+
+variable "worker_desired_count" {
+  type        = number
+  description = "Number of worker containers (they share the master's container group, so the group's vCPU quota caps this)"
+  default     = 0
+}
+
+variable "locust_container_image" {
+  type        = string
+  description = "Locust container image for master and workers"
+  default     = "locustio/locust:2.31.2"
+}
+
+variable "master_port" {
+  type        = number
+  description = "Port exposed by the master UI"
+  default     = 8089
+}
+
+variable "extra_environment" {
+  type        = map(string)
+  description = "Map of KEY => VALUE environment variables added to master and worker containers. Values are visible in the container group definition; avoid long-lived secrets."
+  default     = {}
+}
@@ -0,0 +1,209 @@
+# Root Terraform Configuration for AutoMock on Azure
+# MockServer runs on Azure Container Instances; a sidecar loads the project's expectations as
+# automock saves them for MockServer (configs/<project>/mockserver.json) and reloads them
+# whenever the blob changes.
+
+terraform {
+  required_version = ">= 1.0"
+
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "~> 3.100"
+    }
+    random = {
+      source  = "hashicorp/random"
+      version = "~> 3.5"
+    }
+  }
+
+  # Backend configuration will be generated dynamically by Go CLI
+  # backend "azurerm" {}
+}
+
+provider "azurerm" {
+  features {}
+  storage_use_azuread = true
+}
+
+locals {
+  name_prefix = "automock-${var.project_name}"
+
+  sizes = {
+    small  = { cpu = 0.5, memory = 1 }
+    medium = { cpu = 1, memory = 2 }
+    large  = { cpu = 2, memory = 4 }
+    xlarge = { cpu = 4, memory = 8 }
+  }
+  size = local.sizes[var.instance_size]
+
+  common_tags = {
+    ManagedBy   = "AutoMock-Terraform"
+    Project     = "AutoMock"
+    ProjectName = var.project_name
+  }
+
+  # The blob holds only the fields MockServer accepts; AutoMock's own stay in current.json
+  sync_script = <<-PY
+    import json, os, time, urllib.error, urllib.request
+
+    ACCOUNT = os.environ["STORAGE_ACCOUNT"]
+    CONTAINER = os.environ["STORAGE_CONTAINER"]
+    BLOB = os.environ["CONFIG_BLOB"]
+    CLIENT_ID = os.environ["IDENTITY_CLIENT_ID"]
+    INTERVAL = int(os.environ.get("SYNC_INTERVAL", "30"))
+
+    def token():
+        url = ("http://169.254.169.254/metadata/identity/oauth2/token?api-version=2018-02-01"
+               "&resource=https%3A%2F%2Fstorage.azure.com%2F&client_id=" + CLIENT_ID)
+        with urllib.request.urlopen(urllib.request.Request(url, headers={"Metadata": "true"}), timeout=10) as r:
+            return json.load(r)["access_token"]
+
+    def fetch(etag):
+        url = "https://" + ACCOUNT + ".blob.core.windows.net/" + CONTAINER + "/" + BLOB
+        headers = {"Authorization": "Bearer " + token(), "x-ms-version": "2021-08-06"}
+        if etag:
+            headers["If-None-Match"] = etag
+        try:
+            with urllib.request.urlopen(urllib.request.Request(url, headers=headers), timeout=30) as r:
+                return r.headers.get("ETag"), json.load(r)
+        except urllib.error.HTTPError as e:
+            if e.code == 304:
+                return etag, None
+            raise
+
+    def put(path, body):
+        req = urllib.request.Request("http://localhost:1080" + path, data=body, method="PUT",
+                                     headers={"Content-Type": "application/json"})
+        urllib.request.urlopen(req, timeout=30).close()
+
+    etag = None
+    while True:
+        try:
+            new_etag, exps = fetch(etag)
+            if exps is not None:
+                put("/mockserver/reset", b"")
+                if exps:
+                    put("/mockserver/expectation", json.dumps(exps).encode())
+                etag = new_etag
+                print("loaded %d expectation(s), etag %s" % (len(exps), etag), flush=True)
+        except Exception as e:
+            print("sync failed (retrying): %s" % e, flush=True)
+        time.sleep(INTERVAL)
+  PY
+}
+
+resource "random_string" "dns" {
+  length  = 6
+  lower   = true
+  upper   = false
+  numeric = true
+  special = false
+}
+
+resource "azurerm_resource_group" "mock" {
+  name     = "${local.name_prefix}-rg"
+  location = var.azure_location
+  tags     = local.common_tags
+}
+
+# The storage account is created outside this stack (it holds the state); look it up by name
+data "azurerm_resources" "storage_account" {
+  type = "Microsoft.Storage/storageAccounts"
+  name = var.storage_account_name
+}
+
+locals {
+  container_scope = "${data.azurerm_resources.storage_account.resources[0].id}/blobServices/default/containers/${var.container_name}"
+}
+
+# Identity the sidecar uses to read the project container
+resource "azurerm_user_assigned_identity" "mock" {
+  name                = "${local.name_prefix}-id"
+  resource_group_name = azurerm_resource_group.mock.name
+  location            = azurerm_resource_group.mock.location
+  tags                = local.common_tags
+}
+
+resource "azurerm_role_assignment" "config_reader" {
+  scope                = local.container_scope
+  role_definition_name = "Storage Blob Data Reader"
+  principal_id         = azurerm_user_assigned_identity.mock.principal_id
+}
+
+resource "azurerm_log_analytics_workspace" "mock" {
+  name                = "${local.name_prefix}-logs"
+  resource_group_name = azurerm_resource_group.mock.name
+  location            = azurerm_resource_group.mock.location
+  sku                 = "PerGB2018"
+  retention_in_days   = 30
+  tags                = local.common_tags
+}
+
+resource "azurerm_container_group" "mockserver" {
+  name                = "${local.name_prefix}-mockserver"
+  resource_group_name = azurerm_resource_group.mock.name
+  location            = azurerm_resource_group.mock.location
+  os_type             = "Linux"
+  ip_address_type     = "Public"
+  dns_name_label      = "${local.name_prefix}-${random_string.dns.result}"
+  restart_policy      = "Always"
+  tags                = local.common_tags
+
+  identity {
+    type         = "UserAssigned"
+    identity_ids = [azurerm_user_assigned_identity.mock.id]
+  }
+
+  container {
+    name   = "mockserver"
+    image  = var.mockserver_image
+    cpu    = local.size.cpu
+    memory = local.size.memory
+
+    ports {
+      port     = 1080
+      protocol = "TCP"
+    }
+
+    environment_variables = {
+      MOCKSERVER_SERVER_PORT = "1080"
+      MOCKSERVER_LOG_LEVEL   = "INFO"
+    }
+
+    readiness_probe {
+      http_get {
+        path   = "/mockserver/status"
+        port   = 1080
+        scheme = "Http"
+      }
+      initial_delay_seconds = 5
+      period_seconds        = 10
+    }
+  }
+
+  container {
+    name     = "config-sync"
+    image    = var.sync_image
+    cpu      = 0.25
+    memory   = 0.5
+    commands = ["python", "-u", "-c", local.sync_script]
+
+    environment_variables = {
+      STORAGE_ACCOUNT    = var.storage_account_name
+      STORAGE_CONTAINER  = var.container_name
+      CONFIG_BLOB        = "configs/${var.project_name}/mockserver.json"
+      IDENTITY_CLIENT_ID = azurerm_user_assigned_identity.mock.client_id
+      SYNC_INTERVAL      = tostring(var.sync_interval_seconds)
+    }
+  }
+
+  diagnostics {
+    log_analytics {
+      workspace_id  = azurerm_log_analytics_workspace.mock.workspace_id
+      workspace_key = azurerm_log_analytics_workspace.mock.primary_shared_key
+    }
+  }
+
+  depends_on = [azurerm_role_assignment.config_reader]
+}
@@ -0,0 +1,60 @@
+# Root Terraform Outputs (Azure)
+# Names match the AWS stack so the CLI reads both the same way
+
+locals {
+  mockserver_url = "http://${azurerm_container_group.mockserver.fqdn}:1080"
+}
+
+output "mockserver_url" {
+  description = "MockServer API endpoint URL"
+  value       = local.mockserver_url
+}
+
+output "dashboard_url" {
+  description = "MockServer dashboard URL"
+  value       = "${local.mockserver_url}/mockserver/dashboard"
+}
+
+output "config_bucket" {
+  description = "Blob container holding the configuration"
+  value       = var.container_name
+}
+
+output "integration_summary" {
+  description = "Integration summary for CLI"
+  value = {
+    project_name    = var.project_name
+    bucket_name     = var.container_name
+    storage_account = var.storage_account_name
+    mockserver_url  = local.mockserver_url
+    dashboard_url   = "${local.mockserver_url}/mockserver/dashboard"
+    region          = var.azure_location
+  }
+}
+
+output "cli_integration_commands" {
+  description = "CLI commands for interacting with the deployed infrastructure"
+  value = {
+    upload_expectations = "az storage blob upload --auth-mode login --account-name ${var.storage_account_name} --container-name ${var.container_name} --name configs/${var.project_name}/mockserver.json --file expectations.json --overwrite"
+    view_expectations   = "az storage blob download --auth-mode login --account-name ${var.storage_account_name} --container-name ${var.container_name} --name configs/${var.project_name}/mockserver.json --output none --file /dev/stdout | jq ."
+    view_logs           = "az container logs --resource-group ${azurerm_resource_group.mock.name} --name ${azurerm_container_group.mockserver.name} --container-name mockserver --follow"
+    view_sync_logs      = "az container logs --resource-group ${azurerm_resource_group.mock.name} --name ${azurerm_container_group.mockserver.name} --container-name config-sync"
+  }
+}
+
+output "infrastructure_summary" {
+  description = "Complete infrastructure summary"
+  value = {
+    resource_group = azurerm_resource_group.mock.name
+    container_group = {
+      name = azurerm_container_group.mockserver.name
+      fqdn = azurerm_container_group.mockserver.fqdn
+      ip   = azurerm_container_group.mockserver.ip_address
+    }
+    storage = {
+      account        = var.storage_account_name
+      container_name = var.container_name
+      metadata_path  = "deployment-metadata.json"
+    }
+  }
+}
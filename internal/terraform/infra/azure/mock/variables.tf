@@ -0,0 +1,72 @@
+##############################################
+# AutoMock Root Variables (Azure)
+##############################################
+
+# ───────── General ─────────
+variable "project_name" {
+  description = "Unique name of the AutoMock project; used for tagging and naming Azure resources."
+  type        = string
+
+  validation {
+    condition     = can(regex("^[a-z0-9-]+$", var.project_name))
+    error_message = "Project name must contain only lowercase letters, numbers, and hyphens."
+  }
+}
+
+variable "azure_location" {
+  description = "Azure region in which to deploy infrastructure (e.g., eastus)."
+  type        = string
+  default     = "eastus"
+}
+
+variable "cloud_provider" {
+  description = "Cloud provider (azure)"
+  type        = string
+  default     = "azure"
+}
+
+variable "instance_size" {
+  description = "Container size (small, medium, large, xlarge) that maps to vCPU/memory settings."
+  type        = string
+  default     = "small"
+
+  validation {
+    condition     = contains(["small", "medium", "large", "xlarge"], var.instance_size)
+    error_message = "Instance size must be one of: small, medium, large, xlarge."
+  }
+}
+
+# ───────── Storage ─────────
+variable "storage_account_name" {
+  description = "Storage account holding the project container (created outside this stack)."
+  type        = string
+}
+
+variable "container_name" {
+  description = "Blob container that stores mock configuration and Terraform state."
+  type        = string
+
+  validation {
+    condition     = can(regex("^auto-mock-.+", var.container_name))
+    error_message = "Container name must start with 'auto-mock-'."
+  }
+}
+
+# ───────── Containers ─────────
+variable "mockserver_image" {
+  description = "MockServer container image."
+  type        = string
+  default     = "mockserver/mockserver:5.15.0"
+}
+
+variable "sync_image" {
+  description = "Image of the sidecar that loads expectations from blob storage into MockServer."
+  type        = string
+  default     = "python:3.12-alpine"
+}
+
+variable "sync_interval_seconds" {
+  description = "How often the sidecar checks the stored expectations for changes."
+  type        = number
+  default     = 30
+}
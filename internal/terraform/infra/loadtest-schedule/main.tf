@@ -0,0 +1,206 @@
+# Scheduled load test runs on AWS
+# EventBridge Scheduler starts a CodeBuild build on the cron schedule; the build downloads the
+# active bundle (configs/<project>-loadtest/current.json), runs Locust headless on a single
+# machine and stores a summary next to the bundle version (runner.py).
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.aws_region
+}
+
+locals {
+  name_prefix     = "automock-${var.project_name}-lt-schedule"
+  loadtest_prefix = "configs/${var.project_name}-loadtest"
+
+  common_tags = {
+    ManagedBy   = "AutoMock-Terraform"
+    Project     = "AutoMock"
+    ProjectName = var.project_name
+  }
+
+  buildspec = yamlencode({
+    version = "0.2"
+    phases = {
+      install = {
+        commands = ["pip3 install --quiet boto3 locust==${var.locust_version}"]
+      }
+      build = {
+        commands = ["cat > runner.py <<'PY'\n${file("${path.module}/runner.py")}\nPY", "python3 -u runner.py"]
+      }
+    }
+  })
+}
+
+data "aws_s3_bucket" "artifacts" {
+  bucket = var.existing_bucket_name
+}
+
+resource "aws_cloudwatch_log_group" "runs" {
+  name              = "/automock/${var.project_name}/loadtest-schedule"
+  retention_in_days = var.log_retention_days
+  tags              = local.common_tags
+}
+
+# ===== CodeBuild (runs the test) =====
+
+resource "aws_iam_role" "runner" {
+  name = "${local.name_prefix}-runner"
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Effect    = "Allow"
+      Principal = { Service = "codebuild.amazonaws.com" }
+      Action    = "sts:AssumeRole"
+    }]
+  })
+  tags = local.common_tags
+}
+
+resource "aws_iam_role_policy" "runner" {
+  name = "${local.name_prefix}-runner"
+  role = aws_iam_role.runner.id
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect   = "Allow"
+        Action   = ["logs:CreateLogStream", "logs:PutLogEvents"]
+        Resource = "${aws_cloudwatch_log_group.runs.arn}:*"
+      },
+      {
+        Effect   = "Allow"
+        Action   = ["s3:GetObject"]
+        Resource = "${data.aws_s3_bucket.artifacts.arn}/${local.loadtest_prefix}/*"
+      },
+      {
+        Effect   = "Allow"
+        Action   = ["s3:PutObject"]
+        Resource = "${data.aws_s3_bucket.artifacts.arn}/${local.loadtest_prefix}/results/*"
+      },
+      {
+        # Bundles uploaded with --kms-key are encrypted with a customer managed key
+        Effect    = "Allow"
+        Action    = ["kms:Decrypt", "kms:GenerateDataKey"]
+        Resource  = "*"
+        Condition = { StringEquals = { "kms:ViaService" = "s3.${var.aws_region}.amazonaws.com" } }
+      }
+    ]
+  })
+}
+
+resource "aws_codebuild_project" "runner" {
+  name          = local.name_prefix
+  description   = "Scheduled AutoMock load test of ${var.project_name}"
+  service_role  = aws_iam_role.runner.arn
+  build_timeout = min(480, ceil(var.duration_seconds / 60) + 20)
+
+  artifacts {
+    type = "NO_ARTIFACTS"
+  }
+
+  environment {
+    compute_type = var.compute_type
+    image        = "aws/codebuild/standard:7.0"
+    type         = "LINUX_CONTAINER"
+
+    environment_variable {
+      name  = "STORE"
+      value = "s3"
+    }
+    environment_variable {
+      name  = "BUCKET"
+      value = var.existing_bucket_name
+    }
+    environment_variable {
+      name  = "PROJECT"
+      value = var.project_name
+    }
+    environment_variable {
+      name  = "USERS"
+      value = tostring(var.users)
+    }
+    environment_variable {
+      name  = "SPAWN_RATE"
+      value = tostring(var.spawn_rate)
+    }
+    environment_variable {
+      name  = "DURATION_SECONDS"
+      value = tostring(var.duration_seconds)
+    }
+    environment_variable {
+      name  = "TARGET_HOST"
+      value = var.target_host
+    }
+  }
+
+  source {
+    type      = "NO_SOURCE"
+    buildspec = local.buildspec
+  }
+
+  logs_config {
+    cloudwatch_logs {
+      group_name = aws_cloudwatch_log_group.runs.name
+    }
+  }
+
+  tags = local.common_tags
+}
+
+# ===== EventBridge Scheduler (starts the build) =====
+
+resource "aws_iam_role" "scheduler" {
+  name = "${local.name_prefix}-scheduler"
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Effect    = "Allow"
+      Principal = { Service = "scheduler.amazonaws.com" }
+      Action    = "sts:AssumeRole"
+    }]
+  })
+  tags = local.common_tags
+}
+
+resource "aws_iam_role_policy" "scheduler" {
+  name = "${local.name_prefix}-scheduler"
+  role = aws_iam_role.scheduler.id
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Effect   = "Allow"
+      Action   = ["codebuild:StartBuild"]
+      Resource = aws_codebuild_project.runner.arn
+    }]
+  })
+}
+
+resource "aws_scheduler_schedule" "runs" {
+  name                         = local.name_prefix
+  description                  = "AutoMock load test of ${var.project_name} (${var.cron})"
+  schedule_expression          = var.schedule_expression
+  schedule_expression_timezone = "UTC"
+
+  flexible_time_window {
+    mode = "OFF"
+  }
+
+  target {
+    arn      = "arn:aws:scheduler:::aws-sdk:codebuild:startBuild"
+    role_arn = aws_iam_role.scheduler.arn
+    input    = jsonencode({ ProjectName = aws_codebuild_project.runner.name })
+
+    retry_policy {
+      maximum_retry_attempts = 0
+    }
+  }
+}
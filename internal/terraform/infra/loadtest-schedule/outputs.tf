@@ -0,0 +1,14 @@
+output "schedule_name" {
+  description = "EventBridge Scheduler schedule"
+  value       = aws_scheduler_schedule.runs.name
+}
+
+output "runner_name" {
+  description = "CodeBuild project running the tests"
+  value       = aws_codebuild_project.runner.name
+}
+
+output "log_group" {
+  description = "CloudWatch log group of the runs"
+  value       = aws_cloudwatch_log_group.runs.name
+}
@@ -0,0 +1,159 @@
+# Runs the project's active Locust bundle headless once and stores a summary of the results
+# next to the bundle version, at configs/<project>-loadtest/results/<version>/<run_id>.json,
+# in the format "automock loadtest results" reads.
+#
+# STORE selects the storage: "s3" (BUCKET, read with boto3) or "blob" (STORAGE_ACCOUNT and
+# STORAGE_CONTAINER, read with the job's managed identity).
+
+import csv, datetime, json, os, subprocess, sys, urllib.error, urllib.parse, urllib.request
+
+PROJECT = os.environ["PROJECT"]
+PREFIX = "configs/" + PROJECT + "-loadtest/"
+USERS = int(os.environ["USERS"])
+SPAWN_RATE = float(os.environ["SPAWN_RATE"])
+DURATION = int(os.environ["DURATION_SECONDS"])
+HOST = os.environ.get("TARGET_HOST", "")
+
+
+class S3Store:
+    def __init__(self):
+        import boto3
+        self.s3 = boto3.client("s3")
+        self.bucket = os.environ["BUCKET"]
+
+    def get(self, key):
+        try:
+            return self.s3.get_object(Bucket=self.bucket, Key=key)["Body"].read()
+        except self.s3.exceptions.NoSuchKey:
+            return None
+
+    def put(self, key, data):
+        self.s3.put_object(Bucket=self.bucket, Key=key, Body=data, ContentType="application/json")
+
+
+class BlobStore:
+    def __init__(self):
+        self.base = "https://" + os.environ["STORAGE_ACCOUNT"] + ".blob.core.windows.net/" + os.environ["STORAGE_CONTAINER"] + "/"
+
+    def token(self):
+        query = urllib.parse.urlencode({
+            "resource": "https://storage.azure.com/",
+            "api-version": "2019-08-01",
+            "client_id": os.environ["IDENTITY_CLIENT_ID"],
+        })
+        req = urllib.request.Request(os.environ["IDENTITY_ENDPOINT"] + "?" + query,
+                                     headers={"X-IDENTITY-HEADER": os.environ["IDENTITY_HEADER"]})
+        with urllib.request.urlopen(req, timeout=10) as r:
+            return json.load(r)["access_token"]
+
+    def headers(self):
+        return {"Authorization": "Bearer " + self.token(), "x-ms-version": "2021-08-06"}
+
+    def get(self, key):
+        try:
+            with urllib.request.urlopen(urllib.request.Request(self.base + key, headers=self.headers()), timeout=60) as r:
+                return r.read()
+        except urllib.error.HTTPError as e:
+            if e.code == 404:
+                return None
+            raise
+
+    def put(self, key, data):
+        headers = self.headers()
+        headers.update({"x-ms-blob-type": "BlockBlob", "Content-Type": "application/json"})
+        urllib.request.urlopen(urllib.request.Request(self.base + key, data=data, headers=headers, method="PUT"), timeout=60).close()
+
+
+def number(row, column):
+    try:
+        return float(row.get(column) or 0)
+    except ValueError:
+        return 0.0
+
+
+def summarize(path):
+    """Reads Locust's <prefix>_stats.csv into the totals and the per-endpoint statistics"""
+    total, endpoints = None, []
+    with open(path, newline="") as f:
+        for row in csv.DictReader(f):
+            st = {
+                "method": row.get("Type", ""),
+                "name": row.get("Name", ""),
+                "requests": int(number(row, "Request Count")),
+                "failures": int(number(row, "Failure Count")),
+                "rps": number(row, "Requests/s"),
+                "failures_per_s": number(row, "Failures/s"),
+                "avg_ms": number(row, "Average Response Time"),
+                "min_ms": number(row, "Min Response Time"),
+                "max_ms": number(row, "Max Response Time"),
+                "p50_ms": number(row, "50%"),
+                "p90_ms": number(row, "90%"),
+                "p95_ms": number(row, "95%"),
+                "p99_ms": number(row, "99%"),
+            }
+            if st["name"] == "Aggregated":
+                del st["method"]
+                total = st
+            else:
+                if not st["method"]:
+                    del st["method"]
+                endpoints.append(st)
+    if total is None:
+        raise SystemExit("no Aggregated row in " + path)
+    return total, endpoints
+
+
+def main():
+    store = S3Store() if os.environ.get("STORE") == "s3" else BlobStore()
+    raw = store.get(PREFIX + "current.json")
+    if raw is None:
+        raise SystemExit("no active load test bundle for " + PROJECT)
+    pointer = json.loads(raw)
+    if pointer.get("tool") not in (None, "", "locust"):
+        raise SystemExit("the active bundle is a %s bundle; scheduled runs need a Locust bundle" % pointer["tool"])
+
+    os.makedirs("/tmp/bundle", exist_ok=True)
+    os.chdir("/tmp/bundle")
+    for key in (pointer.get("files") or {}).values():
+        data = store.get(key) if key else None
+        if data is None:
+            continue  # optional files such as user_data.yaml
+        with open(os.path.basename(key), "wb") as f:
+            f.write(data)
+    if os.path.exists("requirements.txt"):
+        subprocess.check_call([sys.executable, "-m", "pip", "install", "--quiet", "--user", "-r", "requirements.txt"])
+
+    started = datetime.datetime.now(datetime.timezone.utc)
+    run_id = started.strftime("%Y%m%dT%H%M%SZ")
+    print("running bundle %s (%s) as run %s" % (pointer.get("bundle_id"), pointer.get("active_version"), run_id), flush=True)
+    args = ["locust", "-f", "locustfile.py", "--headless", "--only-summary",
+            "-u", str(USERS), "-r", str(SPAWN_RATE), "-t", "%ds" % DURATION, "--csv", "run"]
+    env = dict(os.environ)
+    if HOST:
+        args += ["--host", HOST]
+        env["AM_HOST"] = HOST
+    code = subprocess.call(args, env=env)
+
+    total, endpoints = summarize("run_stats.csv")
+    result = {
+        "project_id": PROJECT,
+        "version": pointer.get("active_version", ""),
+        "bundle_id": pointer.get("bundle_id", ""),
+        "run_id": run_id,
+        "target": "scheduled",
+        "host": HOST,
+        "users": USERS,
+        "spawn_rate": SPAWN_RATE,
+        "started_at": started.strftime("%Y-%m-%dT%H:%M:%SZ"),
+        "duration_seconds": DURATION,
+        "total": total,
+        "endpoints": endpoints,
+    }
+    key = PREFIX + "results/%s/%s.json" % (result["version"], run_id)
+    store.put(key, json.dumps(result, indent=2).encode())
+    print("results stored at " + key, flush=True)
+    sys.exit(code)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,72 @@
+variable "project_name" {
+  type        = string
+  description = "Project name (base for resource names)"
+}
+
+variable "aws_region" {
+  type        = string
+  description = "AWS region"
+}
+
+variable "existing_bucket_name" {
+  type        = string
+  description = "Existing S3 bucket holding the load test bundles and results"
+}
+
+variable "cloud_provider" {
+  type        = string
+  description = "Cloud provider (aws)"
+  default     = "aws"
+}
+
+variable "cron" {
+  type        = string
+  description = "Standard cron expression the schedule was requested with (UTC)"
+}
+
+variable "schedule_expression" {
+  type        = string
+  description = "EventBridge Scheduler expression, e.g. cron(0 2 * * ? *)"
+}
+
+variable "users" {
+  type        = number
+  description = "Peak number of concurrent users"
+  default     = 10
+}
+
+variable "spawn_rate" {
+  type        = number
+  description = "Users started per second"
+  default     = 2
+}
+
+variable "duration_seconds" {
+  type        = number
+  description = "How long each run lasts"
+  default     = 300
+}
+
+variable "target_host" {
+  type        = string
+  description = "Host the bundle runs against (the deployed mock by default)"
+  default     = ""
+}
+
+variable "compute_type" {
+  type        = string
+  description = "CodeBuild compute type of the load generator"
+  default     = "BUILD_GENERAL1_MEDIUM"
+}
+
+variable "locust_version" {
+  type        = string
+  description = "Locust version installed on the load generator"
+  default     = "2.31.2"
+}
+
+variable "log_retention_days" {
+  type        = number
+  description = "CloudWatch log retention for run logs"
+  default     = 30
+}
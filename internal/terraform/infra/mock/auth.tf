@@ -0,0 +1,112 @@
+# terraform/auth.tf
+# Optional credential required on every request to the mocks
+#
+# A WAF web ACL on the ALB of the ECS module admits requests carrying the expected header and
+# answers all others with 401, MockServer's control plane (/mockserver/*) included. The sidecar
+# loads expectations over localhost and is not affected. The ALB is looked up by the name in its
+# DNS name (<name>-<id>.<region>.elb.amazonaws.com), so the ECS module is used unchanged.
+
+variable "auth_header" {
+  description = "Header every request must carry (X-AutoMock-Key or Authorization); empty = open endpoint."
+  type        = string
+  default     = ""
+}
+
+variable "auth_value" {
+  description = "Exact value of auth_header: the API key, or 'Basic <base64 user:password>'."
+  type        = string
+  default     = ""
+  sensitive   = true
+}
+
+locals {
+  protected  = var.auth_header != ""
+  auth_basic = lower(var.auth_header) == "authorization"
+}
+
+data "aws_lb" "mock" {
+  count = local.protected ? 1 : 0
+  name  = regex("^(?:internal-)?(.+)-[0-9]+\\.[a-z0-9-]+\\.elb\\.amazonaws\\.com$", local.ecs_alb_dns_name)[0]
+}
+
+resource "aws_wafv2_web_acl" "auth" {
+  count       = local.protected ? 1 : 0
+  name        = "${local.name_prefix}-auth"
+  description = "AutoMock ${var.project_name}: requests need the ${var.auth_header} header"
+  scope       = "REGIONAL"
+
+  default_action {
+    block {
+      custom_response {
+        response_code            = 401
+        custom_response_body_key = "unauthorized"
+
+        # Lets browsers prompt for the basic auth credential
+        dynamic "response_header" {
+          for_each = local.auth_basic ? [1] : []
+          content {
+            name  = "WWW-Authenticate"
+            value = "Basic realm=\"automock\""
+          }
+        }
+      }
+    }
+  }
+
+  custom_response_body {
+    key          = "unauthorized"
+    content_type = "APPLICATION_JSON"
+    content = jsonencode({
+      error   = "unauthorized"
+      message = "These mocks require the ${var.auth_header} header; 'automock status --detailed' shows the credential"
+    })
+  }
+
+  rule {
+    name     = "credential"
+    priority = 0
+
+    action {
+      allow {}
+    }
+
+    statement {
+      byte_match_statement {
+        search_string         = var.auth_value
+        positional_constraint = "EXACTLY"
+
+        field_to_match {
+          single_header {
+            name = lower(var.auth_header)
+          }
+        }
+
+        text_transformation {
+          priority = 0
+          type     = "NONE"
+        }
+      }
+    }
+
+    visibility_config {
+      cloudwatch_metrics_enabled = true
+      metric_name                = "${local.name_prefix}-credential"
+      sampled_requests_enabled   = false
+    }
+  }
+
+  # Sampled requests would keep the credential in the WAF console
+  visibility_config {
+    cloudwatch_metrics_enabled = true
+    metric_name                = "${local.name_prefix}-auth"
+    sampled_requests_enabled   = false
+  }
+
+  tags = local.common_tags
+}
+
+resource "aws_wafv2_web_acl_association" "auth" {
+  count        = local.protected ? 1 : 0
+  resource_arn = data.aws_lb.mock[0].arn
+  web_acl_arn  = aws_wafv2_web_acl.auth[0].arn
+}
@@ -0,0 +1,155 @@
+# terraform/domain.tf
+# Optional custom domain with HTTPS for the mock endpoint
+#
+# CloudFront terminates TLS with an ACM certificate and forwards every request, uncached, to
+# the ALB of the ECS module; Route53 points the domain at the distribution. CloudFront only
+# needs the ALB's DNS name, so the ECS module is used unchanged.
+
+variable "domain" {
+  description = "Custom domain the mocks are served at over HTTPS (e.g. mock.mycompany.dev); empty = ALB URL only."
+  type        = string
+  default     = ""
+
+  validation {
+    condition     = var.domain == "" || can(regex("^([a-z0-9]([a-z0-9-]*[a-z0-9])?\\.)+[a-z]{2,}$", var.domain))
+    error_message = "Domain must be a lowercase host name such as mock.mycompany.dev."
+  }
+}
+
+variable "hosted_zone_id" {
+  description = "Route53 hosted zone of the domain; empty = the public zone of the domain's parent (mycompany.dev for mock.mycompany.dev)."
+  type        = string
+  default     = ""
+}
+
+# CloudFront only accepts certificates from us-east-1
+provider "aws" {
+  alias  = "us_east_1"
+  region = "us-east-1"
+
+  default_tags {
+    tags = {
+      ManagedBy = "AutoMock-Terraform"
+      Project   = "AutoMock"
+    }
+  }
+}
+
+locals {
+  custom_domain = var.domain != ""
+  domain_labels = split(".", var.domain)
+  domain_zone   = var.hosted_zone_id != "" ? var.hosted_zone_id : try(data.aws_route53_zone.domain[0].zone_id, "")
+
+  # The ALB listener port, taken from the URL the ECS module reports (80 unless it says otherwise)
+  alb_origin_port = try(tonumber(regex("^https?://[^/:]+:([0-9]+)", local.ecs_mockserver_url)[0]), 80)
+
+  served_url           = local.custom_domain ? "https://${var.domain}" : local.ecs_mockserver_url
+  served_dashboard_url = local.custom_domain ? "https://${var.domain}/mockserver/dashboard" : local.ecs_dashboard_url
+}
+
+data "aws_route53_zone" "domain" {
+  count        = local.custom_domain && var.hosted_zone_id == "" ? 1 : 0
+  name         = join(".", slice(local.domain_labels, 1, length(local.domain_labels)))
+  private_zone = false
+}
+
+data "aws_cloudfront_cache_policy" "disabled" {
+  count = local.custom_domain ? 1 : 0
+  name  = "Managed-CachingDisabled"
+}
+
+# Forward headers, cookies and query strings as sent, so expectations match as they do on the ALB
+data "aws_cloudfront_origin_request_policy" "all_viewer" {
+  count = local.custom_domain ? 1 : 0
+  name  = "Managed-AllViewer"
+}
+
+resource "aws_acm_certificate" "domain" {
+  count             = local.custom_domain ? 1 : 0
+  provider          = aws.us_east_1
+  domain_name       = var.domain
+  validation_method = "DNS"
+  tags              = local.common_tags
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+resource "aws_route53_record" "domain_validation" {
+  for_each = {
+    for dvo in try(aws_acm_certificate.domain[0].domain_validation_options, []) : dvo.domain_name => dvo
+  }
+
+  zone_id         = local.domain_zone
+  name            = each.value.resource_record_name
+  type            = each.value.resource_record_type
+  records         = [each.value.resource_record_value]
+  ttl             = 60
+  allow_overwrite = true
+}
+
+resource "aws_acm_certificate_validation" "domain" {
+  count                   = local.custom_domain ? 1 : 0
+  provider                = aws.us_east_1
+  certificate_arn         = aws_acm_certificate.domain[0].arn
+  validation_record_fqdns = [for record in aws_route53_record.domain_validation : record.fqdn]
+}
+
+resource "aws_cloudfront_distribution" "domain" {
+  count           = local.custom_domain ? 1 : 0
+  enabled         = true
+  is_ipv6_enabled = true
+  comment         = "AutoMock ${var.project_name}"
+  aliases         = [var.domain]
+  price_class     = "PriceClass_100"
+
+  origin {
+    origin_id   = "alb"
+    domain_name = local.ecs_alb_dns_name
+
+    custom_origin_config {
+      http_port              = local.alb_origin_port
+      https_port             = 443
+      origin_protocol_policy = "http-only"
+      origin_ssl_protocols   = ["TLSv1.2"]
+    }
+  }
+
+  default_cache_behavior {
+    target_origin_id         = "alb"
+    viewer_protocol_policy   = "redirect-to-https"
+    allowed_methods          = ["DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PUT"]
+    cached_methods           = ["GET", "HEAD"]
+    cache_policy_id          = data.aws_cloudfront_cache_policy.disabled[0].id
+    origin_request_policy_id = data.aws_cloudfront_origin_request_policy.all_viewer[0].id
+  }
+
+  restrictions {
+    geo_restriction {
+      restriction_type = "none"
+    }
+  }
+
+  viewer_certificate {
+    acm_certificate_arn      = aws_acm_certificate_validation.domain[0].certificate_arn
+    ssl_support_method       = "sni-only"
+    minimum_protocol_version = "TLSv1.2_2021"
+  }
+
+  tags = local.common_tags
+}
+
+resource "aws_route53_record" "domain" {
+  for_each = local.custom_domain ? toset(["A", "AAAA"]) : toset([])
+
+  zone_id = local.domain_zone
+  name    = var.domain
+  type    = each.key
+
+  alias {
+    name                   = aws_cloudfront_distribution.domain[0].domain_name
+    zone_id                = aws_cloudfront_distribution.domain[0].hosted_zone_id
+    evaluate_target_health = false
+  }
+}
@@ -19,13 +19,13 @@ locals {
 }
 
 output "mockserver_url" {
-  description = "MockServer API endpoint URL"
-  value       = local.ecs_mockserver_url
+  description = "MockServer API endpoint URL (the custom domain when one is set)"
+  value       = local.served_url
 }
 
 output "dashboard_url" {
   description = "MockServer dashboard URL"
-  value       = local.ecs_dashboard_url
+  value       = local.served_dashboard_url
 }
 
 output "config_bucket" {
@@ -58,8 +58,8 @@ output "integration_summary" {
   value = {
     project_name   = var.project_name
     bucket_name    = local.cfg_bucket_name
-    mockserver_url = local.ecs_mockserver_url
-    dashboard_url  = local.ecs_dashboard_url
+    mockserver_url = local.served_url
+    dashboard_url  = local.served_dashboard_url
     region         = var.aws_region
   }
 }
@@ -99,5 +99,17 @@ output "infrastructure_summary" {
       bucket_arn        = local.cfg_bucket_arn
       metadata_path     = "deployment-metadata.json"
     }
+    domain = local.custom_domain ? {
+      name              = var.domain
+      hosted_zone_id    = local.domain_zone
+      certificate_arn   = aws_acm_certificate.domain[0].arn
+      distribution_id   = aws_cloudfront_distribution.domain[0].id
+      distribution_host = aws_cloudfront_distribution.domain[0].domain_name
+      alb_url           = local.ecs_mockserver_url
+    } : null
+    protection = local.protected ? {
+      header      = var.auth_header
+      web_acl_arn = aws_wafv2_web_acl.auth[0].arn
+    } : null
   }
 }
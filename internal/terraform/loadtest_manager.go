@@ -3,6 +3,7 @@ package terraform
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -28,6 +29,9 @@ type LoadTestManager struct {
 
 // NewLoadTestManager creates a new manager for the loadtest stack
 func NewLoadTestManager(cleanProject, profile string, provider core.Provider) (*LoadTestManager, error) {
+	if err := requireCloud(cleanProject, provider); err != nil {
+		return nil, err
+	}
 	workingDir := filepath.Join(osTempDir(), fmt.Sprintf("automock-lt-%s-%d", cleanProject, os.Getpid()))
 
 	return &LoadTestManager{
@@ -71,6 +75,9 @@ func (m *LoadTestManager) Deploy(opts *models.LoadTestDeploymentOptions) (*model
 	if opts.BucketName == "" {
 		opts.BucketName = m.BucketName
 	}
+	if opts.StorageAccount == "" {
+		opts.StorageAccount = storageAccount(m.Provider)
+	}
 	if opts.Provider == "" {
 		opts.Provider = m.Provider.GetProviderType()
 	}
@@ -125,6 +132,7 @@ func (m *LoadTestManager) Destroy() error {
 		ProjectName:        m.ProjectName,
 		Region:             m.Region,
 		BucketName:         m.BucketName,
+		StorageAccount:     storageAccount(m.Provider),
 		Provider:           m.Provider.GetProviderType(),
 		CPUUnits:           256,
 		MemoryUnits:        512,
@@ -152,7 +160,7 @@ func (m *LoadTestManager) prepareWorkspace() error {
 		return fmt.Errorf("mkdir: %w", err)
 	}
 	// Materialize embedded loadtest terraform templates into WorkingDir.
-	if err := writeEmbeddedTemplates(loadtestTemplates, m.WorkingDir); err != nil {
+	if err := writeEmbeddedTemplates(templatesFor(m.Provider, loadtestTemplates, azureLoadtestTemplates), m.WorkingDir); err != nil {
 		return fmt.Errorf("prepare workspace: %w", err)
 	}
 	return nil
@@ -165,23 +173,19 @@ func (m *LoadTestManager) cleanup() {
 
 func (m *LoadTestManager) createBackendConfigWithKey(key string) error {
 	if m.BucketName == "" {
-		return fmt.Errorf("no S3 bucket configured")
-	}
-	backend := fmt.Sprintf(`terraform {
-  backend "s3" {
-    bucket  = "%s"
-    key     = "%s"
-    region  = "%s"
-    encrypt = true
-  }
-}
-`, m.BucketName, key, m.Region)
+		return fmt.Errorf("no storage bucket configured")
+	}
+	backend := backendBlock(m.Provider, m.BucketName, m.Region, key)
 	return osWriteFile(filepath.Join(m.WorkingDir, "backend.tf"), []byte(backend), 0644)
 }
 
 func (m *LoadTestManager) terraformEnv() []string {
 	env := os.Environ()
-	if m.Profile != "" && m.Provider.GetProviderType() == "aws" {
+	if credEnv, err := m.Provider.CredentialEnv(context.Background()); err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+	} else if credEnv != nil {
+		env = append(env, credEnv...)
+	} else if m.Profile != "" && m.Provider.GetProviderType() == "aws" {
 		env = append(env, fmt.Sprintf("AWS_PROFILE=%s", m.Profile))
 	}
 	env = append(env, "TF_CLI_CONFIG_FILE=/dev/null")
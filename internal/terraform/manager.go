@@ -3,6 +3,8 @@ package terraform
 
 import (
 	"bufio"
+	"context"
+	"embed"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -12,6 +14,11 @@ import (
 	"time"
 
 	"github.com/hemantobora/auto-mock/internal"
+	awsprovider "github.com/hemantobora/auto-mock/internal/cloud/aws"
+	azureprovider "github.com/hemantobora/auto-mock/internal/cloud/azure"
+	gitprovider "github.com/hemantobora/auto-mock/internal/cloud/git"
+	localprovider "github.com/hemantobora/auto-mock/internal/cloud/local"
+	"github.com/hemantobora/auto-mock/internal/exitcode"
 	"github.com/hemantobora/auto-mock/internal/models"
 )
 
@@ -34,6 +41,9 @@ func DefaultDeploymentOptions() *models.DeploymentOptions {
 
 // NewManager creates a new Terraform manager
 func NewManager(cleanProject, profile string, provider internal.Provider) (*Manager, error) {
+	if err := requireCloud(cleanProject, provider); err != nil {
+		return nil, err
+	}
 	// Create a unique working directory for this deployment
 	workingDir := filepath.Join(os.TempDir(), fmt.Sprintf("automock-%s-%s", cleanProject, time.Now().Format("20060102-150405")))
 
@@ -47,6 +57,19 @@ func NewManager(cleanProject, profile string, provider internal.Provider) (*Mana
 	}, nil
 }
 
+// requireCloud refuses providers with nothing to deploy into: local and git projects run on
+// this machine and reach a cloud with push
+func requireCloud(cleanProject string, provider internal.Provider) error {
+	store := provider.GetProviderType()
+	if store != localprovider.ProviderType && store != gitprovider.ProviderType {
+		return nil
+	}
+	return exitcode.WithHint(exitcode.Config,
+		fmt.Errorf("❌ Project '%s' is in the %s store, which cannot be deployed to a cloud", cleanProject, store),
+		fmt.Sprintf("Serve it with 'automock serve --project %s', deploy it to a cluster with 'automock deploy --project %s --target kubernetes', or copy it to a cloud with 'automock --cloud %s push --project %s'",
+			cleanProject, cleanProject, store, cleanProject))
+}
+
 func (m *Manager) createTerraformVars(options *models.DeploymentOptions) error {
 	varsFile := filepath.Join(m.WorkingDir, "terraform.tfvars")
 	return os.WriteFile(varsFile, []byte(options.CreateTerraformVars()), 0644)
@@ -54,20 +77,10 @@ func (m *Manager) createTerraformVars(options *models.DeploymentOptions) error {
 
 func (m *Manager) createBackendConfig() error {
 	if m.ExistingBucketName == "" {
-		return fmt.Errorf("no S3 bucket configured")
+		return fmt.Errorf("no storage bucket configured")
 	}
 
-	// NO leading spaces in the template string!
-	backendConfig := fmt.Sprintf(`terraform {
-  backend "s3" {
-    bucket  = "%s"
-    key     = "terraform/state/terraform.tfstate"
-    region  = "%s"
-    encrypt = true
-  }
-}
-`, m.ExistingBucketName, m.Region)
-
+	backendConfig := backendBlock(m.Provider, m.ExistingBucketName, m.Region, "terraform/state/terraform.tfstate")
 	backendFile := filepath.Join(m.WorkingDir, "backend.tf")
 	if err := os.WriteFile(backendFile, []byte(backendConfig), 0644); err != nil {
 		return fmt.Errorf("failed to write backend config: %w", err)
@@ -78,6 +91,48 @@ func (m *Manager) createBackendConfig() error {
 	return nil
 }
 
+// backendBlock renders the Terraform state backend: the project bucket on AWS, the project
+// container (authenticated with Entra ID) on Azure
+func backendBlock(provider internal.Provider, storageName, region, key string) string {
+	// NO leading spaces in the template strings!
+	if az, ok := provider.(*azureprovider.Provider); ok {
+		return fmt.Sprintf(`terraform {
+  backend "azurerm" {
+    storage_account_name = "%s"
+    container_name       = "%s"
+    key                  = "%s"
+    use_azuread_auth     = true
+  }
+}
+`, az.AccountName, storageName, key)
+	}
+	return fmt.Sprintf(`terraform {
+  backend "s3" {
+    bucket  = "%s"
+    key     = "%s"
+    region  = "%s"
+    encrypt = true
+%s  }
+}
+`, storageName, key, region, awsprovider.TerraformBackendSettings())
+}
+
+// storageAccount returns the Azure storage account holding the project container ("" elsewhere)
+func storageAccount(provider internal.Provider) string {
+	if az, ok := provider.(*azureprovider.Provider); ok {
+		return az.AccountName
+	}
+	return ""
+}
+
+// templatesFor returns the embedded stack for the provider; stacks differ per cloud
+func templatesFor(provider internal.Provider, aws, azure embed.FS) embed.FS {
+	if provider.GetProviderType() == "azure" {
+		return azure
+	}
+	return aws
+}
+
 // Deploy creates the complete infrastructure using Terraform
 func (m *Manager) Deploy(options *models.DeploymentOptions) (*InfrastructureOutputs, error) {
 	fmt.Printf("🚀 Deploying infrastructure for project: %s\n", m.ProjectName)
@@ -169,7 +224,7 @@ func (m *Manager) prepareWorkspace() error {
 	}
 
 	// Materialize embedded Terraform templates into the working directory.
-	if err := writeEmbeddedTemplates(mockTemplates, m.WorkingDir); err != nil {
+	if err := writeEmbeddedTemplates(templatesFor(m.Provider, mockTemplates, azureMockTemplates), m.WorkingDir); err != nil {
 		return fmt.Errorf("failed to materialize terraform templates: %w", err)
 	}
 
@@ -301,11 +356,27 @@ func (m *Manager) getOutputs() (*InfrastructureOutputs, error) {
 	return outputs, nil
 }
 
+// DomainFromOutputs returns the custom domain and its hosted zone recorded by a deploy with
+// --domain ("" without one), so a redeploy keeps serving the mocks there
+func DomainFromOutputs(outputs *InfrastructureOutputs) (domain, zoneID string) {
+	if outputs == nil {
+		return "", ""
+	}
+	recorded, _ := outputs.InfrastructureSummary["domain"].(map[string]interface{})
+	domain, _ = recorded["name"].(string)
+	zoneID, _ = recorded["hosted_zone_id"].(string)
+	return domain, zoneID
+}
+
 // getTerraformEnv returns environment variables for Terraform
 func (m *Manager) getTerraformEnv() []string {
 	env := []string{}
 
-	if m.Profile != "" {
+	if credEnv, err := m.Provider.CredentialEnv(context.Background()); err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+	} else if credEnv != nil {
+		env = append(env, credEnv...)
+	} else if m.Profile != "" {
 		switch m.Provider.GetProviderType() {
 		case "aws":
 			env = append(env, fmt.Sprintf("AWS_PROFILE=%s", m.Profile))
@@ -0,0 +1,135 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	core "github.com/hemantobora/auto-mock/internal"
+	"github.com/hemantobora/auto-mock/internal/models"
+)
+
+// scheduleStateKey is where the scheduled load test stack keeps its Terraform state
+const scheduleStateKey = "terraform/loadtest-schedule/state/terraform.tfstate"
+
+// ScheduleManager handles Terraform operations for the scheduled load test stack (EventBridge
+// Scheduler and CodeBuild on AWS, a Container Apps job on Azure). It reuses the loadtest
+// stack's Terraform plumbing with its own templates and state.
+type ScheduleManager struct {
+	*LoadTestManager
+}
+
+// NewScheduleManager creates a new manager for the scheduled load test stack
+func NewScheduleManager(cleanProject, profile string, provider core.Provider) (*ScheduleManager, error) {
+	lt, err := NewLoadTestManager(cleanProject, profile, provider)
+	if err != nil {
+		return nil, err
+	}
+	lt.WorkingDir = filepath.Join(osTempDir(), fmt.Sprintf("automock-lt-schedule-%s-%d", cleanProject, os.Getpid()))
+	return &ScheduleManager{LoadTestManager: lt}, nil
+}
+
+// Apply creates or updates the schedule and returns the names of its resources
+func (m *ScheduleManager) Apply(opts *models.LoadTestScheduleOptions) (map[string]string, error) {
+	if m.BucketName == "" {
+		return nil, fmt.Errorf("no Storage bucket found for project '%s'. Please run 'automock init' first", m.ProjectName)
+	}
+	if err := m.prepareWorkspace(); err != nil {
+		return nil, err
+	}
+	defer m.cleanup()
+	if err := m.createBackendConfigWithKey(scheduleStateKey); err != nil {
+		return nil, err
+	}
+	if err := m.initTerraform(); err != nil {
+		return nil, err
+	}
+	m.fillDefaults(opts)
+	if err := osWriteFile(filepath.Join(m.WorkingDir, "terraform.tfvars"), []byte(opts.CreateTerraformVars()), 0644); err != nil {
+		return nil, err
+	}
+	if err := m.planTerraform(); err != nil {
+		return nil, err
+	}
+	if err := m.applyTerraform(); err != nil {
+		return nil, err
+	}
+	return m.outputs()
+}
+
+// Destroy removes the schedule; runs already stored are kept
+func (m *ScheduleManager) Destroy(opts *models.LoadTestScheduleOptions) error {
+	if err := m.prepareWorkspace(); err != nil {
+		return err
+	}
+	defer m.cleanup()
+	if err := m.createBackendConfigWithKey(scheduleStateKey); err != nil {
+		return err
+	}
+	m.fillDefaults(opts)
+	// Provide required variables to avoid interactive prompts during destroy
+	if err := osWriteFile(filepath.Join(m.WorkingDir, "terraform.tfvars"), []byte(opts.CreateTerraformVars()), 0644); err != nil {
+		return err
+	}
+	if err := m.initTerraform(); err != nil {
+		return err
+	}
+	return m.destroyTerraform()
+}
+
+func (m *ScheduleManager) fillDefaults(opts *models.LoadTestScheduleOptions) {
+	if opts.ProjectName == "" {
+		opts.ProjectName = m.ProjectName
+	}
+	if opts.Region == "" {
+		opts.Region = m.Region
+	}
+	if opts.BucketName == "" {
+		opts.BucketName = m.BucketName
+	}
+	if opts.StorageAccount == "" {
+		opts.StorageAccount = storageAccount(m.Provider)
+	}
+	if opts.Provider == "" {
+		opts.Provider = m.Provider.GetProviderType()
+	}
+}
+
+// prepareWorkspace writes the schedule templates and the runner script into WorkingDir
+func (m *ScheduleManager) prepareWorkspace() error {
+	if err := os.MkdirAll(m.WorkingDir, 0755); err != nil {
+		return fmt.Errorf("mkdir: %w", err)
+	}
+	if err := writeEmbeddedTemplates(templatesFor(m.Provider, scheduleTemplates, azureScheduleTemplates), m.WorkingDir); err != nil {
+		return fmt.Errorf("prepare workspace: %w", err)
+	}
+	if err := osWriteFile(filepath.Join(m.WorkingDir, "runner.py"), scheduleRunner, 0644); err != nil {
+		return fmt.Errorf("prepare workspace: %w", err)
+	}
+	return nil
+}
+
+func (m *ScheduleManager) outputs() (map[string]string, error) {
+	cmd := exec.Command("terraform", "output", "-json")
+	cmd.Dir = m.WorkingDir
+	cmd.Env = m.terraformEnv()
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("terraform output: %w", err)
+	}
+	var raw map[string]struct {
+		Value interface{} `json:"value"`
+	}
+	if err := json.Unmarshal(out, &raw); err != nil {
+		return nil, fmt.Errorf("parse outputs: %w", err)
+	}
+	resources := map[string]string{}
+	for name, v := range raw {
+		if s, ok := v.Value.(string); ok {
+			resources[name] = s
+		}
+	}
+	return resources, nil
+}